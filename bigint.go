@@ -0,0 +1,64 @@
+package ipld
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// BigIntNode is an optional interface for int-kind nodes whose values may not
+// fit in an int64, such as the unsigned integers up to 2^64-1 which dag-cbor
+// can hold.
+// AsInt on such a node returns an ErrIntOverflow if its value doesn't fit.
+//
+// Use the AsBigInt function to get the value of any int-kind node as a big.Int.
+type BigIntNode interface {
+	Node
+	AsBigInt() (*big.Int, error)
+}
+
+// BigIntAssembler is an optional interface for assemblers which can take
+// integers that may not fit in an int64.
+// Codecs check for it when decoding such integers;
+// use the AssignBigInt function to assign to any assembler.
+type BigIntAssembler interface {
+	NodeAssembler
+	AssignBigInt(*big.Int) error
+}
+
+// AsBigInt returns the value of an int-kind node as a big.Int,
+// whether or not it implements BigIntNode.
+// The returned value must not be modified.
+func AsBigInt(n Node) (*big.Int, error) {
+	if n2, ok := n.(BigIntNode); ok {
+		return n2.AsBigInt()
+	}
+	v, err := n.AsInt()
+	if err != nil {
+		return nil, err
+	}
+	return big.NewInt(v), nil
+}
+
+// AssignBigInt assigns v to na, with AssignBigInt if na implements
+// BigIntAssembler, or with AssignInt otherwise.
+// In the latter case, it returns an ErrIntOverflow if v doesn't fit in an int64.
+func AssignBigInt(na NodeAssembler, v *big.Int) error {
+	if na2, ok := na.(BigIntAssembler); ok {
+		return na2.AssignBigInt(v)
+	}
+	if !v.IsInt64() {
+		return ErrIntOverflow{Value: v}
+	}
+	return na.AssignInt(v.Int64())
+}
+
+// ErrIntOverflow is returned when an integer doesn't fit in an int64,
+// such as by AsInt on a BigIntNode, or when assigning it to an assembler
+// which doesn't implement BigIntAssembler.
+type ErrIntOverflow struct {
+	Value *big.Int
+}
+
+func (e ErrIntOverflow) Error() string {
+	return fmt.Sprintf("integer %s is too large to be an int", e.Value)
+}
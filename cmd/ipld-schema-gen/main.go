@@ -0,0 +1,218 @@
+// The ipld-schema-gen command generates a Go package from an IPLD Schema,
+// using the schema/gen/go code generator.
+//
+// Usage:
+//
+//	ipld-schema-gen [-format dsl|dmt|go] [-pkg name] [-o dir] [-check] [-embed types] [-ptr types] schema-file
+//
+// The schema may be written in the schema DSL, as is usual for .ipldsch files,
+// or be the data model form of a schema (its "DMT") encoded as dag-json.
+// It may also be written in the DSL inside a Go source file,
+// in block comments which start with "/*ipldsch":
+//
+//	//go:generate ipld-schema-gen people.go
+//
+//	/*ipldsch
+//	type Person struct {
+//		name String
+//	}
+//	*/
+//
+// By default, files ending in ".json" are read as the DMT, files ending in ".go" as Go source,
+// and others as DSL.
+//
+// The generated files are written into the output directory,
+// which is created if needed; it defaults to the current directory.
+// The package name defaults to the name of the output directory.
+//
+// The -embed and -ptr flags take comma-separated lists of type names,
+// whose optional and nullable values are held in the generated code
+// embedded by value or by pointer respectively, overriding the generator's default;
+// see the MaybeUsesPtr method of gengo.AdjunctCfg for the tradeoffs.
+//
+// The generated code records a hash of the schema in its header.
+// With -check, nothing is written; instead, the command fails
+// if the code in the output directory wasn't generated from the current schema,
+// which is useful to catch stale code in CI.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ipld/go-ipld-prime/codec/dagjson"
+	"github.com/ipld/go-ipld-prime/schema"
+	schemadmt "github.com/ipld/go-ipld-prime/schema/dmt"
+	gengo "github.com/ipld/go-ipld-prime/schema/gen/go"
+	schemaparser "github.com/ipld/go-ipld-prime/schema/parser"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "ipld-schema-gen: %s\n", err)
+		}
+		os.Exit(2)
+	}
+}
+
+// run does all the work of main, so that it can be tested.
+func run(args []string, stderr io.Writer) error {
+	fs := flag.NewFlagSet("ipld-schema-gen", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: ipld-schema-gen [flags] schema-file\n")
+		fs.PrintDefaults()
+	}
+	format := fs.String("format", "", `schema format, "dsl", "dmt", or "go" (default: "dmt" for .json files, "go" for .go files, "dsl" otherwise)`)
+	pkgName := fs.String("pkg", "", "package name of the generated code (default: name of the output directory)")
+	outDir := fs.String("o", ".", "output directory")
+	check := fs.Bool("check", false, "check that the output directory is up to date, without writing to it")
+	embed := fs.String("embed", "", "comma-separated types whose optional and nullable values are embedded by value")
+	ptr := fs.String("ptr", "", "comma-separated types whose optional and nullable values are held by pointer")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("expected one schema file, got %d arguments", fs.NArg())
+	}
+	schemaFile := fs.Arg(0)
+
+	if *format == "" {
+		switch filepath.Ext(schemaFile) {
+		case ".json":
+			*format = "dmt"
+		case ".go":
+			*format = "go"
+		default:
+			*format = "dsl"
+		}
+	}
+	src, err := ioutil.ReadFile(schemaFile)
+	if err != nil {
+		return err
+	}
+	ts, err := loadSchema(*format, src)
+	if err != nil {
+		return fmt.Errorf("%s: %w", schemaFile, err)
+	}
+	if errs := ts.ValidateGraph(); len(errs) > 0 {
+		return fmt.Errorf("%s: %w", schemaFile, errs[0])
+	}
+	adjCfg := &gengo.AdjunctCfg{CfgMaybeUsesPtr: make(map[schema.TypeName]bool)}
+	for _, layout := range []struct {
+		list   string
+		usePtr bool
+	}{{*embed, false}, {*ptr, true}} {
+		if layout.list == "" {
+			continue
+		}
+		for _, name := range strings.Split(layout.list, ",") {
+			if ts.TypeByName(name) == nil {
+				return fmt.Errorf("%s: type %s is not defined", schemaFile, name)
+			}
+			if _, ok := adjCfg.CfgMaybeUsesPtr[schema.TypeName(name)]; ok {
+				return fmt.Errorf("type %s is listed more than once in -embed and -ptr", name)
+			}
+			adjCfg.CfgMaybeUsesPtr[schema.TypeName(name)] = layout.usePtr
+		}
+	}
+	// Not every schema has a data model form to hash yet; those are generated without one.
+	hash, hashErr := schemadmt.Hash(ts)
+
+	if *check {
+		if hashErr != nil {
+			return fmt.Errorf("%s: cannot hash the schema to check the generated code: %w", schemaFile, hashErr)
+		}
+		generated, err := gengo.GeneratedSchemaHash(*outDir)
+		if err != nil {
+			return err
+		}
+		if generated != hash {
+			return fmt.Errorf("generated code in %s is out of date with %s; rerun ipld-schema-gen", *outDir, schemaFile)
+		}
+		return nil
+	}
+
+	if *pkgName == "" {
+		abs, err := filepath.Abs(*outDir)
+		if err != nil {
+			return err
+		}
+		*pkgName = filepath.Base(abs)
+	}
+	if !token.IsIdentifier(*pkgName) {
+		return fmt.Errorf("%q is not a valid package name; use -pkg to set one", *pkgName)
+	}
+	if err := os.MkdirAll(*outDir, 0755); err != nil {
+		return err
+	}
+	adjCfg.SchemaHash = hash
+	return generate(*outDir, *pkgName, ts, adjCfg)
+}
+
+// loadSchema reads a schema in either of the supported formats.
+func loadSchema(format string, src []byte) (*schema.TypeSystem, error) {
+	switch format {
+	case "dsl":
+		return schemaparser.Parse(bytes.NewReader(src))
+	case "dmt":
+		nb := schemadmt.Type.Schema__Repr.NewBuilder()
+		if err := dagjson.Decode(nb, bytes.NewReader(src)); err != nil {
+			return nil, err
+		}
+		return schemadmt.Compile(nb.Build().(schemadmt.Schema))
+	case "go":
+		dsl, err := schemaFromGoComments(src)
+		if err != nil {
+			return nil, err
+		}
+		return schemaparser.Parse(strings.NewReader(dsl))
+	default:
+		return nil, fmt.Errorf("unknown schema format %q", format)
+	}
+}
+
+// schemaFromGoComments returns the schema DSL held in the "/*ipldsch" comments of a Go source file,
+// joining them if there's more than one.
+func schemaFromGoComments(src []byte) (string, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), "", src, parser.ParseComments)
+	if err != nil {
+		return "", err
+	}
+	var dsl strings.Builder
+	for _, group := range f.Comments {
+		for _, c := range group.List {
+			if strings.HasPrefix(c.Text, "/*ipldsch") {
+				dsl.WriteString(strings.TrimSuffix(strings.TrimPrefix(c.Text, "/*ipldsch"), "*/"))
+				dsl.WriteString("\n")
+			}
+		}
+	}
+	if dsl.Len() == 0 {
+		return "", errors.New("no /*ipldsch comments with a schema found")
+	}
+	return dsl.String(), nil
+}
+
+// generate runs the code generator, which panics on errors,
+// such as when it can't write a file, or meets a type it can't generate.
+func generate(outDir, pkgName string, ts *schema.TypeSystem, adjCfg *gengo.AdjunctCfg) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("generating code: %v", r)
+		}
+	}()
+	gengo.Generate(outDir, pkgName, *ts, adjCfg)
+	return nil
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime/codec/dagjson"
+	schemadmt "github.com/ipld/go-ipld-prime/schema/dmt"
+	schemaparser "github.com/ipld/go-ipld-prime/schema/parser"
+)
+
+const testSchema = `
+type Person struct {
+	name String
+	friends [&Person]
+}
+`
+
+// checkGenerated checks that the generated files parse as Go,
+// and that they define the types of the test schema.
+func checkGenerated(t *testing.T, dir, pkgName string) {
+	t.Helper()
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, nil, 0)
+	Require(t, err, ShouldEqual, nil)
+	Require(t, len(pkgs), ShouldEqual, 1)
+	pkg, ok := pkgs[pkgName]
+	Require(t, ok, ShouldEqual, true)
+	for _, name := range []string{"Person", "List__Link__Person", "Link__Person", "String"} {
+		found := false
+		for _, f := range pkg.Files {
+			found = found || f.Scope.Lookup(name) != nil
+		}
+		Wish(t, found, ShouldEqual, true)
+	}
+}
+
+func TestRun(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "ipld-schema-gen")
+	Require(t, err, ShouldEqual, nil)
+	defer os.RemoveAll(tmp)
+
+	dslFile := filepath.Join(tmp, "schema.ipldsch")
+	Require(t, ioutil.WriteFile(dslFile, []byte(testSchema), 0644), ShouldEqual, nil)
+
+	ts, err := schemaparser.Parse(strings.NewReader(testSchema))
+	Require(t, err, ShouldEqual, nil)
+	s, err := schemadmt.SchemaFromTypeSystem(ts)
+	Require(t, err, ShouldEqual, nil)
+	var buf bytes.Buffer
+	Require(t, dagjson.Encode(s.Representation(), &buf), ShouldEqual, nil)
+	dmtFile := filepath.Join(tmp, "schema.json")
+	Require(t, ioutil.WriteFile(dmtFile, buf.Bytes(), 0644), ShouldEqual, nil)
+
+	t.Run("dsl", func(t *testing.T) {
+		out := filepath.Join(tmp, "fromdsl")
+		Require(t, run([]string{"-o", out, dslFile}, ioutil.Discard), ShouldEqual, nil)
+		checkGenerated(t, out, "fromdsl")
+	})
+	t.Run("dmt", func(t *testing.T) {
+		out := filepath.Join(tmp, "fromdmt")
+		Require(t, run([]string{"-o", out, "-pkg", "people", dmtFile}, ioutil.Discard), ShouldEqual, nil)
+		checkGenerated(t, out, "people")
+	})
+	t.Run("go", func(t *testing.T) {
+		// The schema's Go file lives in the package which is generated.
+		out := filepath.Join(tmp, "fromgo")
+		Require(t, os.Mkdir(out, 0755), ShouldEqual, nil)
+		goFile := filepath.Join(out, "people.go")
+		src := "package fromgo\n\n//go:generate ipld-schema-gen people.go\n\n/*ipldsch" + testSchema + "*/\n"
+		Require(t, ioutil.WriteFile(goFile, []byte(src), 0644), ShouldEqual, nil)
+		Require(t, run([]string{"-o", out, goFile}, ioutil.Discard), ShouldEqual, nil)
+		checkGenerated(t, out, "fromgo")
+	})
+	t.Run("check", func(t *testing.T) {
+		out := filepath.Join(tmp, "checked")
+		err := run([]string{"-check", "-o", out, dslFile}, ioutil.Discard)
+		Wish(t, err != nil, ShouldEqual, true)
+
+		Require(t, run([]string{"-o", out, dslFile}, ioutil.Discard), ShouldEqual, nil)
+		Wish(t, run([]string{"-check", "-o", out, dslFile}, ioutil.Discard), ShouldEqual, nil)
+		// The hash is of the schema itself, not the format it's written in.
+		Wish(t, run([]string{"-check", "-o", out, dmtFile}, ioutil.Discard), ShouldEqual, nil)
+
+		changed := filepath.Join(tmp, "changed.ipldsch")
+		Require(t, ioutil.WriteFile(changed, []byte(testSchema+"type Nick string\n"), 0644), ShouldEqual, nil)
+		err = run([]string{"-check", "-o", out, changed}, ioutil.Discard)
+		Require(t, err != nil, ShouldEqual, true)
+		Wish(t, err.Error(), ShouldEqual, "generated code in "+out+" is out of date with "+changed+"; rerun ipld-schema-gen")
+	})
+	t.Run("layout", func(t *testing.T) {
+		// Person is large enough to be held by pointer by default, and String is small enough to be embedded.
+		out := filepath.Join(tmp, "layout")
+		Require(t, run([]string{"-o", out, "-embed", "Person", "-ptr", "String,Link__Person", dslFile}, ioutil.Discard), ShouldEqual, nil)
+		checkGenerated(t, out, "layout")
+		for name, field := range map[string]string{
+			"Person":       "v _Person\n",
+			"String":       "v String\n",
+			"Link__Person": "v Link__Person\n",
+		} {
+			src, err := ioutil.ReadFile(filepath.Join(out, "ipldsch_type_"+name+".go"))
+			Require(t, err, ShouldEqual, nil)
+			if !strings.Contains(string(src), field) {
+				t.Errorf("the Maybe of %s does not have the field %q", name, field)
+			}
+		}
+	})
+	t.Run("errors", func(t *testing.T) {
+		for _, tc := range []struct {
+			args []string
+			err  string
+		}{
+			{[]string{}, "expected one schema file, got 0 arguments"},
+			{[]string{"-format", "yaml", dslFile}, `unknown schema format "yaml"`},
+			{[]string{"-format", "dmt", dslFile}, "schema.ipldsch: func called on wrong kind"},
+			{[]string{"-o", filepath.Join(tmp, "not-a-name"), dslFile}, `"not-a-name" is not a valid package name`},
+			{[]string{filepath.Join(tmp, "missing.ipldsch")}, "no such file"},
+			{[]string{"-format", "go", dslFile}, "expected 'package'"},
+			{[]string{"-ptr", "Nobody", dslFile}, "schema.ipldsch: type Nobody is not defined"},
+			{[]string{"-ptr", "String", "-embed", "String", dslFile}, "type String is listed more than once in -embed and -ptr"},
+			{[]string{"-format", "go", filepath.Join(tmp, "fromgo", "ipldsch_minima.go")}, "no /*ipldsch comments with a schema found"},
+		} {
+			err := run(tc.args, ioutil.Discard)
+			Require(t, err != nil, ShouldEqual, true)
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("error %q does not contain %q", err, tc.err)
+			}
+		}
+	})
+}
@@ -1,3 +1,9 @@
+// Package cbor implements the plain CBOR codec (multicodec 0x51).
+//
+// Unlike dag-cbor, this codec doesn't use tag 42 for links:
+// nodes containing links cannot be encoded,
+// and tagged byte strings in generic CBOR documents produced by other systems
+// (including ones with tag 42) are decoded as plain bytes, ignoring the tag.
 package cbor
 
 import (
@@ -18,10 +24,29 @@ var (
 func init() {
 	multicodec.RegisterEncoder(0x51, Encode)
 	multicodec.RegisterDecoder(0x51, Decode)
+	multicodec.RegisterCapabilities(0x51, multicodec.Capabilities{
+		Kinds: ipld.KindSet{
+			ipld.Kind_Map, ipld.Kind_List,
+			ipld.Kind_Null, ipld.Kind_Bool, ipld.Kind_Int, ipld.Kind_Float,
+			ipld.Kind_String, ipld.Kind_Bytes,
+		},
+	})
 }
 
 func Decode(na ipld.NodeAssembler, r io.Reader) error {
-	return dagcbor.Unmarshal(na, cbor.NewDecoder(cbor.DecodeOptions{}, r), false)
+	return DecodeOptions{}.Decode(na, r)
+}
+
+// DecodeOptions can be used to customize the behavior of a decoding function.
+// The Decode method on this struct fits the ipld.Decoder function interface.
+//
+// The options are the same as those of dag-cbor; see dagcbor.DecodeOptions.
+type DecodeOptions dagcbor.DecodeOptions
+
+// Decode deserializes data from the given io.Reader and feeds it into the
+// given ipld.NodeAssembler, according to the options.
+func (cfg DecodeOptions) Decode(na ipld.NodeAssembler, r io.Reader) error {
+	return dagcbor.DecodeOptions(cfg).UnmarshalReader(na, r, false)
 }
 
 func Encode(n ipld.Node, w io.Writer) error {
@@ -0,0 +1,107 @@
+package cbor
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	cid "github.com/ipfs/go-cid"
+	. "github.com/warpfork/go-wish"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec/dagcbor"
+	"github.com/ipld/go-ipld-prime/fluent"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+)
+
+func TestRoundtrip(t *testing.T) {
+	n := fluent.MustBuildMap(basicnode.Prototype.Map, 2, func(na fluent.MapAssembler) {
+		na.AssembleEntry("bytes").AssignBytes([]byte{1, 2, 3})
+		na.AssembleEntry("list").CreateList(2, func(na fluent.ListAssembler) {
+			na.AssembleValue().AssignInt(-1)
+			na.AssembleValue().AssignNull()
+		})
+	})
+	serial := "\xa2ebytesC\x01\x02\x03dlist\x82\x20\xf6"
+	t.Run("encoding", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := Encode(n, &buf)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, buf.String(), ShouldEqual, serial)
+	})
+	t.Run("decoding", func(t *testing.T) {
+		nb := basicnode.Prototype.Any.NewBuilder()
+		err := Decode(nb, strings.NewReader(serial))
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, nb.Build(), ShouldEqual, n)
+	})
+}
+
+func TestTags(t *testing.T) {
+	t.Run("tagged bytes decode as plain bytes", func(t *testing.T) {
+		for _, serial := range []string{
+			"\xd8\x2aC\x00\x01\x02", // tag 42
+			"\xd8\x40C\x00\x01\x02", // tag 64 (uint8 typed array)
+		} {
+			nb := basicnode.Prototype.Any.NewBuilder()
+			err := Decode(nb, strings.NewReader(serial))
+			Require(t, err, ShouldEqual, nil)
+			Wish(t, nb.Build(), ShouldEqual, basicnode.NewBytes([]byte{0, 1, 2}))
+		}
+	})
+	t.Run("links cannot be encoded", func(t *testing.T) {
+		c, err := cid.Decode("bafyreibbvxxq5fahtmylr5tsfymzqmpw7zyufhh55e2zrf4jbsvtfw6hge")
+		Require(t, err, ShouldEqual, nil)
+		var buf bytes.Buffer
+		err = Encode(basicnode.NewLink(cidlink.Link{Cid: c}), &buf)
+		Wish(t, err.Error(), ShouldEqual, "cannot Marshal ipld links to CBOR")
+	})
+}
+
+func TestLargeUint(t *testing.T) {
+	serial := "\x1b\xff\xff\xff\xff\xff\xff\xff\xff"
+	nb := basicnode.Prototype.Int.NewBuilder()
+	err := Decode(nb, strings.NewReader(serial))
+	Wish(t, err.Error(), ShouldEqual, "cbor integer 18446744073709551615 is too large to be an int (at offset 9)")
+
+	// Assemblers which take big ints get the whole value.
+	nb = basicnode.Prototype.Any.NewBuilder()
+	Require(t, Decode(nb, strings.NewReader(serial)), ShouldEqual, nil)
+	v, err := ipld.AsBigInt(nb.Build())
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, v.String(), ShouldEqual, "18446744073709551615")
+
+	var buf bytes.Buffer
+	Require(t, Encode(nb.Build(), &buf), ShouldEqual, nil)
+	Wish(t, buf.String(), ShouldEqual, serial)
+}
+
+func TestDecodeOptions(t *testing.T) {
+	serial := "\x9f\x01\x02\xff" // [1, 2], indefinite-length
+	nb := basicnode.Prototype.Any.NewBuilder()
+	err := DecodeOptions{}.Decode(nb, strings.NewReader(serial))
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, nb.Build().Length(), ShouldEqual, int64(2))
+
+	nb = basicnode.Prototype.Any.NewBuilder()
+	err = DecodeOptions{RejectIndefiniteLength: true}.Decode(nb, strings.NewReader(serial))
+	Wish(t, errors.Is(err, dagcbor.ErrIndefiniteLength), ShouldEqual, true)
+}
+
+func TestDecodeTagsWithoutLinks(t *testing.T) {
+	// Without links, tag 42 is like any other tag.
+	var gotTag uint64
+	cfg := DecodeOptions{TagDecoders: map[uint64]dagcbor.TagDecoder{
+		42: func(na ipld.NodeAssembler, tag uint64, value ipld.Node) error {
+			gotTag = tag
+			return na.AssignNode(value)
+		},
+	}}
+	nb := basicnode.Prototype.Any.NewBuilder()
+	err := cfg.Decode(nb, strings.NewReader("\xd8\x2aC\x01\x02\x03"))
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, gotTag, ShouldEqual, uint64(42))
+	Wish(t, nb.Build(), ShouldEqual, basicnode.NewBytes([]byte{1, 2, 3}))
+}
@@ -0,0 +1,63 @@
+package dagcbor
+
+import (
+	"io"
+)
+
+// definiteLengthReader passes through the bytes read from r, while following
+// the cbor item headers in them, and fails with ErrIndefiniteLength as soon
+// as it sees the header of an indefinite-length item (or a "break").
+//
+// It only needs to understand the headers, and how much content follows
+// them which isn't made of further items (the content of strings and bytes);
+// so it doesn't need to track any nesting.
+type definiteLengthReader struct {
+	r   io.Reader
+	err error
+
+	argLeft  int    // bytes of the current header's argument still to be read.
+	arg      uint64 // the current header's argument, so far.
+	major    byte   // the current header's major type.
+	skipLeft uint64 // bytes of string or bytes content still to be skipped.
+}
+
+func (d *definiteLengthReader) Read(p []byte) (int, error) {
+	if d.err != nil {
+		return 0, d.err
+	}
+	n, err := d.r.Read(p)
+	for _, b := range p[:n] {
+		switch {
+		case d.skipLeft > 0:
+			d.skipLeft--
+		case d.argLeft > 0:
+			d.arg = d.arg<<8 | uint64(b)
+			d.argLeft--
+			if d.argLeft == 0 {
+				d.endHeader()
+			}
+		default:
+			d.major, d.arg = b>>5, 0
+			switch info := b & 0x1f; {
+			case info < 24:
+				d.arg = uint64(info)
+				d.endHeader()
+			case info <= 27:
+				d.argLeft = 1 << (info - 24)
+			case info == 31:
+				d.err = ErrIndefiniteLength
+				return 0, d.err
+			default:
+				// Reserved values; leave it to the decoder to reject them.
+			}
+		}
+	}
+	return n, err
+}
+
+func (d *definiteLengthReader) endHeader() {
+	switch d.major {
+	case 2, 3: // bytes and strings
+		d.skipLeft = d.arg
+	}
+}
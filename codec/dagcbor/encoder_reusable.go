@@ -0,0 +1,62 @@
+package dagcbor
+
+import (
+	"io"
+	"sync"
+
+	"github.com/polydawn/refmt/cbor"
+
+	ipld "github.com/ipld/go-ipld-prime"
+)
+
+// ReusableEncoder serializes nodes to dag-cbor, like EncodeOptions.Encode does,
+// but keeps its tokenizer state and buffers from one call to the next.
+// Services which encode large numbers of small nodes can hold on to a
+// ReusableEncoder to avoid allocating all of that anew for every node.
+//
+// A ReusableEncoder must not be used concurrently.
+// The Encode function and EncodeOptions.Encode already reuse ReusableEncoders
+// internally, via a sync.Pool.
+type ReusableEncoder struct {
+	cfg EncodeOptions
+	w   redirectWriter
+	enc *cbor.Encoder
+}
+
+// NewReusableEncoder returns a ReusableEncoder which encodes according to the options.
+func NewReusableEncoder(cfg EncodeOptions) *ReusableEncoder {
+	return &ReusableEncoder{cfg: cfg}
+}
+
+var encoderPool = sync.Pool{New: func() interface{} { return new(ReusableEncoder) }}
+
+// Encode walks the given ipld.Node and serializes it to the given io.Writer.
+func (e *ReusableEncoder) Encode(n ipld.Node, w io.Writer) error {
+	if e.enc == nil {
+		e.enc = cbor.NewEncoder(&e.w)
+	}
+	e.w.w = w
+	e.enc.Reset()
+	err := e.cfg.Marshal(n, e.enc, true)
+	e.w.w = nil // don't keep the writer alive
+	if err != nil {
+		// The tokenizer keeps write errors around, and it may be halfway
+		//  through a map or list; start afresh next time.
+		e.enc = nil
+	}
+	return err
+}
+
+// redirectWriter lets a tokenizer, which is tied to the writer it was created
+// with, write to a different writer each time.
+type redirectWriter struct {
+	w io.Writer
+}
+
+func (r *redirectWriter) Write(p []byte) (int, error) {
+	return r.w.Write(p)
+}
+
+func (r *redirectWriter) WriteString(s string) (int, error) {
+	return io.WriteString(r.w, s)
+}
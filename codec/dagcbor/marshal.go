@@ -2,11 +2,13 @@ package dagcbor
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/polydawn/refmt/shared"
 	"github.com/polydawn/refmt/tok"
 
 	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec"
 	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
 )
 
@@ -14,11 +16,77 @@ import (
 // except for the `case ipld.Kind_Link` block,
 // which is dag-cbor's special sauce for schemafree links.
 func Marshal(n ipld.Node, sink shared.TokenSink, allowLinks bool) error {
+	return EncodeOptions{}.Marshal(n, sink, allowLinks)
+}
+
+// EncodeOptions can be used to customize the behavior of an encoding function.
+// The Encode method on this struct fits the ipld.Encoder function interface.
+type EncodeOptions struct {
+	// If true, the encoder emits strict canonical DAG-CBOR:
+	// map entries are sorted by key, shorter keys first and then bytewise
+	// (as in RFC 7049 section 3.9), and maps containing duplicate keys are
+	// rejected with an ErrDuplicateKey.
+	// Since the same logical node always produces the same bytes this way,
+	// it always hashes to the same CID regardless of the order in which its
+	// map entries were assembled.
+	//
+	// Lengths are always definite, whether or not this option is set.
+	//
+	// Sorting map entries requires buffering them, so canonical encoding
+	// is somewhat slower than the default, which emits map entries in
+	// their iteration order.
+	Canonical bool
+
+	// MapSortMode selects the order in which map entries are emitted,
+	// when Canonical isn't set; Canonical always uses MapSortMode_RFC7049.
+	// The default, MapSortMode_None, emits map entries in their iteration
+	// order, which is useful to check that data round-trips unchanged.
+	// Note that only MapSortMode_RFC7049 is valid for content addressing.
+	MapSortMode codec.MapSortMode
+}
+
+// Encode walks the given ipld.Node and serializes it to the given io.Writer,
+// according to the options.
+func (cfg EncodeOptions) Encode(n ipld.Node, w io.Writer) error {
+	e := encoderPool.Get().(*ReusableEncoder)
+	e.cfg = cfg
+	err := e.Encode(n, w)
+	encoderPool.Put(e)
+	return err
+}
+
+// Marshal is like the Marshal function, but according to the options.
+func (cfg EncodeOptions) Marshal(n ipld.Node, sink shared.TokenSink, allowLinks bool) error {
 	var tk tok.Token
-	return marshal(n, &tk, sink, allowLinks)
+	return marshal(n, &tk, sink, allowLinks, cfg)
+}
+
+// ErrDuplicateKey is returned by canonical encoding
+// when a map node yields the same key more than once.
+type ErrDuplicateKey struct {
+	Key string
+}
+
+func (e ErrDuplicateKey) Error() string {
+	return fmt.Sprintf("cannot encode map with duplicate key %q in canonical form", e.Key)
+}
+
+// sortedMapEntries is codec.SortedMapEntries, which also returns
+// an ErrDuplicateKey if any key is repeated, when rejectDups is true.
+func sortedMapEntries(n ipld.Node, mode codec.MapSortMode, rejectDups bool) ([]codec.MapEntry, error) {
+	entries, err := codec.SortedMapEntries(n, mode)
+	if err != nil {
+		return nil, err
+	}
+	for i := 1; rejectDups && i < len(entries); i++ {
+		if entries[i].Key == entries[i-1].Key {
+			return nil, ErrDuplicateKey{entries[i].Key}
+		}
+	}
+	return entries, nil
 }
 
-func marshal(n ipld.Node, tk *tok.Token, sink shared.TokenSink, allowLinks bool) error {
+func marshal(n ipld.Node, tk *tok.Token, sink shared.TokenSink, allowLinks bool, cfg EncodeOptions) error {
 	switch n.Kind() {
 	case ipld.Kind_Invalid:
 		return fmt.Errorf("cannot traverse a node that is absent")
@@ -34,6 +102,29 @@ func marshal(n ipld.Node, tk *tok.Token, sink shared.TokenSink, allowLinks bool)
 			return err
 		}
 		// Emit map contents (and recurse).
+		mode := cfg.MapSortMode
+		if cfg.Canonical {
+			mode = codec.MapSortMode_RFC7049
+		}
+		if mode != codec.MapSortMode_None {
+			entries, err := sortedMapEntries(n, mode, cfg.Canonical)
+			if err != nil {
+				return err
+			}
+			for _, e := range entries {
+				tk.Type = tok.TString
+				tk.Str = e.Key
+				if _, err := sink.Step(tk); err != nil {
+					return err
+				}
+				if err := marshal(e.Value, tk, sink, allowLinks, cfg); err != nil {
+					return err
+				}
+			}
+			tk.Type = tok.TMapClose
+			_, err = sink.Step(tk)
+			return err
+		}
 		for itr := n.MapIterator(); !itr.Done(); {
 			k, v, err := itr.Next()
 			if err != nil {
@@ -47,7 +138,7 @@ func marshal(n ipld.Node, tk *tok.Token, sink shared.TokenSink, allowLinks bool)
 			if _, err := sink.Step(tk); err != nil {
 				return err
 			}
-			if err := marshal(v, tk, sink, allowLinks); err != nil {
+			if err := marshal(v, tk, sink, allowLinks, cfg); err != nil {
 				return err
 			}
 		}
@@ -69,7 +160,7 @@ func marshal(n ipld.Node, tk *tok.Token, sink shared.TokenSink, allowLinks bool)
 			if err != nil {
 				return err
 			}
-			if err := marshal(v, tk, sink, allowLinks); err != nil {
+			if err := marshal(v, tk, sink, allowLinks, cfg); err != nil {
 				return err
 			}
 		}
@@ -87,6 +178,21 @@ func marshal(n ipld.Node, tk *tok.Token, sink shared.TokenSink, allowLinks bool)
 		_, err = sink.Step(tk)
 		return err
 	case ipld.Kind_Int:
+		if n2, ok := n.(ipld.BigIntNode); ok {
+			v, err := n2.AsBigInt()
+			if err != nil {
+				return err
+			}
+			if !v.IsInt64() {
+				if !v.IsUint64() {
+					return fmt.Errorf("cannot encode integer %s as cbor, as it doesn't fit in 64 bits", v)
+				}
+				tk.Type = tok.TUint
+				tk.Uint = v.Uint64()
+				_, err = sink.Step(tk)
+				return err
+			}
+		}
 		v, err := n.AsInt()
 		if err != nil {
 			return err
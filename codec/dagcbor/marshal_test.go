@@ -0,0 +1,113 @@
+package dagcbor
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec"
+	"github.com/ipld/go-ipld-prime/fluent"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+)
+
+func TestEncodeCanonical(t *testing.T) {
+	t.Run("map keys are sorted length-first", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := EncodeOptions{Canonical: true}.Encode(n, &buf)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, buf.String(), ShouldEqual, "\xa4cmap\xa2cone\x01ctwo\x02dlist\x82ethreedfoureplainkolde stringfnested\xa1fdeeper\x81fthings")
+	})
+	t.Run("insertion order does not affect the output", func(t *testing.T) {
+		n2 := fluent.MustBuildMap(basicnode.Prototype__Map{}, 4, func(na fluent.MapAssembler) {
+			na.AssembleEntry("nested").CreateMap(1, func(na fluent.MapAssembler) {
+				na.AssembleEntry("deeper").CreateList(1, func(na fluent.ListAssembler) {
+					na.AssembleValue().AssignString("things")
+				})
+			})
+			na.AssembleEntry("list").CreateList(2, func(na fluent.ListAssembler) {
+				na.AssembleValue().AssignString("three")
+				na.AssembleValue().AssignString("four")
+			})
+			na.AssembleEntry("map").CreateMap(2, func(na fluent.MapAssembler) {
+				na.AssembleEntry("two").AssignInt(2)
+				na.AssembleEntry("one").AssignInt(1)
+			})
+			na.AssembleEntry("plain").AssignString("olde string")
+		})
+		var buf1, buf2 bytes.Buffer
+		Require(t, EncodeOptions{Canonical: true}.Encode(n, &buf1), ShouldEqual, nil)
+		Require(t, EncodeOptions{Canonical: true}.Encode(n2, &buf2), ShouldEqual, nil)
+		Wish(t, buf2.String(), ShouldEqual, buf1.String())
+	})
+	t.Run("the default keeps iteration order", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := EncodeOptions{}.Encode(n, &buf)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, buf.String(), ShouldEqual, serial)
+	})
+	t.Run("other orders can be chosen when not canonical", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := EncodeOptions{MapSortMode: codec.MapSortMode_Lexical}.Encode(n, &buf)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, buf.String(), ShouldEqual, "\xa4dlist\x82ethreedfourcmap\xa2cone\x01ctwo\x02fnested\xa1fdeeper\x81fthingseplainkolde string")
+
+		buf.Reset()
+		err = EncodeOptions{MapSortMode: codec.MapSortMode_RFC7049}.Encode(dupKeyMap{n}, &buf)
+		Wish(t, err, ShouldEqual, nil)
+	})
+	t.Run("duplicate keys are rejected", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := EncodeOptions{Canonical: true}.Encode(dupKeyMap{n}, &buf)
+		Wish(t, err, ShouldEqual, ErrDuplicateKey{"map"})
+	})
+}
+
+// dupKeyMap is a map node whose iterator yields each entry of the wrapped map twice,
+// which no well-behaved node does, but a buggy or hostile implementation could.
+type dupKeyMap struct {
+	ipld.Node
+}
+
+func (n dupKeyMap) Length() int64 { return 2 * n.Node.Length() }
+
+func (n dupKeyMap) MapIterator() ipld.MapIterator {
+	return &dupKeyMapIterator{n.Node.MapIterator(), n.Node.MapIterator()}
+}
+
+type dupKeyMapIterator struct {
+	first, second ipld.MapIterator
+}
+
+func (itr *dupKeyMapIterator) Next() (ipld.Node, ipld.Node, error) {
+	if !itr.first.Done() {
+		return itr.first.Next()
+	}
+	return itr.second.Next()
+}
+
+func (itr *dupKeyMapIterator) Done() bool {
+	return itr.first.Done() && itr.second.Done()
+}
+
+func TestEncoderReuse(t *testing.T) {
+	enc := NewReusableEncoder(EncodeOptions{})
+	for i := 0; i < 3; i++ {
+		var buf bytes.Buffer
+		err := enc.Encode(n, &buf)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, buf.String(), ShouldEqual, serial)
+
+		// A write error doesn't affect later uses.
+		err = enc.Encode(n, failingWriter{})
+		Wish(t, err, ShouldEqual, errWrite)
+	}
+}
+
+var errWrite = fmt.Errorf("write failed")
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errWrite }
@@ -3,8 +3,6 @@ package dagcbor
 import (
 	"io"
 
-	"github.com/polydawn/refmt/cbor"
-
 	"github.com/ipld/go-ipld-prime"
 	"github.com/ipld/go-ipld-prime/multicodec"
 )
@@ -17,6 +15,13 @@ var (
 func init() {
 	multicodec.RegisterEncoder(0x71, Encode)
 	multicodec.RegisterDecoder(0x71, Decode)
+	multicodec.RegisterCapabilities(0x71, multicodec.Capabilities{
+		Kinds: ipld.KindSet{
+			ipld.Kind_Map, ipld.Kind_List,
+			ipld.Kind_Null, ipld.Kind_Bool, ipld.Kind_Int, ipld.Kind_Float,
+			ipld.Kind_String, ipld.Kind_Bytes, ipld.Kind_Link,
+		},
+	})
 }
 
 func Decode(na ipld.NodeAssembler, r io.Reader) error {
@@ -28,7 +33,7 @@ func Decode(na ipld.NodeAssembler, r io.Reader) error {
 		return na2.DecodeDagCbor(r)
 	}
 	// Okay, generic builder path.
-	return Unmarshal(na, cbor.NewDecoder(cbor.DecodeOptions{}, r), true)
+	return DecodeOptions{}.UnmarshalReader(na, r, true)
 }
 
 func Encode(n ipld.Node, w io.Writer) error {
@@ -40,5 +45,5 @@ func Encode(n ipld.Node, w io.Writer) error {
 		return n2.EncodeDagCbor(w)
 	}
 	// Okay, generic inspection path.
-	return Marshal(n, cbor.NewEncoder(w), true)
+	return EncodeOptions{}.Encode(n, w)
 }
@@ -0,0 +1,137 @@
+package dagcbor
+
+import (
+	"io"
+)
+
+// bytesViewReader passes through the bytes read from r, while following the
+// cbor item headers in them, except for the definite-length bytes items:
+// each of those is replaced with an empty bytes item, and its content is
+// skipped, so that the tokenizer never makes a copy of it.
+// The offset and length of the content are queued instead, so that the
+// decoder can use a view into the input for each bytes token, in order.
+//
+// The chunks of an indefinite-length bytes item are passed through as usual,
+// since the tokenizer joins them into a new slice anyway.
+type bytesViewReader struct {
+	r   io.Reader
+	off int64 // bytes read from r so far.
+	err error
+
+	pending  []byte  // header bytes still to be passed on.
+	header   [9]byte // the current header.
+	passLeft uint64  // bytes of string content still to be passed on.
+	inChunks bool    // whether we're within an indefinite-length bytes item.
+	views    []bytesRef
+	scratch  [512]byte
+}
+
+// bytesRef locates the content of a bytes item in the input,
+// or says that the tokenizer's copy of it must be used, if copied is true.
+type bytesRef struct {
+	start, length int64
+	copied        bool
+}
+
+func (d *bytesViewReader) Read(p []byte) (int, error) {
+	if len(d.pending) > 0 {
+		n := copy(p, d.pending)
+		d.pending = d.pending[n:]
+		return n, nil
+	}
+	if d.err != nil {
+		return 0, d.err
+	}
+	if d.passLeft > 0 {
+		if uint64(len(p)) > d.passLeft {
+			p = p[:d.passLeft]
+		}
+		n, err := d.r.Read(p)
+		d.off += int64(n)
+		d.passLeft -= uint64(n)
+		return n, err
+	}
+	if err := d.readHeader(); err != nil {
+		d.err = err
+	}
+	return d.Read(p)
+}
+
+// readHeader reads the next item header from r,
+// and sets up what's to be passed on for it.
+func (d *bytesViewReader) readHeader() error {
+	if err := d.readFull(d.header[:1]); err != nil {
+		return err
+	}
+	b := d.header[0]
+	major, info := b>>5, b&0x1f
+	argLen := 0
+	if info >= 24 && info <= 27 {
+		argLen = 1 << (info - 24)
+	}
+	if err := d.readFull(d.header[1 : 1+argLen]); err != nil {
+		return io.ErrUnexpectedEOF
+	}
+	var arg uint64
+	if info < 24 {
+		arg = uint64(info)
+	}
+	for _, b := range d.header[1 : 1+argLen] {
+		arg = arg<<8 | uint64(b)
+	}
+	d.pending = d.header[:1+argLen]
+	switch {
+	case d.inChunks && b == 0xff: // the "break" ending the chunks.
+		d.inChunks = false
+	case major == 2 && info == 31:
+		d.inChunks = true
+		d.views = append(d.views, bytesRef{copied: true})
+	case major == 2 && info <= 27 && !d.inChunks:
+		d.views = append(d.views, bytesRef{start: d.off, length: int64(arg)})
+		if err := d.skip(arg); err != nil {
+			d.pending = nil
+			return err
+		}
+		d.header[0] = 0x40 // an empty bytes item.
+		d.pending = d.header[:1]
+	case (major == 2 || major == 3) && info <= 27:
+		d.passLeft = arg
+	}
+	return nil
+}
+
+func (d *bytesViewReader) readFull(p []byte) error {
+	n, err := io.ReadFull(d.r, p)
+	d.off += int64(n)
+	return err
+}
+
+// skip reads and discards n bytes from r.
+func (d *bytesViewReader) skip(n uint64) error {
+	for n > 0 {
+		p := d.scratch[:]
+		if uint64(len(p)) > n {
+			p = p[:n]
+		}
+		if err := d.readFull(p); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return err
+		}
+		n -= uint64(len(p))
+	}
+	return nil
+}
+
+// next returns the location of the next bytes item's content.
+func (d *bytesViewReader) next() bytesRef {
+	if len(d.views) == 0 {
+		// Only happens if the tokenizer produced a bytes token which we
+		// didn't see the header of; fall back to its own copy.
+		return bytesRef{copied: true}
+	}
+	ref := d.views[0]
+	d.views = d.views[1:]
+	return ref
+}
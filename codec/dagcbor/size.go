@@ -0,0 +1,137 @@
+package dagcbor
+
+import (
+	"fmt"
+	"math"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+)
+
+// EncodedSize returns the number of bytes that Encode would produce for n,
+// computing it from the node's contents rather than by encoding it.
+// Its result is the same with any EncodeOptions, since map order doesn't
+// affect the size.
+//
+// As with Encode, nodes may provide a fast path,
+// by having an EncodedSizeDagCbor() (int64, error) method;
+// generated types can use it to compute their size without walking their
+// Data Model view.
+func EncodedSize(n ipld.Node) (int64, error) {
+	// Probe for a builtin fast path.  Shortcut to that if possible.
+	type detectFastPath interface {
+		EncodedSizeDagCbor() (int64, error)
+	}
+	if n2, ok := n.(detectFastPath); ok {
+		return n2.EncodedSizeDagCbor()
+	}
+	// Okay, generic inspection path.
+	return encodedSize(n)
+}
+
+// headerSize returns the size of a CBOR major type byte followed by the
+// argument v, in the shortest form, as the encoder always emits it.
+func headerSize(v uint64) int64 {
+	switch {
+	case v <= 0x17:
+		return 1
+	case v <= math.MaxUint8:
+		return 2
+	case v <= math.MaxUint16:
+		return 3
+	case v <= math.MaxUint32:
+		return 5
+	default:
+		return 9
+	}
+}
+
+func encodedSize(n ipld.Node) (int64, error) {
+	switch n.Kind() {
+	case ipld.Kind_Invalid:
+		return 0, fmt.Errorf("cannot traverse a node that is absent")
+	case ipld.Kind_Null, ipld.Kind_Bool:
+		return 1, nil
+	case ipld.Kind_Int:
+		if n2, ok := n.(ipld.BigIntNode); ok {
+			v, err := n2.AsBigInt()
+			if err != nil {
+				return 0, err
+			}
+			if !v.IsInt64() {
+				if !v.IsUint64() {
+					return 0, fmt.Errorf("cannot encode integer %s as cbor, as it doesn't fit in 64 bits", v)
+				}
+				return headerSize(v.Uint64()), nil
+			}
+		}
+		v, err := n.AsInt()
+		if err != nil {
+			return 0, err
+		}
+		if v < 0 {
+			return headerSize(uint64(-1 - v)), nil
+		}
+		return headerSize(uint64(v)), nil
+	case ipld.Kind_Float:
+		return 9, nil // always encoded as a 64-bit float.
+	case ipld.Kind_String:
+		v, err := n.AsString()
+		if err != nil {
+			return 0, err
+		}
+		return headerSize(uint64(len(v))) + int64(len(v)), nil
+	case ipld.Kind_Bytes:
+		v, err := n.AsBytes()
+		if err != nil {
+			return 0, err
+		}
+		return headerSize(uint64(len(v))) + int64(len(v)), nil
+	case ipld.Kind_Link:
+		v, err := n.AsLink()
+		if err != nil {
+			return 0, err
+		}
+		lnk, ok := v.(cidlink.Link)
+		if !ok {
+			return 0, fmt.Errorf("schemafree link emission only supported by this codec for CID type links")
+		}
+		// The tag, then the CID's bytes, behind a zero byte.
+		l := uint64(len(lnk.Bytes()) + 1)
+		return headerSize(linkTag) + headerSize(l) + int64(l), nil
+	case ipld.Kind_Map:
+		size := headerSize(uint64(n.Length()))
+		for itr := n.MapIterator(); !itr.Done(); {
+			k, v, err := itr.Next()
+			if err != nil {
+				return 0, err
+			}
+			ks, err := k.AsString()
+			if err != nil {
+				return 0, err
+			}
+			vs, err := encodedSize(v)
+			if err != nil {
+				return 0, err
+			}
+			size += headerSize(uint64(len(ks))) + int64(len(ks)) + vs
+		}
+		return size, nil
+	case ipld.Kind_List:
+		size := headerSize(uint64(n.Length()))
+		for itr := n.ListIterator(); !itr.Done(); {
+			_, v, err := itr.Next()
+			if err != nil {
+				return 0, err
+			}
+			vs, err := encodedSize(v)
+			if err != nil {
+				return 0, err
+			}
+			size += vs
+		}
+		return size, nil
+	default:
+		panic("unreachable")
+	}
+}
@@ -0,0 +1,61 @@
+package dagcbor
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+
+	cid "github.com/ipfs/go-cid"
+	. "github.com/warpfork/go-wish"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/fluent"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+)
+
+func TestEncodedSize(t *testing.T) {
+	c, err := cid.Decode("bafyreibbvxxq5fahtmylr5tsfymzqmpw7zyufhh55e2zrf4jbsvtfw6hge")
+	Require(t, err, ShouldEqual, nil)
+	long := fluent.MustBuildList(basicnode.Prototype.List, 300, func(la fluent.ListAssembler) {
+		for i := 0; i < 300; i++ {
+			la.AssembleValue().AssignInt(int64(i) * 1000)
+		}
+	})
+	for _, tc := range []struct {
+		name string
+		n    ipld.Node
+	}{
+		{"null", ipld.Null},
+		{"bool", basicnode.NewBool(true)},
+		{"small int", basicnode.NewInt(23)},
+		{"negative int", basicnode.NewInt(-25)},
+		{"large int", basicnode.NewInt(math.MaxInt64)},
+		{"min int", basicnode.NewInt(math.MinInt64)},
+		{"max uint", basicnode.NewBigInt(new(big.Int).SetUint64(math.MaxUint64))},
+		{"float", basicnode.NewFloat(1.5)},
+		{"long string", basicnode.NewString(strings.Repeat("x", 70000))},
+		{"bytes", basicnode.NewBytes([]byte("hello"))},
+		{"link", basicnode.NewLink(cidlink.Link{Cid: c})},
+		{"map", n},
+		{"big list", long},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			Require(t, Encode(tc.n, &buf), ShouldEqual, nil)
+			size, err := EncodedSize(tc.n)
+			Require(t, err, ShouldEqual, nil)
+			Wish(t, size, ShouldEqual, int64(buf.Len()))
+		})
+	}
+
+	// Integers beyond 64 bits can't be encoded at all.
+	tooBig := basicnode.NewBigInt(new(big.Int).Lsh(big.NewInt(1), 64))
+	_, err = EncodedSize(tooBig)
+	Wish(t, err, ShouldEqual, fmt.Errorf("cannot encode integer 18446744073709551616 as cbor, as it doesn't fit in 64 bits"))
+	err = Encode(tooBig, new(bytes.Buffer))
+	Wish(t, err, ShouldEqual, fmt.Errorf("cannot encode integer 18446744073709551616 as cbor, as it doesn't fit in 64 bits"))
+}
@@ -0,0 +1,181 @@
+package dagcbor
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	cid "github.com/ipfs/go-cid"
+	"github.com/polydawn/refmt/cbor"
+	"github.com/polydawn/refmt/shared"
+	"github.com/polydawn/refmt/tok"
+
+	"github.com/ipld/go-ipld-prime/codec"
+	"github.com/ipld/go-ipld-prime/codec/codectools"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+)
+
+// TokenEncoder serializes a stream of tokens to DAG-CBOR,
+// without needing the data to be assembled into an ipld.Node first.
+//
+// The tokens must form a complete, well-formed value:
+// every MapOpen and ListOpen must be matched by a MapClose or ListClose,
+// and the keys of maps must be strings.
+// A MapOpen or ListOpen with a Length of -1 is encoded with an indefinite length,
+// which is valid CBOR, but not valid DAG-CBOR;
+// the length should be given whenever it's known.
+//
+// WriteToken fits the visitFn argument of codectools.TokenWalk,
+// so a TokenEncoder can also be fed by walking an existing node.
+type TokenEncoder struct {
+	sink shared.TokenSink
+	tk   tok.Token
+}
+
+// NewTokenEncoder returns a TokenEncoder which writes to the given io.Writer.
+func NewTokenEncoder(w io.Writer) *TokenEncoder {
+	return &TokenEncoder{sink: cbor.NewEncoder(w)}
+}
+
+// WriteToken encodes a single token.
+// The token is not retained after the call returns.
+func (e *TokenEncoder) WriteToken(tk *codectools.Token) error {
+	e.tk.Tagged = false
+	switch tk.Kind {
+	case codectools.TokenKind_MapOpen:
+		e.tk.Type = tok.TMapOpen
+		e.tk.Length = int(tk.Length)
+	case codectools.TokenKind_MapClose:
+		e.tk.Type = tok.TMapClose
+	case codectools.TokenKind_ListOpen:
+		e.tk.Type = tok.TArrOpen
+		e.tk.Length = int(tk.Length)
+	case codectools.TokenKind_ListClose:
+		e.tk.Type = tok.TArrClose
+	case codectools.TokenKind_Null:
+		e.tk.Type = tok.TNull
+	case codectools.TokenKind_Bool:
+		e.tk.Type = tok.TBool
+		e.tk.Bool = tk.Bool
+	case codectools.TokenKind_Int:
+		e.tk.Type = tok.TInt
+		e.tk.Int = tk.Int
+	case codectools.TokenKind_Float:
+		e.tk.Type = tok.TFloat64
+		e.tk.Float64 = tk.Float
+	case codectools.TokenKind_String:
+		e.tk.Type = tok.TString
+		e.tk.Str = tk.Str
+	case codectools.TokenKind_Bytes:
+		e.tk.Type = tok.TBytes
+		e.tk.Bytes = tk.Bytes
+	case codectools.TokenKind_Link:
+		lnk, ok := tk.Link.(cidlink.Link)
+		if !ok {
+			return fmt.Errorf("schemafree link emission only supported by this codec for CID type links")
+		}
+		e.tk.Type = tok.TBytes
+		e.tk.Bytes = append([]byte{0}, lnk.Bytes()...)
+		e.tk.Tagged = true
+		e.tk.Tag = linkTag
+	default:
+		return fmt.Errorf("unrecognized token kind (%q?)", tk.Kind)
+	}
+	_, err := e.sink.Step(&e.tk)
+	return err
+}
+
+// TokenDecoder tokenizes DAG-CBOR data from a stream,
+// without assembling it into an ipld.Node.
+//
+// ReadToken fits the codectools.TokenReader interface,
+// so a TokenDecoder can also be used to assemble nodes, with codectools.TokenAssemble.
+//
+// The returned tokens carry no position information.
+// Map and list tokens carry the lengths declared in the data,
+// which will be -1 if the data used indefinite lengths.
+type TokenDecoder struct {
+	src  shared.TokenSource
+	tk   tok.Token
+	out  codectools.Token
+	done bool // set after the last token of the value has been read.
+}
+
+// NewTokenDecoder returns a TokenDecoder which reads from the given io.Reader.
+func NewTokenDecoder(r io.Reader) *TokenDecoder {
+	return &TokenDecoder{src: cbor.NewDecoder(cbor.DecodeOptions{}, r)}
+}
+
+// ReadToken decodes the next token.
+// The returned pointer is the same on every call, with varying contents,
+// so the token must be copied if it needs to be retained.
+//
+// ReadToken returns codec.ErrBudgetExhausted if called with a negative budget;
+// it's up to the caller to decrement the budget for each token read.
+// After the end of a complete value, ReadToken returns io.EOF.
+func (d *TokenDecoder) ReadToken(budget *int64) (*codectools.Token, error) {
+	if *budget < 0 {
+		return nil, codec.ErrBudgetExhausted{}
+	}
+	if d.done {
+		return nil, io.EOF
+	}
+	done, err := d.src.Step(&d.tk)
+	if err != nil {
+		return nil, err
+	}
+	d.done = done
+	switch d.tk.Type {
+	case tok.TMapOpen:
+		d.out.Kind = codectools.TokenKind_MapOpen
+		d.out.Length = int64(d.tk.Length)
+	case tok.TMapClose:
+		d.out.Kind = codectools.TokenKind_MapClose
+	case tok.TArrOpen:
+		d.out.Kind = codectools.TokenKind_ListOpen
+		d.out.Length = int64(d.tk.Length)
+	case tok.TArrClose:
+		d.out.Kind = codectools.TokenKind_ListClose
+	case tok.TNull:
+		d.out.Kind = codectools.TokenKind_Null
+	case tok.TBool:
+		d.out.Kind = codectools.TokenKind_Bool
+		d.out.Bool = d.tk.Bool
+	case tok.TInt:
+		d.out.Kind = codectools.TokenKind_Int
+		d.out.Int = d.tk.Int
+	case tok.TUint:
+		d.out.Kind = codectools.TokenKind_Int
+		if d.tk.Uint > math.MaxInt64 {
+			return nil, fmt.Errorf("cbor integer %d is too large to be an int", d.tk.Uint)
+		}
+		d.out.Int = int64(d.tk.Uint)
+	case tok.TFloat64:
+		d.out.Kind = codectools.TokenKind_Float
+		d.out.Float = d.tk.Float64
+	case tok.TString:
+		d.out.Kind = codectools.TokenKind_String
+		d.out.Str = d.tk.Str
+	case tok.TBytes:
+		if !d.tk.Tagged {
+			d.out.Kind = codectools.TokenKind_Bytes
+			d.out.Bytes = d.tk.Bytes
+			break
+		}
+		if d.tk.Tag != linkTag {
+			return nil, fmt.Errorf("unhandled cbor tag %d", d.tk.Tag)
+		}
+		if len(d.tk.Bytes) < 1 || d.tk.Bytes[0] != 0 {
+			return nil, ErrInvalidMultibase
+		}
+		c, err := cid.Cast(d.tk.Bytes[1:])
+		if err != nil {
+			return nil, err
+		}
+		d.out.Kind = codectools.TokenKind_Link
+		d.out.Link = cidlink.Link{Cid: c}
+	default:
+		return nil, fmt.Errorf("unexpected %s token", d.tk.Type)
+	}
+	return &d.out, nil
+}
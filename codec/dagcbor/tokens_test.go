@@ -0,0 +1,75 @@
+package dagcbor
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	cid "github.com/ipfs/go-cid"
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime/codec/codectools"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+)
+
+func TestTokenEncoder(t *testing.T) {
+	t.Run("from a token walk", func(t *testing.T) {
+		var buf bytes.Buffer
+		enc := NewTokenEncoder(&buf)
+		err := codectools.TokenWalk(n, enc.WriteToken)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, buf.String(), ShouldEqual, serial)
+	})
+	t.Run("from hand-written tokens", func(t *testing.T) {
+		c, err := cid.Decode("bafyreibbvxxq5fahtmylr5tsfymzqmpw7zyufhh55e2zrf4jbsvtfw6hge")
+		Require(t, err, ShouldEqual, nil)
+		lnk := cidlink.Link{Cid: c}
+		var buf bytes.Buffer
+		enc := NewTokenEncoder(&buf)
+		for _, tk := range []codectools.Token{
+			{Kind: codectools.TokenKind_ListOpen, Length: 3},
+			{Kind: codectools.TokenKind_Int, Int: 1},
+			{Kind: codectools.TokenKind_Link, Link: lnk},
+			{Kind: codectools.TokenKind_Null},
+			{Kind: codectools.TokenKind_ListClose},
+		} {
+			Require(t, enc.WriteToken(&tk), ShouldEqual, nil)
+		}
+		nb := basicnode.Prototype.Any.NewBuilder()
+		Require(t, Decode(nb, &buf), ShouldEqual, nil)
+		lnk2, err := nb.Build().LookupByIndex(1)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, lnk2, ShouldEqual, basicnode.NewLink(lnk))
+	})
+}
+
+func TestTokenDecoder(t *testing.T) {
+	t.Run("reading tokens", func(t *testing.T) {
+		dec := NewTokenDecoder(strings.NewReader(serial))
+		budget := int64(1 << 10)
+		var result []codectools.Token
+		for {
+			tk, err := dec.ReadToken(&budget)
+			if err == io.EOF {
+				break
+			}
+			Require(t, err, ShouldEqual, nil)
+			result = append(result, *tk)
+		}
+		var expected []codectools.Token
+		codectools.TokenWalk(n, func(tk *codectools.Token) error {
+			expected = append(expected, *tk)
+			return nil
+		})
+		Wish(t, codectools.StringifyTokenSequence(result), ShouldEqual, codectools.StringifyTokenSequence(expected))
+	})
+	t.Run("assembling a node", func(t *testing.T) {
+		dec := NewTokenDecoder(strings.NewReader(serial))
+		nb := basicnode.Prototype.Any.NewBuilder()
+		err := codectools.TokenAssemble(nb, dec.ReadToken, 1<<10)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, nb.Build(), ShouldEqual, n)
+	})
+}
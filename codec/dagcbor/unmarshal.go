@@ -3,19 +3,31 @@ package dagcbor
 import (
 	"errors"
 	"fmt"
+	"io"
 	"math"
+	"math/big"
 
 	cid "github.com/ipfs/go-cid"
+	"github.com/polydawn/refmt/cbor"
 	"github.com/polydawn/refmt/shared"
 	"github.com/polydawn/refmt/tok"
 
 	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec"
 	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
 )
 
+// The errors which decoding can fail with.
+//
+// Decoders return them wrapped in a *codec.DecodeError, which says where in
+// the data they happened, so compare with errors.Is rather than ==:
+//
+//	if errors.Is(err, dagcbor.ErrAllocationBudgetExceeded) { ... }
 var (
 	ErrInvalidMultibase         = errors.New("invalid multibase on IPLD link")
 	ErrAllocationBudgetExceeded = errors.New("message structure demanded too many resources to process")
+	ErrIndefiniteLength         = errors.New("indefinite-length cbor items are not allowed")
 )
 
 const (
@@ -23,20 +35,228 @@ const (
 	listEntryGasScore = 4
 )
 
+const (
+	// DefaultMaxDecodedSize is the MaxDecodedSize used when DecodeOptions doesn't set one.
+	DefaultMaxDecodedSize = 1048576 * 10
+	// DefaultMaxDepth is the MaxDepth used when DecodeOptions doesn't set one.
+	DefaultMaxDepth = 1024
+)
+
+// ErrLimitExceeded is returned when decoding stops because the data exceeds
+// the MaxDepth or MaxStringLength limit of the DecodeOptions.
+// (Exceeding MaxDecodedSize returns ErrAllocationBudgetExceeded.)
+type ErrLimitExceeded struct {
+	Limit string // which limit was exceeded: "depth" or "string length"
+	Max   int    // the value of that limit
+}
+
+func (e ErrLimitExceeded) Error() string {
+	return fmt.Sprintf("message exceeded the decoder's maximum %s of %d", e.Limit, e.Max)
+}
+
+// DecodeOptions can be used to customize the behavior of a decoding function.
+// The Decode method on this struct fits the ipld.Decoder function interface.
+//
+// The limits are a defense against data from untrusted sources,
+// which could otherwise make the decoder overflow the stack or allocate
+// excessive amounts of memory.
+// A zero value for a limit means that its default is used.
+type DecodeOptions struct {
+	// MaxDepth is the maximum nesting depth of maps and lists.
+	// Exceeding it returns an ErrLimitExceeded.
+	// Defaults to DefaultMaxDepth.
+	MaxDepth int
+
+	// MaxStringLength is the maximum length, in bytes, of any single
+	// string, bytes value, or map key.
+	// Exceeding it returns an ErrLimitExceeded.
+	// If unset, only the total MaxDecodedSize applies.
+	//
+	// Note that the tokenizer reads each value before it's checked,
+	// and itself rejects values larger than 32MiB;
+	// this limit prevents the value from being assembled and retained.
+	MaxStringLength int
+
+	// MaxDecodedSize is a rough measure of the total memory which the decoded
+	// data may take, approximately in bytes: it accounts for the lengths of
+	// strings and bytes, and a small fixed cost for every other value
+	// and for each map and list entry.
+	// Exceeding it returns ErrAllocationBudgetExceeded.
+	// Defaults to DefaultMaxDecodedSize.
+	MaxDecodedSize int
+
+	// If true, indefinite-length strings, bytes, lists and maps are rejected
+	// with ErrIndefiniteLength, as strict (canonical) consumers of dag-cbor
+	// require.  Otherwise, they are accepted, and decode just like their
+	// definite-length equivalents.
+	//
+	// Indefinite-length strings and bytes are only detected by Decode and
+	// UnmarshalReader, since the tokens given to Unmarshal no longer show them.
+	RejectIndefiniteLength bool
+
+	// If true, Decode and UnmarshalReader return codec.ErrTrailingData if
+	// there is any data after the value, rather than ignoring it.
+	RejectTrailingData bool
+
+	// TagDecoders maps application-defined cbor tags to functions which
+	// decode the values carrying them, such as tag 1 for timestamps,
+	// or tags 2 and 3 for bignums.
+	//
+	// Tag 42 is reserved for links, and can't be overridden, unless links
+	// aren't allowed (as with the cbor codec).
+	// Tags without a TagDecoder are ignored, except on bytes, where dag-cbor
+	// rejects them.
+	TagDecoders map[uint64]TagDecoder
+
+	// If true, and the reader given to Decode or UnmarshalReader has
+	// a Bytes method (as *bytes.Buffer does), the decoded bytes values
+	// are views into those bytes rather than copies, and they aren't copied
+	// while decoding either; so decoding large blocks, such as file chunks,
+	// needs little memory beyond the buffer itself.
+	// Indefinite-length bytes values, which come in chunks, are still copied.
+	// The buffer's bytes are handed over just as with the raw codec's Decode.
+	OwnershipTransfer bool
+}
+
+// TagDecoder decodes a value with an application-defined cbor tag.
+//
+// It's called with the tag, and the tagged value as it was decoded without
+// the tag (built with basicnode.Prototype.Any); it should assign the result
+// to na.
+type TagDecoder func(na ipld.NodeAssembler, tag uint64, value ipld.Node) error
+
+// Decode deserializes data from the given io.Reader and feeds it into the
+// given ipld.NodeAssembler, according to the options.
+//
+// Unlike the Decode function, this never uses the fast paths which some
+// node implementations provide, since those do not apply the limits.
+func (cfg DecodeOptions) Decode(na ipld.NodeAssembler, r io.Reader) error {
+	return cfg.UnmarshalReader(na, r, true)
+}
+
+// UnmarshalReader is like Unmarshal, but reads the cbor data from r,
+// which lets it apply the options that need to see the raw data.
+func (cfg DecodeOptions) UnmarshalReader(na ipld.NodeAssembler, r io.Reader, allowLinks bool) error {
+	cr := codec.NewCountingReader(r)
+	if err := cfg.unmarshalCounting(na, r, cr, allowLinks); err != nil {
+		return err
+	}
+	if cfg.RejectTrailingData {
+		if _, err := cr.ReadByte(); err == nil {
+			return codec.DecodeErrorAt(codec.ErrTrailingData, cr.Offset()-1)
+		} else if err != io.EOF {
+			return codec.DecodeErrorAt(err, cr.Offset())
+		}
+	}
+	return nil
+}
+
+// DecodePrefix decodes a single value from the start of r, like Decode,
+// but stops right after it, and returns the number of bytes it consumed.
+// This allows decoding a stream of concatenated values one at a time,
+// without having to split the stream first.
+//
+// Nothing after the value is read from r; RejectTrailingData is ignored.
+func (cfg DecodeOptions) DecodePrefix(na ipld.NodeAssembler, r io.Reader) (int64, error) {
+	cr := codec.NewCountingReader(r)
+	err := cfg.unmarshalCounting(na, r, cr, true)
+	return cr.Offset(), err
+}
+
+// unmarshalCounting decodes from cr, which reads from r.
+func (cfg DecodeOptions) unmarshalCounting(na ipld.NodeAssembler, r io.Reader, cr *codec.CountingReader, allowLinks bool) error {
+	st := cfg.newDecodeState()
+	var src io.Reader = cr
+	var dr *definiteLengthReader
+	if cfg.RejectIndefiniteLength {
+		dr = &definiteLengthReader{r: src}
+		src = dr
+	}
+	if buf, ok := r.(interface{ Bytes() []byte }); ok && cfg.OwnershipTransfer {
+		st.input = buf.Bytes()
+		st.views = &bytesViewReader{r: src}
+		src = st.views
+	}
+	err := st.unmarshal(na, cbor.NewDecoder(cbor.DecodeOptions{}, src), allowLinks)
+	if dr != nil && dr.err != nil {
+		err = dr.err
+	}
+	return codec.DecodeErrorAt(err, cr.Offset())
+}
+
+// Unmarshal is like the Unmarshal function, but according to the options.
+//
+// Errors are returned as a *codec.DecodeError with the path to the value
+// which failed to decode, but not its offset, which is unknown at this level.
+func (cfg DecodeOptions) Unmarshal(na ipld.NodeAssembler, tokSrc shared.TokenSource, allowLinks bool) error {
+	st := cfg.newDecodeState()
+	return st.unmarshal(na, tokSrc, allowLinks)
+}
+
+func (cfg DecodeOptions) newDecodeState() *decodeState {
+	st := &decodeState{cfg: cfg}
+	if st.cfg.MaxDepth == 0 {
+		st.cfg.MaxDepth = DefaultMaxDepth
+	}
+	if st.cfg.MaxDecodedSize == 0 {
+		st.cfg.MaxDecodedSize = DefaultMaxDecodedSize
+	}
+	// Have a gas budget, which will be decremented as we allocate memory, and an error returned when execeeded (or about to be exceeded).
+	//  This is a DoS defense mechanism.
+	//  It's *roughly* in units of bytes (but only very, VERY roughly) -- it also treats words as 1 in many cases.
+	st.gas = st.cfg.MaxDecodedSize
+	return st
+}
+
+func (st *decodeState) unmarshal(na ipld.NodeAssembler, tokSrc shared.TokenSource, allowLinks bool) error {
+	if err := unmarshal1(na, tokSrc, st, allowLinks); err != nil {
+		return codec.DecodeErrorAt(err, -1)
+	}
+	return nil
+}
+
+// decodeState tracks the resources used so far by a single decode.
+type decodeState struct {
+	cfg   DecodeOptions
+	gas   int
+	depth int
+
+	// With OwnershipTransfer, input holds all the bytes being decoded,
+	// and views locates the content of each bytes token in it.
+	input []byte
+	views *bytesViewReader
+}
+
+// tokenBytes returns the bytes of the bytes token which was just read:
+// a view into the input if the decoder owns it, or else the token's own.
+func (st *decodeState) tokenBytes(tk *tok.Token) []byte {
+	if st.views == nil {
+		return tk.Bytes
+	}
+	ref := st.views.next()
+	end := ref.start + ref.length
+	if ref.copied || end > int64(len(st.input)) {
+		return tk.Bytes
+	}
+	return st.input[ref.start:end:end]
+}
+
+func (st *decodeState) checkStringLength(n int) error {
+	if st.cfg.MaxStringLength > 0 && n > st.cfg.MaxStringLength {
+		return ErrLimitExceeded{"string length", st.cfg.MaxStringLength}
+	}
+	return nil
+}
+
 // This should be identical to the general feature in the parent package,
 // except for the `case tok.TBytes` block,
 // which has dag-cbor's special sauce for detecting schemafree links.
 
 func Unmarshal(na ipld.NodeAssembler, tokSrc shared.TokenSource, allowLinks bool) error {
-	// Have a gas budget, which will be decremented as we allocate memory, and an error returned when execeeded (or about to be exceeded).
-	//  This is a DoS defense mechanism.
-	//  It's *roughly* in units of bytes (but only very, VERY roughly) -- it also treats words as 1 in many cases.
-	// FUTURE: this ought be configurable somehow.  (How, and at what granularity though?)
-	var gas int = 1048576 * 10
-	return unmarshal1(na, tokSrc, &gas, allowLinks)
+	return DecodeOptions{}.Unmarshal(na, tokSrc, allowLinks)
 }
 
-func unmarshal1(na ipld.NodeAssembler, tokSrc shared.TokenSource, gas *int, allowLinks bool) error {
+func unmarshal1(na ipld.NodeAssembler, tokSrc shared.TokenSource, st *decodeState, allowLinks bool) error {
 	var tk tok.Token
 	done, err := tokSrc.Step(&tk)
 	if err != nil {
@@ -45,22 +265,41 @@ func unmarshal1(na ipld.NodeAssembler, tokSrc shared.TokenSource, gas *int, allo
 	if done && !tk.Type.IsValue() {
 		return fmt.Errorf("unexpected eof")
 	}
-	return unmarshal2(na, tokSrc, &tk, gas, allowLinks)
+	return unmarshal2(na, tokSrc, &tk, st, allowLinks)
 }
 
 // starts with the first token already primed.  Necessary to get recursion
 //  to flow right without a peek+unpeek system.
-func unmarshal2(na ipld.NodeAssembler, tokSrc shared.TokenSource, tk *tok.Token, gas *int, allowLinks bool) error {
+func unmarshal2(na ipld.NodeAssembler, tokSrc shared.TokenSource, tk *tok.Token, st *decodeState, allowLinks bool) error {
 	// FUTURE: check for schema.TypedNodeBuilder that's going to parse a Link (they can slurp any token kind they want).
+	if tk.Tagged && !(allowLinks && tk.Tag == linkTag) {
+		if fn := st.cfg.TagDecoders[uint64(tk.Tag)]; fn != nil {
+			tag := uint64(tk.Tag)
+			tk.Tagged = false
+			nb := basicnode.Prototype.Any.NewBuilder()
+			if err := unmarshal2(nb, tokSrc, tk, st, allowLinks); err != nil {
+				return err
+			}
+			return fn(na, tag, nb.Build())
+		}
+	}
 	switch tk.Type {
 	case tok.TMapOpen:
+		if st.depth >= st.cfg.MaxDepth {
+			return ErrLimitExceeded{"depth", st.cfg.MaxDepth}
+		}
+		if tk.Length == -1 && st.cfg.RejectIndefiniteLength {
+			return ErrIndefiniteLength
+		}
+		st.depth++
+		defer func() { st.depth-- }()
 		expectLen := tk.Length
 		allocLen := tk.Length
 		if tk.Length == -1 {
 			expectLen = math.MaxInt32
 			allocLen = 0
 		} else {
-			if *gas-allocLen < 0 { // halt early if this will clearly demand too many resources
+			if st.gas-allocLen < 0 { // halt early if this will clearly demand too many resources
 				return ErrAllocationBudgetExceeded
 			}
 		}
@@ -81,8 +320,11 @@ func unmarshal2(na ipld.NodeAssembler, tokSrc shared.TokenSource, tk *tok.Token,
 				}
 				return ma.Finish()
 			case tok.TString:
-				*gas -= len(tk.Str) + mapEntryGasScore
-				if *gas < 0 {
+				if err := st.checkStringLength(len(tk.Str)); err != nil {
+					return err
+				}
+				st.gas -= len(tk.Str) + mapEntryGasScore
+				if st.gas < 0 {
 					return ErrAllocationBudgetExceeded
 				}
 				// continue
@@ -93,25 +335,34 @@ func unmarshal2(na ipld.NodeAssembler, tokSrc shared.TokenSource, tk *tok.Token,
 			if observedLen > expectLen {
 				return fmt.Errorf("unexpected continuation of map elements beyond declared length")
 			}
-			mva, err := ma.AssembleEntry(tk.Str)
+			key := tk.Str // the recursion reuses tk.
+			mva, err := ma.AssembleEntry(key)
 			if err != nil { // return in error if the key was rejected
 				return err
 			}
-			err = unmarshal1(mva, tokSrc, gas, allowLinks)
+			err = unmarshal1(mva, tokSrc, st, allowLinks)
 			if err != nil { // return in error if some part of the recursion errored
-				return err
+				return codec.DecodeErrorUnder(err, ipld.PathSegmentOfString(key))
 			}
 		}
 	case tok.TMapClose:
 		return fmt.Errorf("unexpected mapClose token")
 	case tok.TArrOpen:
+		if st.depth >= st.cfg.MaxDepth {
+			return ErrLimitExceeded{"depth", st.cfg.MaxDepth}
+		}
+		if tk.Length == -1 && st.cfg.RejectIndefiniteLength {
+			return ErrIndefiniteLength
+		}
+		st.depth++
+		defer func() { st.depth-- }()
 		expectLen := tk.Length
 		allocLen := tk.Length
 		if tk.Length == -1 {
 			expectLen = math.MaxInt32
 			allocLen = 0
 		} else {
-			if *gas-allocLen < 0 { // halt early if this will clearly demand too many resources
+			if st.gas-allocLen < 0 { // halt early if this will clearly demand too many resources
 				return ErrAllocationBudgetExceeded
 			}
 		}
@@ -132,17 +383,17 @@ func unmarshal2(na ipld.NodeAssembler, tokSrc shared.TokenSource, tk *tok.Token,
 				}
 				return la.Finish()
 			default:
-				*gas -= listEntryGasScore
-				if *gas < 0 {
+				st.gas -= listEntryGasScore
+				if st.gas < 0 {
 					return ErrAllocationBudgetExceeded
 				}
 				observedLen++
 				if observedLen > expectLen {
 					return fmt.Errorf("unexpected continuation of array elements beyond declared length")
 				}
-				err := unmarshal2(la.AssembleValue(), tokSrc, tk, gas, allowLinks)
+				err := unmarshal2(la.AssembleValue(), tokSrc, tk, st, allowLinks)
 				if err != nil { // return in error if some part of the recursion errored
-					return err
+					return codec.DecodeErrorUnder(err, ipld.PathSegmentOfInt(int64(observedLen-1)))
 				}
 			}
 		}
@@ -151,28 +402,34 @@ func unmarshal2(na ipld.NodeAssembler, tokSrc shared.TokenSource, tk *tok.Token,
 	case tok.TNull:
 		return na.AssignNull()
 	case tok.TString:
-		*gas -= len(tk.Str)
-		if *gas < 0 {
+		if err := st.checkStringLength(len(tk.Str)); err != nil {
+			return err
+		}
+		st.gas -= len(tk.Str)
+		if st.gas < 0 {
 			return ErrAllocationBudgetExceeded
 		}
 		return na.AssignString(tk.Str)
 	case tok.TBytes:
-		*gas -= len(tk.Bytes)
-		if *gas < 0 {
+		bs := st.tokenBytes(tk)
+		if err := st.checkStringLength(len(bs)); err != nil {
+			return err
+		}
+		st.gas -= len(bs)
+		if st.gas < 0 {
 			return ErrAllocationBudgetExceeded
 		}
-		if !tk.Tagged {
-			return na.AssignBytes(tk.Bytes)
+		// Tags are only meaningful in dag-cbor; plain cbor (which doesn't allow links)
+		//  ignores them, and keeps the tagged content as plain bytes.
+		if !tk.Tagged || !allowLinks {
+			return na.AssignBytes(bs)
 		}
 		switch tk.Tag {
 		case linkTag:
-			if !allowLinks {
-				return fmt.Errorf("unhandled cbor tag %d", tk.Tag)
-			}
-			if len(tk.Bytes) < 1 || tk.Bytes[0] != 0 {
+			if len(bs) < 1 || bs[0] != 0 {
 				return ErrInvalidMultibase
 			}
-			elCid, err := cid.Cast(tk.Bytes[1:])
+			elCid, err := cid.Cast(bs[1:])
 			if err != nil {
 				return err
 			}
@@ -181,26 +438,32 @@ func unmarshal2(na ipld.NodeAssembler, tokSrc shared.TokenSource, tk *tok.Token,
 			return fmt.Errorf("unhandled cbor tag %d", tk.Tag)
 		}
 	case tok.TBool:
-		*gas -= 1
-		if *gas < 0 {
+		st.gas -= 1
+		if st.gas < 0 {
 			return ErrAllocationBudgetExceeded
 		}
 		return na.AssignBool(tk.Bool)
 	case tok.TInt:
-		*gas -= 1
-		if *gas < 0 {
+		st.gas -= 1
+		if st.gas < 0 {
 			return ErrAllocationBudgetExceeded
 		}
 		return na.AssignInt(tk.Int)
 	case tok.TUint:
-		*gas -= 1
-		if *gas < 0 {
+		st.gas -= 1
+		if st.gas < 0 {
 			return ErrAllocationBudgetExceeded
 		}
-		return na.AssignInt(int64(tk.Uint)) // FIXME overflow check
+		if tk.Uint > math.MaxInt64 {
+			if na2, ok := na.(ipld.BigIntAssembler); ok {
+				return na2.AssignBigInt(new(big.Int).SetUint64(tk.Uint))
+			}
+			return fmt.Errorf("cbor integer %d is too large to be an int", tk.Uint)
+		}
+		return na.AssignInt(int64(tk.Uint))
 	case tok.TFloat64:
-		*gas -= 1
-		if *gas < 0 {
+		st.gas -= 1
+		if st.gas < 0 {
 			return ErrAllocationBudgetExceeded
 		}
 		return na.AssignFloat(tk.Float64)
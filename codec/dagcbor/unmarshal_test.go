@@ -1,11 +1,21 @@
 package dagcbor
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"runtime"
 	"strings"
 	"testing"
 
 	. "github.com/warpfork/go-wish"
 
+	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec"
+	"github.com/ipld/go-ipld-prime/fluent"
+	"github.com/ipld/go-ipld-prime/must"
 	basicnode "github.com/ipld/go-ipld-prime/node/basic"
 )
 
@@ -15,27 +25,300 @@ func TestFunBlocks(t *testing.T) {
 		buf := strings.NewReader("\x8d\x8d\x97\xd8*@")
 		nb := basicnode.Prototype.Any.NewBuilder()
 		err := Decode(nb, buf)
-		Require(t, err, ShouldEqual, ErrInvalidMultibase)
+		Require(t, errors.Unwrap(err), ShouldEqual, ErrInvalidMultibase)
 	})
 	t.Run("fuzz001", func(t *testing.T) {
 		// This fixture might cause an overly large allocation if you aren't careful to have resource budgets.
 		buf := strings.NewReader("\x9a\xff000")
 		nb := basicnode.Prototype.Any.NewBuilder()
 		err := Decode(nb, buf)
-		Require(t, err, ShouldEqual, ErrAllocationBudgetExceeded)
+		Require(t, errors.Unwrap(err), ShouldEqual, ErrAllocationBudgetExceeded)
 	})
 	t.Run("fuzz002", func(t *testing.T) {
 		// This fixture might cause an overly large allocation if you aren't careful to have resource budgets.
 		buf := strings.NewReader("\x9f\x9f\x9f\x9f\x9f\x9f\x9f\x9f\x9f\x9f\x9f\x9f\x9f\x9f\x9f\x9f\x9f\x9f\x9f\x9f\x9a\xff000")
 		nb := basicnode.Prototype.Any.NewBuilder()
 		err := Decode(nb, buf)
-		Require(t, err, ShouldEqual, ErrAllocationBudgetExceeded)
+		Require(t, errors.Unwrap(err), ShouldEqual, ErrAllocationBudgetExceeded)
 	})
 	t.Run("fuzz003", func(t *testing.T) {
 		// This fixture might cause an overly large allocation if you aren't careful to have resource budgets.
 		buf := strings.NewReader("\x9f\x9f\x9f\x9f\x9f\x9f\x9f\xbb00000000")
 		nb := basicnode.Prototype.Any.NewBuilder()
 		err := Decode(nb, buf)
-		Require(t, err, ShouldEqual, ErrAllocationBudgetExceeded)
+		Require(t, errors.Unwrap(err), ShouldEqual, ErrAllocationBudgetExceeded)
+	})
+}
+
+func TestDecodeLimits(t *testing.T) {
+	t.Run("depth", func(t *testing.T) {
+		// [[[["x"]]]]
+		serial := "\x81\x81\x81\x81ax"
+		nb := basicnode.Prototype.Any.NewBuilder()
+		err := DecodeOptions{MaxDepth: 3}.Decode(nb, strings.NewReader(serial))
+		Wish(t, errors.Unwrap(err), ShouldEqual, ErrLimitExceeded{"depth", 3})
+
+		nb = basicnode.Prototype.Any.NewBuilder()
+		err = DecodeOptions{MaxDepth: 4}.Decode(nb, strings.NewReader(serial))
+		Wish(t, err, ShouldEqual, nil)
+	})
+	t.Run("default depth", func(t *testing.T) {
+		serial := strings.Repeat("\x81", DefaultMaxDepth+1) + "\xf6"
+		nb := basicnode.Prototype.Any.NewBuilder()
+		err := Decode(nb, strings.NewReader(serial))
+		Wish(t, errors.Unwrap(err), ShouldEqual, ErrLimitExceeded{"depth", DefaultMaxDepth})
+	})
+	t.Run("string length", func(t *testing.T) {
+		for _, serial := range []string{
+			"eabcde",         // "abcde"
+			"\x45abcde",      // bytes "abcde"
+			"\xa1eabcde\xf6", // {"abcde": null}
+		} {
+			nb := basicnode.Prototype.Any.NewBuilder()
+			err := DecodeOptions{MaxStringLength: 4}.Decode(nb, strings.NewReader(serial))
+			Wish(t, errors.Unwrap(err), ShouldEqual, ErrLimitExceeded{"string length", 4})
+
+			nb = basicnode.Prototype.Any.NewBuilder()
+			err = DecodeOptions{MaxStringLength: 5}.Decode(nb, strings.NewReader(serial))
+			Wish(t, err, ShouldEqual, nil)
+		}
+	})
+	t.Run("decoded size", func(t *testing.T) {
+		serial := "\x82eabcdeeabcde" // ["abcde", "abcde"]
+		nb := basicnode.Prototype.Any.NewBuilder()
+		err := DecodeOptions{MaxDecodedSize: 16}.Decode(nb, strings.NewReader(serial))
+		Wish(t, errors.Unwrap(err), ShouldEqual, ErrAllocationBudgetExceeded)
+		Wish(t, errors.Is(err, ErrAllocationBudgetExceeded), ShouldEqual, true)
+		Wish(t, err == ErrAllocationBudgetExceeded, ShouldEqual, false) // it's wrapped in a *codec.DecodeError.
+
+		nb = basicnode.Prototype.Any.NewBuilder()
+		err = DecodeOptions{MaxDecodedSize: 32}.Decode(nb, strings.NewReader(serial))
+		Wish(t, err, ShouldEqual, nil)
+	})
+}
+
+func TestDecodeIndefiniteLength(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		indefinite string
+		definite   string
+	}{
+		{"string", "\x7fbabccde\xff", "eabcde"},
+		{"bytes", "\x5fBab\x43cde\xff", "\x45abcde"},
+		{"list", "\x9f\x01\x02\xff", "\x82\x01\x02"},
+		{"map", "\xbfaa\x01ab\x02\xff", "\xa2aa\x01ab\x02"},
+		{"nested in a map value", "\xa1aa\x9f\xff", "\xa1aa\x80"},
+		{"after a float and a string", "\x83\xfb\x3f\xf0\x00\x00\x00\x00\x00\x00b\x7f\xff\x9f\xff", "\x83\xfb\x3f\xf0\x00\x00\x00\x00\x00\x00b\x7f\xff\x80"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Accepted by default, and normalized.
+			nb := basicnode.Prototype.Any.NewBuilder()
+			err := Decode(nb, strings.NewReader(tc.indefinite))
+			Require(t, err, ShouldEqual, nil)
+			nb2 := basicnode.Prototype.Any.NewBuilder()
+			err = Decode(nb2, strings.NewReader(tc.definite))
+			Require(t, err, ShouldEqual, nil)
+			Wish(t, nb.Build(), ShouldEqual, nb2.Build())
+
+			// Rejected on request.
+			nb = basicnode.Prototype.Any.NewBuilder()
+			err = DecodeOptions{RejectIndefiniteLength: true}.Decode(nb, strings.NewReader(tc.indefinite))
+			Wish(t, errors.Unwrap(err), ShouldEqual, ErrIndefiniteLength)
+			nb = basicnode.Prototype.Any.NewBuilder()
+			err = DecodeOptions{RejectIndefiniteLength: true}.Decode(nb, strings.NewReader(tc.definite))
+			Wish(t, err, ShouldEqual, nil)
+		})
+	}
+}
+
+func TestDecodeTags(t *testing.T) {
+	// A bignum decoder, which only supports values which fit in an int.
+	bignum := func(na ipld.NodeAssembler, tag uint64, value ipld.Node) error {
+		b, err := value.AsBytes()
+		if err != nil {
+			return err
+		}
+		var i big.Int
+		i.SetBytes(b)
+		if !i.IsInt64() {
+			return fmt.Errorf("bignum too large")
+		}
+		return na.AssignInt(i.Int64())
+	}
+	// A decoder for tagged maps, which records the tag.
+	tagged := func(na ipld.NodeAssembler, tag uint64, value ipld.Node) error {
+		ma, err := na.BeginMap(2)
+		if err != nil {
+			return err
+		}
+		if err := ma.AssembleKey().AssignString("tag"); err != nil {
+			return err
+		}
+		if err := ma.AssembleValue().AssignInt(int64(tag)); err != nil {
+			return err
+		}
+		if err := ma.AssembleKey().AssignString("value"); err != nil {
+			return err
+		}
+		if err := ma.AssembleValue().AssignNode(value); err != nil {
+			return err
+		}
+		return ma.Finish()
+	}
+	cfg := DecodeOptions{TagDecoders: map[uint64]TagDecoder{2: bignum, 100: tagged}}
+
+	t.Run("bignum", func(t *testing.T) {
+		nb := basicnode.Prototype.Any.NewBuilder()
+		err := cfg.Decode(nb, strings.NewReader("\xc2\x42\x01\x00")) // 2(h'0100')
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, nb.Build(), ShouldEqual, basicnode.NewInt(256))
+	})
+	t.Run("tagged map in a list", func(t *testing.T) {
+		nb := basicnode.Prototype.Any.NewBuilder()
+		err := cfg.Decode(nb, strings.NewReader("\x82\xd8\x64\xa1aa\x01\x02")) // [100({"a": 1}), 2]
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, nb.Build(), ShouldEqual, fluent.MustBuildList(basicnode.Prototype.List, 2, func(na fluent.ListAssembler) {
+			na.AssembleValue().CreateMap(2, func(na fluent.MapAssembler) {
+				na.AssembleEntry("tag").AssignInt(100)
+				na.AssembleEntry("value").CreateMap(1, func(na fluent.MapAssembler) {
+					na.AssembleEntry("a").AssignInt(1)
+				})
+			})
+			na.AssembleValue().AssignInt(2)
+		}))
+	})
+	t.Run("errors are passed through", func(t *testing.T) {
+		nb := basicnode.Prototype.Any.NewBuilder()
+		err := cfg.Decode(nb, strings.NewReader("\xc2\x49\x01\x00\x00\x00\x00\x00\x00\x00\x00"))
+		Wish(t, errors.Unwrap(err), ShouldEqual, fmt.Errorf("bignum too large"))
+	})
+	t.Run("links can't be overridden", func(t *testing.T) {
+		cfg := DecodeOptions{TagDecoders: map[uint64]TagDecoder{linkTag: tagged}}
+		nb := basicnode.Prototype.Any.NewBuilder()
+		err := cfg.Decode(nb, strings.NewReader("\xd8\x2a\x58\x25\x00\x01\x71\x12\x20"+strings.Repeat("\x00", 32)))
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, nb.Build().Kind(), ShouldEqual, ipld.Kind_Link)
+	})
+}
+
+func TestDecodeErrorContext(t *testing.T) {
+	// {"a": [1, "xy"]}, where the string is longer than the limit allows.
+	serial := "\xa1aa\x82\x01bxy"
+	nb := basicnode.Prototype.Any.NewBuilder()
+	err := DecodeOptions{MaxStringLength: 1}.Decode(nb, strings.NewReader(serial))
+	var de *codec.DecodeError
+	Require(t, errors.As(err, &de), ShouldEqual, true)
+	Wish(t, de.Offset, ShouldEqual, int64(len(serial)))
+	Wish(t, de.Path.String(), ShouldEqual, "a/1")
+	Wish(t, de.Err, ShouldEqual, ErrLimitExceeded{"string length", 1})
+	Wish(t, err.Error(), ShouldEqual, "message exceeded the decoder's maximum string length of 1 (at offset 8, under path 'a/1')")
+}
+
+func TestDecodeTrailingData(t *testing.T) {
+	// Two concatenated values: "abc" and [1].
+	serial := "cabc\x81\x01"
+	t.Run("trailing data is ignored by default", func(t *testing.T) {
+		nb := basicnode.Prototype.Any.NewBuilder()
+		err := Decode(nb, strings.NewReader(serial))
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, nb.Build(), ShouldEqual, basicnode.NewString("abc"))
+	})
+	t.Run("trailing data can be rejected", func(t *testing.T) {
+		nb := basicnode.Prototype.Any.NewBuilder()
+		err := DecodeOptions{RejectTrailingData: true}.Decode(nb, strings.NewReader(serial))
+		Wish(t, errors.Is(err, codec.ErrTrailingData), ShouldEqual, true)
+		Wish(t, err.Error(), ShouldEqual, "unexpected data after the end of the value (at offset 4)")
+
+		nb = basicnode.Prototype.Any.NewBuilder()
+		err = DecodeOptions{RejectTrailingData: true}.Decode(nb, strings.NewReader(serial[:4]))
+		Wish(t, err, ShouldEqual, nil)
+	})
+	t.Run("decoding a stream of values", func(t *testing.T) {
+		r := strings.NewReader(serial)
+		nb := basicnode.Prototype.Any.NewBuilder()
+		n, err := DecodeOptions{}.DecodePrefix(nb, r)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, n, ShouldEqual, int64(4))
+		Wish(t, nb.Build(), ShouldEqual, basicnode.NewString("abc"))
+
+		nb = basicnode.Prototype.Any.NewBuilder()
+		n, err = DecodeOptions{}.DecodePrefix(nb, r)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, n, ShouldEqual, int64(2))
+		Wish(t, nb.Build().Length(), ShouldEqual, int64(1))
+		Wish(t, r.Len(), ShouldEqual, 0)
+	})
+}
+
+func TestDecodeOwnershipTransfer(t *testing.T) {
+	// {"a": h'68656c6c6f', "b": (_ h'6865', h'6c6c6f')}
+	serial := []byte("\xa2\x61a\x45hello\x61b\x5f\x42he\x43llo\xff")
+	decode := func(cfg DecodeOptions) (a, b []byte) {
+		nb := basicnode.Prototype.Any.NewBuilder()
+		err := cfg.Decode(nb, bytes.NewBuffer(serial))
+		Require(t, err, ShouldEqual, nil)
+		n := nb.Build()
+		a, err = must.Node(n.LookupByString("a")).AsBytes()
+		Require(t, err, ShouldEqual, nil)
+		b, err = must.Node(n.LookupByString("b")).AsBytes()
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, string(a), ShouldEqual, "hello")
+		Wish(t, string(b), ShouldEqual, "hello")
+		return a, b
+	}
+
+	// With ownership transfer, bytes are views into the input,
+	// except for indefinite-length bytes, which aren't contiguous in it.
+	a, b := decode(DecodeOptions{OwnershipTransfer: true})
+	Wish(t, &a[0] == &serial[4], ShouldEqual, true)
+	Wish(t, cap(a), ShouldEqual, 5)
+	Wish(t, &b[0] == &serial[13], ShouldEqual, false)
+
+	// Otherwise, they're copies.
+	a, _ = decode(DecodeOptions{})
+	Wish(t, &a[0] == &serial[4], ShouldEqual, false)
+
+	t.Run("decodes the same as without", func(t *testing.T) {
+		tagDecoders := map[uint64]TagDecoder{1: func(na ipld.NodeAssembler, tag uint64, value ipld.Node) error {
+			return na.AssignNode(value)
+		}}
+		for _, serial := range []string{
+			// [h'', h'0102', {"/": 42(h'0001550000'), "s": "str", "x": 1(h'ff')}, -1.5]
+			"\x84\x40\x42\x01\x02\xa3\x61/\xd8\x2a\x45\x00\x01\x55\x00\x00\x61s\x63str\x61x\xc1\x41\xff\xf9\xbe\x00",
+			// [(_ h'01', h'02'), "zzz..."], where the string has a 1-byte length argument.
+			"\x82\x5f\x41\x01\x41\x02\xff\x78\x1a" + strings.Repeat("z", 26),
+		} {
+			for _, cfg := range []DecodeOptions{{TagDecoders: tagDecoders}, {TagDecoders: tagDecoders, RejectIndefiniteLength: true}} {
+				nbCopy := basicnode.Prototype.Any.NewBuilder()
+				errCopy := cfg.Decode(nbCopy, strings.NewReader(serial))
+				cfg.OwnershipTransfer = true
+				nb := basicnode.Prototype.Any.NewBuilder()
+				err := cfg.Decode(nb, bytes.NewBufferString(serial))
+				Wish(t, fmt.Sprint(err), ShouldEqual, fmt.Sprint(errCopy))
+				if errCopy == nil {
+					Wish(t, nb.Build(), ShouldEqual, nbCopy.Build())
+				}
+			}
+		}
+	})
+	t.Run("rejects truncated bytes", func(t *testing.T) {
+		nb := basicnode.Prototype.Any.NewBuilder()
+		err := DecodeOptions{OwnershipTransfer: true}.Decode(nb, bytes.NewBufferString("\x82\x45hel"))
+		Wish(t, errors.Is(err, io.ErrUnexpectedEOF), ShouldEqual, true)
+	})
+	t.Run("doesn't copy large bytes while decoding", func(t *testing.T) {
+		const size = 4 << 20
+		var buf bytes.Buffer
+		buf.WriteString("\x5a\x00\x40\x00\x00") // a bytes header for 4MiB.
+		buf.Write(make([]byte, size))
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		nb := basicnode.Prototype.Any.NewBuilder()
+		err := DecodeOptions{OwnershipTransfer: true}.Decode(nb, &buf)
+		runtime.ReadMemStats(&after)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, after.TotalAlloc-before.TotalAlloc < size/4, ShouldEqual, true)
+		bs, _ := nb.Build().AsBytes()
+		Wish(t, len(bs), ShouldEqual, size)
 	})
 }
@@ -0,0 +1,318 @@
+package dagjson
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/polydawn/refmt/tok"
+)
+
+// encoder is a shared.TokenSink which emits json bytes.
+// Its zero value is ready to use, once reset.
+//
+// It produces the same output as the refmt json encoder (which it's derived
+// from), except that it also supports floats, which refmt doesn't.
+type encoder struct {
+	wr     io.Writer
+	line   []byte
+	indent []byte
+	floats FloatFormat
+
+	// Stack, tracking how many array and map opens are outstanding.
+	// (Values are only 'phase_mapExpectKeyOrEnd' and 'phase_arrExpectValueOrEnd'.)
+	stack   []phase
+	current phase // shortcut to value at end of stack
+	some    bool  // set to true after first value in any context; use to append commas.
+
+	// Spare memory, for use in operations on leaf nodes (e.g. temp space for an int serialization).
+	scratch [64]byte
+}
+
+var newline = []byte{'\n'}
+
+type phase int
+
+const (
+	phase_anyExpectValue phase = iota
+	phase_mapExpectKeyOrEnd
+	phase_mapExpectValue
+	phase_arrExpectValueOrEnd
+)
+
+// reset readies the encoder to write a new value to wr, keeping its buffers.
+func (e *encoder) reset(wr io.Writer, cfg EncodeOptions) {
+	e.wr = wr
+	e.floats = cfg.FloatFormat
+	e.line = nil
+	if cfg.Indent != "" {
+		e.line = newline
+	}
+	e.indent = append(e.indent[:0], cfg.Indent...)
+	e.stack = e.stack[:0]
+	e.current = phase_anyExpectValue
+	e.some = false
+}
+
+func (e *encoder) Step(tk *tok.Token) (done bool, err error) {
+	switch e.current {
+	case phase_anyExpectValue:
+		switch tk.Type {
+		case tok.TMapOpen:
+			e.pushPhase(phase_mapExpectKeyOrEnd)
+			e.write("{")
+			return false, nil
+		case tok.TArrOpen:
+			e.pushPhase(phase_arrExpectValueOrEnd)
+			e.write("[")
+			return false, nil
+		case tok.TMapClose:
+			return true, fmt.Errorf("unexpected mapClose; expected start of value")
+		case tok.TArrClose:
+			return true, fmt.Errorf("unexpected arrClose; expected start of value")
+		default:
+			return true, e.flushValue(tk)
+		}
+	case phase_mapExpectKeyOrEnd:
+		switch tk.Type {
+		case tok.TMapClose:
+			e.closeSep()
+			e.write("}")
+			return e.popPhase()
+		case tok.TString:
+			e.entrySep()
+			e.emitString(tk.Str)
+			e.write(":")
+			if e.line != nil {
+				e.write(" ")
+			}
+			e.current = phase_mapExpectValue
+			return false, nil
+		default:
+			return true, fmt.Errorf("unexpected %s token; expected start of key or end of map", tk.Type)
+		}
+	case phase_mapExpectValue:
+		switch tk.Type {
+		case tok.TMapOpen:
+			e.pushPhase(phase_mapExpectKeyOrEnd)
+			e.write("{")
+			return false, nil
+		case tok.TArrOpen:
+			e.pushPhase(phase_arrExpectValueOrEnd)
+			e.write("[")
+			return false, nil
+		case tok.TMapClose:
+			return true, fmt.Errorf("unexpected mapClose; expected start of value")
+		case tok.TArrClose:
+			return true, fmt.Errorf("unexpected arrClose; expected start of value")
+		default:
+			e.current = phase_mapExpectKeyOrEnd
+			return false, e.flushValue(tk)
+		}
+	case phase_arrExpectValueOrEnd:
+		switch tk.Type {
+		case tok.TMapOpen:
+			e.entrySep()
+			e.pushPhase(phase_mapExpectKeyOrEnd)
+			e.write("{")
+			return false, nil
+		case tok.TArrOpen:
+			e.entrySep()
+			e.pushPhase(phase_arrExpectValueOrEnd)
+			e.write("[")
+			return false, nil
+		case tok.TMapClose:
+			return true, fmt.Errorf("unexpected mapClose; expected start of value or end of array")
+		case tok.TArrClose:
+			e.closeSep()
+			e.write("]")
+			return e.popPhase()
+		default:
+			e.entrySep()
+			return false, e.flushValue(tk)
+		}
+	default:
+		panic("unreachable")
+	}
+}
+
+func (e *encoder) pushPhase(p phase) {
+	e.current = p
+	e.stack = append(e.stack, e.current)
+	e.some = false
+}
+
+// Pop a phase from the stack; return 'true' if stack now empty.
+func (e *encoder) popPhase() (bool, error) {
+	n := len(e.stack) - 1
+	if n == 0 {
+		e.wr.Write(e.line)
+		return true, nil
+	}
+	e.current = e.stack[n-1]
+	e.stack = e.stack[0:n]
+	e.some = true
+	return false, nil
+}
+
+// Emit an entry separator (comma), unless we're at the start of an object.
+// Mark that we *do* have some content, regardless, so next time will need a sep.
+func (e *encoder) entrySep() {
+	if e.some {
+		e.write(",")
+	}
+	e.some = true
+	e.wr.Write(e.line)
+	for i := 0; i < len(e.stack); i++ {
+		e.wr.Write(e.indent)
+	}
+}
+
+// Emit the line break and indentation before the end of a non-empty map or array.
+func (e *encoder) closeSep() {
+	if e.some {
+		e.wr.Write(e.line)
+		for i := 1; i < len(e.stack); i++ {
+			e.wr.Write(e.indent)
+		}
+	}
+}
+
+func (e *encoder) flushValue(tk *tok.Token) error {
+	switch tk.Type {
+	case tok.TString:
+		e.emitString(tk.Str)
+	case tok.TBool:
+		if tk.Bool {
+			e.write("true")
+		} else {
+			e.write("false")
+		}
+	case tok.TInt:
+		e.wr.Write(strconv.AppendInt(e.scratch[:0], tk.Int, 10))
+	case tok.TUint:
+		e.wr.Write(strconv.AppendUint(e.scratch[:0], tk.Uint, 10))
+	case tok.TFloat64:
+		b, err := appendFloat(e.scratch[:0], tk.Float64, e.floats)
+		if err != nil {
+			return err
+		}
+		e.wr.Write(b)
+	case tok.TNull:
+		e.write("null")
+	default:
+		return fmt.Errorf("cannot encode %s token as json", tk.Type)
+	}
+	return nil
+}
+
+// appendFloat appends the json form of f, which always has a decimal point or
+// an exponent, so that it's decoded as a float again rather than as an int.
+func appendFloat(b []byte, f float64, format FloatFormat) ([]byte, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, fmt.Errorf("cannot encode %v as json", f)
+	}
+	start := len(b)
+	switch format {
+	case FloatFormatShortest:
+		// Like ECMAScript's Number.prototype.toString (and so like encoding/json):
+		// decimal notation, unless the exponent is very large or small.
+		abs := math.Abs(f)
+		if abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+			b = strconv.AppendFloat(b, f, 'e', -1, 64)
+			// Clean up e-09 to e-9.
+			if n := len(b); n-start >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+				b[n-2] = b[n-1]
+				b = b[:n-1]
+			}
+			return b, nil
+		}
+		b = strconv.AppendFloat(b, f, 'f', -1, 64)
+	case FloatFormatFixed:
+		b = strconv.AppendFloat(b, f, 'f', -1, 64)
+	default:
+		return nil, fmt.Errorf("unknown FloatFormat %d", format)
+	}
+	for _, c := range b[start:] {
+		if c == '.' {
+			return b, nil
+		}
+	}
+	return append(b, ".0"...), nil
+}
+
+func (e *encoder) write(s string) {
+	io.WriteString(e.wr, s)
+}
+
+func (e *encoder) writeByte(b byte) {
+	e.scratch[0] = b
+	e.wr.Write(e.scratch[0:1])
+}
+
+const hex = "0123456789abcdef"
+
+func (e *encoder) emitString(s string) {
+	e.writeByte('"')
+	start := 0
+	for i := 0; i < len(s); {
+		if b := s[i]; b < utf8.RuneSelf {
+			if 0x20 <= b && b != '\\' && b != '"' {
+				i++
+				continue
+			}
+			if start < i {
+				e.write(s[start:i])
+			}
+			switch b {
+			case '\\', '"':
+				e.writeByte('\\')
+				e.writeByte(b)
+			case '\n':
+				e.write(`\n`)
+			case '\r':
+				e.write(`\r`)
+			case '\t':
+				e.write(`\t`)
+			default:
+				// This encodes bytes < 0x20 except for \t, \n and \r.
+				e.write(`\u00`)
+				e.writeByte(hex[b>>4])
+				e.writeByte(hex[b&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		c, size := utf8.DecodeRuneInString(s[i:])
+		if c == utf8.RuneError && size == 1 {
+			if start < i {
+				e.write(s[start:i])
+			}
+			e.write(`\ufffd`)
+			i += size
+			start = i
+			continue
+		}
+		// U+2028 is LINE SEPARATOR, and U+2029 is PARAGRAPH SEPARATOR.
+		// They are valid in JSON strings, but not in JavaScript,
+		// so we escape them as the refmt encoder does.
+		if c == '\u2028' || c == '\u2029' {
+			if start < i {
+				e.write(s[start:i])
+			}
+			e.write(`\u202`)
+			e.writeByte(hex[c&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	if start < len(s) {
+		e.write(s[start:])
+	}
+	e.writeByte('"')
+}
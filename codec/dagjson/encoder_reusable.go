@@ -0,0 +1,36 @@
+package dagjson
+
+import (
+	"io"
+	"sync"
+
+	ipld "github.com/ipld/go-ipld-prime"
+)
+
+// ReusableEncoder serializes nodes to dag-json, like EncodeOptions.Encode does,
+// but keeps its state and buffers from one call to the next.
+// Services which encode large numbers of small nodes can hold on to a
+// ReusableEncoder to avoid allocating all of that anew for every node.
+//
+// A ReusableEncoder must not be used concurrently.
+// The Encode function and EncodeOptions.Encode already reuse ReusableEncoders
+// internally, via a sync.Pool.
+type ReusableEncoder struct {
+	cfg EncodeOptions
+	enc encoder
+}
+
+// NewReusableEncoder returns a ReusableEncoder which encodes according to the options.
+func NewReusableEncoder(cfg EncodeOptions) *ReusableEncoder {
+	return &ReusableEncoder{cfg: cfg}
+}
+
+var encoderPool = sync.Pool{New: func() interface{} { return new(ReusableEncoder) }}
+
+// Encode walks the given ipld.Node and serializes it to the given io.Writer.
+func (e *ReusableEncoder) Encode(n ipld.Node, w io.Writer) error {
+	e.enc.reset(w, e.cfg)
+	err := marshal(n, &e.enc, true, e.cfg)
+	e.enc.wr = nil // don't keep the writer alive
+	return err
+}
@@ -1,20 +1,36 @@
 package dagjson
 
 import (
+	"encoding/base64"
 	"fmt"
 
 	"github.com/polydawn/refmt/shared"
 	"github.com/polydawn/refmt/tok"
 
 	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec"
 	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
 )
 
+// ErrReservedKeyMap is returned when encoding a map whose only key is "/",
+// and when decoding with DecodeOptions.Strict a map which uses the "/" key
+// but isn't a valid link or bytes value,
+// since dag-json reserves that key for links and bytes.
+type ErrReservedKeyMap struct{}
+
+func (ErrReservedKeyMap) Error() string {
+	return `the map key "/" is reserved in dag-json for links and bytes`
+}
+
 // This should be identical to the general feature in the parent package,
 // except for the `case ipld.Kind_Link` block,
 // which is dag-json's special sauce for schemafree links.
 
 func Marshal(n ipld.Node, sink shared.TokenSink, allowLinks bool) error {
+	return marshal(n, sink, allowLinks, EncodeOptions{})
+}
+
+func marshal(n ipld.Node, sink shared.TokenSink, allowLinks bool, cfg EncodeOptions) error {
 	var tk tok.Token
 	switch n.Kind() {
 	case ipld.Kind_Invalid:
@@ -24,6 +40,13 @@ func Marshal(n ipld.Node, sink shared.TokenSink, allowLinks bool) error {
 		_, err := sink.Step(&tk)
 		return err
 	case ipld.Kind_Map:
+		// A map whose only key is "/" would be read back as a link (or be rejected),
+		//  so we refuse to write one, unless told to be lenient.
+		if allowLinks && !cfg.AllowReservedKeyMaps && n.Length() == 1 {
+			if _, err := n.LookupByString("/"); err == nil {
+				return ErrReservedKeyMap{}
+			}
+		}
 		// Emit start of map.
 		tk.Type = tok.TMapOpen
 		tk.Length = int(n.Length()) // TODO: overflow check
@@ -31,6 +54,29 @@ func Marshal(n ipld.Node, sink shared.TokenSink, allowLinks bool) error {
 			return err
 		}
 		// Emit map contents (and recurse).
+		mode := cfg.MapSortMode
+		if cfg.SortKeys && mode == codec.MapSortMode_None {
+			mode = codec.MapSortMode_Lexical
+		}
+		if mode != codec.MapSortMode_None {
+			entries, err := codec.SortedMapEntries(n, mode)
+			if err != nil {
+				return err
+			}
+			for _, e := range entries {
+				tk.Type = tok.TString
+				tk.Str = e.Key
+				if _, err := sink.Step(&tk); err != nil {
+					return err
+				}
+				if err := marshal(e.Value, sink, allowLinks, cfg); err != nil {
+					return err
+				}
+			}
+			tk.Type = tok.TMapClose
+			_, err = sink.Step(&tk)
+			return err
+		}
 		for itr := n.MapIterator(); !itr.Done(); {
 			k, v, err := itr.Next()
 			if err != nil {
@@ -44,7 +90,7 @@ func Marshal(n ipld.Node, sink shared.TokenSink, allowLinks bool) error {
 			if _, err := sink.Step(&tk); err != nil {
 				return err
 			}
-			if err := Marshal(v, sink, allowLinks); err != nil {
+			if err := marshal(v, sink, allowLinks, cfg); err != nil {
 				return err
 			}
 		}
@@ -66,7 +112,7 @@ func Marshal(n ipld.Node, sink shared.TokenSink, allowLinks bool) error {
 			if err != nil {
 				return err
 			}
-			if err := Marshal(v, sink, allowLinks); err != nil {
+			if err := marshal(v, sink, allowLinks, cfg); err != nil {
 				return err
 			}
 		}
@@ -84,6 +130,21 @@ func Marshal(n ipld.Node, sink shared.TokenSink, allowLinks bool) error {
 		_, err = sink.Step(&tk)
 		return err
 	case ipld.Kind_Int:
+		if n2, ok := n.(ipld.BigIntNode); ok {
+			v, err := n2.AsBigInt()
+			if err != nil {
+				return err
+			}
+			if !v.IsInt64() {
+				if !v.IsUint64() {
+					return fmt.Errorf("cannot encode integer %s as json, as it doesn't fit in 64 bits", v)
+				}
+				tk.Type = tok.TUint
+				tk.Uint = v.Uint64()
+				_, err = sink.Step(&tk)
+				return err
+			}
+		}
 		v, err := n.AsInt()
 		if err != nil {
 			return err
@@ -111,14 +172,30 @@ func Marshal(n ipld.Node, sink shared.TokenSink, allowLinks bool) error {
 		_, err = sink.Step(&tk)
 		return err
 	case ipld.Kind_Bytes:
+		// Plain JSON has no representation of bytes,
+		//  and the tokenizer would panic if we gave it a bytes token.
+		if !allowLinks {
+			return fmt.Errorf("cannot Marshal bytes to JSON")
+		}
 		v, err := n.AsBytes()
 		if err != nil {
 			return err
 		}
-		tk.Type = tok.TBytes
-		tk.Bytes = v
-		_, err = sink.Step(&tk)
-		return err
+		// dag-json writes bytes as {"/": {"bytes": "<base64>"}}.
+		for _, t := range [...]tok.Token{
+			{Type: tok.TMapOpen, Length: 1},
+			{Type: tok.TString, Str: "/"},
+			{Type: tok.TMapOpen, Length: 1},
+			{Type: tok.TString, Str: "bytes"},
+			{Type: tok.TString, Str: base64.RawStdEncoding.EncodeToString(v)},
+			{Type: tok.TMapClose},
+			{Type: tok.TMapClose},
+		} {
+			if _, err := sink.Step(&t); err != nil {
+				return err
+			}
+		}
+		return nil
 	case ipld.Kind_Link:
 		if !allowLinks {
 			return fmt.Errorf("cannot Marshal ipld links to JSON")
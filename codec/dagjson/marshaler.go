@@ -0,0 +1,43 @@
+package dagjson
+
+import (
+	"bytes"
+
+	ipld "github.com/ipld/go-ipld-prime"
+)
+
+// Marshaler lets a node go where the standard library expects
+// a json.Marshaler or an encoding.TextMarshaler, by encoding it as dag-json.
+// For example, a struct field of type Marshaler is encoded as its node's dag-json
+// by json.Marshal, and logging libraries which use MarshalText print nodes as dag-json too.
+//
+//	json.Marshal(map[string]interface{}{"data": dagjson.Marshaler{Node: n}})
+//
+// As dag-json is JSON, json.Marshal can include it as it is;
+// links and bytes are in their dag-json form, such as {"/":"bafy..."}.
+type Marshaler struct {
+	// Node is the node to encode; a nil Node is encoded as null,
+	// so that a zero Marshaler in a struct field doesn't fail the whole encoding.
+	Node ipld.Node
+
+	// Options are used to encode the node; the zero value gives compact output.
+	// Note that json.Marshal compacts the output of MarshalJSON anyway.
+	Options EncodeOptions
+}
+
+// MarshalJSON implements json.Marshaler.
+func (m Marshaler) MarshalJSON() ([]byte, error) {
+	if m.Node == nil {
+		return []byte("null"), nil
+	}
+	var buf bytes.Buffer
+	if err := m.Options.Encode(m.Node, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalText implements encoding.TextMarshaler, with the same output as MarshalJSON.
+func (m Marshaler) MarshalText() ([]byte, error) {
+	return m.MarshalJSON()
+}
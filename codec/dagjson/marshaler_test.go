@@ -0,0 +1,54 @@
+package dagjson
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime/codec"
+	"github.com/ipld/go-ipld-prime/fluent"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+)
+
+var (
+	_ json.Marshaler         = Marshaler{}
+	_ encoding.TextMarshaler = Marshaler{}
+)
+
+func TestMarshaler(t *testing.T) {
+	b, err := Marshaler{Node: n}.MarshalJSON()
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, string(b), ShouldEqual, `{"plain":"olde string","map":{"one":1,"two":2},"list":["three","four"],"nested":{"deeper":["things"]}}`)
+
+	b, err = Marshaler{Node: n, Options: EncodeOptions{MapSortMode: codec.MapSortMode_Lexical}}.MarshalText()
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, string(b), ShouldEqual, `{"list":["three","four"],"map":{"one":1,"two":2},"nested":{"deeper":["things"]},"plain":"olde string"}`)
+
+	// Nodes can go inside other values encoded by encoding/json.
+	b, err = json.Marshal(struct {
+		Name string
+		Data Marshaler
+	}{"x", Marshaler{Node: fluent.MustBuildList(basicnode.Prototype.List, 2, func(na fluent.ListAssembler) {
+		na.AssembleValue().AssignBytes([]byte("hi"))
+		na.AssembleValue().AssignFloat(1)
+	})}})
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, string(b), ShouldEqual, `{"Name":"x","Data":[{"/":{"bytes":"aGk"}},1.0]}`)
+
+	// A zero Marshaler, with no node, is null.
+	b, err = Marshaler{}.MarshalText()
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, string(b), ShouldEqual, `null`)
+	b, err = json.Marshal(struct{ Data Marshaler }{})
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, string(b), ShouldEqual, `{"Data":null}`)
+
+	// Encoding errors come through too.
+	_, err = json.Marshal(Marshaler{Node: basicnode.NewFloat(math.NaN())})
+	Wish(t, errors.Unwrap(err), ShouldEqual, fmt.Errorf("cannot encode NaN as json"))
+}
@@ -1,12 +1,12 @@
 package dagjson
 
 import (
-	"fmt"
 	"io"
 
 	"github.com/polydawn/refmt/json"
 
 	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec"
 	"github.com/ipld/go-ipld-prime/multicodec"
 )
 
@@ -18,12 +18,59 @@ var (
 func init() {
 	multicodec.RegisterEncoder(0x0129, Encode)
 	multicodec.RegisterDecoder(0x0129, Decode)
+	multicodec.RegisterCapabilities(0x0129, multicodec.Capabilities{
+		Kinds: ipld.KindSet{
+			ipld.Kind_Map, ipld.Kind_List,
+			ipld.Kind_Null, ipld.Kind_Bool, ipld.Kind_Int, ipld.Kind_Float,
+			ipld.Kind_String, ipld.Kind_Bytes, ipld.Kind_Link,
+		},
+	})
 }
 
 func Decode(na ipld.NodeAssembler, r io.Reader) error {
-	err := Unmarshal(na, json.NewDecoder(r), true)
+	return DecodeOptions{}.Decode(na, r)
+}
+
+// DecodeOptions can be used to customize the behavior of a decoding function.
+// The Decode method on this struct fits the ipld.Decoder function interface.
+type DecodeOptions struct {
+	// If true, maps which use the reserved "/" key, but which aren't a valid
+	// link (such as {"/": 123}, or {"/": "...", "x": 1}), are rejected with
+	// an ErrReservedKeyMap, rather than decoded as plain maps.
+	Strict bool
+
+	// If true, every number decodes as a float, even if it's an integer.
+	// Otherwise, numbers written as integers (without a fraction or exponent)
+	// decode as ints, and all others as floats.
+	AllNumbersAsFloats bool
+
+	// Numbers written as integers which don't fit in an int64, but do fit
+	// in 64 bits (such as 10000000000000000000), decode as big ints if the
+	// assembler implements ipld.BigIntAssembler.
+	// If true, other numbers written as integers which don't fit in an int64
+	// are rejected with ErrIntegerOverflow.
+	// Otherwise, they decode as the closest float, losing precision.
+	// Numbers written with a fraction or exponent, such as 1e19, are always floats.
+	RejectIntegerOverflow bool
+}
+
+// Decode deserializes data from the given io.Reader and feeds it into the
+// given ipld.NodeAssembler, according to the options.
+func (cfg DecodeOptions) Decode(na ipld.NodeAssembler, r io.Reader) error {
+	return cfg.UnmarshalReader(na, r, true)
+}
+
+// UnmarshalReader is like Unmarshal, but reads the json data from r,
+// and also checks that nothing but whitespace follows the json value.
+//
+// Errors are returned as a *codec.DecodeError with the offset at which
+// the error was found, and the path to the value which failed to decode.
+func (cfg DecodeOptions) UnmarshalReader(na ipld.NodeAssembler, r io.Reader, parseLinks bool) error {
+	cr := codec.NewCountingReader(r)
+	num := &numberRecorder{r: cr}
+	err := unmarshal(na, json.NewDecoder(num), num, parseLinks, cfg)
 	if err != nil {
-		return err
+		return codec.DecodeErrorAt(err, cr.Offset())
 	}
 	// Slurp any remaining whitespace.
 	//  (This is relevant if our reader is tee'ing bytes to a hasher, and
@@ -33,28 +80,107 @@ func Decode(na ipld.NodeAssembler, r io.Reader) error {
 	//    option is to error if this reader seems to contain more content.)
 	var buf [1]byte
 	for {
-		_, err := r.Read(buf[:])
+		_, err := cr.Read(buf[:])
 		switch buf[0] {
 		case ' ', 0x0, '\t', '\r', '\n': // continue
 		default:
-			return fmt.Errorf("unexpected content after end of json object")
+			return codec.DecodeErrorAt(codec.ErrTrailingData, cr.Offset()-1)
 		}
 		if err == nil {
 			continue
 		} else if err == io.EOF {
 			return nil
 		} else {
-			return err
+			return codec.DecodeErrorAt(err, cr.Offset())
 		}
 	}
 }
 
+// DecodePrefix decodes a single value from the start of r, like Decode,
+// but stops right after it, and returns the number of bytes it consumed.
+// This allows decoding a stream of concatenated values one at a time,
+// without having to split the stream first.
+//
+// Unlike Decode, any whitespace after the value is left unread.
+// Note that a number at the top level only ends at the following byte,
+// such as a space or newline, so that byte is consumed as well.
+func (cfg DecodeOptions) DecodePrefix(na ipld.NodeAssembler, r io.Reader) (int64, error) {
+	cr := codec.NewCountingReader(r)
+	num := &numberRecorder{r: cr}
+	err := unmarshal(na, json.NewDecoder(num), num, true, cfg)
+	return cr.Offset(), codec.DecodeErrorAt(err, cr.Offset())
+}
+
 func Encode(n ipld.Node, w io.Writer) error {
+	return EncodeOptions{Indent: "\t"}.Encode(n, w)
+}
+
+// EncodeOptions can be used to customize the behavior of an encoding function.
+// The Encode method on this struct fits the ipld.Encoder function interface.
+//
+// The zero value produces compact output, with no whitespace at all,
+// and map entries in their iteration order.
+// Note that the Encode function uses an Indent of a tab.
+type EncodeOptions struct {
+	// If non-empty, each map entry and list element is written on its own line,
+	// indented by this string once per level of nesting,
+	// and the output ends with a newline.
+	Indent string
+
+	// If true, map entries are written sorted bytewise by key,
+	// rather than in their iteration order.
+	// This makes the output independent of the order in which maps were built,
+	// which is useful for comparing and diffing the output of debug tools.
+	//
+	// This is the same as a MapSortMode of MapSortMode_Lexical,
+	// and is ignored if MapSortMode is set.
+	SortKeys bool
+
+	// MapSortMode selects the order in which map entries are written.
+	// The default, MapSortMode_None, writes them in their iteration order,
+	// which is useful to check that data round-trips unchanged.
+	// Use MapSortMode_RFC7049 to write them in the same order as
+	// canonical dag-cbor, for content addressing.
+	MapSortMode codec.MapSortMode
+
+	// If true, maps whose only key is "/" are encoded as they are,
+	// rather than rejected with ErrReservedKeyMap.
+	// This can be useful to round-trip data which was decoded leniently,
+	// but beware that such maps will decode as links (or fail to decode)
+	// when read back.
+	AllowReservedKeyMaps bool
+
+	// FloatFormat selects how floats are written.
+	// The default is FloatFormatShortest.
+	FloatFormat FloatFormat
+}
+
+// FloatFormat selects how an encoder writes floats.
+//
+// All formats write the shortest decimal which decodes to the same float,
+// and always include a decimal point or an exponent, so that the number is
+// decoded as a float again.  NaN and infinities can't be encoded.
+type FloatFormat uint8
+
+const (
+	// FloatFormatShortest writes floats like JavaScript (and encoding/json) do:
+	// in decimal notation (such as 1.5 or 100.0), except for numbers smaller
+	// than 1e-6 or larger than 1e21, which use an exponent (such as 1e-7).
+	FloatFormatShortest FloatFormat = iota
+
+	// FloatFormatFixed always writes floats in decimal notation, without an
+	// exponent, no matter how many digits that takes.
+	FloatFormatFixed
+)
+
+// Encode walks the given ipld.Node and serializes it to the given io.Writer,
+// according to the options.
+func (cfg EncodeOptions) Encode(n ipld.Node, w io.Writer) error {
 	// Shell out directly to generic inspection path.
 	//  (There's not really any fastpaths of note for json.)
-	// Write another function if you need to tune encoding options about whitespace.
-	return Marshal(n, json.NewEncoder(w, json.EncodeOptions{
-		Line:   []byte{'\n'},
-		Indent: []byte{'\t'},
-	}), true)
+	e := encoderPool.Get().(*ReusableEncoder)
+	e.cfg = cfg
+	err := e.Encode(n, w)
+	encoderPool.Put(e)
+	return err
 }
@@ -2,6 +2,7 @@ package dagjson
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"strings"
 	"testing"
@@ -10,6 +11,7 @@ import (
 
 	cid "github.com/ipfs/go-cid"
 	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/fluent"
 	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
 	basicnode "github.com/ipld/go-ipld-prime/node/basic"
 )
@@ -65,3 +67,61 @@ func TestUnmarshalTrickyMapContainingLink(t *testing.T) {
 	Require(t, err, ShouldEqual, nil)
 	Wish(t, n2.Kind(), ShouldEqual, ipld.Kind_Link)
 }
+
+func TestReservedKeyMaps(t *testing.T) {
+	lnk := cidlink.LinkPrototype{cid.Prefix{
+		Version:  1,
+		Codec:    0x71,
+		MhType:   0x13,
+		MhLength: 4,
+	}}.BuildLink([]byte{1, 2, 3, 4})
+
+	t.Run("strict decode rejects malformed links", func(t *testing.T) {
+		for _, s := range []string{
+			`{"/":123}`,
+			`{"/":"` + lnk.String() + `","x":1}`,
+			`{"a":{"/":{"/":"` + lnk.String() + `"}}}`,
+		} {
+			nb := basicnode.Prototype.Any.NewBuilder()
+			err := DecodeOptions{Strict: true}.Decode(nb, strings.NewReader(s))
+			Wish(t, errors.Is(err, ErrReservedKeyMap{}), ShouldEqual, true)
+
+			// The default is lenient, and decodes plain maps.
+			nb = basicnode.Prototype.Any.NewBuilder()
+			err = Decode(nb, strings.NewReader(s))
+			Wish(t, err, ShouldEqual, nil)
+		}
+	})
+	t.Run("strict decode accepts links", func(t *testing.T) {
+		nb := basicnode.Prototype.Any.NewBuilder()
+		err := DecodeOptions{Strict: true}.Decode(nb, strings.NewReader(`{"x":{"/":"`+lnk.String()+`"},"/x":1}`))
+		Require(t, err, ShouldEqual, nil)
+		n, err := nb.Build().LookupByString("x")
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, n.Kind(), ShouldEqual, ipld.Kind_Link)
+	})
+	t.Run("encode rejects single-key maps", func(t *testing.T) {
+		n := fluent.MustBuildMap(basicnode.Prototype.Map, 1, func(na fluent.MapAssembler) {
+			na.AssembleEntry("/").AssignString(lnk.String())
+		})
+		var buf bytes.Buffer
+		err := Encode(n, &buf)
+		Wish(t, errors.Is(err, ErrReservedKeyMap{}), ShouldEqual, true)
+		Wish(t, err.Error(), ShouldEqual, `the map key "/" is reserved in dag-json for links and bytes`)
+
+		buf.Reset()
+		err = EncodeOptions{AllowReservedKeyMaps: true}.Encode(n, &buf)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, buf.String(), ShouldEqual, `{"/":"`+lnk.String()+`"}`)
+	})
+	t.Run("encode allows maps with other keys", func(t *testing.T) {
+		n := fluent.MustBuildMap(basicnode.Prototype.Map, 2, func(na fluent.MapAssembler) {
+			na.AssembleEntry("/").AssignInt(1)
+			na.AssembleEntry("x").AssignInt(2)
+		})
+		var buf bytes.Buffer
+		err := EncodeOptions{}.Encode(n, &buf)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, buf.String(), ShouldEqual, `{"/":1,"x":2}`)
+	})
+}
@@ -2,11 +2,17 @@ package dagjson
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
 	"strings"
 	"testing"
 
 	. "github.com/warpfork/go-wish"
 
+	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec"
 	"github.com/ipld/go-ipld-prime/fluent"
 	basicnode "github.com/ipld/go-ipld-prime/node/basic"
 )
@@ -79,3 +85,271 @@ func TestRoundtripScalar(t *testing.T) {
 		Wish(t, nb.Build(), ShouldEqual, simple)
 	})
 }
+
+func TestEncodeOptions(t *testing.T) {
+	t.Run("compact", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := EncodeOptions{}.Encode(n, &buf)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, buf.String(), ShouldEqual, `{"plain":"olde string","map":{"one":1,"two":2},"list":["three","four"],"nested":{"deeper":["things"]}}`)
+	})
+	t.Run("sorted keys", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := EncodeOptions{SortKeys: true}.Encode(n, &buf)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, buf.String(), ShouldEqual, `{"list":["three","four"],"map":{"one":1,"two":2},"nested":{"deeper":["things"]},"plain":"olde string"}`)
+	})
+	t.Run("length-first sort", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := EncodeOptions{MapSortMode: codec.MapSortMode_RFC7049}.Encode(n, &buf)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, buf.String(), ShouldEqual, `{"map":{"one":1,"two":2},"list":["three","four"],"plain":"olde string","nested":{"deeper":["things"]}}`)
+	})
+	t.Run("custom indent", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := EncodeOptions{Indent: "  ", SortKeys: true}.Encode(n, &buf)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, buf.String(), ShouldEqual, `{
+  "list": [
+    "three",
+    "four"
+  ],
+  "map": {
+    "one": 1,
+    "two": 2
+  },
+  "nested": {
+    "deeper": [
+      "things"
+    ]
+  },
+  "plain": "olde string"
+}
+`)
+	})
+}
+
+func TestNumbers(t *testing.T) {
+	t.Run("encoding floats", func(t *testing.T) {
+		for _, tc := range []struct {
+			f        float64
+			shortest string
+			fixed    string
+		}{
+			{1, `1.0`, `1.0`},
+			{-0.5, `-0.5`, `-0.5`},
+			{123456.789, `123456.789`, `123456.789`},
+			{1e20, `100000000000000000000.0`, `100000000000000000000.0`},
+			{1e21, `1e+21`, `1000000000000000000000.0`},
+			{1e-7, `1e-7`, `0.0000001`},
+			{1.5e-300, `1.5e-300`, `0.` + strings.Repeat("0", 299) + `15`},
+		} {
+			nd := basicnode.NewFloat(tc.f)
+			var buf bytes.Buffer
+			err := EncodeOptions{}.Encode(nd, &buf)
+			Require(t, err, ShouldEqual, nil)
+			Wish(t, buf.String(), ShouldEqual, tc.shortest)
+
+			buf.Reset()
+			err = EncodeOptions{FloatFormat: FloatFormatFixed}.Encode(nd, &buf)
+			Require(t, err, ShouldEqual, nil)
+			Wish(t, buf.String(), ShouldEqual, tc.fixed)
+
+			// Either way, the float survives a round trip.
+			nb := basicnode.Prototype.Any.NewBuilder()
+			err = Decode(nb, &buf)
+			Require(t, err, ShouldEqual, nil)
+			Wish(t, nb.Build(), ShouldEqual, nd)
+		}
+	})
+	t.Run("encoding invalid floats", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := Encode(basicnode.NewFloat(math.Inf(1)), &buf)
+		Wish(t, err, ShouldEqual, fmt.Errorf("cannot encode +Inf as json"))
+	})
+	t.Run("encoding big ints", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := Encode(basicnode.NewBigInt(new(big.Int).SetUint64(math.MaxUint64)), &buf)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, buf.String(), ShouldEqual, "18446744073709551615")
+
+		err = Encode(basicnode.NewBigInt(new(big.Int).Lsh(big.NewInt(-1), 64)), &buf)
+		Wish(t, err, ShouldEqual, fmt.Errorf("cannot encode integer -18446744073709551616 as json, as it doesn't fit in 64 bits"))
+	})
+	t.Run("decoding all numbers as floats", func(t *testing.T) {
+		nb := basicnode.Prototype.Any.NewBuilder()
+		err := DecodeOptions{AllNumbersAsFloats: true}.Decode(nb, strings.NewReader(`[1, 2.5]`))
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, nb.Build(), ShouldEqual, fluent.MustBuildList(basicnode.Prototype.List, 2, func(na fluent.ListAssembler) {
+			na.AssembleValue().AssignFloat(1)
+			na.AssembleValue().AssignFloat(2.5)
+		}))
+
+		nb = basicnode.Prototype.Any.NewBuilder()
+		err = Decode(nb, strings.NewReader(`[1, 2.5]`))
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, nb.Build(), ShouldEqual, fluent.MustBuildList(basicnode.Prototype.List, 2, func(na fluent.ListAssembler) {
+			na.AssembleValue().AssignInt(1)
+			na.AssembleValue().AssignFloat(2.5)
+		}))
+	})
+	t.Run("decoding integer overflow", func(t *testing.T) {
+		// Integers which don't fit in 64 bits can only be floats.
+		for _, s := range []string{`100000000000000000000`, `-10000000000000000000`} {
+			nb := basicnode.Prototype.Any.NewBuilder()
+			err := DecodeOptions{RejectIntegerOverflow: true}.Decode(nb, strings.NewReader(s))
+			ok := errors.As(err, new(ErrIntegerOverflow))
+			Wish(t, ok, ShouldEqual, true)
+
+			nb = basicnode.Prototype.Any.NewBuilder()
+			err = Decode(nb, strings.NewReader(s))
+			Require(t, err, ShouldEqual, nil)
+			Wish(t, nb.Build().Kind(), ShouldEqual, ipld.Kind_Float)
+		}
+		nb := basicnode.Prototype.Any.NewBuilder()
+		err := DecodeOptions{RejectIntegerOverflow: true}.Decode(nb, strings.NewReader(`9223372036854775807`))
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, nb.Build(), ShouldEqual, basicnode.NewInt(math.MaxInt64))
+
+		// Numbers written as floats are never rejected, however large.
+		for _, s := range []string{`1e19`, `1.5e300`, `9.5e18`, `-1e19`} {
+			nb := basicnode.Prototype.Any.NewBuilder()
+			err := DecodeOptions{RejectIntegerOverflow: true}.Decode(nb, strings.NewReader(s))
+			Require(t, err, ShouldEqual, nil)
+			Wish(t, nb.Build().Kind(), ShouldEqual, ipld.Kind_Float)
+		}
+	})
+	t.Run("big ints", func(t *testing.T) {
+		for _, s := range []string{`9223372036854775808`, `18446744073709551615`, `[1,18446744073709551615]`, `{"a":9223372036854775808}`} {
+			for _, opts := range []DecodeOptions{{}, {RejectIntegerOverflow: true}} {
+				nb := basicnode.Prototype.Any.NewBuilder()
+				err := opts.Decode(nb, strings.NewReader(s))
+				Require(t, err, ShouldEqual, nil)
+				var buf bytes.Buffer
+				Require(t, EncodeOptions{}.Encode(nb.Build(), &buf), ShouldEqual, nil)
+				Wish(t, buf.String(), ShouldEqual, s)
+			}
+		}
+		nb := basicnode.Prototype.Any.NewBuilder()
+		Require(t, Decode(nb, strings.NewReader(`18446744073709551615`)), ShouldEqual, nil)
+		v, err := ipld.AsBigInt(nb.Build())
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, v.String(), ShouldEqual, "18446744073709551615")
+
+		// Assemblers which only take an int64 get the closest float, as before.
+		nb = basicnode.Prototype.Float.NewBuilder()
+		Require(t, Decode(nb, strings.NewReader(`18446744073709551615`)), ShouldEqual, nil)
+		Wish(t, nb.Build(), ShouldEqual, basicnode.NewFloat(math.MaxUint64))
+		nb = basicnode.Prototype.Float.NewBuilder()
+		err = DecodeOptions{RejectIntegerOverflow: true}.Decode(nb, strings.NewReader(`18446744073709551615`))
+		Wish(t, errors.As(err, new(ErrIntegerOverflow)), ShouldEqual, true)
+	})
+}
+
+func TestEncoderReuse(t *testing.T) {
+	enc := NewReusableEncoder(EncodeOptions{Indent: "\t"})
+	for i := 0; i < 3; i++ {
+		var buf bytes.Buffer
+		err := enc.Encode(n, &buf)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, buf.String(), ShouldEqual, serial)
+
+		// An error halfway through a map doesn't affect later uses.
+		err = enc.Encode(fluent.MustBuildMap(basicnode.Prototype.Map, 2, func(na fluent.MapAssembler) {
+			na.AssembleEntry("a").AssignInt(1)
+			na.AssembleEntry("b").AssignFloat(math.NaN())
+		}), &buf)
+		Wish(t, err, ShouldEqual, fmt.Errorf("cannot encode NaN as json"))
+	}
+}
+
+func TestBytes(t *testing.T) {
+	nb := fluent.MustBuildMap(basicnode.Prototype.Map, 2, func(na fluent.MapAssembler) {
+		na.AssembleEntry("data").AssignBytes([]byte("hello!!"))
+		na.AssembleEntry("empty").AssignBytes([]byte{})
+	})
+	serial := `{"data":{"/":{"bytes":"aGVsbG8hIQ"}},"empty":{"/":{"bytes":""}}}`
+	t.Run("encoding", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := EncodeOptions{}.Encode(nb, &buf)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, buf.String(), ShouldEqual, serial)
+	})
+	t.Run("decoding", func(t *testing.T) {
+		for _, s := range []string{
+			serial,
+			`{"data":{"/":{"bytes":"aGVsbG8hIQ=="}},"empty":{"/":{"bytes":""}}}`, // padding is accepted
+		} {
+			nb2 := basicnode.Prototype.Any.NewBuilder()
+			err := Decode(nb2, strings.NewReader(s))
+			Require(t, err, ShouldEqual, nil)
+			Wish(t, nb2.Build(), ShouldEqual, nb)
+		}
+	})
+	t.Run("decoding invalid base64", func(t *testing.T) {
+		nb2 := basicnode.Prototype.Any.NewBuilder()
+		err := Decode(nb2, strings.NewReader(`{"/":{"bytes":"!!"}}`))
+		Wish(t, err == nil, ShouldEqual, false)
+	})
+	t.Run("decoding maps which almost look like bytes", func(t *testing.T) {
+		for _, s := range []string{
+			`{"/":{"bytes":1}}`,
+			`{"/":{"bytes":"aGk","x":1}}`,
+			`{"/":{"bytez":"aGk"}}`,
+			`{"/":{"bytes":"aGk"},"x":1}`,
+			`{"/":{}}`,
+		} {
+			nb2 := basicnode.Prototype.Any.NewBuilder()
+			err := Decode(nb2, strings.NewReader(s))
+			Require(t, err, ShouldEqual, nil)
+			Wish(t, nb2.Build().Kind(), ShouldEqual, ipld.Kind_Map)
+
+			nb2 = basicnode.Prototype.Any.NewBuilder()
+			err = DecodeOptions{Strict: true}.Decode(nb2, strings.NewReader(s))
+			Wish(t, errors.Is(err, ErrReservedKeyMap{}), ShouldEqual, true)
+		}
+	})
+	t.Run("nested lookahead", func(t *testing.T) {
+		// The outer map almost looks like bytes, but its value is a link.
+		s := `{"/":{"bytes":{"/":"bafyreiaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"}}}`
+		nb2 := basicnode.Prototype.Any.NewBuilder()
+		err := Decode(nb2, strings.NewReader(s))
+		Require(t, err, ShouldEqual, nil)
+		n, err := nb2.Build().LookupByString("/")
+		Require(t, err, ShouldEqual, nil)
+		n, err = n.LookupByString("bytes")
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, n.Kind(), ShouldEqual, ipld.Kind_Link)
+	})
+}
+
+func TestDecodeErrorContext(t *testing.T) {
+	t.Run("path and offset", func(t *testing.T) {
+		serial := `{"a": [1, 100000000000000000000]}`
+		nb := basicnode.Prototype.Any.NewBuilder()
+		err := DecodeOptions{RejectIntegerOverflow: true}.Decode(nb, strings.NewReader(serial))
+		var de *codec.DecodeError
+		Require(t, errors.As(err, &de), ShouldEqual, true)
+		Wish(t, de.Path.String(), ShouldEqual, "a/1")
+		Wish(t, de.Err, ShouldEqual, ErrIntegerOverflow{1e20})
+		Wish(t, err.Error(), ShouldEqual, "json number 1e+20 is integral, but too large to be an int (at offset 32, under path 'a/1')")
+	})
+	t.Run("trailing content", func(t *testing.T) {
+		nb := basicnode.Prototype.Any.NewBuilder()
+		err := Decode(nb, strings.NewReader("[1]\n x"))
+		Wish(t, err.Error(), ShouldEqual, "unexpected data after the end of the value (at offset 5)")
+	})
+}
+
+func TestDecodePrefix(t *testing.T) {
+	r := strings.NewReader(`{"a":1}[2] "x"`)
+	var lengths []int64
+	for r.Len() > 0 {
+		nb := basicnode.Prototype.Any.NewBuilder()
+		n, err := DecodeOptions{}.DecodePrefix(nb, r)
+		Require(t, err, ShouldEqual, nil)
+		lengths = append(lengths, n)
+	}
+	// The third value includes the whitespace before it.
+	Wish(t, lengths, ShouldEqual, []int64{7, 3, 4})
+}
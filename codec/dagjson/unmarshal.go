@@ -1,16 +1,32 @@
 package dagjson
 
 import (
+	"encoding/base64"
 	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strings"
 
 	cid "github.com/ipfs/go-cid"
 	"github.com/polydawn/refmt/shared"
 	"github.com/polydawn/refmt/tok"
 
 	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec"
 	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
 )
 
+// ErrIntegerOverflow is returned when decoding with RejectIntegerOverflow
+// an integral number which doesn't fit in an int64.
+type ErrIntegerOverflow struct {
+	Value float64 // the closest float to the number
+}
+
+func (e ErrIntegerOverflow) Error() string {
+	return fmt.Sprintf("json number %g is integral, but too large to be an int", e.Value)
+}
+
 // This drifts pretty far from the general unmarshal in the parent package:
 //   - we know JSON never has length hints, so we ignore that field in tokens;
 //   - we know JSON never has tags, so we ignore that field as well;
@@ -19,121 +35,216 @@ import (
 //       several steps of handling maps, because it necessitates peeking several
 //        tokens before deciding what kind of value to create).
 
+// Unmarshal feeds the tokens from tokSrc into the given ipld.NodeAssembler.
+//
+// Errors are returned as a *codec.DecodeError with the path to the value
+// which failed to decode, but not its offset, which is unknown at this level.
 func Unmarshal(na ipld.NodeAssembler, tokSrc shared.TokenSource, parseLinks bool) error {
+	return codec.DecodeErrorAt(unmarshal(na, tokSrc, nil, parseLinks, DecodeOptions{}), -1)
+}
+
+// unmarshal is like Unmarshal, but according to the options.
+// If num isn't nil, it must be recording the input which tokSrc reads,
+// so that numbers can be decoded according to how they were written.
+func unmarshal(na ipld.NodeAssembler, tokSrc shared.TokenSource, num *numberRecorder, parseLinks bool, cfg DecodeOptions) error {
 	var st unmarshalState
 	st.parseLinks = parseLinks
-	done, err := tokSrc.Step(&st.tk[0])
+	st.strict = parseLinks && cfg.Strict
+	st.cfg = cfg
+	st.num = num
+	st.num.reset()
+	done, err := tokSrc.Step(&st.tk)
 	if err != nil {
 		return err
 	}
-	if done && !st.tk[0].Type.IsValue() {
+	st.noteNumber()
+	if done && !st.tk.Type.IsValue() {
 		return fmt.Errorf("unexpected eof")
 	}
 	return st.unmarshal(na, tokSrc)
 }
 
 type unmarshalState struct {
-	tk         [4]tok.Token // mostly, only 0'th is used... but [1:4] are used during lookahead for links.
-	shift      int          // how many times to slide something out of tk[1:4] instead of getting a new token.
+	tk         tok.Token   // the current token.
+	peeked     []tok.Token // tokens which linkLookahead read ahead, and step yields before reading any more.
+	look       [6]tok.Token
 	parseLinks bool
+	strict     bool // reject maps using the reserved "/" key which aren't links.
+	cfg        DecodeOptions
+	num        *numberRecorder
 }
 
-// step leaves a "new" token in tk[0],
-// taking account of an shift left by linkLookahead.
-// It's only necessary to use this when handling maps,
-// since the situations resulting in nonzero shift are otherwise unreachable.
-//
-// At most, 'step' will be shifting buffered tokens for:
-//   - the first map key
-//   - the first map value (which will be a string)
-//   - the second map key
-// and so (fortunately! whew!) we can do this in a fixed amount of memory,
-// since none of those states can reach a recursion.
+// numberRecorder records what a tokenizer reads from r during each step,
+// so that the text of a number token can be recovered.
+// The tokenizer turns integers which don't fit in an int64 into floats,
+// which loses both precision, and whether they were written as integers.
+type numberRecorder struct {
+	r   io.Reader
+	buf []byte
+}
+
+func (nr *numberRecorder) Read(p []byte) (int, error) {
+	n, err := nr.r.Read(p)
+	nr.buf = append(nr.buf, p[:n]...)
+	return n, err
+}
+
+func (nr *numberRecorder) reset() {
+	if nr != nil {
+		nr.buf = nr.buf[:0]
+	}
+}
+
+// number returns the first number in what was read since the last reset,
+// which, after a number token, is that number.
+// Before it, there may be whitespace and separators; after it, the byte
+// which ended it.
+func (nr *numberRecorder) number() string {
+	b := nr.buf
+	for len(b) > 0 && b[0] != '-' && (b[0] < '0' || b[0] > '9') {
+		b = b[1:]
+	}
+	end := 0
+	for end < len(b) && strings.IndexByte("+-.0123456789Ee", b[end]) >= 0 {
+		end++
+	}
+	return string(b[:end])
+}
+
+// noteNumber keeps the text of the current token in its Str field,
+// if it's a float token, so that it stays with the token if it's peeked.
+// The text is empty if it's unknown.
+func (st *unmarshalState) noteNumber() {
+	if st.tk.Type != tok.TFloat64 {
+		return
+	}
+	st.tk.Str = ""
+	if st.num != nil {
+		st.tk.Str = st.num.number()
+	}
+}
+
+// step leaves a "new" token in tk,
+// taking account of any tokens left over by linkLookahead.
 func (st *unmarshalState) step(tokSrc shared.TokenSource) error {
-	switch st.shift {
-	case 0:
-		_, err := tokSrc.Step(&st.tk[0])
+	if len(st.peeked) == 0 {
+		st.num.reset()
+		_, err := tokSrc.Step(&st.tk)
+		st.noteNumber()
 		return err
-	case 1:
-		st.tk[0] = st.tk[1]
-		st.shift--
-		return nil
-	case 2:
-		st.tk[0] = st.tk[1]
-		st.tk[1] = st.tk[2]
-		st.shift--
-		return nil
-	case 3:
-		st.tk[0] = st.tk[1]
-		st.tk[1] = st.tk[2]
-		st.tk[2] = st.tk[3]
-		st.shift--
-		return nil
-	default:
-		panic("unreachable")
 	}
+	st.tk = st.peeked[0]
+	copy(st.peeked, st.peeked[1:])
+	st.peeked = st.peeked[:len(st.peeked)-1]
+	return nil
+}
+
+// unstep makes step yield the given tokens again, before any others.
+func (st *unmarshalState) unstep(toks []tok.Token) {
+	if len(st.peeked) == 0 {
+		st.peeked = append(st.peeked, toks...)
+		return
+	}
+	peeked := make([]tok.Token, 0, len(toks)+len(st.peeked))
+	peeked = append(peeked, toks...)
+	st.peeked = append(peeked, st.peeked...)
 }
 
 // linkLookahead is called after receiving a TMapOpen token;
-// when it returns, we will have either created a link, OR
+// when it returns, we will have either created a link (or bytes), OR
 // it's not a link, and the caller should proceed to start a map
 // and while using st.step to ensure the peeked tokens are handled, OR
 // in case of error, the error should just rise.
 // If the bool return is true, we got a link, and you should not
 // continue to attempt to build a map.
+//
+// The patterns we're looking for are {"/": "<cid>"} for links,
+// and {"/": {"bytes": "<base64>"}} for bytes.
 func (st *unmarshalState) linkLookahead(na ipld.NodeAssembler, tokSrc shared.TokenSource) (bool, error) {
-	// Peek next token.  If it's a "/" string, link is still a possibility
-	_, err := tokSrc.Step(&st.tk[1])
-	if err != nil {
-		return false, err
-	}
-	if st.tk[1].Type != tok.TString {
-		st.shift = 1
-		return false, nil
+	look := st.look[:0]
+	// peek reads the next token, and reports whether it has the given type
+	//  (and string, if str isn't empty).
+	peek := func(typ tok.TokenType, str string) (bool, error) {
+		if err := st.step(tokSrc); err != nil {
+			return false, err
+		}
+		look = append(look, st.tk)
+		return st.tk.Type == typ && (str == "" || st.tk.Str == str), nil
 	}
-	if st.tk[1].Str != "/" {
-		st.shift = 1
+	// If the first key is "/", a link is still a possibility.
+	if ok, err := peek(tok.TString, "/"); err != nil {
+		return false, err
+	} else if !ok {
+		st.unstep(look)
 		return false, nil
 	}
-	// Peek next token.  If it's a string, link is still a possibility.
-	//  We won't try to parse it as a CID until we're sure it's the only thing in the map, though.
-	_, err = tokSrc.Step(&st.tk[2])
-	if err != nil {
+	// A string value may be a link; a map value may be bytes.
+	if err := st.step(tokSrc); err != nil {
 		return false, err
 	}
-	if st.tk[2].Type != tok.TString {
-		st.shift = 2
+	look = append(look, st.tk)
+	var want []tok.Token
+	switch st.tk.Type {
+	case tok.TString:
+		// If the next token is a map close, we've got a link!
+		//  (Otherwise it had better be a string, because another map key is the
+		//   only other valid transition here... but we'll leave that check to the caller.)
+		want = linkTail[:]
+	case tok.TMapOpen:
+		// The inner map must be {"bytes": "<base64>"},
+		//  and be the only thing in the outer map.
+		want = bytesTail[:]
+	default:
+		st.unstep(look)
 		return false, nil
 	}
-	// Peek next token.  If it's map close, we've got a link!
-	//  (Otherwise it had better be a string, because another map key is the
-	//   only other valid transition here... but we'll leave that check to the caller.
-	_, err = tokSrc.Step(&st.tk[3])
-	if err != nil {
-		return false, err
+	for _, w := range want {
+		if ok, err := peek(w.Type, w.Str); err != nil {
+			return false, err
+		} else if !ok {
+			st.unstep(look)
+			return false, nil
+		}
 	}
-	if st.tk[3].Type != tok.TMapClose {
-		st.shift = 3
-		return false, nil
+	if look[1].Type == tok.TString {
+		// Okay, we made it -- this looks like a link.  Parse it.
+		//  If it *doesn't* parse as a CID, we treat this as an error.
+		elCid, err := cid.Decode(look[1].Str)
+		if err != nil {
+			return false, err
+		}
+		return true, na.AssignLink(cidlink.Link{Cid: elCid})
 	}
-	// Okay, we made it -- this looks like a link.  Parse it.
-	//  If it *doesn't* parse as a CID, we treat this as an error.
-	elCid, err := cid.Decode(st.tk[2].Str)
+	b, err := decodeBase64(look[3].Str)
 	if err != nil {
-		return false, err
+		return false, fmt.Errorf("invalid dag-json bytes: %v", err)
 	}
-	if err := na.AssignLink(cidlink.Link{Cid: elCid}); err != nil {
-		return false, err
+	return true, na.AssignBytes(b)
+}
+
+// The tokens which must follow {"/": "<cid>" for a link,
+// and {"/": { for bytes; an empty Str matches any string.
+var (
+	linkTail  = [...]tok.Token{{Type: tok.TMapClose}}
+	bytesTail = [...]tok.Token{
+		{Type: tok.TString, Str: "bytes"},
+		{Type: tok.TString},
+		{Type: tok.TMapClose},
+		{Type: tok.TMapClose},
 	}
-	return true, nil
+)
 
+// decodeBase64 decodes standard base64, as dag-json uses for bytes.
+// The encoder doesn't write padding, but we accept it.
+func decodeBase64(s string) ([]byte, error) {
+	return base64.RawStdEncoding.DecodeString(strings.TrimRight(s, "="))
 }
 
 // starts with the first token already primed.  Necessary to get recursion
 //  to flow right without a peek+unpeek system.
 func (st *unmarshalState) unmarshal(na ipld.NodeAssembler, tokSrc shared.TokenSource) error {
 	// FUTURE: check for schema.TypedNodeBuilder that's going to parse a Link (they can slurp any token kind they want).
-	switch st.tk[0].Type {
+	switch st.tk.Type {
 	case tok.TMapOpen:
 		// dag-json has special needs: we pump a few tokens ahead to look for dag-json's "link" pattern.
 		//  We can't actually call BeginMap until we're sure it's not gonna turn out to be a link.
@@ -153,30 +264,34 @@ func (st *unmarshalState) unmarshal(na ipld.NodeAssembler, tokSrc shared.TokenSo
 			return err
 		}
 		for {
-			err := st.step(tokSrc) // shift next token into slot 0.
+			err := st.step(tokSrc) // move on to the next token.
 			if err != nil {        // return in error if next token unreadable
 				return err
 			}
-			switch st.tk[0].Type {
+			switch st.tk.Type {
 			case tok.TMapClose:
 				return ma.Finish()
 			case tok.TString:
-				// continue
+				// Any valid link was already handled by linkLookahead.
+				if st.strict && st.tk.Str == "/" {
+					return ErrReservedKeyMap{}
+				}
 			default:
-				return fmt.Errorf("unexpected %s token while expecting map key", st.tk[0].Type)
+				return fmt.Errorf("unexpected %s token while expecting map key", st.tk.Type)
 			}
-			mva, err := ma.AssembleEntry(st.tk[0].Str)
+			key := st.tk.Str // the recursion reuses tk.
+			mva, err := ma.AssembleEntry(key)
 			if err != nil { // return in error if the key was rejected
 				return err
 			}
-			// Do another shift so the next token is primed before we recurse.
+			// Do another step so the next token is primed before we recurse.
 			err = st.step(tokSrc)
 			if err != nil { // return in error if next token unreadable
-				return err
+				return codec.DecodeErrorUnder(err, ipld.PathSegmentOfString(key))
 			}
 			err = st.unmarshal(mva, tokSrc)
 			if err != nil { // return in error if some part of the recursion errored
-				return err
+				return codec.DecodeErrorUnder(err, ipld.PathSegmentOfString(key))
 			}
 		}
 	case tok.TMapClose:
@@ -186,18 +301,18 @@ func (st *unmarshalState) unmarshal(na ipld.NodeAssembler, tokSrc shared.TokenSo
 		if err != nil {
 			return err
 		}
-		for {
-			_, err := tokSrc.Step(&st.tk[0])
+		for i := int64(0); ; i++ {
+			err := st.step(tokSrc)
 			if err != nil {
 				return err
 			}
-			switch st.tk[0].Type {
+			switch st.tk.Type {
 			case tok.TArrClose:
 				return la.Finish()
 			default:
 				err := st.unmarshal(la.AssembleValue(), tokSrc)
 				if err != nil { // return in error if some part of the recursion errored
-					return err
+					return codec.DecodeErrorUnder(err, ipld.PathSegmentOfInt(i))
 				}
 			}
 		}
@@ -206,17 +321,49 @@ func (st *unmarshalState) unmarshal(na ipld.NodeAssembler, tokSrc shared.TokenSo
 	case tok.TNull:
 		return na.AssignNull()
 	case tok.TString:
-		return na.AssignString(st.tk[0].Str)
+		return na.AssignString(st.tk.Str)
 	case tok.TBytes:
-		return na.AssignBytes(st.tk[0].Bytes)
+		return na.AssignBytes(st.tk.Bytes)
 	case tok.TBool:
-		return na.AssignBool(st.tk[0].Bool)
+		return na.AssignBool(st.tk.Bool)
 	case tok.TInt:
-		return na.AssignInt(st.tk[0].Int)
+		if st.cfg.AllNumbersAsFloats {
+			return na.AssignFloat(float64(st.tk.Int))
+		}
+		return na.AssignInt(st.tk.Int)
 	case tok.TUint:
-		return na.AssignInt(int64(st.tk[0].Uint)) // FIXME overflow check
+		if st.cfg.AllNumbersAsFloats {
+			return na.AssignFloat(float64(st.tk.Uint))
+		}
+		if st.tk.Uint > math.MaxInt64 {
+			if na2, ok := na.(ipld.BigIntAssembler); ok {
+				return na2.AssignBigInt(new(big.Int).SetUint64(st.tk.Uint))
+			}
+			if st.cfg.RejectIntegerOverflow {
+				return ErrIntegerOverflow{float64(st.tk.Uint)}
+			}
+			return na.AssignFloat(float64(st.tk.Uint))
+		}
+		return na.AssignInt(int64(st.tk.Uint))
 	case tok.TFloat64:
-		return na.AssignFloat(st.tk[0].Float64)
+		// The tokenizer gives us integers which don't fit in an int64 as floats.
+		// noteNumber left the number as written in Str, if we know it,
+		//  so we can tell those apart from numbers written as floats, like 1e19.
+		f := st.tk.Float64
+		if st.cfg.AllNumbersAsFloats || st.tk.Str == "" || strings.ContainsAny(st.tk.Str, ".eE") {
+			return na.AssignFloat(f)
+		}
+		// Integers which fit in 64 bits, signed or unsigned, are kept whole
+		//  if the assembler can take them; the encoder can write them back.
+		if na2, ok := na.(ipld.BigIntAssembler); ok {
+			if v, ok := new(big.Int).SetString(st.tk.Str, 10); ok && v.IsUint64() {
+				return na2.AssignBigInt(v)
+			}
+		}
+		if st.cfg.RejectIntegerOverflow {
+			return ErrIntegerOverflow{f}
+		}
+		return na.AssignFloat(f)
 	default:
 		panic("unreachable")
 	}
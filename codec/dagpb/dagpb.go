@@ -0,0 +1,426 @@
+// Package dagpb implements the DAG-PB codec (multicodec 0x70),
+// the protobuf-based format used by UnixFS, as an ipld.Encoder and ipld.Decoder.
+//
+// DAG-PB nodes are handled through the following data model form,
+// which is the "PBNode" type of the DAG-PB specification:
+//
+//	type PBNode struct {
+//		Links [PBLink]
+//		Data optional Bytes
+//	}
+//
+//	type PBLink struct {
+//		Hash Link
+//		Name optional String
+//		Tsize optional Int
+//	}
+//
+// The Decoder always assembles a map with a "Links" entry (which may be an
+// empty list), followed by a "Data" entry if the block has data.
+// The Encoder accepts any map node of that form; the "Links" entry is
+// required, and links are encoded in the order they're listed.
+// The specification requires links to be sorted by Name, but this codec
+// doesn't sort them; that's the responsibility of whoever builds the node.
+package dagpb
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	cid "github.com/ipfs/go-cid"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	"github.com/ipld/go-ipld-prime/multicodec"
+)
+
+// TODO(mvdan): make go-ipld-prime use go-multicodec soon
+const dagpbMulticodec = 0x70
+
+var (
+	_ ipld.Decoder = Decode
+	_ ipld.Encoder = Encode
+)
+
+func init() {
+	multicodec.RegisterEncoder(dagpbMulticodec, Encode)
+	multicodec.RegisterDecoder(dagpbMulticodec, Decode)
+	multicodec.RegisterCapabilities(dagpbMulticodec, multicodec.Capabilities{
+		Kinds: ipld.KindSet{
+			ipld.Kind_Map, ipld.Kind_List,
+			ipld.Kind_Int, ipld.Kind_String, ipld.Kind_Bytes, ipld.Kind_Link,
+		},
+		Restricted: true,
+	})
+}
+
+// Protobuf field numbers and wire types, as per the DAG-PB specification.
+const (
+	pbNodeData  = 1
+	pbNodeLinks = 2
+
+	pbLinkHash  = 1
+	pbLinkName  = 2
+	pbLinkTsize = 3
+
+	wireVarint = 0
+	wireBytes  = 2
+)
+
+// Decode deserializes DAG-PB data from the given io.Reader
+// and feeds it into the given ipld.NodeAssembler.
+func Decode(na ipld.NodeAssembler, r io.Reader) error {
+	return DecodeOptions{}.Decode(na, r)
+}
+
+// DecodeOptions can be used to customize the behavior of a decoding function.
+// The Decode method on this struct fits the ipld.Decoder function interface.
+type DecodeOptions struct {
+	// If true, and the reader has a Bytes method (as *bytes.Buffer does),
+	// the decoded Data bytes are a view into those bytes rather than a copy.
+	// This avoids doubling the memory needed to decode large blocks,
+	// such as UnixFS file chunks.
+	// The buffer's bytes are handed over just as with the raw codec's Decode.
+	OwnershipTransfer bool
+}
+
+// Decode deserializes DAG-PB data from the given io.Reader and feeds it into
+// the given ipld.NodeAssembler, according to the options.
+func (cfg DecodeOptions) Decode(na ipld.NodeAssembler, r io.Reader) error {
+	var data []byte
+	if buf, ok := r.(interface{ Bytes() []byte }); ok && cfg.OwnershipTransfer {
+		data = buf.Bytes()
+	} else {
+		var err error
+		data, err = ioutil.ReadAll(r)
+		if err != nil {
+			return fmt.Errorf("could not decode dag-pb node: %v", err)
+		}
+	}
+
+	// Protobuf messages are flat, so we can find all the fields before
+	// assembling anything; that lets us give BeginMap an accurate size.
+	var links [][]byte
+	var pbData []byte
+	hasData := false
+	for len(data) > 0 {
+		field, value, rest, err := readBytesField(data)
+		if err != nil {
+			return err
+		}
+		data = rest
+		switch field {
+		case pbNodeLinks:
+			if hasData {
+				return fmt.Errorf("invalid dag-pb node: Links must come before Data")
+			}
+			links = append(links, value)
+		case pbNodeData:
+			if hasData {
+				return fmt.Errorf("invalid dag-pb node: duplicate Data field")
+			}
+			pbData = value
+			hasData = true
+		default:
+			return fmt.Errorf("invalid dag-pb node: unexpected field %d", field)
+		}
+	}
+
+	size := int64(1)
+	if hasData {
+		size = 2
+	}
+	ma, err := na.BeginMap(size)
+	if err != nil {
+		return err
+	}
+	va, err := ma.AssembleEntry("Links")
+	if err != nil {
+		return err
+	}
+	la, err := va.BeginList(int64(len(links)))
+	if err != nil {
+		return err
+	}
+	for _, link := range links {
+		if err := decodeLink(la.AssembleValue(), link); err != nil {
+			return err
+		}
+	}
+	if err := la.Finish(); err != nil {
+		return err
+	}
+	if hasData {
+		va, err := ma.AssembleEntry("Data")
+		if err != nil {
+			return err
+		}
+		if err := va.AssignBytes(pbData); err != nil {
+			return err
+		}
+	}
+	return ma.Finish()
+}
+
+func decodeLink(na ipld.NodeAssembler, data []byte) error {
+	var hash cid.Cid
+	var name string
+	var tsize uint64
+	hasHash, hasName, hasTsize := false, false, false
+	for len(data) > 0 {
+		field, wireType, rest, err := readTag(data)
+		if err != nil {
+			return err
+		}
+		data = rest
+		switch {
+		case field == pbLinkHash && wireType == wireBytes && !hasName && !hasTsize && !hasHash:
+			var value []byte
+			if value, data, err = readBytes(data); err != nil {
+				return err
+			}
+			if hash, err = cid.Cast(value); err != nil {
+				return fmt.Errorf("invalid dag-pb link: bad Hash: %v", err)
+			}
+			hasHash = true
+		case field == pbLinkName && wireType == wireBytes && !hasTsize && !hasName:
+			var value []byte
+			if value, data, err = readBytes(data); err != nil {
+				return err
+			}
+			name = string(value)
+			hasName = true
+		case field == pbLinkTsize && wireType == wireVarint && !hasTsize:
+			if tsize, data, err = readVarint(data); err != nil {
+				return err
+			}
+			if tsize > 1<<63-1 {
+				return fmt.Errorf("invalid dag-pb link: Tsize %d is too large", tsize)
+			}
+			hasTsize = true
+		default:
+			return fmt.Errorf("invalid dag-pb link: unexpected, duplicate, or out of order field %d", field)
+		}
+	}
+	if !hasHash {
+		return fmt.Errorf("invalid dag-pb link: missing Hash")
+	}
+
+	size := int64(1)
+	if hasName {
+		size++
+	}
+	if hasTsize {
+		size++
+	}
+	ma, err := na.BeginMap(size)
+	if err != nil {
+		return err
+	}
+	va, err := ma.AssembleEntry("Hash")
+	if err != nil {
+		return err
+	}
+	if err := va.AssignLink(cidlink.Link{Cid: hash}); err != nil {
+		return err
+	}
+	if hasName {
+		va, err := ma.AssembleEntry("Name")
+		if err != nil {
+			return err
+		}
+		if err := va.AssignString(name); err != nil {
+			return err
+		}
+	}
+	if hasTsize {
+		va, err := ma.AssembleEntry("Tsize")
+		if err != nil {
+			return err
+		}
+		if err := va.AssignInt(int64(tsize)); err != nil {
+			return err
+		}
+	}
+	return ma.Finish()
+}
+
+// readBytesField reads a length-delimited field, which is the only wire type
+// used by the fields of a PBNode.
+func readBytesField(data []byte) (field int, value, rest []byte, err error) {
+	field, wireType, data, err := readTag(data)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	if wireType != wireBytes {
+		return 0, nil, nil, fmt.Errorf("invalid dag-pb node: unexpected wire type %d for field %d", wireType, field)
+	}
+	value, rest, err = readBytes(data)
+	return field, value, rest, err
+}
+
+func readTag(data []byte) (field, wireType int, rest []byte, err error) {
+	tag, rest, err := readVarint(data)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	return int(tag >> 3), int(tag & 7), rest, nil
+}
+
+func readBytes(data []byte) (value, rest []byte, err error) {
+	length, data, err := readVarint(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if length > uint64(len(data)) {
+		return nil, nil, fmt.Errorf("invalid dag-pb data: length %d exceeds remaining %d bytes", length, len(data))
+	}
+	return data[:length], data[length:], nil
+}
+
+func readVarint(data []byte) (v uint64, rest []byte, err error) {
+	v, n := binary.Uvarint(data)
+	if n <= 0 {
+		return 0, nil, fmt.Errorf("invalid dag-pb data: bad varint")
+	}
+	return v, data[n:], nil
+}
+
+// Encode walks the given ipld.Node, which must be of the PBNode form,
+// and serializes it to DAG-PB on the given io.Writer.
+func Encode(n ipld.Node, w io.Writer) error {
+	if err := checkFields(n, "node", "Links", "Data"); err != nil {
+		return err
+	}
+	links, err := n.LookupByString("Links")
+	if err != nil {
+		return fmt.Errorf("cannot encode dag-pb node: %v", err)
+	}
+	if links.Kind() != ipld.Kind_List {
+		return fmt.Errorf("cannot encode dag-pb node: Links must be a list, got %s", links.Kind())
+	}
+	var buf []byte
+	for itr := links.ListIterator(); !itr.Done(); {
+		_, link, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		linkBuf, err := encodeLink(link)
+		if err != nil {
+			return err
+		}
+		buf = appendBytesField(buf, pbNodeLinks, linkBuf)
+	}
+	if data, err := lookupOptional(n, "Data"); err != nil {
+		return err
+	} else if data != nil {
+		b, err := data.AsBytes()
+		if err != nil {
+			return fmt.Errorf("cannot encode dag-pb node: Data must be bytes: %v", err)
+		}
+		buf = appendBytesField(buf, pbNodeData, b)
+	}
+	_, err = w.Write(buf)
+	return err
+}
+
+func encodeLink(n ipld.Node) ([]byte, error) {
+	if err := checkFields(n, "link", "Hash", "Name", "Tsize"); err != nil {
+		return nil, err
+	}
+	hashNode, err := n.LookupByString("Hash")
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode dag-pb link: %v", err)
+	}
+	lnk, err := hashNode.AsLink()
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode dag-pb link: Hash must be a link: %v", err)
+	}
+	cl, ok := lnk.(cidlink.Link)
+	if !ok {
+		return nil, fmt.Errorf("cannot encode dag-pb link: Hash must be a CID link")
+	}
+	buf := appendBytesField(nil, pbLinkHash, cl.Bytes())
+	if nameNode, err := lookupOptional(n, "Name"); err != nil {
+		return nil, err
+	} else if nameNode != nil {
+		name, err := nameNode.AsString()
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode dag-pb link: Name must be a string: %v", err)
+		}
+		buf = appendBytesField(buf, pbLinkName, []byte(name))
+	}
+	if tsizeNode, err := lookupOptional(n, "Tsize"); err != nil {
+		return nil, err
+	} else if tsizeNode != nil {
+		tsize, err := tsizeNode.AsInt()
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode dag-pb link: Tsize must be an int: %v", err)
+		}
+		if tsize < 0 {
+			return nil, fmt.Errorf("cannot encode dag-pb link: Tsize must not be negative")
+		}
+		buf = appendUvarint(buf, pbLinkTsize<<3|wireVarint)
+		buf = appendUvarint(buf, uint64(tsize))
+	}
+	return buf, nil
+}
+
+// checkFields returns an error if the map n has any keys other than the
+// fields of a PBNode or PBLink, so that no data is silently dropped.
+func checkFields(n ipld.Node, what string, fields ...string) error {
+	if n.Kind() != ipld.Kind_Map {
+		return fmt.Errorf("cannot encode dag-pb %s: must be a map, got %s", what, n.Kind())
+	}
+	for itr := n.MapIterator(); !itr.Done(); {
+		k, _, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		ks, err := k.AsString()
+		if err != nil {
+			return err
+		}
+		known := false
+		for _, f := range fields {
+			if ks == f {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("cannot encode dag-pb %s: unexpected field %q", what, ks)
+		}
+	}
+	return nil
+}
+
+// lookupOptional returns the value for an optional field,
+// or nil if it's absent or null.
+func lookupOptional(n ipld.Node, key string) (ipld.Node, error) {
+	v, err := n.LookupByString(key)
+	if err != nil {
+		if _, ok := err.(ipld.ErrNotExists); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if v.IsAbsent() || v.IsNull() {
+		return nil, nil
+	}
+	return v, nil
+}
+
+func appendBytesField(buf []byte, field int, value []byte) []byte {
+	buf = appendUvarint(buf, uint64(field)<<3|wireBytes)
+	buf = appendUvarint(buf, uint64(len(value)))
+	return append(buf, value...)
+}
+
+func appendUvarint(buf []byte, v uint64) []byte {
+	var scratch [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(scratch[:], v)
+	return append(buf, scratch[:n]...)
+}
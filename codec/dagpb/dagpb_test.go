@@ -0,0 +1,157 @@
+package dagpb
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	cid "github.com/ipfs/go-cid"
+	. "github.com/warpfork/go-wish"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/fluent"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+)
+
+func mustLink(t *testing.T, s string) ipld.Link {
+	c, err := cid.Decode(s)
+	Require(t, err, ShouldEqual, nil)
+	return cidlink.Link{Cid: c}
+}
+
+func roundtrip(t *testing.T, n ipld.Node, serial string) {
+	t.Helper()
+	var buf bytes.Buffer
+	err := Encode(n, &buf)
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, buf.String(), ShouldEqual, serial)
+
+	nb := basicnode.Prototype.Any.NewBuilder()
+	err = Decode(nb, strings.NewReader(serial))
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, nb.Build(), ShouldEqual, n)
+}
+
+func TestRoundtrip(t *testing.T) {
+	t.Run("empty node", func(t *testing.T) {
+		n := fluent.MustBuildMap(basicnode.Prototype.Map, 1, func(na fluent.MapAssembler) {
+			na.AssembleEntry("Links").CreateList(0, func(na fluent.ListAssembler) {})
+		})
+		roundtrip(t, n, "")
+	})
+	t.Run("data only", func(t *testing.T) {
+		n := fluent.MustBuildMap(basicnode.Prototype.Map, 2, func(na fluent.MapAssembler) {
+			na.AssembleEntry("Links").CreateList(0, func(na fluent.ListAssembler) {})
+			na.AssembleEntry("Data").AssignBytes([]byte("hi"))
+		})
+		roundtrip(t, n, "\x0a\x02hi")
+	})
+	t.Run("links and data", func(t *testing.T) {
+		lnk := mustLink(t, "QmWDtUQj38YLW8v3q4A6LwPn4vYKEbuKWpgSm6bjKW6Xfe")
+		hash := lnk.(cidlink.Link).Bytes()
+		n := fluent.MustBuildMap(basicnode.Prototype.Map, 2, func(na fluent.MapAssembler) {
+			na.AssembleEntry("Links").CreateList(2, func(na fluent.ListAssembler) {
+				na.AssembleValue().CreateMap(3, func(na fluent.MapAssembler) {
+					na.AssembleEntry("Hash").AssignLink(lnk)
+					na.AssembleEntry("Name").AssignString("a")
+					na.AssembleEntry("Tsize").AssignInt(300)
+				})
+				na.AssembleValue().CreateMap(1, func(na fluent.MapAssembler) {
+					na.AssembleEntry("Hash").AssignLink(lnk)
+				})
+			})
+			na.AssembleEntry("Data").AssignBytes([]byte{8, 1})
+		})
+		roundtrip(t, n, ""+
+			"\x12\x2a"+"\x0a\x22"+string(hash)+"\x12\x01a"+"\x18\xac\x02"+
+			"\x12\x24"+"\x0a\x22"+string(hash)+
+			"\x0a\x02\x08\x01")
+	})
+}
+
+func TestDecodeErrors(t *testing.T) {
+	for _, tcase := range []struct {
+		name   string
+		serial string
+		err    string
+	}{
+		{"data before links", "\x0a\x00\x12\x00", "invalid dag-pb node: Links must come before Data"},
+		{"duplicate data", "\x0a\x00\x0a\x00", "invalid dag-pb node: duplicate Data field"},
+		{"unknown field", "\x1a\x00", "invalid dag-pb node: unexpected field 3"},
+		{"truncated", "\x0a\x05hi", "invalid dag-pb data: length 5 exceeds remaining 2 bytes"},
+		{"link without hash", "\x12\x03\x12\x01a", "invalid dag-pb link: missing Hash"},
+	} {
+		t.Run(tcase.name, func(t *testing.T) {
+			nb := basicnode.Prototype.Any.NewBuilder()
+			err := Decode(nb, strings.NewReader(tcase.serial))
+			Require(t, err != nil, ShouldEqual, true)
+			Wish(t, err.Error(), ShouldEqual, tcase.err)
+		})
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	t.Run("missing links", func(t *testing.T) {
+		n := fluent.MustBuildMap(basicnode.Prototype.Map, 1, func(na fluent.MapAssembler) {
+			na.AssembleEntry("Data").AssignBytes([]byte("hi"))
+		})
+		err := Encode(n, &bytes.Buffer{})
+		Require(t, err != nil, ShouldEqual, true)
+	})
+	t.Run("link without hash", func(t *testing.T) {
+		n := fluent.MustBuildMap(basicnode.Prototype.Map, 1, func(na fluent.MapAssembler) {
+			na.AssembleEntry("Links").CreateList(1, func(na fluent.ListAssembler) {
+				na.AssembleValue().CreateMap(1, func(na fluent.MapAssembler) {
+					na.AssembleEntry("Name").AssignString("a")
+				})
+			})
+		})
+		err := Encode(n, &bytes.Buffer{})
+		Require(t, err != nil, ShouldEqual, true)
+	})
+	t.Run("unknown node field", func(t *testing.T) {
+		n := fluent.MustBuildMap(basicnode.Prototype.Map, 2, func(na fluent.MapAssembler) {
+			na.AssembleEntry("Links").CreateList(0, func(na fluent.ListAssembler) {})
+			na.AssembleEntry("data").AssignBytes([]byte("hi"))
+		})
+		err := Encode(n, &bytes.Buffer{})
+		Wish(t, err, ShouldEqual, fmt.Errorf(`cannot encode dag-pb node: unexpected field "data"`))
+	})
+	t.Run("unknown link field", func(t *testing.T) {
+		n := fluent.MustBuildMap(basicnode.Prototype.Map, 1, func(na fluent.MapAssembler) {
+			na.AssembleEntry("Links").CreateList(1, func(na fluent.ListAssembler) {
+				na.AssembleValue().CreateMap(2, func(na fluent.MapAssembler) {
+					na.AssembleEntry("Hash").AssignLink(mustLink(t, "QmWDtUQj38YLW8v3q4A6LwPn4vYKEbuKWpgSm6bjKW6Xfe"))
+					na.AssembleEntry("Size").AssignInt(3)
+				})
+			})
+		})
+		err := Encode(n, &bytes.Buffer{})
+		Wish(t, err, ShouldEqual, fmt.Errorf(`cannot encode dag-pb link: unexpected field "Size"`))
+	})
+}
+
+func TestDecodeOwnershipTransfer(t *testing.T) {
+	serial := []byte("\x0a\x05hello")
+	decodeData := func(cfg DecodeOptions) []byte {
+		nb := basicnode.Prototype.Any.NewBuilder()
+		err := cfg.Decode(nb, bytes.NewBuffer(serial))
+		Require(t, err, ShouldEqual, nil)
+		n, err := nb.Build().LookupByString("Data")
+		Require(t, err, ShouldEqual, nil)
+		data, err := n.AsBytes()
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, string(data), ShouldEqual, "hello")
+		return data
+	}
+
+	// With ownership transfer, the data is a view into the input.
+	data := decodeData(DecodeOptions{OwnershipTransfer: true})
+	Wish(t, &data[0] == &serial[2], ShouldEqual, true)
+
+	// Otherwise, it's a copy.
+	data = decodeData(DecodeOptions{})
+	Wish(t, &data[0] == &serial[2], ShouldEqual, false)
+}
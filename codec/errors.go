@@ -0,0 +1,137 @@
+package codec
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ipld/go-ipld-prime"
+)
+
+// ErrTrailingData is returned by decoders which require that the value
+// takes up the entire input, when there is more data after it.
+var ErrTrailingData = errors.New("unexpected data after the end of the value")
+
+// DecodeError is returned by decoders when decoding fails,
+// and describes where in the data the error was found.
+//
+// Use errors.Is or errors.As to check for the underlying error,
+// such as a codec's limit or budget errors;
+// comparing the returned error with == doesn't match, as it's wrapped:
+//
+//	if errors.Is(err, dagcbor.ErrAllocationBudgetExceeded) { ... }
+type DecodeError struct {
+	Offset int64     // the number of bytes consumed from the input when the error was found; -1 if unknown.
+	Path   ipld.Path // the path to the value being decoded when the error was found.
+	Err    error     // the underlying error.
+}
+
+func (e *DecodeError) Error() string {
+	var where []string
+	if e.Offset >= 0 {
+		where = append(where, fmt.Sprintf("at offset %d", e.Offset))
+	}
+	if len(e.Path.Segments()) > 0 {
+		where = append(where, fmt.Sprintf("under path '%s'", e.Path))
+	}
+	if len(where) == 0 {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%v (%s)", e.Err, strings.Join(where, ", "))
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeErrorUnder returns err as a *DecodeError, with the given path segment
+// prepended to its path.
+// Decoders can call this while unwinding from an error in a child value,
+// so that the path is only built if an error actually happens.
+func DecodeErrorUnder(err error, ps ipld.PathSegment) error {
+	de := asDecodeError(err)
+	de.Path = ipld.NewPath([]ipld.PathSegment{ps}).Join(de.Path)
+	return de
+}
+
+// DecodeErrorAt returns err as a *DecodeError, with the given offset;
+// or nil if err is nil.
+func DecodeErrorAt(err error, offset int64) error {
+	if err == nil {
+		return nil
+	}
+	de := asDecodeError(err)
+	de.Offset = offset
+	return de
+}
+
+func asDecodeError(err error) *DecodeError {
+	if de, ok := err.(*DecodeError); ok {
+		return de
+	}
+	return &DecodeError{Offset: -1, Err: err}
+}
+
+// CountingReader wraps an io.Reader, and counts the bytes read from it,
+// so that decoders can report the offset of an error with DecodeErrorAt.
+//
+// It implements io.ByteScanner, so that tokenizers which need it don't have
+// to wrap it again (and read ahead of what has been counted).
+type CountingReader struct {
+	r        io.Reader
+	n        int64
+	last     [1]byte
+	unread   bool // whether last should be returned by the next read
+	readLast bool // whether last holds the last byte read, so it can be unread
+}
+
+// NewCountingReader returns a CountingReader which reads from r.
+func NewCountingReader(r io.Reader) *CountingReader {
+	return &CountingReader{r: r}
+}
+
+// Offset returns the number of bytes read so far, minus any which were unread.
+func (r *CountingReader) Offset() int64 {
+	return r.n
+}
+
+func (r *CountingReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if r.unread {
+		r.unread = false
+		p[0] = r.last[0]
+		r.n++
+		return 1, nil
+	}
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	if n > 0 {
+		r.last[0] = p[n-1]
+		r.readLast = true
+	}
+	return n, err
+}
+
+func (r *CountingReader) ReadByte() (byte, error) {
+	for {
+		n, err := r.Read(r.last[:])
+		if n == 1 {
+			return r.last[0], nil
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+func (r *CountingReader) UnreadByte() error {
+	if r.unread || !r.readLast {
+		return fmt.Errorf("cannot unread byte")
+	}
+	r.unread = true
+	r.n--
+	return nil
+}
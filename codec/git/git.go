@@ -0,0 +1,496 @@
+// Package git implements a codec for raw git objects (multicodec 0x78, "git-raw"),
+// so that git repositories addressed by CIDs can be loaded, traversed,
+// and walked with selectors like any other IPLD data.
+//
+// The serial form is the uncompressed git object, including its
+// "<type> <size>\x00" header, which is what git hashes to produce object IDs.
+// Links between objects are CIDs with the git-raw codec and a SHA-1 multihash.
+//
+// Objects map onto the data model as follows:
+//
+//   - a blob is a bytes node;
+//   - a tree is a map from entry names to maps with a "mode" string
+//     (e.g. "100644" or "40000") and a "hash" link;
+//   - a commit is a map with the entries "tree" (a link), "parents" (a list of links),
+//     "author" and "committer" (strings such as "Name <email> 1600000000 +0000"),
+//     "extra" (a list of maps with "key" and "value" strings, for any further
+//     headers such as "gpgsig", in order), and "message" (a string);
+//   - a tag is a map with the entries "object" (a link), "type" and "tag" (strings),
+//     optionally "tagger" (a string), "extra" (as for commits), and "message" (a string).
+//
+// Commits and tags are distinguished by their "tree" and "object" entries,
+// respectively, which are links; any other map is encoded as a tree.
+// Since the values in a tree are always maps, trees with entries named
+// "tree" or "object" are encoded as trees too.
+//
+// Multi-line header values (such as signatures) are represented with their
+// lines joined by "\n"; the leading space of git's continuation lines is removed.
+// The encoder emits tree entries in the map's iteration order;
+// git requires them to be sorted, which is the responsibility of whoever
+// builds the node.
+package git
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	cid "github.com/ipfs/go-cid"
+	mh "github.com/multiformats/go-multihash"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/fluent"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	"github.com/ipld/go-ipld-prime/multicodec"
+)
+
+// TODO(mvdan): make go-ipld-prime use go-multicodec soon
+const gitRawMulticodec = 0x78
+
+var (
+	_ ipld.Decoder = Decode
+	_ ipld.Encoder = Encode
+)
+
+func init() {
+	multicodec.RegisterEncoder(gitRawMulticodec, Encode)
+	multicodec.RegisterDecoder(gitRawMulticodec, Decode)
+	multicodec.RegisterCapabilities(gitRawMulticodec, multicodec.Capabilities{
+		Kinds: ipld.KindSet{
+			ipld.Kind_Map, ipld.Kind_List,
+			ipld.Kind_String, ipld.Kind_Bytes, ipld.Kind_Link,
+		},
+		Restricted: true,
+	})
+}
+
+// Decode deserializes a raw git object from the given io.Reader
+// and feeds it into the given ipld.NodeAssembler.
+func Decode(na ipld.NodeAssembler, r io.Reader) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("could not decode git object: %v", err)
+	}
+	nul := bytes.IndexByte(data, 0)
+	if nul < 0 {
+		return fmt.Errorf("invalid git object: missing header")
+	}
+	header, body := string(data[:nul]), data[nul+1:]
+	space := strings.IndexByte(header, ' ')
+	if space < 0 {
+		return fmt.Errorf("invalid git object: malformed header %q", header)
+	}
+	kind, sizeStr := header[:space], header[space+1:]
+	size, err := strconv.Atoi(sizeStr)
+	if err != nil || size != len(body) {
+		return fmt.Errorf("invalid git object: header declares size %q, but body is %d bytes", sizeStr, len(body))
+	}
+	switch kind {
+	case "blob":
+		return na.AssignBytes(body)
+	case "tree":
+		return decodeTree(na, body)
+	case "commit":
+		return decodeCommit(na, body)
+	case "tag":
+		return decodeTag(na, body)
+	default:
+		return fmt.Errorf("invalid git object: unknown type %q", kind)
+	}
+}
+
+func decodeTree(na ipld.NodeAssembler, body []byte) error {
+	type entry struct {
+		mode, name string
+		hash       ipld.Link
+	}
+	var entries []entry
+	for len(body) > 0 {
+		space := bytes.IndexByte(body, ' ')
+		if space < 0 {
+			return fmt.Errorf("invalid git tree: malformed entry")
+		}
+		nul := bytes.IndexByte(body, 0)
+		if nul < space || len(body) < nul+1+20 {
+			return fmt.Errorf("invalid git tree: malformed entry")
+		}
+		lnk, err := linkFromSHA1(body[nul+1 : nul+1+20])
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry{string(body[:space]), string(body[space+1 : nul]), lnk})
+		body = body[nul+1+20:]
+	}
+	return fluent.Recover(func() {
+		fluent.WrapAssembler(na).CreateMap(int64(len(entries)), func(fma fluent.MapAssembler) {
+			for _, e := range entries {
+				fma.AssembleEntry(e.name).CreateMap(2, func(fma fluent.MapAssembler) {
+					fma.AssembleEntry("mode").AssignString(e.mode)
+					fma.AssembleEntry("hash").AssignLink(e.hash)
+				})
+			}
+		})
+	})
+}
+
+type header struct {
+	key, value string
+}
+
+// parseHeaders splits a commit or tag into its headers and message.
+func parseHeaders(body []byte) ([]header, string, error) {
+	var headers []header
+	text := string(body)
+	for {
+		nl := strings.IndexByte(text, '\n')
+		if nl < 0 {
+			return nil, "", fmt.Errorf("invalid git object: headers must be followed by an empty line")
+		}
+		line := text[:nl]
+		text = text[nl+1:]
+		if line == "" {
+			return headers, text, nil
+		}
+		if line[0] == ' ' {
+			if len(headers) == 0 {
+				return nil, "", fmt.Errorf("invalid git object: continuation line before any header")
+			}
+			headers[len(headers)-1].value += "\n" + line[1:]
+			continue
+		}
+		space := strings.IndexByte(line, ' ')
+		if space < 0 {
+			return nil, "", fmt.Errorf("invalid git object: malformed header line %q", line)
+		}
+		headers = append(headers, header{line[:space], line[space+1:]})
+	}
+}
+
+// takeHeader returns the value of the first remaining header if it has the
+// given key, and the rest of the headers.
+func takeHeader(headers []header, key string) (string, []header, bool) {
+	if len(headers) == 0 || headers[0].key != key {
+		return "", headers, false
+	}
+	return headers[0].value, headers[1:], true
+}
+
+func decodeCommit(na ipld.NodeAssembler, body []byte) error {
+	headers, message, err := parseHeaders(body)
+	if err != nil {
+		return err
+	}
+	treeHex, headers, ok := takeHeader(headers, "tree")
+	if !ok {
+		return fmt.Errorf("invalid git commit: tree must be the first header")
+	}
+	tree, err := linkFromHex(treeHex)
+	if err != nil {
+		return err
+	}
+	var parents []ipld.Link
+	for {
+		parentHex, rest, ok := takeHeader(headers, "parent")
+		if !ok {
+			break
+		}
+		headers = rest
+		parent, err := linkFromHex(parentHex)
+		if err != nil {
+			return err
+		}
+		parents = append(parents, parent)
+	}
+	author, headers, ok := takeHeader(headers, "author")
+	if !ok {
+		return fmt.Errorf("invalid git commit: missing author after tree and parents")
+	}
+	committer, headers, ok := takeHeader(headers, "committer")
+	if !ok {
+		return fmt.Errorf("invalid git commit: missing committer after author")
+	}
+	return fluent.Recover(func() {
+		fluent.WrapAssembler(na).CreateMap(6, func(fma fluent.MapAssembler) {
+			fma.AssembleEntry("tree").AssignLink(tree)
+			fma.AssembleEntry("parents").CreateList(int64(len(parents)), func(fla fluent.ListAssembler) {
+				for _, parent := range parents {
+					fla.AssembleValue().AssignLink(parent)
+				}
+			})
+			fma.AssembleEntry("author").AssignString(author)
+			fma.AssembleEntry("committer").AssignString(committer)
+			assembleExtra(fma, headers)
+			fma.AssembleEntry("message").AssignString(message)
+		})
+	})
+}
+
+func decodeTag(na ipld.NodeAssembler, body []byte) error {
+	headers, message, err := parseHeaders(body)
+	if err != nil {
+		return err
+	}
+	objectHex, headers, ok := takeHeader(headers, "object")
+	if !ok {
+		return fmt.Errorf("invalid git tag: object must be the first header")
+	}
+	object, err := linkFromHex(objectHex)
+	if err != nil {
+		return err
+	}
+	typ, headers, ok := takeHeader(headers, "type")
+	if !ok {
+		return fmt.Errorf("invalid git tag: missing type after object")
+	}
+	tag, headers, ok := takeHeader(headers, "tag")
+	if !ok {
+		return fmt.Errorf("invalid git tag: missing tag after type")
+	}
+	tagger, headers, hasTagger := takeHeader(headers, "tagger")
+	size := int64(5)
+	if hasTagger {
+		size++
+	}
+	return fluent.Recover(func() {
+		fluent.WrapAssembler(na).CreateMap(size, func(fma fluent.MapAssembler) {
+			fma.AssembleEntry("object").AssignLink(object)
+			fma.AssembleEntry("type").AssignString(typ)
+			fma.AssembleEntry("tag").AssignString(tag)
+			if hasTagger {
+				fma.AssembleEntry("tagger").AssignString(tagger)
+			}
+			assembleExtra(fma, headers)
+			fma.AssembleEntry("message").AssignString(message)
+		})
+	})
+}
+
+func assembleExtra(fma fluent.MapAssembler, headers []header) {
+	fma.AssembleEntry("extra").CreateList(int64(len(headers)), func(fla fluent.ListAssembler) {
+		for _, h := range headers {
+			fla.AssembleValue().CreateMap(2, func(fma fluent.MapAssembler) {
+				fma.AssembleEntry("key").AssignString(h.key)
+				fma.AssembleEntry("value").AssignString(h.value)
+			})
+		}
+	})
+}
+
+func linkFromHex(s string) (ipld.Link, error) {
+	sum, err := hex.DecodeString(s)
+	if err != nil || len(sum) != 20 {
+		return nil, fmt.Errorf("invalid git object: bad object ID %q", s)
+	}
+	return linkFromSHA1(sum)
+}
+
+func linkFromSHA1(sum []byte) (ipld.Link, error) {
+	hash, err := mh.Encode(sum, mh.SHA1)
+	if err != nil {
+		return nil, err
+	}
+	return cidlink.Link{Cid: cid.NewCidV1(gitRawMulticodec, hash)}, nil
+}
+
+// Encode serializes the given ipld.Node, which must be of one of the forms
+// described in the package documentation, as a raw git object.
+func Encode(n ipld.Node, w io.Writer) error {
+	var kind string
+	var body bytes.Buffer
+	switch n.Kind() {
+	case ipld.Kind_Bytes:
+		b, err := n.AsBytes()
+		if err != nil {
+			return err
+		}
+		kind = "blob"
+		body.Write(b)
+	case ipld.Kind_Map:
+		var err error
+		if hasLink(n, "tree") {
+			kind = "commit"
+			err = encodeCommit(n, &body)
+		} else if hasLink(n, "object") {
+			kind = "tag"
+			err = encodeTag(n, &body)
+		} else {
+			kind = "tree"
+			err = encodeTree(n, &body)
+		}
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("cannot encode git object from %s node", n.Kind())
+	}
+	if _, err := fmt.Fprintf(w, "%s %d\x00", kind, body.Len()); err != nil {
+		return err
+	}
+	_, err := body.WriteTo(w)
+	return err
+}
+
+// hasLink reports whether the map n has a link value for key.
+func hasLink(n ipld.Node, key string) bool {
+	v, err := n.LookupByString(key)
+	return err == nil && v.Kind() == ipld.Kind_Link
+}
+
+func encodeTree(n ipld.Node, w *bytes.Buffer) error {
+	for itr := n.MapIterator(); !itr.Done(); {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		name, err := k.AsString()
+		if err != nil {
+			return err
+		}
+		mode, err := lookupString(v, "mode")
+		if err != nil {
+			return fmt.Errorf("cannot encode git tree entry %q: %v", name, err)
+		}
+		sum, err := lookupSHA1(v, "hash")
+		if err != nil {
+			return fmt.Errorf("cannot encode git tree entry %q: %v", name, err)
+		}
+		fmt.Fprintf(w, "%s %s\x00", mode, name)
+		w.Write(sum)
+	}
+	return nil
+}
+
+func encodeCommit(n ipld.Node, w *bytes.Buffer) error {
+	tree, err := lookupSHA1(n, "tree")
+	if err != nil {
+		return fmt.Errorf("cannot encode git commit: %v", err)
+	}
+	writeHeader(w, "tree", hex.EncodeToString(tree))
+	parents, err := n.LookupByString("parents")
+	if err != nil {
+		return fmt.Errorf("cannot encode git commit: %v", err)
+	}
+	for itr := parents.ListIterator(); itr != nil && !itr.Done(); {
+		_, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		parent, err := sha1FromLink(v)
+		if err != nil {
+			return fmt.Errorf("cannot encode git commit: parent: %v", err)
+		}
+		writeHeader(w, "parent", hex.EncodeToString(parent))
+	}
+	for _, key := range []string{"author", "committer"} {
+		v, err := lookupString(n, key)
+		if err != nil {
+			return fmt.Errorf("cannot encode git commit: %v", err)
+		}
+		writeHeader(w, key, v)
+	}
+	return encodeExtraAndMessage(n, w)
+}
+
+func encodeTag(n ipld.Node, w *bytes.Buffer) error {
+	object, err := lookupSHA1(n, "object")
+	if err != nil {
+		return fmt.Errorf("cannot encode git tag: %v", err)
+	}
+	writeHeader(w, "object", hex.EncodeToString(object))
+	for _, key := range []string{"type", "tag"} {
+		v, err := lookupString(n, key)
+		if err != nil {
+			return fmt.Errorf("cannot encode git tag: %v", err)
+		}
+		writeHeader(w, key, v)
+	}
+	if _, err := n.LookupByString("tagger"); err == nil {
+		v, err := lookupString(n, "tagger")
+		if err != nil {
+			return fmt.Errorf("cannot encode git tag: %v", err)
+		}
+		writeHeader(w, "tagger", v)
+	}
+	return encodeExtraAndMessage(n, w)
+}
+
+func encodeExtraAndMessage(n ipld.Node, w *bytes.Buffer) error {
+	if extra, err := n.LookupByString("extra"); err == nil {
+		for itr := extra.ListIterator(); itr != nil && !itr.Done(); {
+			_, v, err := itr.Next()
+			if err != nil {
+				return err
+			}
+			key, err := lookupString(v, "key")
+			if err != nil {
+				return fmt.Errorf("cannot encode git header: %v", err)
+			}
+			value, err := lookupString(v, "value")
+			if err != nil {
+				return fmt.Errorf("cannot encode git header: %v", err)
+			}
+			writeHeader(w, key, value)
+		}
+	}
+	message, err := lookupString(n, "message")
+	if err != nil {
+		return fmt.Errorf("cannot encode git object: %v", err)
+	}
+	w.WriteByte('\n')
+	w.WriteString(message)
+	return nil
+}
+
+func writeHeader(w *bytes.Buffer, key, value string) {
+	w.WriteString(key)
+	w.WriteByte(' ')
+	w.WriteString(strings.ReplaceAll(value, "\n", "\n "))
+	w.WriteByte('\n')
+}
+
+func lookupString(n ipld.Node, key string) (string, error) {
+	v, err := n.LookupByString(key)
+	if err != nil {
+		return "", err
+	}
+	s, err := v.AsString()
+	if err != nil {
+		return "", fmt.Errorf("%s: %v", key, err)
+	}
+	return s, nil
+}
+
+func lookupSHA1(n ipld.Node, key string) ([]byte, error) {
+	v, err := n.LookupByString(key)
+	if err != nil {
+		return nil, err
+	}
+	sum, err := sha1FromLink(v)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", key, err)
+	}
+	return sum, nil
+}
+
+func sha1FromLink(n ipld.Node) ([]byte, error) {
+	lnk, err := n.AsLink()
+	if err != nil {
+		return nil, err
+	}
+	cl, ok := lnk.(cidlink.Link)
+	if !ok {
+		return nil, fmt.Errorf("git objects can only link by CID")
+	}
+	decoded, err := mh.Decode(cl.Hash())
+	if err != nil {
+		return nil, err
+	}
+	if decoded.Code != mh.SHA1 || len(decoded.Digest) != 20 {
+		return nil, fmt.Errorf("git objects can only link by SHA-1 hash")
+	}
+	return decoded.Digest, nil
+}
@@ -0,0 +1,159 @@
+package git
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+)
+
+func object(kind, body string) string {
+	return fmt.Sprintf("%s %d\x00%s", kind, len(body), body)
+}
+
+func mustHex(s string) string {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+// These fixtures are objects from a real repository, along with their IDs.
+var fixtures = []struct {
+	name   string
+	id     string
+	serial string
+}{
+	{"blob", "ce013625030ba8dba906f756967f9e9ca394464a", object("blob", "hello\n")},
+	{"tree", "aaa96ced2d9a1c8e72c56b253a0e2fe78393feb7", object("tree", "100644 hello.txt\x00"+mustHex("ce013625030ba8dba906f756967f9e9ca394464a"))},
+	{"commit", "ac1f36c3fd2ffdcada95ce514757dfbe436bc19e", object("commit", ""+
+		"tree aaa96ced2d9a1c8e72c56b253a0e2fe78393feb7\n"+
+		"author Alice <alice@example.com> 1600000000 +0000\n"+
+		"committer Alice <alice@example.com> 1600000000 +0000\n"+
+		"\n"+
+		"first commit\n")},
+	{"tag", "14b042215a58d3e642e775f0be3c6cf2922dfd28", object("tag", ""+
+		"object ac1f36c3fd2ffdcada95ce514757dfbe436bc19e\n"+
+		"type commit\n"+
+		"tag v1\n"+
+		"tagger Alice <alice@example.com> 1600000001 +0000\n"+
+		"\n"+
+		"release\n")},
+}
+
+func decode(t *testing.T, serial string) ipld.Node {
+	t.Helper()
+	nb := basicnode.Prototype.Any.NewBuilder()
+	err := Decode(nb, strings.NewReader(serial))
+	Require(t, err, ShouldEqual, nil)
+	return nb.Build()
+}
+
+func TestRoundtrip(t *testing.T) {
+	for _, fixture := range fixtures {
+		t.Run(fixture.name, func(t *testing.T) {
+			Wish(t, fmt.Sprintf("%x", sha1.Sum([]byte(fixture.serial))), ShouldEqual, fixture.id)
+			n := decode(t, fixture.serial)
+			var buf bytes.Buffer
+			err := Encode(n, &buf)
+			Require(t, err, ShouldEqual, nil)
+			Wish(t, buf.String(), ShouldEqual, fixture.serial)
+		})
+	}
+}
+
+func TestRoundtripAmbiguousTree(t *testing.T) {
+	// A tree whose entries have the names of commit and tag entries.
+	hash := mustHex("ce013625030ba8dba906f756967f9e9ca394464a")
+	serial := object("tree", ""+
+		"100644 object\x00"+hash+
+		"100644 tree\x00"+hash)
+	n := decode(t, serial)
+	var buf bytes.Buffer
+	err := Encode(n, &buf)
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, buf.String(), ShouldEqual, serial)
+}
+
+func TestDecodedForm(t *testing.T) {
+	t.Run("tree", func(t *testing.T) {
+		n := decode(t, fixtures[1].serial)
+		hash, err := n.LookupByString("hello.txt")
+		Require(t, err, ShouldEqual, nil)
+		hash, err = hash.LookupByString("hash")
+		Require(t, err, ShouldEqual, nil)
+		lnk, err := hash.AsLink()
+		Require(t, err, ShouldEqual, nil)
+		sum, err := sha1FromLink(basicnode.NewLink(lnk))
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, hex.EncodeToString(sum), ShouldEqual, fixtures[0].id)
+	})
+	t.Run("commit", func(t *testing.T) {
+		n := decode(t, fixtures[2].serial)
+		Wish(t, n.Length(), ShouldEqual, int64(6))
+		parents, err := n.LookupByString("parents")
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, parents.Length(), ShouldEqual, int64(0))
+		msg, err := n.LookupByString("message")
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, msg, ShouldEqual, basicnode.NewString("first commit\n"))
+	})
+}
+
+func TestMultilineHeaders(t *testing.T) {
+	serial := object("commit", ""+
+		"tree aaa96ced2d9a1c8e72c56b253a0e2fe78393feb7\n"+
+		"parent ac1f36c3fd2ffdcada95ce514757dfbe436bc19e\n"+
+		"parent 14b042215a58d3e642e775f0be3c6cf2922dfd28\n"+
+		"author Alice <alice@example.com> 1600000000 +0000\n"+
+		"committer Alice <alice@example.com> 1600000000 +0000\n"+
+		"gpgsig -----BEGIN PGP SIGNATURE-----\n"+
+		" \n"+
+		" abcdef\n"+
+		" -----END PGP SIGNATURE-----\n"+
+		"\n"+
+		"merge\n")
+	n := decode(t, serial)
+	extra, err := n.LookupByString("extra")
+	Require(t, err, ShouldEqual, nil)
+	sig, err := extra.LookupByIndex(0)
+	Require(t, err, ShouldEqual, nil)
+	value, err := sig.LookupByString("value")
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, value, ShouldEqual, basicnode.NewString("-----BEGIN PGP SIGNATURE-----\n\nabcdef\n-----END PGP SIGNATURE-----"))
+
+	var buf bytes.Buffer
+	err = Encode(n, &buf)
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, buf.String(), ShouldEqual, serial)
+}
+
+func TestDecodeErrors(t *testing.T) {
+	for _, tcase := range []struct {
+		name   string
+		serial string
+		err    string
+	}{
+		{"no header", "blob", "invalid git object: missing header"},
+		{"wrong size", "blob 3\x00ab", `invalid git object: header declares size "3", but body is 2 bytes`},
+		{"unknown type", object("thing", ""), `invalid git object: unknown type "thing"`},
+		{"truncated tree", object("tree", "100644 a\x00abc"), "invalid git tree: malformed entry"},
+		{"commit without tree", object("commit", "author x\ncommitter x\n\nmsg"), "invalid git commit: tree must be the first header"},
+		{"commit without blank line", object("commit", "tree aaa96ced2d9a1c8e72c56b253a0e2fe78393feb7\n"), "invalid git object: headers must be followed by an empty line"},
+	} {
+		t.Run(tcase.name, func(t *testing.T) {
+			nb := basicnode.Prototype.Any.NewBuilder()
+			err := Decode(nb, strings.NewReader(tcase.serial))
+			Require(t, err != nil, ShouldEqual, true)
+			Wish(t, err.Error(), ShouldEqual, tcase.err)
+		})
+	}
+}
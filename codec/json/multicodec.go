@@ -1,7 +1,15 @@
+// Package json implements the plain JSON codec (multicodec 0x0200).
+//
+// Unlike dag-json, this codec gives no special meaning to any data:
+// a map of the form `{"/": "..."}` decodes as an ordinary map,
+// and nodes containing links cannot be encoded.
+// This makes it suitable for interoperating with non-IPLD JSON APIs,
+// while still working with the usual Node and NodeAssembler interfaces.
+//
+// JSON has no bytes kind, so nodes containing bytes cannot be encoded either.
 package json
 
 import (
-	"fmt"
 	"io"
 
 	rfmtjson "github.com/polydawn/refmt/json"
@@ -19,37 +27,19 @@ var (
 func init() {
 	multicodec.RegisterEncoder(0x0200, Encode)
 	multicodec.RegisterDecoder(0x0200, Decode)
+	multicodec.RegisterCapabilities(0x0200, multicodec.Capabilities{
+		Kinds: ipld.KindSet{
+			ipld.Kind_Map, ipld.Kind_List,
+			ipld.Kind_Null, ipld.Kind_Bool, ipld.Kind_Int, ipld.Kind_Float,
+			ipld.Kind_String,
+		},
+	})
 }
 
 func Decode(na ipld.NodeAssembler, r io.Reader) error {
 	// Shell out directly to generic builder path.
 	//  (There's not really any fastpaths of note for json.)
-	err := dagjson.Unmarshal(na, rfmtjson.NewDecoder(r), false)
-	if err != nil {
-		return err
-	}
-	// Slurp any remaining whitespace.
-	//  (This is relevant if our reader is tee'ing bytes to a hasher, and
-	//   the json contained any trailing whitespace.)
-	//  (We can't actually support multiple objects per reader from here;
-	//   we can't unpeek if we find a non-whitespace token, so our only
-	//    option is to error if this reader seems to contain more content.)
-	var buf [1]byte
-	for {
-		_, err := r.Read(buf[:])
-		switch buf[0] {
-		case ' ', 0x0, '\t', '\r', '\n': // continue
-		default:
-			return fmt.Errorf("unexpected content after end of json object")
-		}
-		if err == nil {
-			continue
-		} else if err == io.EOF {
-			return nil
-		} else {
-			return err
-		}
-	}
+	return dagjson.DecodeOptions{}.UnmarshalReader(na, r, false)
 }
 
 func Encode(n ipld.Node, w io.Writer) error {
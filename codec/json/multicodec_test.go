@@ -0,0 +1,57 @@
+package json
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	cid "github.com/ipfs/go-cid"
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime/fluent"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+)
+
+const cidString = "bafyreibbvxxq5fahtmylr5tsfymzqmpw7zyufhh55e2zrf4jbsvtfw6hge"
+
+func TestSlashMapsAreOrdinary(t *testing.T) {
+	serial := `{
+	"link": {
+		"/": "` + cidString + `"
+	}
+}
+`
+	expected := fluent.MustBuildMap(basicnode.Prototype.Map, 1, func(na fluent.MapAssembler) {
+		na.AssembleEntry("link").CreateMap(1, func(na fluent.MapAssembler) {
+			na.AssembleEntry("/").AssignString(cidString)
+		})
+	})
+	t.Run("decoding", func(t *testing.T) {
+		nb := basicnode.Prototype.Any.NewBuilder()
+		err := Decode(nb, strings.NewReader(serial))
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, nb.Build(), ShouldEqual, expected)
+	})
+	t.Run("encoding", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := Encode(expected, &buf)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, buf.String(), ShouldEqual, serial)
+	})
+}
+
+func TestEncodeRejectsNonJSONKinds(t *testing.T) {
+	t.Run("links", func(t *testing.T) {
+		c, err := cid.Decode(cidString)
+		Require(t, err, ShouldEqual, nil)
+		var buf bytes.Buffer
+		err = Encode(basicnode.NewLink(cidlink.Link{Cid: c}), &buf)
+		Wish(t, err.Error(), ShouldEqual, "cannot Marshal ipld links to JSON")
+	})
+	t.Run("bytes", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := Encode(basicnode.NewBytes([]byte("abc")), &buf)
+		Wish(t, err.Error(), ShouldEqual, "cannot Marshal bytes to JSON")
+	})
+}
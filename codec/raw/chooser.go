@@ -0,0 +1,30 @@
+package raw
+
+import (
+	ipld "github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/traversal"
+)
+
+// WrapChooser returns a LinkTargetNodePrototypeChooser which loads every
+// link to a raw block (that is, a CID with the raw codec 0x55) as a plain
+// bytes node, and defers to the given chooser for all other links.
+//
+// This lets a traversal descend into raw leaves, such as those of unixfs
+// files, even when the given chooser only knows about the structured
+// blocks of a graph (for example, if it always returns a schema type's
+// prototype, which could not be assembled from bytes).
+//
+// If chooser is nil, all other links are loaded with basicnode.Prototype.Any.
+func WrapChooser(chooser traversal.LinkTargetNodePrototypeChooser) traversal.LinkTargetNodePrototypeChooser {
+	return func(lnk ipld.Link, lnkCtx ipld.LinkContext) (ipld.NodePrototype, error) {
+		if cl, ok := lnk.(cidlink.Link); ok && cl.Cid.Type() == rawMulticodec {
+			return basicnode.Prototype.Bytes, nil
+		}
+		if chooser == nil {
+			return basicnode.Prototype.Any, nil
+		}
+		return chooser(lnk, lnkCtx)
+	}
+}
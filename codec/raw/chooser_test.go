@@ -0,0 +1,64 @@
+package raw
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/ipfs/go-cid"
+	ipld "github.com/ipld/go-ipld-prime"
+	_ "github.com/ipld/go-ipld-prime/codec/dagcbor"
+	"github.com/ipld/go-ipld-prime/fluent"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/storage"
+	"github.com/ipld/go-ipld-prime/traversal"
+	"github.com/ipld/go-ipld-prime/traversal/selector/presets"
+)
+
+func TestWrapChooser(t *testing.T) {
+	t.Parallel()
+
+	store := storage.Memory{}
+	lsys := cidlink.DefaultLinkSystem()
+	lsys.StorageReadOpener = (&store).OpenRead
+	lsys.StorageWriteOpener = (&store).OpenWrite
+
+	leafLink, err := lsys.Store(ipld.LinkContext{}, cidlink.LinkPrototype{Prefix: cid.Prefix{
+		Version:  1,
+		Codec:    rawMulticodec,
+		MhType:   0x13,
+		MhLength: 64,
+	}}, basicnode.NewBytes([]byte("leaf data")))
+	qt.Assert(t, err, qt.IsNil)
+	root := fluent.MustBuildMap(basicnode.Prototype.Map, 1, func(na fluent.MapAssembler) {
+		na.AssembleEntry("leaf").AssignLink(leafLink)
+	})
+
+	sel, err := presets.FullWalk().Selector()
+	qt.Assert(t, err, qt.IsNil)
+
+	// A chooser which only knows about the structured blocks;
+	// without the wrapper, loading the raw leaf would fail.
+	mapsOnly := func(ipld.Link, ipld.LinkContext) (ipld.NodePrototype, error) {
+		return basicnode.Prototype.Map, nil
+	}
+	walk := func(chooser traversal.LinkTargetNodePrototypeChooser) ([]ipld.Node, error) {
+		var visited []ipld.Node
+		err := traversal.Progress{Cfg: &traversal.Config{
+			LinkSystem:                     lsys,
+			LinkTargetNodePrototypeChooser: chooser,
+		}}.WalkMatching(root, sel, func(prog traversal.Progress, n ipld.Node) error {
+			visited = append(visited, n)
+			return nil
+		})
+		return visited, err
+	}
+
+	_, err = walk(mapsOnly)
+	qt.Assert(t, err, qt.Not(qt.IsNil))
+
+	visited, err := walk(WrapChooser(mapsOnly))
+	qt.Assert(t, err, qt.IsNil)
+	qt.Assert(t, visited, qt.HasLen, 2)
+	qt.Assert(t, visited[1], qt.DeepEquals, basicnode.NewBytes([]byte("leaf data")))
+}
@@ -26,6 +26,10 @@ var (
 func init() {
 	multicodec.RegisterEncoder(rawMulticodec, Encode)
 	multicodec.RegisterDecoder(rawMulticodec, Decode)
+	multicodec.RegisterCapabilities(rawMulticodec, multicodec.Capabilities{
+		Kinds:     ipld.KindSet_JustBytes,
+		Canonical: true,
+	})
 }
 
 // Decode implements decoding of a node with the raw codec.
@@ -36,13 +40,29 @@ func init() {
 // be modified in-place. Similarly, we assume that the incoming buffer's bytes
 // won't get modified in-place later.
 //
-// To disable the shortcut above, hide the Bytes method by wrapping the buffer
-// with an io.Reader:
+// In other words, Decode is DecodeOptions{OwnershipTransfer: true}.Decode.
+// To disable the shortcut above, use DecodeOptions{}.Decode instead,
+// or hide the Bytes method by wrapping the buffer with an io.Reader:
 //
 //     Decode([...], struct{io.Reader}{buf})
 func Decode(am ipld.NodeAssembler, r io.Reader) error {
+	return DecodeOptions{OwnershipTransfer: true}.Decode(am, r)
+}
+
+// DecodeOptions can be used to customize the behavior of a decoding function.
+// The Decode method on this struct fits the ipld.Decoder function interface.
+type DecodeOptions struct {
+	// If true, and the reader has a Bytes method (as *bytes.Buffer does),
+	// the decoded node's bytes are those bytes rather than a copy,
+	// with the assumptions explained on the Decode function.
+	OwnershipTransfer bool
+}
+
+// Decode implements decoding of a node with the raw codec,
+// according to the options.
+func (cfg DecodeOptions) Decode(am ipld.NodeAssembler, r io.Reader) error {
 	var data []byte
-	if buf, ok := r.(interface{ Bytes() []byte }); ok {
+	if buf, ok := r.(interface{ Bytes() []byte }); ok && cfg.OwnershipTransfer {
 		data = buf.Bytes()
 	} else {
 		var err error
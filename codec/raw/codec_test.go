@@ -113,3 +113,24 @@ func TestDecodeBuffer(t *testing.T) {
 	)
 	qt.Assert(t, err, qt.IsNil)
 }
+
+func TestDecodeOwnershipTransfer(t *testing.T) {
+	t.Parallel()
+
+	serial := []byte("hello there")
+	decodeData := func(cfg DecodeOptions) []byte {
+		nb := basicnode.Prototype.Bytes.NewBuilder()
+		err := cfg.Decode(nb, bytes.NewBuffer(serial))
+		qt.Assert(t, err, qt.IsNil)
+		data, err := nb.Build().AsBytes()
+		qt.Assert(t, err, qt.IsNil)
+		qt.Assert(t, data, qt.DeepEquals, serial)
+		return data
+	}
+
+	data := decodeData(DecodeOptions{OwnershipTransfer: true})
+	qt.Assert(t, &data[0] == &serial[0], qt.IsTrue)
+
+	data = decodeData(DecodeOptions{})
+	qt.Assert(t, &data[0] == &serial[0], qt.IsFalse)
+}
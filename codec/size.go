@@ -0,0 +1,115 @@
+package codec
+
+import (
+	"fmt"
+
+	"github.com/ipld/go-ipld-prime"
+)
+
+// CountingWriter is an io.Writer which discards what's written to it,
+// and only counts the bytes.
+type CountingWriter struct {
+	N int64 // the number of bytes written so far.
+}
+
+func (w *CountingWriter) Write(p []byte) (int, error) {
+	w.N += int64(len(p))
+	return len(p), nil
+}
+
+func (w *CountingWriter) WriteString(s string) (int, error) {
+	w.N += int64(len(s))
+	return len(s), nil
+}
+
+// EncodedSize returns the number of bytes that encode would produce for n,
+// without keeping them around: the encoder writes to a CountingWriter.
+// This lets layers which split data into blocks decide whether a node still
+// fits in one, without buffering its encoding.
+//
+// It works with any encoder, but still does all the work of encoding.
+// Some codecs have a faster way to compute the size, such as dagcbor.EncodedSize.
+func EncodedSize(n ipld.Node, encode ipld.Encoder) (int64, error) {
+	var w CountingWriter
+	if err := encode(n, &w); err != nil {
+		return 0, err
+	}
+	return w.N, nil
+}
+
+// Rough sizes of the parts of a node in memory, on a 64-bit platform,
+// as used by ApproxMemorySize.
+const (
+	sizeNode     = 16 // an interface value pointing at the node.
+	sizeScalar   = 8  // a bool, int, or float.
+	sizeString   = 16 // a string or slice header, not counting its contents.
+	sizeMapEntry = 16 // per-entry overhead of an ordered map, beyond the key and value.
+	sizeLink     = 56 // a typical CIDv1 with a sha2-256 hash, and its string header.
+)
+
+// ApproxMemorySize returns a rough estimate of the bytes of memory taken up
+// by n and everything under it, without following links.
+//
+// The estimate is based on the Data Model, and is the same for any node
+// implementation; it's meant for deciding when to flush or split data
+// (such as when a block builder should consider a block "full"),
+// not for precise accounting.
+// Use EncodedSize to know how many bytes a node takes up once encoded.
+func ApproxMemorySize(n ipld.Node) (int64, error) {
+	switch n.Kind() {
+	case ipld.Kind_Invalid:
+		return 0, fmt.Errorf("cannot size a node that is absent")
+	case ipld.Kind_Null:
+		return sizeNode, nil
+	case ipld.Kind_Bool, ipld.Kind_Int, ipld.Kind_Float:
+		return sizeNode + sizeScalar, nil
+	case ipld.Kind_String:
+		s, err := n.AsString()
+		if err != nil {
+			return 0, err
+		}
+		return sizeNode + sizeString + int64(len(s)), nil
+	case ipld.Kind_Bytes:
+		b, err := n.AsBytes()
+		if err != nil {
+			return 0, err
+		}
+		return sizeNode + sizeString + int64(len(b)), nil
+	case ipld.Kind_Link:
+		return sizeNode + sizeLink, nil
+	case ipld.Kind_Map:
+		size := int64(sizeNode)
+		for itr := n.MapIterator(); !itr.Done(); {
+			k, v, err := itr.Next()
+			if err != nil {
+				return 0, err
+			}
+			ks, err := ApproxMemorySize(k)
+			if err != nil {
+				return 0, err
+			}
+			vs, err := ApproxMemorySize(v)
+			if err != nil {
+				return 0, err
+			}
+			size += sizeMapEntry + ks + vs
+		}
+		return size, nil
+	case ipld.Kind_List:
+		size := int64(sizeNode)
+		for itr := n.ListIterator(); !itr.Done(); {
+			_, v, err := itr.Next()
+			if err != nil {
+				return 0, err
+			}
+			vs, err := ApproxMemorySize(v)
+			if err != nil {
+				return 0, err
+			}
+			size += vs // lists store their values directly.
+		}
+		return size, nil
+	default:
+		panic("unreachable")
+	}
+}
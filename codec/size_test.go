@@ -0,0 +1,61 @@
+package codec_test
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec"
+	"github.com/ipld/go-ipld-prime/codec/dagcbor"
+	"github.com/ipld/go-ipld-prime/codec/dagjson"
+	"github.com/ipld/go-ipld-prime/fluent"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+)
+
+func TestEncodedSize(t *testing.T) {
+	n := fluent.MustBuildMap(basicnode.Prototype.Map, 2, func(ma fluent.MapAssembler) {
+		ma.AssembleEntry("name").AssignString("hello")
+		ma.AssembleEntry("list").CreateList(2, func(la fluent.ListAssembler) {
+			la.AssembleValue().AssignInt(1)
+			la.AssembleValue().AssignBytes([]byte{1, 2, 3})
+		})
+	})
+	for _, encode := range []ipld.Encoder{dagcbor.Encode, dagjson.Encode} {
+		var buf bytes.Buffer
+		Require(t, encode(n, &buf), ShouldEqual, nil)
+		size, err := codec.EncodedSize(n, encode)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, size, ShouldEqual, int64(buf.Len()))
+	}
+
+	_, err := codec.EncodedSize(basicnode.NewFloat(math.NaN()), dagjson.Encode)
+	Wish(t, err, ShouldEqual, fmt.Errorf("cannot encode NaN as json"))
+}
+
+func TestApproxMemorySize(t *testing.T) {
+	size := func(n ipld.Node) int64 {
+		s, err := codec.ApproxMemorySize(n)
+		Require(t, err, ShouldEqual, nil)
+		return s
+	}
+	Wish(t, size(basicnode.NewString("abc")), ShouldEqual, size(basicnode.NewString(""))+3)
+	Wish(t, size(basicnode.NewBytes(make([]byte, 100))) > 100, ShouldEqual, true)
+
+	small := fluent.MustBuildList(basicnode.Prototype.List, 1, func(la fluent.ListAssembler) {
+		la.AssembleValue().AssignInt(1)
+	})
+	large := fluent.MustBuildList(basicnode.Prototype.List, 2, func(la fluent.ListAssembler) {
+		la.AssembleValue().AssignInt(1)
+		la.AssembleValue().AssignString("x")
+	})
+	Wish(t, size(large), ShouldEqual, size(small)+size(basicnode.NewString("x")))
+
+	m := fluent.MustBuildMap(basicnode.Prototype.Map, 1, func(ma fluent.MapAssembler) {
+		ma.AssembleEntry("k").AssignNode(large)
+	})
+	Wish(t, size(m) > size(large)+size(basicnode.NewString("k")), ShouldEqual, true)
+}
@@ -0,0 +1,72 @@
+package codec
+
+import (
+	"sort"
+
+	"github.com/ipld/go-ipld-prime"
+)
+
+// MapSortMode selects the order in which an encoder writes the entries of maps.
+//
+// Codecs which support it expose it as an EncodeOptions field;
+// the zero value is MapSortMode_None.
+type MapSortMode uint8
+
+const (
+	// MapSortMode_None writes map entries in the node's iteration order,
+	// so that data is written back exactly as it was built or decoded.
+	// This is useful in round-trip tests, but the same logical map may be
+	// written in different ways, and so hash to different CIDs.
+	MapSortMode_None MapSortMode = iota
+
+	// MapSortMode_Lexical sorts map entries bytewise by key.
+	MapSortMode_Lexical
+
+	// MapSortMode_RFC7049 sorts map entries by key length first,
+	// and then bytewise, as RFC 7049 canonical CBOR does.
+	// This is the order which dag-cbor requires for content addressing.
+	MapSortMode_RFC7049
+)
+
+// MapEntry is a map entry with a string key, as returned by SortedMapEntries.
+type MapEntry struct {
+	Key   string
+	Value ipld.Node
+}
+
+// SortedMapEntries returns the entries of a map node in the given order,
+// for encoders which need to sort them before writing them.
+// With MapSortMode_None, they are in the node's iteration order.
+func SortedMapEntries(n ipld.Node, mode MapSortMode) ([]MapEntry, error) {
+	entries := make([]MapEntry, 0, n.Length())
+	for itr := n.MapIterator(); !itr.Done(); {
+		k, v, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		ks, err := k.AsString()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, MapEntry{ks, v})
+	}
+	switch mode {
+	case MapSortMode_Lexical:
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].Key < entries[j].Key
+		})
+	case MapSortMode_RFC7049:
+		sort.SliceStable(entries, func(i, j int) bool {
+			return rfc7049Less(entries[i].Key, entries[j].Key)
+		})
+	}
+	return entries, nil
+}
+
+// rfc7049Less orders keys by length first, and then bytewise.
+func rfc7049Less(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
@@ -0,0 +1,38 @@
+package codec_test
+
+import (
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec"
+	"github.com/ipld/go-ipld-prime/fluent/qp"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+)
+
+func TestSortedMapEntries(t *testing.T) {
+	n, err := qp.BuildMap(basicnode.Prototype.Any, 4, func(ma ipld.MapAssembler) {
+		qp.MapEntry(ma, "bb", qp.Int(1))
+		qp.MapEntry(ma, "a", qp.Int(2))
+		qp.MapEntry(ma, "ab", qp.Int(3))
+		qp.MapEntry(ma, "c", qp.Int(4))
+	})
+	Require(t, err, ShouldEqual, nil)
+	for _, tc := range []struct {
+		mode codec.MapSortMode
+		want []string
+	}{
+		{codec.MapSortMode_None, []string{"bb", "a", "ab", "c"}},
+		{codec.MapSortMode_Lexical, []string{"a", "ab", "bb", "c"}},
+		{codec.MapSortMode_RFC7049, []string{"a", "c", "ab", "bb"}},
+	} {
+		entries, err := codec.SortedMapEntries(n, tc.mode)
+		Require(t, err, ShouldEqual, nil)
+		var keys []string
+		for _, e := range entries {
+			keys = append(keys, e.Key)
+		}
+		Wish(t, keys, ShouldEqual, tc.want)
+	}
+}
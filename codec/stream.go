@@ -0,0 +1,266 @@
+package codec
+
+import (
+	"fmt"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+)
+
+// EntryFunc is called by the assembler returned by StreamEntries for each
+// entry of a map or list, with its key or index, and its value.
+// If it returns an error, decoding stops, and the decoder returns that error.
+type EntryFunc func(ps ipld.PathSegment, value ipld.Node) error
+
+// StreamEntries returns a NodeAssembler for a map or list which doesn't build
+// the collection at all: instead, it builds the value of each entry with np,
+// and hands it to fn as soon as it's complete.
+//
+// Giving it to a decoder, such as dagcbor.Decode or dagjson.Decode, decodes
+// a huge collection with memory bounded by the size of its largest entry,
+// rather than by the size of the whole collection,
+// as long as fn doesn't hold on to the values.
+// Note that the decoder's own limits, such as dag-cbor's MaxDecodedSize,
+// still apply to the data as a whole, and may need raising.
+//
+// Only the top-level collection is streamed; nested maps and lists are
+// built whole, as part of the entry they're in.
+// Trying to assemble any other kind of value is an error.
+func StreamEntries(np ipld.NodePrototype, fn EntryFunc) ipld.NodeAssembler {
+	return &streamAssembler{np: np, fn: fn}
+}
+
+type streamAssembler struct {
+	np ipld.NodePrototype
+	fn EntryFunc
+}
+
+func (s *streamAssembler) wrongKind(kind ipld.Kind) error {
+	return fmt.Errorf("can only stream the entries of a map or list, not a %s", kind)
+}
+
+func (s *streamAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return &streamMapAssembler{s: s}, nil
+}
+func (s *streamAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return &streamListAssembler{s: s}, nil
+}
+func (s *streamAssembler) AssignNull() error          { return s.wrongKind(ipld.Kind_Null) }
+func (s *streamAssembler) AssignBool(bool) error      { return s.wrongKind(ipld.Kind_Bool) }
+func (s *streamAssembler) AssignInt(int64) error      { return s.wrongKind(ipld.Kind_Int) }
+func (s *streamAssembler) AssignFloat(float64) error  { return s.wrongKind(ipld.Kind_Float) }
+func (s *streamAssembler) AssignString(string) error  { return s.wrongKind(ipld.Kind_String) }
+func (s *streamAssembler) AssignBytes([]byte) error   { return s.wrongKind(ipld.Kind_Bytes) }
+func (s *streamAssembler) AssignLink(ipld.Link) error { return s.wrongKind(ipld.Kind_Link) }
+func (s *streamAssembler) AssignNode(n ipld.Node) error {
+	switch n.Kind() {
+	case ipld.Kind_Map:
+		for itr := n.MapIterator(); !itr.Done(); {
+			k, v, err := itr.Next()
+			if err != nil {
+				return err
+			}
+			ks, err := k.AsString()
+			if err != nil {
+				return err
+			}
+			if err := s.fn(ipld.PathSegmentOfString(ks), v); err != nil {
+				return err
+			}
+		}
+		return nil
+	case ipld.Kind_List:
+		for itr := n.ListIterator(); !itr.Done(); {
+			i, v, err := itr.Next()
+			if err != nil {
+				return err
+			}
+			if err := s.fn(ipld.PathSegmentOfInt(i), v); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return s.wrongKind(n.Kind())
+	}
+}
+func (s *streamAssembler) Prototype() ipld.NodePrototype {
+	// There's no prototype which describes streaming; the closest thing
+	//  is the prototype each entry is built with.
+	return s.np
+}
+
+// entry returns an assembler for the value of the entry at ps,
+// which calls fn once the value is complete.
+func (s *streamAssembler) entry(ps ipld.PathSegment) ipld.NodeAssembler {
+	return &entryAssembler{s: s, ps: ps, nb: s.np.NewBuilder()}
+}
+
+type streamMapAssembler struct {
+	s   *streamAssembler
+	key string
+}
+
+func (ma *streamMapAssembler) AssembleKey() ipld.NodeAssembler {
+	return &streamKeyAssembler{mixins.StringAssembler{TypeName: "string"}, ma}
+}
+func (ma *streamMapAssembler) AssembleValue() ipld.NodeAssembler {
+	return ma.s.entry(ipld.PathSegmentOfString(ma.key))
+}
+func (ma *streamMapAssembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	ma.key = k
+	return ma.AssembleValue(), nil
+}
+func (ma *streamMapAssembler) Finish() error {
+	return nil
+}
+func (ma *streamMapAssembler) KeyPrototype() ipld.NodePrototype {
+	return keyPrototype{}
+}
+func (ma *streamMapAssembler) ValuePrototype(k string) ipld.NodePrototype {
+	return ma.s.np
+}
+
+type streamKeyAssembler struct {
+	mixins.StringAssembler
+	ma *streamMapAssembler
+}
+
+func (ka *streamKeyAssembler) AssignString(s string) error {
+	ka.ma.key = s
+	return nil
+}
+func (ka *streamKeyAssembler) AssignNode(n ipld.Node) error {
+	s, err := n.AsString()
+	if err != nil {
+		return err
+	}
+	return ka.AssignString(s)
+}
+func (ka *streamKeyAssembler) Prototype() ipld.NodePrototype {
+	return keyPrototype{}
+}
+
+// keyPrototype builds the plain string nodes which map keys are.
+// It stands in for basicnode.Prototype.String, as this package can't depend
+// on basicnode, whose own tests depend on the codecs.
+type keyPrototype struct{}
+
+func (keyPrototype) NewBuilder() ipld.NodeBuilder {
+	return &keyBuilder{StringAssembler: mixins.StringAssembler{TypeName: "string"}}
+}
+
+type keyBuilder struct {
+	mixins.StringAssembler
+	k keyNode
+}
+
+func (kb *keyBuilder) AssignString(s string) error {
+	kb.k = keyNode{mixins.String{TypeName: "string"}, s}
+	return nil
+}
+func (kb *keyBuilder) AssignNode(n ipld.Node) error {
+	s, err := n.AsString()
+	if err != nil {
+		return err
+	}
+	return kb.AssignString(s)
+}
+func (kb *keyBuilder) Prototype() ipld.NodePrototype { return keyPrototype{} }
+func (kb *keyBuilder) Build() ipld.Node              { return kb.k }
+func (kb *keyBuilder) Reset()                        { kb.k = keyNode{} }
+
+type keyNode struct {
+	mixins.String
+	s string
+}
+
+func (keyNode) Kind() ipld.Kind                 { return ipld.Kind_String }
+func (keyNode) MapIterator() ipld.MapIterator   { return nil }
+func (keyNode) ListIterator() ipld.ListIterator { return nil }
+func (keyNode) Length() int64                   { return -1 }
+func (keyNode) IsAbsent() bool                  { return false }
+func (keyNode) IsNull() bool                    { return false }
+func (k keyNode) AsString() (string, error)     { return k.s, nil }
+func (keyNode) Prototype() ipld.NodePrototype   { return keyPrototype{} }
+
+type streamListAssembler struct {
+	s   *streamAssembler
+	idx int64
+}
+
+func (la *streamListAssembler) AssembleValue() ipld.NodeAssembler {
+	na := la.s.entry(ipld.PathSegmentOfInt(la.idx))
+	la.idx++
+	return na
+}
+func (la *streamListAssembler) Finish() error {
+	return nil
+}
+func (la *streamListAssembler) ValuePrototype(idx int64) ipld.NodePrototype {
+	return la.s.np
+}
+
+// entryAssembler assembles the value of one entry with a NodeBuilder,
+// and hands the result to the EntryFunc as soon as the value is complete:
+// after an Assign method, or after Finish for a map or list.
+type entryAssembler struct {
+	s  *streamAssembler
+	ps ipld.PathSegment
+	nb ipld.NodeBuilder
+}
+
+func (e *entryAssembler) done(err error) error {
+	if err != nil {
+		return err
+	}
+	return e.s.fn(e.ps, e.nb.Build())
+}
+
+func (e *entryAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	ma, err := e.nb.BeginMap(sizeHint)
+	if err != nil {
+		return nil, err
+	}
+	return &entryMapAssembler{ma, e}, nil
+}
+func (e *entryAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	la, err := e.nb.BeginList(sizeHint)
+	if err != nil {
+		return nil, err
+	}
+	return &entryListAssembler{la, e}, nil
+}
+func (e *entryAssembler) AssignNull() error           { return e.done(e.nb.AssignNull()) }
+func (e *entryAssembler) AssignBool(v bool) error     { return e.done(e.nb.AssignBool(v)) }
+func (e *entryAssembler) AssignInt(v int64) error     { return e.done(e.nb.AssignInt(v)) }
+func (e *entryAssembler) AssignFloat(v float64) error { return e.done(e.nb.AssignFloat(v)) }
+func (e *entryAssembler) AssignString(v string) error { return e.done(e.nb.AssignString(v)) }
+func (e *entryAssembler) AssignBytes(v []byte) error  { return e.done(e.nb.AssignBytes(v)) }
+func (e *entryAssembler) AssignLink(v ipld.Link) error {
+	return e.done(e.nb.AssignLink(v))
+}
+func (e *entryAssembler) AssignNode(v ipld.Node) error {
+	return e.done(e.nb.AssignNode(v))
+}
+func (e *entryAssembler) Prototype() ipld.NodePrototype {
+	return e.nb.Prototype()
+}
+
+type entryMapAssembler struct {
+	ipld.MapAssembler
+	e *entryAssembler
+}
+
+func (ma *entryMapAssembler) Finish() error {
+	return ma.e.done(ma.MapAssembler.Finish())
+}
+
+type entryListAssembler struct {
+	ipld.ListAssembler
+	e *entryAssembler
+}
+
+func (la *entryListAssembler) Finish() error {
+	return la.e.done(la.ListAssembler.Finish())
+}
@@ -0,0 +1,91 @@
+package codec_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec"
+	"github.com/ipld/go-ipld-prime/codec/dagcbor"
+	"github.com/ipld/go-ipld-prime/codec/dagjson"
+	"github.com/ipld/go-ipld-prime/must"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+)
+
+func TestStreamEntries(t *testing.T) {
+	type entry struct {
+		ps   string
+		kind ipld.Kind
+	}
+	collect := func(entries *[]entry) codec.EntryFunc {
+		return func(ps ipld.PathSegment, value ipld.Node) error {
+			*entries = append(*entries, entry{ps.String(), value.Kind()})
+			return nil
+		}
+	}
+	t.Run("map", func(t *testing.T) {
+		var entries []entry
+		na := codec.StreamEntries(basicnode.Prototype.Any, collect(&entries))
+		err := dagjson.Decode(na, strings.NewReader(`{"a": 1, "b": {"c": [2]}, "d": "x"}`))
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, entries, ShouldEqual, []entry{
+			{"a", ipld.Kind_Int},
+			{"b", ipld.Kind_Map},
+			{"d", ipld.Kind_String},
+		})
+	})
+	t.Run("list", func(t *testing.T) {
+		var entries []entry
+		na := codec.StreamEntries(basicnode.Prototype.Any, collect(&entries))
+		// [1, [true], null]
+		err := dagcbor.Decode(na, strings.NewReader("\x83\x01\x81\xf5\xf6"))
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, entries, ShouldEqual, []entry{
+			{"0", ipld.Kind_Int},
+			{"1", ipld.Kind_List},
+			{"2", ipld.Kind_Null},
+		})
+	})
+	t.Run("errors stop decoding", func(t *testing.T) {
+		stop := errors.New("stop")
+		var entries []entry
+		na := codec.StreamEntries(basicnode.Prototype.Any, func(ps ipld.PathSegment, value ipld.Node) error {
+			if ps.String() == "1" {
+				return stop
+			}
+			return collect(&entries)(ps, value)
+		})
+		err := dagjson.Decode(na, strings.NewReader(`[1, [2, 3], 4]`))
+		Wish(t, errors.Is(err, stop), ShouldEqual, true)
+		Wish(t, err.Error(), ShouldEqual, "stop (at offset 10, under path '1')")
+		Wish(t, entries, ShouldEqual, []entry{{"0", ipld.Kind_Int}})
+	})
+	t.Run("scalars are rejected", func(t *testing.T) {
+		na := codec.StreamEntries(basicnode.Prototype.Any, collect(new([]entry)))
+		err := dagjson.Decode(na, strings.NewReader(`"x"`))
+		Wish(t, err.Error(), ShouldEqual, "can only stream the entries of a map or list, not a string (at offset 3)")
+	})
+	t.Run("map keys have a string prototype", func(t *testing.T) {
+		var entries []entry
+		na := codec.StreamEntries(basicnode.Prototype.Any, collect(&entries))
+		ma, err := na.BeginMap(1)
+		Require(t, err, ShouldEqual, nil)
+		for _, np := range []ipld.NodePrototype{ma.KeyPrototype(), ma.AssembleKey().Prototype()} {
+			nb := np.NewBuilder()
+			Require(t, nb.AssignString("k"), ShouldEqual, nil)
+			k := nb.Build()
+			Wish(t, k.Kind(), ShouldEqual, ipld.Kind_String)
+			Wish(t, must.String(k), ShouldEqual, "k")
+			Wish(t, nb.AssignInt(1) != nil, ShouldEqual, true)
+		}
+		nb := ma.KeyPrototype().NewBuilder()
+		Require(t, nb.AssignString("k"), ShouldEqual, nil)
+		Require(t, ma.AssembleKey().AssignNode(nb.Build()), ShouldEqual, nil)
+		Require(t, ma.AssembleValue().AssignInt(1), ShouldEqual, nil)
+		Require(t, ma.Finish(), ShouldEqual, nil)
+		Wish(t, entries, ShouldEqual, []entry{{"k", ipld.Kind_Int}})
+	})
+}
@@ -0,0 +1,44 @@
+// Package tests provides fuzzing entrypoints and round-trip checks which
+// apply uniformly to all the codecs in this module.
+//
+// The Fuzz functions follow the go-fuzz convention, so they can be wired up
+// directly to go-fuzz or oss-fuzz; each of them also panics if the codec
+// fails to round-trip data it accepted.
+// The SpecTest functions are meant to be called from a downstream test,
+// with that downstream's own corpus of data.
+package tests
+
+import (
+	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec/cbor"
+	"github.com/ipld/go-ipld-prime/codec/dagcbor"
+	"github.com/ipld/go-ipld-prime/codec/dagjson"
+	"github.com/ipld/go-ipld-prime/codec/dagpb"
+	"github.com/ipld/go-ipld-prime/codec/git"
+	"github.com/ipld/go-ipld-prime/codec/json"
+	"github.com/ipld/go-ipld-prime/codec/raw"
+)
+
+// Codec pairs the encoder and decoder of a codec.
+type Codec struct {
+	Name   string
+	Encode ipld.Encoder
+	Decode ipld.Decoder
+}
+
+// The codecs in this module.
+//
+// DagJSON decodes strictly, since maps which only look like links
+// can't be encoded again.
+var (
+	DagCBOR = Codec{"dag-cbor", dagcbor.Encode, dagcbor.Decode}
+	DagJSON = Codec{"dag-json", dagjson.Encode, dagjson.DecodeOptions{Strict: true}.Decode}
+	CBOR    = Codec{"cbor", cbor.Encode, cbor.Decode}
+	JSON    = Codec{"json", json.Encode, json.Decode}
+	DagPB   = Codec{"dag-pb", dagpb.Encode, dagpb.Decode}
+	Raw     = Codec{"raw", raw.Encode, raw.Decode}
+	Git     = Codec{"git-raw", git.Encode, git.Decode}
+)
+
+// AllCodecs lists all the codecs in this module.
+var AllCodecs = []Codec{DagCBOR, DagJSON, CBOR, JSON, DagPB, Raw, Git}
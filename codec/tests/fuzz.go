@@ -0,0 +1,60 @@
+package tests
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Fuzz is a go-fuzz entrypoint for the given codec.
+//
+// It returns 0 if the codec rejects data, and 1 otherwise.
+// If the codec accepts data, Fuzz checks that it round-trips:
+// that encoding the decoded node, and decoding that again, gives an equal
+// node, and that the encoding is stable (data itself may not be canonical).
+// It panics if that's not the case.
+func Fuzz(c Codec, data []byte) int {
+	n, err := decode(c, data)
+	if err != nil {
+		return 0
+	}
+	data2, err := encode(c, n)
+	if err != nil {
+		panic(fmt.Sprintf("%s: cannot re-encode decoded data: %v", c.Name, err))
+	}
+	n2, err := decode(c, data2)
+	if err != nil {
+		panic(fmt.Sprintf("%s: cannot decode re-encoded data: %v", c.Name, err))
+	}
+	if !equal(n, n2) {
+		panic(fmt.Sprintf("%s: node changed after a round-trip", c.Name))
+	}
+	data3, err := encode(c, n2)
+	if err != nil {
+		panic(fmt.Sprintf("%s: cannot re-encode decoded data: %v", c.Name, err))
+	}
+	if !bytes.Equal(data2, data3) {
+		panic(fmt.Sprintf("%s: encoding isn't stable:\n%q\n%q", c.Name, data2, data3))
+	}
+	return 1
+}
+
+// FuzzDagCBOR is a go-fuzz entrypoint for the dag-cbor codec.
+func FuzzDagCBOR(data []byte) int { return Fuzz(DagCBOR, data) }
+
+// FuzzDagJSON is a go-fuzz entrypoint for the dag-json codec.
+func FuzzDagJSON(data []byte) int { return Fuzz(DagJSON, data) }
+
+// FuzzCBOR is a go-fuzz entrypoint for the cbor codec.
+func FuzzCBOR(data []byte) int { return Fuzz(CBOR, data) }
+
+// FuzzJSON is a go-fuzz entrypoint for the json codec.
+func FuzzJSON(data []byte) int { return Fuzz(JSON, data) }
+
+// FuzzDagPB is a go-fuzz entrypoint for the dag-pb codec.
+func FuzzDagPB(data []byte) int { return Fuzz(DagPB, data) }
+
+// FuzzRaw is a go-fuzz entrypoint for the raw codec.
+func FuzzRaw(data []byte) int { return Fuzz(Raw, data) }
+
+// FuzzGit is a go-fuzz entrypoint for the git-raw codec.
+func FuzzGit(data []byte) int { return Fuzz(Git, data) }
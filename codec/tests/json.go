@@ -0,0 +1,32 @@
+package tests
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec/dagjson"
+)
+
+// FromJSON decodes a dag-json string into a node built with np,
+// failing the test if the string can't be decoded.
+func FromJSON(t *testing.T, np ipld.NodePrototype, s string) ipld.Node {
+	t.Helper()
+	nb := np.NewBuilder()
+	if err := dagjson.Decode(nb, strings.NewReader(s)); err != nil {
+		t.Fatalf("dag-json: decode: %v", err)
+	}
+	return nb.Build()
+}
+
+// ToJSON encodes a node as compact dag-json,
+// failing the test if the node can't be encoded.
+func ToJSON(t *testing.T, n ipld.Node) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := (dagjson.EncodeOptions{}).Encode(n, &buf); err != nil {
+		t.Fatalf("dag-json: encode: %v", err)
+	}
+	return buf.String()
+}
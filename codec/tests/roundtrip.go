@@ -0,0 +1,173 @@
+package tests
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+)
+
+func decode(c Codec, data []byte) (ipld.Node, error) {
+	nb := basicnode.Prototype.Any.NewBuilder()
+	if err := c.Decode(nb, bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+	return nb.Build(), nil
+}
+
+func encode(c Codec, n ipld.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := c.Encode(n, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// CheckRoundTrip decodes data with the codec, encodes the result again,
+// and checks that the encoding is identical to data.
+//
+// This only holds for data in the codec's canonical form;
+// for example, json with extra whitespace will not round-trip.
+func CheckRoundTrip(c Codec, data []byte) error {
+	n, err := decode(c, data)
+	if err != nil {
+		return fmt.Errorf("%s: decode: %v", c.Name, err)
+	}
+	data2, err := encode(c, n)
+	if err != nil {
+		return fmt.Errorf("%s: re-encode: %v", c.Name, err)
+	}
+	if !bytes.Equal(data, data2) {
+		return fmt.Errorf("%s: re-encoded data differs:\n%q\n%q", c.Name, data, data2)
+	}
+	return nil
+}
+
+// CheckCrossCodec encodes the node with each of the codecs,
+// decodes the results, and checks that they all equal the node.
+func CheckCrossCodec(n ipld.Node, codecs ...Codec) error {
+	for _, c := range codecs {
+		data, err := encode(c, n)
+		if err != nil {
+			return fmt.Errorf("%s: encode: %v", c.Name, err)
+		}
+		n2, err := decode(c, data)
+		if err != nil {
+			return fmt.Errorf("%s: decode: %v", c.Name, err)
+		}
+		if !equal(n, n2) {
+			return fmt.Errorf("%s: decoded node differs from the original", c.Name)
+		}
+	}
+	return nil
+}
+
+// SpecTestRoundTrip runs CheckRoundTrip on each entry of a corpus,
+// each in a subtest named after its key.
+func SpecTestRoundTrip(t *testing.T, c Codec, corpus map[string][]byte) {
+	for _, name := range sortedKeys(corpus) {
+		data := corpus[name]
+		t.Run(name, func(t *testing.T) {
+			if err := CheckRoundTrip(c, data); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// SpecTestCrossCodec decodes each entry of a corpus with the given codec,
+// and runs CheckCrossCodec on the result with the other codecs,
+// each in a subtest named after its key.
+func SpecTestCrossCodec(t *testing.T, c Codec, corpus map[string][]byte, others ...Codec) {
+	for _, name := range sortedKeys(corpus) {
+		data := corpus[name]
+		t.Run(name, func(t *testing.T) {
+			n, err := decode(c, data)
+			if err != nil {
+				t.Fatalf("%s: decode: %v", c.Name, err)
+			}
+			if err := CheckCrossCodec(n, others...); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func sortedKeys(corpus map[string][]byte) []string {
+	keys := make([]string, 0, len(corpus))
+	for k := range corpus {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// equal reports whether two nodes hold the same data model values,
+// regardless of their implementation.
+// NaN floats are considered equal to each other, so that they round-trip.
+func equal(a, b ipld.Node) bool {
+	if a.Kind() != b.Kind() {
+		return false
+	}
+	switch a.Kind() {
+	case ipld.Kind_Null:
+		return true
+	case ipld.Kind_Bool:
+		x, err1 := a.AsBool()
+		y, err2 := b.AsBool()
+		return err1 == nil && err2 == nil && x == y
+	case ipld.Kind_Int:
+		x, err1 := ipld.AsBigInt(a)
+		y, err2 := ipld.AsBigInt(b)
+		return err1 == nil && err2 == nil && x.Cmp(y) == 0
+	case ipld.Kind_Float:
+		x, err1 := a.AsFloat()
+		y, err2 := b.AsFloat()
+		return err1 == nil && err2 == nil && math.Float64bits(x) == math.Float64bits(y)
+	case ipld.Kind_String:
+		x, err1 := a.AsString()
+		y, err2 := b.AsString()
+		return err1 == nil && err2 == nil && x == y
+	case ipld.Kind_Bytes:
+		x, err1 := a.AsBytes()
+		y, err2 := b.AsBytes()
+		return err1 == nil && err2 == nil && bytes.Equal(x, y)
+	case ipld.Kind_Link:
+		x, err1 := a.AsLink()
+		y, err2 := b.AsLink()
+		return err1 == nil && err2 == nil && x.String() == y.String()
+	case ipld.Kind_List:
+		if a.Length() != b.Length() {
+			return false
+		}
+		for i := int64(0); i < a.Length(); i++ {
+			x, err1 := a.LookupByIndex(i)
+			y, err2 := b.LookupByIndex(i)
+			if err1 != nil || err2 != nil || !equal(x, y) {
+				return false
+			}
+		}
+		return true
+	case ipld.Kind_Map:
+		if a.Length() != b.Length() {
+			return false
+		}
+		for itr := a.MapIterator(); !itr.Done(); {
+			k, x, err := itr.Next()
+			if err != nil {
+				return false
+			}
+			y, err := b.LookupByNode(k)
+			if err != nil || !equal(x, y) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"testing"
+)
+
+var corpora = map[string]map[string][]byte{
+	"dag-cbor": {
+		"map":        []byte("\xa2aa\x01ab\x82\xf5\xf6"),
+		"bytes":      []byte("C\x01\x02\x03"),
+		"link":       []byte("\xd8\x2a\x58\x25\x00\x01\x71\x12\x20" + string(make([]byte, 32))),
+		"2^63":       []byte("\x1b\x80\x00\x00\x00\x00\x00\x00\x00"),
+		"max uint64": []byte("\x1b\xff\xff\xff\xff\xff\xff\xff\xff"),
+	},
+	"dag-json": {
+		"map":        []byte("{\n\t\"a\": 1,\n\t\"b\": [\n\t\ttrue,\n\t\tnull\n\t]\n}\n"),
+		"link":       []byte("{\n\t\"/\": \"bafyreiaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\"\n}\n"),
+		"float":      []byte("1.5"),
+		"2^63":       []byte("9223372036854775808"),
+		"max uint64": []byte("18446744073709551615"),
+		"bytes":      []byte("{\n\t\"/\": {\n\t\t\"bytes\": \"AQID\"\n\t}\n}\n"),
+	},
+	"cbor": {
+		"bytes": []byte("C\x01\x02\x03"),
+	},
+	"json": {
+		"link-like map": []byte("{\n\t\"/\": \"x\"\n}\n"),
+	},
+	"dag-pb": {
+		"data": []byte("\x0a\x02hi"),
+	},
+	"raw": {
+		"bytes": []byte("hello"),
+	},
+	"git-raw": {
+		"blob": []byte("blob 6\x00hello\n"),
+	},
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, c := range AllCodecs {
+		t.Run(c.Name, func(t *testing.T) {
+			SpecTestRoundTrip(t, c, corpora[c.Name])
+		})
+	}
+}
+
+func TestCrossCodec(t *testing.T) {
+	SpecTestCrossCodec(t, DagCBOR, corpora["dag-cbor"], DagJSON)
+	SpecTestCrossCodec(t, DagJSON, corpora["dag-json"], DagCBOR)
+}
+
+func TestFuzz(t *testing.T) {
+	for _, c := range AllCodecs {
+		t.Run(c.Name, func(t *testing.T) {
+			for name, data := range corpora[c.Name] {
+				if Fuzz(c, data) != 1 {
+					t.Errorf("%s was rejected", name)
+				}
+			}
+			// Fuzz inputs aren't necessarily canonical, or even valid;
+			// Fuzz must not panic on any of these.
+			for _, data := range []string{"", "\x00", "\xff\xff", "{", "[1, 2]", "\x9f\x01\xff"} {
+				Fuzz(c, []byte(data))
+			}
+		})
+	}
+}
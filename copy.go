@@ -0,0 +1,113 @@
+package ipld
+
+import (
+	"fmt"
+)
+
+// Copy reproduces the content of a node into an assembler, one value at a time.
+//
+// Any node can be copied into any assembler which accepts its data,
+// so Copy is the usual way of converting data from one node implementation to another:
+// for example, from basicnode into the types generated from a schema, or back again.
+// (To copy a typed node as its representation, rather than at the type level, pass its Representation node.)
+//
+// Map entries which are absent, such as optional fields which a typed struct doesn't have, are left out;
+// null values are copied as null, leaving it to the assembler to reject them if they're not nullable.
+// Links are copied as they are, without loading them.
+//
+// Copy returns the first error from an assembler, or from reading the node.
+// It's an error to copy an absent node itself.
+//
+// Unlike NodeAssembler.AssignNode, which is free to take shortcuts when it recognizes the node
+// (and in generic implementations is often implemented much like Copy),
+// Copy always walks the whole node.
+func Copy(dst NodeAssembler, src Node) error {
+	switch src.Kind() {
+	case Kind_Null:
+		if src.IsAbsent() {
+			return fmt.Errorf("copy: cannot copy an absent node")
+		}
+		return dst.AssignNull()
+	case Kind_Bool:
+		v, err := src.AsBool()
+		if err != nil {
+			return err
+		}
+		return dst.AssignBool(v)
+	case Kind_Int:
+		if src2, ok := src.(BigIntNode); ok {
+			v, err := src2.AsBigInt()
+			if err != nil {
+				return err
+			}
+			return AssignBigInt(dst, v)
+		}
+		v, err := src.AsInt()
+		if err != nil {
+			return err
+		}
+		return dst.AssignInt(v)
+	case Kind_Float:
+		v, err := src.AsFloat()
+		if err != nil {
+			return err
+		}
+		return dst.AssignFloat(v)
+	case Kind_String:
+		v, err := src.AsString()
+		if err != nil {
+			return err
+		}
+		return dst.AssignString(v)
+	case Kind_Bytes:
+		v, err := src.AsBytes()
+		if err != nil {
+			return err
+		}
+		return dst.AssignBytes(v)
+	case Kind_Link:
+		v, err := src.AsLink()
+		if err != nil {
+			return err
+		}
+		return dst.AssignLink(v)
+	case Kind_Map:
+		ma, err := dst.BeginMap(src.Length())
+		if err != nil {
+			return err
+		}
+		for itr := src.MapIterator(); !itr.Done(); {
+			k, v, err := itr.Next()
+			if err != nil {
+				return err
+			}
+			if v.IsAbsent() {
+				continue
+			}
+			if err := Copy(ma.AssembleKey(), k); err != nil {
+				return err
+			}
+			if err := Copy(ma.AssembleValue(), v); err != nil {
+				return err
+			}
+		}
+		return ma.Finish()
+	case Kind_List:
+		la, err := dst.BeginList(src.Length())
+		if err != nil {
+			return err
+		}
+		for itr := src.ListIterator(); !itr.Done(); {
+			_, v, err := itr.Next()
+			if err != nil {
+				return err
+			}
+			if err := Copy(la.AssembleValue(), v); err != nil {
+				return err
+			}
+		}
+		return la.Finish()
+	default:
+		return fmt.Errorf("copy: cannot copy a node of kind %s", src.Kind())
+	}
+}
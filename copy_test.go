@@ -0,0 +1,92 @@
+package ipld_test
+
+import (
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec/dagjson"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/node/gendemo"
+	"github.com/ipld/go-ipld-prime/schema"
+	schemadmt "github.com/ipld/go-ipld-prime/schema/dmt"
+)
+
+func TestCopy(t *testing.T) {
+	fromJSON := func(t *testing.T, np ipld.NodePrototype, s string) ipld.Node {
+		nb := np.NewBuilder()
+		Require(t, dagjson.Decode(nb, strings.NewReader(s)), ShouldEqual, nil)
+		return nb.Build()
+	}
+	copyInto := func(np ipld.NodePrototype, n ipld.Node) (ipld.Node, error) {
+		nb := np.NewBuilder()
+		if err := ipld.Copy(nb, n); err != nil {
+			return nil, err
+		}
+		return nb.Build(), nil
+	}
+	any := basicnode.Prototype.Any
+
+	t.Run("generic", func(t *testing.T) {
+		for _, n := range []ipld.Node{
+			ipld.Null,
+			basicnode.NewBool(true),
+			basicnode.NewInt(12),
+			basicnode.NewFloat(1.5),
+			basicnode.NewString("x"),
+			fromJSON(t, any, `{"/":{"bytes":"YWI"}}`),
+			fromJSON(t, any, `{"/":"bafkqaaa"}`),
+			fromJSON(t, any, `[1,[null],{"a":[]}]`),
+			fromJSON(t, any, `{"b":1,"a":{"c":null,"d":[{}]}}`),
+			fromJSON(t, any, `[9223372036854775808,18446744073709551615]`),
+		} {
+			n2, err := copyInto(any, n)
+			Require(t, err, ShouldEqual, nil)
+			Wish(t, ipld.DeepEqual(n2, n), ShouldEqual, true)
+		}
+	})
+	t.Run("generic to generated", func(t *testing.T) {
+		s := `{"a":{"whee":1,"woot":2,"waga":3}}`
+		n, err := copyInto(gendemo.Type.Map__String__Msg3, fromJSON(t, any, s))
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, n, ShouldEqual, fromJSON(t, gendemo.Type.Map__String__Msg3, s))
+	})
+	t.Run("generated to generic", func(t *testing.T) {
+		s := `{"a":{"whee":1,"woot":2,"waga":3}}`
+		n, err := copyInto(any, fromJSON(t, gendemo.Type.Map__String__Msg3, s))
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, n, ShouldEqual, fromJSON(t, any, s))
+	})
+	t.Run("absent fields are left out", func(t *testing.T) {
+		typed := fromJSON(t, schemadmt.Type.StructRepresentation_Map_FieldDetails__Repr, `{"rename":"x"}`)
+		implicit, err := typed.LookupByString("implicit")
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, implicit.IsAbsent(), ShouldEqual, true)
+
+		n, err := copyInto(any, typed)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, n, ShouldEqual, fromJSON(t, any, `{"rename":"x"}`))
+
+		n, err = copyInto(schemadmt.Type.StructRepresentation_Map_FieldDetails, typed)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, ipld.DeepEqual(n.(schema.TypedNode).Representation(), typed.(schema.TypedNode).Representation()), ShouldEqual, true)
+
+		_, err = copyInto(any, implicit)
+		Wish(t, err != nil, ShouldEqual, true)
+	})
+	t.Run("big ints", func(t *testing.T) {
+		n := fromJSON(t, any, `18446744073709551615`)
+		_, err := copyInto(basicnode.Prototype.Int, n)
+		Wish(t, err, ShouldEqual, ipld.ErrIntOverflow{Value: new(big.Int).SetUint64(math.MaxUint64)})
+	})
+	t.Run("mismatched data", func(t *testing.T) {
+		_, err := copyInto(gendemo.Type.Msg3, fromJSON(t, any, `{"whee":"not an int"}`))
+		Wish(t, err != nil, ShouldEqual, true)
+		_, err = copyInto(gendemo.Type.Msg3, fromJSON(t, any, `[1]`))
+		Wish(t, err != nil, ShouldEqual, true)
+	})
+}
@@ -18,9 +18,8 @@
 // "link" reference), the next types you should look at are:
 //
 //   - Link
-//   - LinkBuilder
-//   - Loader
-//   - Storer
+//   - LinkPrototype
+//   - LinkSystem
 //
 // All of these types are interfaces.  There are several implementations you
 // can choose; we've provided some in subpackages, or you can bring your own.
@@ -30,7 +29,7 @@
 //   - node/* -- various Node + NodeBuilder implementations
 //   - node/basic -- the first Node implementation you should try
 //   - codec/* -- functions for serializing and deserializing Nodes
-//   - linking/* -- various Link + LinkBuilder implementations
+//   - linking/* -- various Link + LinkPrototype implementations
 //   - traversal -- functions for walking Node graphs (including
 //        automatic link loading) and visiting
 //   - must -- helpful functions for streamlining error handling
@@ -0,0 +1,238 @@
+// Package dump renders nodes as indented text, for humans to read:
+// in logs, in debuggers, and in the messages of failing tests.
+//
+// Every value is annotated with its kind, and typed nodes with their type name as well,
+// so that the output shows what a node holds, rather than how it's implemented
+// (which is all that fmt's %v and %#v can show).
+// Links are abbreviated, and bytes are shown in hex, up to a limit, to keep the output readable.
+//
+// For example, dumping a map built from the dag-json `{"a":[1,"x"],"l":{"/":"bafkqaaa"}}` gives:
+//
+//	map {
+//		"a": list [
+//			0: int 1
+//			1: string "x"
+//		]
+//		"l": link bafkqaaa
+//	}
+//
+// The format is meant for reading, and may change; don't parse it, or compare it in tests.
+// Use a codec to serialize data, and ipld.DeepEqual to compare it.
+package dump
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// Config controls how Dump renders nodes.
+// Its zero value uses the defaults described on each field.
+type Config struct {
+	// Indent is the indentation for each level of nesting.
+	// If empty, a tab is used.
+	Indent string
+
+	// MaxBytes is how many bytes of a bytes value are shown;
+	// the length of the whole value is always shown too.
+	// If zero, 32 bytes are shown.  If negative, all bytes are shown.
+	MaxBytes int
+
+	// FullLinks shows links in full.
+	// Otherwise, links longer than 16 characters only show their first and last 8.
+	FullLinks bool
+}
+
+// Dump renders a node with the default config.
+func Dump(n ipld.Node) string {
+	return Config{}.Dump(n)
+}
+
+// Dump renders a node, ending with a newline.
+//
+// Errors from reading the node, such as from an iterator, don't stop the rendering:
+// they show up in the output in place of the values that couldn't be read.
+func (cfg Config) Dump(n ipld.Node) string {
+	var buf bytes.Buffer
+	p := printer{cfg, &buf}
+	p.node(n, 0)
+	buf.WriteByte('\n')
+	return buf.String()
+}
+
+// Fprint renders a node into a writer, like Dump.
+func (cfg Config) Fprint(w io.Writer, n ipld.Node) error {
+	_, err := io.WriteString(w, cfg.Dump(n))
+	return err
+}
+
+type printer struct {
+	cfg Config
+	buf *bytes.Buffer
+}
+
+func (p printer) indent(depth int) {
+	ind := p.cfg.Indent
+	if ind == "" {
+		ind = "\t"
+	}
+	for i := 0; i < depth; i++ {
+		p.buf.WriteString(ind)
+	}
+}
+
+func (p printer) err(err error) {
+	fmt.Fprintf(p.buf, "!error(%s)", err)
+}
+
+// node writes a node, without any leading indentation or trailing newline.
+// Maps and lists go over several lines, with their contents indented deeper than depth.
+func (p printer) node(n ipld.Node, depth int) {
+	if n == nil {
+		p.buf.WriteString("<nil>")
+		return
+	}
+	if n.IsAbsent() {
+		p.buf.WriteString("absent")
+		return
+	}
+	p.buf.WriteString(n.Kind().String())
+	if t := typeOf(n); t != nil {
+		fmt.Fprintf(p.buf, "<%s>", t.Name())
+	}
+
+	switch n.Kind() {
+	case ipld.Kind_Map:
+		if n.Length() == 0 {
+			p.buf.WriteString(" {}")
+			return
+		}
+		p.buf.WriteString(" {\n")
+		for itr := n.MapIterator(); !itr.Done(); {
+			k, v, err := itr.Next()
+			p.indent(depth + 1)
+			if err != nil {
+				p.err(err)
+				p.buf.WriteByte('\n')
+				break
+			}
+			p.key(k, depth+1)
+			p.buf.WriteString(": ")
+			p.node(v, depth+1)
+			p.buf.WriteByte('\n')
+		}
+		p.indent(depth)
+		p.buf.WriteByte('}')
+	case ipld.Kind_List:
+		if n.Length() == 0 {
+			p.buf.WriteString(" []")
+			return
+		}
+		p.buf.WriteString(" [\n")
+		for itr := n.ListIterator(); !itr.Done(); {
+			idx, v, err := itr.Next()
+			p.indent(depth + 1)
+			if err != nil {
+				p.err(err)
+				p.buf.WriteByte('\n')
+				break
+			}
+			fmt.Fprintf(p.buf, "%d: ", idx)
+			p.node(v, depth+1)
+			p.buf.WriteByte('\n')
+		}
+		p.indent(depth)
+		p.buf.WriteByte(']')
+	case ipld.Kind_Null:
+	default:
+		p.buf.WriteByte(' ')
+		p.scalar(n)
+	}
+}
+
+// key writes a map key: plain string keys are just quoted,
+// while keys which are typed, or aren't strings, are written in full.
+func (p printer) key(k ipld.Node, depth int) {
+	if typeOf(k) == nil && k.Kind() == ipld.Kind_String {
+		p.scalar(k)
+		return
+	}
+	p.node(k, depth)
+}
+
+// typeOf returns the schema type of a typed node, or nil if it's untyped.
+// Some typed nodes don't know their type, such as those from codegen at the moment,
+// and they're treated as untyped.
+func typeOf(n ipld.Node) schema.Type {
+	if tn, ok := n.(schema.TypedNode); ok {
+		return tn.Type()
+	}
+	return nil
+}
+
+// scalar writes the value of a scalar node, without its kind.
+func (p printer) scalar(n ipld.Node) {
+	switch n.Kind() {
+	case ipld.Kind_Bool:
+		v, err := n.AsBool()
+		if err != nil {
+			p.err(err)
+			return
+		}
+		p.buf.WriteString(strconv.FormatBool(v))
+	case ipld.Kind_Int:
+		v, err := n.AsInt()
+		if err != nil {
+			p.err(err)
+			return
+		}
+		p.buf.WriteString(strconv.FormatInt(v, 10))
+	case ipld.Kind_Float:
+		v, err := n.AsFloat()
+		if err != nil {
+			p.err(err)
+			return
+		}
+		p.buf.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
+	case ipld.Kind_String:
+		v, err := n.AsString()
+		if err != nil {
+			p.err(err)
+			return
+		}
+		p.buf.WriteString(strconv.Quote(v))
+	case ipld.Kind_Bytes:
+		v, err := n.AsBytes()
+		if err != nil {
+			p.err(err)
+			return
+		}
+		max := p.cfg.MaxBytes
+		if max == 0 {
+			max = 32
+		}
+		fmt.Fprintf(p.buf, "(%d) ", len(v))
+		if max < 0 || len(v) <= max {
+			p.buf.WriteString(hex.EncodeToString(v))
+		} else {
+			p.buf.WriteString(hex.EncodeToString(v[:max]))
+			p.buf.WriteString("…")
+		}
+	case ipld.Kind_Link:
+		v, err := n.AsLink()
+		if err != nil {
+			p.err(err)
+			return
+		}
+		s := v.String()
+		if !p.cfg.FullLinks && len(s) > 16 {
+			s = s[:8] + "…" + s[len(s)-8:]
+		}
+		p.buf.WriteString(s)
+	}
+}
@@ -0,0 +1,101 @@
+package dump
+
+import (
+	"strings"
+	"testing"
+
+	cid "github.com/ipfs/go-cid"
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec/tests"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/node/gendemo"
+	"github.com/ipld/go-ipld-prime/node/wrapnode"
+	"github.com/ipld/go-ipld-prime/schema"
+	schemaparser "github.com/ipld/go-ipld-prime/schema/parser"
+)
+
+func TestDump(t *testing.T) {
+	n := tests.FromJSON(t, basicnode.Prototype.Any, `{
+		"a": [1, "x", 1.5, true, null],
+		"b": {"c": {}, "d": []},
+		"l": {"/": "bafkqaaa"},
+		"x": {"/": {"bytes": "AAEC"}}
+	}`)
+	Wish(t, Dump(n), ShouldEqual, strings.Join([]string{
+		`map {`,
+		`	"a": list [`,
+		`		0: int 1`,
+		`		1: string "x"`,
+		`		2: float 1.5`,
+		`		3: bool true`,
+		`		4: null`,
+		`	]`,
+		`	"b": map {`,
+		`		"c": map {}`,
+		`		"d": list []`,
+		`	}`,
+		`	"l": link bafkqaaa`,
+		`	"x": bytes (3) 000102`,
+		`}`,
+		``,
+	}, "\n"))
+
+	Wish(t, Dump(basicnode.NewString("just a string")), ShouldEqual, `string "just a string"`+"\n")
+	Wish(t, Dump(ipld.Absent), ShouldEqual, "absent\n")
+	Wish(t, Dump(nil), ShouldEqual, "<nil>\n")
+}
+
+func TestDumpTyped(t *testing.T) {
+	ts, err := schemaparser.Parse(strings.NewReader(`
+		type Point struct {
+			x Int
+			tags {String:Int}
+			y optional Int
+		} representation tuple
+	`))
+	Require(t, err, ShouldEqual, nil)
+	n := tests.FromJSON(t, wrapnode.Prototype(ts, "Point"), `[1,{"a":2}]`)
+	Wish(t, Dump(n), ShouldEqual, strings.Join([]string{
+		`map<Point> {`,
+		`	"x": int<Int> 1`,
+		`	"tags": map<Map__String__Int> {`,
+		`		string<String> "a": int<Int> 2`,
+		`	}`,
+		`	"y": absent`,
+		`}`,
+		``,
+	}, "\n"))
+	Wish(t, Dump(n.(schema.TypedNode).Representation()), ShouldEqual, strings.Join([]string{
+		`list [`,
+		`	0: int 1`,
+		`	1: map {`,
+		`		"a": int 2`,
+		`	}`,
+		`]`,
+		``,
+	}, "\n"))
+
+	// Generated nodes don't know their types yet, so they look untyped.
+	n = tests.FromJSON(t, gendemo.Type.Msg3, `{"whee":1,"woot":2,"waga":3}`)
+	Wish(t, Dump(n), ShouldEqual, "map {\n\t\"whee\": int 1\n\t\"woot\": int 2\n\t\"waga\": int 3\n}\n")
+}
+
+func TestDumpConfig(t *testing.T) {
+	c, err := cid.Decode("bafyreidykglsfhoixmivffc5uwhcgshx4j465xwqntbmu43nb2dzqwfvae")
+	Require(t, err, ShouldEqual, nil)
+	n := tests.FromJSON(t, basicnode.Prototype.Any, `{"a":[1]}`)
+	link := basicnode.NewLink(cidlink.Link{Cid: c})
+	long := basicnode.NewBytes([]byte(strings.Repeat("\xff", 40)))
+
+	Wish(t, Dump(link), ShouldEqual, "link bafyreid…dzqwfvae\n")
+	Wish(t, Config{FullLinks: true}.Dump(link), ShouldEqual, "link "+c.String()+"\n")
+
+	Wish(t, Dump(long), ShouldEqual, "bytes (40) "+strings.Repeat("ff", 32)+"…\n")
+	Wish(t, Config{MaxBytes: 2}.Dump(long), ShouldEqual, "bytes (40) ffff…\n")
+	Wish(t, Config{MaxBytes: -1}.Dump(long), ShouldEqual, "bytes (40) "+strings.Repeat("ff", 40)+"\n")
+
+	Wish(t, Config{Indent: "  "}.Dump(n), ShouldEqual, "map {\n  \"a\": list [\n    0: int 1\n  ]\n}\n")
+}
@@ -0,0 +1,152 @@
+package ipld
+
+import (
+	"bytes"
+)
+
+// DeepEqual reports whether x and y are equal at the Data Model level.
+//
+// Two nodes are equal if they have the same kind and the same content:
+// scalars compare by value, links compare by their String form
+// (which the Link contract requires to be unique, while not all Link types
+// are comparable with ==),
+// and maps and lists compare entry by entry, recursively, in iteration order.
+// (In the IPLD Data Model, the order of map entries is part of the data,
+// so two maps with the same entries in different orders are not equal.)
+//
+// Nodes may come from different implementations: only the Data Model view of them is compared,
+// so for example a basicnode string and a string from a schema type with the same content are equal.
+// To compare the representations of typed nodes, pass their Representation nodes.
+// Absent values are only equal to other absent values, and not to null.
+// Two nil nodes are equal, but a nil node isn't equal to anything else.
+//
+// DeepEqual is meant for comparing nodes that are already in memory.
+// If either node returns an error while being read (such as a node which loads data lazily),
+// DeepEqual panics with that error, since it can't make any decision about equality.
+func DeepEqual(x, y Node) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	xk, yk := x.Kind(), y.Kind()
+	if xk != yk {
+		return false
+	}
+
+	switch xk {
+	case Kind_Null:
+		return x.IsAbsent() == y.IsAbsent()
+	case Kind_Bool:
+		xv, err := x.AsBool()
+		if err != nil {
+			panic(err)
+		}
+		yv, err := y.AsBool()
+		if err != nil {
+			panic(err)
+		}
+		return xv == yv
+	case Kind_Int:
+		_, xbig := x.(BigIntNode)
+		_, ybig := y.(BigIntNode)
+		if xbig || ybig {
+			xv, err := AsBigInt(x)
+			if err != nil {
+				panic(err)
+			}
+			yv, err := AsBigInt(y)
+			if err != nil {
+				panic(err)
+			}
+			return xv.Cmp(yv) == 0
+		}
+		xv, err := x.AsInt()
+		if err != nil {
+			panic(err)
+		}
+		yv, err := y.AsInt()
+		if err != nil {
+			panic(err)
+		}
+		return xv == yv
+	case Kind_Float:
+		xv, err := x.AsFloat()
+		if err != nil {
+			panic(err)
+		}
+		yv, err := y.AsFloat()
+		if err != nil {
+			panic(err)
+		}
+		return xv == yv
+	case Kind_String:
+		xv, err := x.AsString()
+		if err != nil {
+			panic(err)
+		}
+		yv, err := y.AsString()
+		if err != nil {
+			panic(err)
+		}
+		return xv == yv
+	case Kind_Bytes:
+		xv, err := x.AsBytes()
+		if err != nil {
+			panic(err)
+		}
+		yv, err := y.AsBytes()
+		if err != nil {
+			panic(err)
+		}
+		return bytes.Equal(xv, yv)
+	case Kind_Link:
+		xv, err := x.AsLink()
+		if err != nil {
+			panic(err)
+		}
+		yv, err := y.AsLink()
+		if err != nil {
+			panic(err)
+		}
+		return xv.String() == yv.String()
+	case Kind_Map:
+		if x.Length() != y.Length() {
+			return false
+		}
+		xitr, yitr := x.MapIterator(), y.MapIterator()
+		for !xitr.Done() && !yitr.Done() {
+			xk, xv, err := xitr.Next()
+			if err != nil {
+				panic(err)
+			}
+			yk, yv, err := yitr.Next()
+			if err != nil {
+				panic(err)
+			}
+			if !DeepEqual(xk, yk) || !DeepEqual(xv, yv) {
+				return false
+			}
+		}
+		return xitr.Done() && yitr.Done()
+	case Kind_List:
+		if x.Length() != y.Length() {
+			return false
+		}
+		xitr, yitr := x.ListIterator(), y.ListIterator()
+		for !xitr.Done() && !yitr.Done() {
+			_, xv, err := xitr.Next()
+			if err != nil {
+				panic(err)
+			}
+			_, yv, err := yitr.Next()
+			if err != nil {
+				panic(err)
+			}
+			if !DeepEqual(xv, yv) {
+				return false
+			}
+		}
+		return xitr.Done() && yitr.Done()
+	default:
+		panic("unreachable: a node of Kind_Invalid isn't a valid node")
+	}
+}
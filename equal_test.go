@@ -0,0 +1,92 @@
+package ipld_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+
+	cid "github.com/ipfs/go-cid"
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec/dagjson"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/node/gendemo"
+)
+
+func TestDeepEqual(t *testing.T) {
+	fromJSON := func(np ipld.NodePrototype, s string) ipld.Node {
+		nb := np.NewBuilder()
+		Require(t, dagjson.Decode(nb, strings.NewReader(s)), ShouldEqual, nil)
+		return nb.Build()
+	}
+	link := func(s string) ipld.Node {
+		c, err := cid.Decode(s)
+		Require(t, err, ShouldEqual, nil)
+		return basicnode.NewLink(cidlink.Link{Cid: c})
+	}
+	any := basicnode.Prototype.Any
+	msg3 := `{"whee":1,"woot":2,"waga":3}`
+
+	for _, tc := range []struct {
+		name  string
+		x, y  ipld.Node
+		equal bool
+	}{
+		{"nil", nil, nil, true},
+		{"nil and null", nil, ipld.Null, false},
+		{"null", ipld.Null, ipld.Null, true},
+		{"absent", ipld.Absent, ipld.Absent, true},
+		{"absent and null", ipld.Absent, ipld.Null, false},
+		{"bool", basicnode.NewBool(true), basicnode.NewBool(true), true},
+		{"different bool", basicnode.NewBool(true), basicnode.NewBool(false), false},
+		{"int", basicnode.NewInt(3), basicnode.NewInt(3), true},
+		{"int and float", basicnode.NewInt(3), basicnode.NewFloat(3), false},
+		{"float", basicnode.NewFloat(1.5), basicnode.NewFloat(1.5), true},
+		{"NaN", basicnode.NewFloat(math.NaN()), basicnode.NewFloat(math.NaN()), false},
+		{"string", basicnode.NewString("a"), basicnode.NewString("a"), true},
+		{"string and bytes", basicnode.NewString("a"), basicnode.NewBytes([]byte("a")), false},
+		{"bytes", basicnode.NewBytes([]byte("a")), basicnode.NewBytes([]byte("a")), true},
+		{"nil and empty bytes", basicnode.NewBytes(nil), basicnode.NewBytes([]byte{}), true},
+		{"link", link("bafkqaaa"), link("bafkqaaa"), true},
+		{"different link", link("bafkqaaa"), link("bafkqaalb"), false},
+		{"slice link", basicnode.NewLink(bytesLink{1, 2}), basicnode.NewLink(bytesLink{1, 2}), true},
+		{"different slice link", basicnode.NewLink(bytesLink{1, 2}), basicnode.NewLink(bytesLink{1, 3}), false},
+		{"list", fromJSON(any, `[1,[2],"x"]`), fromJSON(any, `[1,[2],"x"]`), true},
+		{"shorter list", fromJSON(any, `[1,2]`), fromJSON(any, `[1,2,3]`), false},
+		{"list with different values", fromJSON(any, `[1,[2]]`), fromJSON(any, `[1,[3]]`), false},
+		{"map", fromJSON(any, `{"a":1,"b":{"c":null}}`), fromJSON(any, `{"a":1,"b":{"c":null}}`), true},
+		{"map with different order", fromJSON(any, `{"a":1,"b":2}`), fromJSON(any, `{"b":2,"a":1}`), false},
+		{"map with different keys", fromJSON(any, `{"a":1}`), fromJSON(any, `{"b":1}`), false},
+		{"bigger map", fromJSON(any, `{"a":1}`), fromJSON(any, `{"a":1,"b":2}`), false},
+		{"map and list", fromJSON(any, `{}`), fromJSON(any, `[]`), false},
+
+		// Different implementations of the same data are equal.
+		{"typed string", fromJSON(gendemo.Type.String, `"a"`), basicnode.NewString("a"), true},
+		{"typed struct", fromJSON(gendemo.Type.Msg3, msg3), fromJSON(any, msg3), true},
+		{"typed struct and different map", fromJSON(gendemo.Type.Msg3, msg3), fromJSON(any, `{"whee":1,"woot":2,"waga":4}`), false},
+		{"typed map", fromJSON(gendemo.Type.Map__String__Msg3, `{"a":`+msg3+`}`), fromJSON(any, `{"a":`+msg3+`}`), true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			Wish(t, ipld.DeepEqual(tc.x, tc.y), ShouldEqual, tc.equal)
+			Wish(t, ipld.DeepEqual(tc.y, tc.x), ShouldEqual, tc.equal)
+		})
+	}
+}
+
+// bytesLink is a link type which can't be compared with ==.
+type bytesLink []byte
+
+func (l bytesLink) Prototype() ipld.LinkPrototype { return nil }
+func (l bytesLink) String() string                { return fmt.Sprintf("%x", []byte(l)) }
+
+func TestDeepEqualBigInt(t *testing.T) {
+	big1 := new(big.Int).SetUint64(math.MaxUint64)
+	big2 := new(big.Int).Add(big1, big.NewInt(0))
+	Wish(t, ipld.DeepEqual(basicnode.NewBigInt(big1), basicnode.NewBigInt(big2)), ShouldEqual, true)
+	Wish(t, ipld.DeepEqual(basicnode.NewBigInt(big1), basicnode.NewInt(math.MaxInt64)), ShouldEqual, false)
+	Wish(t, ipld.DeepEqual(basicnode.NewInt(3), basicnode.NewBigInt(big.NewInt(3))), ShouldEqual, true)
+}
@@ -100,6 +100,8 @@ func (e ErrInvalidKey) Error() string {
 	}
 }
 
+func (e ErrInvalidKey) Unwrap() error { return e.Reason }
+
 // ErrInvalidSegmentForList is returned when using Node.LookupBySegment and the
 // given PathSegment can't be applied to a list because it's unparsable as a number.
 type ErrInvalidSegmentForList struct {
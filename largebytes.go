@@ -0,0 +1,15 @@
+package ipld
+
+import "io"
+
+// LargeBytesNode is an optional interface for bytes-kind nodes whose values
+// may be too large to hold in memory all at once,
+// such as files which are split across many blocks.
+// AsBytes on such a node may need to read and concatenate all of its data;
+// AsLargeBytes lets callers read only the parts they need instead.
+type LargeBytesNode interface {
+	Node
+	// AsLargeBytes returns a reader over the node's bytes,
+	// which can also seek to any offset within them.
+	AsLargeBytes() (io.ReadSeeker, error)
+}
@@ -1,8 +1,12 @@
 package ipld
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"hash"
 	"io"
+	"time"
 )
 
 // This file contains all the functions on LinkSystem.
@@ -19,18 +23,53 @@ import (
 // Can we get as far as a `QuickLoad(lnk Link) (Node, error)` function, which doesn't even ask you for a NodePrototype?
 //  No, not quite.  (Alas.)  If we tried to do so, and make it use `basicnode.Prototype`, we'd have import cycles; ded.
 
+// Load loads the data for the given link, and builds a node from it with np.
+// If np is nil, the LinkSystem's NodePrototypeChooser picks the prototype.
+// If the LinkSystem has a NodeCache, a cached node is returned when there is one,
+// without reading the block nor reporting the load to the Observer.
 func (lsys *LinkSystem) Load(lnkCtx LinkContext, lnk Link, np NodePrototype) (Node, error) {
-	nb := np.NewBuilder()
-	if err := lsys.Fill(lnkCtx, lnk, nb); err != nil {
+	if np == nil {
+		if lsys.NodePrototypeChooser == nil {
+			return nil, ErrLinkingSetup{"could not choose a node prototype", fmt.Errorf("no prototype given, and no NodePrototypeChooser configured")}
+		}
+		var err error
+		np, err = lsys.NodePrototypeChooser(lnk, lnkCtx)
+		if err != nil {
+			return nil, ErrLinkingSetup{"could not choose a node prototype", err}
+		}
+	}
+	nd, err := lsys.loadNode(lnkCtx, lnk, np)
+	if err != nil {
 		return nil, err
 	}
-	nd := nb.Build()
 	if lsys.NodeReifier == nil {
 		return nd, nil
 	}
 	return lsys.NodeReifier(lnkCtx, nd, lsys)
 }
 
+// loadNode does the work for Load, short of reifying the node.
+func (lsys *LinkSystem) loadNode(lnkCtx LinkContext, lnk Link, np NodePrototype) (Node, error) {
+	if lsys.NodeCache == nil {
+		nb := np.NewBuilder()
+		if err := lsys.Fill(lnkCtx, lnk, nb); err != nil {
+			return nil, err
+		}
+		return nb.Build(), nil
+	}
+	if nd, ok := lsys.NodeCache.GetNode(lnk, np); ok {
+		return nd, nil
+	}
+	nb := np.NewBuilder()
+	var size int64
+	if err := lsys.fillObserved(lnkCtx, lnk, nb, &size); err != nil {
+		return nil, err
+	}
+	nd := nb.Build()
+	lsys.NodeCache.PutNode(lnk, np, nd, size)
+	return nd, nil
+}
+
 func (lsys *LinkSystem) MustLoad(lnkCtx LinkContext, lnk Link, np NodePrototype) Node {
 	if n, err := lsys.Load(lnkCtx, lnk, np); err != nil {
 		panic(err)
@@ -40,17 +79,52 @@ func (lsys *LinkSystem) MustLoad(lnkCtx LinkContext, lnk Link, np NodePrototype)
 }
 
 func (lsys *LinkSystem) Fill(lnkCtx LinkContext, lnk Link, na NodeAssembler) error {
+	return lsys.fillObserved(lnkCtx, lnk, na, nil)
+}
+
+// fillObserved does the work for Fill, reporting to the Observer if there is one.
+// If size isn't nil, it's set to the number of bytes read.
+func (lsys *LinkSystem) fillObserved(lnkCtx LinkContext, lnk Link, na NodeAssembler, size *int64) error {
 	if lnkCtx.Ctx == nil {
 		lnkCtx.Ctx = context.Background()
 	}
+	if lsys.Observer == nil {
+		return lsys.fill(lnkCtx, lnk, na, size)
+	}
+	if size == nil {
+		size = new(int64)
+	}
+	start := time.Now()
+	ctx, done := lsys.Observer(lnkCtx.Ctx, LinkOp_Load)
+	lnkCtx.Ctx = ctx
+	err := lsys.fill(lnkCtx, lnk, na, size)
+	if done != nil {
+		done(LinkEvent{
+			Op:        LinkOp_Load,
+			Link:      lnk,
+			Prototype: lnk.Prototype(),
+			Size:      *size,
+			Duration:  time.Since(start),
+			Err:       err,
+		})
+	}
+	return err
+}
+
+// fill does the work for Fill.
+// If size isn't nil, it's increased by the number of bytes read.
+func (lsys *LinkSystem) fill(lnkCtx LinkContext, lnk Link, na NodeAssembler, size *int64) error {
 	// Choose all the parts.
 	decoder, err := lsys.DecoderChooser(lnk)
 	if err != nil {
 		return ErrLinkingSetup{"could not choose a decoder", err}
 	}
-	hasher, err := lsys.HasherChooser(lnk.Prototype())
-	if err != nil {
-		return ErrLinkingSetup{"could not choose a hasher", err}
+	// Some links carry their data inline, such as CIDs with the identity multihash.
+	//  There's nothing to fetch from storage, nor to verify.
+	if il, ok := lnk.(inlineLink); ok {
+		if data, ok := il.InlineData(); ok {
+			return decoder(na, countReader(bytes.NewReader(data), size))
+		}
 	}
 	if lsys.StorageReadOpener == nil {
 		return ErrLinkingSetup{"no storage configured for reading", io.ErrClosedPipe} // REVIEW: better cause?
@@ -60,11 +134,16 @@ func (lsys *LinkSystem) Fill(lnkCtx LinkContext, lnk Link, na NodeAssembler) err
 	if err != nil {
 		return err
 	}
-	// TrustaedStorage indicates the data coming out of this reader has already been hashed and verified earlier.
-	// As a result, we can skip rehashing it
+	reader = countReader(reader, size)
+	// TrustedStorage indicates the data coming out of this reader has already been hashed and verified earlier.
+	// As a result, we can skip rehashing it, and don't even need a hasher.
 	if lsys.TrustedStorage {
 		return decoder(na, reader)
 	}
+	hasher, err := lsys.HasherChooser(lnk.Prototype())
+	if err != nil {
+		return ErrLinkingSetup{"could not choose a hasher", err}
+	}
 	// Tee the stream so that the hasher is fed as the unmarshal progresses through the stream.
 	tee := io.TeeReader(reader, hasher)
 	decodeErr := decoder(na, tee)
@@ -87,6 +166,33 @@ func (lsys *LinkSystem) Fill(lnkCtx LinkContext, lnk Link, na NodeAssembler) err
 	return nil
 }
 
+// countReader wraps r so that size is increased by the number of bytes read,
+// unless size is nil.
+func countReader(r io.Reader, size *int64) io.Reader {
+	if size == nil {
+		return r
+	}
+	return &countingReader{r, size}
+}
+
+type countingReader struct {
+	r    io.Reader
+	size *int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	*cr.size += int64(n)
+	return n, err
+}
+
+// inlineLink is implemented by links which may embed their data,
+// such as cidlink.Link with the identity multihash.
+// InlineData returns false if the link doesn't embed its data.
+type inlineLink interface {
+	InlineData() ([]byte, bool)
+}
+
 func (lsys *LinkSystem) MustFill(lnkCtx LinkContext, lnk Link, na NodeAssembler) {
 	if err := lsys.Fill(lnkCtx, lnk, na); err != nil {
 		panic(err)
@@ -102,25 +208,125 @@ func (lsys *LinkSystem) Store(lnkCtx LinkContext, lp LinkPrototype, n Node) (Lin
 	if err != nil {
 		return nil, ErrLinkingSetup{"could not choose an encoder", err}
 	}
+	// Open a writer which feeds the storage and the hasher at once, and funnel the codec output into it.
+	//  Nothing is buffered here, so peak memory doesn't grow with the size of the block.
+	bw, err := lsys.OpenWrite(lnkCtx, lp)
+	if err != nil {
+		return nil, err
+	}
+	if err := encoder(n, bw); err != nil {
+		bw.abort(err)
+		return nil, err
+	}
+	if err := bw.Close(); err != nil {
+		return nil, err
+	}
+	return bw.Link(), nil
+}
+
+// OpenWrite opens a BlockWriter, into which already-encoded data can be
+// streamed.  The data is hashed and written to storage as it arrives,
+// and the block is committed under its link when the BlockWriter is closed.
+//
+// This is what Store uses after encoding a node; OpenWrite is useful directly
+// when the serial data comes from elsewhere, such as when copying blocks,
+// and it needn't be held in memory all at once.
+func (lsys *LinkSystem) OpenWrite(lnkCtx LinkContext, lp LinkPrototype) (*BlockWriter, error) {
+	if lnkCtx.Ctx == nil {
+		lnkCtx.Ctx = context.Background()
+	}
+	bw := &BlockWriter{lp: lp, start: time.Now()}
+	if lsys.Observer != nil {
+		lnkCtx.Ctx, bw.done = lsys.Observer(lnkCtx.Ctx, LinkOp_Store)
+	}
 	hasher, err := lsys.HasherChooser(lp)
 	if err != nil {
-		return nil, ErrLinkingSetup{"could not choose a hasher", err}
+		err = ErrLinkingSetup{"could not choose a hasher", err}
+		bw.abort(err)
+		return nil, err
 	}
 	if lsys.StorageWriteOpener == nil {
-		return nil, ErrLinkingSetup{"no storage configured for writing", io.ErrClosedPipe} // REVIEW: better cause?
+		err = ErrLinkingSetup{"no storage configured for writing", io.ErrClosedPipe} // REVIEW: better cause?
+		bw.abort(err)
+		return nil, err
 	}
-	// Open storage write stream, feed serial data to the storage and the hasher, and funnel the codec output into both.
 	writer, commitFn, err := lsys.StorageWriteOpener(lnkCtx)
 	if err != nil {
+		bw.abort(err)
 		return nil, err
 	}
-	tee := io.MultiWriter(writer, hasher)
-	err = encoder(n, tee)
-	if err != nil {
-		return nil, err
+	bw.w = io.MultiWriter(writer, hasher)
+	bw.hasher = hasher
+	bw.commit = commitFn
+	return bw, nil
+}
+
+// BlockWriter streams serial data into both a hasher and a storage write
+// stream, and commits the block once its link is known; see OpenWrite.
+type BlockWriter struct {
+	lp     LinkPrototype
+	w      io.Writer
+	hasher hash.Hash
+	commit BlockWriteCommitter
+
+	lnk Link  // set by Close.
+	err error // sticky, once Close has been called.
+
+	// For the LinkSystem's Observer, if any.
+	start time.Time
+	size  int64
+	done  func(LinkEvent)
+}
+
+func (bw *BlockWriter) Write(p []byte) (int, error) {
+	if bw.commit == nil {
+		return 0, fmt.Errorf("write to a closed BlockWriter")
+	}
+	n, err := bw.w.Write(p)
+	bw.size += int64(n)
+	return n, err
+}
+
+// Close builds the link from the hash of all the data written,
+// and commits the block to storage under that link.
+// Calling Close again has no effect, and returns the same error.
+func (bw *BlockWriter) Close() error {
+	if bw.commit == nil {
+		return bw.err
+	}
+	bw.lnk = bw.lp.BuildLink(bw.hasher.Sum(nil))
+	bw.err = bw.commit(bw.lnk)
+	bw.commit = nil
+	bw.report()
+	return bw.err
+}
+
+// abort gives up on the write, without committing anything.
+func (bw *BlockWriter) abort(err error) {
+	bw.err = err
+	bw.commit = nil
+	bw.report()
+}
+
+// report tells the LinkSystem's Observer, if any, how the write went.
+func (bw *BlockWriter) report() {
+	if bw.done == nil {
+		return
 	}
-	lnk := lp.BuildLink(hasher.Sum(nil))
-	return lnk, commitFn(lnk)
+	bw.done(LinkEvent{
+		Op:        LinkOp_Store,
+		Link:      bw.lnk,
+		Prototype: bw.lp,
+		Size:      bw.size,
+		Duration:  time.Since(bw.start),
+		Err:       bw.err,
+	})
+}
+
+// Link returns the link of the data written,
+// or nil if the BlockWriter hasn't been closed yet.
+func (bw *BlockWriter) Link() Link {
+	return bw.lnk
 }
 
 func (lsys *LinkSystem) MustStore(lnkCtx LinkContext, lp LinkPrototype, n Node) Link {
@@ -131,6 +337,19 @@ func (lsys *LinkSystem) MustStore(lnkCtx LinkContext, lp LinkPrototype, n Node)
 	}
 }
 
+// Prefetch tells the StoragePrefetcher, if there is one, that the blocks for
+// the given links are likely to be loaded soon.
+// It does nothing if no StoragePrefetcher is configured.
+func (lsys *LinkSystem) Prefetch(lnkCtx LinkContext, lnks []Link) {
+	if lsys.StoragePrefetcher == nil || len(lnks) == 0 {
+		return
+	}
+	if lnkCtx.Ctx == nil {
+		lnkCtx.Ctx = context.Background()
+	}
+	lsys.StoragePrefetcher(lnkCtx, lnks)
+}
+
 // ComputeLink returns a Link for the given data, but doesn't do anything else
 // (e.g. it doesn't try to store any of the serial-form data anywhere else).
 func (lsys *LinkSystem) ComputeLink(lp LinkPrototype, n Node) (Link, error) {
@@ -0,0 +1,48 @@
+package cidlink_test
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec/dagjson"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/storage"
+)
+
+func TestOpenWrite(t *testing.T) {
+	store := storage.Memory{}
+	lsys := cidlink.DefaultLinkSystem()
+	lsys.StorageReadOpener = (&store).OpenRead
+	lsys.StorageWriteOpener = (&store).OpenWrite
+
+	bw, err := lsys.OpenWrite(ipld.LinkContext{}, lp)
+	Require(t, err, ShouldEqual, nil)
+	// Stream the encoded data in a few pieces.
+	n := basicnode.NewString("hello world")
+	var buf bytes.Buffer
+	Require(t, dagjson.Encode(n, &buf), ShouldEqual, nil)
+	for buf.Len() > 0 {
+		_, err := bw.Write(buf.Next(4))
+		Require(t, err, ShouldEqual, nil)
+	}
+	Wish(t, bw.Link(), ShouldEqual, nil)
+	Wish(t, len(store.Bag), ShouldEqual, 0)
+	Require(t, bw.Close(), ShouldEqual, nil)
+
+	n2, err := lsys.Load(ipld.LinkContext{}, bw.Link(), basicnode.Prototype.Any)
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, n2, ShouldEqual, n)
+	lnk, err := lsys.ComputeLink(lp, n)
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, bw.Link(), ShouldEqual, lnk)
+
+	// The writer is done once closed.
+	Wish(t, bw.Close(), ShouldEqual, nil)
+	_, err = bw.Write([]byte("more"))
+	Wish(t, err.Error(), ShouldEqual, "write to a closed BlockWriter")
+	Wish(t, len(store.Bag), ShouldEqual, 1)
+}
@@ -31,6 +31,20 @@ func (lnk Link) String() string {
 	return lnk.Cid.String()
 }
 
+// InlineData returns the data embedded in the link itself, if it uses the
+// identity multihash; such links don't refer to any data in storage.
+// LinkSystem uses this to load them without opening any storage at all.
+func (lnk Link) InlineData() ([]byte, bool) {
+	if !lnk.Cid.Defined() || lnk.Cid.Prefix().MhType != multihash.ID {
+		return nil, false
+	}
+	dmh, err := multihash.Decode(lnk.Cid.Hash())
+	if err != nil {
+		return nil, false
+	}
+	return dmh.Digest, true
+}
+
 type LinkPrototype struct {
 	cid.Prefix
 }
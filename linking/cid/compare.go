@@ -0,0 +1,48 @@
+package cidlink
+
+import (
+	"sort"
+	"strings"
+
+	ipld "github.com/ipld/go-ipld-prime"
+)
+
+// Equals returns true if other is a Link for the same CID.
+// This is the same as comparing the two with ==,
+// but it reads better when one side is an ipld.Link interface.
+func (lnk Link) Equals(other ipld.Link) bool {
+	lnk2, ok := other.(Link)
+	return ok && lnk.Cid == lnk2.Cid
+}
+
+// Compare returns -1, 0, or 1, if lnk sorts before, the same as, or after other.
+// Links are ordered by the bytes of their CIDs' binary form,
+// which is stable, and cheap to compute; it isn't the order of their strings.
+func (lnk Link) Compare(other Link) int {
+	// KeyString is the binary form, without any copying.
+	return strings.Compare(lnk.Cid.KeyString(), other.Cid.KeyString())
+}
+
+// Less returns true if lnk sorts before other; see Compare.
+func (lnk Link) Less(other Link) bool {
+	return lnk.Compare(other) < 0
+}
+
+// SortLinks sorts a slice of links in place, in a deterministic order,
+// such as for building canonical sets of links or manifests of blocks.
+// Links of type Link are sorted as per Compare, and go first;
+// any other links follow, sorted by their String method.
+func SortLinks(lnks []ipld.Link) {
+	sort.SliceStable(lnks, func(i, j int) bool {
+		a, aok := lnks[i].(Link)
+		b, bok := lnks[j].(Link)
+		switch {
+		case aok && bok:
+			return a.Less(b)
+		case aok != bok:
+			return aok
+		default:
+			return lnks[i].String() < lnks[j].String()
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package cidlink_test
+
+import (
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+)
+
+func TestCompare(t *testing.T) {
+	lsys := cidlink.DefaultLinkSystem()
+	var lnks []cidlink.Link
+	for _, s := range []string{"a", "b", "c"} {
+		lnk, err := lsys.ComputeLink(lp, basicnode.NewString(s))
+		Require(t, err, ShouldEqual, nil)
+		lnks = append(lnks, lnk.(cidlink.Link))
+	}
+	a, b, c := lnks[0], lnks[1], lnks[2]
+
+	Wish(t, a.Equals(a), ShouldEqual, true)
+	Wish(t, a.Equals(b), ShouldEqual, false)
+	Wish(t, a.Equals(nil), ShouldEqual, false)
+	Wish(t, a.Compare(a), ShouldEqual, 0)
+	Wish(t, a.Compare(b), ShouldEqual, -b.Compare(a))
+	Wish(t, a.Less(b), ShouldEqual, a.Compare(b) < 0)
+	Wish(t, a.Less(a), ShouldEqual, false)
+
+	sorted := []ipld.Link{c, a, b, a}
+	cidlink.SortLinks(sorted)
+	for i := 1; i < len(sorted); i++ {
+		Wish(t, sorted[i].(cidlink.Link).Less(sorted[i-1].(cidlink.Link)), ShouldEqual, false)
+	}
+	// The order doesn't depend on the starting order.
+	sorted2 := []ipld.Link{b, a, a, c}
+	cidlink.SortLinks(sorted2)
+	Wish(t, sorted2, ShouldEqual, sorted)
+}
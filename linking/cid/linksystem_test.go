@@ -0,0 +1,67 @@
+package cidlink_test
+
+import (
+	"testing"
+
+	cid "github.com/ipfs/go-cid"
+	. "github.com/warpfork/go-wish"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	_ "github.com/ipld/go-ipld-prime/codec/dagjson"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/storage"
+)
+
+var lp = cidlink.LinkPrototype{Prefix: cid.Prefix{
+	Version:  1,
+	Codec:    0x0129, // dag-json
+	MhType:   0x12,   // sha2-256
+	MhLength: 32,
+}}
+
+func TestTrustedStorage(t *testing.T) {
+	store := storage.Memory{}
+	lsys := cidlink.DefaultLinkSystem()
+	lsys.StorageReadOpener = (&store).OpenRead
+	lsys.StorageWriteOpener = (&store).OpenWrite
+	lnk := lsys.MustStore(ipld.LinkContext{}, lp, basicnode.NewString("hello"))
+
+	// Swap the block's contents for some other valid data.
+	store.Bag[lnk] = []byte(`"bye"`)
+
+	_, err := lsys.Load(ipld.LinkContext{}, lnk, basicnode.Prototype.Any)
+	_, ok := err.(ipld.ErrHashMismatch)
+	Wish(t, ok, ShouldEqual, true)
+
+	// Trusted storage isn't checked, so the swap goes unnoticed.
+	lsys.TrustedStorage = true
+	n, err := lsys.Load(ipld.LinkContext{}, lnk, basicnode.Prototype.Any)
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, n, ShouldEqual, basicnode.NewString("bye"))
+}
+
+func TestIdentityLinks(t *testing.T) {
+	idLp := cidlink.LinkPrototype{Prefix: cid.Prefix{
+		Version:  1,
+		Codec:    0x0129, // dag-json
+		MhType:   0x00,   // identity
+		MhLength: -1,
+	}}
+	lsys := cidlink.DefaultLinkSystem()
+	lnk, err := lsys.ComputeLink(idLp, basicnode.NewString("inline"))
+	Require(t, err, ShouldEqual, nil)
+	data, ok := lnk.(cidlink.Link).InlineData()
+	Wish(t, ok, ShouldEqual, true)
+	Wish(t, string(data), ShouldEqual, `"inline"`)
+
+	// No storage is configured, and none is needed.
+	n, err := lsys.Load(ipld.LinkContext{}, lnk, basicnode.Prototype.Any)
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, n, ShouldEqual, basicnode.NewString("inline"))
+
+	lnk, err = lsys.ComputeLink(lp, basicnode.NewString("not inline"))
+	Require(t, err, ShouldEqual, nil)
+	_, ok = lnk.(cidlink.Link).InlineData()
+	Wish(t, ok, ShouldEqual, false)
+}
@@ -0,0 +1,54 @@
+package cidlink_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/storage"
+)
+
+type spanKey struct{}
+
+func TestObserver(t *testing.T) {
+	store := storage.Memory{}
+	lsys := cidlink.DefaultLinkSystem()
+	var spans []string // the spans seen by the storage functions
+	lsys.StorageReadOpener = func(lnkCtx ipld.LinkContext, lnk ipld.Link) (io.Reader, error) {
+		spans = append(spans, lnkCtx.Ctx.Value(spanKey{}).(string))
+		return store.OpenRead(lnkCtx, lnk)
+	}
+	lsys.StorageWriteOpener = func(lnkCtx ipld.LinkContext) (io.Writer, ipld.BlockWriteCommitter, error) {
+		spans = append(spans, lnkCtx.Ctx.Value(spanKey{}).(string))
+		return store.OpenWrite(lnkCtx)
+	}
+	var events []ipld.LinkEvent
+	lsys.Observer = func(ctx context.Context, op ipld.LinkOp) (context.Context, func(ipld.LinkEvent)) {
+		ctx = context.WithValue(ctx, spanKey{}, op.String())
+		return ctx, func(ev ipld.LinkEvent) {
+			ev.Duration = 0 // not deterministic
+			events = append(events, ev)
+		}
+	}
+
+	lnk, err := lsys.Store(ipld.LinkContext{}, lp, basicnode.NewString("hello"))
+	Require(t, err, ShouldEqual, nil)
+	_, err = lsys.Load(ipld.LinkContext{}, lnk, basicnode.Prototype.Any)
+	Require(t, err, ShouldEqual, nil)
+	missing, err := lsys.ComputeLink(lp, basicnode.NewString("missing"))
+	Require(t, err, ShouldEqual, nil)
+	_, err = lsys.Load(ipld.LinkContext{}, missing, basicnode.Prototype.Any)
+	Wish(t, err, ShouldEqual, storage.ErrNotFound{Link: missing})
+
+	Wish(t, spans, ShouldEqual, []string{"store", "load", "load"})
+	Wish(t, events, ShouldEqual, []ipld.LinkEvent{
+		{Op: ipld.LinkOp_Store, Link: lnk, Prototype: lp, Size: 7},
+		{Op: ipld.LinkOp_Load, Link: lnk, Prototype: lp, Size: 7},
+		{Op: ipld.LinkOp_Load, Link: missing, Prototype: lp, Err: storage.ErrNotFound{Link: missing}},
+	})
+}
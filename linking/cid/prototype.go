@@ -0,0 +1,63 @@
+package cidlink
+
+import (
+	"fmt"
+
+	cid "github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multihash/core"
+)
+
+// Some commonly used LinkPrototypes, so that callers don't need to assemble
+// a cid.Prefix themselves.
+//
+// All of them use the sha2-256 multihash, at its full length.
+var (
+	DefaultV0        = LinkPrototype{Prefix: cid.Prefix{Version: 0, Codec: 0x70, MhType: 0x12, MhLength: 32}}
+	DefaultV1DagPb   = LinkPrototype{Prefix: cid.Prefix{Version: 1, Codec: 0x70, MhType: 0x12, MhLength: 32}}
+	DefaultV1DagCbor = LinkPrototype{Prefix: cid.Prefix{Version: 1, Codec: 0x71, MhType: 0x12, MhLength: 32}}
+	DefaultV1DagJson = LinkPrototype{Prefix: cid.Prefix{Version: 1, Codec: 0x0129, MhType: 0x12, MhLength: 32}}
+	DefaultV1Raw     = LinkPrototype{Prefix: cid.Prefix{Version: 1, Codec: 0x55, MhType: 0x12, MhLength: 32}}
+)
+
+// NewLinkPrototype returns a LinkPrototype for CIDs of the given version,
+// using the given multicodec and multihash indicator codes.
+//
+// The length is the number of bytes of the hash to keep in the CID;
+// -1 means the full length of the hash.
+// The identity multihash has no fixed length, so its length must be -1.
+//
+// An error is returned if the combination can't produce valid CIDs:
+// if the version isn't 0 or 1, if no hasher is registered for the multihash,
+// if the length is longer than the hash, or if a CIDv0 would use anything
+// other than dag-pb and a full-length sha2-256 hash.
+func NewLinkPrototype(version uint64, codec uint64, mhType uint64, mhLength int) (LinkPrototype, error) {
+	switch version {
+	case 0:
+		if codec != 0x70 || mhType != 0x12 || (mhLength != 32 && mhLength != -1) {
+			return LinkPrototype{}, fmt.Errorf("invalid cid v0 prefix: only dag-pb with a full sha2-256 hash is allowed")
+		}
+	case 1:
+	default:
+		return LinkPrototype{}, fmt.Errorf("invalid cid version %d", version)
+	}
+	hasher, err := multihash.GetHasher(mhType)
+	if err != nil {
+		return LinkPrototype{}, err
+	}
+	switch {
+	case mhType == multihash.IDENTITY:
+		if mhLength != -1 {
+			return LinkPrototype{}, fmt.Errorf("invalid multihash length %d: the identity multihash must use -1", mhLength)
+		}
+	case mhLength == -1:
+		mhLength = hasher.Size()
+	case mhLength <= 0 || mhLength > hasher.Size():
+		return LinkPrototype{}, fmt.Errorf("invalid multihash length %d: multihash 0x%x has length %d", mhLength, mhType, hasher.Size())
+	}
+	return LinkPrototype{Prefix: cid.Prefix{
+		Version:  version,
+		Codec:    codec,
+		MhType:   mhType,
+		MhLength: mhLength,
+	}}, nil
+}
@@ -0,0 +1,52 @@
+package cidlink_test
+
+import (
+	"testing"
+
+	cid "github.com/ipfs/go-cid"
+	. "github.com/warpfork/go-wish"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+)
+
+func TestNewLinkPrototype(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		lp2, err := cidlink.NewLinkPrototype(1, 0x0129, 0x12, -1)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, lp2, ShouldEqual, lp)
+		Wish(t, lp2, ShouldEqual, cidlink.DefaultV1DagJson)
+
+		lp2, err = cidlink.NewLinkPrototype(1, 0x71, 0x12, 20)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, lp2, ShouldEqual, cidlink.LinkPrototype{Prefix: cid.Prefix{Version: 1, Codec: 0x71, MhType: 0x12, MhLength: 20}})
+
+		lp2, err = cidlink.NewLinkPrototype(0, 0x70, 0x12, -1)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, lp2, ShouldEqual, cidlink.DefaultV0)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		for _, tc := range []struct {
+			version, codec, mhType uint64
+			mhLength               int
+			err                    string
+		}{
+			{2, 0x71, 0x12, -1, "invalid cid version 2"},
+			{0, 0x71, 0x12, -1, "invalid cid v0 prefix: only dag-pb with a full sha2-256 hash is allowed"},
+			{0, 0x70, 0x12, 20, "invalid cid v0 prefix: only dag-pb with a full sha2-256 hash is allowed"},
+			{1, 0x71, 0x12, 33, "invalid multihash length 33: multihash 0x12 has length 32"},
+			{1, 0x71, 0x00, 10, "invalid multihash length 10: the identity multihash must use -1"},
+			{1, 0x71, 0xfffff, -1, "unknown multihash code 1048575 (0xfffff): no such hash registered"},
+		} {
+			_, err := cidlink.NewLinkPrototype(tc.version, tc.codec, tc.mhType, tc.mhLength)
+			Wish(t, err.Error(), ShouldEqual, tc.err)
+		}
+	})
+	t.Run("presets match links", func(t *testing.T) {
+		lsys := cidlink.DefaultLinkSystem()
+		lnk, err := lsys.ComputeLink(cidlink.DefaultV1DagJson, basicnode.NewString("hello"))
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, lnk.Prototype(), ShouldEqual, ipld.LinkPrototype(cidlink.DefaultV1DagJson))
+	})
+}
@@ -1,9 +1,11 @@
 package ipld
 
 import (
+	"context"
 	"fmt"
 	"hash"
 	"io"
+	"time"
 )
 
 // LinkSystem is a struct that composes all the individual functions
@@ -21,6 +23,9 @@ import (
 // and use the multicodec registry and multihash registry to select encodings and hashing mechanisms.
 // The BlockWriteOpener and BlockReadOpener must still be provided by the user;
 // otherwise, only the ComputeLink method will work.
+// A NodePrototypeChooser may also be provided, so that code which loads
+// links (such as the traversal package) doesn't need to be told separately
+// which node implementations to use.
 //
 // Some implementations of BlockWriteOpener and BlockReadOpener may be
 // found in the storage package.  Applications are also free to write their own.
@@ -32,8 +37,109 @@ type LinkSystem struct {
 	HasherChooser      func(LinkPrototype) (hash.Hash, error)
 	StorageWriteOpener BlockWriteOpener
 	StorageReadOpener  BlockReadOpener
-	TrustedStorage     bool
+	StoragePrefetcher  BlockPrefetcher
 	NodeReifier        NodeReifier
+
+	// TrustedStorage, if true, makes loading skip checking that each block
+	// read from storage matches the hash in its link.
+	// Only set it when the storage is already known to hold verified data,
+	// such as a local store which checked each block as it was written,
+	// since hashing can dominate the cost of loading large amounts of data.
+	// The default of false checks every block.
+	TrustedStorage bool
+
+	// NodePrototypeChooser picks the NodePrototype used to build nodes when
+	// loading a link without one, such as when Load is given a nil prototype,
+	// or when a traversal.Config doesn't set its own chooser.
+	// It's optional; if nil, a prototype must always be given.
+	NodePrototypeChooser NodePrototypeChooser
+
+	// Observer, if set, is told about every block loaded or stored via
+	// this LinkSystem, so that applications can record metrics or traces.
+	// It's optional; see LinkObserver for details.
+	Observer LinkObserver
+
+	// NodeCache, if set, keeps the nodes built by Load, so that loading
+	// the same link with the same prototype again doesn't need to read
+	// and decode its block again; see storage.ReadCache.
+	// Nodes are immutable, so the cached ones are shared by every Load.
+	NodeCache NodeCache
+}
+
+// NodeCache is the interface of caches for the nodes built by LinkSystem.Load.
+//
+// Implementations must be safe for concurrent use,
+// as a LinkSystem may be used by many goroutines at once.
+type NodeCache interface {
+	// GetNode returns the node built with np for lnk, if it's cached.
+	GetNode(lnk Link, np NodePrototype) (Node, bool)
+
+	// PutNode is called with each node that Load builds with np for lnk,
+	// along with the size in bytes of the block it was decoded from,
+	// which a cache may use to keep its memory use bounded.
+	PutNode(lnk Link, np NodePrototype, n Node, size int64)
+}
+
+// NodePrototypeChooser defines the shape of a function which picks the
+// NodePrototype to use when loading the data for a Link.
+//
+// In a simple example, it could always return basicnode.Prototype.Any.
+// In a more complex example, a program using schemas could look at the
+// LinkContext to find out where the link was found, and so which type
+// the data is expected to have.
+type NodePrototypeChooser func(Link, LinkContext) (NodePrototype, error)
+
+// LinkObserver defines the shape of a function which is called when a
+// LinkSystem starts loading or storing a block.
+//
+// It may return a derived context, such as one carrying a tracing span,
+// which is then used in the LinkContext given to the storage functions
+// for the rest of the operation.
+// It may also return a function, which is called with a LinkEvent
+// describing the outcome once the operation ends; it may be nil.
+type LinkObserver func(ctx context.Context, op LinkOp) (context.Context, func(LinkEvent))
+
+// LinkOp is the kind of operation reported to a LinkObserver.
+type LinkOp uint8
+
+const (
+	LinkOp_Load  LinkOp = iota // a block is read from storage and decoded, as by Load or Fill.
+	LinkOp_Store               // a block is written to storage, as by Store or OpenWrite.
+)
+
+func (op LinkOp) String() string {
+	switch op {
+	case LinkOp_Load:
+		return "load"
+	case LinkOp_Store:
+		return "store"
+	default:
+		return fmt.Sprintf("LinkOp(%d)", uint8(op))
+	}
+}
+
+// LinkEvent describes a finished operation on a block, as reported to a LinkObserver.
+type LinkEvent struct {
+	Op LinkOp
+
+	// Link is the block's link.
+	// It's nil if storing failed before the link could be computed.
+	Link Link
+
+	// Prototype is the block's link prototype, which tells its codec and hash;
+	// for cidlink.LinkPrototype, see its Codec and MhType fields.
+	Prototype LinkPrototype
+
+	// Size is the number of bytes read or written.
+	// When loading, it may be less than the block's size if decoding failed.
+	Size int64
+
+	// Duration is how long the whole operation took,
+	// including hashing and encoding or decoding.
+	Duration time.Duration
+
+	// Err is the error the operation failed with, if any.
+	Err error
 }
 
 // The following two types define the two directions of transform that a codec can be expected to perform:
@@ -158,6 +264,21 @@ type (
 	// found in the storage package.  Applications are also free to write their own.
 	BlockReadOpener func(LinkContext, Link) (io.Reader, error)
 
+	// BlockPrefetcher defines the shape of a function which is told about
+	// a batch of links whose blocks are likely to be read soon,
+	// such as the links in a node which a traversal is about to descend into.
+	//
+	// It's only a hint: it must not block for long, and the blocks will
+	// still be read with the BlockReadOpener later, one at a time, and perhaps
+	// not all of them.
+	// Stores which fetch blocks over a network can use this to request many
+	// blocks at once, or to start requesting them in the background,
+	// rather than making one round trip per block once each is needed.
+	//
+	// BlockPrefetcher is optional in a LinkSystem,
+	// and is typically called via the LinkSystem's Prefetch method.
+	BlockPrefetcher func(LinkContext, []Link)
+
 	// BlockWriteOpener defines the shape of a function used to open a writer
 	// into which data can be streamed, and which will eventually be "commited".
 	// Committing is done using the BlockWriteCommitter returned by using the BlockWriteOpener,
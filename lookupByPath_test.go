@@ -0,0 +1,53 @@
+package ipld_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec/dagjson"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/node/gendemo"
+)
+
+func TestLookupByPath(t *testing.T) {
+	nb := basicnode.Prototype.Any.NewBuilder()
+	Require(t, dagjson.Decode(nb, strings.NewReader(`{"a":[{"b":"c"},{"/":"bafkqaaa"}],"0":true}`)), ShouldEqual, nil)
+	n := nb.Build()
+
+	got, err := ipld.LookupByPath(n, ipld.ParsePath("a/0/b"))
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, got, ShouldEqual, basicnode.NewString("c"))
+
+	got, err = ipld.LookupByPath(n, ipld.ParsePath(""))
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, got, ShouldEqual, n)
+
+	// Segments made of ints work on lists, and as map keys.
+	got, err = ipld.LookupByPath(n, ipld.NewPath([]ipld.PathSegment{ipld.PathSegmentOfInt(0)}))
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, got, ShouldEqual, basicnode.NewBool(true))
+	got, err = ipld.LookupByPath(n, ipld.NewPath([]ipld.PathSegment{ipld.PathSegmentOfString("a"), ipld.PathSegmentOfInt(1)}))
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, got.Kind(), ShouldEqual, ipld.Kind_Link)
+
+	_, err = ipld.LookupByPath(n, ipld.ParsePath("a/2"))
+	Wish(t, err.Error(), ShouldEqual, `error traversing segment "2" on node at "a": key not found: "2"`)
+	Wish(t, errors.As(err, new(ipld.ErrNotExists)), ShouldEqual, true)
+	_, err = ipld.LookupByPath(n, ipld.ParsePath("a/x"))
+	Wish(t, errors.As(err, new(ipld.ErrInvalidSegmentForList)), ShouldEqual, true)
+	_, err = ipld.LookupByPath(n, ipld.ParsePath("a/0/b/c"))
+	Wish(t, err.Error(), ShouldEqual, `cannot traverse segment "c" on string node at "a/0/b"`)
+	_, err = ipld.LookupByPath(n, ipld.ParsePath("a/1/x"))
+	Wish(t, err.Error(), ShouldEqual, `cannot traverse segment "x" on link node at "a/1"`)
+
+	// Generated types work the same.
+	nb = gendemo.Type.Map__String__Msg3.NewBuilder()
+	Require(t, dagjson.Decode(nb, strings.NewReader(`{"a":{"whee":1,"woot":2,"waga":3}}`)), ShouldEqual, nil)
+	got, err = ipld.LookupByPath(nb.Build(), ipld.ParsePath("a/woot"))
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, ipld.DeepEqual(got, basicnode.NewInt(2)), ShouldEqual, true)
+}
@@ -0,0 +1,159 @@
+// Package merge combines two nodes into one, recursively,
+// as when layering configuration documents, or putting together partial responses.
+//
+// Maps are merged entry by entry, and lists are either concatenated or replaced;
+// wherever the two nodes disagree about anything else, one of them wins.
+// Which side wins, and what happens to lists, is up to a Config.
+package merge
+
+import (
+	"github.com/ipld/go-ipld-prime"
+)
+
+// Precedence decides which node's value is kept when both have one
+// at the same place, and the two can't be merged.
+type Precedence uint8
+
+const (
+	// RightWins keeps the values from the second node, as when a later layer overrides an earlier one.
+	RightWins Precedence = iota
+	// LeftWins keeps the values from the first node, as when filling in defaults.
+	LeftWins
+)
+
+// ListPolicy decides what happens when both nodes have a list at the same place.
+type ListPolicy uint8
+
+const (
+	// ReplaceLists keeps one of the lists, per the Precedence.
+	ReplaceLists ListPolicy = iota
+	// ConcatLists keeps the values of both lists: the first node's, then the second node's.
+	ConcatLists
+)
+
+// Config says how to merge nodes.
+// Its zero value merges maps, and otherwise keeps values from the second node,
+// including replacing whole lists.
+type Config struct {
+	Precedence Precedence
+	Lists      ListPolicy
+}
+
+// Merge merges two nodes with the default config.
+func Merge(x, y ipld.Node) (ipld.Node, error) {
+	return Config{}.Merge(x, y)
+}
+
+// Merge returns a new node which combines x and y:
+//
+// - if both are maps, the result has every entry of x, in order, then the entries only in y;
+// entries in both are merged recursively, and absent values count as missing;
+//
+// - if both are lists, they're concatenated or replaced, per the ListPolicy;
+//
+// - otherwise, including if the kinds differ, or either is null, the result is one of them, per the Precedence.
+//
+// Merged maps and lists are built with the prototype of the one from x,
+// so merging generated nodes of the same type gives a node of that type,
+// and data which doesn't fit in that type is an error.
+// Values which aren't merged, such as whole subtrees only one node has, are shared with the originals,
+// as far as the AssignNode methods of the assemblers permit.
+func (cfg Config) Merge(x, y ipld.Node) (ipld.Node, error) {
+	switch {
+	case x.Kind() == ipld.Kind_Map && y.Kind() == ipld.Kind_Map:
+		return cfg.mergeMaps(x, y)
+	case x.Kind() == ipld.Kind_List && y.Kind() == ipld.Kind_List && cfg.Lists == ConcatLists:
+		return concatLists(x, y)
+	case cfg.Precedence == LeftWins:
+		return x, nil
+	}
+	return y, nil
+}
+
+func (cfg Config) mergeMaps(x, y ipld.Node) (ipld.Node, error) {
+	nb := x.Prototype().NewBuilder()
+	ma, err := nb.BeginMap(x.Length() + y.Length())
+	if err != nil {
+		return nil, err
+	}
+	for itr := x.MapIterator(); !itr.Done(); {
+		k, vx, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if vx.IsAbsent() {
+			continue
+		}
+		v := vx
+		vy, err := y.LookupByNode(k)
+		switch err.(type) {
+		case nil:
+			if !vy.IsAbsent() {
+				if v, err = cfg.Merge(vx, vy); err != nil {
+					return nil, err
+				}
+			}
+		case ipld.ErrNotExists:
+		default:
+			return nil, err
+		}
+		if err := ma.AssembleKey().AssignNode(k); err != nil {
+			return nil, err
+		}
+		if err := ma.AssembleValue().AssignNode(v); err != nil {
+			return nil, err
+		}
+	}
+	for itr := y.MapIterator(); !itr.Done(); {
+		k, vy, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if vy.IsAbsent() {
+			continue
+		}
+		vx, err := x.LookupByNode(k)
+		switch err.(type) {
+		case nil:
+			if !vx.IsAbsent() {
+				continue // Already merged.
+			}
+		case ipld.ErrNotExists:
+		default:
+			return nil, err
+		}
+		if err := ma.AssembleKey().AssignNode(k); err != nil {
+			return nil, err
+		}
+		if err := ma.AssembleValue().AssignNode(vy); err != nil {
+			return nil, err
+		}
+	}
+	if err := ma.Finish(); err != nil {
+		return nil, err
+	}
+	return nb.Build(), nil
+}
+
+func concatLists(x, y ipld.Node) (ipld.Node, error) {
+	nb := x.Prototype().NewBuilder()
+	la, err := nb.BeginList(x.Length() + y.Length())
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range []ipld.Node{x, y} {
+		for itr := l.ListIterator(); !itr.Done(); {
+			_, v, err := itr.Next()
+			if err != nil {
+				return nil, err
+			}
+			if err := la.AssembleValue().AssignNode(v); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := la.Finish(); err != nil {
+		return nil, err
+	}
+	return nb.Build(), nil
+}
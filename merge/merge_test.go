@@ -0,0 +1,72 @@
+package merge
+
+import (
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime/codec/tests"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/node/gendemo"
+)
+
+func TestMerge(t *testing.T) {
+	const (
+		left  = `{"name":"base","opts":{"a":1,"b":[1,2],"c":{"d":true}},"tags":["x"],"only":null}`
+		right = `{"opts":{"b":[3],"c":"flat","e":2},"tags":["y","z"],"name":null,"more":1}`
+	)
+	for _, tc := range []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{"default", Config{},
+			`{"name":null,"opts":{"a":1,"b":[3],"c":"flat","e":2},"tags":["y","z"],"only":null,"more":1}`},
+		{"left wins", Config{Precedence: LeftWins},
+			`{"name":"base","opts":{"a":1,"b":[1,2],"c":{"d":true},"e":2},"tags":["x"],"only":null,"more":1}`},
+		{"concat lists", Config{Lists: ConcatLists},
+			`{"name":null,"opts":{"a":1,"b":[1,2,3],"c":"flat","e":2},"tags":["x","y","z"],"only":null,"more":1}`},
+		{"left wins, concat lists", Config{Precedence: LeftWins, Lists: ConcatLists},
+			`{"name":"base","opts":{"a":1,"b":[1,2,3],"c":{"d":true},"e":2},"tags":["x","y","z"],"only":null,"more":1}`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			x := tests.FromJSON(t, basicnode.Prototype.Any, left)
+			y := tests.FromJSON(t, basicnode.Prototype.Any, right)
+			n, err := tc.cfg.Merge(x, y)
+			Require(t, err, ShouldEqual, nil)
+			Wish(t, tests.ToJSON(t, n), ShouldEqual, tc.want)
+
+			// The inputs are unchanged.
+			Wish(t, tests.ToJSON(t, x), ShouldEqual, left)
+			Wish(t, tests.ToJSON(t, y), ShouldEqual, right)
+		})
+	}
+
+	// Values which aren't merged are shared.
+	x := tests.FromJSON(t, basicnode.Prototype.Any, `{"a":{"b":1}}`)
+	y := tests.FromJSON(t, basicnode.Prototype.Any, `{"c":{"d":2}}`)
+	n, err := Merge(x, y)
+	Require(t, err, ShouldEqual, nil)
+	a1, _ := x.LookupByString("a")
+	a2, _ := n.LookupByString("a")
+	Wish(t, a1 == a2, ShouldEqual, true)
+
+	// Scalars at the top just pick a side.
+	n, err = Merge(basicnode.NewInt(1), basicnode.NewInt(2))
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, n, ShouldEqual, basicnode.NewInt(2))
+}
+
+func TestMergeGenerated(t *testing.T) {
+	x := tests.FromJSON(t, gendemo.Type.Map__String__Msg3, `{"a":{"whee":1,"woot":2,"waga":3}}`)
+	y := tests.FromJSON(t, gendemo.Type.Map__String__Msg3, `{"a":{"whee":10,"woot":20,"waga":30},"b":{"whee":4,"woot":5,"waga":6}}`)
+	n, err := Merge(x, y)
+	Require(t, err, ShouldEqual, nil)
+	_, ok := n.(gendemo.Map__String__Msg3)
+	Wish(t, ok, ShouldEqual, true)
+	Wish(t, tests.ToJSON(t, n), ShouldEqual, tests.ToJSON(t, y))
+
+	// Data which doesn't fit the type of the left node is rejected.
+	_, err = Merge(x, tests.FromJSON(t, basicnode.Prototype.Any, `{"c":"not a struct"}`))
+	Wish(t, err != nil, ShouldEqual, true)
+}
@@ -0,0 +1,103 @@
+package multicodec
+
+import (
+	"fmt"
+
+	"github.com/ipld/go-ipld-prime"
+)
+
+// Capabilities describes what data a codec can encode and decode,
+// so that generic tools can pick a suitable codec for some data,
+// or fail early with a clear message rather than partway through encoding.
+//
+// Codec packages register the Capabilities of their codec alongside their
+// encoder and decoder; see RegisterCapabilities.
+type Capabilities struct {
+	// Kinds lists the data model kinds which the codec can encode and decode.
+	// For example, plain json lacks bytes and links,
+	// so neither of those kinds can appear anywhere in the data.
+	Kinds ipld.KindSet
+
+	// Restricted is true if the codec only accepts data of particular shapes,
+	// beyond which kinds appear in it; for example, dag-pb only supports
+	// maps with the fields of a PBNode.
+	Restricted bool
+
+	// Canonical is true if the registered encoder always produces a single
+	// canonical encoding for each value, such that equal data always encodes
+	// to the same bytes, and so to the same CID.
+	// Codecs which are meant for content addressing generally define such an
+	// encoding, but their registered encoders may not always produce it;
+	// for instance, dag-cbor's only sorts map entries when asked to, via
+	// dagcbor.EncodeOptions.Canonical.
+	Canonical bool
+}
+
+// Supports reports whether the codec can encode and decode the given kind.
+func (c Capabilities) Supports(k ipld.Kind) bool {
+	return c.Kinds.Contains(k)
+}
+
+// Complete reports whether the codec can encode and decode any data model
+// value: every kind, with no restrictions on shape.
+func (c Capabilities) Complete() bool {
+	if c.Restricted {
+		return false
+	}
+	for _, k := range allKinds {
+		if !c.Supports(k) {
+			return false
+		}
+	}
+	return true
+}
+
+var allKinds = ipld.KindSet{
+	ipld.Kind_Map, ipld.Kind_List,
+	ipld.Kind_Null, ipld.Kind_Bool, ipld.Kind_Int, ipld.Kind_Float,
+	ipld.Kind_String, ipld.Kind_Bytes, ipld.Kind_Link,
+}
+
+// RegisterCapabilities is like the package-level RegisterCapabilities function, but for this registry.
+func (r *Registry) RegisterCapabilities(indicator uint64, caps Capabilities) {
+	if r.capabilities == nil {
+		r.capabilities = make(map[uint64]Capabilities)
+	}
+	r.capabilities[indicator] = caps
+}
+
+// LookupCapabilities is like the package-level LookupCapabilities function, but for this registry.
+func (r *Registry) LookupCapabilities(indicator uint64) (Capabilities, error) {
+	caps, exists := r.capabilities[indicator]
+	if !exists {
+		return Capabilities{}, fmt.Errorf("no capabilities registered for multicodec code %d (0x%x)", indicator, indicator)
+	}
+	return caps, nil
+}
+
+// RegisterCapabilities records what the codec with a multicodec indicator code
+// number supports, so that it can be queried with LookupCapabilities.
+//
+// Codec packages which call RegisterEncoder and RegisterDecoder at init time
+// are encouraged to call this at the same time.
+// As with those functions, the last call for an indicator number wins.
+func RegisterCapabilities(indicator uint64, caps Capabilities) {
+	DefaultRegistry.RegisterCapabilities(indicator, caps)
+}
+
+// LookupCapabilities returns what the codec with a multicodec indicator code
+// number supports, as registered by RegisterCapabilities.
+//
+// For example, a tool which needs to store arbitrary data can check that
+// the codec chosen by a user supports it before encoding anything:
+//
+//	caps, err := multicodec.LookupCapabilities(indicator)
+//	if err != nil {
+//		return err
+//	}
+//	if !caps.Complete() {
+//		return fmt.Errorf("codec 0x%x cannot encode all data", indicator)
+//	}
+func LookupCapabilities(indicator uint64) (Capabilities, error) {
+	return DefaultRegistry.LookupCapabilities(indicator)
+}
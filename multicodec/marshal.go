@@ -0,0 +1,57 @@
+package multicodec
+
+import (
+	"bytes"
+
+	"github.com/ipld/go-ipld-prime"
+)
+
+// Marshal is like the package-level Marshal function, but for this registry.
+func (r *Registry) Marshal(indicator uint64, n ipld.Node) ([]byte, error) {
+	encodeFunc, err := r.LookupEncoder(indicator)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := encodeFunc(n, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal is like the package-level Unmarshal function, but for this registry.
+func (r *Registry) Unmarshal(indicator uint64, data []byte, np ipld.NodePrototype) (ipld.Node, error) {
+	decodeFunc, err := r.LookupDecoder(indicator)
+	if err != nil {
+		return nil, err
+	}
+	nb := np.NewBuilder()
+	if err := decodeFunc(nb, bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+	return nb.Build(), nil
+}
+
+// Marshal serializes a node with the encoder registered for a multicodec
+// indicator code number, and returns the resulting bytes.
+//
+// This is a convenience for callers which deal with whole blocks of data
+// in memory, and only know which codec to use by its number.
+// As with LookupEncoder, the codec must have been registered,
+// which codec packages do when they're imported;
+// for example, with a blank import like:
+//
+//	import _ "github.com/ipld/go-ipld-prime/codec/dagcbor"
+func Marshal(indicator uint64, n ipld.Node) ([]byte, error) {
+	return DefaultRegistry.Marshal(indicator, n)
+}
+
+// Unmarshal deserializes data with the decoder registered for a multicodec
+// indicator code number, building a node with the given prototype.
+//
+// As with Marshal, the codec must have been registered.
+// If you don't need a particular node implementation,
+// github.com/ipld/go-ipld-prime/node/basic.Prototype.Any is a good choice.
+func Unmarshal(indicator uint64, data []byte, np ipld.NodePrototype) (ipld.Node, error) {
+	return DefaultRegistry.Unmarshal(indicator, data, np)
+}
@@ -2,12 +2,92 @@ package multicodec
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/ipld/go-ipld-prime"
 )
 
-var encoderRegistry = make(map[uint64]ipld.Encoder)
-var decoderRegistry = make(map[uint64]ipld.Decoder)
+// Registry is a mapping of multicodec indicator numbers to ipld.Encoder and
+// ipld.Decoder functions.
+//
+// DefaultRegistry is the Registry used by the package-level functions,
+// and which the codec packages in this module register themselves into.
+// Applications which want full control over which codecs are available
+// can create their own Registry instead, and use it with a LinkSystem
+// (see linking/cid.LinkSystemUsingMulticodecRegistry).
+//
+// The zero value is an empty registry, ready to use.
+// A Registry is not safe for concurrent modification; as with DefaultRegistry,
+// registering should happen up front (e.g. at init time), before any lookups.
+type Registry struct {
+	encoders     map[uint64]ipld.Encoder
+	decoders     map[uint64]ipld.Decoder
+	capabilities map[uint64]Capabilities
+}
+
+// DefaultRegistry is the Registry used by RegisterEncoder, LookupEncoder, and
+// the other package-level functions.
+var DefaultRegistry = Registry{}
+
+// RegisterEncoder is like the package-level RegisterEncoder function, but for this registry.
+func (r *Registry) RegisterEncoder(indicator uint64, encodeFunc ipld.Encoder) {
+	if encodeFunc == nil {
+		panic("not sensible to attempt to register a nil function")
+	}
+	if r.encoders == nil {
+		r.encoders = make(map[uint64]ipld.Encoder)
+	}
+	r.encoders[indicator] = encodeFunc
+}
+
+// LookupEncoder is like the package-level LookupEncoder function, but for this registry.
+func (r *Registry) LookupEncoder(indicator uint64) (ipld.Encoder, error) {
+	encodeFunc, exists := r.encoders[indicator]
+	if !exists {
+		return nil, fmt.Errorf("no encoder registered for multicodec code %d (0x%x)", indicator, indicator)
+	}
+	return encodeFunc, nil
+}
+
+// ListEncoders returns the indicator numbers of all the encoders in this registry, in ascending order.
+func (r *Registry) ListEncoders() []uint64 {
+	indicators := make([]uint64, 0, len(r.encoders))
+	for indicator := range r.encoders {
+		indicators = append(indicators, indicator)
+	}
+	sort.Slice(indicators, func(i, j int) bool { return indicators[i] < indicators[j] })
+	return indicators
+}
+
+// RegisterDecoder is like the package-level RegisterDecoder function, but for this registry.
+func (r *Registry) RegisterDecoder(indicator uint64, decodeFunc ipld.Decoder) {
+	if decodeFunc == nil {
+		panic("not sensible to attempt to register a nil function")
+	}
+	if r.decoders == nil {
+		r.decoders = make(map[uint64]ipld.Decoder)
+	}
+	r.decoders[indicator] = decodeFunc
+}
+
+// LookupDecoder is like the package-level LookupDecoder function, but for this registry.
+func (r *Registry) LookupDecoder(indicator uint64) (ipld.Decoder, error) {
+	decodeFunc, exists := r.decoders[indicator]
+	if !exists {
+		return nil, fmt.Errorf("no decoder registered for multicodec code %d (0x%x)", indicator, indicator)
+	}
+	return decodeFunc, nil
+}
+
+// ListDecoders returns the indicator numbers of all the decoders in this registry, in ascending order.
+func (r *Registry) ListDecoders() []uint64 {
+	indicators := make([]uint64, 0, len(r.decoders))
+	for indicator := range r.decoders {
+		indicators = append(indicators, indicator)
+	}
+	sort.Slice(indicators, func(i, j int) bool { return indicators[i] < indicators[j] })
+	return indicators
+}
 
 // RegisterEncoder updates a simple map of multicodec indicator number to ipld.Encoder function.
 // The encoder functions registered can be subsequently looked up using LookupEncoder.
@@ -38,10 +118,7 @@ func RegisterEncoder(indicator uint64, encodeFunc ipld.Encoder) {
 	// E.g. one could introduce logging here to help detect unintended conflicting registrations.
 	// (We probably won't do this, but you can do it yourself as a printf debug hack. :))
 
-	if encodeFunc == nil {
-		panic("not sensible to attempt to register a nil function")
-	}
-	encoderRegistry[indicator] = encodeFunc
+	DefaultRegistry.RegisterEncoder(indicator, encodeFunc)
 }
 
 // LookupEncoder yields an ipld.Encoder function matching a multicodec indicator code number.
@@ -52,11 +129,13 @@ func RegisterEncoder(indicator uint64, encodeFunc ipld.Encoder) {
 // To be available from this lookup function, an encoder must have been registered
 // for this indicator number by an earlier call to the RegisterEncoder function.
 func LookupEncoder(indicator uint64) (ipld.Encoder, error) {
-	encodeFunc, exists := encoderRegistry[indicator]
-	if !exists {
-		return nil, fmt.Errorf("no encoder registered for multicodec code %d (0x%x)", indicator, indicator)
-	}
-	return encodeFunc, nil
+	return DefaultRegistry.LookupEncoder(indicator)
+}
+
+// ListEncoders returns the indicator numbers of all the encoders which have
+// been registered by the RegisterEncoder function, in ascending order.
+func ListEncoders() []uint64 {
+	return DefaultRegistry.ListEncoders()
 }
 
 // RegisterDecoder updates a simple map of multicodec indicator number to ipld.Decoder function.
@@ -88,10 +167,7 @@ func RegisterDecoder(indicator uint64, decodeFunc ipld.Decoder) {
 	// E.g. one could introduce logging here to help detect unintended conflicting registrations.
 	// (We probably won't do this, but you can do it yourself as a printf debug hack. :))
 
-	if decodeFunc == nil {
-		panic("not sensible to attempt to register a nil function")
-	}
-	decoderRegistry[indicator] = decodeFunc
+	DefaultRegistry.RegisterDecoder(indicator, decodeFunc)
 }
 
 // LookupDecoder yields an ipld.Decoder function matching a multicodec indicator code number.
@@ -102,9 +178,11 @@ func RegisterDecoder(indicator uint64, decodeFunc ipld.Decoder) {
 // To be available from this lookup function, an decoder must have been registered
 // for this indicator number by an earlier call to the RegisterDecoder function.
 func LookupDecoder(indicator uint64) (ipld.Decoder, error) {
-	decodeFunc, exists := decoderRegistry[indicator]
-	if !exists {
-		return nil, fmt.Errorf("no decoder registered for multicodec code %d (0x%x)", indicator, indicator)
-	}
-	return decodeFunc, nil
+	return DefaultRegistry.LookupDecoder(indicator)
+}
+
+// ListDecoders returns the indicator numbers of all the decoders which have
+// been registered by the RegisterDecoder function, in ascending order.
+func ListDecoders() []uint64 {
+	return DefaultRegistry.ListDecoders()
 }
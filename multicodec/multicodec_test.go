@@ -0,0 +1,94 @@
+package multicodec_test
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	_ "github.com/ipld/go-ipld-prime/codec/dagcbor"
+	_ "github.com/ipld/go-ipld-prime/codec/dagjson"
+	"github.com/ipld/go-ipld-prime/codec/raw"
+	"github.com/ipld/go-ipld-prime/multicodec"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+)
+
+func TestDefaultRegistry(t *testing.T) {
+	// The codec packages register themselves when imported.
+	Wish(t, multicodec.ListEncoders(), ShouldEqual, []uint64{0x55, 0x71, 0x0129})
+	Wish(t, multicodec.ListDecoders(), ShouldEqual, []uint64{0x55, 0x71, 0x0129})
+}
+
+func TestRegistry(t *testing.T) {
+	var reg multicodec.Registry
+	Wish(t, reg.ListEncoders(), ShouldEqual, []uint64{})
+
+	_, err := reg.LookupEncoder(0x55)
+	Wish(t, err.Error(), ShouldEqual, "no encoder registered for multicodec code 85 (0x55)")
+
+	reg.RegisterEncoder(0x300000, raw.Encode)
+	reg.RegisterDecoder(0x300000, raw.Decode)
+	Wish(t, reg.ListEncoders(), ShouldEqual, []uint64{0x300000})
+	Wish(t, reg.ListDecoders(), ShouldEqual, []uint64{0x300000})
+
+	fn, err := reg.LookupDecoder(0x300000)
+	Require(t, err, ShouldEqual, nil)
+	nb := basicnode.Prototype.Bytes.NewBuilder()
+	Require(t, fn(nb, bytes.NewReader([]byte("abc"))), ShouldEqual, nil)
+	Wish(t, nb.Build(), ShouldEqual, basicnode.NewBytes([]byte("abc")))
+
+	// Registering into a separate registry doesn't affect the default one.
+	_, err = multicodec.LookupDecoder(0x300000)
+	Wish(t, err != nil, ShouldEqual, true)
+}
+
+func TestMarshal(t *testing.T) {
+	n := basicnode.NewString("hello")
+	data, err := multicodec.Marshal(0x71, n)
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, string(data), ShouldEqual, "ehello")
+
+	n2, err := multicodec.Unmarshal(0x71, data, basicnode.Prototype.Any)
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, n2, ShouldEqual, n)
+
+	_, err = multicodec.Marshal(0x300001, n)
+	Wish(t, err.Error(), ShouldEqual, "no encoder registered for multicodec code 3145729 (0x300001)")
+	_, err = multicodec.Unmarshal(0x300001, data, basicnode.Prototype.Any)
+	Wish(t, err.Error(), ShouldEqual, "no decoder registered for multicodec code 3145729 (0x300001)")
+
+	// Decoding errors are passed through.
+	_, err = multicodec.Unmarshal(0x71, data, basicnode.Prototype.Int)
+	Wish(t, err == nil, ShouldEqual, false)
+}
+
+func TestCapabilities(t *testing.T) {
+	caps, err := multicodec.LookupCapabilities(0x71)
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, caps.Complete(), ShouldEqual, true)
+	// The registered encoder emits map entries in their iteration order.
+	Wish(t, caps.Canonical, ShouldEqual, false)
+
+	caps, err = multicodec.LookupCapabilities(0x0129)
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, caps.Complete(), ShouldEqual, true)
+	Wish(t, caps.Canonical, ShouldEqual, false)
+
+	caps, err = multicodec.LookupCapabilities(0x55)
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, caps.Complete(), ShouldEqual, false)
+	Wish(t, caps.Supports(ipld.Kind_Bytes), ShouldEqual, true)
+	Wish(t, caps.Supports(ipld.Kind_Link), ShouldEqual, false)
+	Wish(t, caps.Canonical, ShouldEqual, true)
+
+	var reg multicodec.Registry
+	_, err = reg.LookupCapabilities(0x71)
+	Wish(t, err.Error(), ShouldEqual, "no capabilities registered for multicodec code 113 (0x71)")
+
+	reg.RegisterCapabilities(0x300000, multicodec.Capabilities{Kinds: ipld.KindSet_Scalar})
+	caps, err = reg.LookupCapabilities(0x300000)
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, caps.Supports(ipld.Kind_Map), ShouldEqual, false)
+	Wish(t, caps.Complete(), ShouldEqual, false)
+}
@@ -0,0 +1,110 @@
+package basicnode
+
+import (
+	"math/big"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+)
+
+var (
+	_ ipld.Node            = &plainBigInt{}
+	_ ipld.BigIntNode      = &plainBigInt{}
+	_ ipld.BigIntAssembler = &anyBuilder{}
+	_ ipld.BigIntAssembler = &plainMap__ValueAssembler{}
+	_ ipld.BigIntAssembler = &plainList__ValueAssembler{}
+)
+
+// NewBigInt returns an int node holding a copy of value.
+// Values which fit in an int64 give the same node as NewInt;
+// others give a node which implements ipld.BigIntNode,
+// and whose AsInt method returns an ipld.ErrIntOverflow.
+//
+// The Any prototype, and the value assemblers of maps and lists,
+// implement ipld.BigIntAssembler with this function.
+// The Int prototype only holds an int64.
+func NewBigInt(value *big.Int) ipld.Node {
+	if value.IsInt64() {
+		return NewInt(value.Int64())
+	}
+	return &plainBigInt{new(big.Int).Set(value)}
+}
+
+// plainBigInt is an int which doesn't fit in an int64.
+type plainBigInt struct {
+	v *big.Int
+}
+
+// -- Node interface methods -->
+
+func (plainBigInt) Kind() ipld.Kind {
+	return ipld.Kind_Int
+}
+func (plainBigInt) LookupByString(string) (ipld.Node, error) {
+	return mixins.Int{TypeName: "int"}.LookupByString("")
+}
+func (plainBigInt) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	return mixins.Int{TypeName: "int"}.LookupByNode(nil)
+}
+func (plainBigInt) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Int{TypeName: "int"}.LookupByIndex(0)
+}
+func (plainBigInt) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return mixins.Int{TypeName: "int"}.LookupBySegment(seg)
+}
+func (plainBigInt) MapIterator() ipld.MapIterator {
+	return nil
+}
+func (plainBigInt) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (plainBigInt) Length() int64 {
+	return -1
+}
+func (plainBigInt) IsAbsent() bool {
+	return false
+}
+func (plainBigInt) IsNull() bool {
+	return false
+}
+func (plainBigInt) AsBool() (bool, error) {
+	return mixins.Int{TypeName: "int"}.AsBool()
+}
+func (n *plainBigInt) AsInt() (int64, error) {
+	return 0, ipld.ErrIntOverflow{Value: n.v}
+}
+func (n *plainBigInt) AsBigInt() (*big.Int, error) {
+	return n.v, nil
+}
+func (plainBigInt) AsFloat() (float64, error) {
+	return mixins.Int{TypeName: "int"}.AsFloat()
+}
+func (plainBigInt) AsString() (string, error) {
+	return mixins.Int{TypeName: "int"}.AsString()
+}
+func (plainBigInt) AsBytes() ([]byte, error) {
+	return mixins.Int{TypeName: "int"}.AsBytes()
+}
+func (plainBigInt) AsLink() (ipld.Link, error) {
+	return mixins.Int{TypeName: "int"}.AsLink()
+}
+func (plainBigInt) Prototype() ipld.NodePrototype {
+	return Prototype__Any{}
+}
+
+// -- BigIntAssembler methods on other assemblers -->
+
+func (nb *anyBuilder) AssignBigInt(v *big.Int) error {
+	if nb.kind != ipld.Kind_Invalid {
+		panic("misuse")
+	}
+	nb.kind = ipld.Kind_Int
+	nb.scalarNode = NewBigInt(v)
+	return nil
+}
+func (mva *plainMap__ValueAssembler) AssignBigInt(v *big.Int) error {
+	return mva.AssignNode(NewBigInt(v))
+}
+func (lva *plainList__ValueAssembler) AssignBigInt(v *big.Int) error {
+	return lva.AssignNode(NewBigInt(v))
+}
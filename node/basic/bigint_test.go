@@ -0,0 +1,61 @@
+package basicnode
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	ipld "github.com/ipld/go-ipld-prime"
+)
+
+func TestBigInt(t *testing.T) {
+	maxUint := new(big.Int).SetUint64(math.MaxUint64)
+
+	// Values which fit in an int64 are plain ints.
+	Wish(t, NewBigInt(big.NewInt(-3)), ShouldEqual, NewInt(-3))
+
+	n := NewBigInt(maxUint)
+	Wish(t, n.Kind(), ShouldEqual, ipld.Kind_Int)
+	_, err := n.AsInt()
+	Wish(t, err, ShouldEqual, ipld.ErrIntOverflow{Value: maxUint})
+	v, err := ipld.AsBigInt(n)
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, v.Cmp(maxUint), ShouldEqual, 0)
+
+	// The value is copied.
+	orig := new(big.Int).Set(maxUint)
+	n = NewBigInt(orig)
+	orig.SetInt64(0)
+	v, _ = ipld.AsBigInt(n)
+	Wish(t, v.Cmp(maxUint), ShouldEqual, 0)
+
+	// Any, map and list assemblers take big ints.
+	nb := Prototype.List.NewBuilder()
+	la, err := nb.BeginList(2)
+	Require(t, err, ShouldEqual, nil)
+	ma, err := la.AssembleValue().BeginMap(1)
+	Require(t, err, ShouldEqual, nil)
+	Require(t, ma.AssembleKey().AssignString("k"), ShouldEqual, nil)
+	Require(t, ipld.AssignBigInt(ma.AssembleValue(), maxUint), ShouldEqual, nil)
+	Require(t, ma.Finish(), ShouldEqual, nil)
+	Require(t, ipld.AssignBigInt(la.AssembleValue(), maxUint), ShouldEqual, nil)
+	Require(t, la.Finish(), ShouldEqual, nil)
+	l := nb.Build()
+	v1, _ := l.LookupByIndex(1)
+	Wish(t, ipld.DeepEqual(v1, n), ShouldEqual, true)
+	v0, _ := l.LookupByIndex(0)
+	v0, _ = v0.LookupByString("k")
+	Wish(t, ipld.DeepEqual(v0, n), ShouldEqual, true)
+
+	nb = Prototype.Any.NewBuilder()
+	Require(t, ipld.AssignBigInt(nb, maxUint), ShouldEqual, nil)
+	Wish(t, ipld.DeepEqual(nb.Build(), n), ShouldEqual, true)
+
+	// Int only holds an int64.
+	nb = Prototype.Int.NewBuilder()
+	Wish(t, ipld.AssignBigInt(nb, maxUint), ShouldEqual, ipld.ErrIntOverflow{Value: maxUint})
+	Require(t, ipld.AssignBigInt(nb, big.NewInt(5)), ShouldEqual, nil)
+	Wish(t, nb.Build(), ShouldEqual, NewInt(5))
+}
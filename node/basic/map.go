@@ -18,13 +18,18 @@ var (
 // It can contain any kind of value.
 // plainMap is also embedded in the 'any' struct and usable from there.
 type plainMap struct {
-	m map[string]ipld.Node // string key -- even if a runtime schema wrapper is using us for storage, we must have a comparable type here, and string is all we know.
-	t []plainMap__Entry    // table for fast iteration, order keeping, and yielding pointers to enable alloc/conv amortization.
+	m map[string]int    // index into 't' by key; only built once there are more than plainMap__indexThreshold entries, and nil before that.
+	t []plainMap__Entry // table for fast iteration, order keeping, and yielding pointers to enable alloc/conv amortization.
 }
 
+// plainMap__indexThreshold is how many entries a plainMap holds before it builds an index of its keys.
+// Up to this size, scanning the entry table is about as fast as a map lookup, and saves allocating the map;
+// most maps in the wild are small, so this saves a lot of memory when decoding lots of them.
+const plainMap__indexThreshold = 8
+
 type plainMap__Entry struct {
 	k plainString // address of this used when we return keys as nodes, such as in iterators.  Need in one place to amortize shifts to heap when ptr'ing for iface.
-	v ipld.Node   // identical to map values.  keeping them here simplifies iteration.  (in codegen'd maps, this position is also part of amortization, but in this implementation, that's less useful.)
+	v ipld.Node   // the only place values are kept; the index just points here.  (in codegen'd maps, this position is also part of amortization, but in this implementation, that's less useful.)
 	// note on alternate implementations: 'v' could also use the 'any' type, and thus amortize value allocations.  the memory size trade would be large however, so we don't, here.
 }
 
@@ -34,11 +39,11 @@ func (plainMap) Kind() ipld.Kind {
 	return ipld.Kind_Map
 }
 func (n *plainMap) LookupByString(key string) (ipld.Node, error) {
-	v, exists := n.m[key]
-	if !exists {
+	idx := n.index(key)
+	if idx < 0 {
 		return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(key)}
 	}
-	return v, nil
+	return n.t[idx].v, nil
 }
 func (n *plainMap) LookupByNode(key ipld.Node) (ipld.Node, error) {
 	ks, err := key.AsString()
@@ -90,6 +95,40 @@ func (plainMap) Prototype() ipld.NodePrototype {
 	return Prototype__Map{}
 }
 
+// index returns the position of a key in the entry table, or -1 if it's not there.
+func (n *plainMap) index(key string) int {
+	if n.m != nil {
+		idx, exists := n.m[key]
+		if !exists {
+			return -1
+		}
+		return idx
+	}
+	for i := range n.t {
+		if string(n.t[i].k) == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// indexLast adds the last entry in the table to the index,
+// building the index first if the table just grew past plainMap__indexThreshold.
+func (n *plainMap) indexLast() {
+	l := len(n.t)
+	switch {
+	case l <= plainMap__indexThreshold:
+		return
+	case n.m == nil:
+		n.m = make(map[string]int, cap(n.t))
+		for i := range n.t {
+			n.m[string(n.t[i].k)] = i
+		}
+	default:
+		n.m[string(n.t[l-1].k)] = l - 1
+	}
+}
+
 type plainMap_MapIterator struct {
 	n   *plainMap
 	idx int
@@ -141,6 +180,12 @@ type plainMap__Assembler struct {
 	ka plainMap__KeyAssembler
 	va plainMap__ValueAssembler
 
+	// cm and cl are the assemblers for values which are maps and lists.
+	// They're allocated the first time such a value comes along, and reused for every one after that,
+	//  along with all of their own child assemblers, since only one value is ever being assembled at a time.
+	cm *plainMap__ValueAssemblerMap
+	cl *plainMap__ValueAssemblerList
+
 	state maState
 }
 type plainMap__KeyAssembler struct {
@@ -167,8 +212,8 @@ func (na *plainMap__Assembler) BeginMap(sizeHint int64) (ipld.MapAssembler, erro
 		sizeHint = 0
 	}
 	// Allocate storage space.
+	//  The index of keys is left until there are enough entries to need it; see plainMap__indexThreshold.
 	na.w.t = make([]plainMap__Entry, 0, sizeHint)
-	na.w.m = make(map[string]ipld.Node, sizeHint)
 	// That's it; return self as the MapAssembler.  We already have all the right methods on this structure.
 	return na, nil
 }
@@ -247,8 +292,7 @@ func (ma *plainMap__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, erro
 		panic("misuse")
 	}
 	// Check for dup keys; error if so.
-	_, exists := ma.w.m[k]
-	if exists {
+	if ma.w.index(k) >= 0 {
 		return nil, ipld.ErrRepeatedMapKey{Key: plainString(k)}
 	}
 	ma.state = maState_midValue
@@ -325,14 +369,13 @@ func (plainMap__KeyAssembler) AssignFloat(float64) error {
 func (mka *plainMap__KeyAssembler) AssignString(v string) error {
 	// Check for dup keys; error if so.
 	//  (And, backtrack state to accepting keys again so we don't get eternally wedged here.)
-	_, exists := mka.ma.w.m[v]
-	if exists {
+	if mka.ma.w.index(v) >= 0 {
 		mka.ma.state = maState_initial
 		mka.ma = nil // invalidate self to prevent further incorrect use.
 		return ipld.ErrRepeatedMapKey{Key: plainString(v)}
 	}
 	// Assign the key into the end of the entry table;
-	//  we'll be doing map insertions after we get the value in hand.
+	//  we'll be doing index insertions after we get the value in hand.
 	//  (There's no need to delegate to another assembler for the key type,
 	//   because we're just at Data Model level here, which only regards plain strings.)
 	mka.ma.w.t = append(mka.ma.w.t, plainMap__Entry{})
@@ -362,18 +405,26 @@ func (plainMap__KeyAssembler) Prototype() ipld.NodePrototype {
 // -- MapAssembler.ValueAssembler -->
 
 func (mva *plainMap__ValueAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
-	ma := plainMap__ValueAssemblerMap{}
+	if mva.ma.cm == nil {
+		mva.ma.cm = &plainMap__ValueAssemblerMap{}
+	}
+	ma := mva.ma.cm
 	ma.ca.w = &plainMap{}
+	ma.ca.state = maState_initial
 	ma.p = mva.ma
 	_, err := ma.ca.BeginMap(sizeHint)
-	return &ma, err
+	return ma, err
 }
 func (mva *plainMap__ValueAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
-	la := plainMap__ValueAssemblerList{}
+	if mva.ma.cl == nil {
+		mva.ma.cl = &plainMap__ValueAssemblerList{}
+	}
+	la := mva.ma.cl
 	la.ca.w = &plainList{}
+	la.ca.state = laState_initial
 	la.p = mva.ma
 	_, err := la.ca.BeginList(sizeHint)
-	return &la, err
+	return la, err
 }
 func (mva *plainMap__ValueAssembler) AssignNull() error {
 	return mva.AssignNode(ipld.Null)
@@ -405,7 +456,7 @@ func (mva *plainMap__ValueAssembler) AssignLink(v ipld.Link) error {
 func (mva *plainMap__ValueAssembler) AssignNode(v ipld.Node) error {
 	l := len(mva.ma.w.t) - 1
 	mva.ma.w.t[l].v = v
-	mva.ma.w.m[string(mva.ma.w.t[l].k)] = v
+	mva.ma.w.indexLast()
 	mva.ma.state = maState_initial
 	mva.ma = nil // invalidate self to prevent further incorrect use.
 	return nil
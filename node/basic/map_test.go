@@ -1,8 +1,12 @@
 package basicnode
 
 import (
+	"fmt"
 	"testing"
 
+	. "github.com/warpfork/go-wish"
+
+	ipld "github.com/ipld/go-ipld-prime"
 	"github.com/ipld/go-ipld-prime/node/tests"
 )
 
@@ -12,6 +16,55 @@ func TestMap(t *testing.T) {
 	tests.SpecTestMapStrListStr(t, Prototype__Map{})
 }
 
+// TestMapIndex checks maps on both sides of plainMap__indexThreshold,
+// and that values which are maps come out right when their assemblers are reused.
+func TestMapIndex(t *testing.T) {
+	for _, n := range []int{plainMap__indexThreshold, plainMap__indexThreshold + 1, 25} {
+		t.Run(fmt.Sprintf("n=%d", n), func(t *testing.T) {
+			nb := Prototype__Map{}.NewBuilder()
+			ma, err := nb.BeginMap(int64(n))
+			Require(t, err, ShouldEqual, nil)
+			for i := 0; i < n; i++ {
+				va, err := ma.AssembleEntry(fmt.Sprintf("k%d", i))
+				Require(t, err, ShouldEqual, nil)
+				ma2, err := va.BeginMap(1)
+				Require(t, err, ShouldEqual, nil)
+				Require(t, ma2.AssembleKey().AssignString("i"), ShouldEqual, nil)
+				Require(t, ma2.AssembleValue().AssignInt(int64(i)), ShouldEqual, nil)
+				Require(t, ma2.Finish(), ShouldEqual, nil)
+			}
+
+			// Repeated keys are rejected, whichever way they come in.
+			_, err = ma.AssembleEntry("k0")
+			Wish(t, err, ShouldEqual, ipld.ErrRepeatedMapKey{Key: plainString("k0")})
+			err = ma.AssembleKey().AssignString(fmt.Sprintf("k%d", n-1))
+			Wish(t, err, ShouldEqual, ipld.ErrRepeatedMapKey{Key: plainString(fmt.Sprintf("k%d", n-1))})
+
+			Require(t, ma.Finish(), ShouldEqual, nil)
+			m := nb.Build()
+			Wish(t, m.Length(), ShouldEqual, int64(n))
+
+			for i := 0; i < n; i++ {
+				v, err := m.LookupByString(fmt.Sprintf("k%d", i))
+				Require(t, err, ShouldEqual, nil)
+				v, err = v.LookupByString("i")
+				Require(t, err, ShouldEqual, nil)
+				Wish(t, v, ShouldEqual, NewInt(int64(i)))
+			}
+			_, err = m.LookupByString("nope")
+			Wish(t, err, ShouldEqual, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString("nope")})
+
+			i := 0
+			for itr := m.MapIterator(); !itr.Done(); i++ {
+				k, _, err := itr.Next()
+				Require(t, err, ShouldEqual, nil)
+				Wish(t, k, ShouldEqual, NewString(fmt.Sprintf("k%d", i)))
+			}
+			Wish(t, i, ShouldEqual, n)
+		})
+	}
+}
+
 func BenchmarkMapStrInt_3n_AssembleStandard(b *testing.B) {
 	tests.SpecBenchmarkMapStrInt_3n_AssembleStandard(b, Prototype__Map{})
 }
@@ -0,0 +1,481 @@
+package bindnode
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/ipld/go-ipld-prime"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+)
+
+type prototype struct {
+	typ reflect.Type
+}
+
+func (p *prototype) NewBuilder() ipld.NodeBuilder {
+	return &builder{assembler{reflect.New(p.typ).Elem()}}
+}
+
+type builder struct {
+	assembler
+}
+
+func (b *builder) Build() ipld.Node {
+	return nodeOf(b.val)
+}
+
+func (b *builder) Reset() {
+	b.val = reflect.New(b.val.Type()).Elem()
+}
+
+// assembler sets a Go value, which may be of any supported type,
+// including pointers and ipld.Node.
+type assembler struct {
+	val reflect.Value
+}
+
+// target returns the value to set, allocating through any pointers on the way.
+func (a *assembler) target() reflect.Value {
+	v := a.val
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func (a *assembler) wrongKind(methodName string, appropriate ipld.KindSet) error {
+	t := a.val.Type()
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return ipld.ErrWrongKind{TypeName: t.String(), MethodName: methodName, AppropriateKind: appropriate, ActualKind: kindOf(t)}
+}
+
+func (a *assembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	v := a.target()
+	if v.Type() == nodeType {
+		nb := basicnode.Prototype.Any.NewBuilder()
+		ma, err := nb.BeginMap(sizeHint)
+		if err != nil {
+			return nil, err
+		}
+		return &anyMapAssembler{ma, nb, v}, nil
+	}
+	if kindOf(v.Type()) != ipld.Kind_Map {
+		return nil, a.wrongKind("BeginMap", ipld.KindSet_JustMap)
+	}
+	if v.Kind() == reflect.Map {
+		if sizeHint < 0 {
+			sizeHint = 0
+		}
+		v.Set(reflect.MakeMapWithSize(v.Type(), int(sizeHint)))
+		return &mapAssembler{m: v}, nil
+	}
+	v.Set(reflect.Zero(v.Type()))
+	info := mustStructInfo(v.Type())
+	return &structAssembler{val: v, info: info, seen: make([]bool, len(info.fields))}, nil
+}
+
+func (a *assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	v := a.target()
+	if v.Type() == nodeType {
+		nb := basicnode.Prototype.Any.NewBuilder()
+		la, err := nb.BeginList(sizeHint)
+		if err != nil {
+			return nil, err
+		}
+		return &anyListAssembler{la, nb, v}, nil
+	}
+	if kindOf(v.Type()) != ipld.Kind_List {
+		return nil, a.wrongKind("BeginList", ipld.KindSet_JustList)
+	}
+	if v.Kind() == reflect.Slice {
+		if sizeHint < 0 {
+			sizeHint = 0
+		}
+		v.Set(reflect.MakeSlice(v.Type(), 0, int(sizeHint)))
+	} else {
+		v.Set(reflect.Zero(v.Type()))
+	}
+	return &listAssembler{val: v}, nil
+}
+
+func (a *assembler) AssignNull() error {
+	switch a.val.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		a.val.Set(reflect.Zero(a.val.Type()))
+		return nil
+	}
+	return a.wrongKind("AssignNull", ipld.KindSet_JustNull)
+}
+
+func (a *assembler) AssignBool(b bool) error {
+	v := a.target()
+	switch {
+	case v.Type() == nodeType:
+		v.Set(reflect.ValueOf(basicnode.NewBool(b)))
+		return nil
+	case kindOf(v.Type()) == ipld.Kind_Bool:
+		v.SetBool(b)
+		return nil
+	}
+	return a.wrongKind("AssignBool", ipld.KindSet_JustBool)
+}
+
+func (a *assembler) AssignInt(i int64) error {
+	v := a.target()
+	if v.Type() == nodeType {
+		v.Set(reflect.ValueOf(basicnode.NewInt(i)))
+		return nil
+	}
+	if kindOf(v.Type()) != ipld.Kind_Int {
+		return a.wrongKind("AssignInt", ipld.KindSet_JustInt)
+	}
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if i < 0 || v.OverflowUint(uint64(i)) {
+			return fmt.Errorf("bindnode: %d overflows %s", i, v.Type())
+		}
+		v.SetUint(uint64(i))
+	default:
+		if v.OverflowInt(i) {
+			return fmt.Errorf("bindnode: %d overflows %s", i, v.Type())
+		}
+		v.SetInt(i)
+	}
+	return nil
+}
+
+// AssignBigInt implements ipld.BigIntAssembler,
+// so that uint64 fields can take values which don't fit in an int64.
+func (a *assembler) AssignBigInt(i *big.Int) error {
+	if i.IsInt64() {
+		return a.AssignInt(i.Int64())
+	}
+	v := a.target()
+	if v.Type() == nodeType {
+		v.Set(reflect.ValueOf(basicnode.NewBigInt(i)))
+		return nil
+	}
+	if kindOf(v.Type()) != ipld.Kind_Int {
+		return a.wrongKind("AssignBigInt", ipld.KindSet_JustInt)
+	}
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if i.IsUint64() && !v.OverflowUint(i.Uint64()) {
+			v.SetUint(i.Uint64())
+			return nil
+		}
+	}
+	return fmt.Errorf("bindnode: %s overflows %s", i, v.Type())
+}
+
+func (a *assembler) AssignFloat(f float64) error {
+	v := a.target()
+	switch {
+	case v.Type() == nodeType:
+		v.Set(reflect.ValueOf(basicnode.NewFloat(f)))
+		return nil
+	case kindOf(v.Type()) == ipld.Kind_Float:
+		v.SetFloat(f)
+		return nil
+	}
+	return a.wrongKind("AssignFloat", ipld.KindSet_JustFloat)
+}
+
+func (a *assembler) AssignString(s string) error {
+	v := a.target()
+	switch {
+	case v.Type() == nodeType:
+		v.Set(reflect.ValueOf(basicnode.NewString(s)))
+		return nil
+	case kindOf(v.Type()) == ipld.Kind_String:
+		v.SetString(s)
+		return nil
+	}
+	return a.wrongKind("AssignString", ipld.KindSet_JustString)
+}
+
+func (a *assembler) AssignBytes(b []byte) error {
+	v := a.target()
+	switch {
+	case v.Type() == nodeType:
+		v.Set(reflect.ValueOf(basicnode.NewBytes(b)))
+		return nil
+	case kindOf(v.Type()) != ipld.Kind_Bytes:
+		return a.wrongKind("AssignBytes", ipld.KindSet_JustBytes)
+	case v.Kind() == reflect.Slice:
+		v.SetBytes(append([]byte(nil), b...))
+		return nil
+	case len(b) != v.Len():
+		return fmt.Errorf("bindnode: cannot assign %d bytes to %s", len(b), v.Type())
+	}
+	for i, c := range b {
+		v.Index(i).SetUint(uint64(c))
+	}
+	return nil
+}
+
+func (a *assembler) AssignLink(l ipld.Link) error {
+	v := a.target()
+	switch {
+	case v.Type() == nodeType:
+		v.Set(reflect.ValueOf(basicnode.NewLink(l)))
+		return nil
+	case kindOf(v.Type()) != ipld.Kind_Link:
+		return a.wrongKind("AssignLink", ipld.KindSet_JustLink)
+	}
+	lv := reflect.ValueOf(l)
+	if !lv.Type().AssignableTo(v.Type()) {
+		return fmt.Errorf("bindnode: cannot assign link of type %s to %s", lv.Type(), v.Type())
+	}
+	v.Set(lv)
+	return nil
+}
+
+func (a *assembler) AssignNode(n ipld.Node) error {
+	if a.val.Type() == nodeType {
+		a.val.Set(reflect.ValueOf(&n).Elem())
+		return nil
+	}
+	return ipld.Copy(a, n)
+}
+
+func (a *assembler) Prototype() ipld.NodePrototype {
+	return &prototype{a.val.Type()}
+}
+
+// mapAssembler fills a Go map.
+// Map values can't be set in place, so each value is assembled separately,
+// and set in the map when the next entry starts, or when the map is finished.
+type mapAssembler struct {
+	m          reflect.Value
+	key, value reflect.Value // the pending entry, if value is valid.
+}
+
+func (ma *mapAssembler) flush() {
+	if ma.value.IsValid() {
+		ma.m.SetMapIndex(ma.key, ma.value)
+		ma.value = reflect.Value{}
+	}
+}
+
+func (ma *mapAssembler) AssembleKey() ipld.NodeAssembler {
+	ma.flush()
+	ma.key = reflect.New(ma.m.Type().Key()).Elem()
+	return &keyAssembler{ma.m.Type().String(), func(k string) error {
+		ma.key.SetString(k)
+		if ma.m.MapIndex(ma.key).IsValid() {
+			return ipld.ErrRepeatedMapKey{Key: basicnode.NewString(k)}
+		}
+		return nil
+	}}
+}
+
+func (ma *mapAssembler) AssembleValue() ipld.NodeAssembler {
+	ma.value = reflect.New(ma.m.Type().Elem()).Elem()
+	return &assembler{ma.value}
+}
+
+func (ma *mapAssembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	if err := ma.AssembleKey().AssignString(k); err != nil {
+		return nil, err
+	}
+	return ma.AssembleValue(), nil
+}
+
+func (ma *mapAssembler) Finish() error {
+	ma.flush()
+	return nil
+}
+
+func (ma *mapAssembler) KeyPrototype() ipld.NodePrototype {
+	return basicnode.Prototype.String
+}
+
+func (ma *mapAssembler) ValuePrototype(k string) ipld.NodePrototype {
+	return &prototype{ma.m.Type().Elem()}
+}
+
+// structAssembler fills the fields of a Go struct, in place.
+type structAssembler struct {
+	val  reflect.Value
+	info *structInfo
+	seen []bool
+	cur  int // the field whose key was assembled last
+}
+
+func (sa *structAssembler) AssembleKey() ipld.NodeAssembler {
+	return &keyAssembler{sa.val.Type().String(), func(k string) error {
+		i, ok := sa.info.byName[k]
+		if !ok {
+			return ipld.ErrInvalidKey{TypeName: sa.val.Type().String(), Key: basicnode.NewString(k)}
+		}
+		if sa.seen[i] {
+			return ipld.ErrRepeatedMapKey{Key: basicnode.NewString(k)}
+		}
+		sa.seen[i] = true
+		sa.cur = i
+		return nil
+	}}
+}
+
+func (sa *structAssembler) AssembleValue() ipld.NodeAssembler {
+	return &assembler{sa.val.Field(sa.info.fields[sa.cur].index)}
+}
+
+func (sa *structAssembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	if err := sa.AssembleKey().AssignString(k); err != nil {
+		return nil, err
+	}
+	return sa.AssembleValue(), nil
+}
+
+func (sa *structAssembler) Finish() error {
+	var missing []string
+	for i, f := range sa.info.fields {
+		if !sa.seen[i] && !f.optional {
+			missing = append(missing, f.name)
+		}
+	}
+	if missing != nil {
+		return ipld.ErrMissingRequiredField{Missing: missing}
+	}
+	return nil
+}
+
+func (sa *structAssembler) KeyPrototype() ipld.NodePrototype {
+	return basicnode.Prototype.String
+}
+
+func (sa *structAssembler) ValuePrototype(k string) ipld.NodePrototype {
+	i, ok := sa.info.byName[k]
+	if !ok {
+		return basicnode.Prototype.Any
+	}
+	return &prototype{sa.val.Type().Field(sa.info.fields[i].index).Type}
+}
+
+// listAssembler fills a Go slice by appending, or a Go array in place.
+type listAssembler struct {
+	val reflect.Value
+	n   int // values assembled so far
+}
+
+func (la *listAssembler) AssembleValue() ipld.NodeAssembler {
+	if la.val.Kind() == reflect.Slice {
+		la.val.Set(reflect.Append(la.val, reflect.Zero(la.val.Type().Elem())))
+	} else if la.n >= la.val.Len() {
+		return errorAssembler{fmt.Errorf("bindnode: too many values for %s", la.val.Type())}
+	}
+	la.n++
+	return &assembler{la.val.Index(la.n - 1)}
+}
+
+func (la *listAssembler) Finish() error {
+	if la.n != la.val.Len() {
+		return fmt.Errorf("bindnode: %d values for %s", la.n, la.val.Type())
+	}
+	return nil
+}
+
+func (la *listAssembler) ValuePrototype(idx int64) ipld.NodePrototype {
+	return &prototype{la.val.Type().Elem()}
+}
+
+// keyAssembler takes a map key as a string, and hands it to a func to be checked and used.
+type keyAssembler struct {
+	typeName string
+	assign   func(string) error
+}
+
+func (ka *keyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{TypeName: ka.typeName}.BeginMap(0)
+}
+func (ka *keyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{TypeName: ka.typeName}.BeginList(0)
+}
+func (ka *keyAssembler) AssignNull() error {
+	return mixins.StringAssembler{TypeName: ka.typeName}.AssignNull()
+}
+func (ka *keyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{TypeName: ka.typeName}.AssignBool(false)
+}
+func (ka *keyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{TypeName: ka.typeName}.AssignInt(0)
+}
+func (ka *keyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{TypeName: ka.typeName}.AssignFloat(0)
+}
+func (ka *keyAssembler) AssignString(s string) error {
+	return ka.assign(s)
+}
+func (ka *keyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{TypeName: ka.typeName}.AssignBytes(nil)
+}
+func (ka *keyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{TypeName: ka.typeName}.AssignLink(nil)
+}
+func (ka *keyAssembler) AssignNode(n ipld.Node) error {
+	s, err := n.AsString()
+	if err != nil {
+		return err
+	}
+	return ka.assign(s)
+}
+func (ka *keyAssembler) Prototype() ipld.NodePrototype {
+	return basicnode.Prototype.String
+}
+
+// anyMapAssembler and anyListAssembler assemble data for an ipld.Node in a basicnode,
+// and set the Go value when they finish.
+type anyMapAssembler struct {
+	ipld.MapAssembler
+	nb  ipld.NodeBuilder
+	val reflect.Value
+}
+
+func (ma *anyMapAssembler) Finish() error {
+	if err := ma.MapAssembler.Finish(); err != nil {
+		return err
+	}
+	ma.val.Set(reflect.ValueOf(ma.nb.Build()))
+	return nil
+}
+
+type anyListAssembler struct {
+	ipld.ListAssembler
+	nb  ipld.NodeBuilder
+	val reflect.Value
+}
+
+func (la *anyListAssembler) Finish() error {
+	if err := la.ListAssembler.Finish(); err != nil {
+		return err
+	}
+	la.val.Set(reflect.ValueOf(la.nb.Build()))
+	return nil
+}
+
+// errorAssembler returns the same error from every method,
+// for when AssembleValue has no way to return one itself.
+type errorAssembler struct {
+	err error
+}
+
+func (ea errorAssembler) BeginMap(int64) (ipld.MapAssembler, error)   { return nil, ea.err }
+func (ea errorAssembler) BeginList(int64) (ipld.ListAssembler, error) { return nil, ea.err }
+func (ea errorAssembler) AssignNull() error                           { return ea.err }
+func (ea errorAssembler) AssignBool(bool) error                       { return ea.err }
+func (ea errorAssembler) AssignInt(int64) error                       { return ea.err }
+func (ea errorAssembler) AssignFloat(float64) error                   { return ea.err }
+func (ea errorAssembler) AssignString(string) error                   { return ea.err }
+func (ea errorAssembler) AssignBytes([]byte) error                    { return ea.err }
+func (ea errorAssembler) AssignLink(ipld.Link) error                  { return ea.err }
+func (ea errorAssembler) AssignNode(ipld.Node) error                  { return ea.err }
+func (ea errorAssembler) Prototype() ipld.NodePrototype               { return nil }
@@ -0,0 +1,273 @@
+/*
+	The bindnode package implements ipld.Node over ordinary Go values, via reflection,
+	so that applications can use their own Go types with codecs and traversals
+	without running the code generator.
+
+	Go types map onto the Data Model like so:
+
+		bool                        bool
+		intN, uintN                 int
+		floatN                      float
+		string                      string
+		[]byte, [N]byte             bytes
+		other slices and arrays     list
+		map[string]T                map, iterated in key order
+		struct                      map, of its exported fields in order
+		*T                          T, or null when nil
+		ipld.Link, or a Link type   link, or null when a nil interface
+		ipld.Node                   any data at all, or null when nil
+
+	Named types with any of those underlying types work too.
+
+	A struct field's map key is its name, unless a struct tag like `ipld:"key"` gives another.
+	Fields tagged `ipld:"-"` are left out.
+	A pointer field may be tagged `ipld:",optional"`:
+	when nil, it's absent from the map rather than null.
+	When assembling a struct, every field which isn't optional must be present,
+	and keys which match no field are rejected.
+
+	Nodes view the Go value they wrap, rather than copying it:
+	changing the Go value changes what the node holds.
+	Nodes are meant to be immutable, so don't change a Go value while a node viewing it is in use.
+
+	To check the data against a schema, and get typed nodes,
+	use WrapTyped and TypedPrototype, which combine this package with wrapnode.
+	The Go value then holds the representation of the schema type:
+	for example, a struct type with a tuple representation is held in a Go slice,
+	while the default map representation is held in a Go struct.
+*/
+package bindnode
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/wrapnode"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// Wrap returns a node viewing the Go value that ptr points to.
+//
+// Wrap panics if ptr isn't a non-nil pointer,
+// or if it points to a Go type which can't be used as a node.
+func Wrap(ptr interface{}) ipld.Node {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		panic(fmt.Sprintf("bindnode: Wrap needs a non-nil pointer, got %T", ptr))
+	}
+	mustCheck(v.Type().Elem())
+	return nodeOf(v.Elem())
+}
+
+// Prototype returns a NodePrototype whose builders assemble new Go values,
+// of the type that ptr points to.
+// ptr may be nil, as in Prototype((*Person)(nil)).
+// Use Unwrap on a built node to get at its Go value.
+//
+// Prototype panics if ptr isn't a pointer,
+// or if it points to a Go type which can't be used as a node.
+func Prototype(ptr interface{}) ipld.NodePrototype {
+	t := reflect.TypeOf(ptr)
+	if t == nil || t.Kind() != reflect.Ptr {
+		panic(fmt.Sprintf("bindnode: Prototype needs a pointer, got %T", ptr))
+	}
+	mustCheck(t.Elem())
+	return &prototype{t.Elem()}
+}
+
+// Unwrap returns a pointer to the Go value held by a node from this package,
+// or by a typed node from WrapTyped or TypedPrototype.
+// It returns nil for any other node.
+func Unwrap(n ipld.Node) interface{} {
+	if tn, ok := n.(schema.TypedNode); ok {
+		n = tn.Representation()
+	}
+	bn, ok := n.(*node)
+	if !ok {
+		return nil
+	}
+	if bn.val.CanAddr() {
+		return bn.val.Addr().Interface()
+	}
+	ptr := reflect.New(bn.val.Type())
+	ptr.Elem().Set(bn.val)
+	return ptr.Interface()
+}
+
+// WrapTyped is like Wrap, but checks that the Go value holds the representation
+// of the named schema type, and returns a typed node viewing it.
+func WrapTyped(ts *schema.TypeSystem, typeName schema.TypeName, ptr interface{}) (schema.TypedNode, error) {
+	return wrapnode.Wrap(ts, typeName, Wrap(ptr))
+}
+
+// TypedPrototype is like Prototype, but its builders check that the data
+// is the representation of the named schema type, and build typed nodes.
+// See wrapnode.Prototype for when the data is checked.
+func TypedPrototype(ts *schema.TypeSystem, typeName schema.TypeName, ptr interface{}) ipld.NodePrototype {
+	return wrapnode.PrototypeWithStorage(ts, typeName, Prototype(ptr))
+}
+
+var (
+	nodeType = reflect.TypeOf((*ipld.Node)(nil)).Elem()
+	linkType = reflect.TypeOf((*ipld.Link)(nil)).Elem()
+)
+
+// isLink reports whether values of a type are links.
+// Pointer types are dereferenced before asking, so they're never links themselves.
+func isLink(t reflect.Type) bool {
+	if t == linkType {
+		return true
+	}
+	return t.Kind() != reflect.Interface && t.Kind() != reflect.Ptr && t.Implements(linkType)
+}
+
+// isBytes reports whether values of a slice or array type are bytes.
+func isBytes(t reflect.Type) bool {
+	return t.Elem().Kind() == reflect.Uint8 && !isLink(t.Elem())
+}
+
+// kindOf returns the kind of the nodes for values of a type,
+// which mustn't be a pointer, nor ipld.Node.
+func kindOf(t reflect.Type) ipld.Kind {
+	if isLink(t) {
+		return ipld.Kind_Link
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return ipld.Kind_Bool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return ipld.Kind_Int
+	case reflect.Float32, reflect.Float64:
+		return ipld.Kind_Float
+	case reflect.String:
+		return ipld.Kind_String
+	case reflect.Slice, reflect.Array:
+		if isBytes(t) {
+			return ipld.Kind_Bytes
+		}
+		return ipld.Kind_List
+	case reflect.Map, reflect.Struct:
+		return ipld.Kind_Map
+	}
+	return ipld.Kind_Invalid
+}
+
+var checked sync.Map // map[reflect.Type]error
+
+func mustCheck(t reflect.Type) {
+	if err, ok := checked.Load(t); ok {
+		if err != nil {
+			panic(err)
+		}
+		return
+	}
+	err := check(t, map[reflect.Type]bool{})
+	checked.Store(t, err)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// check returns an error if a Go type, or any type it holds, can't be used as a node.
+// Types already in seen are assumed to be fine, so that recursive types work.
+func check(t reflect.Type, seen map[reflect.Type]bool) error {
+	if seen[t] {
+		return nil
+	}
+	seen[t] = true
+	if t == nodeType || isLink(t) {
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return check(t.Elem(), seen)
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return fmt.Errorf("bindnode: unsupported map key type in %s: only string keys are supported", t)
+		}
+		return check(t.Elem(), seen)
+	case reflect.Struct:
+		info, err := structInfoOf(t)
+		if err != nil {
+			return err
+		}
+		for _, f := range info.fields {
+			if err := check(t.Field(f.index).Type, seen); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if kindOf(t) == ipld.Kind_Invalid {
+		return fmt.Errorf("bindnode: unsupported type %s", t)
+	}
+	return nil
+}
+
+// structInfo describes how a struct type maps onto a map.
+type structInfo struct {
+	fields []fieldInfo
+	byName map[string]int // index into fields
+}
+
+type fieldInfo struct {
+	name     string
+	index    int // the index of the Go struct field
+	optional bool
+}
+
+var structInfos sync.Map // map[reflect.Type]*structInfo
+
+func structInfoOf(t reflect.Type) (*structInfo, error) {
+	if info, ok := structInfos.Load(t); ok {
+		return info.(*structInfo), nil
+	}
+	info := &structInfo{byName: make(map[string]int)}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" { // unexported
+			continue
+		}
+		f := fieldInfo{name: sf.Name, index: i}
+		if tag, ok := sf.Tag.Lookup("ipld"); ok {
+			if tag == "-" {
+				continue
+			}
+			opts := strings.Split(tag, ",")
+			if opts[0] != "" {
+				f.name = opts[0]
+			}
+			for _, opt := range opts[1:] {
+				switch opt {
+				case "optional":
+					if sf.Type.Kind() != reflect.Ptr {
+						return nil, fmt.Errorf("bindnode: optional field %s.%s must be a pointer", t, sf.Name)
+					}
+					f.optional = true
+				default:
+					return nil, fmt.Errorf("bindnode: unknown option %q on field %s.%s", opt, t, sf.Name)
+				}
+			}
+		}
+		if _, exists := info.byName[f.name]; exists {
+			return nil, fmt.Errorf("bindnode: repeated key %q in %s", f.name, t)
+		}
+		info.byName[f.name] = len(info.fields)
+		info.fields = append(info.fields, f)
+	}
+	actual, _ := structInfos.LoadOrStore(t, info)
+	return actual.(*structInfo), nil
+}
+
+// mustStructInfo is structInfoOf for types which were already checked.
+func mustStructInfo(t reflect.Type) *structInfo {
+	info, err := structInfoOf(t)
+	if err != nil {
+		panic(err)
+	}
+	return info
+}
@@ -0,0 +1,254 @@
+package bindnode_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+
+	cid "github.com/ipfs/go-cid"
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec/dagjson"
+	"github.com/ipld/go-ipld-prime/codec/tests"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/node/bindnode"
+	"github.com/ipld/go-ipld-prime/schema"
+	schemaparser "github.com/ipld/go-ipld-prime/schema/parser"
+	"github.com/ipld/go-ipld-prime/traversal"
+)
+
+type Person struct {
+	Name    string             `ipld:"name"`
+	Age     uint8              `ipld:"age"`
+	Nick    *string            `ipld:"nick,optional"`
+	Friend  *Person            `ipld:"friend"`
+	Tags    []string           `ipld:"tags"`
+	Scores  map[string]float64 `ipld:"scores"`
+	Avatar  []byte             `ipld:"avatar"`
+	Home    cidlink.Link       `ipld:"home"`
+	Extra   ipld.Node          `ipld:"extra"`
+	Skipped bool               `ipld:"-"`
+	secret  int
+}
+
+func decode(t *testing.T, np ipld.NodePrototype, s string) (ipld.Node, error) {
+	nb := np.NewBuilder()
+	if err := dagjson.Decode(nb, strings.NewReader(s)); err != nil {
+		return nil, err
+	}
+	return nb.Build(), nil
+}
+
+const personJSON = `{"name":"Alice","age":30,"nick":"al",` +
+	`"friend":{"name":"Bob","age":31,"friend":null,"tags":[],"scores":{},"avatar":{"/":{"bytes":""}},"home":{"/":"bafkqaaa"},"extra":null},` +
+	`"tags":["a","b"],"scores":{"x":1.5,"y":2.5},"avatar":{"/":{"bytes":"AAEC"}},"home":{"/":"bafkqaaa"},"extra":{"any":[1,true]}}`
+
+func TestRoundtrip(t *testing.T) {
+	n, err := decode(t, bindnode.Prototype((*Person)(nil)), personJSON)
+	Require(t, err, ShouldEqual, nil)
+
+	p := bindnode.Unwrap(n).(*Person)
+	Wish(t, p.Name, ShouldEqual, "Alice")
+	Wish(t, p.Age, ShouldEqual, uint8(30))
+	Wish(t, *p.Nick, ShouldEqual, "al")
+	Wish(t, p.Friend.Name, ShouldEqual, "Bob")
+	Wish(t, p.Friend.Friend == nil, ShouldEqual, true)
+	Wish(t, p.Friend.Nick == nil, ShouldEqual, true)
+	Wish(t, p.Tags, ShouldEqual, []string{"a", "b"})
+	Wish(t, p.Scores, ShouldEqual, map[string]float64{"x": 1.5, "y": 2.5})
+	Wish(t, p.Avatar, ShouldEqual, []byte{0, 1, 2})
+	Wish(t, p.Home.String(), ShouldEqual, "bafkqaaa")
+	Wish(t, p.Extra.Kind(), ShouldEqual, ipld.Kind_Map)
+
+	Wish(t, tests.ToJSON(t, n), ShouldEqual, personJSON)
+	Wish(t, tests.ToJSON(t, bindnode.Wrap(p)), ShouldEqual, personJSON)
+}
+
+func TestWrap(t *testing.T) {
+	c, err := cid.Decode("bafkqaalb")
+	Require(t, err, ShouldEqual, nil)
+	p := &Person{
+		Name:   "Alice",
+		Tags:   []string{"a"},
+		Scores: map[string]float64{"b": 2, "a": 1},
+		Home:   cidlink.Link{Cid: c},
+		Extra:  basicnode.NewString("x"),
+	}
+	n := bindnode.Wrap(p)
+	Wish(t, n.Kind(), ShouldEqual, ipld.Kind_Map)
+	Wish(t, n.Length(), ShouldEqual, int64(8)) // nick is absent.
+
+	nick, err := n.LookupByString("nick")
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, nick.IsAbsent(), ShouldEqual, true)
+	friend, err := n.LookupByString("friend")
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, friend.IsNull(), ShouldEqual, true)
+	_, err = n.LookupByString("Skipped")
+	Wish(t, err, ShouldEqual, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString("Skipped")})
+
+	v, err := traversal.Get(n, ipld.ParsePath("tags/0"))
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, ipld.DeepEqual(v, basicnode.NewString("a")), ShouldEqual, true)
+	v, err = n.LookupByString("home")
+	Require(t, err, ShouldEqual, nil)
+	l, err := v.AsLink()
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, l, ShouldEqual, cidlink.Link{Cid: c})
+
+	// Go maps are iterated in key order.
+	scores, err := n.LookupByString("scores")
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, tests.ToJSON(t, scores), ShouldEqual, `{"a":1.0,"b":2.0}`)
+
+	// Nodes view the Go value, rather than copying it.
+	p.Name = "Carol"
+	name, err := n.LookupByString("name")
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, ipld.DeepEqual(name, basicnode.NewString("Carol")), ShouldEqual, true)
+	Wish(t, bindnode.Unwrap(n) == p, ShouldEqual, true)
+
+	// Scalars, lists, and arrays work at the top level too.
+	i := uint64(math.MaxUint64)
+	_, err = bindnode.Wrap(&i).AsInt()
+	Wish(t, err, ShouldEqual, ipld.ErrIntOverflow{Value: new(big.Int).SetUint64(math.MaxUint64)})
+	Wish(t, tests.ToJSON(t, bindnode.Wrap(&i)), ShouldEqual, `18446744073709551615`)
+	arr := [2][3]byte{{1, 2, 3}}
+	Wish(t, tests.ToJSON(t, bindnode.Wrap(&arr)), ShouldEqual, `[{"/":{"bytes":"AQID"}},{"/":{"bytes":"AAAA"}}]`)
+}
+
+func TestAssembleErrors(t *testing.T) {
+	full := func(extra string) string {
+		return `{"name":"x","age":1,"friend":null,"tags":[],"scores":{},"avatar":{"/":{"bytes":""}},"home":{"/":"bafkqaaa"},"extra":null` + extra + `}`
+	}
+	_, err := decode(t, bindnode.Prototype((*Person)(nil)), full(""))
+	Wish(t, err, ShouldEqual, nil)
+
+	for _, tc := range []struct {
+		name string
+		data string
+		want error
+	}{
+		{"missing fields", `{"name":"x","age":1}`, ipld.ErrMissingRequiredField{Missing: []string{"friend", "tags", "scores", "avatar", "home", "extra"}}},
+		{"unknown key", full(`,"nope":1`), ipld.ErrInvalidKey{TypeName: "bindnode_test.Person", Key: basicnode.NewString("nope")}},
+		{"repeated key", full(`,"name":"y"`), ipld.ErrRepeatedMapKey{Key: basicnode.NewString("name")}},
+		{"wrong kind", strings.Replace(full(""), `"name":"x"`, `"name":1`, 1), ipld.ErrWrongKind{TypeName: "string", MethodName: "AssignInt", AppropriateKind: ipld.KindSet_JustInt, ActualKind: ipld.Kind_String}},
+		{"not nullable", strings.Replace(full(""), `"tags":[]`, `"tags":null`, 1), ipld.ErrWrongKind{TypeName: "[]string", MethodName: "AssignNull", AppropriateKind: ipld.KindSet_JustNull, ActualKind: ipld.Kind_List}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := decode(t, bindnode.Prototype((*Person)(nil)), tc.data)
+			Wish(t, errors.Unwrap(err), ShouldEqual, tc.want)
+		})
+	}
+
+	_, err = decode(t, bindnode.Prototype((*Person)(nil)), strings.Replace(full(""), `"age":1`, `"age":300`, 1))
+	Wish(t, err != nil, ShouldEqual, true)
+	_, err = decode(t, bindnode.Prototype((*int64)(nil)), `9223372036854775808`)
+	Wish(t, errors.Unwrap(err), ShouldEqual, fmt.Errorf("bindnode: 9223372036854775808 overflows int64"))
+	_, err = decode(t, bindnode.Prototype((*uint32)(nil)), `9223372036854775808`)
+	Wish(t, errors.Unwrap(err), ShouldEqual, fmt.Errorf("bindnode: 9223372036854775808 overflows uint32"))
+	_, err = decode(t, bindnode.Prototype((*[2]int)(nil)), `[1,2,3]`)
+	Wish(t, err != nil, ShouldEqual, true)
+	_, err = decode(t, bindnode.Prototype((*[2]int)(nil)), `[1]`)
+	Wish(t, err != nil, ShouldEqual, true)
+	_, err = decode(t, bindnode.Prototype((*map[string][]int)(nil)), `{"a":[1],"a":[2]}`)
+	Wish(t, errors.Unwrap(err), ShouldEqual, ipld.ErrRepeatedMapKey{Key: basicnode.NewString("a")})
+}
+
+func TestBigUint(t *testing.T) {
+	type T struct {
+		A uint64
+		B []uint64
+		C ipld.Node
+	}
+	serial := `{"A":18446744073709551615,"B":[9223372036854775808],"C":9223372036854775808}`
+	n, err := decode(t, bindnode.Prototype((*T)(nil)), serial)
+	Require(t, err, ShouldEqual, nil)
+	v := bindnode.Unwrap(n).(*T)
+	Wish(t, v.A, ShouldEqual, uint64(math.MaxUint64))
+	Wish(t, v.B, ShouldEqual, []uint64{1 << 63})
+	Wish(t, tests.ToJSON(t, n), ShouldEqual, serial)
+
+	// Copying from other nodes works too.
+	nb := bindnode.Prototype((*T)(nil)).NewBuilder()
+	Require(t, ipld.Copy(nb, n), ShouldEqual, nil)
+	Wish(t, tests.ToJSON(t, nb.Build()), ShouldEqual, serial)
+}
+
+func TestUnsupportedTypes(t *testing.T) {
+	for _, ptr := range []interface{}{
+		(*chan int)(nil),
+		(*map[int]string)(nil),
+		(*interface{})(nil),
+		(*struct {
+			A int `ipld:",optional"`
+		})(nil),
+		(*struct {
+			A int `ipld:"x"`
+			B int `ipld:"x"`
+		})(nil),
+		(*[]func())(nil),
+		"not a pointer",
+	} {
+		func() {
+			defer func() {
+				Wish(t, recover() != nil, ShouldEqual, true)
+			}()
+			bindnode.Prototype(ptr)
+		}()
+	}
+}
+
+func TestTyped(t *testing.T) {
+	ts, err := schemaparser.Parse(strings.NewReader(`
+		type Point struct {
+			x Int
+			y Int
+			label optional String
+		}
+		type Pair struct {
+			left Int
+			right Int
+		} representation tuple
+	`))
+	Require(t, err, ShouldEqual, nil)
+	type Point struct {
+		X     int     `ipld:"x"`
+		Y     int     `ipld:"y"`
+		Label *string `ipld:"label,optional"`
+	}
+
+	// A map-represented struct is held in a Go struct.
+	tn, err := bindnode.WrapTyped(ts, "Point", &Point{X: 1, Y: 2})
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, tn.Type().Name(), ShouldEqual, schema.TypeName("Point"))
+	label, err := tn.LookupByString("label")
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, label.IsAbsent(), ShouldEqual, true)
+	Wish(t, tests.ToJSON(t, tn.Representation()), ShouldEqual, `{"x":1,"y":2}`)
+
+	n, err := decode(t, bindnode.TypedPrototype(ts, "Point", (*Point)(nil)), `{"x":3,"y":4,"label":"p"}`)
+	Require(t, err, ShouldEqual, nil)
+	p := bindnode.Unwrap(n).(*Point)
+	Wish(t, p.X, ShouldEqual, 3)
+	Wish(t, *p.Label, ShouldEqual, "p")
+
+	// A tuple-represented struct is held in a Go slice.
+	n, err = decode(t, bindnode.TypedPrototype(ts, "Pair", (*[]int)(nil)), `[5,6]`)
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, *bindnode.Unwrap(n).(*[]int), ShouldEqual, []int{5, 6})
+	right, err := n.LookupByString("right")
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, ipld.DeepEqual(right.(schema.TypedNode).Representation(), basicnode.NewInt(6)), ShouldEqual, true)
+
+	// Data which doesn't match the schema is rejected.
+	_, err = bindnode.WrapTyped(ts, "Pair", &[]int{1})
+	Wish(t, err != nil, ShouldEqual, true)
+	_, err = decode(t, bindnode.TypedPrototype(ts, "Pair", (*[]int)(nil)), `[1,2,3]`)
+	Wish(t, err != nil, ShouldEqual, true)
+}
@@ -0,0 +1,289 @@
+package bindnode
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+
+	"github.com/ipld/go-ipld-prime"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+)
+
+// node views a Go value, which is never a pointer nor an ipld.Node;
+// nodeOf deals with those.
+type node struct {
+	val reflect.Value
+}
+
+// nodeOf returns the node for a Go value,
+// following pointers, and turning nils into null.
+func nodeOf(v reflect.Value) ipld.Node {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ipld.Null
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Interface && v.IsNil() {
+		return ipld.Null
+	}
+	if v.Type() == nodeType {
+		return v.Interface().(ipld.Node)
+	}
+	return &node{v}
+}
+
+func (n *node) wrongKind(methodName string, appropriate ipld.KindSet) error {
+	return ipld.ErrWrongKind{TypeName: n.val.Type().String(), MethodName: methodName, AppropriateKind: appropriate, ActualKind: n.Kind()}
+}
+
+func (n *node) Kind() ipld.Kind {
+	return kindOf(n.val.Type())
+}
+
+func (n *node) LookupByString(key string) (ipld.Node, error) {
+	if n.Kind() != ipld.Kind_Map {
+		return nil, n.wrongKind("LookupByString", ipld.KindSet_JustMap)
+	}
+	if n.val.Kind() == reflect.Map {
+		v := n.val.MapIndex(reflect.ValueOf(key).Convert(n.val.Type().Key()))
+		if !v.IsValid() {
+			return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(key)}
+		}
+		return nodeOf(v), nil
+	}
+	info := mustStructInfo(n.val.Type())
+	i, ok := info.byName[key]
+	if !ok {
+		return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(key)}
+	}
+	f := info.fields[i]
+	v := n.val.Field(f.index)
+	if f.optional && v.IsNil() {
+		return ipld.Absent, nil
+	}
+	return nodeOf(v), nil
+}
+
+func (n *node) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	if n.Kind() != ipld.Kind_Map {
+		return nil, n.wrongKind("LookupByNode", ipld.KindSet_JustMap)
+	}
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+
+func (n *node) LookupByIndex(idx int64) (ipld.Node, error) {
+	if n.Kind() != ipld.Kind_List {
+		return nil, n.wrongKind("LookupByIndex", ipld.KindSet_JustList)
+	}
+	if idx < 0 || idx >= int64(n.val.Len()) {
+		return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfInt(idx)}
+	}
+	return nodeOf(n.val.Index(int(idx))), nil
+}
+
+func (n *node) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	switch n.Kind() {
+	case ipld.Kind_Map:
+		return n.LookupByString(seg.String())
+	case ipld.Kind_List:
+		idx, err := seg.Index()
+		if err != nil {
+			return nil, ipld.ErrInvalidSegmentForList{TypeName: n.val.Type().String(), TroubleSegment: seg, Reason: err}
+		}
+		return n.LookupByIndex(idx)
+	}
+	return nil, n.wrongKind("LookupBySegment", ipld.KindSet_Recursive)
+}
+
+func (n *node) MapIterator() ipld.MapIterator {
+	if n.Kind() != ipld.Kind_Map {
+		return nil
+	}
+	if n.val.Kind() == reflect.Map {
+		keys := n.val.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		return &goMapIterator{n.val, keys}
+	}
+	return &structIterator{n.val, mustStructInfo(n.val.Type()), 0}
+}
+
+func (n *node) ListIterator() ipld.ListIterator {
+	if n.Kind() != ipld.Kind_List {
+		return nil
+	}
+	return &listIterator{n.val, 0}
+}
+
+func (n *node) Length() int64 {
+	switch n.Kind() {
+	case ipld.Kind_List:
+		return int64(n.val.Len())
+	case ipld.Kind_Map:
+		if n.val.Kind() == reflect.Map {
+			return int64(n.val.Len())
+		}
+		length := int64(0)
+		for _, f := range mustStructInfo(n.val.Type()).fields {
+			if !f.optional || !n.val.Field(f.index).IsNil() {
+				length++
+			}
+		}
+		return length
+	}
+	return -1
+}
+
+func (n *node) IsAbsent() bool {
+	return false
+}
+
+func (n *node) IsNull() bool {
+	return false
+}
+
+func (n *node) AsBool() (bool, error) {
+	if n.Kind() != ipld.Kind_Bool {
+		return false, n.wrongKind("AsBool", ipld.KindSet_JustBool)
+	}
+	return n.val.Bool(), nil
+}
+
+func (n *node) AsInt() (int64, error) {
+	if n.Kind() != ipld.Kind_Int {
+		return 0, n.wrongKind("AsInt", ipld.KindSet_JustInt)
+	}
+	switch n.val.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u := n.val.Uint()
+		if u > math.MaxInt64 {
+			return 0, ipld.ErrIntOverflow{Value: new(big.Int).SetUint64(u)}
+		}
+		return int64(u), nil
+	}
+	return n.val.Int(), nil
+}
+
+// AsBigInt implements ipld.BigIntNode, so that uint64 values which don't
+// fit in an int64 can still be read.
+func (n *node) AsBigInt() (*big.Int, error) {
+	if n.Kind() != ipld.Kind_Int {
+		return nil, n.wrongKind("AsBigInt", ipld.KindSet_JustInt)
+	}
+	switch n.val.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(n.val.Uint()), nil
+	}
+	return big.NewInt(n.val.Int()), nil
+}
+
+func (n *node) AsFloat() (float64, error) {
+	if n.Kind() != ipld.Kind_Float {
+		return 0, n.wrongKind("AsFloat", ipld.KindSet_JustFloat)
+	}
+	return n.val.Float(), nil
+}
+
+func (n *node) AsString() (string, error) {
+	if n.Kind() != ipld.Kind_String {
+		return "", n.wrongKind("AsString", ipld.KindSet_JustString)
+	}
+	return n.val.String(), nil
+}
+
+func (n *node) AsBytes() ([]byte, error) {
+	if n.Kind() != ipld.Kind_Bytes {
+		return nil, n.wrongKind("AsBytes", ipld.KindSet_JustBytes)
+	}
+	if n.val.Kind() == reflect.Slice {
+		return n.val.Bytes(), nil
+	}
+	b := make([]byte, n.val.Len())
+	for i := range b {
+		b[i] = byte(n.val.Index(i).Uint())
+	}
+	return b, nil
+}
+
+func (n *node) AsLink() (ipld.Link, error) {
+	if n.Kind() != ipld.Kind_Link {
+		return nil, n.wrongKind("AsLink", ipld.KindSet_JustLink)
+	}
+	return n.val.Interface().(ipld.Link), nil
+}
+
+func (n *node) Prototype() ipld.NodePrototype {
+	return &prototype{n.val.Type()}
+}
+
+type goMapIterator struct {
+	m    reflect.Value
+	keys []reflect.Value // sorted, and consumed as we go
+}
+
+func (itr *goMapIterator) Next() (ipld.Node, ipld.Node, error) {
+	if itr.Done() {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	k := itr.keys[0]
+	itr.keys = itr.keys[1:]
+	return basicnode.NewString(k.String()), nodeOf(itr.m.MapIndex(k)), nil
+}
+
+func (itr *goMapIterator) Done() bool {
+	return len(itr.keys) == 0
+}
+
+type structIterator struct {
+	val  reflect.Value
+	info *structInfo
+	idx  int // the next field, skipping any absent ones
+}
+
+// skipAbsent moves idx past any optional fields which are nil.
+func (itr *structIterator) skipAbsent() {
+	for itr.idx < len(itr.info.fields) {
+		f := itr.info.fields[itr.idx]
+		if !f.optional || !itr.val.Field(f.index).IsNil() {
+			return
+		}
+		itr.idx++
+	}
+}
+
+func (itr *structIterator) Next() (ipld.Node, ipld.Node, error) {
+	if itr.Done() {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	f := itr.info.fields[itr.idx]
+	itr.idx++
+	return basicnode.NewString(f.name), nodeOf(itr.val.Field(f.index)), nil
+}
+
+func (itr *structIterator) Done() bool {
+	itr.skipAbsent()
+	return itr.idx >= len(itr.info.fields)
+}
+
+type listIterator struct {
+	val reflect.Value
+	idx int
+}
+
+func (itr *listIterator) Next() (int64, ipld.Node, error) {
+	if itr.Done() {
+		return -1, nil, ipld.ErrIteratorOverread{}
+	}
+	idx := itr.idx
+	itr.idx++
+	return int64(idx), nodeOf(itr.val.Index(idx)), nil
+}
+
+func (itr *listIterator) Done() bool {
+	return itr.idx >= itr.val.Len()
+}
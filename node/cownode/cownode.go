@@ -0,0 +1,487 @@
+/*
+	The cownode package presents an existing Node as if it were mutable,
+	by keeping writes in an overlay on top of it: copy-on-write.
+
+	Wrap a node, then Set and Delete values at paths within it;
+	the wrapper reads as the original data with those edits applied,
+	while the original node is never touched.
+	Only the maps and lists along the paths written to get overlays of their own,
+	and only for the entries which were written;
+	everything else keeps reading straight from the original.
+
+	Commit materializes the edits as a new immutable node.
+	It only builds new maps and lists where there were edits, using basicnode;
+	every untouched value, however big, is shared with the original by reference.
+	So a small edit to a big document costs about as much as the path to it,
+	rather than the whole document.
+*/
+package cownode
+
+import (
+	"fmt"
+
+	"github.com/ipld/go-ipld-prime"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+)
+
+var _ ipld.Node = &Node{}
+
+// Node is a copy-on-write view of another node.
+// It reads as the node it wraps, with the writes made to it applied.
+//
+// Node is not safe for concurrent use while it's being written to.
+type Node struct {
+	base ipld.Node
+
+	// dirty is true once a write has succeeded on this node or anywhere within it.
+	dirty bool
+
+	// The overlay, for maps: values set by key, which may be new keys;
+	//  keys deleted from the base; and the new keys, in the order they were set.
+	set     map[string]ipld.Node
+	deleted map[string]struct{}
+	added   []string
+
+	// The overlay, for lists: values replaced by index, and values appended after the base's.
+	replaced map[int64]ipld.Node
+	appended []ipld.Node
+
+	// Any of the above values may be another *Node, when writes have gone deeper than this node.
+}
+
+// Wrap returns a copy-on-write view of n, with no writes yet.
+func Wrap(n ipld.Node) *Node {
+	return &Node{base: n}
+}
+
+// Set puts a value at a path.
+//
+// All but the last segment of the path must already exist, and lead through maps and lists.
+// If the last segment names a map key, the entry is replaced, or added at the end of the map if it's new.
+// If it names a list index, the value there is replaced;
+// the index may also be the length of the list, which appends the value.
+//
+// The path can't be empty, since a Node can't replace itself;
+// just Wrap the new value instead.
+func (n *Node) Set(p ipld.Path, v ipld.Node) error {
+	parents, last, err := n.walk("Set", p)
+	if err != nil {
+		return err
+	}
+	parent := parents[len(parents)-1]
+	switch parent.base.Kind() {
+	case ipld.Kind_Map:
+		parent.setEntry(last.String(), v)
+	case ipld.Kind_List:
+		idx, err := parent.index(last)
+		if err != nil {
+			return err
+		}
+		switch {
+		case idx < 0:
+			return ipld.ErrNotExists{Segment: last}
+		case idx < parent.Length():
+			parent.setIndex(idx, v)
+		case idx == parent.Length():
+			parent.appended = append(parent.appended, v)
+		default:
+			return ipld.ErrNotExists{Segment: last}
+		}
+	}
+	markDirty(parents)
+	return nil
+}
+
+// Delete removes a map entry at a path.
+//
+// All of the path must already exist, and lead through maps and lists;
+// the last segment must name a key of a map.
+// Removing values from lists isn't supported, since that would move all the values after them.
+func (n *Node) Delete(p ipld.Path) error {
+	parents, last, err := n.walk("Delete", p)
+	if err != nil {
+		return err
+	}
+	parent := parents[len(parents)-1]
+	if parent.base.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{MethodName: "Delete", AppropriateKind: ipld.KindSet_JustMap, ActualKind: parent.base.Kind()}
+	}
+	k := last.String()
+	if _, err := parent.LookupByString(k); err != nil {
+		return err
+	}
+	parent.deleteEntry(k)
+	markDirty(parents)
+	return nil
+}
+
+// walk finds the node which a write to a path goes into,
+// and the last segment of the path, which names the value within it.
+// It returns all the nodes along the way, starting with n, and ending with the one the write goes into;
+// each of them besides n has been put in the overlay of its parent.
+// The method name is for errors.
+func (n *Node) walk(method string, p ipld.Path) ([]*Node, ipld.PathSegment, error) {
+	segs := p.Segments()
+	if len(segs) == 0 {
+		return nil, ipld.PathSegment{}, fmt.Errorf("cownode: cannot write to an empty path")
+	}
+	parents := []*Node{n}
+	for i, seg := range segs {
+		cur := parents[len(parents)-1]
+		switch k := cur.base.Kind(); k {
+		case ipld.Kind_Map, ipld.Kind_List:
+		default:
+			return nil, ipld.PathSegment{}, fmt.Errorf("cownode: at %s: %w", p.Truncate(i), ipld.ErrWrongKind{MethodName: method, AppropriateKind: ipld.KindSet_Recursive, ActualKind: k})
+		}
+		if i == len(segs)-1 {
+			return parents, seg, nil
+		}
+		child, err := cur.child(seg)
+		if err != nil {
+			return nil, ipld.PathSegment{}, fmt.Errorf("cownode: at %s: %w", p.Truncate(i+1), err)
+		}
+		parents = append(parents, child)
+	}
+	panic("unreachable")
+}
+
+// child returns the value of a map or list at a segment, wrapped as a *Node and put in the overlay,
+// so that writes can go into it.
+func (n *Node) child(seg ipld.PathSegment) (*Node, error) {
+	v, err := n.LookupBySegment(seg)
+	if err != nil {
+		return nil, err
+	}
+	if c, ok := v.(*Node); ok {
+		return c, nil
+	}
+	c := Wrap(v)
+	switch n.base.Kind() {
+	case ipld.Kind_Map:
+		n.setEntry(seg.String(), c)
+	case ipld.Kind_List:
+		idx, _ := n.index(seg) // already checked by the lookup.
+		n.setIndex(idx, c)
+	}
+	return c, nil
+}
+
+func markDirty(nodes []*Node) {
+	for _, n := range nodes {
+		n.dirty = true
+	}
+}
+
+func (n *Node) setEntry(k string, v ipld.Node) {
+	if n.set == nil {
+		n.set = make(map[string]ipld.Node)
+	}
+	_, wasSet := n.set[k]
+	_, wasDeleted := n.deleted[k]
+	n.set[k] = v
+	switch {
+	case wasDeleted:
+		delete(n.deleted, k) // back in the base's position.
+	case wasSet:
+	default:
+		if _, err := n.base.LookupByString(k); err != nil {
+			n.added = append(n.added, k)
+		}
+	}
+}
+
+func (n *Node) deleteEntry(k string) {
+	delete(n.set, k)
+	for i, k2 := range n.added {
+		if k2 == k {
+			n.added = append(n.added[:i:i], n.added[i+1:]...)
+			return
+		}
+	}
+	if n.deleted == nil {
+		n.deleted = make(map[string]struct{})
+	}
+	n.deleted[k] = struct{}{}
+}
+
+func (n *Node) setIndex(idx int64, v ipld.Node) {
+	if baseLen := n.base.Length(); idx >= baseLen {
+		n.appended[idx-baseLen] = v
+		return
+	}
+	if n.replaced == nil {
+		n.replaced = make(map[int64]ipld.Node)
+	}
+	n.replaced[idx] = v
+}
+
+func (n *Node) index(seg ipld.PathSegment) (int64, error) {
+	idx, err := seg.Index()
+	if err != nil {
+		return 0, ipld.ErrInvalidSegmentForList{TroubleSegment: seg, Reason: err}
+	}
+	return idx, nil
+}
+
+// Commit returns a new node holding the data as it reads now, with the writes applied.
+//
+// The maps and lists which were written to, and those above them, are built anew with basicnode;
+// all other values are the very same nodes as in the wrapped node.
+// If nothing has been written, the wrapped node itself is returned.
+//
+// The Node stays usable after Commit: further writes don't affect the committed node.
+func (n *Node) Commit() (ipld.Node, error) {
+	if !n.dirty {
+		return n.base, nil
+	}
+	nb := basicnode.Prototype.Any.NewBuilder()
+	switch n.base.Kind() {
+	case ipld.Kind_Map:
+		ma, err := nb.BeginMap(n.Length())
+		if err != nil {
+			return nil, err
+		}
+		for itr := n.MapIterator(); !itr.Done(); {
+			k, v, err := itr.Next()
+			if err != nil {
+				return nil, err
+			}
+			if v, err = commitValue(v); err != nil {
+				return nil, err
+			}
+			if err := ma.AssembleKey().AssignNode(k); err != nil {
+				return nil, err
+			}
+			if err := ma.AssembleValue().AssignNode(v); err != nil {
+				return nil, err
+			}
+		}
+		if err := ma.Finish(); err != nil {
+			return nil, err
+		}
+	case ipld.Kind_List:
+		la, err := nb.BeginList(n.Length())
+		if err != nil {
+			return nil, err
+		}
+		for itr := n.ListIterator(); !itr.Done(); {
+			_, v, err := itr.Next()
+			if err != nil {
+				return nil, err
+			}
+			if v, err = commitValue(v); err != nil {
+				return nil, err
+			}
+			if err := la.AssembleValue().AssignNode(v); err != nil {
+				return nil, err
+			}
+		}
+		if err := la.Finish(); err != nil {
+			return nil, err
+		}
+	}
+	return nb.Build(), nil
+}
+
+func commitValue(v ipld.Node) (ipld.Node, error) {
+	if c, ok := v.(*Node); ok {
+		return c.Commit()
+	}
+	return v, nil
+}
+
+// -- Node interface methods -->
+
+func (n *Node) Kind() ipld.Kind {
+	return n.base.Kind()
+}
+func (n *Node) LookupByString(key string) (ipld.Node, error) {
+	if n.base.Kind() != ipld.Kind_Map {
+		return n.base.LookupByString(key)
+	}
+	if v, ok := n.set[key]; ok {
+		return v, nil
+	}
+	if _, ok := n.deleted[key]; ok {
+		return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(key)}
+	}
+	return n.base.LookupByString(key)
+}
+func (n *Node) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	switch n.base.Kind() {
+	case ipld.Kind_Map:
+		ks, err := key.AsString()
+		if err != nil {
+			return nil, err
+		}
+		return n.LookupByString(ks)
+	case ipld.Kind_List:
+		ki, err := key.AsInt()
+		if err != nil {
+			return nil, err
+		}
+		return n.LookupByIndex(ki)
+	default:
+		return n.base.LookupByNode(key)
+	}
+}
+func (n *Node) LookupByIndex(idx int64) (ipld.Node, error) {
+	if n.base.Kind() != ipld.Kind_List {
+		return n.base.LookupByIndex(idx)
+	}
+	if v, ok := n.replaced[idx]; ok {
+		return v, nil
+	}
+	baseLen := n.base.Length()
+	if idx >= baseLen && idx < baseLen+int64(len(n.appended)) {
+		return n.appended[idx-baseLen], nil
+	}
+	return n.base.LookupByIndex(idx)
+}
+func (n *Node) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	switch n.base.Kind() {
+	case ipld.Kind_Map:
+		return n.LookupByString(seg.String())
+	case ipld.Kind_List:
+		idx, err := n.index(seg)
+		if err != nil {
+			return nil, err
+		}
+		return n.LookupByIndex(idx)
+	default:
+		return n.base.LookupBySegment(seg)
+	}
+}
+func (n *Node) MapIterator() ipld.MapIterator {
+	if n.base.Kind() != ipld.Kind_Map {
+		return nil
+	}
+	itr := &mapIterator{n: n, base: n.base.MapIterator()}
+	itr.advance()
+	return itr
+}
+func (n *Node) ListIterator() ipld.ListIterator {
+	if n.base.Kind() != ipld.Kind_List {
+		return nil
+	}
+	return &listIterator{n: n}
+}
+func (n *Node) Length() int64 {
+	switch n.base.Kind() {
+	case ipld.Kind_Map:
+		return n.base.Length() - int64(len(n.deleted)) + int64(len(n.added))
+	case ipld.Kind_List:
+		return n.base.Length() + int64(len(n.appended))
+	default:
+		return n.base.Length()
+	}
+}
+func (n *Node) IsAbsent() bool {
+	return n.base.IsAbsent()
+}
+func (n *Node) IsNull() bool {
+	return n.base.IsNull()
+}
+func (n *Node) AsBool() (bool, error) {
+	return n.base.AsBool()
+}
+func (n *Node) AsInt() (int64, error) {
+	return n.base.AsInt()
+}
+func (n *Node) AsFloat() (float64, error) {
+	return n.base.AsFloat()
+}
+func (n *Node) AsString() (string, error) {
+	return n.base.AsString()
+}
+func (n *Node) AsBytes() ([]byte, error) {
+	return n.base.AsBytes()
+}
+func (n *Node) AsLink() (ipld.Link, error) {
+	return n.base.AsLink()
+}
+
+// Prototype returns the basicnode prototype, which is what Commit builds with.
+func (n *Node) Prototype() ipld.NodePrototype {
+	return basicnode.Prototype.Any
+}
+
+// mapIterator goes through the base's entries, leaving out deleted ones and swapping in set ones,
+// then through the added ones.
+// It finds each entry one step ahead, so that Done can tell whether there is one.
+type mapIterator struct {
+	n     *Node
+	base  ipld.MapIterator
+	added int
+
+	ok   bool // whether there's a next entry, or error, in the following.
+	k, v ipld.Node
+	err  error
+}
+
+func (itr *mapIterator) advance() {
+	for !itr.base.Done() {
+		k, v, err := itr.base.Next()
+		if err != nil {
+			itr.ok, itr.err = true, err
+			return
+		}
+		ks, err := k.AsString()
+		if err != nil {
+			itr.ok, itr.err = true, err
+			return
+		}
+		if _, ok := itr.n.deleted[ks]; ok {
+			continue
+		}
+		if v2, ok := itr.n.set[ks]; ok {
+			v = v2
+		}
+		itr.ok, itr.k, itr.v = true, k, v
+		return
+	}
+	if itr.added < len(itr.n.added) {
+		ks := itr.n.added[itr.added]
+		itr.added++
+		itr.ok, itr.k, itr.v = true, basicnode.NewString(ks), itr.n.set[ks]
+		return
+	}
+	itr.ok = false
+}
+
+func (itr *mapIterator) Next() (ipld.Node, ipld.Node, error) {
+	if !itr.ok {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	k, v, err := itr.k, itr.v, itr.err
+	if err != nil {
+		itr.ok = false // the base iterator can't go on after an error.
+		return nil, nil, err
+	}
+	itr.advance()
+	return k, v, nil
+}
+func (itr *mapIterator) Done() bool {
+	return !itr.ok
+}
+
+type listIterator struct {
+	n   *Node
+	idx int64
+}
+
+func (itr *listIterator) Next() (int64, ipld.Node, error) {
+	if itr.Done() {
+		return -1, nil, ipld.ErrIteratorOverread{}
+	}
+	idx := itr.idx
+	v, err := itr.n.LookupByIndex(idx)
+	if err != nil {
+		return -1, nil, err
+	}
+	itr.idx++
+	return idx, v, nil
+}
+func (itr *listIterator) Done() bool {
+	return itr.idx >= itr.n.Length()
+}
@@ -0,0 +1,88 @@
+package cownode
+
+import (
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec/tests"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+)
+
+func TestWrites(t *testing.T) {
+	const original = `{"a":{"b":[1,2],"c":"x"},"big":{"d":[{"e":1}]},"f":true}`
+	base := tests.FromJSON(t, basicnode.Prototype.Any, original)
+	n := Wrap(base)
+
+	Require(t, n.Set(ipld.ParsePath("a/b/0"), basicnode.NewInt(10)), ShouldEqual, nil)
+	Require(t, n.Set(ipld.ParsePath("a/b/2"), basicnode.NewInt(3)), ShouldEqual, nil) // appends.
+	Require(t, n.Set(ipld.ParsePath("a/new"), basicnode.NewString("y z")), ShouldEqual, nil)
+	Require(t, n.Delete(ipld.ParsePath("a/c")), ShouldEqual, nil)
+	Require(t, n.Delete(ipld.ParsePath("f")), ShouldEqual, nil)
+	Require(t, n.Set(ipld.ParsePath("f"), basicnode.NewBool(false)), ShouldEqual, nil) // back where it was.
+	Require(t, n.Set(ipld.ParsePath("g"), tests.FromJSON(t, basicnode.Prototype.Any, `{"h":null}`)), ShouldEqual, nil)
+	Require(t, n.Set(ipld.ParsePath("g/h"), basicnode.NewInt(1)), ShouldEqual, nil)
+	Require(t, n.Set(ipld.ParsePath("a/b/2"), basicnode.NewInt(30)), ShouldEqual, nil) // replaces the appended value.
+
+	const want = `{"a":{"b":[10,2,30],"new":"y z"},"big":{"d":[{"e":1}]},"f":false,"g":{"h":1}}`
+
+	// The wrapper reads with the writes applied, and the original is untouched.
+	Wish(t, tests.ToJSON(t, n), ShouldEqual, want)
+	Wish(t, tests.ToJSON(t, base), ShouldEqual, original)
+	v, err := n.LookupBySegment(ipld.PathSegmentOfString("a"))
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, v.Length(), ShouldEqual, int64(2))
+	_, err = v.LookupByString("c")
+	Wish(t, err, ShouldEqual, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString("c")})
+
+	// Committing gives the same data, sharing the untouched parts with the original.
+	committed, err := n.Commit()
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, tests.ToJSON(t, committed), ShouldEqual, want)
+	Wish(t, ipld.DeepEqual(committed, n), ShouldEqual, true)
+	_, isWrapper := committed.(*Node)
+	Wish(t, isWrapper, ShouldEqual, false)
+	bigBefore, err := base.LookupByString("big")
+	Require(t, err, ShouldEqual, nil)
+	bigAfter, err := committed.LookupByString("big")
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, bigAfter == bigBefore, ShouldEqual, true)
+
+	// Writing more afterwards doesn't change what was committed.
+	Require(t, n.Delete(ipld.ParsePath("big")), ShouldEqual, nil)
+	Wish(t, tests.ToJSON(t, committed), ShouldEqual, want)
+}
+
+func TestNoWrites(t *testing.T) {
+	base := tests.FromJSON(t, basicnode.Prototype.Any, `{"a":[1]}`)
+	n := Wrap(base)
+
+	// A failed write leaves nothing to commit.
+	Wish(t, n.Set(ipld.ParsePath("a/5"), basicnode.NewInt(1)), ShouldEqual, ipld.ErrNotExists{Segment: ipld.ParsePathSegment("5")})
+	committed, err := n.Commit()
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, committed == base, ShouldEqual, true)
+}
+
+func TestWriteErrors(t *testing.T) {
+	n := Wrap(tests.FromJSON(t, basicnode.Prototype.Any, `{"a":[1],"s":"x"}`))
+	for _, tc := range []struct {
+		name string
+		err  error
+	}{
+		{"empty path", n.Set(ipld.ParsePath(""), ipld.Null)},
+		{"missing parent", n.Set(ipld.ParsePath("nope/b"), ipld.Null)},
+		{"into a scalar", n.Set(ipld.ParsePath("s/b"), ipld.Null)},
+		{"bad index", n.Set(ipld.ParsePath("a/x"), ipld.Null)},
+		{"negative index", n.Set(ipld.ParsePath("a/-1"), ipld.Null)},
+		{"past the end", n.Set(ipld.ParsePath("a/2"), ipld.Null)},
+		{"delete from list", n.Delete(ipld.ParsePath("a/0"))},
+		{"delete missing", n.Delete(ipld.ParsePath("nope"))},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			Wish(t, tc.err != nil, ShouldEqual, true)
+		})
+	}
+	Wish(t, tests.ToJSON(t, n), ShouldEqual, `{"a":[1],"s":"x"}`)
+}
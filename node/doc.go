@@ -12,13 +12,22 @@
 	This includes standardized behavioral tests (!), which are
 	in the 'node/mixins/tests' package.
 
+	The 'node/wrapnode' package supports Schema type constraints without
+	compile-time/codegen support, by delegating storage to another Node implementation.
+
+	The 'node/cownode' package presents any Node as if it were mutable,
+	keeping writes in a copy-on-write overlay until they're committed to a new Node.
+
+	The 'node/bindnode' package works over golang native types by use of reflection,
+	so that ordinary Go structs, slices, and maps can be used as Nodes.
+
+	The 'node/jsonnode' package presents the interface{} values which
+	encoding/json decodes into as Nodes, without converting them.
+
 	Other planned subpackages include:
 	a cbor-native Node implementation (which can optimize performance in some
 	cases by lazily parsing serial	data, and also retaining it as byte slice
 	references for minimizing reserialization work for small mutations);
-	a Node implementation which works over golang native types by use of reflection;
-	a Node implementation which supports Schema type constraints and works
-	without compile-time/codegen support by delegating storage to another Node implementation;
 	etc.
 
 	You can create your own Node implementations, too.
@@ -1,11 +1,34 @@
 package gendemo
 
 import (
+	"strings"
 	"testing"
 
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime/codec/dagjson"
 	"github.com/ipld/go-ipld-prime/node/tests"
 )
 
+func TestNativeAccessors(t *testing.T) {
+	nb := Type.Map__String__Msg3.NewBuilder()
+	Require(t, dagjson.Decode(nb, strings.NewReader(`{"a":{"whee":1,"woot":2,"waga":3}}`)), ShouldEqual, nil)
+	n := nb.Build().(Map__String__Msg3)
+
+	k, err := Type.String.FromString("a")
+	Require(t, err, ShouldEqual, nil)
+	msg := n.Lookup(k)
+	Require(t, msg != nil, ShouldEqual, true)
+	Wish(t, msg.FieldWhee().Int(), ShouldEqual, int64(1))
+	Wish(t, msg.FieldWaga().Int(), ShouldEqual, int64(3))
+	Wish(t, n.LookupMaybe(k).Must().FieldWoot().Int(), ShouldEqual, int64(2))
+
+	k, err = Type.String.FromString("b")
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, n.Lookup(k) == nil, ShouldEqual, true)
+	Wish(t, n.LookupMaybe(k).IsAbsent(), ShouldEqual, true)
+}
+
 func BenchmarkMapStrInt_3n_AssembleStandard(b *testing.B) {
 	tests.SpecBenchmarkMapStrInt_3n_AssembleStandard(b, _Msg3__Prototype{})
 }
@@ -0,0 +1,268 @@
+/*
+	The jsonnode package implements ipld.Node over the generic Go values
+	which encoding/json decodes into when given an interface{}:
+	map[string]interface{}, []interface{}, string, float64, bool, and nil.
+
+	This lets code which already holds such values hand them to traversals
+	and encoders, without first converting them into some other Node implementation.
+	Values are wrapped as they're reached, so wrapping a large document is cheap.
+
+	JSON doesn't tell ints and floats apart, and encoding/json decodes every number
+	as a float64. A float64 with no fractional part is seen as an int,
+	as long as it's small enough for the float64 to hold it exactly (2^53);
+	other float64s are floats.
+	To keep larger ints exact, decode with json.Decoder.UseNumber:
+	a json.Number is an int if it parses as an int64, and a float otherwise.
+
+	A few more types are accepted, for values assembled by hand:
+	int and int64 are ints, []byte is bytes, ipld.Link is a link,
+	and an ipld.Node is used as it is.
+
+	Nodes view the Go values they wrap, rather than copying them,
+	so don't change those values while a node viewing them is in use.
+*/
+package jsonnode
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/ipld/go-ipld-prime"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+)
+
+var (
+	_ ipld.Node = &mapNode{}
+	_ ipld.Node = &listNode{}
+)
+
+// Wrap returns a node viewing a value like those which encoding/json decodes into.
+//
+// It returns an error if the value is of an unsupported type.
+// Values nested in maps and lists are only checked once they're reached,
+// so an unsupported one is reported by the lookup or iterator which reaches it.
+func Wrap(v interface{}) (ipld.Node, error) {
+	switch x := v.(type) {
+	case nil:
+		return ipld.Null, nil
+	case bool:
+		return basicnode.NewBool(x), nil
+	case float64:
+		if x == math.Trunc(x) && math.Abs(x) <= 1<<53 {
+			return basicnode.NewInt(int64(x)), nil
+		}
+		return basicnode.NewFloat(x), nil
+	case json.Number:
+		if i, err := x.Int64(); err == nil {
+			return basicnode.NewInt(i), nil
+		}
+		f, err := x.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("jsonnode: invalid number %q: %w", x, err)
+		}
+		return basicnode.NewFloat(f), nil
+	case int:
+		return basicnode.NewInt(int64(x)), nil
+	case int64:
+		return basicnode.NewInt(x), nil
+	case string:
+		return basicnode.NewString(x), nil
+	case []byte:
+		return basicnode.NewBytes(x), nil
+	case ipld.Link:
+		return basicnode.NewLink(x), nil
+	case ipld.Node:
+		return x, nil
+	case map[string]interface{}:
+		return &mapNode{x}, nil
+	case []interface{}:
+		return &listNode{x}, nil
+	}
+	return nil, fmt.Errorf("jsonnode: unsupported value of type %T", v)
+}
+
+// mapNode is a map-kind node over a map[string]interface{}.
+// It iterates in key order, like encoding/json encodes.
+type mapNode struct {
+	x map[string]interface{}
+}
+
+func (mapNode) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n *mapNode) LookupByString(key string) (ipld.Node, error) {
+	v, ok := n.x[key]
+	if !ok {
+		return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(key)}
+	}
+	return Wrap(v)
+}
+func (n *mapNode) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (mapNode) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{TypeName: "map"}.LookupByIndex(0)
+}
+func (n *mapNode) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n *mapNode) MapIterator() ipld.MapIterator {
+	keys := make([]string, 0, len(n.x))
+	for k := range n.x {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return &mapNode_MapIterator{n, keys}
+}
+func (mapNode) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (n *mapNode) Length() int64 {
+	return int64(len(n.x))
+}
+func (mapNode) IsAbsent() bool {
+	return false
+}
+func (mapNode) IsNull() bool {
+	return false
+}
+func (mapNode) AsBool() (bool, error) {
+	return mixins.Map{TypeName: "map"}.AsBool()
+}
+func (mapNode) AsInt() (int64, error) {
+	return mixins.Map{TypeName: "map"}.AsInt()
+}
+func (mapNode) AsFloat() (float64, error) {
+	return mixins.Map{TypeName: "map"}.AsFloat()
+}
+func (mapNode) AsString() (string, error) {
+	return mixins.Map{TypeName: "map"}.AsString()
+}
+func (mapNode) AsBytes() ([]byte, error) {
+	return mixins.Map{TypeName: "map"}.AsBytes()
+}
+func (mapNode) AsLink() (ipld.Link, error) {
+	return mixins.Map{TypeName: "map"}.AsLink()
+}
+
+// Prototype returns basicnode's map prototype,
+// as this package only views existing values, and doesn't build new ones.
+func (mapNode) Prototype() ipld.NodePrototype {
+	return basicnode.Prototype.Map
+}
+
+type mapNode_MapIterator struct {
+	n    *mapNode
+	keys []string // sorted, and consumed as we go
+}
+
+func (itr *mapNode_MapIterator) Next() (k ipld.Node, v ipld.Node, _ error) {
+	if itr.Done() {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	key := itr.keys[0]
+	itr.keys = itr.keys[1:]
+	v, err := Wrap(itr.n.x[key])
+	if err != nil {
+		return nil, nil, err
+	}
+	return basicnode.NewString(key), v, nil
+}
+func (itr *mapNode_MapIterator) Done() bool {
+	return len(itr.keys) == 0
+}
+
+// listNode is a list-kind node over a []interface{}.
+type listNode struct {
+	x []interface{}
+}
+
+func (listNode) Kind() ipld.Kind {
+	return ipld.Kind_List
+}
+func (listNode) LookupByString(string) (ipld.Node, error) {
+	return mixins.List{TypeName: "list"}.LookupByString("")
+}
+func (listNode) LookupByNode(ipld.Node) (ipld.Node, error) {
+	return mixins.List{TypeName: "list"}.LookupByNode(nil)
+}
+func (n *listNode) LookupByIndex(idx int64) (ipld.Node, error) {
+	if idx < 0 || n.Length() <= idx {
+		return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfInt(idx)}
+	}
+	return Wrap(n.x[idx])
+}
+func (n *listNode) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	idx, err := seg.Index()
+	if err != nil {
+		return nil, ipld.ErrInvalidSegmentForList{TroubleSegment: seg, Reason: err}
+	}
+	return n.LookupByIndex(idx)
+}
+func (listNode) MapIterator() ipld.MapIterator {
+	return nil
+}
+func (n *listNode) ListIterator() ipld.ListIterator {
+	return &listNode_ListIterator{n, 0}
+}
+func (n *listNode) Length() int64 {
+	return int64(len(n.x))
+}
+func (listNode) IsAbsent() bool {
+	return false
+}
+func (listNode) IsNull() bool {
+	return false
+}
+func (listNode) AsBool() (bool, error) {
+	return mixins.List{TypeName: "list"}.AsBool()
+}
+func (listNode) AsInt() (int64, error) {
+	return mixins.List{TypeName: "list"}.AsInt()
+}
+func (listNode) AsFloat() (float64, error) {
+	return mixins.List{TypeName: "list"}.AsFloat()
+}
+func (listNode) AsString() (string, error) {
+	return mixins.List{TypeName: "list"}.AsString()
+}
+func (listNode) AsBytes() ([]byte, error) {
+	return mixins.List{TypeName: "list"}.AsBytes()
+}
+func (listNode) AsLink() (ipld.Link, error) {
+	return mixins.List{TypeName: "list"}.AsLink()
+}
+
+// Prototype returns basicnode's list prototype,
+// as this package only views existing values, and doesn't build new ones.
+func (listNode) Prototype() ipld.NodePrototype {
+	return basicnode.Prototype.List
+}
+
+type listNode_ListIterator struct {
+	n   *listNode
+	idx int
+}
+
+func (itr *listNode_ListIterator) Next() (idx int64, v ipld.Node, _ error) {
+	if itr.Done() {
+		return -1, nil, ipld.ErrIteratorOverread{}
+	}
+	v, err := Wrap(itr.n.x[itr.idx])
+	if err != nil {
+		return -1, nil, err
+	}
+	idx = int64(itr.idx)
+	itr.idx++
+	return idx, v, nil
+}
+func (itr *listNode_ListIterator) Done() bool {
+	return itr.idx >= len(itr.n.x)
+}
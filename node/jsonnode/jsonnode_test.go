@@ -0,0 +1,116 @@
+package jsonnode
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec/dagjson"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/traversal"
+)
+
+func unmarshal(t *testing.T, s string, useNumber bool) interface{} {
+	dec := json.NewDecoder(strings.NewReader(s))
+	if useNumber {
+		dec.UseNumber()
+	}
+	var v interface{}
+	Require(t, dec.Decode(&v), ShouldEqual, nil)
+	return v
+}
+
+func encode(t *testing.T, n ipld.Node) (string, error) {
+	var buf bytes.Buffer
+	err := dagjson.Encode(n, &buf)
+	return strings.Join(strings.Fields(buf.String()), ""), err
+}
+
+func TestWrap(t *testing.T) {
+	const doc = `{"b":[1,-2.5,"x",true,null,{}],"a":{"c":[]},"big":12345678901234567890}`
+	n, err := Wrap(unmarshal(t, doc, false))
+	Require(t, err, ShouldEqual, nil)
+
+	// Maps iterate in key order; integral numbers are ints.
+	s, err := encode(t, n)
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, s, ShouldEqual, `{"a":{"c":[]},"b":[1,-2.5,"x",true,null,{}],"big":12345678901234567000.0}`)
+
+	v, err := traversal.Get(n, ipld.ParsePath("b/1"))
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, v, ShouldEqual, basicnode.NewFloat(-2.5))
+	v, err = traversal.Get(n, ipld.ParsePath("b/4"))
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, v, ShouldEqual, ipld.Null)
+	_, err = traversal.Get(n, ipld.ParsePath("b/6"))
+	Wish(t, err != nil, ShouldEqual, true)
+	Wish(t, n.Length(), ShouldEqual, int64(3))
+
+	// It's the same data as decoding the JSON as dag-json.
+	nb := basicnode.Prototype.Any.NewBuilder()
+	Require(t, dagjson.Decode(nb, strings.NewReader(`{"a":{"c":[]},"b":[1,-2.5,"x",true,null,{}]}`)), ShouldEqual, nil)
+	m := unmarshal(t, doc, false).(map[string]interface{})
+	delete(m, "big")
+	n, err = Wrap(m)
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, ipld.DeepEqual(n, nb.Build()), ShouldEqual, true)
+}
+
+func TestNumbers(t *testing.T) {
+	for _, tc := range []struct {
+		in        interface{}
+		wantKind  ipld.Kind
+		wantValue interface{}
+	}{
+		{float64(3), ipld.Kind_Int, int64(3)},
+		{float64(-3), ipld.Kind_Int, int64(-3)},
+		{float64(1 << 53), ipld.Kind_Int, int64(1 << 53)},
+		{float64(1 << 54), ipld.Kind_Float, float64(1 << 54)},
+		{0.5, ipld.Kind_Float, 0.5},
+		{json.Number("12345678901234567"), ipld.Kind_Int, int64(12345678901234567)},
+		{json.Number("1.5"), ipld.Kind_Float, 1.5},
+		{json.Number("1e3"), ipld.Kind_Float, 1000.0},
+		{7, ipld.Kind_Int, int64(7)},
+		{int64(-7), ipld.Kind_Int, int64(-7)},
+	} {
+		n, err := Wrap(tc.in)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, n.Kind(), ShouldEqual, tc.wantKind)
+		if tc.wantKind == ipld.Kind_Int {
+			v, _ := n.AsInt()
+			Wish(t, v, ShouldEqual, tc.wantValue)
+		} else {
+			v, _ := n.AsFloat()
+			Wish(t, v, ShouldEqual, tc.wantValue)
+		}
+	}
+
+	n, err := Wrap(unmarshal(t, `[12345678901234567]`, true))
+	Require(t, err, ShouldEqual, nil)
+	s, err := encode(t, n)
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, s, ShouldEqual, `[12345678901234567]`)
+
+	_, err = Wrap(json.Number("nope"))
+	Wish(t, err != nil, ShouldEqual, true)
+}
+
+func TestUnsupported(t *testing.T) {
+	_, err := Wrap(struct{}{})
+	Wish(t, err.Error(), ShouldEqual, "jsonnode: unsupported value of type struct {}")
+
+	// Nested values are only checked when they're reached.
+	n, err := Wrap(map[string]interface{}{"ok": "x", "bad": []interface{}{1.5, uint8(1)}})
+	Require(t, err, ShouldEqual, nil)
+	v, err := n.LookupByString("ok")
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, v, ShouldEqual, basicnode.NewString("x"))
+	_, err = traversal.Get(n, ipld.ParsePath("bad/1"))
+	Wish(t, err != nil, ShouldEqual, true)
+	_, err = encode(t, n)
+	Wish(t, err != nil, ShouldEqual, true)
+}
@@ -38,3 +38,25 @@ func SplitExact(s string, sep string, count int) ([]string, error) {
 func SplitN(s, sep string, n int) []string {
 	return strings.SplitN(s, sep, n)
 }
+
+// SplitPairs splits a string of entries like "a=1,b=2" into key-value pairs.
+// It will error if any entry doesn't contain the inner delimiter;
+// anything after the first inner delimiter in an entry is part of the value.
+// An empty string has no entries at all.
+//
+// SplitPairs is used by the 'stringpairs' representation for structs.
+func SplitPairs(s string, entryDelim string, innerDelim string) ([][2]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	entries := strings.Split(s, entryDelim)
+	pairs := make([][2]string, len(entries))
+	for i, ent := range entries {
+		kv := strings.SplitN(ent, innerDelim, 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("expected entry %q to contain the delimiter %q", ent, innerDelim)
+		}
+		pairs[i] = [2]string{kv[0], kv[1]}
+	}
+	return pairs, nil
+}
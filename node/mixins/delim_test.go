@@ -32,3 +32,26 @@ func TestSplitExact(t *testing.T) {
 		Wish(t, ent2, ShouldEqual, ent)
 	}
 }
+
+func TestSplitPairs(t *testing.T) {
+	type expect struct {
+		value [][2]string
+		err   error
+	}
+	type tcase struct {
+		s      string
+		expect expect
+	}
+	for _, ent := range []tcase{
+		{"", expect{nil, nil}},
+		{"x=1", expect{[][2]string{{"x", "1"}}, nil}},
+		{"x=1,y=2", expect{[][2]string{{"x", "1"}, {"y", "2"}}, nil}},
+		{"x=,y=a=b", expect{[][2]string{{"x", ""}, {"y", "a=b"}}, nil}},
+		{"x=1,", expect{nil, fmt.Errorf(`expected entry "" to contain the delimiter "="`)}},
+		{"x=1,y", expect{nil, fmt.Errorf(`expected entry "y" to contain the delimiter "="`)}},
+	} {
+		value, err := SplitPairs(ent.s, ",", "=")
+		ent2 := tcase{ent.s, expect{value, err}}
+		Wish(t, ent2, ShouldEqual, ent)
+	}
+}
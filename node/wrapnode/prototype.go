@@ -0,0 +1,113 @@
+package wrapnode
+
+import (
+	"github.com/ipld/go-ipld-prime"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// Prototype returns a NodePrototype for the named type.
+//
+// Its builders accept the representation of the type, as a codec would
+// produce it, and keep it in a basicnode.
+// The data is validated when assembly finishes, and the finishing call
+// (AssignString, or Finish on a map or list, and so on) returns any
+// problem found; once that's succeeded, Build returns a schema.TypedNode.
+//
+// This is much like the representation prototypes produced by codegen,
+// and is what to pass to LinkSystem.Load, or to a codec's Decode function.
+// Note that, unlike codegen, building the type-level form of the data isn't
+// supported.
+func Prototype(ts *schema.TypeSystem, typeName schema.TypeName) ipld.NodePrototype {
+	return &prototype{ts, typeName, basicnode.Prototype.Any}
+}
+
+// PrototypeWithStorage is like Prototype, but keeps the representation
+// in nodes built by storage, rather than in a basicnode.
+// The nodes built by storage are what Representation returns.
+func PrototypeWithStorage(ts *schema.TypeSystem, typeName schema.TypeName, storage ipld.NodePrototype) ipld.NodePrototype {
+	return &prototype{ts, typeName, storage}
+}
+
+type prototype struct {
+	ts       *schema.TypeSystem
+	typeName schema.TypeName
+	storage  ipld.NodePrototype
+}
+
+func (p *prototype) NewBuilder() ipld.NodeBuilder {
+	return &builder{p: p, nb: p.storage.NewBuilder()}
+}
+
+// builder assembles data into a storage builder,
+// then validates and wraps the result when it's finished.
+type builder struct {
+	p  *prototype
+	nb ipld.NodeBuilder
+	n  schema.TypedNode
+}
+
+// finish validates the data once its assembly has finished without error.
+func (b *builder) finish(err error) error {
+	if err != nil {
+		return err
+	}
+	b.n, err = Wrap(b.p.ts, b.p.typeName, b.nb.Build())
+	return err
+}
+
+func (b *builder) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	ma, err := b.nb.BeginMap(sizeHint)
+	if err != nil {
+		return nil, err
+	}
+	return &mapAssembler{ma, b}, nil
+}
+
+func (b *builder) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	la, err := b.nb.BeginList(sizeHint)
+	if err != nil {
+		return nil, err
+	}
+	return &listAssembler{la, b}, nil
+}
+
+func (b *builder) AssignNull() error             { return b.finish(b.nb.AssignNull()) }
+func (b *builder) AssignBool(v bool) error       { return b.finish(b.nb.AssignBool(v)) }
+func (b *builder) AssignInt(v int64) error       { return b.finish(b.nb.AssignInt(v)) }
+func (b *builder) AssignFloat(v float64) error   { return b.finish(b.nb.AssignFloat(v)) }
+func (b *builder) AssignString(v string) error   { return b.finish(b.nb.AssignString(v)) }
+func (b *builder) AssignBytes(v []byte) error    { return b.finish(b.nb.AssignBytes(v)) }
+func (b *builder) AssignLink(v ipld.Link) error  { return b.finish(b.nb.AssignLink(v)) }
+func (b *builder) AssignNode(v ipld.Node) error  { return b.finish(b.nb.AssignNode(v)) }
+func (b *builder) Prototype() ipld.NodePrototype { return b.p }
+
+func (b *builder) Build() ipld.Node {
+	if b.n == nil {
+		panic("wrapnode: Build called before assembly finished successfully")
+	}
+	return b.n
+}
+
+func (b *builder) Reset() {
+	b.nb.Reset()
+	b.n = nil
+}
+
+type mapAssembler struct {
+	ipld.MapAssembler
+	b *builder
+}
+
+func (ma *mapAssembler) Finish() error {
+	return ma.b.finish(ma.MapAssembler.Finish())
+}
+
+type listAssembler struct {
+	ipld.ListAssembler
+	b *builder
+}
+
+func (la *listAssembler) Finish() error {
+	return la.b.finish(la.ListAssembler.Finish())
+}
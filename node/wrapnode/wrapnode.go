@@ -0,0 +1,642 @@
+/*
+	The wrapnode package implements schema.TypedNode without code generation,
+	by wrapping a node holding the representation of some data, such as a
+	basicnode, together with the schema.Type it's meant to have.
+
+	This is useful for tools which only receive their schemas at runtime,
+	and so can't run the code generator.
+	It's slower than generated code, as every access goes through the type
+	info, but it doesn't copy the data: the Representation of a wrapped node
+	is the node it wraps.
+
+	Data is validated with schema.Validate when it's wrapped,
+	or when it's assembled via a Prototype.
+*/
+package wrapnode
+
+import (
+	"strings"
+
+	"github.com/ipld/go-ipld-prime"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// Wrap checks that n holds the representation of the named type,
+// and returns a typed node viewing it.
+//
+// Only the first problem found is returned;
+// use schema.Validate to get all of them.
+func Wrap(ts *schema.TypeSystem, typeName schema.TypeName, n ipld.Node) (schema.TypedNode, error) {
+	if errs := schema.Validate(ts, typeName, n); len(errs) > 0 {
+		return nil, errs[0]
+	}
+	return wrap(ts, ts.TypeByName(string(typeName)), n), nil
+}
+
+// wrap returns a typed node without validating the data,
+// which must have been validated already.
+func wrap(ts *schema.TypeSystem, typ schema.Type, repr ipld.Node) schema.TypedNode {
+	n := &node{ts, typ, repr}
+	switch t := typ.(type) {
+	case *schema.TypeLink:
+		if t.HasReferencedType() {
+			return &linkNode{n}
+		}
+	case *schema.TypeAny:
+		return &anyNode{repr, n}
+	}
+	return n
+}
+
+// wrapValue is like wrap, for list and map values and struct fields,
+// which may be null or absent.
+// Units represented as null are still wrapped, as null is their only value.
+func wrapValue(ts *schema.TypeSystem, typ schema.Type, repr ipld.Node) ipld.Node {
+	switch {
+	case repr.IsAbsent():
+		return ipld.Absent
+	case repr.IsNull() && typ.RepresentationBehavior() != ipld.Kind_Null:
+		return ipld.Null
+	}
+	return wrap(ts, typ, repr)
+}
+
+// node is the type-level view of some data.
+// Which methods do something useful depends on the kind of its type;
+// the others return errors, just like for any other node.
+type node struct {
+	ts   *schema.TypeSystem
+	typ  schema.Type
+	repr ipld.Node
+}
+
+// linkNode is a node for a link type which names the type it links to.
+type linkNode struct {
+	*node
+}
+
+// anyNode is a node for the any type.
+// The data it holds has no type, so it acts just like its representation,
+// and anything found inside it is left as it is.
+type anyNode struct {
+	ipld.Node
+	n *node
+}
+
+var (
+	_ schema.TypedNode     = (*node)(nil)
+	_ schema.TypedLinkNode = (*linkNode)(nil)
+	_ schema.TypedNode     = (*anyNode)(nil)
+)
+
+func (n *node) Type() schema.Type {
+	return n.typ
+}
+
+func (n *node) Representation() ipld.Node {
+	return n.repr
+}
+
+func (n *anyNode) Type() schema.Type {
+	return n.n.typ
+}
+
+func (n *anyNode) Representation() ipld.Node {
+	return n.Node
+}
+
+func (n *anyNode) Prototype() ipld.NodePrototype {
+	return n.n.Prototype()
+}
+
+func (n *linkNode) LinkTargetNodePrototype() ipld.NodePrototype {
+	return Prototype(n.ts, n.typ.(*schema.TypeLink).ReferencedType().Name())
+}
+
+func (n *node) Kind() ipld.Kind {
+	return n.typ.TypeKind().ActsLike()
+}
+
+func (n *node) LookupByString(key string) (ipld.Node, error) {
+	switch t := n.typ.(type) {
+	case *schema.TypeStruct:
+		f := t.Field(key)
+		if f == nil {
+			return nil, schema.ErrNoSuchField{Type: t, Field: ipld.PathSegmentOfString(key)}
+		}
+		return n.fieldValue(t, *f)
+	case *schema.TypeUnion:
+		member, value, err := n.member(t)
+		if err != nil {
+			return nil, err
+		}
+		if key != string(member.Name()) {
+			return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(key)}
+		}
+		return wrap(n.ts, member, value), nil
+	case *schema.TypeMap:
+		if _, ok := t.RepresentationStrategy().(schema.MapRepresentation_ListPairs); ok {
+			value, err := lookupListPair(n.repr, key)
+			if err != nil {
+				return nil, err
+			}
+			if value == nil {
+				return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(key)}
+			}
+			return wrapValue(n.ts, t.ValueType(), value), nil
+		}
+		value, err := n.repr.LookupByString(key)
+		if err != nil {
+			return nil, err
+		}
+		return wrapValue(n.ts, t.ValueType(), value), nil
+	}
+	return n.repr.LookupByString(key)
+}
+
+func (n *node) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	switch n.Kind() {
+	case ipld.Kind_Map:
+		ks, err := key.AsString()
+		if err != nil {
+			return nil, err
+		}
+		return n.LookupByString(ks)
+	case ipld.Kind_List:
+		idx, err := key.AsInt()
+		if err != nil {
+			return nil, err
+		}
+		return n.LookupByIndex(idx)
+	}
+	return n.repr.LookupByNode(key)
+}
+
+func (n *node) LookupByIndex(idx int64) (ipld.Node, error) {
+	if t, ok := n.typ.(*schema.TypeList); ok {
+		value, err := n.repr.LookupByIndex(idx)
+		if err != nil {
+			return nil, err
+		}
+		return wrapValue(n.ts, t.ValueType(), value), nil
+	}
+	return nil, ipld.ErrWrongKind{TypeName: string(n.typ.Name()), MethodName: "LookupByIndex", AppropriateKind: ipld.KindSet_JustList, ActualKind: n.Kind()}
+}
+
+func (n *node) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	switch n.Kind() {
+	case ipld.Kind_Map:
+		return n.LookupByString(seg.String())
+	case ipld.Kind_List:
+		idx, err := seg.Index()
+		if err != nil {
+			return nil, err
+		}
+		return n.LookupByIndex(idx)
+	}
+	return n.repr.LookupBySegment(seg)
+}
+
+func (n *node) MapIterator() ipld.MapIterator {
+	switch t := n.typ.(type) {
+	case *schema.TypeStruct:
+		return &structIterator{n: n, t: t, fields: t.Fields()}
+	case *schema.TypeUnion:
+		return &unionIterator{n: n, t: t}
+	case *schema.TypeMap:
+		if _, ok := t.RepresentationStrategy().(schema.MapRepresentation_ListPairs); ok {
+			return &mapIterator{n: n, t: t, itr: &listPairsIterator{n.repr.ListIterator()}}
+		}
+		return &mapIterator{n: n, t: t, itr: n.repr.MapIterator()}
+	}
+	return nil
+}
+
+func (n *node) ListIterator() ipld.ListIterator {
+	if t, ok := n.typ.(*schema.TypeList); ok {
+		return &listIterator{n: n, t: t, itr: n.repr.ListIterator()}
+	}
+	return nil
+}
+
+func (n *node) Length() int64 {
+	switch t := n.typ.(type) {
+	case *schema.TypeStruct:
+		return int64(len(t.Fields()))
+	case *schema.TypeUnion:
+		return 1
+	case *schema.TypeMap, *schema.TypeList:
+		return n.repr.Length()
+	}
+	return -1
+}
+
+func (n *node) IsAbsent() bool {
+	return false
+}
+
+func (n *node) IsNull() bool {
+	return false
+}
+
+// scalar returns the representation node if this node's type is a scalar,
+// and an error for the named method otherwise.
+// The representations of structs and unions may be scalars,
+// but the types themselves aren't.
+func (n *node) scalar(methodName string, kind ipld.Kind) (ipld.Node, error) {
+	if n.Kind() != kind {
+		return nil, ipld.ErrWrongKind{TypeName: string(n.typ.Name()), MethodName: methodName, AppropriateKind: ipld.KindSet{kind}, ActualKind: n.Kind()}
+	}
+	return n.repr, nil
+}
+
+func (n *node) AsBool() (bool, error) {
+	if _, ok := n.typ.(*schema.TypeUnit); ok {
+		return true, nil // a unit's only value acts like true, however it's represented.
+	}
+	repr, err := n.scalar("AsBool", ipld.Kind_Bool)
+	if err != nil {
+		return false, err
+	}
+	return repr.AsBool()
+}
+
+func (n *node) AsInt() (int64, error) {
+	repr, err := n.scalar("AsInt", ipld.Kind_Int)
+	if err != nil {
+		return 0, err
+	}
+	return repr.AsInt()
+}
+
+func (n *node) AsFloat() (float64, error) {
+	repr, err := n.scalar("AsFloat", ipld.Kind_Float)
+	if err != nil {
+		return 0, err
+	}
+	return repr.AsFloat()
+}
+
+func (n *node) AsString() (string, error) {
+	repr, err := n.scalar("AsString", ipld.Kind_String)
+	if err != nil {
+		return "", err
+	}
+	if t, ok := n.typ.(*schema.TypeEnum); ok {
+		return enumMember(t, repr)
+	}
+	return repr.AsString()
+}
+
+// enumMember returns the member of an enum which the representation stands
+// for; these are only the same string with the string representation,
+// and when the member isn't renamed.
+func enumMember(t *schema.TypeEnum, repr ipld.Node) (string, error) {
+	switch r := t.RepresentationStrategy().(type) {
+	case schema.EnumRepresentation_String:
+		s, err := repr.AsString()
+		if err != nil {
+			return "", err
+		}
+		for _, m := range t.Members() {
+			if r.GetSerial(m) == s {
+				return m, nil
+			}
+		}
+	case schema.EnumRepresentation_Int:
+		i, err := repr.AsInt()
+		if err != nil {
+			return "", err
+		}
+		for _, m := range t.Members() {
+			if serial, _ := r.GetSerial(m); int64(serial) == i {
+				return m, nil
+			}
+		}
+	}
+	panic("unreachable: the data was validated when it was wrapped")
+}
+
+func (n *node) AsBytes() ([]byte, error) {
+	repr, err := n.scalar("AsBytes", ipld.Kind_Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return repr.AsBytes()
+}
+
+func (n *node) AsLink() (ipld.Link, error) {
+	repr, err := n.scalar("AsLink", ipld.Kind_Link)
+	if err != nil {
+		return nil, err
+	}
+	return repr.AsLink()
+}
+
+func (n *node) Prototype() ipld.NodePrototype {
+	return Prototype(n.ts, n.typ.Name())
+}
+
+// fieldValue returns the value of a struct field, which may be absent or null.
+func (n *node) fieldValue(t *schema.TypeStruct, f schema.StructField) (ipld.Node, error) {
+	switch r := t.RepresentationStrategy().(type) {
+	case schema.StructRepresentation_Map:
+		value, err := n.repr.LookupByString(r.GetFieldKey(f))
+		if _, ok := err.(ipld.ErrNotExists); ok {
+			if iv := r.GetImplicit(f); iv != nil {
+				return wrap(n.ts, f.Type(), implicitNode(iv)), nil
+			}
+			return ipld.Absent, nil
+		} else if err != nil {
+			return nil, err
+		}
+		return wrapValue(n.ts, f.Type(), value), nil
+	case schema.StructRepresentation_Tuple:
+		idx := fieldIndex(t, f)
+		if idx >= n.repr.Length() {
+			return ipld.Absent, nil
+		}
+		value, err := n.repr.LookupByIndex(idx)
+		if err != nil {
+			return nil, err
+		}
+		return wrapValue(n.ts, f.Type(), value), nil
+	case schema.StructRepresentation_Stringjoin:
+		s, err := n.repr.AsString()
+		if err != nil {
+			return nil, err
+		}
+		parts := strings.Split(s, r.GetDelim())
+		return wrap(n.ts, f.Type(), basicnode.NewString(parts[fieldIndex(t, f)])), nil
+	case schema.StructRepresentation_ListPairs:
+		value, err := lookupListPair(n.repr, f.Name())
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			return ipld.Absent, nil
+		}
+		return wrapValue(n.ts, f.Type(), value), nil
+	case schema.StructRepresentation_StringPairs:
+		s, err := n.repr.AsString()
+		if err != nil {
+			return nil, err
+		}
+		pairs, err := mixins.SplitPairs(s, r.GetEntryDelim(), r.GetInnerDelim())
+		if err != nil {
+			return nil, err
+		}
+		for _, kv := range pairs {
+			if kv[0] == f.Name() {
+				return wrap(n.ts, f.Type(), basicnode.NewString(kv[1])), nil
+			}
+		}
+		return ipld.Absent, nil
+	}
+	panic("unreachable: schema.Validate rejects other representations")
+}
+
+// lookupListPair finds the value for a key in a listpairs representation,
+// returning a nil node if there's no entry for it.
+// implicitNode returns the value which a field with an implicit value has
+// when it's absent from the representation.
+func implicitNode(iv schema.ImplicitValue) ipld.Node {
+	switch iv := iv.(type) {
+	case schema.ImplicitValue_String:
+		return basicnode.NewString(iv.String())
+	case schema.ImplicitValue_Int:
+		return basicnode.NewInt(int64(iv.Int()))
+	case schema.ImplicitValue_Bool:
+		return basicnode.NewBool(iv.Bool())
+	}
+	panic("unreachable: other implicit values are rejected by ValidateGraph")
+}
+
+func lookupListPair(repr ipld.Node, key string) (ipld.Node, error) {
+	for itr := (&listPairsIterator{repr.ListIterator()}); !itr.Done(); {
+		k, v, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if ks, _ := k.AsString(); ks == key {
+			return v, nil
+		}
+	}
+	return nil, nil
+}
+
+func fieldIndex(t *schema.TypeStruct, f schema.StructField) int64 {
+	for i, f2 := range t.Fields() {
+		if f2.Name() == f.Name() {
+			return int64(i)
+		}
+	}
+	panic("unreachable: field is not in the struct")
+}
+
+// member returns the member type of a union, and its representation.
+func (n *node) member(t *schema.TypeUnion) (schema.Type, ipld.Node, error) {
+	switch r := t.RepresentationStrategy().(type) {
+	case schema.UnionRepresentation_Keyed:
+		key, value, err := n.repr.MapIterator().Next()
+		if err != nil {
+			return nil, nil, err
+		}
+		ks, err := key.AsString()
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, member := range t.Members() {
+			if r.GetDiscriminant(member) == ks {
+				return member, value, nil
+			}
+		}
+	case schema.UnionRepresentation_Kinded:
+		return n.ts.TypeByName(string(r.GetMember(n.repr.Kind()))), n.repr, nil
+	case schema.UnionRepresentation_Stringprefix:
+		s, err := n.repr.AsString()
+		if err != nil {
+			return nil, nil, err
+		}
+		if i := strings.Index(s, r.GetDelim()); i >= 0 {
+			for _, member := range t.Members() {
+				if r.GetDiscriminant(member) == s[:i] {
+					return member, basicnode.NewString(s[i+len(r.GetDelim()):]), nil
+				}
+			}
+		}
+	case schema.UnionRepresentation_Envelope:
+		discriminant, err := n.repr.LookupByString(r.GetDiscriminantKey())
+		if err != nil {
+			return nil, nil, err
+		}
+		ds, err := discriminant.AsString()
+		if err != nil {
+			return nil, nil, err
+		}
+		content, err := n.repr.LookupByString(r.GetContentKey())
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, member := range t.Members() {
+			if r.GetDiscriminant(member) == ds {
+				return member, content, nil
+			}
+		}
+	case schema.UnionRepresentation_Inline:
+		discriminant, err := n.repr.LookupByString(r.GetDiscriminantKey())
+		if err != nil {
+			return nil, nil, err
+		}
+		ds, err := discriminant.AsString()
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, member := range t.Members() {
+			if r.GetDiscriminant(member) == ds {
+				content, err := withoutKey(n.repr, r.GetDiscriminantKey())
+				return member, content, err
+			}
+		}
+	}
+	panic("unreachable: schema.Validate rejects data matching no member")
+}
+
+// withoutKey returns a copy of a map without one of its entries,
+// such as an inline union's discriminant; the values aren't copied.
+func withoutKey(n ipld.Node, key string) (ipld.Node, error) {
+	nb := basicnode.Prototype.Map.NewBuilder()
+	ma, err := nb.BeginMap(n.Length() - 1)
+	if err != nil {
+		return nil, err
+	}
+	for itr := n.MapIterator(); !itr.Done(); {
+		k, v, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if ks, _ := k.AsString(); ks == key {
+			continue
+		}
+		if err := ma.AssembleKey().AssignNode(k); err != nil {
+			return nil, err
+		}
+		if err := ma.AssembleValue().AssignNode(v); err != nil {
+			return nil, err
+		}
+	}
+	if err := ma.Finish(); err != nil {
+		return nil, err
+	}
+	return nb.Build(), nil
+}
+
+type structIterator struct {
+	n      *node
+	t      *schema.TypeStruct
+	fields []schema.StructField
+	idx    int
+}
+
+func (itr *structIterator) Next() (ipld.Node, ipld.Node, error) {
+	if itr.Done() {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	f := itr.fields[itr.idx]
+	itr.idx++
+	value, err := itr.n.fieldValue(itr.t, f)
+	if err != nil {
+		return nil, nil, err
+	}
+	return basicnode.NewString(f.Name()), value, nil
+}
+
+func (itr *structIterator) Done() bool {
+	return itr.idx >= len(itr.fields)
+}
+
+type unionIterator struct {
+	n    *node
+	t    *schema.TypeUnion
+	done bool
+}
+
+func (itr *unionIterator) Next() (ipld.Node, ipld.Node, error) {
+	if itr.done {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	itr.done = true
+	member, value, err := itr.n.member(itr.t)
+	if err != nil {
+		return nil, nil, err
+	}
+	return basicnode.NewString(string(member.Name())), wrap(itr.n.ts, member, value), nil
+}
+
+func (itr *unionIterator) Done() bool {
+	return itr.done
+}
+
+type mapIterator struct {
+	n   *node
+	t   *schema.TypeMap
+	itr ipld.MapIterator
+}
+
+func (itr *mapIterator) Next() (ipld.Node, ipld.Node, error) {
+	key, value, err := itr.itr.Next()
+	if err != nil {
+		return nil, nil, err
+	}
+	return wrap(itr.n.ts, itr.t.KeyType(), key), wrapValue(itr.n.ts, itr.t.ValueType(), value), nil
+}
+
+func (itr *mapIterator) Done() bool {
+	return itr.itr.Done()
+}
+
+// listPairsIterator iterates over the [key, value] entries of a listpairs
+// representation as if they were a map's.
+type listPairsIterator struct {
+	itr ipld.ListIterator
+}
+
+func (itr *listPairsIterator) Next() (ipld.Node, ipld.Node, error) {
+	_, entry, err := itr.itr.Next()
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := entry.LookupByIndex(0)
+	if err != nil {
+		return nil, nil, err
+	}
+	value, err := entry.LookupByIndex(1)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, value, nil
+}
+
+func (itr *listPairsIterator) Done() bool {
+	return itr.itr.Done()
+}
+
+type listIterator struct {
+	n   *node
+	t   *schema.TypeList
+	itr ipld.ListIterator
+}
+
+func (itr *listIterator) Next() (int64, ipld.Node, error) {
+	idx, value, err := itr.itr.Next()
+	if err != nil {
+		return -1, nil, err
+	}
+	return idx, wrapValue(itr.n.ts, itr.t.ValueType(), value), nil
+}
+
+func (itr *listIterator) Done() bool {
+	return itr.itr.Done()
+}
@@ -0,0 +1,378 @@
+package wrapnode_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec/dagjson"
+	"github.com/ipld/go-ipld-prime/fluent"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/node/wrapnode"
+	"github.com/ipld/go-ipld-prime/schema"
+	schemaparser "github.com/ipld/go-ipld-prime/schema/parser"
+	"github.com/ipld/go-ipld-prime/storage"
+	"github.com/ipld/go-ipld-prime/traversal"
+)
+
+var testSchema = `
+type Person struct {
+	name String
+	nick optional String (rename "nickname")
+	tags [String]
+	home Coords
+	shape Shape
+	value Value
+	message Message
+	pet Pet
+	query Query
+	headers Headers
+}
+
+type Pair struct {
+	left Int
+	right Int
+} representation tuple
+
+type Coords struct {
+	x String
+	y String
+} representation stringjoin {join ","}
+
+type Query struct {
+	q String
+	lang optional String
+} representation stringpairs {
+	innerDelim "="
+	entryDelim "&"
+}
+
+type Header struct {
+	name String
+	value optional String
+} representation listpairs
+
+type Headers {String:Header} representation listpairs
+
+type Shape union {
+	| Pair "pair"
+	| Coords "coords"
+} representation keyed
+
+type Value union {
+	| String string
+	| Int int
+} representation kinded
+
+type Message union {
+	| String "text"
+	| Pair "pair"
+} representation envelope {
+	discriminantKey "type"
+	contentKey "value"
+}
+
+type Cat struct {
+	name String
+	lives Int (implicit "9")
+}
+
+type Pet union {
+	| Cat "cat"
+} representation inline {
+	discriminantKey "kind"
+}
+
+type Mood enum {
+	| Happy
+	| Grumpy ("grumpy")
+}
+
+type Level enum {
+	| Low ("1")
+	| High ("10")
+} representation int
+
+type Ref struct {
+	pair &Pair
+}
+
+type Nothing unit representation null
+type Empty unit representation emptymap
+
+type Note struct {
+	marker Nothing
+	done Empty
+	extra Any
+}
+`
+
+func decode(t *testing.T, ts *schema.TypeSystem, typeName, data string) (schema.TypedNode, error) {
+	nb := wrapnode.Prototype(ts, schema.TypeName(typeName)).NewBuilder()
+	if err := dagjson.Decode(nb, strings.NewReader(data)); err != nil {
+		return nil, err
+	}
+	return nb.Build().(schema.TypedNode), nil
+}
+
+func TestWrapNode(t *testing.T) {
+	ts, err := schemaparser.Parse(strings.NewReader(testSchema))
+	Require(t, err, ShouldEqual, nil)
+
+	data := `{"name":"Alice","tags":["a","b"],"home":"1,2","shape":{"pair":[3,4]},"value":5,"message":{"type":"text","value":"hi"},"pet":{"kind":"cat","name":"Tom"},"query":"q=ipld","headers":[["a",[["name","x"]]]]}`
+	n, err := decode(t, ts, "Person", data)
+	Require(t, err, ShouldEqual, nil)
+
+	t.Run("type-level view", func(t *testing.T) {
+		Wish(t, n.Type().Name(), ShouldEqual, schema.TypeName("Person"))
+		Wish(t, n.Kind(), ShouldEqual, ipld.Kind_Map)
+		Wish(t, n.Length(), ShouldEqual, int64(10))
+
+		name, err := n.LookupByString("name")
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, name.(schema.TypedNode).Type().Name(), ShouldEqual, schema.TypeName("String"))
+		s, _ := name.AsString()
+		Wish(t, s, ShouldEqual, "Alice")
+
+		nick, err := n.LookupByString("nick")
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, nick.IsAbsent(), ShouldEqual, true)
+
+		_, err = n.LookupByString("nickname")
+		Wish(t, err.Error(), ShouldEqual, "no such field: Person.nickname")
+
+		tag, err := n.LookupBySegment(ipld.PathSegmentOfString("tags"))
+		Require(t, err, ShouldEqual, nil)
+		tag, err = tag.LookupByIndex(1)
+		Require(t, err, ShouldEqual, nil)
+		s, _ = tag.AsString()
+		Wish(t, s, ShouldEqual, "b")
+
+		// Stringjoin structs are maps at the type level.
+		home, err := n.LookupByString("home")
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, home.Kind(), ShouldEqual, ipld.Kind_Map)
+		_, err = home.AsString()
+		Wish(t, err, ShouldEqual, ipld.ErrWrongKind{TypeName: "Coords", MethodName: "AsString", AppropriateKind: ipld.KindSet_JustString, ActualKind: ipld.Kind_Map})
+		y, err := home.LookupByString("y")
+		Require(t, err, ShouldEqual, nil)
+		s, _ = y.AsString()
+		Wish(t, s, ShouldEqual, "2")
+
+		// Unions are single-entry maps keyed by the member type's name.
+		shape, err := n.LookupByString("shape")
+		Require(t, err, ShouldEqual, nil)
+		k, v, err := shape.MapIterator().Next()
+		Require(t, err, ShouldEqual, nil)
+		s, _ = k.AsString()
+		Wish(t, s, ShouldEqual, "Pair")
+		right, err := v.LookupByString("right")
+		Require(t, err, ShouldEqual, nil)
+		i, _ := right.AsInt()
+		Wish(t, i, ShouldEqual, int64(4))
+
+		value, err := n.LookupByString("value")
+		Require(t, err, ShouldEqual, nil)
+		_, err = value.LookupByString("String")
+		Wish(t, err, ShouldEqual, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString("String")})
+		member, err := value.LookupByString("Int")
+		Require(t, err, ShouldEqual, nil)
+		i, _ = member.AsInt()
+		Wish(t, i, ShouldEqual, int64(5))
+
+		message, err := n.LookupByString("message")
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, message.Length(), ShouldEqual, int64(1))
+		member, err = message.LookupByString("String")
+		Require(t, err, ShouldEqual, nil)
+		s, _ = member.AsString()
+		Wish(t, s, ShouldEqual, "hi")
+
+		// The members of inline unions don't see the discriminant.
+		pet, err := n.LookupByString("pet")
+		Require(t, err, ShouldEqual, nil)
+		cat, err := pet.LookupByString("Cat")
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, cat.Length(), ShouldEqual, int64(2))
+		var buf bytes.Buffer
+		Require(t, dagjson.Encode(cat.(schema.TypedNode).Representation(), &buf), ShouldEqual, nil)
+		Wish(t, strings.Join(strings.Fields(buf.String()), ""), ShouldEqual, `{"name":"Tom"}`)
+
+		// Fields with implicit values have them when absent from the representation.
+		lives, err := cat.LookupByString("lives")
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, lives.(schema.TypedNode).Type().Name(), ShouldEqual, schema.TypeName("Int"))
+		i, _ = lives.AsInt()
+		Wish(t, i, ShouldEqual, int64(9))
+
+		// Stringpairs structs leave out absent fields.
+		query, err := n.LookupByString("query")
+		Require(t, err, ShouldEqual, nil)
+		q, err := query.LookupByString("q")
+		Require(t, err, ShouldEqual, nil)
+		s, _ = q.AsString()
+		Wish(t, s, ShouldEqual, "ipld")
+		lang, err := query.LookupByString("lang")
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, lang.IsAbsent(), ShouldEqual, true)
+
+		// Listpairs maps and structs are still maps at the type level.
+		headers, err := n.LookupByString("headers")
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, headers.Kind(), ShouldEqual, ipld.Kind_Map)
+		Wish(t, headers.Length(), ShouldEqual, int64(1))
+		_, err = headers.LookupByString("b")
+		Wish(t, err, ShouldEqual, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString("b")})
+		k, header, err := headers.MapIterator().Next()
+		Require(t, err, ShouldEqual, nil)
+		s, _ = k.AsString()
+		Wish(t, s, ShouldEqual, "a")
+		hname, err := header.LookupByString("name")
+		Require(t, err, ShouldEqual, nil)
+		s, _ = hname.AsString()
+		Wish(t, s, ShouldEqual, "x")
+		hvalue, err := header.LookupByString("value")
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, hvalue.IsAbsent(), ShouldEqual, true)
+		header, err = headers.LookupByString("a")
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, header.(schema.TypedNode).Type().Name(), ShouldEqual, schema.TypeName("Header"))
+	})
+	t.Run("iteration", func(t *testing.T) {
+		var keys []string
+		for itr := n.MapIterator(); !itr.Done(); {
+			k, _, err := itr.Next()
+			Require(t, err, ShouldEqual, nil)
+			ks, _ := k.AsString()
+			keys = append(keys, ks)
+		}
+		Wish(t, keys, ShouldEqual, []string{"name", "nick", "tags", "home", "shape", "value", "message", "pet", "query", "headers"})
+	})
+	t.Run("representation", func(t *testing.T) {
+		var buf bytes.Buffer
+		Require(t, dagjson.Encode(n.Representation(), &buf), ShouldEqual, nil)
+		compact := strings.Join(strings.Fields(buf.String()), "")
+		Wish(t, compact, ShouldEqual, data)
+	})
+}
+
+func TestWrapNodeEnum(t *testing.T) {
+	ts, err := schemaparser.Parse(strings.NewReader(testSchema))
+	Require(t, err, ShouldEqual, nil)
+
+	// Enums are strings holding the member at the type level,
+	// whatever their representation.
+	for _, tc := range []struct {
+		typeName, data, member string
+	}{
+		{"Mood", `"Happy"`, "Happy"},
+		{"Mood", `"grumpy"`, "Grumpy"},
+		{"Level", `10`, "High"},
+	} {
+		n, err := decode(t, ts, tc.typeName, tc.data)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, n.Kind(), ShouldEqual, ipld.Kind_String)
+		s, err := n.AsString()
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, s, ShouldEqual, tc.member)
+	}
+
+	_, err = decode(t, ts, "Level", `"High"`)
+	Wish(t, strings.HasPrefix(err.Error(), schema.ErrInvalidData{TypeName: "Level", Detail: "expected kind int, got string"}.Error()), ShouldEqual, true)
+}
+
+func TestWrapNodeLinks(t *testing.T) {
+	ts, err := schemaparser.Parse(strings.NewReader(testSchema))
+	Require(t, err, ShouldEqual, nil)
+
+	store := storage.Memory{}
+	lsys := cidlink.DefaultLinkSystem()
+	lsys.StorageReadOpener = (&store).OpenRead
+	lsys.StorageWriteOpener = (&store).OpenWrite
+	lnk, err := lsys.Store(ipld.LinkContext{}, cidlink.DefaultV1DagJson, fluent.MustBuildList(basicnode.Prototype.List, 2, func(la fluent.ListAssembler) {
+		la.AssembleValue().AssignInt(3)
+		la.AssembleValue().AssignInt(4)
+	}))
+	Require(t, err, ShouldEqual, nil)
+
+	n, err := decode(t, ts, "Ref", `{"pair":{"/":"`+lnk.String()+`"}}`)
+	Require(t, err, ShouldEqual, nil)
+
+	pair, err := n.LookupByString("pair")
+	Require(t, err, ShouldEqual, nil)
+	typ := pair.(schema.TypedNode).Type().(*schema.TypeLink)
+	Wish(t, typ.HasReferencedType(), ShouldEqual, true)
+	Wish(t, typ.ReferencedType().Name(), ShouldEqual, schema.TypeName("Pair"))
+
+	// Traversals load the data on the other side of the link as the referenced type,
+	// without having to be told which prototype to use.
+	// Pair has a tuple representation, so its fields are only found by name at the type level.
+	prog := traversal.Progress{Cfg: &traversal.Config{LinkSystem: lsys}}
+	left, err := prog.Get(n, ipld.ParsePath("pair/left"))
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, left.(schema.TypedNode).Type().Name(), ShouldEqual, schema.TypeName("Int"))
+	i, err := left.AsInt()
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, i, ShouldEqual, int64(3))
+}
+
+func TestWrapNodeUnitAndAny(t *testing.T) {
+	ts, err := schemaparser.Parse(strings.NewReader(testSchema))
+	Require(t, err, ShouldEqual, nil)
+
+	data := `{"done":{},"extra":{"a":[1,null]},"marker":null}`
+	n, err := decode(t, ts, "Note", data)
+	Require(t, err, ShouldEqual, nil)
+
+	// Units act like true at the type level, whatever their representation.
+	for _, name := range []string{"marker", "done"} {
+		v, err := n.LookupByString(name)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, v.(schema.TypedNode).Type().TypeKind(), ShouldEqual, schema.TypeKind_Unit)
+		Wish(t, v.Kind(), ShouldEqual, ipld.Kind_Bool)
+		b, err := v.AsBool()
+		Wish(t, err, ShouldEqual, nil)
+		Wish(t, b, ShouldEqual, true)
+	}
+
+	// Any acts like the data it holds, which has no type of its own.
+	extra, err := n.LookupByString("extra")
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, extra.(schema.TypedNode).Type().TypeKind(), ShouldEqual, schema.TypeKind_Any)
+	Wish(t, extra.Kind(), ShouldEqual, ipld.Kind_Map)
+	elem, err := traversal.Get(extra, ipld.ParsePath("a/0"))
+	Require(t, err, ShouldEqual, nil)
+	i, err := elem.AsInt()
+	Wish(t, err, ShouldEqual, nil)
+	Wish(t, i, ShouldEqual, int64(1))
+
+	var buf bytes.Buffer
+	Require(t, dagjson.Encode(n.Representation(), &buf), ShouldEqual, nil)
+	Wish(t, strings.Join(strings.Fields(buf.String()), ""), ShouldEqual, data)
+
+	_, err = decode(t, ts, "Note", `{"done":{"x":1},"extra":null,"marker":null}`)
+	Wish(t, strings.HasPrefix(err.Error(), schema.ErrInvalidData{Path: ipld.ParsePath("done"), TypeName: "Empty", Detail: "expected an empty map, got 1 entries"}.Error()), ShouldEqual, true)
+}
+
+func TestWrapNodeInvalid(t *testing.T) {
+	ts, err := schemaparser.Parse(strings.NewReader(testSchema))
+	Require(t, err, ShouldEqual, nil)
+
+	wrap := func(typeName, data string) error {
+		nb := basicnode.Prototype.Any.NewBuilder()
+		Require(t, dagjson.Decode(nb, strings.NewReader(data)), ShouldEqual, nil)
+		_, err := wrapnode.Wrap(ts, schema.TypeName(typeName), nb.Build())
+		return err
+	}
+	Wish(t, wrap("Person", `{"name":"Alice"}`), ShouldEqual, schema.ErrInvalidData{TypeName: "Person", Detail: `missing required field "tags"`})
+	Wish(t, wrap("Shape", `{"pair":[1,"two"]}`), ShouldEqual, schema.ErrInvalidData{Path: ipld.NewPath([]ipld.PathSegment{ipld.PathSegmentOfString("pair"), ipld.PathSegmentOfInt(1)}), TypeName: "Int", Detail: "expected kind int, got string"})
+	Wish(t, wrap("Value", `true`), ShouldEqual, schema.ErrInvalidData{TypeName: "Value", Detail: "kind bool does not match any member of the union"})
+
+	// Decoding with a Prototype reports the same errors, as assembly finishes.
+	_, err = decode(t, ts, "Value", `true`)
+	Wish(t, strings.HasPrefix(err.Error(), schema.ErrInvalidData{TypeName: "Value", Detail: "kind bool does not match any member of the union"}.Error()), ShouldEqual, true)
+}
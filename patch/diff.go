@@ -0,0 +1,132 @@
+package patch
+
+import (
+	"github.com/ipld/go-ipld-prime"
+)
+
+// Diff returns the operations which Apply needs to turn node x into node y.
+// If x and y are equal, as by ipld.DeepEqual, there are no operations.
+//
+// Maps and lists are compared recursively, so that a change deep inside some data
+// only costs an operation for what changed, rather than one replacing everything.
+// Maps are compared by their keys, regardless of order.
+// Lists are compared index by index, with values added or removed at the end:
+// Diff doesn't look for values which moved, so inserting a value near the start of a list
+// means replacing every value after it.
+//
+// Values in the operations are the nodes from y, rather than copies of them.
+// An error is only returned if reading either node fails.
+func Diff(x, y ipld.Node) ([]Operation, error) {
+	var ops []Operation
+	if err := diff(&ops, ipld.Path{}, x, y); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
+func diff(ops *[]Operation, p ipld.Path, x, y ipld.Node) error {
+	switch {
+	case x.Kind() != y.Kind():
+	case x.Kind() == ipld.Kind_Map:
+		return diffMap(ops, p, x, y)
+	case x.Kind() == ipld.Kind_List:
+		return diffList(ops, p, x, y)
+	case ipld.DeepEqual(x, y):
+		return nil
+	}
+	*ops = append(*ops, Operation{Op: Replace, Path: p, Value: y})
+	return nil
+}
+
+func diffMap(ops *[]Operation, p ipld.Path, x, y ipld.Node) error {
+	for itr := x.MapIterator(); !itr.Done(); {
+		k, vx, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if vx.IsAbsent() {
+			continue
+		}
+		ks, err := k.AsString()
+		if err != nil {
+			return err
+		}
+		vy, err := lookup(y, ks)
+		if err != nil {
+			return err
+		}
+		kp := p.AppendSegmentString(ks)
+		if vy == nil {
+			*ops = append(*ops, Operation{Op: Remove, Path: kp})
+			continue
+		}
+		if err := diff(ops, kp, vx, vy); err != nil {
+			return err
+		}
+	}
+	for itr := y.MapIterator(); !itr.Done(); {
+		k, vy, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if vy.IsAbsent() {
+			continue
+		}
+		ks, err := k.AsString()
+		if err != nil {
+			return err
+		}
+		vx, err := lookup(x, ks)
+		if err != nil {
+			return err
+		}
+		if vx == nil {
+			*ops = append(*ops, Operation{Op: Add, Path: p.AppendSegmentString(ks), Value: vy})
+		}
+	}
+	return nil
+}
+
+// lookup returns the value for a key in a map, or nil if there isn't one.
+func lookup(n ipld.Node, key string) (ipld.Node, error) {
+	v, err := n.LookupByString(key)
+	if _, ok := err.(ipld.ErrNotExists); ok {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if v.IsAbsent() {
+		return nil, nil
+	}
+	return v, nil
+}
+
+func diffList(ops *[]Operation, p ipld.Path, x, y ipld.Node) error {
+	lx, ly := x.Length(), y.Length()
+	for i := int64(0); i < lx && i < ly; i++ {
+		vx, err := x.LookupByIndex(i)
+		if err != nil {
+			return err
+		}
+		vy, err := y.LookupByIndex(i)
+		if err != nil {
+			return err
+		}
+		if err := diff(ops, p.AppendSegment(ipld.PathSegmentOfInt(i)), vx, vy); err != nil {
+			return err
+		}
+	}
+	// Remove from the end, so that each index is still right when its turn comes.
+	for i := lx - 1; i >= ly; i-- {
+		*ops = append(*ops, Operation{Op: Remove, Path: p.AppendSegment(ipld.PathSegmentOfInt(i))})
+	}
+	for i := lx; i < ly; i++ {
+		vy, err := y.LookupByIndex(i)
+		if err != nil {
+			return err
+		}
+		*ops = append(*ops, Operation{Op: Add, Path: p.AppendSegment(ipld.PathSegmentOfInt(i)), Value: vy})
+	}
+	return nil
+}
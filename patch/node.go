@@ -0,0 +1,119 @@
+package patch
+
+import (
+	"fmt"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/fluent"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+)
+
+// ToNode returns operations as a node, so that they can be encoded with any codec.
+// The node is a list of maps, one per operation, like a JSON Patch:
+//
+//	[
+//		{"op": "replace", "path": ["a", "0"], "value": 1},
+//		{"op": "remove", "path": ["b"]}
+//	]
+//
+// Paths are lists of segments, rather than strings,
+// so that map keys which contain slashes, or are empty, survive.
+func ToNode(ops []Operation) (ipld.Node, error) {
+	return fluent.BuildList(basicnode.Prototype.List, int64(len(ops)), func(la fluent.ListAssembler) {
+		for _, op := range ops {
+			size := int64(3)
+			if op.Op == Remove {
+				size = 2
+			}
+			la.AssembleValue().CreateMap(size, func(ma fluent.MapAssembler) {
+				ma.AssembleEntry("op").AssignString(string(op.Op))
+				segs := op.Path.Segments()
+				ma.AssembleEntry("path").CreateList(int64(len(segs)), func(la fluent.ListAssembler) {
+					for _, seg := range segs {
+						la.AssembleValue().AssignString(seg.String())
+					}
+				})
+				if op.Op != Remove {
+					ma.AssembleEntry("value").AssignNode(op.Value)
+				}
+			})
+		}
+	})
+}
+
+// FromNode reads operations from a node in the form which ToNode produces.
+func FromNode(n ipld.Node) ([]Operation, error) {
+	if n.Kind() != ipld.Kind_List {
+		return nil, fmt.Errorf("patch: operations must be a list, not a %s", n.Kind())
+	}
+	ops := make([]Operation, 0, n.Length())
+	for itr := n.ListIterator(); !itr.Done(); {
+		i, on, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		op, err := operationFromNode(on)
+		if err != nil {
+			return nil, fmt.Errorf("patch: operation %d: %w", i, err)
+		}
+		ops = append(ops, op)
+	}
+	return ops, nil
+}
+
+func operationFromNode(n ipld.Node) (op Operation, err error) {
+	if n.Kind() != ipld.Kind_Map {
+		return op, fmt.Errorf("must be a map, not a %s", n.Kind())
+	}
+	for itr := n.MapIterator(); !itr.Done(); {
+		k, v, err := itr.Next()
+		if err != nil {
+			return op, err
+		}
+		ks, err := k.AsString()
+		if err != nil {
+			return op, err
+		}
+		switch ks {
+		case "op":
+			s, err := v.AsString()
+			if err != nil {
+				return op, fmt.Errorf("op: %w", err)
+			}
+			op.Op = Op(s)
+		case "path":
+			if v.Kind() != ipld.Kind_List {
+				return op, fmt.Errorf("path must be a list, not a %s", v.Kind())
+			}
+			segs := make([]ipld.PathSegment, 0, v.Length())
+			for itr := v.ListIterator(); !itr.Done(); {
+				_, sn, err := itr.Next()
+				if err != nil {
+					return op, err
+				}
+				s, err := sn.AsString()
+				if err != nil {
+					return op, fmt.Errorf("path: %w", err)
+				}
+				segs = append(segs, ipld.PathSegmentOfString(s))
+			}
+			op.Path = ipld.NewPathNocopy(segs)
+		case "value":
+			op.Value = v
+		default:
+			return op, fmt.Errorf("unknown key %q", ks)
+		}
+	}
+	switch op.Op {
+	case Add, Replace:
+		if op.Value == nil {
+			return op, fmt.Errorf("missing value")
+		}
+	case Remove:
+	case "":
+		return op, fmt.Errorf("missing op")
+	default:
+		return op, fmt.Errorf("unknown op %q", op.Op)
+	}
+	return op, nil
+}
@@ -0,0 +1,155 @@
+// Package patch computes the differences between two nodes as a list of operations,
+// and applies such lists of operations to nodes, much like JSON Patch (RFC 6902) does for JSON.
+//
+// This lets a replication protocol ship only what changed in some data,
+// rather than all of it, when the receiver already has an earlier version.
+//
+// Operations address nodes by ipld.Path, and only work at the Data Model level:
+// typed nodes are patched via their type-level view, as with any other traversal,
+// and links are compared as values, without being loaded.
+//
+// The order of map entries isn't preserved:
+// entries which are added to a map go at its end.
+package patch
+
+import (
+	"fmt"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/traversal"
+)
+
+// Op is the kind of an Operation.
+type Op string
+
+const (
+	// Add puts a value at a path.
+	// In a map, this adds an entry, or replaces an existing one.
+	// In a list, this inserts a value at an index, moving later values along;
+	// the index may be the length of the list, or "-", to append.
+	Add Op = "add"
+
+	// Remove removes the value at a path, which must exist.
+	// In a list, later values move up.
+	Remove Op = "remove"
+
+	// Replace replaces the value at a path, which must exist.
+	Replace Op = "replace"
+)
+
+// Operation is one change to a node.
+type Operation struct {
+	Op    Op
+	Path  ipld.Path
+	Value ipld.Node // Unused for Remove.
+}
+
+// String describes an operation briefly, without its value.
+func (o Operation) String() string {
+	return fmt.Sprintf("%s %q", o.Op, o.Path)
+}
+
+// Apply returns the result of applying operations to a node, in order.
+// The original node is unchanged, and the result shares every part of it
+// which the operations don't change.
+//
+// Nodes are rebuilt using the prototypes of the nodes they replace,
+// so applying a patch to a generated node gives a generated node;
+// operations which would make invalid data for its type return an error.
+//
+// If any operation fails, Apply returns the error, saying which operation it was.
+func Apply(n ipld.Node, ops []Operation) (ipld.Node, error) {
+	for i, op := range ops {
+		var err error
+		n, err = apply(n, op)
+		if err != nil {
+			return nil, fmt.Errorf("patch: operation %d (%s): %w", i, op, err)
+		}
+	}
+	return n, nil
+}
+
+func apply(n ipld.Node, op Operation) (ipld.Node, error) {
+	switch op.Op {
+	case Add, Replace:
+		if op.Value == nil {
+			return nil, fmt.Errorf("missing value")
+		}
+	case Remove:
+	default:
+		return nil, fmt.Errorf("unknown op %q", op.Op)
+	}
+	if op.Path.Len() == 0 {
+		if op.Op == Remove {
+			return nil, fmt.Errorf("cannot remove the root")
+		}
+		return op.Value, nil
+	}
+	switch op.Op {
+	case Remove:
+		return traversal.Amend(n, op.Path, nil)
+	case Replace:
+		return traversal.FocusedTransform(n, op.Path, func(_ traversal.Progress, prev ipld.Node) (ipld.Node, error) {
+			if prev == nil {
+				return nil, ipld.ErrNotExists{Segment: op.Path.Last()}
+			}
+			return op.Value, nil
+		}, false)
+	}
+
+	// Adding to a map is a plain Amend, but adding to a list inserts,
+	// so it rebuilds the parent instead.
+	seg := op.Path.Last()
+	return traversal.FocusedTransform(n, op.Path.Parent(), func(_ traversal.Progress, parent ipld.Node) (ipld.Node, error) {
+		if parent == nil {
+			return nil, ipld.ErrNotExists{Segment: op.Path.Parent().Last()}
+		}
+		switch parent.Kind() {
+		case ipld.Kind_Map:
+			return traversal.Amend(parent, ipld.NewPath([]ipld.PathSegment{seg}), op.Value)
+		case ipld.Kind_List:
+			idx := parent.Length()
+			if seg.String() != "-" {
+				var err error
+				idx, err = seg.Index()
+				if err != nil || idx < 0 || idx > parent.Length() {
+					return nil, fmt.Errorf("cannot add at %q, as the list there has length %d", seg, parent.Length())
+				}
+			}
+			return insert(parent, idx, op.Value)
+		}
+		return nil, fmt.Errorf("cannot add to a %s", parent.Kind())
+	}, false)
+}
+
+// insert returns a copy of a list with a value inserted at an index.
+func insert(list ipld.Node, idx int64, v ipld.Node) (ipld.Node, error) {
+	nb := list.Prototype().NewBuilder()
+	la, err := nb.BeginList(list.Length() + 1)
+	if err != nil {
+		return nil, err
+	}
+	for itr := list.ListIterator(); !itr.Done(); {
+		i, v2, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if i == idx {
+			if err := la.AssembleValue().AssignNode(v); err != nil {
+				return nil, err
+			}
+		}
+		if err := la.AssembleValue().AssignNode(v2); err != nil {
+			return nil, err
+		}
+	}
+	if idx == list.Length() {
+		if err := la.AssembleValue().AssignNode(v); err != nil {
+			return nil, err
+		}
+	}
+	if err := la.Finish(); err != nil {
+		return nil, err
+	}
+	return nb.Build(), nil
+}
@@ -0,0 +1,165 @@
+package patch
+
+import (
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec/tests"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/node/gendemo"
+)
+
+// describe renders operations with their values, to compare them in tests.
+func describe(t *testing.T, ops []Operation) []string {
+	var s []string
+	for _, op := range ops {
+		if op.Op == Remove {
+			s = append(s, op.String())
+		} else {
+			s = append(s, op.String()+" "+tests.ToJSON(t, op.Value))
+		}
+	}
+	return s
+}
+
+func TestDiffAndApply(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		x, y string
+		ops  []string
+	}{
+		{"equal", `{"a":[1,{"b":null}]}`, `{"a":[1,{"b":null}]}`, nil},
+		{"scalar", `{"a":1}`, `{"a":"1"}`, []string{`replace "a" "1"`}},
+		{"root", `[1]`, `{"a":1}`, []string{`replace "" {"a":1}`}},
+		{"map entries", `{"a":1,"b":2,"c":{"d":3}}`, `{"b":2,"c":{"d":4,"e":5},"f":6}`, []string{
+			`remove "a"`,
+			`replace "c/d" 4`,
+			`add "c/e" 5`,
+			`add "f" 6`,
+		}},
+		{"map order", `{"a":1,"b":2}`, `{"b":2,"a":1}`, nil},
+		{"list growing", `[1,[2]]`, `[1,[3,4],5,6]`, []string{
+			`replace "1/0" 3`,
+			`add "1/1" 4`,
+			`add "2" 5`,
+			`add "3" 6`,
+		}},
+		{"list shrinking", `[1,2,3,4]`, `[0,2]`, []string{
+			`replace "0" 0`,
+			`remove "3"`,
+			`remove "2"`,
+		}},
+		{"links", `[{"/":"bafkqaaa"},{"/":"bafkqaaa"}]`, `[{"/":"bafkqaaa"},{"/":"bafkqaalb"}]`, []string{
+			`replace "1" {"/":"bafkqaalb"}`,
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			x := tests.FromJSON(t, basicnode.Prototype.Any, tc.x)
+			y := tests.FromJSON(t, basicnode.Prototype.Any, tc.y)
+			ops, err := Diff(x, y)
+			Require(t, err, ShouldEqual, nil)
+			Wish(t, describe(t, ops), ShouldEqual, tc.ops)
+
+			n, err := Apply(x, ops)
+			Require(t, err, ShouldEqual, nil)
+			again, err := Diff(n, y)
+			Require(t, err, ShouldEqual, nil)
+			Wish(t, again, ShouldEqual, []Operation(nil))
+			Wish(t, tests.ToJSON(t, x), ShouldEqual, tc.x) // unchanged.
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	x := tests.FromJSON(t, basicnode.Prototype.Any, `{"l":[1,2],"m":{"a":1}}`)
+	apply := func(ops ...Operation) (string, error) {
+		n, err := Apply(x, ops)
+		if err != nil {
+			return "", err
+		}
+		return tests.ToJSON(t, n), nil
+	}
+	path := ipld.ParsePath
+
+	got, err := apply(
+		Operation{Op: Add, Path: path("l/0"), Value: basicnode.NewInt(0)},
+		Operation{Op: Add, Path: path("l/2"), Value: basicnode.NewInt(15)},
+		Operation{Op: Add, Path: path("l/-"), Value: basicnode.NewInt(3)},
+		Operation{Op: Add, Path: path("m/a"), Value: basicnode.NewInt(10)},
+		Operation{Op: Remove, Path: path("l/1")},
+	)
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, got, ShouldEqual, `{"l":[0,15,2,3],"m":{"a":10}}`)
+
+	for _, tc := range []struct {
+		op   Operation
+		want string
+	}{
+		{Operation{Op: Replace, Path: path("m/b"), Value: ipld.Null}, `patch: operation 0 (replace "m/b"): key not found: "b"`},
+		{Operation{Op: Remove, Path: path("m/b")}, `patch: operation 0 (remove "m/b"): key not found: "b"`},
+		{Operation{Op: Add, Path: path("l/3"), Value: ipld.Null}, `patch: operation 0 (add "l/3"): cannot add at "3", as the list there has length 2`},
+		{Operation{Op: Add, Path: path("m/a/b"), Value: ipld.Null}, `patch: operation 0 (add "m/a/b"): cannot add to a int`},
+		{Operation{Op: Remove, Path: path("")}, `patch: operation 0 (remove ""): cannot remove the root`},
+		{Operation{Op: Add, Path: path("m/a")}, `patch: operation 0 (add "m/a"): missing value`},
+		{Operation{Op: "move", Path: path("m/a")}, `patch: operation 0 (move "m/a"): unknown op "move"`},
+	} {
+		_, err := apply(tc.op)
+		Wish(t, err.Error(), ShouldEqual, tc.want)
+	}
+}
+
+func TestGenerated(t *testing.T) {
+	x := tests.FromJSON(t, gendemo.Type.Map__String__Msg3, `{"a":{"whee":1,"woot":2,"waga":3}}`)
+	y := tests.FromJSON(t, gendemo.Type.Map__String__Msg3, `{"a":{"whee":1,"woot":20,"waga":3},"b":{"whee":4,"woot":5,"waga":6}}`)
+	ops, err := Diff(x, y)
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, describe(t, ops), ShouldEqual, []string{`replace "a/woot" 20`, `add "b" {"whee":4,"woot":5,"waga":6}`})
+
+	n, err := Apply(x, ops)
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, n, ShouldEqual, y)
+
+	// Operations which would break the type are rejected.
+	_, err = Apply(x, []Operation{{Op: Remove, Path: ipld.ParsePath("a/woot")}})
+	Wish(t, err != nil, ShouldEqual, true)
+}
+
+func TestNodeForm(t *testing.T) {
+	x := tests.FromJSON(t, basicnode.Prototype.Any, `{"a":{"b/c":[1,2]},"":true}`)
+	y := tests.FromJSON(t, basicnode.Prototype.Any, `{"a":{"b/c":[1]},"":false,"d":{"e":null}}`)
+	ops, err := Diff(x, y)
+	Require(t, err, ShouldEqual, nil)
+
+	n, err := ToNode(ops)
+	Require(t, err, ShouldEqual, nil)
+	encoded := tests.ToJSON(t, n)
+	Wish(t, encoded, ShouldEqual, `[`+
+		`{"op":"remove","path":["a","b/c","1"]},`+
+		`{"op":"replace","path":[""],"value":false},`+
+		`{"op":"add","path":["d"],"value":{"e":null}}`+
+		`]`)
+
+	ops2, err := FromNode(tests.FromJSON(t, basicnode.Prototype.Any, encoded))
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, describe(t, ops2), ShouldEqual, describe(t, ops))
+	applied, err := Apply(x, ops2)
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, ipld.DeepEqual(applied, y), ShouldEqual, true)
+
+	for _, tc := range []struct {
+		in   string
+		want string
+	}{
+		{`{}`, `patch: operations must be a list, not a map`},
+		{`[1]`, `patch: operation 0: must be a map, not a int`},
+		{`[{"path":[]}]`, `patch: operation 0: missing op`},
+		{`[{"op":"add","path":[]}]`, `patch: operation 0: missing value`},
+		{`[{"op":"remove","path":"a"}]`, `patch: operation 0: path must be a list, not a string`},
+		{`[{"op":"remove","path":[],"from":[]}]`, `patch: operation 0: unknown key "from"`},
+	} {
+		_, err := FromNode(tests.FromJSON(t, basicnode.Prototype.Any, tc.in))
+		Wish(t, err.Error(), ShouldEqual, tc.want)
+	}
+}
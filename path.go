@@ -1,6 +1,7 @@
 package ipld
 
 import (
+	"fmt"
 	"strings"
 )
 
@@ -216,3 +217,31 @@ func (p Path) Shift() (PathSegment, Path) {
 	}
 	return p.segments[0], Path{p.segments[1:]}
 }
+
+// LookupByPath follows p down from n, calling LookupBySegment with each of
+// its segments in turn, and returns the node it reaches.
+// An empty path gives n itself.
+//
+// Segments are given to LookupBySegment as they are,
+// so list nodes parse them as indexes, and map nodes use them as keys;
+// other kinds of node can't be traversed, so the path must end at them.
+// Links are not loaded: a link in the middle of the path is an error.
+// Use traversal.Get to follow paths across links.
+//
+// Errors wrap the error from LookupBySegment, such as an ErrNotExists,
+// with the path to the node it happened at.
+func LookupByPath(n Node, p Path) (Node, error) {
+	for i, seg := range p.segments {
+		switch n.Kind() {
+		case Kind_Map, Kind_List:
+		default:
+			return nil, fmt.Errorf("cannot traverse segment %q on %s node at %q", seg, n.Kind(), p.Truncate(i))
+		}
+		next, err := n.LookupBySegment(seg)
+		if err != nil {
+			return nil, fmt.Errorf("error traversing segment %q on node at %q: %w", seg, p.Truncate(i), err)
+		}
+		n = next
+	}
+	return n, nil
+}
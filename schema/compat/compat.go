@@ -0,0 +1,493 @@
+// Package compat compares two versions of a schema, and reports the changes
+// which could make data written with the old version unreadable with the new one.
+//
+// This is meant for gating schema changes, for example in code review tooling:
+// if Check reports nothing, any data which was valid under the old schema
+// is also valid under the new one.
+//
+// Each type of the old schema is compared with the type of the same name in the new one,
+// and then so are the types they use, structurally:
+// for example, a struct field may switch to a type with another name,
+// as long as it describes the same data.
+// The comparison is about the representation of the data:
+// for example, renaming an enum member is fine, as long as its representation stays the same,
+// but renaming a struct field with the map representation is not, unless it keeps its old key.
+//
+// Some of the rules, roughly:
+//
+//   - a type may not change kind, nor representation strategy,
+//     except to become any, which reads all data;
+//   - added struct fields must be optional, or have an implicit value;
+//   - struct fields may not be removed, as data which has them would be rejected;
+//   - struct fields may become optional or nullable, but not the other way around;
+//   - map and list values may become nullable, but not the other way around;
+//   - union members and enum members may be added, but not removed;
+//   - tuple struct fields may only be added at the end.
+//
+// A type which isn't in the new schema at all is reported too,
+// since Check doesn't know which types the data may have been written as;
+// unless it's used by another type, such as for a struct field,
+// in which case the type using it is compared instead.
+// (Types only referenced by links don't count as used,
+// since the data on the other side of the link may well have been written with them.)
+package compat
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// Incompatibility describes a change to a type which could make data written
+// with the old version of a schema unreadable with the new one.
+type Incompatibility struct {
+	TypeName schema.TypeName // The name of the type in the old schema.
+	Detail   string
+}
+
+func (e Incompatibility) Error() string {
+	return fmt.Sprintf("type %s: %s", e.TypeName, e.Detail)
+}
+
+// Check compares the types of two versions of a schema,
+// and returns an Incompatibility for each problem it finds.
+// Both type systems should already be valid; see schema.TypeSystem.ValidateGraph.
+//
+// Problems are reported in order of the names of the types they're found in.
+func Check(old, new *schema.TypeSystem) []error {
+	c := &checker{seen: make(map[[2]schema.TypeName]bool)}
+	oldTypes := old.GetTypes()
+	names := make([]string, 0, len(oldTypes))
+	for name := range oldTypes {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	used := usedTypes(oldTypes)
+	for _, name := range names {
+		newType := new.TypeByName(name)
+		if newType == nil {
+			if !used[schema.TypeName(name)] {
+				c.errs = append(c.errs, Incompatibility{schema.TypeName(name), "type was removed"})
+			}
+			continue
+		}
+		c.check(oldTypes[schema.TypeName(name)], newType)
+	}
+	sort.SliceStable(c.errs, func(i, j int) bool {
+		return c.errs[i].(Incompatibility).TypeName < c.errs[j].(Incompatibility).TypeName
+	})
+	return c.errs
+}
+
+// usedTypes returns the names of the types which other types use for some part of their data.
+func usedTypes(types map[schema.TypeName]schema.Type) map[schema.TypeName]bool {
+	used := make(map[schema.TypeName]bool)
+	for _, typ := range types {
+		switch t := typ.(type) {
+		case *schema.TypeMap:
+			used[t.KeyType().Name()] = true
+			used[t.ValueType().Name()] = true
+		case *schema.TypeList:
+			used[t.ValueType().Name()] = true
+		case *schema.TypeStruct:
+			for _, f := range t.Fields() {
+				used[f.Type().Name()] = true
+			}
+		case *schema.TypeUnion:
+			for _, m := range t.Members() {
+				used[m.Name()] = true
+			}
+		}
+	}
+	return used
+}
+
+// checker holds the state of a Check.
+// Each pair of old and new types is only compared once,
+// which also stops recursive types from recursing forever.
+type checker struct {
+	seen map[[2]schema.TypeName]bool
+	errs []error
+}
+
+func (c *checker) errorf(old schema.Type, format string, args ...interface{}) {
+	c.errs = append(c.errs, Incompatibility{old.Name(), fmt.Sprintf(format, args...)})
+}
+
+func (c *checker) check(old, new schema.Type) {
+	pair := [2]schema.TypeName{old.Name(), new.Name()}
+	if c.seen[pair] {
+		return
+	}
+	c.seen[pair] = true
+
+	if new.TypeKind() == schema.TypeKind_Any {
+		return // any reads whatever was there before.
+	}
+	if old.TypeKind() != new.TypeKind() {
+		if old.Name() != new.Name() {
+			c.errorf(old, "replaced by type %s, of kind %s rather than %s", new.Name(), new.TypeKind(), old.TypeKind())
+		} else {
+			c.errorf(old, "kind changed from %s to %s", old.TypeKind(), new.TypeKind())
+		}
+		return
+	}
+	switch old := old.(type) {
+	case *schema.TypeBool, *schema.TypeString, *schema.TypeBytes, *schema.TypeInt, *schema.TypeFloat:
+		// Scalars of the same kind always read the same data.
+	case *schema.TypeLink:
+		// Links read any link, whatever type they reference.
+	case *schema.TypeUnit:
+		if oldRepr, newRepr := unitReprName(old.RepresentationStrategy()), unitReprName(new.(*schema.TypeUnit).RepresentationStrategy()); oldRepr != newRepr {
+			c.errorf(old, "representation changed from %s to %s", oldRepr, newRepr)
+		}
+	case *schema.TypeMap:
+		c.checkMap(old, new.(*schema.TypeMap))
+	case *schema.TypeList:
+		c.checkList(old, new.(*schema.TypeList))
+	case *schema.TypeStruct:
+		c.checkStruct(old, new.(*schema.TypeStruct))
+	case *schema.TypeUnion:
+		c.checkUnion(old, new.(*schema.TypeUnion))
+	case *schema.TypeEnum:
+		c.checkEnum(old, new.(*schema.TypeEnum))
+	default:
+		panic(fmt.Errorf("unknown type %T", old))
+	}
+}
+
+func (c *checker) checkMap(old, new *schema.TypeMap) {
+	oldRepr, newRepr := mapReprName(old.RepresentationStrategy()), mapReprName(new.RepresentationStrategy())
+	if oldRepr != newRepr {
+		c.errorf(old, "representation changed from %s to %s", oldRepr, newRepr)
+		return
+	}
+	if old.ValueIsNullable() && !new.ValueIsNullable() {
+		c.errorf(old, "values are no longer nullable")
+	}
+	c.check(old.KeyType(), new.KeyType())
+	c.check(old.ValueType(), new.ValueType())
+}
+
+func unitReprName(r schema.UnitRepresentation) string {
+	switch r.(type) {
+	case schema.UnitRepresentation_Null:
+		return "null"
+	case schema.UnitRepresentation_EmptyMap:
+		return "emptymap"
+	default:
+		panic(fmt.Errorf("unknown unit representation %T", r))
+	}
+}
+
+func mapReprName(r schema.MapRepresentation) string {
+	switch r.(type) {
+	case schema.MapRepresentation_Map:
+		return "map"
+	case schema.MapRepresentation_ListPairs:
+		return "listpairs"
+	default:
+		panic(fmt.Errorf("unknown map representation %T", r))
+	}
+}
+
+func (c *checker) checkList(old, new *schema.TypeList) {
+	if old.ValueIsNullable() && !new.ValueIsNullable() {
+		c.errorf(old, "values are no longer nullable")
+	}
+	c.check(old.ValueType(), new.ValueType())
+}
+
+func (c *checker) checkStruct(old, new *schema.TypeStruct) {
+	oldRepr, newRepr := old.RepresentationStrategy(), new.RepresentationStrategy()
+	if structReprName(oldRepr) != structReprName(newRepr) {
+		c.errorf(old, "representation changed from %s to %s", structReprName(oldRepr), structReprName(newRepr))
+		return
+	}
+	switch oldRepr := oldRepr.(type) {
+	case schema.StructRepresentation_Map:
+		newRepr := newRepr.(schema.StructRepresentation_Map)
+		c.checkFieldsByName(old, new, &oldRepr, &newRepr)
+	case schema.StructRepresentation_ListPairs:
+		c.checkFieldsByName(old, new, nil, nil)
+	case schema.StructRepresentation_StringPairs:
+		newRepr := newRepr.(schema.StructRepresentation_StringPairs)
+		if oldRepr.GetInnerDelim() != newRepr.GetInnerDelim() || oldRepr.GetEntryDelim() != newRepr.GetEntryDelim() {
+			c.errorf(old, "stringpairs delimiters changed from %q and %q to %q and %q",
+				oldRepr.GetInnerDelim(), oldRepr.GetEntryDelim(), newRepr.GetInnerDelim(), newRepr.GetEntryDelim())
+		}
+		c.checkFieldsByName(old, new, nil, nil)
+	case schema.StructRepresentation_Stringjoin:
+		newRepr := newRepr.(schema.StructRepresentation_Stringjoin)
+		if oldRepr.GetDelim() != newRepr.GetDelim() {
+			c.errorf(old, "stringjoin delimiter changed from %q to %q", oldRepr.GetDelim(), newRepr.GetDelim())
+		}
+		c.checkFieldsByPosition(old, new)
+	case schema.StructRepresentation_Tuple:
+		c.checkFieldsByPosition(old, new)
+	default:
+		panic(fmt.Errorf("unknown struct representation %T", oldRepr))
+	}
+}
+
+func structReprName(r schema.StructRepresentation) string {
+	switch r.(type) {
+	case schema.StructRepresentation_Map:
+		return "map"
+	case schema.StructRepresentation_Tuple:
+		return "tuple"
+	case schema.StructRepresentation_StringPairs:
+		return "stringpairs"
+	case schema.StructRepresentation_Stringjoin:
+		return "stringjoin"
+	case schema.StructRepresentation_ListPairs:
+		return "listpairs"
+	default:
+		panic(fmt.Errorf("unknown struct representation %T", r))
+	}
+}
+
+// checkFieldsByName compares the fields of structs whose representations
+// identify fields by their names, or by the keys they're renamed to.
+// The map representations are only given for the map representation;
+// they're nil for the others, which don't have renames or implicits.
+func (c *checker) checkFieldsByName(old, new *schema.TypeStruct, oldRepr, newRepr *schema.StructRepresentation_Map) {
+	key := func(r *schema.StructRepresentation_Map, f schema.StructField) string {
+		if r == nil {
+			return f.Name()
+		}
+		return r.GetFieldKey(f)
+	}
+	implicit := func(r *schema.StructRepresentation_Map, f schema.StructField) schema.ImplicitValue {
+		if r == nil {
+			return nil
+		}
+		return r.GetImplicit(f)
+	}
+	newFields := make(map[string]schema.StructField)
+	for _, f := range new.Fields() {
+		newFields[key(newRepr, f)] = f
+	}
+	oldKeys := make(map[string]bool)
+	for _, of := range old.Fields() {
+		k := key(oldRepr, of)
+		oldKeys[k] = true
+		nf, ok := newFields[k]
+		if !ok {
+			c.errorf(old, "field %s was removed", of.Name())
+			continue
+		}
+		c.checkField(old, of, nf, implicit(oldRepr, of), implicit(newRepr, nf))
+	}
+	for _, nf := range new.Fields() {
+		if oldKeys[key(newRepr, nf)] {
+			continue
+		}
+		if !nf.IsOptional() && implicit(newRepr, nf) == nil {
+			c.errorf(old, "field %s was added, but it's not optional", nf.Name())
+		}
+	}
+}
+
+// checkFieldsByPosition compares the fields of structs whose representations
+// identify fields by their position, such as tuples.
+// Fields may only be added at the end, and only if they're optional.
+func (c *checker) checkFieldsByPosition(old, new *schema.TypeStruct) {
+	oldFields, newFields := old.Fields(), new.Fields()
+	for i, of := range oldFields {
+		if i >= len(newFields) {
+			c.errorf(old, "field %s was removed", of.Name())
+			continue
+		}
+		c.checkField(old, of, newFields[i], nil, nil)
+	}
+	for _, nf := range newFields[min(len(oldFields), len(newFields)):] {
+		if !nf.IsOptional() {
+			c.errorf(old, "field %s was added, but it's not optional", nf.Name())
+		}
+	}
+}
+
+func (c *checker) checkField(old *schema.TypeStruct, of, nf schema.StructField, oldImplicit, newImplicit schema.ImplicitValue) {
+	// Data written with the old schema may leave out a field if it's optional, or has an implicit value;
+	// so the new field must allow that too, and give such data the same meaning.
+	switch {
+	case oldImplicit != nil && newImplicit != nil:
+		if oldImplicit != newImplicit {
+			c.errorf(old, "field %s has a different implicit value, so data which leaves it out would be read differently", of.Name())
+		}
+	case oldImplicit != nil && !nf.IsOptional():
+		c.errorf(old, "field %s no longer has an implicit value, so data which leaves it out can't be read", of.Name())
+	case of.IsOptional() && !nf.IsOptional() && newImplicit == nil:
+		c.errorf(old, "field %s is no longer optional", of.Name())
+	}
+	if of.IsNullable() && !nf.IsNullable() {
+		c.errorf(old, "field %s is no longer nullable", of.Name())
+	}
+	c.check(of.Type(), nf.Type())
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func (c *checker) checkUnion(old, new *schema.TypeUnion) {
+	switch oldRepr := old.RepresentationStrategy().(type) {
+	case schema.UnionRepresentation_Keyed:
+		newRepr, ok := new.RepresentationStrategy().(schema.UnionRepresentation_Keyed)
+		if !ok {
+			break
+		}
+		c.checkUnionDiscriminants(old, new, oldRepr, newRepr)
+		return
+	case schema.UnionRepresentation_Envelope:
+		newRepr, ok := new.RepresentationStrategy().(schema.UnionRepresentation_Envelope)
+		if !ok {
+			break
+		}
+		if oldRepr.GetDiscriminantKey() != newRepr.GetDiscriminantKey() {
+			c.errorf(old, "discriminant key changed from %q to %q", oldRepr.GetDiscriminantKey(), newRepr.GetDiscriminantKey())
+		}
+		if oldRepr.GetContentKey() != newRepr.GetContentKey() {
+			c.errorf(old, "content key changed from %q to %q", oldRepr.GetContentKey(), newRepr.GetContentKey())
+		}
+		c.checkUnionDiscriminants(old, new, oldRepr, newRepr)
+		return
+	case schema.UnionRepresentation_Inline:
+		newRepr, ok := new.RepresentationStrategy().(schema.UnionRepresentation_Inline)
+		if !ok {
+			break
+		}
+		if oldRepr.GetDiscriminantKey() != newRepr.GetDiscriminantKey() {
+			c.errorf(old, "discriminant key changed from %q to %q", oldRepr.GetDiscriminantKey(), newRepr.GetDiscriminantKey())
+		}
+		c.checkUnionDiscriminants(old, new, oldRepr, newRepr)
+		return
+	case schema.UnionRepresentation_Stringprefix:
+		newRepr, ok := new.RepresentationStrategy().(schema.UnionRepresentation_Stringprefix)
+		if !ok {
+			break
+		}
+		if oldRepr.GetDelim() != newRepr.GetDelim() {
+			c.errorf(old, "stringprefix delimiter changed from %q to %q", oldRepr.GetDelim(), newRepr.GetDelim())
+		}
+		c.checkUnionDiscriminants(old, new, oldRepr, newRepr)
+		return
+	case schema.UnionRepresentation_Kinded:
+		newRepr, ok := new.RepresentationStrategy().(schema.UnionRepresentation_Kinded)
+		if !ok {
+			break
+		}
+		for _, k := range allKinds {
+			oldMember := oldRepr.GetMember(k)
+			if oldMember == "" {
+				continue
+			}
+			newMember := newRepr.GetMember(k)
+			if newMember == "" {
+				c.errorf(old, "member %s for kind %s was removed", oldMember, k)
+				continue
+			}
+			c.check(old.TypeSystem().TypeByName(string(oldMember)), new.TypeSystem().TypeByName(string(newMember)))
+		}
+		return
+	default:
+		panic(fmt.Errorf("unknown union representation %T", oldRepr))
+	}
+	c.errorf(old, "representation changed from %s to %s", unionReprName(old.RepresentationStrategy()), unionReprName(new.RepresentationStrategy()))
+}
+
+var allKinds = []ipld.Kind{
+	ipld.Kind_Map,
+	ipld.Kind_List,
+	ipld.Kind_Null,
+	ipld.Kind_Bool,
+	ipld.Kind_Int,
+	ipld.Kind_Float,
+	ipld.Kind_String,
+	ipld.Kind_Bytes,
+	ipld.Kind_Link,
+}
+
+// discriminated is implemented by the union representations which have a discriminant string for each member.
+type discriminated interface {
+	GetDiscriminant(schema.Type) string
+}
+
+// checkUnionDiscriminants checks that each discriminant of the old union
+// is still in the new one, and that the members it picks are compatible.
+func (c *checker) checkUnionDiscriminants(old, new *schema.TypeUnion, oldRepr, newRepr discriminated) {
+	newMembers := make(map[string]schema.Type)
+	for _, m := range new.Members() {
+		newMembers[newRepr.GetDiscriminant(m)] = m
+	}
+	for _, m := range old.Members() {
+		d := oldRepr.GetDiscriminant(m)
+		nm, ok := newMembers[d]
+		if !ok {
+			c.errorf(old, "member %s with discriminant %q was removed", m.Name(), d)
+			continue
+		}
+		c.check(m, nm)
+	}
+}
+
+func unionReprName(r schema.UnionRepresentation) string {
+	switch r.(type) {
+	case schema.UnionRepresentation_Keyed:
+		return "keyed"
+	case schema.UnionRepresentation_Kinded:
+		return "kinded"
+	case schema.UnionRepresentation_Envelope:
+		return "envelope"
+	case schema.UnionRepresentation_Inline:
+		return "inline"
+	case schema.UnionRepresentation_Stringprefix:
+		return "stringprefix"
+	default:
+		panic(fmt.Errorf("unknown union representation %T", r))
+	}
+}
+
+func (c *checker) checkEnum(old, new *schema.TypeEnum) {
+	switch oldRepr := old.RepresentationStrategy().(type) {
+	case schema.EnumRepresentation_String:
+		newRepr, ok := new.RepresentationStrategy().(schema.EnumRepresentation_String)
+		if !ok {
+			c.errorf(old, "representation changed from string to int")
+			return
+		}
+		serials := make(map[string]bool)
+		for _, m := range new.Members() {
+			serials[newRepr.GetSerial(m)] = true
+		}
+		for _, m := range old.Members() {
+			if s := oldRepr.GetSerial(m); !serials[s] {
+				c.errorf(old, "member %s, represented as %q, was removed", m, s)
+			}
+		}
+	case schema.EnumRepresentation_Int:
+		newRepr, ok := new.RepresentationStrategy().(schema.EnumRepresentation_Int)
+		if !ok {
+			c.errorf(old, "representation changed from int to string")
+			return
+		}
+		serials := make(map[int]bool)
+		for _, m := range new.Members() {
+			s, _ := newRepr.GetSerial(m)
+			serials[s] = true
+		}
+		for _, m := range old.Members() {
+			if s, _ := oldRepr.GetSerial(m); !serials[s] {
+				c.errorf(old, "member %s, represented as %d, was removed", m, s)
+			}
+		}
+	default:
+		panic(fmt.Errorf("unknown enum representation %T", oldRepr))
+	}
+}
@@ -0,0 +1,243 @@
+package compat_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime/schema"
+	"github.com/ipld/go-ipld-prime/schema/compat"
+	schemaparser "github.com/ipld/go-ipld-prime/schema/parser"
+)
+
+func parse(t *testing.T, src string) *schema.TypeSystem {
+	t.Helper()
+	ts, err := schemaparser.Parse(strings.NewReader(src))
+	Require(t, err, ShouldEqual, nil)
+	return ts
+}
+
+func TestCheck(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		old, new string
+		expected []string
+	}{
+		{
+			name: "Unchanged",
+			old:  `type Foo struct { a String b optional Int }`,
+			new:  `type Foo struct { a String b optional Int }`,
+		},
+		{
+			name: "AddedOptionalField",
+			old:  `type Foo struct { a String }`,
+			new:  `type Foo struct { a String b optional Int }`,
+		},
+		{
+			name: "AddedFieldWithImplicit",
+			old:  `type Foo struct { a String }`,
+			new:  `type Foo struct { a String b Int (implicit "3") }`,
+		},
+		{
+			name:     "AddedRequiredField",
+			old:      `type Foo struct { a String }`,
+			new:      `type Foo struct { a String b Int }`,
+			expected: []string{"type Foo: field b was added, but it's not optional"},
+		},
+		{
+			name:     "RemovedField",
+			old:      `type Foo struct { a String b optional Int }`,
+			new:      `type Foo struct { a String }`,
+			expected: []string{"type Foo: field b was removed"},
+		},
+		{
+			name: "FieldBecameOptionalAndNullable",
+			old:  `type Foo struct { a String }`,
+			new:  `type Foo struct { a optional nullable String }`,
+		},
+		{
+			name: "FieldNoLongerOptionalOrNullable",
+			old:  `type Foo struct { a optional nullable String }`,
+			new:  `type Foo struct { a String }`,
+			expected: []string{
+				"type Foo: field a is no longer optional",
+				"type Foo: field a is no longer nullable",
+			},
+		},
+		{
+			name:     "FieldRenamedInRepresentation",
+			old:      `type Foo struct { a String }`,
+			new:      `type Foo struct { a String (rename "A") }`,
+			expected: []string{"type Foo: field a was removed", "type Foo: field a was added, but it's not optional"},
+		},
+		{
+			name: "FieldRenamedKeepingItsKey",
+			old:  `type Foo struct { a String }`,
+			new:  `type Foo struct { b String (rename "a") }`,
+		},
+		{
+			name:     "ImplicitChanged",
+			old:      `type Foo struct { a Int (implicit "1") }`,
+			new:      `type Foo struct { a Int (implicit "2") }`,
+			expected: []string{"type Foo: field a has a different implicit value, so data which leaves it out would be read differently"},
+		},
+		{
+			name:     "ImplicitRemoved",
+			old:      `type Foo struct { a Int (implicit "1") }`,
+			new:      `type Foo struct { a Int }`,
+			expected: []string{"type Foo: field a no longer has an implicit value, so data which leaves it out can't be read"},
+		},
+		{
+			name: "ImplicitReplacingOptional",
+			old:  `type Foo struct { a optional Int }`,
+			new:  `type Foo struct { a Int (implicit "1") }`,
+		},
+		{
+			name:     "FieldTypeChanged",
+			old:      `type Foo struct { a String }`,
+			new:      `type Foo struct { a Int }`,
+			expected: []string{"type String: replaced by type Int, of kind Int rather than String"},
+		},
+		{
+			name: "FieldTypeRenamed",
+			old:  `type Foo struct { a Bar } type Bar [String]`,
+			new:  `type Foo struct { a Baz } type Baz [String]`,
+		},
+		{
+			name:     "LinkTargetRemoved",
+			old:      `type Foo &Bar type Bar [String]`,
+			new:      `type Foo &Any`,
+			expected: []string{"type Bar: type was removed"},
+		},
+		{
+			name:     "RepresentationChanged",
+			old:      `type Foo struct { a String }`,
+			new:      `type Foo struct { a String } representation tuple`,
+			expected: []string{"type Foo: representation changed from map to tuple"},
+		},
+		{
+			name: "TupleFieldAppended",
+			old:  `type Foo struct { a String } representation tuple`,
+			new:  `type Foo struct { a String b optional Int } representation tuple`,
+		},
+		{
+			name:     "TupleFieldsReordered",
+			old:      `type Foo struct { a String b Int } representation tuple`,
+			new:      `type Foo struct { b Int a String } representation tuple`,
+			expected: []string{"type Int: replaced by type String, of kind String rather than Int", "type String: replaced by type Int, of kind Int rather than String"},
+		},
+		{
+			name:     "StringjoinDelimChanged",
+			old:      `type Foo struct { a String b String } representation stringjoin {join ":"}`,
+			new:      `type Foo struct { a String b String } representation stringjoin {join ","}`,
+			expected: []string{`type Foo: stringjoin delimiter changed from ":" to ","`},
+		},
+		{
+			name:     "ListValuesNoLongerNullable",
+			old:      `type Foo [nullable String]`,
+			new:      `type Foo [String]`,
+			expected: []string{"type Foo: values are no longer nullable"},
+		},
+		{
+			name: "MapValuesBecameNullable",
+			old:  `type Foo {String:Int}`,
+			new:  `type Foo {String:nullable Int}`,
+		},
+		{
+			name:     "MapValueTypeChanged",
+			old:      `type Foo {String:Int}`,
+			new:      `type Foo {String:Bool}`,
+			expected: []string{"type Int: replaced by type Bool, of kind Bool rather than Int"},
+		},
+		{
+			name: "UnionMemberAdded",
+			old:  `type Foo union { | String "s" } representation keyed`,
+			new:  `type Foo union { | String "s" | Int "i" } representation keyed`,
+		},
+		{
+			name:     "UnionMemberRemoved",
+			old:      `type Foo union { | String "s" | Int "i" } representation keyed`,
+			new:      `type Foo union { | String "s" } representation keyed`,
+			expected: []string{`type Foo: member Int with discriminant "i" was removed`},
+		},
+		{
+			name:     "UnionDiscriminantChanged",
+			old:      `type Foo union { | String "s" } representation keyed`,
+			new:      `type Foo union { | String "str" } representation keyed`,
+			expected: []string{`type Foo: member String with discriminant "s" was removed`},
+		},
+		{
+			name:     "KindedUnionMemberRemoved",
+			old:      `type Foo union { | String string | Int int } representation kinded`,
+			new:      `type Foo union { | String string } representation kinded`,
+			expected: []string{"type Foo: member Int for kind int was removed"},
+		},
+		{
+			name:     "UnionRepresentationChanged",
+			old:      `type Foo union { | String "s" } representation keyed`,
+			new:      `type Foo union { | String "s" } representation envelope { discriminantKey "t" contentKey "v" }`,
+			expected: []string{"type Foo: representation changed from keyed to envelope"},
+		},
+		{
+			name: "EnumMemberAdded",
+			old:  `type Foo enum { | A | B }`,
+			new:  `type Foo enum { | A | B | C }`,
+		},
+		{
+			name: "EnumMemberRenamedKeepingItsRepresentation",
+			old:  `type Foo enum { | A | B }`,
+			new:  `type Foo enum { | A | Bee ("B") }`,
+		},
+		{
+			name:     "EnumMemberRemoved",
+			old:      `type Foo enum { | Low ("1") | High ("10") } representation int`,
+			new:      `type Foo enum { | Low ("1") } representation int`,
+			expected: []string{"type Foo: member High, represented as 10, was removed"},
+		},
+		{
+			name:     "KindChanged",
+			old:      `type Foo string`,
+			new:      `type Foo int`,
+			expected: []string{"type Foo: kind changed from String to Int"},
+		},
+		{
+			name:     "TypeRemoved",
+			old:      `type Foo string type Bar int`,
+			new:      `type Foo string`,
+			expected: []string{"type Bar: type was removed"},
+		},
+		{
+			name: "FieldBecameAny",
+			old:  `type Foo struct { a String }`,
+			new:  `type Foo struct { a Any }`,
+		},
+		{
+			name:     "FieldNoLongerAny",
+			old:      `type Foo struct { a Any }`,
+			new:      `type Foo struct { a String }`,
+			expected: []string{"type Any: replaced by type String, of kind String rather than Any"},
+		},
+		{
+			name:     "UnitRepresentationChanged",
+			old:      `type Foo unit representation null`,
+			new:      `type Foo unit representation emptymap`,
+			expected: []string{"type Foo: representation changed from null to emptymap"},
+		},
+		{
+			name: "RecursiveType",
+			old:  `type Tree struct { children [Tree] }`,
+			new:  `type Tree struct { children [Tree] name optional String }`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := compat.Check(parse(t, tc.old), parse(t, tc.new))
+			var got []string
+			for _, err := range errs {
+				Wish(t, err, ShouldBeSameTypeAs, compat.Incompatibility{})
+				got = append(got, err.Error())
+			}
+			Wish(t, got, ShouldEqual, tc.expected)
+		})
+	}
+}
@@ -60,6 +60,8 @@ func main() {
 			"TypeStruct",
 			"TypeEnum",
 			"TypeCopy",
+			"TypeUnit",
+			"TypeAny",
 		},
 		schema.SpawnUnionRepresentationKeyed(map[string]schema.TypeName{
 			"bool":   "TypeBool",
@@ -74,6 +76,8 @@ func main() {
 			"struct": "TypeStruct",
 			"enum":   "TypeEnum",
 			"copy":   "TypeCopy",
+			"unit":   "TypeUnit",
+			"any":    "TypeAny",
 		}),
 	))
 	ts.Accumulate(schema.SpawnUnion("TypeNameOrInlineDefn",
@@ -90,10 +94,12 @@ func main() {
 		[]schema.TypeName{
 			"TypeMap",
 			"TypeList",
+			"TypeLink",
 		},
 		schema.SpawnUnionRepresentationKeyed(map[string]schema.TypeName{
 			"map":  "TypeMap",
 			"list": "TypeList",
+			"link": "TypeLink",
 		}),
 	))
 	ts.Accumulate(schema.SpawnStruct("TypeBool",
@@ -126,10 +132,12 @@ func main() {
 		[]schema.StructField{
 			schema.SpawnStructField("keyType", "TypeName", false, false),
 			schema.SpawnStructField("valueType", "TypeNameOrInlineDefn", false, false),
-			schema.SpawnStructField("valueNullable", "Bool", false, false), // todo: wants to use the "implicit" feature, but not supported yet
+			schema.SpawnStructField("valueNullable", "Bool", false, false),
 			schema.SpawnStructField("representation", "MapRepresentation", false, false),
 		},
-		schema.StructRepresentation_Map{},
+		schema.SpawnStructRepresentationMapWithImplicits(nil, map[string]schema.ImplicitValue{
+			"valueNullable": schema.SpawnImplicitValueBool(false),
+		}),
 	))
 	ts.Accumulate(schema.SpawnUnion("MapRepresentation",
 		[]schema.TypeName{
@@ -161,10 +169,12 @@ func main() {
 	ts.Accumulate(schema.SpawnStruct("TypeList",
 		[]schema.StructField{
 			schema.SpawnStructField("valueType", "TypeNameOrInlineDefn", false, false),
-			schema.SpawnStructField("valueNullable", "Bool", false, false), // todo: wants to use the "implicit" feature, but not supported yet
+			schema.SpawnStructField("valueNullable", "Bool", false, false),
 			schema.SpawnStructField("representation", "ListRepresentation", false, false),
 		},
-		schema.StructRepresentation_Map{},
+		schema.SpawnStructRepresentationMapWithImplicits(nil, map[string]schema.ImplicitValue{
+			"valueNullable": schema.SpawnImplicitValueBool(false),
+		}),
 	))
 	ts.Accumulate(schema.SpawnUnion("ListRepresentation",
 		[]schema.TypeName{
@@ -262,10 +272,13 @@ func main() {
 	ts.Accumulate(schema.SpawnStruct("StructField",
 		[]schema.StructField{
 			schema.SpawnStructField("type", "TypeNameOrInlineDefn", false, false),
-			schema.SpawnStructField("optional", "Bool", false, false), // todo: wants to use the "implicit" feature, but not supported yet
-			schema.SpawnStructField("nullable", "Bool", false, false), // todo: wants to use the "implicit" feature, but not supported yet
+			schema.SpawnStructField("optional", "Bool", false, false),
+			schema.SpawnStructField("nullable", "Bool", false, false),
 		},
-		schema.StructRepresentation_Map{},
+		schema.SpawnStructRepresentationMapWithImplicits(nil, map[string]schema.ImplicitValue{
+			"optional": schema.SpawnImplicitValueBool(false),
+			"nullable": schema.SpawnImplicitValueBool(false),
+		}),
 	))
 	ts.Accumulate(schema.SpawnUnion("StructRepresentation",
 		[]schema.TypeName{
@@ -363,6 +376,23 @@ func main() {
 		},
 		schema.StructRepresentation_Map{},
 	))
+	ts.Accumulate(schema.SpawnStruct("TypeUnit",
+		[]schema.StructField{
+			schema.SpawnStructField("representation", "UnitRepresentation", false, false),
+		},
+		schema.StructRepresentation_Map{},
+	))
+	ts.Accumulate(schema.SpawnEnumWithRepresentation("UnitRepresentation",
+		[]string{"Null", "Emptymap"},
+		schema.SpawnEnumRepresentationString(map[string]string{
+			"Null":     "null",
+			"Emptymap": "emptymap",
+		}),
+	))
+	ts.Accumulate(schema.SpawnStruct("TypeAny",
+		[]schema.StructField{},
+		schema.StructRepresentation_Map{},
+	))
 	ts.Accumulate(schema.SpawnUnion("AnyScalar",
 		[]schema.TypeName{
 			"Bool",
@@ -1,4 +1,3 @@
 //go:generate go run gen.go
-//go:generate gofmt -w .
 
 package schemadmt
@@ -0,0 +1,995 @@
+package schemadmt
+
+// Code generated by go-ipld-prime gengo.  DO NOT EDIT.
+
+import (
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// AnyScalar matches the IPLD Schema type "AnyScalar".
+// AnyScalar has Union typekind, which means its data model behaviors are that of a map kind.
+type AnyScalar = *_AnyScalar
+type _AnyScalar struct {
+	tag uint
+	x1  _Bool
+	x2  _String
+	x3  _Bytes
+	x4  _Int
+	x5  _Float
+}
+type _AnyScalar__iface interface {
+	_AnyScalar__member()
+}
+
+func (_Bool) _AnyScalar__member()   {}
+func (_String) _AnyScalar__member() {}
+func (_Bytes) _AnyScalar__member()  {}
+func (_Int) _AnyScalar__member()    {}
+func (_Float) _AnyScalar__member()  {}
+func (n _AnyScalar) AsInterface() _AnyScalar__iface {
+	switch n.tag {
+	case 1:
+		return &n.x1
+	case 2:
+		return &n.x2
+	case 3:
+		return &n.x3
+	case 4:
+		return &n.x4
+	case 5:
+		return &n.x5
+	default:
+		panic("invalid union state; how did you create this object?")
+	}
+}
+
+// MemberBool returns the union's value if it's a Bool, and nil otherwise.
+func (n _AnyScalar) MemberBool() Bool {
+	if n.tag != 1 {
+		return nil
+	}
+	return &n.x1
+}
+
+// MemberString returns the union's value if it's a String, and nil otherwise.
+func (n _AnyScalar) MemberString() String {
+	if n.tag != 2 {
+		return nil
+	}
+	return &n.x2
+}
+
+// MemberBytes returns the union's value if it's a Bytes, and nil otherwise.
+func (n _AnyScalar) MemberBytes() Bytes {
+	if n.tag != 3 {
+		return nil
+	}
+	return &n.x3
+}
+
+// MemberInt returns the union's value if it's a Int, and nil otherwise.
+func (n _AnyScalar) MemberInt() Int {
+	if n.tag != 4 {
+		return nil
+	}
+	return &n.x4
+}
+
+// MemberFloat returns the union's value if it's a Float, and nil otherwise.
+func (n _AnyScalar) MemberFloat() Float {
+	if n.tag != 5 {
+		return nil
+	}
+	return &n.x5
+}
+
+type _AnyScalar__Maybe struct {
+	m schema.Maybe
+	v AnyScalar
+}
+type MaybeAnyScalar = *_AnyScalar__Maybe
+
+func (m MaybeAnyScalar) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeAnyScalar) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeAnyScalar) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeAnyScalar) AsNode() ipld.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return ipld.Absent
+	case schema.Maybe_Null:
+		return ipld.Null
+	case schema.Maybe_Value:
+		return m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybeAnyScalar) Must() AnyScalar {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return m.v
+}
+
+var (
+	memberName__AnyScalar_Bool   = _String{"Bool"}
+	memberName__AnyScalar_String = _String{"String"}
+	memberName__AnyScalar_Bytes  = _String{"Bytes"}
+	memberName__AnyScalar_Int    = _String{"Int"}
+	memberName__AnyScalar_Float  = _String{"Float"}
+)
+var _ ipld.Node = (AnyScalar)(&_AnyScalar{})
+var _ schema.TypedNode = (AnyScalar)(&_AnyScalar{})
+
+func (AnyScalar) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n AnyScalar) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	case "Bool":
+		if n.tag != 1 {
+			return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(key)}
+		}
+		return &n.x1, nil
+	case "String":
+		if n.tag != 2 {
+			return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(key)}
+		}
+		return &n.x2, nil
+	case "Bytes":
+		if n.tag != 3 {
+			return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(key)}
+		}
+		return &n.x3, nil
+	case "Int":
+		if n.tag != 4 {
+			return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(key)}
+		}
+		return &n.x4, nil
+	case "Float":
+		if n.tag != 5 {
+			return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(key)}
+		}
+		return &n.x5, nil
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n AnyScalar) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (AnyScalar) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.AnyScalar"}.LookupByIndex(0)
+}
+func (n AnyScalar) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n AnyScalar) MapIterator() ipld.MapIterator {
+	return &_AnyScalar__MapItr{n, false}
+}
+
+type _AnyScalar__MapItr struct {
+	n    AnyScalar
+	done bool
+}
+
+func (itr *_AnyScalar__MapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	if itr.done {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	switch itr.n.tag {
+	case 1:
+		k, v = &memberName__AnyScalar_Bool, &itr.n.x1
+	case 2:
+		k, v = &memberName__AnyScalar_String, &itr.n.x2
+	case 3:
+		k, v = &memberName__AnyScalar_Bytes, &itr.n.x3
+	case 4:
+		k, v = &memberName__AnyScalar_Int, &itr.n.x4
+	case 5:
+		k, v = &memberName__AnyScalar_Float, &itr.n.x5
+	default:
+		panic("unreachable")
+	}
+	itr.done = true
+	return
+}
+func (itr *_AnyScalar__MapItr) Done() bool {
+	return itr.done
+}
+
+func (AnyScalar) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (AnyScalar) Length() int64 {
+	return 1
+}
+func (AnyScalar) IsAbsent() bool {
+	return false
+}
+func (AnyScalar) IsNull() bool {
+	return false
+}
+func (AnyScalar) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.AnyScalar"}.AsBool()
+}
+func (AnyScalar) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.AnyScalar"}.AsInt()
+}
+func (AnyScalar) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.AnyScalar"}.AsFloat()
+}
+func (AnyScalar) AsString() (string, error) {
+	return mixins.Map{"schemadmt.AnyScalar"}.AsString()
+}
+func (AnyScalar) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.AnyScalar"}.AsBytes()
+}
+func (AnyScalar) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.AnyScalar"}.AsLink()
+}
+func (AnyScalar) Prototype() ipld.NodePrototype {
+	return _AnyScalar__Prototype{}
+}
+
+type _AnyScalar__Prototype struct{}
+
+func (_AnyScalar__Prototype) NewBuilder() ipld.NodeBuilder {
+	var nb _AnyScalar__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _AnyScalar__Builder struct {
+	_AnyScalar__Assembler
+}
+
+func (nb *_AnyScalar__Builder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_AnyScalar__Builder) Reset() {
+	var w _AnyScalar
+	var m schema.Maybe
+	*nb = _AnyScalar__Builder{_AnyScalar__Assembler{w: &w, m: &m}}
+}
+
+type _AnyScalar__Assembler struct {
+	w     *_AnyScalar
+	m     *schema.Maybe
+	state maState
+
+	cm  schema.Maybe
+	ca1 _Bool__Assembler
+
+	ca2 _String__Assembler
+
+	ca3 _Bytes__Assembler
+
+	ca4 _Int__Assembler
+
+	ca5 _Float__Assembler
+	ca  uint
+}
+
+func (na *_AnyScalar__Assembler) reset() {
+	na.state = maState_initial
+	switch na.ca {
+	case 0:
+		return
+	case 1:
+		na.ca1.reset()
+
+	case 2:
+		na.ca2.reset()
+
+	case 3:
+		na.ca3.reset()
+
+	case 4:
+		na.ca4.reset()
+
+	case 5:
+		na.ca5.reset()
+	default:
+		panic("unreachable")
+	}
+	na.ca = 0
+	na.cm = schema.Maybe_Absent
+}
+func (na *_AnyScalar__Assembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_AnyScalar{}
+	}
+	return na, nil
+}
+func (_AnyScalar__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.AnyScalar"}.BeginList(0)
+}
+func (na *_AnyScalar__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.AnyScalar"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_AnyScalar__Assembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.AnyScalar"}.AssignBool(false)
+}
+func (_AnyScalar__Assembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.AnyScalar"}.AssignInt(0)
+}
+func (_AnyScalar__Assembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.AnyScalar"}.AssignFloat(0)
+}
+func (_AnyScalar__Assembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.AnyScalar"}.AssignString("")
+}
+func (_AnyScalar__Assembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.AnyScalar"}.AssignBytes(nil)
+}
+func (_AnyScalar__Assembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.AnyScalar"}.AssignLink(nil)
+}
+func (na *_AnyScalar__Assembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_AnyScalar); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.AnyScalar", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_AnyScalar__Assembler) Prototype() ipld.NodePrototype {
+	return _AnyScalar__Prototype{}
+}
+func (ma *_AnyScalar__Assembler) valueFinishTidy() bool {
+	switch ma.cm {
+	case schema.Maybe_Value:
+		ma.state = maState_initial
+		return true
+	default:
+		return false
+	}
+}
+func (ma *_AnyScalar__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on for the moment, but we'll still be erroring shortly.
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	if ma.ca != 0 {
+		return nil, schema.ErrNotUnionStructure{TypeName: "schemadmt.AnyScalar", Detail: "cannot add another entry -- a union can only contain one thing!"}
+	}
+	switch k {
+	case "Bool":
+		ma.state = maState_midValue
+		ma.ca = 1
+		ma.w.tag = 1
+		ma.ca1.w = &ma.w.x1
+		ma.ca1.m = &ma.cm
+		return &ma.ca1, nil
+	case "String":
+		ma.state = maState_midValue
+		ma.ca = 2
+		ma.w.tag = 2
+		ma.ca2.w = &ma.w.x2
+		ma.ca2.m = &ma.cm
+		return &ma.ca2, nil
+	case "Bytes":
+		ma.state = maState_midValue
+		ma.ca = 3
+		ma.w.tag = 3
+		ma.ca3.w = &ma.w.x3
+		ma.ca3.m = &ma.cm
+		return &ma.ca3, nil
+	case "Int":
+		ma.state = maState_midValue
+		ma.ca = 4
+		ma.w.tag = 4
+		ma.ca4.w = &ma.w.x4
+		ma.ca4.m = &ma.cm
+		return &ma.ca4, nil
+	case "Float":
+		ma.state = maState_midValue
+		ma.ca = 5
+		ma.w.tag = 5
+		ma.ca5.w = &ma.w.x5
+		ma.ca5.m = &ma.cm
+		return &ma.ca5, nil
+	}
+	return nil, schema.ErrInvalidUnionDiscriminant{TypeName: "schemadmt.AnyScalar", Discriminant: k, Expected: []string{"Bool", "String", "Bytes", "Int", "Float"}}
+}
+func (ma *_AnyScalar__Assembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on for the moment, but we'll still be erroring shortly... or rather, the keyassembler will be.
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_AnyScalar__KeyAssembler)(ma)
+}
+func (ma *_AnyScalar__Assembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.ca {
+	case 1:
+		ma.ca1.w = &ma.w.x1
+		ma.ca1.m = &ma.cm
+		return &ma.ca1
+	case 2:
+		ma.ca2.w = &ma.w.x2
+		ma.ca2.m = &ma.cm
+		return &ma.ca2
+	case 3:
+		ma.ca3.w = &ma.w.x3
+		ma.ca3.m = &ma.cm
+		return &ma.ca3
+	case 4:
+		ma.ca4.w = &ma.w.x4
+		ma.ca4.m = &ma.cm
+		return &ma.ca4
+	case 5:
+		ma.ca5.w = &ma.w.x5
+		ma.ca5.m = &ma.cm
+		return &ma.ca5
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_AnyScalar__Assembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.ca == 0 {
+		return schema.ErrNotUnionStructure{TypeName: "schemadmt.AnyScalar", Detail: "a union must have exactly one entry (not none)!"}
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_AnyScalar__Assembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_AnyScalar__Assembler) ValuePrototype(k string) ipld.NodePrototype {
+	switch k {
+	case "Bool":
+		return _Bool__Prototype{}
+	case "String":
+		return _String__Prototype{}
+	case "Bytes":
+		return _Bytes__Prototype{}
+	case "Int":
+		return _Int__Prototype{}
+	case "Float":
+		return _Float__Prototype{}
+	default:
+		return nil
+	}
+}
+
+type _AnyScalar__KeyAssembler _AnyScalar__Assembler
+
+func (_AnyScalar__KeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.AnyScalar.KeyAssembler"}.BeginMap(0)
+}
+func (_AnyScalar__KeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.AnyScalar.KeyAssembler"}.BeginList(0)
+}
+func (na *_AnyScalar__KeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.AnyScalar.KeyAssembler"}.AssignNull()
+}
+func (_AnyScalar__KeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.AnyScalar.KeyAssembler"}.AssignBool(false)
+}
+func (_AnyScalar__KeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.AnyScalar.KeyAssembler"}.AssignInt(0)
+}
+func (_AnyScalar__KeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.AnyScalar.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_AnyScalar__KeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	if ka.ca != 0 {
+		return schema.ErrNotUnionStructure{TypeName: "schemadmt.AnyScalar", Detail: "cannot add another entry -- a union can only contain one thing!"}
+	}
+	switch k {
+	case "Bool":
+		ka.ca = 1
+		ka.w.tag = 1
+		ka.state = maState_expectValue
+		return nil
+	case "String":
+		ka.ca = 2
+		ka.w.tag = 2
+		ka.state = maState_expectValue
+		return nil
+	case "Bytes":
+		ka.ca = 3
+		ka.w.tag = 3
+		ka.state = maState_expectValue
+		return nil
+	case "Int":
+		ka.ca = 4
+		ka.w.tag = 4
+		ka.state = maState_expectValue
+		return nil
+	case "Float":
+		ka.ca = 5
+		ka.w.tag = 5
+		ka.state = maState_expectValue
+		return nil
+	}
+	return schema.ErrInvalidUnionDiscriminant{TypeName: "schemadmt.AnyScalar", Discriminant: k, Expected: []string{"Bool", "String", "Bytes", "Int", "Float"}}
+}
+func (_AnyScalar__KeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.AnyScalar.KeyAssembler"}.AssignBytes(nil)
+}
+func (_AnyScalar__KeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.AnyScalar.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_AnyScalar__KeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_AnyScalar__KeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (AnyScalar) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (n AnyScalar) Representation() ipld.Node {
+	return (*_AnyScalar__Repr)(n)
+}
+
+type _AnyScalar__Repr _AnyScalar
+
+var _ ipld.Node = &_AnyScalar__Repr{}
+
+func (n *_AnyScalar__Repr) Kind() ipld.Kind {
+	switch n.tag {
+	case 1:
+		return ipld.Kind_Bool
+	case 2:
+		return ipld.Kind_String
+	case 3:
+		return ipld.Kind_Bytes
+	case 4:
+		return ipld.Kind_Int
+	case 5:
+		return ipld.Kind_Float
+	default:
+		panic("unreachable")
+	}
+}
+func (n *_AnyScalar__Repr) LookupByString(key string) (ipld.Node, error) {
+	return nil, ipld.ErrWrongKind{TypeName: "schemadmt.AnyScalar.Repr", MethodName: "LookupByString", AppropriateKind: ipld.KindSet_JustMap, ActualKind: n.Kind()}
+}
+func (n *_AnyScalar__Repr) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	return nil, ipld.ErrWrongKind{TypeName: "schemadmt.AnyScalar.Repr", MethodName: "LookupByNode", AppropriateKind: ipld.KindSet_Recursive, ActualKind: n.Kind()}
+}
+func (n *_AnyScalar__Repr) LookupByIndex(idx int64) (ipld.Node, error) {
+	return nil, ipld.ErrWrongKind{TypeName: "schemadmt.AnyScalar.Repr", MethodName: "LookupByIndex", AppropriateKind: ipld.KindSet_JustList, ActualKind: n.Kind()}
+}
+func (n *_AnyScalar__Repr) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return nil, ipld.ErrWrongKind{TypeName: "schemadmt.AnyScalar.Repr", MethodName: "LookupBySegment", AppropriateKind: ipld.KindSet_Recursive, ActualKind: n.Kind()}
+}
+func (n *_AnyScalar__Repr) MapIterator() ipld.MapIterator {
+	return nil
+}
+func (n *_AnyScalar__Repr) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (n *_AnyScalar__Repr) Length() int64 {
+	return -1
+}
+func (n *_AnyScalar__Repr) IsAbsent() bool {
+	return false
+}
+func (n *_AnyScalar__Repr) IsNull() bool {
+	return false
+}
+func (n *_AnyScalar__Repr) AsBool() (bool, error) {
+	switch n.tag {
+	case 1:
+		return n.x1.Representation().AsBool()
+	default:
+		return false, ipld.ErrWrongKind{TypeName: "schemadmt.AnyScalar.Repr", MethodName: "AsBool", AppropriateKind: ipld.KindSet_JustBool, ActualKind: n.Kind()}
+	}
+}
+func (n *_AnyScalar__Repr) AsInt() (int64, error) {
+	switch n.tag {
+	case 4:
+		return n.x4.Representation().AsInt()
+	default:
+		return 0, ipld.ErrWrongKind{TypeName: "schemadmt.AnyScalar.Repr", MethodName: "AsInt", AppropriateKind: ipld.KindSet_JustInt, ActualKind: n.Kind()}
+	}
+}
+func (n *_AnyScalar__Repr) AsFloat() (float64, error) {
+	switch n.tag {
+	case 5:
+		return n.x5.Representation().AsFloat()
+	default:
+		return 0, ipld.ErrWrongKind{TypeName: "schemadmt.AnyScalar.Repr", MethodName: "AsFloat", AppropriateKind: ipld.KindSet_JustFloat, ActualKind: n.Kind()}
+	}
+}
+func (n *_AnyScalar__Repr) AsString() (string, error) {
+	switch n.tag {
+	case 2:
+		return n.x2.Representation().AsString()
+	default:
+		return "", ipld.ErrWrongKind{TypeName: "schemadmt.AnyScalar.Repr", MethodName: "AsString", AppropriateKind: ipld.KindSet_JustString, ActualKind: n.Kind()}
+	}
+}
+func (n *_AnyScalar__Repr) AsBytes() ([]byte, error) {
+	switch n.tag {
+	case 3:
+		return n.x3.Representation().AsBytes()
+	default:
+		return nil, ipld.ErrWrongKind{TypeName: "schemadmt.AnyScalar.Repr", MethodName: "AsBytes", AppropriateKind: ipld.KindSet_JustBytes, ActualKind: n.Kind()}
+	}
+}
+func (n *_AnyScalar__Repr) AsLink() (ipld.Link, error) {
+	return nil, ipld.ErrWrongKind{TypeName: "schemadmt.AnyScalar.Repr", MethodName: "AsLink", AppropriateKind: ipld.KindSet_JustLink, ActualKind: n.Kind()}
+}
+func (_AnyScalar__Repr) Prototype() ipld.NodePrototype {
+	return _AnyScalar__ReprPrototype{}
+}
+
+type _AnyScalar__ReprPrototype struct{}
+
+func (_AnyScalar__ReprPrototype) NewBuilder() ipld.NodeBuilder {
+	var nb _AnyScalar__ReprBuilder
+	nb.Reset()
+	return &nb
+}
+
+type _AnyScalar__ReprBuilder struct {
+	_AnyScalar__ReprAssembler
+}
+
+func (nb *_AnyScalar__ReprBuilder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_AnyScalar__ReprBuilder) Reset() {
+	var w _AnyScalar
+	var m schema.Maybe
+	*nb = _AnyScalar__ReprBuilder{_AnyScalar__ReprAssembler{w: &w, m: &m}}
+}
+
+type _AnyScalar__ReprAssembler struct {
+	w   *_AnyScalar
+	m   *schema.Maybe
+	ca1 _Bool__ReprAssembler
+	ca2 _String__ReprAssembler
+	ca3 _Bytes__ReprAssembler
+	ca4 _Int__ReprAssembler
+	ca5 _Float__ReprAssembler
+	ca  uint
+}
+
+func (na *_AnyScalar__ReprAssembler) reset() {
+	switch na.ca {
+	case 0:
+		return
+	case 1:
+		na.ca1.reset()
+	case 2:
+		na.ca2.reset()
+	case 3:
+		na.ca3.reset()
+	case 4:
+		na.ca4.reset()
+	case 5:
+		na.ca5.reset()
+	default:
+		panic("unreachable")
+	}
+	na.ca = 0
+}
+func (na *_AnyScalar__ReprAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign into assembler that's already working on a larger structure!")
+	}
+	return nil, schema.ErrNotUnionStructure{TypeName: "schemadmt.AnyScalar.Repr", Detail: "BeginMap called but is not valid for any of the kinds that are valid members of this union"}
+}
+func (na *_AnyScalar__ReprAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign into assembler that's already working on a larger structure!")
+	}
+	return nil, schema.ErrNotUnionStructure{TypeName: "schemadmt.AnyScalar.Repr", Detail: "BeginList called but is not valid for any of the kinds that are valid members of this union"}
+}
+func (na *_AnyScalar__ReprAssembler) AssignNull() error {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign into assembler that's already working on a larger structure!")
+	}
+	return schema.ErrNotUnionStructure{TypeName: "schemadmt.AnyScalar.Repr", Detail: "AssignNull called but is not valid for any of the kinds that are valid members of this union"}
+}
+func (na *_AnyScalar__ReprAssembler) AssignBool(v bool) error {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign into assembler that's already working on a larger structure!")
+	}
+	if na.w == nil {
+		na.w = &_AnyScalar{}
+	}
+	na.ca = 1
+	na.w.tag = 1
+	na.ca1.w = &na.w.x1
+	na.ca1.m = na.m
+	return na.ca1.AssignBool(v)
+}
+func (na *_AnyScalar__ReprAssembler) AssignInt(v int64) error {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign into assembler that's already working on a larger structure!")
+	}
+	if na.w == nil {
+		na.w = &_AnyScalar{}
+	}
+	na.ca = 4
+	na.w.tag = 4
+	na.ca4.w = &na.w.x4
+	na.ca4.m = na.m
+	return na.ca4.AssignInt(v)
+}
+func (na *_AnyScalar__ReprAssembler) AssignFloat(v float64) error {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign into assembler that's already working on a larger structure!")
+	}
+	if na.w == nil {
+		na.w = &_AnyScalar{}
+	}
+	na.ca = 5
+	na.w.tag = 5
+	na.ca5.w = &na.w.x5
+	na.ca5.m = na.m
+	return na.ca5.AssignFloat(v)
+}
+func (na *_AnyScalar__ReprAssembler) AssignString(v string) error {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign into assembler that's already working on a larger structure!")
+	}
+	if na.w == nil {
+		na.w = &_AnyScalar{}
+	}
+	na.ca = 2
+	na.w.tag = 2
+	na.ca2.w = &na.w.x2
+	na.ca2.m = na.m
+	return na.ca2.AssignString(v)
+}
+func (na *_AnyScalar__ReprAssembler) AssignBytes(v []byte) error {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign into assembler that's already working on a larger structure!")
+	}
+	if na.w == nil {
+		na.w = &_AnyScalar{}
+	}
+	na.ca = 3
+	na.w.tag = 3
+	na.ca3.w = &na.w.x3
+	na.ca3.m = na.m
+	return na.ca3.AssignBytes(v)
+}
+func (na *_AnyScalar__ReprAssembler) AssignLink(v ipld.Link) error {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign into assembler that's already working on a larger structure!")
+	}
+	return schema.ErrNotUnionStructure{TypeName: "schemadmt.AnyScalar.Repr", Detail: "AssignLink called but is not valid for any of the kinds that are valid members of this union"}
+}
+func (na *_AnyScalar__ReprAssembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	v2, ok := v.(*_AnyScalar)
+	if v3, ok3 := v.(*_AnyScalar__Repr); ok3 {
+		v2, ok = (*_AnyScalar)(v3), true
+	}
+	if ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	switch v.Kind() {
+	case ipld.Kind_Bool:
+		v2, _ := v.AsBool()
+		return na.AssignBool(v2)
+	case ipld.Kind_Int:
+		v2, _ := v.AsInt()
+		return na.AssignInt(v2)
+	case ipld.Kind_Float:
+		v2, _ := v.AsFloat()
+		return na.AssignFloat(v2)
+	case ipld.Kind_String:
+		v2, _ := v.AsString()
+		return na.AssignString(v2)
+	case ipld.Kind_Bytes:
+		v2, _ := v.AsBytes()
+		return na.AssignBytes(v2)
+	case ipld.Kind_Map:
+		na, err := na.BeginMap(v.Length())
+		if err != nil {
+			return err
+		}
+		itr := v.MapIterator()
+		for !itr.Done() {
+			k, v, err := itr.Next()
+			if err != nil {
+				return err
+			}
+			if err := na.AssembleKey().AssignNode(k); err != nil {
+				return err
+			}
+			if err := na.AssembleValue().AssignNode(v); err != nil {
+				return err
+			}
+		}
+		return na.Finish()
+	case ipld.Kind_List:
+		na, err := na.BeginList(v.Length())
+		if err != nil {
+			return err
+		}
+		itr := v.ListIterator()
+		for !itr.Done() {
+			_, v, err := itr.Next()
+			if err != nil {
+				return err
+			}
+			if err := na.AssembleValue().AssignNode(v); err != nil {
+				return err
+			}
+		}
+		return na.Finish()
+	case ipld.Kind_Link:
+		v2, _ := v.AsLink()
+		return na.AssignLink(v2)
+	default:
+		panic("unreachable")
+	}
+}
+func (na *_AnyScalar__ReprAssembler) Prototype() ipld.NodePrototype {
+	return _AnyScalar__ReprPrototype{}
+}
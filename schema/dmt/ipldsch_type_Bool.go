@@ -0,0 +1,219 @@
+package schemadmt
+
+// Code generated by go-ipld-prime gengo.  DO NOT EDIT.
+
+import (
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// Bool matches the IPLD Schema type "Bool".  It has bool kind.
+type Bool = *_Bool
+type _Bool struct{ x bool }
+
+// Bool returns the value as a native bool.
+func (n Bool) Bool() bool {
+	return n.x
+}
+func (_Bool__Prototype) FromBool(v bool) (Bool, error) {
+	n := _Bool{v}
+	return &n, nil
+}
+
+type _Bool__Maybe struct {
+	m schema.Maybe
+	v _Bool
+}
+type MaybeBool = *_Bool__Maybe
+
+func (m MaybeBool) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeBool) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeBool) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeBool) AsNode() ipld.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return ipld.Absent
+	case schema.Maybe_Null:
+		return ipld.Null
+	case schema.Maybe_Value:
+		return &m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybeBool) Must() Bool {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return &m.v
+}
+
+var _ ipld.Node = (Bool)(&_Bool{})
+var _ schema.TypedNode = (Bool)(&_Bool{})
+
+func (Bool) Kind() ipld.Kind {
+	return ipld.Kind_Bool
+}
+func (Bool) LookupByString(string) (ipld.Node, error) {
+	return mixins.Bool{"schemadmt.Bool"}.LookupByString("")
+}
+func (Bool) LookupByNode(ipld.Node) (ipld.Node, error) {
+	return mixins.Bool{"schemadmt.Bool"}.LookupByNode(nil)
+}
+func (Bool) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Bool{"schemadmt.Bool"}.LookupByIndex(0)
+}
+func (Bool) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return mixins.Bool{"schemadmt.Bool"}.LookupBySegment(seg)
+}
+func (Bool) MapIterator() ipld.MapIterator {
+	return nil
+}
+func (Bool) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (Bool) Length() int64 {
+	return -1
+}
+func (Bool) IsAbsent() bool {
+	return false
+}
+func (Bool) IsNull() bool {
+	return false
+}
+func (n Bool) AsBool() (bool, error) {
+	return n.x, nil
+}
+func (Bool) AsInt() (int64, error) {
+	return mixins.Bool{"schemadmt.Bool"}.AsInt()
+}
+func (Bool) AsFloat() (float64, error) {
+	return mixins.Bool{"schemadmt.Bool"}.AsFloat()
+}
+func (Bool) AsString() (string, error) {
+	return mixins.Bool{"schemadmt.Bool"}.AsString()
+}
+func (Bool) AsBytes() ([]byte, error) {
+	return mixins.Bool{"schemadmt.Bool"}.AsBytes()
+}
+func (Bool) AsLink() (ipld.Link, error) {
+	return mixins.Bool{"schemadmt.Bool"}.AsLink()
+}
+func (Bool) Prototype() ipld.NodePrototype {
+	return _Bool__Prototype{}
+}
+
+type _Bool__Prototype struct{}
+
+func (_Bool__Prototype) NewBuilder() ipld.NodeBuilder {
+	var nb _Bool__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _Bool__Builder struct {
+	_Bool__Assembler
+}
+
+func (nb *_Bool__Builder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_Bool__Builder) Reset() {
+	var w _Bool
+	var m schema.Maybe
+	*nb = _Bool__Builder{_Bool__Assembler{w: &w, m: &m}}
+}
+
+type _Bool__Assembler struct {
+	w *_Bool
+	m *schema.Maybe
+}
+
+func (na *_Bool__Assembler) reset() {}
+func (_Bool__Assembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.BoolAssembler{"schemadmt.Bool"}.BeginMap(0)
+}
+func (_Bool__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.BoolAssembler{"schemadmt.Bool"}.BeginList(0)
+}
+func (na *_Bool__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.BoolAssembler{"schemadmt.Bool"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	}
+	panic("unreachable")
+}
+func (na *_Bool__Assembler) AssignBool(v bool) error {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	}
+	na.w.x = v
+	*na.m = schema.Maybe_Value
+	return nil
+}
+func (_Bool__Assembler) AssignInt(int64) error {
+	return mixins.BoolAssembler{"schemadmt.Bool"}.AssignInt(0)
+}
+func (_Bool__Assembler) AssignFloat(float64) error {
+	return mixins.BoolAssembler{"schemadmt.Bool"}.AssignFloat(0)
+}
+func (_Bool__Assembler) AssignString(string) error {
+	return mixins.BoolAssembler{"schemadmt.Bool"}.AssignString("")
+}
+func (_Bool__Assembler) AssignBytes([]byte) error {
+	return mixins.BoolAssembler{"schemadmt.Bool"}.AssignBytes(nil)
+}
+func (_Bool__Assembler) AssignLink(ipld.Link) error {
+	return mixins.BoolAssembler{"schemadmt.Bool"}.AssignLink(nil)
+}
+func (na *_Bool__Assembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_Bool); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v2, err := v.AsBool(); err != nil {
+		return err
+	} else {
+		return na.AssignBool(v2)
+	}
+}
+func (_Bool__Assembler) Prototype() ipld.NodePrototype {
+	return _Bool__Prototype{}
+}
+func (Bool) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (n Bool) Representation() ipld.Node {
+	return (*_Bool__Repr)(n)
+}
+
+type _Bool__Repr = _Bool
+
+var _ ipld.Node = &_Bool__Repr{}
+
+type _Bool__ReprPrototype = _Bool__Prototype
+type _Bool__ReprAssembler = _Bool__Assembler
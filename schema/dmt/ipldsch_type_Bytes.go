@@ -0,0 +1,227 @@
+package schemadmt
+
+// Code generated by go-ipld-prime gengo.  DO NOT EDIT.
+
+import (
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// Bytes matches the IPLD Schema type "Bytes".  It has bytes kind.
+type Bytes = *_Bytes
+type _Bytes struct{ x []byte }
+
+// Bytes returns a copy of the value as a native []byte.
+func (n Bytes) Bytes() []byte {
+	return append([]byte(nil), n.x...)
+}
+
+// BytesUnsafe returns the value as a native []byte, without copying it.
+// The slice is shared with the node, so it must not be modified.
+func (n Bytes) BytesUnsafe() []byte {
+	return n.x
+}
+
+// FromBytes returns a node holding a copy of the given bytes.
+func (_Bytes__Prototype) FromBytes(v []byte) (Bytes, error) {
+	n := _Bytes{append([]byte(nil), v...)}
+	return &n, nil
+}
+
+type _Bytes__Maybe struct {
+	m schema.Maybe
+	v _Bytes
+}
+type MaybeBytes = *_Bytes__Maybe
+
+func (m MaybeBytes) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeBytes) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeBytes) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeBytes) AsNode() ipld.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return ipld.Absent
+	case schema.Maybe_Null:
+		return ipld.Null
+	case schema.Maybe_Value:
+		return &m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybeBytes) Must() Bytes {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return &m.v
+}
+
+var _ ipld.Node = (Bytes)(&_Bytes{})
+var _ schema.TypedNode = (Bytes)(&_Bytes{})
+
+func (Bytes) Kind() ipld.Kind {
+	return ipld.Kind_Bytes
+}
+func (Bytes) LookupByString(string) (ipld.Node, error) {
+	return mixins.Bytes{"schemadmt.Bytes"}.LookupByString("")
+}
+func (Bytes) LookupByNode(ipld.Node) (ipld.Node, error) {
+	return mixins.Bytes{"schemadmt.Bytes"}.LookupByNode(nil)
+}
+func (Bytes) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Bytes{"schemadmt.Bytes"}.LookupByIndex(0)
+}
+func (Bytes) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return mixins.Bytes{"schemadmt.Bytes"}.LookupBySegment(seg)
+}
+func (Bytes) MapIterator() ipld.MapIterator {
+	return nil
+}
+func (Bytes) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (Bytes) Length() int64 {
+	return -1
+}
+func (Bytes) IsAbsent() bool {
+	return false
+}
+func (Bytes) IsNull() bool {
+	return false
+}
+func (Bytes) AsBool() (bool, error) {
+	return mixins.Bytes{"schemadmt.Bytes"}.AsBool()
+}
+func (Bytes) AsInt() (int64, error) {
+	return mixins.Bytes{"schemadmt.Bytes"}.AsInt()
+}
+func (Bytes) AsFloat() (float64, error) {
+	return mixins.Bytes{"schemadmt.Bytes"}.AsFloat()
+}
+func (Bytes) AsString() (string, error) {
+	return mixins.Bytes{"schemadmt.Bytes"}.AsString()
+}
+func (n Bytes) AsBytes() ([]byte, error) {
+	return n.x, nil
+}
+func (Bytes) AsLink() (ipld.Link, error) {
+	return mixins.Bytes{"schemadmt.Bytes"}.AsLink()
+}
+func (Bytes) Prototype() ipld.NodePrototype {
+	return _Bytes__Prototype{}
+}
+
+type _Bytes__Prototype struct{}
+
+func (_Bytes__Prototype) NewBuilder() ipld.NodeBuilder {
+	var nb _Bytes__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _Bytes__Builder struct {
+	_Bytes__Assembler
+}
+
+func (nb *_Bytes__Builder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_Bytes__Builder) Reset() {
+	var w _Bytes
+	var m schema.Maybe
+	*nb = _Bytes__Builder{_Bytes__Assembler{w: &w, m: &m}}
+}
+
+type _Bytes__Assembler struct {
+	w *_Bytes
+	m *schema.Maybe
+}
+
+func (na *_Bytes__Assembler) reset() {}
+func (_Bytes__Assembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.BytesAssembler{"schemadmt.Bytes"}.BeginMap(0)
+}
+func (_Bytes__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.BytesAssembler{"schemadmt.Bytes"}.BeginList(0)
+}
+func (na *_Bytes__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.BytesAssembler{"schemadmt.Bytes"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	}
+	panic("unreachable")
+}
+func (_Bytes__Assembler) AssignBool(bool) error {
+	return mixins.BytesAssembler{"schemadmt.Bytes"}.AssignBool(false)
+}
+func (_Bytes__Assembler) AssignInt(int64) error {
+	return mixins.BytesAssembler{"schemadmt.Bytes"}.AssignInt(0)
+}
+func (_Bytes__Assembler) AssignFloat(float64) error {
+	return mixins.BytesAssembler{"schemadmt.Bytes"}.AssignFloat(0)
+}
+func (_Bytes__Assembler) AssignString(string) error {
+	return mixins.BytesAssembler{"schemadmt.Bytes"}.AssignString("")
+}
+func (na *_Bytes__Assembler) AssignBytes(v []byte) error {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	}
+	na.w.x = append([]byte(nil), v...)
+	*na.m = schema.Maybe_Value
+	return nil
+}
+func (_Bytes__Assembler) AssignLink(ipld.Link) error {
+	return mixins.BytesAssembler{"schemadmt.Bytes"}.AssignLink(nil)
+}
+func (na *_Bytes__Assembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_Bytes); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v2, err := v.AsBytes(); err != nil {
+		return err
+	} else {
+		return na.AssignBytes(v2)
+	}
+}
+func (_Bytes__Assembler) Prototype() ipld.NodePrototype {
+	return _Bytes__Prototype{}
+}
+func (Bytes) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (n Bytes) Representation() ipld.Node {
+	return (*_Bytes__Repr)(n)
+}
+
+type _Bytes__Repr = _Bytes
+
+var _ ipld.Node = &_Bytes__Repr{}
+
+type _Bytes__ReprPrototype = _Bytes__Prototype
+type _Bytes__ReprAssembler = _Bytes__Assembler
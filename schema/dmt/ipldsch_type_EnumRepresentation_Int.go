@@ -0,0 +1,794 @@
+package schemadmt
+
+// Code generated by go-ipld-prime gengo.  DO NOT EDIT.
+
+import (
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// EnumRepresentation_Int matches the IPLD Schema type "EnumRepresentation_Int".  It has map kind.
+type EnumRepresentation_Int = *_EnumRepresentation_Int
+type _EnumRepresentation_Int struct {
+	m map[_EnumValue]*_Int
+	t []_EnumRepresentation_Int__entry
+}
+type _EnumRepresentation_Int__entry struct {
+	k _EnumValue
+	v _Int
+}
+
+func (n *_EnumRepresentation_Int) Lookup(k EnumValue) Int {
+	v, exists := n.m[*k]
+	if !exists {
+		return nil
+	}
+	return v
+}
+func (n *_EnumRepresentation_Int) LookupMaybe(k EnumValue) MaybeInt {
+	v, exists := n.m[*k]
+	if !exists {
+		return &_EnumRepresentation_Int__valueAbsent
+	}
+	return &_Int__Maybe{
+		m: schema.Maybe_Value,
+		v: *v,
+	}
+}
+
+var _EnumRepresentation_Int__valueAbsent = _Int__Maybe{m: schema.Maybe_Absent}
+
+func (n EnumRepresentation_Int) Iterator() *EnumRepresentation_Int__Itr {
+	return &EnumRepresentation_Int__Itr{n, 0}
+}
+
+type EnumRepresentation_Int__Itr struct {
+	n   EnumRepresentation_Int
+	idx int
+}
+
+func (itr *EnumRepresentation_Int__Itr) Next() (k EnumValue, v Int) {
+	if itr.idx >= len(itr.n.t) {
+		return nil, nil
+	}
+	x := &itr.n.t[itr.idx]
+	k = &x.k
+	v = &x.v
+	itr.idx++
+	return
+}
+func (itr *EnumRepresentation_Int__Itr) Done() bool {
+	return itr.idx >= len(itr.n.t)
+}
+
+type _EnumRepresentation_Int__Maybe struct {
+	m schema.Maybe
+	v _EnumRepresentation_Int
+}
+type MaybeEnumRepresentation_Int = *_EnumRepresentation_Int__Maybe
+
+func (m MaybeEnumRepresentation_Int) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeEnumRepresentation_Int) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeEnumRepresentation_Int) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeEnumRepresentation_Int) AsNode() ipld.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return ipld.Absent
+	case schema.Maybe_Null:
+		return ipld.Null
+	case schema.Maybe_Value:
+		return &m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybeEnumRepresentation_Int) Must() EnumRepresentation_Int {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return &m.v
+}
+
+var _ ipld.Node = (EnumRepresentation_Int)(&_EnumRepresentation_Int{})
+var _ schema.TypedNode = (EnumRepresentation_Int)(&_EnumRepresentation_Int{})
+
+func (EnumRepresentation_Int) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n EnumRepresentation_Int) LookupByString(k string) (ipld.Node, error) {
+	var k2 _EnumValue
+	if err := (_EnumValue__Prototype{}).fromString(&k2, k); err != nil {
+		return nil, err
+	}
+	v, exists := n.m[k2]
+	if !exists {
+		return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(k)}
+	}
+	return v, nil
+}
+func (n EnumRepresentation_Int) LookupByNode(k ipld.Node) (ipld.Node, error) {
+	k2, ok := k.(EnumValue)
+	if !ok {
+		ks, err := k.AsString()
+		if err != nil {
+			return nil, err
+		}
+		return n.LookupByString(ks)
+	}
+	v, exists := n.m[*k2]
+	if !exists {
+		return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(k2.String())}
+	}
+	return v, nil
+}
+func (EnumRepresentation_Int) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.EnumRepresentation_Int"}.LookupByIndex(0)
+}
+func (n EnumRepresentation_Int) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n EnumRepresentation_Int) MapIterator() ipld.MapIterator {
+	return &_EnumRepresentation_Int__MapItr{n, 0}
+}
+
+type _EnumRepresentation_Int__MapItr struct {
+	n   EnumRepresentation_Int
+	idx int
+}
+
+func (itr *_EnumRepresentation_Int__MapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	if itr.idx >= len(itr.n.t) {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	x := &itr.n.t[itr.idx]
+	k = &x.k
+	v = &x.v
+	itr.idx++
+	return
+}
+func (itr *_EnumRepresentation_Int__MapItr) Done() bool {
+	return itr.idx >= len(itr.n.t)
+}
+
+func (EnumRepresentation_Int) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (n EnumRepresentation_Int) Length() int64 {
+	return int64(len(n.t))
+}
+func (EnumRepresentation_Int) IsAbsent() bool {
+	return false
+}
+func (EnumRepresentation_Int) IsNull() bool {
+	return false
+}
+func (EnumRepresentation_Int) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.EnumRepresentation_Int"}.AsBool()
+}
+func (EnumRepresentation_Int) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.EnumRepresentation_Int"}.AsInt()
+}
+func (EnumRepresentation_Int) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.EnumRepresentation_Int"}.AsFloat()
+}
+func (EnumRepresentation_Int) AsString() (string, error) {
+	return mixins.Map{"schemadmt.EnumRepresentation_Int"}.AsString()
+}
+func (EnumRepresentation_Int) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.EnumRepresentation_Int"}.AsBytes()
+}
+func (EnumRepresentation_Int) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.EnumRepresentation_Int"}.AsLink()
+}
+func (EnumRepresentation_Int) Prototype() ipld.NodePrototype {
+	return _EnumRepresentation_Int__Prototype{}
+}
+
+type _EnumRepresentation_Int__Prototype struct{}
+
+func (_EnumRepresentation_Int__Prototype) NewBuilder() ipld.NodeBuilder {
+	var nb _EnumRepresentation_Int__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _EnumRepresentation_Int__Builder struct {
+	_EnumRepresentation_Int__Assembler
+}
+
+func (nb *_EnumRepresentation_Int__Builder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_EnumRepresentation_Int__Builder) Reset() {
+	var w _EnumRepresentation_Int
+	var m schema.Maybe
+	*nb = _EnumRepresentation_Int__Builder{_EnumRepresentation_Int__Assembler{w: &w, m: &m}}
+}
+
+type _EnumRepresentation_Int__Assembler struct {
+	w     *_EnumRepresentation_Int
+	m     *schema.Maybe
+	state maState
+
+	cm schema.Maybe
+	ka _EnumValue__Assembler
+	va _Int__Assembler
+}
+
+func (na *_EnumRepresentation_Int__Assembler) reset() {
+	na.state = maState_initial
+	na.ka.reset()
+	na.va.reset()
+}
+func (na *_EnumRepresentation_Int__Assembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if sizeHint < 0 {
+		sizeHint = 0
+	}
+	na.w.m = make(map[_EnumValue]*_Int, sizeHint)
+	na.w.t = make([]_EnumRepresentation_Int__entry, 0, sizeHint)
+	return na, nil
+}
+func (_EnumRepresentation_Int__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.EnumRepresentation_Int"}.BeginList(0)
+}
+func (na *_EnumRepresentation_Int__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.EnumRepresentation_Int"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_EnumRepresentation_Int__Assembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.EnumRepresentation_Int"}.AssignBool(false)
+}
+func (_EnumRepresentation_Int__Assembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.EnumRepresentation_Int"}.AssignInt(0)
+}
+func (_EnumRepresentation_Int__Assembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.EnumRepresentation_Int"}.AssignFloat(0)
+}
+func (_EnumRepresentation_Int__Assembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.EnumRepresentation_Int"}.AssignString("")
+}
+func (_EnumRepresentation_Int__Assembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.EnumRepresentation_Int"}.AssignBytes(nil)
+}
+func (_EnumRepresentation_Int__Assembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.EnumRepresentation_Int"}.AssignLink(nil)
+}
+func (na *_EnumRepresentation_Int__Assembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	v2, ok := v.(*_EnumRepresentation_Int)
+	if ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.EnumRepresentation_Int", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_EnumRepresentation_Int__Assembler) Prototype() ipld.NodePrototype {
+	return _EnumRepresentation_Int__Prototype{}
+}
+func (ma *_EnumRepresentation_Int__Assembler) keyFinishTidy() bool {
+	switch ma.cm {
+	case schema.Maybe_Value:
+		ma.ka.w = nil
+		tz := &ma.w.t[len(ma.w.t)-1]
+		ma.cm = schema.Maybe_Absent
+		ma.state = maState_expectValue
+		ma.w.m[tz.k] = &tz.v
+		ma.va.w = &tz.v
+		ma.va.m = &ma.cm
+		ma.ka.reset()
+		return true
+	default:
+		return false
+	}
+}
+func (ma *_EnumRepresentation_Int__Assembler) valueFinishTidy() bool {
+	switch ma.cm {
+	case schema.Maybe_Value:
+		ma.va.w = nil
+		ma.cm = schema.Maybe_Absent
+		ma.state = maState_initial
+		ma.va.reset()
+		return true
+	default:
+		return false
+	}
+}
+func (ma *_EnumRepresentation_Int__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+
+	var k2 _EnumValue
+	if err := (_EnumValue__Prototype{}).fromString(&k2, k); err != nil {
+		return nil, err
+	}
+	if _, exists := ma.w.m[k2]; exists {
+		return nil, ipld.ErrRepeatedMapKey{Key: &k2}
+	}
+	ma.w.t = append(ma.w.t, _EnumRepresentation_Int__entry{k: k2})
+	tz := &ma.w.t[len(ma.w.t)-1]
+	ma.state = maState_midValue
+
+	ma.w.m[k2] = &tz.v
+	ma.va.w = &tz.v
+	ma.va.m = &ma.cm
+	return &ma.va, nil
+}
+func (ma *_EnumRepresentation_Int__Assembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.w.t = append(ma.w.t, _EnumRepresentation_Int__entry{})
+	ma.state = maState_midKey
+	ma.ka.m = &ma.cm
+	ma.ka.w = &ma.w.t[len(ma.w.t)-1].k
+	return &ma.ka
+}
+func (ma *_EnumRepresentation_Int__Assembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		if !ma.keyFinishTidy() {
+			panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+		} // if tidy success: carry on
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	return &ma.va
+}
+func (ma *_EnumRepresentation_Int__Assembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_EnumRepresentation_Int__Assembler) KeyPrototype() ipld.NodePrototype {
+	return _EnumValue__Prototype{}
+}
+func (ma *_EnumRepresentation_Int__Assembler) ValuePrototype(_ string) ipld.NodePrototype {
+	return _Int__Prototype{}
+}
+func (EnumRepresentation_Int) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (n EnumRepresentation_Int) Representation() ipld.Node {
+	return (*_EnumRepresentation_Int__Repr)(n)
+}
+
+type _EnumRepresentation_Int__Repr _EnumRepresentation_Int
+
+var _ ipld.Node = &_EnumRepresentation_Int__Repr{}
+
+func (_EnumRepresentation_Int__Repr) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (nr *_EnumRepresentation_Int__Repr) LookupByString(k string) (ipld.Node, error) {
+	var k2 _EnumValue
+	if err := (_EnumValue__ReprPrototype{}).fromString(&k2, k); err != nil {
+		return nil, err
+	}
+	v, err := (EnumRepresentation_Int)(nr).LookupByNode(&k2)
+	if err != nil || v == ipld.Null {
+		return v, err
+	}
+	return v.(Int).Representation(), nil
+}
+func (nr *_EnumRepresentation_Int__Repr) LookupByNode(k ipld.Node) (ipld.Node, error) {
+	if _, ok := k.(EnumValue); !ok {
+		if ks, err := k.AsString(); err == nil {
+			return nr.LookupByString(ks)
+		}
+	}
+	v, err := (EnumRepresentation_Int)(nr).LookupByNode(k)
+	if err != nil || v == ipld.Null {
+		return v, err
+	}
+	return v.(Int).Representation(), nil
+}
+func (_EnumRepresentation_Int__Repr) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.EnumRepresentation_Int.Repr"}.LookupByIndex(0)
+}
+func (n _EnumRepresentation_Int__Repr) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (nr *_EnumRepresentation_Int__Repr) MapIterator() ipld.MapIterator {
+	return &_EnumRepresentation_Int__ReprMapItr{(EnumRepresentation_Int)(nr), 0}
+}
+
+type _EnumRepresentation_Int__ReprMapItr _EnumRepresentation_Int__MapItr
+
+func (itr *_EnumRepresentation_Int__ReprMapItr) Next() (k ipld.Node, v ipld.Node, err error) {
+	k, v, err = (*_EnumRepresentation_Int__MapItr)(itr).Next()
+	if err != nil {
+		return
+	}
+	k = k.(EnumValue).Representation()
+	if v == ipld.Null {
+		return
+	}
+	return k, v.(Int).Representation(), nil
+}
+func (itr *_EnumRepresentation_Int__ReprMapItr) Done() bool {
+	return (*_EnumRepresentation_Int__MapItr)(itr).Done()
+}
+
+func (_EnumRepresentation_Int__Repr) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (rn *_EnumRepresentation_Int__Repr) Length() int64 {
+	return int64(len(rn.t))
+}
+func (_EnumRepresentation_Int__Repr) IsAbsent() bool {
+	return false
+}
+func (_EnumRepresentation_Int__Repr) IsNull() bool {
+	return false
+}
+func (_EnumRepresentation_Int__Repr) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.EnumRepresentation_Int.Repr"}.AsBool()
+}
+func (_EnumRepresentation_Int__Repr) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.EnumRepresentation_Int.Repr"}.AsInt()
+}
+func (_EnumRepresentation_Int__Repr) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.EnumRepresentation_Int.Repr"}.AsFloat()
+}
+func (_EnumRepresentation_Int__Repr) AsString() (string, error) {
+	return mixins.Map{"schemadmt.EnumRepresentation_Int.Repr"}.AsString()
+}
+func (_EnumRepresentation_Int__Repr) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.EnumRepresentation_Int.Repr"}.AsBytes()
+}
+func (_EnumRepresentation_Int__Repr) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.EnumRepresentation_Int.Repr"}.AsLink()
+}
+func (_EnumRepresentation_Int__Repr) Prototype() ipld.NodePrototype {
+	return _EnumRepresentation_Int__ReprPrototype{}
+}
+
+type _EnumRepresentation_Int__ReprPrototype struct{}
+
+func (_EnumRepresentation_Int__ReprPrototype) NewBuilder() ipld.NodeBuilder {
+	var nb _EnumRepresentation_Int__ReprBuilder
+	nb.Reset()
+	return &nb
+}
+
+type _EnumRepresentation_Int__ReprBuilder struct {
+	_EnumRepresentation_Int__ReprAssembler
+}
+
+func (nb *_EnumRepresentation_Int__ReprBuilder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_EnumRepresentation_Int__ReprBuilder) Reset() {
+	var w _EnumRepresentation_Int
+	var m schema.Maybe
+	*nb = _EnumRepresentation_Int__ReprBuilder{_EnumRepresentation_Int__ReprAssembler{w: &w, m: &m}}
+}
+
+type _EnumRepresentation_Int__ReprAssembler struct {
+	w     *_EnumRepresentation_Int
+	m     *schema.Maybe
+	state maState
+
+	cm schema.Maybe
+	ka _EnumValue__ReprAssembler
+	va _Int__ReprAssembler
+}
+
+func (na *_EnumRepresentation_Int__ReprAssembler) reset() {
+	na.state = maState_initial
+	na.ka.reset()
+	na.va.reset()
+}
+func (na *_EnumRepresentation_Int__ReprAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if sizeHint < 0 {
+		sizeHint = 0
+	}
+	na.w.m = make(map[_EnumValue]*_Int, sizeHint)
+	na.w.t = make([]_EnumRepresentation_Int__entry, 0, sizeHint)
+	return na, nil
+}
+func (_EnumRepresentation_Int__ReprAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.EnumRepresentation_Int.Repr"}.BeginList(0)
+}
+func (na *_EnumRepresentation_Int__ReprAssembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.EnumRepresentation_Int.Repr.Repr"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_EnumRepresentation_Int__ReprAssembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.EnumRepresentation_Int.Repr"}.AssignBool(false)
+}
+func (_EnumRepresentation_Int__ReprAssembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.EnumRepresentation_Int.Repr"}.AssignInt(0)
+}
+func (_EnumRepresentation_Int__ReprAssembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.EnumRepresentation_Int.Repr"}.AssignFloat(0)
+}
+func (_EnumRepresentation_Int__ReprAssembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.EnumRepresentation_Int.Repr"}.AssignString("")
+}
+func (_EnumRepresentation_Int__ReprAssembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.EnumRepresentation_Int.Repr"}.AssignBytes(nil)
+}
+func (_EnumRepresentation_Int__ReprAssembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.EnumRepresentation_Int.Repr"}.AssignLink(nil)
+}
+func (na *_EnumRepresentation_Int__ReprAssembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	v2, ok := v.(*_EnumRepresentation_Int)
+	if v3, ok3 := v.(*_EnumRepresentation_Int__Repr); ok3 {
+		v2, ok = (*_EnumRepresentation_Int)(v3), true
+	}
+	if ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.EnumRepresentation_Int.Repr", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_EnumRepresentation_Int__ReprAssembler) Prototype() ipld.NodePrototype {
+	return _EnumRepresentation_Int__ReprPrototype{}
+}
+func (ma *_EnumRepresentation_Int__ReprAssembler) keyFinishTidy() bool {
+	switch ma.cm {
+	case schema.Maybe_Value:
+		ma.ka.w = nil
+		tz := &ma.w.t[len(ma.w.t)-1]
+		ma.cm = schema.Maybe_Absent
+		ma.state = maState_expectValue
+		ma.w.m[tz.k] = &tz.v
+		ma.va.w = &tz.v
+		ma.va.m = &ma.cm
+		ma.ka.reset()
+		return true
+	default:
+		return false
+	}
+}
+func (ma *_EnumRepresentation_Int__ReprAssembler) valueFinishTidy() bool {
+	switch ma.cm {
+	case schema.Maybe_Value:
+		ma.va.w = nil
+		ma.cm = schema.Maybe_Absent
+		ma.state = maState_initial
+		ma.va.reset()
+		return true
+	default:
+		return false
+	}
+}
+func (ma *_EnumRepresentation_Int__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+
+	var k2 _EnumValue
+	if err := (_EnumValue__ReprPrototype{}).fromString(&k2, k); err != nil {
+		return nil, err
+	}
+	if _, exists := ma.w.m[k2]; exists {
+		return nil, ipld.ErrRepeatedMapKey{Key: &k2}
+	}
+	ma.w.t = append(ma.w.t, _EnumRepresentation_Int__entry{k: k2})
+	tz := &ma.w.t[len(ma.w.t)-1]
+	ma.state = maState_midValue
+
+	ma.w.m[k2] = &tz.v
+	ma.va.w = &tz.v
+	ma.va.m = &ma.cm
+	return &ma.va, nil
+}
+func (ma *_EnumRepresentation_Int__ReprAssembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.w.t = append(ma.w.t, _EnumRepresentation_Int__entry{})
+	ma.state = maState_midKey
+	ma.ka.m = &ma.cm
+	ma.ka.w = &ma.w.t[len(ma.w.t)-1].k
+	return &ma.ka
+}
+func (ma *_EnumRepresentation_Int__ReprAssembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		if !ma.keyFinishTidy() {
+			panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+		} // if tidy success: carry on
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	return &ma.va
+}
+func (ma *_EnumRepresentation_Int__ReprAssembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_EnumRepresentation_Int__ReprAssembler) KeyPrototype() ipld.NodePrototype {
+	return _EnumValue__ReprPrototype{}
+}
+func (ma *_EnumRepresentation_Int__ReprAssembler) ValuePrototype(_ string) ipld.NodePrototype {
+	return _Int__ReprPrototype{}
+}
@@ -0,0 +1,223 @@
+package schemadmt
+
+// Code generated by go-ipld-prime gengo.  DO NOT EDIT.
+
+import (
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// EnumValue matches the IPLD Schema type "EnumValue".  It has string kind.
+type EnumValue = *_EnumValue
+type _EnumValue struct{ x string }
+
+// String returns the value as a native string.
+func (n EnumValue) String() string {
+	return n.x
+}
+func (_EnumValue__Prototype) fromString(w *_EnumValue, v string) error {
+	*w = _EnumValue{v}
+	return nil
+}
+func (_EnumValue__Prototype) FromString(v string) (EnumValue, error) {
+	n := _EnumValue{v}
+	return &n, nil
+}
+
+type _EnumValue__Maybe struct {
+	m schema.Maybe
+	v _EnumValue
+}
+type MaybeEnumValue = *_EnumValue__Maybe
+
+func (m MaybeEnumValue) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeEnumValue) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeEnumValue) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeEnumValue) AsNode() ipld.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return ipld.Absent
+	case schema.Maybe_Null:
+		return ipld.Null
+	case schema.Maybe_Value:
+		return &m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybeEnumValue) Must() EnumValue {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return &m.v
+}
+
+var _ ipld.Node = (EnumValue)(&_EnumValue{})
+var _ schema.TypedNode = (EnumValue)(&_EnumValue{})
+
+func (EnumValue) Kind() ipld.Kind {
+	return ipld.Kind_String
+}
+func (EnumValue) LookupByString(string) (ipld.Node, error) {
+	return mixins.String{"schemadmt.EnumValue"}.LookupByString("")
+}
+func (EnumValue) LookupByNode(ipld.Node) (ipld.Node, error) {
+	return mixins.String{"schemadmt.EnumValue"}.LookupByNode(nil)
+}
+func (EnumValue) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.String{"schemadmt.EnumValue"}.LookupByIndex(0)
+}
+func (EnumValue) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return mixins.String{"schemadmt.EnumValue"}.LookupBySegment(seg)
+}
+func (EnumValue) MapIterator() ipld.MapIterator {
+	return nil
+}
+func (EnumValue) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (EnumValue) Length() int64 {
+	return -1
+}
+func (EnumValue) IsAbsent() bool {
+	return false
+}
+func (EnumValue) IsNull() bool {
+	return false
+}
+func (EnumValue) AsBool() (bool, error) {
+	return mixins.String{"schemadmt.EnumValue"}.AsBool()
+}
+func (EnumValue) AsInt() (int64, error) {
+	return mixins.String{"schemadmt.EnumValue"}.AsInt()
+}
+func (EnumValue) AsFloat() (float64, error) {
+	return mixins.String{"schemadmt.EnumValue"}.AsFloat()
+}
+func (n EnumValue) AsString() (string, error) {
+	return n.x, nil
+}
+func (EnumValue) AsBytes() ([]byte, error) {
+	return mixins.String{"schemadmt.EnumValue"}.AsBytes()
+}
+func (EnumValue) AsLink() (ipld.Link, error) {
+	return mixins.String{"schemadmt.EnumValue"}.AsLink()
+}
+func (EnumValue) Prototype() ipld.NodePrototype {
+	return _EnumValue__Prototype{}
+}
+
+type _EnumValue__Prototype struct{}
+
+func (_EnumValue__Prototype) NewBuilder() ipld.NodeBuilder {
+	var nb _EnumValue__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _EnumValue__Builder struct {
+	_EnumValue__Assembler
+}
+
+func (nb *_EnumValue__Builder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_EnumValue__Builder) Reset() {
+	var w _EnumValue
+	var m schema.Maybe
+	*nb = _EnumValue__Builder{_EnumValue__Assembler{w: &w, m: &m}}
+}
+
+type _EnumValue__Assembler struct {
+	w *_EnumValue
+	m *schema.Maybe
+}
+
+func (na *_EnumValue__Assembler) reset() {}
+func (_EnumValue__Assembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.EnumValue"}.BeginMap(0)
+}
+func (_EnumValue__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.EnumValue"}.BeginList(0)
+}
+func (na *_EnumValue__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.StringAssembler{"schemadmt.EnumValue"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	}
+	panic("unreachable")
+}
+func (_EnumValue__Assembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.EnumValue"}.AssignBool(false)
+}
+func (_EnumValue__Assembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.EnumValue"}.AssignInt(0)
+}
+func (_EnumValue__Assembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.EnumValue"}.AssignFloat(0)
+}
+func (na *_EnumValue__Assembler) AssignString(v string) error {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	}
+	na.w.x = v
+	*na.m = schema.Maybe_Value
+	return nil
+}
+func (_EnumValue__Assembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.EnumValue"}.AssignBytes(nil)
+}
+func (_EnumValue__Assembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.EnumValue"}.AssignLink(nil)
+}
+func (na *_EnumValue__Assembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_EnumValue); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return na.AssignString(v2)
+	}
+}
+func (_EnumValue__Assembler) Prototype() ipld.NodePrototype {
+	return _EnumValue__Prototype{}
+}
+func (EnumValue) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (n EnumValue) Representation() ipld.Node {
+	return (*_EnumValue__Repr)(n)
+}
+
+type _EnumValue__Repr = _EnumValue
+
+var _ ipld.Node = &_EnumValue__Repr{}
+
+type _EnumValue__ReprPrototype = _EnumValue__Prototype
+type _EnumValue__ReprAssembler = _EnumValue__Assembler
@@ -0,0 +1,873 @@
+package schemadmt
+
+// Code generated by go-ipld-prime gengo.  DO NOT EDIT.
+
+import (
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// ListRepresentation matches the IPLD Schema type "ListRepresentation".
+// ListRepresentation has Union typekind, which means its data model behaviors are that of a map kind.
+type ListRepresentation = *_ListRepresentation
+type _ListRepresentation struct {
+	tag uint
+	x1  _ListRepresentation_List
+}
+type _ListRepresentation__iface interface {
+	_ListRepresentation__member()
+}
+
+func (_ListRepresentation_List) _ListRepresentation__member() {}
+func (n _ListRepresentation) AsInterface() _ListRepresentation__iface {
+	switch n.tag {
+	case 1:
+		return &n.x1
+	default:
+		panic("invalid union state; how did you create this object?")
+	}
+}
+
+// MemberListRepresentation_List returns the union's value if it's a ListRepresentation_List, and nil otherwise.
+func (n _ListRepresentation) MemberListRepresentation_List() ListRepresentation_List {
+	if n.tag != 1 {
+		return nil
+	}
+	return &n.x1
+}
+
+type _ListRepresentation__Maybe struct {
+	m schema.Maybe
+	v ListRepresentation
+}
+type MaybeListRepresentation = *_ListRepresentation__Maybe
+
+func (m MaybeListRepresentation) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeListRepresentation) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeListRepresentation) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeListRepresentation) AsNode() ipld.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return ipld.Absent
+	case schema.Maybe_Null:
+		return ipld.Null
+	case schema.Maybe_Value:
+		return m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybeListRepresentation) Must() ListRepresentation {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return m.v
+}
+
+var (
+	memberName__ListRepresentation_ListRepresentation_List = _String{"ListRepresentation_List"}
+)
+var _ ipld.Node = (ListRepresentation)(&_ListRepresentation{})
+var _ schema.TypedNode = (ListRepresentation)(&_ListRepresentation{})
+
+func (ListRepresentation) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n ListRepresentation) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	case "ListRepresentation_List":
+		if n.tag != 1 {
+			return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(key)}
+		}
+		return &n.x1, nil
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n ListRepresentation) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (ListRepresentation) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.ListRepresentation"}.LookupByIndex(0)
+}
+func (n ListRepresentation) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n ListRepresentation) MapIterator() ipld.MapIterator {
+	return &_ListRepresentation__MapItr{n, false}
+}
+
+type _ListRepresentation__MapItr struct {
+	n    ListRepresentation
+	done bool
+}
+
+func (itr *_ListRepresentation__MapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	if itr.done {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	switch itr.n.tag {
+	case 1:
+		k, v = &memberName__ListRepresentation_ListRepresentation_List, &itr.n.x1
+	default:
+		panic("unreachable")
+	}
+	itr.done = true
+	return
+}
+func (itr *_ListRepresentation__MapItr) Done() bool {
+	return itr.done
+}
+
+func (ListRepresentation) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (ListRepresentation) Length() int64 {
+	return 1
+}
+func (ListRepresentation) IsAbsent() bool {
+	return false
+}
+func (ListRepresentation) IsNull() bool {
+	return false
+}
+func (ListRepresentation) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.ListRepresentation"}.AsBool()
+}
+func (ListRepresentation) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.ListRepresentation"}.AsInt()
+}
+func (ListRepresentation) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.ListRepresentation"}.AsFloat()
+}
+func (ListRepresentation) AsString() (string, error) {
+	return mixins.Map{"schemadmt.ListRepresentation"}.AsString()
+}
+func (ListRepresentation) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.ListRepresentation"}.AsBytes()
+}
+func (ListRepresentation) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.ListRepresentation"}.AsLink()
+}
+func (ListRepresentation) Prototype() ipld.NodePrototype {
+	return _ListRepresentation__Prototype{}
+}
+
+type _ListRepresentation__Prototype struct{}
+
+func (_ListRepresentation__Prototype) NewBuilder() ipld.NodeBuilder {
+	var nb _ListRepresentation__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _ListRepresentation__Builder struct {
+	_ListRepresentation__Assembler
+}
+
+func (nb *_ListRepresentation__Builder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_ListRepresentation__Builder) Reset() {
+	var w _ListRepresentation
+	var m schema.Maybe
+	*nb = _ListRepresentation__Builder{_ListRepresentation__Assembler{w: &w, m: &m}}
+}
+
+type _ListRepresentation__Assembler struct {
+	w     *_ListRepresentation
+	m     *schema.Maybe
+	state maState
+
+	cm  schema.Maybe
+	ca1 _ListRepresentation_List__Assembler
+	ca  uint
+}
+
+func (na *_ListRepresentation__Assembler) reset() {
+	na.state = maState_initial
+	switch na.ca {
+	case 0:
+		return
+	case 1:
+		na.ca1.reset()
+	default:
+		panic("unreachable")
+	}
+	na.ca = 0
+	na.cm = schema.Maybe_Absent
+}
+func (na *_ListRepresentation__Assembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_ListRepresentation{}
+	}
+	return na, nil
+}
+func (_ListRepresentation__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.ListRepresentation"}.BeginList(0)
+}
+func (na *_ListRepresentation__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.ListRepresentation"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_ListRepresentation__Assembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.ListRepresentation"}.AssignBool(false)
+}
+func (_ListRepresentation__Assembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.ListRepresentation"}.AssignInt(0)
+}
+func (_ListRepresentation__Assembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.ListRepresentation"}.AssignFloat(0)
+}
+func (_ListRepresentation__Assembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.ListRepresentation"}.AssignString("")
+}
+func (_ListRepresentation__Assembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.ListRepresentation"}.AssignBytes(nil)
+}
+func (_ListRepresentation__Assembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.ListRepresentation"}.AssignLink(nil)
+}
+func (na *_ListRepresentation__Assembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_ListRepresentation); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.ListRepresentation", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_ListRepresentation__Assembler) Prototype() ipld.NodePrototype {
+	return _ListRepresentation__Prototype{}
+}
+func (ma *_ListRepresentation__Assembler) valueFinishTidy() bool {
+	switch ma.cm {
+	case schema.Maybe_Value:
+		ma.state = maState_initial
+		return true
+	default:
+		return false
+	}
+}
+func (ma *_ListRepresentation__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on for the moment, but we'll still be erroring shortly.
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	if ma.ca != 0 {
+		return nil, schema.ErrNotUnionStructure{TypeName: "schemadmt.ListRepresentation", Detail: "cannot add another entry -- a union can only contain one thing!"}
+	}
+	switch k {
+	case "ListRepresentation_List":
+		ma.state = maState_midValue
+		ma.ca = 1
+		ma.w.tag = 1
+		ma.ca1.w = &ma.w.x1
+		ma.ca1.m = &ma.cm
+		return &ma.ca1, nil
+	}
+	return nil, schema.ErrInvalidUnionDiscriminant{TypeName: "schemadmt.ListRepresentation", Discriminant: k, Expected: []string{"ListRepresentation_List"}}
+}
+func (ma *_ListRepresentation__Assembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on for the moment, but we'll still be erroring shortly... or rather, the keyassembler will be.
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_ListRepresentation__KeyAssembler)(ma)
+}
+func (ma *_ListRepresentation__Assembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.ca {
+	case 1:
+		ma.ca1.w = &ma.w.x1
+		ma.ca1.m = &ma.cm
+		return &ma.ca1
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_ListRepresentation__Assembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.ca == 0 {
+		return schema.ErrNotUnionStructure{TypeName: "schemadmt.ListRepresentation", Detail: "a union must have exactly one entry (not none)!"}
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_ListRepresentation__Assembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_ListRepresentation__Assembler) ValuePrototype(k string) ipld.NodePrototype {
+	switch k {
+	case "ListRepresentation_List":
+		return _ListRepresentation_List__Prototype{}
+	default:
+		return nil
+	}
+}
+
+type _ListRepresentation__KeyAssembler _ListRepresentation__Assembler
+
+func (_ListRepresentation__KeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.ListRepresentation.KeyAssembler"}.BeginMap(0)
+}
+func (_ListRepresentation__KeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.ListRepresentation.KeyAssembler"}.BeginList(0)
+}
+func (na *_ListRepresentation__KeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.ListRepresentation.KeyAssembler"}.AssignNull()
+}
+func (_ListRepresentation__KeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.ListRepresentation.KeyAssembler"}.AssignBool(false)
+}
+func (_ListRepresentation__KeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.ListRepresentation.KeyAssembler"}.AssignInt(0)
+}
+func (_ListRepresentation__KeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.ListRepresentation.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_ListRepresentation__KeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	if ka.ca != 0 {
+		return schema.ErrNotUnionStructure{TypeName: "schemadmt.ListRepresentation", Detail: "cannot add another entry -- a union can only contain one thing!"}
+	}
+	switch k {
+	case "ListRepresentation_List":
+		ka.ca = 1
+		ka.w.tag = 1
+		ka.state = maState_expectValue
+		return nil
+	}
+	return schema.ErrInvalidUnionDiscriminant{TypeName: "schemadmt.ListRepresentation", Discriminant: k, Expected: []string{"ListRepresentation_List"}}
+}
+func (_ListRepresentation__KeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.ListRepresentation.KeyAssembler"}.AssignBytes(nil)
+}
+func (_ListRepresentation__KeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.ListRepresentation.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_ListRepresentation__KeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_ListRepresentation__KeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ListRepresentation) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (n ListRepresentation) Representation() ipld.Node {
+	return (*_ListRepresentation__Repr)(n)
+}
+
+type _ListRepresentation__Repr _ListRepresentation
+
+var (
+	memberName__ListRepresentation_ListRepresentation_List_serial = _String{"list"}
+)
+var _ ipld.Node = &_ListRepresentation__Repr{}
+
+func (_ListRepresentation__Repr) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n *_ListRepresentation__Repr) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	case "list":
+		if n.tag != 1 {
+			return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(key)}
+		}
+		return n.x1.Representation(), nil
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n *_ListRepresentation__Repr) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (_ListRepresentation__Repr) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.ListRepresentation.Repr"}.LookupByIndex(0)
+}
+func (n _ListRepresentation__Repr) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n *_ListRepresentation__Repr) MapIterator() ipld.MapIterator {
+	return &_ListRepresentation__ReprMapItr{n, false}
+}
+
+type _ListRepresentation__ReprMapItr struct {
+	n    *_ListRepresentation__Repr
+	done bool
+}
+
+func (itr *_ListRepresentation__ReprMapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	if itr.done {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	switch itr.n.tag {
+	case 1:
+		k, v = &memberName__ListRepresentation_ListRepresentation_List_serial, itr.n.x1.Representation()
+	default:
+		panic("unreachable")
+	}
+	itr.done = true
+	return
+}
+func (itr *_ListRepresentation__ReprMapItr) Done() bool {
+	return itr.done
+}
+
+func (_ListRepresentation__Repr) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (_ListRepresentation__Repr) Length() int64 {
+	return 1
+}
+func (_ListRepresentation__Repr) IsAbsent() bool {
+	return false
+}
+func (_ListRepresentation__Repr) IsNull() bool {
+	return false
+}
+func (_ListRepresentation__Repr) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.ListRepresentation.Repr"}.AsBool()
+}
+func (_ListRepresentation__Repr) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.ListRepresentation.Repr"}.AsInt()
+}
+func (_ListRepresentation__Repr) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.ListRepresentation.Repr"}.AsFloat()
+}
+func (_ListRepresentation__Repr) AsString() (string, error) {
+	return mixins.Map{"schemadmt.ListRepresentation.Repr"}.AsString()
+}
+func (_ListRepresentation__Repr) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.ListRepresentation.Repr"}.AsBytes()
+}
+func (_ListRepresentation__Repr) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.ListRepresentation.Repr"}.AsLink()
+}
+func (_ListRepresentation__Repr) Prototype() ipld.NodePrototype {
+	return _ListRepresentation__ReprPrototype{}
+}
+
+type _ListRepresentation__ReprPrototype struct{}
+
+func (_ListRepresentation__ReprPrototype) NewBuilder() ipld.NodeBuilder {
+	var nb _ListRepresentation__ReprBuilder
+	nb.Reset()
+	return &nb
+}
+
+type _ListRepresentation__ReprBuilder struct {
+	_ListRepresentation__ReprAssembler
+}
+
+func (nb *_ListRepresentation__ReprBuilder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_ListRepresentation__ReprBuilder) Reset() {
+	var w _ListRepresentation
+	var m schema.Maybe
+	*nb = _ListRepresentation__ReprBuilder{_ListRepresentation__ReprAssembler{w: &w, m: &m}}
+}
+
+type _ListRepresentation__ReprAssembler struct {
+	w     *_ListRepresentation
+	m     *schema.Maybe
+	state maState
+
+	cm  schema.Maybe
+	ca1 _ListRepresentation_List__ReprAssembler
+	ca  uint
+}
+
+func (na *_ListRepresentation__ReprAssembler) reset() {
+	na.state = maState_initial
+	switch na.ca {
+	case 0:
+		return
+	case 1:
+		na.ca1.reset()
+	default:
+		panic("unreachable")
+	}
+	na.ca = 0
+	na.cm = schema.Maybe_Absent
+}
+func (na *_ListRepresentation__ReprAssembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_ListRepresentation{}
+	}
+	return na, nil
+}
+func (_ListRepresentation__ReprAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.ListRepresentation.Repr"}.BeginList(0)
+}
+func (na *_ListRepresentation__ReprAssembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.ListRepresentation.Repr.Repr"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_ListRepresentation__ReprAssembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.ListRepresentation.Repr"}.AssignBool(false)
+}
+func (_ListRepresentation__ReprAssembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.ListRepresentation.Repr"}.AssignInt(0)
+}
+func (_ListRepresentation__ReprAssembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.ListRepresentation.Repr"}.AssignFloat(0)
+}
+func (_ListRepresentation__ReprAssembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.ListRepresentation.Repr"}.AssignString("")
+}
+func (_ListRepresentation__ReprAssembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.ListRepresentation.Repr"}.AssignBytes(nil)
+}
+func (_ListRepresentation__ReprAssembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.ListRepresentation.Repr"}.AssignLink(nil)
+}
+func (na *_ListRepresentation__ReprAssembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	v2, ok := v.(*_ListRepresentation)
+	if v3, ok3 := v.(*_ListRepresentation__Repr); ok3 {
+		v2, ok = (*_ListRepresentation)(v3), true
+	}
+	if ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.ListRepresentation.Repr", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_ListRepresentation__ReprAssembler) Prototype() ipld.NodePrototype {
+	return _ListRepresentation__ReprPrototype{}
+}
+func (ma *_ListRepresentation__ReprAssembler) valueFinishTidy() bool {
+	switch ma.cm {
+	case schema.Maybe_Value:
+		ma.state = maState_initial
+		return true
+	default:
+		return false
+	}
+}
+func (ma *_ListRepresentation__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on for the moment, but we'll still be erroring shortly.
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	if ma.ca != 0 {
+		return nil, schema.ErrNotUnionStructure{TypeName: "schemadmt.ListRepresentation.Repr", Detail: "cannot add another entry -- a union can only contain one thing!"}
+	}
+	switch k {
+	case "list":
+		ma.state = maState_midValue
+		ma.ca = 1
+		ma.w.tag = 1
+		ma.ca1.w = &ma.w.x1
+		ma.ca1.m = &ma.cm
+		return &ma.ca1, nil
+	}
+	return nil, schema.ErrInvalidUnionDiscriminant{TypeName: "schemadmt.ListRepresentation.Repr", Discriminant: k, Expected: []string{"list"}}
+}
+func (ma *_ListRepresentation__ReprAssembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on for the moment, but we'll still be erroring shortly... or rather, the keyassembler will be.
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_ListRepresentation__ReprKeyAssembler)(ma)
+}
+func (ma *_ListRepresentation__ReprAssembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.ca {
+	case 1:
+		ma.ca1.w = &ma.w.x1
+		ma.ca1.m = &ma.cm
+		return &ma.ca1
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_ListRepresentation__ReprAssembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.ca == 0 {
+		return schema.ErrNotUnionStructure{TypeName: "schemadmt.ListRepresentation.Repr", Detail: "a union must have exactly one entry (not none)!"}
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_ListRepresentation__ReprAssembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_ListRepresentation__ReprAssembler) ValuePrototype(k string) ipld.NodePrototype {
+	switch k {
+	case "ListRepresentation_List":
+		return _ListRepresentation_List__ReprPrototype{}
+	default:
+		return nil
+	}
+}
+
+type _ListRepresentation__ReprKeyAssembler _ListRepresentation__ReprAssembler
+
+func (_ListRepresentation__ReprKeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.ListRepresentation.Repr.KeyAssembler"}.BeginMap(0)
+}
+func (_ListRepresentation__ReprKeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.ListRepresentation.Repr.KeyAssembler"}.BeginList(0)
+}
+func (na *_ListRepresentation__ReprKeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.ListRepresentation.Repr.KeyAssembler"}.AssignNull()
+}
+func (_ListRepresentation__ReprKeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.ListRepresentation.Repr.KeyAssembler"}.AssignBool(false)
+}
+func (_ListRepresentation__ReprKeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.ListRepresentation.Repr.KeyAssembler"}.AssignInt(0)
+}
+func (_ListRepresentation__ReprKeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.ListRepresentation.Repr.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_ListRepresentation__ReprKeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	if ka.ca != 0 {
+		return schema.ErrNotUnionStructure{TypeName: "schemadmt.ListRepresentation.Repr", Detail: "cannot add another entry -- a union can only contain one thing!"}
+	}
+	switch k {
+	case "list":
+		ka.ca = 1
+		ka.w.tag = 1
+		ka.state = maState_expectValue
+		return nil
+	}
+	return schema.ErrInvalidUnionDiscriminant{TypeName: "schemadmt.ListRepresentation.Repr", Discriminant: k, Expected: []string{"list"}}
+}
+func (_ListRepresentation__ReprKeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.ListRepresentation.Repr.KeyAssembler"}.AssignBytes(nil)
+}
+func (_ListRepresentation__ReprKeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.ListRepresentation.Repr.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_ListRepresentation__ReprKeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_ListRepresentation__ReprKeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
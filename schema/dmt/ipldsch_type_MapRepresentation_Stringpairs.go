@@ -0,0 +1,947 @@
+package schemadmt
+
+// Code generated by go-ipld-prime gengo.  DO NOT EDIT.
+
+import (
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// MapRepresentation_Stringpairs matches the IPLD Schema type "MapRepresentation_Stringpairs".  It has Struct type-kind, and may be interrogated like map kind.
+type MapRepresentation_Stringpairs = *_MapRepresentation_Stringpairs
+type _MapRepresentation_Stringpairs struct {
+	innerDelim _String
+	entryDelim _String
+}
+
+// FieldInnerDelim returns the value of the field "innerDelim".
+func (n _MapRepresentation_Stringpairs) FieldInnerDelim() String {
+	return &n.innerDelim
+}
+
+// FieldEntryDelim returns the value of the field "entryDelim".
+func (n _MapRepresentation_Stringpairs) FieldEntryDelim() String {
+	return &n.entryDelim
+}
+
+type _MapRepresentation_Stringpairs__Maybe struct {
+	m schema.Maybe
+	v MapRepresentation_Stringpairs
+}
+type MaybeMapRepresentation_Stringpairs = *_MapRepresentation_Stringpairs__Maybe
+
+func (m MaybeMapRepresentation_Stringpairs) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeMapRepresentation_Stringpairs) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeMapRepresentation_Stringpairs) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeMapRepresentation_Stringpairs) AsNode() ipld.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return ipld.Absent
+	case schema.Maybe_Null:
+		return ipld.Null
+	case schema.Maybe_Value:
+		return m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybeMapRepresentation_Stringpairs) Must() MapRepresentation_Stringpairs {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return m.v
+}
+
+var (
+	fieldName__MapRepresentation_Stringpairs_InnerDelim = _String{"innerDelim"}
+	fieldName__MapRepresentation_Stringpairs_EntryDelim = _String{"entryDelim"}
+)
+var _ ipld.Node = (MapRepresentation_Stringpairs)(&_MapRepresentation_Stringpairs{})
+var _ schema.TypedNode = (MapRepresentation_Stringpairs)(&_MapRepresentation_Stringpairs{})
+
+func (MapRepresentation_Stringpairs) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n MapRepresentation_Stringpairs) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	case "innerDelim":
+		return &n.innerDelim, nil
+	case "entryDelim":
+		return &n.entryDelim, nil
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n MapRepresentation_Stringpairs) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (MapRepresentation_Stringpairs) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.MapRepresentation_Stringpairs"}.LookupByIndex(0)
+}
+func (n MapRepresentation_Stringpairs) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n MapRepresentation_Stringpairs) MapIterator() ipld.MapIterator {
+	return &_MapRepresentation_Stringpairs__MapItr{n, 0}
+}
+
+type _MapRepresentation_Stringpairs__MapItr struct {
+	n   MapRepresentation_Stringpairs
+	idx int
+}
+
+func (itr *_MapRepresentation_Stringpairs__MapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	if itr.idx >= 2 {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	switch itr.idx {
+	case 0:
+		k = &fieldName__MapRepresentation_Stringpairs_InnerDelim
+		v = &itr.n.innerDelim
+	case 1:
+		k = &fieldName__MapRepresentation_Stringpairs_EntryDelim
+		v = &itr.n.entryDelim
+	default:
+		panic("unreachable")
+	}
+	itr.idx++
+	return
+}
+func (itr *_MapRepresentation_Stringpairs__MapItr) Done() bool {
+	return itr.idx >= 2
+}
+
+func (MapRepresentation_Stringpairs) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (MapRepresentation_Stringpairs) Length() int64 {
+	return 2
+}
+func (MapRepresentation_Stringpairs) IsAbsent() bool {
+	return false
+}
+func (MapRepresentation_Stringpairs) IsNull() bool {
+	return false
+}
+func (MapRepresentation_Stringpairs) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.MapRepresentation_Stringpairs"}.AsBool()
+}
+func (MapRepresentation_Stringpairs) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.MapRepresentation_Stringpairs"}.AsInt()
+}
+func (MapRepresentation_Stringpairs) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.MapRepresentation_Stringpairs"}.AsFloat()
+}
+func (MapRepresentation_Stringpairs) AsString() (string, error) {
+	return mixins.Map{"schemadmt.MapRepresentation_Stringpairs"}.AsString()
+}
+func (MapRepresentation_Stringpairs) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.MapRepresentation_Stringpairs"}.AsBytes()
+}
+func (MapRepresentation_Stringpairs) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.MapRepresentation_Stringpairs"}.AsLink()
+}
+func (MapRepresentation_Stringpairs) Prototype() ipld.NodePrototype {
+	return _MapRepresentation_Stringpairs__Prototype{}
+}
+
+type _MapRepresentation_Stringpairs__Prototype struct{}
+
+func (_MapRepresentation_Stringpairs__Prototype) NewBuilder() ipld.NodeBuilder {
+	var nb _MapRepresentation_Stringpairs__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _MapRepresentation_Stringpairs__Builder struct {
+	_MapRepresentation_Stringpairs__Assembler
+}
+
+func (nb *_MapRepresentation_Stringpairs__Builder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_MapRepresentation_Stringpairs__Builder) Reset() {
+	var w _MapRepresentation_Stringpairs
+	var m schema.Maybe
+	*nb = _MapRepresentation_Stringpairs__Builder{_MapRepresentation_Stringpairs__Assembler{w: &w, m: &m}}
+}
+
+type _MapRepresentation_Stringpairs__Assembler struct {
+	w     *_MapRepresentation_Stringpairs
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm            schema.Maybe
+	ca_innerDelim _String__Assembler
+	ca_entryDelim _String__Assembler
+}
+
+func (na *_MapRepresentation_Stringpairs__Assembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+	na.ca_innerDelim.reset()
+	na.ca_entryDelim.reset()
+}
+
+var (
+	fieldBit__MapRepresentation_Stringpairs_InnerDelim  = 1 << 0
+	fieldBit__MapRepresentation_Stringpairs_EntryDelim  = 1 << 1
+	fieldBits__MapRepresentation_Stringpairs_sufficient = 0 + 1<<0 + 1<<1
+)
+
+func (na *_MapRepresentation_Stringpairs__Assembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_MapRepresentation_Stringpairs{}
+	}
+	return na, nil
+}
+func (_MapRepresentation_Stringpairs__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.MapRepresentation_Stringpairs"}.BeginList(0)
+}
+func (na *_MapRepresentation_Stringpairs__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.MapRepresentation_Stringpairs"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_MapRepresentation_Stringpairs__Assembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.MapRepresentation_Stringpairs"}.AssignBool(false)
+}
+func (_MapRepresentation_Stringpairs__Assembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.MapRepresentation_Stringpairs"}.AssignInt(0)
+}
+func (_MapRepresentation_Stringpairs__Assembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.MapRepresentation_Stringpairs"}.AssignFloat(0)
+}
+func (_MapRepresentation_Stringpairs__Assembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.MapRepresentation_Stringpairs"}.AssignString("")
+}
+func (_MapRepresentation_Stringpairs__Assembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.MapRepresentation_Stringpairs"}.AssignBytes(nil)
+}
+func (_MapRepresentation_Stringpairs__Assembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.MapRepresentation_Stringpairs"}.AssignLink(nil)
+}
+func (na *_MapRepresentation_Stringpairs__Assembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_MapRepresentation_Stringpairs); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.MapRepresentation_Stringpairs", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_MapRepresentation_Stringpairs__Assembler) Prototype() ipld.NodePrototype {
+	return _MapRepresentation_Stringpairs__Prototype{}
+}
+func (ma *_MapRepresentation_Stringpairs__Assembler) valueFinishTidy() bool {
+	switch ma.f {
+	case 0:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.ca_innerDelim.w = nil
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 1:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.ca_entryDelim.w = nil
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_MapRepresentation_Stringpairs__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	switch k {
+	case "innerDelim":
+		if ma.s&fieldBit__MapRepresentation_Stringpairs_InnerDelim != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__MapRepresentation_Stringpairs_InnerDelim}
+		}
+		ma.s += fieldBit__MapRepresentation_Stringpairs_InnerDelim
+		ma.state = maState_midValue
+		ma.f = 0
+		ma.ca_innerDelim.w = &ma.w.innerDelim
+		ma.ca_innerDelim.m = &ma.cm
+		return &ma.ca_innerDelim, nil
+	case "entryDelim":
+		if ma.s&fieldBit__MapRepresentation_Stringpairs_EntryDelim != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__MapRepresentation_Stringpairs_EntryDelim}
+		}
+		ma.s += fieldBit__MapRepresentation_Stringpairs_EntryDelim
+		ma.state = maState_midValue
+		ma.f = 1
+		ma.ca_entryDelim.w = &ma.w.entryDelim
+		ma.ca_entryDelim.m = &ma.cm
+		return &ma.ca_entryDelim, nil
+	}
+	return nil, ipld.ErrInvalidKey{TypeName: "schemadmt.MapRepresentation_Stringpairs", Key: &_String{k}}
+}
+func (ma *_MapRepresentation_Stringpairs__Assembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_MapRepresentation_Stringpairs__KeyAssembler)(ma)
+}
+func (ma *_MapRepresentation_Stringpairs__Assembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	case 0:
+		ma.ca_innerDelim.w = &ma.w.innerDelim
+		ma.ca_innerDelim.m = &ma.cm
+		return &ma.ca_innerDelim
+	case 1:
+		ma.ca_entryDelim.w = &ma.w.entryDelim
+		ma.ca_entryDelim.m = &ma.cm
+		return &ma.ca_entryDelim
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_MapRepresentation_Stringpairs__Assembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__MapRepresentation_Stringpairs_sufficient != fieldBits__MapRepresentation_Stringpairs_sufficient {
+		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		if ma.s&fieldBit__MapRepresentation_Stringpairs_InnerDelim == 0 {
+			err.Missing = append(err.Missing, "innerDelim")
+		}
+		if ma.s&fieldBit__MapRepresentation_Stringpairs_EntryDelim == 0 {
+			err.Missing = append(err.Missing, "entryDelim")
+		}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_MapRepresentation_Stringpairs__Assembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_MapRepresentation_Stringpairs__Assembler) ValuePrototype(k string) ipld.NodePrototype {
+	panic("todo structbuilder mapassembler valueprototype")
+}
+
+type _MapRepresentation_Stringpairs__KeyAssembler _MapRepresentation_Stringpairs__Assembler
+
+func (_MapRepresentation_Stringpairs__KeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.MapRepresentation_Stringpairs.KeyAssembler"}.BeginMap(0)
+}
+func (_MapRepresentation_Stringpairs__KeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.MapRepresentation_Stringpairs.KeyAssembler"}.BeginList(0)
+}
+func (na *_MapRepresentation_Stringpairs__KeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.MapRepresentation_Stringpairs.KeyAssembler"}.AssignNull()
+}
+func (_MapRepresentation_Stringpairs__KeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.MapRepresentation_Stringpairs.KeyAssembler"}.AssignBool(false)
+}
+func (_MapRepresentation_Stringpairs__KeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.MapRepresentation_Stringpairs.KeyAssembler"}.AssignInt(0)
+}
+func (_MapRepresentation_Stringpairs__KeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.MapRepresentation_Stringpairs.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_MapRepresentation_Stringpairs__KeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	switch k {
+	case "innerDelim":
+		if ka.s&fieldBit__MapRepresentation_Stringpairs_InnerDelim != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__MapRepresentation_Stringpairs_InnerDelim}
+		}
+		ka.s += fieldBit__MapRepresentation_Stringpairs_InnerDelim
+		ka.state = maState_expectValue
+		ka.f = 0
+	case "entryDelim":
+		if ka.s&fieldBit__MapRepresentation_Stringpairs_EntryDelim != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__MapRepresentation_Stringpairs_EntryDelim}
+		}
+		ka.s += fieldBit__MapRepresentation_Stringpairs_EntryDelim
+		ka.state = maState_expectValue
+		ka.f = 1
+	default:
+		return ipld.ErrInvalidKey{TypeName: "schemadmt.MapRepresentation_Stringpairs", Key: &_String{k}}
+	}
+	return nil
+}
+func (_MapRepresentation_Stringpairs__KeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.MapRepresentation_Stringpairs.KeyAssembler"}.AssignBytes(nil)
+}
+func (_MapRepresentation_Stringpairs__KeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.MapRepresentation_Stringpairs.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_MapRepresentation_Stringpairs__KeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_MapRepresentation_Stringpairs__KeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (MapRepresentation_Stringpairs) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (n MapRepresentation_Stringpairs) Representation() ipld.Node {
+	return (*_MapRepresentation_Stringpairs__Repr)(n)
+}
+
+type _MapRepresentation_Stringpairs__Repr _MapRepresentation_Stringpairs
+
+var (
+	fieldName__MapRepresentation_Stringpairs_InnerDelim_serial = _String{"innerDelim"}
+	fieldName__MapRepresentation_Stringpairs_EntryDelim_serial = _String{"entryDelim"}
+)
+var _ ipld.Node = &_MapRepresentation_Stringpairs__Repr{}
+
+func (_MapRepresentation_Stringpairs__Repr) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n *_MapRepresentation_Stringpairs__Repr) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	case "innerDelim":
+		return n.innerDelim.Representation(), nil
+	case "entryDelim":
+		return n.entryDelim.Representation(), nil
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n *_MapRepresentation_Stringpairs__Repr) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (_MapRepresentation_Stringpairs__Repr) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.MapRepresentation_Stringpairs.Repr"}.LookupByIndex(0)
+}
+func (n _MapRepresentation_Stringpairs__Repr) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n *_MapRepresentation_Stringpairs__Repr) MapIterator() ipld.MapIterator {
+	return &_MapRepresentation_Stringpairs__ReprMapItr{n, 0}
+}
+
+type _MapRepresentation_Stringpairs__ReprMapItr struct {
+	n   *_MapRepresentation_Stringpairs__Repr
+	idx int
+}
+
+func (itr *_MapRepresentation_Stringpairs__ReprMapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	if itr.idx >= 2 {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	switch itr.idx {
+	case 0:
+		k = &fieldName__MapRepresentation_Stringpairs_InnerDelim_serial
+		v = itr.n.innerDelim.Representation()
+	case 1:
+		k = &fieldName__MapRepresentation_Stringpairs_EntryDelim_serial
+		v = itr.n.entryDelim.Representation()
+	default:
+		panic("unreachable")
+	}
+	itr.idx++
+	return
+}
+func (itr *_MapRepresentation_Stringpairs__ReprMapItr) Done() bool {
+	return itr.idx >= 2
+}
+func (_MapRepresentation_Stringpairs__Repr) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (rn *_MapRepresentation_Stringpairs__Repr) Length() int64 {
+	l := 2
+	return int64(l)
+}
+func (_MapRepresentation_Stringpairs__Repr) IsAbsent() bool {
+	return false
+}
+func (_MapRepresentation_Stringpairs__Repr) IsNull() bool {
+	return false
+}
+func (_MapRepresentation_Stringpairs__Repr) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.MapRepresentation_Stringpairs.Repr"}.AsBool()
+}
+func (_MapRepresentation_Stringpairs__Repr) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.MapRepresentation_Stringpairs.Repr"}.AsInt()
+}
+func (_MapRepresentation_Stringpairs__Repr) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.MapRepresentation_Stringpairs.Repr"}.AsFloat()
+}
+func (_MapRepresentation_Stringpairs__Repr) AsString() (string, error) {
+	return mixins.Map{"schemadmt.MapRepresentation_Stringpairs.Repr"}.AsString()
+}
+func (_MapRepresentation_Stringpairs__Repr) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.MapRepresentation_Stringpairs.Repr"}.AsBytes()
+}
+func (_MapRepresentation_Stringpairs__Repr) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.MapRepresentation_Stringpairs.Repr"}.AsLink()
+}
+func (_MapRepresentation_Stringpairs__Repr) Prototype() ipld.NodePrototype {
+	return _MapRepresentation_Stringpairs__ReprPrototype{}
+}
+
+type _MapRepresentation_Stringpairs__ReprPrototype struct{}
+
+func (_MapRepresentation_Stringpairs__ReprPrototype) NewBuilder() ipld.NodeBuilder {
+	var nb _MapRepresentation_Stringpairs__ReprBuilder
+	nb.Reset()
+	return &nb
+}
+
+type _MapRepresentation_Stringpairs__ReprBuilder struct {
+	_MapRepresentation_Stringpairs__ReprAssembler
+}
+
+func (nb *_MapRepresentation_Stringpairs__ReprBuilder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_MapRepresentation_Stringpairs__ReprBuilder) Reset() {
+	var w _MapRepresentation_Stringpairs
+	var m schema.Maybe
+	*nb = _MapRepresentation_Stringpairs__ReprBuilder{_MapRepresentation_Stringpairs__ReprAssembler{w: &w, m: &m}}
+}
+
+type _MapRepresentation_Stringpairs__ReprAssembler struct {
+	w     *_MapRepresentation_Stringpairs
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm            schema.Maybe
+	ca_innerDelim _String__ReprAssembler
+	ca_entryDelim _String__ReprAssembler
+}
+
+func (na *_MapRepresentation_Stringpairs__ReprAssembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+	na.ca_innerDelim.reset()
+	na.ca_entryDelim.reset()
+}
+func (na *_MapRepresentation_Stringpairs__ReprAssembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_MapRepresentation_Stringpairs{}
+	}
+	return na, nil
+}
+func (_MapRepresentation_Stringpairs__ReprAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.MapRepresentation_Stringpairs.Repr"}.BeginList(0)
+}
+func (na *_MapRepresentation_Stringpairs__ReprAssembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.MapRepresentation_Stringpairs.Repr.Repr"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_MapRepresentation_Stringpairs__ReprAssembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.MapRepresentation_Stringpairs.Repr"}.AssignBool(false)
+}
+func (_MapRepresentation_Stringpairs__ReprAssembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.MapRepresentation_Stringpairs.Repr"}.AssignInt(0)
+}
+func (_MapRepresentation_Stringpairs__ReprAssembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.MapRepresentation_Stringpairs.Repr"}.AssignFloat(0)
+}
+func (_MapRepresentation_Stringpairs__ReprAssembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.MapRepresentation_Stringpairs.Repr"}.AssignString("")
+}
+func (_MapRepresentation_Stringpairs__ReprAssembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.MapRepresentation_Stringpairs.Repr"}.AssignBytes(nil)
+}
+func (_MapRepresentation_Stringpairs__ReprAssembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.MapRepresentation_Stringpairs.Repr"}.AssignLink(nil)
+}
+func (na *_MapRepresentation_Stringpairs__ReprAssembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	v2, ok := v.(*_MapRepresentation_Stringpairs)
+	if v3, ok3 := v.(*_MapRepresentation_Stringpairs__Repr); ok3 {
+		v2, ok = (*_MapRepresentation_Stringpairs)(v3), true
+	}
+	if ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.MapRepresentation_Stringpairs.Repr", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_MapRepresentation_Stringpairs__ReprAssembler) Prototype() ipld.NodePrototype {
+	return _MapRepresentation_Stringpairs__ReprPrototype{}
+}
+func (ma *_MapRepresentation_Stringpairs__ReprAssembler) valueFinishTidy() bool {
+	switch ma.f {
+	case 0:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 1:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_MapRepresentation_Stringpairs__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	switch k {
+	case "innerDelim":
+		if ma.s&fieldBit__MapRepresentation_Stringpairs_InnerDelim != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__MapRepresentation_Stringpairs_InnerDelim_serial}
+		}
+		ma.s += fieldBit__MapRepresentation_Stringpairs_InnerDelim
+		ma.state = maState_midValue
+		ma.f = 0
+		ma.ca_innerDelim.w = &ma.w.innerDelim
+		ma.ca_innerDelim.m = &ma.cm
+		return &ma.ca_innerDelim, nil
+	case "entryDelim":
+		if ma.s&fieldBit__MapRepresentation_Stringpairs_EntryDelim != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__MapRepresentation_Stringpairs_EntryDelim_serial}
+		}
+		ma.s += fieldBit__MapRepresentation_Stringpairs_EntryDelim
+		ma.state = maState_midValue
+		ma.f = 1
+		ma.ca_entryDelim.w = &ma.w.entryDelim
+		ma.ca_entryDelim.m = &ma.cm
+		return &ma.ca_entryDelim, nil
+	default:
+	}
+	return nil, ipld.ErrInvalidKey{TypeName: "schemadmt.MapRepresentation_Stringpairs.Repr", Key: &_String{k}}
+}
+func (ma *_MapRepresentation_Stringpairs__ReprAssembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_MapRepresentation_Stringpairs__ReprKeyAssembler)(ma)
+}
+func (ma *_MapRepresentation_Stringpairs__ReprAssembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	case 0:
+		ma.ca_innerDelim.w = &ma.w.innerDelim
+		ma.ca_innerDelim.m = &ma.cm
+		return &ma.ca_innerDelim
+	case 1:
+		ma.ca_entryDelim.w = &ma.w.entryDelim
+		ma.ca_entryDelim.m = &ma.cm
+		return &ma.ca_entryDelim
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_MapRepresentation_Stringpairs__ReprAssembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__MapRepresentation_Stringpairs_sufficient != fieldBits__MapRepresentation_Stringpairs_sufficient {
+		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		if ma.s&fieldBit__MapRepresentation_Stringpairs_InnerDelim == 0 {
+			err.Missing = append(err.Missing, "innerDelim")
+		}
+		if ma.s&fieldBit__MapRepresentation_Stringpairs_EntryDelim == 0 {
+			err.Missing = append(err.Missing, "entryDelim")
+		}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_MapRepresentation_Stringpairs__ReprAssembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_MapRepresentation_Stringpairs__ReprAssembler) ValuePrototype(k string) ipld.NodePrototype {
+	panic("todo structbuilder mapassembler repr valueprototype")
+}
+
+type _MapRepresentation_Stringpairs__ReprKeyAssembler _MapRepresentation_Stringpairs__ReprAssembler
+
+func (_MapRepresentation_Stringpairs__ReprKeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.MapRepresentation_Stringpairs.Repr.KeyAssembler"}.BeginMap(0)
+}
+func (_MapRepresentation_Stringpairs__ReprKeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.MapRepresentation_Stringpairs.Repr.KeyAssembler"}.BeginList(0)
+}
+func (na *_MapRepresentation_Stringpairs__ReprKeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.MapRepresentation_Stringpairs.Repr.KeyAssembler"}.AssignNull()
+}
+func (_MapRepresentation_Stringpairs__ReprKeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.MapRepresentation_Stringpairs.Repr.KeyAssembler"}.AssignBool(false)
+}
+func (_MapRepresentation_Stringpairs__ReprKeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.MapRepresentation_Stringpairs.Repr.KeyAssembler"}.AssignInt(0)
+}
+func (_MapRepresentation_Stringpairs__ReprKeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.MapRepresentation_Stringpairs.Repr.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_MapRepresentation_Stringpairs__ReprKeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	switch k {
+	case "innerDelim":
+		if ka.s&fieldBit__MapRepresentation_Stringpairs_InnerDelim != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__MapRepresentation_Stringpairs_InnerDelim_serial}
+		}
+		ka.s += fieldBit__MapRepresentation_Stringpairs_InnerDelim
+		ka.state = maState_expectValue
+		ka.f = 0
+		return nil
+	case "entryDelim":
+		if ka.s&fieldBit__MapRepresentation_Stringpairs_EntryDelim != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__MapRepresentation_Stringpairs_EntryDelim_serial}
+		}
+		ka.s += fieldBit__MapRepresentation_Stringpairs_EntryDelim
+		ka.state = maState_expectValue
+		ka.f = 1
+		return nil
+	}
+	return ipld.ErrInvalidKey{TypeName: "schemadmt.MapRepresentation_Stringpairs.Repr", Key: &_String{k}}
+}
+func (_MapRepresentation_Stringpairs__ReprKeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.MapRepresentation_Stringpairs.Repr.KeyAssembler"}.AssignBytes(nil)
+}
+func (_MapRepresentation_Stringpairs__ReprKeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.MapRepresentation_Stringpairs.Repr.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_MapRepresentation_Stringpairs__ReprKeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_MapRepresentation_Stringpairs__ReprKeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
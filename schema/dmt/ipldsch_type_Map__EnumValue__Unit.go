@@ -0,0 +1,794 @@
+package schemadmt
+
+// Code generated by go-ipld-prime gengo.  DO NOT EDIT.
+
+import (
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// Map__EnumValue__Unit matches the IPLD Schema type "Map__EnumValue__Unit".  It has map kind.
+type Map__EnumValue__Unit = *_Map__EnumValue__Unit
+type _Map__EnumValue__Unit struct {
+	m map[_EnumValue]*_Unit
+	t []_Map__EnumValue__Unit__entry
+}
+type _Map__EnumValue__Unit__entry struct {
+	k _EnumValue
+	v _Unit
+}
+
+func (n *_Map__EnumValue__Unit) Lookup(k EnumValue) Unit {
+	v, exists := n.m[*k]
+	if !exists {
+		return nil
+	}
+	return v
+}
+func (n *_Map__EnumValue__Unit) LookupMaybe(k EnumValue) MaybeUnit {
+	v, exists := n.m[*k]
+	if !exists {
+		return &_Map__EnumValue__Unit__valueAbsent
+	}
+	return &_Unit__Maybe{
+		m: schema.Maybe_Value,
+		v: v,
+	}
+}
+
+var _Map__EnumValue__Unit__valueAbsent = _Unit__Maybe{m: schema.Maybe_Absent}
+
+func (n Map__EnumValue__Unit) Iterator() *Map__EnumValue__Unit__Itr {
+	return &Map__EnumValue__Unit__Itr{n, 0}
+}
+
+type Map__EnumValue__Unit__Itr struct {
+	n   Map__EnumValue__Unit
+	idx int
+}
+
+func (itr *Map__EnumValue__Unit__Itr) Next() (k EnumValue, v Unit) {
+	if itr.idx >= len(itr.n.t) {
+		return nil, nil
+	}
+	x := &itr.n.t[itr.idx]
+	k = &x.k
+	v = &x.v
+	itr.idx++
+	return
+}
+func (itr *Map__EnumValue__Unit__Itr) Done() bool {
+	return itr.idx >= len(itr.n.t)
+}
+
+type _Map__EnumValue__Unit__Maybe struct {
+	m schema.Maybe
+	v _Map__EnumValue__Unit
+}
+type MaybeMap__EnumValue__Unit = *_Map__EnumValue__Unit__Maybe
+
+func (m MaybeMap__EnumValue__Unit) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeMap__EnumValue__Unit) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeMap__EnumValue__Unit) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeMap__EnumValue__Unit) AsNode() ipld.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return ipld.Absent
+	case schema.Maybe_Null:
+		return ipld.Null
+	case schema.Maybe_Value:
+		return &m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybeMap__EnumValue__Unit) Must() Map__EnumValue__Unit {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return &m.v
+}
+
+var _ ipld.Node = (Map__EnumValue__Unit)(&_Map__EnumValue__Unit{})
+var _ schema.TypedNode = (Map__EnumValue__Unit)(&_Map__EnumValue__Unit{})
+
+func (Map__EnumValue__Unit) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n Map__EnumValue__Unit) LookupByString(k string) (ipld.Node, error) {
+	var k2 _EnumValue
+	if err := (_EnumValue__Prototype{}).fromString(&k2, k); err != nil {
+		return nil, err
+	}
+	v, exists := n.m[k2]
+	if !exists {
+		return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(k)}
+	}
+	return v, nil
+}
+func (n Map__EnumValue__Unit) LookupByNode(k ipld.Node) (ipld.Node, error) {
+	k2, ok := k.(EnumValue)
+	if !ok {
+		ks, err := k.AsString()
+		if err != nil {
+			return nil, err
+		}
+		return n.LookupByString(ks)
+	}
+	v, exists := n.m[*k2]
+	if !exists {
+		return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(k2.String())}
+	}
+	return v, nil
+}
+func (Map__EnumValue__Unit) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.Map__EnumValue__Unit"}.LookupByIndex(0)
+}
+func (n Map__EnumValue__Unit) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n Map__EnumValue__Unit) MapIterator() ipld.MapIterator {
+	return &_Map__EnumValue__Unit__MapItr{n, 0}
+}
+
+type _Map__EnumValue__Unit__MapItr struct {
+	n   Map__EnumValue__Unit
+	idx int
+}
+
+func (itr *_Map__EnumValue__Unit__MapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	if itr.idx >= len(itr.n.t) {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	x := &itr.n.t[itr.idx]
+	k = &x.k
+	v = &x.v
+	itr.idx++
+	return
+}
+func (itr *_Map__EnumValue__Unit__MapItr) Done() bool {
+	return itr.idx >= len(itr.n.t)
+}
+
+func (Map__EnumValue__Unit) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (n Map__EnumValue__Unit) Length() int64 {
+	return int64(len(n.t))
+}
+func (Map__EnumValue__Unit) IsAbsent() bool {
+	return false
+}
+func (Map__EnumValue__Unit) IsNull() bool {
+	return false
+}
+func (Map__EnumValue__Unit) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.Map__EnumValue__Unit"}.AsBool()
+}
+func (Map__EnumValue__Unit) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.Map__EnumValue__Unit"}.AsInt()
+}
+func (Map__EnumValue__Unit) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.Map__EnumValue__Unit"}.AsFloat()
+}
+func (Map__EnumValue__Unit) AsString() (string, error) {
+	return mixins.Map{"schemadmt.Map__EnumValue__Unit"}.AsString()
+}
+func (Map__EnumValue__Unit) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.Map__EnumValue__Unit"}.AsBytes()
+}
+func (Map__EnumValue__Unit) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.Map__EnumValue__Unit"}.AsLink()
+}
+func (Map__EnumValue__Unit) Prototype() ipld.NodePrototype {
+	return _Map__EnumValue__Unit__Prototype{}
+}
+
+type _Map__EnumValue__Unit__Prototype struct{}
+
+func (_Map__EnumValue__Unit__Prototype) NewBuilder() ipld.NodeBuilder {
+	var nb _Map__EnumValue__Unit__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _Map__EnumValue__Unit__Builder struct {
+	_Map__EnumValue__Unit__Assembler
+}
+
+func (nb *_Map__EnumValue__Unit__Builder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_Map__EnumValue__Unit__Builder) Reset() {
+	var w _Map__EnumValue__Unit
+	var m schema.Maybe
+	*nb = _Map__EnumValue__Unit__Builder{_Map__EnumValue__Unit__Assembler{w: &w, m: &m}}
+}
+
+type _Map__EnumValue__Unit__Assembler struct {
+	w     *_Map__EnumValue__Unit
+	m     *schema.Maybe
+	state maState
+
+	cm schema.Maybe
+	ka _EnumValue__Assembler
+	va _Unit__Assembler
+}
+
+func (na *_Map__EnumValue__Unit__Assembler) reset() {
+	na.state = maState_initial
+	na.ka.reset()
+	na.va.reset()
+}
+func (na *_Map__EnumValue__Unit__Assembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if sizeHint < 0 {
+		sizeHint = 0
+	}
+	na.w.m = make(map[_EnumValue]*_Unit, sizeHint)
+	na.w.t = make([]_Map__EnumValue__Unit__entry, 0, sizeHint)
+	return na, nil
+}
+func (_Map__EnumValue__Unit__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.Map__EnumValue__Unit"}.BeginList(0)
+}
+func (na *_Map__EnumValue__Unit__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.Map__EnumValue__Unit"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_Map__EnumValue__Unit__Assembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.Map__EnumValue__Unit"}.AssignBool(false)
+}
+func (_Map__EnumValue__Unit__Assembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.Map__EnumValue__Unit"}.AssignInt(0)
+}
+func (_Map__EnumValue__Unit__Assembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.Map__EnumValue__Unit"}.AssignFloat(0)
+}
+func (_Map__EnumValue__Unit__Assembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.Map__EnumValue__Unit"}.AssignString("")
+}
+func (_Map__EnumValue__Unit__Assembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.Map__EnumValue__Unit"}.AssignBytes(nil)
+}
+func (_Map__EnumValue__Unit__Assembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.Map__EnumValue__Unit"}.AssignLink(nil)
+}
+func (na *_Map__EnumValue__Unit__Assembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	v2, ok := v.(*_Map__EnumValue__Unit)
+	if ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.Map__EnumValue__Unit", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_Map__EnumValue__Unit__Assembler) Prototype() ipld.NodePrototype {
+	return _Map__EnumValue__Unit__Prototype{}
+}
+func (ma *_Map__EnumValue__Unit__Assembler) keyFinishTidy() bool {
+	switch ma.cm {
+	case schema.Maybe_Value:
+		ma.ka.w = nil
+		tz := &ma.w.t[len(ma.w.t)-1]
+		ma.cm = schema.Maybe_Absent
+		ma.state = maState_expectValue
+		ma.w.m[tz.k] = &tz.v
+		ma.va.w = &tz.v
+		ma.va.m = &ma.cm
+		ma.ka.reset()
+		return true
+	default:
+		return false
+	}
+}
+func (ma *_Map__EnumValue__Unit__Assembler) valueFinishTidy() bool {
+	switch ma.cm {
+	case schema.Maybe_Value:
+		ma.va.w = nil
+		ma.cm = schema.Maybe_Absent
+		ma.state = maState_initial
+		ma.va.reset()
+		return true
+	default:
+		return false
+	}
+}
+func (ma *_Map__EnumValue__Unit__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+
+	var k2 _EnumValue
+	if err := (_EnumValue__Prototype{}).fromString(&k2, k); err != nil {
+		return nil, err
+	}
+	if _, exists := ma.w.m[k2]; exists {
+		return nil, ipld.ErrRepeatedMapKey{Key: &k2}
+	}
+	ma.w.t = append(ma.w.t, _Map__EnumValue__Unit__entry{k: k2})
+	tz := &ma.w.t[len(ma.w.t)-1]
+	ma.state = maState_midValue
+
+	ma.w.m[k2] = &tz.v
+	ma.va.w = &tz.v
+	ma.va.m = &ma.cm
+	return &ma.va, nil
+}
+func (ma *_Map__EnumValue__Unit__Assembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.w.t = append(ma.w.t, _Map__EnumValue__Unit__entry{})
+	ma.state = maState_midKey
+	ma.ka.m = &ma.cm
+	ma.ka.w = &ma.w.t[len(ma.w.t)-1].k
+	return &ma.ka
+}
+func (ma *_Map__EnumValue__Unit__Assembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		if !ma.keyFinishTidy() {
+			panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+		} // if tidy success: carry on
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	return &ma.va
+}
+func (ma *_Map__EnumValue__Unit__Assembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_Map__EnumValue__Unit__Assembler) KeyPrototype() ipld.NodePrototype {
+	return _EnumValue__Prototype{}
+}
+func (ma *_Map__EnumValue__Unit__Assembler) ValuePrototype(_ string) ipld.NodePrototype {
+	return _Unit__Prototype{}
+}
+func (Map__EnumValue__Unit) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (n Map__EnumValue__Unit) Representation() ipld.Node {
+	return (*_Map__EnumValue__Unit__Repr)(n)
+}
+
+type _Map__EnumValue__Unit__Repr _Map__EnumValue__Unit
+
+var _ ipld.Node = &_Map__EnumValue__Unit__Repr{}
+
+func (_Map__EnumValue__Unit__Repr) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (nr *_Map__EnumValue__Unit__Repr) LookupByString(k string) (ipld.Node, error) {
+	var k2 _EnumValue
+	if err := (_EnumValue__ReprPrototype{}).fromString(&k2, k); err != nil {
+		return nil, err
+	}
+	v, err := (Map__EnumValue__Unit)(nr).LookupByNode(&k2)
+	if err != nil || v == ipld.Null {
+		return v, err
+	}
+	return v.(Unit).Representation(), nil
+}
+func (nr *_Map__EnumValue__Unit__Repr) LookupByNode(k ipld.Node) (ipld.Node, error) {
+	if _, ok := k.(EnumValue); !ok {
+		if ks, err := k.AsString(); err == nil {
+			return nr.LookupByString(ks)
+		}
+	}
+	v, err := (Map__EnumValue__Unit)(nr).LookupByNode(k)
+	if err != nil || v == ipld.Null {
+		return v, err
+	}
+	return v.(Unit).Representation(), nil
+}
+func (_Map__EnumValue__Unit__Repr) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.Map__EnumValue__Unit.Repr"}.LookupByIndex(0)
+}
+func (n _Map__EnumValue__Unit__Repr) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (nr *_Map__EnumValue__Unit__Repr) MapIterator() ipld.MapIterator {
+	return &_Map__EnumValue__Unit__ReprMapItr{(Map__EnumValue__Unit)(nr), 0}
+}
+
+type _Map__EnumValue__Unit__ReprMapItr _Map__EnumValue__Unit__MapItr
+
+func (itr *_Map__EnumValue__Unit__ReprMapItr) Next() (k ipld.Node, v ipld.Node, err error) {
+	k, v, err = (*_Map__EnumValue__Unit__MapItr)(itr).Next()
+	if err != nil {
+		return
+	}
+	k = k.(EnumValue).Representation()
+	if v == ipld.Null {
+		return
+	}
+	return k, v.(Unit).Representation(), nil
+}
+func (itr *_Map__EnumValue__Unit__ReprMapItr) Done() bool {
+	return (*_Map__EnumValue__Unit__MapItr)(itr).Done()
+}
+
+func (_Map__EnumValue__Unit__Repr) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (rn *_Map__EnumValue__Unit__Repr) Length() int64 {
+	return int64(len(rn.t))
+}
+func (_Map__EnumValue__Unit__Repr) IsAbsent() bool {
+	return false
+}
+func (_Map__EnumValue__Unit__Repr) IsNull() bool {
+	return false
+}
+func (_Map__EnumValue__Unit__Repr) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.Map__EnumValue__Unit.Repr"}.AsBool()
+}
+func (_Map__EnumValue__Unit__Repr) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.Map__EnumValue__Unit.Repr"}.AsInt()
+}
+func (_Map__EnumValue__Unit__Repr) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.Map__EnumValue__Unit.Repr"}.AsFloat()
+}
+func (_Map__EnumValue__Unit__Repr) AsString() (string, error) {
+	return mixins.Map{"schemadmt.Map__EnumValue__Unit.Repr"}.AsString()
+}
+func (_Map__EnumValue__Unit__Repr) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.Map__EnumValue__Unit.Repr"}.AsBytes()
+}
+func (_Map__EnumValue__Unit__Repr) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.Map__EnumValue__Unit.Repr"}.AsLink()
+}
+func (_Map__EnumValue__Unit__Repr) Prototype() ipld.NodePrototype {
+	return _Map__EnumValue__Unit__ReprPrototype{}
+}
+
+type _Map__EnumValue__Unit__ReprPrototype struct{}
+
+func (_Map__EnumValue__Unit__ReprPrototype) NewBuilder() ipld.NodeBuilder {
+	var nb _Map__EnumValue__Unit__ReprBuilder
+	nb.Reset()
+	return &nb
+}
+
+type _Map__EnumValue__Unit__ReprBuilder struct {
+	_Map__EnumValue__Unit__ReprAssembler
+}
+
+func (nb *_Map__EnumValue__Unit__ReprBuilder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_Map__EnumValue__Unit__ReprBuilder) Reset() {
+	var w _Map__EnumValue__Unit
+	var m schema.Maybe
+	*nb = _Map__EnumValue__Unit__ReprBuilder{_Map__EnumValue__Unit__ReprAssembler{w: &w, m: &m}}
+}
+
+type _Map__EnumValue__Unit__ReprAssembler struct {
+	w     *_Map__EnumValue__Unit
+	m     *schema.Maybe
+	state maState
+
+	cm schema.Maybe
+	ka _EnumValue__ReprAssembler
+	va _Unit__ReprAssembler
+}
+
+func (na *_Map__EnumValue__Unit__ReprAssembler) reset() {
+	na.state = maState_initial
+	na.ka.reset()
+	na.va.reset()
+}
+func (na *_Map__EnumValue__Unit__ReprAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if sizeHint < 0 {
+		sizeHint = 0
+	}
+	na.w.m = make(map[_EnumValue]*_Unit, sizeHint)
+	na.w.t = make([]_Map__EnumValue__Unit__entry, 0, sizeHint)
+	return na, nil
+}
+func (_Map__EnumValue__Unit__ReprAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.Map__EnumValue__Unit.Repr"}.BeginList(0)
+}
+func (na *_Map__EnumValue__Unit__ReprAssembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.Map__EnumValue__Unit.Repr.Repr"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_Map__EnumValue__Unit__ReprAssembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.Map__EnumValue__Unit.Repr"}.AssignBool(false)
+}
+func (_Map__EnumValue__Unit__ReprAssembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.Map__EnumValue__Unit.Repr"}.AssignInt(0)
+}
+func (_Map__EnumValue__Unit__ReprAssembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.Map__EnumValue__Unit.Repr"}.AssignFloat(0)
+}
+func (_Map__EnumValue__Unit__ReprAssembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.Map__EnumValue__Unit.Repr"}.AssignString("")
+}
+func (_Map__EnumValue__Unit__ReprAssembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.Map__EnumValue__Unit.Repr"}.AssignBytes(nil)
+}
+func (_Map__EnumValue__Unit__ReprAssembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.Map__EnumValue__Unit.Repr"}.AssignLink(nil)
+}
+func (na *_Map__EnumValue__Unit__ReprAssembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	v2, ok := v.(*_Map__EnumValue__Unit)
+	if v3, ok3 := v.(*_Map__EnumValue__Unit__Repr); ok3 {
+		v2, ok = (*_Map__EnumValue__Unit)(v3), true
+	}
+	if ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.Map__EnumValue__Unit.Repr", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_Map__EnumValue__Unit__ReprAssembler) Prototype() ipld.NodePrototype {
+	return _Map__EnumValue__Unit__ReprPrototype{}
+}
+func (ma *_Map__EnumValue__Unit__ReprAssembler) keyFinishTidy() bool {
+	switch ma.cm {
+	case schema.Maybe_Value:
+		ma.ka.w = nil
+		tz := &ma.w.t[len(ma.w.t)-1]
+		ma.cm = schema.Maybe_Absent
+		ma.state = maState_expectValue
+		ma.w.m[tz.k] = &tz.v
+		ma.va.w = &tz.v
+		ma.va.m = &ma.cm
+		ma.ka.reset()
+		return true
+	default:
+		return false
+	}
+}
+func (ma *_Map__EnumValue__Unit__ReprAssembler) valueFinishTidy() bool {
+	switch ma.cm {
+	case schema.Maybe_Value:
+		ma.va.w = nil
+		ma.cm = schema.Maybe_Absent
+		ma.state = maState_initial
+		ma.va.reset()
+		return true
+	default:
+		return false
+	}
+}
+func (ma *_Map__EnumValue__Unit__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+
+	var k2 _EnumValue
+	if err := (_EnumValue__ReprPrototype{}).fromString(&k2, k); err != nil {
+		return nil, err
+	}
+	if _, exists := ma.w.m[k2]; exists {
+		return nil, ipld.ErrRepeatedMapKey{Key: &k2}
+	}
+	ma.w.t = append(ma.w.t, _Map__EnumValue__Unit__entry{k: k2})
+	tz := &ma.w.t[len(ma.w.t)-1]
+	ma.state = maState_midValue
+
+	ma.w.m[k2] = &tz.v
+	ma.va.w = &tz.v
+	ma.va.m = &ma.cm
+	return &ma.va, nil
+}
+func (ma *_Map__EnumValue__Unit__ReprAssembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.w.t = append(ma.w.t, _Map__EnumValue__Unit__entry{})
+	ma.state = maState_midKey
+	ma.ka.m = &ma.cm
+	ma.ka.w = &ma.w.t[len(ma.w.t)-1].k
+	return &ma.ka
+}
+func (ma *_Map__EnumValue__Unit__ReprAssembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		if !ma.keyFinishTidy() {
+			panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+		} // if tidy success: carry on
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	return &ma.va
+}
+func (ma *_Map__EnumValue__Unit__ReprAssembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_Map__EnumValue__Unit__ReprAssembler) KeyPrototype() ipld.NodePrototype {
+	return _EnumValue__ReprPrototype{}
+}
+func (ma *_Map__EnumValue__Unit__ReprAssembler) ValuePrototype(_ string) ipld.NodePrototype {
+	return _Unit__ReprPrototype{}
+}
@@ -0,0 +1,223 @@
+package schemadmt
+
+// Code generated by go-ipld-prime gengo.  DO NOT EDIT.
+
+import (
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// RepresentationKind matches the IPLD Schema type "RepresentationKind".  It has string kind.
+type RepresentationKind = *_RepresentationKind
+type _RepresentationKind struct{ x string }
+
+// String returns the value as a native string.
+func (n RepresentationKind) String() string {
+	return n.x
+}
+func (_RepresentationKind__Prototype) fromString(w *_RepresentationKind, v string) error {
+	*w = _RepresentationKind{v}
+	return nil
+}
+func (_RepresentationKind__Prototype) FromString(v string) (RepresentationKind, error) {
+	n := _RepresentationKind{v}
+	return &n, nil
+}
+
+type _RepresentationKind__Maybe struct {
+	m schema.Maybe
+	v _RepresentationKind
+}
+type MaybeRepresentationKind = *_RepresentationKind__Maybe
+
+func (m MaybeRepresentationKind) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeRepresentationKind) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeRepresentationKind) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeRepresentationKind) AsNode() ipld.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return ipld.Absent
+	case schema.Maybe_Null:
+		return ipld.Null
+	case schema.Maybe_Value:
+		return &m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybeRepresentationKind) Must() RepresentationKind {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return &m.v
+}
+
+var _ ipld.Node = (RepresentationKind)(&_RepresentationKind{})
+var _ schema.TypedNode = (RepresentationKind)(&_RepresentationKind{})
+
+func (RepresentationKind) Kind() ipld.Kind {
+	return ipld.Kind_String
+}
+func (RepresentationKind) LookupByString(string) (ipld.Node, error) {
+	return mixins.String{"schemadmt.RepresentationKind"}.LookupByString("")
+}
+func (RepresentationKind) LookupByNode(ipld.Node) (ipld.Node, error) {
+	return mixins.String{"schemadmt.RepresentationKind"}.LookupByNode(nil)
+}
+func (RepresentationKind) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.String{"schemadmt.RepresentationKind"}.LookupByIndex(0)
+}
+func (RepresentationKind) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return mixins.String{"schemadmt.RepresentationKind"}.LookupBySegment(seg)
+}
+func (RepresentationKind) MapIterator() ipld.MapIterator {
+	return nil
+}
+func (RepresentationKind) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (RepresentationKind) Length() int64 {
+	return -1
+}
+func (RepresentationKind) IsAbsent() bool {
+	return false
+}
+func (RepresentationKind) IsNull() bool {
+	return false
+}
+func (RepresentationKind) AsBool() (bool, error) {
+	return mixins.String{"schemadmt.RepresentationKind"}.AsBool()
+}
+func (RepresentationKind) AsInt() (int64, error) {
+	return mixins.String{"schemadmt.RepresentationKind"}.AsInt()
+}
+func (RepresentationKind) AsFloat() (float64, error) {
+	return mixins.String{"schemadmt.RepresentationKind"}.AsFloat()
+}
+func (n RepresentationKind) AsString() (string, error) {
+	return n.x, nil
+}
+func (RepresentationKind) AsBytes() ([]byte, error) {
+	return mixins.String{"schemadmt.RepresentationKind"}.AsBytes()
+}
+func (RepresentationKind) AsLink() (ipld.Link, error) {
+	return mixins.String{"schemadmt.RepresentationKind"}.AsLink()
+}
+func (RepresentationKind) Prototype() ipld.NodePrototype {
+	return _RepresentationKind__Prototype{}
+}
+
+type _RepresentationKind__Prototype struct{}
+
+func (_RepresentationKind__Prototype) NewBuilder() ipld.NodeBuilder {
+	var nb _RepresentationKind__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _RepresentationKind__Builder struct {
+	_RepresentationKind__Assembler
+}
+
+func (nb *_RepresentationKind__Builder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_RepresentationKind__Builder) Reset() {
+	var w _RepresentationKind
+	var m schema.Maybe
+	*nb = _RepresentationKind__Builder{_RepresentationKind__Assembler{w: &w, m: &m}}
+}
+
+type _RepresentationKind__Assembler struct {
+	w *_RepresentationKind
+	m *schema.Maybe
+}
+
+func (na *_RepresentationKind__Assembler) reset() {}
+func (_RepresentationKind__Assembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.RepresentationKind"}.BeginMap(0)
+}
+func (_RepresentationKind__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.RepresentationKind"}.BeginList(0)
+}
+func (na *_RepresentationKind__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.StringAssembler{"schemadmt.RepresentationKind"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	}
+	panic("unreachable")
+}
+func (_RepresentationKind__Assembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.RepresentationKind"}.AssignBool(false)
+}
+func (_RepresentationKind__Assembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.RepresentationKind"}.AssignInt(0)
+}
+func (_RepresentationKind__Assembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.RepresentationKind"}.AssignFloat(0)
+}
+func (na *_RepresentationKind__Assembler) AssignString(v string) error {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	}
+	na.w.x = v
+	*na.m = schema.Maybe_Value
+	return nil
+}
+func (_RepresentationKind__Assembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.RepresentationKind"}.AssignBytes(nil)
+}
+func (_RepresentationKind__Assembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.RepresentationKind"}.AssignLink(nil)
+}
+func (na *_RepresentationKind__Assembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_RepresentationKind); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return na.AssignString(v2)
+	}
+}
+func (_RepresentationKind__Assembler) Prototype() ipld.NodePrototype {
+	return _RepresentationKind__Prototype{}
+}
+func (RepresentationKind) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (n RepresentationKind) Representation() ipld.Node {
+	return (*_RepresentationKind__Repr)(n)
+}
+
+type _RepresentationKind__Repr = _RepresentationKind
+
+var _ ipld.Node = &_RepresentationKind__Repr{}
+
+type _RepresentationKind__ReprPrototype = _RepresentationKind__Prototype
+type _RepresentationKind__ReprAssembler = _RepresentationKind__Assembler
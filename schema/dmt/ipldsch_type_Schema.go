@@ -0,0 +1,856 @@
+package schemadmt
+
+// Code generated by go-ipld-prime gengo.  DO NOT EDIT.
+
+import (
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// Schema matches the IPLD Schema type "Schema".  It has Struct type-kind, and may be interrogated like map kind.
+type Schema = *_Schema
+type _Schema struct {
+	types _SchemaMap
+}
+
+// FieldTypes returns the value of the field "types".
+func (n _Schema) FieldTypes() SchemaMap {
+	return &n.types
+}
+
+type _Schema__Maybe struct {
+	m schema.Maybe
+	v Schema
+}
+type MaybeSchema = *_Schema__Maybe
+
+func (m MaybeSchema) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeSchema) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeSchema) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeSchema) AsNode() ipld.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return ipld.Absent
+	case schema.Maybe_Null:
+		return ipld.Null
+	case schema.Maybe_Value:
+		return m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybeSchema) Must() Schema {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return m.v
+}
+
+var (
+	fieldName__Schema_Types = _String{"types"}
+)
+var _ ipld.Node = (Schema)(&_Schema{})
+var _ schema.TypedNode = (Schema)(&_Schema{})
+
+func (Schema) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n Schema) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	case "types":
+		return &n.types, nil
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n Schema) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (Schema) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.Schema"}.LookupByIndex(0)
+}
+func (n Schema) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n Schema) MapIterator() ipld.MapIterator {
+	return &_Schema__MapItr{n, 0}
+}
+
+type _Schema__MapItr struct {
+	n   Schema
+	idx int
+}
+
+func (itr *_Schema__MapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	if itr.idx >= 1 {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	switch itr.idx {
+	case 0:
+		k = &fieldName__Schema_Types
+		v = &itr.n.types
+	default:
+		panic("unreachable")
+	}
+	itr.idx++
+	return
+}
+func (itr *_Schema__MapItr) Done() bool {
+	return itr.idx >= 1
+}
+
+func (Schema) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (Schema) Length() int64 {
+	return 1
+}
+func (Schema) IsAbsent() bool {
+	return false
+}
+func (Schema) IsNull() bool {
+	return false
+}
+func (Schema) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.Schema"}.AsBool()
+}
+func (Schema) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.Schema"}.AsInt()
+}
+func (Schema) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.Schema"}.AsFloat()
+}
+func (Schema) AsString() (string, error) {
+	return mixins.Map{"schemadmt.Schema"}.AsString()
+}
+func (Schema) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.Schema"}.AsBytes()
+}
+func (Schema) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.Schema"}.AsLink()
+}
+func (Schema) Prototype() ipld.NodePrototype {
+	return _Schema__Prototype{}
+}
+
+type _Schema__Prototype struct{}
+
+func (_Schema__Prototype) NewBuilder() ipld.NodeBuilder {
+	var nb _Schema__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _Schema__Builder struct {
+	_Schema__Assembler
+}
+
+func (nb *_Schema__Builder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_Schema__Builder) Reset() {
+	var w _Schema
+	var m schema.Maybe
+	*nb = _Schema__Builder{_Schema__Assembler{w: &w, m: &m}}
+}
+
+type _Schema__Assembler struct {
+	w     *_Schema
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm       schema.Maybe
+	ca_types _SchemaMap__Assembler
+}
+
+func (na *_Schema__Assembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+	na.ca_types.reset()
+}
+
+var (
+	fieldBit__Schema_Types       = 1 << 0
+	fieldBits__Schema_sufficient = 0 + 1<<0
+)
+
+func (na *_Schema__Assembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_Schema{}
+	}
+	return na, nil
+}
+func (_Schema__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.Schema"}.BeginList(0)
+}
+func (na *_Schema__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.Schema"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_Schema__Assembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.Schema"}.AssignBool(false)
+}
+func (_Schema__Assembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.Schema"}.AssignInt(0)
+}
+func (_Schema__Assembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.Schema"}.AssignFloat(0)
+}
+func (_Schema__Assembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.Schema"}.AssignString("")
+}
+func (_Schema__Assembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.Schema"}.AssignBytes(nil)
+}
+func (_Schema__Assembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.Schema"}.AssignLink(nil)
+}
+func (na *_Schema__Assembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_Schema); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.Schema", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_Schema__Assembler) Prototype() ipld.NodePrototype {
+	return _Schema__Prototype{}
+}
+func (ma *_Schema__Assembler) valueFinishTidy() bool {
+	switch ma.f {
+	case 0:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.ca_types.w = nil
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_Schema__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	switch k {
+	case "types":
+		if ma.s&fieldBit__Schema_Types != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__Schema_Types}
+		}
+		ma.s += fieldBit__Schema_Types
+		ma.state = maState_midValue
+		ma.f = 0
+		ma.ca_types.w = &ma.w.types
+		ma.ca_types.m = &ma.cm
+		return &ma.ca_types, nil
+	}
+	return nil, ipld.ErrInvalidKey{TypeName: "schemadmt.Schema", Key: &_String{k}}
+}
+func (ma *_Schema__Assembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_Schema__KeyAssembler)(ma)
+}
+func (ma *_Schema__Assembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	case 0:
+		ma.ca_types.w = &ma.w.types
+		ma.ca_types.m = &ma.cm
+		return &ma.ca_types
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_Schema__Assembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__Schema_sufficient != fieldBits__Schema_sufficient {
+		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		if ma.s&fieldBit__Schema_Types == 0 {
+			err.Missing = append(err.Missing, "types")
+		}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_Schema__Assembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_Schema__Assembler) ValuePrototype(k string) ipld.NodePrototype {
+	panic("todo structbuilder mapassembler valueprototype")
+}
+
+type _Schema__KeyAssembler _Schema__Assembler
+
+func (_Schema__KeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.Schema.KeyAssembler"}.BeginMap(0)
+}
+func (_Schema__KeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.Schema.KeyAssembler"}.BeginList(0)
+}
+func (na *_Schema__KeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.Schema.KeyAssembler"}.AssignNull()
+}
+func (_Schema__KeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.Schema.KeyAssembler"}.AssignBool(false)
+}
+func (_Schema__KeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.Schema.KeyAssembler"}.AssignInt(0)
+}
+func (_Schema__KeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.Schema.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_Schema__KeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	switch k {
+	case "types":
+		if ka.s&fieldBit__Schema_Types != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__Schema_Types}
+		}
+		ka.s += fieldBit__Schema_Types
+		ka.state = maState_expectValue
+		ka.f = 0
+	default:
+		return ipld.ErrInvalidKey{TypeName: "schemadmt.Schema", Key: &_String{k}}
+	}
+	return nil
+}
+func (_Schema__KeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.Schema.KeyAssembler"}.AssignBytes(nil)
+}
+func (_Schema__KeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.Schema.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_Schema__KeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_Schema__KeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (Schema) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (n Schema) Representation() ipld.Node {
+	return (*_Schema__Repr)(n)
+}
+
+type _Schema__Repr _Schema
+
+var (
+	fieldName__Schema_Types_serial = _String{"types"}
+)
+var _ ipld.Node = &_Schema__Repr{}
+
+func (_Schema__Repr) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n *_Schema__Repr) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	case "types":
+		return n.types.Representation(), nil
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n *_Schema__Repr) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (_Schema__Repr) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.Schema.Repr"}.LookupByIndex(0)
+}
+func (n _Schema__Repr) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n *_Schema__Repr) MapIterator() ipld.MapIterator {
+	return &_Schema__ReprMapItr{n, 0}
+}
+
+type _Schema__ReprMapItr struct {
+	n   *_Schema__Repr
+	idx int
+}
+
+func (itr *_Schema__ReprMapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	if itr.idx >= 1 {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	switch itr.idx {
+	case 0:
+		k = &fieldName__Schema_Types_serial
+		v = itr.n.types.Representation()
+	default:
+		panic("unreachable")
+	}
+	itr.idx++
+	return
+}
+func (itr *_Schema__ReprMapItr) Done() bool {
+	return itr.idx >= 1
+}
+func (_Schema__Repr) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (rn *_Schema__Repr) Length() int64 {
+	l := 1
+	return int64(l)
+}
+func (_Schema__Repr) IsAbsent() bool {
+	return false
+}
+func (_Schema__Repr) IsNull() bool {
+	return false
+}
+func (_Schema__Repr) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.Schema.Repr"}.AsBool()
+}
+func (_Schema__Repr) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.Schema.Repr"}.AsInt()
+}
+func (_Schema__Repr) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.Schema.Repr"}.AsFloat()
+}
+func (_Schema__Repr) AsString() (string, error) {
+	return mixins.Map{"schemadmt.Schema.Repr"}.AsString()
+}
+func (_Schema__Repr) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.Schema.Repr"}.AsBytes()
+}
+func (_Schema__Repr) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.Schema.Repr"}.AsLink()
+}
+func (_Schema__Repr) Prototype() ipld.NodePrototype {
+	return _Schema__ReprPrototype{}
+}
+
+type _Schema__ReprPrototype struct{}
+
+func (_Schema__ReprPrototype) NewBuilder() ipld.NodeBuilder {
+	var nb _Schema__ReprBuilder
+	nb.Reset()
+	return &nb
+}
+
+type _Schema__ReprBuilder struct {
+	_Schema__ReprAssembler
+}
+
+func (nb *_Schema__ReprBuilder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_Schema__ReprBuilder) Reset() {
+	var w _Schema
+	var m schema.Maybe
+	*nb = _Schema__ReprBuilder{_Schema__ReprAssembler{w: &w, m: &m}}
+}
+
+type _Schema__ReprAssembler struct {
+	w     *_Schema
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm       schema.Maybe
+	ca_types _SchemaMap__ReprAssembler
+}
+
+func (na *_Schema__ReprAssembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+	na.ca_types.reset()
+}
+func (na *_Schema__ReprAssembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_Schema{}
+	}
+	return na, nil
+}
+func (_Schema__ReprAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.Schema.Repr"}.BeginList(0)
+}
+func (na *_Schema__ReprAssembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.Schema.Repr.Repr"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_Schema__ReprAssembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.Schema.Repr"}.AssignBool(false)
+}
+func (_Schema__ReprAssembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.Schema.Repr"}.AssignInt(0)
+}
+func (_Schema__ReprAssembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.Schema.Repr"}.AssignFloat(0)
+}
+func (_Schema__ReprAssembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.Schema.Repr"}.AssignString("")
+}
+func (_Schema__ReprAssembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.Schema.Repr"}.AssignBytes(nil)
+}
+func (_Schema__ReprAssembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.Schema.Repr"}.AssignLink(nil)
+}
+func (na *_Schema__ReprAssembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	v2, ok := v.(*_Schema)
+	if v3, ok3 := v.(*_Schema__Repr); ok3 {
+		v2, ok = (*_Schema)(v3), true
+	}
+	if ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.Schema.Repr", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_Schema__ReprAssembler) Prototype() ipld.NodePrototype {
+	return _Schema__ReprPrototype{}
+}
+func (ma *_Schema__ReprAssembler) valueFinishTidy() bool {
+	switch ma.f {
+	case 0:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_Schema__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	switch k {
+	case "types":
+		if ma.s&fieldBit__Schema_Types != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__Schema_Types_serial}
+		}
+		ma.s += fieldBit__Schema_Types
+		ma.state = maState_midValue
+		ma.f = 0
+		ma.ca_types.w = &ma.w.types
+		ma.ca_types.m = &ma.cm
+		return &ma.ca_types, nil
+	default:
+	}
+	return nil, ipld.ErrInvalidKey{TypeName: "schemadmt.Schema.Repr", Key: &_String{k}}
+}
+func (ma *_Schema__ReprAssembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_Schema__ReprKeyAssembler)(ma)
+}
+func (ma *_Schema__ReprAssembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	case 0:
+		ma.ca_types.w = &ma.w.types
+		ma.ca_types.m = &ma.cm
+		return &ma.ca_types
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_Schema__ReprAssembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__Schema_sufficient != fieldBits__Schema_sufficient {
+		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		if ma.s&fieldBit__Schema_Types == 0 {
+			err.Missing = append(err.Missing, "types")
+		}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_Schema__ReprAssembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_Schema__ReprAssembler) ValuePrototype(k string) ipld.NodePrototype {
+	panic("todo structbuilder mapassembler repr valueprototype")
+}
+
+type _Schema__ReprKeyAssembler _Schema__ReprAssembler
+
+func (_Schema__ReprKeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.Schema.Repr.KeyAssembler"}.BeginMap(0)
+}
+func (_Schema__ReprKeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.Schema.Repr.KeyAssembler"}.BeginList(0)
+}
+func (na *_Schema__ReprKeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.Schema.Repr.KeyAssembler"}.AssignNull()
+}
+func (_Schema__ReprKeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.Schema.Repr.KeyAssembler"}.AssignBool(false)
+}
+func (_Schema__ReprKeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.Schema.Repr.KeyAssembler"}.AssignInt(0)
+}
+func (_Schema__ReprKeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.Schema.Repr.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_Schema__ReprKeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	switch k {
+	case "types":
+		if ka.s&fieldBit__Schema_Types != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__Schema_Types_serial}
+		}
+		ka.s += fieldBit__Schema_Types
+		ka.state = maState_expectValue
+		ka.f = 0
+		return nil
+	}
+	return ipld.ErrInvalidKey{TypeName: "schemadmt.Schema.Repr", Key: &_String{k}}
+}
+func (_Schema__ReprKeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.Schema.Repr.KeyAssembler"}.AssignBytes(nil)
+}
+func (_Schema__ReprKeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.Schema.Repr.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_Schema__ReprKeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_Schema__ReprKeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
@@ -0,0 +1,738 @@
+package schemadmt
+
+// Code generated by go-ipld-prime gengo.  DO NOT EDIT.
+
+import (
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// StructRepresentation_Listpairs matches the IPLD Schema type "StructRepresentation_Listpairs".  It has Struct type-kind, and may be interrogated like map kind.
+type StructRepresentation_Listpairs = *_StructRepresentation_Listpairs
+type _StructRepresentation_Listpairs struct {
+}
+
+type _StructRepresentation_Listpairs__Maybe struct {
+	m schema.Maybe
+	v StructRepresentation_Listpairs
+}
+type MaybeStructRepresentation_Listpairs = *_StructRepresentation_Listpairs__Maybe
+
+func (m MaybeStructRepresentation_Listpairs) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeStructRepresentation_Listpairs) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeStructRepresentation_Listpairs) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeStructRepresentation_Listpairs) AsNode() ipld.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return ipld.Absent
+	case schema.Maybe_Null:
+		return ipld.Null
+	case schema.Maybe_Value:
+		return m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybeStructRepresentation_Listpairs) Must() StructRepresentation_Listpairs {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return m.v
+}
+
+var ()
+var _ ipld.Node = (StructRepresentation_Listpairs)(&_StructRepresentation_Listpairs{})
+var _ schema.TypedNode = (StructRepresentation_Listpairs)(&_StructRepresentation_Listpairs{})
+
+func (StructRepresentation_Listpairs) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n StructRepresentation_Listpairs) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n StructRepresentation_Listpairs) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (StructRepresentation_Listpairs) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Listpairs"}.LookupByIndex(0)
+}
+func (n StructRepresentation_Listpairs) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n StructRepresentation_Listpairs) MapIterator() ipld.MapIterator {
+	return &_StructRepresentation_Listpairs__MapItr{n, 0}
+}
+
+type _StructRepresentation_Listpairs__MapItr struct {
+	n   StructRepresentation_Listpairs
+	idx int
+}
+
+func (itr *_StructRepresentation_Listpairs__MapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	return nil, nil, ipld.ErrIteratorOverread{}
+
+}
+func (itr *_StructRepresentation_Listpairs__MapItr) Done() bool {
+	return itr.idx >= 0
+}
+
+func (StructRepresentation_Listpairs) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (StructRepresentation_Listpairs) Length() int64 {
+	return 0
+}
+func (StructRepresentation_Listpairs) IsAbsent() bool {
+	return false
+}
+func (StructRepresentation_Listpairs) IsNull() bool {
+	return false
+}
+func (StructRepresentation_Listpairs) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Listpairs"}.AsBool()
+}
+func (StructRepresentation_Listpairs) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Listpairs"}.AsInt()
+}
+func (StructRepresentation_Listpairs) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Listpairs"}.AsFloat()
+}
+func (StructRepresentation_Listpairs) AsString() (string, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Listpairs"}.AsString()
+}
+func (StructRepresentation_Listpairs) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Listpairs"}.AsBytes()
+}
+func (StructRepresentation_Listpairs) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Listpairs"}.AsLink()
+}
+func (StructRepresentation_Listpairs) Prototype() ipld.NodePrototype {
+	return _StructRepresentation_Listpairs__Prototype{}
+}
+
+type _StructRepresentation_Listpairs__Prototype struct{}
+
+func (_StructRepresentation_Listpairs__Prototype) NewBuilder() ipld.NodeBuilder {
+	var nb _StructRepresentation_Listpairs__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _StructRepresentation_Listpairs__Builder struct {
+	_StructRepresentation_Listpairs__Assembler
+}
+
+func (nb *_StructRepresentation_Listpairs__Builder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_StructRepresentation_Listpairs__Builder) Reset() {
+	var w _StructRepresentation_Listpairs
+	var m schema.Maybe
+	*nb = _StructRepresentation_Listpairs__Builder{_StructRepresentation_Listpairs__Assembler{w: &w, m: &m}}
+}
+
+type _StructRepresentation_Listpairs__Assembler struct {
+	w     *_StructRepresentation_Listpairs
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm schema.Maybe
+}
+
+func (na *_StructRepresentation_Listpairs__Assembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+}
+
+var (
+	fieldBits__StructRepresentation_Listpairs_sufficient = 0
+)
+
+func (na *_StructRepresentation_Listpairs__Assembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_StructRepresentation_Listpairs{}
+	}
+	return na, nil
+}
+func (_StructRepresentation_Listpairs__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Listpairs"}.BeginList(0)
+}
+func (na *_StructRepresentation_Listpairs__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.StructRepresentation_Listpairs"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_StructRepresentation_Listpairs__Assembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Listpairs"}.AssignBool(false)
+}
+func (_StructRepresentation_Listpairs__Assembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Listpairs"}.AssignInt(0)
+}
+func (_StructRepresentation_Listpairs__Assembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Listpairs"}.AssignFloat(0)
+}
+func (_StructRepresentation_Listpairs__Assembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Listpairs"}.AssignString("")
+}
+func (_StructRepresentation_Listpairs__Assembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Listpairs"}.AssignBytes(nil)
+}
+func (_StructRepresentation_Listpairs__Assembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Listpairs"}.AssignLink(nil)
+}
+func (na *_StructRepresentation_Listpairs__Assembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_StructRepresentation_Listpairs); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.StructRepresentation_Listpairs", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_StructRepresentation_Listpairs__Assembler) Prototype() ipld.NodePrototype {
+	return _StructRepresentation_Listpairs__Prototype{}
+}
+func (ma *_StructRepresentation_Listpairs__Assembler) valueFinishTidy() bool {
+	switch ma.f {
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_StructRepresentation_Listpairs__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	return nil, ipld.ErrInvalidKey{TypeName: "schemadmt.StructRepresentation_Listpairs", Key: &_String{k}}
+}
+func (ma *_StructRepresentation_Listpairs__Assembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_StructRepresentation_Listpairs__KeyAssembler)(ma)
+}
+func (ma *_StructRepresentation_Listpairs__Assembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_StructRepresentation_Listpairs__Assembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__StructRepresentation_Listpairs_sufficient != fieldBits__StructRepresentation_Listpairs_sufficient {
+		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_StructRepresentation_Listpairs__Assembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_StructRepresentation_Listpairs__Assembler) ValuePrototype(k string) ipld.NodePrototype {
+	panic("todo structbuilder mapassembler valueprototype")
+}
+
+type _StructRepresentation_Listpairs__KeyAssembler _StructRepresentation_Listpairs__Assembler
+
+func (_StructRepresentation_Listpairs__KeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Listpairs.KeyAssembler"}.BeginMap(0)
+}
+func (_StructRepresentation_Listpairs__KeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Listpairs.KeyAssembler"}.BeginList(0)
+}
+func (na *_StructRepresentation_Listpairs__KeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Listpairs.KeyAssembler"}.AssignNull()
+}
+func (_StructRepresentation_Listpairs__KeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Listpairs.KeyAssembler"}.AssignBool(false)
+}
+func (_StructRepresentation_Listpairs__KeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Listpairs.KeyAssembler"}.AssignInt(0)
+}
+func (_StructRepresentation_Listpairs__KeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Listpairs.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_StructRepresentation_Listpairs__KeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	return ipld.ErrInvalidKey{TypeName: "schemadmt.StructRepresentation_Listpairs", Key: &_String{k}}
+}
+func (_StructRepresentation_Listpairs__KeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Listpairs.KeyAssembler"}.AssignBytes(nil)
+}
+func (_StructRepresentation_Listpairs__KeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Listpairs.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_StructRepresentation_Listpairs__KeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_StructRepresentation_Listpairs__KeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (StructRepresentation_Listpairs) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (n StructRepresentation_Listpairs) Representation() ipld.Node {
+	return (*_StructRepresentation_Listpairs__Repr)(n)
+}
+
+type _StructRepresentation_Listpairs__Repr _StructRepresentation_Listpairs
+
+var ()
+var _ ipld.Node = &_StructRepresentation_Listpairs__Repr{}
+
+func (_StructRepresentation_Listpairs__Repr) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n *_StructRepresentation_Listpairs__Repr) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n *_StructRepresentation_Listpairs__Repr) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (_StructRepresentation_Listpairs__Repr) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Listpairs.Repr"}.LookupByIndex(0)
+}
+func (n _StructRepresentation_Listpairs__Repr) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n *_StructRepresentation_Listpairs__Repr) MapIterator() ipld.MapIterator {
+	return &_StructRepresentation_Listpairs__ReprMapItr{n, 0}
+}
+
+type _StructRepresentation_Listpairs__ReprMapItr struct {
+	n   *_StructRepresentation_Listpairs__Repr
+	idx int
+}
+
+func (itr *_StructRepresentation_Listpairs__ReprMapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	return nil, nil, ipld.ErrIteratorOverread{}
+
+}
+func (itr *_StructRepresentation_Listpairs__ReprMapItr) Done() bool {
+	return itr.idx >= 0
+}
+func (_StructRepresentation_Listpairs__Repr) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (rn *_StructRepresentation_Listpairs__Repr) Length() int64 {
+	l := 0
+	return int64(l)
+}
+func (_StructRepresentation_Listpairs__Repr) IsAbsent() bool {
+	return false
+}
+func (_StructRepresentation_Listpairs__Repr) IsNull() bool {
+	return false
+}
+func (_StructRepresentation_Listpairs__Repr) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Listpairs.Repr"}.AsBool()
+}
+func (_StructRepresentation_Listpairs__Repr) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Listpairs.Repr"}.AsInt()
+}
+func (_StructRepresentation_Listpairs__Repr) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Listpairs.Repr"}.AsFloat()
+}
+func (_StructRepresentation_Listpairs__Repr) AsString() (string, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Listpairs.Repr"}.AsString()
+}
+func (_StructRepresentation_Listpairs__Repr) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Listpairs.Repr"}.AsBytes()
+}
+func (_StructRepresentation_Listpairs__Repr) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Listpairs.Repr"}.AsLink()
+}
+func (_StructRepresentation_Listpairs__Repr) Prototype() ipld.NodePrototype {
+	return _StructRepresentation_Listpairs__ReprPrototype{}
+}
+
+type _StructRepresentation_Listpairs__ReprPrototype struct{}
+
+func (_StructRepresentation_Listpairs__ReprPrototype) NewBuilder() ipld.NodeBuilder {
+	var nb _StructRepresentation_Listpairs__ReprBuilder
+	nb.Reset()
+	return &nb
+}
+
+type _StructRepresentation_Listpairs__ReprBuilder struct {
+	_StructRepresentation_Listpairs__ReprAssembler
+}
+
+func (nb *_StructRepresentation_Listpairs__ReprBuilder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_StructRepresentation_Listpairs__ReprBuilder) Reset() {
+	var w _StructRepresentation_Listpairs
+	var m schema.Maybe
+	*nb = _StructRepresentation_Listpairs__ReprBuilder{_StructRepresentation_Listpairs__ReprAssembler{w: &w, m: &m}}
+}
+
+type _StructRepresentation_Listpairs__ReprAssembler struct {
+	w     *_StructRepresentation_Listpairs
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm schema.Maybe
+}
+
+func (na *_StructRepresentation_Listpairs__ReprAssembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+}
+func (na *_StructRepresentation_Listpairs__ReprAssembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_StructRepresentation_Listpairs{}
+	}
+	return na, nil
+}
+func (_StructRepresentation_Listpairs__ReprAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Listpairs.Repr"}.BeginList(0)
+}
+func (na *_StructRepresentation_Listpairs__ReprAssembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.StructRepresentation_Listpairs.Repr.Repr"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_StructRepresentation_Listpairs__ReprAssembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Listpairs.Repr"}.AssignBool(false)
+}
+func (_StructRepresentation_Listpairs__ReprAssembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Listpairs.Repr"}.AssignInt(0)
+}
+func (_StructRepresentation_Listpairs__ReprAssembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Listpairs.Repr"}.AssignFloat(0)
+}
+func (_StructRepresentation_Listpairs__ReprAssembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Listpairs.Repr"}.AssignString("")
+}
+func (_StructRepresentation_Listpairs__ReprAssembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Listpairs.Repr"}.AssignBytes(nil)
+}
+func (_StructRepresentation_Listpairs__ReprAssembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Listpairs.Repr"}.AssignLink(nil)
+}
+func (na *_StructRepresentation_Listpairs__ReprAssembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	v2, ok := v.(*_StructRepresentation_Listpairs)
+	if v3, ok3 := v.(*_StructRepresentation_Listpairs__Repr); ok3 {
+		v2, ok = (*_StructRepresentation_Listpairs)(v3), true
+	}
+	if ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.StructRepresentation_Listpairs.Repr", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_StructRepresentation_Listpairs__ReprAssembler) Prototype() ipld.NodePrototype {
+	return _StructRepresentation_Listpairs__ReprPrototype{}
+}
+func (ma *_StructRepresentation_Listpairs__ReprAssembler) valueFinishTidy() bool {
+	switch ma.f {
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_StructRepresentation_Listpairs__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	return nil, ipld.ErrInvalidKey{TypeName: "schemadmt.StructRepresentation_Listpairs.Repr", Key: &_String{k}}
+}
+func (ma *_StructRepresentation_Listpairs__ReprAssembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_StructRepresentation_Listpairs__ReprKeyAssembler)(ma)
+}
+func (ma *_StructRepresentation_Listpairs__ReprAssembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_StructRepresentation_Listpairs__ReprAssembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__StructRepresentation_Listpairs_sufficient != fieldBits__StructRepresentation_Listpairs_sufficient {
+		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_StructRepresentation_Listpairs__ReprAssembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_StructRepresentation_Listpairs__ReprAssembler) ValuePrototype(k string) ipld.NodePrototype {
+	panic("todo structbuilder mapassembler repr valueprototype")
+}
+
+type _StructRepresentation_Listpairs__ReprKeyAssembler _StructRepresentation_Listpairs__ReprAssembler
+
+func (_StructRepresentation_Listpairs__ReprKeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Listpairs.Repr.KeyAssembler"}.BeginMap(0)
+}
+func (_StructRepresentation_Listpairs__ReprKeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Listpairs.Repr.KeyAssembler"}.BeginList(0)
+}
+func (na *_StructRepresentation_Listpairs__ReprKeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Listpairs.Repr.KeyAssembler"}.AssignNull()
+}
+func (_StructRepresentation_Listpairs__ReprKeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Listpairs.Repr.KeyAssembler"}.AssignBool(false)
+}
+func (_StructRepresentation_Listpairs__ReprKeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Listpairs.Repr.KeyAssembler"}.AssignInt(0)
+}
+func (_StructRepresentation_Listpairs__ReprKeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Listpairs.Repr.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_StructRepresentation_Listpairs__ReprKeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	return ipld.ErrInvalidKey{TypeName: "schemadmt.StructRepresentation_Listpairs.Repr", Key: &_String{k}}
+}
+func (_StructRepresentation_Listpairs__ReprKeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Listpairs.Repr.KeyAssembler"}.AssignBytes(nil)
+}
+func (_StructRepresentation_Listpairs__ReprKeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Listpairs.Repr.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_StructRepresentation_Listpairs__ReprKeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_StructRepresentation_Listpairs__ReprKeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
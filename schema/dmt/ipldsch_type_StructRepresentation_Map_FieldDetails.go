@@ -0,0 +1,983 @@
+package schemadmt
+
+// Code generated by go-ipld-prime gengo.  DO NOT EDIT.
+
+import (
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// StructRepresentation_Map_FieldDetails matches the IPLD Schema type "StructRepresentation_Map_FieldDetails".  It has Struct type-kind, and may be interrogated like map kind.
+type StructRepresentation_Map_FieldDetails = *_StructRepresentation_Map_FieldDetails
+type _StructRepresentation_Map_FieldDetails struct {
+	rename   _String__Maybe
+	implicit _AnyScalar__Maybe
+}
+
+// FieldRename returns the value of the field "rename".
+func (n _StructRepresentation_Map_FieldDetails) FieldRename() MaybeString {
+	return &n.rename
+}
+
+// FieldImplicit returns the value of the field "implicit".
+func (n _StructRepresentation_Map_FieldDetails) FieldImplicit() MaybeAnyScalar {
+	return &n.implicit
+}
+
+type _StructRepresentation_Map_FieldDetails__Maybe struct {
+	m schema.Maybe
+	v StructRepresentation_Map_FieldDetails
+}
+type MaybeStructRepresentation_Map_FieldDetails = *_StructRepresentation_Map_FieldDetails__Maybe
+
+func (m MaybeStructRepresentation_Map_FieldDetails) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeStructRepresentation_Map_FieldDetails) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeStructRepresentation_Map_FieldDetails) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeStructRepresentation_Map_FieldDetails) AsNode() ipld.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return ipld.Absent
+	case schema.Maybe_Null:
+		return ipld.Null
+	case schema.Maybe_Value:
+		return m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybeStructRepresentation_Map_FieldDetails) Must() StructRepresentation_Map_FieldDetails {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return m.v
+}
+
+var (
+	fieldName__StructRepresentation_Map_FieldDetails_Rename   = _String{"rename"}
+	fieldName__StructRepresentation_Map_FieldDetails_Implicit = _String{"implicit"}
+)
+var _ ipld.Node = (StructRepresentation_Map_FieldDetails)(&_StructRepresentation_Map_FieldDetails{})
+var _ schema.TypedNode = (StructRepresentation_Map_FieldDetails)(&_StructRepresentation_Map_FieldDetails{})
+
+func (StructRepresentation_Map_FieldDetails) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n StructRepresentation_Map_FieldDetails) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	case "rename":
+		if n.rename.m == schema.Maybe_Absent {
+			return ipld.Absent, nil
+		}
+		return &n.rename.v, nil
+	case "implicit":
+		if n.implicit.m == schema.Maybe_Absent {
+			return ipld.Absent, nil
+		}
+		return n.implicit.v, nil
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n StructRepresentation_Map_FieldDetails) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (StructRepresentation_Map_FieldDetails) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Map_FieldDetails"}.LookupByIndex(0)
+}
+func (n StructRepresentation_Map_FieldDetails) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n StructRepresentation_Map_FieldDetails) MapIterator() ipld.MapIterator {
+	return &_StructRepresentation_Map_FieldDetails__MapItr{n, 0}
+}
+
+type _StructRepresentation_Map_FieldDetails__MapItr struct {
+	n   StructRepresentation_Map_FieldDetails
+	idx int
+}
+
+func (itr *_StructRepresentation_Map_FieldDetails__MapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	if itr.idx >= 2 {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	switch itr.idx {
+	case 0:
+		k = &fieldName__StructRepresentation_Map_FieldDetails_Rename
+		if itr.n.rename.m == schema.Maybe_Absent {
+			v = ipld.Absent
+			break
+		}
+		v = &itr.n.rename.v
+	case 1:
+		k = &fieldName__StructRepresentation_Map_FieldDetails_Implicit
+		if itr.n.implicit.m == schema.Maybe_Absent {
+			v = ipld.Absent
+			break
+		}
+		v = itr.n.implicit.v
+	default:
+		panic("unreachable")
+	}
+	itr.idx++
+	return
+}
+func (itr *_StructRepresentation_Map_FieldDetails__MapItr) Done() bool {
+	return itr.idx >= 2
+}
+
+func (StructRepresentation_Map_FieldDetails) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (StructRepresentation_Map_FieldDetails) Length() int64 {
+	return 2
+}
+func (StructRepresentation_Map_FieldDetails) IsAbsent() bool {
+	return false
+}
+func (StructRepresentation_Map_FieldDetails) IsNull() bool {
+	return false
+}
+func (StructRepresentation_Map_FieldDetails) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Map_FieldDetails"}.AsBool()
+}
+func (StructRepresentation_Map_FieldDetails) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Map_FieldDetails"}.AsInt()
+}
+func (StructRepresentation_Map_FieldDetails) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Map_FieldDetails"}.AsFloat()
+}
+func (StructRepresentation_Map_FieldDetails) AsString() (string, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Map_FieldDetails"}.AsString()
+}
+func (StructRepresentation_Map_FieldDetails) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Map_FieldDetails"}.AsBytes()
+}
+func (StructRepresentation_Map_FieldDetails) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Map_FieldDetails"}.AsLink()
+}
+func (StructRepresentation_Map_FieldDetails) Prototype() ipld.NodePrototype {
+	return _StructRepresentation_Map_FieldDetails__Prototype{}
+}
+
+type _StructRepresentation_Map_FieldDetails__Prototype struct{}
+
+func (_StructRepresentation_Map_FieldDetails__Prototype) NewBuilder() ipld.NodeBuilder {
+	var nb _StructRepresentation_Map_FieldDetails__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _StructRepresentation_Map_FieldDetails__Builder struct {
+	_StructRepresentation_Map_FieldDetails__Assembler
+}
+
+func (nb *_StructRepresentation_Map_FieldDetails__Builder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_StructRepresentation_Map_FieldDetails__Builder) Reset() {
+	var w _StructRepresentation_Map_FieldDetails
+	var m schema.Maybe
+	*nb = _StructRepresentation_Map_FieldDetails__Builder{_StructRepresentation_Map_FieldDetails__Assembler{w: &w, m: &m}}
+}
+
+type _StructRepresentation_Map_FieldDetails__Assembler struct {
+	w     *_StructRepresentation_Map_FieldDetails
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm          schema.Maybe
+	ca_rename   _String__Assembler
+	ca_implicit _AnyScalar__Assembler
+}
+
+func (na *_StructRepresentation_Map_FieldDetails__Assembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+	na.ca_rename.reset()
+	na.ca_implicit.reset()
+}
+
+var (
+	fieldBit__StructRepresentation_Map_FieldDetails_Rename      = 1 << 0
+	fieldBit__StructRepresentation_Map_FieldDetails_Implicit    = 1 << 1
+	fieldBits__StructRepresentation_Map_FieldDetails_sufficient = 0
+)
+
+func (na *_StructRepresentation_Map_FieldDetails__Assembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_StructRepresentation_Map_FieldDetails{}
+	}
+	return na, nil
+}
+func (_StructRepresentation_Map_FieldDetails__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Map_FieldDetails"}.BeginList(0)
+}
+func (na *_StructRepresentation_Map_FieldDetails__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.StructRepresentation_Map_FieldDetails"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_StructRepresentation_Map_FieldDetails__Assembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Map_FieldDetails"}.AssignBool(false)
+}
+func (_StructRepresentation_Map_FieldDetails__Assembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Map_FieldDetails"}.AssignInt(0)
+}
+func (_StructRepresentation_Map_FieldDetails__Assembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Map_FieldDetails"}.AssignFloat(0)
+}
+func (_StructRepresentation_Map_FieldDetails__Assembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Map_FieldDetails"}.AssignString("")
+}
+func (_StructRepresentation_Map_FieldDetails__Assembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Map_FieldDetails"}.AssignBytes(nil)
+}
+func (_StructRepresentation_Map_FieldDetails__Assembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Map_FieldDetails"}.AssignLink(nil)
+}
+func (na *_StructRepresentation_Map_FieldDetails__Assembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_StructRepresentation_Map_FieldDetails); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.StructRepresentation_Map_FieldDetails", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_StructRepresentation_Map_FieldDetails__Assembler) Prototype() ipld.NodePrototype {
+	return _StructRepresentation_Map_FieldDetails__Prototype{}
+}
+func (ma *_StructRepresentation_Map_FieldDetails__Assembler) valueFinishTidy() bool {
+	switch ma.f {
+	case 0:
+		switch ma.w.rename.m {
+		case schema.Maybe_Value:
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 1:
+		switch ma.w.implicit.m {
+		case schema.Maybe_Value:
+			ma.w.implicit.v = ma.ca_implicit.w
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_StructRepresentation_Map_FieldDetails__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	switch k {
+	case "rename":
+		if ma.s&fieldBit__StructRepresentation_Map_FieldDetails_Rename != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__StructRepresentation_Map_FieldDetails_Rename}
+		}
+		ma.s += fieldBit__StructRepresentation_Map_FieldDetails_Rename
+		ma.state = maState_midValue
+		ma.f = 0
+		ma.ca_rename.w = &ma.w.rename.v
+		ma.ca_rename.m = &ma.w.rename.m
+		return &ma.ca_rename, nil
+	case "implicit":
+		if ma.s&fieldBit__StructRepresentation_Map_FieldDetails_Implicit != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__StructRepresentation_Map_FieldDetails_Implicit}
+		}
+		ma.s += fieldBit__StructRepresentation_Map_FieldDetails_Implicit
+		ma.state = maState_midValue
+		ma.f = 1
+		ma.ca_implicit.w = ma.w.implicit.v
+		ma.ca_implicit.m = &ma.w.implicit.m
+		return &ma.ca_implicit, nil
+	}
+	return nil, ipld.ErrInvalidKey{TypeName: "schemadmt.StructRepresentation_Map_FieldDetails", Key: &_String{k}}
+}
+func (ma *_StructRepresentation_Map_FieldDetails__Assembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_StructRepresentation_Map_FieldDetails__KeyAssembler)(ma)
+}
+func (ma *_StructRepresentation_Map_FieldDetails__Assembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	case 0:
+		ma.ca_rename.w = &ma.w.rename.v
+		ma.ca_rename.m = &ma.w.rename.m
+		return &ma.ca_rename
+	case 1:
+		ma.ca_implicit.w = ma.w.implicit.v
+		ma.ca_implicit.m = &ma.w.implicit.m
+		return &ma.ca_implicit
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_StructRepresentation_Map_FieldDetails__Assembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__StructRepresentation_Map_FieldDetails_sufficient != fieldBits__StructRepresentation_Map_FieldDetails_sufficient {
+		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_StructRepresentation_Map_FieldDetails__Assembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_StructRepresentation_Map_FieldDetails__Assembler) ValuePrototype(k string) ipld.NodePrototype {
+	panic("todo structbuilder mapassembler valueprototype")
+}
+
+type _StructRepresentation_Map_FieldDetails__KeyAssembler _StructRepresentation_Map_FieldDetails__Assembler
+
+func (_StructRepresentation_Map_FieldDetails__KeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Map_FieldDetails.KeyAssembler"}.BeginMap(0)
+}
+func (_StructRepresentation_Map_FieldDetails__KeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Map_FieldDetails.KeyAssembler"}.BeginList(0)
+}
+func (na *_StructRepresentation_Map_FieldDetails__KeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Map_FieldDetails.KeyAssembler"}.AssignNull()
+}
+func (_StructRepresentation_Map_FieldDetails__KeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Map_FieldDetails.KeyAssembler"}.AssignBool(false)
+}
+func (_StructRepresentation_Map_FieldDetails__KeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Map_FieldDetails.KeyAssembler"}.AssignInt(0)
+}
+func (_StructRepresentation_Map_FieldDetails__KeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Map_FieldDetails.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_StructRepresentation_Map_FieldDetails__KeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	switch k {
+	case "rename":
+		if ka.s&fieldBit__StructRepresentation_Map_FieldDetails_Rename != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__StructRepresentation_Map_FieldDetails_Rename}
+		}
+		ka.s += fieldBit__StructRepresentation_Map_FieldDetails_Rename
+		ka.state = maState_expectValue
+		ka.f = 0
+	case "implicit":
+		if ka.s&fieldBit__StructRepresentation_Map_FieldDetails_Implicit != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__StructRepresentation_Map_FieldDetails_Implicit}
+		}
+		ka.s += fieldBit__StructRepresentation_Map_FieldDetails_Implicit
+		ka.state = maState_expectValue
+		ka.f = 1
+	default:
+		return ipld.ErrInvalidKey{TypeName: "schemadmt.StructRepresentation_Map_FieldDetails", Key: &_String{k}}
+	}
+	return nil
+}
+func (_StructRepresentation_Map_FieldDetails__KeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Map_FieldDetails.KeyAssembler"}.AssignBytes(nil)
+}
+func (_StructRepresentation_Map_FieldDetails__KeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Map_FieldDetails.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_StructRepresentation_Map_FieldDetails__KeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_StructRepresentation_Map_FieldDetails__KeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (StructRepresentation_Map_FieldDetails) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (n StructRepresentation_Map_FieldDetails) Representation() ipld.Node {
+	return (*_StructRepresentation_Map_FieldDetails__Repr)(n)
+}
+
+type _StructRepresentation_Map_FieldDetails__Repr _StructRepresentation_Map_FieldDetails
+
+var (
+	fieldName__StructRepresentation_Map_FieldDetails_Rename_serial   = _String{"rename"}
+	fieldName__StructRepresentation_Map_FieldDetails_Implicit_serial = _String{"implicit"}
+)
+var _ ipld.Node = &_StructRepresentation_Map_FieldDetails__Repr{}
+
+func (_StructRepresentation_Map_FieldDetails__Repr) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n *_StructRepresentation_Map_FieldDetails__Repr) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	case "rename":
+		if n.rename.m == schema.Maybe_Absent {
+			return ipld.Absent, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(key)}
+		}
+		return n.rename.v.Representation(), nil
+	case "implicit":
+		if n.implicit.m == schema.Maybe_Absent {
+			return ipld.Absent, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(key)}
+		}
+		return n.implicit.v.Representation(), nil
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n *_StructRepresentation_Map_FieldDetails__Repr) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (_StructRepresentation_Map_FieldDetails__Repr) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Map_FieldDetails.Repr"}.LookupByIndex(0)
+}
+func (n _StructRepresentation_Map_FieldDetails__Repr) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n *_StructRepresentation_Map_FieldDetails__Repr) MapIterator() ipld.MapIterator {
+	end := 2
+	if n.implicit.m == schema.Maybe_Absent {
+		end = 1
+	} else {
+		goto done
+	}
+	if n.rename.m == schema.Maybe_Absent {
+		end = 0
+	} else {
+		goto done
+	}
+done:
+	return &_StructRepresentation_Map_FieldDetails__ReprMapItr{n, 0, end}
+}
+
+type _StructRepresentation_Map_FieldDetails__ReprMapItr struct {
+	n   *_StructRepresentation_Map_FieldDetails__Repr
+	idx int
+	end int
+}
+
+func (itr *_StructRepresentation_Map_FieldDetails__ReprMapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+advance:
+	if itr.idx >= 2 {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	switch itr.idx {
+	case 0:
+		k = &fieldName__StructRepresentation_Map_FieldDetails_Rename_serial
+		if itr.n.rename.m == schema.Maybe_Absent {
+			itr.idx++
+			goto advance
+		}
+		v = itr.n.rename.v.Representation()
+	case 1:
+		k = &fieldName__StructRepresentation_Map_FieldDetails_Implicit_serial
+		if itr.n.implicit.m == schema.Maybe_Absent {
+			itr.idx++
+			goto advance
+		}
+		v = itr.n.implicit.v.Representation()
+	default:
+		panic("unreachable")
+	}
+	itr.idx++
+	return
+}
+func (itr *_StructRepresentation_Map_FieldDetails__ReprMapItr) Done() bool {
+	return itr.idx >= itr.end
+}
+func (_StructRepresentation_Map_FieldDetails__Repr) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (rn *_StructRepresentation_Map_FieldDetails__Repr) Length() int64 {
+	l := 2
+	if rn.rename.m == schema.Maybe_Absent {
+		l--
+	}
+	if rn.implicit.m == schema.Maybe_Absent {
+		l--
+	}
+	return int64(l)
+}
+func (_StructRepresentation_Map_FieldDetails__Repr) IsAbsent() bool {
+	return false
+}
+func (_StructRepresentation_Map_FieldDetails__Repr) IsNull() bool {
+	return false
+}
+func (_StructRepresentation_Map_FieldDetails__Repr) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Map_FieldDetails.Repr"}.AsBool()
+}
+func (_StructRepresentation_Map_FieldDetails__Repr) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Map_FieldDetails.Repr"}.AsInt()
+}
+func (_StructRepresentation_Map_FieldDetails__Repr) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Map_FieldDetails.Repr"}.AsFloat()
+}
+func (_StructRepresentation_Map_FieldDetails__Repr) AsString() (string, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Map_FieldDetails.Repr"}.AsString()
+}
+func (_StructRepresentation_Map_FieldDetails__Repr) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Map_FieldDetails.Repr"}.AsBytes()
+}
+func (_StructRepresentation_Map_FieldDetails__Repr) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Map_FieldDetails.Repr"}.AsLink()
+}
+func (_StructRepresentation_Map_FieldDetails__Repr) Prototype() ipld.NodePrototype {
+	return _StructRepresentation_Map_FieldDetails__ReprPrototype{}
+}
+
+type _StructRepresentation_Map_FieldDetails__ReprPrototype struct{}
+
+func (_StructRepresentation_Map_FieldDetails__ReprPrototype) NewBuilder() ipld.NodeBuilder {
+	var nb _StructRepresentation_Map_FieldDetails__ReprBuilder
+	nb.Reset()
+	return &nb
+}
+
+type _StructRepresentation_Map_FieldDetails__ReprBuilder struct {
+	_StructRepresentation_Map_FieldDetails__ReprAssembler
+}
+
+func (nb *_StructRepresentation_Map_FieldDetails__ReprBuilder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_StructRepresentation_Map_FieldDetails__ReprBuilder) Reset() {
+	var w _StructRepresentation_Map_FieldDetails
+	var m schema.Maybe
+	*nb = _StructRepresentation_Map_FieldDetails__ReprBuilder{_StructRepresentation_Map_FieldDetails__ReprAssembler{w: &w, m: &m}}
+}
+
+type _StructRepresentation_Map_FieldDetails__ReprAssembler struct {
+	w     *_StructRepresentation_Map_FieldDetails
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm          schema.Maybe
+	ca_rename   _String__ReprAssembler
+	ca_implicit _AnyScalar__ReprAssembler
+}
+
+func (na *_StructRepresentation_Map_FieldDetails__ReprAssembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+	na.ca_rename.reset()
+	na.ca_implicit.reset()
+}
+func (na *_StructRepresentation_Map_FieldDetails__ReprAssembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_StructRepresentation_Map_FieldDetails{}
+	}
+	return na, nil
+}
+func (_StructRepresentation_Map_FieldDetails__ReprAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Map_FieldDetails.Repr"}.BeginList(0)
+}
+func (na *_StructRepresentation_Map_FieldDetails__ReprAssembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.StructRepresentation_Map_FieldDetails.Repr.Repr"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_StructRepresentation_Map_FieldDetails__ReprAssembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Map_FieldDetails.Repr"}.AssignBool(false)
+}
+func (_StructRepresentation_Map_FieldDetails__ReprAssembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Map_FieldDetails.Repr"}.AssignInt(0)
+}
+func (_StructRepresentation_Map_FieldDetails__ReprAssembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Map_FieldDetails.Repr"}.AssignFloat(0)
+}
+func (_StructRepresentation_Map_FieldDetails__ReprAssembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Map_FieldDetails.Repr"}.AssignString("")
+}
+func (_StructRepresentation_Map_FieldDetails__ReprAssembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Map_FieldDetails.Repr"}.AssignBytes(nil)
+}
+func (_StructRepresentation_Map_FieldDetails__ReprAssembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Map_FieldDetails.Repr"}.AssignLink(nil)
+}
+func (na *_StructRepresentation_Map_FieldDetails__ReprAssembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	v2, ok := v.(*_StructRepresentation_Map_FieldDetails)
+	if v3, ok3 := v.(*_StructRepresentation_Map_FieldDetails__Repr); ok3 {
+		v2, ok = (*_StructRepresentation_Map_FieldDetails)(v3), true
+	}
+	if ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.StructRepresentation_Map_FieldDetails.Repr", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_StructRepresentation_Map_FieldDetails__ReprAssembler) Prototype() ipld.NodePrototype {
+	return _StructRepresentation_Map_FieldDetails__ReprPrototype{}
+}
+func (ma *_StructRepresentation_Map_FieldDetails__ReprAssembler) valueFinishTidy() bool {
+	switch ma.f {
+	case 0:
+		switch ma.w.rename.m {
+		case schema.Maybe_Value:
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 1:
+		switch ma.w.implicit.m {
+		case schema.Maybe_Value:
+			ma.w.implicit.v = ma.ca_implicit.w
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_StructRepresentation_Map_FieldDetails__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	switch k {
+	case "rename":
+		if ma.s&fieldBit__StructRepresentation_Map_FieldDetails_Rename != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__StructRepresentation_Map_FieldDetails_Rename_serial}
+		}
+		ma.s += fieldBit__StructRepresentation_Map_FieldDetails_Rename
+		ma.state = maState_midValue
+		ma.f = 0
+		ma.ca_rename.w = &ma.w.rename.v
+		ma.ca_rename.m = &ma.w.rename.m
+
+		return &ma.ca_rename, nil
+	case "implicit":
+		if ma.s&fieldBit__StructRepresentation_Map_FieldDetails_Implicit != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__StructRepresentation_Map_FieldDetails_Implicit_serial}
+		}
+		ma.s += fieldBit__StructRepresentation_Map_FieldDetails_Implicit
+		ma.state = maState_midValue
+		ma.f = 1
+		ma.ca_implicit.w = ma.w.implicit.v
+		ma.ca_implicit.m = &ma.w.implicit.m
+
+		return &ma.ca_implicit, nil
+	default:
+	}
+	return nil, ipld.ErrInvalidKey{TypeName: "schemadmt.StructRepresentation_Map_FieldDetails.Repr", Key: &_String{k}}
+}
+func (ma *_StructRepresentation_Map_FieldDetails__ReprAssembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_StructRepresentation_Map_FieldDetails__ReprKeyAssembler)(ma)
+}
+func (ma *_StructRepresentation_Map_FieldDetails__ReprAssembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	case 0:
+		ma.ca_rename.w = &ma.w.rename.v
+		ma.ca_rename.m = &ma.w.rename.m
+
+		return &ma.ca_rename
+	case 1:
+		ma.ca_implicit.w = ma.w.implicit.v
+		ma.ca_implicit.m = &ma.w.implicit.m
+
+		return &ma.ca_implicit
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_StructRepresentation_Map_FieldDetails__ReprAssembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__StructRepresentation_Map_FieldDetails_sufficient != fieldBits__StructRepresentation_Map_FieldDetails_sufficient {
+		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_StructRepresentation_Map_FieldDetails__ReprAssembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_StructRepresentation_Map_FieldDetails__ReprAssembler) ValuePrototype(k string) ipld.NodePrototype {
+	panic("todo structbuilder mapassembler repr valueprototype")
+}
+
+type _StructRepresentation_Map_FieldDetails__ReprKeyAssembler _StructRepresentation_Map_FieldDetails__ReprAssembler
+
+func (_StructRepresentation_Map_FieldDetails__ReprKeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Map_FieldDetails.Repr.KeyAssembler"}.BeginMap(0)
+}
+func (_StructRepresentation_Map_FieldDetails__ReprKeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Map_FieldDetails.Repr.KeyAssembler"}.BeginList(0)
+}
+func (na *_StructRepresentation_Map_FieldDetails__ReprKeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Map_FieldDetails.Repr.KeyAssembler"}.AssignNull()
+}
+func (_StructRepresentation_Map_FieldDetails__ReprKeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Map_FieldDetails.Repr.KeyAssembler"}.AssignBool(false)
+}
+func (_StructRepresentation_Map_FieldDetails__ReprKeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Map_FieldDetails.Repr.KeyAssembler"}.AssignInt(0)
+}
+func (_StructRepresentation_Map_FieldDetails__ReprKeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Map_FieldDetails.Repr.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_StructRepresentation_Map_FieldDetails__ReprKeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	switch k {
+	case "rename":
+		if ka.s&fieldBit__StructRepresentation_Map_FieldDetails_Rename != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__StructRepresentation_Map_FieldDetails_Rename_serial}
+		}
+		ka.s += fieldBit__StructRepresentation_Map_FieldDetails_Rename
+		ka.state = maState_expectValue
+		ka.f = 0
+		return nil
+	case "implicit":
+		if ka.s&fieldBit__StructRepresentation_Map_FieldDetails_Implicit != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__StructRepresentation_Map_FieldDetails_Implicit_serial}
+		}
+		ka.s += fieldBit__StructRepresentation_Map_FieldDetails_Implicit
+		ka.state = maState_expectValue
+		ka.f = 1
+		return nil
+	}
+	return ipld.ErrInvalidKey{TypeName: "schemadmt.StructRepresentation_Map_FieldDetails.Repr", Key: &_String{k}}
+}
+func (_StructRepresentation_Map_FieldDetails__ReprKeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Map_FieldDetails.Repr.KeyAssembler"}.AssignBytes(nil)
+}
+func (_StructRepresentation_Map_FieldDetails__ReprKeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Map_FieldDetails.Repr.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_StructRepresentation_Map_FieldDetails__ReprKeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_StructRepresentation_Map_FieldDetails__ReprKeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
@@ -0,0 +1,966 @@
+package schemadmt
+
+// Code generated by go-ipld-prime gengo.  DO NOT EDIT.
+
+import (
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// StructRepresentation_Stringjoin matches the IPLD Schema type "StructRepresentation_Stringjoin".  It has Struct type-kind, and may be interrogated like map kind.
+type StructRepresentation_Stringjoin = *_StructRepresentation_Stringjoin
+type _StructRepresentation_Stringjoin struct {
+	join       _String
+	fieldOrder _List__FieldName__Maybe
+}
+
+// FieldJoin returns the value of the field "join".
+func (n _StructRepresentation_Stringjoin) FieldJoin() String {
+	return &n.join
+}
+
+// FieldFieldOrder returns the value of the field "fieldOrder".
+func (n _StructRepresentation_Stringjoin) FieldFieldOrder() MaybeList__FieldName {
+	return &n.fieldOrder
+}
+
+type _StructRepresentation_Stringjoin__Maybe struct {
+	m schema.Maybe
+	v StructRepresentation_Stringjoin
+}
+type MaybeStructRepresentation_Stringjoin = *_StructRepresentation_Stringjoin__Maybe
+
+func (m MaybeStructRepresentation_Stringjoin) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeStructRepresentation_Stringjoin) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeStructRepresentation_Stringjoin) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeStructRepresentation_Stringjoin) AsNode() ipld.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return ipld.Absent
+	case schema.Maybe_Null:
+		return ipld.Null
+	case schema.Maybe_Value:
+		return m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybeStructRepresentation_Stringjoin) Must() StructRepresentation_Stringjoin {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return m.v
+}
+
+var (
+	fieldName__StructRepresentation_Stringjoin_Join       = _String{"join"}
+	fieldName__StructRepresentation_Stringjoin_FieldOrder = _String{"fieldOrder"}
+)
+var _ ipld.Node = (StructRepresentation_Stringjoin)(&_StructRepresentation_Stringjoin{})
+var _ schema.TypedNode = (StructRepresentation_Stringjoin)(&_StructRepresentation_Stringjoin{})
+
+func (StructRepresentation_Stringjoin) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n StructRepresentation_Stringjoin) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	case "join":
+		return &n.join, nil
+	case "fieldOrder":
+		if n.fieldOrder.m == schema.Maybe_Absent {
+			return ipld.Absent, nil
+		}
+		return &n.fieldOrder.v, nil
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n StructRepresentation_Stringjoin) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (StructRepresentation_Stringjoin) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Stringjoin"}.LookupByIndex(0)
+}
+func (n StructRepresentation_Stringjoin) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n StructRepresentation_Stringjoin) MapIterator() ipld.MapIterator {
+	return &_StructRepresentation_Stringjoin__MapItr{n, 0}
+}
+
+type _StructRepresentation_Stringjoin__MapItr struct {
+	n   StructRepresentation_Stringjoin
+	idx int
+}
+
+func (itr *_StructRepresentation_Stringjoin__MapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	if itr.idx >= 2 {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	switch itr.idx {
+	case 0:
+		k = &fieldName__StructRepresentation_Stringjoin_Join
+		v = &itr.n.join
+	case 1:
+		k = &fieldName__StructRepresentation_Stringjoin_FieldOrder
+		if itr.n.fieldOrder.m == schema.Maybe_Absent {
+			v = ipld.Absent
+			break
+		}
+		v = &itr.n.fieldOrder.v
+	default:
+		panic("unreachable")
+	}
+	itr.idx++
+	return
+}
+func (itr *_StructRepresentation_Stringjoin__MapItr) Done() bool {
+	return itr.idx >= 2
+}
+
+func (StructRepresentation_Stringjoin) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (StructRepresentation_Stringjoin) Length() int64 {
+	return 2
+}
+func (StructRepresentation_Stringjoin) IsAbsent() bool {
+	return false
+}
+func (StructRepresentation_Stringjoin) IsNull() bool {
+	return false
+}
+func (StructRepresentation_Stringjoin) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Stringjoin"}.AsBool()
+}
+func (StructRepresentation_Stringjoin) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Stringjoin"}.AsInt()
+}
+func (StructRepresentation_Stringjoin) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Stringjoin"}.AsFloat()
+}
+func (StructRepresentation_Stringjoin) AsString() (string, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Stringjoin"}.AsString()
+}
+func (StructRepresentation_Stringjoin) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Stringjoin"}.AsBytes()
+}
+func (StructRepresentation_Stringjoin) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Stringjoin"}.AsLink()
+}
+func (StructRepresentation_Stringjoin) Prototype() ipld.NodePrototype {
+	return _StructRepresentation_Stringjoin__Prototype{}
+}
+
+type _StructRepresentation_Stringjoin__Prototype struct{}
+
+func (_StructRepresentation_Stringjoin__Prototype) NewBuilder() ipld.NodeBuilder {
+	var nb _StructRepresentation_Stringjoin__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _StructRepresentation_Stringjoin__Builder struct {
+	_StructRepresentation_Stringjoin__Assembler
+}
+
+func (nb *_StructRepresentation_Stringjoin__Builder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_StructRepresentation_Stringjoin__Builder) Reset() {
+	var w _StructRepresentation_Stringjoin
+	var m schema.Maybe
+	*nb = _StructRepresentation_Stringjoin__Builder{_StructRepresentation_Stringjoin__Assembler{w: &w, m: &m}}
+}
+
+type _StructRepresentation_Stringjoin__Assembler struct {
+	w     *_StructRepresentation_Stringjoin
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm            schema.Maybe
+	ca_join       _String__Assembler
+	ca_fieldOrder _List__FieldName__Assembler
+}
+
+func (na *_StructRepresentation_Stringjoin__Assembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+	na.ca_join.reset()
+	na.ca_fieldOrder.reset()
+}
+
+var (
+	fieldBit__StructRepresentation_Stringjoin_Join        = 1 << 0
+	fieldBit__StructRepresentation_Stringjoin_FieldOrder  = 1 << 1
+	fieldBits__StructRepresentation_Stringjoin_sufficient = 0 + 1<<0
+)
+
+func (na *_StructRepresentation_Stringjoin__Assembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_StructRepresentation_Stringjoin{}
+	}
+	return na, nil
+}
+func (_StructRepresentation_Stringjoin__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Stringjoin"}.BeginList(0)
+}
+func (na *_StructRepresentation_Stringjoin__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.StructRepresentation_Stringjoin"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_StructRepresentation_Stringjoin__Assembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Stringjoin"}.AssignBool(false)
+}
+func (_StructRepresentation_Stringjoin__Assembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Stringjoin"}.AssignInt(0)
+}
+func (_StructRepresentation_Stringjoin__Assembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Stringjoin"}.AssignFloat(0)
+}
+func (_StructRepresentation_Stringjoin__Assembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Stringjoin"}.AssignString("")
+}
+func (_StructRepresentation_Stringjoin__Assembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Stringjoin"}.AssignBytes(nil)
+}
+func (_StructRepresentation_Stringjoin__Assembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Stringjoin"}.AssignLink(nil)
+}
+func (na *_StructRepresentation_Stringjoin__Assembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_StructRepresentation_Stringjoin); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.StructRepresentation_Stringjoin", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_StructRepresentation_Stringjoin__Assembler) Prototype() ipld.NodePrototype {
+	return _StructRepresentation_Stringjoin__Prototype{}
+}
+func (ma *_StructRepresentation_Stringjoin__Assembler) valueFinishTidy() bool {
+	switch ma.f {
+	case 0:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.ca_join.w = nil
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 1:
+		switch ma.w.fieldOrder.m {
+		case schema.Maybe_Value:
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_StructRepresentation_Stringjoin__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	switch k {
+	case "join":
+		if ma.s&fieldBit__StructRepresentation_Stringjoin_Join != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__StructRepresentation_Stringjoin_Join}
+		}
+		ma.s += fieldBit__StructRepresentation_Stringjoin_Join
+		ma.state = maState_midValue
+		ma.f = 0
+		ma.ca_join.w = &ma.w.join
+		ma.ca_join.m = &ma.cm
+		return &ma.ca_join, nil
+	case "fieldOrder":
+		if ma.s&fieldBit__StructRepresentation_Stringjoin_FieldOrder != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__StructRepresentation_Stringjoin_FieldOrder}
+		}
+		ma.s += fieldBit__StructRepresentation_Stringjoin_FieldOrder
+		ma.state = maState_midValue
+		ma.f = 1
+		ma.ca_fieldOrder.w = &ma.w.fieldOrder.v
+		ma.ca_fieldOrder.m = &ma.w.fieldOrder.m
+		return &ma.ca_fieldOrder, nil
+	}
+	return nil, ipld.ErrInvalidKey{TypeName: "schemadmt.StructRepresentation_Stringjoin", Key: &_String{k}}
+}
+func (ma *_StructRepresentation_Stringjoin__Assembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_StructRepresentation_Stringjoin__KeyAssembler)(ma)
+}
+func (ma *_StructRepresentation_Stringjoin__Assembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	case 0:
+		ma.ca_join.w = &ma.w.join
+		ma.ca_join.m = &ma.cm
+		return &ma.ca_join
+	case 1:
+		ma.ca_fieldOrder.w = &ma.w.fieldOrder.v
+		ma.ca_fieldOrder.m = &ma.w.fieldOrder.m
+		return &ma.ca_fieldOrder
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_StructRepresentation_Stringjoin__Assembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__StructRepresentation_Stringjoin_sufficient != fieldBits__StructRepresentation_Stringjoin_sufficient {
+		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		if ma.s&fieldBit__StructRepresentation_Stringjoin_Join == 0 {
+			err.Missing = append(err.Missing, "join")
+		}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_StructRepresentation_Stringjoin__Assembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_StructRepresentation_Stringjoin__Assembler) ValuePrototype(k string) ipld.NodePrototype {
+	panic("todo structbuilder mapassembler valueprototype")
+}
+
+type _StructRepresentation_Stringjoin__KeyAssembler _StructRepresentation_Stringjoin__Assembler
+
+func (_StructRepresentation_Stringjoin__KeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Stringjoin.KeyAssembler"}.BeginMap(0)
+}
+func (_StructRepresentation_Stringjoin__KeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Stringjoin.KeyAssembler"}.BeginList(0)
+}
+func (na *_StructRepresentation_Stringjoin__KeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Stringjoin.KeyAssembler"}.AssignNull()
+}
+func (_StructRepresentation_Stringjoin__KeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Stringjoin.KeyAssembler"}.AssignBool(false)
+}
+func (_StructRepresentation_Stringjoin__KeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Stringjoin.KeyAssembler"}.AssignInt(0)
+}
+func (_StructRepresentation_Stringjoin__KeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Stringjoin.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_StructRepresentation_Stringjoin__KeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	switch k {
+	case "join":
+		if ka.s&fieldBit__StructRepresentation_Stringjoin_Join != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__StructRepresentation_Stringjoin_Join}
+		}
+		ka.s += fieldBit__StructRepresentation_Stringjoin_Join
+		ka.state = maState_expectValue
+		ka.f = 0
+	case "fieldOrder":
+		if ka.s&fieldBit__StructRepresentation_Stringjoin_FieldOrder != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__StructRepresentation_Stringjoin_FieldOrder}
+		}
+		ka.s += fieldBit__StructRepresentation_Stringjoin_FieldOrder
+		ka.state = maState_expectValue
+		ka.f = 1
+	default:
+		return ipld.ErrInvalidKey{TypeName: "schemadmt.StructRepresentation_Stringjoin", Key: &_String{k}}
+	}
+	return nil
+}
+func (_StructRepresentation_Stringjoin__KeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Stringjoin.KeyAssembler"}.AssignBytes(nil)
+}
+func (_StructRepresentation_Stringjoin__KeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Stringjoin.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_StructRepresentation_Stringjoin__KeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_StructRepresentation_Stringjoin__KeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (StructRepresentation_Stringjoin) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (n StructRepresentation_Stringjoin) Representation() ipld.Node {
+	return (*_StructRepresentation_Stringjoin__Repr)(n)
+}
+
+type _StructRepresentation_Stringjoin__Repr _StructRepresentation_Stringjoin
+
+var (
+	fieldName__StructRepresentation_Stringjoin_Join_serial       = _String{"join"}
+	fieldName__StructRepresentation_Stringjoin_FieldOrder_serial = _String{"fieldOrder"}
+)
+var _ ipld.Node = &_StructRepresentation_Stringjoin__Repr{}
+
+func (_StructRepresentation_Stringjoin__Repr) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n *_StructRepresentation_Stringjoin__Repr) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	case "join":
+		return n.join.Representation(), nil
+	case "fieldOrder":
+		if n.fieldOrder.m == schema.Maybe_Absent {
+			return ipld.Absent, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(key)}
+		}
+		return n.fieldOrder.v.Representation(), nil
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n *_StructRepresentation_Stringjoin__Repr) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (_StructRepresentation_Stringjoin__Repr) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Stringjoin.Repr"}.LookupByIndex(0)
+}
+func (n _StructRepresentation_Stringjoin__Repr) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n *_StructRepresentation_Stringjoin__Repr) MapIterator() ipld.MapIterator {
+	end := 2
+	if n.fieldOrder.m == schema.Maybe_Absent {
+		end = 1
+	} else {
+		goto done
+	}
+done:
+	return &_StructRepresentation_Stringjoin__ReprMapItr{n, 0, end}
+}
+
+type _StructRepresentation_Stringjoin__ReprMapItr struct {
+	n   *_StructRepresentation_Stringjoin__Repr
+	idx int
+	end int
+}
+
+func (itr *_StructRepresentation_Stringjoin__ReprMapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+advance:
+	if itr.idx >= 2 {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	switch itr.idx {
+	case 0:
+		k = &fieldName__StructRepresentation_Stringjoin_Join_serial
+		v = itr.n.join.Representation()
+	case 1:
+		k = &fieldName__StructRepresentation_Stringjoin_FieldOrder_serial
+		if itr.n.fieldOrder.m == schema.Maybe_Absent {
+			itr.idx++
+			goto advance
+		}
+		v = itr.n.fieldOrder.v.Representation()
+	default:
+		panic("unreachable")
+	}
+	itr.idx++
+	return
+}
+func (itr *_StructRepresentation_Stringjoin__ReprMapItr) Done() bool {
+	return itr.idx >= itr.end
+}
+func (_StructRepresentation_Stringjoin__Repr) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (rn *_StructRepresentation_Stringjoin__Repr) Length() int64 {
+	l := 2
+	if rn.fieldOrder.m == schema.Maybe_Absent {
+		l--
+	}
+	return int64(l)
+}
+func (_StructRepresentation_Stringjoin__Repr) IsAbsent() bool {
+	return false
+}
+func (_StructRepresentation_Stringjoin__Repr) IsNull() bool {
+	return false
+}
+func (_StructRepresentation_Stringjoin__Repr) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Stringjoin.Repr"}.AsBool()
+}
+func (_StructRepresentation_Stringjoin__Repr) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Stringjoin.Repr"}.AsInt()
+}
+func (_StructRepresentation_Stringjoin__Repr) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Stringjoin.Repr"}.AsFloat()
+}
+func (_StructRepresentation_Stringjoin__Repr) AsString() (string, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Stringjoin.Repr"}.AsString()
+}
+func (_StructRepresentation_Stringjoin__Repr) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Stringjoin.Repr"}.AsBytes()
+}
+func (_StructRepresentation_Stringjoin__Repr) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.StructRepresentation_Stringjoin.Repr"}.AsLink()
+}
+func (_StructRepresentation_Stringjoin__Repr) Prototype() ipld.NodePrototype {
+	return _StructRepresentation_Stringjoin__ReprPrototype{}
+}
+
+type _StructRepresentation_Stringjoin__ReprPrototype struct{}
+
+func (_StructRepresentation_Stringjoin__ReprPrototype) NewBuilder() ipld.NodeBuilder {
+	var nb _StructRepresentation_Stringjoin__ReprBuilder
+	nb.Reset()
+	return &nb
+}
+
+type _StructRepresentation_Stringjoin__ReprBuilder struct {
+	_StructRepresentation_Stringjoin__ReprAssembler
+}
+
+func (nb *_StructRepresentation_Stringjoin__ReprBuilder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_StructRepresentation_Stringjoin__ReprBuilder) Reset() {
+	var w _StructRepresentation_Stringjoin
+	var m schema.Maybe
+	*nb = _StructRepresentation_Stringjoin__ReprBuilder{_StructRepresentation_Stringjoin__ReprAssembler{w: &w, m: &m}}
+}
+
+type _StructRepresentation_Stringjoin__ReprAssembler struct {
+	w     *_StructRepresentation_Stringjoin
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm            schema.Maybe
+	ca_join       _String__ReprAssembler
+	ca_fieldOrder _List__FieldName__ReprAssembler
+}
+
+func (na *_StructRepresentation_Stringjoin__ReprAssembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+	na.ca_join.reset()
+	na.ca_fieldOrder.reset()
+}
+func (na *_StructRepresentation_Stringjoin__ReprAssembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_StructRepresentation_Stringjoin{}
+	}
+	return na, nil
+}
+func (_StructRepresentation_Stringjoin__ReprAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Stringjoin.Repr"}.BeginList(0)
+}
+func (na *_StructRepresentation_Stringjoin__ReprAssembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.StructRepresentation_Stringjoin.Repr.Repr"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_StructRepresentation_Stringjoin__ReprAssembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Stringjoin.Repr"}.AssignBool(false)
+}
+func (_StructRepresentation_Stringjoin__ReprAssembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Stringjoin.Repr"}.AssignInt(0)
+}
+func (_StructRepresentation_Stringjoin__ReprAssembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Stringjoin.Repr"}.AssignFloat(0)
+}
+func (_StructRepresentation_Stringjoin__ReprAssembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Stringjoin.Repr"}.AssignString("")
+}
+func (_StructRepresentation_Stringjoin__ReprAssembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Stringjoin.Repr"}.AssignBytes(nil)
+}
+func (_StructRepresentation_Stringjoin__ReprAssembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.StructRepresentation_Stringjoin.Repr"}.AssignLink(nil)
+}
+func (na *_StructRepresentation_Stringjoin__ReprAssembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	v2, ok := v.(*_StructRepresentation_Stringjoin)
+	if v3, ok3 := v.(*_StructRepresentation_Stringjoin__Repr); ok3 {
+		v2, ok = (*_StructRepresentation_Stringjoin)(v3), true
+	}
+	if ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.StructRepresentation_Stringjoin.Repr", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_StructRepresentation_Stringjoin__ReprAssembler) Prototype() ipld.NodePrototype {
+	return _StructRepresentation_Stringjoin__ReprPrototype{}
+}
+func (ma *_StructRepresentation_Stringjoin__ReprAssembler) valueFinishTidy() bool {
+	switch ma.f {
+	case 0:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 1:
+		switch ma.w.fieldOrder.m {
+		case schema.Maybe_Value:
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_StructRepresentation_Stringjoin__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	switch k {
+	case "join":
+		if ma.s&fieldBit__StructRepresentation_Stringjoin_Join != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__StructRepresentation_Stringjoin_Join_serial}
+		}
+		ma.s += fieldBit__StructRepresentation_Stringjoin_Join
+		ma.state = maState_midValue
+		ma.f = 0
+		ma.ca_join.w = &ma.w.join
+		ma.ca_join.m = &ma.cm
+		return &ma.ca_join, nil
+	case "fieldOrder":
+		if ma.s&fieldBit__StructRepresentation_Stringjoin_FieldOrder != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__StructRepresentation_Stringjoin_FieldOrder_serial}
+		}
+		ma.s += fieldBit__StructRepresentation_Stringjoin_FieldOrder
+		ma.state = maState_midValue
+		ma.f = 1
+		ma.ca_fieldOrder.w = &ma.w.fieldOrder.v
+		ma.ca_fieldOrder.m = &ma.w.fieldOrder.m
+
+		return &ma.ca_fieldOrder, nil
+	default:
+	}
+	return nil, ipld.ErrInvalidKey{TypeName: "schemadmt.StructRepresentation_Stringjoin.Repr", Key: &_String{k}}
+}
+func (ma *_StructRepresentation_Stringjoin__ReprAssembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_StructRepresentation_Stringjoin__ReprKeyAssembler)(ma)
+}
+func (ma *_StructRepresentation_Stringjoin__ReprAssembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	case 0:
+		ma.ca_join.w = &ma.w.join
+		ma.ca_join.m = &ma.cm
+		return &ma.ca_join
+	case 1:
+		ma.ca_fieldOrder.w = &ma.w.fieldOrder.v
+		ma.ca_fieldOrder.m = &ma.w.fieldOrder.m
+
+		return &ma.ca_fieldOrder
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_StructRepresentation_Stringjoin__ReprAssembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__StructRepresentation_Stringjoin_sufficient != fieldBits__StructRepresentation_Stringjoin_sufficient {
+		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		if ma.s&fieldBit__StructRepresentation_Stringjoin_Join == 0 {
+			err.Missing = append(err.Missing, "join")
+		}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_StructRepresentation_Stringjoin__ReprAssembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_StructRepresentation_Stringjoin__ReprAssembler) ValuePrototype(k string) ipld.NodePrototype {
+	panic("todo structbuilder mapassembler repr valueprototype")
+}
+
+type _StructRepresentation_Stringjoin__ReprKeyAssembler _StructRepresentation_Stringjoin__ReprAssembler
+
+func (_StructRepresentation_Stringjoin__ReprKeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Stringjoin.Repr.KeyAssembler"}.BeginMap(0)
+}
+func (_StructRepresentation_Stringjoin__ReprKeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Stringjoin.Repr.KeyAssembler"}.BeginList(0)
+}
+func (na *_StructRepresentation_Stringjoin__ReprKeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Stringjoin.Repr.KeyAssembler"}.AssignNull()
+}
+func (_StructRepresentation_Stringjoin__ReprKeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Stringjoin.Repr.KeyAssembler"}.AssignBool(false)
+}
+func (_StructRepresentation_Stringjoin__ReprKeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Stringjoin.Repr.KeyAssembler"}.AssignInt(0)
+}
+func (_StructRepresentation_Stringjoin__ReprKeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Stringjoin.Repr.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_StructRepresentation_Stringjoin__ReprKeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	switch k {
+	case "join":
+		if ka.s&fieldBit__StructRepresentation_Stringjoin_Join != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__StructRepresentation_Stringjoin_Join_serial}
+		}
+		ka.s += fieldBit__StructRepresentation_Stringjoin_Join
+		ka.state = maState_expectValue
+		ka.f = 0
+		return nil
+	case "fieldOrder":
+		if ka.s&fieldBit__StructRepresentation_Stringjoin_FieldOrder != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__StructRepresentation_Stringjoin_FieldOrder_serial}
+		}
+		ka.s += fieldBit__StructRepresentation_Stringjoin_FieldOrder
+		ka.state = maState_expectValue
+		ka.f = 1
+		return nil
+	}
+	return ipld.ErrInvalidKey{TypeName: "schemadmt.StructRepresentation_Stringjoin.Repr", Key: &_String{k}}
+}
+func (_StructRepresentation_Stringjoin__ReprKeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Stringjoin.Repr.KeyAssembler"}.AssignBytes(nil)
+}
+func (_StructRepresentation_Stringjoin__ReprKeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.StructRepresentation_Stringjoin.Repr.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_StructRepresentation_Stringjoin__ReprKeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_StructRepresentation_Stringjoin__ReprKeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
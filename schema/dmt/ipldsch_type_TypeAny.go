@@ -0,0 +1,738 @@
+package schemadmt
+
+// Code generated by go-ipld-prime gengo.  DO NOT EDIT.
+
+import (
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// TypeAny matches the IPLD Schema type "TypeAny".  It has Struct type-kind, and may be interrogated like map kind.
+type TypeAny = *_TypeAny
+type _TypeAny struct {
+}
+
+type _TypeAny__Maybe struct {
+	m schema.Maybe
+	v TypeAny
+}
+type MaybeTypeAny = *_TypeAny__Maybe
+
+func (m MaybeTypeAny) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeTypeAny) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeTypeAny) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeTypeAny) AsNode() ipld.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return ipld.Absent
+	case schema.Maybe_Null:
+		return ipld.Null
+	case schema.Maybe_Value:
+		return m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybeTypeAny) Must() TypeAny {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return m.v
+}
+
+var ()
+var _ ipld.Node = (TypeAny)(&_TypeAny{})
+var _ schema.TypedNode = (TypeAny)(&_TypeAny{})
+
+func (TypeAny) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n TypeAny) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n TypeAny) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (TypeAny) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.TypeAny"}.LookupByIndex(0)
+}
+func (n TypeAny) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n TypeAny) MapIterator() ipld.MapIterator {
+	return &_TypeAny__MapItr{n, 0}
+}
+
+type _TypeAny__MapItr struct {
+	n   TypeAny
+	idx int
+}
+
+func (itr *_TypeAny__MapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	return nil, nil, ipld.ErrIteratorOverread{}
+
+}
+func (itr *_TypeAny__MapItr) Done() bool {
+	return itr.idx >= 0
+}
+
+func (TypeAny) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (TypeAny) Length() int64 {
+	return 0
+}
+func (TypeAny) IsAbsent() bool {
+	return false
+}
+func (TypeAny) IsNull() bool {
+	return false
+}
+func (TypeAny) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.TypeAny"}.AsBool()
+}
+func (TypeAny) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.TypeAny"}.AsInt()
+}
+func (TypeAny) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.TypeAny"}.AsFloat()
+}
+func (TypeAny) AsString() (string, error) {
+	return mixins.Map{"schemadmt.TypeAny"}.AsString()
+}
+func (TypeAny) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.TypeAny"}.AsBytes()
+}
+func (TypeAny) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.TypeAny"}.AsLink()
+}
+func (TypeAny) Prototype() ipld.NodePrototype {
+	return _TypeAny__Prototype{}
+}
+
+type _TypeAny__Prototype struct{}
+
+func (_TypeAny__Prototype) NewBuilder() ipld.NodeBuilder {
+	var nb _TypeAny__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _TypeAny__Builder struct {
+	_TypeAny__Assembler
+}
+
+func (nb *_TypeAny__Builder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_TypeAny__Builder) Reset() {
+	var w _TypeAny
+	var m schema.Maybe
+	*nb = _TypeAny__Builder{_TypeAny__Assembler{w: &w, m: &m}}
+}
+
+type _TypeAny__Assembler struct {
+	w     *_TypeAny
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm schema.Maybe
+}
+
+func (na *_TypeAny__Assembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+}
+
+var (
+	fieldBits__TypeAny_sufficient = 0
+)
+
+func (na *_TypeAny__Assembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_TypeAny{}
+	}
+	return na, nil
+}
+func (_TypeAny__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.TypeAny"}.BeginList(0)
+}
+func (na *_TypeAny__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.TypeAny"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_TypeAny__Assembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.TypeAny"}.AssignBool(false)
+}
+func (_TypeAny__Assembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.TypeAny"}.AssignInt(0)
+}
+func (_TypeAny__Assembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.TypeAny"}.AssignFloat(0)
+}
+func (_TypeAny__Assembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.TypeAny"}.AssignString("")
+}
+func (_TypeAny__Assembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.TypeAny"}.AssignBytes(nil)
+}
+func (_TypeAny__Assembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.TypeAny"}.AssignLink(nil)
+}
+func (na *_TypeAny__Assembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_TypeAny); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.TypeAny", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_TypeAny__Assembler) Prototype() ipld.NodePrototype {
+	return _TypeAny__Prototype{}
+}
+func (ma *_TypeAny__Assembler) valueFinishTidy() bool {
+	switch ma.f {
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_TypeAny__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	return nil, ipld.ErrInvalidKey{TypeName: "schemadmt.TypeAny", Key: &_String{k}}
+}
+func (ma *_TypeAny__Assembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_TypeAny__KeyAssembler)(ma)
+}
+func (ma *_TypeAny__Assembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_TypeAny__Assembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__TypeAny_sufficient != fieldBits__TypeAny_sufficient {
+		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_TypeAny__Assembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_TypeAny__Assembler) ValuePrototype(k string) ipld.NodePrototype {
+	panic("todo structbuilder mapassembler valueprototype")
+}
+
+type _TypeAny__KeyAssembler _TypeAny__Assembler
+
+func (_TypeAny__KeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeAny.KeyAssembler"}.BeginMap(0)
+}
+func (_TypeAny__KeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeAny.KeyAssembler"}.BeginList(0)
+}
+func (na *_TypeAny__KeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.TypeAny.KeyAssembler"}.AssignNull()
+}
+func (_TypeAny__KeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.TypeAny.KeyAssembler"}.AssignBool(false)
+}
+func (_TypeAny__KeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.TypeAny.KeyAssembler"}.AssignInt(0)
+}
+func (_TypeAny__KeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.TypeAny.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_TypeAny__KeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	return ipld.ErrInvalidKey{TypeName: "schemadmt.TypeAny", Key: &_String{k}}
+}
+func (_TypeAny__KeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.TypeAny.KeyAssembler"}.AssignBytes(nil)
+}
+func (_TypeAny__KeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.TypeAny.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_TypeAny__KeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_TypeAny__KeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (TypeAny) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (n TypeAny) Representation() ipld.Node {
+	return (*_TypeAny__Repr)(n)
+}
+
+type _TypeAny__Repr _TypeAny
+
+var ()
+var _ ipld.Node = &_TypeAny__Repr{}
+
+func (_TypeAny__Repr) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n *_TypeAny__Repr) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n *_TypeAny__Repr) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (_TypeAny__Repr) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.TypeAny.Repr"}.LookupByIndex(0)
+}
+func (n _TypeAny__Repr) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n *_TypeAny__Repr) MapIterator() ipld.MapIterator {
+	return &_TypeAny__ReprMapItr{n, 0}
+}
+
+type _TypeAny__ReprMapItr struct {
+	n   *_TypeAny__Repr
+	idx int
+}
+
+func (itr *_TypeAny__ReprMapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	return nil, nil, ipld.ErrIteratorOverread{}
+
+}
+func (itr *_TypeAny__ReprMapItr) Done() bool {
+	return itr.idx >= 0
+}
+func (_TypeAny__Repr) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (rn *_TypeAny__Repr) Length() int64 {
+	l := 0
+	return int64(l)
+}
+func (_TypeAny__Repr) IsAbsent() bool {
+	return false
+}
+func (_TypeAny__Repr) IsNull() bool {
+	return false
+}
+func (_TypeAny__Repr) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.TypeAny.Repr"}.AsBool()
+}
+func (_TypeAny__Repr) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.TypeAny.Repr"}.AsInt()
+}
+func (_TypeAny__Repr) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.TypeAny.Repr"}.AsFloat()
+}
+func (_TypeAny__Repr) AsString() (string, error) {
+	return mixins.Map{"schemadmt.TypeAny.Repr"}.AsString()
+}
+func (_TypeAny__Repr) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.TypeAny.Repr"}.AsBytes()
+}
+func (_TypeAny__Repr) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.TypeAny.Repr"}.AsLink()
+}
+func (_TypeAny__Repr) Prototype() ipld.NodePrototype {
+	return _TypeAny__ReprPrototype{}
+}
+
+type _TypeAny__ReprPrototype struct{}
+
+func (_TypeAny__ReprPrototype) NewBuilder() ipld.NodeBuilder {
+	var nb _TypeAny__ReprBuilder
+	nb.Reset()
+	return &nb
+}
+
+type _TypeAny__ReprBuilder struct {
+	_TypeAny__ReprAssembler
+}
+
+func (nb *_TypeAny__ReprBuilder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_TypeAny__ReprBuilder) Reset() {
+	var w _TypeAny
+	var m schema.Maybe
+	*nb = _TypeAny__ReprBuilder{_TypeAny__ReprAssembler{w: &w, m: &m}}
+}
+
+type _TypeAny__ReprAssembler struct {
+	w     *_TypeAny
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm schema.Maybe
+}
+
+func (na *_TypeAny__ReprAssembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+}
+func (na *_TypeAny__ReprAssembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_TypeAny{}
+	}
+	return na, nil
+}
+func (_TypeAny__ReprAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.TypeAny.Repr"}.BeginList(0)
+}
+func (na *_TypeAny__ReprAssembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.TypeAny.Repr.Repr"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_TypeAny__ReprAssembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.TypeAny.Repr"}.AssignBool(false)
+}
+func (_TypeAny__ReprAssembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.TypeAny.Repr"}.AssignInt(0)
+}
+func (_TypeAny__ReprAssembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.TypeAny.Repr"}.AssignFloat(0)
+}
+func (_TypeAny__ReprAssembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.TypeAny.Repr"}.AssignString("")
+}
+func (_TypeAny__ReprAssembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.TypeAny.Repr"}.AssignBytes(nil)
+}
+func (_TypeAny__ReprAssembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.TypeAny.Repr"}.AssignLink(nil)
+}
+func (na *_TypeAny__ReprAssembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	v2, ok := v.(*_TypeAny)
+	if v3, ok3 := v.(*_TypeAny__Repr); ok3 {
+		v2, ok = (*_TypeAny)(v3), true
+	}
+	if ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.TypeAny.Repr", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_TypeAny__ReprAssembler) Prototype() ipld.NodePrototype {
+	return _TypeAny__ReprPrototype{}
+}
+func (ma *_TypeAny__ReprAssembler) valueFinishTidy() bool {
+	switch ma.f {
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_TypeAny__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	return nil, ipld.ErrInvalidKey{TypeName: "schemadmt.TypeAny.Repr", Key: &_String{k}}
+}
+func (ma *_TypeAny__ReprAssembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_TypeAny__ReprKeyAssembler)(ma)
+}
+func (ma *_TypeAny__ReprAssembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_TypeAny__ReprAssembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__TypeAny_sufficient != fieldBits__TypeAny_sufficient {
+		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_TypeAny__ReprAssembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_TypeAny__ReprAssembler) ValuePrototype(k string) ipld.NodePrototype {
+	panic("todo structbuilder mapassembler repr valueprototype")
+}
+
+type _TypeAny__ReprKeyAssembler _TypeAny__ReprAssembler
+
+func (_TypeAny__ReprKeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeAny.Repr.KeyAssembler"}.BeginMap(0)
+}
+func (_TypeAny__ReprKeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeAny.Repr.KeyAssembler"}.BeginList(0)
+}
+func (na *_TypeAny__ReprKeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.TypeAny.Repr.KeyAssembler"}.AssignNull()
+}
+func (_TypeAny__ReprKeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.TypeAny.Repr.KeyAssembler"}.AssignBool(false)
+}
+func (_TypeAny__ReprKeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.TypeAny.Repr.KeyAssembler"}.AssignInt(0)
+}
+func (_TypeAny__ReprKeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.TypeAny.Repr.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_TypeAny__ReprKeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	return ipld.ErrInvalidKey{TypeName: "schemadmt.TypeAny.Repr", Key: &_String{k}}
+}
+func (_TypeAny__ReprKeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.TypeAny.Repr.KeyAssembler"}.AssignBytes(nil)
+}
+func (_TypeAny__ReprKeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.TypeAny.Repr.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_TypeAny__ReprKeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_TypeAny__ReprKeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
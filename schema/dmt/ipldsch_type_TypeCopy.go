@@ -0,0 +1,856 @@
+package schemadmt
+
+// Code generated by go-ipld-prime gengo.  DO NOT EDIT.
+
+import (
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// TypeCopy matches the IPLD Schema type "TypeCopy".  It has Struct type-kind, and may be interrogated like map kind.
+type TypeCopy = *_TypeCopy
+type _TypeCopy struct {
+	fromType _TypeName
+}
+
+// FieldFromType returns the value of the field "fromType".
+func (n _TypeCopy) FieldFromType() TypeName {
+	return &n.fromType
+}
+
+type _TypeCopy__Maybe struct {
+	m schema.Maybe
+	v TypeCopy
+}
+type MaybeTypeCopy = *_TypeCopy__Maybe
+
+func (m MaybeTypeCopy) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeTypeCopy) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeTypeCopy) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeTypeCopy) AsNode() ipld.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return ipld.Absent
+	case schema.Maybe_Null:
+		return ipld.Null
+	case schema.Maybe_Value:
+		return m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybeTypeCopy) Must() TypeCopy {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return m.v
+}
+
+var (
+	fieldName__TypeCopy_FromType = _String{"fromType"}
+)
+var _ ipld.Node = (TypeCopy)(&_TypeCopy{})
+var _ schema.TypedNode = (TypeCopy)(&_TypeCopy{})
+
+func (TypeCopy) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n TypeCopy) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	case "fromType":
+		return &n.fromType, nil
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n TypeCopy) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (TypeCopy) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.TypeCopy"}.LookupByIndex(0)
+}
+func (n TypeCopy) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n TypeCopy) MapIterator() ipld.MapIterator {
+	return &_TypeCopy__MapItr{n, 0}
+}
+
+type _TypeCopy__MapItr struct {
+	n   TypeCopy
+	idx int
+}
+
+func (itr *_TypeCopy__MapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	if itr.idx >= 1 {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	switch itr.idx {
+	case 0:
+		k = &fieldName__TypeCopy_FromType
+		v = &itr.n.fromType
+	default:
+		panic("unreachable")
+	}
+	itr.idx++
+	return
+}
+func (itr *_TypeCopy__MapItr) Done() bool {
+	return itr.idx >= 1
+}
+
+func (TypeCopy) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (TypeCopy) Length() int64 {
+	return 1
+}
+func (TypeCopy) IsAbsent() bool {
+	return false
+}
+func (TypeCopy) IsNull() bool {
+	return false
+}
+func (TypeCopy) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.TypeCopy"}.AsBool()
+}
+func (TypeCopy) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.TypeCopy"}.AsInt()
+}
+func (TypeCopy) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.TypeCopy"}.AsFloat()
+}
+func (TypeCopy) AsString() (string, error) {
+	return mixins.Map{"schemadmt.TypeCopy"}.AsString()
+}
+func (TypeCopy) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.TypeCopy"}.AsBytes()
+}
+func (TypeCopy) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.TypeCopy"}.AsLink()
+}
+func (TypeCopy) Prototype() ipld.NodePrototype {
+	return _TypeCopy__Prototype{}
+}
+
+type _TypeCopy__Prototype struct{}
+
+func (_TypeCopy__Prototype) NewBuilder() ipld.NodeBuilder {
+	var nb _TypeCopy__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _TypeCopy__Builder struct {
+	_TypeCopy__Assembler
+}
+
+func (nb *_TypeCopy__Builder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_TypeCopy__Builder) Reset() {
+	var w _TypeCopy
+	var m schema.Maybe
+	*nb = _TypeCopy__Builder{_TypeCopy__Assembler{w: &w, m: &m}}
+}
+
+type _TypeCopy__Assembler struct {
+	w     *_TypeCopy
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm          schema.Maybe
+	ca_fromType _TypeName__Assembler
+}
+
+func (na *_TypeCopy__Assembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+	na.ca_fromType.reset()
+}
+
+var (
+	fieldBit__TypeCopy_FromType    = 1 << 0
+	fieldBits__TypeCopy_sufficient = 0 + 1<<0
+)
+
+func (na *_TypeCopy__Assembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_TypeCopy{}
+	}
+	return na, nil
+}
+func (_TypeCopy__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.TypeCopy"}.BeginList(0)
+}
+func (na *_TypeCopy__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.TypeCopy"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_TypeCopy__Assembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.TypeCopy"}.AssignBool(false)
+}
+func (_TypeCopy__Assembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.TypeCopy"}.AssignInt(0)
+}
+func (_TypeCopy__Assembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.TypeCopy"}.AssignFloat(0)
+}
+func (_TypeCopy__Assembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.TypeCopy"}.AssignString("")
+}
+func (_TypeCopy__Assembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.TypeCopy"}.AssignBytes(nil)
+}
+func (_TypeCopy__Assembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.TypeCopy"}.AssignLink(nil)
+}
+func (na *_TypeCopy__Assembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_TypeCopy); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.TypeCopy", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_TypeCopy__Assembler) Prototype() ipld.NodePrototype {
+	return _TypeCopy__Prototype{}
+}
+func (ma *_TypeCopy__Assembler) valueFinishTidy() bool {
+	switch ma.f {
+	case 0:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.ca_fromType.w = nil
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_TypeCopy__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	switch k {
+	case "fromType":
+		if ma.s&fieldBit__TypeCopy_FromType != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__TypeCopy_FromType}
+		}
+		ma.s += fieldBit__TypeCopy_FromType
+		ma.state = maState_midValue
+		ma.f = 0
+		ma.ca_fromType.w = &ma.w.fromType
+		ma.ca_fromType.m = &ma.cm
+		return &ma.ca_fromType, nil
+	}
+	return nil, ipld.ErrInvalidKey{TypeName: "schemadmt.TypeCopy", Key: &_String{k}}
+}
+func (ma *_TypeCopy__Assembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_TypeCopy__KeyAssembler)(ma)
+}
+func (ma *_TypeCopy__Assembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	case 0:
+		ma.ca_fromType.w = &ma.w.fromType
+		ma.ca_fromType.m = &ma.cm
+		return &ma.ca_fromType
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_TypeCopy__Assembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__TypeCopy_sufficient != fieldBits__TypeCopy_sufficient {
+		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		if ma.s&fieldBit__TypeCopy_FromType == 0 {
+			err.Missing = append(err.Missing, "fromType")
+		}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_TypeCopy__Assembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_TypeCopy__Assembler) ValuePrototype(k string) ipld.NodePrototype {
+	panic("todo structbuilder mapassembler valueprototype")
+}
+
+type _TypeCopy__KeyAssembler _TypeCopy__Assembler
+
+func (_TypeCopy__KeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeCopy.KeyAssembler"}.BeginMap(0)
+}
+func (_TypeCopy__KeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeCopy.KeyAssembler"}.BeginList(0)
+}
+func (na *_TypeCopy__KeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.TypeCopy.KeyAssembler"}.AssignNull()
+}
+func (_TypeCopy__KeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.TypeCopy.KeyAssembler"}.AssignBool(false)
+}
+func (_TypeCopy__KeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.TypeCopy.KeyAssembler"}.AssignInt(0)
+}
+func (_TypeCopy__KeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.TypeCopy.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_TypeCopy__KeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	switch k {
+	case "fromType":
+		if ka.s&fieldBit__TypeCopy_FromType != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__TypeCopy_FromType}
+		}
+		ka.s += fieldBit__TypeCopy_FromType
+		ka.state = maState_expectValue
+		ka.f = 0
+	default:
+		return ipld.ErrInvalidKey{TypeName: "schemadmt.TypeCopy", Key: &_String{k}}
+	}
+	return nil
+}
+func (_TypeCopy__KeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.TypeCopy.KeyAssembler"}.AssignBytes(nil)
+}
+func (_TypeCopy__KeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.TypeCopy.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_TypeCopy__KeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_TypeCopy__KeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (TypeCopy) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (n TypeCopy) Representation() ipld.Node {
+	return (*_TypeCopy__Repr)(n)
+}
+
+type _TypeCopy__Repr _TypeCopy
+
+var (
+	fieldName__TypeCopy_FromType_serial = _String{"fromType"}
+)
+var _ ipld.Node = &_TypeCopy__Repr{}
+
+func (_TypeCopy__Repr) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n *_TypeCopy__Repr) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	case "fromType":
+		return n.fromType.Representation(), nil
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n *_TypeCopy__Repr) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (_TypeCopy__Repr) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.TypeCopy.Repr"}.LookupByIndex(0)
+}
+func (n _TypeCopy__Repr) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n *_TypeCopy__Repr) MapIterator() ipld.MapIterator {
+	return &_TypeCopy__ReprMapItr{n, 0}
+}
+
+type _TypeCopy__ReprMapItr struct {
+	n   *_TypeCopy__Repr
+	idx int
+}
+
+func (itr *_TypeCopy__ReprMapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	if itr.idx >= 1 {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	switch itr.idx {
+	case 0:
+		k = &fieldName__TypeCopy_FromType_serial
+		v = itr.n.fromType.Representation()
+	default:
+		panic("unreachable")
+	}
+	itr.idx++
+	return
+}
+func (itr *_TypeCopy__ReprMapItr) Done() bool {
+	return itr.idx >= 1
+}
+func (_TypeCopy__Repr) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (rn *_TypeCopy__Repr) Length() int64 {
+	l := 1
+	return int64(l)
+}
+func (_TypeCopy__Repr) IsAbsent() bool {
+	return false
+}
+func (_TypeCopy__Repr) IsNull() bool {
+	return false
+}
+func (_TypeCopy__Repr) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.TypeCopy.Repr"}.AsBool()
+}
+func (_TypeCopy__Repr) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.TypeCopy.Repr"}.AsInt()
+}
+func (_TypeCopy__Repr) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.TypeCopy.Repr"}.AsFloat()
+}
+func (_TypeCopy__Repr) AsString() (string, error) {
+	return mixins.Map{"schemadmt.TypeCopy.Repr"}.AsString()
+}
+func (_TypeCopy__Repr) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.TypeCopy.Repr"}.AsBytes()
+}
+func (_TypeCopy__Repr) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.TypeCopy.Repr"}.AsLink()
+}
+func (_TypeCopy__Repr) Prototype() ipld.NodePrototype {
+	return _TypeCopy__ReprPrototype{}
+}
+
+type _TypeCopy__ReprPrototype struct{}
+
+func (_TypeCopy__ReprPrototype) NewBuilder() ipld.NodeBuilder {
+	var nb _TypeCopy__ReprBuilder
+	nb.Reset()
+	return &nb
+}
+
+type _TypeCopy__ReprBuilder struct {
+	_TypeCopy__ReprAssembler
+}
+
+func (nb *_TypeCopy__ReprBuilder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_TypeCopy__ReprBuilder) Reset() {
+	var w _TypeCopy
+	var m schema.Maybe
+	*nb = _TypeCopy__ReprBuilder{_TypeCopy__ReprAssembler{w: &w, m: &m}}
+}
+
+type _TypeCopy__ReprAssembler struct {
+	w     *_TypeCopy
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm          schema.Maybe
+	ca_fromType _TypeName__ReprAssembler
+}
+
+func (na *_TypeCopy__ReprAssembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+	na.ca_fromType.reset()
+}
+func (na *_TypeCopy__ReprAssembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_TypeCopy{}
+	}
+	return na, nil
+}
+func (_TypeCopy__ReprAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.TypeCopy.Repr"}.BeginList(0)
+}
+func (na *_TypeCopy__ReprAssembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.TypeCopy.Repr.Repr"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_TypeCopy__ReprAssembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.TypeCopy.Repr"}.AssignBool(false)
+}
+func (_TypeCopy__ReprAssembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.TypeCopy.Repr"}.AssignInt(0)
+}
+func (_TypeCopy__ReprAssembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.TypeCopy.Repr"}.AssignFloat(0)
+}
+func (_TypeCopy__ReprAssembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.TypeCopy.Repr"}.AssignString("")
+}
+func (_TypeCopy__ReprAssembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.TypeCopy.Repr"}.AssignBytes(nil)
+}
+func (_TypeCopy__ReprAssembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.TypeCopy.Repr"}.AssignLink(nil)
+}
+func (na *_TypeCopy__ReprAssembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	v2, ok := v.(*_TypeCopy)
+	if v3, ok3 := v.(*_TypeCopy__Repr); ok3 {
+		v2, ok = (*_TypeCopy)(v3), true
+	}
+	if ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.TypeCopy.Repr", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_TypeCopy__ReprAssembler) Prototype() ipld.NodePrototype {
+	return _TypeCopy__ReprPrototype{}
+}
+func (ma *_TypeCopy__ReprAssembler) valueFinishTidy() bool {
+	switch ma.f {
+	case 0:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_TypeCopy__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	switch k {
+	case "fromType":
+		if ma.s&fieldBit__TypeCopy_FromType != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__TypeCopy_FromType_serial}
+		}
+		ma.s += fieldBit__TypeCopy_FromType
+		ma.state = maState_midValue
+		ma.f = 0
+		ma.ca_fromType.w = &ma.w.fromType
+		ma.ca_fromType.m = &ma.cm
+		return &ma.ca_fromType, nil
+	default:
+	}
+	return nil, ipld.ErrInvalidKey{TypeName: "schemadmt.TypeCopy.Repr", Key: &_String{k}}
+}
+func (ma *_TypeCopy__ReprAssembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_TypeCopy__ReprKeyAssembler)(ma)
+}
+func (ma *_TypeCopy__ReprAssembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	case 0:
+		ma.ca_fromType.w = &ma.w.fromType
+		ma.ca_fromType.m = &ma.cm
+		return &ma.ca_fromType
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_TypeCopy__ReprAssembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__TypeCopy_sufficient != fieldBits__TypeCopy_sufficient {
+		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		if ma.s&fieldBit__TypeCopy_FromType == 0 {
+			err.Missing = append(err.Missing, "fromType")
+		}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_TypeCopy__ReprAssembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_TypeCopy__ReprAssembler) ValuePrototype(k string) ipld.NodePrototype {
+	panic("todo structbuilder mapassembler repr valueprototype")
+}
+
+type _TypeCopy__ReprKeyAssembler _TypeCopy__ReprAssembler
+
+func (_TypeCopy__ReprKeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeCopy.Repr.KeyAssembler"}.BeginMap(0)
+}
+func (_TypeCopy__ReprKeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeCopy.Repr.KeyAssembler"}.BeginList(0)
+}
+func (na *_TypeCopy__ReprKeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.TypeCopy.Repr.KeyAssembler"}.AssignNull()
+}
+func (_TypeCopy__ReprKeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.TypeCopy.Repr.KeyAssembler"}.AssignBool(false)
+}
+func (_TypeCopy__ReprKeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.TypeCopy.Repr.KeyAssembler"}.AssignInt(0)
+}
+func (_TypeCopy__ReprKeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.TypeCopy.Repr.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_TypeCopy__ReprKeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	switch k {
+	case "fromType":
+		if ka.s&fieldBit__TypeCopy_FromType != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__TypeCopy_FromType_serial}
+		}
+		ka.s += fieldBit__TypeCopy_FromType
+		ka.state = maState_expectValue
+		ka.f = 0
+		return nil
+	}
+	return ipld.ErrInvalidKey{TypeName: "schemadmt.TypeCopy.Repr", Key: &_String{k}}
+}
+func (_TypeCopy__ReprKeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.TypeCopy.Repr.KeyAssembler"}.AssignBytes(nil)
+}
+func (_TypeCopy__ReprKeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.TypeCopy.Repr.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_TypeCopy__ReprKeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_TypeCopy__ReprKeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
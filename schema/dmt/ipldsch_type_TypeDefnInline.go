@@ -0,0 +1,1057 @@
+package schemadmt
+
+// Code generated by go-ipld-prime gengo.  DO NOT EDIT.
+
+import (
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// TypeDefnInline matches the IPLD Schema type "TypeDefnInline".
+// TypeDefnInline has Union typekind, which means its data model behaviors are that of a map kind.
+type TypeDefnInline = *_TypeDefnInline
+type _TypeDefnInline struct {
+	x _TypeDefnInline__iface
+}
+type _TypeDefnInline__iface interface {
+	_TypeDefnInline__member()
+}
+
+func (_TypeMap) _TypeDefnInline__member()  {}
+func (_TypeList) _TypeDefnInline__member() {}
+func (_TypeLink) _TypeDefnInline__member() {}
+func (n _TypeDefnInline) AsInterface() _TypeDefnInline__iface {
+	return n.x
+}
+
+// MemberTypeMap returns the union's value if it's a TypeMap, and nil otherwise.
+func (n _TypeDefnInline) MemberTypeMap() TypeMap {
+	x, _ := n.x.(TypeMap)
+	return x
+}
+
+// MemberTypeList returns the union's value if it's a TypeList, and nil otherwise.
+func (n _TypeDefnInline) MemberTypeList() TypeList {
+	x, _ := n.x.(TypeList)
+	return x
+}
+
+// MemberTypeLink returns the union's value if it's a TypeLink, and nil otherwise.
+func (n _TypeDefnInline) MemberTypeLink() TypeLink {
+	x, _ := n.x.(TypeLink)
+	return x
+}
+
+type _TypeDefnInline__Maybe struct {
+	m schema.Maybe
+	v TypeDefnInline
+}
+type MaybeTypeDefnInline = *_TypeDefnInline__Maybe
+
+func (m MaybeTypeDefnInline) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeTypeDefnInline) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeTypeDefnInline) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeTypeDefnInline) AsNode() ipld.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return ipld.Absent
+	case schema.Maybe_Null:
+		return ipld.Null
+	case schema.Maybe_Value:
+		return m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybeTypeDefnInline) Must() TypeDefnInline {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return m.v
+}
+
+var (
+	memberName__TypeDefnInline_TypeMap  = _String{"TypeMap"}
+	memberName__TypeDefnInline_TypeList = _String{"TypeList"}
+	memberName__TypeDefnInline_TypeLink = _String{"TypeLink"}
+)
+var _ ipld.Node = (TypeDefnInline)(&_TypeDefnInline{})
+var _ schema.TypedNode = (TypeDefnInline)(&_TypeDefnInline{})
+
+func (TypeDefnInline) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n TypeDefnInline) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	case "TypeMap":
+		if n2, ok := n.x.(TypeMap); ok {
+			return n2, nil
+		} else {
+			return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(key)}
+		}
+	case "TypeList":
+		if n2, ok := n.x.(TypeList); ok {
+			return n2, nil
+		} else {
+			return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(key)}
+		}
+	case "TypeLink":
+		if n2, ok := n.x.(TypeLink); ok {
+			return n2, nil
+		} else {
+			return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(key)}
+		}
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n TypeDefnInline) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (TypeDefnInline) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.TypeDefnInline"}.LookupByIndex(0)
+}
+func (n TypeDefnInline) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n TypeDefnInline) MapIterator() ipld.MapIterator {
+	return &_TypeDefnInline__MapItr{n, false}
+}
+
+type _TypeDefnInline__MapItr struct {
+	n    TypeDefnInline
+	done bool
+}
+
+func (itr *_TypeDefnInline__MapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	if itr.done {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	switch n2 := itr.n.x.(type) {
+	case TypeMap:
+		k, v = &memberName__TypeDefnInline_TypeMap, n2
+	case TypeList:
+		k, v = &memberName__TypeDefnInline_TypeList, n2
+	case TypeLink:
+		k, v = &memberName__TypeDefnInline_TypeLink, n2
+	default:
+		panic("unreachable")
+	}
+	itr.done = true
+	return
+}
+func (itr *_TypeDefnInline__MapItr) Done() bool {
+	return itr.done
+}
+
+func (TypeDefnInline) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (TypeDefnInline) Length() int64 {
+	return 1
+}
+func (TypeDefnInline) IsAbsent() bool {
+	return false
+}
+func (TypeDefnInline) IsNull() bool {
+	return false
+}
+func (TypeDefnInline) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.TypeDefnInline"}.AsBool()
+}
+func (TypeDefnInline) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.TypeDefnInline"}.AsInt()
+}
+func (TypeDefnInline) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.TypeDefnInline"}.AsFloat()
+}
+func (TypeDefnInline) AsString() (string, error) {
+	return mixins.Map{"schemadmt.TypeDefnInline"}.AsString()
+}
+func (TypeDefnInline) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.TypeDefnInline"}.AsBytes()
+}
+func (TypeDefnInline) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.TypeDefnInline"}.AsLink()
+}
+func (TypeDefnInline) Prototype() ipld.NodePrototype {
+	return _TypeDefnInline__Prototype{}
+}
+
+type _TypeDefnInline__Prototype struct{}
+
+func (_TypeDefnInline__Prototype) NewBuilder() ipld.NodeBuilder {
+	var nb _TypeDefnInline__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _TypeDefnInline__Builder struct {
+	_TypeDefnInline__Assembler
+}
+
+func (nb *_TypeDefnInline__Builder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_TypeDefnInline__Builder) Reset() {
+	var w _TypeDefnInline
+	var m schema.Maybe
+	*nb = _TypeDefnInline__Builder{_TypeDefnInline__Assembler{w: &w, m: &m}}
+}
+
+type _TypeDefnInline__Assembler struct {
+	w     *_TypeDefnInline
+	m     *schema.Maybe
+	state maState
+
+	cm  schema.Maybe
+	ca1 *_TypeMap__Assembler
+
+	ca2 *_TypeList__Assembler
+
+	ca3 *_TypeLink__Assembler
+	ca  uint
+}
+
+func (na *_TypeDefnInline__Assembler) reset() {
+	na.state = maState_initial
+	switch na.ca {
+	case 0:
+		return
+	case 1:
+		na.ca1.reset()
+
+	case 2:
+		na.ca2.reset()
+
+	case 3:
+		na.ca3.reset()
+	default:
+		panic("unreachable")
+	}
+	na.ca = 0
+	na.cm = schema.Maybe_Absent
+}
+func (na *_TypeDefnInline__Assembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_TypeDefnInline{}
+	}
+	return na, nil
+}
+func (_TypeDefnInline__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.TypeDefnInline"}.BeginList(0)
+}
+func (na *_TypeDefnInline__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.TypeDefnInline"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_TypeDefnInline__Assembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.TypeDefnInline"}.AssignBool(false)
+}
+func (_TypeDefnInline__Assembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.TypeDefnInline"}.AssignInt(0)
+}
+func (_TypeDefnInline__Assembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.TypeDefnInline"}.AssignFloat(0)
+}
+func (_TypeDefnInline__Assembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.TypeDefnInline"}.AssignString("")
+}
+func (_TypeDefnInline__Assembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.TypeDefnInline"}.AssignBytes(nil)
+}
+func (_TypeDefnInline__Assembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.TypeDefnInline"}.AssignLink(nil)
+}
+func (na *_TypeDefnInline__Assembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_TypeDefnInline); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.TypeDefnInline", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_TypeDefnInline__Assembler) Prototype() ipld.NodePrototype {
+	return _TypeDefnInline__Prototype{}
+}
+func (ma *_TypeDefnInline__Assembler) valueFinishTidy() bool {
+	switch ma.cm {
+	case schema.Maybe_Value:
+		ma.state = maState_initial
+		return true
+	default:
+		return false
+	}
+}
+func (ma *_TypeDefnInline__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on for the moment, but we'll still be erroring shortly.
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	if ma.ca != 0 {
+		return nil, schema.ErrNotUnionStructure{TypeName: "schemadmt.TypeDefnInline", Detail: "cannot add another entry -- a union can only contain one thing!"}
+	}
+	switch k {
+	case "TypeMap":
+		ma.state = maState_midValue
+		ma.ca = 1
+		x := &_TypeMap{}
+		ma.w.x = x
+		if ma.ca1 == nil {
+			ma.ca1 = &_TypeMap__Assembler{}
+		}
+		ma.ca1.w = x
+		ma.ca1.m = &ma.cm
+		return ma.ca1, nil
+	case "TypeList":
+		ma.state = maState_midValue
+		ma.ca = 2
+		x := &_TypeList{}
+		ma.w.x = x
+		if ma.ca2 == nil {
+			ma.ca2 = &_TypeList__Assembler{}
+		}
+		ma.ca2.w = x
+		ma.ca2.m = &ma.cm
+		return ma.ca2, nil
+	case "TypeLink":
+		ma.state = maState_midValue
+		ma.ca = 3
+		x := &_TypeLink{}
+		ma.w.x = x
+		if ma.ca3 == nil {
+			ma.ca3 = &_TypeLink__Assembler{}
+		}
+		ma.ca3.w = x
+		ma.ca3.m = &ma.cm
+		return ma.ca3, nil
+	}
+	return nil, schema.ErrInvalidUnionDiscriminant{TypeName: "schemadmt.TypeDefnInline", Discriminant: k, Expected: []string{"TypeMap", "TypeList", "TypeLink"}}
+}
+func (ma *_TypeDefnInline__Assembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on for the moment, but we'll still be erroring shortly... or rather, the keyassembler will be.
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_TypeDefnInline__KeyAssembler)(ma)
+}
+func (ma *_TypeDefnInline__Assembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.ca {
+	case 1:
+		x := &_TypeMap{}
+		ma.w.x = x
+		if ma.ca1 == nil {
+			ma.ca1 = &_TypeMap__Assembler{}
+		}
+		ma.ca1.w = x
+		ma.ca1.m = &ma.cm
+		return ma.ca1
+	case 2:
+		x := &_TypeList{}
+		ma.w.x = x
+		if ma.ca2 == nil {
+			ma.ca2 = &_TypeList__Assembler{}
+		}
+		ma.ca2.w = x
+		ma.ca2.m = &ma.cm
+		return ma.ca2
+	case 3:
+		x := &_TypeLink{}
+		ma.w.x = x
+		if ma.ca3 == nil {
+			ma.ca3 = &_TypeLink__Assembler{}
+		}
+		ma.ca3.w = x
+		ma.ca3.m = &ma.cm
+		return ma.ca3
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_TypeDefnInline__Assembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.ca == 0 {
+		return schema.ErrNotUnionStructure{TypeName: "schemadmt.TypeDefnInline", Detail: "a union must have exactly one entry (not none)!"}
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_TypeDefnInline__Assembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_TypeDefnInline__Assembler) ValuePrototype(k string) ipld.NodePrototype {
+	switch k {
+	case "TypeMap":
+		return _TypeMap__Prototype{}
+	case "TypeList":
+		return _TypeList__Prototype{}
+	case "TypeLink":
+		return _TypeLink__Prototype{}
+	default:
+		return nil
+	}
+}
+
+type _TypeDefnInline__KeyAssembler _TypeDefnInline__Assembler
+
+func (_TypeDefnInline__KeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeDefnInline.KeyAssembler"}.BeginMap(0)
+}
+func (_TypeDefnInline__KeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeDefnInline.KeyAssembler"}.BeginList(0)
+}
+func (na *_TypeDefnInline__KeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.TypeDefnInline.KeyAssembler"}.AssignNull()
+}
+func (_TypeDefnInline__KeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.TypeDefnInline.KeyAssembler"}.AssignBool(false)
+}
+func (_TypeDefnInline__KeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.TypeDefnInline.KeyAssembler"}.AssignInt(0)
+}
+func (_TypeDefnInline__KeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.TypeDefnInline.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_TypeDefnInline__KeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	if ka.ca != 0 {
+		return schema.ErrNotUnionStructure{TypeName: "schemadmt.TypeDefnInline", Detail: "cannot add another entry -- a union can only contain one thing!"}
+	}
+	switch k {
+	case "TypeMap":
+		ka.ca = 1
+		ka.state = maState_expectValue
+		return nil
+	case "TypeList":
+		ka.ca = 2
+		ka.state = maState_expectValue
+		return nil
+	case "TypeLink":
+		ka.ca = 3
+		ka.state = maState_expectValue
+		return nil
+	}
+	return schema.ErrInvalidUnionDiscriminant{TypeName: "schemadmt.TypeDefnInline", Discriminant: k, Expected: []string{"TypeMap", "TypeList", "TypeLink"}}
+}
+func (_TypeDefnInline__KeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.TypeDefnInline.KeyAssembler"}.AssignBytes(nil)
+}
+func (_TypeDefnInline__KeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.TypeDefnInline.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_TypeDefnInline__KeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_TypeDefnInline__KeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (TypeDefnInline) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (n TypeDefnInline) Representation() ipld.Node {
+	return (*_TypeDefnInline__Repr)(n)
+}
+
+type _TypeDefnInline__Repr _TypeDefnInline
+
+var (
+	memberName__TypeDefnInline_TypeMap_serial  = _String{"map"}
+	memberName__TypeDefnInline_TypeList_serial = _String{"list"}
+	memberName__TypeDefnInline_TypeLink_serial = _String{"link"}
+)
+var _ ipld.Node = &_TypeDefnInline__Repr{}
+
+func (_TypeDefnInline__Repr) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n *_TypeDefnInline__Repr) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	case "map":
+		if n2, ok := n.x.(TypeMap); ok {
+			return n2.Representation(), nil
+		} else {
+			return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(key)}
+		}
+	case "list":
+		if n2, ok := n.x.(TypeList); ok {
+			return n2.Representation(), nil
+		} else {
+			return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(key)}
+		}
+	case "link":
+		if n2, ok := n.x.(TypeLink); ok {
+			return n2.Representation(), nil
+		} else {
+			return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(key)}
+		}
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n *_TypeDefnInline__Repr) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (_TypeDefnInline__Repr) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.TypeDefnInline.Repr"}.LookupByIndex(0)
+}
+func (n _TypeDefnInline__Repr) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n *_TypeDefnInline__Repr) MapIterator() ipld.MapIterator {
+	return &_TypeDefnInline__ReprMapItr{n, false}
+}
+
+type _TypeDefnInline__ReprMapItr struct {
+	n    *_TypeDefnInline__Repr
+	done bool
+}
+
+func (itr *_TypeDefnInline__ReprMapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	if itr.done {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	switch n2 := itr.n.x.(type) {
+	case TypeMap:
+		k, v = &memberName__TypeDefnInline_TypeMap_serial, n2.Representation()
+	case TypeList:
+		k, v = &memberName__TypeDefnInline_TypeList_serial, n2.Representation()
+	case TypeLink:
+		k, v = &memberName__TypeDefnInline_TypeLink_serial, n2.Representation()
+	default:
+		panic("unreachable")
+	}
+	itr.done = true
+	return
+}
+func (itr *_TypeDefnInline__ReprMapItr) Done() bool {
+	return itr.done
+}
+
+func (_TypeDefnInline__Repr) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (_TypeDefnInline__Repr) Length() int64 {
+	return 1
+}
+func (_TypeDefnInline__Repr) IsAbsent() bool {
+	return false
+}
+func (_TypeDefnInline__Repr) IsNull() bool {
+	return false
+}
+func (_TypeDefnInline__Repr) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.TypeDefnInline.Repr"}.AsBool()
+}
+func (_TypeDefnInline__Repr) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.TypeDefnInline.Repr"}.AsInt()
+}
+func (_TypeDefnInline__Repr) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.TypeDefnInline.Repr"}.AsFloat()
+}
+func (_TypeDefnInline__Repr) AsString() (string, error) {
+	return mixins.Map{"schemadmt.TypeDefnInline.Repr"}.AsString()
+}
+func (_TypeDefnInline__Repr) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.TypeDefnInline.Repr"}.AsBytes()
+}
+func (_TypeDefnInline__Repr) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.TypeDefnInline.Repr"}.AsLink()
+}
+func (_TypeDefnInline__Repr) Prototype() ipld.NodePrototype {
+	return _TypeDefnInline__ReprPrototype{}
+}
+
+type _TypeDefnInline__ReprPrototype struct{}
+
+func (_TypeDefnInline__ReprPrototype) NewBuilder() ipld.NodeBuilder {
+	var nb _TypeDefnInline__ReprBuilder
+	nb.Reset()
+	return &nb
+}
+
+type _TypeDefnInline__ReprBuilder struct {
+	_TypeDefnInline__ReprAssembler
+}
+
+func (nb *_TypeDefnInline__ReprBuilder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_TypeDefnInline__ReprBuilder) Reset() {
+	var w _TypeDefnInline
+	var m schema.Maybe
+	*nb = _TypeDefnInline__ReprBuilder{_TypeDefnInline__ReprAssembler{w: &w, m: &m}}
+}
+
+type _TypeDefnInline__ReprAssembler struct {
+	w     *_TypeDefnInline
+	m     *schema.Maybe
+	state maState
+
+	cm  schema.Maybe
+	ca1 *_TypeMap__ReprAssembler
+
+	ca2 *_TypeList__ReprAssembler
+
+	ca3 *_TypeLink__ReprAssembler
+	ca  uint
+}
+
+func (na *_TypeDefnInline__ReprAssembler) reset() {
+	na.state = maState_initial
+	switch na.ca {
+	case 0:
+		return
+	case 1:
+		na.ca1.reset()
+
+	case 2:
+		na.ca2.reset()
+
+	case 3:
+		na.ca3.reset()
+	default:
+		panic("unreachable")
+	}
+	na.ca = 0
+	na.cm = schema.Maybe_Absent
+}
+func (na *_TypeDefnInline__ReprAssembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_TypeDefnInline{}
+	}
+	return na, nil
+}
+func (_TypeDefnInline__ReprAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.TypeDefnInline.Repr"}.BeginList(0)
+}
+func (na *_TypeDefnInline__ReprAssembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.TypeDefnInline.Repr.Repr"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_TypeDefnInline__ReprAssembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.TypeDefnInline.Repr"}.AssignBool(false)
+}
+func (_TypeDefnInline__ReprAssembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.TypeDefnInline.Repr"}.AssignInt(0)
+}
+func (_TypeDefnInline__ReprAssembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.TypeDefnInline.Repr"}.AssignFloat(0)
+}
+func (_TypeDefnInline__ReprAssembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.TypeDefnInline.Repr"}.AssignString("")
+}
+func (_TypeDefnInline__ReprAssembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.TypeDefnInline.Repr"}.AssignBytes(nil)
+}
+func (_TypeDefnInline__ReprAssembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.TypeDefnInline.Repr"}.AssignLink(nil)
+}
+func (na *_TypeDefnInline__ReprAssembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	v2, ok := v.(*_TypeDefnInline)
+	if v3, ok3 := v.(*_TypeDefnInline__Repr); ok3 {
+		v2, ok = (*_TypeDefnInline)(v3), true
+	}
+	if ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.TypeDefnInline.Repr", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_TypeDefnInline__ReprAssembler) Prototype() ipld.NodePrototype {
+	return _TypeDefnInline__ReprPrototype{}
+}
+func (ma *_TypeDefnInline__ReprAssembler) valueFinishTidy() bool {
+	switch ma.cm {
+	case schema.Maybe_Value:
+		ma.state = maState_initial
+		return true
+	default:
+		return false
+	}
+}
+func (ma *_TypeDefnInline__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on for the moment, but we'll still be erroring shortly.
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	if ma.ca != 0 {
+		return nil, schema.ErrNotUnionStructure{TypeName: "schemadmt.TypeDefnInline.Repr", Detail: "cannot add another entry -- a union can only contain one thing!"}
+	}
+	switch k {
+	case "map":
+		ma.state = maState_midValue
+		ma.ca = 1
+		x := &_TypeMap{}
+		ma.w.x = x
+		if ma.ca1 == nil {
+			ma.ca1 = &_TypeMap__ReprAssembler{}
+		}
+		ma.ca1.w = x
+		ma.ca1.m = &ma.cm
+		return ma.ca1, nil
+	case "list":
+		ma.state = maState_midValue
+		ma.ca = 2
+		x := &_TypeList{}
+		ma.w.x = x
+		if ma.ca2 == nil {
+			ma.ca2 = &_TypeList__ReprAssembler{}
+		}
+		ma.ca2.w = x
+		ma.ca2.m = &ma.cm
+		return ma.ca2, nil
+	case "link":
+		ma.state = maState_midValue
+		ma.ca = 3
+		x := &_TypeLink{}
+		ma.w.x = x
+		if ma.ca3 == nil {
+			ma.ca3 = &_TypeLink__ReprAssembler{}
+		}
+		ma.ca3.w = x
+		ma.ca3.m = &ma.cm
+		return ma.ca3, nil
+	}
+	return nil, schema.ErrInvalidUnionDiscriminant{TypeName: "schemadmt.TypeDefnInline.Repr", Discriminant: k, Expected: []string{"map", "list", "link"}}
+}
+func (ma *_TypeDefnInline__ReprAssembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on for the moment, but we'll still be erroring shortly... or rather, the keyassembler will be.
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_TypeDefnInline__ReprKeyAssembler)(ma)
+}
+func (ma *_TypeDefnInline__ReprAssembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.ca {
+	case 1:
+		x := &_TypeMap{}
+		ma.w.x = x
+		if ma.ca1 == nil {
+			ma.ca1 = &_TypeMap__ReprAssembler{}
+		}
+		ma.ca1.w = x
+		ma.ca1.m = &ma.cm
+		return ma.ca1
+	case 2:
+		x := &_TypeList{}
+		ma.w.x = x
+		if ma.ca2 == nil {
+			ma.ca2 = &_TypeList__ReprAssembler{}
+		}
+		ma.ca2.w = x
+		ma.ca2.m = &ma.cm
+		return ma.ca2
+	case 3:
+		x := &_TypeLink{}
+		ma.w.x = x
+		if ma.ca3 == nil {
+			ma.ca3 = &_TypeLink__ReprAssembler{}
+		}
+		ma.ca3.w = x
+		ma.ca3.m = &ma.cm
+		return ma.ca3
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_TypeDefnInline__ReprAssembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.ca == 0 {
+		return schema.ErrNotUnionStructure{TypeName: "schemadmt.TypeDefnInline.Repr", Detail: "a union must have exactly one entry (not none)!"}
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_TypeDefnInline__ReprAssembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_TypeDefnInline__ReprAssembler) ValuePrototype(k string) ipld.NodePrototype {
+	switch k {
+	case "TypeMap":
+		return _TypeMap__ReprPrototype{}
+	case "TypeList":
+		return _TypeList__ReprPrototype{}
+	case "TypeLink":
+		return _TypeLink__ReprPrototype{}
+	default:
+		return nil
+	}
+}
+
+type _TypeDefnInline__ReprKeyAssembler _TypeDefnInline__ReprAssembler
+
+func (_TypeDefnInline__ReprKeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeDefnInline.Repr.KeyAssembler"}.BeginMap(0)
+}
+func (_TypeDefnInline__ReprKeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeDefnInline.Repr.KeyAssembler"}.BeginList(0)
+}
+func (na *_TypeDefnInline__ReprKeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.TypeDefnInline.Repr.KeyAssembler"}.AssignNull()
+}
+func (_TypeDefnInline__ReprKeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.TypeDefnInline.Repr.KeyAssembler"}.AssignBool(false)
+}
+func (_TypeDefnInline__ReprKeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.TypeDefnInline.Repr.KeyAssembler"}.AssignInt(0)
+}
+func (_TypeDefnInline__ReprKeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.TypeDefnInline.Repr.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_TypeDefnInline__ReprKeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	if ka.ca != 0 {
+		return schema.ErrNotUnionStructure{TypeName: "schemadmt.TypeDefnInline.Repr", Detail: "cannot add another entry -- a union can only contain one thing!"}
+	}
+	switch k {
+	case "map":
+		ka.ca = 1
+		ka.state = maState_expectValue
+		return nil
+	case "list":
+		ka.ca = 2
+		ka.state = maState_expectValue
+		return nil
+	case "link":
+		ka.ca = 3
+		ka.state = maState_expectValue
+		return nil
+	}
+	return schema.ErrInvalidUnionDiscriminant{TypeName: "schemadmt.TypeDefnInline.Repr", Discriminant: k, Expected: []string{"map", "list", "link"}}
+}
+func (_TypeDefnInline__ReprKeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.TypeDefnInline.Repr.KeyAssembler"}.AssignBytes(nil)
+}
+func (_TypeDefnInline__ReprKeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.TypeDefnInline.Repr.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_TypeDefnInline__ReprKeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_TypeDefnInline__ReprKeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
@@ -0,0 +1,947 @@
+package schemadmt
+
+// Code generated by go-ipld-prime gengo.  DO NOT EDIT.
+
+import (
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// TypeEnum matches the IPLD Schema type "TypeEnum".  It has Struct type-kind, and may be interrogated like map kind.
+type TypeEnum = *_TypeEnum
+type _TypeEnum struct {
+	members        _Map__EnumValue__Unit
+	representation _EnumRepresentation
+}
+
+// FieldMembers returns the value of the field "members".
+func (n _TypeEnum) FieldMembers() Map__EnumValue__Unit {
+	return &n.members
+}
+
+// FieldRepresentation returns the value of the field "representation".
+func (n _TypeEnum) FieldRepresentation() EnumRepresentation {
+	return &n.representation
+}
+
+type _TypeEnum__Maybe struct {
+	m schema.Maybe
+	v TypeEnum
+}
+type MaybeTypeEnum = *_TypeEnum__Maybe
+
+func (m MaybeTypeEnum) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeTypeEnum) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeTypeEnum) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeTypeEnum) AsNode() ipld.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return ipld.Absent
+	case schema.Maybe_Null:
+		return ipld.Null
+	case schema.Maybe_Value:
+		return m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybeTypeEnum) Must() TypeEnum {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return m.v
+}
+
+var (
+	fieldName__TypeEnum_Members        = _String{"members"}
+	fieldName__TypeEnum_Representation = _String{"representation"}
+)
+var _ ipld.Node = (TypeEnum)(&_TypeEnum{})
+var _ schema.TypedNode = (TypeEnum)(&_TypeEnum{})
+
+func (TypeEnum) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n TypeEnum) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	case "members":
+		return &n.members, nil
+	case "representation":
+		return &n.representation, nil
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n TypeEnum) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (TypeEnum) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.TypeEnum"}.LookupByIndex(0)
+}
+func (n TypeEnum) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n TypeEnum) MapIterator() ipld.MapIterator {
+	return &_TypeEnum__MapItr{n, 0}
+}
+
+type _TypeEnum__MapItr struct {
+	n   TypeEnum
+	idx int
+}
+
+func (itr *_TypeEnum__MapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	if itr.idx >= 2 {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	switch itr.idx {
+	case 0:
+		k = &fieldName__TypeEnum_Members
+		v = &itr.n.members
+	case 1:
+		k = &fieldName__TypeEnum_Representation
+		v = &itr.n.representation
+	default:
+		panic("unreachable")
+	}
+	itr.idx++
+	return
+}
+func (itr *_TypeEnum__MapItr) Done() bool {
+	return itr.idx >= 2
+}
+
+func (TypeEnum) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (TypeEnum) Length() int64 {
+	return 2
+}
+func (TypeEnum) IsAbsent() bool {
+	return false
+}
+func (TypeEnum) IsNull() bool {
+	return false
+}
+func (TypeEnum) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.TypeEnum"}.AsBool()
+}
+func (TypeEnum) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.TypeEnum"}.AsInt()
+}
+func (TypeEnum) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.TypeEnum"}.AsFloat()
+}
+func (TypeEnum) AsString() (string, error) {
+	return mixins.Map{"schemadmt.TypeEnum"}.AsString()
+}
+func (TypeEnum) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.TypeEnum"}.AsBytes()
+}
+func (TypeEnum) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.TypeEnum"}.AsLink()
+}
+func (TypeEnum) Prototype() ipld.NodePrototype {
+	return _TypeEnum__Prototype{}
+}
+
+type _TypeEnum__Prototype struct{}
+
+func (_TypeEnum__Prototype) NewBuilder() ipld.NodeBuilder {
+	var nb _TypeEnum__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _TypeEnum__Builder struct {
+	_TypeEnum__Assembler
+}
+
+func (nb *_TypeEnum__Builder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_TypeEnum__Builder) Reset() {
+	var w _TypeEnum
+	var m schema.Maybe
+	*nb = _TypeEnum__Builder{_TypeEnum__Assembler{w: &w, m: &m}}
+}
+
+type _TypeEnum__Assembler struct {
+	w     *_TypeEnum
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm                schema.Maybe
+	ca_members        _Map__EnumValue__Unit__Assembler
+	ca_representation _EnumRepresentation__Assembler
+}
+
+func (na *_TypeEnum__Assembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+	na.ca_members.reset()
+	na.ca_representation.reset()
+}
+
+var (
+	fieldBit__TypeEnum_Members        = 1 << 0
+	fieldBit__TypeEnum_Representation = 1 << 1
+	fieldBits__TypeEnum_sufficient    = 0 + 1<<0 + 1<<1
+)
+
+func (na *_TypeEnum__Assembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_TypeEnum{}
+	}
+	return na, nil
+}
+func (_TypeEnum__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.TypeEnum"}.BeginList(0)
+}
+func (na *_TypeEnum__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.TypeEnum"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_TypeEnum__Assembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.TypeEnum"}.AssignBool(false)
+}
+func (_TypeEnum__Assembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.TypeEnum"}.AssignInt(0)
+}
+func (_TypeEnum__Assembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.TypeEnum"}.AssignFloat(0)
+}
+func (_TypeEnum__Assembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.TypeEnum"}.AssignString("")
+}
+func (_TypeEnum__Assembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.TypeEnum"}.AssignBytes(nil)
+}
+func (_TypeEnum__Assembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.TypeEnum"}.AssignLink(nil)
+}
+func (na *_TypeEnum__Assembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_TypeEnum); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.TypeEnum", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_TypeEnum__Assembler) Prototype() ipld.NodePrototype {
+	return _TypeEnum__Prototype{}
+}
+func (ma *_TypeEnum__Assembler) valueFinishTidy() bool {
+	switch ma.f {
+	case 0:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.ca_members.w = nil
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 1:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.ca_representation.w = nil
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_TypeEnum__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	switch k {
+	case "members":
+		if ma.s&fieldBit__TypeEnum_Members != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__TypeEnum_Members}
+		}
+		ma.s += fieldBit__TypeEnum_Members
+		ma.state = maState_midValue
+		ma.f = 0
+		ma.ca_members.w = &ma.w.members
+		ma.ca_members.m = &ma.cm
+		return &ma.ca_members, nil
+	case "representation":
+		if ma.s&fieldBit__TypeEnum_Representation != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__TypeEnum_Representation}
+		}
+		ma.s += fieldBit__TypeEnum_Representation
+		ma.state = maState_midValue
+		ma.f = 1
+		ma.ca_representation.w = &ma.w.representation
+		ma.ca_representation.m = &ma.cm
+		return &ma.ca_representation, nil
+	}
+	return nil, ipld.ErrInvalidKey{TypeName: "schemadmt.TypeEnum", Key: &_String{k}}
+}
+func (ma *_TypeEnum__Assembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_TypeEnum__KeyAssembler)(ma)
+}
+func (ma *_TypeEnum__Assembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	case 0:
+		ma.ca_members.w = &ma.w.members
+		ma.ca_members.m = &ma.cm
+		return &ma.ca_members
+	case 1:
+		ma.ca_representation.w = &ma.w.representation
+		ma.ca_representation.m = &ma.cm
+		return &ma.ca_representation
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_TypeEnum__Assembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__TypeEnum_sufficient != fieldBits__TypeEnum_sufficient {
+		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		if ma.s&fieldBit__TypeEnum_Members == 0 {
+			err.Missing = append(err.Missing, "members")
+		}
+		if ma.s&fieldBit__TypeEnum_Representation == 0 {
+			err.Missing = append(err.Missing, "representation")
+		}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_TypeEnum__Assembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_TypeEnum__Assembler) ValuePrototype(k string) ipld.NodePrototype {
+	panic("todo structbuilder mapassembler valueprototype")
+}
+
+type _TypeEnum__KeyAssembler _TypeEnum__Assembler
+
+func (_TypeEnum__KeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeEnum.KeyAssembler"}.BeginMap(0)
+}
+func (_TypeEnum__KeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeEnum.KeyAssembler"}.BeginList(0)
+}
+func (na *_TypeEnum__KeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.TypeEnum.KeyAssembler"}.AssignNull()
+}
+func (_TypeEnum__KeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.TypeEnum.KeyAssembler"}.AssignBool(false)
+}
+func (_TypeEnum__KeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.TypeEnum.KeyAssembler"}.AssignInt(0)
+}
+func (_TypeEnum__KeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.TypeEnum.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_TypeEnum__KeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	switch k {
+	case "members":
+		if ka.s&fieldBit__TypeEnum_Members != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__TypeEnum_Members}
+		}
+		ka.s += fieldBit__TypeEnum_Members
+		ka.state = maState_expectValue
+		ka.f = 0
+	case "representation":
+		if ka.s&fieldBit__TypeEnum_Representation != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__TypeEnum_Representation}
+		}
+		ka.s += fieldBit__TypeEnum_Representation
+		ka.state = maState_expectValue
+		ka.f = 1
+	default:
+		return ipld.ErrInvalidKey{TypeName: "schemadmt.TypeEnum", Key: &_String{k}}
+	}
+	return nil
+}
+func (_TypeEnum__KeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.TypeEnum.KeyAssembler"}.AssignBytes(nil)
+}
+func (_TypeEnum__KeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.TypeEnum.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_TypeEnum__KeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_TypeEnum__KeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (TypeEnum) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (n TypeEnum) Representation() ipld.Node {
+	return (*_TypeEnum__Repr)(n)
+}
+
+type _TypeEnum__Repr _TypeEnum
+
+var (
+	fieldName__TypeEnum_Members_serial        = _String{"members"}
+	fieldName__TypeEnum_Representation_serial = _String{"representation"}
+)
+var _ ipld.Node = &_TypeEnum__Repr{}
+
+func (_TypeEnum__Repr) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n *_TypeEnum__Repr) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	case "members":
+		return n.members.Representation(), nil
+	case "representation":
+		return n.representation.Representation(), nil
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n *_TypeEnum__Repr) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (_TypeEnum__Repr) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.TypeEnum.Repr"}.LookupByIndex(0)
+}
+func (n _TypeEnum__Repr) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n *_TypeEnum__Repr) MapIterator() ipld.MapIterator {
+	return &_TypeEnum__ReprMapItr{n, 0}
+}
+
+type _TypeEnum__ReprMapItr struct {
+	n   *_TypeEnum__Repr
+	idx int
+}
+
+func (itr *_TypeEnum__ReprMapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	if itr.idx >= 2 {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	switch itr.idx {
+	case 0:
+		k = &fieldName__TypeEnum_Members_serial
+		v = itr.n.members.Representation()
+	case 1:
+		k = &fieldName__TypeEnum_Representation_serial
+		v = itr.n.representation.Representation()
+	default:
+		panic("unreachable")
+	}
+	itr.idx++
+	return
+}
+func (itr *_TypeEnum__ReprMapItr) Done() bool {
+	return itr.idx >= 2
+}
+func (_TypeEnum__Repr) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (rn *_TypeEnum__Repr) Length() int64 {
+	l := 2
+	return int64(l)
+}
+func (_TypeEnum__Repr) IsAbsent() bool {
+	return false
+}
+func (_TypeEnum__Repr) IsNull() bool {
+	return false
+}
+func (_TypeEnum__Repr) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.TypeEnum.Repr"}.AsBool()
+}
+func (_TypeEnum__Repr) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.TypeEnum.Repr"}.AsInt()
+}
+func (_TypeEnum__Repr) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.TypeEnum.Repr"}.AsFloat()
+}
+func (_TypeEnum__Repr) AsString() (string, error) {
+	return mixins.Map{"schemadmt.TypeEnum.Repr"}.AsString()
+}
+func (_TypeEnum__Repr) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.TypeEnum.Repr"}.AsBytes()
+}
+func (_TypeEnum__Repr) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.TypeEnum.Repr"}.AsLink()
+}
+func (_TypeEnum__Repr) Prototype() ipld.NodePrototype {
+	return _TypeEnum__ReprPrototype{}
+}
+
+type _TypeEnum__ReprPrototype struct{}
+
+func (_TypeEnum__ReprPrototype) NewBuilder() ipld.NodeBuilder {
+	var nb _TypeEnum__ReprBuilder
+	nb.Reset()
+	return &nb
+}
+
+type _TypeEnum__ReprBuilder struct {
+	_TypeEnum__ReprAssembler
+}
+
+func (nb *_TypeEnum__ReprBuilder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_TypeEnum__ReprBuilder) Reset() {
+	var w _TypeEnum
+	var m schema.Maybe
+	*nb = _TypeEnum__ReprBuilder{_TypeEnum__ReprAssembler{w: &w, m: &m}}
+}
+
+type _TypeEnum__ReprAssembler struct {
+	w     *_TypeEnum
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm                schema.Maybe
+	ca_members        _Map__EnumValue__Unit__ReprAssembler
+	ca_representation _EnumRepresentation__ReprAssembler
+}
+
+func (na *_TypeEnum__ReprAssembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+	na.ca_members.reset()
+	na.ca_representation.reset()
+}
+func (na *_TypeEnum__ReprAssembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_TypeEnum{}
+	}
+	return na, nil
+}
+func (_TypeEnum__ReprAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.TypeEnum.Repr"}.BeginList(0)
+}
+func (na *_TypeEnum__ReprAssembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.TypeEnum.Repr.Repr"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_TypeEnum__ReprAssembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.TypeEnum.Repr"}.AssignBool(false)
+}
+func (_TypeEnum__ReprAssembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.TypeEnum.Repr"}.AssignInt(0)
+}
+func (_TypeEnum__ReprAssembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.TypeEnum.Repr"}.AssignFloat(0)
+}
+func (_TypeEnum__ReprAssembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.TypeEnum.Repr"}.AssignString("")
+}
+func (_TypeEnum__ReprAssembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.TypeEnum.Repr"}.AssignBytes(nil)
+}
+func (_TypeEnum__ReprAssembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.TypeEnum.Repr"}.AssignLink(nil)
+}
+func (na *_TypeEnum__ReprAssembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	v2, ok := v.(*_TypeEnum)
+	if v3, ok3 := v.(*_TypeEnum__Repr); ok3 {
+		v2, ok = (*_TypeEnum)(v3), true
+	}
+	if ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.TypeEnum.Repr", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_TypeEnum__ReprAssembler) Prototype() ipld.NodePrototype {
+	return _TypeEnum__ReprPrototype{}
+}
+func (ma *_TypeEnum__ReprAssembler) valueFinishTidy() bool {
+	switch ma.f {
+	case 0:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 1:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_TypeEnum__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	switch k {
+	case "members":
+		if ma.s&fieldBit__TypeEnum_Members != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__TypeEnum_Members_serial}
+		}
+		ma.s += fieldBit__TypeEnum_Members
+		ma.state = maState_midValue
+		ma.f = 0
+		ma.ca_members.w = &ma.w.members
+		ma.ca_members.m = &ma.cm
+		return &ma.ca_members, nil
+	case "representation":
+		if ma.s&fieldBit__TypeEnum_Representation != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__TypeEnum_Representation_serial}
+		}
+		ma.s += fieldBit__TypeEnum_Representation
+		ma.state = maState_midValue
+		ma.f = 1
+		ma.ca_representation.w = &ma.w.representation
+		ma.ca_representation.m = &ma.cm
+		return &ma.ca_representation, nil
+	default:
+	}
+	return nil, ipld.ErrInvalidKey{TypeName: "schemadmt.TypeEnum.Repr", Key: &_String{k}}
+}
+func (ma *_TypeEnum__ReprAssembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_TypeEnum__ReprKeyAssembler)(ma)
+}
+func (ma *_TypeEnum__ReprAssembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	case 0:
+		ma.ca_members.w = &ma.w.members
+		ma.ca_members.m = &ma.cm
+		return &ma.ca_members
+	case 1:
+		ma.ca_representation.w = &ma.w.representation
+		ma.ca_representation.m = &ma.cm
+		return &ma.ca_representation
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_TypeEnum__ReprAssembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__TypeEnum_sufficient != fieldBits__TypeEnum_sufficient {
+		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		if ma.s&fieldBit__TypeEnum_Members == 0 {
+			err.Missing = append(err.Missing, "members")
+		}
+		if ma.s&fieldBit__TypeEnum_Representation == 0 {
+			err.Missing = append(err.Missing, "representation")
+		}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_TypeEnum__ReprAssembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_TypeEnum__ReprAssembler) ValuePrototype(k string) ipld.NodePrototype {
+	panic("todo structbuilder mapassembler repr valueprototype")
+}
+
+type _TypeEnum__ReprKeyAssembler _TypeEnum__ReprAssembler
+
+func (_TypeEnum__ReprKeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeEnum.Repr.KeyAssembler"}.BeginMap(0)
+}
+func (_TypeEnum__ReprKeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeEnum.Repr.KeyAssembler"}.BeginList(0)
+}
+func (na *_TypeEnum__ReprKeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.TypeEnum.Repr.KeyAssembler"}.AssignNull()
+}
+func (_TypeEnum__ReprKeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.TypeEnum.Repr.KeyAssembler"}.AssignBool(false)
+}
+func (_TypeEnum__ReprKeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.TypeEnum.Repr.KeyAssembler"}.AssignInt(0)
+}
+func (_TypeEnum__ReprKeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.TypeEnum.Repr.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_TypeEnum__ReprKeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	switch k {
+	case "members":
+		if ka.s&fieldBit__TypeEnum_Members != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__TypeEnum_Members_serial}
+		}
+		ka.s += fieldBit__TypeEnum_Members
+		ka.state = maState_expectValue
+		ka.f = 0
+		return nil
+	case "representation":
+		if ka.s&fieldBit__TypeEnum_Representation != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__TypeEnum_Representation_serial}
+		}
+		ka.s += fieldBit__TypeEnum_Representation
+		ka.state = maState_expectValue
+		ka.f = 1
+		return nil
+	}
+	return ipld.ErrInvalidKey{TypeName: "schemadmt.TypeEnum.Repr", Key: &_String{k}}
+}
+func (_TypeEnum__ReprKeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.TypeEnum.Repr.KeyAssembler"}.AssignBytes(nil)
+}
+func (_TypeEnum__ReprKeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.TypeEnum.Repr.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_TypeEnum__ReprKeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_TypeEnum__ReprKeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
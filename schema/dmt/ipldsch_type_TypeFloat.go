@@ -0,0 +1,738 @@
+package schemadmt
+
+// Code generated by go-ipld-prime gengo.  DO NOT EDIT.
+
+import (
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// TypeFloat matches the IPLD Schema type "TypeFloat".  It has Struct type-kind, and may be interrogated like map kind.
+type TypeFloat = *_TypeFloat
+type _TypeFloat struct {
+}
+
+type _TypeFloat__Maybe struct {
+	m schema.Maybe
+	v TypeFloat
+}
+type MaybeTypeFloat = *_TypeFloat__Maybe
+
+func (m MaybeTypeFloat) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeTypeFloat) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeTypeFloat) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeTypeFloat) AsNode() ipld.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return ipld.Absent
+	case schema.Maybe_Null:
+		return ipld.Null
+	case schema.Maybe_Value:
+		return m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybeTypeFloat) Must() TypeFloat {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return m.v
+}
+
+var ()
+var _ ipld.Node = (TypeFloat)(&_TypeFloat{})
+var _ schema.TypedNode = (TypeFloat)(&_TypeFloat{})
+
+func (TypeFloat) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n TypeFloat) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n TypeFloat) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (TypeFloat) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.TypeFloat"}.LookupByIndex(0)
+}
+func (n TypeFloat) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n TypeFloat) MapIterator() ipld.MapIterator {
+	return &_TypeFloat__MapItr{n, 0}
+}
+
+type _TypeFloat__MapItr struct {
+	n   TypeFloat
+	idx int
+}
+
+func (itr *_TypeFloat__MapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	return nil, nil, ipld.ErrIteratorOverread{}
+
+}
+func (itr *_TypeFloat__MapItr) Done() bool {
+	return itr.idx >= 0
+}
+
+func (TypeFloat) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (TypeFloat) Length() int64 {
+	return 0
+}
+func (TypeFloat) IsAbsent() bool {
+	return false
+}
+func (TypeFloat) IsNull() bool {
+	return false
+}
+func (TypeFloat) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.TypeFloat"}.AsBool()
+}
+func (TypeFloat) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.TypeFloat"}.AsInt()
+}
+func (TypeFloat) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.TypeFloat"}.AsFloat()
+}
+func (TypeFloat) AsString() (string, error) {
+	return mixins.Map{"schemadmt.TypeFloat"}.AsString()
+}
+func (TypeFloat) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.TypeFloat"}.AsBytes()
+}
+func (TypeFloat) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.TypeFloat"}.AsLink()
+}
+func (TypeFloat) Prototype() ipld.NodePrototype {
+	return _TypeFloat__Prototype{}
+}
+
+type _TypeFloat__Prototype struct{}
+
+func (_TypeFloat__Prototype) NewBuilder() ipld.NodeBuilder {
+	var nb _TypeFloat__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _TypeFloat__Builder struct {
+	_TypeFloat__Assembler
+}
+
+func (nb *_TypeFloat__Builder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_TypeFloat__Builder) Reset() {
+	var w _TypeFloat
+	var m schema.Maybe
+	*nb = _TypeFloat__Builder{_TypeFloat__Assembler{w: &w, m: &m}}
+}
+
+type _TypeFloat__Assembler struct {
+	w     *_TypeFloat
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm schema.Maybe
+}
+
+func (na *_TypeFloat__Assembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+}
+
+var (
+	fieldBits__TypeFloat_sufficient = 0
+)
+
+func (na *_TypeFloat__Assembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_TypeFloat{}
+	}
+	return na, nil
+}
+func (_TypeFloat__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.TypeFloat"}.BeginList(0)
+}
+func (na *_TypeFloat__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.TypeFloat"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_TypeFloat__Assembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.TypeFloat"}.AssignBool(false)
+}
+func (_TypeFloat__Assembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.TypeFloat"}.AssignInt(0)
+}
+func (_TypeFloat__Assembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.TypeFloat"}.AssignFloat(0)
+}
+func (_TypeFloat__Assembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.TypeFloat"}.AssignString("")
+}
+func (_TypeFloat__Assembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.TypeFloat"}.AssignBytes(nil)
+}
+func (_TypeFloat__Assembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.TypeFloat"}.AssignLink(nil)
+}
+func (na *_TypeFloat__Assembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_TypeFloat); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.TypeFloat", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_TypeFloat__Assembler) Prototype() ipld.NodePrototype {
+	return _TypeFloat__Prototype{}
+}
+func (ma *_TypeFloat__Assembler) valueFinishTidy() bool {
+	switch ma.f {
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_TypeFloat__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	return nil, ipld.ErrInvalidKey{TypeName: "schemadmt.TypeFloat", Key: &_String{k}}
+}
+func (ma *_TypeFloat__Assembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_TypeFloat__KeyAssembler)(ma)
+}
+func (ma *_TypeFloat__Assembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_TypeFloat__Assembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__TypeFloat_sufficient != fieldBits__TypeFloat_sufficient {
+		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_TypeFloat__Assembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_TypeFloat__Assembler) ValuePrototype(k string) ipld.NodePrototype {
+	panic("todo structbuilder mapassembler valueprototype")
+}
+
+type _TypeFloat__KeyAssembler _TypeFloat__Assembler
+
+func (_TypeFloat__KeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeFloat.KeyAssembler"}.BeginMap(0)
+}
+func (_TypeFloat__KeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeFloat.KeyAssembler"}.BeginList(0)
+}
+func (na *_TypeFloat__KeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.TypeFloat.KeyAssembler"}.AssignNull()
+}
+func (_TypeFloat__KeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.TypeFloat.KeyAssembler"}.AssignBool(false)
+}
+func (_TypeFloat__KeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.TypeFloat.KeyAssembler"}.AssignInt(0)
+}
+func (_TypeFloat__KeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.TypeFloat.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_TypeFloat__KeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	return ipld.ErrInvalidKey{TypeName: "schemadmt.TypeFloat", Key: &_String{k}}
+}
+func (_TypeFloat__KeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.TypeFloat.KeyAssembler"}.AssignBytes(nil)
+}
+func (_TypeFloat__KeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.TypeFloat.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_TypeFloat__KeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_TypeFloat__KeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (TypeFloat) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (n TypeFloat) Representation() ipld.Node {
+	return (*_TypeFloat__Repr)(n)
+}
+
+type _TypeFloat__Repr _TypeFloat
+
+var ()
+var _ ipld.Node = &_TypeFloat__Repr{}
+
+func (_TypeFloat__Repr) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n *_TypeFloat__Repr) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n *_TypeFloat__Repr) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (_TypeFloat__Repr) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.TypeFloat.Repr"}.LookupByIndex(0)
+}
+func (n _TypeFloat__Repr) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n *_TypeFloat__Repr) MapIterator() ipld.MapIterator {
+	return &_TypeFloat__ReprMapItr{n, 0}
+}
+
+type _TypeFloat__ReprMapItr struct {
+	n   *_TypeFloat__Repr
+	idx int
+}
+
+func (itr *_TypeFloat__ReprMapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	return nil, nil, ipld.ErrIteratorOverread{}
+
+}
+func (itr *_TypeFloat__ReprMapItr) Done() bool {
+	return itr.idx >= 0
+}
+func (_TypeFloat__Repr) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (rn *_TypeFloat__Repr) Length() int64 {
+	l := 0
+	return int64(l)
+}
+func (_TypeFloat__Repr) IsAbsent() bool {
+	return false
+}
+func (_TypeFloat__Repr) IsNull() bool {
+	return false
+}
+func (_TypeFloat__Repr) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.TypeFloat.Repr"}.AsBool()
+}
+func (_TypeFloat__Repr) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.TypeFloat.Repr"}.AsInt()
+}
+func (_TypeFloat__Repr) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.TypeFloat.Repr"}.AsFloat()
+}
+func (_TypeFloat__Repr) AsString() (string, error) {
+	return mixins.Map{"schemadmt.TypeFloat.Repr"}.AsString()
+}
+func (_TypeFloat__Repr) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.TypeFloat.Repr"}.AsBytes()
+}
+func (_TypeFloat__Repr) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.TypeFloat.Repr"}.AsLink()
+}
+func (_TypeFloat__Repr) Prototype() ipld.NodePrototype {
+	return _TypeFloat__ReprPrototype{}
+}
+
+type _TypeFloat__ReprPrototype struct{}
+
+func (_TypeFloat__ReprPrototype) NewBuilder() ipld.NodeBuilder {
+	var nb _TypeFloat__ReprBuilder
+	nb.Reset()
+	return &nb
+}
+
+type _TypeFloat__ReprBuilder struct {
+	_TypeFloat__ReprAssembler
+}
+
+func (nb *_TypeFloat__ReprBuilder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_TypeFloat__ReprBuilder) Reset() {
+	var w _TypeFloat
+	var m schema.Maybe
+	*nb = _TypeFloat__ReprBuilder{_TypeFloat__ReprAssembler{w: &w, m: &m}}
+}
+
+type _TypeFloat__ReprAssembler struct {
+	w     *_TypeFloat
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm schema.Maybe
+}
+
+func (na *_TypeFloat__ReprAssembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+}
+func (na *_TypeFloat__ReprAssembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_TypeFloat{}
+	}
+	return na, nil
+}
+func (_TypeFloat__ReprAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.TypeFloat.Repr"}.BeginList(0)
+}
+func (na *_TypeFloat__ReprAssembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.TypeFloat.Repr.Repr"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_TypeFloat__ReprAssembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.TypeFloat.Repr"}.AssignBool(false)
+}
+func (_TypeFloat__ReprAssembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.TypeFloat.Repr"}.AssignInt(0)
+}
+func (_TypeFloat__ReprAssembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.TypeFloat.Repr"}.AssignFloat(0)
+}
+func (_TypeFloat__ReprAssembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.TypeFloat.Repr"}.AssignString("")
+}
+func (_TypeFloat__ReprAssembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.TypeFloat.Repr"}.AssignBytes(nil)
+}
+func (_TypeFloat__ReprAssembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.TypeFloat.Repr"}.AssignLink(nil)
+}
+func (na *_TypeFloat__ReprAssembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	v2, ok := v.(*_TypeFloat)
+	if v3, ok3 := v.(*_TypeFloat__Repr); ok3 {
+		v2, ok = (*_TypeFloat)(v3), true
+	}
+	if ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.TypeFloat.Repr", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_TypeFloat__ReprAssembler) Prototype() ipld.NodePrototype {
+	return _TypeFloat__ReprPrototype{}
+}
+func (ma *_TypeFloat__ReprAssembler) valueFinishTidy() bool {
+	switch ma.f {
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_TypeFloat__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	return nil, ipld.ErrInvalidKey{TypeName: "schemadmt.TypeFloat.Repr", Key: &_String{k}}
+}
+func (ma *_TypeFloat__ReprAssembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_TypeFloat__ReprKeyAssembler)(ma)
+}
+func (ma *_TypeFloat__ReprAssembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_TypeFloat__ReprAssembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__TypeFloat_sufficient != fieldBits__TypeFloat_sufficient {
+		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_TypeFloat__ReprAssembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_TypeFloat__ReprAssembler) ValuePrototype(k string) ipld.NodePrototype {
+	panic("todo structbuilder mapassembler repr valueprototype")
+}
+
+type _TypeFloat__ReprKeyAssembler _TypeFloat__ReprAssembler
+
+func (_TypeFloat__ReprKeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeFloat.Repr.KeyAssembler"}.BeginMap(0)
+}
+func (_TypeFloat__ReprKeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeFloat.Repr.KeyAssembler"}.BeginList(0)
+}
+func (na *_TypeFloat__ReprKeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.TypeFloat.Repr.KeyAssembler"}.AssignNull()
+}
+func (_TypeFloat__ReprKeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.TypeFloat.Repr.KeyAssembler"}.AssignBool(false)
+}
+func (_TypeFloat__ReprKeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.TypeFloat.Repr.KeyAssembler"}.AssignInt(0)
+}
+func (_TypeFloat__ReprKeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.TypeFloat.Repr.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_TypeFloat__ReprKeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	return ipld.ErrInvalidKey{TypeName: "schemadmt.TypeFloat.Repr", Key: &_String{k}}
+}
+func (_TypeFloat__ReprKeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.TypeFloat.Repr.KeyAssembler"}.AssignBytes(nil)
+}
+func (_TypeFloat__ReprKeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.TypeFloat.Repr.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_TypeFloat__ReprKeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_TypeFloat__ReprKeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
@@ -0,0 +1,1144 @@
+package schemadmt
+
+// Code generated by go-ipld-prime gengo.  DO NOT EDIT.
+
+import (
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// TypeMap matches the IPLD Schema type "TypeMap".  It has Struct type-kind, and may be interrogated like map kind.
+type TypeMap = *_TypeMap
+type _TypeMap struct {
+	keyType        _TypeName
+	valueType      _TypeNameOrInlineDefn
+	valueNullable  _Bool
+	representation _MapRepresentation
+}
+
+// FieldKeyType returns the value of the field "keyType".
+func (n _TypeMap) FieldKeyType() TypeName {
+	return &n.keyType
+}
+
+// FieldValueType returns the value of the field "valueType".
+func (n _TypeMap) FieldValueType() TypeNameOrInlineDefn {
+	return &n.valueType
+}
+
+// FieldValueNullable returns the value of the field "valueNullable".
+func (n _TypeMap) FieldValueNullable() Bool {
+	return &n.valueNullable
+}
+
+// FieldRepresentation returns the value of the field "representation".
+func (n _TypeMap) FieldRepresentation() MapRepresentation {
+	return &n.representation
+}
+
+type _TypeMap__Maybe struct {
+	m schema.Maybe
+	v TypeMap
+}
+type MaybeTypeMap = *_TypeMap__Maybe
+
+func (m MaybeTypeMap) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeTypeMap) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeTypeMap) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeTypeMap) AsNode() ipld.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return ipld.Absent
+	case schema.Maybe_Null:
+		return ipld.Null
+	case schema.Maybe_Value:
+		return m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybeTypeMap) Must() TypeMap {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return m.v
+}
+
+var (
+	fieldName__TypeMap_KeyType        = _String{"keyType"}
+	fieldName__TypeMap_ValueType      = _String{"valueType"}
+	fieldName__TypeMap_ValueNullable  = _String{"valueNullable"}
+	fieldName__TypeMap_Representation = _String{"representation"}
+)
+var _ ipld.Node = (TypeMap)(&_TypeMap{})
+var _ schema.TypedNode = (TypeMap)(&_TypeMap{})
+
+func (TypeMap) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n TypeMap) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	case "keyType":
+		return &n.keyType, nil
+	case "valueType":
+		return &n.valueType, nil
+	case "valueNullable":
+		return &n.valueNullable, nil
+	case "representation":
+		return &n.representation, nil
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n TypeMap) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (TypeMap) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.TypeMap"}.LookupByIndex(0)
+}
+func (n TypeMap) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n TypeMap) MapIterator() ipld.MapIterator {
+	return &_TypeMap__MapItr{n, 0}
+}
+
+type _TypeMap__MapItr struct {
+	n   TypeMap
+	idx int
+}
+
+func (itr *_TypeMap__MapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	if itr.idx >= 4 {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	switch itr.idx {
+	case 0:
+		k = &fieldName__TypeMap_KeyType
+		v = &itr.n.keyType
+	case 1:
+		k = &fieldName__TypeMap_ValueType
+		v = &itr.n.valueType
+	case 2:
+		k = &fieldName__TypeMap_ValueNullable
+		v = &itr.n.valueNullable
+	case 3:
+		k = &fieldName__TypeMap_Representation
+		v = &itr.n.representation
+	default:
+		panic("unreachable")
+	}
+	itr.idx++
+	return
+}
+func (itr *_TypeMap__MapItr) Done() bool {
+	return itr.idx >= 4
+}
+
+func (TypeMap) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (TypeMap) Length() int64 {
+	return 4
+}
+func (TypeMap) IsAbsent() bool {
+	return false
+}
+func (TypeMap) IsNull() bool {
+	return false
+}
+func (TypeMap) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.TypeMap"}.AsBool()
+}
+func (TypeMap) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.TypeMap"}.AsInt()
+}
+func (TypeMap) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.TypeMap"}.AsFloat()
+}
+func (TypeMap) AsString() (string, error) {
+	return mixins.Map{"schemadmt.TypeMap"}.AsString()
+}
+func (TypeMap) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.TypeMap"}.AsBytes()
+}
+func (TypeMap) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.TypeMap"}.AsLink()
+}
+func (TypeMap) Prototype() ipld.NodePrototype {
+	return _TypeMap__Prototype{}
+}
+
+type _TypeMap__Prototype struct{}
+
+func (_TypeMap__Prototype) NewBuilder() ipld.NodeBuilder {
+	var nb _TypeMap__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _TypeMap__Builder struct {
+	_TypeMap__Assembler
+}
+
+func (nb *_TypeMap__Builder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_TypeMap__Builder) Reset() {
+	var w _TypeMap
+	var m schema.Maybe
+	*nb = _TypeMap__Builder{_TypeMap__Assembler{w: &w, m: &m}}
+}
+
+type _TypeMap__Assembler struct {
+	w     *_TypeMap
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm                schema.Maybe
+	ca_keyType        _TypeName__Assembler
+	ca_valueType      _TypeNameOrInlineDefn__Assembler
+	ca_valueNullable  _Bool__Assembler
+	ca_representation _MapRepresentation__Assembler
+}
+
+func (na *_TypeMap__Assembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+	na.ca_keyType.reset()
+	na.ca_valueType.reset()
+	na.ca_valueNullable.reset()
+	na.ca_representation.reset()
+}
+
+var (
+	fieldBit__TypeMap_KeyType        = 1 << 0
+	fieldBit__TypeMap_ValueType      = 1 << 1
+	fieldBit__TypeMap_ValueNullable  = 1 << 2
+	fieldBit__TypeMap_Representation = 1 << 3
+	fieldBits__TypeMap_sufficient    = 0 + 1<<0 + 1<<1 + 1<<2 + 1<<3
+)
+
+func (na *_TypeMap__Assembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_TypeMap{}
+	}
+	return na, nil
+}
+func (_TypeMap__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.TypeMap"}.BeginList(0)
+}
+func (na *_TypeMap__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.TypeMap"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_TypeMap__Assembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.TypeMap"}.AssignBool(false)
+}
+func (_TypeMap__Assembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.TypeMap"}.AssignInt(0)
+}
+func (_TypeMap__Assembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.TypeMap"}.AssignFloat(0)
+}
+func (_TypeMap__Assembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.TypeMap"}.AssignString("")
+}
+func (_TypeMap__Assembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.TypeMap"}.AssignBytes(nil)
+}
+func (_TypeMap__Assembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.TypeMap"}.AssignLink(nil)
+}
+func (na *_TypeMap__Assembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_TypeMap); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.TypeMap", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_TypeMap__Assembler) Prototype() ipld.NodePrototype {
+	return _TypeMap__Prototype{}
+}
+func (ma *_TypeMap__Assembler) valueFinishTidy() bool {
+	switch ma.f {
+	case 0:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.ca_keyType.w = nil
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 1:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.ca_valueType.w = nil
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 2:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.ca_valueNullable.w = nil
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 3:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.ca_representation.w = nil
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_TypeMap__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	switch k {
+	case "keyType":
+		if ma.s&fieldBit__TypeMap_KeyType != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__TypeMap_KeyType}
+		}
+		ma.s += fieldBit__TypeMap_KeyType
+		ma.state = maState_midValue
+		ma.f = 0
+		ma.ca_keyType.w = &ma.w.keyType
+		ma.ca_keyType.m = &ma.cm
+		return &ma.ca_keyType, nil
+	case "valueType":
+		if ma.s&fieldBit__TypeMap_ValueType != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__TypeMap_ValueType}
+		}
+		ma.s += fieldBit__TypeMap_ValueType
+		ma.state = maState_midValue
+		ma.f = 1
+		ma.ca_valueType.w = &ma.w.valueType
+		ma.ca_valueType.m = &ma.cm
+		return &ma.ca_valueType, nil
+	case "valueNullable":
+		if ma.s&fieldBit__TypeMap_ValueNullable != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__TypeMap_ValueNullable}
+		}
+		ma.s += fieldBit__TypeMap_ValueNullable
+		ma.state = maState_midValue
+		ma.f = 2
+		ma.ca_valueNullable.w = &ma.w.valueNullable
+		ma.ca_valueNullable.m = &ma.cm
+		return &ma.ca_valueNullable, nil
+	case "representation":
+		if ma.s&fieldBit__TypeMap_Representation != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__TypeMap_Representation}
+		}
+		ma.s += fieldBit__TypeMap_Representation
+		ma.state = maState_midValue
+		ma.f = 3
+		ma.ca_representation.w = &ma.w.representation
+		ma.ca_representation.m = &ma.cm
+		return &ma.ca_representation, nil
+	}
+	return nil, ipld.ErrInvalidKey{TypeName: "schemadmt.TypeMap", Key: &_String{k}}
+}
+func (ma *_TypeMap__Assembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_TypeMap__KeyAssembler)(ma)
+}
+func (ma *_TypeMap__Assembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	case 0:
+		ma.ca_keyType.w = &ma.w.keyType
+		ma.ca_keyType.m = &ma.cm
+		return &ma.ca_keyType
+	case 1:
+		ma.ca_valueType.w = &ma.w.valueType
+		ma.ca_valueType.m = &ma.cm
+		return &ma.ca_valueType
+	case 2:
+		ma.ca_valueNullable.w = &ma.w.valueNullable
+		ma.ca_valueNullable.m = &ma.cm
+		return &ma.ca_valueNullable
+	case 3:
+		ma.ca_representation.w = &ma.w.representation
+		ma.ca_representation.m = &ma.cm
+		return &ma.ca_representation
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_TypeMap__Assembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__TypeMap_sufficient != fieldBits__TypeMap_sufficient {
+		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		if ma.s&fieldBit__TypeMap_KeyType == 0 {
+			err.Missing = append(err.Missing, "keyType")
+		}
+		if ma.s&fieldBit__TypeMap_ValueType == 0 {
+			err.Missing = append(err.Missing, "valueType")
+		}
+		if ma.s&fieldBit__TypeMap_ValueNullable == 0 {
+			err.Missing = append(err.Missing, "valueNullable")
+		}
+		if ma.s&fieldBit__TypeMap_Representation == 0 {
+			err.Missing = append(err.Missing, "representation")
+		}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_TypeMap__Assembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_TypeMap__Assembler) ValuePrototype(k string) ipld.NodePrototype {
+	panic("todo structbuilder mapassembler valueprototype")
+}
+
+type _TypeMap__KeyAssembler _TypeMap__Assembler
+
+func (_TypeMap__KeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeMap.KeyAssembler"}.BeginMap(0)
+}
+func (_TypeMap__KeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeMap.KeyAssembler"}.BeginList(0)
+}
+func (na *_TypeMap__KeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.TypeMap.KeyAssembler"}.AssignNull()
+}
+func (_TypeMap__KeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.TypeMap.KeyAssembler"}.AssignBool(false)
+}
+func (_TypeMap__KeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.TypeMap.KeyAssembler"}.AssignInt(0)
+}
+func (_TypeMap__KeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.TypeMap.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_TypeMap__KeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	switch k {
+	case "keyType":
+		if ka.s&fieldBit__TypeMap_KeyType != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__TypeMap_KeyType}
+		}
+		ka.s += fieldBit__TypeMap_KeyType
+		ka.state = maState_expectValue
+		ka.f = 0
+	case "valueType":
+		if ka.s&fieldBit__TypeMap_ValueType != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__TypeMap_ValueType}
+		}
+		ka.s += fieldBit__TypeMap_ValueType
+		ka.state = maState_expectValue
+		ka.f = 1
+	case "valueNullable":
+		if ka.s&fieldBit__TypeMap_ValueNullable != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__TypeMap_ValueNullable}
+		}
+		ka.s += fieldBit__TypeMap_ValueNullable
+		ka.state = maState_expectValue
+		ka.f = 2
+	case "representation":
+		if ka.s&fieldBit__TypeMap_Representation != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__TypeMap_Representation}
+		}
+		ka.s += fieldBit__TypeMap_Representation
+		ka.state = maState_expectValue
+		ka.f = 3
+	default:
+		return ipld.ErrInvalidKey{TypeName: "schemadmt.TypeMap", Key: &_String{k}}
+	}
+	return nil
+}
+func (_TypeMap__KeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.TypeMap.KeyAssembler"}.AssignBytes(nil)
+}
+func (_TypeMap__KeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.TypeMap.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_TypeMap__KeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_TypeMap__KeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (TypeMap) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (n TypeMap) Representation() ipld.Node {
+	return (*_TypeMap__Repr)(n)
+}
+
+type _TypeMap__Repr _TypeMap
+
+var (
+	fieldName__TypeMap_KeyType_serial        = _String{"keyType"}
+	fieldName__TypeMap_ValueType_serial      = _String{"valueType"}
+	fieldName__TypeMap_ValueNullable_serial  = _String{"valueNullable"}
+	fieldName__TypeMap_Representation_serial = _String{"representation"}
+)
+var _ ipld.Node = &_TypeMap__Repr{}
+
+func (_TypeMap__Repr) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n *_TypeMap__Repr) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	case "keyType":
+		return n.keyType.Representation(), nil
+	case "valueType":
+		return n.valueType.Representation(), nil
+	case "valueNullable":
+		if n.valueNullable.x == false {
+			return ipld.Absent, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(key)}
+		}
+		return n.valueNullable.Representation(), nil
+	case "representation":
+		return n.representation.Representation(), nil
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n *_TypeMap__Repr) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (_TypeMap__Repr) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.TypeMap.Repr"}.LookupByIndex(0)
+}
+func (n _TypeMap__Repr) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n *_TypeMap__Repr) MapIterator() ipld.MapIterator {
+	return &_TypeMap__ReprMapItr{n, 0}
+}
+
+type _TypeMap__ReprMapItr struct {
+	n   *_TypeMap__Repr
+	idx int
+}
+
+func (itr *_TypeMap__ReprMapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+advance:
+	if itr.idx >= 4 {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	switch itr.idx {
+	case 0:
+		k = &fieldName__TypeMap_KeyType_serial
+		v = itr.n.keyType.Representation()
+	case 1:
+		k = &fieldName__TypeMap_ValueType_serial
+		v = itr.n.valueType.Representation()
+	case 2:
+		k = &fieldName__TypeMap_ValueNullable_serial
+		if itr.n.valueNullable.x == false {
+			itr.idx++
+			goto advance
+		}
+		v = itr.n.valueNullable.Representation()
+	case 3:
+		k = &fieldName__TypeMap_Representation_serial
+		v = itr.n.representation.Representation()
+	default:
+		panic("unreachable")
+	}
+	itr.idx++
+	return
+}
+func (itr *_TypeMap__ReprMapItr) Done() bool {
+	return itr.idx >= 4
+}
+func (_TypeMap__Repr) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (rn *_TypeMap__Repr) Length() int64 {
+	l := 4
+	if rn.valueNullable.x == false {
+		l--
+	}
+	return int64(l)
+}
+func (_TypeMap__Repr) IsAbsent() bool {
+	return false
+}
+func (_TypeMap__Repr) IsNull() bool {
+	return false
+}
+func (_TypeMap__Repr) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.TypeMap.Repr"}.AsBool()
+}
+func (_TypeMap__Repr) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.TypeMap.Repr"}.AsInt()
+}
+func (_TypeMap__Repr) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.TypeMap.Repr"}.AsFloat()
+}
+func (_TypeMap__Repr) AsString() (string, error) {
+	return mixins.Map{"schemadmt.TypeMap.Repr"}.AsString()
+}
+func (_TypeMap__Repr) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.TypeMap.Repr"}.AsBytes()
+}
+func (_TypeMap__Repr) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.TypeMap.Repr"}.AsLink()
+}
+func (_TypeMap__Repr) Prototype() ipld.NodePrototype {
+	return _TypeMap__ReprPrototype{}
+}
+
+type _TypeMap__ReprPrototype struct{}
+
+func (_TypeMap__ReprPrototype) NewBuilder() ipld.NodeBuilder {
+	var nb _TypeMap__ReprBuilder
+	nb.Reset()
+	return &nb
+}
+
+type _TypeMap__ReprBuilder struct {
+	_TypeMap__ReprAssembler
+}
+
+func (nb *_TypeMap__ReprBuilder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_TypeMap__ReprBuilder) Reset() {
+	var w _TypeMap
+	var m schema.Maybe
+	*nb = _TypeMap__ReprBuilder{_TypeMap__ReprAssembler{w: &w, m: &m}}
+}
+
+type _TypeMap__ReprAssembler struct {
+	w     *_TypeMap
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm                schema.Maybe
+	ca_keyType        _TypeName__ReprAssembler
+	ca_valueType      _TypeNameOrInlineDefn__ReprAssembler
+	ca_valueNullable  _Bool__ReprAssembler
+	ca_representation _MapRepresentation__ReprAssembler
+}
+
+func (na *_TypeMap__ReprAssembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+	na.ca_keyType.reset()
+	na.ca_valueType.reset()
+	na.ca_valueNullable.reset()
+	na.ca_representation.reset()
+}
+func (na *_TypeMap__ReprAssembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_TypeMap{}
+	}
+	return na, nil
+}
+func (_TypeMap__ReprAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.TypeMap.Repr"}.BeginList(0)
+}
+func (na *_TypeMap__ReprAssembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.TypeMap.Repr.Repr"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_TypeMap__ReprAssembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.TypeMap.Repr"}.AssignBool(false)
+}
+func (_TypeMap__ReprAssembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.TypeMap.Repr"}.AssignInt(0)
+}
+func (_TypeMap__ReprAssembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.TypeMap.Repr"}.AssignFloat(0)
+}
+func (_TypeMap__ReprAssembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.TypeMap.Repr"}.AssignString("")
+}
+func (_TypeMap__ReprAssembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.TypeMap.Repr"}.AssignBytes(nil)
+}
+func (_TypeMap__ReprAssembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.TypeMap.Repr"}.AssignLink(nil)
+}
+func (na *_TypeMap__ReprAssembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	v2, ok := v.(*_TypeMap)
+	if v3, ok3 := v.(*_TypeMap__Repr); ok3 {
+		v2, ok = (*_TypeMap)(v3), true
+	}
+	if ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.TypeMap.Repr", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_TypeMap__ReprAssembler) Prototype() ipld.NodePrototype {
+	return _TypeMap__ReprPrototype{}
+}
+func (ma *_TypeMap__ReprAssembler) valueFinishTidy() bool {
+	switch ma.f {
+	case 0:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 1:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 2:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 3:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_TypeMap__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	switch k {
+	case "keyType":
+		if ma.s&fieldBit__TypeMap_KeyType != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__TypeMap_KeyType_serial}
+		}
+		ma.s += fieldBit__TypeMap_KeyType
+		ma.state = maState_midValue
+		ma.f = 0
+		ma.ca_keyType.w = &ma.w.keyType
+		ma.ca_keyType.m = &ma.cm
+		return &ma.ca_keyType, nil
+	case "valueType":
+		if ma.s&fieldBit__TypeMap_ValueType != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__TypeMap_ValueType_serial}
+		}
+		ma.s += fieldBit__TypeMap_ValueType
+		ma.state = maState_midValue
+		ma.f = 1
+		ma.ca_valueType.w = &ma.w.valueType
+		ma.ca_valueType.m = &ma.cm
+		return &ma.ca_valueType, nil
+	case "valueNullable":
+		if ma.s&fieldBit__TypeMap_ValueNullable != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__TypeMap_ValueNullable_serial}
+		}
+		ma.s += fieldBit__TypeMap_ValueNullable
+		ma.state = maState_midValue
+		ma.f = 2
+		ma.ca_valueNullable.w = &ma.w.valueNullable
+		ma.ca_valueNullable.m = &ma.cm
+		return &ma.ca_valueNullable, nil
+	case "representation":
+		if ma.s&fieldBit__TypeMap_Representation != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__TypeMap_Representation_serial}
+		}
+		ma.s += fieldBit__TypeMap_Representation
+		ma.state = maState_midValue
+		ma.f = 3
+		ma.ca_representation.w = &ma.w.representation
+		ma.ca_representation.m = &ma.cm
+		return &ma.ca_representation, nil
+	default:
+	}
+	return nil, ipld.ErrInvalidKey{TypeName: "schemadmt.TypeMap.Repr", Key: &_String{k}}
+}
+func (ma *_TypeMap__ReprAssembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_TypeMap__ReprKeyAssembler)(ma)
+}
+func (ma *_TypeMap__ReprAssembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	case 0:
+		ma.ca_keyType.w = &ma.w.keyType
+		ma.ca_keyType.m = &ma.cm
+		return &ma.ca_keyType
+	case 1:
+		ma.ca_valueType.w = &ma.w.valueType
+		ma.ca_valueType.m = &ma.cm
+		return &ma.ca_valueType
+	case 2:
+		ma.ca_valueNullable.w = &ma.w.valueNullable
+		ma.ca_valueNullable.m = &ma.cm
+		return &ma.ca_valueNullable
+	case 3:
+		ma.ca_representation.w = &ma.w.representation
+		ma.ca_representation.m = &ma.cm
+		return &ma.ca_representation
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_TypeMap__ReprAssembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBit__TypeMap_ValueNullable == 0 {
+		ma.w.valueNullable = _Bool{false}
+		ma.s += fieldBit__TypeMap_ValueNullable
+	}
+	if ma.s&fieldBits__TypeMap_sufficient != fieldBits__TypeMap_sufficient {
+		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		if ma.s&fieldBit__TypeMap_KeyType == 0 {
+			err.Missing = append(err.Missing, "keyType")
+		}
+		if ma.s&fieldBit__TypeMap_ValueType == 0 {
+			err.Missing = append(err.Missing, "valueType")
+		}
+		if ma.s&fieldBit__TypeMap_ValueNullable == 0 {
+			err.Missing = append(err.Missing, "valueNullable")
+		}
+		if ma.s&fieldBit__TypeMap_Representation == 0 {
+			err.Missing = append(err.Missing, "representation")
+		}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_TypeMap__ReprAssembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_TypeMap__ReprAssembler) ValuePrototype(k string) ipld.NodePrototype {
+	panic("todo structbuilder mapassembler repr valueprototype")
+}
+
+type _TypeMap__ReprKeyAssembler _TypeMap__ReprAssembler
+
+func (_TypeMap__ReprKeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeMap.Repr.KeyAssembler"}.BeginMap(0)
+}
+func (_TypeMap__ReprKeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeMap.Repr.KeyAssembler"}.BeginList(0)
+}
+func (na *_TypeMap__ReprKeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.TypeMap.Repr.KeyAssembler"}.AssignNull()
+}
+func (_TypeMap__ReprKeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.TypeMap.Repr.KeyAssembler"}.AssignBool(false)
+}
+func (_TypeMap__ReprKeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.TypeMap.Repr.KeyAssembler"}.AssignInt(0)
+}
+func (_TypeMap__ReprKeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.TypeMap.Repr.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_TypeMap__ReprKeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	switch k {
+	case "keyType":
+		if ka.s&fieldBit__TypeMap_KeyType != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__TypeMap_KeyType_serial}
+		}
+		ka.s += fieldBit__TypeMap_KeyType
+		ka.state = maState_expectValue
+		ka.f = 0
+		return nil
+	case "valueType":
+		if ka.s&fieldBit__TypeMap_ValueType != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__TypeMap_ValueType_serial}
+		}
+		ka.s += fieldBit__TypeMap_ValueType
+		ka.state = maState_expectValue
+		ka.f = 1
+		return nil
+	case "valueNullable":
+		if ka.s&fieldBit__TypeMap_ValueNullable != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__TypeMap_ValueNullable_serial}
+		}
+		ka.s += fieldBit__TypeMap_ValueNullable
+		ka.state = maState_expectValue
+		ka.f = 2
+		return nil
+	case "representation":
+		if ka.s&fieldBit__TypeMap_Representation != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__TypeMap_Representation_serial}
+		}
+		ka.s += fieldBit__TypeMap_Representation
+		ka.state = maState_expectValue
+		ka.f = 3
+		return nil
+	}
+	return ipld.ErrInvalidKey{TypeName: "schemadmt.TypeMap.Repr", Key: &_String{k}}
+}
+func (_TypeMap__ReprKeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.TypeMap.Repr.KeyAssembler"}.AssignBytes(nil)
+}
+func (_TypeMap__ReprKeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.TypeMap.Repr.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_TypeMap__ReprKeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_TypeMap__ReprKeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
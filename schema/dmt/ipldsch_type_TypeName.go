@@ -0,0 +1,223 @@
+package schemadmt
+
+// Code generated by go-ipld-prime gengo.  DO NOT EDIT.
+
+import (
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// TypeName matches the IPLD Schema type "TypeName".  It has string kind.
+type TypeName = *_TypeName
+type _TypeName struct{ x string }
+
+// String returns the value as a native string.
+func (n TypeName) String() string {
+	return n.x
+}
+func (_TypeName__Prototype) fromString(w *_TypeName, v string) error {
+	*w = _TypeName{v}
+	return nil
+}
+func (_TypeName__Prototype) FromString(v string) (TypeName, error) {
+	n := _TypeName{v}
+	return &n, nil
+}
+
+type _TypeName__Maybe struct {
+	m schema.Maybe
+	v _TypeName
+}
+type MaybeTypeName = *_TypeName__Maybe
+
+func (m MaybeTypeName) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeTypeName) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeTypeName) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeTypeName) AsNode() ipld.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return ipld.Absent
+	case schema.Maybe_Null:
+		return ipld.Null
+	case schema.Maybe_Value:
+		return &m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybeTypeName) Must() TypeName {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return &m.v
+}
+
+var _ ipld.Node = (TypeName)(&_TypeName{})
+var _ schema.TypedNode = (TypeName)(&_TypeName{})
+
+func (TypeName) Kind() ipld.Kind {
+	return ipld.Kind_String
+}
+func (TypeName) LookupByString(string) (ipld.Node, error) {
+	return mixins.String{"schemadmt.TypeName"}.LookupByString("")
+}
+func (TypeName) LookupByNode(ipld.Node) (ipld.Node, error) {
+	return mixins.String{"schemadmt.TypeName"}.LookupByNode(nil)
+}
+func (TypeName) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.String{"schemadmt.TypeName"}.LookupByIndex(0)
+}
+func (TypeName) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return mixins.String{"schemadmt.TypeName"}.LookupBySegment(seg)
+}
+func (TypeName) MapIterator() ipld.MapIterator {
+	return nil
+}
+func (TypeName) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (TypeName) Length() int64 {
+	return -1
+}
+func (TypeName) IsAbsent() bool {
+	return false
+}
+func (TypeName) IsNull() bool {
+	return false
+}
+func (TypeName) AsBool() (bool, error) {
+	return mixins.String{"schemadmt.TypeName"}.AsBool()
+}
+func (TypeName) AsInt() (int64, error) {
+	return mixins.String{"schemadmt.TypeName"}.AsInt()
+}
+func (TypeName) AsFloat() (float64, error) {
+	return mixins.String{"schemadmt.TypeName"}.AsFloat()
+}
+func (n TypeName) AsString() (string, error) {
+	return n.x, nil
+}
+func (TypeName) AsBytes() ([]byte, error) {
+	return mixins.String{"schemadmt.TypeName"}.AsBytes()
+}
+func (TypeName) AsLink() (ipld.Link, error) {
+	return mixins.String{"schemadmt.TypeName"}.AsLink()
+}
+func (TypeName) Prototype() ipld.NodePrototype {
+	return _TypeName__Prototype{}
+}
+
+type _TypeName__Prototype struct{}
+
+func (_TypeName__Prototype) NewBuilder() ipld.NodeBuilder {
+	var nb _TypeName__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _TypeName__Builder struct {
+	_TypeName__Assembler
+}
+
+func (nb *_TypeName__Builder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_TypeName__Builder) Reset() {
+	var w _TypeName
+	var m schema.Maybe
+	*nb = _TypeName__Builder{_TypeName__Assembler{w: &w, m: &m}}
+}
+
+type _TypeName__Assembler struct {
+	w *_TypeName
+	m *schema.Maybe
+}
+
+func (na *_TypeName__Assembler) reset() {}
+func (_TypeName__Assembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeName"}.BeginMap(0)
+}
+func (_TypeName__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeName"}.BeginList(0)
+}
+func (na *_TypeName__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.StringAssembler{"schemadmt.TypeName"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	}
+	panic("unreachable")
+}
+func (_TypeName__Assembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.TypeName"}.AssignBool(false)
+}
+func (_TypeName__Assembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.TypeName"}.AssignInt(0)
+}
+func (_TypeName__Assembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.TypeName"}.AssignFloat(0)
+}
+func (na *_TypeName__Assembler) AssignString(v string) error {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	}
+	na.w.x = v
+	*na.m = schema.Maybe_Value
+	return nil
+}
+func (_TypeName__Assembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.TypeName"}.AssignBytes(nil)
+}
+func (_TypeName__Assembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.TypeName"}.AssignLink(nil)
+}
+func (na *_TypeName__Assembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_TypeName); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return na.AssignString(v2)
+	}
+}
+func (_TypeName__Assembler) Prototype() ipld.NodePrototype {
+	return _TypeName__Prototype{}
+}
+func (TypeName) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (n TypeName) Representation() ipld.Node {
+	return (*_TypeName__Repr)(n)
+}
+
+type _TypeName__Repr = _TypeName
+
+var _ ipld.Node = &_TypeName__Repr{}
+
+type _TypeName__ReprPrototype = _TypeName__Prototype
+type _TypeName__ReprAssembler = _TypeName__Assembler
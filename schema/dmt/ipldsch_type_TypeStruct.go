@@ -0,0 +1,947 @@
+package schemadmt
+
+// Code generated by go-ipld-prime gengo.  DO NOT EDIT.
+
+import (
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// TypeStruct matches the IPLD Schema type "TypeStruct".  It has Struct type-kind, and may be interrogated like map kind.
+type TypeStruct = *_TypeStruct
+type _TypeStruct struct {
+	fields         _Map__FieldName__StructField
+	representation _StructRepresentation
+}
+
+// FieldFields returns the value of the field "fields".
+func (n _TypeStruct) FieldFields() Map__FieldName__StructField {
+	return &n.fields
+}
+
+// FieldRepresentation returns the value of the field "representation".
+func (n _TypeStruct) FieldRepresentation() StructRepresentation {
+	return &n.representation
+}
+
+type _TypeStruct__Maybe struct {
+	m schema.Maybe
+	v TypeStruct
+}
+type MaybeTypeStruct = *_TypeStruct__Maybe
+
+func (m MaybeTypeStruct) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeTypeStruct) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeTypeStruct) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeTypeStruct) AsNode() ipld.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return ipld.Absent
+	case schema.Maybe_Null:
+		return ipld.Null
+	case schema.Maybe_Value:
+		return m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybeTypeStruct) Must() TypeStruct {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return m.v
+}
+
+var (
+	fieldName__TypeStruct_Fields         = _String{"fields"}
+	fieldName__TypeStruct_Representation = _String{"representation"}
+)
+var _ ipld.Node = (TypeStruct)(&_TypeStruct{})
+var _ schema.TypedNode = (TypeStruct)(&_TypeStruct{})
+
+func (TypeStruct) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n TypeStruct) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	case "fields":
+		return &n.fields, nil
+	case "representation":
+		return &n.representation, nil
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n TypeStruct) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (TypeStruct) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.TypeStruct"}.LookupByIndex(0)
+}
+func (n TypeStruct) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n TypeStruct) MapIterator() ipld.MapIterator {
+	return &_TypeStruct__MapItr{n, 0}
+}
+
+type _TypeStruct__MapItr struct {
+	n   TypeStruct
+	idx int
+}
+
+func (itr *_TypeStruct__MapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	if itr.idx >= 2 {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	switch itr.idx {
+	case 0:
+		k = &fieldName__TypeStruct_Fields
+		v = &itr.n.fields
+	case 1:
+		k = &fieldName__TypeStruct_Representation
+		v = &itr.n.representation
+	default:
+		panic("unreachable")
+	}
+	itr.idx++
+	return
+}
+func (itr *_TypeStruct__MapItr) Done() bool {
+	return itr.idx >= 2
+}
+
+func (TypeStruct) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (TypeStruct) Length() int64 {
+	return 2
+}
+func (TypeStruct) IsAbsent() bool {
+	return false
+}
+func (TypeStruct) IsNull() bool {
+	return false
+}
+func (TypeStruct) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.TypeStruct"}.AsBool()
+}
+func (TypeStruct) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.TypeStruct"}.AsInt()
+}
+func (TypeStruct) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.TypeStruct"}.AsFloat()
+}
+func (TypeStruct) AsString() (string, error) {
+	return mixins.Map{"schemadmt.TypeStruct"}.AsString()
+}
+func (TypeStruct) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.TypeStruct"}.AsBytes()
+}
+func (TypeStruct) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.TypeStruct"}.AsLink()
+}
+func (TypeStruct) Prototype() ipld.NodePrototype {
+	return _TypeStruct__Prototype{}
+}
+
+type _TypeStruct__Prototype struct{}
+
+func (_TypeStruct__Prototype) NewBuilder() ipld.NodeBuilder {
+	var nb _TypeStruct__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _TypeStruct__Builder struct {
+	_TypeStruct__Assembler
+}
+
+func (nb *_TypeStruct__Builder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_TypeStruct__Builder) Reset() {
+	var w _TypeStruct
+	var m schema.Maybe
+	*nb = _TypeStruct__Builder{_TypeStruct__Assembler{w: &w, m: &m}}
+}
+
+type _TypeStruct__Assembler struct {
+	w     *_TypeStruct
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm                schema.Maybe
+	ca_fields         _Map__FieldName__StructField__Assembler
+	ca_representation _StructRepresentation__Assembler
+}
+
+func (na *_TypeStruct__Assembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+	na.ca_fields.reset()
+	na.ca_representation.reset()
+}
+
+var (
+	fieldBit__TypeStruct_Fields         = 1 << 0
+	fieldBit__TypeStruct_Representation = 1 << 1
+	fieldBits__TypeStruct_sufficient    = 0 + 1<<0 + 1<<1
+)
+
+func (na *_TypeStruct__Assembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_TypeStruct{}
+	}
+	return na, nil
+}
+func (_TypeStruct__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.TypeStruct"}.BeginList(0)
+}
+func (na *_TypeStruct__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.TypeStruct"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_TypeStruct__Assembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.TypeStruct"}.AssignBool(false)
+}
+func (_TypeStruct__Assembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.TypeStruct"}.AssignInt(0)
+}
+func (_TypeStruct__Assembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.TypeStruct"}.AssignFloat(0)
+}
+func (_TypeStruct__Assembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.TypeStruct"}.AssignString("")
+}
+func (_TypeStruct__Assembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.TypeStruct"}.AssignBytes(nil)
+}
+func (_TypeStruct__Assembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.TypeStruct"}.AssignLink(nil)
+}
+func (na *_TypeStruct__Assembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_TypeStruct); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.TypeStruct", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_TypeStruct__Assembler) Prototype() ipld.NodePrototype {
+	return _TypeStruct__Prototype{}
+}
+func (ma *_TypeStruct__Assembler) valueFinishTidy() bool {
+	switch ma.f {
+	case 0:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.ca_fields.w = nil
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 1:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.ca_representation.w = nil
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_TypeStruct__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	switch k {
+	case "fields":
+		if ma.s&fieldBit__TypeStruct_Fields != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__TypeStruct_Fields}
+		}
+		ma.s += fieldBit__TypeStruct_Fields
+		ma.state = maState_midValue
+		ma.f = 0
+		ma.ca_fields.w = &ma.w.fields
+		ma.ca_fields.m = &ma.cm
+		return &ma.ca_fields, nil
+	case "representation":
+		if ma.s&fieldBit__TypeStruct_Representation != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__TypeStruct_Representation}
+		}
+		ma.s += fieldBit__TypeStruct_Representation
+		ma.state = maState_midValue
+		ma.f = 1
+		ma.ca_representation.w = &ma.w.representation
+		ma.ca_representation.m = &ma.cm
+		return &ma.ca_representation, nil
+	}
+	return nil, ipld.ErrInvalidKey{TypeName: "schemadmt.TypeStruct", Key: &_String{k}}
+}
+func (ma *_TypeStruct__Assembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_TypeStruct__KeyAssembler)(ma)
+}
+func (ma *_TypeStruct__Assembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	case 0:
+		ma.ca_fields.w = &ma.w.fields
+		ma.ca_fields.m = &ma.cm
+		return &ma.ca_fields
+	case 1:
+		ma.ca_representation.w = &ma.w.representation
+		ma.ca_representation.m = &ma.cm
+		return &ma.ca_representation
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_TypeStruct__Assembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__TypeStruct_sufficient != fieldBits__TypeStruct_sufficient {
+		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		if ma.s&fieldBit__TypeStruct_Fields == 0 {
+			err.Missing = append(err.Missing, "fields")
+		}
+		if ma.s&fieldBit__TypeStruct_Representation == 0 {
+			err.Missing = append(err.Missing, "representation")
+		}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_TypeStruct__Assembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_TypeStruct__Assembler) ValuePrototype(k string) ipld.NodePrototype {
+	panic("todo structbuilder mapassembler valueprototype")
+}
+
+type _TypeStruct__KeyAssembler _TypeStruct__Assembler
+
+func (_TypeStruct__KeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeStruct.KeyAssembler"}.BeginMap(0)
+}
+func (_TypeStruct__KeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeStruct.KeyAssembler"}.BeginList(0)
+}
+func (na *_TypeStruct__KeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.TypeStruct.KeyAssembler"}.AssignNull()
+}
+func (_TypeStruct__KeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.TypeStruct.KeyAssembler"}.AssignBool(false)
+}
+func (_TypeStruct__KeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.TypeStruct.KeyAssembler"}.AssignInt(0)
+}
+func (_TypeStruct__KeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.TypeStruct.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_TypeStruct__KeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	switch k {
+	case "fields":
+		if ka.s&fieldBit__TypeStruct_Fields != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__TypeStruct_Fields}
+		}
+		ka.s += fieldBit__TypeStruct_Fields
+		ka.state = maState_expectValue
+		ka.f = 0
+	case "representation":
+		if ka.s&fieldBit__TypeStruct_Representation != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__TypeStruct_Representation}
+		}
+		ka.s += fieldBit__TypeStruct_Representation
+		ka.state = maState_expectValue
+		ka.f = 1
+	default:
+		return ipld.ErrInvalidKey{TypeName: "schemadmt.TypeStruct", Key: &_String{k}}
+	}
+	return nil
+}
+func (_TypeStruct__KeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.TypeStruct.KeyAssembler"}.AssignBytes(nil)
+}
+func (_TypeStruct__KeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.TypeStruct.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_TypeStruct__KeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_TypeStruct__KeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (TypeStruct) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (n TypeStruct) Representation() ipld.Node {
+	return (*_TypeStruct__Repr)(n)
+}
+
+type _TypeStruct__Repr _TypeStruct
+
+var (
+	fieldName__TypeStruct_Fields_serial         = _String{"fields"}
+	fieldName__TypeStruct_Representation_serial = _String{"representation"}
+)
+var _ ipld.Node = &_TypeStruct__Repr{}
+
+func (_TypeStruct__Repr) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n *_TypeStruct__Repr) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	case "fields":
+		return n.fields.Representation(), nil
+	case "representation":
+		return n.representation.Representation(), nil
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n *_TypeStruct__Repr) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (_TypeStruct__Repr) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.TypeStruct.Repr"}.LookupByIndex(0)
+}
+func (n _TypeStruct__Repr) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n *_TypeStruct__Repr) MapIterator() ipld.MapIterator {
+	return &_TypeStruct__ReprMapItr{n, 0}
+}
+
+type _TypeStruct__ReprMapItr struct {
+	n   *_TypeStruct__Repr
+	idx int
+}
+
+func (itr *_TypeStruct__ReprMapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	if itr.idx >= 2 {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	switch itr.idx {
+	case 0:
+		k = &fieldName__TypeStruct_Fields_serial
+		v = itr.n.fields.Representation()
+	case 1:
+		k = &fieldName__TypeStruct_Representation_serial
+		v = itr.n.representation.Representation()
+	default:
+		panic("unreachable")
+	}
+	itr.idx++
+	return
+}
+func (itr *_TypeStruct__ReprMapItr) Done() bool {
+	return itr.idx >= 2
+}
+func (_TypeStruct__Repr) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (rn *_TypeStruct__Repr) Length() int64 {
+	l := 2
+	return int64(l)
+}
+func (_TypeStruct__Repr) IsAbsent() bool {
+	return false
+}
+func (_TypeStruct__Repr) IsNull() bool {
+	return false
+}
+func (_TypeStruct__Repr) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.TypeStruct.Repr"}.AsBool()
+}
+func (_TypeStruct__Repr) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.TypeStruct.Repr"}.AsInt()
+}
+func (_TypeStruct__Repr) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.TypeStruct.Repr"}.AsFloat()
+}
+func (_TypeStruct__Repr) AsString() (string, error) {
+	return mixins.Map{"schemadmt.TypeStruct.Repr"}.AsString()
+}
+func (_TypeStruct__Repr) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.TypeStruct.Repr"}.AsBytes()
+}
+func (_TypeStruct__Repr) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.TypeStruct.Repr"}.AsLink()
+}
+func (_TypeStruct__Repr) Prototype() ipld.NodePrototype {
+	return _TypeStruct__ReprPrototype{}
+}
+
+type _TypeStruct__ReprPrototype struct{}
+
+func (_TypeStruct__ReprPrototype) NewBuilder() ipld.NodeBuilder {
+	var nb _TypeStruct__ReprBuilder
+	nb.Reset()
+	return &nb
+}
+
+type _TypeStruct__ReprBuilder struct {
+	_TypeStruct__ReprAssembler
+}
+
+func (nb *_TypeStruct__ReprBuilder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_TypeStruct__ReprBuilder) Reset() {
+	var w _TypeStruct
+	var m schema.Maybe
+	*nb = _TypeStruct__ReprBuilder{_TypeStruct__ReprAssembler{w: &w, m: &m}}
+}
+
+type _TypeStruct__ReprAssembler struct {
+	w     *_TypeStruct
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm                schema.Maybe
+	ca_fields         _Map__FieldName__StructField__ReprAssembler
+	ca_representation _StructRepresentation__ReprAssembler
+}
+
+func (na *_TypeStruct__ReprAssembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+	na.ca_fields.reset()
+	na.ca_representation.reset()
+}
+func (na *_TypeStruct__ReprAssembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_TypeStruct{}
+	}
+	return na, nil
+}
+func (_TypeStruct__ReprAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.TypeStruct.Repr"}.BeginList(0)
+}
+func (na *_TypeStruct__ReprAssembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.TypeStruct.Repr.Repr"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_TypeStruct__ReprAssembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.TypeStruct.Repr"}.AssignBool(false)
+}
+func (_TypeStruct__ReprAssembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.TypeStruct.Repr"}.AssignInt(0)
+}
+func (_TypeStruct__ReprAssembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.TypeStruct.Repr"}.AssignFloat(0)
+}
+func (_TypeStruct__ReprAssembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.TypeStruct.Repr"}.AssignString("")
+}
+func (_TypeStruct__ReprAssembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.TypeStruct.Repr"}.AssignBytes(nil)
+}
+func (_TypeStruct__ReprAssembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.TypeStruct.Repr"}.AssignLink(nil)
+}
+func (na *_TypeStruct__ReprAssembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	v2, ok := v.(*_TypeStruct)
+	if v3, ok3 := v.(*_TypeStruct__Repr); ok3 {
+		v2, ok = (*_TypeStruct)(v3), true
+	}
+	if ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.TypeStruct.Repr", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_TypeStruct__ReprAssembler) Prototype() ipld.NodePrototype {
+	return _TypeStruct__ReprPrototype{}
+}
+func (ma *_TypeStruct__ReprAssembler) valueFinishTidy() bool {
+	switch ma.f {
+	case 0:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 1:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_TypeStruct__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	switch k {
+	case "fields":
+		if ma.s&fieldBit__TypeStruct_Fields != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__TypeStruct_Fields_serial}
+		}
+		ma.s += fieldBit__TypeStruct_Fields
+		ma.state = maState_midValue
+		ma.f = 0
+		ma.ca_fields.w = &ma.w.fields
+		ma.ca_fields.m = &ma.cm
+		return &ma.ca_fields, nil
+	case "representation":
+		if ma.s&fieldBit__TypeStruct_Representation != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__TypeStruct_Representation_serial}
+		}
+		ma.s += fieldBit__TypeStruct_Representation
+		ma.state = maState_midValue
+		ma.f = 1
+		ma.ca_representation.w = &ma.w.representation
+		ma.ca_representation.m = &ma.cm
+		return &ma.ca_representation, nil
+	default:
+	}
+	return nil, ipld.ErrInvalidKey{TypeName: "schemadmt.TypeStruct.Repr", Key: &_String{k}}
+}
+func (ma *_TypeStruct__ReprAssembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_TypeStruct__ReprKeyAssembler)(ma)
+}
+func (ma *_TypeStruct__ReprAssembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	case 0:
+		ma.ca_fields.w = &ma.w.fields
+		ma.ca_fields.m = &ma.cm
+		return &ma.ca_fields
+	case 1:
+		ma.ca_representation.w = &ma.w.representation
+		ma.ca_representation.m = &ma.cm
+		return &ma.ca_representation
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_TypeStruct__ReprAssembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__TypeStruct_sufficient != fieldBits__TypeStruct_sufficient {
+		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		if ma.s&fieldBit__TypeStruct_Fields == 0 {
+			err.Missing = append(err.Missing, "fields")
+		}
+		if ma.s&fieldBit__TypeStruct_Representation == 0 {
+			err.Missing = append(err.Missing, "representation")
+		}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_TypeStruct__ReprAssembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_TypeStruct__ReprAssembler) ValuePrototype(k string) ipld.NodePrototype {
+	panic("todo structbuilder mapassembler repr valueprototype")
+}
+
+type _TypeStruct__ReprKeyAssembler _TypeStruct__ReprAssembler
+
+func (_TypeStruct__ReprKeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeStruct.Repr.KeyAssembler"}.BeginMap(0)
+}
+func (_TypeStruct__ReprKeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeStruct.Repr.KeyAssembler"}.BeginList(0)
+}
+func (na *_TypeStruct__ReprKeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.TypeStruct.Repr.KeyAssembler"}.AssignNull()
+}
+func (_TypeStruct__ReprKeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.TypeStruct.Repr.KeyAssembler"}.AssignBool(false)
+}
+func (_TypeStruct__ReprKeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.TypeStruct.Repr.KeyAssembler"}.AssignInt(0)
+}
+func (_TypeStruct__ReprKeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.TypeStruct.Repr.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_TypeStruct__ReprKeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	switch k {
+	case "fields":
+		if ka.s&fieldBit__TypeStruct_Fields != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__TypeStruct_Fields_serial}
+		}
+		ka.s += fieldBit__TypeStruct_Fields
+		ka.state = maState_expectValue
+		ka.f = 0
+		return nil
+	case "representation":
+		if ka.s&fieldBit__TypeStruct_Representation != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__TypeStruct_Representation_serial}
+		}
+		ka.s += fieldBit__TypeStruct_Representation
+		ka.state = maState_expectValue
+		ka.f = 1
+		return nil
+	}
+	return ipld.ErrInvalidKey{TypeName: "schemadmt.TypeStruct.Repr", Key: &_String{k}}
+}
+func (_TypeStruct__ReprKeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.TypeStruct.Repr.KeyAssembler"}.AssignBytes(nil)
+}
+func (_TypeStruct__ReprKeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.TypeStruct.Repr.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_TypeStruct__ReprKeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_TypeStruct__ReprKeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
@@ -0,0 +1,856 @@
+package schemadmt
+
+// Code generated by go-ipld-prime gengo.  DO NOT EDIT.
+
+import (
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// TypeUnit matches the IPLD Schema type "TypeUnit".  It has Struct type-kind, and may be interrogated like map kind.
+type TypeUnit = *_TypeUnit
+type _TypeUnit struct {
+	representation _UnitRepresentation
+}
+
+// FieldRepresentation returns the value of the field "representation".
+func (n _TypeUnit) FieldRepresentation() UnitRepresentation {
+	return &n.representation
+}
+
+type _TypeUnit__Maybe struct {
+	m schema.Maybe
+	v TypeUnit
+}
+type MaybeTypeUnit = *_TypeUnit__Maybe
+
+func (m MaybeTypeUnit) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeTypeUnit) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeTypeUnit) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeTypeUnit) AsNode() ipld.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return ipld.Absent
+	case schema.Maybe_Null:
+		return ipld.Null
+	case schema.Maybe_Value:
+		return m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybeTypeUnit) Must() TypeUnit {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return m.v
+}
+
+var (
+	fieldName__TypeUnit_Representation = _String{"representation"}
+)
+var _ ipld.Node = (TypeUnit)(&_TypeUnit{})
+var _ schema.TypedNode = (TypeUnit)(&_TypeUnit{})
+
+func (TypeUnit) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n TypeUnit) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	case "representation":
+		return &n.representation, nil
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n TypeUnit) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (TypeUnit) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.TypeUnit"}.LookupByIndex(0)
+}
+func (n TypeUnit) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n TypeUnit) MapIterator() ipld.MapIterator {
+	return &_TypeUnit__MapItr{n, 0}
+}
+
+type _TypeUnit__MapItr struct {
+	n   TypeUnit
+	idx int
+}
+
+func (itr *_TypeUnit__MapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	if itr.idx >= 1 {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	switch itr.idx {
+	case 0:
+		k = &fieldName__TypeUnit_Representation
+		v = &itr.n.representation
+	default:
+		panic("unreachable")
+	}
+	itr.idx++
+	return
+}
+func (itr *_TypeUnit__MapItr) Done() bool {
+	return itr.idx >= 1
+}
+
+func (TypeUnit) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (TypeUnit) Length() int64 {
+	return 1
+}
+func (TypeUnit) IsAbsent() bool {
+	return false
+}
+func (TypeUnit) IsNull() bool {
+	return false
+}
+func (TypeUnit) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.TypeUnit"}.AsBool()
+}
+func (TypeUnit) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.TypeUnit"}.AsInt()
+}
+func (TypeUnit) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.TypeUnit"}.AsFloat()
+}
+func (TypeUnit) AsString() (string, error) {
+	return mixins.Map{"schemadmt.TypeUnit"}.AsString()
+}
+func (TypeUnit) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.TypeUnit"}.AsBytes()
+}
+func (TypeUnit) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.TypeUnit"}.AsLink()
+}
+func (TypeUnit) Prototype() ipld.NodePrototype {
+	return _TypeUnit__Prototype{}
+}
+
+type _TypeUnit__Prototype struct{}
+
+func (_TypeUnit__Prototype) NewBuilder() ipld.NodeBuilder {
+	var nb _TypeUnit__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _TypeUnit__Builder struct {
+	_TypeUnit__Assembler
+}
+
+func (nb *_TypeUnit__Builder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_TypeUnit__Builder) Reset() {
+	var w _TypeUnit
+	var m schema.Maybe
+	*nb = _TypeUnit__Builder{_TypeUnit__Assembler{w: &w, m: &m}}
+}
+
+type _TypeUnit__Assembler struct {
+	w     *_TypeUnit
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm                schema.Maybe
+	ca_representation _UnitRepresentation__Assembler
+}
+
+func (na *_TypeUnit__Assembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+	na.ca_representation.reset()
+}
+
+var (
+	fieldBit__TypeUnit_Representation = 1 << 0
+	fieldBits__TypeUnit_sufficient    = 0 + 1<<0
+)
+
+func (na *_TypeUnit__Assembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_TypeUnit{}
+	}
+	return na, nil
+}
+func (_TypeUnit__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.TypeUnit"}.BeginList(0)
+}
+func (na *_TypeUnit__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.TypeUnit"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_TypeUnit__Assembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.TypeUnit"}.AssignBool(false)
+}
+func (_TypeUnit__Assembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.TypeUnit"}.AssignInt(0)
+}
+func (_TypeUnit__Assembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.TypeUnit"}.AssignFloat(0)
+}
+func (_TypeUnit__Assembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.TypeUnit"}.AssignString("")
+}
+func (_TypeUnit__Assembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.TypeUnit"}.AssignBytes(nil)
+}
+func (_TypeUnit__Assembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.TypeUnit"}.AssignLink(nil)
+}
+func (na *_TypeUnit__Assembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_TypeUnit); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.TypeUnit", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_TypeUnit__Assembler) Prototype() ipld.NodePrototype {
+	return _TypeUnit__Prototype{}
+}
+func (ma *_TypeUnit__Assembler) valueFinishTidy() bool {
+	switch ma.f {
+	case 0:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.ca_representation.w = nil
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_TypeUnit__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	switch k {
+	case "representation":
+		if ma.s&fieldBit__TypeUnit_Representation != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__TypeUnit_Representation}
+		}
+		ma.s += fieldBit__TypeUnit_Representation
+		ma.state = maState_midValue
+		ma.f = 0
+		ma.ca_representation.w = &ma.w.representation
+		ma.ca_representation.m = &ma.cm
+		return &ma.ca_representation, nil
+	}
+	return nil, ipld.ErrInvalidKey{TypeName: "schemadmt.TypeUnit", Key: &_String{k}}
+}
+func (ma *_TypeUnit__Assembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_TypeUnit__KeyAssembler)(ma)
+}
+func (ma *_TypeUnit__Assembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	case 0:
+		ma.ca_representation.w = &ma.w.representation
+		ma.ca_representation.m = &ma.cm
+		return &ma.ca_representation
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_TypeUnit__Assembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__TypeUnit_sufficient != fieldBits__TypeUnit_sufficient {
+		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		if ma.s&fieldBit__TypeUnit_Representation == 0 {
+			err.Missing = append(err.Missing, "representation")
+		}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_TypeUnit__Assembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_TypeUnit__Assembler) ValuePrototype(k string) ipld.NodePrototype {
+	panic("todo structbuilder mapassembler valueprototype")
+}
+
+type _TypeUnit__KeyAssembler _TypeUnit__Assembler
+
+func (_TypeUnit__KeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeUnit.KeyAssembler"}.BeginMap(0)
+}
+func (_TypeUnit__KeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeUnit.KeyAssembler"}.BeginList(0)
+}
+func (na *_TypeUnit__KeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.TypeUnit.KeyAssembler"}.AssignNull()
+}
+func (_TypeUnit__KeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.TypeUnit.KeyAssembler"}.AssignBool(false)
+}
+func (_TypeUnit__KeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.TypeUnit.KeyAssembler"}.AssignInt(0)
+}
+func (_TypeUnit__KeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.TypeUnit.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_TypeUnit__KeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	switch k {
+	case "representation":
+		if ka.s&fieldBit__TypeUnit_Representation != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__TypeUnit_Representation}
+		}
+		ka.s += fieldBit__TypeUnit_Representation
+		ka.state = maState_expectValue
+		ka.f = 0
+	default:
+		return ipld.ErrInvalidKey{TypeName: "schemadmt.TypeUnit", Key: &_String{k}}
+	}
+	return nil
+}
+func (_TypeUnit__KeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.TypeUnit.KeyAssembler"}.AssignBytes(nil)
+}
+func (_TypeUnit__KeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.TypeUnit.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_TypeUnit__KeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_TypeUnit__KeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (TypeUnit) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (n TypeUnit) Representation() ipld.Node {
+	return (*_TypeUnit__Repr)(n)
+}
+
+type _TypeUnit__Repr _TypeUnit
+
+var (
+	fieldName__TypeUnit_Representation_serial = _String{"representation"}
+)
+var _ ipld.Node = &_TypeUnit__Repr{}
+
+func (_TypeUnit__Repr) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n *_TypeUnit__Repr) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	case "representation":
+		return n.representation.Representation(), nil
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n *_TypeUnit__Repr) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (_TypeUnit__Repr) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.TypeUnit.Repr"}.LookupByIndex(0)
+}
+func (n _TypeUnit__Repr) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n *_TypeUnit__Repr) MapIterator() ipld.MapIterator {
+	return &_TypeUnit__ReprMapItr{n, 0}
+}
+
+type _TypeUnit__ReprMapItr struct {
+	n   *_TypeUnit__Repr
+	idx int
+}
+
+func (itr *_TypeUnit__ReprMapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	if itr.idx >= 1 {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	switch itr.idx {
+	case 0:
+		k = &fieldName__TypeUnit_Representation_serial
+		v = itr.n.representation.Representation()
+	default:
+		panic("unreachable")
+	}
+	itr.idx++
+	return
+}
+func (itr *_TypeUnit__ReprMapItr) Done() bool {
+	return itr.idx >= 1
+}
+func (_TypeUnit__Repr) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (rn *_TypeUnit__Repr) Length() int64 {
+	l := 1
+	return int64(l)
+}
+func (_TypeUnit__Repr) IsAbsent() bool {
+	return false
+}
+func (_TypeUnit__Repr) IsNull() bool {
+	return false
+}
+func (_TypeUnit__Repr) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.TypeUnit.Repr"}.AsBool()
+}
+func (_TypeUnit__Repr) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.TypeUnit.Repr"}.AsInt()
+}
+func (_TypeUnit__Repr) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.TypeUnit.Repr"}.AsFloat()
+}
+func (_TypeUnit__Repr) AsString() (string, error) {
+	return mixins.Map{"schemadmt.TypeUnit.Repr"}.AsString()
+}
+func (_TypeUnit__Repr) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.TypeUnit.Repr"}.AsBytes()
+}
+func (_TypeUnit__Repr) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.TypeUnit.Repr"}.AsLink()
+}
+func (_TypeUnit__Repr) Prototype() ipld.NodePrototype {
+	return _TypeUnit__ReprPrototype{}
+}
+
+type _TypeUnit__ReprPrototype struct{}
+
+func (_TypeUnit__ReprPrototype) NewBuilder() ipld.NodeBuilder {
+	var nb _TypeUnit__ReprBuilder
+	nb.Reset()
+	return &nb
+}
+
+type _TypeUnit__ReprBuilder struct {
+	_TypeUnit__ReprAssembler
+}
+
+func (nb *_TypeUnit__ReprBuilder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_TypeUnit__ReprBuilder) Reset() {
+	var w _TypeUnit
+	var m schema.Maybe
+	*nb = _TypeUnit__ReprBuilder{_TypeUnit__ReprAssembler{w: &w, m: &m}}
+}
+
+type _TypeUnit__ReprAssembler struct {
+	w     *_TypeUnit
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm                schema.Maybe
+	ca_representation _UnitRepresentation__ReprAssembler
+}
+
+func (na *_TypeUnit__ReprAssembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+	na.ca_representation.reset()
+}
+func (na *_TypeUnit__ReprAssembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_TypeUnit{}
+	}
+	return na, nil
+}
+func (_TypeUnit__ReprAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.TypeUnit.Repr"}.BeginList(0)
+}
+func (na *_TypeUnit__ReprAssembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.TypeUnit.Repr.Repr"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_TypeUnit__ReprAssembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.TypeUnit.Repr"}.AssignBool(false)
+}
+func (_TypeUnit__ReprAssembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.TypeUnit.Repr"}.AssignInt(0)
+}
+func (_TypeUnit__ReprAssembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.TypeUnit.Repr"}.AssignFloat(0)
+}
+func (_TypeUnit__ReprAssembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.TypeUnit.Repr"}.AssignString("")
+}
+func (_TypeUnit__ReprAssembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.TypeUnit.Repr"}.AssignBytes(nil)
+}
+func (_TypeUnit__ReprAssembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.TypeUnit.Repr"}.AssignLink(nil)
+}
+func (na *_TypeUnit__ReprAssembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	v2, ok := v.(*_TypeUnit)
+	if v3, ok3 := v.(*_TypeUnit__Repr); ok3 {
+		v2, ok = (*_TypeUnit)(v3), true
+	}
+	if ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.TypeUnit.Repr", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_TypeUnit__ReprAssembler) Prototype() ipld.NodePrototype {
+	return _TypeUnit__ReprPrototype{}
+}
+func (ma *_TypeUnit__ReprAssembler) valueFinishTidy() bool {
+	switch ma.f {
+	case 0:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_TypeUnit__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	switch k {
+	case "representation":
+		if ma.s&fieldBit__TypeUnit_Representation != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__TypeUnit_Representation_serial}
+		}
+		ma.s += fieldBit__TypeUnit_Representation
+		ma.state = maState_midValue
+		ma.f = 0
+		ma.ca_representation.w = &ma.w.representation
+		ma.ca_representation.m = &ma.cm
+		return &ma.ca_representation, nil
+	default:
+	}
+	return nil, ipld.ErrInvalidKey{TypeName: "schemadmt.TypeUnit.Repr", Key: &_String{k}}
+}
+func (ma *_TypeUnit__ReprAssembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_TypeUnit__ReprKeyAssembler)(ma)
+}
+func (ma *_TypeUnit__ReprAssembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	case 0:
+		ma.ca_representation.w = &ma.w.representation
+		ma.ca_representation.m = &ma.cm
+		return &ma.ca_representation
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_TypeUnit__ReprAssembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__TypeUnit_sufficient != fieldBits__TypeUnit_sufficient {
+		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		if ma.s&fieldBit__TypeUnit_Representation == 0 {
+			err.Missing = append(err.Missing, "representation")
+		}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_TypeUnit__ReprAssembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_TypeUnit__ReprAssembler) ValuePrototype(k string) ipld.NodePrototype {
+	panic("todo structbuilder mapassembler repr valueprototype")
+}
+
+type _TypeUnit__ReprKeyAssembler _TypeUnit__ReprAssembler
+
+func (_TypeUnit__ReprKeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeUnit.Repr.KeyAssembler"}.BeginMap(0)
+}
+func (_TypeUnit__ReprKeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.TypeUnit.Repr.KeyAssembler"}.BeginList(0)
+}
+func (na *_TypeUnit__ReprKeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.TypeUnit.Repr.KeyAssembler"}.AssignNull()
+}
+func (_TypeUnit__ReprKeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.TypeUnit.Repr.KeyAssembler"}.AssignBool(false)
+}
+func (_TypeUnit__ReprKeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.TypeUnit.Repr.KeyAssembler"}.AssignInt(0)
+}
+func (_TypeUnit__ReprKeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.TypeUnit.Repr.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_TypeUnit__ReprKeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	switch k {
+	case "representation":
+		if ka.s&fieldBit__TypeUnit_Representation != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__TypeUnit_Representation_serial}
+		}
+		ka.s += fieldBit__TypeUnit_Representation
+		ka.state = maState_expectValue
+		ka.f = 0
+		return nil
+	}
+	return ipld.ErrInvalidKey{TypeName: "schemadmt.TypeUnit.Repr", Key: &_String{k}}
+}
+func (_TypeUnit__ReprKeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.TypeUnit.Repr.KeyAssembler"}.AssignBytes(nil)
+}
+func (_TypeUnit__ReprKeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.TypeUnit.Repr.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_TypeUnit__ReprKeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_TypeUnit__ReprKeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
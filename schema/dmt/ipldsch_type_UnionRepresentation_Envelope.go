@@ -0,0 +1,1038 @@
+package schemadmt
+
+// Code generated by go-ipld-prime gengo.  DO NOT EDIT.
+
+import (
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// UnionRepresentation_Envelope matches the IPLD Schema type "UnionRepresentation_Envelope".  It has Struct type-kind, and may be interrogated like map kind.
+type UnionRepresentation_Envelope = *_UnionRepresentation_Envelope
+type _UnionRepresentation_Envelope struct {
+	discriminantKey   _String
+	contentKey        _String
+	discriminantTable _Map__String__TypeName
+}
+
+// FieldDiscriminantKey returns the value of the field "discriminantKey".
+func (n _UnionRepresentation_Envelope) FieldDiscriminantKey() String {
+	return &n.discriminantKey
+}
+
+// FieldContentKey returns the value of the field "contentKey".
+func (n _UnionRepresentation_Envelope) FieldContentKey() String {
+	return &n.contentKey
+}
+
+// FieldDiscriminantTable returns the value of the field "discriminantTable".
+func (n _UnionRepresentation_Envelope) FieldDiscriminantTable() Map__String__TypeName {
+	return &n.discriminantTable
+}
+
+type _UnionRepresentation_Envelope__Maybe struct {
+	m schema.Maybe
+	v UnionRepresentation_Envelope
+}
+type MaybeUnionRepresentation_Envelope = *_UnionRepresentation_Envelope__Maybe
+
+func (m MaybeUnionRepresentation_Envelope) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeUnionRepresentation_Envelope) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeUnionRepresentation_Envelope) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeUnionRepresentation_Envelope) AsNode() ipld.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return ipld.Absent
+	case schema.Maybe_Null:
+		return ipld.Null
+	case schema.Maybe_Value:
+		return m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybeUnionRepresentation_Envelope) Must() UnionRepresentation_Envelope {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return m.v
+}
+
+var (
+	fieldName__UnionRepresentation_Envelope_DiscriminantKey   = _String{"discriminantKey"}
+	fieldName__UnionRepresentation_Envelope_ContentKey        = _String{"contentKey"}
+	fieldName__UnionRepresentation_Envelope_DiscriminantTable = _String{"discriminantTable"}
+)
+var _ ipld.Node = (UnionRepresentation_Envelope)(&_UnionRepresentation_Envelope{})
+var _ schema.TypedNode = (UnionRepresentation_Envelope)(&_UnionRepresentation_Envelope{})
+
+func (UnionRepresentation_Envelope) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n UnionRepresentation_Envelope) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	case "discriminantKey":
+		return &n.discriminantKey, nil
+	case "contentKey":
+		return &n.contentKey, nil
+	case "discriminantTable":
+		return &n.discriminantTable, nil
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n UnionRepresentation_Envelope) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (UnionRepresentation_Envelope) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.UnionRepresentation_Envelope"}.LookupByIndex(0)
+}
+func (n UnionRepresentation_Envelope) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n UnionRepresentation_Envelope) MapIterator() ipld.MapIterator {
+	return &_UnionRepresentation_Envelope__MapItr{n, 0}
+}
+
+type _UnionRepresentation_Envelope__MapItr struct {
+	n   UnionRepresentation_Envelope
+	idx int
+}
+
+func (itr *_UnionRepresentation_Envelope__MapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	if itr.idx >= 3 {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	switch itr.idx {
+	case 0:
+		k = &fieldName__UnionRepresentation_Envelope_DiscriminantKey
+		v = &itr.n.discriminantKey
+	case 1:
+		k = &fieldName__UnionRepresentation_Envelope_ContentKey
+		v = &itr.n.contentKey
+	case 2:
+		k = &fieldName__UnionRepresentation_Envelope_DiscriminantTable
+		v = &itr.n.discriminantTable
+	default:
+		panic("unreachable")
+	}
+	itr.idx++
+	return
+}
+func (itr *_UnionRepresentation_Envelope__MapItr) Done() bool {
+	return itr.idx >= 3
+}
+
+func (UnionRepresentation_Envelope) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (UnionRepresentation_Envelope) Length() int64 {
+	return 3
+}
+func (UnionRepresentation_Envelope) IsAbsent() bool {
+	return false
+}
+func (UnionRepresentation_Envelope) IsNull() bool {
+	return false
+}
+func (UnionRepresentation_Envelope) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.UnionRepresentation_Envelope"}.AsBool()
+}
+func (UnionRepresentation_Envelope) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.UnionRepresentation_Envelope"}.AsInt()
+}
+func (UnionRepresentation_Envelope) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.UnionRepresentation_Envelope"}.AsFloat()
+}
+func (UnionRepresentation_Envelope) AsString() (string, error) {
+	return mixins.Map{"schemadmt.UnionRepresentation_Envelope"}.AsString()
+}
+func (UnionRepresentation_Envelope) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.UnionRepresentation_Envelope"}.AsBytes()
+}
+func (UnionRepresentation_Envelope) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.UnionRepresentation_Envelope"}.AsLink()
+}
+func (UnionRepresentation_Envelope) Prototype() ipld.NodePrototype {
+	return _UnionRepresentation_Envelope__Prototype{}
+}
+
+type _UnionRepresentation_Envelope__Prototype struct{}
+
+func (_UnionRepresentation_Envelope__Prototype) NewBuilder() ipld.NodeBuilder {
+	var nb _UnionRepresentation_Envelope__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _UnionRepresentation_Envelope__Builder struct {
+	_UnionRepresentation_Envelope__Assembler
+}
+
+func (nb *_UnionRepresentation_Envelope__Builder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_UnionRepresentation_Envelope__Builder) Reset() {
+	var w _UnionRepresentation_Envelope
+	var m schema.Maybe
+	*nb = _UnionRepresentation_Envelope__Builder{_UnionRepresentation_Envelope__Assembler{w: &w, m: &m}}
+}
+
+type _UnionRepresentation_Envelope__Assembler struct {
+	w     *_UnionRepresentation_Envelope
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm                   schema.Maybe
+	ca_discriminantKey   _String__Assembler
+	ca_contentKey        _String__Assembler
+	ca_discriminantTable _Map__String__TypeName__Assembler
+}
+
+func (na *_UnionRepresentation_Envelope__Assembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+	na.ca_discriminantKey.reset()
+	na.ca_contentKey.reset()
+	na.ca_discriminantTable.reset()
+}
+
+var (
+	fieldBit__UnionRepresentation_Envelope_DiscriminantKey   = 1 << 0
+	fieldBit__UnionRepresentation_Envelope_ContentKey        = 1 << 1
+	fieldBit__UnionRepresentation_Envelope_DiscriminantTable = 1 << 2
+	fieldBits__UnionRepresentation_Envelope_sufficient       = 0 + 1<<0 + 1<<1 + 1<<2
+)
+
+func (na *_UnionRepresentation_Envelope__Assembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_UnionRepresentation_Envelope{}
+	}
+	return na, nil
+}
+func (_UnionRepresentation_Envelope__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.UnionRepresentation_Envelope"}.BeginList(0)
+}
+func (na *_UnionRepresentation_Envelope__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.UnionRepresentation_Envelope"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_UnionRepresentation_Envelope__Assembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.UnionRepresentation_Envelope"}.AssignBool(false)
+}
+func (_UnionRepresentation_Envelope__Assembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.UnionRepresentation_Envelope"}.AssignInt(0)
+}
+func (_UnionRepresentation_Envelope__Assembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.UnionRepresentation_Envelope"}.AssignFloat(0)
+}
+func (_UnionRepresentation_Envelope__Assembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.UnionRepresentation_Envelope"}.AssignString("")
+}
+func (_UnionRepresentation_Envelope__Assembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.UnionRepresentation_Envelope"}.AssignBytes(nil)
+}
+func (_UnionRepresentation_Envelope__Assembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.UnionRepresentation_Envelope"}.AssignLink(nil)
+}
+func (na *_UnionRepresentation_Envelope__Assembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_UnionRepresentation_Envelope); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.UnionRepresentation_Envelope", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_UnionRepresentation_Envelope__Assembler) Prototype() ipld.NodePrototype {
+	return _UnionRepresentation_Envelope__Prototype{}
+}
+func (ma *_UnionRepresentation_Envelope__Assembler) valueFinishTidy() bool {
+	switch ma.f {
+	case 0:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.ca_discriminantKey.w = nil
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 1:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.ca_contentKey.w = nil
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 2:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.ca_discriminantTable.w = nil
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_UnionRepresentation_Envelope__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	switch k {
+	case "discriminantKey":
+		if ma.s&fieldBit__UnionRepresentation_Envelope_DiscriminantKey != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__UnionRepresentation_Envelope_DiscriminantKey}
+		}
+		ma.s += fieldBit__UnionRepresentation_Envelope_DiscriminantKey
+		ma.state = maState_midValue
+		ma.f = 0
+		ma.ca_discriminantKey.w = &ma.w.discriminantKey
+		ma.ca_discriminantKey.m = &ma.cm
+		return &ma.ca_discriminantKey, nil
+	case "contentKey":
+		if ma.s&fieldBit__UnionRepresentation_Envelope_ContentKey != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__UnionRepresentation_Envelope_ContentKey}
+		}
+		ma.s += fieldBit__UnionRepresentation_Envelope_ContentKey
+		ma.state = maState_midValue
+		ma.f = 1
+		ma.ca_contentKey.w = &ma.w.contentKey
+		ma.ca_contentKey.m = &ma.cm
+		return &ma.ca_contentKey, nil
+	case "discriminantTable":
+		if ma.s&fieldBit__UnionRepresentation_Envelope_DiscriminantTable != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__UnionRepresentation_Envelope_DiscriminantTable}
+		}
+		ma.s += fieldBit__UnionRepresentation_Envelope_DiscriminantTable
+		ma.state = maState_midValue
+		ma.f = 2
+		ma.ca_discriminantTable.w = &ma.w.discriminantTable
+		ma.ca_discriminantTable.m = &ma.cm
+		return &ma.ca_discriminantTable, nil
+	}
+	return nil, ipld.ErrInvalidKey{TypeName: "schemadmt.UnionRepresentation_Envelope", Key: &_String{k}}
+}
+func (ma *_UnionRepresentation_Envelope__Assembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_UnionRepresentation_Envelope__KeyAssembler)(ma)
+}
+func (ma *_UnionRepresentation_Envelope__Assembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	case 0:
+		ma.ca_discriminantKey.w = &ma.w.discriminantKey
+		ma.ca_discriminantKey.m = &ma.cm
+		return &ma.ca_discriminantKey
+	case 1:
+		ma.ca_contentKey.w = &ma.w.contentKey
+		ma.ca_contentKey.m = &ma.cm
+		return &ma.ca_contentKey
+	case 2:
+		ma.ca_discriminantTable.w = &ma.w.discriminantTable
+		ma.ca_discriminantTable.m = &ma.cm
+		return &ma.ca_discriminantTable
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_UnionRepresentation_Envelope__Assembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__UnionRepresentation_Envelope_sufficient != fieldBits__UnionRepresentation_Envelope_sufficient {
+		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		if ma.s&fieldBit__UnionRepresentation_Envelope_DiscriminantKey == 0 {
+			err.Missing = append(err.Missing, "discriminantKey")
+		}
+		if ma.s&fieldBit__UnionRepresentation_Envelope_ContentKey == 0 {
+			err.Missing = append(err.Missing, "contentKey")
+		}
+		if ma.s&fieldBit__UnionRepresentation_Envelope_DiscriminantTable == 0 {
+			err.Missing = append(err.Missing, "discriminantTable")
+		}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_UnionRepresentation_Envelope__Assembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_UnionRepresentation_Envelope__Assembler) ValuePrototype(k string) ipld.NodePrototype {
+	panic("todo structbuilder mapassembler valueprototype")
+}
+
+type _UnionRepresentation_Envelope__KeyAssembler _UnionRepresentation_Envelope__Assembler
+
+func (_UnionRepresentation_Envelope__KeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.UnionRepresentation_Envelope.KeyAssembler"}.BeginMap(0)
+}
+func (_UnionRepresentation_Envelope__KeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.UnionRepresentation_Envelope.KeyAssembler"}.BeginList(0)
+}
+func (na *_UnionRepresentation_Envelope__KeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.UnionRepresentation_Envelope.KeyAssembler"}.AssignNull()
+}
+func (_UnionRepresentation_Envelope__KeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.UnionRepresentation_Envelope.KeyAssembler"}.AssignBool(false)
+}
+func (_UnionRepresentation_Envelope__KeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.UnionRepresentation_Envelope.KeyAssembler"}.AssignInt(0)
+}
+func (_UnionRepresentation_Envelope__KeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.UnionRepresentation_Envelope.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_UnionRepresentation_Envelope__KeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	switch k {
+	case "discriminantKey":
+		if ka.s&fieldBit__UnionRepresentation_Envelope_DiscriminantKey != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__UnionRepresentation_Envelope_DiscriminantKey}
+		}
+		ka.s += fieldBit__UnionRepresentation_Envelope_DiscriminantKey
+		ka.state = maState_expectValue
+		ka.f = 0
+	case "contentKey":
+		if ka.s&fieldBit__UnionRepresentation_Envelope_ContentKey != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__UnionRepresentation_Envelope_ContentKey}
+		}
+		ka.s += fieldBit__UnionRepresentation_Envelope_ContentKey
+		ka.state = maState_expectValue
+		ka.f = 1
+	case "discriminantTable":
+		if ka.s&fieldBit__UnionRepresentation_Envelope_DiscriminantTable != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__UnionRepresentation_Envelope_DiscriminantTable}
+		}
+		ka.s += fieldBit__UnionRepresentation_Envelope_DiscriminantTable
+		ka.state = maState_expectValue
+		ka.f = 2
+	default:
+		return ipld.ErrInvalidKey{TypeName: "schemadmt.UnionRepresentation_Envelope", Key: &_String{k}}
+	}
+	return nil
+}
+func (_UnionRepresentation_Envelope__KeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.UnionRepresentation_Envelope.KeyAssembler"}.AssignBytes(nil)
+}
+func (_UnionRepresentation_Envelope__KeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.UnionRepresentation_Envelope.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_UnionRepresentation_Envelope__KeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_UnionRepresentation_Envelope__KeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (UnionRepresentation_Envelope) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (n UnionRepresentation_Envelope) Representation() ipld.Node {
+	return (*_UnionRepresentation_Envelope__Repr)(n)
+}
+
+type _UnionRepresentation_Envelope__Repr _UnionRepresentation_Envelope
+
+var (
+	fieldName__UnionRepresentation_Envelope_DiscriminantKey_serial   = _String{"discriminantKey"}
+	fieldName__UnionRepresentation_Envelope_ContentKey_serial        = _String{"contentKey"}
+	fieldName__UnionRepresentation_Envelope_DiscriminantTable_serial = _String{"discriminantTable"}
+)
+var _ ipld.Node = &_UnionRepresentation_Envelope__Repr{}
+
+func (_UnionRepresentation_Envelope__Repr) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n *_UnionRepresentation_Envelope__Repr) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	case "discriminantKey":
+		return n.discriminantKey.Representation(), nil
+	case "contentKey":
+		return n.contentKey.Representation(), nil
+	case "discriminantTable":
+		return n.discriminantTable.Representation(), nil
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n *_UnionRepresentation_Envelope__Repr) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (_UnionRepresentation_Envelope__Repr) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.UnionRepresentation_Envelope.Repr"}.LookupByIndex(0)
+}
+func (n _UnionRepresentation_Envelope__Repr) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n *_UnionRepresentation_Envelope__Repr) MapIterator() ipld.MapIterator {
+	return &_UnionRepresentation_Envelope__ReprMapItr{n, 0}
+}
+
+type _UnionRepresentation_Envelope__ReprMapItr struct {
+	n   *_UnionRepresentation_Envelope__Repr
+	idx int
+}
+
+func (itr *_UnionRepresentation_Envelope__ReprMapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	if itr.idx >= 3 {
+		return nil, nil, ipld.ErrIteratorOverread{}
+	}
+	switch itr.idx {
+	case 0:
+		k = &fieldName__UnionRepresentation_Envelope_DiscriminantKey_serial
+		v = itr.n.discriminantKey.Representation()
+	case 1:
+		k = &fieldName__UnionRepresentation_Envelope_ContentKey_serial
+		v = itr.n.contentKey.Representation()
+	case 2:
+		k = &fieldName__UnionRepresentation_Envelope_DiscriminantTable_serial
+		v = itr.n.discriminantTable.Representation()
+	default:
+		panic("unreachable")
+	}
+	itr.idx++
+	return
+}
+func (itr *_UnionRepresentation_Envelope__ReprMapItr) Done() bool {
+	return itr.idx >= 3
+}
+func (_UnionRepresentation_Envelope__Repr) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (rn *_UnionRepresentation_Envelope__Repr) Length() int64 {
+	l := 3
+	return int64(l)
+}
+func (_UnionRepresentation_Envelope__Repr) IsAbsent() bool {
+	return false
+}
+func (_UnionRepresentation_Envelope__Repr) IsNull() bool {
+	return false
+}
+func (_UnionRepresentation_Envelope__Repr) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.UnionRepresentation_Envelope.Repr"}.AsBool()
+}
+func (_UnionRepresentation_Envelope__Repr) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.UnionRepresentation_Envelope.Repr"}.AsInt()
+}
+func (_UnionRepresentation_Envelope__Repr) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.UnionRepresentation_Envelope.Repr"}.AsFloat()
+}
+func (_UnionRepresentation_Envelope__Repr) AsString() (string, error) {
+	return mixins.Map{"schemadmt.UnionRepresentation_Envelope.Repr"}.AsString()
+}
+func (_UnionRepresentation_Envelope__Repr) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.UnionRepresentation_Envelope.Repr"}.AsBytes()
+}
+func (_UnionRepresentation_Envelope__Repr) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.UnionRepresentation_Envelope.Repr"}.AsLink()
+}
+func (_UnionRepresentation_Envelope__Repr) Prototype() ipld.NodePrototype {
+	return _UnionRepresentation_Envelope__ReprPrototype{}
+}
+
+type _UnionRepresentation_Envelope__ReprPrototype struct{}
+
+func (_UnionRepresentation_Envelope__ReprPrototype) NewBuilder() ipld.NodeBuilder {
+	var nb _UnionRepresentation_Envelope__ReprBuilder
+	nb.Reset()
+	return &nb
+}
+
+type _UnionRepresentation_Envelope__ReprBuilder struct {
+	_UnionRepresentation_Envelope__ReprAssembler
+}
+
+func (nb *_UnionRepresentation_Envelope__ReprBuilder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_UnionRepresentation_Envelope__ReprBuilder) Reset() {
+	var w _UnionRepresentation_Envelope
+	var m schema.Maybe
+	*nb = _UnionRepresentation_Envelope__ReprBuilder{_UnionRepresentation_Envelope__ReprAssembler{w: &w, m: &m}}
+}
+
+type _UnionRepresentation_Envelope__ReprAssembler struct {
+	w     *_UnionRepresentation_Envelope
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm                   schema.Maybe
+	ca_discriminantKey   _String__ReprAssembler
+	ca_contentKey        _String__ReprAssembler
+	ca_discriminantTable _Map__String__TypeName__ReprAssembler
+}
+
+func (na *_UnionRepresentation_Envelope__ReprAssembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+	na.ca_discriminantKey.reset()
+	na.ca_contentKey.reset()
+	na.ca_discriminantTable.reset()
+}
+func (na *_UnionRepresentation_Envelope__ReprAssembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_UnionRepresentation_Envelope{}
+	}
+	return na, nil
+}
+func (_UnionRepresentation_Envelope__ReprAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.UnionRepresentation_Envelope.Repr"}.BeginList(0)
+}
+func (na *_UnionRepresentation_Envelope__ReprAssembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.UnionRepresentation_Envelope.Repr.Repr"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_UnionRepresentation_Envelope__ReprAssembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.UnionRepresentation_Envelope.Repr"}.AssignBool(false)
+}
+func (_UnionRepresentation_Envelope__ReprAssembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.UnionRepresentation_Envelope.Repr"}.AssignInt(0)
+}
+func (_UnionRepresentation_Envelope__ReprAssembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.UnionRepresentation_Envelope.Repr"}.AssignFloat(0)
+}
+func (_UnionRepresentation_Envelope__ReprAssembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.UnionRepresentation_Envelope.Repr"}.AssignString("")
+}
+func (_UnionRepresentation_Envelope__ReprAssembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.UnionRepresentation_Envelope.Repr"}.AssignBytes(nil)
+}
+func (_UnionRepresentation_Envelope__ReprAssembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.UnionRepresentation_Envelope.Repr"}.AssignLink(nil)
+}
+func (na *_UnionRepresentation_Envelope__ReprAssembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	v2, ok := v.(*_UnionRepresentation_Envelope)
+	if v3, ok3 := v.(*_UnionRepresentation_Envelope__Repr); ok3 {
+		v2, ok = (*_UnionRepresentation_Envelope)(v3), true
+	}
+	if ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.UnionRepresentation_Envelope.Repr", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_UnionRepresentation_Envelope__ReprAssembler) Prototype() ipld.NodePrototype {
+	return _UnionRepresentation_Envelope__ReprPrototype{}
+}
+func (ma *_UnionRepresentation_Envelope__ReprAssembler) valueFinishTidy() bool {
+	switch ma.f {
+	case 0:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 1:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 2:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_UnionRepresentation_Envelope__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	switch k {
+	case "discriminantKey":
+		if ma.s&fieldBit__UnionRepresentation_Envelope_DiscriminantKey != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__UnionRepresentation_Envelope_DiscriminantKey_serial}
+		}
+		ma.s += fieldBit__UnionRepresentation_Envelope_DiscriminantKey
+		ma.state = maState_midValue
+		ma.f = 0
+		ma.ca_discriminantKey.w = &ma.w.discriminantKey
+		ma.ca_discriminantKey.m = &ma.cm
+		return &ma.ca_discriminantKey, nil
+	case "contentKey":
+		if ma.s&fieldBit__UnionRepresentation_Envelope_ContentKey != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__UnionRepresentation_Envelope_ContentKey_serial}
+		}
+		ma.s += fieldBit__UnionRepresentation_Envelope_ContentKey
+		ma.state = maState_midValue
+		ma.f = 1
+		ma.ca_contentKey.w = &ma.w.contentKey
+		ma.ca_contentKey.m = &ma.cm
+		return &ma.ca_contentKey, nil
+	case "discriminantTable":
+		if ma.s&fieldBit__UnionRepresentation_Envelope_DiscriminantTable != 0 {
+			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__UnionRepresentation_Envelope_DiscriminantTable_serial}
+		}
+		ma.s += fieldBit__UnionRepresentation_Envelope_DiscriminantTable
+		ma.state = maState_midValue
+		ma.f = 2
+		ma.ca_discriminantTable.w = &ma.w.discriminantTable
+		ma.ca_discriminantTable.m = &ma.cm
+		return &ma.ca_discriminantTable, nil
+	default:
+	}
+	return nil, ipld.ErrInvalidKey{TypeName: "schemadmt.UnionRepresentation_Envelope.Repr", Key: &_String{k}}
+}
+func (ma *_UnionRepresentation_Envelope__ReprAssembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_UnionRepresentation_Envelope__ReprKeyAssembler)(ma)
+}
+func (ma *_UnionRepresentation_Envelope__ReprAssembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	case 0:
+		ma.ca_discriminantKey.w = &ma.w.discriminantKey
+		ma.ca_discriminantKey.m = &ma.cm
+		return &ma.ca_discriminantKey
+	case 1:
+		ma.ca_contentKey.w = &ma.w.contentKey
+		ma.ca_contentKey.m = &ma.cm
+		return &ma.ca_contentKey
+	case 2:
+		ma.ca_discriminantTable.w = &ma.w.discriminantTable
+		ma.ca_discriminantTable.m = &ma.cm
+		return &ma.ca_discriminantTable
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_UnionRepresentation_Envelope__ReprAssembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__UnionRepresentation_Envelope_sufficient != fieldBits__UnionRepresentation_Envelope_sufficient {
+		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		if ma.s&fieldBit__UnionRepresentation_Envelope_DiscriminantKey == 0 {
+			err.Missing = append(err.Missing, "discriminantKey")
+		}
+		if ma.s&fieldBit__UnionRepresentation_Envelope_ContentKey == 0 {
+			err.Missing = append(err.Missing, "contentKey")
+		}
+		if ma.s&fieldBit__UnionRepresentation_Envelope_DiscriminantTable == 0 {
+			err.Missing = append(err.Missing, "discriminantTable")
+		}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_UnionRepresentation_Envelope__ReprAssembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_UnionRepresentation_Envelope__ReprAssembler) ValuePrototype(k string) ipld.NodePrototype {
+	panic("todo structbuilder mapassembler repr valueprototype")
+}
+
+type _UnionRepresentation_Envelope__ReprKeyAssembler _UnionRepresentation_Envelope__ReprAssembler
+
+func (_UnionRepresentation_Envelope__ReprKeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.UnionRepresentation_Envelope.Repr.KeyAssembler"}.BeginMap(0)
+}
+func (_UnionRepresentation_Envelope__ReprKeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.UnionRepresentation_Envelope.Repr.KeyAssembler"}.BeginList(0)
+}
+func (na *_UnionRepresentation_Envelope__ReprKeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.UnionRepresentation_Envelope.Repr.KeyAssembler"}.AssignNull()
+}
+func (_UnionRepresentation_Envelope__ReprKeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.UnionRepresentation_Envelope.Repr.KeyAssembler"}.AssignBool(false)
+}
+func (_UnionRepresentation_Envelope__ReprKeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.UnionRepresentation_Envelope.Repr.KeyAssembler"}.AssignInt(0)
+}
+func (_UnionRepresentation_Envelope__ReprKeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.UnionRepresentation_Envelope.Repr.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_UnionRepresentation_Envelope__ReprKeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	switch k {
+	case "discriminantKey":
+		if ka.s&fieldBit__UnionRepresentation_Envelope_DiscriminantKey != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__UnionRepresentation_Envelope_DiscriminantKey_serial}
+		}
+		ka.s += fieldBit__UnionRepresentation_Envelope_DiscriminantKey
+		ka.state = maState_expectValue
+		ka.f = 0
+		return nil
+	case "contentKey":
+		if ka.s&fieldBit__UnionRepresentation_Envelope_ContentKey != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__UnionRepresentation_Envelope_ContentKey_serial}
+		}
+		ka.s += fieldBit__UnionRepresentation_Envelope_ContentKey
+		ka.state = maState_expectValue
+		ka.f = 1
+		return nil
+	case "discriminantTable":
+		if ka.s&fieldBit__UnionRepresentation_Envelope_DiscriminantTable != 0 {
+			return ipld.ErrRepeatedMapKey{Key: &fieldName__UnionRepresentation_Envelope_DiscriminantTable_serial}
+		}
+		ka.s += fieldBit__UnionRepresentation_Envelope_DiscriminantTable
+		ka.state = maState_expectValue
+		ka.f = 2
+		return nil
+	}
+	return ipld.ErrInvalidKey{TypeName: "schemadmt.UnionRepresentation_Envelope.Repr", Key: &_String{k}}
+}
+func (_UnionRepresentation_Envelope__ReprKeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.UnionRepresentation_Envelope.Repr.KeyAssembler"}.AssignBytes(nil)
+}
+func (_UnionRepresentation_Envelope__ReprKeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.UnionRepresentation_Envelope.Repr.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_UnionRepresentation_Envelope__ReprKeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_UnionRepresentation_Envelope__ReprKeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
@@ -0,0 +1,738 @@
+package schemadmt
+
+// Code generated by go-ipld-prime gengo.  DO NOT EDIT.
+
+import (
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// Unit matches the IPLD Schema type "Unit".  It has Struct type-kind, and may be interrogated like map kind.
+type Unit = *_Unit
+type _Unit struct {
+}
+
+type _Unit__Maybe struct {
+	m schema.Maybe
+	v Unit
+}
+type MaybeUnit = *_Unit__Maybe
+
+func (m MaybeUnit) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeUnit) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeUnit) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeUnit) AsNode() ipld.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return ipld.Absent
+	case schema.Maybe_Null:
+		return ipld.Null
+	case schema.Maybe_Value:
+		return m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybeUnit) Must() Unit {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return m.v
+}
+
+var ()
+var _ ipld.Node = (Unit)(&_Unit{})
+var _ schema.TypedNode = (Unit)(&_Unit{})
+
+func (Unit) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n Unit) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n Unit) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (Unit) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.Unit"}.LookupByIndex(0)
+}
+func (n Unit) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n Unit) MapIterator() ipld.MapIterator {
+	return &_Unit__MapItr{n, 0}
+}
+
+type _Unit__MapItr struct {
+	n   Unit
+	idx int
+}
+
+func (itr *_Unit__MapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	return nil, nil, ipld.ErrIteratorOverread{}
+
+}
+func (itr *_Unit__MapItr) Done() bool {
+	return itr.idx >= 0
+}
+
+func (Unit) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (Unit) Length() int64 {
+	return 0
+}
+func (Unit) IsAbsent() bool {
+	return false
+}
+func (Unit) IsNull() bool {
+	return false
+}
+func (Unit) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.Unit"}.AsBool()
+}
+func (Unit) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.Unit"}.AsInt()
+}
+func (Unit) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.Unit"}.AsFloat()
+}
+func (Unit) AsString() (string, error) {
+	return mixins.Map{"schemadmt.Unit"}.AsString()
+}
+func (Unit) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.Unit"}.AsBytes()
+}
+func (Unit) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.Unit"}.AsLink()
+}
+func (Unit) Prototype() ipld.NodePrototype {
+	return _Unit__Prototype{}
+}
+
+type _Unit__Prototype struct{}
+
+func (_Unit__Prototype) NewBuilder() ipld.NodeBuilder {
+	var nb _Unit__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _Unit__Builder struct {
+	_Unit__Assembler
+}
+
+func (nb *_Unit__Builder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_Unit__Builder) Reset() {
+	var w _Unit
+	var m schema.Maybe
+	*nb = _Unit__Builder{_Unit__Assembler{w: &w, m: &m}}
+}
+
+type _Unit__Assembler struct {
+	w     *_Unit
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm schema.Maybe
+}
+
+func (na *_Unit__Assembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+}
+
+var (
+	fieldBits__Unit_sufficient = 0
+)
+
+func (na *_Unit__Assembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_Unit{}
+	}
+	return na, nil
+}
+func (_Unit__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.Unit"}.BeginList(0)
+}
+func (na *_Unit__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.Unit"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_Unit__Assembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.Unit"}.AssignBool(false)
+}
+func (_Unit__Assembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.Unit"}.AssignInt(0)
+}
+func (_Unit__Assembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.Unit"}.AssignFloat(0)
+}
+func (_Unit__Assembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.Unit"}.AssignString("")
+}
+func (_Unit__Assembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.Unit"}.AssignBytes(nil)
+}
+func (_Unit__Assembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.Unit"}.AssignLink(nil)
+}
+func (na *_Unit__Assembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_Unit); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.Unit", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_Unit__Assembler) Prototype() ipld.NodePrototype {
+	return _Unit__Prototype{}
+}
+func (ma *_Unit__Assembler) valueFinishTidy() bool {
+	switch ma.f {
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_Unit__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	return nil, ipld.ErrInvalidKey{TypeName: "schemadmt.Unit", Key: &_String{k}}
+}
+func (ma *_Unit__Assembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_Unit__KeyAssembler)(ma)
+}
+func (ma *_Unit__Assembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_Unit__Assembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__Unit_sufficient != fieldBits__Unit_sufficient {
+		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_Unit__Assembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_Unit__Assembler) ValuePrototype(k string) ipld.NodePrototype {
+	panic("todo structbuilder mapassembler valueprototype")
+}
+
+type _Unit__KeyAssembler _Unit__Assembler
+
+func (_Unit__KeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.Unit.KeyAssembler"}.BeginMap(0)
+}
+func (_Unit__KeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.Unit.KeyAssembler"}.BeginList(0)
+}
+func (na *_Unit__KeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.Unit.KeyAssembler"}.AssignNull()
+}
+func (_Unit__KeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.Unit.KeyAssembler"}.AssignBool(false)
+}
+func (_Unit__KeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.Unit.KeyAssembler"}.AssignInt(0)
+}
+func (_Unit__KeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.Unit.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_Unit__KeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	return ipld.ErrInvalidKey{TypeName: "schemadmt.Unit", Key: &_String{k}}
+}
+func (_Unit__KeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.Unit.KeyAssembler"}.AssignBytes(nil)
+}
+func (_Unit__KeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.Unit.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_Unit__KeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_Unit__KeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (Unit) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (n Unit) Representation() ipld.Node {
+	return (*_Unit__Repr)(n)
+}
+
+type _Unit__Repr _Unit
+
+var ()
+var _ ipld.Node = &_Unit__Repr{}
+
+func (_Unit__Repr) Kind() ipld.Kind {
+	return ipld.Kind_Map
+}
+func (n *_Unit__Repr) LookupByString(key string) (ipld.Node, error) {
+	switch key {
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+	}
+}
+func (n *_Unit__Repr) LookupByNode(key ipld.Node) (ipld.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (_Unit__Repr) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Map{"schemadmt.Unit.Repr"}.LookupByIndex(0)
+}
+func (n _Unit__Repr) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n *_Unit__Repr) MapIterator() ipld.MapIterator {
+	return &_Unit__ReprMapItr{n, 0}
+}
+
+type _Unit__ReprMapItr struct {
+	n   *_Unit__Repr
+	idx int
+}
+
+func (itr *_Unit__ReprMapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+	return nil, nil, ipld.ErrIteratorOverread{}
+
+}
+func (itr *_Unit__ReprMapItr) Done() bool {
+	return itr.idx >= 0
+}
+func (_Unit__Repr) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (rn *_Unit__Repr) Length() int64 {
+	l := 0
+	return int64(l)
+}
+func (_Unit__Repr) IsAbsent() bool {
+	return false
+}
+func (_Unit__Repr) IsNull() bool {
+	return false
+}
+func (_Unit__Repr) AsBool() (bool, error) {
+	return mixins.Map{"schemadmt.Unit.Repr"}.AsBool()
+}
+func (_Unit__Repr) AsInt() (int64, error) {
+	return mixins.Map{"schemadmt.Unit.Repr"}.AsInt()
+}
+func (_Unit__Repr) AsFloat() (float64, error) {
+	return mixins.Map{"schemadmt.Unit.Repr"}.AsFloat()
+}
+func (_Unit__Repr) AsString() (string, error) {
+	return mixins.Map{"schemadmt.Unit.Repr"}.AsString()
+}
+func (_Unit__Repr) AsBytes() ([]byte, error) {
+	return mixins.Map{"schemadmt.Unit.Repr"}.AsBytes()
+}
+func (_Unit__Repr) AsLink() (ipld.Link, error) {
+	return mixins.Map{"schemadmt.Unit.Repr"}.AsLink()
+}
+func (_Unit__Repr) Prototype() ipld.NodePrototype {
+	return _Unit__ReprPrototype{}
+}
+
+type _Unit__ReprPrototype struct{}
+
+func (_Unit__ReprPrototype) NewBuilder() ipld.NodeBuilder {
+	var nb _Unit__ReprBuilder
+	nb.Reset()
+	return &nb
+}
+
+type _Unit__ReprBuilder struct {
+	_Unit__ReprAssembler
+}
+
+func (nb *_Unit__ReprBuilder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_Unit__ReprBuilder) Reset() {
+	var w _Unit
+	var m schema.Maybe
+	*nb = _Unit__ReprBuilder{_Unit__ReprAssembler{w: &w, m: &m}}
+}
+
+type _Unit__ReprAssembler struct {
+	w     *_Unit
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm schema.Maybe
+}
+
+func (na *_Unit__ReprAssembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+}
+func (na *_Unit__ReprAssembler) BeginMap(int64) (ipld.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_Unit{}
+	}
+	return na, nil
+}
+func (_Unit__ReprAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.MapAssembler{"schemadmt.Unit.Repr"}.BeginList(0)
+}
+func (na *_Unit__ReprAssembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{"schemadmt.Unit.Repr.Repr"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_Unit__ReprAssembler) AssignBool(bool) error {
+	return mixins.MapAssembler{"schemadmt.Unit.Repr"}.AssignBool(false)
+}
+func (_Unit__ReprAssembler) AssignInt(int64) error {
+	return mixins.MapAssembler{"schemadmt.Unit.Repr"}.AssignInt(0)
+}
+func (_Unit__ReprAssembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{"schemadmt.Unit.Repr"}.AssignFloat(0)
+}
+func (_Unit__ReprAssembler) AssignString(string) error {
+	return mixins.MapAssembler{"schemadmt.Unit.Repr"}.AssignString("")
+}
+func (_Unit__ReprAssembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{"schemadmt.Unit.Repr"}.AssignBytes(nil)
+}
+func (_Unit__ReprAssembler) AssignLink(ipld.Link) error {
+	return mixins.MapAssembler{"schemadmt.Unit.Repr"}.AssignLink(nil)
+}
+func (na *_Unit__ReprAssembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	v2, ok := v.(*_Unit)
+	if v3, ok3 := v.(*_Unit__Repr); ok3 {
+		v2, ok = (*_Unit)(v3), true
+	}
+	if ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != ipld.Kind_Map {
+		return ipld.ErrWrongKind{TypeName: "schemadmt.Unit.Repr", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_Unit__ReprAssembler) Prototype() ipld.NodePrototype {
+	return _Unit__ReprPrototype{}
+}
+func (ma *_Unit__ReprAssembler) valueFinishTidy() bool {
+	switch ma.f {
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_Unit__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	return nil, ipld.ErrInvalidKey{TypeName: "schemadmt.Unit.Repr", Key: &_String{k}}
+}
+func (ma *_Unit__ReprAssembler) AssembleKey() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_Unit__ReprKeyAssembler)(ma)
+}
+func (ma *_Unit__ReprAssembler) AssembleValue() ipld.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_Unit__ReprAssembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__Unit_sufficient != fieldBits__Unit_sufficient {
+		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_Unit__ReprAssembler) KeyPrototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_Unit__ReprAssembler) ValuePrototype(k string) ipld.NodePrototype {
+	panic("todo structbuilder mapassembler repr valueprototype")
+}
+
+type _Unit__ReprKeyAssembler _Unit__ReprAssembler
+
+func (_Unit__ReprKeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.Unit.Repr.KeyAssembler"}.BeginMap(0)
+}
+func (_Unit__ReprKeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.Unit.Repr.KeyAssembler"}.BeginList(0)
+}
+func (na *_Unit__ReprKeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{"schemadmt.Unit.Repr.KeyAssembler"}.AssignNull()
+}
+func (_Unit__ReprKeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.Unit.Repr.KeyAssembler"}.AssignBool(false)
+}
+func (_Unit__ReprKeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.Unit.Repr.KeyAssembler"}.AssignInt(0)
+}
+func (_Unit__ReprKeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.Unit.Repr.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_Unit__ReprKeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	return ipld.ErrInvalidKey{TypeName: "schemadmt.Unit.Repr", Key: &_String{k}}
+}
+func (_Unit__ReprKeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.Unit.Repr.KeyAssembler"}.AssignBytes(nil)
+}
+func (_Unit__ReprKeyAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.Unit.Repr.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_Unit__ReprKeyAssembler) AssignNode(v ipld.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_Unit__ReprKeyAssembler) Prototype() ipld.NodePrototype {
+	return _String__Prototype{}
+}
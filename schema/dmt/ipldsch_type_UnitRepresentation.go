@@ -0,0 +1,418 @@
+package schemadmt
+
+// Code generated by go-ipld-prime gengo.  DO NOT EDIT.
+
+import (
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// UnitRepresentation matches the IPLD Schema type "UnitRepresentation".  It has string kind.
+type UnitRepresentation = *_UnitRepresentation
+type _UnitRepresentation struct{ x string }
+
+// String returns the value as a native string.
+func (n UnitRepresentation) String() string {
+	return n.x
+}
+func (_UnitRepresentation__Prototype) fromString(w *_UnitRepresentation, v string) error {
+	switch v {
+	case "Null", "Emptymap":
+		*w = _UnitRepresentation{v}
+		return nil
+	}
+	return schema.ErrInvalidEnumMember{TypeName: "schemadmt.UnitRepresentation", Value: v, Expected: []interface{}{"Null", "Emptymap"}}
+}
+func (_UnitRepresentation__Prototype) FromString(v string) (UnitRepresentation, error) {
+	var n _UnitRepresentation
+	if err := (_UnitRepresentation__Prototype{}).fromString(&n, v); err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+type _UnitRepresentation__Maybe struct {
+	m schema.Maybe
+	v UnitRepresentation
+}
+type MaybeUnitRepresentation = *_UnitRepresentation__Maybe
+
+func (m MaybeUnitRepresentation) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeUnitRepresentation) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeUnitRepresentation) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeUnitRepresentation) AsNode() ipld.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return ipld.Absent
+	case schema.Maybe_Null:
+		return ipld.Null
+	case schema.Maybe_Value:
+		return m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybeUnitRepresentation) Must() UnitRepresentation {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return m.v
+}
+
+var _ ipld.Node = (UnitRepresentation)(&_UnitRepresentation{})
+var _ schema.TypedNode = (UnitRepresentation)(&_UnitRepresentation{})
+
+func (UnitRepresentation) Kind() ipld.Kind {
+	return ipld.Kind_String
+}
+func (UnitRepresentation) LookupByString(string) (ipld.Node, error) {
+	return mixins.String{"schemadmt.UnitRepresentation"}.LookupByString("")
+}
+func (UnitRepresentation) LookupByNode(ipld.Node) (ipld.Node, error) {
+	return mixins.String{"schemadmt.UnitRepresentation"}.LookupByNode(nil)
+}
+func (UnitRepresentation) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.String{"schemadmt.UnitRepresentation"}.LookupByIndex(0)
+}
+func (UnitRepresentation) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return mixins.String{"schemadmt.UnitRepresentation"}.LookupBySegment(seg)
+}
+func (UnitRepresentation) MapIterator() ipld.MapIterator {
+	return nil
+}
+func (UnitRepresentation) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (UnitRepresentation) Length() int64 {
+	return -1
+}
+func (UnitRepresentation) IsAbsent() bool {
+	return false
+}
+func (UnitRepresentation) IsNull() bool {
+	return false
+}
+func (UnitRepresentation) AsBool() (bool, error) {
+	return mixins.String{"schemadmt.UnitRepresentation"}.AsBool()
+}
+func (UnitRepresentation) AsInt() (int64, error) {
+	return mixins.String{"schemadmt.UnitRepresentation"}.AsInt()
+}
+func (UnitRepresentation) AsFloat() (float64, error) {
+	return mixins.String{"schemadmt.UnitRepresentation"}.AsFloat()
+}
+func (n UnitRepresentation) AsString() (string, error) {
+	return n.x, nil
+}
+func (UnitRepresentation) AsBytes() ([]byte, error) {
+	return mixins.String{"schemadmt.UnitRepresentation"}.AsBytes()
+}
+func (UnitRepresentation) AsLink() (ipld.Link, error) {
+	return mixins.String{"schemadmt.UnitRepresentation"}.AsLink()
+}
+func (UnitRepresentation) Prototype() ipld.NodePrototype {
+	return _UnitRepresentation__Prototype{}
+}
+
+type _UnitRepresentation__Prototype struct{}
+
+func (_UnitRepresentation__Prototype) NewBuilder() ipld.NodeBuilder {
+	var nb _UnitRepresentation__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _UnitRepresentation__Builder struct {
+	_UnitRepresentation__Assembler
+}
+
+func (nb *_UnitRepresentation__Builder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_UnitRepresentation__Builder) Reset() {
+	var w _UnitRepresentation
+	var m schema.Maybe
+	*nb = _UnitRepresentation__Builder{_UnitRepresentation__Assembler{w: &w, m: &m}}
+}
+
+type _UnitRepresentation__Assembler struct {
+	w *_UnitRepresentation
+	m *schema.Maybe
+}
+
+func (na *_UnitRepresentation__Assembler) reset() {}
+func (_UnitRepresentation__Assembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.UnitRepresentation"}.BeginMap(0)
+}
+func (_UnitRepresentation__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.UnitRepresentation"}.BeginList(0)
+}
+func (na *_UnitRepresentation__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.StringAssembler{"schemadmt.UnitRepresentation"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	}
+	panic("unreachable")
+}
+func (_UnitRepresentation__Assembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.UnitRepresentation"}.AssignBool(false)
+}
+func (_UnitRepresentation__Assembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.UnitRepresentation"}.AssignInt(0)
+}
+func (_UnitRepresentation__Assembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.UnitRepresentation"}.AssignFloat(0)
+}
+func (na *_UnitRepresentation__Assembler) AssignString(v string) error {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	}
+	if na.w == nil {
+		na.w = &_UnitRepresentation{}
+	}
+	if err := (_UnitRepresentation__Prototype{}).fromString(na.w, v); err != nil {
+		return err
+	}
+	*na.m = schema.Maybe_Value
+	return nil
+}
+func (_UnitRepresentation__Assembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.UnitRepresentation"}.AssignBytes(nil)
+}
+func (_UnitRepresentation__Assembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.UnitRepresentation"}.AssignLink(nil)
+}
+func (na *_UnitRepresentation__Assembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_UnitRepresentation); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return na.AssignString(v2)
+	}
+}
+func (_UnitRepresentation__Assembler) Prototype() ipld.NodePrototype {
+	return _UnitRepresentation__Prototype{}
+}
+func (UnitRepresentation) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (n UnitRepresentation) Representation() ipld.Node {
+	return (*_UnitRepresentation__Repr)(n)
+}
+
+type _UnitRepresentation__Repr _UnitRepresentation
+
+var _ ipld.Node = &_UnitRepresentation__Repr{}
+
+func (_UnitRepresentation__Repr) Kind() ipld.Kind {
+	return ipld.Kind_String
+}
+func (_UnitRepresentation__Repr) LookupByString(string) (ipld.Node, error) {
+	return mixins.String{"schemadmt.UnitRepresentation.Repr"}.LookupByString("")
+}
+func (_UnitRepresentation__Repr) LookupByNode(ipld.Node) (ipld.Node, error) {
+	return mixins.String{"schemadmt.UnitRepresentation.Repr"}.LookupByNode(nil)
+}
+func (_UnitRepresentation__Repr) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.String{"schemadmt.UnitRepresentation.Repr"}.LookupByIndex(0)
+}
+func (_UnitRepresentation__Repr) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return mixins.String{"schemadmt.UnitRepresentation.Repr"}.LookupBySegment(seg)
+}
+func (_UnitRepresentation__Repr) MapIterator() ipld.MapIterator {
+	return nil
+}
+func (_UnitRepresentation__Repr) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (_UnitRepresentation__Repr) Length() int64 {
+	return -1
+}
+func (_UnitRepresentation__Repr) IsAbsent() bool {
+	return false
+}
+func (_UnitRepresentation__Repr) IsNull() bool {
+	return false
+}
+func (_UnitRepresentation__Repr) AsBool() (bool, error) {
+	return mixins.String{"schemadmt.UnitRepresentation.Repr"}.AsBool()
+}
+func (_UnitRepresentation__Repr) AsInt() (int64, error) {
+	return mixins.String{"schemadmt.UnitRepresentation.Repr"}.AsInt()
+}
+func (_UnitRepresentation__Repr) AsFloat() (float64, error) {
+	return mixins.String{"schemadmt.UnitRepresentation.Repr"}.AsFloat()
+}
+func (n *_UnitRepresentation__Repr) AsString() (string, error) {
+	return n.String(), nil
+}
+func (n *_UnitRepresentation__Repr) String() string {
+	switch n.x {
+	case "Null":
+		return "null"
+	case "Emptymap":
+		return "emptymap"
+	}
+	return n.x
+}
+func (_UnitRepresentation__Repr) AsBytes() ([]byte, error) {
+	return mixins.String{"schemadmt.UnitRepresentation.Repr"}.AsBytes()
+}
+func (_UnitRepresentation__Repr) AsLink() (ipld.Link, error) {
+	return mixins.String{"schemadmt.UnitRepresentation.Repr"}.AsLink()
+}
+func (_UnitRepresentation__Repr) Prototype() ipld.NodePrototype {
+	return _UnitRepresentation__ReprPrototype{}
+}
+
+type _UnitRepresentation__ReprPrototype struct{}
+
+func (_UnitRepresentation__ReprPrototype) NewBuilder() ipld.NodeBuilder {
+	var nb _UnitRepresentation__ReprBuilder
+	nb.Reset()
+	return &nb
+}
+
+type _UnitRepresentation__ReprBuilder struct {
+	_UnitRepresentation__ReprAssembler
+}
+
+func (nb *_UnitRepresentation__ReprBuilder) Build() ipld.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_UnitRepresentation__ReprBuilder) Reset() {
+	var w _UnitRepresentation
+	var m schema.Maybe
+	*nb = _UnitRepresentation__ReprBuilder{_UnitRepresentation__ReprAssembler{w: &w, m: &m}}
+}
+func (_UnitRepresentation__ReprPrototype) fromString(w *_UnitRepresentation, v string) error {
+	switch v {
+	case "null":
+		*w = _UnitRepresentation{"Null"}
+		return nil
+	case "emptymap":
+		*w = _UnitRepresentation{"Emptymap"}
+		return nil
+	}
+	return schema.ErrInvalidEnumMember{TypeName: "schemadmt.UnitRepresentation.Repr", Value: v, Expected: []interface{}{"null", "emptymap"}}
+}
+
+type _UnitRepresentation__ReprAssembler struct {
+	w *_UnitRepresentation
+	m *schema.Maybe
+}
+
+func (na *_UnitRepresentation__ReprAssembler) reset() {}
+func (_UnitRepresentation__ReprAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.UnitRepresentation.Repr"}.BeginMap(0)
+}
+func (_UnitRepresentation__ReprAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.StringAssembler{"schemadmt.UnitRepresentation.Repr"}.BeginList(0)
+}
+func (na *_UnitRepresentation__ReprAssembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.StringAssembler{"schemadmt.UnitRepresentation.Repr.Repr"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	}
+	panic("unreachable")
+}
+func (_UnitRepresentation__ReprAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{"schemadmt.UnitRepresentation.Repr"}.AssignBool(false)
+}
+func (_UnitRepresentation__ReprAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{"schemadmt.UnitRepresentation.Repr"}.AssignInt(0)
+}
+func (_UnitRepresentation__ReprAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{"schemadmt.UnitRepresentation.Repr"}.AssignFloat(0)
+}
+func (na *_UnitRepresentation__ReprAssembler) AssignString(v string) error {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	}
+	if na.w == nil {
+		na.w = &_UnitRepresentation{}
+	}
+	if err := (_UnitRepresentation__ReprPrototype{}).fromString(na.w, v); err != nil {
+		return err
+	}
+	*na.m = schema.Maybe_Value
+	return nil
+}
+func (_UnitRepresentation__ReprAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{"schemadmt.UnitRepresentation.Repr"}.AssignBytes(nil)
+}
+func (_UnitRepresentation__ReprAssembler) AssignLink(ipld.Link) error {
+	return mixins.StringAssembler{"schemadmt.UnitRepresentation.Repr"}.AssignLink(nil)
+}
+func (na *_UnitRepresentation__ReprAssembler) AssignNode(v ipld.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	v2, ok := v.(*_UnitRepresentation)
+	if v3, ok3 := v.(*_UnitRepresentation__Repr); ok3 {
+		v2, ok = (*_UnitRepresentation)(v3), true
+	}
+	if ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return na.AssignString(v2)
+	}
+}
+func (_UnitRepresentation__ReprAssembler) Prototype() ipld.NodePrototype {
+	return _UnitRepresentation__ReprPrototype{}
+}
@@ -24,14 +24,9 @@ import (
 //  - enums are specified using the 'Unit' type (which means serially, they have `{}` instead of `null`).
 //  - a few naming changes, which are minor and nonsemantic.
 //
-// There's also a few accumulated changes which are working around incomplete
-// features of our own tooling here, and are bugs that should be fixed (definitely not upstreamed):
-//
-//  - many field definitions have a `"optional": false, "nullable": false`
-//    explicitly stated, where it should be sufficient to leave these implicit.
-//    (These are avoiding our current lack of support for implicits.)
-//  - similarly, many map definitions have an `"valueNullable": false`
-//    explicitly stated, where it should be sufficient to leave these implicit.
+// Many field definitions below have a `"optional": false, "nullable": false`
+// explicitly stated, and many map definitions have an `"valueNullable": false`.
+// These could be left out, since they're implicit, but stating them is fine too.
 //
 func TestSchemaSchemaParse(t *testing.T) {
 	nb := schemadmt.Type.Schema__Repr.NewBuilder()
@@ -630,12 +625,14 @@ func TestSchemaSchemaParse(t *testing.T) {
 			"union": {
 				"members": [
 					"TypeMap",
-					"TypeList"
+					"TypeList",
+					"TypeLink"
 				],
 				"representation": {
 					"keyed": {
 						"map": "TypeMap",
-						"list": "TypeList"
+						"list": "TypeList",
+						"link": "TypeLink"
 					}
 				}
 			}
@@ -32,6 +32,11 @@ func (x TypeDefnInline) TypeReference() TypeReference {
 			return TypeReference(fmt.Sprintf("[nullable %s]", y.FieldValueType().TypeReference()))
 		}
 		return TypeReference(fmt.Sprintf("[%s]", y.FieldValueType().TypeReference()))
+	case TypeLink:
+		if y.FieldExpectedType().Exists() {
+			return TypeReference(fmt.Sprintf("&%s", y.FieldExpectedType().Must()))
+		}
+		return "&Any"
 	default:
 		panic("unreachable")
 	}
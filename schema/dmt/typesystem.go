@@ -0,0 +1,963 @@
+package schemadmt
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec/dagjson"
+	"github.com/ipld/go-ipld-prime/fluent"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// This file converts between schema.TypeSystem and its data model form,
+// the Schema type described by the schema-schema,
+// so that schemas can be serialized, hashed, and stored like any other data.
+//
+// Not everything either side can describe can be converted to the other yet:
+// schema.TypeSystem can't hold copy types, implicit values other than strings,
+// ints, and bools, or several of the representation strategies;
+// and the schema-schema has nowhere to put the delimiter of a stringprefix
+// union.  These are rejected with an error.
+
+// representationKinds are the names of kinds in kinded union representations.
+var representationKinds = []struct {
+	name string
+	kind ipld.Kind
+}{
+	{"bool", ipld.Kind_Bool},
+	{"int", ipld.Kind_Int},
+	{"float", ipld.Kind_Float},
+	{"string", ipld.Kind_String},
+	{"bytes", ipld.Kind_Bytes},
+	{"list", ipld.Kind_List},
+	{"map", ipld.Kind_Map},
+	{"link", ipld.Kind_Link},
+}
+
+// SchemaFromTypeSystem returns the data model form of a TypeSystem.
+// Types are listed in order of their names, and struct fields and enum
+// members in their order in the TypeSystem; so the result is deterministic,
+// and suitable for hashing.
+func SchemaFromTypeSystem(ts *schema.TypeSystem) (Schema, error) {
+	if errs := ts.ValidateGraph(); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid type system: %v", errs[0])
+	}
+	types := ts.GetTypes()
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	n, err := fluent.BuildMap(Type.Schema__Repr, 1, func(ma fluent.MapAssembler) {
+		ma.AssembleEntry("types").CreateMap(int64(len(names)), func(ma fluent.MapAssembler) {
+			for _, name := range names {
+				typ := types[schema.TypeName(name)]
+				ma.AssembleEntry(name).CreateMap(1, func(ma fluent.MapAssembler) {
+					assembleTypeDefn(ma, typ)
+				})
+			}
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return n.(Schema), nil
+}
+
+// Hash returns a hash of the data model form of a TypeSystem,
+// encoded as dag-json, in the form "sha256:{hex}".
+// Since the data model form is deterministic, so is the hash,
+// and it doesn't depend on how the schema was written down.
+func Hash(ts *schema.TypeSystem) (string, error) {
+	s, err := SchemaFromTypeSystem(ts)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	if err := dagjson.Encode(s.Representation(), h); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("sha256:%x", h.Sum(nil)), nil
+}
+
+// unsupported aborts assembling a Schema; fluent.BuildMap returns the error.
+func unsupported(typ schema.Type, format string, args ...interface{}) {
+	panic(fluent.Error{Err: fmt.Errorf("type %s: %s", typ.Name(), fmt.Sprintf(format, args...))})
+}
+
+func assembleTypeDefn(ma fluent.MapAssembler, typ schema.Type) {
+	empty := func(ma fluent.MapAssembler) {}
+	switch t := typ.(type) {
+	case *schema.TypeBool:
+		ma.AssembleEntry("bool").CreateMap(0, empty)
+	case *schema.TypeString:
+		ma.AssembleEntry("string").CreateMap(0, empty)
+	case *schema.TypeBytes:
+		ma.AssembleEntry("bytes").CreateMap(0, empty)
+	case *schema.TypeInt:
+		ma.AssembleEntry("int").CreateMap(0, empty)
+	case *schema.TypeFloat:
+		ma.AssembleEntry("float").CreateMap(0, empty)
+	case *schema.TypeLink:
+		ma.AssembleEntry("link").CreateMap(1, func(ma fluent.MapAssembler) {
+			if !t.HasReferencedType() {
+				return
+			}
+			target := t.ReferencedType()
+			if target == nil {
+				unsupported(t, "link refers to a type not in the type system")
+			}
+			ma.AssembleEntry("expectedType").AssignString(string(target.Name()))
+		})
+	case *schema.TypeMap:
+		ma.AssembleEntry("map").CreateMap(4, func(ma fluent.MapAssembler) {
+			ma.AssembleEntry("keyType").AssignString(string(t.KeyType().Name()))
+			ma.AssembleEntry("valueType").AssignString(string(t.ValueType().Name()))
+			ma.AssembleEntry("valueNullable").AssignBool(t.ValueIsNullable())
+			ma.AssembleEntry("representation").CreateMap(1, func(ma fluent.MapAssembler) {
+				switch r := t.RepresentationStrategy().(type) {
+				case schema.MapRepresentation_Map:
+					ma.AssembleEntry("map").CreateMap(0, empty)
+				case schema.MapRepresentation_ListPairs:
+					ma.AssembleEntry("listpairs").CreateMap(0, empty)
+				default:
+					unsupported(t, "unsupported map representation %T", r)
+				}
+			})
+		})
+	case *schema.TypeList:
+		ma.AssembleEntry("list").CreateMap(3, func(ma fluent.MapAssembler) {
+			ma.AssembleEntry("valueType").AssignString(string(t.ValueType().Name()))
+			ma.AssembleEntry("valueNullable").AssignBool(t.ValueIsNullable())
+			ma.AssembleEntry("representation").CreateMap(1, func(ma fluent.MapAssembler) {
+				ma.AssembleEntry("list").CreateMap(0, empty)
+			})
+		})
+	case *schema.TypeStruct:
+		assembleStruct(ma, t)
+	case *schema.TypeUnion:
+		assembleUnion(ma, t)
+	case *schema.TypeUnit:
+		ma.AssembleEntry("unit").CreateMap(1, func(ma fluent.MapAssembler) {
+			var repr string
+			switch t.RepresentationStrategy().(type) {
+			case schema.UnitRepresentation_Null:
+				repr = "null"
+			case schema.UnitRepresentation_EmptyMap:
+				repr = "emptymap"
+			default:
+				unsupported(t, "unknown unit representation %T", t.RepresentationStrategy())
+			}
+			ma.AssembleEntry("representation").AssignString(repr)
+		})
+	case *schema.TypeAny:
+		ma.AssembleEntry("any").CreateMap(0, empty)
+	case *schema.TypeEnum:
+		ma.AssembleEntry("enum").CreateMap(2, func(ma fluent.MapAssembler) {
+			members := t.Members()
+			ma.AssembleEntry("members").CreateMap(int64(len(members)), func(ma fluent.MapAssembler) {
+				for _, m := range members {
+					ma.AssembleEntry(m).CreateMap(0, empty)
+				}
+			})
+			ma.AssembleEntry("representation").CreateMap(1, func(ma fluent.MapAssembler) {
+				switch r := t.RepresentationStrategy().(type) {
+				case schema.EnumRepresentation_String:
+					var renamed []string
+					for _, m := range members {
+						if r.MemberHasRename(m) {
+							renamed = append(renamed, m)
+						}
+					}
+					ma.AssembleEntry("string").CreateMap(int64(len(renamed)), func(ma fluent.MapAssembler) {
+						for _, m := range renamed {
+							ma.AssembleEntry(m).AssignString(r.GetSerial(m))
+						}
+					})
+				case schema.EnumRepresentation_Int:
+					ma.AssembleEntry("int").CreateMap(int64(len(members)), func(ma fluent.MapAssembler) {
+						for _, m := range members {
+							i, _ := r.GetSerial(m)
+							ma.AssembleEntry(m).AssignInt(int64(i))
+						}
+					})
+				}
+			})
+		})
+	default:
+		panic("unreachable")
+	}
+}
+
+func assembleStruct(ma fluent.MapAssembler, t *schema.TypeStruct) {
+	fields := t.Fields()
+	ma.AssembleEntry("struct").CreateMap(2, func(ma fluent.MapAssembler) {
+		ma.AssembleEntry("fields").CreateMap(int64(len(fields)), func(ma fluent.MapAssembler) {
+			for _, f := range fields {
+				ma.AssembleEntry(f.Name()).CreateMap(3, func(ma fluent.MapAssembler) {
+					ma.AssembleEntry("type").AssignString(string(f.Type().Name()))
+					ma.AssembleEntry("optional").AssignBool(f.IsOptional())
+					ma.AssembleEntry("nullable").AssignBool(f.IsNullable())
+				})
+			}
+		})
+		ma.AssembleEntry("representation").CreateMap(1, func(ma fluent.MapAssembler) {
+			switch r := t.RepresentationStrategy().(type) {
+			case schema.StructRepresentation_Map:
+				ma.AssembleEntry("map").CreateMap(1, func(ma fluent.MapAssembler) {
+					var detailed []schema.StructField
+					for _, f := range fields {
+						if r.FieldHasRename(f) || r.FieldHasImplicit(f) {
+							detailed = append(detailed, f)
+						}
+					}
+					if len(detailed) == 0 {
+						return
+					}
+					ma.AssembleEntry("fields").CreateMap(int64(len(detailed)), func(ma fluent.MapAssembler) {
+						for _, f := range detailed {
+							ma.AssembleEntry(f.Name()).CreateMap(2, func(ma fluent.MapAssembler) {
+								if r.FieldHasRename(f) {
+									ma.AssembleEntry("rename").AssignString(r.GetFieldKey(f))
+								}
+								switch iv := r.GetImplicit(f).(type) {
+								case schema.ImplicitValue_String:
+									ma.AssembleEntry("implicit").AssignString(iv.String())
+								case schema.ImplicitValue_Int:
+									ma.AssembleEntry("implicit").AssignInt(int64(iv.Int()))
+								case schema.ImplicitValue_Bool:
+									ma.AssembleEntry("implicit").AssignBool(iv.Bool())
+								}
+							})
+						}
+					})
+				})
+			case schema.StructRepresentation_Tuple:
+				ma.AssembleEntry("tuple").CreateMap(0, func(ma fluent.MapAssembler) {})
+			case schema.StructRepresentation_Stringjoin:
+				ma.AssembleEntry("stringjoin").CreateMap(1, func(ma fluent.MapAssembler) {
+					ma.AssembleEntry("join").AssignString(r.GetDelim())
+				})
+			case schema.StructRepresentation_ListPairs:
+				ma.AssembleEntry("listpairs").CreateMap(0, func(ma fluent.MapAssembler) {})
+			case schema.StructRepresentation_StringPairs:
+				ma.AssembleEntry("stringpairs").CreateMap(2, func(ma fluent.MapAssembler) {
+					ma.AssembleEntry("innerDelim").AssignString(r.GetInnerDelim())
+					ma.AssembleEntry("entryDelim").AssignString(r.GetEntryDelim())
+				})
+			default:
+				unsupported(t, "unsupported struct representation %T", r)
+			}
+		})
+	})
+}
+
+func assembleUnion(ma fluent.MapAssembler, t *schema.TypeUnion) {
+	members := t.Members()
+	ma.AssembleEntry("union").CreateMap(2, func(ma fluent.MapAssembler) {
+		ma.AssembleEntry("members").CreateList(int64(len(members)), func(la fluent.ListAssembler) {
+			for _, m := range members {
+				la.AssembleValue().AssignString(string(m.Name()))
+			}
+		})
+		ma.AssembleEntry("representation").CreateMap(1, func(ma fluent.MapAssembler) {
+			switch r := t.RepresentationStrategy().(type) {
+			case schema.UnionRepresentation_Keyed:
+				ma.AssembleEntry("keyed").CreateMap(int64(len(members)), func(ma fluent.MapAssembler) {
+					for _, m := range members {
+						ma.AssembleEntry(r.GetDiscriminant(m)).AssignString(string(m.Name()))
+					}
+				})
+			case schema.UnionRepresentation_Kinded:
+				ma.AssembleEntry("kinded").CreateMap(int64(len(members)), func(ma fluent.MapAssembler) {
+					for _, rk := range representationKinds {
+						if m := r.GetMember(rk.kind); m != "" {
+							ma.AssembleEntry(rk.name).AssignString(string(m))
+						}
+					}
+				})
+			case schema.UnionRepresentation_Envelope:
+				ma.AssembleEntry("envelope").CreateMap(3, func(ma fluent.MapAssembler) {
+					ma.AssembleEntry("discriminantKey").AssignString(r.GetDiscriminantKey())
+					ma.AssembleEntry("contentKey").AssignString(r.GetContentKey())
+					ma.AssembleEntry("discriminantTable").CreateMap(int64(len(members)), func(ma fluent.MapAssembler) {
+						for _, m := range members {
+							ma.AssembleEntry(r.GetDiscriminant(m)).AssignString(string(m.Name()))
+						}
+					})
+				})
+			case schema.UnionRepresentation_Inline:
+				ma.AssembleEntry("inline").CreateMap(2, func(ma fluent.MapAssembler) {
+					ma.AssembleEntry("discriminantKey").AssignString(r.GetDiscriminantKey())
+					ma.AssembleEntry("discriminantTable").CreateMap(int64(len(members)), func(ma fluent.MapAssembler) {
+						for _, m := range members {
+							ma.AssembleEntry(r.GetDiscriminant(m)).AssignString(string(m.Name()))
+						}
+					})
+				})
+			case schema.UnionRepresentation_Stringprefix:
+				unsupported(t, "stringprefix unions can't be described by the schema-schema, which has no delimiter")
+			default:
+				unsupported(t, "unsupported union representation %T", r)
+			}
+		})
+	})
+}
+
+// Compile returns the TypeSystem described by a Schema.
+//
+// Inline type definitions are added as types of their own, named after
+// their contents in the same way as the schema parser does,
+// such as "List__String", "Map__String__nullableInt", or "Link__Foo".
+// Types from the prelude, such as String, are added if they're used but
+// not defined.
+func Compile(s Schema) (*schema.TypeSystem, error) {
+	c := &compiler{defined: make(map[schema.TypeName]bool)}
+	types, err := lookup(s.Representation(), "types")
+	if err != nil {
+		return nil, err
+	}
+	for itr := types.MapIterator(); !itr.Done(); {
+		k, v, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		name, err := k.AsString()
+		if err != nil {
+			return nil, err
+		}
+		typ, err := c.compileTypeDefn(schema.TypeName(name), v)
+		if err != nil {
+			return nil, fmt.Errorf("type %s: %w", name, err)
+		}
+		c.add(typ)
+	}
+	for _, name := range c.referenced {
+		if spawn, ok := prelude[name]; ok && !c.defined[name] {
+			c.add(spawn(name))
+		}
+	}
+	ts := &schema.TypeSystem{}
+	ts.Init()
+	for _, typ := range c.types {
+		ts.Accumulate(typ)
+	}
+	if errs := ts.ValidateGraph(); len(errs) > 0 {
+		return nil, errs[0]
+	}
+	return ts, nil
+}
+
+// prelude holds the types which schemas may use without defining them.
+var prelude = map[schema.TypeName]func(schema.TypeName) schema.Type{
+	"Bool":   func(n schema.TypeName) schema.Type { return schema.SpawnBool(n) },
+	"String": func(n schema.TypeName) schema.Type { return schema.SpawnString(n) },
+	"Bytes":  func(n schema.TypeName) schema.Type { return schema.SpawnBytes(n) },
+	"Int":    func(n schema.TypeName) schema.Type { return schema.SpawnInt(n) },
+	"Float":  func(n schema.TypeName) schema.Type { return schema.SpawnFloat(n) },
+	"Link":   func(n schema.TypeName) schema.Type { return schema.SpawnLink(n) },
+	"Any":    func(n schema.TypeName) schema.Type { return schema.SpawnAny(n) },
+}
+
+// compiler gathers the types of a TypeSystem from the representation nodes
+// of a Schema.
+type compiler struct {
+	types      []schema.Type
+	defined    map[schema.TypeName]bool
+	referenced []schema.TypeName
+}
+
+// add adds a type, unless one of the same name has already been added;
+// inline definitions may well be repeated.
+func (c *compiler) add(typ schema.Type) {
+	if c.defined[typ.Name()] {
+		return
+	}
+	c.defined[typ.Name()] = true
+	c.types = append(c.types, typ)
+}
+
+// typeName reads a TypeName, noting it in case it's from the prelude.
+func (c *compiler) typeName(n ipld.Node) (schema.TypeName, error) {
+	s, err := n.AsString()
+	if err != nil {
+		return "", err
+	}
+	c.referenced = append(c.referenced, schema.TypeName(s))
+	return schema.TypeName(s), nil
+}
+
+// typeRef reads a TypeNameOrInlineDefn, adding any inline definition.
+func (c *compiler) typeRef(n ipld.Node) (schema.TypeName, error) {
+	if n.Kind() == ipld.Kind_String {
+		return c.typeName(n)
+	}
+	kind, defn, err := keyed(n)
+	if err != nil {
+		return "", err
+	}
+	var typ schema.Type
+	switch kind {
+	case "list":
+		valueType, nullable, err := c.compileList(defn)
+		if err != nil {
+			return "", err
+		}
+		typ = schema.SpawnList("List__"+nullablePrefix(nullable)+valueType, valueType, nullable)
+	case "map":
+		keyType, valueType, nullable, repr, err := c.compileMap(defn)
+		if err != nil {
+			return "", err
+		}
+		if _, ok := repr.(schema.MapRepresentation_Map); !ok {
+			// The name would be the same as an inline map of the same types with another representation.
+			return "", fmt.Errorf("inline map definitions must use the map representation")
+		}
+		typ = schema.SpawnMap("Map__"+keyType+"__"+nullablePrefix(nullable)+valueType, keyType, valueType, nullable)
+	case "link":
+		expected, err := lookupOptional(defn, "expectedType")
+		if err != nil {
+			return "", err
+		}
+		if expected == nil {
+			typ = schema.SpawnLink("Link")
+			break
+		}
+		target, err := c.typeName(expected)
+		if err != nil {
+			return "", err
+		}
+		typ = schema.SpawnLinkReference("Link__"+target, target)
+	default:
+		return "", fmt.Errorf("unsupported inline definition %q", kind)
+	}
+	c.add(typ)
+	return typ.Name(), nil
+}
+
+func nullablePrefix(nullable bool) schema.TypeName {
+	if nullable {
+		return "nullable"
+	}
+	return ""
+}
+
+func (c *compiler) compileTypeDefn(name schema.TypeName, n ipld.Node) (schema.Type, error) {
+	kind, defn, err := keyed(n)
+	if err != nil {
+		return nil, err
+	}
+	switch kind {
+	case "bool":
+		return schema.SpawnBool(name), nil
+	case "string":
+		return schema.SpawnString(name), nil
+	case "bytes":
+		return schema.SpawnBytes(name), nil
+	case "int":
+		return schema.SpawnInt(name), nil
+	case "float":
+		return schema.SpawnFloat(name), nil
+	case "link":
+		expected, err := lookupOptional(defn, "expectedType")
+		if err != nil || expected == nil {
+			return schema.SpawnLink(name), err
+		}
+		target, err := c.typeName(expected)
+		if err != nil {
+			return nil, err
+		}
+		return schema.SpawnLinkReference(name, target), nil
+	case "map":
+		keyType, valueType, nullable, repr, err := c.compileMap(defn)
+		if err != nil {
+			return nil, err
+		}
+		return schema.SpawnMapWithRepresentation(name, keyType, valueType, nullable, repr), nil
+	case "list":
+		valueType, nullable, err := c.compileList(defn)
+		if err != nil {
+			return nil, err
+		}
+		return schema.SpawnList(name, valueType, nullable), nil
+	case "struct":
+		return c.compileStruct(name, defn)
+	case "union":
+		return c.compileUnion(name, defn)
+	case "enum":
+		return compileEnum(name, defn)
+	case "unit":
+		reprNode, err := lookup(defn, "representation")
+		if err != nil {
+			return nil, err
+		}
+		repr, err := reprNode.AsString()
+		if err != nil {
+			return nil, err
+		}
+		switch repr {
+		case "null":
+			return schema.SpawnUnit(name, schema.SpawnUnitRepresentationNull()), nil
+		case "emptymap":
+			return schema.SpawnUnit(name, schema.SpawnUnitRepresentationEmptyMap()), nil
+		default:
+			return nil, fmt.Errorf("unsupported unit representation %q", repr)
+		}
+	case "any":
+		return schema.SpawnAny(name), nil
+	default:
+		return nil, fmt.Errorf("unsupported type kind %q", kind)
+	}
+}
+
+func (c *compiler) compileMap(defn ipld.Node) (keyType, valueType schema.TypeName, nullable bool, repr schema.MapRepresentation, err error) {
+	if keyType, err = c.lookupTypeName(defn, "keyType"); err != nil {
+		return
+	}
+	if valueType, nullable, err = c.compileValueType(defn); err != nil {
+		return
+	}
+	reprNode, err := lookup(defn, "representation")
+	if err != nil {
+		return
+	}
+	strategy, _, err := keyed(reprNode)
+	if err != nil {
+		return
+	}
+	switch strategy {
+	case "map":
+		repr = schema.SpawnMapRepresentationMap()
+	case "listpairs":
+		repr = schema.SpawnMapRepresentationListPairs()
+	default:
+		err = fmt.Errorf("unsupported map representation %q", strategy)
+	}
+	return
+}
+
+func (c *compiler) compileList(defn ipld.Node) (valueType schema.TypeName, nullable bool, err error) {
+	if valueType, nullable, err = c.compileValueType(defn); err != nil {
+		return
+	}
+	err = expectRepresentation(defn, "list")
+	return
+}
+
+// compileValueType reads the valueType and valueNullable of a map or list.
+func (c *compiler) compileValueType(defn ipld.Node) (schema.TypeName, bool, error) {
+	n, err := lookup(defn, "valueType")
+	if err != nil {
+		return "", false, err
+	}
+	valueType, err := c.typeRef(n)
+	if err != nil {
+		return "", false, err
+	}
+	nullable, err := lookupBool(defn, "valueNullable")
+	return valueType, nullable, err
+}
+
+func (c *compiler) compileStruct(name schema.TypeName, defn ipld.Node) (schema.Type, error) {
+	fieldsNode, err := lookup(defn, "fields")
+	if err != nil {
+		return nil, err
+	}
+	var fields []schema.StructField
+	var fieldNames []string
+	for itr := fieldsNode.MapIterator(); !itr.Done(); {
+		k, v, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		fieldName, err := k.AsString()
+		if err != nil {
+			return nil, err
+		}
+		typNode, err := lookup(v, "type")
+		if err != nil {
+			return nil, err
+		}
+		typ, err := c.typeRef(typNode)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", fieldName, err)
+		}
+		optional, err := lookupBool(v, "optional")
+		if err != nil {
+			return nil, err
+		}
+		nullable, err := lookupBool(v, "nullable")
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, schema.SpawnStructField(fieldName, typ, optional, nullable))
+		fieldNames = append(fieldNames, fieldName)
+	}
+
+	reprNode, err := lookup(defn, "representation")
+	if err != nil {
+		return nil, err
+	}
+	strategy, details, err := keyed(reprNode)
+	if err != nil {
+		return nil, err
+	}
+	var repr schema.StructRepresentation
+	switch strategy {
+	case "map":
+		renames := make(map[string]string)
+		implicits := make(map[string]schema.ImplicitValue)
+		detailsMap, err := lookupOptional(details, "fields")
+		if err != nil {
+			return nil, err
+		}
+		if detailsMap != nil {
+			for itr := detailsMap.MapIterator(); !itr.Done(); {
+				k, v, err := itr.Next()
+				if err != nil {
+					return nil, err
+				}
+				fieldName, _ := k.AsString()
+				if implicit, err := lookupOptional(v, "implicit"); err != nil {
+					return nil, err
+				} else if implicit != nil {
+					if implicits[fieldName], err = implicitValue(implicit); err != nil {
+						return nil, fmt.Errorf("field %s: %w", fieldName, err)
+					}
+				}
+				if rename, err := lookupOptional(v, "rename"); err != nil {
+					return nil, err
+				} else if rename != nil {
+					if renames[fieldName], err = rename.AsString(); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+		repr = schema.SpawnStructRepresentationMapWithImplicits(renames, implicits)
+	case "tuple":
+		if err := expectFieldOrder(details, fieldNames); err != nil {
+			return nil, err
+		}
+		repr = schema.SpawnStructRepresentationTuple()
+	case "stringjoin":
+		join, err := lookup(details, "join")
+		if err != nil {
+			return nil, err
+		}
+		delim, err := join.AsString()
+		if err != nil {
+			return nil, err
+		}
+		if err := expectFieldOrder(details, fieldNames); err != nil {
+			return nil, err
+		}
+		repr = schema.SpawnStructRepresentationStringjoin(delim)
+	case "listpairs":
+		repr = schema.SpawnStructRepresentationListPairs()
+	case "stringpairs":
+		var delims [2]string
+		for i, key := range []string{"innerDelim", "entryDelim"} {
+			n, err := lookup(details, key)
+			if err != nil {
+				return nil, err
+			}
+			if delims[i], err = n.AsString(); err != nil {
+				return nil, err
+			}
+		}
+		for _, f := range fields {
+			if f.IsNullable() {
+				return nil, fmt.Errorf("field %s: nullable is not supported with the stringpairs representation", f.Name())
+			}
+		}
+		repr = schema.SpawnStructRepresentationStringPairs(delims[0], delims[1])
+	default:
+		return nil, fmt.Errorf("unsupported struct representation %q", strategy)
+	}
+	return schema.SpawnStruct(name, fields, repr), nil
+}
+
+// expectFieldOrder checks that a tuple or stringjoin struct's fieldOrder,
+// if present, is the order in which the fields are declared;
+// schema.TypeSystem has no way to express any other.
+func expectFieldOrder(details ipld.Node, fieldNames []string) error {
+	order, err := lookupOptional(details, "fieldOrder")
+	if err != nil || order == nil {
+		return err
+	}
+	if order.Length() != int64(len(fieldNames)) {
+		return fmt.Errorf("fieldOrder must list every field in declaration order")
+	}
+	for itr := order.ListIterator(); !itr.Done(); {
+		i, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if s, _ := v.AsString(); s != fieldNames[i] {
+			return fmt.Errorf("fieldOrder must list every field in declaration order")
+		}
+	}
+	return nil
+}
+
+func (c *compiler) compileUnion(name schema.TypeName, defn ipld.Node) (schema.Type, error) {
+	membersNode, err := lookup(defn, "members")
+	if err != nil {
+		return nil, err
+	}
+	var members []schema.TypeName
+	for itr := membersNode.ListIterator(); !itr.Done(); {
+		_, v, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		member, err := c.typeName(v)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+
+	reprNode, err := lookup(defn, "representation")
+	if err != nil {
+		return nil, err
+	}
+	strategy, details, err := keyed(reprNode)
+	if err != nil {
+		return nil, err
+	}
+	var repr schema.UnionRepresentation
+	switch strategy {
+	case "keyed":
+		table, err := c.discriminantTable(details)
+		if err != nil {
+			return nil, err
+		}
+		repr = schema.SpawnUnionRepresentationKeyed(table)
+	case "envelope":
+		discriminantKey, err := lookup(details, "discriminantKey")
+		if err != nil {
+			return nil, err
+		}
+		contentKey, err := lookup(details, "contentKey")
+		if err != nil {
+			return nil, err
+		}
+		tableNode, err := lookup(details, "discriminantTable")
+		if err != nil {
+			return nil, err
+		}
+		table, err := c.discriminantTable(tableNode)
+		if err != nil {
+			return nil, err
+		}
+		dk, _ := discriminantKey.AsString()
+		ck, _ := contentKey.AsString()
+		repr = schema.SpawnUnionRepresentationEnvelope(dk, ck, table)
+	case "inline":
+		discriminantKey, err := lookup(details, "discriminantKey")
+		if err != nil {
+			return nil, err
+		}
+		tableNode, err := lookup(details, "discriminantTable")
+		if err != nil {
+			return nil, err
+		}
+		table, err := c.discriminantTable(tableNode)
+		if err != nil {
+			return nil, err
+		}
+		dk, _ := discriminantKey.AsString()
+		repr = schema.SpawnUnionRepresentationInline(dk, table)
+	case "kinded":
+		table := make(map[ipld.Kind]schema.TypeName)
+		for itr := details.MapIterator(); !itr.Done(); {
+			k, v, err := itr.Next()
+			if err != nil {
+				return nil, err
+			}
+			kindName, _ := k.AsString()
+			kind, ok := kindNamed(kindName)
+			if !ok {
+				return nil, fmt.Errorf("invalid representation kind %q", kindName)
+			}
+			if table[kind], err = c.typeName(v); err != nil {
+				return nil, err
+			}
+		}
+		repr = schema.SpawnUnionRepresentationKinded(table)
+	default:
+		return nil, fmt.Errorf("unsupported union representation %q", strategy)
+	}
+	return schema.SpawnUnion(name, members, repr), nil
+}
+
+// discriminantTable reads a map from discriminants to member type names,
+// as used by keyed, envelope, and inline unions.
+func (c *compiler) discriminantTable(n ipld.Node) (map[string]schema.TypeName, error) {
+	table := make(map[string]schema.TypeName)
+	for itr := n.MapIterator(); !itr.Done(); {
+		k, v, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := k.AsString()
+		if table[key], err = c.typeName(v); err != nil {
+			return nil, err
+		}
+	}
+	return table, nil
+}
+
+func kindNamed(name string) (ipld.Kind, bool) {
+	for _, rk := range representationKinds {
+		if rk.name == name {
+			return rk.kind, true
+		}
+	}
+	return ipld.Kind_Invalid, false
+}
+
+func compileEnum(name schema.TypeName, defn ipld.Node) (schema.Type, error) {
+	membersNode, err := lookup(defn, "members")
+	if err != nil {
+		return nil, err
+	}
+	var members []string
+	for itr := membersNode.MapIterator(); !itr.Done(); {
+		k, _, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		member, _ := k.AsString()
+		members = append(members, member)
+	}
+
+	reprNode, err := lookup(defn, "representation")
+	if err != nil {
+		return nil, err
+	}
+	strategy, details, err := keyed(reprNode)
+	if err != nil {
+		return nil, err
+	}
+	switch strategy {
+	case "string":
+		table := make(map[string]string)
+		for itr := details.MapIterator(); !itr.Done(); {
+			k, v, err := itr.Next()
+			if err != nil {
+				return nil, err
+			}
+			member, _ := k.AsString()
+			table[member], _ = v.AsString()
+		}
+		return schema.SpawnEnumWithRepresentation(name, members, schema.SpawnEnumRepresentationString(table)), nil
+	case "int":
+		table := make(map[string]int)
+		for itr := details.MapIterator(); !itr.Done(); {
+			k, v, err := itr.Next()
+			if err != nil {
+				return nil, err
+			}
+			member, _ := k.AsString()
+			i, _ := v.AsInt()
+			table[member] = int(i)
+		}
+		return schema.SpawnEnumWithRepresentation(name, members, schema.SpawnEnumRepresentationInt(table)), nil
+	default:
+		return nil, fmt.Errorf("unsupported enum representation %q", strategy)
+	}
+}
+
+// implicitValue converts the implicit value of a struct field,
+// which the schema-schema allows to be any scalar.
+func implicitValue(n ipld.Node) (schema.ImplicitValue, error) {
+	switch n.Kind() {
+	case ipld.Kind_String:
+		s, err := n.AsString()
+		return schema.SpawnImplicitValueString(s), err
+	case ipld.Kind_Int:
+		i, err := n.AsInt()
+		return schema.SpawnImplicitValueInt(int(i)), err
+	case ipld.Kind_Bool:
+		b, err := n.AsBool()
+		return schema.SpawnImplicitValueBool(b), err
+	default:
+		return nil, fmt.Errorf("implicit values of kind %s are not supported", n.Kind())
+	}
+}
+
+// keyed returns the only entry of a map, such as a keyed union's
+// representation.
+func keyed(n ipld.Node) (string, ipld.Node, error) {
+	if n.Kind() != ipld.Kind_Map || n.Length() != 1 {
+		return "", nil, fmt.Errorf("expected a map with a single entry")
+	}
+	k, v, err := n.MapIterator().Next()
+	if err != nil {
+		return "", nil, err
+	}
+	s, err := k.AsString()
+	return s, v, err
+}
+
+func lookup(n ipld.Node, key string) (ipld.Node, error) {
+	v, err := lookupOptional(n, key)
+	if err == nil && v == nil {
+		err = fmt.Errorf("missing %q", key)
+	}
+	return v, err
+}
+
+// lookupOptional is like lookup, but returns a nil node if the key is absent.
+func lookupOptional(n ipld.Node, key string) (ipld.Node, error) {
+	v, err := n.LookupByString(key)
+	if _, ok := err.(ipld.ErrNotExists); ok {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if v.IsAbsent() {
+		return nil, nil
+	}
+	return v, nil
+}
+
+// lookupBool reads a boolean which has an implicit value of false,
+// so that it's left out of the representation when it's false.
+func lookupBool(n ipld.Node, key string) (bool, error) {
+	v, err := lookupOptional(n, key)
+	if err != nil || v == nil {
+		return false, err
+	}
+	return v.AsBool()
+}
+
+func (c *compiler) lookupTypeName(n ipld.Node, key string) (schema.TypeName, error) {
+	v, err := lookup(n, key)
+	if err != nil {
+		return "", err
+	}
+	return c.typeName(v)
+}
+
+// expectRepresentation checks that a map or list uses its plain
+// representation, the only one schema.TypeSystem supports for them.
+func expectRepresentation(defn ipld.Node, want string) error {
+	reprNode, err := lookup(defn, "representation")
+	if err != nil {
+		return err
+	}
+	strategy, _, err := keyed(reprNode)
+	if err != nil {
+		return err
+	}
+	if strategy != want {
+		return fmt.Errorf("unsupported %s representation %q", want, strategy)
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+package schemadmt_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime/codec/dagjson"
+	"github.com/ipld/go-ipld-prime/schema"
+	schemadmt "github.com/ipld/go-ipld-prime/schema/dmt"
+	schemaparser "github.com/ipld/go-ipld-prime/schema/parser"
+)
+
+func TestTypeSystemRoundTrip(t *testing.T) {
+	ts, err := schemaparser.Parse(strings.NewReader(`
+type Person struct {
+	name String
+	nick optional nullable String (rename "nickname")
+	friends [&Person]
+	tags {String:nullable Int}
+	mood Mood
+}
+
+type Mood enum {
+	| Happy
+	| Grumpy ("grumpy")
+}
+
+type Level enum {
+	| Low ("1")
+	| High ("10")
+} representation int
+
+type Pair struct {
+	left Int
+	right Int
+} representation tuple
+
+type Coords struct {
+	x String
+	y String
+} representation stringjoin {join ","}
+
+type Query struct {
+	q String
+	lang optional String
+} representation stringpairs {
+	innerDelim "="
+	entryDelim "&"
+}
+
+type Header struct {
+	name String
+	value optional String
+} representation listpairs
+
+type Headers {String:Header} representation listpairs
+
+type Shape union {
+	| Pair "pair"
+	| Coords "coords"
+} representation keyed
+
+type Value union {
+	| String string
+	| Int int
+	| Pair list
+} representation kinded
+
+type Message union {
+	| String "text"
+	| Pair "pair"
+} representation envelope {
+	discriminantKey "type"
+	contentKey "value"
+}
+
+type Pet struct {
+	name String
+	species String (implicit "cat")
+	lives Int (rename "l" implicit "9")
+	indoor Bool (implicit "true")
+}
+
+type Event union {
+	| Person "person"
+	| Pet "pet"
+} representation inline {
+	discriminantKey "kind"
+}
+
+type AnyLink &Any
+
+type Nothing unit representation null
+type Empty unit representation emptymap
+type Blob any
+`))
+	Require(t, err, ShouldEqual, nil)
+
+	s, err := schemadmt.SchemaFromTypeSystem(ts)
+	Require(t, err, ShouldEqual, nil)
+	var buf bytes.Buffer
+	Require(t, dagjson.Encode(s.Representation(), &buf), ShouldEqual, nil)
+	encoded := buf.String()
+
+	t.Run("encoding", func(t *testing.T) {
+		// dagjson indents its output; none of the strings here contain spaces.
+		compact := strings.Join(strings.Fields(encoded), "")
+		Wish(t, strings.Contains(compact, `"Mood":{"enum":{"members":{"Happy":{},"Grumpy":{}},"representation":{"string":{"Grumpy":"grumpy"}}}}`), ShouldEqual, true)
+		Wish(t, strings.Contains(compact, `"Level":{"enum":{"members":{"Low":{},"High":{}},"representation":{"int":{"Low":1,"High":10}}}}`), ShouldEqual, true)
+		Wish(t, strings.Contains(compact, `"representation":{"map":{"fields":{"nick":{"rename":"nickname"}}}}`), ShouldEqual, true)
+		Wish(t, strings.Contains(compact, `"representation":{"map":{"fields":{"species":{"implicit":"cat"},"lives":{"rename":"l","implicit":9},"indoor":{"implicit":true}}}}`), ShouldEqual, true)
+		Wish(t, strings.Contains(compact, `"AnyLink":{"link":{}}`), ShouldEqual, true)
+		Wish(t, strings.Contains(compact, `"representation":{"stringpairs":{"innerDelim":"=","entryDelim":"&"}}`), ShouldEqual, true)
+		// Booleans like valueNullable and optional are implicitly false, so they only show up when they're true.
+		Wish(t, strings.Contains(compact, `"Headers":{"map":{"keyType":"String","valueType":"Header","representation":{"listpairs":{}}}}`), ShouldEqual, true)
+		Wish(t, strings.Contains(compact, `"valueType":"Int","valueNullable":true,`), ShouldEqual, true)
+		Wish(t, strings.Contains(compact, `"name":{"type":"String"}`), ShouldEqual, true)
+		Wish(t, strings.Contains(compact, `"nick":{"type":"String","optional":true,"nullable":true}`), ShouldEqual, true)
+		Wish(t, strings.Contains(compact, `"representation":{"envelope":{"discriminantKey":"type","contentKey":"value","discriminantTable":{"text":"String","pair":"Pair"}}}`), ShouldEqual, true)
+		Wish(t, strings.Contains(compact, `"Link__Person":{"link":{"expectedType":"Person"}}`), ShouldEqual, true)
+		Wish(t, strings.Contains(compact, `"Nothing":{"unit":{"representation":"null"}}`), ShouldEqual, true)
+		Wish(t, strings.Contains(compact, `"Empty":{"unit":{"representation":"emptymap"}}`), ShouldEqual, true)
+		Wish(t, strings.Contains(compact, `"Blob":{"any":{}}`), ShouldEqual, true)
+	})
+	t.Run("decoding and compiling", func(t *testing.T) {
+		nb := schemadmt.Type.Schema__Repr.NewBuilder()
+		Require(t, dagjson.Decode(nb, strings.NewReader(encoded)), ShouldEqual, nil)
+		ts2, err := schemadmt.Compile(nb.Build().(schemadmt.Schema))
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, len(ts2.GetTypes()), ShouldEqual, len(ts.GetTypes()))
+
+		person := ts2.TypeByName("Person").(*schema.TypeStruct)
+		nick := person.Field("nick")
+		Wish(t, nick.IsOptional(), ShouldEqual, true)
+		Wish(t, nick.IsNullable(), ShouldEqual, true)
+		Wish(t, person.RepresentationStrategy().(schema.StructRepresentation_Map).GetFieldKey(*nick), ShouldEqual, "nickname")
+		Wish(t, ts2.TypeByName("Coords").(*schema.TypeStruct).RepresentationStrategy().(schema.StructRepresentation_Stringjoin).GetDelim(), ShouldEqual, ",")
+		query := ts2.TypeByName("Query").(*schema.TypeStruct).RepresentationStrategy().(schema.StructRepresentation_StringPairs)
+		Wish(t, query.GetInnerDelim(), ShouldEqual, "=")
+		Wish(t, query.GetEntryDelim(), ShouldEqual, "&")
+		Wish(t, ts2.TypeByName("Header").(*schema.TypeStruct).RepresentationStrategy(), ShouldEqual, schema.SpawnStructRepresentationListPairs())
+		Wish(t, ts2.TypeByName("Headers").(*schema.TypeMap).RepresentationStrategy(), ShouldEqual, schema.SpawnMapRepresentationListPairs())
+		message := ts2.TypeByName("Message").(*schema.TypeUnion).RepresentationStrategy().(schema.UnionRepresentation_Envelope)
+		Wish(t, message.GetDiscriminantKey(), ShouldEqual, "type")
+		Wish(t, message.GetContentKey(), ShouldEqual, "value")
+		mood := ts2.TypeByName("Mood").(*schema.TypeEnum).RepresentationStrategy().(schema.EnumRepresentation_String)
+		Wish(t, mood.GetSerial("Happy"), ShouldEqual, "Happy")
+		Wish(t, mood.GetSerial("Grumpy"), ShouldEqual, "grumpy")
+		Wish(t, ts2.TypeByName("Level").(*schema.TypeEnum).RepresentationStrategy(), ShouldEqual, schema.SpawnEnumRepresentationInt(map[string]int{"Low": 1, "High": 10}))
+		pet := ts2.TypeByName("Pet").(*schema.TypeStruct)
+		petRepr := pet.RepresentationStrategy().(schema.StructRepresentation_Map)
+		Wish(t, petRepr.GetImplicit(*pet.Field("species")), ShouldEqual, schema.SpawnImplicitValueString("cat"))
+		Wish(t, petRepr.GetImplicit(*pet.Field("lives")), ShouldEqual, schema.SpawnImplicitValueInt(9))
+		Wish(t, petRepr.GetFieldKey(*pet.Field("lives")), ShouldEqual, "l")
+		Wish(t, petRepr.GetImplicit(*pet.Field("indoor")), ShouldEqual, schema.SpawnImplicitValueBool(true))
+		event := ts2.TypeByName("Event").(*schema.TypeUnion).RepresentationStrategy().(schema.UnionRepresentation_Inline)
+		Wish(t, event.GetDiscriminantKey(), ShouldEqual, "kind")
+		Wish(t, ts2.TypeByName("Nothing").(*schema.TypeUnit).RepresentationStrategy(), ShouldEqual, schema.SpawnUnitRepresentationNull())
+		Wish(t, ts2.TypeByName("Empty").(*schema.TypeUnit).RepresentationStrategy(), ShouldEqual, schema.SpawnUnitRepresentationEmptyMap())
+		Wish(t, ts2.TypeByName("Blob").TypeKind(), ShouldEqual, schema.TypeKind_Any)
+
+		// Converting back again must give exactly the same document.
+		s2, err := schemadmt.SchemaFromTypeSystem(ts2)
+		Require(t, err, ShouldEqual, nil)
+		var buf2 bytes.Buffer
+		Require(t, dagjson.Encode(s2.Representation(), &buf2), ShouldEqual, nil)
+		Wish(t, buf2.String(), ShouldEqual, encoded)
+
+		// Which also means the same hash, even if the type system didn't come from the DSL.
+		hash, err := schemadmt.Hash(ts)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, strings.HasPrefix(hash, "sha256:"), ShouldEqual, true)
+		Wish(t, len(hash), ShouldEqual, len("sha256:")+64)
+		hash2, err := schemadmt.Hash(ts2)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, hash2, ShouldEqual, hash)
+
+		ts3, err := schemaparser.Parse(strings.NewReader(`type AnyLink &Any`))
+		Require(t, err, ShouldEqual, nil)
+		hash3, err := schemadmt.Hash(ts3)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, hash3 != hash, ShouldEqual, true)
+	})
+}
+
+func TestCompileInlineDefns(t *testing.T) {
+	nb := schemadmt.Type.Schema__Repr.NewBuilder()
+	Require(t, dagjson.Decode(nb, strings.NewReader(`{"types": {
+		"Doc": {"struct": {
+			"fields": {
+				"index": {"type": {"map": {
+					"keyType": "String",
+					"valueType": {"list": {"valueType": "Int", "valueNullable": true, "representation": {"list": {}}}},
+					"valueNullable": false,
+					"representation": {"map": {}}
+				}}, "optional": false, "nullable": false},
+				"parents": {"type": {"list": {
+					"valueType": {"link": {"expectedType": "Doc"}},
+					"valueNullable": false,
+					"representation": {"list": {}}
+				}}, "optional": false, "nullable": false}
+			},
+			"representation": {"map": {}}
+		}}
+	}}`)), ShouldEqual, nil)
+	ts, err := schemadmt.Compile(nb.Build().(schemadmt.Schema))
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, ts.TypeByName("Doc").(*schema.TypeStruct).Field("index").Type().Name(), ShouldEqual, schema.TypeName("Map__String__List__nullableInt"))
+	Wish(t, ts.TypeByName("List__nullableInt").(*schema.TypeList).ValueIsNullable(), ShouldEqual, true)
+	Wish(t, ts.TypeByName("Doc").(*schema.TypeStruct).Field("parents").Type().Name(), ShouldEqual, schema.TypeName("List__Link__Doc"))
+	Wish(t, ts.TypeByName("Link__Doc").(*schema.TypeLink).ReferencedType().Name(), ShouldEqual, schema.TypeName("Doc"))
+	Wish(t, ts.TypeByName("Int") != nil, ShouldEqual, true)
+	Wish(t, ts.TypeByName("String") != nil, ShouldEqual, true)
+}
+
+func TestUnsupported(t *testing.T) {
+	t.Run("stringprefix union to schema", func(t *testing.T) {
+		ts, err := schemaparser.Parse(strings.NewReader(`
+type Prefixed union {
+	| String "str"
+	| Int "int"
+} representation stringprefix {delim ":"}
+`))
+		Require(t, err, ShouldEqual, nil)
+		_, err = schemadmt.SchemaFromTypeSystem(ts)
+		Wish(t, err != nil, ShouldEqual, true)
+	})
+	t.Run("copy type to type system", func(t *testing.T) {
+		nb := schemadmt.Type.Schema__Repr.NewBuilder()
+		Require(t, dagjson.Decode(nb, strings.NewReader(`{"types": {
+			"Foo": {"string": {}},
+			"Bar": {"copy": {"fromType": "Foo"}}
+		}}`)), ShouldEqual, nil)
+		_, err := schemadmt.Compile(nb.Build().(schemadmt.Schema))
+		Wish(t, err.Error(), ShouldEqual, `type Bar: unsupported type kind "copy"`)
+	})
+	t.Run("incomplete int enum to type system", func(t *testing.T) {
+		nb := schemadmt.Type.Schema__Repr.NewBuilder()
+		Require(t, dagjson.Decode(nb, strings.NewReader(`{"types": {
+			"Foo": {"enum": {"members": {"A": {}, "B": {}}, "representation": {"int": {"A": 1}}}}
+		}}`)), ShouldEqual, nil)
+		_, err := schemadmt.Compile(nb.Build().(schemadmt.Schema))
+		Wish(t, err.Error(), ShouldEqual, `type Foo has member B, which has no int value in its representation`)
+	})
+	t.Run("float implicit to type system", func(t *testing.T) {
+		nb := schemadmt.Type.Schema__Repr.NewBuilder()
+		Require(t, dagjson.Decode(nb, strings.NewReader(`{"types": {
+			"Float": {"float": {}},
+			"Foo": {"struct": {
+				"fields": {"a": {"type": "Float", "optional": false, "nullable": false}},
+				"representation": {"map": {"fields": {"a": {"implicit": 1.5}}}}
+			}}
+		}}`)), ShouldEqual, nil)
+		_, err := schemadmt.Compile(nb.Build().(schemadmt.Schema))
+		Wish(t, err.Error(), ShouldEqual, `type Foo: field a: implicit values of kind float are not supported`)
+	})
+	t.Run("inline listpairs map to type system", func(t *testing.T) {
+		nb := schemadmt.Type.Schema__Repr.NewBuilder()
+		Require(t, dagjson.Decode(nb, strings.NewReader(`{"types": {
+			"Foo": {"list": {
+				"valueType": {"map": {"keyType": "String", "valueType": "Int", "valueNullable": false, "representation": {"listpairs": {}}}},
+				"valueNullable": false,
+				"representation": {"list": {}}
+			}}
+		}}`)), ShouldEqual, nil)
+		_, err := schemadmt.Compile(nb.Build().(schemadmt.Schema))
+		Wish(t, err.Error(), ShouldEqual, `type Foo: inline map definitions must use the map representation`)
+	})
+}
@@ -2,6 +2,8 @@ package schema
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/ipld/go-ipld-prime"
 )
@@ -60,3 +62,65 @@ type ErrNotUnionStructure struct {
 func (e ErrNotUnionStructure) Error() string {
 	return fmt.Sprintf("cannot match schema: union structure constraints for %s caused rejection: %s", e.TypeName, e.Detail)
 }
+
+// ErrInvalidData is returned by Validate for each place in which data doesn't
+// match the schema type expected there.
+//
+// Path is the path to the offending node from the root of the validated data,
+// and TypeName the type which that node was expected to match.
+// Paths follow the representation of the data, as it's serialized;
+// so a tuple struct's fields are addressed by index, for example.
+type ErrInvalidData struct {
+	Path     ipld.Path
+	TypeName TypeName
+
+	Detail string
+}
+
+func (e ErrInvalidData) Error() string {
+	return fmt.Sprintf("invalid data at %q: does not match type %s: %s", e.Path, e.TypeName, e.Detail)
+}
+
+// ErrInvalidUnionDiscriminant is returned when assembling a union and the
+// key naming its member (or, for representations like stringprefix,
+// the discriminant in the data) doesn't match any member of the union.
+//
+// Expected lists the discriminants which would have been accepted,
+// in the order of the union's members.
+type ErrInvalidUnionDiscriminant struct {
+	TypeName string
+
+	Discriminant string
+	Expected     []string
+}
+
+func (e ErrInvalidUnionDiscriminant) Error() string {
+	expected := make([]string, len(e.Expected))
+	for i, s := range e.Expected {
+		expected[i] = strconv.Quote(s)
+	}
+	return fmt.Sprintf("invalid discriminant for union %s: %q is not one of %s", e.TypeName, e.Discriminant, strings.Join(expected, ", "))
+}
+
+// ErrInvalidEnumMember is returned when assembling an enum from a value
+// which isn't one of its members (or, when assembling its representation,
+// isn't the representation of one of its members).
+//
+// Value and the entries of Expected are strings,
+// or int64s when assembling the int representation of an enum.
+// Expected lists the values which would have been accepted,
+// in the order of the enum's members.
+type ErrInvalidEnumMember struct {
+	TypeName string
+
+	Value    interface{}
+	Expected []interface{}
+}
+
+func (e ErrInvalidEnumMember) Error() string {
+	expected := make([]string, len(e.Expected))
+	for i, v := range e.Expected {
+		expected[i] = fmt.Sprintf("%#v", v)
+	}
+	return fmt.Sprintf("invalid value for enum %s: %#v is not one of %s", e.TypeName, e.Value, strings.Join(expected, ", "))
+}
@@ -2,8 +2,11 @@ package gengo
 
 import (
 	"fmt"
+	"go/token"
 	"reflect"
+	"sort"
 	"strings"
+	"unicode"
 
 	ipld "github.com/ipld/go-ipld-prime"
 	basicnode "github.com/ipld/go-ipld-prime/node/basic"
@@ -22,11 +25,20 @@ type FieldTuple struct {
 }
 
 type AdjunctCfg struct {
-	typeSymbolOverrides       map[schema.TypeName]string
+	TypeSymbolOverrides       map[schema.TypeName]string
 	FieldSymbolLowerOverrides map[FieldTuple]string
-	fieldSymbolUpperOverrides map[FieldTuple]string
-	maybeUsesPtr              map[schema.TypeName]bool   // absent uses a heuristic
+	FieldSymbolUpperOverrides map[FieldTuple]string
+	CfgMaybeUsesPtr           map[schema.TypeName]bool   // see the MaybeUsesPtr method; absent uses a heuristic.
 	CfgUnionMemlayout         map[schema.TypeName]string // "embedAll"|"interface"; maybe more options later, unclear for now.
+	CfgBytesZeroCopy          map[schema.TypeName]bool   // see the BytesZeroCopy method.
+	CfgCompact                bool                       // see the Compact method.
+	CfgCodecMethods           bool                       // see the CodecMethods method.
+	CfgRoundtripTests         bool                       // see the RoundtripTests method.
+
+	// SchemaHash, if set, is recorded in the header of the generated code,
+	//  so that GeneratedSchemaHash can tell later which schema it came from.
+	//   It's usually from schemadmt.Hash; gengo can't call that itself, since schemadmt is generated by gengo.
+	SchemaHash string
 
 	// ... some of these fields have sprouted messy name prefixes so they don't collide with their matching method names.
 	//  this structure has reached the critical threshhold where it due to be cleaned up and taken seriously.
@@ -47,25 +59,133 @@ type AdjunctCfg struct {
 // suffixing "__Something" to make the name of a supporting type;
 // etc.
 // (Most such augmentations are not configurable.)
+//
+// Names which wouldn't make a usable exported Go identifier are munged:
+// the first letter is uppercased (which also steers clear of keywords and builtins like "string"),
+// and characters which can't appear in an identifier become underscores.
+// If that makes two types end up with the same symbol,
+// the one whose name needed munging gets underscores appended until it's unique.
 func (cfg *AdjunctCfg) TypeSymbol(t schema.Type) string {
-	if x, ok := cfg.typeSymbolOverrides[t.Name()]; ok {
+	if x, ok := cfg.TypeSymbolOverrides[t.Name()]; ok {
 		return x
 	}
-	return string(t.Name()) // presumed already upper
+	sym := mungeSymbol(string(t.Name()), true)
+	if sym == string(t.Name()) || t.TypeSystem() == nil {
+		return sym
+	}
+	// Slow path: this type needed munging, so make sure it didn't collide.
+	//  Types keeping their name claim their symbol first; the rest go in name order.
+	taken := map[string]bool{}
+	var munged []string
+	for tn := range t.TypeSystem().GetTypes() {
+		if x, ok := cfg.TypeSymbolOverrides[tn]; ok {
+			taken[x] = true
+		} else if mungeSymbol(string(tn), true) == string(tn) {
+			taken[string(tn)] = true
+		} else {
+			munged = append(munged, string(tn))
+		}
+	}
+	sort.Strings(munged)
+	for _, tn := range munged {
+		s := claimSymbol(taken, mungeSymbol(tn, true))
+		if tn == string(t.Name()) {
+			return s
+		}
+	}
+	return sym // only reachable if the type isn't in its own type system.
 }
 
+// FieldSymbolLower returns the symbol for a field, as used in the unexported fields of the generated struct.
+// By default it's the field's name, with the first letter lowercased,
+// and Go keywords (like "type") suffixed with an underscore;
+// if that makes two fields of the same struct collide, the same rules as TypeSymbol apply.
+// It can be overriden per field with FieldSymbolLowerOverrides.
 func (cfg *AdjunctCfg) FieldSymbolLower(f schema.StructField) string {
-	if x, ok := cfg.FieldSymbolLowerOverrides[FieldTuple{f.Parent().Name(), f.Name()}]; ok {
-		return x
-	}
-	return f.Name() // presumed already lower
+	return cfg.fieldSymbols(f.Parent(), false)[f.Name()]
 }
 
+// FieldSymbolUpper returns the symbol for a field, as used in exported names like its accessor method.
+// By default it's the field's name with the first letter uppercased,
+// munged and deduplicated in the same way as FieldSymbolLower.
+// It can be overriden per field with FieldSymbolUpperOverrides.
 func (cfg *AdjunctCfg) FieldSymbolUpper(f schema.StructField) string {
-	if x, ok := cfg.fieldSymbolUpperOverrides[FieldTuple{f.Type().Name(), f.Name()}]; ok {
-		return x
+	return cfg.fieldSymbols(f.Parent(), true)[f.Name()]
+}
+
+// fieldSymbols computes the symbols for all the fields of a struct at once,
+// since whether a field's symbol needs deduplicating depends on its siblings.
+func (cfg *AdjunctCfg) fieldSymbols(t *schema.TypeStruct, upper bool) map[string]string {
+	overrides := cfg.FieldSymbolLowerOverrides
+	if upper {
+		overrides = cfg.FieldSymbolUpperOverrides
+	}
+	syms := make(map[string]string, len(t.Fields()))
+	taken := make(map[string]bool, len(t.Fields()))
+	var munged []string
+	for _, f := range t.Fields() {
+		if x, ok := overrides[FieldTuple{t.Name(), f.Name()}]; ok {
+			syms[f.Name()] = x
+			taken[x] = true
+		} else if mungeSymbol(f.Name(), false) == f.Name() {
+			syms[f.Name()] = claimSymbol(taken, mungeSymbol(f.Name(), upper))
+		} else {
+			munged = append(munged, f.Name())
+		}
+	}
+	for _, name := range munged {
+		syms[name] = claimSymbol(taken, mungeSymbol(name, upper))
+	}
+	return syms
+}
+
+// mungeSymbol turns a name from a schema into a Go identifier,
+// exported if upper is true, and unexported otherwise.
+// Well-behaved names only have the case of their first letter changed.
+func mungeSymbol(name string, upper bool) string {
+	var sb strings.Builder
+	for i, r := range name {
+		switch {
+		case unicode.IsLetter(r), r == '_':
+		case unicode.IsDigit(r) && i > 0:
+		case unicode.IsDigit(r):
+			sb.WriteString(mungePrefix(upper))
+		default:
+			r = '_'
+		}
+		if i == 0 {
+			if upper {
+				r = unicode.ToUpper(r)
+			} else {
+				r = unicode.ToLower(r)
+			}
+		}
+		sb.WriteRune(r)
+	}
+	sym := sb.String()
+	if sym == "" || sym == "_" || (upper && !token.IsExported(sym)) {
+		sym = mungePrefix(upper) + sym
+	}
+	if token.IsKeyword(sym) {
+		sym += "_"
+	}
+	return sym
+}
+
+func mungePrefix(upper bool) string {
+	if upper {
+		return "X"
 	}
-	return strings.Title(f.Name())
+	return "x"
+}
+
+// claimSymbol appends underscores to sym until it's not taken yet, and then marks it as taken.
+func claimSymbol(taken map[string]bool, sym string) string {
+	for taken[sym] {
+		sym += "_"
+	}
+	taken[sym] = true
+	return sym
 }
 
 // Comments returns a bool for whether comments should be included in gen output or not.
@@ -73,8 +193,43 @@ func (cfg *AdjunctCfg) Comments() bool {
 	return true // FUTURE: okay, maybe this should be configurable :)
 }
 
+// Compact returns a bool for whether the generated code should call the helpers in the schema/gensupport package,
+// rather than spelling out the boilerplate they contain in every type.
+// This makes the output noticeably smaller, at the cost of a few more function calls;
+// it's off by default, since speed takes priority over code size.
+func (cfg *AdjunctCfg) Compact() bool {
+	return cfg.CfgCompact
+}
+
+// CodecMethods returns a bool for whether every type should get an Encode method,
+// and its prototype a Decode method, which serialize the type's representation
+// with any codec registered in the multicodec package.
+// It's off by default, since it makes the generated code import the multicodec package.
+func (cfg *AdjunctCfg) CodecMethods() bool {
+	return cfg.CfgCodecMethods
+}
+
+// RoundtripTests returns a bool for whether to generate a test file alongside the generated code,
+// which checks that a sample value of every type survives being built, read, encoded, and decoded;
+// see EmitRoundtripTests.
+// It gives schema authors some coverage of their generated code for free.
+// It's off by default, since the test depends on the dag-json codec.
+func (cfg *AdjunctCfg) RoundtripTests() bool {
+	return cfg.CfgRoundtripTests
+}
+
+// MaybeUsesPtr returns whether optional or nullable values of a type are held by pointer,
+// rather than embedded by value, in the Maybe types (and so in the struct fields, and map and list values) of the generated code.
+//
+// Embedding means fewer allocations and better locality when the value is usually present;
+// a pointer uses less memory when the value is usually absent, and makes copying the Maybe cheaper.
+// Types which contain themselves, such as a struct with an optional field of its own type,
+// need the pointer form, or the generated code won't compile.
+//
+// It can be set per type with CfgMaybeUsesPtr;
+// otherwise, small types are embedded, and larger ones use a pointer.
 func (cfg *AdjunctCfg) MaybeUsesPtr(t schema.Type) bool {
-	if x, ok := cfg.maybeUsesPtr[t.Name()]; ok {
+	if x, ok := cfg.CfgMaybeUsesPtr[t.Name()]; ok {
 		return x
 	}
 
@@ -164,6 +319,18 @@ func sizeOfSchemaType(t schema.Type) uintptr {
 	return 100 * sizeSmallEnoughForInlining
 }
 
+// BytesZeroCopy returns whether a type of bytes kind holds onto the byte slices assigned to it,
+// rather than copying them.
+// That saves an allocation and a copy for large values being moved through typed data,
+// but the caller must then not modify a slice after assigning it.
+// It's off by default, and can be set per type with CfgBytesZeroCopy.
+//
+// Either way, the BytesUnsafe method of the generated type returns its slice without copying,
+// while its Bytes method returns a copy.
+func (cfg *AdjunctCfg) BytesZeroCopy(t schema.Type) bool {
+	return cfg.CfgBytesZeroCopy[t.Name()]
+}
+
 // UnionMemlayout returns a plain string at present;
 // there's a case-switch in the templates that processes it.
 // We validate that it's a known string when this method is called.
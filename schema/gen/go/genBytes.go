@@ -22,10 +22,43 @@ func (g bytesGenerator) EmitNativeType(w io.Writer) {
 	emitNativeType_scalar(w, g.AdjCfg, g)
 }
 func (g bytesGenerator) EmitNativeAccessors(w io.Writer) {
-	emitNativeAccessors_scalar(w, g.AdjCfg, g)
+	// Unlike the other scalars, the native accessor makes a copy, since a slice is mutable.
+	//  BytesUnsafe is the way to skip that, for those who can promise not to modify the result.
+	doTemplate(`
+		{{- if Comments -}}
+		// Bytes returns a copy of the value as a native []byte.
+		{{- end}}
+		func (n {{ .Type | TypeSymbol }}) Bytes() []byte {
+			return append([]byte(nil), n.x...)
+		}
+		{{ if Comments -}}
+		// BytesUnsafe returns the value as a native []byte, without copying it.
+		// The slice is shared with the node, so it must not be modified.
+		{{ end -}}
+		func (n {{ .Type | TypeSymbol }}) BytesUnsafe() []byte {
+			return n.x
+		}
+	`, w, g.AdjCfg, g)
 }
 func (g bytesGenerator) EmitNativeBuilder(w io.Writer) {
-	emitNativeBuilder_scalar(w, g.AdjCfg, g)
+	doTemplate(`
+		{{- if Comments -}}
+		{{- if .AdjCfg.BytesZeroCopy .Type }}
+		// FromBytes returns a node holding the given bytes, without copying them.
+		// The slice is shared with the node, so it must not be modified after.
+		{{- else }}
+		// FromBytes returns a node holding a copy of the given bytes.
+		{{- end}}
+		{{- end}}
+		func (_{{ .Type | TypeSymbol }}__Prototype) FromBytes(v []byte) ({{ .Type | TypeSymbol }}, error) {
+			{{- if .AdjCfg.BytesZeroCopy .Type }}
+			n := _{{ .Type | TypeSymbol }}{v}
+			{{- else }}
+			n := _{{ .Type | TypeSymbol }}{append([]byte(nil), v...)}
+			{{- end}}
+			return &n, nil
+		}
+	`, w, g.AdjCfg, g)
 }
 
 func (g bytesGenerator) EmitNativeMaybe(w io.Writer) {
@@ -109,7 +142,31 @@ func (g bytesBuilderGenerator) EmitNodeAssemblerMethodAssignNull(w io.Writer) {
 	emitNodeAssemblerMethodAssignNull_scalar(w, g.AdjCfg, g)
 }
 func (g bytesBuilderGenerator) EmitNodeAssemblerMethodAssignBytes(w io.Writer) {
-	emitNodeAssemblerMethodAssignKind_scalar(w, g.AdjCfg, g)
+	// Like emitNodeAssemblerMethodAssignKind_scalar, except that the slice is copied unless configured otherwise.
+	doTemplate(`
+		func (na *_{{ .Type | TypeSymbol }}__Assembler) AssignBytes(v []byte) error {
+			{{- if Compact }}
+			gensupport.CheckAssignable(*na.m)
+			{{- else }}
+			switch *na.m {
+			case schema.Maybe_Value, schema.Maybe_Null:
+				panic("invalid state: cannot assign into assembler that's already finished")
+			}
+			{{- end}}
+			{{- if .Type | MaybeUsesPtr }}
+			if na.w == nil {
+				na.w = &_{{ .Type | TypeSymbol }}{}
+			}
+			{{- end}}
+			{{- if .AdjCfg.BytesZeroCopy .Type }}
+			na.w.x = v
+			{{- else }}
+			na.w.x = append([]byte(nil), v...)
+			{{- end}}
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+	`, w, g.AdjCfg, g)
 }
 func (g bytesBuilderGenerator) EmitNodeAssemblerMethodAssignNode(w io.Writer) {
 	emitNodeAssemblerMethodAssignNode_scalar(w, g.AdjCfg, g)
@@ -0,0 +1,237 @@
+package gengo
+
+import (
+	"io"
+
+	"github.com/ipld/go-ipld-prime/schema"
+	"github.com/ipld/go-ipld-prime/schema/gen/go/mixins"
+)
+
+// Enums act like strings at the type level, holding the name of their member,
+//  whatever their representation strategy is.
+// The memory layout is the same as for a plain string, too;
+//  what differs is that every path to creating one checks the value is a member.
+
+type enumGenerator struct {
+	AdjCfg *AdjunctCfg
+	mixins.StringTraits
+	PkgName string
+	Type    *schema.TypeEnum
+}
+
+func (enumGenerator) IsRepr() bool { return false } // hint used in some generalized templates.
+
+// --- native content and specializations --->
+
+func (g enumGenerator) EmitNativeType(w io.Writer) {
+	emitNativeType_scalar(w, g.AdjCfg, g)
+}
+func (g enumGenerator) EmitNativeAccessors(w io.Writer) {
+	emitNativeAccessors_scalar(w, g.AdjCfg, g)
+}
+func (g enumGenerator) EmitNativeBuilder(w io.Writer) {
+	// This is much like the string builder, except the value is checked against the members.
+	// Unlike most other scalars, the exported constructor is not infallible, and it says so.
+	doTemplate(`
+		func (_{{ .Type | TypeSymbol }}__Prototype) fromString(w *_{{ .Type | TypeSymbol }}, v string) error {
+			switch v {
+			case {{ range $i, $m := .Type.Members }}{{ if $i }}, {{ end }}{{ printf "%q" $m }}{{ end }}:
+				*w = _{{ .Type | TypeSymbol }}{v}
+				return nil
+			}
+			return schema.ErrInvalidEnumMember{TypeName: "{{ .PkgName }}.{{ .Type.Name }}", Value: v, Expected: []interface{}{ {{- range $i, $m := .Type.Members }}{{ if $i }}, {{ end }}{{ printf "%q" $m }}{{ end -}} }}
+		}
+		func (_{{ .Type | TypeSymbol }}__Prototype) FromString(v string) ({{ .Type | TypeSymbol }}, error) {
+			var n _{{ .Type | TypeSymbol }}
+			if err := (_{{ .Type | TypeSymbol }}__Prototype{}).fromString(&n, v); err != nil {
+				return nil, err
+			}
+			return &n, nil
+		}
+	`, w, g.AdjCfg, g)
+}
+
+func (g enumGenerator) EmitNativeMaybe(w io.Writer) {
+	emitNativeMaybe(w, g.AdjCfg, g)
+}
+
+// --- type info --->
+
+func (g enumGenerator) EmitTypeConst(w io.Writer) {
+	doTemplate(`
+		// TODO EmitTypeConst
+	`, w, g.AdjCfg, g)
+}
+
+// --- TypedNode interface satisfaction --->
+
+func (g enumGenerator) EmitTypedNodeMethodType(w io.Writer) {
+	doTemplate(`
+		func ({{ .Type | TypeSymbol }}) Type() schema.Type {
+			return nil /*TODO:typelit*/
+		}
+	`, w, g.AdjCfg, g)
+}
+
+func (g enumGenerator) EmitTypedNodeMethodRepresentation(w io.Writer) {
+	emitTypicalTypedNodeMethodRepresentation(w, g.AdjCfg, g)
+}
+
+// --- Node interface satisfaction --->
+
+func (g enumGenerator) EmitNodeType(w io.Writer) {
+	// No additional types needed.  Methods all attach to the native type.
+}
+
+func (g enumGenerator) EmitNodeTypeAssertions(w io.Writer) {
+	emitNodeTypeAssertions_typical(w, g.AdjCfg, g)
+}
+func (g enumGenerator) EmitNodeMethodAsString(w io.Writer) {
+	emitNodeMethodAsKind_scalar(w, g.AdjCfg, g)
+}
+func (g enumGenerator) EmitNodeMethodPrototype(w io.Writer) {
+	emitNodeMethodPrototype_typical(w, g.AdjCfg, g)
+}
+func (g enumGenerator) EmitNodePrototypeType(w io.Writer) {
+	emitNodePrototypeType_typical(w, g.AdjCfg, g)
+}
+
+// --- NodeBuilder and NodeAssembler --->
+
+func (g enumGenerator) GetNodeBuilderGenerator() NodeBuilderGenerator {
+	return enumBuilderGenerator{
+		g.AdjCfg,
+		mixins.StringAssemblerTraits{
+			PkgName:       g.PkgName,
+			TypeName:      g.TypeName,
+			AppliedPrefix: "_" + g.AdjCfg.TypeSymbol(g.Type) + "__",
+		},
+		g.PkgName,
+		g.Type,
+	}
+}
+
+type enumBuilderGenerator struct {
+	AdjCfg *AdjunctCfg
+	mixins.StringAssemblerTraits
+	PkgName string
+	Type    *schema.TypeEnum
+}
+
+func (enumBuilderGenerator) IsRepr() bool { return false } // hint used in some generalized templates.
+
+func (g enumBuilderGenerator) EmitNodeBuilderType(w io.Writer) {
+	emitEmitNodeBuilderType_typical(w, g.AdjCfg, g)
+}
+func (g enumBuilderGenerator) EmitNodeBuilderMethods(w io.Writer) {
+	emitNodeBuilderMethods_typical(w, g.AdjCfg, g)
+}
+func (g enumBuilderGenerator) EmitNodeAssemblerType(w io.Writer) {
+	emitNodeAssemblerType_scalar(w, g.AdjCfg, g)
+}
+func (g enumBuilderGenerator) EmitNodeAssemblerMethodAssignNull(w io.Writer) {
+	emitNodeAssemblerMethodAssignNull_scalar(w, g.AdjCfg, g)
+}
+func (g enumBuilderGenerator) EmitNodeAssemblerMethodAssignString(w io.Writer) {
+	// This method contains a branch to support MaybeUsesPtr because new memory may need to be allocated.
+	//  This allocation only happens if the 'w' ptr is nil, which means we're being used on a Maybe;
+	//  otherwise, the 'w' ptr should already be set, and we fill that memory location without allocating, as usual.
+	doTemplate(`
+		func (na *_{{ .Type | TypeSymbol }}__Assembler) AssignString(v string) error {
+			{{- if Compact }}
+			gensupport.CheckAssignable(*na.m)
+			{{- else }}
+			switch *na.m {
+			case schema.Maybe_Value, schema.Maybe_Null:
+				panic("invalid state: cannot assign into assembler that's already finished")
+			}
+			{{- end}}
+			{{- if .Type | MaybeUsesPtr }}
+			if na.w == nil {
+				na.w = &_{{ .Type | TypeSymbol }}{}
+			}
+			{{- end}}
+			if err := (_{{ .Type | TypeSymbol }}__Prototype{}).fromString(na.w, v); err != nil {
+				return err
+			}
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+	`, w, g.AdjCfg, g)
+}
+func (g enumBuilderGenerator) EmitNodeAssemblerMethodAssignNode(w io.Writer) {
+	emitNodeAssemblerMethodAssignNode_scalar(w, g.AdjCfg, g)
+}
+func (g enumBuilderGenerator) EmitNodeAssemblerOtherBits(w io.Writer) {
+	// Nothing needed here for enums.
+}
+
+// The representation assemblers of enums are the same for every strategy,
+//  apart from the kind they accept; the 'fromString' or 'fromInt' method on the repr prototype does the real work.
+
+func emitNodeAssemblerMethodAssignKind_enumRepr(w io.Writer, adjCfg *AdjunctCfg, data interface{}) {
+	// This method contains a branch to support MaybeUsesPtr because new memory may need to be allocated.
+	//  This allocation only happens if the 'w' ptr is nil, which means we're being used on a Maybe;
+	//  otherwise, the 'w' ptr should already be set, and we fill that memory location without allocating, as usual.
+	doTemplate(`
+		func (na *_{{ .Type | TypeSymbol }}__ReprAssembler) Assign{{ .Kind.String | title }}(v {{ .Kind | KindPrim }}) error {
+			{{- if Compact }}
+			gensupport.CheckAssignable(*na.m)
+			{{- else }}
+			switch *na.m {
+			case schema.Maybe_Value, schema.Maybe_Null:
+				panic("invalid state: cannot assign into assembler that's already finished")
+			}
+			{{- end}}
+			{{- if .Type | MaybeUsesPtr }}
+			if na.w == nil {
+				na.w = &_{{ .Type | TypeSymbol }}{}
+			}
+			{{- end}}
+			if err := (_{{ .Type | TypeSymbol }}__ReprPrototype{}).from{{ .Kind.String | title }}(na.w, v); err != nil {
+				return err
+			}
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+	`, w, adjCfg, data)
+}
+
+func emitNodeAssemblerMethodAssignNode_enumRepr(w io.Writer, adjCfg *AdjunctCfg, data interface{}) {
+	doTemplate(`
+		func (na *_{{ .Type | TypeSymbol }}__ReprAssembler) AssignNode(v ipld.Node) error {
+			if v.IsNull() {
+				return na.AssignNull()
+			}
+			v2, ok := v.(*_{{ .Type | TypeSymbol }})
+			if v3, ok3 := v.(*_{{ .Type | TypeSymbol }}__Repr); ok3 {
+				v2, ok = (*_{{ .Type | TypeSymbol }})(v3), true
+			}
+			if ok {
+				{{- if Compact }}
+				gensupport.CheckAssignable(*na.m)
+				{{- else }}
+				switch *na.m {
+				case schema.Maybe_Value, schema.Maybe_Null:
+					panic("invalid state: cannot assign into assembler that's already finished")
+				}
+				{{- end}}
+				{{- if .Type | MaybeUsesPtr }}
+				if na.w == nil {
+					na.w = v2
+					*na.m = schema.Maybe_Value
+					return nil
+				}
+				{{- end}}
+				*na.w = *v2
+				*na.m = schema.Maybe_Value
+				return nil
+			}
+			if v2, err := v.As{{ .Kind.String | title }}(); err != nil {
+				return err
+			} else {
+				return na.Assign{{ .Kind.String | title }}(v2)
+			}
+		}
+	`, w, adjCfg, data)
+}
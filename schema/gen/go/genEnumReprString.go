@@ -0,0 +1,190 @@
+package gengo
+
+import (
+	"io"
+
+	"github.com/ipld/go-ipld-prime/schema"
+	"github.com/ipld/go-ipld-prime/schema/gen/go/mixins"
+)
+
+var _ TypeGenerator = &enumReprStringGenerator{}
+
+func NewEnumReprStringGenerator(pkgName string, typ *schema.TypeEnum, adjCfg *AdjunctCfg) TypeGenerator {
+	return enumReprStringGenerator{
+		enumGenerator{
+			adjCfg,
+			mixins.StringTraits{
+				PkgName:    pkgName,
+				TypeName:   string(typ.Name()),
+				TypeSymbol: adjCfg.TypeSymbol(typ),
+			},
+			pkgName,
+			typ,
+		},
+	}
+}
+
+type enumReprStringGenerator struct {
+	enumGenerator
+}
+
+func (g enumReprStringGenerator) GetRepresentationNodeGen() NodeGenerator {
+	return enumReprStringReprGenerator{
+		g.AdjCfg,
+		mixins.StringTraits{
+			PkgName:    g.PkgName,
+			TypeName:   string(g.Type.Name()) + ".Repr",
+			TypeSymbol: "_" + g.AdjCfg.TypeSymbol(g.Type) + "__Repr",
+		},
+		g.PkgName,
+		g.Type,
+	}
+}
+
+type enumReprStringReprGenerator struct {
+	AdjCfg *AdjunctCfg
+	mixins.StringTraits
+	PkgName string
+	Type    *schema.TypeEnum
+}
+
+func (enumReprStringReprGenerator) IsRepr() bool { return true } // hint used in some generalized templates.
+
+// Renames returns the members which are represented by some string other than their own name,
+// paired with that string.
+func (g enumReprStringReprGenerator) Renames() [][2]string {
+	var renames [][2]string
+	for _, ms := range g.MemberSerials() {
+		if ms[0] != ms[1] {
+			renames = append(renames, ms)
+		}
+	}
+	return renames
+}
+
+// MemberSerials returns each member paired with the string which represents it.
+func (g enumReprStringReprGenerator) MemberSerials() [][2]string {
+	r := g.Type.RepresentationStrategy().(schema.EnumRepresentation_String)
+	var pairs [][2]string
+	for _, m := range g.Type.Members() {
+		pairs = append(pairs, [2]string{m, r.GetSerial(m)})
+	}
+	return pairs
+}
+
+func (g enumReprStringReprGenerator) EmitNodeType(w io.Writer) {
+	// The type is structurally the same, but will have a different set of methods.
+	//  (Even when no members are renamed, the assembler still has to check membership,
+	//   so this can't just be an alias the way it is for plain strings.)
+	doTemplate(`
+		type _{{ .Type | TypeSymbol }}__Repr _{{ .Type | TypeSymbol }}
+	`, w, g.AdjCfg, g)
+}
+
+func (g enumReprStringReprGenerator) EmitNodeTypeAssertions(w io.Writer) {
+	doTemplate(`
+		var _ ipld.Node = &_{{ .Type | TypeSymbol }}__Repr{}
+	`, w, g.AdjCfg, g)
+}
+
+func (g enumReprStringReprGenerator) EmitNodeMethodAsString(w io.Writer) {
+	// A speciated String method is also generated here, as it is for other representations of string kind;
+	//  things like stringjoin structs and stringprefix unions count on it.
+	doTemplate(`
+		func (n *_{{ .Type | TypeSymbol }}__Repr) AsString() (string, error) {
+			return n.String(), nil
+		}
+		func (n *_{{ .Type | TypeSymbol }}__Repr) String() string {
+			{{- if .Renames }}
+			switch n.x {
+			{{- range $r := .Renames }}
+			case {{ printf "%q" (index $r 0) }}:
+				return {{ printf "%q" (index $r 1) }}
+			{{- end}}
+			}
+			{{- end}}
+			return n.x
+		}
+	`, w, g.AdjCfg, g)
+}
+
+func (g enumReprStringReprGenerator) EmitNodeMethodPrototype(w io.Writer) {
+	emitNodeMethodPrototype_typical(w, g.AdjCfg, g)
+}
+
+func (g enumReprStringReprGenerator) EmitNodePrototypeType(w io.Writer) {
+	emitNodePrototypeType_typical(w, g.AdjCfg, g)
+}
+
+// --- NodeBuilder and NodeAssembler --->
+
+func (g enumReprStringReprGenerator) GetNodeBuilderGenerator() NodeBuilderGenerator {
+	return enumReprStringReprBuilderGenerator{
+		g.AdjCfg,
+		mixins.StringAssemblerTraits{
+			PkgName:       g.PkgName,
+			TypeName:      g.TypeName,
+			AppliedPrefix: "_" + g.AdjCfg.TypeSymbol(g.Type) + "__Repr",
+		},
+		g.PkgName,
+		g.Type,
+	}
+}
+
+type enumReprStringReprBuilderGenerator struct {
+	AdjCfg *AdjunctCfg
+	mixins.StringAssemblerTraits
+	PkgName string
+	Type    *schema.TypeEnum
+}
+
+func (enumReprStringReprBuilderGenerator) IsRepr() bool { return true } // hint used in some generalized templates.
+
+func (g enumReprStringReprBuilderGenerator) MemberSerials() [][2]string {
+	return enumReprStringReprGenerator{Type: g.Type}.MemberSerials()
+}
+
+func (g enumReprStringReprBuilderGenerator) EmitNodeBuilderType(w io.Writer) {
+	emitEmitNodeBuilderType_typical(w, g.AdjCfg, g)
+}
+func (g enumReprStringReprBuilderGenerator) EmitNodeBuilderMethods(w io.Writer) {
+	emitNodeBuilderMethods_typical(w, g.AdjCfg, g)
+
+	// Like other representations of string kind, this has a 'fromString' method,
+	//  which is used by anything destructuring a string that contains one of these.
+	// Note that a renamed member's own name is not accepted here.
+	doTemplate(`
+		func (_{{ .Type | TypeSymbol }}__ReprPrototype) fromString(w *_{{ .Type | TypeSymbol }}, v string) error {
+			switch v {
+			{{- range $ms := .MemberSerials }}
+			case {{ printf "%q" (index $ms 1) }}:
+				*w = _{{ dot.Type | TypeSymbol }}{ {{- printf "%q" (index $ms 0) -}} }
+				return nil
+			{{- end}}
+			}
+			return schema.ErrInvalidEnumMember{TypeName: "{{ .PkgName }}.{{ .Type.Name }}.Repr", Value: v, Expected: []interface{}{ {{- range $i, $ms := .MemberSerials }}{{ if $i }}, {{ end }}{{ printf "%q" (index $ms 1) }}{{ end -}} }}
+		}
+	`, w, g.AdjCfg, g)
+}
+func (g enumReprStringReprBuilderGenerator) EmitNodeAssemblerType(w io.Writer) {
+	doTemplate(`
+		type _{{ .Type | TypeSymbol }}__ReprAssembler struct {
+			w *_{{ .Type | TypeSymbol }}
+			m *schema.Maybe
+		}
+
+		func (na *_{{ .Type | TypeSymbol }}__ReprAssembler) reset() {}
+	`, w, g.AdjCfg, g)
+}
+func (g enumReprStringReprBuilderGenerator) EmitNodeAssemblerMethodAssignNull(w io.Writer) {
+	emitNodeAssemblerMethodAssignNull_scalar(w, g.AdjCfg, g)
+}
+func (g enumReprStringReprBuilderGenerator) EmitNodeAssemblerMethodAssignString(w io.Writer) {
+	emitNodeAssemblerMethodAssignKind_enumRepr(w, g.AdjCfg, g)
+}
+func (g enumReprStringReprBuilderGenerator) EmitNodeAssemblerMethodAssignNode(w io.Writer) {
+	emitNodeAssemblerMethodAssignNode_enumRepr(w, g.AdjCfg, g)
+}
+func (g enumReprStringReprBuilderGenerator) EmitNodeAssemblerOtherBits(w io.Writer) {
+	// None for this.
+}
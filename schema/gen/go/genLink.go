@@ -50,10 +50,11 @@ func (g linkGenerator) EmitTypedNodeMethodType(w io.Writer) {
 	`, w, g.AdjCfg, g)
 
 	// Bonus feature for some links (conforms to the schema.TypedLinkNode interface):
+	//  the prototype is for the representation of the referenced type, since that's what gets loaded.
 	if g.Type.HasReferencedType() {
 		doTemplate(`
 			func ({{ .Type | TypeSymbol }}) LinkTargetNodePrototype() ipld.NodePrototype {
-				return Type.{{ .Type | TypeSymbol }}__Repr
+				return Type.{{ .Type.ReferencedType | TypeSymbol }}__Repr
 			}
 		`, w, g.AdjCfg, g)
 	}
@@ -25,7 +25,8 @@ func (g mapGenerator) EmitNativeType(w io.Writer) {
 	// Note that the key in 'm' is *not* a pointer.
 	// The value in 'm' is a pointer into 't' (except when it's a maybe; maybes are already pointers).
 	doTemplate(`
-		{{- if Comments -}}
+		{{- Doc .Type.Doc }}
+		{{- if Comments }}
 		// {{ .Type | TypeSymbol }} matches the IPLD Schema type "{{ .Type.Name }}".  It has {{ .Kind }} kind.
 		{{- end}}
 		type {{ .Type | TypeSymbol }} = *_{{ .Type | TypeSymbol }}
@@ -164,16 +165,19 @@ func (g mapGenerator) EmitNodeMethodLookupByString(w io.Writer) {
 	//   we *must* be able to accept a string in a PathSegment and be able to use it to navigate a map -- even if the map has complex keys.
 	//   For that to work out, it means if the key type doesn't have a string type kind, we must be willing to reach into its representation and use the fromString there.
 	//  If the key type *does* have a string kind at the type level, we'll use that; no need to consider going through the representation.
+	// Keys that act like strings already fail with an error that says what's wrong with them (like ErrInvalidEnumMember), so those are returned as-is;
+	//  complex keys fail with ErrUnmatchable, which is wrapped in an ErrInvalidKey so that it also says which map rejected the key.
+	//  The same goes for the other places that parse keys, in the representation and in the assemblers.
 	doTemplate(`
 		func (n {{ .Type | TypeSymbol }}) LookupByString(k string) (ipld.Node, error) {
 			var k2 _{{ .Type.KeyType | TypeSymbol }}
-			{{- if eq .Type.KeyType.TypeKind.String "String" }}
+			{{- if eq .Type.KeyType.TypeKind.ActsLike.String "string" }}
 			if err := (_{{ .Type.KeyType | TypeSymbol }}__Prototype{}).fromString(&k2, k); err != nil {
-				return nil, err // TODO wrap in some kind of ErrInvalidKey
+				return nil, err
 			}
 			{{- else}}
 			if err := (_{{ .Type.KeyType | TypeSymbol }}__ReprPrototype{}).fromString(&k2, k); err != nil {
-				return nil, err // TODO wrap in some kind of ErrInvalidKey
+				return nil, ipld.ErrInvalidKey{TypeName: "{{ .PkgName }}.{{ .Type.Name }}", Key: &_String{k}, Reason: err}
 			}
 			{{- end}}
 			v, exists := n.m[k2]
@@ -193,16 +197,18 @@ func (g mapGenerator) EmitNodeMethodLookupByString(w io.Writer) {
 }
 
 func (g mapGenerator) EmitNodeMethodLookupByNode(w io.Writer) {
-	// LookupByNode will procede by cast if it can; or simply error if that doesn't work.
-	//  There's no attempt to turn the node (or its repr) into a string and then reify that into a key;
-	//   if you used a Node here, you should've meant it.
-	// REVIEW: by comparison structs will coerce anything stringish silently...!  so we should figure out if that inconsistency is acceptable, and at least document it if so.
+	// LookupByNode will procede by cast if it can.
+	//  Failing that, the node is treated as a string, just like structs treat their keys;
+	//   this is what lets a plain string find an entry in a map with complex keys.
 	doTemplate(`
 		func (n {{ .Type | TypeSymbol }}) LookupByNode(k ipld.Node) (ipld.Node, error) {
 			k2, ok := k.({{ .Type.KeyType | TypeSymbol }})
 			if !ok {
-				panic("todo invalid key type error")
-				// 'ipld.ErrInvalidKey{TypeName:"{{ .PkgName }}.{{ .Type.Name }}", Key:&_String{k}}' doesn't quite cut it: need room to explain the type, and it's not guaranteed k can be turned into a string at all
+				ks, err := k.AsString()
+				if err != nil {
+					return nil, err
+				}
+				return n.LookupByString(ks)
 			}
 			v, exists := n.m[*k2]
 			if !exists {
@@ -0,0 +1,402 @@
+package gengo
+
+import (
+	"io"
+
+	"github.com/ipld/go-ipld-prime/schema"
+	"github.com/ipld/go-ipld-prime/schema/gen/go/mixins"
+)
+
+var _ TypeGenerator = &mapReprListpairsGenerator{}
+
+// The listpairs representation of a map is a list of entries, each of which is a list of a key and a value, in the map's order.
+//  The entries are the '_T__entry' rows the map already keeps in its 't' slice, cast to a node type; so iterating doesn't allocate.
+//  See genpartsListpairs.go for the parts that are shared with the listpairs representation of structs.
+
+func NewMapReprListpairsGenerator(pkgName string, typ *schema.TypeMap, adjCfg *AdjunctCfg) TypeGenerator {
+	return mapReprListpairsGenerator{
+		mapGenerator{
+			adjCfg,
+			mixins.MapTraits{
+				PkgName:    pkgName,
+				TypeName:   string(typ.Name()),
+				TypeSymbol: adjCfg.TypeSymbol(typ),
+			},
+			pkgName,
+			typ,
+		},
+	}
+}
+
+type mapReprListpairsGenerator struct {
+	mapGenerator
+}
+
+func (g mapReprListpairsGenerator) GetRepresentationNodeGen() NodeGenerator {
+	return mapReprListpairsReprGenerator{
+		g.AdjCfg,
+		mixins.ListTraits{
+			PkgName:    g.PkgName,
+			TypeName:   string(g.Type.Name()) + ".Repr",
+			TypeSymbol: "_" + g.AdjCfg.TypeSymbol(g.Type) + "__Repr",
+		},
+		g.PkgName,
+		g.Type,
+	}
+}
+
+type mapReprListpairsReprGenerator struct {
+	AdjCfg *AdjunctCfg
+	mixins.ListTraits
+	PkgName string
+	Type    *schema.TypeMap
+}
+
+func (mapReprListpairsReprGenerator) IsRepr() bool { return true } // hint used in some generalized templates.
+
+func (g mapReprListpairsReprGenerator) EmitNodeType(w io.Writer) {
+	// The type is structurally the same, but will have a different set of methods.
+	doTemplate(`
+		type _{{ .Type | TypeSymbol }}__Repr _{{ .Type | TypeSymbol }}
+	`, w, g.AdjCfg, g)
+}
+func (g mapReprListpairsReprGenerator) EmitNodeTypeAssertions(w io.Writer) {
+	doTemplate(`
+		var _ ipld.Node = &_{{ .Type | TypeSymbol }}__Repr{}
+	`, w, g.AdjCfg, g)
+}
+
+func (g mapReprListpairsReprGenerator) EmitNodeMethodLookupByIndex(w io.Writer) {
+	doTemplate(`
+		func (nr *_{{ .Type | TypeSymbol }}__Repr) LookupByIndex(idx int64) (ipld.Node, error) {
+			if idx < 0 || idx >= int64(len(nr.t)) {
+				return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfInt(idx)}
+			}
+			return (*_{{ .Type | TypeSymbol }}__ReprEntry)(&nr.t[idx]), nil
+		}
+	`, w, g.AdjCfg, g)
+}
+func (g mapReprListpairsReprGenerator) EmitNodeMethodLookupByNode(w io.Writer) {
+	doTemplate(`
+		func (nr *_{{ .Type | TypeSymbol }}__Repr) LookupByNode(key ipld.Node) (ipld.Node, error) {
+			ki, err := key.AsInt()
+			if err != nil {
+				return nil, err
+			}
+			return nr.LookupByIndex(ki)
+		}
+	`, w, g.AdjCfg, g)
+}
+func (g mapReprListpairsReprGenerator) EmitNodeMethodListIterator(w io.Writer) {
+	doTemplate(`
+		func (nr *_{{ .Type | TypeSymbol }}__Repr) ListIterator() ipld.ListIterator {
+			return &_{{ .Type | TypeSymbol }}__ReprListItr{nr, 0}
+		}
+
+		type _{{ .Type | TypeSymbol }}__ReprListItr struct {
+			n   *_{{ .Type | TypeSymbol }}__Repr
+			idx int
+		}
+
+		func (itr *_{{ .Type | TypeSymbol }}__ReprListItr) Next() (idx int64, v ipld.Node, _ error) {
+			if itr.idx >= len(itr.n.t) {
+				return -1, nil, ipld.ErrIteratorOverread{}
+			}
+			idx = int64(itr.idx)
+			v = (*_{{ .Type | TypeSymbol }}__ReprEntry)(&itr.n.t[itr.idx])
+			itr.idx++
+			return
+		}
+		func (itr *_{{ .Type | TypeSymbol }}__ReprListItr) Done() bool {
+			return itr.idx >= len(itr.n.t)
+		}
+	`, w, g.AdjCfg, g)
+
+	// The entries are the rows of the map's 't' slice.
+	doTemplate(`
+		type _{{ .Type | TypeSymbol }}__ReprEntry _{{ .Type | TypeSymbol }}__entry
+
+		func (n *_{{ .Type | TypeSymbol }}__ReprEntry) LookupByIndex(idx int64) (ipld.Node, error) {
+			switch idx {
+			case 0:
+				return n.k.Representation(), nil
+			case 1:
+				{{- if .Type.ValueIsNullable }}
+				if n.v.m == schema.Maybe_Null {
+					return ipld.Null, nil
+				}
+				return n.v.v.Representation(), nil
+				{{- else}}
+				return n.v.Representation(), nil
+				{{- end}}
+			default:
+				return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfInt(idx)}
+			}
+		}
+	`, w, g.AdjCfg, g)
+	emitNodeHelper_listpairs_entryNodeMethods(w, g.AdjCfg, g, g.PkgName, g.TypeName, g.TypeSymbol)
+}
+func (g mapReprListpairsReprGenerator) EmitNodeMethodLength(w io.Writer) {
+	doTemplate(`
+		func (rn *_{{ .Type | TypeSymbol }}__Repr) Length() int64 {
+			return int64(len(rn.t))
+		}
+	`, w, g.AdjCfg, g)
+}
+func (g mapReprListpairsReprGenerator) EmitNodeMethodPrototype(w io.Writer) {
+	emitNodeMethodPrototype_typical(w, g.AdjCfg, g)
+}
+func (g mapReprListpairsReprGenerator) EmitNodePrototypeType(w io.Writer) {
+	emitNodePrototypeType_typical(w, g.AdjCfg, g)
+}
+
+// --- NodeBuilder and NodeAssembler --->
+
+func (g mapReprListpairsReprGenerator) GetNodeBuilderGenerator() NodeBuilderGenerator {
+	return mapReprListpairsReprBuilderGenerator{
+		g.AdjCfg,
+		mixins.ListAssemblerTraits{
+			PkgName:       g.PkgName,
+			TypeName:      g.TypeName,
+			AppliedPrefix: "_" + g.AdjCfg.TypeSymbol(g.Type) + "__Repr",
+		},
+		g.PkgName,
+		g.Type,
+	}
+}
+
+type mapReprListpairsReprBuilderGenerator struct {
+	AdjCfg *AdjunctCfg
+	mixins.ListAssemblerTraits
+	PkgName string
+	Type    *schema.TypeMap
+}
+
+func (mapReprListpairsReprBuilderGenerator) IsRepr() bool { return true } // hint used in some generalized templates.
+
+func (g mapReprListpairsReprBuilderGenerator) EmitNodeBuilderType(w io.Writer) {
+	emitEmitNodeBuilderType_typical(w, g.AdjCfg, g)
+}
+func (g mapReprListpairsReprBuilderGenerator) EmitNodeBuilderMethods(w io.Writer) {
+	emitNodeBuilderMethods_typical(w, g.AdjCfg, g)
+}
+func (g mapReprListpairsReprBuilderGenerator) EmitNodeAssemblerType(w io.Writer) {
+	// - 'w' is the "**w**ip" pointer.
+	// - 'm' is the **m**aybe which communicates our completeness to the parent if we're a child assembler.
+	// - 'state' is what it says on the tin.  this is used for the list of entries.
+	// - 'em' and 'es' are the **e**ntry **m**aybe and **e**ntry **s**tate; see genpartsListpairs.go.
+	//
+	// - 'cm' is **c**hild **m**aybe and is used for the completion message from values, if they aren't allowed to be nullable.
+	//    Keys don't need it: at the representation level, they're always assigned as a string in one step.
+	// - 'va' is the value assembler, and is set up by the key assembler in the same way as the map representation does in AssembleEntry.
+	doTemplate(`
+		type _{{ .Type | TypeSymbol }}__ReprAssembler struct {
+			w *_{{ .Type | TypeSymbol }}
+			m *schema.Maybe
+			state laState
+			em schema.Maybe
+			es maState
+
+			cm schema.Maybe
+			va _{{ .Type.ValueType | TypeSymbol }}__ReprAssembler
+		}
+
+		func (na *_{{ .Type | TypeSymbol }}__ReprAssembler) reset() {
+			na.state = laState_initial
+			na.em = schema.Maybe_Absent
+			na.es = maState_initial
+			na.va.reset()
+		}
+	`, w, g.AdjCfg, g)
+}
+func (g mapReprListpairsReprBuilderGenerator) EmitNodeAssemblerMethodBeginList(w io.Writer) {
+	// Same as the mapoid BeginMap, aside from the method name:
+	//  we still need the map and its rows allocated, and the size hint is the number of entries either way.
+	doTemplate(`
+		func (na *_{{ .Type | TypeSymbol }}__ReprAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+			switch *na.m {
+			case schema.Maybe_Value, schema.Maybe_Null:
+				panic("invalid state: cannot assign into assembler that's already finished")
+			case midvalue:
+				panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+			}
+			*na.m = midvalue
+			if sizeHint < 0 {
+				sizeHint = 0
+			}
+			{{- if .Type | MaybeUsesPtr }}
+			if na.w == nil {
+				na.w = &_{{ .Type | TypeSymbol }}{}
+			}
+			{{- end}}
+			na.w.m = make(map[_{{ .Type.KeyType | TypeSymbol }}]{{if .Type.ValueIsNullable }}Maybe{{else}}*_{{end}}{{ .Type.ValueType | TypeSymbol }}, sizeHint)
+			na.w.t = make([]_{{ .Type | TypeSymbol }}__entry, 0, sizeHint)
+			return na, nil
+		}
+	`, w, g.AdjCfg, g)
+}
+func (g mapReprListpairsReprBuilderGenerator) EmitNodeAssemblerMethodAssignNull(w io.Writer) {
+	emitNodeAssemblerMethodAssignNull_recursive(w, g.AdjCfg, g)
+}
+func (g mapReprListpairsReprBuilderGenerator) EmitNodeAssemblerMethodAssignNode(w io.Writer) {
+	emitNodeAssemblerMethodAssignNode_listpairs(w, g.AdjCfg, g)
+}
+func (g mapReprListpairsReprBuilderGenerator) EmitNodeAssemblerOtherBits(w io.Writer) {
+	g.emitValueTidyHelper(w)
+	g.emitListAssemblerMethods(w)
+	emitNodeAssemblerHelper_listpairs_entryAssembler(w, g.AdjCfg, g, g.PkgName, g.TypeName, g.AppliedPrefix)
+	g.emitEntryListAssemblerMethods(w)
+	g.emitKeyAssembler(w)
+}
+func (g mapReprListpairsReprBuilderGenerator) emitValueTidyHelper(w io.Writer) {
+	// This is the mapoid valueFinishTidy, except for which state it moves on to.
+	doTemplate(`
+		func (la *_{{ .Type | TypeSymbol }}__ReprAssembler) valueFinishTidy() bool {
+			{{- if .Type.ValueIsNullable }}
+			tz := &la.w.t[len(la.w.t)-1]
+			switch tz.v.m {
+			case schema.Maybe_Null:
+				la.es = maState_finished
+				la.va.reset()
+				return true
+			case schema.Maybe_Value:
+				{{- if (MaybeUsesPtr .Type.ValueType) }}
+				tz.v.v = la.va.w
+				{{- end}}
+				la.va.w = nil
+				la.es = maState_finished
+				la.va.reset()
+				return true
+			{{- else}}
+			switch la.cm {
+			case schema.Maybe_Value:
+				la.va.w = nil
+				la.cm = schema.Maybe_Absent
+				la.es = maState_finished
+				la.va.reset()
+				return true
+			{{- end}}
+			default:
+				return false
+			}
+		}
+	`, w, g.AdjCfg, g)
+}
+func (g mapReprListpairsReprBuilderGenerator) emitListAssemblerMethods(w io.Writer) {
+	doTemplate(`
+		func (la *_{{ .Type | TypeSymbol }}__ReprAssembler) Finish() error {
+			{{- if Compact }}
+			if la.state == laState_midValue {
+				la.entryFinishTidy()
+			}
+			gensupport.ListAssemblerCheckState("Finish", uint8(la.state))
+			{{- else }}
+			switch la.state {
+			case laState_initial:
+				// carry on
+			case laState_midValue:
+				if !la.entryFinishTidy() {
+					panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+				} // if tidy success: carry on
+			case laState_finished:
+				panic("invalid state: Finish cannot be called on an assembler that's already finished")
+			}
+			{{- end}}
+			la.state = laState_finished
+			*la.m = schema.Maybe_Value
+			return nil
+		}
+	`, w, g.AdjCfg, g)
+}
+func (g mapReprListpairsReprBuilderGenerator) emitEntryListAssemblerMethods(w io.Writer) {
+	doTemplate(`
+		func (ea *_{{ .Type | TypeSymbol }}__ReprEntryAssembler) AssembleValue() ipld.NodeAssembler {
+			switch ea.es {
+			case maState_initial:
+				ea.es = maState_midKey
+				return (*_{{ .Type | TypeSymbol }}__ReprEntryKeyAssembler)(ea)
+			case maState_midKey:
+				panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+			case maState_expectValue:
+				ea.es = maState_midValue
+				return &ea.va
+			case maState_midValue:
+				if !(*_{{ .Type | TypeSymbol }}__ReprAssembler)(ea).valueFinishTidy() {
+					panic("invalid state: AssembleValue cannot be called when in the middle of assembling a value")
+				} // if tidy success: carry on
+			}
+			return _ErrorThunkAssembler{ipld.ErrUnmatchable{TypeName: "{{ .PkgName }}.{{ .Type.Name }}.Repr"}.Reasonf("expected a list of a key and a value, got a longer list")}
+		}
+		func (ea *_{{ .Type | TypeSymbol }}__ReprEntryAssembler) ValuePrototype(idx int64) ipld.NodePrototype {
+			if idx == 0 {
+				return _{{ .Type.KeyType | TypeSymbol }}__ReprPrototype{}
+			}
+			return _{{ .Type.ValueType | TypeSymbol }}__ReprPrototype{}
+		}
+	`, w, g.AdjCfg, g)
+}
+func (g mapReprListpairsReprBuilderGenerator) emitKeyAssembler(w io.Writer) {
+	doTemplate(`
+		type _{{ .Type | TypeSymbol }}__ReprEntryKeyAssembler _{{ .Type | TypeSymbol }}__ReprAssembler
+	`, w, g.AdjCfg, g)
+	stubs := mixins.StringAssemblerTraits{
+		PkgName:       g.PkgName,
+		TypeName:      g.TypeName + ".Entry.KeyAssembler", // ".Repr" is already in `g.TypeName`, so don't stutter the "Repr" part.
+		AppliedPrefix: "_" + g.AdjCfg.TypeSymbol(g.Type) + "__ReprEntryKey",
+	}
+	// Keys are always plain strings at the representation level, so this can do the same thing as AssembleEntry does for the map representation.
+	stubs.EmitNodeAssemblerMethodBeginMap(w)
+	stubs.EmitNodeAssemblerMethodBeginList(w)
+	stubs.EmitNodeAssemblerMethodAssignNull(w)
+	stubs.EmitNodeAssemblerMethodAssignBool(w)
+	stubs.EmitNodeAssemblerMethodAssignInt(w)
+	stubs.EmitNodeAssemblerMethodAssignFloat(w)
+	doTemplate(`
+		func (ka *_{{ .Type | TypeSymbol }}__ReprEntryKeyAssembler) AssignString(k string) error {
+			if ka.es != maState_midKey {
+				panic("misuse: KeyAssembler held beyond its valid lifetime")
+			}
+			var k2 _{{ .Type.KeyType | TypeSymbol }}
+			if err := (_{{ .Type.KeyType | TypeSymbol }}__ReprPrototype{}).fromString(&k2, k); err != nil {
+				{{- if eq .Type.KeyType.TypeKind.ActsLike.String "string" }}
+				return err
+				{{- else}}
+				return ipld.ErrInvalidKey{TypeName: "{{ .PkgName }}.{{ .Type.Name }}.Repr", Key: &_String{k}, Reason: err}
+				{{- end}}
+			}
+			if _, exists := ka.w.m[k2]; exists {
+				return ipld.ErrRepeatedMapKey{Key: &k2}
+			}
+			ka.w.t = append(ka.w.t, _{{ .Type | TypeSymbol }}__entry{k: k2})
+			tz := &ka.w.t[len(ka.w.t)-1]
+			ka.es = maState_expectValue
+
+			ka.w.m[k2] = &tz.v
+			{{- if .Type.ValueIsNullable }}
+			{{- if not (MaybeUsesPtr .Type.ValueType) }}
+			ka.va.w = &tz.v.v
+			{{- end}}
+			ka.va.m = &tz.v.m
+			tz.v.m = allowNull
+			{{- else}}
+			ka.va.w = &tz.v
+			ka.va.m = &ka.cm
+			{{- end}}
+			return nil
+		}
+	`, w, g.AdjCfg, g)
+	stubs.EmitNodeAssemblerMethodAssignBytes(w)
+	stubs.EmitNodeAssemblerMethodAssignLink(w)
+	doTemplate(`
+		func (ka *_{{ .Type | TypeSymbol }}__ReprEntryKeyAssembler) AssignNode(v ipld.Node) error {
+			if v2, err := v.AsString(); err != nil {
+				return err
+			} else {
+				return ka.AssignString(v2)
+			}
+		}
+		func (_{{ .Type | TypeSymbol }}__ReprEntryKeyAssembler) Prototype() ipld.NodePrototype {
+			return _{{ .Type.KeyType | TypeSymbol }}__ReprPrototype{}
+		}
+	`, w, g.AdjCfg, g)
+}
@@ -67,9 +67,19 @@ func (g mapReprMapReprGenerator) EmitNodeTypeAssertions(w io.Writer) {
 }
 
 func (g mapReprMapReprGenerator) EmitNodeMethodLookupByString(w io.Writer) {
+	// The key is parsed as the key type's representation, which isn't always the same as its type-level string;
+	//  enums with renamed members are one example.
 	doTemplate(`
 		func (nr *_{{ .Type | TypeSymbol }}__Repr) LookupByString(k string) (ipld.Node, error) {
-			v, err := ({{ .Type | TypeSymbol }})(nr).LookupByString(k)
+			var k2 _{{ .Type.KeyType | TypeSymbol }}
+			if err := (_{{ .Type.KeyType | TypeSymbol }}__ReprPrototype{}).fromString(&k2, k); err != nil {
+				{{- if eq .Type.KeyType.TypeKind.ActsLike.String "string" }}
+				return nil, err
+				{{- else}}
+				return nil, ipld.ErrInvalidKey{TypeName: "{{ .PkgName }}.{{ .Type.Name }}.Repr", Key: &_String{k}, Reason: err}
+				{{- end}}
+			}
+			v, err := ({{ .Type | TypeSymbol }})(nr).LookupByNode(&k2)
 			if err != nil || v == ipld.Null {
 				return v, err
 			}
@@ -81,8 +91,14 @@ func (g mapReprMapReprGenerator) EmitNodeMethodLookupByNode(w io.Writer) {
 	// Null is also already a branch in the method we're calling; hopefully the compiler inlines and sees this and DTRT.
 	// REVIEW: these unchecked casts are definitely safe at compile time, but I'm not sure if the compiler considers that provable,
 	//  so we should investigate if there's any runtime checks injected here that waste time.  If so: write this with more gsloc to avoid :(
+	// Strings have to be handled here rather than by the type-level method, since they're parsed as the key type's representation.
 	doTemplate(`
 		func (nr *_{{ .Type | TypeSymbol }}__Repr) LookupByNode(k ipld.Node) (ipld.Node, error) {
+			if _, ok := k.({{ .Type.KeyType | TypeSymbol }}); !ok {
+				if ks, err := k.AsString(); err == nil {
+					return nr.LookupByString(ks)
+				}
+			}
 			v, err := ({{ .Type | TypeSymbol }})(nr).LookupByNode(k)
 			if err != nil || v == ipld.Null {
 				return v, err
@@ -102,7 +118,11 @@ func (g mapReprMapReprGenerator) EmitNodeMethodMapIterator(w io.Writer) {
 
 		func (itr *_{{ .Type | TypeSymbol }}__ReprMapItr) Next() (k ipld.Node, v ipld.Node, err error) {
 			k, v, err = (*_{{ .Type | TypeSymbol }}__MapItr)(itr).Next()
-			if err != nil || v == ipld.Null {
+			if err != nil {
+				return
+			}
+			k = k.({{ .Type.KeyType | TypeSymbol }}).Representation()
+			if v == ipld.Null {
 				return
 			}
 			return k, v.({{ .Type.ValueType | TypeSymbol}}).Representation(), nil
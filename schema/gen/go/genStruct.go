@@ -20,7 +20,8 @@ func (structGenerator) IsRepr() bool { return false } // hint used in some gener
 
 func (g structGenerator) EmitNativeType(w io.Writer) {
 	doTemplate(`
-		{{- if Comments -}}
+		{{- Doc .Type.Doc }}
+		{{- if Comments }}
 		// {{ .Type | TypeSymbol }} matches the IPLD Schema type "{{ .Type.Name }}".  It has {{ .Type.TypeKind }} type-kind, and may be interrogated like {{ .Kind }} kind.
 		{{- end}}
 		type {{ .Type | TypeSymbol }} = *_{{ .Type | TypeSymbol }}
@@ -36,6 +37,11 @@ func (g structGenerator) EmitNativeAccessors(w io.Writer) {
 	doTemplate(`
 		{{- $type := .Type -}} {{- /* ranging modifies dot, unhelpfully */ -}}
 		{{- range $field := .Type.Fields }}
+
+		{{ Doc $field.Doc }}
+		{{- if Comments }}
+		// Field{{ $field | FieldSymbolUpper }} returns the value of the field "{{ $field.Name }}".
+		{{- end}}
 		func (n _{{ $type | TypeSymbol }}) Field{{ $field | FieldSymbolUpper }}() {{ if $field.IsMaybe }}Maybe{{end}}{{ $field.Type | TypeSymbol }} {
 			return &n.{{ $field | FieldSymbolLower }}
 		}
@@ -408,6 +414,12 @@ func (g structBuilderGenerator) emitMapAssemblerMethods(w io.Writer) {
 	// FUTURE: some of the setup of the child assemblers could probably be DRY'd up.
 	doTemplate(`
 		func (ma *_{{ .Type | TypeSymbol }}__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+			{{- if Compact }}
+			if ma.state == maState_midValue {
+				ma.valueFinishTidy()
+			}
+			gensupport.MapAssemblerExpectKey("AssembleEntry", uint8(ma.state))
+			{{- else }}
 			switch ma.state {
 			case maState_initial:
 				// carry on
@@ -422,6 +434,7 @@ func (g structBuilderGenerator) emitMapAssemblerMethods(w io.Writer) {
 			case maState_finished:
 				panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
 			}
+			{{- end}}
 			{{- $type := .Type -}} {{- /* ranging modifies dot, unhelpfully */ -}}
 			{{- if .Type.Fields }}
 			switch k {
@@ -450,6 +463,12 @@ func (g structBuilderGenerator) emitMapAssemblerMethods(w io.Writer) {
 			return nil, ipld.ErrInvalidKey{TypeName:"{{ .PkgName }}.{{ .Type.Name }}", Key:&_String{k}}
 		}
 		func (ma *_{{ .Type | TypeSymbol }}__Assembler) AssembleKey() ipld.NodeAssembler {
+			{{- if Compact }}
+			if ma.state == maState_midValue {
+				ma.valueFinishTidy()
+			}
+			gensupport.MapAssemblerExpectKey("AssembleKey", uint8(ma.state))
+			{{- else }}
 			switch ma.state {
 			case maState_initial:
 				// carry on
@@ -464,10 +483,14 @@ func (g structBuilderGenerator) emitMapAssemblerMethods(w io.Writer) {
 			case maState_finished:
 				panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
 			}
+			{{- end}}
 			ma.state = maState_midKey
 			return (*_{{ .Type | TypeSymbol }}__KeyAssembler)(ma)
 		}
 		func (ma *_{{ .Type | TypeSymbol }}__Assembler) AssembleValue() ipld.NodeAssembler {
+			{{- if Compact }}
+			gensupport.MapAssemblerExpectValue("AssembleValue", uint8(ma.state))
+			{{- else }}
 			switch ma.state {
 			case maState_initial:
 				panic("invalid state: AssembleValue cannot be called when no key is primed")
@@ -480,6 +503,7 @@ func (g structBuilderGenerator) emitMapAssemblerMethods(w io.Writer) {
 			case maState_finished:
 				panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
 			}
+			{{- end}}
 			ma.state = maState_midValue
 			switch ma.f {
 			{{- range $i, $field := .Type.Fields }}
@@ -501,6 +525,12 @@ func (g structBuilderGenerator) emitMapAssemblerMethods(w io.Writer) {
 			}
 		}
 		func (ma *_{{ .Type | TypeSymbol }}__Assembler) Finish() error {
+			{{- if Compact }}
+			if ma.state == maState_midValue {
+				ma.valueFinishTidy()
+			}
+			gensupport.MapAssemblerExpectKey("Finish", uint8(ma.state))
+			{{- else }}
 			switch ma.state {
 			case maState_initial:
 				// carry on
@@ -515,10 +545,11 @@ func (g structBuilderGenerator) emitMapAssemblerMethods(w io.Writer) {
 			case maState_finished:
 				panic("invalid state: Finish cannot be called on an assembler that's already finished")
 			}
+			{{- end}}
 			if ma.s & fieldBits__{{ $type | TypeSymbol }}_sufficient != fieldBits__{{ $type | TypeSymbol }}_sufficient {
 				err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
 				{{- range $i, $field := .Type.Fields }}
-				{{- if not $field.IsMaybe}}
+				{{- if not $field.IsOptional }}
 				if ma.s & fieldBit__{{ $type | TypeSymbol }}_{{ $field | FieldSymbolUpper }} == 0 {
 					err.Missing = append(err.Missing, "{{ $field.Name }}")
 				}
@@ -526,6 +557,18 @@ func (g structBuilderGenerator) emitMapAssemblerMethods(w io.Writer) {
 				{{- end}}
 				return err
 			}
+			{{- if (eq (.Type.RepresentationStrategy | printf "%T") "schema.StructRepresentation_Tuple") }}
+			{{- /* the tuple representation can only leave out trailing fields, so an optional field can't be absent if the next one isn't. */ -}}
+			{{- $prev := "" }}
+			{{- range $field := .Type.Fields }}
+			{{- if and $prev $field.IsOptional }}
+			if ma.w.{{ $prev | FieldSymbolLower }}.m == schema.Maybe_Absent && ma.w.{{ $field | FieldSymbolLower }}.m != schema.Maybe_Absent {
+				return ipld.ErrMissingRequiredField{Missing: []string{"{{ $prev.Name }} (needed by the tuple representation, since {{ $field.Name }} is set)"}}
+			}
+			{{- end}}
+			{{- if $field.IsOptional }}{{ $prev = $field }}{{ else }}{{ $prev = "" }}{{ end }}
+			{{- end}}
+			{{- end}}
 			ma.state = maState_finished
 			*ma.m = schema.Maybe_Value
 			return nil
@@ -560,6 +603,7 @@ func (g structBuilderGenerator) emitKeyAssembler(w io.Writer) {
 			if ka.state != maState_midKey {
 				panic("misuse: KeyAssembler held beyond its valid lifetime")
 			}
+			{{- if .Type.Fields }}
 			switch k {
 			{{- $type := .Type -}} {{- /* ranging modifies dot, unhelpfully */ -}}
 			{{- range $i, $field := .Type.Fields }}
@@ -575,6 +619,9 @@ func (g structBuilderGenerator) emitKeyAssembler(w io.Writer) {
 				return ipld.ErrInvalidKey{TypeName:"{{ .PkgName }}.{{ .Type.Name }}", Key:&_String{k}}
 			}
 			return nil
+			{{- else }}
+			return ipld.ErrInvalidKey{TypeName:"{{ .PkgName }}.{{ .Type.Name }}", Key:&_String{k}}
+			{{- end }}
 		}
 	`, w, g.AdjCfg, g)
 	stubs.EmitNodeAssemblerMethodAssignBytes(w)
@@ -0,0 +1,562 @@
+package gengo
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/ipld/go-ipld-prime/schema"
+	"github.com/ipld/go-ipld-prime/schema/gen/go/mixins"
+)
+
+var _ TypeGenerator = &structReprListpairsGenerator{}
+
+// The listpairs representation of a struct is a list of entries, each of which is a list of a field name and its value.
+//  Absent optional fields don't get an entry, the same as in the map representation; and there are no renames.
+// Entries can come in any order when assembling, but are always emitted in the order of the fields.
+//  Unlike with maps, the entry nodes aren't already sitting in memory, so iterating allocates one per field.
+// See genpartsListpairs.go for the parts that are shared with the listpairs representation of maps.
+
+func NewStructReprListpairsGenerator(pkgName string, typ *schema.TypeStruct, adjCfg *AdjunctCfg) TypeGenerator {
+	return structReprListpairsGenerator{
+		structGenerator{
+			adjCfg,
+			mixins.MapTraits{
+				PkgName:    pkgName,
+				TypeName:   string(typ.Name()),
+				TypeSymbol: adjCfg.TypeSymbol(typ),
+			},
+			pkgName,
+			typ,
+		},
+	}
+}
+
+type structReprListpairsGenerator struct {
+	structGenerator
+}
+
+func (g structReprListpairsGenerator) GetRepresentationNodeGen() NodeGenerator {
+	return structReprListpairsReprGenerator{
+		g.AdjCfg,
+		mixins.ListTraits{
+			PkgName:    g.PkgName,
+			TypeName:   string(g.Type.Name()) + ".Repr",
+			TypeSymbol: "_" + g.AdjCfg.TypeSymbol(g.Type) + "__Repr",
+		},
+		g.PkgName,
+		g.Type,
+	}
+}
+
+type structReprListpairsReprGenerator struct {
+	AdjCfg *AdjunctCfg
+	mixins.ListTraits
+	PkgName string
+	Type    *schema.TypeStruct
+}
+
+func (structReprListpairsReprGenerator) IsRepr() bool { return true } // hint used in some generalized templates.
+
+func (g structReprListpairsReprGenerator) EmitNodeType(w io.Writer) {
+	// The type is structurally the same, but will have a different set of methods.
+	doTemplate(`
+		type _{{ .Type | TypeSymbol }}__Repr _{{ .Type | TypeSymbol }}
+	`, w, g.AdjCfg, g)
+
+	// Constants for the field names, same as the map representation has; the iterator and the key assembler both use them.
+	doTemplate(`
+		var (
+			{{- $type := .Type -}} {{- /* ranging modifies dot, unhelpfully */ -}}
+			{{- range $field := .Type.Fields }}
+			fieldName__{{ $type | TypeSymbol }}_{{ $field | FieldSymbolUpper }}_serial = _String{"{{ $field.Name }}"}
+			{{- end }}
+		)
+	`, w, g.AdjCfg, g)
+}
+
+func (g structReprListpairsReprGenerator) EmitNodeTypeAssertions(w io.Writer) {
+	doTemplate(`
+		var _ ipld.Node = &_{{ .Type | TypeSymbol }}__Repr{}
+	`, w, g.AdjCfg, g)
+}
+
+func (g structReprListpairsReprGenerator) EmitNodeMethodLookupByIndex(w io.Writer) {
+	// Which field an index refers to depends on which optional fields before it are absent,
+	//  so it's simplest to let the iterator work that out.
+	doTemplate(`
+		func (n *_{{ .Type | TypeSymbol }}__Repr) LookupByIndex(idx int64) (ipld.Node, error) {
+			itr := n.ListIterator()
+			for !itr.Done() {
+				i, v, err := itr.Next()
+				if err != nil {
+					return nil, err
+				}
+				if i == idx {
+					return v, nil
+				}
+			}
+			return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfInt(idx)}
+		}
+	`, w, g.AdjCfg, g)
+}
+
+func (g structReprListpairsReprGenerator) EmitNodeMethodLookupByNode(w io.Writer) {
+	doTemplate(`
+		func (n *_{{ .Type | TypeSymbol }}__Repr) LookupByNode(key ipld.Node) (ipld.Node, error) {
+			ki, err := key.AsInt()
+			if err != nil {
+				return nil, err
+			}
+			return n.LookupByIndex(ki)
+		}
+	`, w, g.AdjCfg, g)
+}
+
+func (g structReprListpairsReprGenerator) EmitNodeMethodListIterator(w io.Writer) {
+	// This is the same as the map representation's iterator, except that each step yields an entry node,
+	//  and it has to count the entries separately from the fields, since absent fields are skipped.
+	// The 'idx' int is what field we'll look at next; the 'i' int is the index of the next entry we'll yield.
+	fields := g.Type.Fields()
+	haveOptionals := false
+	for _, field := range fields {
+		if field.IsOptional() {
+			haveOptionals = true
+			break
+		}
+	}
+	fieldCount := len(fields)
+	beginTrailingOptionalField := fieldCount
+	for i := fieldCount - 1; i >= 0; i-- {
+		if !fields[i].IsOptional() {
+			break
+		}
+		beginTrailingOptionalField = i
+	}
+	haveTrailingOptionals := beginTrailingOptionalField < fieldCount
+
+	doTemplate(`
+		func (n *_{{ .Type | TypeSymbol }}__Repr) ListIterator() ipld.ListIterator {
+			{{- if .HaveTrailingOptionals }}
+			end := {{ len .Type.Fields }}`+
+		func() string { // this next part was too silly in templates due to lack of reverse ranging.
+			v := "\n"
+			for i := fieldCount - 1; i >= beginTrailingOptionalField; i-- {
+				v += "\t\t\tif n." + g.AdjCfg.FieldSymbolLower(fields[i]) + ".m == schema.Maybe_Absent {\n"
+				v += "\t\t\t\tend = " + strconv.Itoa(i) + "\n"
+				v += "\t\t\t} else {\n"
+				v += "\t\t\t\tgoto done\n"
+				v += "\t\t\t}\n"
+			}
+			return v
+		}()+`done:
+			return &_{{ .Type | TypeSymbol }}__ReprListItr{n, 0, 0, end}
+			{{- else}}
+			return &_{{ .Type | TypeSymbol }}__ReprListItr{n, 0, 0}
+			{{- end}}
+		}
+
+		type _{{ .Type | TypeSymbol }}__ReprListItr struct {
+			n   *_{{ .Type | TypeSymbol }}__Repr
+			idx int
+			i   int64
+			{{if .HaveTrailingOptionals }}end int{{end}}
+		}
+
+		func (itr *_{{ .Type | TypeSymbol }}__ReprListItr) Next() (idx int64, v ipld.Node, _ error) {
+			{{- if not .Type.Fields }}
+			return -1, nil, ipld.ErrIteratorOverread{}
+			{{ else -}}
+			{{ if .HaveOptionals }}advance:{{end -}}
+			if itr.idx >= {{ len .Type.Fields }} {
+				return -1, nil, ipld.ErrIteratorOverread{}
+			}
+			var k, fv ipld.Node
+			switch itr.idx {
+			{{- $type := .Type -}} {{- /* ranging modifies dot, unhelpfully */ -}}
+			{{- range $i, $field := .Type.Fields }}
+			case {{ $i }}:
+				k = &fieldName__{{ $type | TypeSymbol }}_{{ $field | FieldSymbolUpper }}_serial
+				{{- if $field.IsOptional }}
+				if itr.n.{{ $field | FieldSymbolLower }}.m == schema.Maybe_Absent {
+					itr.idx++
+					goto advance
+				}
+				{{- end}}
+				{{- if $field.IsNullable }}
+				if itr.n.{{ $field | FieldSymbolLower }}.m == schema.Maybe_Null {
+					fv = ipld.Null
+					break
+				}
+				{{- end}}
+				{{- if $field.IsMaybe }}
+				fv = itr.n.{{ $field | FieldSymbolLower}}.v.Representation()
+				{{- else}}
+				fv = itr.n.{{ $field | FieldSymbolLower}}.Representation()
+				{{- end}}
+			{{- end}}
+			default:
+				panic("unreachable")
+			}
+			idx = itr.i
+			v = &_{{ .Type | TypeSymbol }}__ReprEntry{k, fv}
+			itr.idx++
+			itr.i++
+			return
+			{{- end}}
+		}
+		{{- if .HaveTrailingOptionals }}
+		func (itr *_{{ .Type | TypeSymbol }}__ReprListItr) Done() bool {
+			return itr.idx >= itr.end
+		}
+		{{- else}}
+		func (itr *_{{ .Type | TypeSymbol }}__ReprListItr) Done() bool {
+			return itr.idx >= {{ len .Type.Fields }}
+		}
+		{{- end}}
+	`, w, g.AdjCfg, struct {
+		Type                  *schema.TypeStruct
+		HaveOptionals         bool
+		HaveTrailingOptionals bool
+	}{
+		g.Type,
+		haveOptionals,
+		haveTrailingOptionals,
+	})
+
+	doTemplate(`
+		type _{{ .Type | TypeSymbol }}__ReprEntry struct {
+			k ipld.Node
+			v ipld.Node
+		}
+
+		func (n *_{{ .Type | TypeSymbol }}__ReprEntry) LookupByIndex(idx int64) (ipld.Node, error) {
+			switch idx {
+			case 0:
+				return n.k, nil
+			case 1:
+				return n.v, nil
+			default:
+				return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfInt(idx)}
+			}
+		}
+	`, w, g.AdjCfg, g)
+	emitNodeHelper_listpairs_entryNodeMethods(w, g.AdjCfg, g, g.PkgName, g.TypeName, g.TypeSymbol)
+}
+
+func (g structReprListpairsReprGenerator) EmitNodeMethodLength(w io.Writer) {
+	// This is fun: it has to count down for any unset optional fields.
+	doTemplate(`
+		func (rn *_{{ .Type | TypeSymbol }}__Repr) Length() int64 {
+			l := {{ len .Type.Fields }}
+			{{- range $field := .Type.Fields }}
+			{{- if $field.IsOptional }}
+			if rn.{{ $field | FieldSymbolLower }}.m == schema.Maybe_Absent {
+				l--
+			}
+			{{- end}}
+			{{- end}}
+			return int64(l)
+		}
+	`, w, g.AdjCfg, g)
+}
+
+func (g structReprListpairsReprGenerator) EmitNodeMethodPrototype(w io.Writer) {
+	emitNodeMethodPrototype_typical(w, g.AdjCfg, g)
+}
+
+func (g structReprListpairsReprGenerator) EmitNodePrototypeType(w io.Writer) {
+	emitNodePrototypeType_typical(w, g.AdjCfg, g)
+}
+
+// --- NodeBuilder and NodeAssembler --->
+
+func (g structReprListpairsReprGenerator) GetNodeBuilderGenerator() NodeBuilderGenerator {
+	return structReprListpairsReprBuilderGenerator{
+		g.AdjCfg,
+		mixins.ListAssemblerTraits{
+			PkgName:       g.PkgName,
+			TypeName:      g.TypeName,
+			AppliedPrefix: "_" + g.AdjCfg.TypeSymbol(g.Type) + "__Repr",
+		},
+		g.PkgName,
+		g.Type,
+	}
+}
+
+type structReprListpairsReprBuilderGenerator struct {
+	AdjCfg *AdjunctCfg
+	mixins.ListAssemblerTraits
+	PkgName string
+	Type    *schema.TypeStruct
+}
+
+func (structReprListpairsReprBuilderGenerator) IsRepr() bool { return true } // hint used in some generalized templates.
+
+func (g structReprListpairsReprBuilderGenerator) EmitNodeBuilderType(w io.Writer) {
+	emitEmitNodeBuilderType_typical(w, g.AdjCfg, g)
+}
+func (g structReprListpairsReprBuilderGenerator) EmitNodeBuilderMethods(w io.Writer) {
+	emitNodeBuilderMethods_typical(w, g.AdjCfg, g)
+}
+func (g structReprListpairsReprBuilderGenerator) EmitNodeAssemblerType(w io.Writer) {
+	// - 'w' is the "**w**ip" pointer.
+	// - 'm' is the **m**aybe which communicates our completeness to the parent if we're a child assembler.
+	// - 'state' is what it says on the tin.  this is used for the list of entries.
+	// - 'em' and 'es' are the **e**ntry **m**aybe and **e**ntry **s**tate; see genpartsListpairs.go.
+	// - 's' is a bitfield for what's been **s**et.
+	// - 'f' is the **f**ocused field that will be assembled next.
+	//
+	// - 'cm' is **c**hild **m**aybe and is used for the completion message from children that aren't allowed to be nullable (for those that are, their own maybe.m is used).
+	// - the 'ca_*' fields embed **c**hild **a**ssemblers -- these are embedded so we can yield pointers to them without causing new allocations.
+	doTemplate(`
+		type _{{ .Type | TypeSymbol }}__ReprAssembler struct {
+			w *_{{ .Type | TypeSymbol }}
+			m *schema.Maybe
+			state laState
+			em schema.Maybe
+			es maState
+			s int
+			f int
+
+			cm schema.Maybe
+			{{range $field := .Type.Fields -}}
+			ca_{{ $field | FieldSymbolLower }} _{{ $field.Type | TypeSymbol }}__ReprAssembler
+			{{end -}}
+		}
+
+		func (na *_{{ .Type | TypeSymbol }}__ReprAssembler) reset() {
+			na.state = laState_initial
+			na.em = schema.Maybe_Absent
+			na.es = maState_initial
+			na.s = 0
+			{{- range $field := .Type.Fields }}
+			na.ca_{{ $field | FieldSymbolLower }}.reset()
+			{{- end}}
+		}
+	`, w, g.AdjCfg, g)
+}
+func (g structReprListpairsReprBuilderGenerator) EmitNodeAssemblerMethodBeginList(w io.Writer) {
+	// Future: This could do something strict with the sizehint; it currently ignores it.
+	doTemplate(`
+		func (na *_{{ .Type | TypeSymbol }}__ReprAssembler) BeginList(int64) (ipld.ListAssembler, error) {
+			switch *na.m {
+			case schema.Maybe_Value, schema.Maybe_Null:
+				panic("invalid state: cannot assign into assembler that's already finished")
+			case midvalue:
+				panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+			}
+			*na.m = midvalue
+			{{- if .Type | MaybeUsesPtr }}
+			if na.w == nil {
+				na.w = &_{{ .Type | TypeSymbol }}{}
+			}
+			{{- end}}
+			return na, nil
+		}
+	`, w, g.AdjCfg, g)
+}
+func (g structReprListpairsReprBuilderGenerator) EmitNodeAssemblerMethodAssignNull(w io.Writer) {
+	emitNodeAssemblerMethodAssignNull_recursive(w, g.AdjCfg, g)
+}
+func (g structReprListpairsReprBuilderGenerator) EmitNodeAssemblerMethodAssignNode(w io.Writer) {
+	emitNodeAssemblerMethodAssignNode_listpairs(w, g.AdjCfg, g)
+}
+func (g structReprListpairsReprBuilderGenerator) EmitNodeAssemblerOtherBits(w io.Writer) {
+	g.emitValueTidyHelper(w)
+	g.emitListAssemblerMethods(w)
+	emitNodeAssemblerHelper_listpairs_entryAssembler(w, g.AdjCfg, g, g.PkgName, g.TypeName, g.AppliedPrefix)
+	g.emitEntryListAssemblerMethods(w)
+	g.emitKeyAssembler(w)
+}
+func (g structReprListpairsReprBuilderGenerator) emitValueTidyHelper(w io.Writer) {
+	// This is the same as the map representation's valueFinishTidy, except for which state it moves on to.
+	doTemplate(`
+		func (la *_{{ .Type | TypeSymbol }}__ReprAssembler) valueFinishTidy() bool {
+			switch la.f {
+			{{- range $i, $field := .Type.Fields }}
+			case {{ $i }}:
+				{{- if $field.IsNullable }}
+				switch la.w.{{ $field | FieldSymbolLower }}.m {
+				case schema.Maybe_Null:
+					la.es = maState_finished
+					return true
+				case schema.Maybe_Value:
+					{{- if (MaybeUsesPtr $field.Type) }}
+					la.w.{{ $field | FieldSymbolLower }}.v = la.ca_{{ $field | FieldSymbolLower }}.w
+					{{- end}}
+					la.es = maState_finished
+					return true
+				default:
+					return false
+				}
+				{{- else if $field.IsOptional }}
+				switch la.w.{{ $field | FieldSymbolLower }}.m {
+				case schema.Maybe_Value:
+					{{- if (MaybeUsesPtr $field.Type) }}
+					la.w.{{ $field | FieldSymbolLower }}.v = la.ca_{{ $field | FieldSymbolLower }}.w
+					{{- end}}
+					la.es = maState_finished
+					return true
+				default:
+					return false
+				}
+				{{- else}}
+				switch la.cm {
+				case schema.Maybe_Value:
+					la.cm = schema.Maybe_Absent
+					la.es = maState_finished
+					return true
+				default:
+					return false
+				}
+				{{- end}}
+			{{- end}}
+			default:
+				panic("unreachable")
+			}
+		}
+	`, w, g.AdjCfg, g)
+}
+func (g structReprListpairsReprBuilderGenerator) emitListAssemblerMethods(w io.Writer) {
+	doTemplate(`
+		func (la *_{{ .Type | TypeSymbol }}__ReprAssembler) Finish() error {
+			{{- if Compact }}
+			if la.state == laState_midValue {
+				la.entryFinishTidy()
+			}
+			gensupport.ListAssemblerCheckState("Finish", uint8(la.state))
+			{{- else }}
+			switch la.state {
+			case laState_initial:
+				// carry on
+			case laState_midValue:
+				if !la.entryFinishTidy() {
+					panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+				} // if tidy success: carry on
+			case laState_finished:
+				panic("invalid state: Finish cannot be called on an assembler that's already finished")
+			}
+			{{- end}}
+			{{- $type := .Type }} {{- /* ranging modifies dot, unhelpfully */}}
+			if la.s & fieldBits__{{ $type | TypeSymbol }}_sufficient != fieldBits__{{ $type | TypeSymbol }}_sufficient {
+				err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+				{{- range $i, $field := .Type.Fields }}
+				{{- if not $field.IsOptional }}
+				if la.s & fieldBit__{{ $type | TypeSymbol }}_{{ $field | FieldSymbolUpper }} == 0 {
+					err.Missing = append(err.Missing, "{{ $field.Name }}")
+				}
+				{{- end}}
+				{{- end}}
+				return err
+			}
+			la.state = laState_finished
+			*la.m = schema.Maybe_Value
+			return nil
+		}
+	`, w, g.AdjCfg, g)
+}
+func (g structReprListpairsReprBuilderGenerator) emitEntryListAssemblerMethods(w io.Writer) {
+	doTemplate(`
+		func (ea *_{{ .Type | TypeSymbol }}__ReprEntryAssembler) AssembleValue() ipld.NodeAssembler {
+			switch ea.es {
+			case maState_initial:
+				ea.es = maState_midKey
+				return (*_{{ .Type | TypeSymbol }}__ReprEntryKeyAssembler)(ea)
+			case maState_midKey:
+				panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+			case maState_expectValue:
+				ea.es = maState_midValue
+				switch ea.f {
+				{{- range $i, $field := .Type.Fields }}
+				case {{ $i }}:
+					{{- if $field.IsMaybe }}
+					ea.ca_{{ $field | FieldSymbolLower }}.w = {{if not (MaybeUsesPtr $field.Type) }}&{{end}}ea.w.{{ $field | FieldSymbolLower }}.v
+					ea.ca_{{ $field | FieldSymbolLower }}.m = &ea.w.{{ $field | FieldSymbolLower }}.m
+					{{if $field.IsNullable }}ea.w.{{ $field | FieldSymbolLower }}.m = allowNull{{end}}
+					{{- else}}
+					ea.ca_{{ $field | FieldSymbolLower }}.w = &ea.w.{{ $field | FieldSymbolLower }}
+					ea.ca_{{ $field | FieldSymbolLower }}.m = &ea.cm
+					{{- end}}
+					return &ea.ca_{{ $field | FieldSymbolLower }}
+				{{- end}}
+				default:
+					panic("unreachable")
+				}
+			case maState_midValue:
+				if !(*_{{ .Type | TypeSymbol }}__ReprAssembler)(ea).valueFinishTidy() {
+					panic("invalid state: AssembleValue cannot be called when in the middle of assembling a value")
+				} // if tidy success: carry on
+			}
+			return _ErrorThunkAssembler{ipld.ErrUnmatchable{TypeName: "{{ .PkgName }}.{{ .Type.Name }}.Repr"}.Reasonf("expected a list of a key and a value, got a longer list")}
+		}
+		func (ea *_{{ .Type | TypeSymbol }}__ReprEntryAssembler) ValuePrototype(idx int64) ipld.NodePrototype {
+			if idx == 0 {
+				return _String__Prototype{}
+			}
+			if ea.es != maState_expectValue && ea.es != maState_midValue {
+				return nil // we don't know which field it is yet.
+			}
+			switch ea.f {
+			{{- range $i, $field := .Type.Fields }}
+			case {{ $i }}:
+				return _{{ $field.Type | TypeSymbol }}__ReprPrototype{}
+			{{- end}}
+			default:
+				return nil
+			}
+		}
+	`, w, g.AdjCfg, g)
+}
+func (g structReprListpairsReprBuilderGenerator) emitKeyAssembler(w io.Writer) {
+	doTemplate(`
+		type _{{ .Type | TypeSymbol }}__ReprEntryKeyAssembler _{{ .Type | TypeSymbol }}__ReprAssembler
+	`, w, g.AdjCfg, g)
+	stubs := mixins.StringAssemblerTraits{
+		PkgName:       g.PkgName,
+		TypeName:      g.TypeName + ".Entry.KeyAssembler", // ".Repr" is already in `g.TypeName`, so don't stutter the "Repr" part.
+		AppliedPrefix: "_" + g.AdjCfg.TypeSymbol(g.Type) + "__ReprEntryKey",
+	}
+	stubs.EmitNodeAssemblerMethodBeginMap(w)
+	stubs.EmitNodeAssemblerMethodBeginList(w)
+	stubs.EmitNodeAssemblerMethodAssignNull(w)
+	stubs.EmitNodeAssemblerMethodAssignBool(w)
+	stubs.EmitNodeAssemblerMethodAssignInt(w)
+	stubs.EmitNodeAssemblerMethodAssignFloat(w)
+	doTemplate(`
+		func (ka *_{{ .Type | TypeSymbol }}__ReprEntryKeyAssembler) AssignString(k string) error {
+			if ka.es != maState_midKey {
+				panic("misuse: KeyAssembler held beyond its valid lifetime")
+			}
+			{{- if .Type.Fields }}
+			switch k {
+			{{- $type := .Type -}} {{- /* ranging modifies dot, unhelpfully */ -}}
+			{{- range $i, $field := .Type.Fields }}
+			case "{{ $field.Name }}":
+				if ka.s & fieldBit__{{ $type | TypeSymbol }}_{{ $field | FieldSymbolUpper }} != 0 {
+					return ipld.ErrRepeatedMapKey{Key: &fieldName__{{ $type | TypeSymbol }}_{{ $field | FieldSymbolUpper }}_serial}
+				}
+				ka.s += fieldBit__{{ $type | TypeSymbol }}_{{ $field | FieldSymbolUpper }}
+				ka.es = maState_expectValue
+				ka.f = {{ $i }}
+				return nil
+			{{- end }}
+			}
+			{{- end }}
+			return ipld.ErrInvalidKey{TypeName:"{{ .PkgName }}.{{ .Type.Name }}.Repr", Key:&_String{k}}
+		}
+	`, w, g.AdjCfg, g)
+	stubs.EmitNodeAssemblerMethodAssignBytes(w)
+	stubs.EmitNodeAssemblerMethodAssignLink(w)
+	doTemplate(`
+		func (ka *_{{ .Type | TypeSymbol }}__ReprEntryKeyAssembler) AssignNode(v ipld.Node) error {
+			if v2, err := v.AsString(); err != nil {
+				return err
+			} else {
+				return ka.AssignString(v2)
+			}
+		}
+		func (_{{ .Type | TypeSymbol }}__ReprEntryKeyAssembler) Prototype() ipld.NodePrototype {
+			return _String__Prototype{}
+		}
+	`, w, g.AdjCfg, g)
+}
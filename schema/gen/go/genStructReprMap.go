@@ -51,6 +51,58 @@ type structReprMapReprGenerator struct {
 
 func (structReprMapReprGenerator) IsRepr() bool { return true } // hint used in some generalized templates.
 
+// Implicits returns the Go literals for the implicit values of fields, keyed by field name.
+func (g structReprMapReprGenerator) Implicits() map[string]string {
+	return structImplicitLiterals(g.Type)
+}
+
+// SkipConds returns, for each field, a condition on the node named by 'recv'
+// which is true when the field is left out of the representation map;
+// or an empty string, for fields which are never left out.
+// That happens when an optional field is absent,
+// or when a field with an implicit value has exactly that value.
+func (g structReprMapReprGenerator) SkipConds(recv string) []string {
+	r := g.Type.RepresentationStrategy().(schema.StructRepresentation_Map)
+	implicits := g.Implicits()
+	conds := make([]string, len(g.Type.Fields()))
+	for i, field := range g.Type.Fields() {
+		fsym := recv + "." + g.AdjCfg.FieldSymbolLower(field)
+		switch {
+		case field.IsOptional():
+			conds[i] = fsym + ".m == schema.Maybe_Absent"
+		case !r.FieldHasImplicit(field):
+		case field.IsNullable():
+			conds[i] = fsym + ".m == schema.Maybe_Value && " + fsym + ".v.x == " + implicits[field.Name()]
+		default:
+			conds[i] = fsym + ".x == " + implicits[field.Name()]
+		}
+	}
+	return conds
+}
+
+// structImplicitLiterals returns the Go literals for the implicit values
+// of a struct's fields, keyed by field name.
+// Implicit values are only allowed on fields of plain string, int, and bool types,
+// whose native types all keep their value in an 'x' field of the matching Go type.
+func structImplicitLiterals(typ *schema.TypeStruct) map[string]string {
+	r := typ.RepresentationStrategy().(schema.StructRepresentation_Map)
+	literals := make(map[string]string)
+	for _, field := range typ.Fields() {
+		switch iv := r.GetImplicit(field).(type) {
+		case nil:
+		case schema.ImplicitValue_String:
+			literals[field.Name()] = strconv.Quote(iv.String())
+		case schema.ImplicitValue_Int:
+			literals[field.Name()] = strconv.Itoa(iv.Int())
+		case schema.ImplicitValue_Bool:
+			literals[field.Name()] = strconv.FormatBool(iv.Bool())
+		default:
+			panic("unsupported implicit value")
+		}
+	}
+	return literals
+}
+
 func (g structReprMapReprGenerator) EmitNodeType(w io.Writer) {
 	// The type is structurally the same, but will have a different set of methods.
 	doTemplate(`
@@ -80,13 +132,15 @@ func (g structReprMapReprGenerator) EmitNodeTypeAssertions(w io.Writer) {
 
 func (g structReprMapReprGenerator) EmitNodeMethodLookupByString(w io.Writer) {
 	// Similar to the type-level method, except any absent fields also return ErrNotExists.
+	//  So do fields left at their implicit value, as they're not in the representation either.
 	doTemplate(`
 		func (n *_{{ .Type | TypeSymbol }}__Repr) LookupByString(key string) (ipld.Node, error) {
+			{{- $skipConds := .SkipConds "n" }}
 			switch key {
-			{{- range $field := .Type.Fields }}
+			{{- range $i, $field := .Type.Fields }}
 			case "{{ $field | $field.Parent.RepresentationStrategy.GetFieldKey }}":
-				{{- if $field.IsOptional }}
-				if n.{{ $field | FieldSymbolLower }}.m == schema.Maybe_Absent {
+				{{- with index $skipConds $i }}
+				if {{ . }} {
 					return ipld.Absent, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(key)}
 				}
 				{{- end}}
@@ -126,14 +180,16 @@ func (g structReprMapReprGenerator) EmitNodeMethodMapIterator(w io.Writer) {
 	//  This makes things a bit trickier -- especially the 'Done' predicate,
 	//   since it may have to do lookahead if there's any optionals at the end of the structure!
 	//  It also means 'idx' can jump ahead by more than one per Next call in order to skip over absent fields.
-	// TODO : support for implicits is still future work.
+	// Fields left at their implicit value are skipped in just the same way,
+	//  so for the purposes of this method, they count as optionals.
 
 	// First: Determine if there are any optionals at all.
 	//  If there are none, some control flow symbols need to not be emitted.
 	fields := g.Type.Fields()
+	skipConds := g.SkipConds("itr.n")
 	haveOptionals := false
-	for _, field := range fields {
-		if field.IsOptional() {
+	for _, cond := range skipConds {
+		if cond != "" {
 			haveOptionals = true
 			break
 		}
@@ -144,11 +200,12 @@ func (g structReprMapReprGenerator) EmitNodeMethodMapIterator(w io.Writer) {
 	fieldCount := len(fields)
 	beginTrailingOptionalField := fieldCount
 	for i := fieldCount - 1; i >= 0; i-- {
-		if !fields[i].IsOptional() {
+		if skipConds[i] == "" {
 			break
 		}
 		beginTrailingOptionalField = i
 	}
+	ctorSkipConds := g.SkipConds("n")
 	haveTrailingOptionals := beginTrailingOptionalField < fieldCount
 
 	// Now: finally we can get on with the actual templating.
@@ -159,7 +216,7 @@ func (g structReprMapReprGenerator) EmitNodeMethodMapIterator(w io.Writer) {
 		func() string { // this next part was too silly in templates due to lack of reverse ranging.
 			v := "\n"
 			for i := fieldCount - 1; i >= beginTrailingOptionalField; i-- {
-				v += "\t\t\tif n." + g.AdjCfg.FieldSymbolLower(fields[i]) + ".m == schema.Maybe_Absent {\n"
+				v += "\t\t\tif " + ctorSkipConds[i] + " {\n"
 				v += "\t\t\t\tend = " + strconv.Itoa(i) + "\n"
 				v += "\t\t\t} else {\n"
 				v += "\t\t\t\tgoto done\n"
@@ -193,8 +250,8 @@ func (g structReprMapReprGenerator) EmitNodeMethodMapIterator(w io.Writer) {
 			{{- range $i, $field := .Type.Fields }}
 			case {{ $i }}:
 				k = &fieldName__{{ $type | TypeSymbol }}_{{ $field | FieldSymbolUpper }}_serial
-				{{- if $field.IsOptional }}
-				if itr.n.{{ $field | FieldSymbolLower }}.m == schema.Maybe_Absent {
+				{{- with index $.SkipConds $i }}
+				if {{ . }} {
 					itr.idx++
 					goto advance
 				}
@@ -229,11 +286,13 @@ func (g structReprMapReprGenerator) EmitNodeMethodMapIterator(w io.Writer) {
 		{{- end}}
 	`, w, g.AdjCfg, struct {
 		Type                       *schema.TypeStruct
+		SkipConds                  []string
 		HaveOptionals              bool
 		HaveTrailingOptionals      bool
 		BeginTrailingOptionalField int
 	}{
 		g.Type,
+		skipConds,
 		haveOptionals,
 		haveTrailingOptionals,
 		beginTrailingOptionalField,
@@ -241,14 +300,14 @@ func (g structReprMapReprGenerator) EmitNodeMethodMapIterator(w io.Writer) {
 }
 
 func (g structReprMapReprGenerator) EmitNodeMethodLength(w io.Writer) {
-	// This is fun: it has to count down for any unset optional fields.
-	// TODO : support for implicits is still future work.
+	// This is fun: it has to count down for any unset optional fields,
+	//  and for any fields left at their implicit value.
 	doTemplate(`
 		func (rn *_{{ .Type | TypeSymbol }}__Repr) Length() int64 {
 			l := {{ len .Type.Fields }}
-			{{- range $field := .Type.Fields }}
-			{{- if $field.IsOptional }}
-			if rn.{{ $field | FieldSymbolLower }}.m == schema.Maybe_Absent {
+			{{- range $cond := .SkipConds "rn" }}
+			{{- if $cond }}
+			if {{ $cond }} {
 				l--
 			}
 			{{- end}}
@@ -290,6 +349,10 @@ type structReprMapReprBuilderGenerator struct {
 
 func (structReprMapReprBuilderGenerator) IsRepr() bool { return true } // hint used in some generalized templates.
 
+func (g structReprMapReprBuilderGenerator) Implicits() map[string]string {
+	return structImplicitLiterals(g.Type)
+}
+
 func (g structReprMapReprBuilderGenerator) EmitNodeBuilderType(w io.Writer) {
 	emitEmitNodeBuilderType_typical(w, g.AdjCfg, g)
 }
@@ -346,7 +409,11 @@ func (g structReprMapReprBuilderGenerator) EmitNodeAssemblerMethodAssignNode(w i
 			if v.IsNull() {
 				return na.AssignNull()
 			}
-			if v2, ok := v.(*_{{ .Type | TypeSymbol }}); ok {
+			v2, ok := v.(*_{{ .Type | TypeSymbol }})
+			if v3, ok3 := v.(*_{{ .Type | TypeSymbol }}__Repr); ok3 {
+				v2, ok = (*_{{ .Type | TypeSymbol }})(v3), true
+			}
+			if ok {
 				switch *na.m {
 				case schema.Maybe_Value, schema.Maybe_Null:
 					panic("invalid state: cannot assign into assembler that's already finished")
@@ -446,6 +513,12 @@ func (g structReprMapReprBuilderGenerator) emitMapAssemblerMethods(w io.Writer)
 	// FUTURE: some of the setup of the child assemblers could probably be DRY'd up.
 	doTemplate(`
 		func (ma *_{{ .Type | TypeSymbol }}__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+			{{- if Compact }}
+			if ma.state == maState_midValue {
+				ma.valueFinishTidy()
+			}
+			gensupport.MapAssemblerExpectKey("AssembleEntry", uint8(ma.state))
+			{{- else }}
 			switch ma.state {
 			case maState_initial:
 				// carry on
@@ -460,6 +533,7 @@ func (g structReprMapReprBuilderGenerator) emitMapAssemblerMethods(w io.Writer)
 			case maState_finished:
 				panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
 			}
+			{{- end}}
 			{{- $type := .Type -}} {{- /* ranging modifies dot, unhelpfully */ -}}
 			{{- if .Type.Fields }}
 			switch k {
@@ -487,6 +561,12 @@ func (g structReprMapReprBuilderGenerator) emitMapAssemblerMethods(w io.Writer)
 			return nil, ipld.ErrInvalidKey{TypeName:"{{ .PkgName }}.{{ .Type.Name }}.Repr", Key:&_String{k}}
 		}
 		func (ma *_{{ .Type | TypeSymbol }}__ReprAssembler) AssembleKey() ipld.NodeAssembler {
+			{{- if Compact }}
+			if ma.state == maState_midValue {
+				ma.valueFinishTidy()
+			}
+			gensupport.MapAssemblerExpectKey("AssembleKey", uint8(ma.state))
+			{{- else }}
 			switch ma.state {
 			case maState_initial:
 				// carry on
@@ -501,10 +581,14 @@ func (g structReprMapReprBuilderGenerator) emitMapAssemblerMethods(w io.Writer)
 			case maState_finished:
 				panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
 			}
+			{{- end}}
 			ma.state = maState_midKey
 			return (*_{{ .Type | TypeSymbol }}__ReprKeyAssembler)(ma)
 		}
 		func (ma *_{{ .Type | TypeSymbol }}__ReprAssembler) AssembleValue() ipld.NodeAssembler {
+			{{- if Compact }}
+			gensupport.MapAssemblerExpectValue("AssembleValue", uint8(ma.state))
+			{{- else }}
 			switch ma.state {
 			case maState_initial:
 				panic("invalid state: AssembleValue cannot be called when no key is primed")
@@ -517,6 +601,7 @@ func (g structReprMapReprBuilderGenerator) emitMapAssemblerMethods(w io.Writer)
 			case maState_finished:
 				panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
 			}
+			{{- end}}
 			ma.state = maState_midValue
 			switch ma.f {
 			{{- range $i, $field := .Type.Fields }}
@@ -536,6 +621,12 @@ func (g structReprMapReprBuilderGenerator) emitMapAssemblerMethods(w io.Writer)
 			}
 		}
 		func (ma *_{{ .Type | TypeSymbol }}__ReprAssembler) Finish() error {
+			{{- if Compact }}
+			if ma.state == maState_midValue {
+				ma.valueFinishTidy()
+			}
+			gensupport.MapAssemblerExpectKey("Finish", uint8(ma.state))
+			{{- else }}
 			switch ma.state {
 			case maState_initial:
 				// carry on
@@ -550,10 +641,24 @@ func (g structReprMapReprBuilderGenerator) emitMapAssemblerMethods(w io.Writer)
 			case maState_finished:
 				panic("invalid state: Finish cannot be called on an assembler that's already finished")
 			}
+			{{- end}}
+			{{- range $field := .Type.Fields }}
+			{{- with index dot.Implicits $field.Name }}
+			if ma.s & fieldBit__{{ $type | TypeSymbol }}_{{ $field | FieldSymbolUpper }} == 0 {
+				{{- if $field.IsNullable }}
+				ma.w.{{ $field | FieldSymbolLower }}.m = schema.Maybe_Value
+				ma.w.{{ $field | FieldSymbolLower }}.v = {{if (MaybeUsesPtr $field.Type) }}&{{end}}_{{ $field.Type | TypeSymbol }}{ {{- . -}} }
+				{{- else}}
+				ma.w.{{ $field | FieldSymbolLower }} = _{{ $field.Type | TypeSymbol }}{ {{- . -}} }
+				{{- end}}
+				ma.s += fieldBit__{{ $type | TypeSymbol }}_{{ $field | FieldSymbolUpper }}
+			}
+			{{- end}}
+			{{- end}}
 			if ma.s & fieldBits__{{ $type | TypeSymbol }}_sufficient != fieldBits__{{ $type | TypeSymbol }}_sufficient {
 				err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
 				{{- range $i, $field := .Type.Fields }}
-				{{- if not $field.IsMaybe}}
+				{{- if not $field.IsOptional }}
 				if ma.s & fieldBit__{{ $type | TypeSymbol }}_{{ $field | FieldSymbolUpper }} == 0 {
 					{{- if $field | $type.RepresentationStrategy.FieldHasRename }}
 					err.Missing = append(err.Missing, "{{ $field.Name }} (serial:\"{{ $field | $type.RepresentationStrategy.GetFieldKey }}\")")
@@ -184,10 +184,14 @@ func (g structReprStringjoinReprBuilderGenerator) EmitNodeAssemblerMethodAssignS
 	//  otherwise, the 'w' ptr should already be set, and we fill that memory location without allocating, as usual.
 	doTemplate(`
 		func (na *_{{ .Type | TypeSymbol }}__ReprAssembler) AssignString(v string) error {
+			{{- if Compact }}
+			gensupport.CheckAssignable(*na.m)
+			{{- else }}
 			switch *na.m {
 			case schema.Maybe_Value, schema.Maybe_Null:
 				panic("invalid state: cannot assign into assembler that's already finished")
 			}
+			{{- end}}
 			{{- if .Type | MaybeUsesPtr }}
 			if na.w == nil {
 				na.w = &_{{ .Type | TypeSymbol }}{}
@@ -207,16 +211,25 @@ func (g structReprStringjoinReprBuilderGenerator) EmitNodeAssemblerMethodAssignN
 	// 1. is it null?  Jump over to AssignNull (which may or may not reject it).
 	// 2. is it our own type?  Handle specially -- we might be able to do efficient things.
 	// 3. is it the right kind to morph into us?  Do so.
+	// Phase 2 also covers our own representation node: it's the same memory, and copying it saves re-parsing its string.
 	doTemplate(`
 		func (na *_{{ .Type | TypeSymbol }}__ReprAssembler) AssignNode(v ipld.Node) error {
 			if v.IsNull() {
 				return na.AssignNull()
 			}
-			if v2, ok := v.(*_{{ .Type | TypeSymbol }}); ok {
+			v2, ok := v.(*_{{ .Type | TypeSymbol }})
+			if v3, ok3 := v.(*_{{ .Type | TypeSymbol }}__Repr); ok3 {
+				v2, ok = (*_{{ .Type | TypeSymbol }})(v3), true
+			}
+			if ok {
+				{{- if Compact }}
+				gensupport.CheckAssignable(*na.m)
+				{{- else }}
 				switch *na.m {
 				case schema.Maybe_Value, schema.Maybe_Null:
 					panic("invalid state: cannot assign into assembler that's already finished")
 				}
+				{{- end}}
 				{{- if .Type | MaybeUsesPtr }}
 				if na.w == nil {
 					na.w = v2
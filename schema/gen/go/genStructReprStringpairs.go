@@ -0,0 +1,287 @@
+package gengo
+
+import (
+	"io"
+
+	"github.com/ipld/go-ipld-prime/schema"
+	"github.com/ipld/go-ipld-prime/schema/gen/go/mixins"
+)
+
+var _ TypeGenerator = &structReprStringpairsGenerator{}
+
+func NewStructReprStringpairsGenerator(pkgName string, typ *schema.TypeStruct, adjCfg *AdjunctCfg) TypeGenerator {
+	return structReprStringpairsGenerator{
+		structGenerator{
+			adjCfg,
+			mixins.MapTraits{
+				PkgName:    pkgName,
+				TypeName:   string(typ.Name()),
+				TypeSymbol: adjCfg.TypeSymbol(typ),
+			},
+			pkgName,
+			typ,
+		},
+	}
+}
+
+type structReprStringpairsGenerator struct {
+	structGenerator
+}
+
+func (g structReprStringpairsGenerator) GetRepresentationNodeGen() NodeGenerator {
+	return structReprStringpairsReprGenerator{
+		g.AdjCfg,
+		mixins.StringTraits{
+			PkgName:    g.PkgName,
+			TypeName:   string(g.Type.Name()) + ".Repr",
+			TypeSymbol: "_" + g.AdjCfg.TypeSymbol(g.Type) + "__Repr",
+		},
+		g.PkgName,
+		g.Type,
+	}
+}
+
+type structReprStringpairsReprGenerator struct {
+	AdjCfg *AdjunctCfg
+	mixins.StringTraits
+	PkgName string
+	Type    *schema.TypeStruct
+}
+
+func (structReprStringpairsReprGenerator) IsRepr() bool { return true } // hint used in some generalized templates.
+
+func (g structReprStringpairsReprGenerator) EmitNodeType(w io.Writer) {
+	// The type is structurally the same, but will have a different set of methods.
+	doTemplate(`
+		type _{{ .Type | TypeSymbol }}__Repr _{{ .Type | TypeSymbol }}
+	`, w, g.AdjCfg, g)
+}
+
+func (g structReprStringpairsReprGenerator) EmitNodeTypeAssertions(w io.Writer) {
+	doTemplate(`
+		var _ ipld.Node = &_{{ .Type | TypeSymbol }}__Repr{}
+	`, w, g.AdjCfg, g)
+}
+
+func (g structReprStringpairsReprGenerator) EmitNodeMethodAsString(w io.Writer) {
+	// Prerequisites are much the same as for stringjoin (see the comments there):
+	//  every field must be a string or have string representation,
+	//  and there are NO sanity checks that your values don't contain either delimiter.
+	// Unlike stringjoin, optional fields are supported: absent ones simply have no entry.
+	// Nullable fields are not; there'd be no way to tell a null from a string saying "null".
+	//
+	// Entries are written in the order the fields are declared in.
+	doTemplate(`
+		func (n *_{{ .Type | TypeSymbol }}__Repr) AsString() (string, error) {
+			return n.String(), nil
+		}
+		func (n *_{{ .Type | TypeSymbol }}__Repr) String() string {
+			var s, delim string
+			{{- $type := .Type -}} {{- /* ranging modifies dot, unhelpfully */ -}}
+			{{- range $field := .Type.Fields }}
+			{{- if $field.IsOptional }}
+			if n.{{ $field | FieldSymbolLower }}.m == schema.Maybe_Value {
+				s += delim + "{{ $field.Name }}{{ $type.RepresentationStrategy.GetInnerDelim }}" + (*_{{ $field.Type | TypeSymbol }}__Repr)({{if not (MaybeUsesPtr $field.Type) }}&{{end}}n.{{ $field | FieldSymbolLower }}.v).String()
+				delim = "{{ $type.RepresentationStrategy.GetEntryDelim }}"
+			}
+			{{- else}}
+			s += delim + "{{ $field.Name }}{{ $type.RepresentationStrategy.GetInnerDelim }}" + (*_{{ $field.Type | TypeSymbol }}__Repr)(&n.{{ $field | FieldSymbolLower }}).String()
+			delim = "{{ $type.RepresentationStrategy.GetEntryDelim }}"
+			{{- end}}
+			{{- end}}
+			return s
+		}
+		func (n {{ .Type | TypeSymbol }}) String() string {
+			return (*_{{ .Type | TypeSymbol }}__Repr)(n).String()
+		}
+	`, w, g.AdjCfg, g)
+}
+
+func (g structReprStringpairsReprGenerator) EmitNodeMethodPrototype(w io.Writer) {
+	emitNodeMethodPrototype_typical(w, g.AdjCfg, g)
+}
+
+func (g structReprStringpairsReprGenerator) EmitNodePrototypeType(w io.Writer) {
+	emitNodePrototypeType_typical(w, g.AdjCfg, g)
+}
+
+// --- NodeBuilder and NodeAssembler --->
+
+func (g structReprStringpairsReprGenerator) GetNodeBuilderGenerator() NodeBuilderGenerator {
+	return structReprStringpairsReprBuilderGenerator{
+		g.AdjCfg,
+		mixins.StringAssemblerTraits{
+			PkgName:       g.PkgName,
+			TypeName:      g.TypeName,
+			AppliedPrefix: "_" + g.AdjCfg.TypeSymbol(g.Type) + "__Repr",
+		},
+		g.PkgName,
+		g.Type,
+	}
+}
+
+type structReprStringpairsReprBuilderGenerator struct {
+	AdjCfg *AdjunctCfg
+	mixins.StringAssemblerTraits
+	PkgName string
+	Type    *schema.TypeStruct
+}
+
+func (structReprStringpairsReprBuilderGenerator) IsRepr() bool { return true } // hint used in some generalized templates.
+
+func (g structReprStringpairsReprBuilderGenerator) EmitNodeBuilderType(w io.Writer) {
+	emitEmitNodeBuilderType_typical(w, g.AdjCfg, g)
+}
+func (g structReprStringpairsReprBuilderGenerator) EmitNodeBuilderMethods(w io.Writer) {
+	emitNodeBuilderMethods_typical(w, g.AdjCfg, g)
+
+	// Generate a single-step construction function -- this is easy to do for a scalar,
+	//  and all representations of scalar kind can be expected to have a method like this.
+	// The function is attached to the NodePrototype for convenient namespacing;
+	//  it needs no new memory, so it would be inappropriate to attach to the builder or assembler.
+	// The function is directly used internally by anything else that might involve recursive destructuring on the same scalar kind
+	//  (for example, structs using stringjoin or stringpairs strategies that have one of this type as a field, etc).
+	// Since we're a representation of scalar kind, and can recurse,
+	//  we ourselves presume this plain construction method must also exist for all our members.
+	// REVIEW: We could make an immut-safe verion of this and export it on the NodePrototype too, as `FromString(string)`.
+	// FUTURE: should engage validation flow.
+	doTemplate(`
+		func (_{{ .Type | TypeSymbol }}__ReprPrototype) fromString(w *_{{ .Type | TypeSymbol }}, v string) error {
+			ss, err := mixins.SplitPairs(v, "{{ .Type.RepresentationStrategy.GetEntryDelim }}", "{{ .Type.RepresentationStrategy.GetInnerDelim }}")
+			if err != nil {
+				return ipld.ErrUnmatchable{TypeName:"{{ .PkgName }}.{{ .Type.Name }}.Repr", Reason: err}
+			}
+			{{- $dot := . -}} {{- /* ranging modifies dot, unhelpfully */ -}}
+			{{- range $field := .Type.Fields }}
+			{{- if $field.IsOptional }}
+			w.{{ $field | FieldSymbolLower }}.m = schema.Maybe_Absent
+			{{- end}}
+			{{- end}}
+			s := 0
+			for _, kv := range ss {
+				switch kv[0] {
+				{{- range $field := .Type.Fields }}
+				case "{{ $field.Name }}":
+					if s & fieldBit__{{ $dot.Type | TypeSymbol }}_{{ $field | FieldSymbolUpper }} != 0 {
+						return ipld.ErrRepeatedMapKey{Key: &_String{kv[0]}}
+					}
+					s += fieldBit__{{ $dot.Type | TypeSymbol }}_{{ $field | FieldSymbolUpper }}
+					{{- if $field.IsOptional }}
+					{{- if MaybeUsesPtr $field.Type }}
+					w.{{ $field | FieldSymbolLower }}.v = &_{{ $field.Type | TypeSymbol }}{}
+					{{- end}}
+					if err := (_{{ $field.Type | TypeSymbol }}__ReprPrototype{}).fromString({{if not (MaybeUsesPtr $field.Type) }}&{{end}}w.{{ $field | FieldSymbolLower }}.v, kv[1]); err != nil {
+						return ipld.ErrUnmatchable{TypeName:"{{ $dot.PkgName }}.{{ $dot.Type.Name }}.Repr", Reason: err}
+					}
+					w.{{ $field | FieldSymbolLower }}.m = schema.Maybe_Value
+					{{- else}}
+					if err := (_{{ $field.Type | TypeSymbol }}__ReprPrototype{}).fromString(&w.{{ $field | FieldSymbolLower }}, kv[1]); err != nil {
+						return ipld.ErrUnmatchable{TypeName:"{{ $dot.PkgName }}.{{ $dot.Type.Name }}.Repr", Reason: err}
+					}
+					{{- end}}
+				{{- end}}
+				default:
+					return ipld.ErrInvalidKey{TypeName:"{{ .PkgName }}.{{ .Type.Name }}.Repr", Key:&_String{kv[0]}}
+				}
+			}
+			if s & fieldBits__{{ .Type | TypeSymbol }}_sufficient != fieldBits__{{ .Type | TypeSymbol }}_sufficient {
+				err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+				{{- range $field := .Type.Fields }}
+				{{- if not $field.IsOptional }}
+				if s & fieldBit__{{ $dot.Type | TypeSymbol }}_{{ $field | FieldSymbolUpper }} == 0 {
+					err.Missing = append(err.Missing, "{{ $field.Name }}")
+				}
+				{{- end}}
+				{{- end}}
+				return err
+			}
+			return nil
+		}
+	`, w, g.AdjCfg, g)
+}
+func (g structReprStringpairsReprBuilderGenerator) EmitNodeAssemblerType(w io.Writer) {
+	doTemplate(`
+		type _{{ .Type | TypeSymbol }}__ReprAssembler struct {
+			w *_{{ .Type | TypeSymbol }}
+			m *schema.Maybe
+		}
+
+		func (na *_{{ .Type | TypeSymbol }}__ReprAssembler) reset() {}
+	`, w, g.AdjCfg, g)
+}
+func (g structReprStringpairsReprBuilderGenerator) EmitNodeAssemblerMethodAssignNull(w io.Writer) {
+	emitNodeAssemblerMethodAssignNull_scalar(w, g.AdjCfg, g)
+}
+func (g structReprStringpairsReprBuilderGenerator) EmitNodeAssemblerMethodAssignString(w io.Writer) {
+	// This method contains a branch to support MaybeUsesPtr because new memory may need to be allocated.
+	//  This allocation only happens if the 'w' ptr is nil, which means we're being used on a Maybe;
+	//  otherwise, the 'w' ptr should already be set, and we fill that memory location without allocating, as usual.
+	doTemplate(`
+		func (na *_{{ .Type | TypeSymbol }}__ReprAssembler) AssignString(v string) error {
+			{{- if Compact }}
+			gensupport.CheckAssignable(*na.m)
+			{{- else }}
+			switch *na.m {
+			case schema.Maybe_Value, schema.Maybe_Null:
+				panic("invalid state: cannot assign into assembler that's already finished")
+			}
+			{{- end}}
+			{{- if .Type | MaybeUsesPtr }}
+			if na.w == nil {
+				na.w = &_{{ .Type | TypeSymbol }}{}
+			}
+			{{- end}}
+			if err := (_{{ .Type | TypeSymbol }}__ReprPrototype{}).fromString(na.w, v); err != nil {
+				return err
+			}
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+	`, w, g.AdjCfg, g)
+}
+
+func (g structReprStringpairsReprBuilderGenerator) EmitNodeAssemblerMethodAssignNode(w io.Writer) {
+	// AssignNode goes through three phases:
+	// 1. is it null?  Jump over to AssignNull (which may or may not reject it).
+	// 2. is it our own type?  Handle specially -- we might be able to do efficient things.
+	// 3. is it the right kind to morph into us?  Do so.
+	doTemplate(`
+		func (na *_{{ .Type | TypeSymbol }}__ReprAssembler) AssignNode(v ipld.Node) error {
+			if v.IsNull() {
+				return na.AssignNull()
+			}
+			v2, ok := v.(*_{{ .Type | TypeSymbol }})
+			if v3, ok3 := v.(*_{{ .Type | TypeSymbol }}__Repr); ok3 {
+				v2, ok = (*_{{ .Type | TypeSymbol }})(v3), true
+			}
+			if ok {
+				{{- if Compact }}
+				gensupport.CheckAssignable(*na.m)
+				{{- else }}
+				switch *na.m {
+				case schema.Maybe_Value, schema.Maybe_Null:
+					panic("invalid state: cannot assign into assembler that's already finished")
+				}
+				{{- end}}
+				{{- if .Type | MaybeUsesPtr }}
+				if na.w == nil {
+					na.w = v2
+					*na.m = schema.Maybe_Value
+					return nil
+				}
+				{{- end}}
+				*na.w = *v2
+				*na.m = schema.Maybe_Value
+				return nil
+			}
+			if v2, err := v.AsString(); err != nil {
+				return err
+			} else {
+				return na.AssignString(v2)
+			}
+		}
+	`, w, g.AdjCfg, g)
+}
+func (g structReprStringpairsReprBuilderGenerator) EmitNodeAssemblerOtherBits(w io.Writer) {
+	// None for this.
+}
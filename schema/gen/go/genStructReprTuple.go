@@ -22,6 +22,17 @@ var _ TypeGenerator = &structReprTupleGenerator{}
 //  which means we probably should consider that someday,
 //   but it's not implemented today.
 
+// tupleRequiredFieldCount returns the number of fields before the trailing optional ones,
+//  which is the least number of values the tuple can have.
+func tupleRequiredFieldCount(typ *schema.TypeStruct) int {
+	fields := typ.Fields()
+	n := len(fields)
+	for n > 0 && fields[n-1].IsOptional() {
+		n--
+	}
+	return n
+}
+
 func NewStructReprTupleGenerator(pkgName string, typ *schema.TypeStruct, adjCfg *AdjunctCfg) TypeGenerator {
 	return structReprTupleGenerator{
 		structGenerator{
@@ -133,13 +144,7 @@ func (g structReprTupleReprGenerator) EmitNodeMethodListIterator(w io.Writer) {
 	//  The 'Done' predicate gets more complex when in the trailing optionals.
 	fields := g.Type.Fields()
 	fieldCount := len(fields)
-	beginTrailingOptionalField := fieldCount
-	for i := fieldCount - 1; i >= 0; i-- {
-		if !fields[i].IsOptional() {
-			break
-		}
-		beginTrailingOptionalField = i
-	}
+	beginTrailingOptionalField := tupleRequiredFieldCount(g.Type)
 	haveTrailingOptionals := beginTrailingOptionalField < fieldCount
 
 	// Now: finally we can get on with the actual templating.
@@ -381,6 +386,12 @@ func (g structReprTupleReprBuilderGenerator) emitListAssemblerChildTidyHelper(w
 func (g structReprTupleReprBuilderGenerator) emitListAssemblerChildListAssemblerMethods(w io.Writer) {
 	doTemplate(`
 		func (la *_{{ .Type | TypeSymbol }}__ReprAssembler) AssembleValue() ipld.NodeAssembler {
+			{{- if Compact }}
+			if la.state == laState_midValue {
+				la.valueFinishTidy()
+			}
+			gensupport.ListAssemblerCheckState("AssembleValue", uint8(la.state))
+			{{- else }}
 			switch la.state {
 			case laState_initial:
 				// carry on
@@ -391,6 +402,7 @@ func (g structReprTupleReprBuilderGenerator) emitListAssemblerChildListAssembler
 			case laState_finished:
 				panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
 			}
+			{{- end}}
 			if la.f >= {{ len .Type.Fields }} {
 				return _ErrorThunkAssembler{schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfInt({{ len .Type.Fields }})}}
 			}
@@ -415,11 +427,18 @@ func (g structReprTupleReprBuilderGenerator) emitListAssemblerChildListAssembler
 			}
 		}
 	`, w, g.AdjCfg, g)
-	// Surprisingly, the Finish method doesn't have anything to do regarding any trailing optionals:
+	// Finish doesn't have anything to do regarding any trailing optionals:
 	//  if they weren't assigned yet, their Maybe state is still the zero value: absent.  And that's correct.
-	// DRY: okay, this finish component is actually identical, both textually and in terms of linking, to lists.  This we should actually extract.
+	// It does have to check that we got at least as far as the last required field, though;
+	//  everything from 'f' up to that field is missing.
 	doTemplate(`
 		func (la *_{{ .Type | TypeSymbol }}__ReprAssembler) Finish() error {
+			{{- if Compact }}
+			if la.state == laState_midValue {
+				la.valueFinishTidy()
+			}
+			gensupport.ListAssemblerCheckState("Finish", uint8(la.state))
+			{{- else }}
 			switch la.state {
 			case laState_initial:
 				// carry on
@@ -430,14 +449,41 @@ func (g structReprTupleReprBuilderGenerator) emitListAssemblerChildListAssembler
 			case laState_finished:
 				panic("invalid state: Finish cannot be called on an assembler that's already finished")
 			}
+			{{- end}}
+			{{- if .Type.Fields }}
+			if la.f < {{ .RequiredFieldCount }} {
+				err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+				{{- range $i, $field := .Type.Fields }}
+				{{- if not $field.IsOptional }}
+				if la.f <= {{ $i }} {
+					err.Missing = append(err.Missing, "{{ $field.Name }}")
+				}
+				{{- end}}
+				{{- end}}
+				return err
+			}
+			{{- end}}
 			la.state = laState_finished
 			*la.m = schema.Maybe_Value
 			return nil
 		}
-	`, w, g.AdjCfg, g)
+	`, w, g.AdjCfg, struct {
+		Type               *schema.TypeStruct
+		RequiredFieldCount int
+	}{
+		g.Type,
+		tupleRequiredFieldCount(g.Type),
+	})
 	doTemplate(`
-		func (la *_{{ .Type | TypeSymbol }}__ReprAssembler) ValuePrototype(_ int64) ipld.NodePrototype {
-			panic("todo structbuilder tuplerepr valueprototype")
+		func (la *_{{ .Type | TypeSymbol }}__ReprAssembler) ValuePrototype(idx int64) ipld.NodePrototype {
+			switch idx {
+			{{- range $i, $field := .Type.Fields }}
+			case {{ $i }}:
+				return _{{ $field.Type | TypeSymbol }}__ReprPrototype{}
+			{{- end}}
+			default:
+				return nil
+			}
 		}
 	`, w, g.AdjCfg, g)
 }
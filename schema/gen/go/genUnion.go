@@ -48,7 +48,8 @@ func (g unionGenerator) EmitNativeType(w io.Writer) {
 	// (see further comments in the EmitNodeAssemblerType function);
 	// and since we do it in that one case, it's just as well to do it uniformly.
 	doTemplate(`
-		{{- if Comments -}}
+		{{- Doc .Type.Doc }}
+		{{- if Comments }}
 		// {{ .Type | TypeSymbol }} matches the IPLD Schema type "{{ .Type.Name }}".
 		// {{ .Type | TypeSymbol }} has {{ .Type.TypeKind }} typekind, which means its data model behaviors are that of a {{ .Kind }} kind.
 		{{- end}}
@@ -92,6 +93,23 @@ func (g unionGenerator) EmitNativeAccessors(w io.Writer) {
 			return n.x
 			{{- end}}
 		}
+		{{- range $i, $member := .Type.Members }}
+
+		{{ if Comments -}}
+		// Member{{ $member | TypeSymbol }} returns the union's value if it's a {{ $member | TypeSymbol }}, and nil otherwise.
+		{{ end -}}
+		func (n _{{ dot.Type | TypeSymbol }}) Member{{ $member | TypeSymbol }}() {{ $member | TypeSymbol }} {
+			{{- if (eq (dot.AdjCfg.UnionMemlayout dot.Type) "embedAll") }}
+			if n.tag != {{ add $i 1 }} {
+				return nil
+			}
+			return &n.x{{ add $i 1 }}
+			{{- else if (eq (dot.AdjCfg.UnionMemlayout dot.Type) "interface") }}
+			x, _ := n.x.({{ $member | TypeSymbol }})
+			return x
+			{{- end}}
+		}
+		{{- end}}
 	`, w, g.AdjCfg, g)
 }
 
@@ -447,6 +465,12 @@ func (g unionBuilderGenerator) emitMapAssemblerMethods(w io.Writer) {
 	//  This is subtle but important: trying to add more data than is acceptable is a data mismatch, not a system misuse, and must error accordingly politely.
 	doTemplate(`
 		func (ma *_{{ .Type | TypeSymbol }}__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+			{{- if Compact }}
+			if ma.state == maState_midValue {
+				ma.valueFinishTidy()
+			}
+			gensupport.MapAssemblerExpectKey("AssembleEntry", uint8(ma.state))
+			{{- else }}
 			switch ma.state {
 			case maState_initial:
 				// carry on
@@ -461,6 +485,7 @@ func (g unionBuilderGenerator) emitMapAssemblerMethods(w io.Writer) {
 			case maState_finished:
 				panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
 			}
+			{{- end}}
 			if ma.ca != 0 {
 				return nil, schema.ErrNotUnionStructure{TypeName:"{{ .PkgName }}.{{ .Type.Name }}", Detail: "cannot add another entry -- a union can only contain one thing!"}
 			}
@@ -488,7 +513,7 @@ func (g unionBuilderGenerator) emitMapAssemblerMethods(w io.Writer) {
 			{{- end}}
 			{{- end}}
 			}
-			return nil, ipld.ErrInvalidKey{TypeName:"{{ .PkgName }}.{{ .Type.Name }}", Key:&_String{k}}
+			return nil, schema.ErrInvalidUnionDiscriminant{TypeName:"{{ .PkgName }}.{{ .Type.Name }}", Discriminant: k, Expected: []string{ {{- range $member := .Type.Members }}"{{ $member.Name }}",{{ end }}}}
 		}
 	`, w, g.AdjCfg, g)
 
@@ -501,6 +526,12 @@ func (g unionBuilderGenerator) emitMapAssemblerMethods(w io.Writer) {
 	//    The transition to midKey state is particularly irritating because it means this assembler will be perma-wedged; but I see no alternative.
 	doTemplate(`
 		func (ma *_{{ .Type | TypeSymbol }}__Assembler) AssembleKey() ipld.NodeAssembler {
+			{{- if Compact }}
+			if ma.state == maState_midValue {
+				ma.valueFinishTidy()
+			}
+			gensupport.MapAssemblerExpectKey("AssembleKey", uint8(ma.state))
+			{{- else }}
 			switch ma.state {
 			case maState_initial:
 				// carry on
@@ -515,6 +546,7 @@ func (g unionBuilderGenerator) emitMapAssemblerMethods(w io.Writer) {
 			case maState_finished:
 				panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
 			}
+			{{- end}}
 			ma.state = maState_midKey
 			return (*_{{ .Type | TypeSymbol }}__KeyAssembler)(ma)
 		}
@@ -525,6 +557,9 @@ func (g unionBuilderGenerator) emitMapAssemblerMethods(w io.Writer) {
 	//  The potential to DRY up some of this should be plentiful, but it's a bit heady.
 	doTemplate(`
 		func (ma *_{{ .Type | TypeSymbol }}__Assembler) AssembleValue() ipld.NodeAssembler {
+			{{- if Compact }}
+			gensupport.MapAssemblerExpectValue("AssembleValue", uint8(ma.state))
+			{{- else }}
 			switch ma.state {
 			case maState_initial:
 				panic("invalid state: AssembleValue cannot be called when no key is primed")
@@ -537,10 +572,11 @@ func (g unionBuilderGenerator) emitMapAssemblerMethods(w io.Writer) {
 			case maState_finished:
 				panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
 			}
+			{{- end}}
 			ma.state = maState_midValue
 			switch ma.ca {
 			{{- range $i, $member := .Type.Members }}
-			case {{ $i }}:
+			case {{ add $i 1 }}:
 				{{- if (eq (dot.AdjCfg.UnionMemlayout dot.Type) "embedAll") }}
 				ma.ca{{ add $i 1 }}.w = &ma.w.x{{ add $i 1 }}
 				ma.ca{{ add $i 1 }}.m = &ma.cm
@@ -566,6 +602,12 @@ func (g unionBuilderGenerator) emitMapAssemblerMethods(w io.Writer) {
 	//  If yes and yes, then together with the rules elsewhere, we must've processed and accepted exactly one entry; perfect.
 	doTemplate(`
 		func (ma *_{{ .Type | TypeSymbol }}__Assembler) Finish() error {
+			{{- if Compact }}
+			if ma.state == maState_midValue {
+				ma.valueFinishTidy()
+			}
+			gensupport.MapAssemblerExpectKey("Finish", uint8(ma.state))
+			{{- else }}
 			switch ma.state {
 			case maState_initial:
 				// carry on
@@ -580,6 +622,7 @@ func (g unionBuilderGenerator) emitMapAssemblerMethods(w io.Writer) {
 			case maState_finished:
 				panic("invalid state: Finish cannot be called on an assembler that's already finished")
 			}
+			{{- end}}
 			if ma.ca == 0 {
 				return schema.ErrNotUnionStructure{TypeName:"{{ .PkgName }}.{{ .Type.Name }}", Detail: "a union must have exactly one entry (not none)!"}
 			}
@@ -641,7 +684,7 @@ func (g unionBuilderGenerator) emitKeyAssembler(w io.Writer) {
 				return nil
 			{{- end}}
 			}
-			return ipld.ErrInvalidKey{TypeName:"{{ .PkgName }}.{{ .Type.Name }}", Key:&_String{k}} // TODO: error quality: ErrInvalidUnionDiscriminant ?
+			return schema.ErrInvalidUnionDiscriminant{TypeName:"{{ .PkgName }}.{{ .Type.Name }}", Discriminant: k, Expected: []string{ {{- range $member := .Type.Members }}"{{ $member.Name }}",{{ end }}}}
 		}
 	`, w, g.AdjCfg, g)
 	stubs.EmitNodeAssemblerMethodAssignBytes(w)
@@ -0,0 +1,595 @@
+package gengo
+
+import (
+	"io"
+
+	"github.com/ipld/go-ipld-prime/schema"
+	"github.com/ipld/go-ipld-prime/schema/gen/go/mixins"
+)
+
+var _ TypeGenerator = &unionReprEnvelopeGenerator{}
+
+// The envelope representation of unions is a map with exactly two entries:
+//  one for the discriminant (a string), and one for the content (the member's representation).
+//
+// The assembler can only begin on the content once it knows which member it is,
+//  so when assembling entry by entry, the discriminant must come before the content.
+//  (Buffering the content until the discriminant arrives would need some other node implementation to buffer into,
+//  which generated code doesn't have.)
+//  AssignNode doesn't have this limitation, since it can look up the two entries in whatever order it likes.
+
+func NewUnionReprEnvelopeGenerator(pkgName string, typ *schema.TypeUnion, adjCfg *AdjunctCfg) TypeGenerator {
+	return unionReprEnvelopeGenerator{
+		unionGenerator{
+			adjCfg,
+			mixins.MapTraits{
+				PkgName:    pkgName,
+				TypeName:   string(typ.Name()),
+				TypeSymbol: adjCfg.TypeSymbol(typ),
+			},
+			pkgName,
+			typ,
+		},
+	}
+}
+
+type unionReprEnvelopeGenerator struct {
+	unionGenerator
+}
+
+func (g unionReprEnvelopeGenerator) GetRepresentationNodeGen() NodeGenerator {
+	return unionReprEnvelopeReprGenerator{
+		g.AdjCfg,
+		mixins.MapTraits{
+			PkgName:    g.PkgName,
+			TypeName:   string(g.Type.Name()) + ".Repr",
+			TypeSymbol: "_" + g.AdjCfg.TypeSymbol(g.Type) + "__Repr",
+		},
+		g.PkgName,
+		g.Type,
+	}
+}
+
+type unionReprEnvelopeReprGenerator struct {
+	AdjCfg *AdjunctCfg
+	mixins.MapTraits
+	PkgName string
+	Type    *schema.TypeUnion
+}
+
+func (unionReprEnvelopeReprGenerator) IsRepr() bool { return true } // hint used in some generalized templates.
+
+func (g unionReprEnvelopeReprGenerator) EmitNodeType(w io.Writer) {
+	// The type is structurally the same, but will have a different set of methods.
+	doTemplate(`
+		type _{{ .Type | TypeSymbol }}__Repr _{{ .Type | TypeSymbol }}
+	`, w, g.AdjCfg, g)
+
+	// Constants for the two keys, and for our discriminant values, so iterators can yield them without allocating.
+	doTemplate(`
+		var (
+			discriminantKey__{{ .Type | TypeSymbol }}_serial = _String{"{{ .Type.RepresentationStrategy.GetDiscriminantKey }}"}
+			contentKey__{{ .Type | TypeSymbol }}_serial = _String{"{{ .Type.RepresentationStrategy.GetContentKey }}"}
+			{{- range $member := .Type.Members }}
+			memberName__{{ dot.Type | TypeSymbol }}_{{ $member.Name }}_serial = _String{"{{ $member | dot.Type.RepresentationStrategy.GetDiscriminant }}"}
+			{{- end }}
+		)
+	`, w, g.AdjCfg, g)
+}
+
+func (g unionReprEnvelopeReprGenerator) EmitNodeTypeAssertions(w io.Writer) {
+	doTemplate(`
+		var _ ipld.Node = &_{{ .Type | TypeSymbol }}__Repr{}
+	`, w, g.AdjCfg, g)
+}
+
+func (g unionReprEnvelopeReprGenerator) EmitNodeMethodLookupByString(w io.Writer) {
+	doTemplate(`
+		func (n *_{{ .Type | TypeSymbol }}__Repr) LookupByString(key string) (ipld.Node, error) {
+			switch key {
+			case "{{ .Type.RepresentationStrategy.GetDiscriminantKey }}":
+				{{- if (eq (.AdjCfg.UnionMemlayout .Type) "embedAll") }}
+				switch n.tag {
+				{{- range $i, $member := .Type.Members }}
+				case {{ add $i 1 }}:
+					return &memberName__{{ dot.Type | TypeSymbol }}_{{ $member.Name }}_serial, nil
+				{{- end}}
+				{{- else if (eq (.AdjCfg.UnionMemlayout .Type) "interface") }}
+				switch n.x.(type) {
+				{{- range $member := .Type.Members }}
+				case {{ $member | TypeSymbol }}:
+					return &memberName__{{ dot.Type | TypeSymbol }}_{{ $member.Name }}_serial, nil
+				{{- end}}
+				{{- end}}
+				default:
+					panic("unreachable")
+				}
+			case "{{ .Type.RepresentationStrategy.GetContentKey }}":
+				{{- if (eq (.AdjCfg.UnionMemlayout .Type) "embedAll") }}
+				switch n.tag {
+				{{- range $i, $member := .Type.Members }}
+				case {{ add $i 1 }}:
+					return n.x{{ add $i 1 }}.Representation(), nil
+				{{- end}}
+				{{- else if (eq (.AdjCfg.UnionMemlayout .Type) "interface") }}
+				switch n2 := n.x.(type) {
+				{{- range $member := .Type.Members }}
+				case {{ $member | TypeSymbol }}:
+					return n2.Representation(), nil
+				{{- end}}
+				{{- end}}
+				default:
+					panic("unreachable")
+				}
+			default:
+				return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+			}
+		}
+	`, w, g.AdjCfg, g)
+}
+
+func (g unionReprEnvelopeReprGenerator) EmitNodeMethodLookupByNode(w io.Writer) {
+	doTemplate(`
+		func (n *_{{ .Type | TypeSymbol }}__Repr) LookupByNode(key ipld.Node) (ipld.Node, error) {
+			ks, err := key.AsString()
+			if err != nil {
+				return nil, err
+			}
+			return n.LookupByString(ks)
+		}
+	`, w, g.AdjCfg, g)
+}
+
+func (g unionReprEnvelopeReprGenerator) EmitNodeMethodMapIterator(w io.Writer) {
+	// Always yields the discriminant first, so that the output can be assembled again, even entry by entry.
+	doTemplate(`
+		func (n *_{{ .Type | TypeSymbol }}__Repr) MapIterator() ipld.MapIterator {
+			return &_{{ .Type | TypeSymbol }}__ReprMapItr{n, 0}
+		}
+
+		type _{{ .Type | TypeSymbol }}__ReprMapItr struct {
+			n   *_{{ .Type | TypeSymbol }}__Repr
+			idx int
+		}
+
+		func (itr *_{{ .Type | TypeSymbol }}__ReprMapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+			switch itr.idx {
+			case 0:
+				k = &discriminantKey__{{ .Type | TypeSymbol }}_serial
+				v, _ = itr.n.LookupByString("{{ .Type.RepresentationStrategy.GetDiscriminantKey }}")
+			case 1:
+				k = &contentKey__{{ .Type | TypeSymbol }}_serial
+				v, _ = itr.n.LookupByString("{{ .Type.RepresentationStrategy.GetContentKey }}")
+			default:
+				return nil, nil, ipld.ErrIteratorOverread{}
+			}
+			itr.idx++
+			return
+		}
+		func (itr *_{{ .Type | TypeSymbol }}__ReprMapItr) Done() bool {
+			return itr.idx >= 2
+		}
+
+	`, w, g.AdjCfg, g)
+}
+
+func (g unionReprEnvelopeReprGenerator) EmitNodeMethodLength(w io.Writer) {
+	doTemplate(`
+		func (_{{ .Type | TypeSymbol }}__Repr) Length() int64 {
+			return 2
+		}
+	`, w, g.AdjCfg, g)
+}
+
+func (g unionReprEnvelopeReprGenerator) EmitNodeMethodPrototype(w io.Writer) {
+	emitNodeMethodPrototype_typical(w, g.AdjCfg, g)
+}
+
+func (g unionReprEnvelopeReprGenerator) EmitNodePrototypeType(w io.Writer) {
+	emitNodePrototypeType_typical(w, g.AdjCfg, g)
+}
+
+// --- NodeBuilder and NodeAssembler --->
+
+func (g unionReprEnvelopeReprGenerator) GetNodeBuilderGenerator() NodeBuilderGenerator {
+	return unionReprEnvelopeReprBuilderGenerator{
+		g.AdjCfg,
+		mixins.MapAssemblerTraits{
+			PkgName:       g.PkgName,
+			TypeName:      g.TypeName,
+			AppliedPrefix: "_" + g.AdjCfg.TypeSymbol(g.Type) + "__Repr",
+		},
+		g.PkgName,
+		g.Type,
+	}
+}
+
+type unionReprEnvelopeReprBuilderGenerator struct {
+	AdjCfg *AdjunctCfg
+	mixins.MapAssemblerTraits
+	PkgName string
+	Type    *schema.TypeUnion
+}
+
+func (unionReprEnvelopeReprBuilderGenerator) IsRepr() bool { return true } // hint used in some generalized templates.
+
+func (g unionReprEnvelopeReprBuilderGenerator) EmitNodeBuilderType(w io.Writer) {
+	emitEmitNodeBuilderType_typical(w, g.AdjCfg, g)
+}
+func (g unionReprEnvelopeReprBuilderGenerator) EmitNodeBuilderMethods(w io.Writer) {
+	emitNodeBuilderMethods_typical(w, g.AdjCfg, g)
+}
+func (g unionReprEnvelopeReprBuilderGenerator) EmitNodeAssemblerType(w io.Writer) {
+	// - 's' is a bitfield for which of the two keys have been **s**et: 1 for the discriminant, 2 for the content.
+	// - 'f' is the **f**ocused key that will be assembled next: 0 for the discriminant, 1 for the content.
+	// - 'ca' is the member chosen by the discriminant, or 0 until then; the 'ca*' fields are the member assemblers, as in the other union representations.
+	doTemplate(`
+		type _{{ .Type | TypeSymbol }}__ReprAssembler struct {
+			w *_{{ .Type | TypeSymbol }}
+			m *schema.Maybe
+			state maState
+			s int
+			f int
+
+			cm schema.Maybe
+			{{- range $i, $member := .Type.Members }}
+			ca{{ add $i 1 }} {{ if (eq (dot.AdjCfg.UnionMemlayout dot.Type) "interface") }}*{{end}}_{{ $member | TypeSymbol }}__ReprAssembler
+			{{end -}}
+			ca uint
+		}
+	`, w, g.AdjCfg, g)
+
+	doTemplate(`
+		func (na *_{{ .Type | TypeSymbol }}__ReprAssembler) reset() {
+			na.state = maState_initial
+			na.s = 0
+			switch na.ca {
+			case 0:
+				return
+			{{- range $i, $member := .Type.Members }}
+			case {{ add $i 1 }}:
+				na.ca{{ add $i 1 }}.reset()
+			{{end -}}
+			default:
+				panic("unreachable")
+			}
+			na.ca = 0
+			na.cm = schema.Maybe_Absent
+		}
+	`, w, g.AdjCfg, g)
+}
+func (g unionReprEnvelopeReprBuilderGenerator) EmitNodeAssemblerMethodBeginMap(w io.Writer) {
+	emitNodeAssemblerMethodBeginMap_strictoid(w, g.AdjCfg, g)
+}
+func (g unionReprEnvelopeReprBuilderGenerator) EmitNodeAssemblerMethodAssignNull(w io.Writer) {
+	emitNodeAssemblerMethodAssignNull_recursive(w, g.AdjCfg, g)
+}
+func (g unionReprEnvelopeReprBuilderGenerator) EmitNodeAssemblerMethodAssignNode(w io.Writer) {
+	// Unlike the other map-like representations, this looks up the two entries by key rather than iterating,
+	//  so that the discriminant is always assembled first, whatever order the entries come in.
+	doTemplate(`
+		func (na *_{{ .Type | TypeSymbol }}__ReprAssembler) AssignNode(v ipld.Node) error {
+			if v.IsNull() {
+				return na.AssignNull()
+			}
+			v2, ok := v.(*_{{ .Type | TypeSymbol }})
+			if v3, ok3 := v.(*_{{ .Type | TypeSymbol }}__Repr); ok3 {
+				v2, ok = (*_{{ .Type | TypeSymbol }})(v3), true
+			}
+			if ok {
+				switch *na.m {
+				case schema.Maybe_Value, schema.Maybe_Null:
+					panic("invalid state: cannot assign into assembler that's already finished")
+				case midvalue:
+					panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+				}
+				{{- if .Type | MaybeUsesPtr }}
+				if na.w == nil {
+					na.w = v2
+					*na.m = schema.Maybe_Value
+					return nil
+				}
+				{{- end}}
+				*na.w = *v2
+				*na.m = schema.Maybe_Value
+				return nil
+			}
+			if v.Kind() != ipld.Kind_Map {
+				return ipld.ErrWrongKind{TypeName: "{{ .PkgName }}.{{ .Type.Name }}.Repr", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+			}
+			if v.Length() != 2 {
+				return schema.ErrNotUnionStructure{TypeName:"{{ .PkgName }}.{{ .Type.Name }}.Repr", Detail: "an envelope union must have exactly two entries"}
+			}
+			if _, err := na.BeginMap(2); err != nil {
+				return err
+			}
+			for _, k := range [2]string{"{{ .Type.RepresentationStrategy.GetDiscriminantKey }}", "{{ .Type.RepresentationStrategy.GetContentKey }}"} {
+				v2, err := v.LookupByString(k)
+				if err != nil {
+					return err
+				}
+				va, err := na.AssembleEntry(k)
+				if err != nil {
+					return err
+				}
+				if err := va.AssignNode(v2); err != nil {
+					return err
+				}
+			}
+			return na.Finish()
+		}
+	`, w, g.AdjCfg, g)
+}
+func (g unionReprEnvelopeReprBuilderGenerator) EmitNodeAssemblerOtherBits(w io.Writer) {
+	g.emitMapAssemblerChildTidyHelper(w)
+	g.emitMapAssemblerMethods(w)
+	g.emitKeyAssembler(w)
+	g.emitDiscriminantAssembler(w)
+}
+func (g unionReprEnvelopeReprBuilderGenerator) emitMapAssemblerChildTidyHelper(w io.Writer) {
+	doTemplate(`
+		func (ma *_{{ .Type | TypeSymbol }}__ReprAssembler) valueFinishTidy() bool {
+			switch ma.f {
+			case 0:
+				if ma.ca == 0 {
+					return false
+				}
+			case 1:
+				if ma.cm != schema.Maybe_Value {
+					return false
+				}
+			default:
+				panic("unreachable")
+			}
+			ma.state = maState_initial
+			return true
+		}
+	`, w, g.AdjCfg, g)
+}
+func (g unionReprEnvelopeReprBuilderGenerator) emitMapAssemblerMethods(w io.Writer) {
+	// AssembleEntry is just AssembleKey and AssembleValue in a row; the key assembler does all the checking.
+	doTemplate(`
+		func (ma *_{{ .Type | TypeSymbol }}__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+			if err := ma.AssembleKey().AssignString(k); err != nil {
+				return nil, err
+			}
+			return ma.AssembleValue(), nil
+		}
+	`, w, g.AdjCfg, g)
+
+	doTemplate(`
+		func (ma *_{{ .Type | TypeSymbol }}__ReprAssembler) AssembleKey() ipld.NodeAssembler {
+			{{- if Compact }}
+			if ma.state == maState_midValue {
+				ma.valueFinishTidy()
+			}
+			gensupport.MapAssemblerExpectKey("AssembleKey", uint8(ma.state))
+			{{- else }}
+			switch ma.state {
+			case maState_initial:
+				// carry on
+			case maState_midKey:
+				panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+			case maState_expectValue:
+				panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+			case maState_midValue:
+				if !ma.valueFinishTidy() {
+					panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+				} // if tidy success: carry on
+			case maState_finished:
+				panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+			}
+			{{- end}}
+			ma.state = maState_midKey
+			return (*_{{ .Type | TypeSymbol }}__ReprKeyAssembler)(ma)
+		}
+	`, w, g.AdjCfg, g)
+
+	doTemplate(`
+		func (ma *_{{ .Type | TypeSymbol }}__ReprAssembler) AssembleValue() ipld.NodeAssembler {
+			{{- if Compact }}
+			gensupport.MapAssemblerExpectValue("AssembleValue", uint8(ma.state))
+			{{- else }}
+			switch ma.state {
+			case maState_initial:
+				panic("invalid state: AssembleValue cannot be called when no key is primed")
+			case maState_midKey:
+				panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+			case maState_expectValue:
+				// carry on
+			case maState_midValue:
+				panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+			case maState_finished:
+				panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+			}
+			{{- end}}
+			ma.state = maState_midValue
+			if ma.f == 0 {
+				return (*_{{ .Type | TypeSymbol }}__ReprDiscriminantAssembler)(ma)
+			}
+			switch ma.ca {
+			{{- range $i, $member := .Type.Members }}
+			case {{ add $i 1 }}:
+				{{- if (eq (dot.AdjCfg.UnionMemlayout dot.Type) "embedAll") }}
+				ma.ca{{ add $i 1 }}.w = &ma.w.x{{ add $i 1 }}
+				ma.ca{{ add $i 1 }}.m = &ma.cm
+				return &ma.ca{{ add $i 1 }}
+				{{- else if (eq (dot.AdjCfg.UnionMemlayout dot.Type) "interface") }}
+				x := &_{{ $member | TypeSymbol }}{}
+				ma.w.x = x
+				if ma.ca{{ add $i 1 }} == nil {
+					ma.ca{{ add $i 1 }} = &_{{ $member | TypeSymbol }}__ReprAssembler{}
+				}
+				ma.ca{{ add $i 1 }}.w = x
+				ma.ca{{ add $i 1 }}.m = &ma.cm
+				return ma.ca{{ add $i 1 }}
+				{{- end}}
+			{{- end}}
+			default:
+				panic("unreachable")
+			}
+		}
+	`, w, g.AdjCfg, g)
+
+	doTemplate(`
+		func (ma *_{{ .Type | TypeSymbol }}__ReprAssembler) Finish() error {
+			{{- if Compact }}
+			if ma.state == maState_midValue {
+				ma.valueFinishTidy()
+			}
+			gensupport.MapAssemblerExpectKey("Finish", uint8(ma.state))
+			{{- else }}
+			switch ma.state {
+			case maState_initial:
+				// carry on
+			case maState_midKey:
+				panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+			case maState_expectValue:
+				panic("invalid state: Finish cannot be called when expecting start of value assembly")
+			case maState_midValue:
+				if !ma.valueFinishTidy() {
+					panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+				} // if tidy success: carry on
+			case maState_finished:
+				panic("invalid state: Finish cannot be called on an assembler that's already finished")
+			}
+			{{- end}}
+			if ma.s & 1 == 0 {
+				return schema.ErrNotUnionStructure{TypeName:"{{ .PkgName }}.{{ .Type.Name }}.Repr", Detail: "missing the discriminant key \"{{ .Type.RepresentationStrategy.GetDiscriminantKey }}\""}
+			}
+			if ma.s & 2 == 0 {
+				return schema.ErrNotUnionStructure{TypeName:"{{ .PkgName }}.{{ .Type.Name }}.Repr", Detail: "missing the content key \"{{ .Type.RepresentationStrategy.GetContentKey }}\""}
+			}
+			ma.state = maState_finished
+			*ma.m = schema.Maybe_Value
+			return nil
+		}
+	`, w, g.AdjCfg, g)
+
+	doTemplate(`
+		func (ma *_{{ .Type | TypeSymbol }}__ReprAssembler) KeyPrototype() ipld.NodePrototype {
+			return _String__Prototype{}
+		}
+		func (ma *_{{ .Type | TypeSymbol }}__ReprAssembler) ValuePrototype(k string) ipld.NodePrototype {
+			switch k {
+			case "{{ .Type.RepresentationStrategy.GetDiscriminantKey }}":
+				return _String__Prototype{}
+			case "{{ .Type.RepresentationStrategy.GetContentKey }}":
+				switch ma.ca {
+				{{- range $i, $member := .Type.Members }}
+				case {{ add $i 1 }}:
+					return _{{ $member | TypeSymbol }}__ReprPrototype{}
+				{{- end}}
+				}
+			}
+			return nil
+		}
+	`, w, g.AdjCfg, g)
+}
+func (g unionReprEnvelopeReprBuilderGenerator) emitKeyAssembler(w io.Writer) {
+	doTemplate(`
+		type _{{ .Type | TypeSymbol }}__ReprKeyAssembler _{{ .Type | TypeSymbol }}__ReprAssembler
+	`, w, g.AdjCfg, g)
+	stubs := mixins.StringAssemblerTraits{
+		PkgName:       g.PkgName,
+		TypeName:      g.TypeName + ".KeyAssembler", // ".Repr" is already in `g.TypeName`, so don't stutter the "Repr" part.
+		AppliedPrefix: "_" + g.AdjCfg.TypeSymbol(g.Type) + "__ReprKey",
+	}
+	stubs.EmitNodeAssemblerMethodBeginMap(w)
+	stubs.EmitNodeAssemblerMethodBeginList(w)
+	stubs.EmitNodeAssemblerMethodAssignNull(w)
+	stubs.EmitNodeAssemblerMethodAssignBool(w)
+	stubs.EmitNodeAssemblerMethodAssignInt(w)
+	stubs.EmitNodeAssemblerMethodAssignFloat(w)
+	doTemplate(`
+		func (ka *_{{ .Type | TypeSymbol }}__ReprKeyAssembler) AssignString(k string) error {
+			if ka.state != maState_midKey {
+				panic("misuse: KeyAssembler held beyond its valid lifetime")
+			}
+			switch k {
+			case "{{ .Type.RepresentationStrategy.GetDiscriminantKey }}":
+				if ka.s & 1 != 0 {
+					return ipld.ErrRepeatedMapKey{Key: &discriminantKey__{{ .Type | TypeSymbol }}_serial}
+				}
+				ka.s += 1
+				ka.f = 0
+			case "{{ .Type.RepresentationStrategy.GetContentKey }}":
+				if ka.s & 2 != 0 {
+					return ipld.ErrRepeatedMapKey{Key: &contentKey__{{ .Type | TypeSymbol }}_serial}
+				}
+				if ka.ca == 0 {
+					return schema.ErrNotUnionStructure{TypeName:"{{ .PkgName }}.{{ .Type.Name }}.Repr", Detail: "the discriminant key \"{{ .Type.RepresentationStrategy.GetDiscriminantKey }}\" must come before the content key \"{{ .Type.RepresentationStrategy.GetContentKey }}\""}
+				}
+				ka.s += 2
+				ka.f = 1
+			default:
+				return ipld.ErrInvalidKey{TypeName:"{{ .PkgName }}.{{ .Type.Name }}.Repr", Key:&_String{k}}
+			}
+			ka.state = maState_expectValue
+			return nil
+		}
+	`, w, g.AdjCfg, g)
+	stubs.EmitNodeAssemblerMethodAssignBytes(w)
+	stubs.EmitNodeAssemblerMethodAssignLink(w)
+	doTemplate(`
+		func (ka *_{{ .Type | TypeSymbol }}__ReprKeyAssembler) AssignNode(v ipld.Node) error {
+			if v2, err := v.AsString(); err != nil {
+				return err
+			} else {
+				return ka.AssignString(v2)
+			}
+		}
+		func (_{{ .Type | TypeSymbol }}__ReprKeyAssembler) Prototype() ipld.NodePrototype {
+			return _String__Prototype{}
+		}
+	`, w, g.AdjCfg, g)
+}
+func (g unionReprEnvelopeReprBuilderGenerator) emitDiscriminantAssembler(w io.Writer) {
+	// The discriminant assembler picks the member; much like the key assembler of keyed unions does.
+	doTemplate(`
+		type _{{ .Type | TypeSymbol }}__ReprDiscriminantAssembler _{{ .Type | TypeSymbol }}__ReprAssembler
+	`, w, g.AdjCfg, g)
+	stubs := mixins.StringAssemblerTraits{
+		PkgName:       g.PkgName,
+		TypeName:      g.TypeName + ".DiscriminantAssembler",
+		AppliedPrefix: "_" + g.AdjCfg.TypeSymbol(g.Type) + "__ReprDiscriminant",
+	}
+	stubs.EmitNodeAssemblerMethodBeginMap(w)
+	stubs.EmitNodeAssemblerMethodBeginList(w)
+	stubs.EmitNodeAssemblerMethodAssignNull(w)
+	stubs.EmitNodeAssemblerMethodAssignBool(w)
+	stubs.EmitNodeAssemblerMethodAssignInt(w)
+	stubs.EmitNodeAssemblerMethodAssignFloat(w)
+	doTemplate(`
+		func (da *_{{ .Type | TypeSymbol }}__ReprDiscriminantAssembler) AssignString(d string) error {
+			if da.state != maState_midValue || da.f != 0 {
+				panic("misuse: DiscriminantAssembler held beyond its valid lifetime")
+			}
+			switch d {
+			{{- range $i, $member := .Type.Members }}
+			case "{{ $member | dot.Type.RepresentationStrategy.GetDiscriminant }}":
+				da.ca = {{ add $i 1 }}
+				{{- if (eq (dot.AdjCfg.UnionMemlayout dot.Type) "embedAll") }}
+				da.w.tag = {{ add $i 1 }}
+				{{- end}}
+				return nil
+			{{- end}}
+			}
+			return schema.ErrInvalidUnionDiscriminant{TypeName:"{{ .PkgName }}.{{ .Type.Name }}.Repr", Discriminant: d, Expected: []string{ {{- range $member := .Type.Members }}"{{ $member | dot.Type.RepresentationStrategy.GetDiscriminant }}",{{ end }}}}
+		}
+	`, w, g.AdjCfg, g)
+	stubs.EmitNodeAssemblerMethodAssignBytes(w)
+	stubs.EmitNodeAssemblerMethodAssignLink(w)
+	doTemplate(`
+		func (da *_{{ .Type | TypeSymbol }}__ReprDiscriminantAssembler) AssignNode(v ipld.Node) error {
+			if v2, err := v.AsString(); err != nil {
+				return err
+			} else {
+				return da.AssignString(v2)
+			}
+		}
+		func (_{{ .Type | TypeSymbol }}__ReprDiscriminantAssembler) Prototype() ipld.NodePrototype {
+			return _String__Prototype{}
+		}
+	`, w, g.AdjCfg, g)
+}
@@ -0,0 +1,575 @@
+package gengo
+
+import (
+	"io"
+
+	"github.com/ipld/go-ipld-prime/schema"
+	"github.com/ipld/go-ipld-prime/schema/gen/go/mixins"
+)
+
+var _ TypeGenerator = &unionReprInlineGenerator{}
+
+// The inline representation of unions is the map representation of the member (which must be a struct),
+//  with one more entry added to it for the discriminant.
+//
+// Most of the work is delegated to the member's own representation node and assembler;
+//  this code only deals with the discriminant entry, and forwards everything else.
+// As with envelope unions, the discriminant must come first when assembling entry by entry,
+//  because we can't begin on the member until we know which one it is; AssignNode doesn't have this limitation.
+
+func NewUnionReprInlineGenerator(pkgName string, typ *schema.TypeUnion, adjCfg *AdjunctCfg) TypeGenerator {
+	return unionReprInlineGenerator{
+		unionGenerator{
+			adjCfg,
+			mixins.MapTraits{
+				PkgName:    pkgName,
+				TypeName:   string(typ.Name()),
+				TypeSymbol: adjCfg.TypeSymbol(typ),
+			},
+			pkgName,
+			typ,
+		},
+	}
+}
+
+type unionReprInlineGenerator struct {
+	unionGenerator
+}
+
+func (g unionReprInlineGenerator) GetRepresentationNodeGen() NodeGenerator {
+	return unionReprInlineReprGenerator{
+		g.AdjCfg,
+		mixins.MapTraits{
+			PkgName:    g.PkgName,
+			TypeName:   string(g.Type.Name()) + ".Repr",
+			TypeSymbol: "_" + g.AdjCfg.TypeSymbol(g.Type) + "__Repr",
+		},
+		g.PkgName,
+		g.Type,
+	}
+}
+
+type unionReprInlineReprGenerator struct {
+	AdjCfg *AdjunctCfg
+	mixins.MapTraits
+	PkgName string
+	Type    *schema.TypeUnion
+}
+
+func (unionReprInlineReprGenerator) IsRepr() bool { return true } // hint used in some generalized templates.
+
+func (g unionReprInlineReprGenerator) EmitNodeType(w io.Writer) {
+	// The type is structurally the same, but will have a different set of methods.
+	doTemplate(`
+		type _{{ .Type | TypeSymbol }}__Repr _{{ .Type | TypeSymbol }}
+	`, w, g.AdjCfg, g)
+
+	// Constants for the discriminant key and values, so iterators can yield them without allocating.
+	doTemplate(`
+		var (
+			discriminantKey__{{ .Type | TypeSymbol }}_serial = _String{"{{ .Type.RepresentationStrategy.GetDiscriminantKey }}"}
+			{{- range $member := .Type.Members }}
+			memberName__{{ dot.Type | TypeSymbol }}_{{ $member.Name }}_serial = _String{"{{ $member | dot.Type.RepresentationStrategy.GetDiscriminant }}"}
+			{{- end }}
+		)
+	`, w, g.AdjCfg, g)
+
+	// Helpers for the discriminant, and for the member's representation, which everything else is delegated to.
+	doTemplate(`
+		func (n *_{{ .Type | TypeSymbol }}__Repr) discriminant() *_String {
+			{{- if (eq (.AdjCfg.UnionMemlayout .Type) "embedAll") }}
+			switch n.tag {
+			{{- range $i, $member := .Type.Members }}
+			case {{ add $i 1 }}:
+				return &memberName__{{ dot.Type | TypeSymbol }}_{{ $member.Name }}_serial
+			{{- end}}
+			{{- else if (eq (.AdjCfg.UnionMemlayout .Type) "interface") }}
+			switch n.x.(type) {
+			{{- range $member := .Type.Members }}
+			case {{ $member | TypeSymbol }}:
+				return &memberName__{{ dot.Type | TypeSymbol }}_{{ $member.Name }}_serial
+			{{- end}}
+			{{- end}}
+			default:
+				panic("unreachable")
+			}
+		}
+		func (n *_{{ .Type | TypeSymbol }}__Repr) content() ipld.Node {
+			{{- if (eq (.AdjCfg.UnionMemlayout .Type) "embedAll") }}
+			switch n.tag {
+			{{- range $i, $member := .Type.Members }}
+			case {{ add $i 1 }}:
+				return n.x{{ add $i 1 }}.Representation()
+			{{- end}}
+			{{- else if (eq (.AdjCfg.UnionMemlayout .Type) "interface") }}
+			switch n2 := n.x.(type) {
+			{{- range $member := .Type.Members }}
+			case {{ $member | TypeSymbol }}:
+				return n2.Representation()
+			{{- end}}
+			{{- end}}
+			default:
+				panic("unreachable")
+			}
+		}
+	`, w, g.AdjCfg, g)
+}
+
+func (g unionReprInlineReprGenerator) EmitNodeTypeAssertions(w io.Writer) {
+	doTemplate(`
+		var _ ipld.Node = &_{{ .Type | TypeSymbol }}__Repr{}
+	`, w, g.AdjCfg, g)
+}
+
+func (g unionReprInlineReprGenerator) EmitNodeMethodLookupByString(w io.Writer) {
+	doTemplate(`
+		func (n *_{{ .Type | TypeSymbol }}__Repr) LookupByString(key string) (ipld.Node, error) {
+			if key == "{{ .Type.RepresentationStrategy.GetDiscriminantKey }}" {
+				return n.discriminant(), nil
+			}
+			return n.content().LookupByString(key)
+		}
+	`, w, g.AdjCfg, g)
+}
+
+func (g unionReprInlineReprGenerator) EmitNodeMethodLookupByNode(w io.Writer) {
+	doTemplate(`
+		func (n *_{{ .Type | TypeSymbol }}__Repr) LookupByNode(key ipld.Node) (ipld.Node, error) {
+			ks, err := key.AsString()
+			if err != nil {
+				return nil, err
+			}
+			return n.LookupByString(ks)
+		}
+	`, w, g.AdjCfg, g)
+}
+
+func (g unionReprInlineReprGenerator) EmitNodeMethodMapIterator(w io.Writer) {
+	// Always yields the discriminant first, so that the output can be assembled again, even entry by entry.
+	doTemplate(`
+		func (n *_{{ .Type | TypeSymbol }}__Repr) MapIterator() ipld.MapIterator {
+			return &_{{ .Type | TypeSymbol }}__ReprMapItr{n, nil}
+		}
+
+		type _{{ .Type | TypeSymbol }}__ReprMapItr struct {
+			n   *_{{ .Type | TypeSymbol }}__Repr
+			itr ipld.MapIterator // nil until the discriminant has been yielded.
+		}
+
+		func (itr *_{{ .Type | TypeSymbol }}__ReprMapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+			if itr.itr == nil {
+				itr.itr = itr.n.content().MapIterator()
+				return &discriminantKey__{{ .Type | TypeSymbol }}_serial, itr.n.discriminant(), nil
+			}
+			return itr.itr.Next()
+		}
+		func (itr *_{{ .Type | TypeSymbol }}__ReprMapItr) Done() bool {
+			return itr.itr != nil && itr.itr.Done()
+		}
+
+	`, w, g.AdjCfg, g)
+}
+
+func (g unionReprInlineReprGenerator) EmitNodeMethodLength(w io.Writer) {
+	doTemplate(`
+		func (n *_{{ .Type | TypeSymbol }}__Repr) Length() int64 {
+			return 1 + n.content().Length()
+		}
+	`, w, g.AdjCfg, g)
+}
+
+func (g unionReprInlineReprGenerator) EmitNodeMethodPrototype(w io.Writer) {
+	emitNodeMethodPrototype_typical(w, g.AdjCfg, g)
+}
+
+func (g unionReprInlineReprGenerator) EmitNodePrototypeType(w io.Writer) {
+	emitNodePrototypeType_typical(w, g.AdjCfg, g)
+}
+
+// --- NodeBuilder and NodeAssembler --->
+
+func (g unionReprInlineReprGenerator) GetNodeBuilderGenerator() NodeBuilderGenerator {
+	return unionReprInlineReprBuilderGenerator{
+		g.AdjCfg,
+		mixins.MapAssemblerTraits{
+			PkgName:       g.PkgName,
+			TypeName:      g.TypeName,
+			AppliedPrefix: "_" + g.AdjCfg.TypeSymbol(g.Type) + "__Repr",
+		},
+		g.PkgName,
+		g.Type,
+	}
+}
+
+type unionReprInlineReprBuilderGenerator struct {
+	AdjCfg *AdjunctCfg
+	mixins.MapAssemblerTraits
+	PkgName string
+	Type    *schema.TypeUnion
+}
+
+func (unionReprInlineReprBuilderGenerator) IsRepr() bool { return true } // hint used in some generalized templates.
+
+func (g unionReprInlineReprBuilderGenerator) EmitNodeBuilderType(w io.Writer) {
+	emitEmitNodeBuilderType_typical(w, g.AdjCfg, g)
+}
+func (g unionReprInlineReprBuilderGenerator) EmitNodeBuilderMethods(w io.Writer) {
+	emitNodeBuilderMethods_typical(w, g.AdjCfg, g)
+}
+func (g unionReprInlineReprBuilderGenerator) EmitNodeAssemblerType(w io.Writer) {
+	// - 'f' is the **f**ocused key that will be assembled next: 0 for the discriminant, 1 for any of the member's fields.
+	// - 'ca' is the member chosen by the discriminant, or 0 until then; the 'ca*' fields are the member assemblers, as in the other union representations.
+	// - 'cma' is the **c**hild **m**ap **a**ssembler we got from beginning a map on the chosen member, which all other entries are forwarded to.
+	doTemplate(`
+		type _{{ .Type | TypeSymbol }}__ReprAssembler struct {
+			w *_{{ .Type | TypeSymbol }}
+			m *schema.Maybe
+			state maState
+			f int
+
+			cm schema.Maybe
+			{{- range $i, $member := .Type.Members }}
+			ca{{ add $i 1 }} {{ if (eq (dot.AdjCfg.UnionMemlayout dot.Type) "interface") }}*{{end}}_{{ $member | TypeSymbol }}__ReprAssembler
+			{{end -}}
+			ca uint
+			cma ipld.MapAssembler
+		}
+	`, w, g.AdjCfg, g)
+
+	doTemplate(`
+		func (na *_{{ .Type | TypeSymbol }}__ReprAssembler) reset() {
+			na.state = maState_initial
+			switch na.ca {
+			case 0:
+				return
+			{{- range $i, $member := .Type.Members }}
+			case {{ add $i 1 }}:
+				na.ca{{ add $i 1 }}.reset()
+			{{end -}}
+			default:
+				panic("unreachable")
+			}
+			na.ca = 0
+			na.cm = schema.Maybe_Absent
+			na.cma = nil
+		}
+	`, w, g.AdjCfg, g)
+}
+func (g unionReprInlineReprBuilderGenerator) EmitNodeAssemblerMethodBeginMap(w io.Writer) {
+	emitNodeAssemblerMethodBeginMap_strictoid(w, g.AdjCfg, g)
+}
+func (g unionReprInlineReprBuilderGenerator) EmitNodeAssemblerMethodAssignNull(w io.Writer) {
+	emitNodeAssemblerMethodAssignNull_recursive(w, g.AdjCfg, g)
+}
+func (g unionReprInlineReprBuilderGenerator) EmitNodeAssemblerMethodAssignNode(w io.Writer) {
+	// Looks up the discriminant first, then copies all the other entries, so their order doesn't matter.
+	doTemplate(`
+		func (na *_{{ .Type | TypeSymbol }}__ReprAssembler) AssignNode(v ipld.Node) error {
+			if v.IsNull() {
+				return na.AssignNull()
+			}
+			v2, ok := v.(*_{{ .Type | TypeSymbol }})
+			if v3, ok3 := v.(*_{{ .Type | TypeSymbol }}__Repr); ok3 {
+				v2, ok = (*_{{ .Type | TypeSymbol }})(v3), true
+			}
+			if ok {
+				switch *na.m {
+				case schema.Maybe_Value, schema.Maybe_Null:
+					panic("invalid state: cannot assign into assembler that's already finished")
+				case midvalue:
+					panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+				}
+				{{- if .Type | MaybeUsesPtr }}
+				if na.w == nil {
+					na.w = v2
+					*na.m = schema.Maybe_Value
+					return nil
+				}
+				{{- end}}
+				*na.w = *v2
+				*na.m = schema.Maybe_Value
+				return nil
+			}
+			if v.Kind() != ipld.Kind_Map {
+				return ipld.ErrWrongKind{TypeName: "{{ .PkgName }}.{{ .Type.Name }}.Repr", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+			}
+			d, err := v.LookupByString("{{ .Type.RepresentationStrategy.GetDiscriminantKey }}")
+			if err != nil {
+				return err
+			}
+			if _, err := na.BeginMap(v.Length()); err != nil {
+				return err
+			}
+			va, err := na.AssembleEntry("{{ .Type.RepresentationStrategy.GetDiscriminantKey }}")
+			if err != nil {
+				return err
+			}
+			if err := va.AssignNode(d); err != nil {
+				return err
+			}
+			itr := v.MapIterator()
+			for !itr.Done() {
+				k, v, err := itr.Next()
+				if err != nil {
+					return err
+				}
+				if ks, _ := k.AsString(); ks == "{{ .Type.RepresentationStrategy.GetDiscriminantKey }}" {
+					continue
+				}
+				if err := na.AssembleKey().AssignNode(k); err != nil {
+					return err
+				}
+				if err := na.AssembleValue().AssignNode(v); err != nil {
+					return err
+				}
+			}
+			return na.Finish()
+		}
+	`, w, g.AdjCfg, g)
+}
+func (g unionReprInlineReprBuilderGenerator) EmitNodeAssemblerOtherBits(w io.Writer) {
+	g.emitMapAssemblerChildTidyHelper(w)
+	g.emitMapAssemblerMethods(w)
+	g.emitKeyAssembler(w)
+	g.emitDiscriminantAssembler(w)
+}
+func (g unionReprInlineReprBuilderGenerator) emitMapAssemblerChildTidyHelper(w io.Writer) {
+	// Only the discriminant needs checking: the member's assembler tidies its own values.
+	doTemplate(`
+		func (ma *_{{ .Type | TypeSymbol }}__ReprAssembler) valueFinishTidy() bool {
+			if ma.f == 0 && ma.ca == 0 {
+				return false
+			}
+			ma.state = maState_initial
+			return true
+		}
+	`, w, g.AdjCfg, g)
+}
+func (g unionReprInlineReprBuilderGenerator) emitMapAssemblerMethods(w io.Writer) {
+	doTemplate(`
+		func (ma *_{{ .Type | TypeSymbol }}__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+			if err := ma.AssembleKey().AssignString(k); err != nil {
+				return nil, err
+			}
+			return ma.AssembleValue(), nil
+		}
+	`, w, g.AdjCfg, g)
+
+	doTemplate(`
+		func (ma *_{{ .Type | TypeSymbol }}__ReprAssembler) AssembleKey() ipld.NodeAssembler {
+			{{- if Compact }}
+			if ma.state == maState_midValue {
+				ma.valueFinishTidy()
+			}
+			gensupport.MapAssemblerExpectKey("AssembleKey", uint8(ma.state))
+			{{- else }}
+			switch ma.state {
+			case maState_initial:
+				// carry on
+			case maState_midKey:
+				panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+			case maState_expectValue:
+				panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+			case maState_midValue:
+				if !ma.valueFinishTidy() {
+					panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+				} // if tidy success: carry on
+			case maState_finished:
+				panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+			}
+			{{- end}}
+			ma.state = maState_midKey
+			return (*_{{ .Type | TypeSymbol }}__ReprKeyAssembler)(ma)
+		}
+	`, w, g.AdjCfg, g)
+
+	doTemplate(`
+		func (ma *_{{ .Type | TypeSymbol }}__ReprAssembler) AssembleValue() ipld.NodeAssembler {
+			{{- if Compact }}
+			gensupport.MapAssemblerExpectValue("AssembleValue", uint8(ma.state))
+			{{- else }}
+			switch ma.state {
+			case maState_initial:
+				panic("invalid state: AssembleValue cannot be called when no key is primed")
+			case maState_midKey:
+				panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+			case maState_expectValue:
+				// carry on
+			case maState_midValue:
+				panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+			case maState_finished:
+				panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+			}
+			{{- end}}
+			ma.state = maState_midValue
+			if ma.f == 0 {
+				return (*_{{ .Type | TypeSymbol }}__ReprDiscriminantAssembler)(ma)
+			}
+			return ma.cma.AssembleValue()
+		}
+	`, w, g.AdjCfg, g)
+
+	doTemplate(`
+		func (ma *_{{ .Type | TypeSymbol }}__ReprAssembler) Finish() error {
+			{{- if Compact }}
+			if ma.state == maState_midValue {
+				ma.valueFinishTidy()
+			}
+			gensupport.MapAssemblerExpectKey("Finish", uint8(ma.state))
+			{{- else }}
+			switch ma.state {
+			case maState_initial:
+				// carry on
+			case maState_midKey:
+				panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+			case maState_expectValue:
+				panic("invalid state: Finish cannot be called when expecting start of value assembly")
+			case maState_midValue:
+				if !ma.valueFinishTidy() {
+					panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+				} // if tidy success: carry on
+			case maState_finished:
+				panic("invalid state: Finish cannot be called on an assembler that's already finished")
+			}
+			{{- end}}
+			if ma.ca == 0 {
+				return schema.ErrNotUnionStructure{TypeName:"{{ .PkgName }}.{{ .Type.Name }}.Repr", Detail: "missing the discriminant key \"{{ .Type.RepresentationStrategy.GetDiscriminantKey }}\""}
+			}
+			if err := ma.cma.Finish(); err != nil {
+				return err
+			}
+			ma.state = maState_finished
+			*ma.m = schema.Maybe_Value
+			return nil
+		}
+	`, w, g.AdjCfg, g)
+
+	doTemplate(`
+		func (ma *_{{ .Type | TypeSymbol }}__ReprAssembler) KeyPrototype() ipld.NodePrototype {
+			return _String__Prototype{}
+		}
+		func (ma *_{{ .Type | TypeSymbol }}__ReprAssembler) ValuePrototype(k string) ipld.NodePrototype {
+			if k == "{{ .Type.RepresentationStrategy.GetDiscriminantKey }}" {
+				return _String__Prototype{}
+			}
+			return nil // struct assemblers can't say yet either.
+		}
+	`, w, g.AdjCfg, g)
+}
+func (g unionReprInlineReprBuilderGenerator) emitKeyAssembler(w io.Writer) {
+	doTemplate(`
+		type _{{ .Type | TypeSymbol }}__ReprKeyAssembler _{{ .Type | TypeSymbol }}__ReprAssembler
+	`, w, g.AdjCfg, g)
+	stubs := mixins.StringAssemblerTraits{
+		PkgName:       g.PkgName,
+		TypeName:      g.TypeName + ".KeyAssembler", // ".Repr" is already in `g.TypeName`, so don't stutter the "Repr" part.
+		AppliedPrefix: "_" + g.AdjCfg.TypeSymbol(g.Type) + "__ReprKey",
+	}
+	stubs.EmitNodeAssemblerMethodBeginMap(w)
+	stubs.EmitNodeAssemblerMethodBeginList(w)
+	stubs.EmitNodeAssemblerMethodAssignNull(w)
+	stubs.EmitNodeAssemblerMethodAssignBool(w)
+	stubs.EmitNodeAssemblerMethodAssignInt(w)
+	stubs.EmitNodeAssemblerMethodAssignFloat(w)
+	doTemplate(`
+		func (ka *_{{ .Type | TypeSymbol }}__ReprKeyAssembler) AssignString(k string) error {
+			if ka.state != maState_midKey {
+				panic("misuse: KeyAssembler held beyond its valid lifetime")
+			}
+			if k == "{{ .Type.RepresentationStrategy.GetDiscriminantKey }}" {
+				if ka.ca != 0 {
+					return ipld.ErrRepeatedMapKey{Key: &discriminantKey__{{ .Type | TypeSymbol }}_serial}
+				}
+				ka.f = 0
+			} else {
+				if ka.ca == 0 {
+					return schema.ErrNotUnionStructure{TypeName:"{{ .PkgName }}.{{ .Type.Name }}.Repr", Detail: "the discriminant key \"{{ .Type.RepresentationStrategy.GetDiscriminantKey }}\" must come before any other keys"}
+				}
+				if err := ka.cma.AssembleKey().AssignString(k); err != nil {
+					return err
+				}
+				ka.f = 1
+			}
+			ka.state = maState_expectValue
+			return nil
+		}
+	`, w, g.AdjCfg, g)
+	stubs.EmitNodeAssemblerMethodAssignBytes(w)
+	stubs.EmitNodeAssemblerMethodAssignLink(w)
+	doTemplate(`
+		func (ka *_{{ .Type | TypeSymbol }}__ReprKeyAssembler) AssignNode(v ipld.Node) error {
+			if v2, err := v.AsString(); err != nil {
+				return err
+			} else {
+				return ka.AssignString(v2)
+			}
+		}
+		func (_{{ .Type | TypeSymbol }}__ReprKeyAssembler) Prototype() ipld.NodePrototype {
+			return _String__Prototype{}
+		}
+	`, w, g.AdjCfg, g)
+}
+func (g unionReprInlineReprBuilderGenerator) emitDiscriminantAssembler(w io.Writer) {
+	// The discriminant assembler picks the member, and begins a map on its assembler, ready for the other entries.
+	doTemplate(`
+		type _{{ .Type | TypeSymbol }}__ReprDiscriminantAssembler _{{ .Type | TypeSymbol }}__ReprAssembler
+	`, w, g.AdjCfg, g)
+	stubs := mixins.StringAssemblerTraits{
+		PkgName:       g.PkgName,
+		TypeName:      g.TypeName + ".DiscriminantAssembler",
+		AppliedPrefix: "_" + g.AdjCfg.TypeSymbol(g.Type) + "__ReprDiscriminant",
+	}
+	stubs.EmitNodeAssemblerMethodBeginMap(w)
+	stubs.EmitNodeAssemblerMethodBeginList(w)
+	stubs.EmitNodeAssemblerMethodAssignNull(w)
+	stubs.EmitNodeAssemblerMethodAssignBool(w)
+	stubs.EmitNodeAssemblerMethodAssignInt(w)
+	stubs.EmitNodeAssemblerMethodAssignFloat(w)
+	doTemplate(`
+		func (da *_{{ .Type | TypeSymbol }}__ReprDiscriminantAssembler) AssignString(d string) error {
+			if da.state != maState_midValue || da.f != 0 {
+				panic("misuse: DiscriminantAssembler held beyond its valid lifetime")
+			}
+			switch d {
+			{{- range $i, $member := .Type.Members }}
+			case "{{ $member | dot.Type.RepresentationStrategy.GetDiscriminant }}":
+				da.ca = {{ add $i 1 }}
+				{{- if (eq (dot.AdjCfg.UnionMemlayout dot.Type) "embedAll") }}
+				da.w.tag = {{ add $i 1 }}
+				da.ca{{ add $i 1 }}.w = &da.w.x{{ add $i 1 }}
+				da.ca{{ add $i 1 }}.m = &da.cm
+				{{- else if (eq (dot.AdjCfg.UnionMemlayout dot.Type) "interface") }}
+				x := &_{{ $member | TypeSymbol }}{}
+				da.w.x = x
+				if da.ca{{ add $i 1 }} == nil {
+					da.ca{{ add $i 1 }} = &_{{ $member | TypeSymbol }}__ReprAssembler{}
+				}
+				da.ca{{ add $i 1 }}.w = x
+				da.ca{{ add $i 1 }}.m = &da.cm
+				{{- end}}
+				cma, err := da.ca{{ add $i 1 }}.BeginMap(0)
+				if err != nil {
+					return err
+				}
+				da.cma = cma
+				return nil
+			{{- end}}
+			}
+			return schema.ErrInvalidUnionDiscriminant{TypeName:"{{ .PkgName }}.{{ .Type.Name }}.Repr", Discriminant: d, Expected: []string{ {{- range $member := .Type.Members }}"{{ $member | dot.Type.RepresentationStrategy.GetDiscriminant }}",{{ end }}}}
+		}
+	`, w, g.AdjCfg, g)
+	stubs.EmitNodeAssemblerMethodAssignBytes(w)
+	stubs.EmitNodeAssemblerMethodAssignLink(w)
+	doTemplate(`
+		func (da *_{{ .Type | TypeSymbol }}__ReprDiscriminantAssembler) AssignNode(v ipld.Node) error {
+			if v2, err := v.AsString(); err != nil {
+				return err
+			} else {
+				return da.AssignString(v2)
+			}
+		}
+		func (_{{ .Type | TypeSymbol }}__ReprDiscriminantAssembler) Prototype() ipld.NodePrototype {
+			return _String__Prototype{}
+		}
+	`, w, g.AdjCfg, g)
+}
@@ -175,7 +175,7 @@ func (g unionReprStringprefixReprBuilderGenerator) EmitNodeBuilderMethods(w io.W
 				{{- end}}
 			{{- end}}
 			default:
-				return schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(ss[0])}
+				return schema.ErrInvalidUnionDiscriminant{TypeName:"{{ .PkgName }}.{{ .Type.Name }}.Repr", Discriminant: ss[0], Expected: []string{ {{- range $member := .Type.Members }}"{{ $member | dot.Type.RepresentationStrategy.GetDiscriminant }}",{{ end }}}}
 			}
 		}
 	`, w, g.AdjCfg, g)
@@ -200,10 +200,14 @@ func (g unionReprStringprefixReprBuilderGenerator) EmitNodeAssemblerMethodAssign
 	// TODO:DRY: this is identical to other string-repr-on-non-string-type.
 	doTemplate(`
 		func (na *_{{ .Type | TypeSymbol }}__ReprAssembler) AssignString(v string) error {
+			{{- if Compact }}
+			gensupport.CheckAssignable(*na.m)
+			{{- else }}
 			switch *na.m {
 			case schema.Maybe_Value, schema.Maybe_Null:
 				panic("invalid state: cannot assign into assembler that's already finished")
 			}
+			{{- end}}
 			{{- if .Type | MaybeUsesPtr }}
 			if na.w == nil {
 				na.w = &_{{ .Type | TypeSymbol }}{}
@@ -229,11 +233,19 @@ func (g unionReprStringprefixReprBuilderGenerator) EmitNodeAssemblerMethodAssign
 			if v.IsNull() {
 				return na.AssignNull()
 			}
-			if v2, ok := v.(*_{{ .Type | TypeSymbol }}); ok {
+			v2, ok := v.(*_{{ .Type | TypeSymbol }})
+			if v3, ok3 := v.(*_{{ .Type | TypeSymbol }}__Repr); ok3 {
+				v2, ok = (*_{{ .Type | TypeSymbol }})(v3), true
+			}
+			if ok {
+				{{- if Compact }}
+				gensupport.CheckAssignable(*na.m)
+				{{- else }}
 				switch *na.m {
 				case schema.Maybe_Value, schema.Maybe_Null:
 					panic("invalid state: cannot assign into assembler that's already finished")
 				}
+				{{- end}}
 				{{- if .Type | MaybeUsesPtr }}
 				if na.w == nil {
 					na.w = v2
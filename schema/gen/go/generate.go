@@ -1,11 +1,18 @@
 package gengo
 
 import (
+	"bytes"
 	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 
 	"github.com/ipld/go-ipld-prime/schema"
 )
@@ -13,12 +20,24 @@ import (
 // Generate takes a typesystem and the adjunct config for codegen,
 // and emits generated code in the given path with the given package name.
 //
-// All of the files produced will match the pattern "ipldsch.*.gen.go".
+// Each type gets a file of its own, named "ipldsch_type_{TypeSymbol}.go";
+// the parts shared by all of them, such as the type table,
+// are in "ipldsch_minima.go".
+// All of the files are gofmt'd, and their content only depends on the arguments,
+// so generating the same schema twice produces the same bytes.
+//
+// With the adjunct config's RoundtripTests, there's also "ipldsch_roundtrip_test.go";
+// see EmitRoundtripTests.
+//
+// The minima file's header also records the adjunct config's SchemaHash, if it's set;
+// see GeneratedSchemaHash for reading it back.
+//
+// Any files in the path which were produced by an earlier run are removed first,
+// so that types which no longer exist don't leave files behind.
 func Generate(pth string, pkgName string, ts schema.TypeSystem, adjCfg *AdjunctCfg) {
-	// Emit fixed bits.
-	withFile(filepath.Join(pth, "ipldsch_minima.go"), func(f io.Writer) {
-		EmitInternalEnums(pkgName, f)
-	})
+	if err := removeGeneratedFiles(pth); err != nil {
+		panic(err)
+	}
 
 	externs, err := getExternTypes(pth)
 	if err != nil {
@@ -26,115 +45,246 @@ func Generate(pth string, pkgName string, ts schema.TypeSystem, adjCfg *AdjunctC
 		externs = make(map[string]struct{})
 	}
 
-	// Local helper function for applying generation logic to each type.
-	//  We will end up doing this more than once because in this layout, more than one file contains part of the story for each type.
-	applyToEachType := func(fn func(tg TypeGenerator, w io.Writer), f io.Writer) {
-		// Sort the type names so we have a determinisic order; this affects output consistency.
-		//  Any stable order would do, but we don't presently have one, so a sort is necessary.
-		types := ts.GetTypes()
-		keys := make(sortableTypeNames, 0, len(types))
-		for tn := range types {
-			if _, exists := externs[tn.String()]; !exists {
-				keys = append(keys, tn)
-			}
+	// Emit fixed bits, and the type table.
+	//  The minima file also records the hash of the schema, when there is one.
+	var buf bytes.Buffer
+	EmitInternalEnums(pkgName, &buf)
+	EmitTypeTable(pkgName, ts, adjCfg, &buf)
+	minima := buf.Bytes()
+	if adjCfg.SchemaHash != "" {
+		minima = bytes.Replace(minima, []byte(doNotEditComment), []byte(doNotEditComment+"\n"+schemaHashComment+adjCfg.SchemaHash), 1)
+	}
+	writeFormatted(filepath.Join(pth, "ipldsch_minima.go"), minima)
+
+	// Sort the type names so we have a determinisic order; this affects output consistency.
+	//  Any stable order would do, but we don't presently have one, so a sort is necessary.
+	types := ts.GetTypes()
+	keys := make(sortableTypeNames, 0, len(types))
+	for tn := range types {
+		if _, exists := externs[adjCfg.TypeSymbol(types[tn])]; !exists {
+			keys = append(keys, tn)
 		}
-		sort.Sort(keys)
-		for _, tn := range keys {
-			switch t2 := types[tn].(type) {
-			case *schema.TypeBool:
-				fn(NewBoolReprBoolGenerator(pkgName, t2, adjCfg), f)
-			case *schema.TypeInt:
-				fn(NewIntReprIntGenerator(pkgName, t2, adjCfg), f)
-			case *schema.TypeFloat:
-				fn(NewFloatReprFloatGenerator(pkgName, t2, adjCfg), f)
-			case *schema.TypeString:
-				fn(NewStringReprStringGenerator(pkgName, t2, adjCfg), f)
-			case *schema.TypeBytes:
-				fn(NewBytesReprBytesGenerator(pkgName, t2, adjCfg), f)
-			case *schema.TypeLink:
-				fn(NewLinkReprLinkGenerator(pkgName, t2, adjCfg), f)
-			case *schema.TypeStruct:
-				switch t2.RepresentationStrategy().(type) {
-				case schema.StructRepresentation_Map:
-					fn(NewStructReprMapGenerator(pkgName, t2, adjCfg), f)
-				case schema.StructRepresentation_Tuple:
-					fn(NewStructReprTupleGenerator(pkgName, t2, adjCfg), f)
-				case schema.StructRepresentation_Stringjoin:
-					fn(NewStructReprStringjoinGenerator(pkgName, t2, adjCfg), f)
-				default:
-					panic("unrecognized struct representation strategy")
-				}
-			case *schema.TypeMap:
-				fn(NewMapReprMapGenerator(pkgName, t2, adjCfg), f)
-			case *schema.TypeList:
-				fn(NewListReprListGenerator(pkgName, t2, adjCfg), f)
-			case *schema.TypeUnion:
-				switch t2.RepresentationStrategy().(type) {
-				case schema.UnionRepresentation_Keyed:
-					fn(NewUnionReprKeyedGenerator(pkgName, t2, adjCfg), f)
-				case schema.UnionRepresentation_Kinded:
-					fn(NewUnionReprKindedGenerator(pkgName, t2, adjCfg), f)
-				case schema.UnionRepresentation_Stringprefix:
-					fn(NewUnionReprStringprefixGenerator(pkgName, t2, adjCfg), f)
-				default:
-					panic("unrecognized union representation strategy")
-				}
-			default:
-				panic("add more type switches here :)")
-			}
+	}
+	sort.Sort(keys)
+	for _, tn := range keys {
+		tg := generatorFor(pkgName, types[tn], adjCfg)
+
+		buf.Reset()
+		// The native type, then everything else: the Node/NodeBuilder/NodeAssembler boilerplate,
+		//  the typedefs for representation-level data, and the MaybeT boilerplate.
+		tg.EmitNativeType(&buf)
+		tg.EmitNativeAccessors(&buf)
+		tg.EmitNativeBuilder(&buf)
+		tg.EmitNativeMaybe(&buf)
+		if adjCfg.CodecMethods() {
+			emitCodecMethods(&buf, adjCfg, tg)
 		}
+		EmitNode(tg, &buf)
+		tg.EmitTypedNodeMethodType(&buf)
+		tg.EmitTypedNodeMethodRepresentation(&buf)
+		EmitNode(tg.GetRepresentationNodeGen(), &buf)
+
+		writeGoFile(filepath.Join(pth, "ipldsch_type_"+adjCfg.TypeSymbol(types[tn])+".go"), pkgName, buf.Bytes())
 	}
 
-	// Emit a file with the type table, and the golang type defns for each type.
-	withFile(filepath.Join(pth, "ipldsch_types.go"), func(f io.Writer) {
-		// Emit headers, import statements, etc.
-		fmt.Fprintf(f, "package %s\n\n", pkgName)
-		fmt.Fprintf(f, doNotEditComment+"\n\n")
-		fmt.Fprintf(f, "import (\n")
-		fmt.Fprintf(f, "\tipld \"github.com/ipld/go-ipld-prime\"\n") // referenced for links
-		fmt.Fprintf(f, ")\n")
-		fmt.Fprintf(f, "var _ ipld.Node = nil // suppress errors when this dependency is not referenced\n")
+	if adjCfg.RoundtripTests() {
+		buf.Reset()
+		EmitRoundtripTests(pkgName, ts, adjCfg, &buf)
+		writeGoFile(filepath.Join(pth, "ipldsch_roundtrip_test.go"), pkgName, buf.Bytes())
+	}
+}
 
-		// Emit the type table.
-		EmitTypeTable(pkgName, ts, adjCfg, f)
+// schemaHashComment precedes the schema hash in the header of the minima file.
+const schemaHashComment = "// Schema hash: "
 
-		// Emit the type defns matching the schema types.
-		fmt.Fprintf(f, "\n// --- type definitions follow ---\n\n")
-		applyToEachType(func(tg TypeGenerator, w io.Writer) {
-			tg.EmitNativeType(w)
-			fmt.Fprintf(f, "\n")
-		}, f)
+// GeneratedSchemaHash returns the hash of the schema which the code generated in a directory came from,
+// as given by the SchemaHash of the adjunct config when it was generated,
+// so that it can be compared with the current one to tell if the code is out of date.
+// It returns an empty string if the generated code doesn't record a hash.
+func GeneratedSchemaHash(pth string) (string, error) {
+	src, err := ioutil.ReadFile(filepath.Join(pth, "ipldsch_minima.go"))
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(src), "\n") {
+		if strings.HasPrefix(line, schemaHashComment) {
+			return strings.TrimPrefix(line, schemaHashComment), nil
+		}
+		if strings.HasPrefix(line, "import") {
+			break // the header is over.
+		}
+	}
+	return "", nil
+}
 
-	})
+// generatorFor picks the generator for a type and its representation strategy.
+func generatorFor(pkgName string, typ schema.Type, adjCfg *AdjunctCfg) TypeGenerator {
+	switch t2 := typ.(type) {
+	case *schema.TypeBool:
+		return NewBoolReprBoolGenerator(pkgName, t2, adjCfg)
+	case *schema.TypeInt:
+		return NewIntReprIntGenerator(pkgName, t2, adjCfg)
+	case *schema.TypeFloat:
+		return NewFloatReprFloatGenerator(pkgName, t2, adjCfg)
+	case *schema.TypeString:
+		return NewStringReprStringGenerator(pkgName, t2, adjCfg)
+	case *schema.TypeBytes:
+		return NewBytesReprBytesGenerator(pkgName, t2, adjCfg)
+	case *schema.TypeLink:
+		return NewLinkReprLinkGenerator(pkgName, t2, adjCfg)
+	case *schema.TypeStruct:
+		switch t2.RepresentationStrategy().(type) {
+		case schema.StructRepresentation_Map:
+			return NewStructReprMapGenerator(pkgName, t2, adjCfg)
+		case schema.StructRepresentation_Tuple:
+			return NewStructReprTupleGenerator(pkgName, t2, adjCfg)
+		case schema.StructRepresentation_Stringjoin:
+			return NewStructReprStringjoinGenerator(pkgName, t2, adjCfg)
+		case schema.StructRepresentation_StringPairs:
+			return NewStructReprStringpairsGenerator(pkgName, t2, adjCfg)
+		case schema.StructRepresentation_ListPairs:
+			return NewStructReprListpairsGenerator(pkgName, t2, adjCfg)
+		default:
+			panic("unrecognized struct representation strategy")
+		}
+	case *schema.TypeMap:
+		switch t2.RepresentationStrategy().(type) {
+		case schema.MapRepresentation_Map:
+			return NewMapReprMapGenerator(pkgName, t2, adjCfg)
+		case schema.MapRepresentation_ListPairs:
+			return NewMapReprListpairsGenerator(pkgName, t2, adjCfg)
+		default:
+			panic("unrecognized map representation strategy")
+		}
+	case *schema.TypeList:
+		return NewListReprListGenerator(pkgName, t2, adjCfg)
+	case *schema.TypeUnion:
+		switch t2.RepresentationStrategy().(type) {
+		case schema.UnionRepresentation_Keyed:
+			return NewUnionReprKeyedGenerator(pkgName, t2, adjCfg)
+		case schema.UnionRepresentation_Kinded:
+			return NewUnionReprKindedGenerator(pkgName, t2, adjCfg)
+		case schema.UnionRepresentation_Stringprefix:
+			return NewUnionReprStringprefixGenerator(pkgName, t2, adjCfg)
+		case schema.UnionRepresentation_Envelope:
+			return NewUnionReprEnvelopeGenerator(pkgName, t2, adjCfg)
+		case schema.UnionRepresentation_Inline:
+			return NewUnionReprInlineGenerator(pkgName, t2, adjCfg)
+		default:
+			panic("unrecognized union representation strategy")
+		}
+	case *schema.TypeEnum:
+		switch t2.RepresentationStrategy().(type) {
+		case schema.EnumRepresentation_String:
+			return NewEnumReprStringGenerator(pkgName, t2, adjCfg)
+		case schema.EnumRepresentation_Int:
+			return NewEnumReprIntGenerator(pkgName, t2, adjCfg)
+		default:
+			panic("unrecognized enum representation strategy")
+		}
+	default:
+		panic("add more type switches here :)")
+	}
+}
 
-	// Emit a file with all the Node/NodeBuilder/NodeAssembler boilerplate.
-	//  Also includes typedefs for representation-level data.
-	//  Also includes the MaybeT boilerplate.
-	withFile(filepath.Join(pth, "ipldsch_satisfaction.go"), func(f io.Writer) {
-		// Emit headers, import statements, etc.
-		fmt.Fprintf(f, "package %s\n\n", pkgName)
-		fmt.Fprintf(f, doNotEditComment+"\n\n")
-		fmt.Fprintf(f, "import (\n")
-		fmt.Fprintf(f, "\tipld \"github.com/ipld/go-ipld-prime\"\n")        // referenced everywhere.
-		fmt.Fprintf(f, "\t\"github.com/ipld/go-ipld-prime/node/mixins\"\n") // referenced by node implementation guts.
-		fmt.Fprintf(f, "\t\"github.com/ipld/go-ipld-prime/schema\"\n")      // referenced by maybes (and surprisingly little else).
-		fmt.Fprintf(f, ")\n\n")
-
-		// For each type, we'll emit... everything except the native type, really.
-		applyToEachType(func(tg TypeGenerator, w io.Writer) {
-			tg.EmitNativeAccessors(w)
-			tg.EmitNativeBuilder(w)
-			tg.EmitNativeMaybe(w)
-			EmitNode(tg, w)
-			tg.EmitTypedNodeMethodType(w)
-			tg.EmitTypedNodeMethodRepresentation(w)
-
-			nrg := tg.GetRepresentationNodeGen()
-			EmitNode(nrg, w)
-
-			fmt.Fprintf(f, "\n")
-		}, f)
+// packagesUsable lists the packages which generated code may refer to,
+// by the names it refers to them with.
+var packagesUsable = map[string]string{
+	"bytes":      "bytes",
+	"dagjson":    "github.com/ipld/go-ipld-prime/codec/dagjson",
+	"fmt":        "fmt",
+	"gensupport": "github.com/ipld/go-ipld-prime/schema/gensupport",
+	"io":         "io",
+	"ipld":       "github.com/ipld/go-ipld-prime",
+	"mixins":     "github.com/ipld/go-ipld-prime/node/mixins",
+	"multicodec": "github.com/ipld/go-ipld-prime/multicodec",
+	"schema":     "github.com/ipld/go-ipld-prime/schema",
+	"strings":    "strings",
+	"testing":    "testing",
+}
+
+// writeGoFile writes a file holding the declarations in body,
+// adding the package clause, and imports for whichever of the packagesUsable
+// the declarations refer to.
+func writeGoFile(filename string, pkgName string, body []byte) {
+	var header bytes.Buffer
+	fmt.Fprintf(&header, "package %s\n\n", pkgName)
+	fmt.Fprintf(&header, doNotEditComment+"\n\n")
+	src := append(header.Bytes(), body...)
+
+	// Look for references to other packages; names which the parser couldn't resolve
+	//  within the file, and which are used as selectors, can only be package names.
+	f, err := parser.ParseFile(token.NewFileSet(), filename, src, 0)
+	if err != nil {
+		writeUnformatted(filename, src, err)
+	}
+	used := map[string]bool{}
+	ast.Inspect(f, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok && id.Obj == nil {
+				if _, ok := packagesUsable[id.Name]; ok {
+					used[id.Name] = true
+				}
+			}
+		}
+		return true
 	})
+	var imports []string
+	for name := range used {
+		imports = append(imports, packagesUsable[name])
+	}
+	sort.Strings(imports)
+	if len(imports) > 0 {
+		fmt.Fprintf(&header, "import (\n")
+		for _, imp := range imports {
+			fmt.Fprintf(&header, "\t%q\n", imp)
+		}
+		fmt.Fprintf(&header, ")\n\n")
+	}
+	writeFormatted(filename, append(header.Bytes(), body...))
+}
+
+// writeFormatted writes a file of Go source, gofmt'd.
+func writeFormatted(filename string, src []byte) {
+	formatted, err := format.Source(src)
+	if err != nil {
+		writeUnformatted(filename, src, err)
+	}
+	if err := ioutil.WriteFile(filename, formatted, 0644); err != nil {
+		panic(err)
+	}
+}
+
+// writeUnformatted writes source which failed to parse as it is, so that it can be inspected,
+// and then panics, as that's a bug in the generator.
+func writeUnformatted(filename string, src []byte, err error) {
+	if err := ioutil.WriteFile(filename, src, 0644); err != nil {
+		panic(err)
+	}
+	panic(fmt.Errorf("generated invalid code: %w", err))
+}
+
+// removeGeneratedFiles removes the files which an earlier Generate produced in a directory.
+// These are recognized by their name and their "do not edit" comment,
+// so that files written by hand are left alone.
+func removeGeneratedFiles(pth string) error {
+	files, err := filepath.Glob(filepath.Join(pth, "ipldsch_*.go"))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		src, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		if !strings.Contains(string(src), doNotEditComment) {
+			continue
+		}
+		if err := os.Remove(file); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func withFile(filename string, fn func(io.Writer)) {
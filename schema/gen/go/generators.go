@@ -173,8 +173,8 @@ func EmitTypeTable(pkgName string, ts schema.TypeSystem, adjCfg *AdjunctCfg, w i
 
 		type typeSlab struct {
 			{{- range . }}
-			{{ .Name }}       _{{ . | TypeSymbol }}__Prototype
-			{{ .Name }}__Repr _{{ . | TypeSymbol }}__ReprPrototype
+			{{ . | TypeSymbol }}       _{{ . | TypeSymbol }}__Prototype
+			{{ . | TypeSymbol }}__Repr _{{ . | TypeSymbol }}__ReprPrototype
 			{{- end}}
 		}
 	`, w, adjCfg, ts.GetTypes())
@@ -35,6 +35,9 @@ func emitNativeMaybe(w io.Writer, adjCfg *AdjunctCfg, data interface{}) {
 			return m.m == schema.Maybe_Value
 		}
 		func (m Maybe{{ .Type | TypeSymbol }}) AsNode() ipld.Node {
+			{{- if Compact }}
+			return gensupport.MaybeAsNode(m.m, {{if not (MaybeUsesPtr .Type) }}&{{end}}m.v)
+			{{- else }}
 			switch m.m {
 				case schema.Maybe_Absent:
 					return ipld.Absent
@@ -45,11 +48,16 @@ func emitNativeMaybe(w io.Writer, adjCfg *AdjunctCfg, data interface{}) {
 				default:
 					panic("unreachable")
 			}
+			{{- end}}
 		}
 		func (m Maybe{{ .Type | TypeSymbol }}) Must() {{ .Type | TypeSymbol }} {
+			{{- if Compact }}
+			gensupport.MaybeMust(m.m)
+			{{- else }}
 			if !m.Exists() {
 				panic("unbox of a maybe rejected")
 			}
+			{{- end}}
 			return {{if not (MaybeUsesPtr .Type) }}&{{end}}m.v
 		}
 	`, w, adjCfg, data)
@@ -60,7 +68,8 @@ func emitNativeType_scalar(w io.Writer, adjCfg *AdjunctCfg, data interface{}) {
 	//  while also having the advantage of meaning we can block direct casting,
 	//   which is desirable because the compiler then ensures our validate methods can't be evaded.
 	doTemplate(`
-		{{- if Comments -}}
+		{{- Doc .Type.Doc }}
+		{{- if Comments }}
 		// {{ .Type | TypeSymbol }} matches the IPLD Schema type "{{ .Type.Name }}".  It has {{ .Kind }} kind.
 		{{- end}}
 		type {{ .Type | TypeSymbol }} = *_{{ .Type | TypeSymbol }}
@@ -73,6 +82,9 @@ func emitNativeAccessors_scalar(w io.Writer, adjCfg *AdjunctCfg, data interface{
 	//  this method unboxes without needing to return an error that's statically impossible,
 	//   which makes it easier to use in chaining.
 	doTemplate(`
+		{{- if Comments -}}
+		// {{ .Kind.String | title }} returns the value as a native {{ .Kind | KindPrim }}.
+		{{- end}}
 		func (n {{ .Type | TypeSymbol }}) {{ .Kind.String | title }}() {{ .Kind | KindPrim }} {
 			return n.x
 		}
@@ -162,9 +174,13 @@ func emitEmitNodeBuilderType_typical(w io.Writer, adjCfg *AdjunctCfg, data inter
 func emitNodeBuilderMethods_typical(w io.Writer, adjCfg *AdjunctCfg, data interface{}) {
 	doTemplate(`
 		func (nb *_{{ .Type | TypeSymbol }}__{{ if .IsRepr }}Repr{{end}}Builder) Build() ipld.Node {
+			{{- if Compact }}
+			gensupport.CheckBuildable(*nb.m)
+			{{- else }}
 			if *nb.m != schema.Maybe_Value {
 				panic("invalid state: cannot call Build on an assembler that's not finished")
 			}
+			{{- end}}
 			return nb.w
 		}
 		func (nb *_{{ .Type | TypeSymbol }}__{{ if .IsRepr }}Repr{{end}}Builder) Reset() {
@@ -192,6 +208,9 @@ func emitNodeAssemblerType_scalar(w io.Writer, adjCfg *AdjunctCfg, data interfac
 func emitNodeAssemblerMethodAssignNull_scalar(w io.Writer, adjCfg *AdjunctCfg, data interface{}) {
 	doTemplate(`
 		func (na *_{{ .Type | TypeSymbol }}__{{ if .IsRepr }}Repr{{end}}Assembler) AssignNull() error {
+			{{- if Compact }}
+			return gensupport.AssignNull(na.m, allowNull, midvalue, "{{ .PkgName }}.{{ .TypeName }}{{ if .IsRepr }}.Repr{{end}}", {{ .Kind | KindSymbol }})
+			{{- else }}
 			switch *na.m {
 			case allowNull:
 				*na.m = schema.Maybe_Null
@@ -202,6 +221,7 @@ func emitNodeAssemblerMethodAssignNull_scalar(w io.Writer, adjCfg *AdjunctCfg, d
 				panic("invalid state: cannot assign into assembler that's already finished")
 			}
 			panic("unreachable")
+			{{- end}}
 		}
 	`, w, adjCfg, data)
 }
@@ -210,6 +230,9 @@ func emitNodeAssemblerMethodAssignNull_scalar(w io.Writer, adjCfg *AdjunctCfg, d
 func emitNodeAssemblerMethodAssignNull_recursive(w io.Writer, adjCfg *AdjunctCfg, data interface{}) {
 	doTemplate(`
 		func (na *_{{ .Type | TypeSymbol }}__{{ if .IsRepr }}Repr{{end}}Assembler) AssignNull() error {
+			{{- if Compact }}
+			return gensupport.AssignNull(na.m, allowNull, midvalue, "{{ .PkgName }}.{{ .TypeName }}{{ if .IsRepr }}.Repr{{end}}", {{ .Kind | KindSymbol }})
+			{{- else }}
 			switch *na.m {
 			case allowNull:
 				*na.m = schema.Maybe_Null
@@ -222,6 +245,7 @@ func emitNodeAssemblerMethodAssignNull_recursive(w io.Writer, adjCfg *AdjunctCfg
 				panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
 			}
 			panic("unreachable")
+			{{- end}}
 		}
 	`, w, adjCfg, data)
 }
@@ -234,10 +258,14 @@ func emitNodeAssemblerMethodAssignKind_scalar(w io.Writer, adjCfg *AdjunctCfg, d
 	//  otherwise, the 'w' ptr should already be set, and we fill that memory location without allocating, as usual.
 	doTemplate(`
 		func (na *_{{ .Type | TypeSymbol }}__Assembler) Assign{{ .Kind.String | title }}(v {{ .Kind | KindPrim }}) error {
+			{{- if Compact }}
+			gensupport.CheckAssignable(*na.m)
+			{{- else }}
 			switch *na.m {
 			case schema.Maybe_Value, schema.Maybe_Null:
 				panic("invalid state: cannot assign into assembler that's already finished")
 			}
+			{{- end}}
 			{{- if .Type | MaybeUsesPtr }}
 			if na.w == nil {
 				na.w = &_{{ .Type | TypeSymbol }}{}
@@ -263,10 +291,14 @@ func emitNodeAssemblerMethodAssignNode_scalar(w io.Writer, adjCfg *AdjunctCfg, d
 				return na.AssignNull()
 			}
 			if v2, ok := v.(*_{{ .Type | TypeSymbol }}); ok {
+				{{- if Compact }}
+				gensupport.CheckAssignable(*na.m)
+				{{- else }}
 				switch *na.m {
 				case schema.Maybe_Value, schema.Maybe_Null:
 					panic("invalid state: cannot assign into assembler that's already finished")
 				}
+				{{- end}}
 				{{- if .Type | MaybeUsesPtr }}
 				if na.w == nil {
 					na.w = v2
@@ -286,3 +318,38 @@ func emitNodeAssemblerMethodAssignNode_scalar(w io.Writer, adjCfg *AdjunctCfg, d
 		}
 	`, w, adjCfg, data)
 }
+
+// emitCodecMethods emits the methods for encoding and decoding a type with any codec in the multicodec registry,
+// which are only generated if the adjunct config asks for them.
+// They always use the type's representation, since that's what's meant to be serialized.
+func emitCodecMethods(w io.Writer, adjCfg *AdjunctCfg, data interface{}) {
+	doTemplate(`
+		{{- if Comments -}}
+		// Encode serializes the representation of the value with the codec registered for the given multicodec indicator
+		// (see the multicodec package), and writes it to w.
+		{{- end}}
+		func (n {{ .Type | TypeSymbol }}) Encode(w io.Writer, indicator uint64) error {
+			encode, err := multicodec.LookupEncoder(indicator)
+			if err != nil {
+				return err
+			}
+			return encode(n.Representation(), w)
+		}
+
+		{{ if Comments -}}
+		// Decode deserializes a value of this type from its representation, read from r,
+		// using the codec registered for the given multicodec indicator (see the multicodec package).
+		{{ end -}}
+		func (_{{ .Type | TypeSymbol }}__Prototype) Decode(r io.Reader, indicator uint64) ({{ .Type | TypeSymbol }}, error) {
+			decode, err := multicodec.LookupDecoder(indicator)
+			if err != nil {
+				return nil, err
+			}
+			nb := _{{ .Type | TypeSymbol }}__ReprPrototype{}.NewBuilder()
+			if err := decode(nb, r); err != nil {
+				return nil, err
+			}
+			return nb.Build().({{ .Type | TypeSymbol }}), nil
+		}
+	`, w, adjCfg, data)
+}
@@ -59,12 +59,19 @@ func emitNodeAssemblerMethodAssignNode_listoid(w io.Writer, adjCfg *AdjunctCfg,
 	//
 	// This works easily for both type-level and representational nodes because
 	//  any divergences that have to do with the child value are nicely hidden behind `AssembleValue`.
+	// Representational assemblers also take phase 2 for our own representation node, since it's the same memory as the type-level node.
 	doTemplate(`
 		func (na *_{{ .Type | TypeSymbol }}__{{ if .IsRepr }}Repr{{end}}Assembler) AssignNode(v ipld.Node) error {
 			if v.IsNull() {
 				return na.AssignNull()
 			}
-			if v2, ok := v.(*_{{ .Type | TypeSymbol }}); ok {
+			v2, ok := v.(*_{{ .Type | TypeSymbol }})
+			{{- if .IsRepr }}
+			if v3, ok3 := v.(*_{{ .Type | TypeSymbol }}__Repr); ok3 {
+				v2, ok = (*_{{ .Type | TypeSymbol }})(v3), true
+			}
+			{{- end}}
+			if ok {
 				switch *na.m {
 				case schema.Maybe_Value, schema.Maybe_Null:
 					panic("invalid state: cannot assign into assembler that's already finished")
@@ -155,6 +162,12 @@ func emitNodeAssemblerHelper_listoid_listAssemblerMethods(w io.Writer, adjCfg *A
 	//  Same story as the tidy helper -- it touches `la.va` concretely in several places, and that blocks extraction.
 	doTemplate(`
 		func (la *_{{ .Type | TypeSymbol }}__{{ if .IsRepr }}Repr{{end}}Assembler) AssembleValue() ipld.NodeAssembler {
+			{{- if Compact }}
+			if la.state == laState_midValue {
+				la.valueFinishTidy()
+			}
+			gensupport.ListAssemblerCheckState("AssembleValue", uint8(la.state))
+			{{- else }}
 			switch la.state {
 			case laState_initial:
 				// carry on
@@ -165,6 +178,7 @@ func emitNodeAssemblerHelper_listoid_listAssemblerMethods(w io.Writer, adjCfg *A
 			case laState_finished:
 				panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
 			}
+			{{- end}}
 			la.w.x = append(la.w.x, _{{ .Type.ValueType | TypeSymbol }}{{if .Type.ValueIsNullable }}__Maybe{{end}}{})
 			la.state = laState_midValue
 			row := &la.w.x[len(la.w.x)-1]
@@ -183,6 +197,12 @@ func emitNodeAssemblerHelper_listoid_listAssemblerMethods(w io.Writer, adjCfg *A
 	`, w, adjCfg, data)
 	doTemplate(`
 		func (la *_{{ .Type | TypeSymbol }}__{{ if .IsRepr }}Repr{{end}}Assembler) Finish() error {
+			{{- if Compact }}
+			if la.state == laState_midValue {
+				la.valueFinishTidy()
+			}
+			gensupport.ListAssemblerCheckState("Finish", uint8(la.state))
+			{{- else }}
 			switch la.state {
 			case laState_initial:
 				// carry on
@@ -193,6 +213,7 @@ func emitNodeAssemblerHelper_listoid_listAssemblerMethods(w io.Writer, adjCfg *A
 			case laState_finished:
 				panic("invalid state: Finish cannot be called on an assembler that's already finished")
 			}
+			{{- end}}
 			la.state = laState_finished
 			*la.m = schema.Maybe_Value
 			return nil
@@ -0,0 +1,240 @@
+package gengo
+
+import (
+	"io"
+
+	"github.com/ipld/go-ipld-prime/schema/gen/go/mixins"
+)
+
+// The listpairs representation is shared by maps and structs:
+//  the representation node is a list, and each of its values is itself a list of exactly two values: a key, and a value.
+// These helpers emit the parts which don't care which of the two we're doing.
+//  All of them expect the data to have a '.Type' and a '.PkgName', the same as most other templates.
+//
+// The entries are nodes too, with the symbol `_T__ReprEntry`.
+//  They're emitted by the map and struct generators themselves, since their memory layout differs;
+//   all they need to provide here is a 'LookupByIndex' method.
+//  Entries don't have a prototype of their own: you can't build one in isolation, only as part of the whole.
+//
+// When assembling, the entry assembler is a cast of the whole repr assembler, as is the key assembler within it;
+//  this is the same trick the map representation of structs uses for its key assembler, and means no more allocations are needed.
+//  The repr assembler thus needs a few more fields than usual:
+//  - 'em' is the **e**ntry **m**aybe, which plays the part of 'm' for the entry assembler.
+//  - 'es' is the **e**ntry **s**tate; we reuse 'maState' for it, since an entry is shaped a lot like a single map entry.
+//     'maState_initial' means we expect the key, and 'maState_finished' means we've got both the key and the value.
+//  The repr assembler must also have a 'valueFinishTidy' method, which moves 'es' to 'maState_finished' when it succeeds.
+
+func emitNodeHelper_listpairs_entryNodeMethods(w io.Writer, adjCfg *AdjunctCfg, data interface{}, pkgName, typeName, typeSymbol string) {
+	stubs := mixins.ListTraits{
+		PkgName:    pkgName,
+		TypeName:   typeName + ".Entry", // ".Repr" is already in `typeName`.
+		TypeSymbol: typeSymbol + "Entry",
+	}
+	stubs.EmitNodeMethodKind(w)
+	stubs.EmitNodeMethodLookupByString(w)
+	stubs.EmitNodeMethodLookupBySegment(w)
+	stubs.EmitNodeMethodMapIterator(w)
+	stubs.EmitNodeMethodIsAbsent(w)
+	stubs.EmitNodeMethodIsNull(w)
+	stubs.EmitNodeMethodAsBool(w)
+	stubs.EmitNodeMethodAsInt(w)
+	stubs.EmitNodeMethodAsFloat(w)
+	stubs.EmitNodeMethodAsString(w)
+	stubs.EmitNodeMethodAsBytes(w)
+	stubs.EmitNodeMethodAsLink(w)
+	doTemplate(`
+		func (n *_{{ .Type | TypeSymbol }}__ReprEntry) LookupByNode(key ipld.Node) (ipld.Node, error) {
+			ki, err := key.AsInt()
+			if err != nil {
+				return nil, err
+			}
+			return n.LookupByIndex(ki)
+		}
+		func (n *_{{ .Type | TypeSymbol }}__ReprEntry) ListIterator() ipld.ListIterator {
+			return &_{{ .Type | TypeSymbol }}__ReprEntryItr{n, 0}
+		}
+
+		type _{{ .Type | TypeSymbol }}__ReprEntryItr struct {
+			n   *_{{ .Type | TypeSymbol }}__ReprEntry
+			idx int
+		}
+
+		func (itr *_{{ .Type | TypeSymbol }}__ReprEntryItr) Next() (idx int64, v ipld.Node, err error) {
+			if itr.idx >= 2 {
+				return -1, nil, ipld.ErrIteratorOverread{}
+			}
+			idx = int64(itr.idx)
+			v, err = itr.n.LookupByIndex(idx)
+			itr.idx++
+			return
+		}
+		func (itr *_{{ .Type | TypeSymbol }}__ReprEntryItr) Done() bool {
+			return itr.idx >= 2
+		}
+
+		func (_{{ .Type | TypeSymbol }}__ReprEntry) Length() int64 {
+			return 2
+		}
+		func (_{{ .Type | TypeSymbol }}__ReprEntry) Prototype() ipld.NodePrototype {
+			return nil
+		}
+	`, w, adjCfg, data)
+}
+
+func emitNodeAssemblerMethodAssignNode_listpairs(w io.Writer, adjCfg *AdjunctCfg, data interface{}) {
+	// This is the same as the listoid AssignNode, except it does call BeginList when morphing,
+	//  since the listpairs assemblers have setup to do there (and the repr of a map needs its storage allocated).
+	doTemplate(`
+		func (na *_{{ .Type | TypeSymbol }}__ReprAssembler) AssignNode(v ipld.Node) error {
+			if v.IsNull() {
+				return na.AssignNull()
+			}
+			v2, ok := v.(*_{{ .Type | TypeSymbol }})
+			if v3, ok3 := v.(*_{{ .Type | TypeSymbol }}__Repr); ok3 {
+				v2, ok = (*_{{ .Type | TypeSymbol }})(v3), true
+			}
+			if ok {
+				switch *na.m {
+				case schema.Maybe_Value, schema.Maybe_Null:
+					panic("invalid state: cannot assign into assembler that's already finished")
+				case midvalue:
+					panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+				}
+				{{- if .Type | MaybeUsesPtr }}
+				if na.w == nil {
+					na.w = v2
+					*na.m = schema.Maybe_Value
+					return nil
+				}
+				{{- end}}
+				*na.w = *v2
+				*na.m = schema.Maybe_Value
+				return nil
+			}
+			if v.Kind() != ipld.Kind_List {
+				return ipld.ErrWrongKind{TypeName: "{{ .PkgName }}.{{ .Type.Name }}.Repr", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustList, ActualKind: v.Kind()}
+			}
+			if _, err := na.BeginList(v.Length()); err != nil {
+				return err
+			}
+			itr := v.ListIterator()
+			for !itr.Done() {
+				_, v, err := itr.Next()
+				if err != nil {
+					return err
+				}
+				if err := na.AssembleValue().AssignNode(v); err != nil {
+					return err
+				}
+			}
+			return na.Finish()
+		}
+	`, w, adjCfg, data)
+}
+
+func emitNodeAssemblerHelper_listpairs_entryAssembler(w io.Writer, adjCfg *AdjunctCfg, data interface{}, pkgName, typeName, appliedPrefix string) {
+	// The outer list only ever yields the entry assembler, so its AssembleValue is the same for maps and structs.
+	//  Its Finish isn't: structs have to check for missing fields.
+	doTemplate(`
+		func (la *_{{ .Type | TypeSymbol }}__ReprAssembler) entryFinishTidy() bool {
+			switch la.em {
+			case schema.Maybe_Value:
+				la.em = schema.Maybe_Absent
+				la.state = laState_initial
+				return true
+			default:
+				return false
+			}
+		}
+		func (la *_{{ .Type | TypeSymbol }}__ReprAssembler) AssembleValue() ipld.NodeAssembler {
+			{{- if Compact }}
+			if la.state == laState_midValue {
+				la.entryFinishTidy()
+			}
+			gensupport.ListAssemblerCheckState("AssembleValue", uint8(la.state))
+			{{- else }}
+			switch la.state {
+			case laState_initial:
+				// carry on
+			case laState_midValue:
+				if !la.entryFinishTidy() {
+					panic("invalid state: AssembleValue cannot be called when still in the middle of assembling the previous value")
+				} // if tidy success: carry on
+			case laState_finished:
+				panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+			}
+			{{- end}}
+			la.state = laState_midValue
+			la.es = maState_initial
+			return (*_{{ .Type | TypeSymbol }}__ReprEntryAssembler)(la)
+		}
+		func (la *_{{ .Type | TypeSymbol }}__ReprAssembler) ValuePrototype(_ int64) ipld.NodePrototype {
+			return nil // entries don't have a prototype of their own.
+		}
+	`, w, adjCfg, data)
+
+	doTemplate(`
+		type _{{ .Type | TypeSymbol }}__ReprEntryAssembler _{{ .Type | TypeSymbol }}__ReprAssembler
+	`, w, adjCfg, data)
+	stubs := mixins.ListAssemblerTraits{
+		PkgName:       pkgName,
+		TypeName:      typeName + ".Entry", // ".Repr" is already in `typeName`.
+		AppliedPrefix: appliedPrefix + "Entry",
+	}
+	stubs.EmitNodeAssemblerMethodBeginMap(w)
+	stubs.EmitNodeAssemblerMethodAssignNull(w)
+	stubs.EmitNodeAssemblerMethodAssignBool(w)
+	stubs.EmitNodeAssemblerMethodAssignInt(w)
+	stubs.EmitNodeAssemblerMethodAssignFloat(w)
+	stubs.EmitNodeAssemblerMethodAssignString(w)
+	stubs.EmitNodeAssemblerMethodAssignBytes(w)
+	stubs.EmitNodeAssemblerMethodAssignLink(w)
+	doTemplate(`
+		func (ea *_{{ .Type | TypeSymbol }}__ReprEntryAssembler) BeginList(int64) (ipld.ListAssembler, error) {
+			switch ea.em {
+			case schema.Maybe_Value:
+				panic("invalid state: cannot assign into assembler that's already finished")
+			case midvalue:
+				panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+			}
+			ea.em = midvalue
+			return ea, nil
+		}
+		func (ea *_{{ .Type | TypeSymbol }}__ReprEntryAssembler) AssignNode(v ipld.Node) error {
+			if v.Kind() != ipld.Kind_List {
+				return ipld.ErrWrongKind{TypeName: "{{ .PkgName }}.{{ .Type.Name }}.Repr.Entry", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustList, ActualKind: v.Kind()}
+			}
+			if _, err := ea.BeginList(v.Length()); err != nil {
+				return err
+			}
+			itr := v.ListIterator()
+			for !itr.Done() {
+				_, v, err := itr.Next()
+				if err != nil {
+					return err
+				}
+				if err := ea.AssembleValue().AssignNode(v); err != nil {
+					return err
+				}
+			}
+			return ea.Finish()
+		}
+		func (_{{ .Type | TypeSymbol }}__ReprEntryAssembler) Prototype() ipld.NodePrototype {
+			return nil // entries don't have a prototype of their own.
+		}
+		func (ea *_{{ .Type | TypeSymbol }}__ReprEntryAssembler) Finish() error {
+			switch ea.es {
+			case maState_initial, maState_expectValue:
+				return ipld.ErrUnmatchable{TypeName: "{{ .PkgName }}.{{ .Type.Name }}.Repr"}.Reasonf("expected a list of a key and a value, got a shorter list")
+			case maState_midKey:
+				panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+			case maState_midValue:
+				if !(*_{{ .Type | TypeSymbol }}__ReprAssembler)(ea).valueFinishTidy() {
+					panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+				} // if tidy success: carry on
+			}
+			ea.em = schema.Maybe_Value
+			return nil
+		}
+	`, w, adjCfg, data)
+}
@@ -54,7 +54,13 @@ func emitNodeAssemblerMethodAssignNode_mapoid(w io.Writer, adjCfg *AdjunctCfg, d
 			if v.IsNull() {
 				return na.AssignNull()
 			}
-			if v2, ok := v.(*_{{ .Type | TypeSymbol }}); ok {
+			v2, ok := v.(*_{{ .Type | TypeSymbol }})
+			{{- if .IsRepr }}
+			if v3, ok3 := v.(*_{{ .Type | TypeSymbol }}__Repr); ok3 {
+				v2, ok = (*_{{ .Type | TypeSymbol }})(v3), true
+			}
+			{{- end}}
+			if ok {
 				switch *na.m {
 				case schema.Maybe_Value, schema.Maybe_Null:
 					panic("invalid state: cannot assign into assembler that's already finished")
@@ -201,6 +207,12 @@ func emitNodeAssemblerHelper_mapoid_mapAssemblerMethods(w io.Writer, adjCfg *Adj
 	//   Maybe the templates can be textually dedup'd more, though, at least.
 	doTemplate(`
 		func (ma *_{{ .Type | TypeSymbol }}__{{ if .IsRepr }}Repr{{end}}Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+			{{- if Compact }}
+			if ma.state == maState_midValue {
+				ma.valueFinishTidy()
+			}
+			gensupport.MapAssemblerExpectKey("AssembleEntry", uint8(ma.state))
+			{{- else }}
 			switch ma.state {
 			case maState_initial:
 				// carry on
@@ -215,15 +227,20 @@ func emitNodeAssemblerHelper_mapoid_mapAssemblerMethods(w io.Writer, adjCfg *Adj
 			case maState_finished:
 				panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
 			}
+			{{- end}}
 
 			var k2 _{{ .Type.KeyType | TypeSymbol }}
-			{{- if or (not (eq .Type.KeyType.TypeKind.String "String")) .IsRepr }}
+			{{- if or (not (eq .Type.KeyType.TypeKind.ActsLike.String "string")) .IsRepr }}
 			if err := (_{{ .Type.KeyType | TypeSymbol }}__ReprPrototype{}).fromString(&k2, k); err != nil {
-				return nil, err // TODO wrap in some kind of ErrInvalidKey
+				{{- if eq .Type.KeyType.TypeKind.ActsLike.String "string" }}
+				return nil, err
+				{{- else}}
+				return nil, ipld.ErrInvalidKey{TypeName: "{{ .PkgName }}.{{ .Type.Name }}{{ if .IsRepr }}.Repr{{end}}", Key: &_String{k}, Reason: err}
+				{{- end}}
 			}
 			{{- else}}
 			if err := (_{{ .Type.KeyType | TypeSymbol }}__Prototype{}).fromString(&k2, k); err != nil {
-				return nil, err // TODO wrap in some kind of ErrInvalidKey
+				return nil, err
 			}
 			{{- end}}
 			if _, exists := ma.w.m[k2]; exists {
@@ -249,6 +266,12 @@ func emitNodeAssemblerHelper_mapoid_mapAssemblerMethods(w io.Writer, adjCfg *Adj
 	`, w, adjCfg, data)
 	doTemplate(`
 		func (ma *_{{ .Type | TypeSymbol }}__{{ if .IsRepr }}Repr{{end}}Assembler) AssembleKey() ipld.NodeAssembler {
+			{{- if Compact }}
+			if ma.state == maState_midValue {
+				ma.valueFinishTidy()
+			}
+			gensupport.MapAssemblerExpectKey("AssembleKey", uint8(ma.state))
+			{{- else }}
 			switch ma.state {
 			case maState_initial:
 				// carry on
@@ -263,6 +286,7 @@ func emitNodeAssemblerHelper_mapoid_mapAssemblerMethods(w io.Writer, adjCfg *Adj
 			case maState_finished:
 				panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
 			}
+			{{- end}}
 			ma.w.t = append(ma.w.t, _{{ .Type | TypeSymbol }}__entry{})
 			ma.state = maState_midKey
 			ma.ka.m = &ma.cm
@@ -272,6 +296,12 @@ func emitNodeAssemblerHelper_mapoid_mapAssemblerMethods(w io.Writer, adjCfg *Adj
 	`, w, adjCfg, data)
 	doTemplate(`
 		func (ma *_{{ .Type | TypeSymbol }}__{{ if .IsRepr }}Repr{{end}}Assembler) AssembleValue() ipld.NodeAssembler {
+			{{- if Compact }}
+			if ma.state == maState_midKey {
+				ma.keyFinishTidy()
+			}
+			gensupport.MapAssemblerExpectValue("AssembleValue", uint8(ma.state))
+			{{- else }}
 			switch ma.state {
 			case maState_initial:
 				panic("invalid state: AssembleValue cannot be called when no key is primed")
@@ -286,12 +316,19 @@ func emitNodeAssemblerHelper_mapoid_mapAssemblerMethods(w io.Writer, adjCfg *Adj
 			case maState_finished:
 				panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
 			}
+			{{- end}}
 			ma.state = maState_midValue
 			return &ma.va
 		}
 	`, w, adjCfg, data)
 	doTemplate(`
 		func (ma *_{{ .Type | TypeSymbol }}__{{ if .IsRepr }}Repr{{end}}Assembler) Finish() error {
+			{{- if Compact }}
+			if ma.state == maState_midValue {
+				ma.valueFinishTidy()
+			}
+			gensupport.MapAssemblerExpectKey("Finish", uint8(ma.state))
+			{{- else }}
 			switch ma.state {
 			case maState_initial:
 				// carry on
@@ -306,6 +343,7 @@ func emitNodeAssemblerHelper_mapoid_mapAssemblerMethods(w io.Writer, adjCfg *Adj
 			case maState_finished:
 				panic("invalid state: Finish cannot be called on an assembler that's already finished")
 			}
+			{{- end}}
 			ma.state = maState_finished
 			*ma.m = schema.Maybe_Value
 			return nil
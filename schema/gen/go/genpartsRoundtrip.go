@@ -0,0 +1,390 @@
+package gengo
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// EmitRoundtripTests emits a test which builds a sample value of every type in the schema from dag-json,
+// through its representation; reads all of it; copies it at the type level;
+// and checks that encoding and decoding it again gives the same dag-json.
+// Generate writes it to "ipldsch_roundtrip_test.go" when the adjunct config asks for it.
+//
+// The samples are made up from the schema alone, so they're only as interesting as the schema allows:
+// every list and map has one entry, every union has its first member,
+// and every optional field is present, except where that would recurse forever.
+func EmitRoundtripTests(pkgName string, ts schema.TypeSystem, adjCfg *AdjunctCfg, w io.Writer) {
+	types := make(sortableTypeNames, 0, len(ts.GetTypes()))
+	for tn := range ts.GetTypes() {
+		types = append(types, tn)
+	}
+	sort.Sort(types)
+	sampler := newRoundtripSampler(ts)
+	type roundtripCase struct {
+		Type   schema.Type
+		Sample string
+	}
+	var cases []roundtripCase
+	for _, tn := range types {
+		t := ts.TypeByName(string(tn))
+		sample, err := json.Marshal(sampler.sample(t, map[schema.TypeName]bool{}))
+		if err != nil {
+			panic(err) // the samples are all plain JSON values.
+		}
+		cases = append(cases, roundtripCase{t, string(sample)})
+	}
+	doTemplate(`
+		// TestIpldschRoundtrip builds a sample value of every type in the schema from dag-json,
+		// through its representation; reads all of it, both at the type level and as its representation;
+		// copies it at the type level; and checks that encoding the value and its copy,
+		// and decoding and encoding it again, all give the same dag-json.
+		func TestIpldschRoundtrip(t *testing.T) {
+			for _, tc := range []struct {
+				name   string
+				np     ipld.NodePrototype
+				nrp    ipld.NodePrototype
+				sample string
+			}{
+				{{- range . }}
+				{ {{ printf "%q" .Type.Name }}, Type.{{ .Type | TypeSymbol }}, Type.{{ .Type | TypeSymbol }}__Repr, {{ printf "%q" .Sample }} },
+				{{- end}}
+			} {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					nb := tc.nrp.NewBuilder()
+					if err := dagjson.Decode(nb, strings.NewReader(tc.sample)); err != nil {
+						t.Fatalf("building the sample: %v", err)
+					}
+					n := nb.Build()
+					if err := ipldschRead(n); err != nil {
+						t.Fatalf("reading the sample: %v", err)
+					}
+					if err := ipldschRead(n.(schema.TypedNode).Representation()); err != nil {
+						t.Fatalf("reading the sample's representation: %v", err)
+					}
+					want := ipldschEncode(t, n)
+
+					nb = tc.nrp.NewBuilder()
+					if err := dagjson.Decode(nb, bytes.NewReader(want)); err != nil {
+						t.Fatalf("decoding the sample: %v", err)
+					}
+					if got := ipldschEncode(t, nb.Build()); !bytes.Equal(got, want) {
+						t.Fatalf("decoding and encoding again gave:\n%s\nwant:\n%s", got, want)
+					}
+
+					nb = tc.np.NewBuilder()
+					if err := ipld.Copy(nb, n); err != nil {
+						t.Fatalf("copying the sample: %v", err)
+					}
+					if got := ipldschEncode(t, nb.Build()); !bytes.Equal(got, want) {
+						t.Fatalf("copying gave:\n%s\nwant:\n%s", got, want)
+					}
+				})
+			}
+		}
+
+		// ipldschEncode encodes the representation of a typed node as dag-json.
+		func ipldschEncode(t *testing.T, n ipld.Node) []byte {
+			var buf bytes.Buffer
+			if err := dagjson.Encode(n.(schema.TypedNode).Representation(), &buf); err != nil {
+				t.Fatalf("encoding: %v", err)
+			}
+			return buf.Bytes()
+		}
+
+		// ipldschRead reads every value in a node.
+		func ipldschRead(n ipld.Node) error {
+			switch n.Kind() {
+			case ipld.Kind_Map:
+				for itr := n.MapIterator(); !itr.Done(); {
+					k, v, err := itr.Next()
+					if err != nil {
+						return err
+					}
+					if err := ipldschRead(k); err != nil {
+						return err
+					}
+					if err := ipldschRead(v); err != nil {
+						return err
+					}
+				}
+			case ipld.Kind_List:
+				for itr := n.ListIterator(); !itr.Done(); {
+					_, v, err := itr.Next()
+					if err != nil {
+						return err
+					}
+					if err := ipldschRead(v); err != nil {
+						return err
+					}
+				}
+			case ipld.Kind_Bool:
+				_, err := n.AsBool()
+				return err
+			case ipld.Kind_Int:
+				_, err := n.AsInt()
+				return err
+			case ipld.Kind_Float:
+				_, err := n.AsFloat()
+				return err
+			case ipld.Kind_String:
+				_, err := n.AsString()
+				return err
+			case ipld.Kind_Bytes:
+				_, err := n.AsBytes()
+				return err
+			case ipld.Kind_Link:
+				_, err := n.AsLink()
+				return err
+			}
+			return nil
+		}
+	`, w, adjCfg, cases)
+}
+
+// sampleLink is the CID which samples use for links: the identity hash of no bytes,
+// which is valid without needing any content to exist.
+const sampleLink = "bafkqaaa"
+
+// roundtripSampler makes up sample values for the types of a schema,
+// in the form of values which encoding/json turns into their dag-json representation.
+type roundtripSampler struct {
+	str string // the sample string, which doesn't clash with any delimiter in the schema.
+}
+
+func newRoundtripSampler(ts schema.TypeSystem) roundtripSampler {
+	var delims []string
+	for _, t := range ts.GetTypes() {
+		switch t := t.(type) {
+		case *schema.TypeStruct:
+			switch r := t.RepresentationStrategy().(type) {
+			case schema.StructRepresentation_Stringjoin:
+				delims = append(delims, r.GetDelim())
+			case schema.StructRepresentation_StringPairs:
+				delims = append(delims, r.GetInnerDelim(), r.GetEntryDelim())
+			}
+		case *schema.TypeUnion:
+			if r, ok := t.RepresentationStrategy().(schema.UnionRepresentation_Stringprefix); ok {
+				delims = append(delims, r.GetDelim())
+			}
+		}
+	}
+	all := strings.Join(delims, "")
+	for _, r := range "abcdefghijklmnopqrstuvwxyz" {
+		if !strings.ContainsRune(all, r) {
+			return roundtripSampler{string(r)}
+		}
+	}
+	return roundtripSampler{"0"}
+}
+
+// sample returns a sample of a type's representation.
+// The types on the stack are those being sampled already,
+// which optional and nullable values, and the values of lists and maps, leave out to avoid recursing forever.
+func (s roundtripSampler) sample(t schema.Type, stack map[schema.TypeName]bool) interface{} {
+	stack[t.Name()] = true
+	defer delete(stack, t.Name())
+	switch t := t.(type) {
+	case *schema.TypeBool:
+		return true
+	case *schema.TypeInt:
+		return 1
+	case *schema.TypeFloat:
+		return 1.5
+	case *schema.TypeString:
+		return s.str
+	case *schema.TypeBytes:
+		return sampleMap{{"/", sampleMap{{"bytes", base64.RawStdEncoding.EncodeToString([]byte(s.str))}}}}
+	case *schema.TypeLink:
+		return sampleMap{{"/", sampleLink}}
+	case *schema.TypeEnum:
+		member := t.Members()[0]
+		switch r := t.RepresentationStrategy().(type) {
+		case schema.EnumRepresentation_Int:
+			v, _ := r.GetSerial(member)
+			return v
+		case schema.EnumRepresentation_String:
+			return r.GetSerial(member)
+		}
+	case *schema.TypeList:
+		if stack[t.ValueType().Name()] {
+			return []interface{}{}
+		}
+		return []interface{}{s.sample(t.ValueType(), stack)}
+	case *schema.TypeMap:
+		key, ok := s.sampleString(t.KeyType(), stack)
+		if !ok || stack[t.ValueType().Name()] {
+			if _, ok := t.RepresentationStrategy().(schema.MapRepresentation_ListPairs); ok {
+				return []interface{}{}
+			}
+			return sampleMap{}
+		}
+		value := s.sample(t.ValueType(), stack)
+		if _, ok := t.RepresentationStrategy().(schema.MapRepresentation_ListPairs); ok {
+			return []interface{}{[]interface{}{key, value}}
+		}
+		return sampleMap{{key, value}}
+	case *schema.TypeStruct:
+		switch r := t.RepresentationStrategy().(type) {
+		case schema.StructRepresentation_Map:
+			m := sampleMap{}
+			for _, f := range t.Fields() {
+				if v, ok := s.sampleField(f, stack); ok {
+					m = append(m, sampleEntry{r.GetFieldKey(f), v})
+				}
+			}
+			return m
+		case schema.StructRepresentation_Tuple:
+			var l []interface{}
+			for _, f := range t.Fields() {
+				v, ok := s.sampleField(f, stack)
+				if !ok {
+					break // only trailing fields may be absent from a tuple.
+				}
+				l = append(l, v)
+			}
+			return l
+		case schema.StructRepresentation_ListPairs:
+			l := []interface{}{}
+			for _, f := range t.Fields() {
+				if v, ok := s.sampleField(f, stack); ok {
+					l = append(l, []interface{}{f.Name(), v})
+				}
+			}
+			return l
+		case schema.StructRepresentation_Stringjoin, schema.StructRepresentation_StringPairs:
+			str, _ := s.sampleString(t, stack)
+			return str
+		}
+	case *schema.TypeUnion:
+		member := s.sampleMember(t, stack)
+		switch r := t.RepresentationStrategy().(type) {
+		case schema.UnionRepresentation_Keyed:
+			return sampleMap{{r.GetDiscriminant(member), s.sample(member, stack)}}
+		case schema.UnionRepresentation_Kinded:
+			return s.sample(member, stack)
+		case schema.UnionRepresentation_Envelope:
+			return sampleMap{
+				{r.GetDiscriminantKey(), r.GetDiscriminant(member)},
+				{r.GetContentKey(), s.sample(member, stack)},
+			}
+		case schema.UnionRepresentation_Inline:
+			m := sampleMap{{r.GetDiscriminantKey(), r.GetDiscriminant(member)}}
+			return append(m, s.sample(member, stack).(sampleMap)...)
+		case schema.UnionRepresentation_Stringprefix:
+			str, _ := s.sampleString(t, stack)
+			return str
+		}
+	}
+	panic("no sample for type " + t.Name().String())
+}
+
+// sampleMap is a map in a sample, which keeps its entries in order,
+// since some representations want their keys in a particular order, such as discriminants before content.
+type sampleMap []sampleEntry
+
+type sampleEntry struct {
+	key   string
+	value interface{}
+}
+
+func (m sampleMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, e := range m {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(e.key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(e.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// sampleField returns a sample of a struct field's value,
+// or false if the field is optional and left out.
+func (s roundtripSampler) sampleField(f schema.StructField, stack map[schema.TypeName]bool) (interface{}, bool) {
+	if stack[f.Type().Name()] {
+		if f.IsNullable() {
+			return nil, true
+		}
+		if f.IsOptional() {
+			return nil, false
+		}
+	}
+	return s.sample(f.Type(), stack), true
+}
+
+// sampleMember picks the member of a union for its sample:
+// the first one which isn't being sampled already.
+func (s roundtripSampler) sampleMember(t *schema.TypeUnion, stack map[schema.TypeName]bool) schema.Type {
+	members := t.Members()
+	for _, m := range members {
+		if !stack[m.Name()] {
+			return m
+		}
+	}
+	return members[0]
+}
+
+// sampleString returns a sample of a type whose representation is a string,
+// such as the fields of stringjoin structs and the keys of maps,
+// or false if the type's representation isn't a string.
+func (s roundtripSampler) sampleString(t schema.Type, stack map[schema.TypeName]bool) (string, bool) {
+	switch t := t.(type) {
+	case *schema.TypeString:
+		return s.str, true
+	case *schema.TypeEnum:
+		if r, ok := t.RepresentationStrategy().(schema.EnumRepresentation_String); ok {
+			return r.GetSerial(t.Members()[0]), true
+		}
+	case *schema.TypeStruct:
+		var parts []string
+		switch r := t.RepresentationStrategy().(type) {
+		case schema.StructRepresentation_Stringjoin:
+			for _, f := range t.Fields() {
+				str, ok := s.sampleString(f.Type(), stack)
+				if !ok {
+					return "", false
+				}
+				parts = append(parts, str)
+			}
+			return strings.Join(parts, r.GetDelim()), true
+		case schema.StructRepresentation_StringPairs:
+			for _, f := range t.Fields() {
+				str, ok := s.sampleString(f.Type(), stack)
+				if !ok {
+					return "", false
+				}
+				parts = append(parts, f.Name()+r.GetInnerDelim()+str)
+			}
+			return strings.Join(parts, r.GetEntryDelim()), true
+		}
+	case *schema.TypeUnion:
+		if r, ok := t.RepresentationStrategy().(schema.UnionRepresentation_Stringprefix); ok {
+			member := s.sampleMember(t, stack)
+			str, ok := s.sampleString(member, stack)
+			if !ok {
+				return "", false
+			}
+			return r.GetDiscriminant(member) + r.GetDelim() + str, true
+		}
+	}
+	return "", false
+}
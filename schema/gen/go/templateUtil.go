@@ -21,6 +21,26 @@ func doTemplate(tmplstr string, w io.Writer, adjCfg *AdjunctCfg, data interface{
 			"FieldSymbolUpper": adjCfg.FieldSymbolUpper,
 			"MaybeUsesPtr":     adjCfg.MaybeUsesPtr,
 			"Comments":         adjCfg.Comments,
+			"Compact":          adjCfg.Compact,
+
+			// "Doc" turns the documentation from a schema into the lines of a Go comment, without a trailing newline.
+			//  If generated comments are also on, it ends with an empty comment line, so that they follow as another paragraph.
+			"Doc": func(doc string) string {
+				if doc == "" {
+					return ""
+				}
+				var sb strings.Builder
+				for i, line := range strings.Split(doc, "\n") {
+					if i > 0 {
+						sb.WriteString("\n")
+					}
+					sb.WriteString(strings.TrimRight("// "+line, " "))
+				}
+				if adjCfg.Comments() {
+					sb.WriteString("\n//")
+				}
+				return sb.String()
+			},
 
 			// The whole AdjunctConfig can be accessed.
 			//  Access methods like UnionMemlayout through this, as e.g. `.AdjCfg.UnionMemlayout`.
@@ -0,0 +1,80 @@
+package gengo
+
+import (
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// TestAssignNodeRepr checks that representation assemblers accept the representation node
+// of their own type in AssignNode, which they take a shortcut for and copy directly.
+func TestAssignNodeRepr(t *testing.T) {
+	t.Parallel()
+
+	prefix := "assign-node-repr"
+	pkgName := "main"
+
+	ts := schema.TypeSystem{}
+	ts.Init()
+	adjCfg := &AdjunctCfg{
+		CfgMaybeUsesPtr: map[schema.TypeName]bool{},
+	}
+	ts.Accumulate(schema.SpawnString("String"))
+	ts.Accumulate(schema.SpawnInt("Int"))
+	ts.Accumulate(schema.SpawnEnum("Color", []string{"red", "green"}))
+	ts.Accumulate(schema.SpawnMap("Map__String__Int", "String", "Int", false))
+	ts.Accumulate(schema.SpawnStruct("Mapped",
+		[]schema.StructField{
+			schema.SpawnStructField("a", "String", false, false),
+			schema.SpawnStructField("b", "Int", true, false),
+		},
+		schema.SpawnStructRepresentationMap(map[string]string{"a": "x"}),
+	))
+	ts.Accumulate(schema.SpawnStruct("Paired",
+		[]schema.StructField{
+			schema.SpawnStructField("a", "String", false, false),
+			schema.SpawnStructField("b", "String", false, false),
+		},
+		schema.SpawnStructRepresentationStringPairs("=", ","),
+	))
+	ts.Accumulate(schema.SpawnUnion("Enveloped",
+		[]schema.TypeName{"String", "Mapped"},
+		schema.SpawnUnionRepresentationEnvelope("tag", "content", map[string]schema.TypeName{"s": "String", "m": "Mapped"}),
+	))
+	ts.Accumulate(schema.SpawnUnion("Kinded",
+		[]schema.TypeName{"String", "Map__String__Int"},
+		schema.SpawnUnionRepresentationKinded(map[ipld.Kind]schema.TypeName{
+			ipld.Kind_String: "String",
+			ipld.Kind_Map:    "Map__String__Int",
+		}),
+	))
+
+	genAndCompileAndTest(t, prefix, pkgName, ts, adjCfg, func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+		for _, tc := range []struct {
+			typ  string
+			json string
+		}{
+			{"Color", `"green"`},
+			{"Map__String__Int", `{"a":1,"b":2}`},
+			{"Mapped", `{"x":"y"}`},
+			{"Mapped", `{"x":"y","b":3}`},
+			{"Paired", `"a=1,b=2"`},
+			{"Enveloped", `{"tag":"m","content":{"x":"y"}}`},
+			{"Kinded", `{"a":1}`},
+			{"Kinded", `"z"`},
+		} {
+			t.Run(tc.typ, func(t *testing.T) {
+				nrp := getPrototypeByName(tc.typ + ".Repr")
+				n := testUnmarshal(t, nrp, tc.json, nil)
+				repr := n.(schema.TypedNode).Representation()
+
+				nb := nrp.NewBuilder()
+				Require(t, nb.AssignNode(repr), ShouldEqual, nil)
+				Wish(t, nb.Build(), ShouldEqual, n)
+			})
+		}
+	})
+}
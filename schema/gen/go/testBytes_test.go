@@ -0,0 +1,69 @@
+package gengo
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+func TestBytes(t *testing.T) {
+	t.Parallel()
+
+	ts := schema.TypeSystem{}
+	ts.Init()
+	adjCfg := &AdjunctCfg{
+		CfgMaybeUsesPtr:  map[schema.TypeName]bool{},
+		CfgBytesZeroCopy: map[schema.TypeName]bool{"Blob": true},
+	}
+
+	ts.Accumulate(schema.SpawnBytes("Bytes"))
+	ts.Accumulate(schema.SpawnBytes("Blob"))
+
+	prefix := "bytes"
+	pkgName := "main"
+	genAndCompileAndTest(t, prefix, pkgName, ts, adjCfg, func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+		call := func(v interface{}, method string, args ...interface{}) []byte {
+			var in []reflect.Value
+			for _, arg := range args {
+				in = append(in, reflect.ValueOf(arg))
+			}
+			return reflect.ValueOf(v).MethodByName(method).Call(in)[0].Bytes()
+		}
+		for _, tc := range []struct {
+			typ      string
+			zeroCopy bool
+		}{
+			{"Bytes", false},
+			{"Blob", true},
+		} {
+			t.Run(tc.typ, func(t *testing.T) {
+				for _, np := range []ipld.NodePrototype{getPrototypeByName(tc.typ), getPrototypeByName(tc.typ + ".Repr")} {
+					src := []byte("abc")
+					nb := np.NewBuilder()
+					Require(t, nb.AssignBytes(src), ShouldEqual, nil)
+					n := nb.Build()
+					src[0] = 'x'
+					b, err := n.AsBytes()
+					Require(t, err, ShouldEqual, nil)
+					Wish(t, b[0] == 'x', ShouldEqual, tc.zeroCopy)
+				}
+
+				src := []byte("abc")
+				out := reflect.ValueOf(getPrototypeByName(tc.typ)).MethodByName("FromBytes").Call([]reflect.Value{reflect.ValueOf(src)})
+				n := out[0].Interface()
+				src[0] = 'x'
+				Wish(t, call(n, "BytesUnsafe")[0] == 'x', ShouldEqual, tc.zeroCopy)
+
+				// Bytes always copies, and BytesUnsafe never does.
+				call(n, "Bytes")[1] = 'y'
+				Wish(t, call(n, "BytesUnsafe")[1], ShouldEqual, byte('b'))
+				call(n, "BytesUnsafe")[1] = 'y'
+				Wish(t, call(n, "Bytes")[1], ShouldEqual, byte('y'))
+			})
+		}
+	})
+}
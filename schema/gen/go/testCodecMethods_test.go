@@ -0,0 +1,96 @@
+package gengo
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime"
+	_ "github.com/ipld/go-ipld-prime/codec/dagjson"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// TestCodecMethods checks the Encode and Decode methods which CfgCodecMethods adds,
+// using dag-json via the multicodec registry.
+func TestCodecMethods(t *testing.T) {
+	t.Parallel()
+
+	prefix := "codec-methods"
+	pkgName := "main"
+
+	ts := schema.TypeSystem{}
+	ts.Init()
+	adjCfg := &AdjunctCfg{
+		CfgCodecMethods: true,
+	}
+	ts.Accumulate(schema.SpawnString("String"))
+	ts.Accumulate(schema.SpawnInt("Int"))
+	ts.Accumulate(schema.SpawnStruct("Point",
+		[]schema.StructField{
+			schema.SpawnStructField("x", "Int", false, false),
+			schema.SpawnStructField("y", "Int", false, false),
+		},
+		schema.SpawnStructRepresentationTuple(),
+	))
+	ts.Accumulate(schema.SpawnUnion("Shape",
+		[]schema.TypeName{"String", "Point"},
+		schema.SpawnUnionRepresentationKeyed(map[string]schema.TypeName{"name": "String", "point": "Point"}),
+	))
+
+	const dagjson = 0x0129
+
+	encode := func(n ipld.Node, indicator uint64) (string, error) {
+		var buf bytes.Buffer
+		out := reflect.ValueOf(n).MethodByName("Encode").Call([]reflect.Value{reflect.ValueOf(&buf), reflect.ValueOf(indicator)})
+		err, _ := out[0].Interface().(error)
+		return buf.String(), err
+	}
+	decode := func(np ipld.NodePrototype, src string, indicator uint64) (ipld.Node, error) {
+		out := reflect.ValueOf(np).MethodByName("Decode").Call([]reflect.Value{reflect.ValueOf(strings.NewReader(src)), reflect.ValueOf(indicator)})
+		err, _ := out[1].Interface().(error)
+		if out[0].IsNil() {
+			return nil, err
+		}
+		return out[0].Interface().(ipld.Node), err
+	}
+
+	genAndCompileAndTest(t, prefix, pkgName, ts, adjCfg, func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+		for _, tc := range []struct {
+			typ  string
+			json string
+		}{
+			{"String", `"hello"`},
+			{"Point", `[1,2]`},
+			{"Shape", `{"point":[3,4]}`},
+			{"Shape", `{"name":"circle"}`},
+		} {
+			t.Run(tc.typ, func(t *testing.T) {
+				n, err := decode(getPrototypeByName(tc.typ), tc.json, dagjson)
+				Require(t, err, ShouldEqual, nil)
+				// Decode gives the type-level node, not the representation.
+				Wish(t, n.Prototype(), ShouldEqual, getPrototypeByName(tc.typ))
+				// Repr builders also build the type-level node.
+				Wish(t, n, ShouldEqual, testUnmarshal(t, getPrototypeByName(tc.typ+".Repr"), tc.json, nil))
+
+				out, err := encode(n, dagjson)
+				Require(t, err, ShouldEqual, nil)
+				// dag-json indents its output; none of the strings here contain spaces.
+				Wish(t, strings.Join(strings.Fields(out), ""), ShouldEqual, tc.json)
+			})
+		}
+		t.Run("errors", func(t *testing.T) {
+			n, err := decode(getPrototypeByName("Point"), `{"x":1}`, dagjson)
+			Wish(t, n, ShouldEqual, nil)
+			Wish(t, err != nil, ShouldEqual, true)
+
+			_, err = decode(getPrototypeByName("Point"), `[1,2]`, 0x12345)
+			Wish(t, err != nil, ShouldEqual, true)
+			point := testUnmarshal(t, getPrototypeByName("Point.Repr"), `[1,2]`, nil)
+			_, err = encode(point.(schema.TypedNode), 0x12345)
+			Wish(t, err != nil, ShouldEqual, true)
+		})
+	})
+}
@@ -0,0 +1,121 @@
+package gengo
+
+import (
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// TestCompact checks that code generated with CfgCompact,
+// which uses the gensupport package for much of its boilerplate,
+// behaves the same as the default output does.
+// It covers a bit of every kind, rather than going into depth on any one of them;
+// the other tests do that for the default output.
+func TestCompact(t *testing.T) {
+	t.Parallel()
+
+	prefix := "compact"
+	pkgName := "main"
+
+	ts := schema.TypeSystem{}
+	ts.Init()
+	adjCfg := &AdjunctCfg{
+		CfgCompact: true,
+	}
+	ts.Accumulate(schema.SpawnString("String"))
+	ts.Accumulate(schema.SpawnInt("Int"))
+	ts.Accumulate(schema.SpawnList("List__String", "String", false))
+	ts.Accumulate(schema.SpawnMap("Map__String__Int", "String", "Int", true))
+	ts.Accumulate(schema.SpawnEnum("Color", []string{"red", "green"}))
+	ts.Accumulate(schema.SpawnStruct("Pair",
+		[]schema.StructField{
+			schema.SpawnStructField("a", "String", false, false),
+			schema.SpawnStructField("b", "Int", true, false),
+		},
+		schema.SpawnStructRepresentationTuple(),
+	))
+	ts.Accumulate(schema.SpawnStruct("Joined",
+		[]schema.StructField{
+			schema.SpawnStructField("a", "String", false, false),
+			schema.SpawnStructField("b", "String", false, false),
+		},
+		schema.SpawnStructRepresentationStringjoin(":"),
+	))
+	ts.Accumulate(schema.SpawnUnion("Either",
+		[]schema.TypeName{"String", "Pair"},
+		schema.SpawnUnionRepresentationKeyed(map[string]schema.TypeName{"s": "String", "p": "Pair"}),
+	))
+	ts.Accumulate(schema.SpawnStruct("Stroct",
+		[]schema.StructField{
+			schema.SpawnStructField("name", "String", false, false),
+			schema.SpawnStructField("nick", "String", true, true),
+			schema.SpawnStructField("color", "Color", false, false),
+			schema.SpawnStructField("tags", "List__String", false, false),
+			schema.SpawnStructField("scores", "Map__String__Int", false, false),
+			schema.SpawnStructField("joined", "Joined", false, false),
+			schema.SpawnStructField("either", "Either", false, false),
+		},
+		schema.SpawnStructRepresentationMap(map[string]string{"name": "n"}),
+	))
+
+	genAndCompileAndTest(t, prefix, pkgName, ts, adjCfg, func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+		np := getPrototypeByName("Stroct")
+		nrp := getPrototypeByName("Stroct.Repr")
+		t.Run("roundtrip", func(t *testing.T) {
+			testcase{
+				typeJson: `{"name":"x","nick":null,"color":"red","tags":["a","b"],"scores":{"a":1,"b":null},"joined":{"a":"j","b":"k"},"either":{"Pair":{"a":"y","b":2}}}`,
+				reprJson: `{"n":"x","nick":null,"color":"red","tags":["a","b"],"scores":{"a":1,"b":null},"joined":"j:k","either":{"p":["y",2]}}`,
+				typePoints: []testcasePoint{
+					{"nick", ipld.Null},
+					{"tags/1", "b"},
+					{"scores/a", 1},
+					{"scores/b", ipld.Null},
+					{"either/Pair/b", 2},
+				},
+				reprPoints: []testcasePoint{
+					{"n", "x"},
+					{"joined", "j:k"},
+					{"either/p/0", "y"},
+				},
+			}.Test(t, np, nrp)
+		})
+		t.Run("absent optional", func(t *testing.T) {
+			testcase{
+				reprJson: `{"n":"x","color":"green","tags":[],"scores":{},"joined":"j:k","either":{"s":"z"}}`,
+				reprPoints: []testcasePoint{
+					{"either/s", "z"},
+				},
+				typePoints: []testcasePoint{
+					{"nick", ipld.Absent},
+				},
+			}.Test(t, np, nrp)
+		})
+		t.Run("null rejected", func(t *testing.T) {
+			testUnmarshal(t, nrp, `{"n":null}`, ipld.ErrWrongKind{})
+			testUnmarshal(t, getPrototypeByName("List__String"), `[null]`, ipld.ErrWrongKind{})
+		})
+		t.Run("assembler misuse", func(t *testing.T) {
+			ma, err := np.NewBuilder().BeginMap(0)
+			Require(t, err, ShouldEqual, nil)
+			func() {
+				defer func() {
+					Wish(t, recover(), ShouldEqual, "invalid state: AssembleValue cannot be called when no key is primed")
+				}()
+				ma.AssembleValue()
+			}()
+
+			la, err := getPrototypeByName("List__String").NewBuilder().BeginList(0)
+			Require(t, err, ShouldEqual, nil)
+			Require(t, la.Finish(), ShouldEqual, nil)
+			func() {
+				defer func() {
+					Wish(t, recover(), ShouldEqual, "invalid state: AssembleValue cannot be called on an assembler that's already finished")
+				}()
+				la.AssembleValue()
+			}()
+		})
+	})
+}
@@ -0,0 +1,80 @@
+package gengo
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// TestDocs checks that the documentation of types and struct fields in a schema
+// becomes the doc comments of the generated types and field accessors.
+func TestDocs(t *testing.T) {
+	t.Parallel()
+
+	ts := schema.TypeSystem{}
+	ts.Init()
+	adjCfg := &AdjunctCfg{}
+	ts.Accumulate(schema.SpawnDoc(schema.SpawnString("String"), "String is text."))
+	ts.Accumulate(schema.SpawnInt("Int"))
+	ts.Accumulate(schema.SpawnDoc(schema.SpawnList("List__String", "String", false), "List__String is many strings."))
+	ts.Accumulate(schema.SpawnDoc(schema.SpawnStruct("Foo",
+		[]schema.StructField{
+			schema.SpawnStructFieldDoc(schema.SpawnStructField("a", "String", false, false), "a is first.\n\nIt's required."),
+			schema.SpawnStructField("b", "Int", true, false),
+		},
+		schema.SpawnStructRepresentationMap(nil),
+	), "Foo has two fields."))
+	ts.Accumulate(schema.SpawnDoc(schema.SpawnUnion("Bar",
+		[]schema.TypeName{"String", "Int"},
+		schema.SpawnUnionRepresentationKeyed(map[string]schema.TypeName{"s": "String", "i": "Int"}),
+	), "Bar is one or the other."))
+
+	tmp, err := ioutil.TempDir("", "gengo-docs")
+	Require(t, err, ShouldEqual, nil)
+	defer os.RemoveAll(tmp)
+	Generate(tmp, "foo", ts, adjCfg)
+
+	// docs finds the doc comments of the declarations in a generated file,
+	// keyed like "type Foo" or "func FieldA".
+	docs := func(typeName string) map[string]string {
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, filepath.Join(tmp, "ipldsch_type_"+typeName+".go"), nil, parser.ParseComments)
+		Require(t, err, ShouldEqual, nil)
+		m := make(map[string]string)
+		for _, decl := range f.Decls {
+			switch decl := decl.(type) {
+			case *ast.GenDecl:
+				if decl.Tok == token.TYPE {
+					m["type "+decl.Specs[0].(*ast.TypeSpec).Name.Name] = decl.Doc.Text()
+				}
+			case *ast.FuncDecl:
+				m["func "+decl.Name.Name] = decl.Doc.Text()
+			}
+		}
+		return m
+	}
+
+	Wish(t, docs("String")["type String"], ShouldEqual, "String is text.\n\n"+
+		"String matches the IPLD Schema type \"String\".  It has string kind.\n")
+	Wish(t, docs("Int")["type Int"], ShouldEqual, "Int matches the IPLD Schema type \"Int\".  It has int kind.\n")
+	Wish(t, docs("List__String")["type List__String"], ShouldEqual, "List__String is many strings.\n\n"+
+		"List__String matches the IPLD Schema type \"List__String\".  It has list kind.\n")
+	Wish(t, docs("Bar")["type Bar"], ShouldEqual, "Bar is one or the other.\n\n"+
+		"Bar matches the IPLD Schema type \"Bar\".\n"+
+		"Bar has Union typekind, which means its data model behaviors are that of a map kind.\n")
+
+	foo := docs("Foo")
+	Wish(t, foo["type Foo"], ShouldEqual, "Foo has two fields.\n\n"+
+		"Foo matches the IPLD Schema type \"Foo\".  It has Struct type-kind, and may be interrogated like map kind.\n")
+	Wish(t, foo["func FieldA"], ShouldEqual, "a is first.\n\nIt's required.\n\n"+
+		"FieldA returns the value of the field \"a\".\n")
+	Wish(t, foo["func FieldB"], ShouldEqual, "FieldB returns the value of the field \"b\".\n")
+}
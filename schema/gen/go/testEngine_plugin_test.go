@@ -7,11 +7,25 @@ import (
 	"os/exec"
 	"path/filepath"
 	"plugin"
+	"reflect"
 	"testing"
 
 	"github.com/ipld/go-ipld-prime"
 )
 
+func init() {
+	// The behavioral tests call methods of genned types with reflect, which makes func types as it goes,
+	//  and caches some parts of those the first time it needs them for any given number of params and results.
+	// If that first time comes after some plugins are loaded, reflect may find its own internal types in them,
+	//  and then panics saying they're from a different scope.
+	// So make sure those parts are cached before any plugin is loaded.
+	var params []reflect.Type
+	for n := 0; n <= 8; n++ {
+		reflect.FuncOf(params, nil, false)
+		params = append(params, reflect.TypeOf(0))
+	}
+}
+
 func objPath(prefix string) string {
 	return filepath.Join(tmpGenBuildDir, prefix, "obj.so")
 }
@@ -0,0 +1,138 @@
+package gengo
+
+import (
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+func TestEnums(t *testing.T) {
+	t.Parallel()
+
+	ts := schema.TypeSystem{}
+	ts.Init()
+	adjCfg := &AdjunctCfg{
+		CfgMaybeUsesPtr: map[schema.TypeName]bool{},
+	}
+	ts.Accumulate(schema.SpawnString("String"))
+	ts.Accumulate(schema.SpawnInt("Int"))
+	ts.Accumulate(schema.SpawnEnumWithRepresentation("Mood",
+		[]string{"Happy", "Grumpy"},
+		schema.SpawnEnumRepresentationString(map[string]string{"Grumpy": "grumpy"}),
+	))
+	ts.Accumulate(schema.SpawnEnumWithRepresentation("Level",
+		[]string{"Low", "High"},
+		schema.SpawnEnumRepresentationInt(map[string]int{"Low": 1, "High": 10}),
+	))
+	ts.Accumulate(schema.SpawnStruct("Status",
+		[]schema.StructField{
+			schema.SpawnStructField("mood", "Mood", false, false),
+			schema.SpawnStructField("level", "Level", false, true),
+		},
+		schema.SpawnStructRepresentationMap(nil),
+	))
+	ts.Accumulate(schema.SpawnMap("Counts",
+		"Mood", "Int", false,
+	))
+
+	specs := []testcase{
+		{
+			name:     "Status",
+			typeJson: `{"mood":"Grumpy","level":"High"}`,
+			reprJson: `{"mood":"grumpy","level":10}`,
+			typePoints: []testcasePoint{
+				{"mood", "Grumpy"},
+				{"level", "High"},
+			},
+			reprPoints: []testcasePoint{
+				{"mood", "grumpy"},
+				{"level", 10},
+			},
+		},
+		{
+			name:     "StatusWithNull",
+			typeJson: `{"mood":"Happy","level":null}`,
+			reprJson: `{"mood":"Happy","level":null}`,
+			typePoints: []testcasePoint{
+				{"mood", "Happy"},
+				{"level", ipld.Null},
+			},
+		},
+		{
+			name:                "TypeLevelNonMember",
+			typeJson:            `{"mood":"Sleepy","level":"Low"}`,
+			expectUnmarshalFail: schema.ErrInvalidEnumMember{},
+		},
+		{
+			name:                "RenamedMemberNameInRepr",
+			reprJson:            `{"mood":"Grumpy","level":1}`,
+			expectUnmarshalFail: schema.ErrInvalidEnumMember{},
+		},
+		{
+			name:                "UnknownInt",
+			reprJson:            `{"mood":"happy","level":5}`,
+			expectUnmarshalFail: schema.ErrInvalidEnumMember{},
+		},
+	}
+	countsSpecs := []testcase{
+		{
+			name:     "Counts",
+			typeJson: `{"Happy":1,"Grumpy":2}`,
+			reprJson: `{"Happy":1,"grumpy":2}`,
+			typePoints: []testcasePoint{
+				{"Grumpy", 2},
+			},
+			reprPoints: []testcasePoint{
+				{"grumpy", 2},
+			},
+		},
+		{
+			name:                "NonMemberKey",
+			typeJson:            `{"Sleepy":1}`,
+			expectUnmarshalFail: schema.ErrInvalidEnumMember{},
+		},
+	}
+
+	test := func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+		for _, tcase := range specs {
+			tcase.Test(t, getPrototypeByName("Status"), getPrototypeByName("Status.Repr"))
+		}
+		for _, tcase := range countsSpecs {
+			tcase.Test(t, getPrototypeByName("Counts"), getPrototypeByName("Counts.Repr"))
+		}
+		t.Run("non-member error lists the members", func(t *testing.T) {
+			nb := getPrototypeByName("Mood").NewBuilder()
+			err := nb.AssignString("Sleepy")
+			Wish(t, err, ShouldEqual, schema.ErrInvalidEnumMember{TypeName: "main.Mood", Value: "Sleepy", Expected: []interface{}{"Happy", "Grumpy"}})
+			nb = getPrototypeByName("Level.Repr").NewBuilder()
+			err = nb.AssignInt(5)
+			Wish(t, err, ShouldEqual, schema.ErrInvalidEnumMember{TypeName: "main.Level.Repr", Value: int64(5), Expected: []interface{}{int64(1), int64(10)}})
+		})
+		t.Run("wrong kind for int representation", func(t *testing.T) {
+			nb := getPrototypeByName("Level.Repr").NewBuilder()
+			Wish(t, nb.AssignString("High"), ShouldBeSameTypeAs, ipld.ErrWrongKind{})
+		})
+	}
+
+	t.Run("maybe-using-embed", func(t *testing.T) {
+		adjCfg.CfgMaybeUsesPtr["Level"] = false
+
+		prefix := "enums-using-embed"
+		pkgName := "main"
+		genAndCompileAndTest(t, prefix, pkgName, ts, adjCfg, func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+			test(t, getPrototypeByName)
+		})
+	})
+	t.Run("maybe-using-ptr", func(t *testing.T) {
+		adjCfg.CfgMaybeUsesPtr["Level"] = true
+
+		prefix := "enums-using-ptr"
+		pkgName := "main"
+		genAndCompileAndTest(t, prefix, pkgName, ts, adjCfg, func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+			test(t, getPrototypeByName)
+		})
+	})
+}
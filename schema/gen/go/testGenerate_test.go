@@ -0,0 +1,105 @@
+package gengo
+
+import (
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// TestGenerateOutput checks the files Generate writes, rather than the behavior of the code in them:
+// there's one per type, they're gofmt'd, and generating the same schema twice gives the same bytes.
+func TestGenerateOutput(t *testing.T) {
+	t.Parallel()
+
+	ts := schema.TypeSystem{}
+	ts.Init()
+	adjCfg := &AdjunctCfg{}
+	ts.Accumulate(schema.SpawnString("String"))
+	ts.Accumulate(schema.SpawnInt("Int"))
+	ts.Accumulate(schema.SpawnList("List__String", "String", false))
+	ts.Accumulate(schema.SpawnStruct("Foo",
+		[]schema.StructField{
+			schema.SpawnStructField("a", "String", false, false),
+			schema.SpawnStructField("b", "List__String", true, false),
+		},
+		schema.SpawnStructRepresentationMap(map[string]string{"a": "A"}),
+	))
+	ts.Accumulate(schema.SpawnUnion("Bar",
+		[]schema.TypeName{"String", "Int"},
+		schema.SpawnUnionRepresentationKeyed(map[string]schema.TypeName{"s": "String", "i": "Int"}),
+	))
+
+	readDir := func(dir string) map[string][]byte {
+		infos, err := ioutil.ReadDir(dir)
+		Require(t, err, ShouldEqual, nil)
+		files := make(map[string][]byte, len(infos))
+		for _, info := range infos {
+			src, err := ioutil.ReadFile(filepath.Join(dir, info.Name()))
+			Require(t, err, ShouldEqual, nil)
+			files[info.Name()] = src
+		}
+		return files
+	}
+
+	tmp, err := ioutil.TempDir("", "gengo-output")
+	Require(t, err, ShouldEqual, nil)
+	defer os.RemoveAll(tmp)
+	dir1, dir2 := filepath.Join(tmp, "1"), filepath.Join(tmp, "2")
+	Require(t, os.Mkdir(dir1, 0755), ShouldEqual, nil)
+	Require(t, os.Mkdir(dir2, 0755), ShouldEqual, nil)
+
+	// Leftovers from an earlier run are removed; files written by hand are kept,
+	//  even if their name looks like a generated one.
+	Require(t, ioutil.WriteFile(filepath.Join(dir1, "ipldsch_type_Gone.go"), []byte("package foo\n\n"+doNotEditComment+"\n"), 0644), ShouldEqual, nil)
+	Require(t, ioutil.WriteFile(filepath.Join(dir1, "ipldsch_mine.go"), []byte("package foo\n"), 0644), ShouldEqual, nil)
+
+	Generate(dir1, "foo", ts, adjCfg)
+	Generate(dir2, "foo", ts, adjCfg)
+	files1, files2 := readDir(dir1), readDir(dir2)
+
+	var names []string
+	for name := range files1 {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	Wish(t, names, ShouldEqual, []string{
+		"ipldsch_mine.go",
+		"ipldsch_minima.go",
+		"ipldsch_type_Bar.go",
+		"ipldsch_type_Foo.go",
+		"ipldsch_type_Int.go",
+		"ipldsch_type_List__String.go",
+		"ipldsch_type_String.go",
+	})
+	delete(files1, "ipldsch_mine.go")
+
+	for name, src := range files1 {
+		formatted, err := format.Source(src)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, string(formatted), ShouldEqual, string(src))
+		Wish(t, string(files2[name]), ShouldEqual, string(src))
+	}
+
+	// Without a schema hash in the config, none is recorded;
+	//  with one, it's in the header of the minima file, and nothing else changes.
+	hash, err := GeneratedSchemaHash(dir1)
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, hash, ShouldEqual, "")
+	Generate(dir2, "foo", ts, &AdjunctCfg{SchemaHash: "sha256:0123"})
+	hash, err = GeneratedSchemaHash(dir2)
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, hash, ShouldEqual, "sha256:0123")
+	files2 = readDir(dir2)
+	Wish(t, strings.HasPrefix(string(files2["ipldsch_minima.go"]), "package foo\n\n"+doNotEditComment+"\n// Schema hash: sha256:0123\n"), ShouldEqual, true)
+	Wish(t, string(files2["ipldsch_type_Foo.go"]), ShouldEqual, string(files1["ipldsch_type_Foo.go"]))
+	_, err = GeneratedSchemaHash(filepath.Join(tmp, "missing"))
+	Wish(t, err != nil, ShouldEqual, true)
+}
@@ -0,0 +1,59 @@
+package gengo
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime"
+	schemaparser "github.com/ipld/go-ipld-prime/schema/parser"
+)
+
+// TestInlineDefns checks that the types spawned for anonymous inline definitions in a schema,
+// which are named after their contents, get the same code generated for them as any other type.
+func TestInlineDefns(t *testing.T) {
+	t.Parallel()
+
+	ts, err := schemaparser.Parse(strings.NewReader(`
+type Doc struct {
+	names [nullable String]
+	index {String:[nullable Int]}
+	parents [&Doc]
+}
+`))
+	Require(t, err, ShouldEqual, nil)
+	adjCfg := &AdjunctCfg{}
+
+	specs := []testcase{
+		{
+			name:     "Doc",
+			typeJson: `{"names":["a",null],"index":{"x":[1,null]},"parents":[]}`,
+			reprJson: `{"names":["a",null],"index":{"x":[1,null]},"parents":[]}`,
+			typePoints: []testcasePoint{
+				{"names/0", "a"},
+				{"names/1", ipld.Null},
+				{"index/x/0", 1},
+				{"index/x/1", ipld.Null},
+			},
+		},
+		{
+			name:                "NullInNonNullableList",
+			typeJson:            `{"names":[],"index":{"x":null},"parents":[]}`,
+			expectUnmarshalFail: ipld.ErrWrongKind{},
+		},
+	}
+
+	prefix := "inline-defns"
+	pkgName := "main"
+	genAndCompileAndTest(t, prefix, pkgName, *ts, adjCfg, func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+		for _, tcase := range specs {
+			tcase.Test(t, getPrototypeByName("Doc"), getPrototypeByName("Doc.Repr"))
+		}
+		t.Run("spawned types have prototypes", func(t *testing.T) {
+			for _, name := range []string{"List__nullableString", "Map__String__List__nullableInt", "List__nullableInt", "List__Link__Doc", "Link__Doc"} {
+				Wish(t, getPrototypeByName(name) != nil, ShouldEqual, true)
+			}
+		})
+	})
+}
@@ -0,0 +1,71 @@
+package gengo
+
+import (
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipfs/go-cid"
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/fluent"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// TestLinks checks that links which name the type they link to
+// hint the prototype for loading the data on the other side of them,
+// and that links which don't, don't.
+func TestLinks(t *testing.T) {
+	t.Parallel()
+
+	ts := schema.TypeSystem{}
+	ts.Init()
+	adjCfg := &AdjunctCfg{}
+	ts.Accumulate(schema.SpawnString("String"))
+	ts.Accumulate(schema.SpawnInt("Int"))
+	ts.Accumulate(schema.SpawnLink("Link"))
+	ts.Accumulate(schema.SpawnLinkReference("Link__Pair", "Pair"))
+	ts.Accumulate(schema.SpawnStruct("Pair",
+		[]schema.StructField{
+			schema.SpawnStructField("left", "Int", false, false),
+			schema.SpawnStructField("right", "Int", false, false),
+		},
+		schema.SpawnStructRepresentationTuple(),
+	))
+	ts.Accumulate(schema.SpawnStruct("Ref",
+		[]schema.StructField{
+			schema.SpawnStructField("pair", "Link__Pair", false, false),
+			schema.SpawnStructField("any", "Link", false, false),
+		},
+		schema.SpawnStructRepresentationMap(nil),
+	))
+
+	c, err := cid.Decode("bafyreibbvxxq5fahtmylr5tsfymzqmpw7zyufhh55e2zrf4jbsvtfw6hge")
+	Require(t, err, ShouldEqual, nil)
+	lnk := cidlink.Link{Cid: c}
+
+	prefix := "links"
+	pkgName := "main"
+	genAndCompileAndTest(t, prefix, pkgName, ts, adjCfg, func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+		n := fluent.MustBuildMap(getPrototypeByName("Ref"), 2, func(ma fluent.MapAssembler) {
+			ma.AssembleEntry("pair").AssignLink(lnk)
+			ma.AssembleEntry("any").AssignLink(lnk)
+		})
+		t.Run("link with a referenced type", func(t *testing.T) {
+			fv, err := n.LookupByString("pair")
+			Require(t, err, ShouldEqual, nil)
+			tlnk, ok := fv.(schema.TypedLinkNode)
+			Require(t, ok, ShouldEqual, true)
+			Wish(t, tlnk.LinkTargetNodePrototype(), ShouldEqual, getPrototypeByName("Pair.Repr"))
+			l, err := fv.AsLink()
+			Wish(t, err, ShouldEqual, nil)
+			Wish(t, l, ShouldEqual, ipld.Link(lnk))
+		})
+		t.Run("link without a referenced type", func(t *testing.T) {
+			fv, err := n.LookupByString("any")
+			Require(t, err, ShouldEqual, nil)
+			_, ok := fv.(schema.TypedLinkNode)
+			Wish(t, ok, ShouldEqual, false)
+		})
+	})
+}
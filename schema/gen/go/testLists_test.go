@@ -17,13 +17,23 @@ func TestListsContainingMaybe(t *testing.T) {
 	ts := schema.TypeSystem{}
 	ts.Init()
 	adjCfg := &AdjunctCfg{
-		maybeUsesPtr: map[schema.TypeName]bool{},
+		CfgMaybeUsesPtr: map[schema.TypeName]bool{},
 	}
 	ts.Accumulate(schema.SpawnString("String"))
 	ts.Accumulate(schema.SpawnList("List__String",
 		"String", false))
 	ts.Accumulate(schema.SpawnList("List__nullableString",
 		"String", true))
+	ts.Accumulate(schema.SpawnStruct("Frub",
+		[]schema.StructField{
+			schema.SpawnStructField("field", "String", false, false),
+		},
+		schema.SpawnStructRepresentationMap(map[string]string{
+			"field": "encoded",
+		}),
+	))
+	ts.Accumulate(schema.SpawnList("List__nullableFrub",
+		"Frub", true))
 
 	test := func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
 		t.Run("non-nullable", func(t *testing.T) {
@@ -49,7 +59,7 @@ func TestListsContainingMaybe(t *testing.T) {
 					Wish(t, nr.Length(), ShouldEqual, int64(2))
 					Wish(t, must.String(must.Node(nr.LookupByIndex(0))), ShouldEqual, "1")
 					Wish(t, must.String(must.Node(nr.LookupByIndex(1))), ShouldEqual, "2")
-					_, err := n.LookupByIndex(3)
+					_, err := nr.LookupByIndex(3)
 					Wish(t, err, ShouldBeSameTypeAs, ipld.ErrNotExists{})
 				})
 			})
@@ -60,6 +70,13 @@ func TestListsContainingMaybe(t *testing.T) {
 				})
 				Wish(t, n, ShouldEqual, nr)
 			})
+			t.Run("null rejected", func(t *testing.T) {
+				for _, np := range []ipld.NodePrototype{np, nrp} {
+					la, err := np.NewBuilder().BeginList(1)
+					Require(t, err, ShouldEqual, nil)
+					Wish(t, la.AssembleValue().AssignNull(), ShouldBeSameTypeAs, ipld.ErrWrongKind{})
+				}
+			})
 		})
 		t.Run("nullable", func(t *testing.T) {
 			np := getPrototypeByName("List__nullableString")
@@ -82,11 +99,23 @@ func TestListsContainingMaybe(t *testing.T) {
 					nr := n.Representation()
 					Require(t, nr.Kind(), ShouldEqual, ipld.Kind_List)
 					Wish(t, nr.Length(), ShouldEqual, int64(2))
-					Wish(t, must.String(must.Node(n.LookupByIndex(0))), ShouldEqual, "1")
-					Wish(t, must.Node(n.LookupByIndex(1)), ShouldEqual, ipld.Null)
-					_, err := n.LookupByIndex(3)
+					Wish(t, must.String(must.Node(nr.LookupByIndex(0))), ShouldEqual, "1")
+					Wish(t, must.Node(nr.LookupByIndex(1)), ShouldEqual, ipld.Null)
+					_, err := nr.LookupByIndex(3)
 					Wish(t, err, ShouldBeSameTypeAs, ipld.ErrNotExists{})
 				})
+				t.Run("iterate", func(t *testing.T) {
+					for _, n := range []ipld.Node{n, n.Representation()} {
+						itr := n.ListIterator()
+						_, v, err := itr.Next()
+						Wish(t, err, ShouldEqual, nil)
+						Wish(t, must.String(v), ShouldEqual, "1")
+						_, v, err = itr.Next()
+						Wish(t, err, ShouldEqual, nil)
+						Wish(t, v.IsNull(), ShouldEqual, true)
+						Wish(t, itr.Done(), ShouldEqual, true)
+					}
+				})
 			})
 			t.Run("repr-create", func(t *testing.T) {
 				nr := fluent.MustBuildList(nrp, 2, func(la fluent.ListAssembler) {
@@ -96,10 +125,50 @@ func TestListsContainingMaybe(t *testing.T) {
 				Wish(t, n, ShouldEqual, nr)
 			})
 		})
+		t.Run("nullable structs", func(t *testing.T) {
+			// Recursive values take a different path through the value assembler than strings do,
+			//  and their representation differs from the type level.
+			np := getPrototypeByName("List__nullableFrub")
+			nrp := getPrototypeByName("List__nullableFrub.Repr")
+			var n schema.TypedNode
+			t.Run("typed-create", func(t *testing.T) {
+				n = fluent.MustBuildList(np, 3, func(la fluent.ListAssembler) {
+					la.AssembleValue().AssignNull()
+					la.AssembleValue().CreateMap(1, func(ma fluent.MapAssembler) {
+						ma.AssembleEntry("field").AssignString("1")
+					})
+					la.AssembleValue().AssignNull()
+				}).(schema.TypedNode)
+				t.Run("typed-read", func(t *testing.T) {
+					Wish(t, n.Length(), ShouldEqual, int64(3))
+					Wish(t, must.Node(n.LookupByIndex(0)), ShouldEqual, ipld.Null)
+					Wish(t, must.String(must.Node(must.Node(n.LookupByIndex(1)).LookupByString("field"))), ShouldEqual, "1")
+					Wish(t, must.Node(n.LookupByIndex(2)), ShouldEqual, ipld.Null)
+				})
+				t.Run("repr-read", func(t *testing.T) {
+					nr := n.Representation()
+					Wish(t, nr.Length(), ShouldEqual, int64(3))
+					Wish(t, must.Node(nr.LookupByIndex(0)), ShouldEqual, ipld.Null)
+					Wish(t, must.String(must.Node(must.Node(nr.LookupByIndex(1)).LookupByString("encoded"))), ShouldEqual, "1")
+					Wish(t, must.Node(nr.LookupByIndex(2)), ShouldEqual, ipld.Null)
+				})
+			})
+			t.Run("repr-create", func(t *testing.T) {
+				nr := fluent.MustBuildList(nrp, 3, func(la fluent.ListAssembler) {
+					la.AssembleValue().AssignNull()
+					la.AssembleValue().CreateMap(1, func(ma fluent.MapAssembler) {
+						ma.AssembleEntry("encoded").AssignString("1")
+					})
+					la.AssembleValue().AssignNull()
+				})
+				Wish(t, n, ShouldEqual, nr)
+			})
+		})
 	}
 
 	t.Run("maybe-using-embed", func(t *testing.T) {
-		adjCfg.maybeUsesPtr["String"] = false
+		adjCfg.CfgMaybeUsesPtr["String"] = false
+		adjCfg.CfgMaybeUsesPtr["Frub"] = false
 
 		prefix := "lists-embed"
 		pkgName := "main"
@@ -108,7 +177,8 @@ func TestListsContainingMaybe(t *testing.T) {
 		})
 	})
 	t.Run("maybe-using-ptr", func(t *testing.T) {
-		adjCfg.maybeUsesPtr["String"] = true
+		adjCfg.CfgMaybeUsesPtr["String"] = true
+		adjCfg.CfgMaybeUsesPtr["Frub"] = true
 
 		prefix := "lists-mptr"
 		pkgName := "main"
@@ -128,7 +198,7 @@ func TestListsContainingLists(t *testing.T) {
 	ts := schema.TypeSystem{}
 	ts.Init()
 	adjCfg := &AdjunctCfg{
-		maybeUsesPtr: map[schema.TypeName]bool{},
+		CfgMaybeUsesPtr: map[schema.TypeName]bool{},
 	}
 	ts.Accumulate(schema.SpawnString("String"))
 	ts.Accumulate(schema.SpawnStruct("Frub",
@@ -0,0 +1,123 @@
+package gengo
+
+import (
+	"testing"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// TestMapReprListpairs checks the listpairs representation for maps,
+// both with values which are themselves lists at the representation level
+// (a tuple struct), and with nullable values.
+func TestMapReprListpairs(t *testing.T) {
+	t.Parallel()
+
+	ts := schema.TypeSystem{}
+	ts.Init()
+	adjCfg := &AdjunctCfg{
+		CfgMaybeUsesPtr: map[schema.TypeName]bool{},
+	}
+	ts.Accumulate(schema.SpawnString("String"))
+	ts.Accumulate(schema.SpawnStruct("Pair",
+		[]schema.StructField{
+			schema.SpawnStructField("left", "String", false, false),
+			schema.SpawnStructField("right", "String", false, false),
+		},
+		schema.SpawnStructRepresentationTuple(),
+	))
+	ts.Accumulate(schema.SpawnMapWithRepresentation("Pairs",
+		"String", "Pair", false,
+		schema.SpawnMapRepresentationListPairs(),
+	))
+	ts.Accumulate(schema.SpawnMapWithRepresentation("Names",
+		"String", "String", true,
+		schema.SpawnMapRepresentationListPairs(),
+	))
+
+	pairsSpecs := []testcase{
+		{
+			name:     "Entries",
+			typeJson: `{"z":{"left":"a","right":"b"},"y":{"left":"c","right":"d"}}`,
+			reprJson: `[["z",["a","b"]],["y",["c","d"]]]`,
+			typePoints: []testcasePoint{
+				{"", ipld.Kind_Map},
+				{"z/left", "a"},
+				{"y/right", "d"},
+			},
+			reprPoints: []testcasePoint{
+				{"", ipld.Kind_List},
+				{"0/0", "z"},
+				{"0/1", ipld.Kind_List},
+				{"0/1/1", "b"},
+				{"1/0", "y"},
+			},
+		},
+		{
+			name:     "Empty",
+			typeJson: `{}`,
+			reprJson: `[]`,
+		},
+		{
+			name:                "RepeatedKey",
+			reprJson:            `[["z",["a","b"]],["z",["c","d"]]]`,
+			expectUnmarshalFail: ipld.ErrRepeatedMapKey{},
+		},
+		{
+			name:                "ShortEntry",
+			reprJson:            `[["z"]]`,
+			expectUnmarshalFail: ipld.ErrUnmatchable{},
+		},
+		{
+			name:                "NotAnEntry",
+			reprJson:            `["z"]`,
+			expectUnmarshalFail: ipld.ErrWrongKind{},
+		},
+	}
+	namesSpecs := []testcase{
+		{
+			name:     "NullValue",
+			typeJson: `{"b":"x","a":null}`,
+			reprJson: `[["b","x"],["a",null]]`,
+			typePoints: []testcasePoint{
+				{"b", "x"},
+				{"a", ipld.Null},
+			},
+			reprPoints: []testcasePoint{
+				{"0/1", "x"},
+				{"1/1", ipld.Null},
+			},
+		},
+	}
+
+	t.Run("maybe-using-embed", func(t *testing.T) {
+		adjCfg.CfgMaybeUsesPtr["String"] = false
+		adjCfg.CfgMaybeUsesPtr["Pair"] = false
+
+		prefix := "maplistpairs"
+		pkgName := "main"
+		genAndCompileAndTest(t, prefix, pkgName, ts, adjCfg, func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+			for _, tcase := range pairsSpecs {
+				tcase.Test(t, getPrototypeByName("Pairs"), getPrototypeByName("Pairs.Repr"))
+			}
+			for _, tcase := range namesSpecs {
+				tcase.Test(t, getPrototypeByName("Names"), getPrototypeByName("Names.Repr"))
+			}
+		})
+	})
+	t.Run("maybe-using-ptr", func(t *testing.T) {
+		adjCfg.CfgMaybeUsesPtr["String"] = true
+		adjCfg.CfgMaybeUsesPtr["Pair"] = true
+
+		prefix := "maplistpairs2"
+		pkgName := "main"
+		genAndCompileAndTest(t, prefix, pkgName, ts, adjCfg, func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+			for _, tcase := range pairsSpecs {
+				tcase.Test(t, getPrototypeByName("Pairs"), getPrototypeByName("Pairs.Repr"))
+			}
+			for _, tcase := range namesSpecs {
+				tcase.Test(t, getPrototypeByName("Names"), getPrototypeByName("Names.Repr"))
+			}
+		})
+	})
+}
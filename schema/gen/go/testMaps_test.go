@@ -1,6 +1,7 @@
 package gengo
 
 import (
+	"errors"
 	"testing"
 
 	. "github.com/warpfork/go-wish"
@@ -8,6 +9,7 @@ import (
 	"github.com/ipld/go-ipld-prime"
 	"github.com/ipld/go-ipld-prime/fluent"
 	"github.com/ipld/go-ipld-prime/must"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
 	"github.com/ipld/go-ipld-prime/schema"
 )
 
@@ -17,7 +19,7 @@ func TestMapsContainingMaybe(t *testing.T) {
 	ts := schema.TypeSystem{}
 	ts.Init()
 	adjCfg := &AdjunctCfg{
-		maybeUsesPtr: map[schema.TypeName]bool{},
+		CfgMaybeUsesPtr: map[schema.TypeName]bool{},
 	}
 	ts.Accumulate(schema.SpawnString("String"))
 	ts.Accumulate(schema.SpawnMap("Map__String__String",
@@ -99,7 +101,7 @@ func TestMapsContainingMaybe(t *testing.T) {
 	}
 
 	t.Run("maybe-using-embed", func(t *testing.T) {
-		adjCfg.maybeUsesPtr["String"] = false
+		adjCfg.CfgMaybeUsesPtr["String"] = false
 
 		prefix := "maps-embed"
 		pkgName := "main"
@@ -108,7 +110,7 @@ func TestMapsContainingMaybe(t *testing.T) {
 		})
 	})
 	t.Run("maybe-using-ptr", func(t *testing.T) {
-		adjCfg.maybeUsesPtr["String"] = true
+		adjCfg.CfgMaybeUsesPtr["String"] = true
 
 		prefix := "maps-mptr"
 		pkgName := "main"
@@ -128,7 +130,7 @@ func TestMapsContainingMaps(t *testing.T) {
 	ts := schema.TypeSystem{}
 	ts.Init()
 	adjCfg := &AdjunctCfg{
-		maybeUsesPtr: map[schema.TypeName]bool{},
+		CfgMaybeUsesPtr: map[schema.TypeName]bool{},
 	}
 	ts.Accumulate(schema.SpawnString("String"))
 	ts.Accumulate(schema.SpawnStruct("Frub", // "type Frub struct { field String (rename "encoded") }"
@@ -217,7 +219,7 @@ func TestMapsWithComplexKeys(t *testing.T) {
 	ts := schema.TypeSystem{}
 	ts.Init()
 	adjCfg := &AdjunctCfg{
-		maybeUsesPtr: map[schema.TypeName]bool{},
+		CfgMaybeUsesPtr: map[schema.TypeName]bool{},
 	}
 	ts.Accumulate(schema.SpawnString("String"))
 	ts.Accumulate(schema.SpawnStruct("StringyStruct",
@@ -278,5 +280,29 @@ func TestMapsWithComplexKeys(t *testing.T) {
 			})
 			Wish(t, n, ShouldEqual, nr)
 		})
+		t.Run("lookup by node", func(t *testing.T) {
+			k := fluent.MustBuildMap(getPrototypeByName("StringyStruct"), 2, func(ma fluent.MapAssembler) {
+				ma.AssembleEntry("foo").AssignString("c")
+				ma.AssembleEntry("bar").AssignString("d")
+			}).(schema.TypedNode)
+			for _, k := range []ipld.Node{k, k.Representation(), basicnode.NewString("c:d")} {
+				Wish(t, must.String(must.Node(n.LookupByNode(k))), ShouldEqual, "2")
+				Wish(t, must.String(must.Node(n.Representation().LookupByNode(k))), ShouldEqual, "2")
+			}
+			_, err := n.LookupByNode(basicnode.NewInt(3))
+			Wish(t, err, ShouldBeSameTypeAs, ipld.ErrWrongKind{})
+		})
+		t.Run("invalid keys", func(t *testing.T) {
+			_, err := n.LookupByString("nocolon")
+			Wish(t, err, ShouldBeSameTypeAs, ipld.ErrInvalidKey{})
+			Wish(t, errors.As(err, &ipld.ErrUnmatchable{}), ShouldEqual, true)
+			_, err = n.Representation().LookupByString("a:b:c")
+			Wish(t, err, ShouldBeSameTypeAs, ipld.ErrInvalidKey{})
+
+			ma, err := nrp.NewBuilder().BeginMap(1)
+			Require(t, err, ShouldEqual, nil)
+			_, err = ma.AssembleEntry("nocolon")
+			Wish(t, err, ShouldBeSameTypeAs, ipld.ErrInvalidKey{})
+		})
 	})
 }
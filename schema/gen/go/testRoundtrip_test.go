@@ -0,0 +1,109 @@
+package gengo
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// TestRoundtripTests generates code with CfgRoundtripTests for a schema with a bit of everything,
+// and runs the roundtrip test which that emits.
+func TestRoundtripTests(t *testing.T) {
+	t.Parallel()
+
+	ts := schema.TypeSystem{}
+	ts.Init()
+	adjCfg := &AdjunctCfg{
+		CfgRoundtripTests: true,
+	}
+	ts.Accumulate(schema.SpawnString("String"))
+	ts.Accumulate(schema.SpawnInt("Int"))
+	ts.Accumulate(schema.SpawnFloat("Float"))
+	ts.Accumulate(schema.SpawnBool("Bool"))
+	ts.Accumulate(schema.SpawnBytes("Bytes"))
+	ts.Accumulate(schema.SpawnLink("Link"))
+	ts.Accumulate(schema.SpawnEnum("Color", []string{"red", "green"}))
+	ts.Accumulate(schema.SpawnEnumWithRepresentation("Level", []string{"low", "high"},
+		schema.SpawnEnumRepresentationInt(map[string]int{"low": 1, "high": 10})))
+	ts.Accumulate(schema.SpawnList("List__nullableString", "String", true))
+	ts.Accumulate(schema.SpawnMap("Map__Color__Float", "Color", "Float", false))
+	ts.Accumulate(schema.SpawnMapWithRepresentation("Pairs", "String", "Int", false, schema.SpawnMapRepresentationListPairs()))
+	ts.Accumulate(schema.SpawnStruct("Tree",
+		[]schema.StructField{
+			schema.SpawnStructField("name", "String", false, false),
+			schema.SpawnStructField("tags", "List__nullableString", false, false),
+			schema.SpawnStructField("data", "Bytes", true, true),
+			schema.SpawnStructField("link", "Link", false, false),
+		},
+		schema.SpawnStructRepresentationMap(map[string]string{"name": "n"}),
+	))
+	ts.Accumulate(schema.SpawnStruct("Point",
+		[]schema.StructField{
+			schema.SpawnStructField("x", "Int", false, false),
+			schema.SpawnStructField("y", "Float", false, false),
+			schema.SpawnStructField("z", "Bool", true, false),
+		},
+		schema.SpawnStructRepresentationTuple(),
+	))
+	ts.Accumulate(schema.SpawnStruct("Joined",
+		[]schema.StructField{
+			schema.SpawnStructField("a", "String", false, false),
+			schema.SpawnStructField("b", "Color", false, false),
+		},
+		schema.SpawnStructRepresentationStringjoin("a"),
+	))
+	ts.Accumulate(schema.SpawnStruct("Query",
+		[]schema.StructField{
+			schema.SpawnStructField("q", "String", false, false),
+			schema.SpawnStructField("at", "Joined", false, false),
+		},
+		schema.SpawnStructRepresentationStringPairs("=", "&"),
+	))
+	ts.Accumulate(schema.SpawnStruct("Header",
+		[]schema.StructField{
+			schema.SpawnStructField("name", "String", false, false),
+			schema.SpawnStructField("level", "Level", true, false),
+		},
+		schema.SpawnStructRepresentationListPairs(),
+	))
+	ts.Accumulate(schema.SpawnUnion("Keyed",
+		[]schema.TypeName{"Point", "String"},
+		schema.SpawnUnionRepresentationKeyed(map[string]schema.TypeName{"p": "Point", "s": "String"}),
+	))
+	ts.Accumulate(schema.SpawnUnion("Kinded",
+		[]schema.TypeName{"Map__Color__Float", "Int"},
+		schema.SpawnUnionRepresentationKinded(map[ipld.Kind]schema.TypeName{ipld.Kind_Map: "Map__Color__Float", ipld.Kind_Int: "Int"}),
+	))
+	ts.Accumulate(schema.SpawnUnion("Enveloped",
+		[]schema.TypeName{"Header", "Pairs"},
+		schema.SpawnUnionRepresentationEnvelope("tag", "content", map[string]schema.TypeName{"h": "Header", "p": "Pairs"}),
+	))
+	ts.Accumulate(schema.SpawnUnion("Inlined",
+		[]schema.TypeName{"Tree"},
+		schema.SpawnUnionRepresentationInline("kind", map[string]schema.TypeName{"tree": "Tree"}),
+	))
+	ts.Accumulate(schema.SpawnUnion("Prefixed",
+		[]schema.TypeName{"Joined", "String"},
+		schema.SpawnUnionRepresentationStringprefix(":", map[string]schema.TypeName{"j": "Joined", "s": "String"}),
+	))
+
+	dir := filepath.Join(tmpGenBuildDir, "roundtrip-tests")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	Generate(dir, "roundtrip", ts, adjCfg)
+
+	// The generated files are outside our module, so list them, as buildGennedCode does.
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command("go", append([]string{"test"}, files...)...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("generated roundtrip test failed: %s\n%s", err, out)
+	}
+}
@@ -15,7 +15,7 @@ func TestString(t *testing.T) {
 	ts := schema.TypeSystem{}
 	ts.Init()
 	adjCfg := &AdjunctCfg{
-		maybeUsesPtr: map[schema.TypeName]bool{},
+		CfgMaybeUsesPtr: map[schema.TypeName]bool{},
 	}
 
 	ts.Accumulate(schema.SpawnString("String"))
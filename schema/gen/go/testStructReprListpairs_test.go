@@ -0,0 +1,137 @@
+package gengo
+
+import (
+	"testing"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// TestStructReprListpairs checks the listpairs representation for structs,
+// including optional fields, and a field which is itself a listpairs map.
+// Like TestStructsContainingMaybe, it runs once with maybes implemented as
+// embeds, and once with pointers.
+func TestStructReprListpairs(t *testing.T) {
+	t.Parallel()
+
+	ts := schema.TypeSystem{}
+	ts.Init()
+	adjCfg := &AdjunctCfg{
+		CfgMaybeUsesPtr: map[schema.TypeName]bool{},
+	}
+	ts.Accumulate(schema.SpawnString("String"))
+	ts.Accumulate(schema.SpawnInt("Int"))
+	ts.Accumulate(schema.SpawnMapWithRepresentation("Tags",
+		"String", "Int", true,
+		schema.SpawnMapRepresentationListPairs(),
+	))
+	ts.Accumulate(schema.SpawnStruct("Header",
+		[]schema.StructField{
+			schema.SpawnStructField("name", "String", false, false),
+			schema.SpawnStructField("count", "Int", true, true),
+			schema.SpawnStructField("tags", "Tags", true, false),
+		},
+		schema.SpawnStructRepresentationListPairs(),
+	))
+
+	specs := []testcase{
+		{
+			name:     "AllFieldsSet",
+			typeJson: `{"name":"a","count":1,"tags":{"x":2,"y":null}}`,
+			reprJson: `[["name","a"],["count",1],["tags",[["x",2],["y",null]]]]`,
+			typePoints: []testcasePoint{
+				{"", ipld.Kind_Map},
+				{"name", "a"},
+				{"count", 1},
+				{"tags/x", 2},
+				{"tags/y", ipld.Null},
+			},
+			reprPoints: []testcasePoint{
+				{"", ipld.Kind_List},
+				{"0/0", "name"},
+				{"0/1", "a"},
+				{"2/1", ipld.Kind_List},
+				{"2/1/1/0", "y"},
+				{"2/1/1/1", ipld.Null},
+			},
+		},
+		{
+			name:     "AbsentOptionals",
+			reprJson: `[["name","a"]]`,
+			typePoints: []testcasePoint{
+				{"name", "a"},
+				{"count", ipld.Absent},
+				{"tags", ipld.Absent},
+			},
+			typeItr: []entry{
+				{"name", "a"},
+				{"count", ipld.Absent},
+				{"tags", ipld.Absent},
+			},
+		},
+		{
+			name:     "NullField",
+			typeJson: `{"name":"a","count":null,"tags":{}}`,
+			reprJson: `[["name","a"],["count",null],["tags",[]]]`,
+			reprPoints: []testcasePoint{
+				{"1/1", ipld.Null},
+				{"2/1", ipld.Kind_List},
+			},
+		},
+		{
+			name:                "MissingRequired",
+			reprJson:            `[["count",1]]`,
+			expectUnmarshalFail: ipld.ErrMissingRequiredField{},
+		},
+		{
+			name:                "RepeatedField",
+			reprJson:            `[["name","a"],["name","b"]]`,
+			expectUnmarshalFail: ipld.ErrRepeatedMapKey{},
+		},
+		{
+			name:                "UnknownField",
+			reprJson:            `[["name","a"],["page",2]]`,
+			expectUnmarshalFail: ipld.ErrInvalidKey{},
+		},
+		{
+			name:                "ShortEntry",
+			reprJson:            `[["name"]]`,
+			expectUnmarshalFail: ipld.ErrUnmatchable{},
+		},
+		{
+			name:                "LongEntry",
+			reprJson:            `[["name","a","b"]]`,
+			expectUnmarshalFail: ipld.ErrUnmatchable{},
+		},
+		{
+			name:                "RepeatedMapKey",
+			reprJson:            `[["name","a"],["tags",[["x",1],["x",2]]]]`,
+			expectUnmarshalFail: ipld.ErrRepeatedMapKey{},
+		},
+	}
+
+	t.Run("maybe-using-embed", func(t *testing.T) {
+		adjCfg.CfgMaybeUsesPtr["Int"] = false
+		adjCfg.CfgMaybeUsesPtr["Tags"] = false
+
+		prefix := "structlistpairs"
+		pkgName := "main"
+		genAndCompileAndTest(t, prefix, pkgName, ts, adjCfg, func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+			for _, tcase := range specs {
+				tcase.Test(t, getPrototypeByName("Header"), getPrototypeByName("Header.Repr"))
+			}
+		})
+	})
+	t.Run("maybe-using-ptr", func(t *testing.T) {
+		adjCfg.CfgMaybeUsesPtr["Int"] = true
+		adjCfg.CfgMaybeUsesPtr["Tags"] = true
+
+		prefix := "structlistpairs2"
+		pkgName := "main"
+		genAndCompileAndTest(t, prefix, pkgName, ts, adjCfg, func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+			for _, tcase := range specs {
+				tcase.Test(t, getPrototypeByName("Header"), getPrototypeByName("Header.Repr"))
+			}
+		})
+	})
+}
@@ -0,0 +1,126 @@
+package gengo
+
+import (
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// TestStructReprMapImplicits checks fields with implicit values in the map representation of structs:
+// when such a field is absent from the representation it gets its implicit value at the type level,
+// and when it has its implicit value it's left out of the representation.
+// The fields with implicits are all trailing, so the iterator's lookahead is exercised too;
+// and one of them is nullable, so the test runs once with maybes implemented as embeds, and once with pointers.
+func TestStructReprMapImplicits(t *testing.T) {
+	t.Parallel()
+
+	ts := schema.TypeSystem{}
+	ts.Init()
+	adjCfg := &AdjunctCfg{
+		CfgMaybeUsesPtr: map[schema.TypeName]bool{},
+	}
+	ts.Accumulate(schema.SpawnString("String"))
+	ts.Accumulate(schema.SpawnInt("Int"))
+	ts.Accumulate(schema.SpawnBool("Bool"))
+	ts.Accumulate(schema.SpawnStruct("Settings",
+		[]schema.StructField{
+			schema.SpawnStructField("id", "String", false, false),
+			schema.SpawnStructField("name", "String", false, false),
+			schema.SpawnStructField("retries", "Int", false, false),
+			schema.SpawnStructField("verbose", "Bool", false, false),
+			schema.SpawnStructField("note", "String", false, true),
+		},
+		schema.SpawnStructRepresentationMapWithImplicits(
+			map[string]string{"name": "n"},
+			map[string]schema.ImplicitValue{
+				"name":    schema.SpawnImplicitValueString("anon"),
+				"retries": schema.SpawnImplicitValueInt(3),
+				"verbose": schema.SpawnImplicitValueBool(false),
+				"note":    schema.SpawnImplicitValueString("none"),
+			},
+		),
+	))
+
+	specs := []testcase{
+		{
+			name:     "AllImplicit",
+			typeJson: `{"id":"a","name":"anon","retries":3,"verbose":false,"note":"none"}`,
+			reprJson: `{"id":"a"}`,
+			typePoints: []testcasePoint{
+				{"name", "anon"},
+				{"retries", 3},
+				{"verbose", false},
+				{"note", "none"},
+			},
+			reprPoints: []testcasePoint{
+				{"id", "a"},
+			},
+		},
+		{
+			name:     "NoneImplicit",
+			typeJson: `{"id":"a","name":"bob","retries":0,"verbose":true,"note":null}`,
+			reprJson: `{"id":"a","n":"bob","retries":0,"verbose":true,"note":null}`,
+			typePoints: []testcasePoint{
+				{"name", "bob"},
+				{"retries", 0},
+				{"verbose", true},
+				{"note", ipld.Null},
+			},
+			reprPoints: []testcasePoint{
+				{"n", "bob"},
+				{"retries", 0},
+			},
+		},
+		{
+			name:     "SomeImplicit",
+			typeJson: `{"id":"a","name":"anon","retries":5,"verbose":false,"note":"hi"}`,
+			reprJson: `{"id":"a","retries":5,"note":"hi"}`,
+			typePoints: []testcasePoint{
+				{"name", "anon"},
+				{"retries", 5},
+				{"verbose", false},
+			},
+		},
+		{
+			name:                "MissingRequired",
+			reprJson:            `{"n":"bob"}`,
+			expectUnmarshalFail: ipld.ErrMissingRequiredField{},
+		},
+	}
+
+	test := func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+		for _, tcase := range specs {
+			tcase.Test(t, getPrototypeByName("Settings"), getPrototypeByName("Settings.Repr"))
+		}
+		t.Run("implicit values given explicitly are left out of the representation", func(t *testing.T) {
+			n := testUnmarshal(t, getPrototypeByName("Settings.Repr"), `{"id":"a","n":"anon","retries":3,"note":"none"}`, nil)
+			nr := n.(schema.TypedNode).Representation()
+			Wish(t, nr.Length(), ShouldEqual, int64(1))
+			_, err := nr.LookupByString("n")
+			Wish(t, err, ShouldEqual, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString("n")})
+			testMarshal(t, nr, `{"id":"a"}`)
+		})
+	}
+
+	t.Run("maybe-using-embed", func(t *testing.T) {
+		adjCfg.CfgMaybeUsesPtr["String"] = false
+
+		prefix := "struct-implicits-using-embed"
+		pkgName := "main"
+		genAndCompileAndTest(t, prefix, pkgName, ts, adjCfg, func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+			test(t, getPrototypeByName)
+		})
+	})
+	t.Run("maybe-using-ptr", func(t *testing.T) {
+		adjCfg.CfgMaybeUsesPtr["String"] = true
+
+		prefix := "struct-implicits-using-ptr"
+		pkgName := "main"
+		genAndCompileAndTest(t, prefix, pkgName, ts, adjCfg, func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+			test(t, getPrototypeByName)
+		})
+	})
+}
@@ -24,7 +24,7 @@ func TestStructReprStringjoin(t *testing.T) {
 	ts := schema.TypeSystem{}
 	ts.Init()
 	adjCfg := &AdjunctCfg{
-		maybeUsesPtr: map[schema.TypeName]bool{},
+		CfgMaybeUsesPtr: map[schema.TypeName]bool{},
 	}
 	ts.Accumulate(schema.SpawnString("String"))
 	ts.Accumulate(schema.SpawnStruct("StringyStruct",
@@ -141,6 +141,19 @@ func TestStructReprStringjoin(t *testing.T) {
 				})
 				Wish(t, n, ShouldEqual, nr)
 			})
+			t.Run("repr-copy", func(t *testing.T) {
+				nb := nrp.NewBuilder()
+				Require(t, nb.AssignNode(n.Representation()), ShouldEqual, nil)
+				Wish(t, nb.Build(), ShouldEqual, n)
+			})
+		})
+
+		t.Run("wrong number of parts", func(t *testing.T) {
+			nrp := getPrototypeByName("Recurzorator.Repr")
+			for _, s := range []string{"v1-v2:v3", "v1-v2-v3-v4", "v1-v2:v3:v4-v5"} {
+				nb := nrp.NewBuilder()
+				Wish(t, nb.AssignString(s), ShouldBeSameTypeAs, ipld.ErrUnmatchable{})
+			}
 		})
 	})
 }
@@ -0,0 +1,124 @@
+package gengo
+
+import (
+	"testing"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// TestStructReprStringpairs checks the stringpairs representation for structs,
+// including optional fields, and a field which is itself a stringjoin struct.
+// Like TestStructsContainingMaybe, it runs once with maybes implemented as
+// embeds, and once with pointers.
+func TestStructReprStringpairs(t *testing.T) {
+	t.Parallel()
+
+	ts := schema.TypeSystem{}
+	ts.Init()
+	adjCfg := &AdjunctCfg{
+		CfgMaybeUsesPtr: map[schema.TypeName]bool{},
+	}
+	ts.Accumulate(schema.SpawnString("String"))
+	ts.Accumulate(schema.SpawnStruct("Coords",
+		[]schema.StructField{
+			schema.SpawnStructField("x", "String", false, false),
+			schema.SpawnStructField("y", "String", false, false),
+		},
+		schema.SpawnStructRepresentationStringjoin(":"),
+	))
+	ts.Accumulate(schema.SpawnStruct("Query",
+		[]schema.StructField{
+			schema.SpawnStructField("q", "String", false, false),
+			schema.SpawnStructField("at", "Coords", true, false),
+			schema.SpawnStructField("lang", "String", true, false),
+		},
+		schema.SpawnStructRepresentationStringPairs("=", "&"),
+	))
+
+	specs := []testcase{
+		{
+			name:     "AllFieldsSet",
+			typeJson: `{"q":"ipld","at":{"x":"1","y":"2"},"lang":"en"}`,
+			reprJson: `"q=ipld&at=1:2&lang=en"`,
+			typePoints: []testcasePoint{
+				{"", ipld.Kind_Map},
+				{"q", "ipld"},
+				{"at/y", "2"},
+				{"lang", "en"},
+			},
+			reprPoints: []testcasePoint{
+				{"", "q=ipld&at=1:2&lang=en"},
+			},
+		},
+		{
+			name:     "AbsentOptionals",
+			reprJson: `"q=a=b"`,
+			typePoints: []testcasePoint{
+				{"", ipld.Kind_Map},
+				{"q", "a=b"},
+				{"at", ipld.Absent},
+				{"lang", ipld.Absent},
+			},
+			typeItr: []entry{
+				{"q", "a=b"},
+				{"at", ipld.Absent},
+				{"lang", ipld.Absent},
+			},
+		},
+		{
+			name:     "EmptyValues",
+			reprJson: `"q=&lang="`,
+			typePoints: []testcasePoint{
+				{"q", ""},
+				{"at", ipld.Absent},
+				{"lang", ""},
+			},
+		},
+		{
+			name:                "MissingRequired",
+			reprJson:            `"lang=en"`,
+			expectUnmarshalFail: ipld.ErrMissingRequiredField{},
+		},
+		{
+			name:                "RepeatedField",
+			reprJson:            `"q=a&q=b"`,
+			expectUnmarshalFail: ipld.ErrRepeatedMapKey{},
+		},
+		{
+			name:                "UnknownField",
+			reprJson:            `"q=a&page=2"`,
+			expectUnmarshalFail: ipld.ErrInvalidKey{},
+		},
+		{
+			name:                "MissingInnerDelim",
+			reprJson:            `"q"`,
+			expectUnmarshalFail: ipld.ErrUnmatchable{},
+		},
+	}
+
+	t.Run("maybe-using-embed", func(t *testing.T) {
+		adjCfg.CfgMaybeUsesPtr["Coords"] = false
+		adjCfg.CfgMaybeUsesPtr["String"] = false
+
+		prefix := "structstrpairs"
+		pkgName := "main"
+		genAndCompileAndTest(t, prefix, pkgName, ts, adjCfg, func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+			for _, tcase := range specs {
+				tcase.Test(t, getPrototypeByName("Query"), getPrototypeByName("Query.Repr"))
+			}
+		})
+	})
+	t.Run("maybe-using-ptr", func(t *testing.T) {
+		adjCfg.CfgMaybeUsesPtr["Coords"] = true
+		adjCfg.CfgMaybeUsesPtr["String"] = true
+
+		prefix := "structstrpairs2"
+		pkgName := "main"
+		genAndCompileAndTest(t, prefix, pkgName, ts, adjCfg, func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+			for _, tcase := range specs {
+				tcase.Test(t, getPrototypeByName("Query"), getPrototypeByName("Query.Repr"))
+			}
+		})
+	})
+}
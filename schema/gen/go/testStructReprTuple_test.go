@@ -20,7 +20,7 @@ func TestStructReprTuple(t *testing.T) {
 	ts := schema.TypeSystem{}
 	ts.Init()
 	adjCfg := &AdjunctCfg{
-		maybeUsesPtr: map[schema.TypeName]bool{},
+		CfgMaybeUsesPtr: map[schema.TypeName]bool{},
 	}
 	ts.Accumulate(schema.SpawnString("String"))
 	ts.Accumulate(schema.SpawnStruct("OneTuple",
@@ -141,5 +141,62 @@ func TestStructReprTuple(t *testing.T) {
 				Wish(t, n, ShouldEqual, nr)
 			})
 		})
+
+		t.Run("fourtuple missing required", func(t *testing.T) {
+			nrp := getPrototypeByName("FourTuple.Repr")
+			nb := nrp.NewBuilder()
+			la, err := nb.BeginList(1)
+			Require(t, err, ShouldEqual, nil)
+			Require(t, la.AssembleValue().AssignString("0"), ShouldEqual, nil)
+			err = la.Finish()
+			Wish(t, err, ShouldBeSameTypeAs, ipld.ErrMissingRequiredField{})
+			Wish(t, err.(ipld.ErrMissingRequiredField).Missing, ShouldEqual, []string{"bar"})
+		})
+
+		t.Run("fourtuple too long", func(t *testing.T) {
+			nrp := getPrototypeByName("FourTuple.Repr")
+			nb := nrp.NewBuilder()
+			la, err := nb.BeginList(5)
+			Require(t, err, ShouldEqual, nil)
+			for i := 0; i < 4; i++ {
+				Require(t, la.AssembleValue().AssignString("x"), ShouldEqual, nil)
+			}
+			Wish(t, la.AssembleValue().AssignString("x"), ShouldBeSameTypeAs, schema.ErrNoSuchField{})
+			Require(t, la.Finish(), ShouldEqual, nil)
+
+			_, err = nb.Build().(schema.TypedNode).Representation().LookupByIndex(4)
+			Wish(t, err, ShouldBeSameTypeAs, schema.ErrNoSuchField{})
+		})
+
+		t.Run("fourtuple repr copy", func(t *testing.T) {
+			// The repr node is a view over the same memory as the type-level node,
+			//  so assigning either of them to the repr assembler gives an equal value.
+			np := getPrototypeByName("FourTuple")
+			nrp := getPrototypeByName("FourTuple.Repr")
+			n := fluent.MustBuildMap(np, 2, func(ma fluent.MapAssembler) {
+				ma.AssembleEntry("foo").AssignString("0")
+				ma.AssembleEntry("bar").AssignNull()
+			}).(schema.TypedNode)
+			for _, v := range []ipld.Node{n, n.Representation()} {
+				nb := nrp.NewBuilder()
+				Require(t, nb.AssignNode(v), ShouldEqual, nil)
+				Wish(t, nb.Build(), ShouldEqual, n)
+			}
+		})
+
+		t.Run("fourtuple with gap", func(t *testing.T) {
+			// baz can't be left out while qux is set, because the tuple would have nowhere to put it.
+			np := getPrototypeByName("FourTuple")
+			nb := np.NewBuilder()
+			ma, err := nb.BeginMap(3)
+			Require(t, err, ShouldEqual, nil)
+			Require(t, ma.AssembleKey().AssignString("foo"), ShouldEqual, nil)
+			Require(t, ma.AssembleValue().AssignString("0"), ShouldEqual, nil)
+			Require(t, ma.AssembleKey().AssignString("bar"), ShouldEqual, nil)
+			Require(t, ma.AssembleValue().AssignString("1"), ShouldEqual, nil)
+			Require(t, ma.AssembleKey().AssignString("qux"), ShouldEqual, nil)
+			Require(t, ma.AssembleValue().AssignString("3"), ShouldEqual, nil)
+			Wish(t, ma.Finish(), ShouldBeSameTypeAs, ipld.ErrMissingRequiredField{})
+		})
 	})
 }
@@ -22,7 +22,7 @@ func TestStructsContainingMaybe(t *testing.T) {
 	ts := schema.TypeSystem{}
 	ts.Init()
 	adjCfg := &AdjunctCfg{
-		maybeUsesPtr: map[schema.TypeName]bool{},
+		CfgMaybeUsesPtr: map[schema.TypeName]bool{},
 	}
 	ts.Accumulate(schema.SpawnString("String"))
 	ts.Accumulate(schema.SpawnStruct("Stroct",
@@ -150,7 +150,7 @@ func TestStructsContainingMaybe(t *testing.T) {
 
 	// And finally, launch tests! ...while specializing the adjunct config a bit.
 	t.Run("maybe-using-embed", func(t *testing.T) {
-		adjCfg.maybeUsesPtr["String"] = false
+		adjCfg.CfgMaybeUsesPtr["String"] = false
 
 		prefix := "stroct"
 		pkgName := "main"
@@ -161,7 +161,7 @@ func TestStructsContainingMaybe(t *testing.T) {
 		})
 	})
 	t.Run("maybe-using-ptr", func(t *testing.T) {
-		adjCfg.maybeUsesPtr["String"] = true
+		adjCfg.CfgMaybeUsesPtr["String"] = true
 
 		prefix := "stroct2"
 		pkgName := "main"
@@ -0,0 +1,221 @@
+package gengo
+
+import (
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// TestStructsMaybeStrictness checks that struct assemblers keep "nullable" and "optional" apart,
+// for every struct representation which allows either of them:
+// null is only accepted for nullable fields, and only optional fields may be left out.
+// (Nullable but required fields must be present, even if only as null.)
+//
+// Each struct has the same four fields, so the same type-level fixtures apply to all of them:
+//  - 'a' -- plain;
+//  - 'b' -- nullable;
+//  - 'c' -- optional;
+//  - 'd' -- optional and nullable.
+// The stringpairs representation can't express null, so its struct has no nullable fields.
+func TestStructsMaybeStrictness(t *testing.T) {
+	t.Parallel()
+
+	ts := schema.TypeSystem{}
+	ts.Init()
+	adjCfg := &AdjunctCfg{
+		CfgMaybeUsesPtr: map[schema.TypeName]bool{},
+	}
+	ts.Accumulate(schema.SpawnString("String"))
+	fields := func() []schema.StructField {
+		return []schema.StructField{
+			schema.SpawnStructField("a", "String", false, false),
+			schema.SpawnStructField("b", "String", false, true),
+			schema.SpawnStructField("c", "String", true, false),
+			schema.SpawnStructField("d", "String", true, true),
+		}
+	}
+	ts.Accumulate(schema.SpawnStruct("StrictMap", fields(), schema.SpawnStructRepresentationMap(nil)))
+	ts.Accumulate(schema.SpawnStruct("StrictTuple", fields(), schema.SpawnStructRepresentationTuple()))
+	ts.Accumulate(schema.SpawnStruct("StrictListpairs", fields(), schema.SpawnStructRepresentationListPairs()))
+	ts.Accumulate(schema.SpawnStruct("StrictStringpairs",
+		[]schema.StructField{
+			schema.SpawnStructField("a", "String", false, false),
+			schema.SpawnStructField("c", "String", true, false),
+		},
+		schema.SpawnStructRepresentationStringPairs("=", "&"),
+	))
+
+	// The type-level fixtures are shared by all the structs with nullable fields.
+	typeSpecs := []testcase{
+		{
+			name:                "NullInPlainField",
+			typeJson:            `{"a":null,"b":"x"}`,
+			expectUnmarshalFail: ipld.ErrWrongKind{},
+		},
+		{
+			name:                "NullInOptionalField",
+			typeJson:            `{"a":"x","b":"y","c":null}`,
+			expectUnmarshalFail: ipld.ErrWrongKind{},
+		},
+		{
+			name:                "AbsentNullableField",
+			typeJson:            `{"a":"x"}`,
+			expectUnmarshalFail: ipld.ErrMissingRequiredField{},
+		},
+		{
+			name:     "NullInNullableFields",
+			typeJson: `{"a":"x","b":null,"c":"y","d":null}`,
+			typePoints: []testcasePoint{
+				{"b", ipld.Null},
+				{"c", "y"},
+				{"d", ipld.Null},
+			},
+		},
+	}
+	reprSpecs := map[string][]testcase{
+		"StrictMap": {
+			{
+				name:                "ReprNullInPlainField",
+				reprJson:            `{"a":null,"b":"x"}`,
+				expectUnmarshalFail: ipld.ErrWrongKind{},
+			},
+			{
+				name:                "ReprNullInOptionalField",
+				reprJson:            `{"a":"x","b":"y","c":null}`,
+				expectUnmarshalFail: ipld.ErrWrongKind{},
+			},
+			{
+				name:                "ReprAbsentNullableField",
+				reprJson:            `{"a":"x"}`,
+				expectUnmarshalFail: ipld.ErrMissingRequiredField{},
+			},
+			{
+				name:     "ReprNullInNullableFields",
+				typeJson: `{"a":"x","b":null,"c":"y","d":null}`,
+				reprJson: `{"a":"x","b":null,"c":"y","d":null}`,
+			},
+		},
+		"StrictTuple": {
+			{
+				name:                "ReprNullInPlainField",
+				reprJson:            `[null,"x"]`,
+				expectUnmarshalFail: ipld.ErrWrongKind{},
+			},
+			{
+				name:                "ReprNullInOptionalField",
+				reprJson:            `["x","y",null]`,
+				expectUnmarshalFail: ipld.ErrWrongKind{},
+			},
+			{
+				name:                "ReprAbsentNullableField",
+				reprJson:            `["x"]`,
+				expectUnmarshalFail: ipld.ErrMissingRequiredField{},
+			},
+			{
+				name:     "ReprNullInNullableFields",
+				typeJson: `{"a":"x","b":null,"c":"y","d":null}`,
+				reprJson: `["x",null,"y",null]`,
+			},
+		},
+		"StrictListpairs": {
+			{
+				name:                "ReprNullInPlainField",
+				reprJson:            `[["a",null],["b","x"]]`,
+				expectUnmarshalFail: ipld.ErrWrongKind{},
+			},
+			{
+				name:                "ReprNullInOptionalField",
+				reprJson:            `[["a","x"],["b","y"],["c",null]]`,
+				expectUnmarshalFail: ipld.ErrWrongKind{},
+			},
+			{
+				name:                "ReprAbsentNullableField",
+				reprJson:            `[["a","x"]]`,
+				expectUnmarshalFail: ipld.ErrMissingRequiredField{},
+			},
+			{
+				name:     "ReprNullInNullableFields",
+				typeJson: `{"a":"x","b":null,"c":"y","d":null}`,
+				reprJson: `[["a","x"],["b",null],["c","y"],["d",null]]`,
+			},
+		},
+		"StrictStringpairs": {
+			{
+				name:                "NullInPlainField",
+				typeJson:            `{"a":null}`,
+				expectUnmarshalFail: ipld.ErrWrongKind{},
+			},
+			{
+				name:                "NullInOptionalField",
+				typeJson:            `{"a":"x","c":null}`,
+				expectUnmarshalFail: ipld.ErrWrongKind{},
+			},
+			{
+				name:                "ReprAbsentPlainField",
+				reprJson:            `"c=x"`,
+				expectUnmarshalFail: ipld.ErrMissingRequiredField{},
+			},
+			{
+				name:     "ReprAbsentOptionalField",
+				typeJson: `{"a":"x"}`,
+				reprJson: `"a=x"`,
+				typePoints: []testcasePoint{
+					{"c", ipld.Absent},
+				},
+				typeItr: []entry{
+					{"a", "x"},
+					{"c", ipld.Absent},
+				},
+			},
+		},
+	}
+
+	test := func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+		for _, name := range []string{"StrictMap", "StrictTuple", "StrictListpairs", "StrictStringpairs"} {
+			t.Run(name, func(t *testing.T) {
+				np, npr := getPrototypeByName(name), getPrototypeByName(name+".Repr")
+				if name != "StrictStringpairs" {
+					for _, tcase := range typeSpecs {
+						tcase.Test(t, np, npr)
+					}
+				}
+				for _, tcase := range reprSpecs[name] {
+					tcase.Test(t, np, npr)
+				}
+			})
+		}
+		t.Run("missing fields are all reported", func(t *testing.T) {
+			// Nullable fields are required, so they have to be listed too.
+			for _, name := range []string{"StrictMap", "StrictMap.Repr", "StrictTuple", "StrictListpairs"} {
+				ma, err := getPrototypeByName(name).NewBuilder().BeginMap(0)
+				Require(t, err, ShouldEqual, nil)
+				Wish(t, ma.Finish(), ShouldEqual, ipld.ErrMissingRequiredField{Missing: []string{"a", "b"}})
+			}
+			la, err := getPrototypeByName("StrictListpairs.Repr").NewBuilder().BeginList(0)
+			Require(t, err, ShouldEqual, nil)
+			Wish(t, la.Finish(), ShouldEqual, ipld.ErrMissingRequiredField{Missing: []string{"a", "b"}})
+		})
+	}
+
+	t.Run("maybe-using-embed", func(t *testing.T) {
+		adjCfg.CfgMaybeUsesPtr["String"] = false
+
+		prefix := "structs-maybe-strictness-using-embed"
+		pkgName := "main"
+		genAndCompileAndTest(t, prefix, pkgName, ts, adjCfg, func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+			test(t, getPrototypeByName)
+		})
+	})
+	t.Run("maybe-using-ptr", func(t *testing.T) {
+		adjCfg.CfgMaybeUsesPtr["String"] = true
+
+		prefix := "structs-maybe-strictness-using-ptr"
+		pkgName := "main"
+		genAndCompileAndTest(t, prefix, pkgName, ts, adjCfg, func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+			test(t, getPrototypeByName)
+		})
+	})
+}
@@ -0,0 +1,111 @@
+package gengo
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// TestSymbols covers schemas whose names don't make for usable Go symbols as they are,
+// as well as the adjunct config for picking the symbols by hand.
+func TestSymbols(t *testing.T) {
+	t.Parallel()
+
+	prefix := "symbols"
+	pkgName := "main"
+
+	ts := schema.TypeSystem{}
+	ts.Init()
+	adjCfg := &AdjunctCfg{
+		TypeSymbolOverrides: map[schema.TypeName]string{
+			"Overridden": "Renamed",
+		},
+		FieldSymbolLowerOverrides: map[FieldTuple]string{
+			{"Overridden", "a"}: "alpha",
+		},
+		FieldSymbolUpperOverrides: map[FieldTuple]string{
+			{"Overridden", "a"}: "Alpha",
+		},
+	}
+	ts.Accumulate(schema.SpawnString("String"))
+	ts.Accumulate(schema.SpawnInt("Int"))
+	ts.Accumulate(schema.SpawnString("range"))
+	ts.Accumulate(schema.SpawnString("Range"))
+	ts.Accumulate(schema.SpawnStruct("Keywordy",
+		[]schema.StructField{
+			schema.SpawnStructField("type", "String", false, false),
+			schema.SpawnStructField("func", "range", false, false),
+			schema.SpawnStructField("Kind", "Int", false, false),
+			schema.SpawnStructField("kind", "Range", false, false),
+			schema.SpawnStructField("map", "String", true, false),
+		},
+		schema.SpawnStructRepresentationMap(nil),
+	))
+	ts.Accumulate(schema.SpawnStruct("Overridden",
+		[]schema.StructField{
+			schema.SpawnStructField("a", "String", false, false),
+		},
+		schema.SpawnStructRepresentationMap(nil),
+	))
+
+	t.Run("munging", func(t *testing.T) {
+		Wish(t, adjCfg.TypeSymbol(ts.TypeByName("String")), ShouldEqual, "String")
+		Wish(t, adjCfg.TypeSymbol(ts.TypeByName("Range")), ShouldEqual, "Range")
+		Wish(t, adjCfg.TypeSymbol(ts.TypeByName("range")), ShouldEqual, "Range_")
+		Wish(t, adjCfg.TypeSymbol(ts.TypeByName("Overridden")), ShouldEqual, "Renamed")
+
+		var lower, upper []string
+		for _, f := range ts.TypeByName("Keywordy").(*schema.TypeStruct).Fields() {
+			lower = append(lower, adjCfg.FieldSymbolLower(f))
+			upper = append(upper, adjCfg.FieldSymbolUpper(f))
+		}
+		Wish(t, lower, ShouldEqual, []string{"type_", "func_", "kind_", "kind", "map_"})
+		Wish(t, upper, ShouldEqual, []string{"Type", "Func", "Kind_", "Kind", "Map"})
+
+		for _, tc := range []struct{ name, lower, upper string }{
+			{"foo", "foo", "Foo"},
+			{"fooBar", "fooBar", "FooBar"},
+			{"foo-bar", "foo_bar", "Foo_bar"},
+			{"9lives", "x9lives", "X9lives"},
+			{"_", "x_", "X_"},
+			{"_foo", "_foo", "X_foo"},
+			{"", "x", "X"},
+		} {
+			Wish(t, mungeSymbol(tc.name, false), ShouldEqual, tc.lower)
+			Wish(t, mungeSymbol(tc.name, true), ShouldEqual, tc.upper)
+		}
+	})
+
+	genAndCompileAndTest(t, prefix, pkgName, ts, adjCfg, func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+		t.Run("keywords", func(t *testing.T) {
+			np := getPrototypeByName("Keywordy")
+			nrp := getPrototypeByName("Keywordy.Repr")
+			testcase{
+				typeJson: `{"type":"a","func":"b","Kind":1,"kind":"c","map":"d"}`,
+				reprJson: `{"type":"a","func":"b","Kind":1,"kind":"c","map":"d"}`,
+				typePoints: []testcasePoint{
+					{"type", "a"},
+					{"func", "b"},
+					{"Kind", 1},
+					{"kind", "c"},
+					{"map", "d"},
+				},
+			}.Test(t, np, nrp)
+
+			n := testUnmarshal(t, np, `{"type":"a","func":"b","Kind":1,"kind":"c"}`, nil)
+			for _, method := range []string{"FieldType", "FieldFunc", "FieldKind_", "FieldKind", "FieldMap"} {
+				Wish(t, reflect.ValueOf(n).MethodByName(method).IsValid(), ShouldEqual, true)
+			}
+		})
+		t.Run("overrides", func(t *testing.T) {
+			np := getPrototypeByName("Overridden")
+			n := testUnmarshal(t, np, `{"a":"x"}`, nil)
+			Wish(t, reflect.TypeOf(n).Elem().Name(), ShouldEqual, "_Renamed")
+			Wish(t, reflect.ValueOf(n).MethodByName("FieldAlpha").IsValid(), ShouldEqual, true)
+		})
+	})
+}
@@ -0,0 +1,134 @@
+package gengo
+
+import (
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/fluent"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+func TestUnionEnvelope(t *testing.T) {
+	t.Parallel()
+
+	ts := schema.TypeSystem{}
+	ts.Init()
+	adjCfg := &AdjunctCfg{}
+	ts.Accumulate(schema.SpawnString("String"))
+	ts.Accumulate(schema.SpawnStruct("SmolStruct",
+		[]schema.StructField{
+			schema.SpawnStructField("s", "String", false, false),
+		},
+		schema.SpawnStructRepresentationMap(map[string]string{
+			"s": "q",
+		}),
+	))
+	ts.Accumulate(schema.SpawnUnion("WheeUnion",
+		[]schema.TypeName{
+			"String",
+			"SmolStruct",
+		},
+		schema.SpawnUnionRepresentationEnvelope(
+			"tag",
+			"content",
+			map[string]schema.TypeName{
+				"a": "String",
+				"b": "SmolStruct",
+			},
+		),
+	))
+
+	// These are the same *type-level* as in TestUnionKeyedComplexChildren,
+	//  but (of course) have very different representations.
+	specs := []testcase{
+		{
+			name:     "InhabitantA",
+			typeJson: `{"String":"whee"}`,
+			reprJson: `{"tag":"a","content":"whee"}`,
+			typePoints: []testcasePoint{
+				{"", ipld.Kind_Map},
+				{"String", "whee"},
+			},
+			reprPoints: []testcasePoint{
+				{"", ipld.Kind_Map},
+				{"tag", "a"},
+				{"content", "whee"},
+			},
+		},
+		{
+			name:     "InhabitantB",
+			typeJson: `{"SmolStruct":{"s":"whee"}}`,
+			reprJson: `{"tag":"b","content":{"q":"whee"}}`,
+			typePoints: []testcasePoint{
+				{"", ipld.Kind_Map},
+				{"SmolStruct", ipld.Kind_Map},
+				{"SmolStruct/s", "whee"},
+			},
+			reprPoints: []testcasePoint{
+				{"", ipld.Kind_Map},
+				{"tag", "b"},
+				{"content", ipld.Kind_Map},
+				{"content/q", "whee"},
+			},
+		},
+		{
+			name:                "UnknownDiscriminant",
+			reprJson:            `{"tag":"c","content":"whee"}`,
+			expectUnmarshalFail: schema.ErrInvalidUnionDiscriminant{},
+		},
+		{
+			name:                "ContentBeforeDiscriminant",
+			reprJson:            `{"content":"whee","tag":"a"}`,
+			expectUnmarshalFail: schema.ErrNotUnionStructure{},
+		},
+		{
+			name:                "MissingContent",
+			reprJson:            `{"tag":"a"}`,
+			expectUnmarshalFail: schema.ErrNotUnionStructure{},
+		},
+	}
+
+	test := func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+		np := getPrototypeByName("WheeUnion")
+		nrp := getPrototypeByName("WheeUnion.Repr")
+		for _, tcase := range specs {
+			tcase.Test(t, np, nrp)
+		}
+
+		// AssignNode looks up the two entries, so their order doesn't matter to it.
+		t.Run("AssignNodeContentFirst", func(t *testing.T) {
+			n := fluent.MustBuildMap(basicnode.Prototype.Map, 2, func(ma fluent.MapAssembler) {
+				ma.AssembleEntry("content").AssignString("whee")
+				ma.AssembleEntry("tag").AssignString("a")
+			})
+			nb := nrp.NewBuilder()
+			Require(t, nb.AssignNode(n), ShouldEqual, nil)
+			v, err := nb.Build().LookupByString("String")
+			Require(t, err, ShouldEqual, nil)
+			s, _ := v.AsString()
+			Wish(t, s, ShouldEqual, "whee")
+		})
+	}
+
+	t.Run("union-using-embed", func(t *testing.T) {
+		adjCfg.CfgUnionMemlayout = map[schema.TypeName]string{"WheeUnion": "embedAll"}
+
+		prefix := "union-envelope-using-embed"
+		pkgName := "main"
+		genAndCompileAndTest(t, prefix, pkgName, ts, adjCfg, func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+			test(t, getPrototypeByName)
+		})
+	})
+	t.Run("union-using-interface", func(t *testing.T) {
+		adjCfg.CfgUnionMemlayout = map[schema.TypeName]string{"WheeUnion": "interface"}
+
+		prefix := "union-envelope-using-interface"
+		pkgName := "main"
+		genAndCompileAndTest(t, prefix, pkgName, ts, adjCfg, func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+			test(t, getPrototypeByName)
+		})
+	})
+}
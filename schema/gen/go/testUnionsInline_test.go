@@ -0,0 +1,147 @@
+package gengo
+
+import (
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/fluent"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+func TestUnionInline(t *testing.T) {
+	t.Parallel()
+
+	ts := schema.TypeSystem{}
+	ts.Init()
+	adjCfg := &AdjunctCfg{}
+	ts.Accumulate(schema.SpawnString("String"))
+	ts.Accumulate(schema.SpawnStruct("Cat",
+		[]schema.StructField{
+			schema.SpawnStructField("name", "String", false, false),
+		},
+		schema.SpawnStructRepresentationMap(nil),
+	))
+	ts.Accumulate(schema.SpawnStruct("Dog",
+		[]schema.StructField{
+			schema.SpawnStructField("name", "String", false, false),
+			schema.SpawnStructField("toy", "String", true, false),
+		},
+		schema.SpawnStructRepresentationMap(map[string]string{
+			"toy": "t",
+		}),
+	))
+	ts.Accumulate(schema.SpawnUnion("WheeUnion",
+		[]schema.TypeName{
+			"Cat",
+			"Dog",
+		},
+		schema.SpawnUnionRepresentationInline(
+			"kind",
+			map[string]schema.TypeName{
+				"cat": "Cat",
+				"dog": "Dog",
+			},
+		),
+	))
+
+	specs := []testcase{
+		{
+			name:     "InhabitantA",
+			typeJson: `{"Cat":{"name":"Tom"}}`,
+			reprJson: `{"kind":"cat","name":"Tom"}`,
+			typePoints: []testcasePoint{
+				{"", ipld.Kind_Map},
+				{"Cat", ipld.Kind_Map},
+				{"Cat/name", "Tom"},
+			},
+			reprPoints: []testcasePoint{
+				{"", ipld.Kind_Map},
+				{"kind", "cat"},
+				{"name", "Tom"},
+			},
+		},
+		{
+			name:     "InhabitantB",
+			typeJson: `{"Dog":{"name":"Rex","toy":"ball"}}`,
+			reprJson: `{"kind":"dog","name":"Rex","t":"ball"}`,
+			typePoints: []testcasePoint{
+				{"", ipld.Kind_Map},
+				{"Dog", ipld.Kind_Map},
+				{"Dog/name", "Rex"},
+				{"Dog/toy", "ball"},
+			},
+			reprPoints: []testcasePoint{
+				{"", ipld.Kind_Map},
+				{"kind", "dog"},
+				{"name", "Rex"},
+				{"t", "ball"},
+			},
+		},
+		{
+			name:                "UnknownDiscriminant",
+			reprJson:            `{"kind":"cow","name":"Daisy"}`,
+			expectUnmarshalFail: schema.ErrInvalidUnionDiscriminant{},
+		},
+		{
+			name:                "FieldBeforeDiscriminant",
+			reprJson:            `{"name":"Tom","kind":"cat"}`,
+			expectUnmarshalFail: schema.ErrNotUnionStructure{},
+		},
+		{
+			name:                "UnknownField",
+			reprJson:            `{"kind":"cat","name":"Tom","t":"ball"}`,
+			expectUnmarshalFail: ipld.ErrInvalidKey{},
+		},
+		{
+			name:                "MissingField",
+			reprJson:            `{"kind":"dog"}`,
+			expectUnmarshalFail: ipld.ErrMissingRequiredField{},
+		},
+	}
+
+	test := func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+		np := getPrototypeByName("WheeUnion")
+		nrp := getPrototypeByName("WheeUnion.Repr")
+		for _, tcase := range specs {
+			tcase.Test(t, np, nrp)
+		}
+
+		// AssignNode looks up the discriminant first, so the order of the entries doesn't matter to it.
+		t.Run("AssignNodeDiscriminantLast", func(t *testing.T) {
+			n := fluent.MustBuildMap(basicnode.Prototype.Map, 2, func(ma fluent.MapAssembler) {
+				ma.AssembleEntry("name").AssignString("Tom")
+				ma.AssembleEntry("kind").AssignString("cat")
+			})
+			nb := nrp.NewBuilder()
+			Require(t, nb.AssignNode(n), ShouldEqual, nil)
+			v, err := nb.Build().LookupByString("Cat")
+			Require(t, err, ShouldEqual, nil)
+			v, err = v.LookupByString("name")
+			Require(t, err, ShouldEqual, nil)
+			s, _ := v.AsString()
+			Wish(t, s, ShouldEqual, "Tom")
+		})
+	}
+
+	t.Run("union-using-embed", func(t *testing.T) {
+		adjCfg.CfgUnionMemlayout = map[schema.TypeName]string{"WheeUnion": "embedAll"}
+
+		prefix := "union-inline-using-embed"
+		pkgName := "main"
+		genAndCompileAndTest(t, prefix, pkgName, ts, adjCfg, func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+			test(t, getPrototypeByName)
+		})
+	})
+	t.Run("union-using-interface", func(t *testing.T) {
+		adjCfg.CfgUnionMemlayout = map[schema.TypeName]string{"WheeUnion": "interface"}
+
+		prefix := "union-inline-using-interface"
+		pkgName := "main"
+		genAndCompileAndTest(t, prefix, pkgName, ts, adjCfg, func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+			test(t, getPrototypeByName)
+		})
+	})
+}
@@ -0,0 +1,72 @@
+package gengo
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// TestUnionKindedMore covers kinded union members of the kinds TestUnionKinded doesn't,
+// and the Member accessors generated for each member.
+func TestUnionKindedMore(t *testing.T) {
+	t.Parallel()
+
+	ts := schema.TypeSystem{}
+	ts.Init()
+	adjCfg := &AdjunctCfg{}
+	ts.Accumulate(schema.SpawnString("String"))
+	ts.Accumulate(schema.SpawnFloat("Float"))
+	ts.Accumulate(schema.SpawnBytes("Bytes"))
+	ts.Accumulate(schema.SpawnLink("Link"))
+	ts.Accumulate(schema.SpawnMap("Map__String__String", "String", "String", false))
+	ts.Accumulate(schema.SpawnUnion("WheeUnion",
+		[]schema.TypeName{"Float", "Bytes", "Link", "Map__String__String"},
+		schema.SpawnUnionRepresentationKinded(map[ipld.Kind]schema.TypeName{
+			ipld.Kind_Float: "Float",
+			ipld.Kind_Bytes: "Bytes",
+			ipld.Kind_Link:  "Link",
+			ipld.Kind_Map:   "Map__String__String",
+		}),
+	))
+	// Each case is named after the member it should inhabit.
+	specs := []testcase{
+		{
+			name:     "Map__String__String",
+			typeJson: `{"Map__String__String":{"a":"b"}}`,
+			reprJson: `{"a":"b"}`,
+		},
+		{
+			name:     "Bytes",
+			typeJson: `{"Bytes":{"/":{"bytes":"AQI"}}}`,
+			reprJson: `{"/":{"bytes":"AQI"}}`,
+		},
+		{
+			name:     "Link",
+			typeJson: `{"Link":{"/":"bafyreibbvxxq5fahtmylr5tsfymzqmpw7zyufhh55e2zrf4jbsvtfw6hge"}}`,
+			reprJson: `{"/":"bafyreibbvxxq5fahtmylr5tsfymzqmpw7zyufhh55e2zrf4jbsvtfw6hge"}`,
+		},
+	}
+	for _, layout := range []string{"embedAll", "interface"} {
+		t.Run(layout, func(t *testing.T) {
+			adjCfg.CfgUnionMemlayout = map[schema.TypeName]string{"WheeUnion": layout}
+			genAndCompileAndTest(t, "union-kinded-more-"+layout, "main", ts, adjCfg, func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+				for _, tcase := range specs {
+					tcase.Test(t, getPrototypeByName("WheeUnion"), getPrototypeByName("WheeUnion.Repr"))
+				}
+				t.Run("MemberAccessors", func(t *testing.T) {
+					for _, tcase := range specs {
+						n := testUnmarshal(t, getPrototypeByName("WheeUnion.Repr"), tcase.reprJson, nil)
+						for _, member := range []string{"Float", "Bytes", "Link", "Map__String__String"} {
+							got := reflect.ValueOf(n).MethodByName("Member" + member).Call(nil)[0]
+							Wish(t, got.IsNil(), ShouldEqual, member != tcase.name)
+						}
+					}
+				})
+			})
+		})
+	}
+}
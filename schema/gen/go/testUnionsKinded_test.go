@@ -94,3 +94,131 @@ func TestUnionKinded(t *testing.T) {
 		})
 	})
 }
+
+func TestUnionKindedScalarsAndUnions(t *testing.T) {
+	t.Parallel()
+
+	ts := schema.TypeSystem{}
+	ts.Init()
+	adjCfg := &AdjunctCfg{}
+	ts.Accumulate(schema.SpawnString("String"))
+	ts.Accumulate(schema.SpawnInt("Int"))
+	ts.Accumulate(schema.SpawnBool("Bool"))
+	ts.Accumulate(schema.SpawnList("List__Int", "Int", false))
+	ts.Accumulate(schema.SpawnUnion("Prefixed",
+		[]schema.TypeName{
+			"String",
+		},
+		schema.SpawnUnionRepresentationStringprefix(
+			":",
+			map[string]schema.TypeName{
+				"s": "String",
+			},
+		),
+	))
+	// A kinded union's members may be of any kind, including other unions,
+	//  as long as the kind of their representation is known in advance.
+	ts.Accumulate(schema.SpawnUnion("WheeUnion",
+		[]schema.TypeName{
+			"Prefixed",
+			"Int",
+			"Bool",
+			"List__Int",
+		},
+		schema.SpawnUnionRepresentationKinded(map[ipld.Kind]schema.TypeName{
+			ipld.Kind_String: "Prefixed",
+			ipld.Kind_Int:    "Int",
+			ipld.Kind_Bool:   "Bool",
+			ipld.Kind_List:   "List__Int",
+		}),
+	))
+
+	specs := []testcase{
+		{
+			name:     "InhabitantInt",
+			typeJson: `{"Int":3}`,
+			reprJson: `3`,
+			typePoints: []testcasePoint{
+				{"", ipld.Kind_Map},
+				{"Int", 3},
+			},
+			reprPoints: []testcasePoint{
+				{"", ipld.Kind_Int},
+				{"", 3},
+			},
+		},
+		{
+			name:     "InhabitantBool",
+			typeJson: `{"Bool":true}`,
+			reprJson: `true`,
+			typePoints: []testcasePoint{
+				{"", ipld.Kind_Map},
+				{"Bool", true},
+			},
+			reprPoints: []testcasePoint{
+				{"", ipld.Kind_Bool},
+				{"", true},
+			},
+		},
+		{
+			name:     "InhabitantList",
+			typeJson: `{"List__Int":[1,2]}`,
+			reprJson: `[1,2]`,
+			typePoints: []testcasePoint{
+				{"", ipld.Kind_Map},
+				{"List__Int", ipld.Kind_List},
+				{"List__Int/1", 2},
+			},
+			reprPoints: []testcasePoint{
+				{"", ipld.Kind_List},
+				{"1", 2},
+			},
+		},
+		{
+			name:     "InhabitantUnion",
+			typeJson: `{"Prefixed":{"String":"whee"}}`,
+			reprJson: `"s:whee"`,
+			typePoints: []testcasePoint{
+				{"", ipld.Kind_Map},
+				{"Prefixed", ipld.Kind_Map},
+				{"Prefixed/String", "whee"},
+			},
+			reprPoints: []testcasePoint{
+				{"", ipld.Kind_String},
+				{"", "s:whee"},
+			},
+		},
+		{
+			name:                "NoMemberForKind",
+			reprJson:            `1.5`,
+			expectUnmarshalFail: schema.ErrNotUnionStructure{},
+		},
+	}
+
+	test := func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+		np := getPrototypeByName("WheeUnion")
+		nrp := getPrototypeByName("WheeUnion.Repr")
+		for _, tcase := range specs {
+			tcase.Test(t, np, nrp)
+		}
+	}
+
+	t.Run("union-using-embed", func(t *testing.T) {
+		adjCfg.CfgUnionMemlayout = map[schema.TypeName]string{"WheeUnion": "embedAll"}
+
+		prefix := "union-kinded-scalars-using-embed"
+		pkgName := "main"
+		genAndCompileAndTest(t, prefix, pkgName, ts, adjCfg, func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+			test(t, getPrototypeByName)
+		})
+	})
+	t.Run("union-using-interface", func(t *testing.T) {
+		adjCfg.CfgUnionMemlayout = map[schema.TypeName]string{"WheeUnion": "interface"}
+
+		prefix := "union-kinded-scalars-using-interface"
+		pkgName := "main"
+		genAndCompileAndTest(t, prefix, pkgName, ts, adjCfg, func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
+			test(t, getPrototypeByName)
+		})
+	})
+}
@@ -68,6 +68,11 @@ func TestUnionStringprefix(t *testing.T) {
 				{"", "complex:whee:woo"},
 			},
 		},
+		{
+			name:                "UnknownDiscriminant",
+			reprJson:            `"other:whee"`,
+			expectUnmarshalFail: schema.ErrInvalidUnionDiscriminant{},
+		},
 	}
 
 	test := func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
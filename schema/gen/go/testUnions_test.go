@@ -3,6 +3,8 @@ package gengo
 import (
 	"testing"
 
+	. "github.com/warpfork/go-wish"
+
 	"github.com/ipld/go-ipld-prime"
 	"github.com/ipld/go-ipld-prime/schema"
 )
@@ -57,6 +59,12 @@ func TestUnionKeyed(t *testing.T) {
 				{"b", "whee"},
 			},
 		},
+		{
+			name:                "UnknownDiscriminant",
+			typeJson:            `{"Strang":"whee"}`,
+			reprJson:            `{"c":"whee"}`,
+			expectUnmarshalFail: schema.ErrInvalidUnionDiscriminant{},
+		},
 	}
 
 	test := func(t *testing.T, getPrototypeByName func(string) ipld.NodePrototype) {
@@ -65,6 +73,16 @@ func TestUnionKeyed(t *testing.T) {
 		for _, tcase := range specs {
 			tcase.Test(t, np, nrp)
 		}
+		t.Run("AssignNode", func(t *testing.T) {
+			// Assigning a node of the same generated type, at either level, copies it rather than iterating over it.
+			n := testUnmarshal(t, nrp, `{"b":"whee"}`, nil)
+			nb := np.NewBuilder()
+			Wish(t, nb.AssignNode(n), ShouldEqual, nil)
+			Wish(t, nb.Build(), ShouldEqual, n)
+			nb = nrp.NewBuilder()
+			Wish(t, nb.AssignNode(n.(schema.TypedNode).Representation()), ShouldEqual, nil)
+			Wish(t, nb.Build(), ShouldEqual, n)
+		})
 	}
 
 	t.Run("union-using-embed", func(t *testing.T) {
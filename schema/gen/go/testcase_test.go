@@ -11,6 +11,7 @@ import (
 	. "github.com/warpfork/go-wish"
 
 	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec"
 	"github.com/ipld/go-ipld-prime/codec/dagjson"
 	"github.com/ipld/go-ipld-prime/schema"
 	"github.com/ipld/go-ipld-prime/traversal"
@@ -197,7 +198,12 @@ func testUnmarshal(t *testing.T, np ipld.NodePrototype, data string, expectFail
 	case expectFail == nil && err == nil:
 		// carry on
 	case expectFail != nil && err != nil:
+		// The codec wraps errors with the offset at which they happened.
+		if de, ok := err.(*codec.DecodeError); ok {
+			err = de.Err
+		}
 		Wish(t, err, ShouldBeSameTypeAs, expectFail)
+		return nil // the builder isn't in a state that can build.
 	case expectFail != nil && err == nil:
 		t.Errorf("expected creation to fail with a %T error, but got no error", expectFail)
 	}
@@ -262,6 +268,12 @@ func closeEnough(actual, expected interface{}) (string, bool) {
 			return fmt.Sprintf("expected something with kind int, got kind %s", a.Kind()), false
 		}
 		x, _ := a.AsInt()
+		return ShouldEqual(x, int64(expected.(int)))
+	case bool:
+		if a.Kind() != ipld.Kind_Bool {
+			return fmt.Sprintf("expected something with kind bool, got kind %s", a.Kind()), false
+		}
+		x, _ := a.AsBool()
 		return ShouldEqual(x, expected)
 	case ipld.Node:
 		return ShouldEqual(actual, expected)
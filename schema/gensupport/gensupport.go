@@ -0,0 +1,152 @@
+// Package gensupport holds functions which code generated by schema/gen/go calls,
+// when it's generated with the CfgCompact option of gengo.AdjunctCfg.
+//
+// Without that option, the generated code spells out the same few snippets
+// (checking the state of an assembler, unboxing a Maybe, and so on)
+// in every type it generates; with it, those snippets become calls to this package,
+// which makes the generated code considerably shorter, and quicker to compile.
+// The cost is a function call in a few places which were branch-free before,
+// though the compiler will often inline the smallest of these anyway.
+//
+// There's little reason to use this package other than from generated code.
+// The "schema.Maybe" states it works with follow the conventions of the generated code,
+// including the extra states it defines for assemblers, which are passed in as parameters.
+package gensupport
+
+import (
+	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// MaybeAsNode implements the AsNode method of a generated Maybe type,
+// given its state and a pointer to its value.
+func MaybeAsNode(m schema.Maybe, v ipld.Node) ipld.Node {
+	switch m {
+	case schema.Maybe_Absent:
+		return ipld.Absent
+	case schema.Maybe_Null:
+		return ipld.Null
+	case schema.Maybe_Value:
+		return v
+	default:
+		panic("unreachable")
+	}
+}
+
+// MaybeMust panics unless the state of a Maybe is schema.Maybe_Value.
+// The Must method of a generated Maybe type calls it before returning its value.
+func MaybeMust(m schema.Maybe) {
+	if m != schema.Maybe_Value {
+		panic("unbox of a maybe rejected")
+	}
+}
+
+// CheckBuildable panics unless an assembler is finished,
+// which is the state a builder must be in to call Build.
+func CheckBuildable(m schema.Maybe) {
+	if m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+}
+
+// CheckAssignable panics if an assembler has already been assigned a value.
+func CheckAssignable(m schema.Maybe) {
+	switch m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	}
+}
+
+// AssignNull implements the AssignNull method of a generated assembler.
+// If the assembler's parent allowed a null in its position, by setting the state to allowNull,
+// the state becomes schema.Maybe_Null; otherwise, the null is rejected with an error
+// which names the type and kind of the assembler, and says it doesn't accept nulls.
+// Assemblers of recursive kinds set their state to midvalue once they've begun,
+// at which point assigning null is a misuse of the assembler, and panics.
+func AssignNull(m *schema.Maybe, allowNull, midvalue schema.Maybe, typeName string, kind ipld.Kind) error {
+	switch *m {
+	case allowNull:
+		*m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return ipld.ErrWrongKind{TypeName: typeName, MethodName: "AssignNull", AppropriateKind: ipld.KindSet_JustNull, ActualKind: kind}
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+
+// The states of a generated map assembler.
+// These match the maState constants which the generated code declares for itself.
+const (
+	maState_initial uint8 = iota
+	maState_midKey
+	maState_expectValue
+	maState_midValue
+	maState_finished
+)
+
+// MapAssemblerExpectKey panics unless a map assembler is in its initial state,
+// which is the state it must be in to begin a new entry (with AssembleEntry or AssembleKey), or to Finish.
+// The caller should already have tried to tidy up any value it was in the middle of.
+func MapAssemblerExpectKey(method string, state uint8) {
+	switch state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		if method == "Finish" {
+			panic("invalid state: " + method + " cannot be called when in the middle of assembling a key")
+		}
+		panic("invalid state: " + method + " cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: " + method + " cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		panic("invalid state: " + method + " cannot be called when in the middle of assembling a value")
+	case maState_finished:
+		panic("invalid state: " + method + " cannot be called on an assembler that's already finished")
+	}
+}
+
+// MapAssemblerExpectValue panics unless a map assembler has a key ready for a value,
+// which is the state it must be in for AssembleValue.
+// The caller should already have tried to tidy up any key it was in the middle of.
+func MapAssemblerExpectValue(method string, state uint8) {
+	switch state {
+	case maState_initial:
+		panic("invalid state: " + method + " cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: " + method + " cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: " + method + " cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: " + method + " cannot be called on an assembler that's already finished")
+	}
+}
+
+// The states of a generated list assembler.
+// These match the laState constants which the generated code declares for itself.
+const (
+	laState_initial uint8 = iota
+	laState_midValue
+	laState_finished
+)
+
+// ListAssemblerCheckState panics unless a list assembler is ready for a new value (with AssembleValue), or to Finish.
+// The caller should already have tried to tidy up any value it was in the middle of.
+func ListAssemblerCheckState(method string, state uint8) {
+	switch state {
+	case laState_initial:
+		// carry on
+	case laState_midValue:
+		if method == "Finish" {
+			panic("invalid state: " + method + " cannot be called when in the middle of assembling a value")
+		}
+		panic("invalid state: " + method + " cannot be called when still in the middle of assembling the previous value")
+	case laState_finished:
+		panic("invalid state: " + method + " cannot be called on an assembler that's already finished")
+	}
+}
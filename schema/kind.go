@@ -26,7 +26,7 @@ const (
 	TypeKind_Struct  TypeKind = '$'
 	TypeKind_Union   TypeKind = '^'
 	TypeKind_Enum    TypeKind = '%'
-	// FUTURE: TypeKind_Any = '?'?
+	TypeKind_Any     TypeKind = '?'
 )
 
 func (k TypeKind) String() string {
@@ -57,6 +57,8 @@ func (k TypeKind) String() string {
 		return "Union"
 	case TypeKind_Enum:
 		return "Enum"
+	case TypeKind_Any:
+		return "Any"
 	default:
 		panic("invalid enumeration value!")
 	}
@@ -74,6 +76,9 @@ func (k TypeKind) String() string {
 // it does not necessarily describe how it will be *serialized*
 // (for example, a struct will always act like a map, even if it has a tuple
 // representation strategy and thus becomes a list when serialized).
+//
+// The Any kind can't be mapped to anything in advance:
+// it acts like whatever data it holds, so Kind_Invalid is returned for it.
 func (k TypeKind) ActsLike() ipld.Kind {
 	switch k {
 	case TypeKind_Invalid:
@@ -102,6 +107,8 @@ func (k TypeKind) ActsLike() ipld.Kind {
 		return ipld.Kind_Map // REVIEW: unions are tricky.
 	case TypeKind_Enum:
 		return ipld.Kind_String // 'AsString' is the one clear thing to define.
+	case TypeKind_Any:
+		return ipld.Kind_Invalid // varies with the data; you have to look at the value.
 	default:
 		panic("invalid enumeration value!")
 	}
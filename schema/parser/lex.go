@@ -0,0 +1,154 @@
+package schemaparser
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind uint8
+
+const (
+	tokenEOF    tokenKind = iota
+	tokenWord             // type names, field names, and keywords.
+	tokenString           // a double-quoted string; the token's text is unquoted.
+	tokenPunct            // one of the characters in punctChars.
+)
+
+const punctChars = "{}[]:|&()"
+
+type token struct {
+	kind tokenKind
+	text string
+	line int
+	col  int
+	doc  string // the comment lines directly above the token, if any.
+}
+
+func (tok token) String() string {
+	if tok.kind == tokenEOF {
+		return "end of input"
+	}
+	return strconv.Quote(tok.text)
+}
+
+// lexer splits the schema DSL into tokens.
+// Whitespace and comments, which run from a '#' to the end of the line,
+// are skipped.
+//
+// Comments on lines of their own, directly above a token,
+// are kept as the token's doc; that's how types and fields are documented.
+// A blank line between the comment and the token, or a comment which follows
+// another token on the same line, doesn't count.
+type lexer struct {
+	src  string
+	off  int
+	line int
+	col  int
+
+	lastLine int      // the line of the last token.
+	doc      []string // the comment lines being gathered for the next token.
+	docLine  int      // the line of the last comment in doc.
+}
+
+func (lx *lexer) next() (token, error) {
+	for lx.off < len(lx.src) {
+		r, size := utf8.DecodeRuneInString(lx.src[lx.off:])
+		switch {
+		case r == '#':
+			start := lx.off
+			for lx.off < len(lx.src) && lx.src[lx.off] != '\n' {
+				lx.advance(1)
+			}
+			lx.comment(lx.src[start:lx.off])
+		case unicode.IsSpace(r):
+			lx.advance(size)
+		default:
+			tok, err := lx.token(r, size)
+			if len(lx.doc) > 0 && lx.docLine == tok.line-1 {
+				tok.doc = strings.Join(lx.doc, "\n")
+			}
+			lx.doc = nil
+			lx.lastLine = tok.line
+			return tok, err
+		}
+	}
+	return token{kind: tokenEOF, line: lx.line, col: lx.col}, nil
+}
+
+// comment gathers a comment, which starts on the current line,
+// for the doc of the next token.
+func (lx *lexer) comment(text string) {
+	if lx.line == lx.lastLine {
+		return // it follows a token.
+	}
+	if len(lx.doc) > 0 && lx.docLine != lx.line-1 {
+		lx.doc = nil // there was a blank line since the last one.
+	}
+	text = strings.TrimLeft(text, "#")
+	text = strings.TrimPrefix(text, " ")
+	lx.doc = append(lx.doc, strings.TrimRightFunc(text, unicode.IsSpace))
+	lx.docLine = lx.line
+}
+
+func (lx *lexer) token(r rune, size int) (token, error) {
+	tok := token{line: lx.line, col: lx.col}
+	switch {
+	case strings.ContainsRune(punctChars, r):
+		tok.kind = tokenPunct
+		tok.text = string(r)
+		lx.advance(size)
+	case r == '"':
+		end := lx.off + 1
+		for ; end < len(lx.src); end++ {
+			if c := lx.src[end]; c == '\\' {
+				end++
+			} else if c == '"' || c == '\n' {
+				break
+			}
+		}
+		if end >= len(lx.src) || lx.src[end] != '"' {
+			return tok, &Error{tok.line, tok.col, "unterminated string"}
+		}
+		s, err := strconv.Unquote(lx.src[lx.off : end+1])
+		if err != nil {
+			return tok, &Error{tok.line, tok.col, "invalid string: " + err.Error()}
+		}
+		tok.kind = tokenString
+		tok.text = s
+		lx.advance(end + 1 - lx.off)
+	case isWordRune(r):
+		end := lx.off
+		for end < len(lx.src) {
+			r, size := utf8.DecodeRuneInString(lx.src[end:])
+			if !isWordRune(r) {
+				break
+			}
+			end += size
+		}
+		tok.kind = tokenWord
+		tok.text = lx.src[lx.off:end]
+		lx.advance(end - lx.off)
+	default:
+		return tok, &Error{tok.line, tok.col, "unexpected character " + strconv.QuoteRune(r)}
+	}
+	return tok, nil
+}
+
+// advance moves forward by n bytes, keeping track of the line and column.
+func (lx *lexer) advance(n int) {
+	for _, c := range lx.src[lx.off : lx.off+n] {
+		if c == '\n' {
+			lx.line++
+			lx.col = 1
+		} else {
+			lx.col++
+		}
+	}
+	lx.off += n
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,826 @@
+// Package schemaparser reads schemas written in the IPLD Schema DSL,
+// the textual language for schemas often kept in .ipldsch files,
+// and produces a schema.TypeSystem from them.
+//
+// For example:
+//
+//		type Person struct {
+//			name String
+//			nick optional String (rename "nickname")
+//			friends [&Person]
+//		}
+//
+// Inline type definitions, such as the list of links above, become types of
+// their own, named after their contents as elsewhere in this module:
+// "List__Link__Person" and "Link__Person" in this case.
+// Types from the prelude, such as String or Int, are added as needed.
+//
+// Only the parts of the language which schema.TypeSystem can currently hold
+// are supported; others, such as copy types,
+// are rejected with an error.
+// One small extension is needed for stringprefix unions, whose delimiter is
+// written like a stringjoin struct's: `representation stringprefix {delim ":"}`.
+//
+// Comments directly above a type or a struct field, with no blank line between,
+// document it; see schema.Type's Doc method.
+package schemaparser
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// Error is the type of the errors returned by Parse,
+// with the position in the input which they relate to.
+type Error struct {
+	Line, Column int
+	Msg          string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Msg)
+}
+
+// Parse reads a schema in the IPLD Schema DSL from r,
+// and returns the types it defines as a TypeSystem.
+// All the types referred to must be defined in the schema, or in the prelude.
+func Parse(r io.Reader) (*schema.TypeSystem, error) {
+	src, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{
+		lx:      lexer{src: string(src), line: 1, col: 1},
+		defined: make(map[schema.TypeName]bool),
+	}
+	if err := p.parseSchema(); err != nil {
+		return nil, err
+	}
+	ts := &schema.TypeSystem{}
+	ts.Init()
+	for _, typ := range p.types {
+		ts.Accumulate(typ)
+	}
+	return ts, nil
+}
+
+// prelude holds the types which schemas may use without defining them.
+var prelude = map[schema.TypeName]func(schema.TypeName) schema.Type{
+	"Bool":   func(n schema.TypeName) schema.Type { return schema.SpawnBool(n) },
+	"String": func(n schema.TypeName) schema.Type { return schema.SpawnString(n) },
+	"Bytes":  func(n schema.TypeName) schema.Type { return schema.SpawnBytes(n) },
+	"Int":    func(n schema.TypeName) schema.Type { return schema.SpawnInt(n) },
+	"Float":  func(n schema.TypeName) schema.Type { return schema.SpawnFloat(n) },
+	"Link":   func(n schema.TypeName) schema.Type { return schema.SpawnLink(n) },
+	"Any":    func(n schema.TypeName) schema.Type { return schema.SpawnAny(n) },
+}
+
+var representationKinds = map[string]ipld.Kind{
+	"map":    ipld.Kind_Map,
+	"list":   ipld.Kind_List,
+	"string": ipld.Kind_String,
+	"bytes":  ipld.Kind_Bytes,
+	"int":    ipld.Kind_Int,
+	"float":  ipld.Kind_Float,
+	"bool":   ipld.Kind_Bool,
+	"link":   ipld.Kind_Link,
+}
+
+type parser struct {
+	lx  lexer
+	tok token // the current token, not yet consumed.
+
+	types     []schema.Type
+	defined   map[schema.TypeName]bool
+	refs      []reference
+	implicits []implicit
+}
+
+// reference records where a type name was used, so that it can be checked
+// once all the types have been defined.
+type reference struct {
+	name schema.TypeName
+	tok  token
+}
+
+// implicit records the implicit value of a struct field as written.
+// How the value is read depends on the kind of the field's type,
+// so it can only be added to its struct's table once all the types have been
+// defined; for example, `(implicit "1")` is an int if the field's type is Int.
+type implicit struct {
+	table map[string]schema.ImplicitValue
+	field string
+	typ   schema.TypeName
+	value string
+	tok   token
+}
+
+func (p *parser) errorf(tok token, format string, args ...interface{}) error {
+	return &Error{tok.line, tok.col, fmt.Sprintf(format, args...)}
+}
+
+// advance consumes the current token, and reads the next one.
+func (p *parser) advance() error {
+	tok, err := p.lx.next()
+	p.tok = tok
+	return err
+}
+
+func (p *parser) isWord(text string) bool {
+	return p.tok.kind == tokenWord && p.tok.text == text
+}
+
+func (p *parser) isPunct(text string) bool {
+	return p.tok.kind == tokenPunct && p.tok.text == text
+}
+
+// acceptWord consumes the current token if it's the given word.
+func (p *parser) acceptWord(text string) (bool, error) {
+	if !p.isWord(text) {
+		return false, nil
+	}
+	return true, p.advance()
+}
+
+func (p *parser) expectWord(text string) error {
+	if !p.isWord(text) {
+		return p.errorf(p.tok, "expected %q, got %s", text, p.tok)
+	}
+	return p.advance()
+}
+
+func (p *parser) expectPunct(text string) error {
+	if !p.isPunct(text) {
+		return p.errorf(p.tok, "expected %q, got %s", text, p.tok)
+	}
+	return p.advance()
+}
+
+func (p *parser) expectString() (string, error) {
+	if p.tok.kind != tokenString {
+		return "", p.errorf(p.tok, "expected a string, got %s", p.tok)
+	}
+	s := p.tok.text
+	return s, p.advance()
+}
+
+// name consumes a word, such as a type or field name.
+func (p *parser) name() (string, token, error) {
+	tok := p.tok
+	if tok.kind != tokenWord {
+		return "", tok, p.errorf(tok, "expected a name, got %s", tok)
+	}
+	return tok.text, tok, p.advance()
+}
+
+// add adds a type to the result; inline types may be added more than once.
+func (p *parser) add(typ schema.Type) {
+	if p.defined[typ.Name()] {
+		return
+	}
+	p.defined[typ.Name()] = true
+	p.types = append(p.types, typ)
+}
+
+func (p *parser) parseSchema() error {
+	if err := p.advance(); err != nil {
+		return err
+	}
+	for p.tok.kind != tokenEOF {
+		doc := p.tok.doc
+		if err := p.expectWord("type"); err != nil {
+			return err
+		}
+		name, tok, err := p.name()
+		if err != nil {
+			return err
+		}
+		if p.defined[schema.TypeName(name)] {
+			return p.errorf(tok, "type %s is defined more than once", name)
+		}
+		typ, err := p.parseTypeDefn(schema.TypeName(name))
+		if err != nil {
+			return err
+		}
+		if doc != "" {
+			schema.SpawnDoc(typ, doc)
+		}
+		p.add(typ)
+	}
+	// Check the references only now, as types may be used before they're defined.
+	for _, ref := range p.refs {
+		if p.defined[ref.name] {
+			continue
+		}
+		spawn, ok := prelude[ref.name]
+		if !ok {
+			return p.errorf(ref.tok, "type %s is not defined", ref.name)
+		}
+		p.add(spawn(ref.name))
+	}
+	for _, iv := range p.implicits {
+		if err := p.resolveImplicit(iv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveImplicit reads an implicit value according to the kind of its
+// field's type, and adds it to its struct's table.
+func (p *parser) resolveImplicit(iv implicit) error {
+	var kind schema.TypeKind
+	for _, typ := range p.types {
+		if typ.Name() == iv.typ {
+			kind = typ.TypeKind()
+			break
+		}
+	}
+	switch kind {
+	case schema.TypeKind_String:
+		iv.table[iv.field] = schema.SpawnImplicitValueString(iv.value)
+	case schema.TypeKind_Int:
+		i, err := strconv.Atoi(iv.value)
+		if err != nil {
+			return p.errorf(iv.tok, "the implicit value for field %s must be an int, got %q", iv.field, iv.value)
+		}
+		iv.table[iv.field] = schema.SpawnImplicitValueInt(i)
+	case schema.TypeKind_Bool:
+		if iv.value != "true" && iv.value != "false" {
+			return p.errorf(iv.tok, "the implicit value for field %s must be true or false, got %q", iv.field, iv.value)
+		}
+		iv.table[iv.field] = schema.SpawnImplicitValueBool(iv.value == "true")
+	default:
+		return p.errorf(iv.tok, "field %s has type %s, which is of kind %s; implicit values are only supported for kinds String, Int, and Bool", iv.field, iv.typ, kind)
+	}
+	return nil
+}
+
+func (p *parser) parseTypeDefn(name schema.TypeName) (schema.Type, error) {
+	tok := p.tok
+	switch {
+	case p.isPunct("&"):
+		target, err := p.parseLinkTarget()
+		if err != nil {
+			return nil, err
+		}
+		if target == "" {
+			return schema.SpawnLink(name), nil
+		}
+		return schema.SpawnLinkReference(name, target), nil
+	case p.isPunct("["):
+		valueType, nullable, err := p.parseListBody()
+		if err != nil {
+			return nil, err
+		}
+		return schema.SpawnList(name, valueType, nullable), p.parsePlainRepresentation("list")
+	case p.isPunct("{"):
+		keyType, valueType, nullable, err := p.parseMapBody()
+		if err != nil {
+			return nil, err
+		}
+		repr, err := p.parseMapRepresentation()
+		if err != nil {
+			return nil, err
+		}
+		return schema.SpawnMapWithRepresentation(name, keyType, valueType, nullable, repr), nil
+	case tok.kind != tokenWord:
+		return nil, p.errorf(tok, "expected a type definition, got %s", tok)
+	}
+	var typ schema.Type
+	switch tok.text {
+	case "bool":
+		typ = schema.SpawnBool(name)
+	case "string":
+		typ = schema.SpawnString(name)
+	case "bytes":
+		typ = schema.SpawnBytes(name)
+	case "int":
+		typ = schema.SpawnInt(name)
+	case "float":
+		typ = schema.SpawnFloat(name)
+	case "link":
+		typ = schema.SpawnLink(name)
+	case "any":
+		typ = schema.SpawnAny(name)
+	case "unit":
+		return p.parseUnit(name)
+	case "struct":
+		return p.parseStruct(name)
+	case "union":
+		return p.parseUnion(name)
+	case "enum":
+		return p.parseEnum(name)
+	default:
+		return nil, p.errorf(tok, "unsupported type definition %s; copying another type is not supported", tok)
+	}
+	return typ, p.advance()
+}
+
+// parsePlainRepresentation parses the representation clause for types
+// which only have one representation strategy, such as lists.
+func (p *parser) parsePlainRepresentation(only string) error {
+	if ok, err := p.acceptWord("representation"); !ok || err != nil {
+		return err
+	}
+	if !p.isWord(only) {
+		return p.errorf(p.tok, "unsupported representation %s; only %s is supported", p.tok, only)
+	}
+	return p.advance()
+}
+
+// parseMapRepresentation parses the representation clause for maps,
+// which defaults to the map representation if there's none.
+func (p *parser) parseMapRepresentation() (schema.MapRepresentation, error) {
+	if ok, err := p.acceptWord("representation"); !ok || err != nil {
+		return schema.SpawnMapRepresentationMap(), err
+	}
+	tok := p.tok
+	switch {
+	case p.isWord("map"):
+		return schema.SpawnMapRepresentationMap(), p.advance()
+	case p.isWord("listpairs"):
+		return schema.SpawnMapRepresentationListPairs(), p.advance()
+	}
+	return nil, p.errorf(tok, "unsupported map representation %s", tok)
+}
+
+// parseUnit parses a unit type, which has no body,
+// but must always say how it's represented.
+func (p *parser) parseUnit(name schema.TypeName) (schema.Type, error) {
+	if err := p.expectWord("unit"); err != nil {
+		return nil, err
+	}
+	if err := p.expectWord("representation"); err != nil {
+		return nil, err
+	}
+	tok := p.tok
+	switch {
+	case p.isWord("null"):
+		return schema.SpawnUnit(name, schema.SpawnUnitRepresentationNull()), p.advance()
+	case p.isWord("emptymap"):
+		return schema.SpawnUnit(name, schema.SpawnUnitRepresentationEmptyMap()), p.advance()
+	}
+	return nil, p.errorf(tok, "unsupported unit representation %s", tok)
+}
+
+// parseTypeRef parses a reference to a type, where one is used in another
+// type's definition; inline definitions are given a name, and added.
+func (p *parser) parseTypeRef() (schema.TypeName, error) {
+	switch {
+	case p.tok.kind == tokenWord:
+		name := schema.TypeName(p.tok.text)
+		p.refs = append(p.refs, reference{name, p.tok})
+		return name, p.advance()
+	case p.isPunct("&"):
+		target, err := p.parseLinkTarget()
+		if err != nil {
+			return "", err
+		}
+		if target == "" {
+			p.add(schema.SpawnLink("Link"))
+			return "Link", nil
+		}
+		name := "Link__" + target
+		p.add(schema.SpawnLinkReference(name, target))
+		return name, nil
+	case p.isPunct("["):
+		valueType, nullable, err := p.parseListBody()
+		if err != nil {
+			return "", err
+		}
+		name := "List__" + nullablePrefix(nullable) + valueType
+		p.add(schema.SpawnList(name, valueType, nullable))
+		return name, nil
+	case p.isPunct("{"):
+		keyType, valueType, nullable, err := p.parseMapBody()
+		if err != nil {
+			return "", err
+		}
+		name := "Map__" + keyType + "__" + nullablePrefix(nullable) + valueType
+		p.add(schema.SpawnMap(name, keyType, valueType, nullable))
+		return name, nil
+	default:
+		return "", p.errorf(p.tok, "expected a type, got %s", p.tok)
+	}
+}
+
+func nullablePrefix(nullable bool) schema.TypeName {
+	if nullable {
+		return "nullable"
+	}
+	return ""
+}
+
+// parseLinkTarget parses "&Target", returning "" for "&Any".
+func (p *parser) parseLinkTarget() (schema.TypeName, error) {
+	if err := p.expectPunct("&"); err != nil {
+		return "", err
+	}
+	name, tok, err := p.name()
+	if err != nil {
+		return "", err
+	}
+	if name == "Any" {
+		return "", nil
+	}
+	p.refs = append(p.refs, reference{schema.TypeName(name), tok})
+	return schema.TypeName(name), nil
+}
+
+func (p *parser) parseListBody() (valueType schema.TypeName, nullable bool, err error) {
+	if err := p.expectPunct("["); err != nil {
+		return "", false, err
+	}
+	if nullable, err = p.acceptWord("nullable"); err != nil {
+		return "", false, err
+	}
+	if valueType, err = p.parseTypeRef(); err != nil {
+		return "", false, err
+	}
+	return valueType, nullable, p.expectPunct("]")
+}
+
+func (p *parser) parseMapBody() (keyType, valueType schema.TypeName, nullable bool, err error) {
+	if err := p.expectPunct("{"); err != nil {
+		return "", "", false, err
+	}
+	if keyType, err = p.parseTypeRef(); err != nil {
+		return "", "", false, err
+	}
+	if err := p.expectPunct(":"); err != nil {
+		return "", "", false, err
+	}
+	if nullable, err = p.acceptWord("nullable"); err != nil {
+		return "", "", false, err
+	}
+	if valueType, err = p.parseTypeRef(); err != nil {
+		return "", "", false, err
+	}
+	return keyType, valueType, nullable, p.expectPunct("}")
+}
+
+func (p *parser) parseStruct(name schema.TypeName) (schema.Type, error) {
+	if err := p.expectWord("struct"); err != nil {
+		return nil, err
+	}
+	if err := p.expectPunct("{"); err != nil {
+		return nil, err
+	}
+	var fields []schema.StructField
+	seen := make(map[string]bool)
+	renames := make(map[string]string)
+	implicits := make(map[string]schema.ImplicitValue)
+	hasImplicits := false // the implicits map is only filled in once all types are defined.
+	for !p.isPunct("}") {
+		fieldName, tok, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if seen[fieldName] {
+			return nil, p.errorf(tok, "field %s is defined more than once", fieldName)
+		}
+		seen[fieldName] = true
+		var optional, nullable bool
+		for {
+			if ok, err := p.acceptWord("optional"); err != nil {
+				return nil, err
+			} else if ok {
+				optional = true
+				continue
+			}
+			if ok, err := p.acceptWord("nullable"); err != nil {
+				return nil, err
+			} else if ok {
+				nullable = true
+				continue
+			}
+			break
+		}
+		typ, err := p.parseTypeRef()
+		if err != nil {
+			return nil, err
+		}
+		if p.isPunct("(") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			for !p.isPunct(")") {
+				optTok := p.tok
+				switch {
+				case p.isWord("rename"):
+					if err := p.advance(); err != nil {
+						return nil, err
+					}
+					if renames[fieldName], err = p.expectString(); err != nil {
+						return nil, err
+					}
+				case p.isWord("implicit"):
+					if optional {
+						return nil, p.errorf(optTok, "field %s cannot be optional and have an implicit value", fieldName)
+					}
+					if err := p.advance(); err != nil {
+						return nil, err
+					}
+					valueTok := p.tok
+					value, err := p.expectString()
+					if err != nil {
+						return nil, err
+					}
+					p.implicits = append(p.implicits, implicit{implicits, fieldName, typ, value, valueTok})
+					hasImplicits = true
+				default:
+					return nil, p.errorf(optTok, "unsupported field option %s", optTok)
+				}
+			}
+			if err := p.advance(); err != nil { // the closing paren
+				return nil, err
+			}
+		}
+		field := schema.SpawnStructField(fieldName, typ, optional, nullable)
+		if tok.doc != "" {
+			field = schema.SpawnStructFieldDoc(field, tok.doc)
+		}
+		fields = append(fields, field)
+	}
+	if err := p.advance(); err != nil { // the closing brace
+		return nil, err
+	}
+
+	var repr schema.StructRepresentation = schema.SpawnStructRepresentationMapWithImplicits(renames, implicits)
+	if ok, err := p.acceptWord("representation"); err != nil {
+		return nil, err
+	} else if ok {
+		tok := p.tok
+		if tok.kind != tokenWord {
+			return nil, p.errorf(tok, "expected a representation strategy, got %s", tok)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if tok.text != "map" && len(renames) > 0 {
+			return nil, p.errorf(tok, "field renames are only supported with the map representation")
+		}
+		if tok.text != "map" && hasImplicits {
+			return nil, p.errorf(tok, "implicit values are only supported with the map representation")
+		}
+		switch tok.text {
+		case "map":
+		case "tuple":
+			repr = schema.SpawnStructRepresentationTuple()
+		case "stringjoin":
+			params, err := p.parseRepresentationParams("join")
+			if err != nil {
+				return nil, err
+			}
+			for _, f := range fields {
+				if f.IsMaybe() {
+					return nil, p.errorf(tok, "field %s cannot be optional or nullable with the stringjoin representation", f.Name())
+				}
+			}
+			repr = schema.SpawnStructRepresentationStringjoin(params[0])
+		case "stringpairs":
+			params, err := p.parseRepresentationParams("innerDelim", "entryDelim")
+			if err != nil {
+				return nil, err
+			}
+			for _, f := range fields {
+				if f.IsNullable() {
+					return nil, p.errorf(tok, "field %s cannot be nullable with the stringpairs representation", f.Name())
+				}
+			}
+			repr = schema.SpawnStructRepresentationStringPairs(params[0], params[1])
+		case "listpairs":
+			repr = schema.SpawnStructRepresentationListPairs()
+		default:
+			return nil, p.errorf(tok, "unsupported struct representation %s", tok)
+		}
+	}
+	return schema.SpawnStruct(name, fields, repr), nil
+}
+
+// parseRepresentationParams parses a block like `{join ":"}`,
+// with the given parameters in order, each of which takes a string.
+func (p *parser) parseRepresentationParams(params ...string) ([]string, error) {
+	if err := p.expectPunct("{"); err != nil {
+		return nil, err
+	}
+	values := make([]string, len(params))
+	for i, param := range params {
+		if err := p.expectWord(param); err != nil {
+			return nil, err
+		}
+		s, err := p.expectString()
+		if err != nil {
+			return nil, err
+		}
+		values[i] = s
+	}
+	return values, p.expectPunct("}")
+}
+
+func (p *parser) parseUnion(name schema.TypeName) (schema.Type, error) {
+	if err := p.expectWord("union"); err != nil {
+		return nil, err
+	}
+	if err := p.expectPunct("{"); err != nil {
+		return nil, err
+	}
+	// The meaning of the discriminants depends on the representation,
+	// which comes after the members; so keep their tokens until then.
+	var members []schema.TypeName
+	var discriminants []token
+	for !p.isPunct("}") {
+		if err := p.expectPunct("|"); err != nil {
+			return nil, err
+		}
+		member, tok, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range members {
+			if m == schema.TypeName(member) {
+				return nil, p.errorf(tok, "member %s is listed more than once", member)
+			}
+		}
+		p.refs = append(p.refs, reference{schema.TypeName(member), tok})
+		members = append(members, schema.TypeName(member))
+		if p.tok.kind != tokenString && p.tok.kind != tokenWord {
+			return nil, p.errorf(p.tok, "expected a discriminant for member %s, got %s", member, p.tok)
+		}
+		discriminants = append(discriminants, p.tok)
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.advance(); err != nil { // the closing brace
+		return nil, err
+	}
+
+	if err := p.expectWord("representation"); err != nil {
+		return nil, err
+	}
+	tok := p.tok
+	if tok.kind != tokenWord {
+		return nil, p.errorf(tok, "expected a representation strategy, got %s", tok)
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	var repr schema.UnionRepresentation
+	switch tok.text {
+	case "keyed", "stringprefix", "envelope", "inline":
+		table := make(map[string]schema.TypeName, len(members))
+		for i, d := range discriminants {
+			if d.kind != tokenString {
+				return nil, p.errorf(d, "the discriminant for member %s must be a string, got %s", members[i], d)
+			}
+			if _, ok := table[d.text]; ok {
+				return nil, p.errorf(d, "discriminant %s is used more than once", d)
+			}
+			table[d.text] = members[i]
+		}
+		switch tok.text {
+		case "keyed":
+			repr = schema.SpawnUnionRepresentationKeyed(table)
+		case "stringprefix":
+			params, err := p.parseRepresentationParams("delim")
+			if err != nil {
+				return nil, err
+			}
+			repr = schema.SpawnUnionRepresentationStringprefix(params[0], table)
+		case "envelope":
+			params, err := p.parseRepresentationParams("discriminantKey", "contentKey")
+			if err != nil {
+				return nil, err
+			}
+			repr = schema.SpawnUnionRepresentationEnvelope(params[0], params[1], table)
+		case "inline":
+			params, err := p.parseRepresentationParams("discriminantKey")
+			if err != nil {
+				return nil, err
+			}
+			repr = schema.SpawnUnionRepresentationInline(params[0], table)
+		}
+	case "kinded":
+		table := make(map[ipld.Kind]schema.TypeName, len(members))
+		for i, d := range discriminants {
+			k, ok := representationKinds[d.text]
+			if d.kind != tokenWord || !ok {
+				return nil, p.errorf(d, "the discriminant for member %s must be a kind, got %s", members[i], d)
+			}
+			if _, ok := table[k]; ok {
+				return nil, p.errorf(d, "kind %s is used more than once", d.text)
+			}
+			table[k] = members[i]
+		}
+		repr = schema.SpawnUnionRepresentationKinded(table)
+	default:
+		return nil, p.errorf(tok, "unsupported union representation %s", tok)
+	}
+	return schema.SpawnUnion(name, members, repr), nil
+}
+
+func (p *parser) parseEnum(name schema.TypeName) (schema.Type, error) {
+	if err := p.expectWord("enum"); err != nil {
+		return nil, err
+	}
+	if err := p.expectPunct("{"); err != nil {
+		return nil, err
+	}
+	// As with unions, the meaning of the representation values depends on
+	// the representation strategy, which comes after the members.
+	var members []string
+	memberToks := make(map[string]token)
+	serials := make(map[string]token)
+	for !p.isPunct("}") {
+		if err := p.expectPunct("|"); err != nil {
+			return nil, err
+		}
+		member, tok, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range members {
+			if m == member {
+				return nil, p.errorf(tok, "member %s is listed more than once", member)
+			}
+		}
+		members = append(members, member)
+		memberToks[member] = tok
+		if p.isPunct("(") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if p.tok.kind != tokenString {
+				return nil, p.errorf(p.tok, "expected a representation value for member %s, got %s", member, p.tok)
+			}
+			serials[member] = p.tok
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if err := p.expectPunct(")"); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := p.advance(); err != nil { // the closing brace
+		return nil, err
+	}
+
+	strategy := "string"
+	if ok, err := p.acceptWord("representation"); err != nil {
+		return nil, err
+	} else if ok {
+		strategy = p.tok.text
+		if p.tok.kind != tokenWord || (strategy != "string" && strategy != "int") {
+			return nil, p.errorf(p.tok, "unsupported enum representation %s", p.tok)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	var repr schema.EnumRepresentation
+	switch strategy {
+	case "string":
+		table := make(map[string]string, len(serials))
+		seen := make(map[string]bool, len(members))
+		for _, m := range members {
+			s, tok := m, memberToks[m]
+			if serial, ok := serials[m]; ok {
+				s, tok = serial.text, serial
+				table[m] = s
+			}
+			if seen[s] {
+				return nil, p.errorf(tok, "representation value %q is used more than once", s)
+			}
+			seen[s] = true
+		}
+		repr = schema.SpawnEnumRepresentationString(table)
+	case "int":
+		table := make(map[string]int, len(members))
+		seen := make(map[int]bool, len(members))
+		for _, m := range members {
+			tok, ok := serials[m]
+			if !ok {
+				return nil, p.errorf(memberToks[m], "member %s needs an int representation value", m)
+			}
+			i, err := strconv.Atoi(tok.text)
+			if err != nil {
+				return nil, p.errorf(tok, "the representation value for member %s must be an int, got %q", m, tok.text)
+			}
+			if seen[i] {
+				return nil, p.errorf(tok, "representation value %d is used more than once", i)
+			}
+			seen[i] = true
+			table[m] = i
+		}
+		repr = schema.SpawnEnumRepresentationInt(table)
+	}
+	return schema.SpawnEnumWithRepresentation(name, members, repr), nil
+}
@@ -0,0 +1,273 @@
+package schemaparser_test
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/schema"
+	schemaparser "github.com/ipld/go-ipld-prime/schema/parser"
+)
+
+func TestParse(t *testing.T) {
+	ts, err := schemaparser.Parse(strings.NewReader(`
+# A schema using most of what's supported.
+type Person struct {
+	name String
+	nick optional nullable String (rename "nickname")
+	friends [&Person]
+	tags {String:nullable Int}
+	mood Mood
+}
+
+type Mood enum {
+	| Happy
+	| Grumpy ("grumpy")
+}
+
+type Level enum {
+	| Low ("1")
+	| High ("10")
+} representation int
+
+type Pair struct {
+	left Int
+	right Int
+} representation tuple
+
+type Coords struct {
+	x String
+	y String
+} representation stringjoin {join ","}
+
+type Query struct {
+	q String
+	lang optional String
+} representation stringpairs {
+	innerDelim "="
+	entryDelim "&"
+}
+
+type Header struct {
+	name String
+	value optional String
+} representation listpairs
+
+type Headers {String:Header} representation listpairs
+
+type Shape union {
+	| Pair "pair"
+	| Coords "coords"
+} representation keyed
+
+type Value union {
+	| String string
+	| Int int
+	| Pair list
+} representation kinded
+
+type Prefixed union {
+	| String "str"
+	| Coords "coords"
+} representation stringprefix {delim ":"}
+
+type Message union {
+	| String "text"
+	| Pair "pair"
+} representation envelope {
+	discriminantKey "type"
+	contentKey "value"
+}
+
+type Pet struct {
+	name String
+	species String (implicit "cat")
+	age Int (implicit "0")
+	indoor Bool (rename "in" implicit "true")
+}
+
+type Event union {
+	| Person "person"
+	| Pet "pet"
+} representation inline {
+	discriminantKey "kind"
+}
+
+type AnyLink &Any
+type Bools [nullable Bool]
+
+type Nothing unit representation null
+type Empty unit representation emptymap
+type Blob any
+type Meta {String:Any}
+`))
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, ts.ValidateGraph(), ShouldEqual, []error(nil))
+
+	var names []string
+	for name := range ts.GetTypes() {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	Wish(t, names, ShouldEqual, []string{
+		"Any", "AnyLink", "Blob", "Bool", "Bools", "Coords", "Empty", "Event", "Header", "Headers", "Int", "Level",
+		"Link__Person", "List__Link__Person", "Map__String__nullableInt",
+		"Message", "Meta", "Mood", "Nothing", "Pair", "Person", "Pet", "Prefixed", "Query", "Shape", "String", "Value",
+	})
+
+	person := ts.TypeByName("Person").(*schema.TypeStruct)
+	fields := person.Fields()
+	Wish(t, len(fields), ShouldEqual, 5)
+	Wish(t, fields[1].Name(), ShouldEqual, "nick")
+	Wish(t, fields[1].IsOptional(), ShouldEqual, true)
+	Wish(t, fields[1].IsNullable(), ShouldEqual, true)
+	Wish(t, fields[1].Type().Name(), ShouldEqual, schema.TypeName("String"))
+	repr := person.RepresentationStrategy().(schema.StructRepresentation_Map)
+	Wish(t, repr.GetFieldKey(fields[1]), ShouldEqual, "nickname")
+	Wish(t, repr.GetFieldKey(fields[0]), ShouldEqual, "name")
+
+	friends := fields[2].Type().(*schema.TypeList)
+	Wish(t, friends.Name(), ShouldEqual, schema.TypeName("List__Link__Person"))
+	friend := friends.ValueType().(*schema.TypeLink)
+	Wish(t, friend.HasReferencedType(), ShouldEqual, true)
+	Wish(t, friend.ReferencedType().Name(), ShouldEqual, schema.TypeName("Person"))
+
+	tags := fields[3].Type().(*schema.TypeMap)
+	Wish(t, tags.Name(), ShouldEqual, schema.TypeName("Map__String__nullableInt"))
+	Wish(t, tags.ValueIsNullable(), ShouldEqual, true)
+	Wish(t, tags.ValueType().TypeKind(), ShouldEqual, schema.TypeKind_Int)
+
+	mood := ts.TypeByName("Mood").(*schema.TypeEnum)
+	Wish(t, mood.Members(), ShouldEqual, []string{"Happy", "Grumpy"})
+	Wish(t, mood.RepresentationBehavior(), ShouldEqual, ipld.Kind_String)
+	moodRepr := mood.RepresentationStrategy().(schema.EnumRepresentation_String)
+	Wish(t, moodRepr.GetSerial("Happy"), ShouldEqual, "Happy")
+	Wish(t, moodRepr.GetSerial("Grumpy"), ShouldEqual, "grumpy")
+	level := ts.TypeByName("Level").(*schema.TypeEnum)
+	Wish(t, level.RepresentationBehavior(), ShouldEqual, ipld.Kind_Int)
+	Wish(t, level.RepresentationStrategy(), ShouldEqual, schema.SpawnEnumRepresentationInt(map[string]int{"Low": 1, "High": 10}))
+	Wish(t, ts.TypeByName("Pair").(*schema.TypeStruct).RepresentationStrategy(), ShouldEqual, schema.SpawnStructRepresentationTuple())
+	Wish(t, ts.TypeByName("Coords").(*schema.TypeStruct).RepresentationStrategy(), ShouldEqual, schema.SpawnStructRepresentationStringjoin(","))
+	query := ts.TypeByName("Query").(*schema.TypeStruct).RepresentationStrategy().(schema.StructRepresentation_StringPairs)
+	Wish(t, query.GetInnerDelim(), ShouldEqual, "=")
+	Wish(t, query.GetEntryDelim(), ShouldEqual, "&")
+	Wish(t, ts.TypeByName("Header").(*schema.TypeStruct).RepresentationStrategy(), ShouldEqual, schema.SpawnStructRepresentationListPairs())
+	Wish(t, ts.TypeByName("Headers").(*schema.TypeMap).RepresentationStrategy(), ShouldEqual, schema.SpawnMapRepresentationListPairs())
+	Wish(t, tags.RepresentationStrategy(), ShouldEqual, schema.SpawnMapRepresentationMap())
+
+	shape := ts.TypeByName("Shape").(*schema.TypeUnion)
+	Wish(t, shape.RepresentationStrategy().(schema.UnionRepresentation_Keyed).GetDiscriminant(ts.TypeByName("Coords")), ShouldEqual, "coords")
+	value := ts.TypeByName("Value").(*schema.TypeUnion)
+	Wish(t, value.RepresentationStrategy().(schema.UnionRepresentation_Kinded).GetMember(ipld.Kind_List), ShouldEqual, schema.TypeName("Pair"))
+	prefixed := ts.TypeByName("Prefixed").(*schema.TypeUnion).RepresentationStrategy().(schema.UnionRepresentation_Stringprefix)
+	Wish(t, prefixed.GetDelim(), ShouldEqual, ":")
+	Wish(t, prefixed.GetDiscriminant(ts.TypeByName("String")), ShouldEqual, "str")
+	message := ts.TypeByName("Message").(*schema.TypeUnion).RepresentationStrategy().(schema.UnionRepresentation_Envelope)
+	Wish(t, message.GetDiscriminantKey(), ShouldEqual, "type")
+	Wish(t, message.GetContentKey(), ShouldEqual, "value")
+	Wish(t, message.GetDiscriminant(ts.TypeByName("Pair")), ShouldEqual, "pair")
+	event := ts.TypeByName("Event").(*schema.TypeUnion).RepresentationStrategy().(schema.UnionRepresentation_Inline)
+	Wish(t, event.GetDiscriminantKey(), ShouldEqual, "kind")
+	Wish(t, event.GetDiscriminant(ts.TypeByName("Person")), ShouldEqual, "person")
+
+	pet := ts.TypeByName("Pet").(*schema.TypeStruct)
+	petRepr := pet.RepresentationStrategy().(schema.StructRepresentation_Map)
+	Wish(t, petRepr.GetImplicit(*pet.Field("name")), ShouldEqual, nil)
+	Wish(t, petRepr.GetImplicit(*pet.Field("species")), ShouldEqual, schema.SpawnImplicitValueString("cat"))
+	Wish(t, petRepr.GetImplicit(*pet.Field("age")), ShouldEqual, schema.SpawnImplicitValueInt(0))
+	Wish(t, petRepr.GetImplicit(*pet.Field("indoor")), ShouldEqual, schema.SpawnImplicitValueBool(true))
+	Wish(t, petRepr.GetFieldKey(*pet.Field("indoor")), ShouldEqual, "in")
+
+	Wish(t, ts.TypeByName("AnyLink").(*schema.TypeLink).HasReferencedType(), ShouldEqual, false)
+	Wish(t, ts.TypeByName("Bools").(*schema.TypeList).ValueIsNullable(), ShouldEqual, true)
+
+	Wish(t, ts.TypeByName("Nothing").(*schema.TypeUnit).RepresentationStrategy(), ShouldEqual, schema.SpawnUnitRepresentationNull())
+	Wish(t, ts.TypeByName("Nothing").RepresentationBehavior(), ShouldEqual, ipld.Kind_Null)
+	Wish(t, ts.TypeByName("Empty").(*schema.TypeUnit).RepresentationStrategy(), ShouldEqual, schema.SpawnUnitRepresentationEmptyMap())
+	Wish(t, ts.TypeByName("Empty").RepresentationBehavior(), ShouldEqual, ipld.Kind_Map)
+	Wish(t, ts.TypeByName("Blob").TypeKind(), ShouldEqual, schema.TypeKind_Any)
+	Wish(t, ts.TypeByName("Meta").(*schema.TypeMap).ValueType().TypeKind(), ShouldEqual, schema.TypeKind_Any)
+}
+
+func TestParseDocs(t *testing.T) {
+	ts, err := schemaparser.Parse(strings.NewReader(`
+# Not about anything.
+
+# Person is someone we know.
+#
+#   With an indented line.
+type Person struct {
+	# name is what they're called.
+	name String # but not this.
+	age Int
+
+	# Not about age.
+
+	# nick is optional.
+	##   And may span lines.
+	nick optional String
+}
+type Nameless string # not about Nameless.
+`))
+	Require(t, err, ShouldEqual, nil)
+
+	person := ts.TypeByName("Person").(*schema.TypeStruct)
+	Wish(t, person.Doc(), ShouldEqual, "Person is someone we know.\n\n  With an indented line.")
+	var docs []string
+	for _, f := range person.Fields() {
+		docs = append(docs, f.Doc())
+	}
+	Wish(t, docs, ShouldEqual, []string{"name is what they're called.", "", "nick is optional.\n  And may span lines."})
+	Wish(t, ts.TypeByName("Nameless").Doc(), ShouldEqual, "")
+	Wish(t, ts.TypeByName("String").Doc(), ShouldEqual, "")
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, tc := range []struct {
+		src string
+		err string
+	}{
+		{`type Foo struct {`, `1:18: expected a name, got end of input`},
+		{"type Foo string\ntype Foo int", `2:6: type Foo is defined more than once`},
+		{`type Foo struct { bar Bar }`, `1:23: type Bar is not defined`},
+		{`type Foo struct { a Int  a Int }`, `1:26: field a is defined more than once`},
+		{`type Foo Bar`, `1:10: unsupported type definition "Bar"; copying another type is not supported`},
+		{`type Foo struct { a Int (default "1") }`, `1:26: unsupported field option "default"`},
+		{`type Foo struct { a Int (implicit 1) }`, `1:35: expected a string, got "1"`},
+		{`type Foo struct { a Int (implicit "one") }`, `1:35: the implicit value for field a must be an int, got "one"`},
+		{`type Foo struct { a Bool (implicit "yes") }`, `1:36: the implicit value for field a must be true or false, got "yes"`},
+		{`type Foo struct { a Bar (implicit "x") }  type Bar [String]`, `1:35: field a has type Bar, which is of kind List; implicit values are only supported for kinds String, Int, and Bool`},
+		{`type Foo struct { a optional Int (implicit "1") }`, `1:35: field a cannot be optional and have an implicit value`},
+		{`type Foo struct { a Int (implicit "1") } representation tuple`, `1:57: implicit values are only supported with the map representation`},
+		{`type Foo struct { a Int (rename "b") } representation tuple`, `1:55: field renames are only supported with the map representation`},
+		{`type Foo struct { a optional String } representation stringjoin {join ":"}`, `1:54: field a cannot be optional or nullable with the stringjoin representation`},
+		{`type Foo struct { a nullable String } representation stringpairs {innerDelim "=" entryDelim ","}`, `1:54: field a cannot be nullable with the stringpairs representation`},
+		{`type Foo union { | Int "int" }`, `1:31: expected "representation", got end of input`},
+		{`type Foo union { | Int int } representation keyed`, `1:24: the discriminant for member Int must be a string, got "int"`},
+		{`type Foo union { | Int "int" } representation kinded`, `1:24: the discriminant for member Int must be a kind, got "int"`},
+		{`type Foo union { | Int "a" } representation byteprefix`, `1:45: unsupported union representation "byteprefix"`},
+		{`type Foo union { | Int "a" } representation envelope {discriminantKey "tag"}`, `1:76: expected "contentKey", got "}"`},
+		{`type Foo enum { | A (a) }`, `1:22: expected a representation value for member A, got "a"`},
+		{`type Foo enum { | A ("B") | B }`, `1:29: representation value "B" is used more than once`},
+		{`type Foo enum { | A ("1") | B } representation int`, `1:29: member B needs an int representation value`},
+		{`type Foo enum { | A ("one") } representation int`, `1:22: the representation value for member A must be an int, got "one"`},
+		{`type Foo enum { | A ("1") | B ("01") } representation int`, `1:32: representation value 1 is used more than once`},
+		{`type Foo enum { | A } representation bytes`, `1:38: unsupported enum representation "bytes"`},
+		{`type Foo [String] representation listpairs`, `1:34: unsupported representation "listpairs"; only list is supported`},
+		{`type Foo {String:String} representation stringpairs`, `1:41: unsupported map representation "stringpairs"`},
+		{`type Foo unit`, `1:14: expected "representation", got end of input`},
+		{`type Foo unit representation true`, `1:30: unsupported unit representation "true"`},
+		{`type Foo "bar`, `1:10: unterminated string`},
+		{`type Foo = int`, `1:10: unexpected character '='`},
+	} {
+		_, err := schemaparser.Parse(strings.NewReader(tc.src))
+		if err == nil {
+			t.Errorf("no error for %q", tc.src)
+			continue
+		}
+		Wish(t, err.Error(), ShouldEqual, tc.err)
+	}
+}
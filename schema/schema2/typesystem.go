@@ -53,47 +53,19 @@ func BuildTypeSystem(schdmt schemadmt.Schema) (*TypeSystem, []error) {
 			// - the key type either is a string or has a representation strategy that makes it stringable
 			// - the value type exists
 			// - or, if the value is an inline defn, make that happen.
-			ktdmt := typesdmt.Lookup(t2.FieldKeyType())
-			if ktdmt == nil {
-				ee = append(ee, fmt.Errorf("type %s refers to missing type %s as key type", tn, t2.FieldKeyType()))
-			} else {
-				if !hasStringRepresentation(ktdmt) {
-					ee = append(ee, fmt.Errorf("type %s refers to type %s as key type, but it is not a valid key type because it is not stringable", tn, t2.FieldKeyType()))
-				}
-			}
-			switch vtndmt := t2.FieldValueType().AsInterface().(type) {
-			case schemadmt.TypeName:
-				vtdmt := typesdmt.Lookup(vtndmt)
-				if vtdmt == nil {
-					ee = append(ee, fmt.Errorf("type %s refers to missing type %s as value type", tn, vtndmt))
-				}
-			case schemadmt.TypeDefnInline:
-				panic("nyi") // TODO this needs to engage in anonymous type spawning.
-			}
+			checkMapKeyType(typesdmt, tn, t2.FieldKeyType(), &ee)
+			ts.checkValueType(typesdmt, tn, t2.FieldValueType(), &ee)
 			ts.types[tn.TypeReference()] = &TypeMap{tn, t2, ts}
 		case schemadmt.TypeList:
 			// Verify that:
 			// - the value type exists
 			// - or, if the value is an inline defn, make that happen.
-			switch vtndmt := t2.FieldValueType().AsInterface().(type) {
-			case schemadmt.TypeName:
-				vtdmt := typesdmt.Lookup(vtndmt)
-				if vtdmt == nil {
-					ee = append(ee, fmt.Errorf("type %s refers to missing type %s as value type", tn, vtndmt))
-				}
-			case schemadmt.TypeDefnInline:
-				panic("nyi") // TODO this needs to engage in anonymous type spawning.
-			}
+			ts.checkValueType(typesdmt, tn, t2.FieldValueType(), &ee)
 			ts.types[tn.TypeReference()] = &TypeList{tn, t2, ts}
 		case schemadmt.TypeLink:
 			// Verify that:
 			// - if there's an expected type, that type exists.
-			if t2.FieldExpectedType().Exists() {
-				referencedTn := t2.FieldExpectedType().Must()
-				if typesdmt.Lookup(referencedTn) == nil {
-					ee = append(ee, fmt.Errorf("type %s refers to missing type %s as link reference type", tn, referencedTn))
-				}
-			}
+			checkLinkReferencedType(typesdmt, tn, t2, &ee)
 			ts.types[tn.TypeReference()] = &TypeLink{tn, t2, ts}
 		case schemadmt.TypeStruct:
 			// Verify that:
@@ -108,7 +80,7 @@ func BuildTypeSystem(schdmt schemadmt.Schema) (*TypeSystem, []error) {
 						ee = append(ee, fmt.Errorf("type %s refers to missing type %s in field %s", tn, f2, fndmt))
 					}
 				case schemadmt.TypeDefnInline:
-					panic("nyi") // TODO this needs to engage in anonymous type spawning.
+					ts.spawnAnonymous(typesdmt, tn, f2, &ee)
 				}
 			}
 			ts.types[tn.TypeReference()] = &TypeStruct{tn, t2, ts}
@@ -325,6 +297,69 @@ func BuildTypeSystem(schdmt schemadmt.Schema) (*TypeSystem, []error) {
 	return nil, ee
 }
 
+// checkMapKeyType verifies that the key type of a map exists, and is stringable.
+func checkMapKeyType(typesdmt schemadmt.SchemaMap, tn schemadmt.TypeName, kt schemadmt.TypeName, ee *[]error) {
+	ktdmt := typesdmt.Lookup(kt)
+	if ktdmt == nil {
+		*ee = append(*ee, fmt.Errorf("type %s refers to missing type %s as key type", tn, kt))
+	} else if !hasStringRepresentation(ktdmt) {
+		*ee = append(*ee, fmt.Errorf("type %s refers to type %s as key type, but it is not a valid key type because it is not stringable", tn, kt))
+	}
+}
+
+// checkValueType verifies that the value type of a map or list exists,
+// or, if the value is an inline defn, spawns it.
+func (ts *TypeSystem) checkValueType(typesdmt schemadmt.SchemaMap, tn schemadmt.TypeName, vt schemadmt.TypeNameOrInlineDefn, ee *[]error) {
+	switch vtndmt := vt.AsInterface().(type) {
+	case schemadmt.TypeName:
+		if typesdmt.Lookup(vtndmt) == nil {
+			*ee = append(*ee, fmt.Errorf("type %s refers to missing type %s as value type", tn, vtndmt))
+		}
+	case schemadmt.TypeDefnInline:
+		ts.spawnAnonymous(typesdmt, tn, vtndmt, ee)
+	}
+}
+
+// checkLinkReferencedType verifies that the type a link refers to exists, if it names one.
+func checkLinkReferencedType(typesdmt schemadmt.SchemaMap, tn schemadmt.TypeName, t schemadmt.TypeLink, ee *[]error) {
+	if t.FieldExpectedType().Exists() {
+		referencedTn := t.FieldExpectedType().Must()
+		if typesdmt.Lookup(referencedTn) == nil {
+			*ee = append(*ee, fmt.Errorf("type %s refers to missing type %s as link reference type", tn, referencedTn))
+		}
+	}
+}
+
+// spawnAnonymous adds the reified form of an inline type definition to the typesystem,
+// keyed by its TypeReference (which is also used as its name),
+// after applying the same checks a named type of the same kind would get.
+// Any inline defns nested within it are spawned too.
+// The same inline defn may appear many times; it's only spawned (and checked) once.
+//
+// Errors about an anonymous type are reported against tn,
+// the named type in which it appears, since the anonymous type has no name of its own for users to look for.
+func (ts *TypeSystem) spawnAnonymous(typesdmt schemadmt.SchemaMap, tn schemadmt.TypeName, defn schemadmt.TypeDefnInline, ee *[]error) {
+	ref := defn.TypeReference()
+	if _, exists := ts.types[ref]; exists {
+		return
+	}
+	name, _ := schemadmt.Type.TypeName.FromString(string(ref))
+	switch t2 := defn.AsInterface().(type) {
+	case schemadmt.TypeMap:
+		checkMapKeyType(typesdmt, tn, t2.FieldKeyType(), ee)
+		ts.types[ref] = &TypeMap{name, t2, ts}
+		ts.checkValueType(typesdmt, tn, t2.FieldValueType(), ee)
+	case schemadmt.TypeList:
+		ts.types[ref] = &TypeList{name, t2, ts}
+		ts.checkValueType(typesdmt, tn, t2.FieldValueType(), ee)
+	case schemadmt.TypeLink:
+		checkLinkReferencedType(typesdmt, tn, t2, ee)
+		ts.types[ref] = &TypeLink{name, t2, ts}
+	default:
+		panic("unreachable")
+	}
+}
+
 // hasStringRepresentation returns a bool for... well, what it says on the tin.
 // This question is easier to ask of fully reified data; this function works pre-reification, because we use it during that process.
 func hasStringRepresentation(t schemadmt.TypeDefn) bool {
@@ -190,6 +190,124 @@ func TestBuildTypeSystem(t *testing.T) {
 			},
 		)
 	})
+	t.Run("InlineDefns", func(t *testing.T) {
+		ts := testParse(t,
+			`{
+				"types": {
+					"Foo": {
+						"struct": {
+							"fields": {
+								"index": {
+									"type": {
+										"map": {
+											"keyType": "String",
+											"valueType": {
+												"list": {
+													"valueType": {
+														"link": {
+															"expectedType": "Foo"
+														}
+													},
+													"valueNullable": false,
+													"representation": {
+														"list": {}
+													}
+												}
+											},
+											"valueNullable": false,
+											"representation": {
+												"map": {}
+											}
+										}
+									},
+									"optional": false,
+									"nullable": false
+								},
+								"names": {
+									"type": {
+										"list": {
+											"valueType": "String",
+											"valueNullable": true,
+											"representation": {
+												"list": {}
+											}
+										}
+									},
+									"optional": false,
+									"nullable": false
+								}
+							},
+							"representation": {
+								"map": {}
+							}
+						}
+					},
+					"String": {
+						"string": {}
+					}
+				}
+			}`,
+			nil,
+			nil,
+		)
+		foo := ts.types["Foo"].(*TypeStruct)
+
+		index := foo.Field("index").Type()
+		Wish(t, index, ShouldBeSameTypeAs, &TypeMap{})
+		Wish(t, index.Name().String(), ShouldEqual, "{String:[&Foo]}")
+		Wish(t, index.(*TypeMap).KeyType().Name().String(), ShouldEqual, "String")
+		indexValue := index.(*TypeMap).ValueType()
+		Wish(t, indexValue, ShouldBeSameTypeAs, &TypeList{})
+		Wish(t, indexValue.Name().String(), ShouldEqual, "[&Foo]")
+		link := indexValue.(*TypeList).ValueType()
+		Wish(t, link, ShouldBeSameTypeAs, &TypeLink{})
+		Wish(t, link.Name().String(), ShouldEqual, "&Foo")
+		Wish(t, link.(*TypeLink).ReferencedType() == ts.types["Foo"], ShouldEqual, true)
+
+		names := foo.Field("names").Type()
+		Wish(t, names, ShouldBeSameTypeAs, &TypeList{})
+		Wish(t, names.Name().String(), ShouldEqual, "[nullable String]")
+		Wish(t, names.(*TypeList).ValueIsNullable(), ShouldEqual, true)
+		Wish(t, names.(*TypeList).ValueType().Name().String(), ShouldEqual, "String")
+	})
+	t.Run("InlineDefnsAreChecked", func(t *testing.T) {
+		testParse(t,
+			`{
+				"types": {
+					"SomeList": {
+						"list": {
+							"valueType": {
+								"map": {
+									"keyType": "Int",
+									"valueType": {
+										"link": {
+											"expectedType": "Bork"
+										}
+									},
+									"valueNullable": false,
+									"representation": {
+										"map": {}
+									}
+								}
+							},
+							"valueNullable": false,
+							"representation": {
+								"list": {}
+							}
+						}
+					},
+					"Int": {
+						"int": {}
+					}
+				}
+			}`,
+			nil,
+			[]error{
+				fmt.Errorf("type SomeList refers to type Int as key type, but it is not a valid key type because it is not stringable"),
+				fmt.Errorf("type SomeList refers to missing type Bork as link reference type"),
+			},
+		)
+	})
 }
 
 func testParse(t *testing.T, schemajson string, expectParseErr error, expectTypesystemError []error) *TypeSystem {
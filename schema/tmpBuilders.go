@@ -29,44 +29,53 @@ import (
 //   (It's not really compatible with "Prototype and Type are the same thing for codegen'd stuff", either (or, we need more interfaces, and to *really* lean into them), but maybe that's okay.)
 
 func SpawnString(name TypeName) *TypeString {
-	return &TypeString{typeBase{name, nil}}
+	return &TypeString{typeBase{name, nil, ""}}
 }
 
 func SpawnBool(name TypeName) *TypeBool {
-	return &TypeBool{typeBase{name, nil}}
+	return &TypeBool{typeBase{name, nil, ""}}
 }
 
 func SpawnInt(name TypeName) *TypeInt {
-	return &TypeInt{typeBase{name, nil}}
+	return &TypeInt{typeBase{name, nil, ""}}
 }
 
 func SpawnFloat(name TypeName) *TypeFloat {
-	return &TypeFloat{typeBase{name, nil}}
+	return &TypeFloat{typeBase{name, nil, ""}}
 }
 
 func SpawnBytes(name TypeName) *TypeBytes {
-	return &TypeBytes{typeBase{name, nil}}
+	return &TypeBytes{typeBase{name, nil, ""}}
 }
 
 func SpawnLink(name TypeName) *TypeLink {
-	return &TypeLink{typeBase{name, nil}, "", false}
+	return &TypeLink{typeBase{name, nil, ""}, "", false}
 }
 
 func SpawnLinkReference(name TypeName, pointsTo TypeName) *TypeLink {
-	return &TypeLink{typeBase{name, nil}, pointsTo, true}
+	return &TypeLink{typeBase{name, nil, ""}, pointsTo, true}
 }
 
 func SpawnList(name TypeName, valueType TypeName, nullable bool) *TypeList {
-	return &TypeList{typeBase{name, nil}, false, valueType, nullable}
+	return &TypeList{typeBase{name, nil, ""}, false, valueType, nullable}
 }
 
 func SpawnMap(name TypeName, keyType TypeName, valueType TypeName, nullable bool) *TypeMap {
-	return &TypeMap{typeBase{name, nil}, false, keyType, valueType, nullable}
+	return SpawnMapWithRepresentation(name, keyType, valueType, nullable, SpawnMapRepresentationMap())
+}
+func SpawnMapWithRepresentation(name TypeName, keyType TypeName, valueType TypeName, nullable bool, repr MapRepresentation) *TypeMap {
+	return &TypeMap{typeBase{name, nil, ""}, false, keyType, valueType, nullable, repr}
+}
+func SpawnMapRepresentationMap() MapRepresentation_Map {
+	return MapRepresentation_Map{}
+}
+func SpawnMapRepresentationListPairs() MapRepresentation_ListPairs {
+	return MapRepresentation_ListPairs{}
 }
 
 func SpawnStruct(name TypeName, fields []StructField, repr StructRepresentation) *TypeStruct {
 	v := &TypeStruct{
-		typeBase{name, nil},
+		typeBase{name, nil, ""},
 		fields,
 		make(map[string]StructField, len(fields)),
 		repr,
@@ -82,24 +91,50 @@ func SpawnStruct(name TypeName, fields []StructField, repr StructRepresentation)
 				panic("neither nullable nor optional is supported on struct stringjoin representation")
 			}
 		}
+	case StructRepresentation_StringPairs:
+		for _, f := range fields {
+			if f.IsNullable() {
+				panic("nullable is not supported on struct stringpairs representation")
+			}
+		}
 	}
 	return v
 }
 func SpawnStructField(name string, typ TypeName, optional bool, nullable bool) StructField {
-	return StructField{nil /*populated later*/, name, typ, optional, nullable}
+	return StructField{nil /*populated later*/, name, typ, optional, nullable, ""}
+}
+
+// SpawnStructFieldDoc returns a copy of a field with the given documentation.
+func SpawnStructFieldDoc(field StructField, doc string) StructField {
+	field.doc = doc
+	return field
 }
 func SpawnStructRepresentationMap(renames map[string]string) StructRepresentation_Map {
 	return StructRepresentation_Map{renames, nil}
 }
+
+// SpawnStructRepresentationMapWithImplicits is like SpawnStructRepresentationMap,
+// but also gives some fields an implicit value, keyed by field name.
+// A field with an implicit value may be absent from the representation,
+// in which case it has that value at the type level.
+func SpawnStructRepresentationMapWithImplicits(renames map[string]string, implicits map[string]ImplicitValue) StructRepresentation_Map {
+	return StructRepresentation_Map{renames, implicits}
+}
 func SpawnStructRepresentationTuple() StructRepresentation_Tuple {
 	return StructRepresentation_Tuple{}
 }
 func SpawnStructRepresentationStringjoin(delim string) StructRepresentation_Stringjoin {
 	return StructRepresentation_Stringjoin{delim}
 }
+func SpawnStructRepresentationListPairs() StructRepresentation_ListPairs {
+	return StructRepresentation_ListPairs{}
+}
+func SpawnStructRepresentationStringPairs(innerDelim, entryDelim string) StructRepresentation_StringPairs {
+	return StructRepresentation_StringPairs{innerDelim, entryDelim}
+}
 
 func SpawnUnion(name TypeName, members []TypeName, repr UnionRepresentation) *TypeUnion {
-	return &TypeUnion{typeBase{name, nil}, members, repr}
+	return &TypeUnion{typeBase{name, nil, ""}, members, repr}
 }
 func SpawnUnionRepresentationKeyed(table map[string]TypeName) UnionRepresentation_Keyed {
 	return UnionRepresentation_Keyed{table}
@@ -110,6 +145,57 @@ func SpawnUnionRepresentationKinded(table map[ipld.Kind]TypeName) UnionRepresent
 func SpawnUnionRepresentationStringprefix(delim string, table map[string]TypeName) UnionRepresentation_Stringprefix {
 	return UnionRepresentation_Stringprefix{delim, table}
 }
+func SpawnUnionRepresentationInline(discriminantKey string, table map[string]TypeName) UnionRepresentation_Inline {
+	return UnionRepresentation_Inline{discriminantKey, table}
+}
+func SpawnUnionRepresentationEnvelope(discriminantKey string, contentKey string, table map[string]TypeName) UnionRepresentation_Envelope {
+	return UnionRepresentation_Envelope{discriminantKey, contentKey, table}
+}
+
+func SpawnEnum(name TypeName, members []string) *TypeEnum {
+	return SpawnEnumWithRepresentation(name, members, SpawnEnumRepresentationString(nil))
+}
+func SpawnEnumWithRepresentation(name TypeName, members []string, repr EnumRepresentation) *TypeEnum {
+	return &TypeEnum{typeBase{name, nil, ""}, members, repr}
+}
+func SpawnEnumRepresentationString(table map[string]string) EnumRepresentation_String {
+	return EnumRepresentation_String{table}
+}
+func SpawnEnumRepresentationInt(table map[string]int) EnumRepresentation_Int {
+	return EnumRepresentation_Int{table}
+}
+
+func SpawnUnit(name TypeName, repr UnitRepresentation) *TypeUnit {
+	return &TypeUnit{typeBase{name, nil, ""}, repr}
+}
+func SpawnUnitRepresentationNull() UnitRepresentation_Null {
+	return UnitRepresentation_Null{}
+}
+func SpawnUnitRepresentationEmptyMap() UnitRepresentation_EmptyMap {
+	return UnitRepresentation_EmptyMap{}
+}
+
+func SpawnAny(name TypeName) *TypeAny {
+	return &TypeAny{typeBase{name, nil, ""}}
+}
+
+// SpawnDoc sets the documentation of a type, and returns the same type,
+// so that it can wrap the call which spawns the type.
+// It should be called before the type is accumulated into a TypeSystem.
+func SpawnDoc(typ Type, doc string) Type {
+	typ.(interface{ setDoc(string) }).setDoc(doc)
+	return typ
+}
+
+func SpawnImplicitValueString(x string) ImplicitValue_String {
+	return ImplicitValue_String{x}
+}
+func SpawnImplicitValueInt(x int) ImplicitValue_Int {
+	return ImplicitValue_Int{x}
+}
+func SpawnImplicitValueBool(x bool) ImplicitValue_Bool {
+	return ImplicitValue_Bool{x}
+}
 
 // The methods relating to TypeSystem are also mutation-heavy and placeholdery.
 
@@ -142,8 +228,11 @@ func (ts TypeSystem) ValidateGraph() []error {
 			*TypeFloat,
 			*TypeString,
 			*TypeBytes,
-			*TypeEnum:
+			*TypeUnit,
+			*TypeAny:
 			continue // nothing to check: these are leaf nodes and refer to no other types.
+		case *TypeEnum:
+			ee = append(ee, validateEnumRepresentation(t2)...)
 		case *TypeLink:
 			if !t2.hasReferencedType {
 				continue
@@ -155,18 +244,57 @@ func (ts TypeSystem) ValidateGraph() []error {
 			for _, f := range t2.fields {
 				if _, ok := ts.namedTypes[f.typ]; !ok {
 					ee = append(ee, fmt.Errorf("type %s refers to missing type %s (in field %s)", tn, f.typ, f.name))
+				} else if f.nullable && ts.representsNull(f.typ) {
+					ee = append(ee, fmt.Errorf("type %s has nullable field %s, but its type %s is represented as null, so null would be ambiguous", tn, f.name, f.typ))
+				}
+			}
+			switch r := t2.representation.(type) {
+			case StructRepresentation_Map:
+				ee = append(ee, ts.validateStructImplicits(t2, r)...)
+			case StructRepresentation_Tuple:
+				// Values are matched to fields by position,
+				//  so only fields at the end can be left out.
+				for i := 1; i < len(t2.fields); i++ {
+					if prev, f := t2.fields[i-1], t2.fields[i]; prev.optional && !f.optional {
+						ee = append(ee, fmt.Errorf("type %s has optional field %s followed by required field %s, but only trailing fields can be optional with the tuple representation", tn, prev.name, f.name))
+					}
+				}
+			case StructRepresentation_Stringjoin:
+				if r.sep == "" {
+					ee = append(ee, fmt.Errorf("type %s has an empty delimiter in its stringjoin representation", tn))
+				}
+				// The fields are parsed from and joined into a single string,
+				//  so each of them has to be represented as one too.
+				for _, f := range t2.fields {
+					if ft, ok := ts.namedTypes[f.typ]; ok {
+						if rk := ft.RepresentationBehavior(); rk != ipld.Kind_String {
+							ee = append(ee, fmt.Errorf("type %s has field %s of type %s, which is represented as kind %s, but stringjoin fields must be strings", tn, f.name, f.typ, rk))
+						}
+					}
+				}
+			case StructRepresentation_StringPairs:
+				if r.sep1 == "" || r.sep2 == "" {
+					ee = append(ee, fmt.Errorf("type %s has an empty delimiter in its stringpairs representation", tn))
+				} else if r.sep1 == r.sep2 {
+					ee = append(ee, fmt.Errorf("type %s uses the same delimiter %q within and between its entries", tn, r.sep1))
 				}
 			}
 		case *TypeMap:
-			if _, ok := ts.namedTypes[t2.keyType]; !ok {
+			if kt, ok := ts.namedTypes[t2.keyType]; !ok {
 				ee = append(ee, fmt.Errorf("type %s refers to missing type %s (as key type)", tn, t2.keyType))
+			} else if rk := kt.RepresentationBehavior(); rk != ipld.Kind_String {
+				ee = append(ee, fmt.Errorf("type %s has key type %s, which is represented as kind %s, but map keys must be strings", tn, t2.keyType, rk))
 			}
 			if _, ok := ts.namedTypes[t2.valueType]; !ok {
 				ee = append(ee, fmt.Errorf("type %s refers to missing type %s (as key type)", tn, t2.valueType))
+			} else if t2.valueNullable && ts.representsNull(t2.valueType) {
+				ee = append(ee, fmt.Errorf("type %s has nullable values, but their type %s is represented as null, so null would be ambiguous", tn, t2.valueType))
 			}
 		case *TypeList:
 			if _, ok := ts.namedTypes[t2.valueType]; !ok {
 				ee = append(ee, fmt.Errorf("type %s refers to missing type %s (as key type)", tn, t2.valueType))
+			} else if t2.valueNullable && ts.representsNull(t2.valueType) {
+				ee = append(ee, fmt.Errorf("type %s has nullable values, but their type %s is represented as null, so null would be ambiguous", tn, t2.valueType))
 			}
 		case *TypeUnion:
 			for _, mn := range t2.members {
@@ -174,6 +302,214 @@ func (ts TypeSystem) ValidateGraph() []error {
 					ee = append(ee, fmt.Errorf("type %s refers to missing type %s (as a member)", tn, mn))
 				}
 			}
+			switch r := t2.representation.(type) {
+			case UnionRepresentation_Keyed:
+				ee = append(ee, validateUnionDiscriminants(t2, r.table)...)
+			case UnionRepresentation_Kinded:
+				ee = append(ee, ts.validateKindedUnion(t2, r)...)
+			case UnionRepresentation_Stringprefix:
+				ee = append(ee, validateUnionDiscriminants(t2, r.table)...)
+			case UnionRepresentation_Envelope:
+				if r.discriminantKey == r.contentKey {
+					ee = append(ee, fmt.Errorf("type %s uses the same key %q for both its discriminant and its content", tn, r.contentKey))
+				}
+				ee = append(ee, validateUnionDiscriminants(t2, r.table)...)
+			case UnionRepresentation_Inline:
+				ee = append(ee, ts.validateInlineUnion(t2, r)...)
+			}
+		}
+	}
+	return ee
+}
+
+// representsNull returns true if the named type is a unit represented as null.
+// Such types can't be used where null is also allowed as the absence of a value.
+func (ts TypeSystem) representsNull(tn TypeName) bool {
+	return ts.namedTypes[tn].RepresentationBehavior() == ipld.Kind_Null
+}
+
+// validateKindedUnion checks that the table of a kinded union maps each kind
+// to a member whose representation has that kind, and that every member
+// appears in the table once.
+//
+// Members whose representation kind can't be known in advance,
+// such as other kinded unions, can't be members of a kinded union.
+func (ts TypeSystem) validateKindedUnion(t *TypeUnion, r UnionRepresentation_Kinded) []error {
+	var ee []error
+	seen := make(map[TypeName]bool, len(r.table))
+	for k, mn := range r.table {
+		seen[mn] = true
+		member, ok := ts.namedTypes[mn]
+		if !ok {
+			ee = append(ee, fmt.Errorf("type %s refers to missing type %s (in its representation, for kind %s)", t.name, mn, k))
+			continue
+		}
+		isMember := false
+		for _, mn2 := range t.members {
+			isMember = isMember || mn2 == mn
+		}
+		if !isMember {
+			ee = append(ee, fmt.Errorf("type %s refers to type %s in its representation, but it's not a member", t.name, mn))
+			continue
+		}
+		switch rk := member.RepresentationBehavior(); rk {
+		case k:
+		case ipld.Kind_Invalid:
+			ee = append(ee, fmt.Errorf("type %s has member %s, whose representation kind can't be known in advance", t.name, mn))
+		default:
+			ee = append(ee, fmt.Errorf("type %s maps kind %s to member %s, but that type is represented as kind %s", t.name, k, mn, rk))
+		}
+	}
+	for _, mn := range t.members {
+		if !seen[mn] {
+			ee = append(ee, fmt.Errorf("type %s has member %s, which has no kind in its representation", t.name, mn))
+		}
+	}
+	return ee
+}
+
+// validateInlineUnion checks that every member of an inline union is a struct
+// with the map representation, as the discriminant is added to that map,
+// and that none of their fields use the discriminant key.
+func (ts TypeSystem) validateInlineUnion(t *TypeUnion, r UnionRepresentation_Inline) []error {
+	ee := validateUnionDiscriminants(t, r.table)
+	for _, mn := range t.members {
+		member, ok := ts.namedTypes[mn]
+		if !ok {
+			continue // already reported as a missing member.
+		}
+		st, ok := member.(*TypeStruct)
+		if !ok {
+			ee = append(ee, fmt.Errorf("type %s has member %s, but members of inline unions must be structs", t.name, mn))
+			continue
+		}
+		sr, ok := st.representation.(StructRepresentation_Map)
+		if !ok {
+			ee = append(ee, fmt.Errorf("type %s has member %s, but members of inline unions must have the map representation", t.name, mn))
+			continue
+		}
+		for _, f := range st.fields {
+			if sr.GetFieldKey(f) == r.discriminantKey {
+				ee = append(ee, fmt.Errorf("type %s has member %s, whose field %s uses the discriminant key %q", t.name, mn, f.name, r.discriminantKey))
+			}
+		}
+	}
+	return ee
+}
+
+// validateUnionDiscriminants checks that the discriminant table of a union
+// (such as a keyed union's) maps to members of the union,
+// and has exactly one discriminant for each member.
+func validateUnionDiscriminants(t *TypeUnion, table map[string]TypeName) []error {
+	var ee []error
+	count := make(map[TypeName]int, len(table))
+	for _, mn := range t.members {
+		count[mn] = 0
+	}
+	for d, mn := range table {
+		if _, ok := count[mn]; !ok {
+			ee = append(ee, fmt.Errorf("type %s refers to type %s in its representation (as discriminant %q), but it's not a member", t.name, mn, d))
+			continue
+		}
+		count[mn]++
+	}
+	for _, mn := range t.members {
+		switch count[mn] {
+		case 0:
+			ee = append(ee, fmt.Errorf("type %s has member %s, which has no discriminant in its representation", t.name, mn))
+		case 1:
+		default:
+			ee = append(ee, fmt.Errorf("type %s has member %s, which has more than one discriminant in its representation", t.name, mn))
+		}
+	}
+	return ee
+}
+
+// validateStructImplicits checks that the implicit values of a struct
+// refer to its fields, and that each matches the kind of its field's type.
+//
+// Implicit values are only supported for fields whose type is a plain
+// bool, int, or string, and not for optional fields:
+// an absent optional field and a field left at its implicit value
+// would be impossible to tell apart in the representation.
+func (ts TypeSystem) validateStructImplicits(t *TypeStruct, r StructRepresentation_Map) []error {
+	var ee []error
+	for fn, iv := range r.implicits {
+		f, ok := t.fieldsMap[fn]
+		if !ok {
+			ee = append(ee, fmt.Errorf("type %s has an implicit value for %s in its representation, but it's not a field", t.name, fn))
+			continue
+		}
+		if f.optional {
+			ee = append(ee, fmt.Errorf("type %s has field %s, which is optional, so it can't also have an implicit value", t.name, fn))
+		}
+		var want TypeKind
+		switch iv.(type) {
+		case ImplicitValue_String:
+			want = TypeKind_String
+		case ImplicitValue_Int:
+			want = TypeKind_Int
+		case ImplicitValue_Bool:
+			want = TypeKind_Bool
+		default:
+			ee = append(ee, fmt.Errorf("type %s has an implicit empty list or map for field %s, which is not supported yet", t.name, fn))
+			continue
+		}
+		ft, ok := ts.namedTypes[f.typ]
+		if !ok {
+			continue // already reported as a missing type.
+		}
+		if tk := ft.TypeKind(); tk != want {
+			ee = append(ee, fmt.Errorf("type %s has an implicit %s value for field %s, but the field's type %s is of kind %s", t.name, want, fn, f.typ, tk))
+		}
+	}
+	return ee
+}
+
+// validateEnumRepresentation checks that the representation table of an enum
+// only refers to its members, and that no two members share a serial value.
+// The int representation must also give a value for every member,
+// since members have no int value of their own to fall back to.
+func validateEnumRepresentation(t *TypeEnum) []error {
+	var ee []error
+	isMember := make(map[string]bool, len(t.members))
+	for _, m := range t.members {
+		isMember[m] = true
+	}
+	switch r := t.representation.(type) {
+	case EnumRepresentation_String:
+		for m := range r.table {
+			if !isMember[m] {
+				ee = append(ee, fmt.Errorf("type %s refers to %s in its representation, but it's not a member", t.name, m))
+			}
+		}
+		seen := make(map[string]string, len(t.members))
+		for _, m := range t.members {
+			s := r.GetSerial(m)
+			if prev, ok := seen[s]; ok {
+				ee = append(ee, fmt.Errorf("type %s has members %s and %s, which are both represented as %q", t.name, prev, m, s))
+				continue
+			}
+			seen[s] = m
+		}
+	case EnumRepresentation_Int:
+		for m := range r.table {
+			if !isMember[m] {
+				ee = append(ee, fmt.Errorf("type %s refers to %s in its representation, but it's not a member", t.name, m))
+			}
+		}
+		seen := make(map[int]string, len(t.members))
+		for _, m := range t.members {
+			i, ok := r.table[m]
+			if !ok {
+				ee = append(ee, fmt.Errorf("type %s has member %s, which has no int value in its representation", t.name, m))
+				continue
+			}
+			if prev, ok := seen[i]; ok {
+				ee = append(ee, fmt.Errorf("type %s has members %s and %s, which are both represented as %d", t.name, prev, m, i))
+				continue
+			}
+			seen[i] = m
 		}
 	}
 	return ee
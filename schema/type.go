@@ -24,6 +24,8 @@ func (tn TypeName) String() string { return string(tn) }
 // 	TypeUnion
 // 	TypeStruct
 // 	TypeEnum
+// 	TypeUnit
+// 	TypeAny
 //
 // are all of the kinds of Type.
 //
@@ -89,6 +91,12 @@ type Type interface {
 	// However, this method doesn't exist, because it's a deterministic property of `TypeKind()`!
 	// You can use `TypeKind.ActsLike()` to get type-level behavioral information.
 	RepresentationBehavior() ipld.Kind
+
+	// Doc returns the documentation of the type, if it has any.
+	// In the schema DSL, that's the comment directly above the type's definition,
+	// without its leading '#' characters.
+	// The text may span many lines, and is empty if the type isn't documented.
+	Doc() string
 }
 
 var (
@@ -103,11 +111,14 @@ var (
 	_ Type = &TypeUnion{}
 	_ Type = &TypeStruct{}
 	_ Type = &TypeEnum{}
+	_ Type = &TypeUnit{}
+	_ Type = &TypeAny{}
 )
 
 type typeBase struct {
 	name     TypeName
 	universe *TypeSystem
+	doc      string
 }
 
 type TypeBool struct {
@@ -132,12 +143,21 @@ type TypeFloat struct {
 
 type TypeMap struct {
 	typeBase
-	anonymous     bool
-	keyType       TypeName // must be Kind==string (e.g. Type==String|Enum).
-	valueType     TypeName
-	valueNullable bool
+	anonymous      bool
+	keyType        TypeName // must be Kind==string (e.g. Type==String|Enum).
+	valueType      TypeName
+	valueNullable  bool
+	representation MapRepresentation
 }
 
+type MapRepresentation interface{ _MapRepresentation() }
+
+func (MapRepresentation_Map) _MapRepresentation()       {}
+func (MapRepresentation_ListPairs) _MapRepresentation() {}
+
+type MapRepresentation_Map struct{}
+type MapRepresentation_ListPairs struct{}
+
 type TypeList struct {
 	typeBase
 	anonymous     bool
@@ -211,6 +231,7 @@ type StructField struct {
 	typ      TypeName
 	optional bool
 	nullable bool
+	doc      string
 }
 
 type StructRepresentation interface{ _StructRepresentation() }
@@ -219,6 +240,7 @@ func (StructRepresentation_Map) _StructRepresentation()         {}
 func (StructRepresentation_Tuple) _StructRepresentation()       {}
 func (StructRepresentation_StringPairs) _StructRepresentation() {}
 func (StructRepresentation_Stringjoin) _StructRepresentation()  {}
+func (StructRepresentation_ListPairs) _StructRepresentation()   {}
 
 type StructRepresentation_Map struct {
 	renames   map[string]string
@@ -227,10 +249,46 @@ type StructRepresentation_Map struct {
 type StructRepresentation_Tuple struct{}
 type StructRepresentation_StringPairs struct{ sep1, sep2 string }
 type StructRepresentation_Stringjoin struct{ sep string }
+type StructRepresentation_ListPairs struct{}
 
 type TypeEnum struct {
 	typeBase
-	members []string
+	members        []string
+	representation EnumRepresentation
+}
+
+type EnumRepresentation interface{ _EnumRepresentation() }
+
+func (EnumRepresentation_String) _EnumRepresentation() {}
+func (EnumRepresentation_Int) _EnumRepresentation()    {}
+
+type EnumRepresentation_String struct {
+	table map[string]string // members missing from the table are represented by their own name.
+}
+type EnumRepresentation_Int struct {
+	table map[string]int // must have an entry for every member.
+}
+
+// TypeUnit is a type with only one value, so it carries no information
+// beyond its presence.  At the type level, that value acts like the bool 'true';
+// the representation strategy decides how it's serialized.
+type TypeUnit struct {
+	typeBase
+	representation UnitRepresentation
+}
+
+type UnitRepresentation interface{ _UnitRepresentation() }
+
+func (UnitRepresentation_Null) _UnitRepresentation()     {}
+func (UnitRepresentation_EmptyMap) _UnitRepresentation() {}
+
+type UnitRepresentation_Null struct{}
+type UnitRepresentation_EmptyMap struct{}
+
+// TypeAny accepts any Data Model value at all, including null,
+// and those values are left untyped.
+type TypeAny struct {
+	typeBase
 }
 
 // ImplicitValue is an sum type holding values that are implicits.
@@ -239,7 +297,14 @@ type TypeEnum struct {
 // but if so, only its empty value is valid here).
 type ImplicitValue interface{ _ImplicitValue() }
 
+func (ImplicitValue_EmptyList) _ImplicitValue() {}
+func (ImplicitValue_EmptyMap) _ImplicitValue()  {}
+func (ImplicitValue_String) _ImplicitValue()    {}
+func (ImplicitValue_Int) _ImplicitValue()       {}
+func (ImplicitValue_Bool) _ImplicitValue()      {}
+
 type ImplicitValue_EmptyList struct{}
 type ImplicitValue_EmptyMap struct{}
 type ImplicitValue_String struct{ x string }
 type ImplicitValue_Int struct{ x int }
+type ImplicitValue_Bool struct{ x bool }
@@ -9,8 +9,12 @@ import (
 func (t *typeBase) _Type(ts *TypeSystem) {
 	t.universe = ts
 }
+func (t *typeBase) setDoc(doc string) {
+	t.doc = doc
+}
 func (t typeBase) TypeSystem() *TypeSystem { return t.universe }
 func (t typeBase) Name() TypeName          { return t.name }
+func (t typeBase) Doc() string             { return t.doc }
 
 func (TypeBool) TypeKind() TypeKind   { return TypeKind_Bool }
 func (TypeString) TypeKind() TypeKind { return TypeKind_String }
@@ -23,15 +27,27 @@ func (TypeLink) TypeKind() TypeKind   { return TypeKind_Link }
 func (TypeUnion) TypeKind() TypeKind  { return TypeKind_Union }
 func (TypeStruct) TypeKind() TypeKind { return TypeKind_Struct }
 func (TypeEnum) TypeKind() TypeKind   { return TypeKind_Enum }
+func (TypeUnit) TypeKind() TypeKind   { return TypeKind_Unit }
+func (TypeAny) TypeKind() TypeKind    { return TypeKind_Any }
 
 func (TypeBool) RepresentationBehavior() ipld.Kind   { return ipld.Kind_Bool }
 func (TypeString) RepresentationBehavior() ipld.Kind { return ipld.Kind_String }
 func (TypeBytes) RepresentationBehavior() ipld.Kind  { return ipld.Kind_Bytes }
 func (TypeInt) RepresentationBehavior() ipld.Kind    { return ipld.Kind_Int }
 func (TypeFloat) RepresentationBehavior() ipld.Kind  { return ipld.Kind_Float }
-func (TypeMap) RepresentationBehavior() ipld.Kind    { return ipld.Kind_Map }
 func (TypeList) RepresentationBehavior() ipld.Kind   { return ipld.Kind_List }
 func (TypeLink) RepresentationBehavior() ipld.Kind   { return ipld.Kind_Link }
+func (TypeAny) RepresentationBehavior() ipld.Kind    { return ipld.Kind_Invalid } // could be anything!
+func (t TypeMap) RepresentationBehavior() ipld.Kind {
+	switch t.representation.(type) {
+	case MapRepresentation_Map:
+		return ipld.Kind_Map
+	case MapRepresentation_ListPairs:
+		return ipld.Kind_List
+	default:
+		panic("unreachable")
+	}
+}
 func (t TypeUnion) RepresentationBehavior() ipld.Kind {
 	switch t.representation.(type) {
 	case UnionRepresentation_Keyed:
@@ -42,6 +58,8 @@ func (t TypeUnion) RepresentationBehavior() ipld.Kind {
 		return ipld.Kind_Map
 	case UnionRepresentation_Inline:
 		return ipld.Kind_Map
+	case UnionRepresentation_Stringprefix:
+		return ipld.Kind_String
 	default:
 		panic("unreachable")
 	}
@@ -56,13 +74,31 @@ func (t TypeStruct) RepresentationBehavior() ipld.Kind {
 		return ipld.Kind_String
 	case StructRepresentation_Stringjoin:
 		return ipld.Kind_String
+	case StructRepresentation_ListPairs:
+		return ipld.Kind_List
 	default:
 		panic("unreachable")
 	}
 }
 func (t TypeEnum) RepresentationBehavior() ipld.Kind {
-	// TODO: this should have a representation strategy switch too; sometimes that will indicate int representation behavior.
-	return ipld.Kind_String
+	switch t.representation.(type) {
+	case EnumRepresentation_String:
+		return ipld.Kind_String
+	case EnumRepresentation_Int:
+		return ipld.Kind_Int
+	default:
+		panic("unreachable")
+	}
+}
+func (t TypeUnit) RepresentationBehavior() ipld.Kind {
+	switch t.representation.(type) {
+	case UnitRepresentation_Null:
+		return ipld.Kind_Null
+	case UnitRepresentation_EmptyMap:
+		return ipld.Kind_Map
+	default:
+		panic("unreachable")
+	}
 }
 
 /* interesting methods per Type type */
@@ -108,6 +144,10 @@ func (t TypeMap) ValueIsNullable() bool {
 	return t.valueNullable
 }
 
+func (t TypeMap) RepresentationStrategy() MapRepresentation {
+	return t.representation
+}
+
 // IsAnonymous is returns true if the type was unnamed.  Unnamed types will
 // claim to have a Name property like `[Foo]`, and this is not guaranteed
 // to be a unique string for all types in the universe.
@@ -161,6 +201,40 @@ func (r UnionRepresentation_Stringprefix) GetDiscriminant(t Type) string {
 	panic("that type isn't a member of this union")
 }
 
+// GetDiscriminantKey returns the map key under which the discriminant is found,
+// alongside the fields of the member struct.
+func (r UnionRepresentation_Inline) GetDiscriminantKey() string {
+	return r.discriminantKey
+}
+
+func (r UnionRepresentation_Inline) GetDiscriminant(t Type) string {
+	for d, t2 := range r.table {
+		if t2 == t.Name() {
+			return d
+		}
+	}
+	panic("that type isn't a member of this union")
+}
+
+// GetDiscriminantKey returns the map key under which the discriminant is found.
+func (r UnionRepresentation_Envelope) GetDiscriminantKey() string {
+	return r.discriminantKey
+}
+
+// GetContentKey returns the map key under which the member's data is found.
+func (r UnionRepresentation_Envelope) GetContentKey() string {
+	return r.contentKey
+}
+
+func (r UnionRepresentation_Envelope) GetDiscriminant(t Type) string {
+	for d, t2 := range r.table {
+		if t2 == t.Name() {
+			return d
+		}
+	}
+	panic("that type isn't a member of this union")
+}
+
 // GetMember returns type info for the member matching the kind argument,
 // or may return nil if that kind is not mapped to a member of this union.
 func (r UnionRepresentation_Kinded) GetMember(k ipld.Kind) TypeName {
@@ -228,6 +302,11 @@ func (f StructField) IsNullable() bool { return f.nullable }
 // but this method is a shorthand that turns out useful often.
 func (f StructField) IsMaybe() bool { return f.nullable || f.optional }
 
+// Doc returns the documentation of this field, if it has any.
+// Like the documentation of a type, this is the comment directly above the field
+// in the schema DSL, and is empty if the field isn't documented.
+func (f StructField) Doc() string { return f.doc }
+
 func (t TypeStruct) RepresentationStrategy() StructRepresentation {
 	return t.representation
 }
@@ -244,10 +323,35 @@ func (r StructRepresentation_Map) FieldHasRename(field StructField) bool {
 	return ok
 }
 
+// GetImplicit returns the implicit value of the field,
+// or nil if the field has none.
+func (r StructRepresentation_Map) GetImplicit(field StructField) ImplicitValue {
+	return r.implicits[field.name]
+}
+
+func (r StructRepresentation_Map) FieldHasImplicit(field StructField) bool {
+	_, ok := r.implicits[field.name]
+	return ok
+}
+
+func (iv ImplicitValue_String) String() string { return iv.x }
+func (iv ImplicitValue_Int) Int() int          { return iv.x }
+func (iv ImplicitValue_Bool) Bool() bool       { return iv.x }
+
 func (r StructRepresentation_Stringjoin) GetDelim() string {
 	return r.sep
 }
 
+// GetInnerDelim returns the delimiter between a field's key and its value.
+func (r StructRepresentation_StringPairs) GetInnerDelim() string {
+	return r.sep1
+}
+
+// GetEntryDelim returns the delimiter between one field's entry and the next.
+func (r StructRepresentation_StringPairs) GetEntryDelim() string {
+	return r.sep2
+}
+
 // Members returns a slice the strings which are valid inhabitants of this enum.
 func (t TypeEnum) Members() []string {
 	a := make([]string, len(t.members))
@@ -257,6 +361,42 @@ func (t TypeEnum) Members() []string {
 	return a
 }
 
+// HasMember returns true if the given string is one of the enum's members.
+func (t TypeEnum) HasMember(member string) bool {
+	for _, m := range t.members {
+		if m == member {
+			return true
+		}
+	}
+	return false
+}
+
+func (t TypeEnum) RepresentationStrategy() EnumRepresentation {
+	return t.representation
+}
+
+// GetSerial returns the string which represents the given member.
+// Members without an entry in the representation table are represented by their own name.
+func (r EnumRepresentation_String) GetSerial(member string) string {
+	if s, ok := r.table[member]; ok {
+		return s
+	}
+	return member
+}
+
+// MemberHasRename returns true if the member is represented by a string other than its own name.
+func (r EnumRepresentation_String) MemberHasRename(member string) bool {
+	_, ok := r.table[member]
+	return ok
+}
+
+// GetSerial returns the int which represents the given member,
+// or false if the member isn't in the representation table.
+func (r EnumRepresentation_Int) GetSerial(member string) (int, bool) {
+	i, ok := r.table[member]
+	return i, ok
+}
+
 // Links can keep a referenced type, which is a hint only about the data on the
 // other side of the link, no something that can be explicitly validated without
 // loading the link
@@ -271,3 +411,7 @@ func (t TypeLink) HasReferencedType() bool {
 func (t TypeLink) ReferencedType() Type {
 	return t.universe.namedTypes[t.referencedType]
 }
+
+func (t TypeUnit) RepresentationStrategy() UnitRepresentation {
+	return t.representation
+}
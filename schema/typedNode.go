@@ -57,15 +57,20 @@ type TypedNode interface {
 // on the other side of the link contained within the node, so that it can be assembled
 // into a node representation and validated against the schema as quickly as possible
 //
-// So, for example, if you wanted to support loading the other side of a link
-// with a code-gen'd node builder while utilizing the automatic loading facilities
-// of the traversal package, you could write a LinkNodeBuilderChooser as follows:
+// Nodes for link types which name the type they link to (written as `&Foo` in a schema)
+// implement this interface; both code-gen'd nodes and the nodes from the wrapnode package do.
+// The prototype is for the representation of the referenced type,
+// since the representation is what gets loaded.
 //
-//		func LinkNodeBuilderChooser(lnk ipld.Link, lnkCtx ipld.LinkContext) ipld.NodePrototype {
+// The traversal package uses this automatically when crossing links,
+// unless its Config has a LinkTargetNodePrototypeChooser set.
+// A custom chooser could keep the same behavior as follows:
+//
+//		func LinkTargetNodePrototypeChooser(lnk ipld.Link, lnkCtx ipld.LinkContext) (ipld.NodePrototype, error) {
 //			if tlnkNd, ok := lnkCtx.LinkNode.(schema.TypedLinkNode); ok {
-//				return tlnkNd.LinkTargetNodePrototype()
+//				return tlnkNd.LinkTargetNodePrototype(), nil
 //			}
-//			return basicnode.Prototype__Any{}
+//			return basicnode.Prototype.Any, nil
 //		}
 //
 type TypedLinkNode interface {
@@ -1,5 +1,13 @@
 package schema
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+)
+
 /*
 	Okay, so.  There are several fun considerations for a "validate" method.
 
@@ -68,3 +76,467 @@ package schema
 	returns *only* errors: only then we can have it in the schema package.
 
 */
+
+// Validate checks that a node matches a type of the TypeSystem, without
+// needing any code generation: this is "Option 1" from the notes above.
+// The node should be untyped data, as it would be deserialized; Validate
+// checks it against the type's representation.
+//
+// Every mismatch found is returned as an ErrInvalidData, which carries the
+// path to the offending node.  Validation continues past most errors,
+// but doesn't look further into data it couldn't match at all,
+// such as a union whose discriminant isn't recognized.
+// Links are only checked to be links; the data they point to isn't loaded.
+//
+// A nil slice is returned if the node is valid.
+func Validate(ts *TypeSystem, typeName TypeName, n ipld.Node) []error {
+	v := validator{ts: ts}
+	v.validate(ipld.Path{}, typeName, n)
+	return v.errs
+}
+
+type validator struct {
+	ts   *TypeSystem
+	errs []error
+}
+
+func (v *validator) errorf(path ipld.Path, typeName TypeName, format string, args ...interface{}) {
+	v.errs = append(v.errs, ErrInvalidData{path, typeName, fmt.Sprintf(format, args...)})
+}
+
+// expectKind checks the kind of a node, reporting an error if it's wrong.
+func (v *validator) expectKind(path ipld.Path, typeName TypeName, n ipld.Node, kind ipld.Kind) bool {
+	if n.Kind() != kind {
+		v.errorf(path, typeName, "expected kind %s, got %s", kind, n.Kind())
+		return false
+	}
+	return true
+}
+
+func (v *validator) validate(path ipld.Path, typeName TypeName, n ipld.Node) {
+	typ, ok := v.ts.namedTypes[typeName]
+	if !ok {
+		v.errorf(path, typeName, "type is not defined in the type system")
+		return
+	}
+	switch t := typ.(type) {
+	case *TypeBool:
+		v.expectKind(path, typeName, n, ipld.Kind_Bool)
+	case *TypeInt:
+		v.expectKind(path, typeName, n, ipld.Kind_Int)
+	case *TypeFloat:
+		v.expectKind(path, typeName, n, ipld.Kind_Float)
+	case *TypeString:
+		v.expectKind(path, typeName, n, ipld.Kind_String)
+	case *TypeBytes:
+		v.expectKind(path, typeName, n, ipld.Kind_Bytes)
+	case *TypeLink:
+		v.expectKind(path, typeName, n, ipld.Kind_Link)
+	case *TypeEnum:
+		switch r := t.representation.(type) {
+		case EnumRepresentation_String:
+			if !v.expectKind(path, typeName, n, ipld.Kind_String) {
+				return
+			}
+			s, _ := n.AsString()
+			expected := make([]string, len(t.members))
+			for i, m := range t.members {
+				if expected[i] = r.GetSerial(m); s == expected[i] {
+					return
+				}
+			}
+			v.errorf(path, typeName, "%q is not a member of the enum (expected one of %q)", s, expected)
+		case EnumRepresentation_Int:
+			if !v.expectKind(path, typeName, n, ipld.Kind_Int) {
+				return
+			}
+			i, _ := n.AsInt()
+			expected := make([]int, len(t.members))
+			for j, m := range t.members {
+				if expected[j], _ = r.GetSerial(m); i == int64(expected[j]) {
+					return
+				}
+			}
+			v.errorf(path, typeName, "%d is not a member of the enum (expected one of %d)", i, expected)
+		}
+	case *TypeList:
+		if !v.expectKind(path, typeName, n, ipld.Kind_List) {
+			return
+		}
+		for itr := n.ListIterator(); !itr.Done(); {
+			i, value, err := itr.Next()
+			if err != nil {
+				v.errorf(path, typeName, "%s", err)
+				return
+			}
+			v.validateValue(path.AppendSegment(ipld.PathSegmentOfInt(i)), t.valueType, t.valueNullable, value)
+		}
+	case *TypeMap:
+		switch t.representation.(type) {
+		case MapRepresentation_ListPairs:
+			if !v.expectKind(path, typeName, n, ipld.Kind_List) {
+				return
+			}
+			seen := make(map[string]bool, n.Length())
+			for itr := n.ListIterator(); !itr.Done(); {
+				idx, entry, err := itr.Next()
+				if err != nil {
+					v.errorf(path, typeName, "%s", err)
+					return
+				}
+				entryPath := path.AppendSegment(ipld.PathSegmentOfInt(idx))
+				key, value, ok := v.listPair(entryPath, typeName, entry)
+				if !ok {
+					continue
+				}
+				ks, _ := key.AsString()
+				if seen[ks] {
+					v.errorf(entryPath, typeName, "repeated key %q", ks)
+					continue
+				}
+				seen[ks] = true
+				v.validate(entryPath.AppendSegment(ipld.PathSegmentOfInt(0)), t.keyType, key)
+				v.validateValue(entryPath.AppendSegment(ipld.PathSegmentOfInt(1)), t.valueType, t.valueNullable, value)
+			}
+			return
+		}
+		if !v.expectKind(path, typeName, n, ipld.Kind_Map) {
+			return
+		}
+		for itr := n.MapIterator(); !itr.Done(); {
+			key, value, err := itr.Next()
+			if err != nil {
+				v.errorf(path, typeName, "%s", err)
+				return
+			}
+			ks, _ := key.AsString()
+			valuePath := path.AppendSegment(ipld.PathSegmentOfString(ks))
+			v.validate(valuePath, t.keyType, key)
+			v.validateValue(valuePath, t.valueType, t.valueNullable, value)
+		}
+	case *TypeStruct:
+		v.validateStruct(path, t, n, "")
+	case *TypeUnion:
+		v.validateUnion(path, t, n)
+	case *TypeUnit:
+		switch t.representation.(type) {
+		case UnitRepresentation_Null:
+			v.expectKind(path, typeName, n, ipld.Kind_Null)
+		case UnitRepresentation_EmptyMap:
+			if v.expectKind(path, typeName, n, ipld.Kind_Map) && n.Length() != 0 {
+				v.errorf(path, typeName, "expected an empty map, got %d entries", n.Length())
+			}
+		}
+	case *TypeAny:
+		// Anything goes, and what's inside isn't typed, so there's nothing to look into.
+	default:
+		v.errorf(path, typeName, "validation of %s types is not supported", typ.TypeKind())
+	}
+}
+
+// validateValue validates a list or map value, or a struct field,
+// which may be null if nullable is true.
+// Some types accept null as a value of their own, whether or not they're nullable.
+func (v *validator) validateValue(path ipld.Path, typeName TypeName, nullable bool, n ipld.Node) {
+	if n.IsNull() && !v.acceptsNull(typeName) {
+		if !nullable {
+			v.errorf(path, typeName, "value is null, but not nullable")
+		}
+		return
+	}
+	v.validate(path, typeName, n)
+}
+
+// acceptsNull returns true if null is a valid value of the named type:
+// that's the case for any, and for units represented as null.
+func (v *validator) acceptsNull(typeName TypeName) bool {
+	switch t := v.ts.namedTypes[typeName].(type) {
+	case *TypeAny:
+		return true
+	case *TypeUnit:
+		_, ok := t.representation.(UnitRepresentation_Null)
+		return ok
+	}
+	return false
+}
+
+// listPair checks that an entry of a listpairs representation is a list
+// holding a string key and a value, and returns them.
+func (v *validator) listPair(path ipld.Path, typeName TypeName, entry ipld.Node) (key, value ipld.Node, ok bool) {
+	if !v.expectKind(path, typeName, entry, ipld.Kind_List) {
+		return nil, nil, false
+	}
+	if entry.Length() != 2 {
+		v.errorf(path, typeName, "expected a key and a value, got a list of %d", entry.Length())
+		return nil, nil, false
+	}
+	key, err := entry.LookupByIndex(0)
+	if err == nil {
+		value, err = entry.LookupByIndex(1)
+	}
+	if err != nil {
+		v.errorf(path, typeName, "%s", err)
+		return nil, nil, false
+	}
+	if !v.expectKind(path.AppendSegment(ipld.PathSegmentOfInt(0)), typeName, key, ipld.Kind_String) {
+		return nil, nil, false
+	}
+	return key, value, true
+}
+
+// validateStruct checks a struct's representation.
+// If the struct is a member of an inline union, discriminantKey is the key
+// that union adds to the struct's map; otherwise, it's empty.
+func (v *validator) validateStruct(path ipld.Path, t *TypeStruct, n ipld.Node, discriminantKey string) {
+	switch r := t.representation.(type) {
+	case StructRepresentation_Map:
+		if !v.expectKind(path, t.name, n, ipld.Kind_Map) {
+			return
+		}
+		known := make(map[string]bool, len(t.fields)+1)
+		if discriminantKey != "" {
+			known[discriminantKey] = true
+		}
+		for _, f := range t.fields {
+			key := r.GetFieldKey(f)
+			known[key] = true
+			value, err := n.LookupByString(key)
+			if err != nil || value.IsAbsent() {
+				if _, ok := r.implicits[f.name]; !ok && !f.optional {
+					v.errorf(path, t.name, "missing required field %q", key)
+				}
+				continue
+			}
+			v.validateValue(path.AppendSegment(ipld.PathSegmentOfString(key)), f.typ, f.nullable, value)
+		}
+		for itr := n.MapIterator(); !itr.Done(); {
+			key, _, err := itr.Next()
+			if err != nil {
+				v.errorf(path, t.name, "%s", err)
+				return
+			}
+			if ks, _ := key.AsString(); !known[ks] {
+				v.errorf(path, t.name, "unexpected field %q", ks)
+			}
+		}
+	case StructRepresentation_Tuple:
+		if !v.expectKind(path, t.name, n, ipld.Kind_List) {
+			return
+		}
+		// Only trailing optional fields may be left out of a tuple.
+		required := len(t.fields)
+		for required > 0 && t.fields[required-1].optional {
+			required--
+		}
+		length := int(n.Length())
+		if length < required || length > len(t.fields) {
+			v.errorf(path, t.name, "expected a list of %d fields, got %d", len(t.fields), length)
+			return
+		}
+		for i, f := range t.fields[:length] {
+			value, err := n.LookupByIndex(int64(i))
+			if err != nil {
+				v.errorf(path, t.name, "%s", err)
+				return
+			}
+			v.validateValue(path.AppendSegment(ipld.PathSegmentOfInt(int64(i))), f.typ, f.nullable, value)
+		}
+	case StructRepresentation_Stringjoin:
+		if !v.expectKind(path, t.name, n, ipld.Kind_String) {
+			return
+		}
+		s, _ := n.AsString()
+		parts := strings.Split(s, r.sep)
+		if len(parts) != len(t.fields) {
+			v.errorf(path, t.name, "expected %d fields joined by %q, got %d", len(t.fields), r.sep, len(parts))
+			return
+		}
+		for i, f := range t.fields {
+			v.validate(path.AppendSegment(ipld.PathSegmentOfInt(int64(i))), f.typ, stringPart{s: parts[i]})
+		}
+	case StructRepresentation_ListPairs:
+		if !v.expectKind(path, t.name, n, ipld.Kind_List) {
+			return
+		}
+		seen := make(map[string]bool, n.Length())
+		for itr := n.ListIterator(); !itr.Done(); {
+			idx, entry, err := itr.Next()
+			if err != nil {
+				v.errorf(path, t.name, "%s", err)
+				return
+			}
+			entryPath := path.AppendSegment(ipld.PathSegmentOfInt(idx))
+			key, value, ok := v.listPair(entryPath, t.name, entry)
+			if !ok {
+				continue
+			}
+			ks, _ := key.AsString()
+			f, ok := t.fieldsMap[ks]
+			switch {
+			case !ok:
+				v.errorf(path, t.name, "unexpected field %q", ks)
+			case seen[ks]:
+				v.errorf(path, t.name, "repeated field %q", ks)
+			default:
+				seen[ks] = true
+				v.validateValue(entryPath.AppendSegment(ipld.PathSegmentOfInt(1)), f.typ, f.nullable, value)
+			}
+		}
+		for _, f := range t.fields {
+			if !f.optional && !seen[f.name] {
+				v.errorf(path, t.name, "missing required field %q", f.name)
+			}
+		}
+	case StructRepresentation_StringPairs:
+		if !v.expectKind(path, t.name, n, ipld.Kind_String) {
+			return
+		}
+		s, _ := n.AsString()
+		pairs, err := mixins.SplitPairs(s, r.sep2, r.sep1)
+		if err != nil {
+			v.errorf(path, t.name, "%s", err)
+			return
+		}
+		seen := make(map[string]bool, len(pairs))
+		for _, kv := range pairs {
+			f, ok := t.fieldsMap[kv[0]]
+			switch {
+			case !ok:
+				v.errorf(path, t.name, "unexpected field %q", kv[0])
+			case seen[kv[0]]:
+				v.errorf(path, t.name, "repeated field %q", kv[0])
+			default:
+				seen[kv[0]] = true
+				v.validate(path.AppendSegment(ipld.PathSegmentOfString(kv[0])), f.typ, stringPart{s: kv[1]})
+			}
+		}
+		for _, f := range t.fields {
+			if !f.optional && !seen[f.name] {
+				v.errorf(path, t.name, "missing required field %q", f.name)
+			}
+		}
+	default:
+		v.errorf(path, t.name, "validation of the struct representation %T is not supported", r)
+	}
+}
+
+func (v *validator) validateUnion(path ipld.Path, t *TypeUnion, n ipld.Node) {
+	switch r := t.representation.(type) {
+	case UnionRepresentation_Keyed:
+		if !v.expectKind(path, t.name, n, ipld.Kind_Map) {
+			return
+		}
+		if n.Length() != 1 {
+			v.errorf(path, t.name, "expected a map with a single entry, got %d entries", n.Length())
+			return
+		}
+		key, value, err := n.MapIterator().Next()
+		if err != nil {
+			v.errorf(path, t.name, "%s", err)
+			return
+		}
+		ks, _ := key.AsString()
+		member, ok := r.table[ks]
+		if !ok {
+			v.errorf(path, t.name, "%q is not a discriminant of the union", ks)
+			return
+		}
+		v.validate(path.AppendSegment(ipld.PathSegmentOfString(ks)), member, value)
+	case UnionRepresentation_Kinded:
+		member, ok := r.table[n.Kind()]
+		if !ok {
+			v.errorf(path, t.name, "kind %s does not match any member of the union", n.Kind())
+			return
+		}
+		v.validate(path, member, n)
+	case UnionRepresentation_Stringprefix:
+		if !v.expectKind(path, t.name, n, ipld.Kind_String) {
+			return
+		}
+		s, _ := n.AsString()
+		i := strings.Index(s, r.delim)
+		if i < 0 {
+			v.errorf(path, t.name, "missing delimiter %q", r.delim)
+			return
+		}
+		member, ok := r.table[s[:i]]
+		if !ok {
+			v.errorf(path, t.name, "%q is not a discriminant of the union", s[:i])
+			return
+		}
+		v.validate(path, member, stringPart{s: s[i+len(r.delim):]})
+	case UnionRepresentation_Envelope:
+		if !v.expectKind(path, t.name, n, ipld.Kind_Map) {
+			return
+		}
+		for itr := n.MapIterator(); !itr.Done(); {
+			key, _, err := itr.Next()
+			if err != nil {
+				v.errorf(path, t.name, "%s", err)
+				return
+			}
+			if ks, _ := key.AsString(); ks != r.discriminantKey && ks != r.contentKey {
+				v.errorf(path, t.name, "unexpected key %q", ks)
+			}
+		}
+		discriminant, err := n.LookupByString(r.discriminantKey)
+		if err != nil {
+			v.errorf(path, t.name, "missing discriminant key %q", r.discriminantKey)
+			return
+		}
+		dpath := path.AppendSegment(ipld.PathSegmentOfString(r.discriminantKey))
+		if !v.expectKind(dpath, t.name, discriminant, ipld.Kind_String) {
+			return
+		}
+		ds, _ := discriminant.AsString()
+		member, ok := r.table[ds]
+		if !ok {
+			v.errorf(dpath, t.name, "%q is not a discriminant of the union", ds)
+			return
+		}
+		content, err := n.LookupByString(r.contentKey)
+		if err != nil {
+			v.errorf(path, t.name, "missing content key %q", r.contentKey)
+			return
+		}
+		v.validate(path.AppendSegment(ipld.PathSegmentOfString(r.contentKey)), member, content)
+	case UnionRepresentation_Inline:
+		if !v.expectKind(path, t.name, n, ipld.Kind_Map) {
+			return
+		}
+		discriminant, err := n.LookupByString(r.discriminantKey)
+		if err != nil {
+			v.errorf(path, t.name, "missing discriminant key %q", r.discriminantKey)
+			return
+		}
+		dpath := path.AppendSegment(ipld.PathSegmentOfString(r.discriminantKey))
+		if !v.expectKind(dpath, t.name, discriminant, ipld.Kind_String) {
+			return
+		}
+		ds, _ := discriminant.AsString()
+		member, ok := r.table[ds]
+		if !ok {
+			v.errorf(dpath, t.name, "%q is not a discriminant of the union", ds)
+			return
+		}
+		st, ok := v.ts.namedTypes[member].(*TypeStruct)
+		if !ok {
+			v.errorf(path, member, "members of inline unions must be structs")
+			return
+		}
+		v.validateStruct(path, st, n, r.discriminantKey)
+	default:
+		v.errorf(path, t.name, "validation of the union representation %T is not supported", r)
+	}
+}
+
+// stringPart is a string node holding part of a stringjoin struct or
+// stringprefix union, so that it can be validated like any other node.
+// (basicnode can't be used here: its tests import this package.)
+type stringPart struct {
+	mixins.String
+	s string
+}
+
+func (n stringPart) AsString() (string, error)   { return n.s, nil }
+func (stringPart) Prototype() ipld.NodePrototype { return nil }
@@ -0,0 +1,696 @@
+package schema_test
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec/dagjson"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/schema"
+	schemaparser "github.com/ipld/go-ipld-prime/schema/parser"
+)
+
+func TestValidate(t *testing.T) {
+	ts, err := schemaparser.Parse(strings.NewReader(`
+type Person struct {
+	name String
+	nick optional nullable String (rename "nickname")
+	friends [&Person]
+	tags {String:nullable Int}
+	mood Mood
+	home Coords
+	shape Shape
+}
+
+type Mood enum {
+	| Happy
+	| Grumpy
+}
+
+type Level enum {
+	| Low ("low")
+	| High
+}
+
+type Priority enum {
+	| Low ("1")
+	| High ("10")
+} representation int
+
+type Pair struct {
+	left Int
+	right optional Int
+} representation tuple
+
+type Coords struct {
+	x String
+	y String
+} representation stringjoin {join ","}
+
+type Query struct {
+	q String
+	mood optional Mood
+} representation stringpairs {
+	innerDelim "="
+	entryDelim "&"
+}
+
+type Header struct {
+	name String
+	value optional nullable Int
+} representation listpairs
+
+type Headers {String:Header} representation listpairs
+
+type Shape union {
+	| Pair "pair"
+	| Coords "coords"
+} representation keyed
+
+type Value union {
+	| String string
+	| Pair list
+} representation kinded
+
+type Prefixed union {
+	| Mood "mood"
+	| Coords "coords"
+} representation stringprefix {delim ":"}
+
+type Message union {
+	| Mood "mood"
+	| Pair "pair"
+} representation envelope {
+	discriminantKey "type"
+	contentKey "value"
+}
+
+type Cat struct {
+	name String
+	lives Int (implicit "9")
+}
+
+type Pet union {
+	| Cat "cat"
+} representation inline {
+	discriminantKey "kind"
+}
+`))
+	Require(t, err, ShouldEqual, nil)
+
+	validate := func(typeName, data string) []string {
+		nb := basicnode.Prototype.Any.NewBuilder()
+		Require(t, dagjson.Decode(nb, strings.NewReader(data)), ShouldEqual, nil)
+		var msgs []string
+		for _, err := range schema.Validate(ts, schema.TypeName(typeName), nb.Build()) {
+			msgs = append(msgs, err.Error())
+		}
+		return msgs
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		Wish(t, validate("Person", `{
+			"name": "Alice",
+			"nickname": null,
+			"friends": [],
+			"tags": {"a": 1, "b": null},
+			"mood": "Happy",
+			"home": "1,2",
+			"shape": {"pair": [1]}
+		}`), ShouldEqual, []string(nil))
+		Wish(t, validate("Value", `"foo"`), ShouldEqual, []string(nil))
+		Wish(t, validate("Value", `[1, 2]`), ShouldEqual, []string(nil))
+		Wish(t, validate("Prefixed", `"mood:Grumpy"`), ShouldEqual, []string(nil))
+		Wish(t, validate("Prefixed", `"coords:3,4"`), ShouldEqual, []string(nil))
+		Wish(t, validate("Message", `{"type": "pair", "value": [1, 2]}`), ShouldEqual, []string(nil))
+		Wish(t, validate("Message", `{"value": "Happy", "type": "mood"}`), ShouldEqual, []string(nil))
+		Wish(t, validate("Pet", `{"name": "Tom", "kind": "cat"}`), ShouldEqual, []string(nil))
+		Wish(t, validate("Pet", `{"name": "Tom", "kind": "cat", "lives": 3}`), ShouldEqual, []string(nil))
+		Wish(t, validate("Query", `"q=a=b"`), ShouldEqual, []string(nil))
+		Wish(t, validate("Query", `"mood=Happy&q="`), ShouldEqual, []string(nil))
+		Wish(t, validate("Headers", `[["a", [["name", "x"]]], ["b", [["value", null], ["name", "y"]]]]`), ShouldEqual, []string(nil))
+		Wish(t, validate("Level", `"low"`), ShouldEqual, []string(nil))
+		Wish(t, validate("Level", `"High"`), ShouldEqual, []string(nil))
+		Wish(t, validate("Priority", `10`), ShouldEqual, []string(nil))
+	})
+	t.Run("invalid enum", func(t *testing.T) {
+		Wish(t, validate("Level", `"Low"`), ShouldEqual, []string{
+			`invalid data at "": does not match type Level: "Low" is not a member of the enum (expected one of ["low" "High"])`,
+		})
+		Wish(t, validate("Priority", `"High"`), ShouldEqual, []string{
+			`invalid data at "": does not match type Priority: expected kind int, got string`,
+		})
+		Wish(t, validate("Priority", `5`), ShouldEqual, []string{
+			`invalid data at "": does not match type Priority: 5 is not a member of the enum (expected one of [1 10])`,
+		})
+	})
+	t.Run("invalid struct", func(t *testing.T) {
+		Wish(t, validate("Person", `{
+			"name": 5,
+			"friends": [null],
+			"tags": {"a": "x"},
+			"mood": "Sleepy",
+			"home": "1,2,3",
+			"shape": {"circle": {}},
+			"age": 30
+		}`), ShouldEqual, []string{
+			`invalid data at "name": does not match type String: expected kind string, got int`,
+			`invalid data at "friends/0": does not match type Link__Person: value is null, but not nullable`,
+			`invalid data at "tags/a": does not match type Int: expected kind int, got string`,
+			`invalid data at "mood": does not match type Mood: "Sleepy" is not a member of the enum (expected one of ["Happy" "Grumpy"])`,
+			`invalid data at "home": does not match type Coords: expected 2 fields joined by ",", got 3`,
+			`invalid data at "shape": does not match type Shape: "circle" is not a discriminant of the union`,
+			`invalid data at "": does not match type Person: unexpected field "age"`,
+		})
+		Wish(t, validate("Person", `{}`), ShouldEqual, []string{
+			`invalid data at "": does not match type Person: missing required field "name"`,
+			`invalid data at "": does not match type Person: missing required field "friends"`,
+			`invalid data at "": does not match type Person: missing required field "tags"`,
+			`invalid data at "": does not match type Person: missing required field "mood"`,
+			`invalid data at "": does not match type Person: missing required field "home"`,
+			`invalid data at "": does not match type Person: missing required field "shape"`,
+		})
+	})
+	t.Run("invalid tuple", func(t *testing.T) {
+		Wish(t, validate("Pair", `[]`), ShouldEqual, []string{
+			`invalid data at "": does not match type Pair: expected a list of 2 fields, got 0`,
+		})
+		Wish(t, validate("Pair", `[1, "two"]`), ShouldEqual, []string{
+			`invalid data at "1": does not match type Int: expected kind int, got string`,
+		})
+	})
+	t.Run("invalid stringpairs", func(t *testing.T) {
+		Wish(t, validate("Query", `""`), ShouldEqual, []string{
+			`invalid data at "": does not match type Query: missing required field "q"`,
+		})
+		Wish(t, validate("Query", `"q&mood=Happy"`), ShouldEqual, []string{
+			`invalid data at "": does not match type Query: expected entry "q" to contain the delimiter "="`,
+		})
+		Wish(t, validate("Query", `"q=a&q=b&mood=Sleepy&page=2"`), ShouldEqual, []string{
+			`invalid data at "": does not match type Query: repeated field "q"`,
+			`invalid data at "mood": does not match type Mood: "Sleepy" is not a member of the enum (expected one of ["Happy" "Grumpy"])`,
+			`invalid data at "": does not match type Query: unexpected field "page"`,
+		})
+	})
+	t.Run("invalid listpairs", func(t *testing.T) {
+		Wish(t, validate("Headers", `{"a": [["name", "x"]]}`), ShouldEqual, []string{
+			`invalid data at "": does not match type Headers: expected kind list, got map`,
+		})
+		Wish(t, validate("Headers", `[["a"], [1, []], ["b", [["name", "x"]]], ["b", [["name", "y"]]]]`), ShouldEqual, []string{
+			`invalid data at "0": does not match type Headers: expected a key and a value, got a list of 1`,
+			`invalid data at "1/0": does not match type Headers: expected kind string, got int`,
+			`invalid data at "3": does not match type Headers: repeated key "b"`,
+		})
+		Wish(t, validate("Header", `[["value", "x"], ["size", 1], ["value", 2]]`), ShouldEqual, []string{
+			`invalid data at "0/1": does not match type Int: expected kind int, got string`,
+			`invalid data at "": does not match type Header: unexpected field "size"`,
+			`invalid data at "": does not match type Header: repeated field "value"`,
+			`invalid data at "": does not match type Header: missing required field "name"`,
+		})
+	})
+	t.Run("invalid union", func(t *testing.T) {
+		Wish(t, validate("Shape", `{"pair": [1], "coords": "1,2"}`), ShouldEqual, []string{
+			`invalid data at "": does not match type Shape: expected a map with a single entry, got 2 entries`,
+		})
+		Wish(t, validate("Shape", `{"coords": "1"}`), ShouldEqual, []string{
+			`invalid data at "coords": does not match type Coords: expected 2 fields joined by ",", got 1`,
+		})
+		Wish(t, validate("Value", `{}`), ShouldEqual, []string{
+			`invalid data at "": does not match type Value: kind map does not match any member of the union`,
+		})
+		Wish(t, validate("Prefixed", `"mood"`), ShouldEqual, []string{
+			`invalid data at "": does not match type Prefixed: missing delimiter ":"`,
+		})
+		Wish(t, validate("Prefixed", `"mood:Sleepy"`), ShouldEqual, []string{
+			`invalid data at "": does not match type Mood: "Sleepy" is not a member of the enum (expected one of ["Happy" "Grumpy"])`,
+		})
+		Wish(t, validate("Message", `{"type": "pair", "value": [1, "two"], "extra": 3}`), ShouldEqual, []string{
+			`invalid data at "": does not match type Message: unexpected key "extra"`,
+			`invalid data at "value/1": does not match type Int: expected kind int, got string`,
+		})
+		Wish(t, validate("Message", `{"type": "circle", "value": {}}`), ShouldEqual, []string{
+			`invalid data at "type": does not match type Message: "circle" is not a discriminant of the union`,
+		})
+		Wish(t, validate("Message", `{"type": "mood"}`), ShouldEqual, []string{
+			`invalid data at "": does not match type Message: missing content key "value"`,
+		})
+		Wish(t, validate("Message", `{"value": "Happy"}`), ShouldEqual, []string{
+			`invalid data at "": does not match type Message: missing discriminant key "type"`,
+		})
+		Wish(t, validate("Pet", `{"kind": "cat", "name": 3, "age": 4}`), ShouldEqual, []string{
+			`invalid data at "name": does not match type String: expected kind string, got int`,
+			`invalid data at "": does not match type Cat: unexpected field "age"`,
+		})
+		Wish(t, validate("Pet", `{"kind": "dog"}`), ShouldEqual, []string{
+			`invalid data at "kind": does not match type Pet: "dog" is not a discriminant of the union`,
+		})
+		Wish(t, validate("Pet", `{"name": "Tom"}`), ShouldEqual, []string{
+			`invalid data at "": does not match type Pet: missing discriminant key "kind"`,
+		})
+	})
+	t.Run("errors carry paths", func(t *testing.T) {
+		nb := basicnode.Prototype.Any.NewBuilder()
+		Require(t, dagjson.Decode(nb, strings.NewReader(`{"a": "x"}`)), ShouldEqual, nil)
+		errs := schema.Validate(ts, "Map__String__nullableInt", nb.Build())
+		Require(t, len(errs), ShouldEqual, 1)
+		err := errs[0].(schema.ErrInvalidData)
+		Wish(t, err.Path, ShouldEqual, ipld.ParsePath("a"))
+		Wish(t, err.TypeName, ShouldEqual, schema.TypeName("Int"))
+	})
+}
+
+func TestValidateUnitAndAny(t *testing.T) {
+	ts, err := schemaparser.Parse(strings.NewReader(`
+type Nothing unit representation null
+type Empty unit representation emptymap
+
+type Entry struct {
+	marker Nothing
+	done optional Empty
+	extra Any
+	more optional nullable Any
+}
+
+type Entries [Nothing]
+`))
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, ts.ValidateGraph(), ShouldEqual, []error(nil))
+
+	validate := func(typeName, data string) []string {
+		nb := basicnode.Prototype.Any.NewBuilder()
+		Require(t, dagjson.Decode(nb, strings.NewReader(data)), ShouldEqual, nil)
+		var msgs []string
+		for _, err := range schema.Validate(ts, schema.TypeName(typeName), nb.Build()) {
+			msgs = append(msgs, err.Error())
+		}
+		return msgs
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		Wish(t, schema.Validate(ts, "Nothing", ipld.Null), ShouldEqual, []error(nil)) // dagjson can't decode a lone null.
+		Wish(t, validate("Empty", `{}`), ShouldEqual, []string(nil))
+		Wish(t, validate("Any", `{"a": [1, null, "x"]}`), ShouldEqual, []string(nil))
+		Wish(t, validate("Entries", `[null, null]`), ShouldEqual, []string(nil))
+		Wish(t, validate("Entry", `{"marker": null, "extra": null}`), ShouldEqual, []string(nil))
+		Wish(t, validate("Entry", `{"marker": null, "done": {}, "extra": [true], "more": null}`), ShouldEqual, []string(nil))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		Wish(t, validate("Nothing", `{}`), ShouldEqual, []string{
+			`invalid data at "": does not match type Nothing: expected kind null, got map`,
+		})
+		Wish(t, validate("Empty", `{"a": 1}`), ShouldEqual, []string{
+			`invalid data at "": does not match type Empty: expected an empty map, got 1 entries`,
+		})
+		Wish(t, validate("Entry", `{"marker": true, "done": null}`), ShouldEqual, []string{
+			`invalid data at "marker": does not match type Nothing: expected kind null, got bool`,
+			`invalid data at "done": does not match type Empty: value is null, but not nullable`,
+			`invalid data at "": does not match type Entry: missing required field "extra"`,
+		})
+	})
+}
+
+func TestValidateGraphKindedUnion(t *testing.T) {
+	spawn := func(table map[ipld.Kind]schema.TypeName) []error {
+		ts := schema.TypeSystem{}
+		ts.Init()
+		ts.Accumulate(schema.SpawnString("String"))
+		ts.Accumulate(schema.SpawnInt("Int"))
+		ts.Accumulate(schema.SpawnList("List", "String", false))
+		ts.Accumulate(schema.SpawnUnion("Inner", []schema.TypeName{"String", "Int"},
+			schema.SpawnUnionRepresentationKinded(map[ipld.Kind]schema.TypeName{
+				ipld.Kind_String: "String",
+				ipld.Kind_Int:    "Int",
+			}),
+		))
+		members := []schema.TypeName{"String", "List"}
+		if table[ipld.Kind_Map] == "Inner" {
+			members = append(members, "Inner")
+		}
+		ts.Accumulate(schema.SpawnUnion("Outer", members, schema.SpawnUnionRepresentationKinded(table)))
+		return ts.ValidateGraph()
+	}
+
+	Wish(t, spawn(map[ipld.Kind]schema.TypeName{
+		ipld.Kind_String: "String",
+		ipld.Kind_List:   "List",
+	}), ShouldEqual, []error(nil))
+
+	var msgs []string
+	for _, err := range spawn(map[ipld.Kind]schema.TypeName{
+		ipld.Kind_String: "List",
+	}) {
+		msgs = append(msgs, err.Error())
+	}
+	sort.Strings(msgs)
+	Wish(t, msgs, ShouldEqual, []string{
+		"type Outer has member String, which has no kind in its representation",
+		"type Outer maps kind string to member List, but that type is represented as kind list",
+	})
+
+	msgs = nil
+	for _, err := range spawn(map[ipld.Kind]schema.TypeName{
+		ipld.Kind_String: "String",
+		ipld.Kind_List:   "List",
+		ipld.Kind_Map:    "Inner",
+	}) {
+		msgs = append(msgs, err.Error())
+	}
+	Wish(t, msgs, ShouldEqual, []string{
+		"type Outer has member Inner, whose representation kind can't be known in advance",
+	})
+}
+
+func TestValidateGraphKeyedUnion(t *testing.T) {
+	ts := schema.TypeSystem{}
+	ts.Init()
+	ts.Accumulate(schema.SpawnString("String"))
+	ts.Accumulate(schema.SpawnInt("Int"))
+	ts.Accumulate(schema.SpawnBool("Bool"))
+	ts.Accumulate(schema.SpawnUnion("Good", []schema.TypeName{"String", "Int"},
+		schema.SpawnUnionRepresentationKeyed(map[string]schema.TypeName{
+			"s": "String",
+			"i": "Int",
+		}),
+	))
+	Wish(t, ts.ValidateGraph(), ShouldEqual, []error(nil))
+
+	ts.Accumulate(schema.SpawnUnion("Bad", []schema.TypeName{"String", "Int"},
+		schema.SpawnUnionRepresentationKeyed(map[string]schema.TypeName{
+			"s":  "String",
+			"s2": "String",
+			"b":  "Bool",
+		}),
+	))
+	var msgs []string
+	for _, err := range ts.ValidateGraph() {
+		msgs = append(msgs, err.Error())
+	}
+	sort.Strings(msgs)
+	Wish(t, msgs, ShouldEqual, []string{
+		`type Bad has member Int, which has no discriminant in its representation`,
+		`type Bad has member String, which has more than one discriminant in its representation`,
+		`type Bad refers to type Bool in its representation (as discriminant "b"), but it's not a member`,
+	})
+}
+
+func TestValidateGraphEnvelopeUnion(t *testing.T) {
+	ts := schema.TypeSystem{}
+	ts.Init()
+	ts.Accumulate(schema.SpawnString("String"))
+	ts.Accumulate(schema.SpawnInt("Int"))
+	ts.Accumulate(schema.SpawnUnion("Good", []schema.TypeName{"String", "Int"},
+		schema.SpawnUnionRepresentationEnvelope("type", "value", map[string]schema.TypeName{
+			"s": "String",
+			"i": "Int",
+		}),
+	))
+	Wish(t, ts.ValidateGraph(), ShouldEqual, []error(nil))
+
+	ts.Accumulate(schema.SpawnUnion("Bad", []schema.TypeName{"String", "Int"},
+		schema.SpawnUnionRepresentationEnvelope("type", "type", map[string]schema.TypeName{
+			"s": "String",
+		}),
+	))
+	var msgs []string
+	for _, err := range ts.ValidateGraph() {
+		msgs = append(msgs, err.Error())
+	}
+	Wish(t, msgs, ShouldEqual, []string{
+		`type Bad uses the same key "type" for both its discriminant and its content`,
+		`type Bad has member Int, which has no discriminant in its representation`,
+	})
+}
+
+func TestValidateGraphInlineUnion(t *testing.T) {
+	ts := schema.TypeSystem{}
+	ts.Init()
+	ts.Accumulate(schema.SpawnString("String"))
+	ts.Accumulate(schema.SpawnStruct("Cat",
+		[]schema.StructField{schema.SpawnStructField("name", "String", false, false)},
+		schema.SpawnStructRepresentationMap(nil),
+	))
+	ts.Accumulate(schema.SpawnStruct("Dog",
+		[]schema.StructField{schema.SpawnStructField("name", "String", false, false)},
+		schema.SpawnStructRepresentationMap(map[string]string{"name": "kind"}),
+	))
+	ts.Accumulate(schema.SpawnStruct("Pair",
+		[]schema.StructField{schema.SpawnStructField("name", "String", false, false)},
+		schema.SpawnStructRepresentationTuple(),
+	))
+	ts.Accumulate(schema.SpawnUnion("Good", []schema.TypeName{"Cat"},
+		schema.SpawnUnionRepresentationInline("kind", map[string]schema.TypeName{
+			"cat": "Cat",
+		}),
+	))
+	Wish(t, ts.ValidateGraph(), ShouldEqual, []error(nil))
+
+	ts.Accumulate(schema.SpawnUnion("Bad", []schema.TypeName{"Dog", "Pair", "String"},
+		schema.SpawnUnionRepresentationInline("kind", map[string]schema.TypeName{
+			"dog":    "Dog",
+			"pair":   "Pair",
+			"string": "String",
+		}),
+	))
+	var msgs []string
+	for _, err := range ts.ValidateGraph() {
+		msgs = append(msgs, err.Error())
+	}
+	Wish(t, msgs, ShouldEqual, []string{
+		`type Bad has member Dog, whose field name uses the discriminant key "kind"`,
+		`type Bad has member Pair, but members of inline unions must have the map representation`,
+		`type Bad has member String, but members of inline unions must be structs`,
+	})
+}
+
+func TestValidateGraphTupleStruct(t *testing.T) {
+	ts := schema.TypeSystem{}
+	ts.Init()
+	ts.Accumulate(schema.SpawnString("String"))
+	ts.Accumulate(schema.SpawnStruct("Good",
+		[]schema.StructField{
+			schema.SpawnStructField("a", "String", false, false),
+			schema.SpawnStructField("b", "String", true, false),
+			schema.SpawnStructField("c", "String", true, false),
+		},
+		schema.SpawnStructRepresentationTuple(),
+	))
+	Wish(t, ts.ValidateGraph(), ShouldEqual, []error(nil))
+
+	ts.Accumulate(schema.SpawnStruct("Bad",
+		[]schema.StructField{
+			schema.SpawnStructField("a", "String", true, false),
+			schema.SpawnStructField("b", "String", false, false),
+		},
+		schema.SpawnStructRepresentationTuple(),
+	))
+	var msgs []string
+	for _, err := range ts.ValidateGraph() {
+		msgs = append(msgs, err.Error())
+	}
+	Wish(t, msgs, ShouldEqual, []string{
+		`type Bad has optional field a followed by required field b, but only trailing fields can be optional with the tuple representation`,
+	})
+}
+
+func TestValidateGraphStringPairsStruct(t *testing.T) {
+	ts := schema.TypeSystem{}
+	ts.Init()
+	ts.Accumulate(schema.SpawnString("String"))
+	fields := func() []schema.StructField {
+		return []schema.StructField{
+			schema.SpawnStructField("a", "String", false, false),
+			schema.SpawnStructField("b", "String", true, false),
+		}
+	}
+	ts.Accumulate(schema.SpawnStruct("Good", fields(), schema.SpawnStructRepresentationStringPairs("=", ",")))
+	Wish(t, ts.ValidateGraph(), ShouldEqual, []error(nil))
+
+	ts.Accumulate(schema.SpawnStruct("Empty", fields(), schema.SpawnStructRepresentationStringPairs("", ",")))
+	ts.Accumulate(schema.SpawnStruct("Same", fields(), schema.SpawnStructRepresentationStringPairs(",", ",")))
+	var msgs []string
+	for _, err := range ts.ValidateGraph() {
+		msgs = append(msgs, err.Error())
+	}
+	sort.Strings(msgs)
+	Wish(t, msgs, ShouldEqual, []string{
+		`type Empty has an empty delimiter in its stringpairs representation`,
+		`type Same uses the same delimiter "," within and between its entries`,
+	})
+}
+
+func TestValidateGraphStringjoinStruct(t *testing.T) {
+	ts := schema.TypeSystem{}
+	ts.Init()
+	ts.Accumulate(schema.SpawnString("String"))
+	ts.Accumulate(schema.SpawnInt("Int"))
+	ts.Accumulate(schema.SpawnStruct("Good",
+		[]schema.StructField{
+			schema.SpawnStructField("a", "String", false, false),
+			schema.SpawnStructField("b", "String", false, false),
+		},
+		schema.SpawnStructRepresentationStringjoin(":"),
+	))
+	ts.Accumulate(schema.SpawnStruct("Nested",
+		[]schema.StructField{
+			schema.SpawnStructField("a", "Good", false, false),
+			schema.SpawnStructField("b", "String", false, false),
+		},
+		schema.SpawnStructRepresentationStringjoin("-"),
+	))
+	Wish(t, ts.ValidateGraph(), ShouldEqual, []error(nil))
+
+	ts.Accumulate(schema.SpawnStruct("Empty",
+		[]schema.StructField{schema.SpawnStructField("a", "String", false, false)},
+		schema.SpawnStructRepresentationStringjoin(""),
+	))
+	ts.Accumulate(schema.SpawnStruct("WithInt",
+		[]schema.StructField{schema.SpawnStructField("a", "Int", false, false)},
+		schema.SpawnStructRepresentationStringjoin(":"),
+	))
+	var msgs []string
+	for _, err := range ts.ValidateGraph() {
+		msgs = append(msgs, err.Error())
+	}
+	sort.Strings(msgs)
+	Wish(t, msgs, ShouldEqual, []string{
+		`type Empty has an empty delimiter in its stringjoin representation`,
+		`type WithInt has field a of type Int, which is represented as kind int, but stringjoin fields must be strings`,
+	})
+}
+
+func TestValidateGraphStructImplicits(t *testing.T) {
+	ts := schema.TypeSystem{}
+	ts.Init()
+	ts.Accumulate(schema.SpawnString("String"))
+	ts.Accumulate(schema.SpawnInt("Int"))
+	ts.Accumulate(schema.SpawnBool("Bool"))
+	ts.Accumulate(schema.SpawnStruct("Good",
+		[]schema.StructField{
+			schema.SpawnStructField("s", "String", false, false),
+			schema.SpawnStructField("i", "Int", false, true),
+			schema.SpawnStructField("b", "Bool", false, false),
+		},
+		schema.SpawnStructRepresentationMapWithImplicits(nil, map[string]schema.ImplicitValue{
+			"s": schema.SpawnImplicitValueString("x"),
+			"i": schema.SpawnImplicitValueInt(1),
+			"b": schema.SpawnImplicitValueBool(false),
+		}),
+	))
+	Wish(t, ts.ValidateGraph(), ShouldEqual, []error(nil))
+
+	ts.Accumulate(schema.SpawnStruct("Bad",
+		[]schema.StructField{
+			schema.SpawnStructField("s", "String", false, false),
+			schema.SpawnStructField("o", "Int", true, false),
+			schema.SpawnStructField("l", "Bool", false, false),
+		},
+		schema.SpawnStructRepresentationMapWithImplicits(nil, map[string]schema.ImplicitValue{
+			"s":     schema.SpawnImplicitValueInt(1),
+			"o":     schema.SpawnImplicitValueInt(1),
+			"l":     schema.ImplicitValue_EmptyList{},
+			"ghost": schema.SpawnImplicitValueBool(true),
+		}),
+	))
+	var msgs []string
+	for _, err := range ts.ValidateGraph() {
+		msgs = append(msgs, err.Error())
+	}
+	sort.Strings(msgs)
+	Wish(t, msgs, ShouldEqual, []string{
+		`type Bad has an implicit Int value for field s, but the field's type String is of kind String`,
+		`type Bad has an implicit empty list or map for field l, which is not supported yet`,
+		`type Bad has an implicit value for ghost in its representation, but it's not a field`,
+		`type Bad has field o, which is optional, so it can't also have an implicit value`,
+	})
+}
+
+func TestValidateGraphEnum(t *testing.T) {
+	ts := schema.TypeSystem{}
+	ts.Init()
+	ts.Accumulate(schema.SpawnEnum("Plain", []string{"A", "B"}))
+	ts.Accumulate(schema.SpawnEnumWithRepresentation("Renamed", []string{"A", "B"},
+		schema.SpawnEnumRepresentationString(map[string]string{"A": "a", "B": "b"}),
+	))
+	ts.Accumulate(schema.SpawnEnumWithRepresentation("Ints", []string{"A", "B"},
+		schema.SpawnEnumRepresentationInt(map[string]int{"A": 0, "B": 1}),
+	))
+	Wish(t, ts.ValidateGraph(), ShouldEqual, []error(nil))
+
+	ts.Accumulate(schema.SpawnEnumWithRepresentation("Clash", []string{"A", "B"},
+		schema.SpawnEnumRepresentationString(map[string]string{"A": "B"}),
+	))
+	ts.Accumulate(schema.SpawnEnumWithRepresentation("Stranger", []string{"A"},
+		schema.SpawnEnumRepresentationString(map[string]string{"C": "c"}),
+	))
+	ts.Accumulate(schema.SpawnEnumWithRepresentation("MissingInt", []string{"A", "B"},
+		schema.SpawnEnumRepresentationInt(map[string]int{"A": 0}),
+	))
+	ts.Accumulate(schema.SpawnEnumWithRepresentation("SameInt", []string{"A", "B"},
+		schema.SpawnEnumRepresentationInt(map[string]int{"A": 3, "B": 3}),
+	))
+	ts.Accumulate(schema.SpawnMap("IntsKeyed", "Ints", "Plain", false))
+	var msgs []string
+	for _, err := range ts.ValidateGraph() {
+		msgs = append(msgs, err.Error())
+	}
+	sort.Strings(msgs)
+	Wish(t, msgs, ShouldEqual, []string{
+		`type Clash has members A and B, which are both represented as "B"`,
+		`type IntsKeyed has key type Ints, which is represented as kind int, but map keys must be strings`,
+		`type MissingInt has member B, which has no int value in its representation`,
+		`type SameInt has members A and B, which are both represented as 3`,
+		`type Stranger refers to C in its representation, but it's not a member`,
+	})
+}
+
+func TestErrInvalidEnumMember(t *testing.T) {
+	err := schema.ErrInvalidEnumMember{TypeName: "main.Mood", Value: "Sleepy", Expected: []interface{}{"Happy", "Grumpy"}}
+	Wish(t, err.Error(), ShouldEqual, `invalid value for enum main.Mood: "Sleepy" is not one of "Happy", "Grumpy"`)
+	err = schema.ErrInvalidEnumMember{TypeName: "main.Level.Repr", Value: int64(5), Expected: []interface{}{int64(1), int64(10)}}
+	Wish(t, err.Error(), ShouldEqual, `invalid value for enum main.Level.Repr: 5 is not one of 1, 10`)
+}
+
+func TestErrInvalidUnionDiscriminant(t *testing.T) {
+	err := schema.ErrInvalidUnionDiscriminant{TypeName: "main.Shape.Repr", Discriminant: "circle", Expected: []string{"pair", "coords"}}
+	Wish(t, err.Error(), ShouldEqual, `invalid discriminant for union main.Shape.Repr: "circle" is not one of "pair", "coords"`)
+}
+
+func TestValidateGraphUnit(t *testing.T) {
+	ts := schema.TypeSystem{}
+	ts.Init()
+	ts.Accumulate(schema.SpawnUnit("Nothing", schema.SpawnUnitRepresentationNull()))
+	ts.Accumulate(schema.SpawnUnit("Empty", schema.SpawnUnitRepresentationEmptyMap()))
+	ts.Accumulate(schema.SpawnString("String"))
+	ts.Accumulate(schema.SpawnStruct("Good",
+		[]schema.StructField{
+			schema.SpawnStructField("a", "Nothing", true, false),
+			schema.SpawnStructField("b", "Empty", true, true),
+		},
+		schema.SpawnStructRepresentationMap(nil),
+	))
+	ts.Accumulate(schema.SpawnList("GoodList", "Empty", true))
+	Wish(t, ts.ValidateGraph(), ShouldEqual, []error(nil))
+
+	ts.Accumulate(schema.SpawnStruct("Bad",
+		[]schema.StructField{
+			schema.SpawnStructField("a", "Nothing", false, true),
+		},
+		schema.SpawnStructRepresentationMap(nil),
+	))
+	ts.Accumulate(schema.SpawnList("BadList", "Nothing", true))
+	ts.Accumulate(schema.SpawnMap("BadMap", "String", "Nothing", true))
+	var msgs []string
+	for _, err := range ts.ValidateGraph() {
+		msgs = append(msgs, err.Error())
+	}
+	sort.Strings(msgs)
+	Wish(t, msgs, ShouldEqual, []string{
+		`type Bad has nullable field a, but its type Nothing is represented as null, so null would be ambiguous`,
+		`type BadList has nullable values, but their type Nothing is represented as null, so null would be ambiguous`,
+		`type BadMap has nullable values, but their type Nothing is represented as null, so null would be ambiguous`,
+	})
+}
@@ -0,0 +1,115 @@
+// Package bsadapter connects block stores which are keyed by CIDs,
+// such as the Blockstore and BlockService from go-ipfs,
+// with the storage functions of an ipld.LinkSystem, in either direction.
+//
+// To avoid depending on go-ipfs, the block stores are represented by
+// plain Getter and Putter functions over raw block data;
+// adapting a go-ipfs Blockstore takes a couple of lines:
+//
+//		lsys.StorageReadOpener = bsadapter.ReadOpener(func(ctx context.Context, c cid.Cid) ([]byte, error) {
+//			blk, err := bs.Get(c)
+//			if err != nil {
+//				return nil, err
+//			}
+//			return blk.RawData(), nil
+//		})
+//		lsys.StorageWriteOpener = bsadapter.WriteOpener(func(ctx context.Context, c cid.Cid, data []byte) error {
+//			blk, err := blocks.NewBlockWithCid(data, c)
+//			if err != nil {
+//				return err
+//			}
+//			return bs.Put(blk)
+//		})
+//
+// Going the other way, GetterOf and PutterOf expose a LinkSystem's storage
+// to code which deals in CIDs and raw blocks.
+//
+// Only cidlink.Link links are supported.
+package bsadapter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	cid "github.com/ipfs/go-cid"
+
+	"github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+)
+
+// Getter returns the raw data of the block with the given CID.
+type Getter func(ctx context.Context, c cid.Cid) ([]byte, error)
+
+// Putter stores the raw data of a block under the given CID.
+type Putter func(ctx context.Context, c cid.Cid, data []byte) error
+
+// ReadOpener returns an ipld.BlockReadOpener which reads blocks with get.
+// Errors from get are returned unchanged.
+func ReadOpener(get Getter) ipld.BlockReadOpener {
+	return func(lnkCtx ipld.LinkContext, lnk ipld.Link) (io.Reader, error) {
+		c, err := toCid(lnk)
+		if err != nil {
+			return nil, err
+		}
+		data, err := get(lnkCtx.Ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		return bytes.NewReader(data), nil
+	}
+}
+
+// WriteOpener returns an ipld.BlockWriteOpener which buffers each block
+// in memory, and stores it with put when the write is committed.
+func WriteOpener(put Putter) ipld.BlockWriteOpener {
+	return func(lnkCtx ipld.LinkContext) (io.Writer, ipld.BlockWriteCommitter, error) {
+		var buf bytes.Buffer
+		return &buf, func(lnk ipld.Link) error {
+			c, err := toCid(lnk)
+			if err != nil {
+				return err
+			}
+			return put(lnkCtx.Ctx, c, buf.Bytes())
+		}, nil
+	}
+}
+
+// GetterOf returns a Getter which reads blocks with the given
+// ipld.BlockReadOpener, such as a LinkSystem's StorageReadOpener.
+// Note that the data isn't checked against the CID's hash.
+func GetterOf(open ipld.BlockReadOpener) Getter {
+	return func(ctx context.Context, c cid.Cid) ([]byte, error) {
+		r, err := open(ipld.LinkContext{Ctx: ctx}, cidlink.Link{Cid: c})
+		if err != nil {
+			return nil, err
+		}
+		return ioutil.ReadAll(r)
+	}
+}
+
+// PutterOf returns a Putter which writes blocks with the given
+// ipld.BlockWriteOpener, such as a LinkSystem's StorageWriteOpener.
+// Note that the data isn't checked against the CID's hash.
+func PutterOf(open ipld.BlockWriteOpener) Putter {
+	return func(ctx context.Context, c cid.Cid, data []byte) error {
+		w, commit, err := open(ipld.LinkContext{Ctx: ctx})
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+		return commit(cidlink.Link{Cid: c})
+	}
+}
+
+func toCid(lnk ipld.Link) (cid.Cid, error) {
+	clnk, ok := lnk.(cidlink.Link)
+	if !ok {
+		return cid.Undef, fmt.Errorf("bsadapter: can only handle cidlink.Link links; got %T", lnk)
+	}
+	return clnk.Cid, nil
+}
@@ -0,0 +1,73 @@
+package bsadapter_test
+
+import (
+	"context"
+	"testing"
+
+	cid "github.com/ipfs/go-cid"
+	. "github.com/warpfork/go-wish"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	_ "github.com/ipld/go-ipld-prime/codec/raw"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/storage"
+	"github.com/ipld/go-ipld-prime/storage/bsadapter"
+)
+
+// blockstore stands in for a go-ipfs Blockstore.
+type blockstore map[cid.Cid][]byte
+
+func (bs blockstore) get(ctx context.Context, c cid.Cid) ([]byte, error) {
+	data, ok := bs[c]
+	if !ok {
+		return nil, storage.ErrNotFound{Link: cidlink.Link{Cid: c}}
+	}
+	return data, nil
+}
+
+func (bs blockstore) put(ctx context.Context, c cid.Cid, data []byte) error {
+	bs[c] = data
+	return nil
+}
+
+func TestAdapters(t *testing.T) {
+	lp := cidlink.LinkPrototype{Prefix: cid.Prefix{
+		Version:  1,
+		Codec:    0x55, // raw
+		MhType:   0x12, // sha2-256
+		MhLength: 32,
+	}}
+	n := basicnode.NewBytes([]byte("hello"))
+
+	t.Run("blockstore as storage", func(t *testing.T) {
+		bs := blockstore{}
+		lsys := cidlink.DefaultLinkSystem()
+		lsys.StorageReadOpener = bsadapter.ReadOpener(bs.get)
+		lsys.StorageWriteOpener = bsadapter.WriteOpener(bs.put)
+
+		lnk, err := lsys.Store(ipld.LinkContext{}, lp, n)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, string(bs[lnk.(cidlink.Link).Cid]), ShouldEqual, "hello")
+		n2, err := lsys.Load(ipld.LinkContext{}, lnk, basicnode.Prototype.Bytes)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, n2, ShouldEqual, n)
+	})
+	t.Run("storage as blockstore", func(t *testing.T) {
+		store := storage.Memory{}
+		get := bsadapter.GetterOf((&store).OpenRead)
+		put := bsadapter.PutterOf((&store).OpenWrite)
+
+		lsys := cidlink.DefaultLinkSystem()
+		lnk, err := lsys.ComputeLink(lp, n)
+		Require(t, err, ShouldEqual, nil)
+		c := lnk.(cidlink.Link).Cid
+		_, err = get(context.Background(), c)
+		Wish(t, err, ShouldEqual, storage.ErrNotFound{Link: lnk})
+
+		Require(t, put(context.Background(), c, []byte("hello")), ShouldEqual, nil)
+		data, err := get(context.Background(), c)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, string(data), ShouldEqual, "hello")
+	})
+}
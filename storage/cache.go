@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"container/list"
+	"reflect"
+	"sync"
+
+	"github.com/ipld/go-ipld-prime"
+)
+
+// ReadCache keeps the nodes loaded via a LinkSystem in memory,
+// so that loading them again doesn't need to read nor decode their blocks again.
+// This is useful when traversing graphs which share many blocks,
+// or when traversing the same graph repeatedly.
+//
+// Nodes are cached by link and by the NodePrototype they were built with,
+// as the same block loaded with different prototypes gives different nodes.
+// Prototypes which can't be compared, and so can't be used as map keys,
+// are never cached.
+//
+// The total size of the blocks which the cached nodes were decoded from
+// is kept under a maximum; when it's reached, the least recently used nodes
+// are evicted first.
+//
+// It implements ipld.NodeCache:
+//
+//		lsys.NodeCache = storage.NewReadCache(64 << 20)
+//
+// Note that a LinkSystem doesn't verify the hash of a block again
+// when its node comes from the cache, as it was verified when first loaded.
+// A ReadCache is safe for concurrent use.
+type ReadCache struct {
+	maxSize int64
+
+	mu      sync.Mutex
+	lru     list.List // of *cacheEntry, most recently used first
+	entries map[cacheKey]*list.Element
+	size    int64
+}
+
+type cacheKey struct {
+	lnk ipld.Link
+	np  ipld.NodePrototype
+}
+
+type cacheEntry struct {
+	key  cacheKey
+	node ipld.Node
+	size int64
+}
+
+// NewReadCache returns a ReadCache which caches nodes decoded from
+// up to maxSize bytes of blocks.
+func NewReadCache(maxSize int64) *ReadCache {
+	return &ReadCache{
+		maxSize: maxSize,
+		entries: make(map[cacheKey]*list.Element),
+	}
+}
+
+// cacheable reports whether a link and prototype can be used as a map key;
+// comparing interfaces holding uncomparable values, such as slices, panics.
+func cacheable(lnk ipld.Link, np ipld.NodePrototype) bool {
+	return reflect.TypeOf(lnk).Comparable() && reflect.TypeOf(np).Comparable()
+}
+
+// GetNode implements ipld.NodeCache.
+func (c *ReadCache) GetNode(lnk ipld.Link, np ipld.NodePrototype) (ipld.Node, bool) {
+	if !cacheable(lnk, np) {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[cacheKey{lnk, np}]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return elem.Value.(*cacheEntry).node, true
+}
+
+// PutNode implements ipld.NodeCache.
+func (c *ReadCache) PutNode(lnk ipld.Link, np ipld.NodePrototype, n ipld.Node, size int64) {
+	if !cacheable(lnk, np) {
+		return
+	}
+	if size > c.maxSize {
+		return // it would evict everything else, and not fit anyway
+	}
+	key := cacheKey{lnk, np}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; ok {
+		return // another loader added it in the meantime
+	}
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key, n, size})
+	c.size += size
+	for c.size > c.maxSize {
+		entry := c.lru.Remove(c.lru.Back()).(*cacheEntry)
+		delete(c.entries, entry.key)
+		c.size -= entry.size
+	}
+}
+
+// Size returns the total size, in bytes, of the blocks
+// which the cached nodes were decoded from.
+func (c *ReadCache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size
+}
+
+// Len returns the number of cached nodes.
+func (c *ReadCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
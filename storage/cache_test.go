@@ -0,0 +1,78 @@
+package storage_test
+
+import (
+	"io"
+	"testing"
+
+	cid "github.com/ipfs/go-cid"
+	. "github.com/warpfork/go-wish"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	_ "github.com/ipld/go-ipld-prime/codec/raw"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/storage"
+)
+
+func TestReadCache(t *testing.T) {
+	lp := cidlink.LinkPrototype{Prefix: cid.Prefix{
+		Version:  1,
+		Codec:    0x55, // raw
+		MhType:   0x12, // sha2-256
+		MhLength: 32,
+	}}
+	store := storage.Memory{}
+	lsys := cidlink.DefaultLinkSystem()
+	lsys.StorageWriteOpener = (&store).OpenWrite
+	var links []ipld.Link
+	for _, s := range []string{"aaaa", "bbbb", "cccc"} {
+		lnk, err := lsys.Store(ipld.LinkContext{}, lp, basicnode.NewBytes([]byte(s)))
+		Require(t, err, ShouldEqual, nil)
+		links = append(links, lnk)
+	}
+
+	reads := 0
+	lsys.StorageReadOpener = func(lnkCtx ipld.LinkContext, lnk ipld.Link) (io.Reader, error) {
+		reads++
+		return store.OpenRead(lnkCtx, lnk)
+	}
+	cache := storage.NewReadCache(8)
+	lsys.NodeCache = cache
+	load := func(lnk ipld.Link) {
+		n, err := lsys.Load(ipld.LinkContext{}, lnk, basicnode.Prototype.Bytes)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, n.Kind(), ShouldEqual, ipld.Kind_Bytes)
+	}
+
+	load(links[0])
+	load(links[0])
+	Wish(t, reads, ShouldEqual, 1)
+	load(links[1])
+	Wish(t, cache.Len(), ShouldEqual, 2)
+	Wish(t, cache.Size(), ShouldEqual, int64(8))
+
+	// The cache is full; using the first block again makes the second one
+	// the least recently used, so it's the one which gets evicted.
+	load(links[0])
+	load(links[2])
+	Wish(t, reads, ShouldEqual, 3)
+	Wish(t, cache.Len(), ShouldEqual, 2)
+	load(links[0])
+	Wish(t, reads, ShouldEqual, 3)
+	load(links[1])
+	Wish(t, reads, ShouldEqual, 4)
+
+	// The same block loaded with another prototype is another node.
+	n, err := lsys.Load(ipld.LinkContext{}, links[1], basicnode.Prototype.Any)
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, n, ShouldEqual, basicnode.NewBytes([]byte("bbbb")))
+	Wish(t, reads, ShouldEqual, 5)
+	Wish(t, cache.Len(), ShouldEqual, 2)
+
+	// Errors aren't cached.
+	lnk, err := lsys.ComputeLink(lp, basicnode.NewBytes([]byte("dddd")))
+	Require(t, err, ShouldEqual, nil)
+	_, err = lsys.Load(ipld.LinkContext{}, lnk, basicnode.Prototype.Bytes)
+	Wish(t, err, ShouldEqual, storage.ErrNotFound{Link: lnk})
+	Wish(t, cache.Len(), ShouldEqual, 2)
+}
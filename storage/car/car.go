@@ -0,0 +1,268 @@
+// Package car reads and writes CARv1 archives: files which contain a set of
+// blocks, along with the links to one or more roots among them.
+// The format is described at https://ipld.io/specs/transport/car/carv1/.
+//
+// Write exports the blocks which a selector reaches from a root,
+// by running a traversal over a LinkSystem.
+// ReadStore goes in the other direction, and serves the blocks in an archive
+// as a read-only storage for a LinkSystem.
+//
+// Blocks are identified by CIDs, so only cidlink.Link links are supported.
+package car
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	cid "github.com/ipfs/go-cid"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec/dagcbor"
+	"github.com/ipld/go-ipld-prime/fluent"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/storage"
+	"github.com/ipld/go-ipld-prime/traversal"
+	"github.com/ipld/go-ipld-prime/traversal/selector"
+)
+
+// Write writes a CARv1 archive to w, with root as its only root,
+// and containing the root block and every block which the selector reaches
+// from it, each only once, in the order in which the traversal loads them.
+//
+// The blocks are read with the LinkSystem's StorageReadOpener,
+// and each is checked against its link's hash before being written.
+// The nodes for the traversal are built with the LinkSystem's
+// NodePrototypeChooser, or with basicnode.Prototype.Any if it has none.
+func Write(w io.Writer, lsys ipld.LinkSystem, root ipld.Link, sel selector.Selector) error {
+	if err := writeHeader(w, []ipld.Link{root}); err != nil {
+		return err
+	}
+	read := lsys.StorageReadOpener
+	if read == nil {
+		return fmt.Errorf("car: no storage configured for reading")
+	}
+	written := make(map[ipld.Link]bool)
+	lsys.StorageReadOpener = func(lnkCtx ipld.LinkContext, lnk ipld.Link) (io.Reader, error) {
+		r, err := read(lnkCtx, lnk)
+		if err != nil {
+			return nil, err
+		}
+		data, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		if !written[lnk] {
+			// Don't trust the storage to put bad blocks into the archive.
+			if err := checkHash(lsys, lnk, data); err != nil {
+				return nil, err
+			}
+			if err := writeSection(w, lnk, data); err != nil {
+				return nil, err
+			}
+			written[lnk] = true
+		}
+		return bytes.NewReader(data), nil
+	}
+	if lsys.NodePrototypeChooser == nil {
+		lsys.NodePrototypeChooser = func(ipld.Link, ipld.LinkContext) (ipld.NodePrototype, error) {
+			return basicnode.Prototype.Any, nil
+		}
+	}
+	n, err := lsys.Load(ipld.LinkContext{}, root, nil)
+	if err != nil {
+		return err
+	}
+	return traversal.Progress{
+		Cfg: &traversal.Config{LinkSystem: lsys},
+	}.WalkAdv(n, sel, func(traversal.Progress, ipld.Node, traversal.VisitReason) error {
+		return nil
+	})
+}
+
+func checkHash(lsys ipld.LinkSystem, lnk ipld.Link, data []byte) error {
+	hasher, err := lsys.HasherChooser(lnk.Prototype())
+	if err != nil {
+		return err
+	}
+	hasher.Write(data)
+	if lnk2 := lnk.Prototype().BuildLink(hasher.Sum(nil)); lnk2 != lnk {
+		return ipld.ErrHashMismatch{Actual: lnk2, Expected: lnk}
+	}
+	return nil
+}
+
+func writeHeader(w io.Writer, roots []ipld.Link) error {
+	n := fluent.MustBuildMap(basicnode.Prototype.Map, 2, func(na fluent.MapAssembler) {
+		na.AssembleEntry("roots").CreateList(int64(len(roots)), func(na fluent.ListAssembler) {
+			for _, root := range roots {
+				na.AssembleValue().AssignLink(root)
+			}
+		})
+		na.AssembleEntry("version").AssignInt(1)
+	})
+	var buf bytes.Buffer
+	if err := (dagcbor.EncodeOptions{Canonical: true}).Encode(n, &buf); err != nil {
+		return err
+	}
+	return writeVarintPrefixed(w, buf.Bytes())
+}
+
+func writeSection(w io.Writer, lnk ipld.Link, data []byte) error {
+	clnk, ok := lnk.(cidlink.Link)
+	if !ok {
+		return fmt.Errorf("car: can only store cidlink.Link links; got %T", lnk)
+	}
+	return writeVarintPrefixed(w, clnk.Bytes(), data)
+}
+
+// writeVarintPrefixed writes the concatenation of chunks,
+// prefixed by its total length as an unsigned varint.
+func writeVarintPrefixed(w io.Writer, chunks ...[]byte) error {
+	var size uint64
+	for _, chunk := range chunks {
+		size += uint64(len(chunk))
+	}
+	var prefix [binary.MaxVarintLen64]byte
+	if _, err := w.Write(prefix[:binary.PutUvarint(prefix[:], size)]); err != nil {
+		return err
+	}
+	for _, chunk := range chunks {
+		if _, err := w.Write(chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadStore serves the blocks in a CARv1 archive.
+//
+// Its OpenRead method conforms to ipld.BlockReadOpener,
+// so it can be used as the read-only storage of a LinkSystem:
+//
+//		store, err := car.NewReadStore(file)
+//		[...]
+//		lsys.StorageReadOpener = store.OpenRead
+//
+// Only an index of where each block is in the archive is kept in memory;
+// blocks are read from the archive as they're opened.
+type ReadStore struct {
+	ra    io.ReaderAt
+	roots []ipld.Link
+	index map[ipld.Link]section
+}
+
+type section struct {
+	offset int64
+	size   int64
+}
+
+// NewReadStore reads the header of the CARv1 archive in ra, and indexes the
+// blocks in it.
+// The archive must not be modified while the ReadStore is used.
+func NewReadStore(ra io.ReaderAt) (*ReadStore, error) {
+	store := &ReadStore{ra: ra, index: make(map[ipld.Link]section)}
+	br := bufio.NewReader(io.NewSectionReader(ra, 0, 1<<63-1))
+	var offset int64
+	// nextSection reads the length prefix of the next section,
+	//  returning io.EOF if there are no more.
+	nextSection := func() (int64, error) {
+		size, err := binary.ReadUvarint(br)
+		if err != nil {
+			return 0, err
+		}
+		var prefix [binary.MaxVarintLen64]byte
+		offset += int64(binary.PutUvarint(prefix[:], size))
+		if size > 1<<32 {
+			return 0, fmt.Errorf("car: section at offset %d is too large: %d bytes", offset, size)
+		}
+		return int64(size), nil
+	}
+
+	size, err := nextSection()
+	if err != nil {
+		return nil, fmt.Errorf("car: could not read header: %w", err)
+	}
+	nb := basicnode.Prototype.Map.NewBuilder()
+	if err := dagcbor.Decode(nb, io.LimitReader(br, size)); err != nil {
+		return nil, fmt.Errorf("car: could not decode header: %w", err)
+	}
+	if err := store.readHeader(nb.Build()); err != nil {
+		return nil, fmt.Errorf("car: invalid header: %w", err)
+	}
+	offset += size
+
+	for {
+		size, err := nextSection()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("car: could not read section at offset %d: %w", offset, err)
+		}
+		peek := size
+		if peek > int64(br.Size()) {
+			peek = int64(br.Size())
+		}
+		prefix, err := br.Peek(int(peek))
+		if err != nil {
+			return nil, fmt.Errorf("car: could not read section at offset %d: %w", offset, err)
+		}
+		cidLen, c, err := cid.CidFromBytes(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("car: invalid cid in section at offset %d: %w", offset, err)
+		}
+		if _, err := br.Discard(int(size)); err != nil {
+			return nil, fmt.Errorf("car: could not read section at offset %d: %w", offset, err)
+		}
+		store.index[cidlink.Link{Cid: c}] = section{offset + int64(cidLen), size - int64(cidLen)}
+		offset += size
+	}
+	return store, nil
+}
+
+func (store *ReadStore) readHeader(n ipld.Node) error {
+	version, err := n.LookupByString("version")
+	if err != nil {
+		return err
+	}
+	if v, err := version.AsInt(); err != nil {
+		return err
+	} else if v != 1 {
+		return fmt.Errorf("unsupported version %d", v)
+	}
+	roots, err := n.LookupByString("roots")
+	if err != nil {
+		return err
+	}
+	for itr := roots.ListIterator(); itr != nil && !itr.Done(); {
+		_, root, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		lnk, err := root.AsLink()
+		if err != nil {
+			return err
+		}
+		store.roots = append(store.roots, lnk)
+	}
+	return nil
+}
+
+// Roots returns the links to the roots of the archive, as listed in its header.
+func (store *ReadStore) Roots() []ipld.Link {
+	return store.roots
+}
+
+// OpenRead opens the block for the given link,
+// or returns a storage.ErrNotFound if the archive doesn't contain it.
+func (store *ReadStore) OpenRead(lnkCtx ipld.LinkContext, lnk ipld.Link) (io.Reader, error) {
+	sec, ok := store.index[lnk]
+	if !ok {
+		return nil, storage.ErrNotFound{Link: lnk}
+	}
+	return io.NewSectionReader(store.ra, sec.offset, sec.size), nil
+}
@@ -0,0 +1,113 @@
+package car_test
+
+import (
+	"bytes"
+	"testing"
+
+	cid "github.com/ipfs/go-cid"
+	. "github.com/warpfork/go-wish"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	_ "github.com/ipld/go-ipld-prime/codec/dagcbor"
+	"github.com/ipld/go-ipld-prime/fluent"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/storage"
+	"github.com/ipld/go-ipld-prime/storage/car"
+	"github.com/ipld/go-ipld-prime/traversal/selector"
+	"github.com/ipld/go-ipld-prime/traversal/selector/builder"
+)
+
+func TestRoundTrip(t *testing.T) {
+	lp := cidlink.LinkPrototype{Prefix: cid.Prefix{
+		Version:  1,
+		Codec:    0x71, // dag-cbor
+		MhType:   0x12, // sha2-256
+		MhLength: 32,
+	}}
+	store := storage.Memory{}
+	lsys := cidlink.DefaultLinkSystem()
+	lsys.StorageReadOpener = (&store).OpenRead
+	lsys.StorageWriteOpener = (&store).OpenWrite
+
+	leaf := basicnode.NewString("leaf")
+	leafLnk := lsys.MustStore(ipld.LinkContext{}, lp, leaf)
+	middle := fluent.MustBuildList(basicnode.Prototype.List, 2, func(na fluent.ListAssembler) {
+		na.AssembleValue().AssignLink(leafLnk)
+		na.AssembleValue().AssignLink(leafLnk)
+	})
+	middleLnk := lsys.MustStore(ipld.LinkContext{}, lp, middle)
+	root := fluent.MustBuildMap(basicnode.Prototype.Map, 2, func(na fluent.MapAssembler) {
+		na.AssembleEntry("middle").AssignLink(middleLnk)
+		na.AssembleEntry("leaf").AssignLink(leafLnk)
+	})
+	rootLnk := lsys.MustStore(ipld.LinkContext{}, lp, root)
+	// A block which isn't reachable from the root shouldn't be exported.
+	lsys.MustStore(ipld.LinkContext{}, lp, basicnode.NewString("unrelated"))
+
+	ssb := builder.NewSelectorSpecBuilder(basicnode.Prototype.Any)
+	selectAll, err := ssb.ExploreRecursive(selector.RecursionLimitNone(), ssb.ExploreAll(ssb.ExploreRecursiveEdge())).Selector()
+	Require(t, err, ShouldEqual, nil)
+	selectMiddle, err := ssb.ExploreFields(func(efsb builder.ExploreFieldsSpecBuilder) {
+		efsb.Insert("middle", ssb.Matcher())
+	}).Selector()
+	Require(t, err, ShouldEqual, nil)
+
+	t.Run("all blocks", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := car.Write(&buf, lsys, rootLnk, selectAll)
+		Require(t, err, ShouldEqual, nil)
+
+		carStore, err := car.NewReadStore(bytes.NewReader(buf.Bytes()))
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, carStore.Roots(), ShouldEqual, []ipld.Link{rootLnk})
+
+		lsys2 := cidlink.DefaultLinkSystem()
+		lsys2.StorageReadOpener = carStore.OpenRead
+		for _, tc := range []struct {
+			lnk ipld.Link
+			n   ipld.Node
+		}{{rootLnk, root}, {middleLnk, middle}, {leafLnk, leaf}} {
+			n, err := lsys2.Load(ipld.LinkContext{}, tc.lnk, basicnode.Prototype.Any)
+			Require(t, err, ShouldEqual, nil)
+			Wish(t, n, ShouldEqual, tc.n)
+		}
+		unrelatedLnk, err := lsys.ComputeLink(lp, basicnode.NewString("unrelated"))
+		Require(t, err, ShouldEqual, nil)
+		_, err = lsys2.Load(ipld.LinkContext{}, unrelatedLnk, basicnode.Prototype.Any)
+		Wish(t, err, ShouldEqual, storage.ErrNotFound{Link: unrelatedLnk})
+	})
+	t.Run("selected blocks", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := car.Write(&buf, lsys, rootLnk, selectMiddle)
+		Require(t, err, ShouldEqual, nil)
+
+		carStore, err := car.NewReadStore(bytes.NewReader(buf.Bytes()))
+		Require(t, err, ShouldEqual, nil)
+		lsys2 := cidlink.DefaultLinkSystem()
+		lsys2.StorageReadOpener = carStore.OpenRead
+		_, err = lsys2.Load(ipld.LinkContext{}, middleLnk, basicnode.Prototype.Any)
+		Wish(t, err, ShouldEqual, nil)
+		// The selector stops at the middle block, so the leaf isn't included.
+		_, err = lsys2.Load(ipld.LinkContext{}, leafLnk, basicnode.Prototype.Any)
+		Wish(t, err, ShouldEqual, storage.ErrNotFound{Link: leafLnk})
+	})
+	t.Run("corrupt blocks are not exported", func(t *testing.T) {
+		bad := storage.Memory{Bag: map[ipld.Link][]byte{}}
+		for lnk, data := range store.Bag {
+			bad.Bag[lnk] = data
+		}
+		bad.Bag[leafLnk] = []byte("dmeow")
+		lsys := lsys
+		lsys.StorageReadOpener = (&bad).OpenRead
+		var buf bytes.Buffer
+		err := car.Write(&buf, lsys, rootLnk, selectAll)
+		Wish(t, err != nil, ShouldEqual, true)
+	})
+	t.Run("invalid archives", func(t *testing.T) {
+		_, err := car.NewReadStore(bytes.NewReader(nil))
+		Wish(t, err.Error(), ShouldEqual, "car: could not read header: EOF")
+		_, err = car.NewReadStore(bytes.NewReader([]byte("\x0a\xa1gversion\x02")))
+		Wish(t, err.Error(), ShouldEqual, "car: invalid header: unsupported version 2")
+	})
+}
@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ipld/go-ipld-prime"
+)
+
+// FS is a simple storage for blocks in a directory on the local filesystem.
+// Each block is kept in its own file, named after the string form of its link
+// (for a cidlink.Link, that's the CID), and placed in a subdirectory chosen by
+// the Shard function, so that no single directory grows too large.
+//
+// The OpenRead method conforms to ipld.BlockReadOpener,
+// and the OpenWrite method conforms to ipld.BlockWriteOpener:
+//
+//		store := storage.FS{Root: "/path/to/blocks"}
+//		lsys.StorageReadOpener = (&store).OpenRead
+//		lsys.StorageWriteOpener = (&store).OpenWrite
+//
+// Writes go straight to a temporary file under Root, which is moved into place
+// when the write is committed; so readers never see partially written blocks,
+// and large blocks aren't held in memory.
+// Callers must commit every write they open: the temporary file is removed if
+// committing fails, but a write which is never committed (as happens when
+// encoding a block fails in LinkSystem.Store) leaves its temporary file behind.
+// Such files have names starting with ".tmp-", directly under Root,
+// and may be removed whenever no writes are in progress.
+// Blocks are read into memory whole when opened.
+//
+// An FS is safe for concurrent use, as long as its fields aren't modified.
+// This is not meant to compete with a full datastore; it has no locking
+// against other processes, nor garbage collection.
+// Note that CIDv0 strings are case-sensitive, so Root should be on a
+// case-sensitive filesystem if they're used.
+type FS struct {
+	// Root is the directory under which blocks are stored.
+	// It's created on the first write if it doesn't exist.
+	Root string
+
+	// Shard returns the subdirectories, relative to Root, in which to place
+	// the file with the given name.
+	// If nil, ShardNextToLast(2) is used.
+	// Returning no elements places the file directly in Root.
+	//
+	// The sharding function must not change once blocks have been stored,
+	// as blocks are only looked for where the current function places them.
+	Shard func(name string) []string
+}
+
+// ShardNextToLast returns a sharding function for FS which places each file
+// in a directory named after the n characters before the last in its name.
+// The last character is skipped as, in some encodings of CIDs,
+// it doesn't carry a full character's worth of entropy.
+// This is the same scheme used by the flatfs datastore in go-ipfs.
+func ShardNextToLast(n int) func(name string) []string {
+	return func(name string) []string {
+		if len(name) <= n {
+			return []string{strings.Repeat("_", n)}
+		}
+		return []string{name[len(name)-n-1 : len(name)-1]}
+	}
+}
+
+// path returns the path of the file for the block with the given link.
+func (store *FS) path(lnk ipld.Link) (string, error) {
+	name := lnk.String()
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("cannot store link %q in a file: not a valid file name", name)
+	}
+	shard := store.Shard
+	if shard == nil {
+		shard = ShardNextToLast(2)
+	}
+	elems := append([]string{store.Root}, shard(name)...)
+	return filepath.Join(append(elems, name)...), nil
+}
+
+func (store *FS) OpenRead(lnkCtx ipld.LinkContext, lnk ipld.Link) (io.Reader, error) {
+	path, err := store.path(lnk)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound{lnk}
+	} else if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(data), nil
+}
+
+func (store *FS) OpenWrite(lnkCtx ipld.LinkContext) (io.Writer, ipld.BlockWriteCommitter, error) {
+	if err := os.MkdirAll(store.Root, 0777); err != nil {
+		return nil, nil, err
+	}
+	f, err := createTemp(store.Root)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, func(lnk ipld.Link) error {
+		path, err := store.path(lnk)
+		if err != nil {
+			f.Close()
+			os.Remove(f.Name())
+			return err
+		}
+		if err := f.Close(); err != nil {
+			os.Remove(f.Name())
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			os.Remove(f.Name())
+			return err
+		}
+		if err := os.Rename(f.Name(), path); err != nil {
+			os.Remove(f.Name())
+			return err
+		}
+		return nil
+	}, nil
+}
+
+// createTemp creates a new temporary file in dir for a block being written.
+// Unlike ioutil.TempFile, which always uses mode 0600, the file is created
+// with mode 0666 (before the umask), like the directories are with 0777.
+func createTemp(dir string) (*os.File, error) {
+	for {
+		name := filepath.Join(dir, ".tmp-"+strconv.FormatUint(rand.Uint64(), 36))
+		f, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0666)
+		if os.IsExist(err) {
+			continue
+		}
+		return f, err
+	}
+}
@@ -0,0 +1,108 @@
+package storage_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cid "github.com/ipfs/go-cid"
+	. "github.com/warpfork/go-wish"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/storage"
+)
+
+func TestFS(t *testing.T) {
+	lp := cidlink.LinkPrototype{Prefix: cid.Prefix{
+		Version:  1,
+		Codec:    0x55, // raw
+		MhType:   0x12, // sha2-256
+		MhLength: 32,
+	}}
+	dir, err := ioutil.TempDir("", "ipld-storage-fs")
+	Require(t, err, ShouldEqual, nil)
+	defer os.RemoveAll(dir)
+
+	t.Run("default sharding", func(t *testing.T) {
+		store := storage.FS{Root: filepath.Join(dir, "default")}
+		lsys := cidlink.DefaultLinkSystem()
+		lsys.StorageReadOpener = (&store).OpenRead
+		lsys.StorageWriteOpener = (&store).OpenWrite
+
+		lnk, err := lsys.Store(ipld.LinkContext{}, lp, basicnode.NewBytes([]byte("hello")))
+		Require(t, err, ShouldEqual, nil)
+		name := lnk.String()
+		data, err := ioutil.ReadFile(filepath.Join(store.Root, name[len(name)-3:len(name)-1], name))
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, string(data), ShouldEqual, "hello")
+
+		n, err := lsys.Load(ipld.LinkContext{}, lnk, basicnode.Prototype.Bytes)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, n, ShouldEqual, basicnode.NewBytes([]byte("hello")))
+
+		// No temporary files are left behind.
+		entries, err := ioutil.ReadDir(store.Root)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, len(entries), ShouldEqual, 1)
+
+		lnk2, err := lsys.ComputeLink(lp, basicnode.NewBytes([]byte("goodbye")))
+		Require(t, err, ShouldEqual, nil)
+		_, err = lsys.Load(ipld.LinkContext{}, lnk2, basicnode.Prototype.Bytes)
+		Wish(t, err, ShouldEqual, storage.ErrNotFound{Link: lnk2})
+	})
+	t.Run("failed commits", func(t *testing.T) {
+		store := storage.FS{Root: filepath.Join(dir, "failed")}
+		w, commit, err := store.OpenWrite(ipld.LinkContext{})
+		Require(t, err, ShouldEqual, nil)
+		_, err = w.Write([]byte("hello"))
+		Require(t, err, ShouldEqual, nil)
+		err = commit(badLink("a/b"))
+		Wish(t, err == nil, ShouldEqual, false)
+
+		// The temporary file was removed.
+		entries, err := ioutil.ReadDir(store.Root)
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, len(entries), ShouldEqual, 0)
+	})
+	t.Run("file modes", func(t *testing.T) {
+		store := storage.FS{Root: filepath.Join(dir, "modes")}
+		lsys := cidlink.DefaultLinkSystem()
+		lsys.StorageWriteOpener = (&store).OpenWrite
+		lnk, err := lsys.Store(ipld.LinkContext{}, lp, basicnode.NewBytes([]byte("hello")))
+		Require(t, err, ShouldEqual, nil)
+
+		// Blocks get the same mode as other files created under the umask.
+		f, err := os.OpenFile(filepath.Join(dir, "reference"), os.O_CREATE|os.O_EXCL, 0666)
+		Require(t, err, ShouldEqual, nil)
+		f.Close()
+		want, err := os.Stat(f.Name())
+		Require(t, err, ShouldEqual, nil)
+		name := lnk.String()
+		got, err := os.Stat(filepath.Join(store.Root, name[len(name)-3:len(name)-1], name))
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, got.Mode(), ShouldEqual, want.Mode())
+	})
+	t.Run("custom sharding", func(t *testing.T) {
+		store := storage.FS{
+			Root:  filepath.Join(dir, "flat"),
+			Shard: func(string) []string { return nil },
+		}
+		lsys := cidlink.DefaultLinkSystem()
+		lsys.StorageReadOpener = (&store).OpenRead
+		lsys.StorageWriteOpener = (&store).OpenWrite
+
+		lnk, err := lsys.Store(ipld.LinkContext{}, lp, basicnode.NewBytes([]byte("hello")))
+		Require(t, err, ShouldEqual, nil)
+		_, err = os.Stat(filepath.Join(store.Root, lnk.String()))
+		Wish(t, err, ShouldEqual, nil)
+	})
+}
+
+// badLink is a link whose string form isn't a valid file name.
+type badLink string
+
+func (badLink) Prototype() ipld.LinkPrototype { return nil }
+func (l badLink) String() string              { return string(l) }
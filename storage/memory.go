@@ -22,8 +22,39 @@ import (
 //
 // This storage is mostly expected to be used for testing and demos,
 // and as an example of how you can implement and integrate your own storage systems.
+//
+// Memory keeps track of the total size of the blocks written through OpenWrite,
+// and can limit it by setting MaxSize; blocks put directly into Bag aren't counted.
+// A Memory is not safe for concurrent use.
 type Memory struct {
 	Bag map[ipld.Link][]byte
+
+	// MaxSize is the maximum total size, in bytes, of the stored blocks.
+	// Committing a write which would go over it fails with ErrStorageFull.
+	// The default of zero means there's no limit.
+	MaxSize int64
+
+	size    int64
+	written map[ipld.Link]int64 // the size counted for each block written through OpenWrite.
+}
+
+// ErrNotFound is returned by OpenRead when no data is stored for a link.
+type ErrNotFound struct {
+	Link ipld.Link
+}
+
+func (e ErrNotFound) Error() string {
+	return fmt.Sprintf("no data stored for link %s", e.Link)
+}
+
+// ErrStorageFull is returned when committing a write to a Memory would take
+// its total size over its MaxSize.
+type ErrStorageFull struct {
+	MaxSize int64
+}
+
+func (e ErrStorageFull) Error() string {
+	return fmt.Sprintf("storage is full: writing would exceed the maximum size of %d bytes", e.MaxSize)
 }
 
 func (store *Memory) beInitialized() {
@@ -37,7 +68,7 @@ func (store *Memory) OpenRead(lnkCtx ipld.LinkContext, lnk ipld.Link) (io.Reader
 	store.beInitialized()
 	data, exists := store.Bag[lnk]
 	if !exists {
-		return nil, fmt.Errorf("404") // FIXME this needs a standard error type
+		return nil, ErrNotFound{lnk}
 	}
 	return bytes.NewReader(data), nil
 }
@@ -46,7 +77,23 @@ func (store *Memory) OpenWrite(lnkCtx ipld.LinkContext) (io.Writer, ipld.BlockWr
 	store.beInitialized()
 	buf := bytes.Buffer{}
 	return &buf, func(lnk ipld.Link) error {
+		// Only what was counted for the link before is replaced;
+		// blocks put directly into Bag were never counted.
+		size := store.size + int64(buf.Len()) - store.written[lnk]
+		if store.MaxSize > 0 && size > store.MaxSize {
+			return ErrStorageFull{store.MaxSize}
+		}
+		if store.written == nil {
+			store.written = make(map[ipld.Link]int64)
+		}
 		store.Bag[lnk] = buf.Bytes()
+		store.written[lnk] = int64(buf.Len())
+		store.size = size
 		return nil
 	}, nil
 }
+
+// Size returns the total size, in bytes, of the blocks written through OpenWrite.
+func (store *Memory) Size() int64 {
+	return store.size
+}
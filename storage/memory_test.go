@@ -0,0 +1,60 @@
+package storage_test
+
+import (
+	"testing"
+
+	cid "github.com/ipfs/go-cid"
+	. "github.com/warpfork/go-wish"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	_ "github.com/ipld/go-ipld-prime/codec/raw"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/storage"
+)
+
+func TestMemory(t *testing.T) {
+	lp := cidlink.LinkPrototype{Prefix: cid.Prefix{
+		Version:  1,
+		Codec:    0x55, // raw
+		MhType:   0x12, // sha2-256
+		MhLength: 32,
+	}}
+	store := storage.Memory{MaxSize: 10}
+	lsys := cidlink.DefaultLinkSystem()
+	lsys.StorageReadOpener = (&store).OpenRead
+	lsys.StorageWriteOpener = (&store).OpenWrite
+
+	lnk, err := lsys.Store(ipld.LinkContext{}, lp, basicnode.NewBytes([]byte("hello")))
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, store.Size(), ShouldEqual, int64(5))
+
+	// Storing the same block again doesn't use more space.
+	_, err = lsys.Store(ipld.LinkContext{}, lp, basicnode.NewBytes([]byte("hello")))
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, store.Size(), ShouldEqual, int64(5))
+
+	n, err := lsys.Load(ipld.LinkContext{}, lnk, basicnode.Prototype.Bytes)
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, n, ShouldEqual, basicnode.NewBytes([]byte("hello")))
+
+	_, err = lsys.Store(ipld.LinkContext{}, lp, basicnode.NewBytes([]byte("goodbye")))
+	Wish(t, err, ShouldEqual, storage.ErrStorageFull{MaxSize: 10})
+	Wish(t, store.Size(), ShouldEqual, int64(5))
+	Wish(t, len(store.Bag), ShouldEqual, 1)
+
+	lnk2, err := lsys.ComputeLink(lp, basicnode.NewBytes([]byte("goodbye")))
+	Require(t, err, ShouldEqual, nil)
+	_, err = lsys.Load(ipld.LinkContext{}, lnk2, basicnode.Prototype.Bytes)
+	Wish(t, err, ShouldEqual, storage.ErrNotFound{Link: lnk2})
+
+	// Blocks put directly into Bag aren't counted,
+	// even if the same block is written through OpenWrite later.
+	store = storage.Memory{}
+	lsys.StorageReadOpener = (&store).OpenRead
+	lsys.StorageWriteOpener = (&store).OpenWrite
+	store.Bag = map[ipld.Link][]byte{lnk: []byte("hello")}
+	_, err = lsys.Store(ipld.LinkContext{}, lp, basicnode.NewBytes([]byte("hello")))
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, store.Size(), ShouldEqual, int64(5))
+}
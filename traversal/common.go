@@ -11,9 +11,10 @@ import (
 // init sets all the values in TraveralConfig to reasonable defaults
 // if they're currently the zero value.
 //
-// Note that you're absolutely going to need to replace the
-// LinkLoader and LinkNodeBuilderChooser if you want automatic link traversal;
-// the defaults return error and/or panic.
+// Note that you're absolutely going to need to configure the LinkSystem
+// if you want automatic link traversal, as well as either its
+// NodePrototypeChooser or the LinkTargetNodePrototypeChooser;
+// the defaults return errors.
 func (tc *Config) init() {
 	if tc.Ctx == nil {
 		tc.Ctx = context.Background()
@@ -23,6 +24,9 @@ func (tc *Config) init() {
 			if tlnkNd, ok := lnkCtx.LinkNode.(schema.TypedLinkNode); ok {
 				return tlnkNd.LinkTargetNodePrototype(), nil
 			}
+			if tc.LinkSystem.NodePrototypeChooser != nil {
+				return tc.LinkSystem.NodePrototypeChooser(lnk, lnkCtx)
+			}
 			return nil, fmt.Errorf("no LinkTargetNodePrototypeChooser configured")
 		}
 	}
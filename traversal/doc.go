@@ -32,7 +32,7 @@
 // All of these functions -- the "Focus*" and "Walk*" family alike --
 // include support for automatic resolution and loading of new Node trees
 // whenever IPLD Links are encountered.  This can be configured freely
-// by providing LinkLoader interfaces to the traversal.Config.
+// by providing a LinkSystem in the traversal.Config.
 //
 // Some notes on the limits of usage:
 //
@@ -35,12 +35,13 @@ type Progress struct {
 		Path ipld.Path
 		Link ipld.Link
 	}
+	MatchLabels []string // MatchLabels holds the labels of the Matcher clauses which selected the current node.  (It is only set when visiting with VisitReason_SelectionMatch, and only if the matchers were labelled.)
 }
 
 type Config struct {
 	Ctx                            context.Context                // Context carried through a traversal.  Optional; use it if you need cancellation.
 	LinkSystem                     ipld.LinkSystem                // LinkSystem used for automatic link loading, and also any storing if mutation features (e.g. traversal.Transform) are used.
-	LinkTargetNodePrototypeChooser LinkTargetNodePrototypeChooser // Chooser for Node implementations to produce during automatic link traversal.
+	LinkTargetNodePrototypeChooser LinkTargetNodePrototypeChooser // Chooser for Node implementations to produce during automatic link traversal.  Optional; defaults to the LinkSystem's NodePrototypeChooser.
 }
 
 // LinkTargetNodePrototypeChooser is a function that returns a NodePrototype based on
@@ -56,7 +57,8 @@ type LinkTargetNodePrototypeChooser func(ipld.Link, ipld.LinkContext) (ipld.Node
 
 // SkipMe is a signalling "error" which can be used to tell traverse to skip some data.
 //
-// SkipMe can be returned by the Config.LinkLoader to skip entire blocks without aborting the walk.
+// SkipMe can be returned by the StorageReadOpener of the Config's LinkSystem
+// to skip entire blocks without aborting the walk.
 // (This can be useful if you know you don't have data on hand,
 // but want to continue the walk in other areas anyway;
 // or, if you're doing a way where you know that it's valid to memoize seen
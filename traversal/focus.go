@@ -40,6 +40,17 @@ func FocusedTransform(n ipld.Node, p ipld.Path, fn TransformFn, createParents bo
 	return Progress{}.FocusedTransform(n, p, fn, createParents)
 }
 
+// Amend returns a new Node tree which is like n, but with the node at the given path
+// replaced by leaf, or removed if leaf is nil.
+//
+// This function is a helper function which starts a new traversal with default configuration.
+// It cannot cross links automatically (since this requires configuration).
+// Use the equivalent Amend function on the Progress structure
+// for more advanced and configurable walks.
+func Amend(n ipld.Node, p ipld.Path, leaf ipld.Node) (ipld.Node, error) {
+	return Progress{}.Amend(n, p, leaf)
+}
+
 // Focus traverses a Node graph according to a path, reaches a single Node,
 // and calls the given VisitFn on that reached node.
 //
@@ -173,6 +184,11 @@ func (prog *Progress) get(n ipld.Node, p ipld.Path, trackProgress bool) (ipld.No
 // (This is determined by the node it applies to -- if that path segment
 // is applied to a map, it's just a regular map key of the string of dash.)
 //
+// If the TransformFn returns nil, the reached node is removed from its parent:
+// a map entry is left out, and a list element is left out, moving later elements up.
+// If there was no node there (because the TransformFn was called with nil),
+// nothing is added.  The root node can't be removed.
+//
 // Note that anything you can do with the Transform function, you can also
 // do with regular Node and NodeBuilder usage directly.  Transform just
 // does a large amount of the intermediate bookkeeping that's useful when
@@ -187,6 +203,28 @@ func (prog Progress) FocusedTransform(n ipld.Node, p ipld.Path, fn TransformFn,
 	return nb.Build(), nil
 }
 
+// Amend returns a new Node tree which is like n, but with the node at the given path
+// replaced by leaf, or removed if leaf is nil.  The original is unchanged.
+//
+// This is a FocusedTransform which doesn't look at the node it replaces,
+// so everything said there applies here too:
+// only the nodes along the path are rebuilt, using the prototypes of the nodes they replace,
+// so this works for generated nodes as well as for basicnode;
+// every other node is shared with the original tree (as far as each AssignNode implementation permits).
+//
+// The parent of the path must exist.
+// If the last segment of the path doesn't exist in a map, leaf is added as a new entry;
+// in a list, the path segment "-" appends leaf.
+// Removing a node which doesn't exist returns ipld.ErrNotExists.
+func (prog Progress) Amend(n ipld.Node, p ipld.Path, leaf ipld.Node) (ipld.Node, error) {
+	return prog.FocusedTransform(n, p, func(prog Progress, prev ipld.Node) (ipld.Node, error) {
+		if prev == nil && leaf == nil {
+			return nil, ipld.ErrNotExists{Segment: prog.Path.Last()}
+		}
+		return leaf, nil
+	}, false)
+}
+
 // focusedTransform assumes that an update will actually happen, and as it recurses deeper,
 // begins building an updated node tree.
 //
@@ -197,6 +235,9 @@ func (prog Progress) focusedTransform(n ipld.Node, na ipld.NodeAssembler, p ipld
 		if err != nil {
 			return err
 		}
+		if n2 == nil {
+			return fmt.Errorf("transform: cannot remove the node at %q, as it has no parent", prog.Path)
+		}
 		return na.AssignNode(n2)
 	}
 	seg, p2 := p.Shift()
@@ -237,31 +278,67 @@ func (prog Progress) focusedTransform(n ipld.Node, na ipld.NodeAssembler, p ipld
 			if err != nil {
 				return err
 			}
-			if err := ma.AssembleKey().AssignNode(k); err != nil {
-				return err
+			if !asPathSegment(k).Equals(seg) {
+				if err := ma.AssembleKey().AssignNode(k); err != nil {
+					return err
+				}
+				if err := ma.AssembleValue().AssignNode(v); err != nil {
+					return err
+				}
+				continue
 			}
-			if asPathSegment(k).Equals(seg) {
-				prog.Path = prog.Path.AppendSegment(seg)
-				if err := prog.focusedTransform(v, ma.AssembleValue(), p2, fn, createParents); err != nil {
+			prog.Path = prog.Path.AppendSegment(seg)
+			replaced = true
+			if p2.Len() == 0 {
+				// At the target, call the TransformFn before assembling the key, in case it removes the entry.
+				v, err = fn(prog, v)
+				if err != nil {
+					return err
+				}
+				if v == nil {
+					continue
+				}
+				if err := ma.AssembleKey().AssignNode(k); err != nil {
 					return err
 				}
-				replaced = true
-			} else {
 				if err := ma.AssembleValue().AssignNode(v); err != nil {
 					return err
 				}
+				continue
+			}
+			if err := ma.AssembleKey().AssignNode(k); err != nil {
+				return err
+			}
+			if err := prog.focusedTransform(v, ma.AssembleValue(), p2, fn, createParents); err != nil {
+				return err
 			}
 		}
 		if replaced {
 			return ma.Finish()
 		}
 		// If we didn't find the target yet: append it.
-		//  If we're at the end, always do this;
+		//  If we're at the end, always do this (unless the TransformFn declines to make anything);
 		//  if we're in the middle, only do this if createParents mode is enabled.
 		prog.Path = prog.Path.AppendSegment(seg)
 		if p.Len() > 1 && !createParents {
 			return fmt.Errorf("transform: parent position at %q did not exist (and createParents was false)", prog.Path)
 		}
+		if p2.Len() == 0 {
+			v, err := fn(prog, nil)
+			if err != nil {
+				return err
+			}
+			if v == nil {
+				return ma.Finish()
+			}
+			if err := ma.AssembleKey().AssignString(seg.String()); err != nil {
+				return err
+			}
+			if err := ma.AssembleValue().AssignNode(v); err != nil {
+				return err
+			}
+			return ma.Finish()
+		}
 		if err := ma.AssembleKey().AssignString(seg.String()); err != nil {
 			return err
 		}
@@ -294,10 +371,24 @@ func (prog Progress) focusedTransform(n ipld.Node, na ipld.NodeAssembler, p ipld
 			}
 			if ti == i {
 				prog.Path = prog.Path.AppendSegment(seg)
+				replaced = true
+				if p2.Len() == 0 {
+					// At the target, call the TransformFn before assembling the value, in case it removes the element.
+					v, err = fn(prog, v)
+					if err != nil {
+						return err
+					}
+					if v == nil {
+						continue
+					}
+					if err := la.AssembleValue().AssignNode(v); err != nil {
+						return err
+					}
+					continue
+				}
 				if err := prog.focusedTransform(v, la.AssembleValue(), p2, fn, createParents); err != nil {
 					return err
 				}
-				replaced = true
 			} else {
 				if err := la.AssembleValue().AssignNode(v); err != nil {
 					return err
@@ -313,6 +404,19 @@ func (prog Progress) focusedTransform(n ipld.Node, na ipld.NodeAssembler, p ipld
 			return fmt.Errorf("transform: cannot navigate path segment %q at %q because it is beyond the list bounds", seg, prog.Path)
 		}
 		prog.Path = prog.Path.AppendSegment(ipld.PathSegmentOfInt(n.Length()))
+		if p2.Len() == 0 {
+			v, err := fn(prog, nil)
+			if err != nil {
+				return err
+			}
+			if v == nil {
+				return la.Finish()
+			}
+			if err := la.AssembleValue().AssignNode(v); err != nil {
+				return err
+			}
+			return la.Finish()
+		}
 		if err := prog.focusedTransform(nil, la.AssembleValue(), p2, fn, createParents); err != nil {
 			return err
 		}
@@ -2,17 +2,19 @@ package traversal_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	. "github.com/warpfork/go-wish"
 
 	"github.com/ipfs/go-cid"
 	"github.com/ipld/go-ipld-prime"
-	_ "github.com/ipld/go-ipld-prime/codec/dagjson"
+	"github.com/ipld/go-ipld-prime/codec/dagjson"
 	"github.com/ipld/go-ipld-prime/fluent"
 	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
 	"github.com/ipld/go-ipld-prime/must"
 	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/node/gendemo"
 	"github.com/ipld/go-ipld-prime/storage"
 	"github.com/ipld/go-ipld-prime/traversal"
 )
@@ -152,6 +154,30 @@ func TestFocusWithLinkLoading(t *testing.T) {
 	})
 }
 
+func TestFocusWithLinkSystemChooser(t *testing.T) {
+	lsys := cidlink.DefaultLinkSystem()
+	lsys.StorageReadOpener = (&store).OpenRead
+	lsys.NodePrototypeChooser = func(_ ipld.Link, _ ipld.LinkContext) (ipld.NodePrototype, error) {
+		return basicnode.Prototype__Any{}, nil
+	}
+	err := traversal.Progress{
+		Cfg: &traversal.Config{LinkSystem: lsys},
+	}.Focus(rootNode, ipld.ParsePath("linkedMap/nested/nonlink"), func(prog traversal.Progress, n ipld.Node) error {
+		Wish(t, n, ShouldEqual, basicnode.NewString("zoo"))
+		return nil
+	})
+	Wish(t, err, ShouldEqual, nil)
+
+	// Load uses the chooser too, when given no prototype.
+	n, err := lsys.Load(ipld.LinkContext{}, middleMapNodeLnk, nil)
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, n, ShouldEqual, middleMapNode)
+
+	lsys.NodePrototypeChooser = nil
+	_, err = lsys.Load(ipld.LinkContext{}, middleMapNodeLnk, nil)
+	Wish(t, err.Error(), ShouldEqual, "could not choose a node prototype: no prototype given, and no NodePrototypeChooser configured")
+}
+
 func TestGetWithLinkLoading(t *testing.T) {
 	t.Run("link traversal with no configured loader should fail", func(t *testing.T) {
 		t.Run("terminal link should fail", func(t *testing.T) {
@@ -284,6 +310,36 @@ func TestFocusedTransform(t *testing.T) {
 		// everything else should be there
 		Wish(t, n.Length(), ShouldEqual, int64(5))
 	})
+	t.Run("RemoveMapEntry", func(t *testing.T) {
+		n, err := traversal.FocusedTransform(rootNode, ipld.ParsePath("linkedString"), func(progress traversal.Progress, prev ipld.Node) (ipld.Node, error) {
+			Wish(t, prev, ShouldEqual, basicnode.NewLink(leafAlphaLnk))
+			return nil, nil
+		}, false)
+		Wish(t, err, ShouldEqual, nil)
+		Wish(t, keys(n), ShouldEqual, []string{"plain", "linkedMap", "linkedList"})
+	})
+	t.Run("RemoveListEntry", func(t *testing.T) {
+		n, err := traversal.FocusedTransform(middleListNode, ipld.ParsePath("2"), func(progress traversal.Progress, prev ipld.Node) (ipld.Node, error) {
+			return nil, nil
+		}, false)
+		Wish(t, err, ShouldEqual, nil)
+		Wish(t, n.Length(), ShouldEqual, int64(3))
+		Wish(t, must.Node(n.LookupByIndex(2)), ShouldEqual, basicnode.NewLink(leafAlphaLnk))
+	})
+	t.Run("RemoveNothing", func(t *testing.T) {
+		n, err := traversal.FocusedTransform(rootNode, ipld.ParsePath("newpart"), func(progress traversal.Progress, prev ipld.Node) (ipld.Node, error) {
+			Wish(t, prev, ShouldEqual, nil)
+			return nil, nil
+		}, false)
+		Wish(t, err, ShouldEqual, nil)
+		Wish(t, keys(n), ShouldEqual, []string{"plain", "linkedString", "linkedMap", "linkedList"})
+	})
+	t.Run("RemoveRoot", func(t *testing.T) {
+		_, err := traversal.FocusedTransform(rootNode, ipld.ParsePath(""), func(progress traversal.Progress, prev ipld.Node) (ipld.Node, error) {
+			return nil, nil
+		}, false)
+		Wish(t, err, ShouldEqual, fmt.Errorf("transform: cannot remove the node at \"\", as it has no parent"))
+	})
 	t.Run("ListBounds", func(t *testing.T) {
 		_, err := traversal.FocusedTransform(middleListNode, ipld.ParsePath("4"), func(progress traversal.Progress, prev ipld.Node) (ipld.Node, error) {
 			Wish(t, true, ShouldEqual, false) // ought not be reached
@@ -306,6 +362,55 @@ func TestFocusedTransform(t *testing.T) {
 	})
 }
 
+func TestAmend(t *testing.T) {
+	t.Run("Replace", func(t *testing.T) {
+		n, err := traversal.Amend(middleMapNode, ipld.ParsePath("nested/nonlink"), basicnode.NewString("new string!"))
+		Wish(t, err, ShouldEqual, nil)
+		Wish(t, must.String(must.Node(traversal.Get(n, ipld.ParsePath("nested/nonlink")))), ShouldEqual, "new string!")
+		Wish(t, must.String(must.Node(traversal.Get(middleMapNode, ipld.ParsePath("nested/nonlink")))), ShouldEqual, "zoo")
+		Wish(t, keys(n), ShouldEqual, []string{"foo", "bar", "nested"})
+	})
+	t.Run("Add", func(t *testing.T) {
+		n, err := traversal.Amend(middleMapNode, ipld.ParsePath("nested/new"), basicnode.NewInt(1))
+		Wish(t, err, ShouldEqual, nil)
+		Wish(t, keys(must.Node(n.LookupByString("nested"))), ShouldEqual, []string{"alink", "nonlink", "new"})
+		n, err = traversal.Amend(middleListNode, ipld.ParsePath("-"), basicnode.NewInt(1))
+		Wish(t, err, ShouldEqual, nil)
+		Wish(t, n.Length(), ShouldEqual, int64(5))
+	})
+	t.Run("Remove", func(t *testing.T) {
+		n, err := traversal.Amend(middleMapNode, ipld.ParsePath("nested/alink"), nil)
+		Wish(t, err, ShouldEqual, nil)
+		Wish(t, keys(must.Node(n.LookupByString("nested"))), ShouldEqual, []string{"nonlink"})
+		_, err = traversal.Amend(middleMapNode, ipld.ParsePath("nested/nope"), nil)
+		Wish(t, err, ShouldEqual, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString("nope")})
+		_, err = traversal.Amend(middleMapNode, ipld.ParsePath("nope/nope"), nil)
+		Wish(t, err, ShouldEqual, fmt.Errorf("transform: parent position at \"nope\" did not exist (and createParents was false)"))
+	})
+	t.Run("SharesUntouchedNodes", func(t *testing.T) {
+		before := must.Node(middleMapNode.LookupByString("nested"))
+		n, err := traversal.Amend(middleMapNode, ipld.ParsePath("foo"), basicnode.NewBool(false))
+		Wish(t, err, ShouldEqual, nil)
+		Wish(t, must.Node(n.LookupByString("nested")) == before, ShouldEqual, true)
+	})
+	t.Run("Generated", func(t *testing.T) {
+		nb := gendemo.Type.Map__String__Msg3.NewBuilder()
+		Require(t, dagjson.Decode(nb, strings.NewReader(`{"a":{"whee":1,"woot":2,"waga":3}}`)), ShouldEqual, nil)
+		orig := nb.Build()
+		n, err := traversal.Amend(orig, ipld.ParsePath("a/woot"), basicnode.NewInt(20))
+		Wish(t, err, ShouldEqual, nil)
+		_, ok := n.(gendemo.Map__String__Msg3)
+		Wish(t, ok, ShouldEqual, true)
+		Wish(t, must.Int(must.Node(traversal.Get(n, ipld.ParsePath("a/woot")))), ShouldEqual, int64(20))
+		Wish(t, must.Int(must.Node(traversal.Get(orig, ipld.ParsePath("a/woot")))), ShouldEqual, int64(2))
+		// Values are checked by the generated assemblers, as usual.
+		_, err = traversal.Amend(orig, ipld.ParsePath("a/woot"), basicnode.NewString("x"))
+		Wish(t, err != nil, ShouldEqual, true)
+		_, err = traversal.Amend(orig, ipld.ParsePath("a/woot"), nil)
+		Wish(t, err != nil, ShouldEqual, true)
+	})
+}
+
 func TestFocusedTransformWithLinks(t *testing.T) {
 	var store2 = storage.Memory{}
 	lsys := cidlink.DefaultLinkSystem()
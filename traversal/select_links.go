@@ -1,6 +1,8 @@
 package traversal
 
 import (
+	"fmt"
+
 	"github.com/ipld/go-ipld-prime"
 )
 
@@ -36,7 +38,9 @@ func accumulateLinks(a *[]ipld.Link, n ipld.Node) error {
 			if err != nil {
 				return err
 			}
-			accumulateLinks(a, v)
+			if err := accumulateLinks(a, v); err != nil {
+				return err
+			}
 		}
 	case ipld.Kind_List:
 		for itr := n.ListIterator(); !itr.Done(); {
@@ -44,7 +48,9 @@ func accumulateLinks(a *[]ipld.Link, n ipld.Node) error {
 			if err != nil {
 				return err
 			}
-			accumulateLinks(a, v)
+			if err := accumulateLinks(a, v); err != nil {
+				return err
+			}
 		}
 	case ipld.Kind_Link:
 		lnk, _ := n.AsLink()
@@ -52,3 +58,100 @@ func accumulateLinks(a *[]ipld.Link, n ipld.Node) error {
 	}
 	return nil
 }
+
+// SelectAllLinks returns every distinct link reachable from the root link,
+// loading the blocks they point to with the Config's LinkSystem.
+// This is the set of references one needs for pinning, garbage collection,
+// or replication; it's gathered without the overhead of selectors or visitors.
+//
+// The maxDepth parameter limits how many blocks deep the search goes:
+// 1 returns only the links in the root block, 2 also those in the blocks
+// it links to, and so on.  A negative maxDepth means there's no limit.
+//
+// Links are returned in breadth-first order, each the first time it's seen;
+// the root link itself isn't included.
+// Each block is loaded once, even if it's linked to several times.
+// If loading a block returns SkipMe, the links within it aren't followed.
+// In case of an error, the links found so far are still returned.
+func (prog Progress) SelectAllLinks(root ipld.Link, maxDepth int) ([]ipld.Link, error) {
+	prog.init()
+	type pending struct {
+		lnk    ipld.Link
+		lnkCtx ipld.LinkContext
+	}
+	var answer []ipld.Link
+	seen := map[ipld.Link]bool{root: true}
+	queue := []pending{{root, ipld.LinkContext{Ctx: prog.Cfg.Ctx, LinkPath: prog.Path}}}
+	for depth := 0; len(queue) > 0 && depth != maxDepth; depth++ {
+		if depth > 0 {
+			lnks := make([]ipld.Link, len(queue))
+			for i, p := range queue {
+				lnks[i] = p.lnk
+			}
+			prog.Cfg.LinkSystem.Prefetch(ipld.LinkContext{Ctx: prog.Cfg.Ctx}, lnks)
+		}
+		var next []pending
+		for _, p := range queue {
+			np, err := prog.Cfg.LinkTargetNodePrototypeChooser(p.lnk, p.lnkCtx)
+			if err != nil {
+				return answer, fmt.Errorf("error traversing node at %q: could not load link %q: %s", p.lnkCtx.LinkPath, p.lnk, err)
+			}
+			n, err := prog.Cfg.LinkSystem.Load(p.lnkCtx, p.lnk, np)
+			if err != nil {
+				if _, ok := err.(SkipMe); ok {
+					continue
+				}
+				return answer, fmt.Errorf("error traversing node at %q: could not load link %q: %s", p.lnkCtx.LinkPath, p.lnk, err)
+			}
+			err = walkLinks(n, nil, p.lnkCtx.LinkPath, func(lnkNode, parent ipld.Node, path ipld.Path) {
+				lnk, _ := lnkNode.AsLink()
+				if seen[lnk] {
+					return
+				}
+				seen[lnk] = true
+				answer = append(answer, lnk)
+				next = append(next, pending{lnk, ipld.LinkContext{
+					Ctx:        prog.Cfg.Ctx,
+					LinkPath:   path,
+					LinkNode:   lnkNode,
+					ParentNode: parent,
+				}})
+			})
+			if err != nil {
+				return answer, err
+			}
+		}
+		queue = next
+	}
+	return answer, nil
+}
+
+// walkLinks is like accumulateLinks, but keeps track of the path to,
+// and the parent of, each link node it finds.
+func walkLinks(n, parent ipld.Node, path ipld.Path, fn func(lnkNode, parent ipld.Node, path ipld.Path)) error {
+	switch n.Kind() {
+	case ipld.Kind_Map:
+		for itr := n.MapIterator(); !itr.Done(); {
+			k, v, err := itr.Next()
+			if err != nil {
+				return err
+			}
+			if err := walkLinks(v, n, path.AppendSegment(asPathSegment(k)), fn); err != nil {
+				return err
+			}
+		}
+	case ipld.Kind_List:
+		for itr := n.ListIterator(); !itr.Done(); {
+			i, v, err := itr.Next()
+			if err != nil {
+				return err
+			}
+			if err := walkLinks(v, n, path.AppendSegment(ipld.PathSegmentOfInt(i)), fn); err != nil {
+				return err
+			}
+		}
+	case ipld.Kind_Link:
+		fn(n, parent, path)
+	}
+	return nil
+}
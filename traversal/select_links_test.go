@@ -1,11 +1,14 @@
 package traversal_test
 
 import (
+	"io"
 	"testing"
 
 	. "github.com/warpfork/go-wish"
 
 	"github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
 	"github.com/ipld/go-ipld-prime/traversal"
 )
 
@@ -23,3 +26,47 @@ func TestSelectLinks(t *testing.T) {
 		Wish(t, lnks, ShouldEqual, []ipld.Link{leafAlphaLnk, middleMapNodeLnk, middleListNodeLnk})
 	})
 }
+
+func TestSelectAllLinks(t *testing.T) {
+	lsys := cidlink.DefaultLinkSystem()
+	lsys.StorageReadOpener = (&store).OpenRead
+	lsys.NodePrototypeChooser = func(ipld.Link, ipld.LinkContext) (ipld.NodePrototype, error) {
+		return basicnode.Prototype.Any, nil
+	}
+	prog := traversal.Progress{Cfg: &traversal.Config{LinkSystem: lsys}}
+	t.Run("Unlimited", func(t *testing.T) {
+		lnks, err := prog.SelectAllLinks(rootNodeLnk, -1)
+		Wish(t, err, ShouldEqual, nil)
+		Wish(t, lnks, ShouldEqual, []ipld.Link{leafAlphaLnk, middleMapNodeLnk, middleListNodeLnk, leafBetaLnk})
+	})
+	t.Run("OneBlock", func(t *testing.T) {
+		lnks, err := prog.SelectAllLinks(rootNodeLnk, 1)
+		Wish(t, err, ShouldEqual, nil)
+		Wish(t, lnks, ShouldEqual, []ipld.Link{leafAlphaLnk, middleMapNodeLnk, middleListNodeLnk})
+	})
+	t.Run("Paths", func(t *testing.T) {
+		var paths []string
+		lsys := lsys
+		lsys.StorageReadOpener = func(lnkCtx ipld.LinkContext, lnk ipld.Link) (io.Reader, error) {
+			paths = append(paths, lnkCtx.LinkPath.String())
+			return store.OpenRead(lnkCtx, lnk)
+		}
+		prog := traversal.Progress{Cfg: &traversal.Config{LinkSystem: lsys}}
+		_, err := prog.SelectAllLinks(rootNodeLnk, -1)
+		Wish(t, err, ShouldEqual, nil)
+		Wish(t, paths, ShouldEqual, []string{"", "linkedString", "linkedMap", "linkedList", "linkedList/2"})
+	})
+	t.Run("Skip", func(t *testing.T) {
+		lsys := lsys
+		lsys.StorageReadOpener = func(lnkCtx ipld.LinkContext, lnk ipld.Link) (io.Reader, error) {
+			if lnk == middleListNodeLnk {
+				return nil, traversal.SkipMe{}
+			}
+			return store.OpenRead(lnkCtx, lnk)
+		}
+		prog := traversal.Progress{Cfg: &traversal.Config{LinkSystem: lsys}}
+		lnks, err := prog.SelectAllLinks(rootNodeLnk, -1)
+		Wish(t, err, ShouldEqual, nil)
+		Wish(t, lnks, ShouldEqual, []ipld.Link{leafAlphaLnk, middleMapNodeLnk, middleListNodeLnk})
+	})
+}
@@ -0,0 +1,136 @@
+package selector
+
+import (
+	"fmt"
+
+	ipld "github.com/ipld/go-ipld-prime"
+)
+
+// Budget limits how much work a selector may cause.
+//
+// A selector received from an untrusted source can cause a great deal of
+// work even on a small graph (for example, a deeply nested union of
+// ExploreRecursive clauses); wrapping it with NewBudgetedSelector ensures the
+// work is bounded regardless of the selector's contents.
+//
+// Zero values mean "no limit".
+type Budget struct {
+	MaxEvaluations int64 // maximum number of Explore and Decide calls, in total.
+	MaxDepth       int64 // maximum number of nested Explore steps from the root (which bounds how many levels of recursion can actually be taken).
+}
+
+// ErrBudgetExceeded is the error reported when a BudgetedSelector's Budget
+// is used up.
+type ErrBudgetExceeded struct {
+	Limit string // which limit was exceeded: "evaluations" or "depth"
+	Value int64  // the value of that limit
+}
+
+func (e ErrBudgetExceeded) Error() string {
+	return fmt.Sprintf("selector budget exceeded: more than %d %s", e.Value, e.Limit)
+}
+
+// BudgetedSelector wraps another Selector and enforces a Budget on it.
+//
+// When the budget is used up, Explore still returns a selector, and Decide
+// returns true, so that the walk reaches the next node and treats it as a
+// match; MatchedNode then returns the ErrBudgetExceeded, which aborts the walk
+// with that error.  This way the result of a walk is never silently truncated,
+// even if the BudgetedSelector is nested within other selectors.
+// Err also reports the ErrBudgetExceeded, for callers which use the selector
+// directly.
+//
+// All the selectors returned by Explore on a BudgetedSelector share the
+// same budget.  A BudgetedSelector is not safe for concurrent use.
+type BudgetedSelector struct {
+	inner Selector
+	depth int64
+	state *budgetState
+}
+
+type budgetState struct {
+	budget      Budget
+	evaluations int64
+	err         error
+}
+
+// NewBudgetedSelector returns a selector which behaves like the given one,
+// but enforces the given budget.
+func NewBudgetedSelector(s Selector, b Budget) BudgetedSelector {
+	return BudgetedSelector{s, 0, &budgetState{budget: b}}
+}
+
+// Err returns an ErrBudgetExceeded if the budget has been used up, or nil.
+func (s BudgetedSelector) Err() error {
+	return s.state.err
+}
+
+// spend accounts for one evaluation, and returns false if the budget is exceeded.
+func (s BudgetedSelector) spend() bool {
+	st := s.state
+	if st.err != nil {
+		return false
+	}
+	st.evaluations++
+	if st.budget.MaxEvaluations > 0 && st.evaluations > st.budget.MaxEvaluations {
+		st.err = ErrBudgetExceeded{"evaluations", st.budget.MaxEvaluations}
+		return false
+	}
+	return true
+}
+
+// Interests for BudgetedSelector are the interests of the wrapped selector
+func (s BudgetedSelector) Interests() []ipld.PathSegment {
+	return s.inner.Interests()
+}
+
+// IndexRange returns the index range of the wrapped selector
+func (s BudgetedSelector) IndexRange() (start, end int64, ok bool) {
+	return IndexRange(s.inner)
+}
+
+// Explore returns the wrapped selector's exploration, sharing this budget,
+// or this same selector if the budget is used up
+func (s BudgetedSelector) Explore(n ipld.Node, p ipld.PathSegment) Selector {
+	if !s.spend() {
+		return s
+	}
+	next := s.inner.Explore(n, p)
+	if next == nil {
+		return nil
+	}
+	if s.state.budget.MaxDepth > 0 && s.depth >= s.state.budget.MaxDepth {
+		s.state.err = ErrBudgetExceeded{"depth", s.state.budget.MaxDepth}
+		return s
+	}
+	return BudgetedSelector{next, s.depth + 1, s.state}
+}
+
+// Decide returns the wrapped selector's decision,
+// or true if the budget is used up, so that MatchedNode reports it
+func (s BudgetedSelector) Decide(n ipld.Node) bool {
+	if !s.spend() {
+		return true
+	}
+	return s.inner.Decide(n)
+}
+
+// ToNode returns the IPLD data representation of the wrapped selector
+// (budgets are not part of the representation)
+func (s BudgetedSelector) ToNode(np ipld.NodePrototype) ipld.Node {
+	return s.inner.ToNode(np)
+}
+
+// MatchLabels returns the labels of the wrapped selector
+func (s BudgetedSelector) MatchLabels(n ipld.Node) []string {
+	return MatchLabels(s.inner, n)
+}
+
+// MatchedNode returns the matched node of the wrapped selector,
+// or an ErrBudgetExceeded if the budget is used up
+func (s BudgetedSelector) MatchedNode(n ipld.Node) (ipld.Node, error) {
+	if s.state.err != nil {
+		return nil, s.state.err
+	}
+	return MatchedNode(s.inner, n)
+}
@@ -0,0 +1,48 @@
+package selector
+
+import (
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/fluent"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+)
+
+func TestBudgetedSelector(t *testing.T) {
+	n := fluent.MustBuildList(basicnode.Prototype__List{}, 3, func(na fluent.ListAssembler) {
+		na.AssembleValue().AssignInt(0)
+		na.AssembleValue().AssignInt(1)
+		na.AssembleValue().AssignInt(2)
+	})
+	t.Run("exploring within the budget should behave like the wrapped selector", func(t *testing.T) {
+		s := NewBudgetedSelector(ExploreAll{Matcher{}}, Budget{MaxEvaluations: 10, MaxDepth: 1})
+		Wish(t, s.Decide(n), ShouldEqual, false)
+		next := s.Explore(n, ipld.PathSegmentOfInt(0))
+		Wish(t, next.Decide(basicnode.NewInt(0)), ShouldEqual, true)
+		Wish(t, s.Err(), ShouldEqual, nil)
+	})
+	t.Run("exceeding the evaluation budget should report an error on the next match", func(t *testing.T) {
+		s := NewBudgetedSelector(ExploreAll{Matcher{}}, Budget{MaxEvaluations: 2})
+		Wish(t, s.Explore(n, ipld.PathSegmentOfInt(0)) != nil, ShouldEqual, true)
+		Wish(t, s.Explore(n, ipld.PathSegmentOfInt(1)) != nil, ShouldEqual, true)
+		next := s.Explore(n, ipld.PathSegmentOfInt(2))
+		Wish(t, s.Err(), ShouldEqual, ErrBudgetExceeded{"evaluations", 2})
+		Wish(t, next.Decide(basicnode.NewInt(2)), ShouldEqual, true)
+		_, err := MatchedNode(next, basicnode.NewInt(2))
+		Wish(t, err, ShouldEqual, ErrBudgetExceeded{"evaluations", 2})
+	})
+	t.Run("exceeding the depth budget should report an error on the next match", func(t *testing.T) {
+		rs := ExploreRecursive{ExploreAll{ExploreRecursiveEdge{}}, ExploreAll{ExploreRecursiveEdge{}}, RecursionLimitNone()}
+		s := NewBudgetedSelector(rs, Budget{MaxDepth: 2})
+		next := s.Explore(n, ipld.PathSegmentOfInt(0))
+		next = next.Explore(n, ipld.PathSegmentOfInt(0))
+		Wish(t, s.Err(), ShouldEqual, nil)
+		next = next.Explore(n, ipld.PathSegmentOfInt(0))
+		Wish(t, s.Err(), ShouldEqual, ErrBudgetExceeded{"depth", 2})
+		_, err := MatchedNode(next, n)
+		Wish(t, err, ShouldEqual, ErrBudgetExceeded{"depth", 2})
+		Wish(t, s.Err().Error(), ShouldEqual, "selector budget exceeded: more than 2 depth")
+	})
+}
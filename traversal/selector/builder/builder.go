@@ -30,6 +30,7 @@ type SelectorSpecBuilder interface {
 	ExploreIndex(index int64, next SelectorSpec) SelectorSpec
 	ExploreRange(start, end int64, next SelectorSpec) SelectorSpec
 	ExploreFields(ExploreFieldsSpecBuildingClosure) SelectorSpec
+	ExploreConditional(condition selector.Condition, next SelectorSpec) SelectorSpec
 	Matcher() SelectorSpec
 }
 
@@ -150,6 +151,17 @@ func (ssb *selectorSpecBuilder) ExploreFields(specBuilder ExploreFieldsSpecBuild
 	}
 }
 
+func (ssb *selectorSpecBuilder) ExploreConditional(condition selector.Condition, next SelectorSpec) SelectorSpec {
+	return selectorSpec{
+		fluent.MustBuildMap(ssb.np, 1, func(na fluent.MapAssembler) {
+			na.AssembleEntry(selector.SelectorKey_ExploreConditional).CreateMap(2, func(na fluent.MapAssembler) {
+				na.AssembleEntry(selector.SelectorKey_Condition).AssignNode(condition.ToNode(ssb.np))
+				na.AssembleEntry(selector.SelectorKey_Next).AssignNode(next.Node())
+			})
+		}),
+	}
+}
+
 func (ssb *selectorSpecBuilder) Matcher() SelectorSpec {
 	return selectorSpec{
 		fluent.MustBuildMap(ssb.np, 1, func(na fluent.MapAssembler) {
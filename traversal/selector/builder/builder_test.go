@@ -1,8 +1,10 @@
 package builder
 
 import (
+	"bytes"
 	"testing"
 
+	"github.com/ipld/go-ipld-prime/codec/dagjson"
 	"github.com/ipld/go-ipld-prime/fluent"
 	basicnode "github.com/ipld/go-ipld-prime/node/basic"
 	"github.com/ipld/go-ipld-prime/traversal/selector"
@@ -129,3 +131,36 @@ func TestBuildingSelectors(t *testing.T) {
 		Wish(t, sn, ShouldEqual, esn)
 	})
 }
+
+func TestSelectorsToNode(t *testing.T) {
+	ssb := NewSelectorSpecBuilder(basicnode.Prototype__Any{})
+	for name, ss := range map[string]SelectorSpec{
+		"Matcher":              ssb.Matcher(),
+		"ExploreAll":           ssb.ExploreAll(ssb.Matcher()),
+		"ExploreIndex":         ssb.ExploreIndex(2, ssb.Matcher()),
+		"ExploreRange":         ssb.ExploreRange(2, 3, ssb.Matcher()),
+		"ExploreUnion":         ssb.ExploreUnion(ssb.Matcher(), ssb.ExploreIndex(2, ssb.Matcher())),
+		"ExploreRecursiveNone": ssb.ExploreRecursive(selector.RecursionLimitNone(), ssb.ExploreAll(ssb.ExploreRecursiveEdge())),
+		"ExploreRecursiveDepth": ssb.ExploreRecursive(selector.RecursionLimitDepth(3), ssb.ExploreUnion(
+			ssb.Matcher(),
+			ssb.ExploreAll(ssb.ExploreRecursiveEdge()),
+		)),
+		"ExploreConditional": ssb.ExploreConditional(
+			selector.ConditionFieldEquals("type", basicnode.NewString("dir")),
+			ssb.ExploreAll(ssb.Matcher()),
+		),
+		"ExploreFields": ssb.ExploreFields(func(efsb ExploreFieldsSpecBuilder) {
+			efsb.Insert("zoo", ssb.Matcher())
+			efsb.Insert("applesauce", ssb.ExploreIndex(1, ssb.Matcher()))
+		}),
+	} {
+		t.Run(name+" should round-trip through ToNode", func(t *testing.T) {
+			s, err := ss.Selector()
+			Require(t, err, ShouldEqual, nil)
+			var expected, actual bytes.Buffer
+			Require(t, dagjson.Encode(ss.Node(), &expected), ShouldEqual, nil)
+			Require(t, dagjson.Encode(s.ToNode(basicnode.Prototype__Any{}), &actual), ShouldEqual, nil)
+			Wish(t, actual.String(), ShouldEqual, expected.String())
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Package canonical computes a canonical serial form of selectors,
+// which can be used to compare them, or to key a cache by them.
+//
+// The canonical form of a selector is the strict canonical DAG-CBOR encoding
+// of its data representation (as returned by its ToNode method), in which the
+// keys of every map are sorted (shorter keys first, then bytewise).
+// Two selectors which were parsed from documents that differ only in the
+// order of their map entries (e.g., the fields of an ExploreFields) thus
+// have the same canonical form.
+//
+// The order of list entries, such as the members of an ExploreUnion, is
+// significant, since it affects the order in which nodes are visited.
+package canonical
+
+import (
+	"bytes"
+	"crypto/sha256"
+
+	"github.com/ipld/go-ipld-prime/codec/dagcbor"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/traversal/selector"
+)
+
+// Bytes returns the canonical serial form of a selector.
+func Bytes(s selector.Selector) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := (dagcbor.EncodeOptions{Canonical: true}).Encode(s.ToNode(basicnode.Prototype__Any{}), &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Digest returns the SHA2-256 hash of the canonical serial form of a selector.
+//
+// Selectors which are Equal have the same digest, so the digest of a
+// selector can be used, together with the link of the node it's applied
+// to, to key a cache of traversal results.
+func Digest(s selector.Selector) ([sha256.Size]byte, error) {
+	b, err := Bytes(s)
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+	return sha256.Sum256(b), nil
+}
+
+// Equal reports whether two selectors have the same canonical form,
+// and thus select the same set of nodes.
+func Equal(a, b selector.Selector) bool {
+	ab, err := Bytes(a)
+	if err != nil {
+		return false
+	}
+	bb, err := Bytes(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(ab, bb)
+}
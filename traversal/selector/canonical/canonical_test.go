@@ -0,0 +1,63 @@
+package canonical
+
+import (
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/traversal/selector"
+	"github.com/ipld/go-ipld-prime/traversal/selector/builder"
+	"github.com/ipld/go-ipld-prime/traversal/selector/parse"
+)
+
+func mustParse(t *testing.T, str string) selector.Selector {
+	s, err := parse.ParseString(str)
+	Require(t, err, ShouldEqual, nil)
+	return s
+}
+
+func TestEqual(t *testing.T) {
+	t.Run("identical selectors are equal", func(t *testing.T) {
+		Wish(t, Equal(mustParse(t, "R5(. | */@)"), mustParse(t, "R5(. | */@)")), ShouldEqual, true)
+	})
+	t.Run("the order of fields does not matter", func(t *testing.T) {
+		ssb := builder.NewSelectorSpecBuilder(basicnode.Prototype__Any{})
+		a, err := ssb.ExploreFields(func(efsb builder.ExploreFieldsSpecBuilder) {
+			efsb.Insert("foo", ssb.Matcher())
+			efsb.Insert("bar", ssb.ExploreAll(ssb.Matcher()))
+		}).Selector()
+		Require(t, err, ShouldEqual, nil)
+		b, err := ssb.ExploreFields(func(efsb builder.ExploreFieldsSpecBuilder) {
+			efsb.Insert("bar", ssb.ExploreAll(ssb.Matcher()))
+			efsb.Insert("foo", ssb.Matcher())
+		}).Selector()
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, Equal(a, b), ShouldEqual, true)
+	})
+	t.Run("the order of union members matters", func(t *testing.T) {
+		Wish(t, Equal(mustParse(t, "foo/. | bar/."), mustParse(t, "bar/. | foo/.")), ShouldEqual, false)
+	})
+	t.Run("different selectors are not equal", func(t *testing.T) {
+		Wish(t, Equal(mustParse(t, "R5(. | */@)"), mustParse(t, "R4(. | */@)")), ShouldEqual, false)
+		Wish(t, Equal(mustParse(t, "foo/."), mustParse(t, "bar/.")), ShouldEqual, false)
+	})
+}
+
+func TestDigest(t *testing.T) {
+	a, err := Digest(mustParse(t, "R*(. | entries/*/@)"))
+	Require(t, err, ShouldEqual, nil)
+	b, err := Digest(mustParse(t, "R*( . | entries / * / @ )"))
+	Require(t, err, ShouldEqual, nil)
+	c, err := Digest(mustParse(t, "R*(. | children/*/@)"))
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, a, ShouldEqual, b)
+	Wish(t, a == c, ShouldEqual, false)
+}
+
+func TestBytes(t *testing.T) {
+	b, err := Bytes(mustParse(t, "."))
+	Require(t, err, ShouldEqual, nil)
+	// {".": {}}
+	Wish(t, b, ShouldEqual, []byte{0xa1, 0x61, '.', 0xa0})
+}
@@ -0,0 +1,246 @@
+package selector
+
+import (
+	"bytes"
+	"fmt"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/fluent"
+)
+
+// Condition is a predicate over a node.
+//
+// Conditions are used to refine selectors: for example, a Matcher with a
+// Condition only includes a node in the result set if the condition holds,
+// and an ExploreConditional only continues exploring if its condition holds.
+//
+// Condition is a union type: which parameters are meaningful depends on its Mode.
+// It's represented as a keyed union, with the keys from the selector spec:
+// "%" (hasKind) for a kind condition, "hasField" for a field-equality
+// condition (whose body is a map with "field" and "==" entries),
+// and "/" (isLink) for a link-equality condition.
+type Condition struct {
+	mode  ConditionMode
+	kind  ipld.Kind
+	field string
+	value ipld.Node
+	link  ipld.Link
+}
+
+// ConditionMode is an enum that represents the type of a Condition.
+type ConditionMode uint8
+
+const (
+	// ConditionMode_Kind means the condition holds for nodes of a given kind.
+	ConditionMode_Kind ConditionMode = 1
+	// ConditionMode_FieldEquals means the condition holds for map nodes
+	// which have a given key, and whose value for that key equals a given scalar.
+	ConditionMode_FieldEquals ConditionMode = 2
+	// ConditionMode_LinkEquals means the condition holds for link nodes
+	// which are equal to a given link.
+	ConditionMode_LinkEquals ConditionMode = 3
+)
+
+// Mode returns the type of this condition.
+func (c Condition) Mode() ConditionMode {
+	return c.mode
+}
+
+// Kind returns the kind checked by a kind condition, or Kind_Invalid otherwise.
+func (c Condition) Kind() ipld.Kind {
+	if c.mode != ConditionMode_Kind {
+		return ipld.Kind_Invalid
+	}
+	return c.kind
+}
+
+// Field returns the field name and value checked by a field-equality
+// condition, or the empty string and nil otherwise.
+func (c Condition) Field() (string, ipld.Node) {
+	if c.mode != ConditionMode_FieldEquals {
+		return "", nil
+	}
+	return c.field, c.value
+}
+
+// Link returns the link checked by a link-equality condition, or nil otherwise.
+func (c Condition) Link() ipld.Link {
+	if c.mode != ConditionMode_LinkEquals {
+		return nil
+	}
+	return c.link
+}
+
+// ConditionKind returns a condition which holds for nodes of the given kind.
+func ConditionKind(k ipld.Kind) Condition {
+	return Condition{mode: ConditionMode_Kind, kind: k}
+}
+
+// ConditionFieldEquals returns a condition which holds for map nodes which
+// have an entry for the given key, where that entry's value is equal to the
+// given scalar value.
+func ConditionFieldEquals(field string, value ipld.Node) Condition {
+	return Condition{mode: ConditionMode_FieldEquals, field: field, value: value}
+}
+
+// ConditionLinkEquals returns a condition which holds for link nodes which
+// are equal to the given link (e.g., for CIDs, which have the same CID).
+func ConditionLinkEquals(lnk ipld.Link) Condition {
+	return Condition{mode: ConditionMode_LinkEquals, link: lnk}
+}
+
+// Match returns true if the condition holds for the given node.
+func (c Condition) Match(n ipld.Node) bool {
+	switch c.mode {
+	case ConditionMode_Kind:
+		return n.Kind() == c.kind
+	case ConditionMode_FieldEquals:
+		if n.Kind() != ipld.Kind_Map {
+			return false
+		}
+		v, err := n.LookupByString(c.field)
+		if err != nil {
+			return false
+		}
+		return scalarEquals(v, c.value)
+	case ConditionMode_LinkEquals:
+		lnk, err := n.AsLink()
+		if err != nil {
+			return false
+		}
+		return linkEquals(lnk, c.link)
+	default:
+		// A zero Condition, with no mode, holds for nothing.
+		return false
+	}
+}
+
+// scalarEquals compares two scalar nodes by kind and value.
+// Recursive kinds are never considered equal.
+func scalarEquals(a, b ipld.Node) bool {
+	if a.Kind() != b.Kind() {
+		return false
+	}
+	switch a.Kind() {
+	case ipld.Kind_Null:
+		return true
+	case ipld.Kind_Bool:
+		x, err1 := a.AsBool()
+		y, err2 := b.AsBool()
+		return err1 == nil && err2 == nil && x == y
+	case ipld.Kind_Int:
+		x, err1 := a.AsInt()
+		y, err2 := b.AsInt()
+		return err1 == nil && err2 == nil && x == y
+	case ipld.Kind_Float:
+		x, err1 := a.AsFloat()
+		y, err2 := b.AsFloat()
+		return err1 == nil && err2 == nil && x == y
+	case ipld.Kind_String:
+		x, err1 := a.AsString()
+		y, err2 := b.AsString()
+		return err1 == nil && err2 == nil && x == y
+	case ipld.Kind_Bytes:
+		x, err1 := a.AsBytes()
+		y, err2 := b.AsBytes()
+		return err1 == nil && err2 == nil && bytes.Equal(x, y)
+	case ipld.Kind_Link:
+		x, err1 := a.AsLink()
+		y, err2 := b.AsLink()
+		return err1 == nil && err2 == nil && linkEquals(x, y)
+	default:
+		return false
+	}
+}
+
+// linkEquals compares two links by their string form, which is unique per
+// the Link contract; links aren't necessarily comparable with ==, as their
+// underlying types may be slices.
+func linkEquals(a, b ipld.Link) bool {
+	return a.String() == b.String()
+}
+
+// ToNode returns the IPLD data representation of this condition
+func (c Condition) ToNode(np ipld.NodePrototype) ipld.Node {
+	return fluent.MustBuildMap(np, 1, func(na fluent.MapAssembler) {
+		switch c.mode {
+		case ConditionMode_Kind:
+			na.AssembleEntry(SelectorKey_ConditionHasKind).AssignString(c.kind.String())
+		case ConditionMode_FieldEquals:
+			na.AssembleEntry(SelectorKey_ConditionHasField).CreateMap(2, func(na fluent.MapAssembler) {
+				na.AssembleEntry(SelectorKey_ConditionField).AssignString(c.field)
+				na.AssembleEntry(SelectorKey_ConditionHasValue).AssignNode(c.value)
+			})
+		case ConditionMode_LinkEquals:
+			na.AssembleEntry(SelectorKey_ConditionIsLink).AssignLink(c.link)
+		default:
+			// A zero Condition has no representation; leaving the map empty
+			// means it's rejected if it's parsed again.
+		}
+	})
+}
+
+var conditionKinds = map[string]ipld.Kind{
+	ipld.Kind_Map.String():    ipld.Kind_Map,
+	ipld.Kind_List.String():   ipld.Kind_List,
+	ipld.Kind_Null.String():   ipld.Kind_Null,
+	ipld.Kind_Bool.String():   ipld.Kind_Bool,
+	ipld.Kind_Int.String():    ipld.Kind_Int,
+	ipld.Kind_Float.String():  ipld.Kind_Float,
+	ipld.Kind_String.String(): ipld.Kind_String,
+	ipld.Kind_Bytes.String():  ipld.Kind_Bytes,
+	ipld.Kind_Link.String():   ipld.Kind_Link,
+}
+
+// ParseCondition assembles a Condition from a condition node
+func (pc ParseContext) ParseCondition(n ipld.Node) (Condition, error) {
+	if n.Kind() != ipld.Kind_Map {
+		return Condition{}, fmt.Errorf("selector spec parse rejected: condition is a keyed union and thus must be a map")
+	}
+	if n.Length() != 1 {
+		return Condition{}, fmt.Errorf("selector spec parse rejected: condition is a keyed union and thus must be a single-entry map")
+	}
+	kn, v, _ := n.MapIterator().Next()
+	kstr, _ := kn.AsString()
+	switch kstr {
+	case SelectorKey_ConditionHasKind:
+		kindName, err := v.AsString()
+		if err != nil {
+			return Condition{}, fmt.Errorf("selector spec parse rejected: hasKind condition must be a string")
+		}
+		kind, ok := conditionKinds[kindName]
+		if !ok {
+			return Condition{}, fmt.Errorf("selector spec parse rejected: %q is not a known kind in hasKind condition", kindName)
+		}
+		return ConditionKind(kind), nil
+	case SelectorKey_ConditionHasField:
+		if v.Kind() != ipld.Kind_Map {
+			return Condition{}, fmt.Errorf("selector spec parse rejected: hasField condition body must be a map")
+		}
+		fieldNode, err := v.LookupByString(SelectorKey_ConditionField)
+		if err != nil {
+			return Condition{}, fmt.Errorf("selector spec parse rejected: field must be present in hasField condition")
+		}
+		field, err := fieldNode.AsString()
+		if err != nil {
+			return Condition{}, fmt.Errorf("selector spec parse rejected: field must be a string in hasField condition")
+		}
+		value, err := v.LookupByString(SelectorKey_ConditionHasValue)
+		if err != nil {
+			return Condition{}, fmt.Errorf("selector spec parse rejected: \"==\" value must be present in hasField condition")
+		}
+		switch value.Kind() {
+		case ipld.Kind_Map, ipld.Kind_List:
+			return Condition{}, fmt.Errorf("selector spec parse rejected: \"==\" value must be a scalar in hasField condition")
+		}
+		return ConditionFieldEquals(field, value), nil
+	case SelectorKey_ConditionIsLink:
+		lnk, err := v.AsLink()
+		if err != nil {
+			return Condition{}, fmt.Errorf("selector spec parse rejected: isLink condition must be a link")
+		}
+		return ConditionLinkEquals(lnk), nil
+	default:
+		return Condition{}, fmt.Errorf("selector spec parse rejected: %q is not a known member of the condition union", kstr)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/fluent"
 )
 
 // ExploreAll is similar to a `*` -- it traverses all elements of an array,
@@ -27,6 +28,15 @@ func (s ExploreAll) Decide(n ipld.Node) bool {
 	return false
 }
 
+// ToNode returns the IPLD data representation of this selector
+func (s ExploreAll) ToNode(np ipld.NodePrototype) ipld.Node {
+	return fluent.MustBuildMap(np, 1, func(na fluent.MapAssembler) {
+		na.AssembleEntry(SelectorKey_ExploreAll).CreateMap(1, func(na fluent.MapAssembler) {
+			na.AssembleEntry(SelectorKey_Next).AssignNode(s.next.ToNode(np))
+		})
+	})
+}
+
 // ParseExploreAll assembles a Selector from a ExploreAll selector node
 func (pc ParseContext) ParseExploreAll(n ipld.Node) (Selector, error) {
 	if n.Kind() != ipld.Kind_Map {
@@ -36,7 +46,7 @@ func (pc ParseContext) ParseExploreAll(n ipld.Node) (Selector, error) {
 	if err != nil {
 		return nil, fmt.Errorf("selector spec parse rejected: next field must be present in ExploreAll selector")
 	}
-	selector, err := pc.ParseSelector(next)
+	selector, err := pc.Descend(ipld.PathSegmentOfString(SelectorKey_Next)).ParseSelector(next)
 	if err != nil {
 		return nil, err
 	}
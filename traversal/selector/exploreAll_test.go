@@ -6,6 +6,7 @@ import (
 
 	. "github.com/warpfork/go-wish"
 
+	ipld "github.com/ipld/go-ipld-prime"
 	"github.com/ipld/go-ipld-prime/fluent"
 	basicnode "github.com/ipld/go-ipld-prime/node/basic"
 )
@@ -27,7 +28,7 @@ func TestParseExploreAll(t *testing.T) {
 			na.AssembleEntry(SelectorKey_Next).AssignInt(0)
 		})
 		_, err := ParseContext{}.ParseExploreAll(sn)
-		Wish(t, err, ShouldEqual, fmt.Errorf("selector spec parse rejected: selector is a keyed union and thus must be a map"))
+		Wish(t, err, ShouldEqual, ParseError{ipld.ParsePath(">"), fmt.Errorf("selector spec parse rejected: selector is a keyed union and thus must be a map, got int")})
 	})
 	t.Run("parsing map node with next field with valid selector node should parse", func(t *testing.T) {
 		sn := fluent.MustBuildMap(basicnode.Prototype__Map{}, 1, func(na fluent.MapAssembler) {
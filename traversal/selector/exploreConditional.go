@@ -0,0 +1,88 @@
+package selector
+
+import (
+	"fmt"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/fluent"
+)
+
+// ExploreConditional applies a next selector to a node only if a condition
+// holds for that node; otherwise, exploration stops there.
+//
+// ExploreConditional is useful for pruning exploration based on the contents
+// of nodes: for example, when placed inside the sequence of an
+// ExploreRecursive, it can limit recursion to only those entries whose "type"
+// field is equal to "dir".
+type ExploreConditional struct {
+	condition Condition // condition which must hold for the node
+	next      Selector  // selector to apply to the node if the condition holds
+}
+
+// Interests for ExploreConditional are the interests of the next selector
+func (s ExploreConditional) Interests() []ipld.PathSegment {
+	return s.next.Interests()
+}
+
+// Explore returns the next selector's exploration of the node
+// if the condition holds for the node, or nil if not
+func (s ExploreConditional) Explore(n ipld.Node, p ipld.PathSegment) Selector {
+	if !s.condition.Match(n) {
+		return nil
+	}
+	return s.next.Explore(n, p)
+}
+
+// Decide returns the next selector's decision for the node
+// if the condition holds for the node, or false if not
+func (s ExploreConditional) Decide(n ipld.Node) bool {
+	return s.condition.Match(n) && s.next.Decide(n)
+}
+
+// ToNode returns the IPLD data representation of this selector
+func (s ExploreConditional) ToNode(np ipld.NodePrototype) ipld.Node {
+	return fluent.MustBuildMap(np, 1, func(na fluent.MapAssembler) {
+		na.AssembleEntry(SelectorKey_ExploreConditional).CreateMap(2, func(na fluent.MapAssembler) {
+			na.AssembleEntry(SelectorKey_Condition).AssignNode(s.condition.ToNode(np))
+			na.AssembleEntry(SelectorKey_Next).AssignNode(s.next.ToNode(np))
+		})
+	})
+}
+
+// MatchLabels returns the labels of the next selector if the condition holds
+func (s ExploreConditional) MatchLabels(n ipld.Node) []string {
+	if !s.condition.Match(n) {
+		return nil
+	}
+	return MatchLabels(s.next, n)
+}
+
+// MatchedNode returns the matched node of the next selector
+func (s ExploreConditional) MatchedNode(n ipld.Node) (ipld.Node, error) {
+	return MatchedNode(s.next, n)
+}
+
+// ParseExploreConditional assembles a Selector
+// from an ExploreConditional selector node
+func (pc ParseContext) ParseExploreConditional(n ipld.Node) (Selector, error) {
+	if n.Kind() != ipld.Kind_Map {
+		return nil, fmt.Errorf("selector spec parse rejected: selector body must be a map")
+	}
+	conditionNode, err := n.LookupByString(SelectorKey_Condition)
+	if err != nil {
+		return nil, fmt.Errorf("selector spec parse rejected: condition field must be present in ExploreConditional selector")
+	}
+	condition, err := pc.ParseCondition(conditionNode)
+	if err != nil {
+		return nil, pc.Descend(ipld.PathSegmentOfString(SelectorKey_Condition)).wrapError(err)
+	}
+	next, err := n.LookupByString(SelectorKey_Next)
+	if err != nil {
+		return nil, fmt.Errorf("selector spec parse rejected: next field must be present in ExploreConditional selector")
+	}
+	selector, err := pc.Descend(ipld.PathSegmentOfString(SelectorKey_Next)).ParseSelector(next)
+	if err != nil {
+		return nil, err
+	}
+	return ExploreConditional{condition, selector}, nil
+}
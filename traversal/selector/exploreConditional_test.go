@@ -0,0 +1,93 @@
+package selector
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/fluent"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+)
+
+func TestParseExploreConditional(t *testing.T) {
+	t.Run("parsing non map node should error", func(t *testing.T) {
+		sn := basicnode.NewInt(0)
+		_, err := ParseContext{}.ParseExploreConditional(sn)
+		Wish(t, err, ShouldEqual, fmt.Errorf("selector spec parse rejected: selector body must be a map"))
+	})
+	t.Run("parsing map node without condition field should error", func(t *testing.T) {
+		sn := fluent.MustBuildMap(basicnode.Prototype__Map{}, 1, func(na fluent.MapAssembler) {
+			na.AssembleEntry(SelectorKey_Next).CreateMap(1, func(na fluent.MapAssembler) {
+				na.AssembleEntry(SelectorKey_Matcher).CreateMap(0, func(na fluent.MapAssembler) {})
+			})
+		})
+		_, err := ParseContext{}.ParseExploreConditional(sn)
+		Wish(t, err, ShouldEqual, fmt.Errorf("selector spec parse rejected: condition field must be present in ExploreConditional selector"))
+	})
+	t.Run("parsing map node with a non-scalar hasField value should error", func(t *testing.T) {
+		sn := fluent.MustBuildMap(basicnode.Prototype__Map{}, 2, func(na fluent.MapAssembler) {
+			na.AssembleEntry(SelectorKey_Condition).CreateMap(1, func(na fluent.MapAssembler) {
+				na.AssembleEntry(SelectorKey_ConditionHasField).CreateMap(2, func(na fluent.MapAssembler) {
+					na.AssembleEntry(SelectorKey_ConditionField).AssignString("type")
+					na.AssembleEntry(SelectorKey_ConditionHasValue).CreateList(0, func(na fluent.ListAssembler) {})
+				})
+			})
+			na.AssembleEntry(SelectorKey_Next).CreateMap(1, func(na fluent.MapAssembler) {
+				na.AssembleEntry(SelectorKey_Matcher).CreateMap(0, func(na fluent.MapAssembler) {})
+			})
+		})
+		_, err := ParseContext{}.ParseExploreConditional(sn)
+		Wish(t, err, ShouldEqual, ParseError{ipld.ParsePath("&"), fmt.Errorf("selector spec parse rejected: \"==\" value must be a scalar in hasField condition")})
+	})
+	t.Run("parsing map node without next field should error", func(t *testing.T) {
+		sn := fluent.MustBuildMap(basicnode.Prototype__Map{}, 1, func(na fluent.MapAssembler) {
+			na.AssembleEntry(SelectorKey_Condition).CreateMap(1, func(na fluent.MapAssembler) {
+				na.AssembleEntry(SelectorKey_ConditionHasKind).AssignString("map")
+			})
+		})
+		_, err := ParseContext{}.ParseExploreConditional(sn)
+		Wish(t, err, ShouldEqual, fmt.Errorf("selector spec parse rejected: next field must be present in ExploreConditional selector"))
+	})
+	t.Run("parsing map node with condition and next should parse", func(t *testing.T) {
+		sn := fluent.MustBuildMap(basicnode.Prototype__Map{}, 2, func(na fluent.MapAssembler) {
+			na.AssembleEntry(SelectorKey_Condition).CreateMap(1, func(na fluent.MapAssembler) {
+				na.AssembleEntry(SelectorKey_ConditionHasField).CreateMap(2, func(na fluent.MapAssembler) {
+					na.AssembleEntry(SelectorKey_ConditionField).AssignString("type")
+					na.AssembleEntry(SelectorKey_ConditionHasValue).AssignString("dir")
+				})
+			})
+			na.AssembleEntry(SelectorKey_Next).CreateMap(1, func(na fluent.MapAssembler) {
+				na.AssembleEntry(SelectorKey_Matcher).CreateMap(0, func(na fluent.MapAssembler) {})
+			})
+		})
+		s, err := ParseContext{}.ParseExploreConditional(sn)
+		Wish(t, err, ShouldEqual, nil)
+		Wish(t, s, ShouldEqual, ExploreConditional{ConditionFieldEquals("type", basicnode.NewString("dir")), Matcher{}})
+	})
+}
+
+func TestExploreConditionalExplore(t *testing.T) {
+	s := ExploreConditional{ConditionFieldEquals("type", basicnode.NewString("dir")), ExploreAll{Matcher{}}}
+	dir := fluent.MustBuildMap(basicnode.Prototype__Map{}, 2, func(na fluent.MapAssembler) {
+		na.AssembleEntry("type").AssignString("dir")
+		na.AssembleEntry("name").AssignString("foo")
+	})
+	file := fluent.MustBuildMap(basicnode.Prototype__Map{}, 2, func(na fluent.MapAssembler) {
+		na.AssembleEntry("type").AssignString("file")
+		na.AssembleEntry("name").AssignString("bar")
+	})
+	t.Run("exploring should return the next selector's result when the condition holds", func(t *testing.T) {
+		Wish(t, s.Explore(dir, ipld.PathSegmentOfString("name")), ShouldEqual, Matcher{})
+	})
+	t.Run("exploring should return nil when the condition doesn't hold", func(t *testing.T) {
+		Wish(t, s.Explore(file, ipld.PathSegmentOfString("name")), ShouldEqual, nil)
+		Wish(t, s.Explore(basicnode.NewString("dir"), ipld.PathSegmentOfString("name")), ShouldEqual, nil)
+	})
+	t.Run("deciding should require the condition to hold", func(t *testing.T) {
+		m := ExploreConditional{ConditionKind(ipld.Kind_String), Matcher{}}
+		Wish(t, m.Decide(basicnode.NewString("x")), ShouldEqual, true)
+		Wish(t, m.Decide(basicnode.NewInt(1)), ShouldEqual, false)
+	})
+}
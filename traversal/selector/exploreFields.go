@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/fluent"
 )
 
 // ExploreFields traverses named fields in a map (or equivalently, struct, if
@@ -38,6 +39,19 @@ func (s ExploreFields) Decide(n ipld.Node) bool {
 	return false
 }
 
+// ToNode returns the IPLD data representation of this selector
+func (s ExploreFields) ToNode(np ipld.NodePrototype) ipld.Node {
+	return fluent.MustBuildMap(np, 1, func(na fluent.MapAssembler) {
+		na.AssembleEntry(SelectorKey_ExploreFields).CreateMap(1, func(na fluent.MapAssembler) {
+			na.AssembleEntry(SelectorKey_Fields).CreateMap(int64(len(s.interests)), func(na fluent.MapAssembler) {
+				for _, ps := range s.interests {
+					na.AssembleEntry(ps.String()).AssignNode(s.selections[ps.String()].ToNode(np))
+				}
+			})
+		})
+	})
+}
+
 // ParseExploreFields assembles a Selector
 // from a ExploreFields selector node
 func (pc ParseContext) ParseExploreFields(n ipld.Node) (Selector, error) {
@@ -63,7 +77,7 @@ func (pc ParseContext) ParseExploreFields(n ipld.Node) (Selector, error) {
 
 		kstr, _ := kn.AsString()
 		x.interests = append(x.interests, ipld.PathSegmentOfString(kstr))
-		x.selections[kstr], err = pc.ParseSelector(v)
+		x.selections[kstr], err = pc.Descend(ipld.PathSegmentOfString(SelectorKey_Fields)).Descend(ipld.PathSegmentOfString(kstr)).ParseSelector(v)
 		if err != nil {
 			return nil, err
 		}
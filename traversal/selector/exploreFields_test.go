@@ -36,7 +36,7 @@ func TestParseExploreFields(t *testing.T) {
 			})
 		})
 		_, err := ParseContext{}.ParseExploreFields(sn)
-		Wish(t, err, ShouldEqual, fmt.Errorf("selector spec parse rejected: selector is a keyed union and thus must be a map"))
+		Wish(t, err, ShouldEqual, ParseError{ipld.ParsePath("f>/applesauce"), fmt.Errorf("selector spec parse rejected: selector is a keyed union and thus must be a map, got int")})
 	})
 	t.Run("parsing map node with fields value that is map of only valid selector node should parse", func(t *testing.T) {
 		sn := fluent.MustBuildMap(basicnode.Prototype__Map{}, 1, func(na fluent.MapAssembler) {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/fluent"
 )
 
 // ExploreIndex traverses a specific index in a list, and applies a next
@@ -37,6 +38,17 @@ func (s ExploreIndex) Decide(n ipld.Node) bool {
 	return false
 }
 
+// ToNode returns the IPLD data representation of this selector
+func (s ExploreIndex) ToNode(np ipld.NodePrototype) ipld.Node {
+	index, _ := s.interest[0].Index()
+	return fluent.MustBuildMap(np, 1, func(na fluent.MapAssembler) {
+		na.AssembleEntry(SelectorKey_ExploreIndex).CreateMap(2, func(na fluent.MapAssembler) {
+			na.AssembleEntry(SelectorKey_Index).AssignInt(index)
+			na.AssembleEntry(SelectorKey_Next).AssignNode(s.next.ToNode(np))
+		})
+	})
+}
+
 // ParseExploreIndex assembles a Selector
 // from a ExploreIndex selector node
 func (pc ParseContext) ParseExploreIndex(n ipld.Node) (Selector, error) {
@@ -55,7 +67,7 @@ func (pc ParseContext) ParseExploreIndex(n ipld.Node) (Selector, error) {
 	if err != nil {
 		return nil, fmt.Errorf("selector spec parse rejected: next field must be present in ExploreIndex selector")
 	}
-	selector, err := pc.ParseSelector(next)
+	selector, err := pc.Descend(ipld.PathSegmentOfString(SelectorKey_Next)).ParseSelector(next)
 	if err != nil {
 		return nil, err
 	}
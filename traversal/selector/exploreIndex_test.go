@@ -49,7 +49,7 @@ func TestParseExploreIndex(t *testing.T) {
 			na.AssembleEntry(SelectorKey_Next).AssignInt(0)
 		})
 		_, err := ParseContext{}.ParseExploreIndex(sn)
-		Wish(t, err, ShouldEqual, fmt.Errorf("selector spec parse rejected: selector is a keyed union and thus must be a map"))
+		Wish(t, err, ShouldEqual, ParseError{ipld.ParsePath(">"), fmt.Errorf("selector spec parse rejected: selector is a keyed union and thus must be a map, got int")})
 	})
 	t.Run("parsing map node with next field with valid selector node should parse", func(t *testing.T) {
 		sn := fluent.MustBuildMap(basicnode.Prototype__Map{}, 2, func(na fluent.MapAssembler) {
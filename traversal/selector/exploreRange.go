@@ -4,10 +4,19 @@ import (
 	"fmt"
 
 	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/fluent"
 )
 
 // ExploreRange traverses a list, and for each element in the range specified,
 // will apply a next selector to those reached nodes.
+//
+// The range is half-open: start is included, end is not.
+// Small ranges report each index in the range as an interest, so a traversal
+// can look up just those elements without iterating the rest of the list.
+// Ranges wider than exploreRangeMaxInterests report nil interests instead,
+// so that a very wide range (e.g. one used for open-ended pagination)
+// doesn't require a huge allocation up front; the traversal then uses
+// IndexRange to visit only the indexes in the range which the list has.
 type ExploreRange struct {
 	next     Selector // selector for element we're interested in
 	start    int64
@@ -15,11 +24,21 @@ type ExploreRange struct {
 	interest []ipld.PathSegment // index of element we're interested in
 }
 
-// Interests for ExploreRange are all path segments within the iteration range
+// exploreRangeMaxInterests is the widest range for which ExploreRange will
+// precompute its interests.
+const exploreRangeMaxInterests = 1 << 10
+
+// Interests for ExploreRange are all path segments within the iteration range,
+// or nil if the range is too wide to enumerate
 func (s ExploreRange) Interests() []ipld.PathSegment {
 	return s.interest
 }
 
+// IndexRange returns the range of list indexes this selector explores
+func (s ExploreRange) IndexRange() (start, end int64, ok bool) {
+	return s.start, s.end, true
+}
+
 // Explore returns the node's selector if
 // the path matches an index in the range of this selector
 func (s ExploreRange) Explore(n ipld.Node, p ipld.PathSegment) Selector {
@@ -41,6 +60,17 @@ func (s ExploreRange) Decide(n ipld.Node) bool {
 	return false
 }
 
+// ToNode returns the IPLD data representation of this selector
+func (s ExploreRange) ToNode(np ipld.NodePrototype) ipld.Node {
+	return fluent.MustBuildMap(np, 1, func(na fluent.MapAssembler) {
+		na.AssembleEntry(SelectorKey_ExploreRange).CreateMap(3, func(na fluent.MapAssembler) {
+			na.AssembleEntry(SelectorKey_Start).AssignInt(s.start)
+			na.AssembleEntry(SelectorKey_End).AssignInt(s.end)
+			na.AssembleEntry(SelectorKey_Next).AssignNode(s.next.ToNode(np))
+		})
+	})
+}
+
 // ParseExploreRange assembles a Selector
 // from a ExploreRange selector node
 func (pc ParseContext) ParseExploreRange(n ipld.Node) (Selector, error) {
@@ -55,6 +85,9 @@ func (pc ParseContext) ParseExploreRange(n ipld.Node) (Selector, error) {
 	if err != nil {
 		return nil, fmt.Errorf("selector spec parse rejected: start field must be a number in ExploreRange selector")
 	}
+	if startValue < 0 {
+		return nil, fmt.Errorf("selector spec parse rejected: start field must not be negative in ExploreRange selector")
+	}
 	endNode, err := n.LookupByString(SelectorKey_End)
 	if err != nil {
 		return nil, fmt.Errorf("selector spec parse rejected: end field must be present in ExploreRange selector")
@@ -70,7 +103,7 @@ func (pc ParseContext) ParseExploreRange(n ipld.Node) (Selector, error) {
 	if err != nil {
 		return nil, fmt.Errorf("selector spec parse rejected: next field must be present in ExploreRange selector")
 	}
-	selector, err := pc.ParseSelector(next)
+	selector, err := pc.Descend(ipld.PathSegmentOfString(SelectorKey_Next)).ParseSelector(next)
 	if err != nil {
 		return nil, err
 	}
@@ -78,10 +111,13 @@ func (pc ParseContext) ParseExploreRange(n ipld.Node) (Selector, error) {
 		selector,
 		startValue,
 		endValue,
-		make([]ipld.PathSegment, 0, endValue-startValue),
+		nil,
 	}
-	for i := startValue; i < endValue; i++ {
-		x.interest = append(x.interest, ipld.PathSegmentOfInt(i))
+	if endValue-startValue <= exploreRangeMaxInterests {
+		x.interest = make([]ipld.PathSegment, 0, endValue-startValue)
+		for i := startValue; i < endValue; i++ {
+			x.interest = append(x.interest, ipld.PathSegmentOfInt(i))
+		}
 	}
 	return x, nil
 }
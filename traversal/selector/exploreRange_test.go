@@ -46,6 +46,17 @@ func TestParseExploreRange(t *testing.T) {
 		_, err := ParseContext{}.ParseExploreRange(sn)
 		Wish(t, err, ShouldEqual, fmt.Errorf("selector spec parse rejected: start field must be a number in ExploreRange selector"))
 	})
+	t.Run("parsing map node with a negative start field should error", func(t *testing.T) {
+		sn := fluent.MustBuildMap(basicnode.Prototype__Map{}, 3, func(na fluent.MapAssembler) {
+			na.AssembleEntry(SelectorKey_Start).AssignInt(-1)
+			na.AssembleEntry(SelectorKey_End).AssignInt(3)
+			na.AssembleEntry(SelectorKey_Next).CreateMap(1, func(na fluent.MapAssembler) {
+				na.AssembleEntry(SelectorKey_Matcher).CreateMap(0, func(na fluent.MapAssembler) {})
+			})
+		})
+		_, err := ParseContext{}.ParseExploreRange(sn)
+		Wish(t, err, ShouldEqual, fmt.Errorf("selector spec parse rejected: start field must not be negative in ExploreRange selector"))
+	})
 	t.Run("parsing map node without end field should error", func(t *testing.T) {
 		sn := fluent.MustBuildMap(basicnode.Prototype__Map{}, 2, func(na fluent.MapAssembler) {
 			na.AssembleEntry(SelectorKey_Start).AssignInt(2)
@@ -85,7 +96,7 @@ func TestParseExploreRange(t *testing.T) {
 			na.AssembleEntry(SelectorKey_Next).AssignInt(0)
 		})
 		_, err := ParseContext{}.ParseExploreRange(sn)
-		Wish(t, err, ShouldEqual, fmt.Errorf("selector spec parse rejected: selector is a keyed union and thus must be a map"))
+		Wish(t, err, ShouldEqual, ParseError{ipld.ParsePath(">"), fmt.Errorf("selector spec parse rejected: selector is a keyed union and thus must be a map, got int")})
 	})
 
 	t.Run("parsing map node with next field with valid selector node should parse", func(t *testing.T) {
@@ -100,6 +111,19 @@ func TestParseExploreRange(t *testing.T) {
 		Wish(t, err, ShouldEqual, nil)
 		Wish(t, s, ShouldEqual, ExploreRange{Matcher{}, 2, 3, []ipld.PathSegment{ipld.PathSegmentOfInt(2)}})
 	})
+	t.Run("parsing a very wide range should not enumerate interests", func(t *testing.T) {
+		sn := fluent.MustBuildMap(basicnode.Prototype__Map{}, 3, func(na fluent.MapAssembler) {
+			na.AssembleEntry(SelectorKey_Start).AssignInt(100)
+			na.AssembleEntry(SelectorKey_End).AssignInt(1 << 40)
+			na.AssembleEntry(SelectorKey_Next).CreateMap(1, func(na fluent.MapAssembler) {
+				na.AssembleEntry(SelectorKey_Matcher).CreateMap(0, func(na fluent.MapAssembler) {})
+			})
+		})
+		s, err := ParseContext{}.ParseExploreRange(sn)
+		Wish(t, err, ShouldEqual, nil)
+		Wish(t, s, ShouldEqual, ExploreRange{Matcher{}, 100, 1 << 40, nil})
+		Wish(t, s.Interests() == nil, ShouldEqual, true)
+	})
 }
 
 func TestExploreRangeExplore(t *testing.T) {
@@ -127,4 +151,10 @@ func TestExploreRangeExplore(t *testing.T) {
 		returnedSelector := s.Explore(n, ipld.PathSegmentOfInt(3))
 		Wish(t, returnedSelector, ShouldEqual, Matcher{})
 	})
+	t.Run("exploring a wide range should filter by index", func(t *testing.T) {
+		s := ExploreRange{Matcher{}, 2, 1 << 40, nil}
+		Wish(t, s.Explore(n, ipld.PathSegmentOfInt(1)), ShouldEqual, nil)
+		Wish(t, s.Explore(n, ipld.PathSegmentOfInt(2)), ShouldEqual, Matcher{})
+		Wish(t, s.Explore(n, ipld.PathSegmentOfInt(3)), ShouldEqual, Matcher{})
+	})
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/fluent"
 )
 
 // ExploreRecursive traverses some structure recursively.
@@ -121,6 +122,10 @@ func (s ExploreRecursive) hasRecursiveEdge(nextSelector Selector) bool {
 			}
 		}
 	}
+	exploreConditional, isConditional := nextSelector.(ExploreConditional)
+	if isConditional {
+		return s.hasRecursiveEdge(exploreConditional.next)
+	}
 	return false
 }
 
@@ -146,6 +151,14 @@ func (s ExploreRecursive) replaceRecursiveEdge(nextSelector Selector, replacemen
 		}
 		return ExploreUnion{replacementMembers}
 	}
+	exploreConditional, isConditional := nextSelector.(ExploreConditional)
+	if isConditional {
+		newSelector := s.replaceRecursiveEdge(exploreConditional.next, replacement)
+		if newSelector == nil {
+			return nil
+		}
+		return ExploreConditional{exploreConditional.condition, newSelector}
+	}
 	return nextSelector
 }
 
@@ -154,6 +167,40 @@ func (s ExploreRecursive) Decide(n ipld.Node) bool {
 	return s.current.Decide(n)
 }
 
+// ToNode returns the IPLD data representation of this selector.
+//
+// Note that the representation describes the recursion from the start of its
+// sequence, with the remaining limit; if the selector has been partially
+// explored, the progress within the current iteration of the sequence is not
+// represented.
+func (s ExploreRecursive) ToNode(np ipld.NodePrototype) ipld.Node {
+	return fluent.MustBuildMap(np, 1, func(na fluent.MapAssembler) {
+		na.AssembleEntry(SelectorKey_ExploreRecursive).CreateMap(2, func(na fluent.MapAssembler) {
+			na.AssembleEntry(SelectorKey_Limit).CreateMap(1, func(na fluent.MapAssembler) {
+				switch s.limit.mode {
+				case RecursionLimit_Depth:
+					na.AssembleEntry(SelectorKey_LimitDepth).AssignInt(s.limit.depth)
+				case RecursionLimit_None:
+					na.AssembleEntry(SelectorKey_LimitNone).CreateMap(0, func(na fluent.MapAssembler) {})
+				default:
+					panic("Unsupported recursion limit type")
+				}
+			})
+			na.AssembleEntry(SelectorKey_Sequence).AssignNode(s.sequence.ToNode(np))
+		})
+	})
+}
+
+// MatchLabels returns the labels of the current selector if it matches the node
+func (s ExploreRecursive) MatchLabels(n ipld.Node) []string {
+	return MatchLabels(s.current, n)
+}
+
+// MatchedNode returns the matched node of the current selector
+func (s ExploreRecursive) MatchedNode(n ipld.Node) (ipld.Node, error) {
+	return MatchedNode(s.current, n)
+}
+
 type exploreRecursiveContext struct {
 	edgesFound int
 }
@@ -178,14 +225,14 @@ func (pc ParseContext) ParseExploreRecursive(n ipld.Node) (Selector, error) {
 	}
 	limit, err := parseLimit(limitNode)
 	if err != nil {
-		return nil, err
+		return nil, pc.Descend(ipld.PathSegmentOfString(SelectorKey_Limit)).wrapError(err)
 	}
 	sequence, err := n.LookupByString(SelectorKey_Sequence)
 	if err != nil {
 		return nil, fmt.Errorf("selector spec parse rejected: sequence field must be present in ExploreRecursive selector")
 	}
 	erc := &exploreRecursiveContext{}
-	selector, err := pc.PushParent(erc).ParseSelector(sequence)
+	selector, err := pc.PushParent(erc).Descend(ipld.PathSegmentOfString(SelectorKey_Sequence)).ParseSelector(sequence)
 	if err != nil {
 		return nil, err
 	}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/fluent"
 )
 
 // ExploreRecursiveEdge is a special sentinel value which is used to mark
@@ -31,6 +32,13 @@ func (s ExploreRecursiveEdge) Decide(n ipld.Node) bool {
 	panic("Traversed Explore Recursive Edge Node With No Parent")
 }
 
+// ToNode returns the IPLD data representation of this selector
+func (s ExploreRecursiveEdge) ToNode(np ipld.NodePrototype) ipld.Node {
+	return fluent.MustBuildMap(np, 1, func(na fluent.MapAssembler) {
+		na.AssembleEntry(SelectorKey_ExploreRecursiveEdge).CreateMap(0, func(na fluent.MapAssembler) {})
+	})
+}
+
 // ParseExploreRecursiveEdge assembles a Selector
 // from a exploreRecursiveEdge selector node
 func (pc ParseContext) ParseExploreRecursiveEdge(n ipld.Node) (Selector, error) {
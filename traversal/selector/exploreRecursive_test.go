@@ -45,7 +45,7 @@ func TestParseExploreRecursive(t *testing.T) {
 			})
 		})
 		_, err := ParseContext{}.ParseExploreRecursive(sn)
-		Wish(t, err, ShouldEqual, fmt.Errorf("selector spec parse rejected: limit in ExploreRecursive is a keyed union and thus must be a map"))
+		Wish(t, err, ShouldEqual, ParseError{ipld.ParsePath("l"), fmt.Errorf("selector spec parse rejected: limit in ExploreRecursive is a keyed union and thus must be a map")})
 	})
 	t.Run("parsing map node with limit field that is not a single entry map should fail", func(t *testing.T) {
 		sn := fluent.MustBuildMap(basicnode.Prototype__Map{}, 2, func(na fluent.MapAssembler) {
@@ -58,7 +58,7 @@ func TestParseExploreRecursive(t *testing.T) {
 			})
 		})
 		_, err := ParseContext{}.ParseExploreRecursive(sn)
-		Wish(t, err, ShouldEqual, fmt.Errorf("selector spec parse rejected: limit in ExploreRecursive is a keyed union and thus must be a single-entry map"))
+		Wish(t, err, ShouldEqual, ParseError{ipld.ParsePath("l"), fmt.Errorf("selector spec parse rejected: limit in ExploreRecursive is a keyed union and thus must be a single-entry map")})
 	})
 	t.Run("parsing map node with limit field that does not have a known key should fail", func(t *testing.T) {
 		sn := fluent.MustBuildMap(basicnode.Prototype__Map{}, 2, func(na fluent.MapAssembler) {
@@ -70,7 +70,7 @@ func TestParseExploreRecursive(t *testing.T) {
 			})
 		})
 		_, err := ParseContext{}.ParseExploreRecursive(sn)
-		Wish(t, err, ShouldEqual, fmt.Errorf("selector spec parse rejected: \"applesauce\" is not a known member of the limit union in ExploreRecursive"))
+		Wish(t, err, ShouldEqual, ParseError{ipld.ParsePath("l"), fmt.Errorf("selector spec parse rejected: \"applesauce\" is not a known member of the limit union in ExploreRecursive")})
 	})
 	t.Run("parsing map node with limit field of type depth that is not an int should error", func(t *testing.T) {
 		sn := fluent.MustBuildMap(basicnode.Prototype__Map{}, 2, func(na fluent.MapAssembler) {
@@ -82,7 +82,7 @@ func TestParseExploreRecursive(t *testing.T) {
 			})
 		})
 		_, err := ParseContext{}.ParseExploreRecursive(sn)
-		Wish(t, err, ShouldEqual, fmt.Errorf("selector spec parse rejected: limit field of type depth must be a number in ExploreRecursive selector"))
+		Wish(t, err, ShouldEqual, ParseError{ipld.ParsePath("l"), fmt.Errorf("selector spec parse rejected: limit field of type depth must be a number in ExploreRecursive selector")})
 	})
 	t.Run("parsing map node with sequence field with invalid selector node should return child's error", func(t *testing.T) {
 		sn := fluent.MustBuildMap(basicnode.Prototype__Map{}, 2, func(na fluent.MapAssembler) {
@@ -92,7 +92,7 @@ func TestParseExploreRecursive(t *testing.T) {
 			na.AssembleEntry(SelectorKey_Sequence).AssignInt(0)
 		})
 		_, err := ParseContext{}.ParseExploreRecursive(sn)
-		Wish(t, err, ShouldEqual, fmt.Errorf("selector spec parse rejected: selector is a keyed union and thus must be a map"))
+		Wish(t, err, ShouldEqual, ParseError{ipld.ParsePath(":>"), fmt.Errorf("selector spec parse rejected: selector is a keyed union and thus must be a map, got int")})
 	})
 	t.Run("parsing map node with sequence field with valid selector w/o ExploreRecursiveEdge should not parse", func(t *testing.T) {
 		sn := fluent.MustBuildMap(basicnode.Prototype__Map{}, 2, func(na fluent.MapAssembler) {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/fluent"
 )
 
 // ExploreUnion allows selection to continue with two or more distinct selectors
@@ -20,6 +21,10 @@ type ExploreUnion struct {
 // Interests for ExploreUnion is:
 // - nil (aka all) if any member selector has nil interests
 // - the union of values returned by all member selectors otherwise
+//
+// Segments which several members are interested in are only listed once,
+// so that a traversal explores each child once (with the combined selector
+// returned by Explore), rather than once per interested member.
 func (s ExploreUnion) Interests() []ipld.PathSegment {
 	// Check for any high-cardinality selectors first; if so, shortcircuit.
 	//  (n.b. we're assuming the 'Interests' method is cheap here.)
@@ -28,11 +33,19 @@ func (s ExploreUnion) Interests() []ipld.PathSegment {
 			return nil
 		}
 	}
-	// Accumulate the whitelist of interesting path segments.
-	// TODO: Dedup?
+	// Accumulate the whitelist of interesting path segments, skipping duplicates.
 	v := []ipld.PathSegment{}
+	//  Segments are compared as they are, so that an int segment and
+	//  a string segment with the same digits are both kept.
+	seen := map[ipld.PathSegment]struct{}{}
 	for _, m := range s.Members {
-		v = append(v, m.Interests()...)
+		for _, ps := range m.Interests() {
+			if _, exists := seen[ps]; exists {
+				continue
+			}
+			seen[ps] = struct{}{}
+			v = append(v, ps)
+		}
 	}
 	return v
 }
@@ -42,12 +55,19 @@ func (s ExploreUnion) Interests() []ipld.PathSegment {
 // - a new union selector if more than one member returns a selector
 // - if exactly one member returns a selector, that selector
 // - nil if no members return a selector
+//
+// Results which are themselves unions are flattened into the new union,
+// so that repeated exploration doesn't build up deeply nested unions.
 func (s ExploreUnion) Explore(n ipld.Node, p ipld.PathSegment) Selector {
 	// TODO: memory efficient?
 	nonNilResults := make([]Selector, 0, len(s.Members))
 	for _, member := range s.Members {
 		resultSelector := member.Explore(n, p)
-		if resultSelector != nil {
+		switch resultSelector := resultSelector.(type) {
+		case nil:
+		case ExploreUnion:
+			nonNilResults = append(nonNilResults, resultSelector.Members...)
+		default:
 			nonNilResults = append(nonNilResults, resultSelector)
 		}
 	}
@@ -71,6 +91,36 @@ func (s ExploreUnion) Decide(n ipld.Node) bool {
 	return false
 }
 
+// ToNode returns the IPLD data representation of this selector
+func (s ExploreUnion) ToNode(np ipld.NodePrototype) ipld.Node {
+	return fluent.MustBuildMap(np, 1, func(na fluent.MapAssembler) {
+		na.AssembleEntry(SelectorKey_ExploreUnion).CreateList(int64(len(s.Members)), func(na fluent.ListAssembler) {
+			for _, member := range s.Members {
+				na.AssembleValue().AssignNode(member.ToNode(np))
+			}
+		})
+	})
+}
+
+// MatchLabels returns the labels of all members which match the node
+func (s ExploreUnion) MatchLabels(n ipld.Node) []string {
+	var labels []string
+	for _, m := range s.Members {
+		labels = append(labels, MatchLabels(m, n)...)
+	}
+	return labels
+}
+
+// MatchedNode returns the matched node of the first member which matches the node
+func (s ExploreUnion) MatchedNode(n ipld.Node) (ipld.Node, error) {
+	for _, m := range s.Members {
+		if m.Decide(n) {
+			return MatchedNode(m, n)
+		}
+	}
+	return n, nil
+}
+
 // ParseExploreUnion assembles a Selector
 // from an ExploreUnion selector node
 func (pc ParseContext) ParseExploreUnion(n ipld.Node) (Selector, error) {
@@ -81,11 +131,11 @@ func (pc ParseContext) ParseExploreUnion(n ipld.Node) (Selector, error) {
 		make([]Selector, 0, n.Length()),
 	}
 	for itr := n.ListIterator(); !itr.Done(); {
-		_, v, err := itr.Next()
+		idx, v, err := itr.Next()
 		if err != nil {
 			return nil, fmt.Errorf("error during selector spec parse: %s", err)
 		}
-		member, err := pc.ParseSelector(v)
+		member, err := pc.Descend(ipld.PathSegmentOfInt(idx)).ParseSelector(v)
 		if err != nil {
 			return nil, err
 		}
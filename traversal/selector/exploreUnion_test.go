@@ -25,7 +25,7 @@ func TestParseExploreUnion(t *testing.T) {
 			na.AssembleValue().AssignInt(2)
 		})
 		_, err := ParseContext{}.ParseExploreUnion(sn)
-		Wish(t, err, ShouldEqual, fmt.Errorf("selector spec parse rejected: selector is a keyed union and thus must be a map"))
+		Wish(t, err, ShouldEqual, ParseError{ipld.NewPath([]ipld.PathSegment{ipld.PathSegmentOfInt(1)}), fmt.Errorf("selector spec parse rejected: selector is a keyed union and thus must be a map, got int")})
 	})
 
 	t.Run("parsing map node with next field with valid selector node should parse", func(t *testing.T) {
@@ -78,6 +78,15 @@ func TestExploreUnionExplore(t *testing.T) {
 		returnedSelector := s.Explore(n, ipld.PathSegmentOfInt(2))
 		Wish(t, returnedSelector, ShouldEqual, ExploreUnion{[]Selector{Matcher{}, Matcher{}}})
 	})
+	t.Run("exploring should flatten member results which are unions", func(t *testing.T) {
+		s := ExploreUnion{[]Selector{
+			ExploreIndex{ExploreUnion{[]Selector{Matcher{}, ExploreAll{Matcher{}}}}, [1]ipld.PathSegment{ipld.PathSegmentOfInt(2)}},
+			ExploreRange{Matcher{}, 2, 3, []ipld.PathSegment{ipld.PathSegmentOfInt(2)}},
+		}}
+
+		returnedSelector := s.Explore(n, ipld.PathSegmentOfInt(2))
+		Wish(t, returnedSelector, ShouldEqual, ExploreUnion{[]Selector{Matcher{}, ExploreAll{Matcher{}}, Matcher{}}})
+	})
 }
 
 func TestExploreUnionInterests(t *testing.T) {
@@ -97,6 +106,15 @@ func TestExploreUnionInterests(t *testing.T) {
 		}}
 		Wish(t, s.Interests(), ShouldEqual, []ipld.PathSegment{ipld.PathSegmentOfString("applesauce"), ipld.PathSegmentOfInt(2)})
 	})
+	t.Run("interests shared by several member selectors should only be listed once", func(t *testing.T) {
+		s := ExploreUnion{[]Selector{
+			ExploreFields{map[string]Selector{"applesauce": Matcher{}}, []ipld.PathSegment{ipld.PathSegmentOfString("applesauce")}},
+			ExploreIndex{Matcher{}, [1]ipld.PathSegment{ipld.PathSegmentOfInt(2)}},
+			ExploreIndex{Matcher{}, [1]ipld.PathSegment{ipld.PathSegmentOfInt(2)}},
+			ExploreFields{map[string]Selector{"applesauce": Matcher{}, "2": Matcher{}}, []ipld.PathSegment{ipld.PathSegmentOfString("applesauce"), ipld.PathSegmentOfString("2")}},
+		}}
+		Wish(t, s.Interests(), ShouldEqual, []ipld.PathSegment{ipld.PathSegmentOfString("applesauce"), ipld.PathSegmentOfInt(2), ipld.PathSegmentOfString("2")})
+	})
 }
 
 func TestExploreUnionDecide(t *testing.T) {
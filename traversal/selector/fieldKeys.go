@@ -21,5 +21,14 @@ const (
 	SelectorKey_LimitNone            = "none"
 	SelectorKey_StopAt               = "!"
 	SelectorKey_Condition            = "&"
-	// not filling conditional keys since it's not complete
+	SelectorKey_OnlyIf               = "onlyIf"
+	SelectorKey_Label                = "label"
+	SelectorKey_ConditionHasKind     = "%"
+	SelectorKey_ConditionHasField    = "hasField"
+	SelectorKey_ConditionField       = "field"
+	SelectorKey_ConditionHasValue    = "=="
+	SelectorKey_ConditionIsLink      = "/"
+	SelectorKey_Subset               = "subset"
+	SelectorKey_From                 = "["
+	SelectorKey_To                   = "]"
 )
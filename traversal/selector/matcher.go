@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/fluent"
 )
 
 // Matcher marks a node to be included in the "result" set.
@@ -15,8 +16,46 @@ import (
 //
 // A selector tree with only "explore*"-type selectors and no Matcher selectors
 // is valid; it will just generate a "covered" set of nodes and no "result" set.
-// TODO: From spec: implement conditions and labels
-type Matcher struct{}
+//
+// A Matcher may have a condition, in which case it only matches nodes for
+// which the condition holds; and it may have a label, which is reported
+// (see MatchLabels) so that users can tell which Matcher caused a match.
+//
+// A Matcher may also have a Subset, in which case a matched string or bytes
+// node is limited to a byte range of its content (see MatchedNode).
+type Matcher struct {
+	onlyIf *Condition // if set, only match when this condition holds
+	label  string     // if set, reported by MatchLabels
+	subset *Subset    // if set, the matched node is limited to this range
+}
+
+// NewMatcher returns a Matcher with the given condition (which may be nil)
+// and label (which may be empty).
+func NewMatcher(onlyIf *Condition, label string) Matcher {
+	return Matcher{onlyIf: onlyIf, label: label}
+}
+
+// WithSubset returns a copy of this matcher which limits matched string
+// and bytes nodes to the byte range [from, to).
+func (s Matcher) WithSubset(from, to int64) Matcher {
+	s.subset = &Subset{from, to}
+	return s
+}
+
+// Condition returns the condition of this matcher, or nil if it has none.
+func (s Matcher) Condition() *Condition {
+	return s.onlyIf
+}
+
+// Label returns the label of this matcher, or the empty string if it has none.
+func (s Matcher) Label() string {
+	return s.label
+}
+
+// Subset returns the subset of this matcher, or nil if it has none.
+func (s Matcher) Subset() *Subset {
+	return s.subset
+}
 
 // Interests are empty for a matcher (for now) because
 // It is always just there to match, not explore further
@@ -29,18 +68,75 @@ func (s Matcher) Explore(n ipld.Node, p ipld.PathSegment) Selector {
 	return nil
 }
 
-// Decide is always true for a match cause it's in the result set
-// TODO: Implement boolean logic for conditionals
+// Decide is true for a match cause it's in the result set,
+// unless the matcher has a condition which doesn't hold for the node.
 func (s Matcher) Decide(n ipld.Node) bool {
+	if s.onlyIf != nil {
+		return s.onlyIf.Match(n)
+	}
 	return true
 }
 
+// ToNode returns the IPLD data representation of this selector
+func (s Matcher) ToNode(np ipld.NodePrototype) ipld.Node {
+	return fluent.MustBuildMap(np, 1, func(na fluent.MapAssembler) {
+		na.AssembleEntry(SelectorKey_Matcher).CreateMap(-1, func(na fluent.MapAssembler) {
+			if s.onlyIf != nil {
+				na.AssembleEntry(SelectorKey_OnlyIf).AssignNode(s.onlyIf.ToNode(np))
+			}
+			if s.label != "" {
+				na.AssembleEntry(SelectorKey_Label).AssignString(s.label)
+			}
+			if s.subset != nil {
+				na.AssembleEntry(SelectorKey_Subset).AssignNode(s.subset.ToNode(np))
+			}
+		})
+	})
+}
+
+// MatchLabels returns the label of this matcher if it decides to match the node.
+func (s Matcher) MatchLabels(n ipld.Node) []string {
+	if s.label == "" || !s.Decide(n) {
+		return nil
+	}
+	return []string{s.label}
+}
+
+// MatchedNode returns the node limited to this matcher's subset, if it has one.
+func (s Matcher) MatchedNode(n ipld.Node) (ipld.Node, error) {
+	if s.subset == nil {
+		return n, nil
+	}
+	return s.subset.Apply(n)
+}
+
 // ParseMatcher assembles a Selector
 // from a matcher selector node
-// TODO: Parse labels and conditions
 func (pc ParseContext) ParseMatcher(n ipld.Node) (Selector, error) {
 	if n.Kind() != ipld.Kind_Map {
 		return nil, fmt.Errorf("selector spec parse rejected: selector body must be a map")
 	}
-	return Matcher{}, nil
+	var x Matcher
+	if conditionNode, err := n.LookupByString(SelectorKey_OnlyIf); err == nil {
+		condition, err := pc.ParseCondition(conditionNode)
+		if err != nil {
+			return nil, pc.Descend(ipld.PathSegmentOfString(SelectorKey_OnlyIf)).wrapError(err)
+		}
+		x.onlyIf = &condition
+	}
+	if labelNode, err := n.LookupByString(SelectorKey_Label); err == nil {
+		label, err := labelNode.AsString()
+		if err != nil {
+			return nil, fmt.Errorf("selector spec parse rejected: label field must be a string in Matcher selector")
+		}
+		x.label = label
+	}
+	if subsetNode, err := n.LookupByString(SelectorKey_Subset); err == nil {
+		subset, err := pc.ParseSubset(subsetNode)
+		if err != nil {
+			return nil, pc.Descend(ipld.PathSegmentOfString(SelectorKey_Subset)).wrapError(err)
+		}
+		x.subset = &subset
+	}
+	return x, nil
 }
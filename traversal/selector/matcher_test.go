@@ -0,0 +1,130 @@
+package selector
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/fluent"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+)
+
+func TestParseMatcher(t *testing.T) {
+	t.Run("parsing non map node should error", func(t *testing.T) {
+		sn := basicnode.NewInt(0)
+		_, err := ParseContext{}.ParseMatcher(sn)
+		Wish(t, err, ShouldEqual, fmt.Errorf("selector spec parse rejected: selector body must be a map"))
+	})
+	t.Run("parsing empty map node should parse", func(t *testing.T) {
+		sn := fluent.MustBuildMap(basicnode.Prototype__Map{}, 0, func(na fluent.MapAssembler) {})
+		s, err := ParseContext{}.ParseMatcher(sn)
+		Wish(t, err, ShouldEqual, nil)
+		Wish(t, s, ShouldEqual, Matcher{})
+	})
+	t.Run("parsing map node with label that is not a string should error", func(t *testing.T) {
+		sn := fluent.MustBuildMap(basicnode.Prototype__Map{}, 1, func(na fluent.MapAssembler) {
+			na.AssembleEntry(SelectorKey_Label).AssignInt(1)
+		})
+		_, err := ParseContext{}.ParseMatcher(sn)
+		Wish(t, err, ShouldEqual, fmt.Errorf("selector spec parse rejected: label field must be a string in Matcher selector"))
+	})
+	t.Run("parsing map node with unknown condition should error", func(t *testing.T) {
+		sn := fluent.MustBuildMap(basicnode.Prototype__Map{}, 1, func(na fluent.MapAssembler) {
+			na.AssembleEntry(SelectorKey_OnlyIf).CreateMap(1, func(na fluent.MapAssembler) {
+				na.AssembleEntry("cheese").AssignString("map")
+			})
+		})
+		_, err := ParseContext{}.ParseMatcher(sn)
+		Wish(t, err, ShouldEqual, ParseError{ipld.ParsePath("onlyIf"), fmt.Errorf("selector spec parse rejected: \"cheese\" is not a known member of the condition union")})
+	})
+	t.Run("parsing map node with unknown kind in condition should error", func(t *testing.T) {
+		sn := fluent.MustBuildMap(basicnode.Prototype__Map{}, 1, func(na fluent.MapAssembler) {
+			na.AssembleEntry(SelectorKey_OnlyIf).CreateMap(1, func(na fluent.MapAssembler) {
+				na.AssembleEntry(SelectorKey_ConditionHasKind).AssignString("cheese")
+			})
+		})
+		_, err := ParseContext{}.ParseMatcher(sn)
+		Wish(t, err, ShouldEqual, ParseError{ipld.ParsePath("onlyIf"), fmt.Errorf("selector spec parse rejected: \"cheese\" is not a known kind in hasKind condition")})
+	})
+	t.Run("parsing map node with condition and label should parse", func(t *testing.T) {
+		sn := fluent.MustBuildMap(basicnode.Prototype__Map{}, 2, func(na fluent.MapAssembler) {
+			na.AssembleEntry(SelectorKey_OnlyIf).CreateMap(1, func(na fluent.MapAssembler) {
+				na.AssembleEntry(SelectorKey_ConditionHasKind).AssignString("string")
+			})
+			na.AssembleEntry(SelectorKey_Label).AssignString("strings")
+		})
+		s, err := ParseContext{}.ParseMatcher(sn)
+		Wish(t, err, ShouldEqual, nil)
+		cond := ConditionKind(ipld.Kind_String)
+		Wish(t, s, ShouldEqual, NewMatcher(&cond, "strings"))
+	})
+}
+
+func TestMatcherDecide(t *testing.T) {
+	cond := ConditionKind(ipld.Kind_String)
+	s := NewMatcher(&cond, "strings")
+	t.Run("deciding should be false if the condition doesn't hold", func(t *testing.T) {
+		Wish(t, s.Decide(basicnode.NewInt(1)), ShouldEqual, false)
+		Wish(t, MatchLabels(s, basicnode.NewInt(1)), ShouldEqual, []string(nil))
+	})
+	t.Run("deciding should be true if the condition holds", func(t *testing.T) {
+		Wish(t, s.Decide(basicnode.NewString("x")), ShouldEqual, true)
+		Wish(t, MatchLabels(s, basicnode.NewString("x")), ShouldEqual, []string{"strings"})
+	})
+	t.Run("labels should be collected from all matching union members", func(t *testing.T) {
+		u := ExploreUnion{[]Selector{s, NewMatcher(nil, "all"), Matcher{}}}
+		Wish(t, u.Decide(basicnode.NewInt(1)), ShouldEqual, true)
+		Wish(t, MatchLabels(u, basicnode.NewInt(1)), ShouldEqual, []string{"all"})
+		Wish(t, MatchLabels(u, basicnode.NewString("x")), ShouldEqual, []string{"strings", "all"})
+	})
+}
+
+func TestMatcherToNode(t *testing.T) {
+	cond := ConditionKind(ipld.Kind_Map)
+	for _, s := range []Matcher{{}, NewMatcher(&cond, ""), NewMatcher(nil, "lbl"), NewMatcher(&cond, "lbl")} {
+		s2, err := ParseSelector(s.ToNode(basicnode.Prototype__Any{}))
+		Wish(t, err, ShouldEqual, nil)
+		Wish(t, s2, ShouldEqual, s)
+	}
+}
+
+func TestConditionRepresentation(t *testing.T) {
+	t.Run("conditions should use the selector spec's keys", func(t *testing.T) {
+		n := ConditionKind(ipld.Kind_Map).ToNode(basicnode.Prototype__Any{})
+		v, err := n.LookupByString("%")
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, v, ShouldEqual, basicnode.NewString("map"))
+
+		n = ConditionFieldEquals("type", basicnode.NewString("dir")).ToNode(basicnode.Prototype__Any{})
+		v, err = n.LookupByString("hasField")
+		Require(t, err, ShouldEqual, nil)
+		v, err = v.LookupByString("==")
+		Require(t, err, ShouldEqual, nil)
+		Wish(t, v, ShouldEqual, basicnode.NewString("dir"))
+	})
+	t.Run("a zero condition should hold for nothing", func(t *testing.T) {
+		Wish(t, Condition{}.Match(basicnode.NewString("x")), ShouldEqual, false)
+		_, err := ParseContext{}.ParseCondition(Condition{}.ToNode(basicnode.Prototype__Any{}))
+		Wish(t, err, ShouldEqual, fmt.Errorf("selector spec parse rejected: condition is a keyed union and thus must be a single-entry map"))
+	})
+}
+
+// bytesLink is a link type which can't be compared with ==.
+type bytesLink []byte
+
+func (l bytesLink) Prototype() ipld.LinkPrototype { return nil }
+func (l bytesLink) String() string                { return fmt.Sprintf("%x", []byte(l)) }
+
+func TestConditionLinks(t *testing.T) {
+	cond := ConditionLinkEquals(bytesLink{1, 2, 3})
+	Wish(t, cond.Match(basicnode.NewLink(bytesLink{1, 2, 3})), ShouldEqual, true)
+	Wish(t, cond.Match(basicnode.NewLink(bytesLink{1, 2, 4})), ShouldEqual, false)
+
+	cond = ConditionFieldEquals("lnk", basicnode.NewLink(bytesLink{1, 2, 3}))
+	n := fluent.MustBuildMap(basicnode.Prototype__Map{}, 1, func(na fluent.MapAssembler) {
+		na.AssembleEntry("lnk").AssignLink(bytesLink{1, 2, 3})
+	})
+	Wish(t, cond.Match(n), ShouldEqual, true)
+}
@@ -0,0 +1,310 @@
+// Package parse implements a compact textual syntax for selectors.
+//
+// Writing selectors as their full IPLD data representation (e.g. in JSON)
+// is verbose; this syntax is meant for CLIs, tests, and other places where
+// a human is writing selectors by hand.
+//
+// The syntax is as follows:
+//
+//	.            a Matcher
+//	@            an ExploreRecursiveEdge
+//	a | b        an ExploreUnion of the selectors a and b
+//	(a)          grouping, e.g. to put a union after a path
+//	R5(a)        an ExploreRecursive with a depth limit of 5 and sequence a
+//	R*(a)        an ExploreRecursive with no limit and sequence a
+//	foo/a        an ExploreFields on the field "foo", followed by selector a
+//	"f o o"/a    the same, for field names which aren't plain words
+//	*/a          an ExploreAll, followed by selector a
+//	[3]/a        an ExploreIndex of index 3, followed by selector a
+//	[3:7]/a      an ExploreRange from index 3 (inclusive) to 7 (exclusive), followed by selector a
+//
+// Whitespace between tokens is ignored.
+// For example, `R*(. | entries/*/@)` matches a node and recursively every
+// node reachable through "entries" lists beneath it,
+// and `files/[100:200]/.` matches the files from index 100 to 199.
+package parse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/traversal/selector"
+	"github.com/ipld/go-ipld-prime/traversal/selector/builder"
+)
+
+// ParseString parses a selector in the textual syntax, and compiles it.
+func ParseString(s string) (selector.Selector, error) {
+	n, err := ParseStringToNode(s)
+	if err != nil {
+		return nil, err
+	}
+	return selector.ParseSelector(n)
+}
+
+// ParseStringToNode parses a selector in the textual syntax,
+// and returns its IPLD data representation without compiling it.
+func ParseStringToNode(s string) (ipld.Node, error) {
+	p := parser{
+		src: s,
+		ssb: builder.NewSelectorSpecBuilder(basicnode.Prototype__Any{}),
+	}
+	spec, err := p.parseUnion()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.src) {
+		return nil, p.errorf("unexpected %q after end of selector", p.src[p.pos])
+	}
+	return spec.Node(), nil
+}
+
+// Error is returned when the selector syntax is invalid.
+type Error struct {
+	Offset int    // byte offset in the input at which the problem was found
+	Msg    string // describes the problem
+}
+
+func (e Error) Error() string {
+	return fmt.Sprintf("selector syntax error at offset %d: %s", e.Offset, e.Msg)
+}
+
+type parser struct {
+	src string
+	pos int
+	ssb builder.SelectorSpecBuilder
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return Error{p.pos, fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.src) {
+		switch p.src[p.pos] {
+		case ' ', '\t', '\r', '\n':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+// peek returns the next non-space byte, or 0 at the end of input.
+func (p *parser) peek() byte {
+	p.skipSpace()
+	if p.pos >= len(p.src) {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *parser) expect(c byte) error {
+	if p.peek() != c {
+		if p.pos >= len(p.src) {
+			return p.errorf("expected %q, got end of input", c)
+		}
+		return p.errorf("expected %q, got %q", c, p.src[p.pos])
+	}
+	p.pos++
+	return nil
+}
+
+// parseUnion parses one or more sequences separated by '|'.
+func (p *parser) parseUnion() (builder.SelectorSpec, error) {
+	var members []builder.SelectorSpec
+	for {
+		member, err := p.parseSequence()
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+		if p.peek() != '|' {
+			break
+		}
+		p.pos++
+	}
+	if len(members) == 1 {
+		return members[0], nil
+	}
+	return p.ssb.ExploreUnion(members...), nil
+}
+
+// parseSequence parses a path of explore steps, ending in an atom.
+func (p *parser) parseSequence() (builder.SelectorSpec, error) {
+	switch c := p.peek(); {
+	case c == 0:
+		return nil, p.errorf("expected selector, got end of input")
+	case c == '.':
+		p.pos++
+		return p.ssb.Matcher(), nil
+	case c == '@':
+		p.pos++
+		return p.ssb.ExploreRecursiveEdge(), nil
+	case c == '(':
+		p.pos++
+		spec, err := p.parseUnion()
+		if err != nil {
+			return nil, err
+		}
+		return spec, p.expect(')')
+	case c == 'R' && p.isRecursive():
+		return p.parseRecursive()
+	case c == '*':
+		p.pos++
+		next, err := p.parseNext()
+		if err != nil {
+			return nil, err
+		}
+		return p.ssb.ExploreAll(next), nil
+	case c == '[':
+		return p.parseIndexOrRange()
+	case c == '"' || isWordByte(c):
+		field, err := p.parseField()
+		if err != nil {
+			return nil, err
+		}
+		next, err := p.parseNext()
+		if err != nil {
+			return nil, err
+		}
+		return p.ssb.ExploreFields(func(efsb builder.ExploreFieldsSpecBuilder) {
+			efsb.Insert(field, next)
+		}), nil
+	default:
+		return nil, p.errorf("expected selector, got %q", c)
+	}
+}
+
+// parseNext parses the '/' after an explore step, and the selector following it.
+func (p *parser) parseNext() (builder.SelectorSpec, error) {
+	if err := p.expect('/'); err != nil {
+		return nil, err
+	}
+	return p.parseSequence()
+}
+
+// isRecursive reports whether the input at the current position is the
+// start of a recursion, rather than a field name starting with 'R'.
+func (p *parser) isRecursive() bool {
+	rest := p.src[p.pos+1:]
+	if strings.HasPrefix(rest, "*") {
+		return true
+	}
+	i := 0
+	for i < len(rest) && rest[i] >= '0' && rest[i] <= '9' {
+		i++
+	}
+	return i > 0 && strings.HasPrefix(strings.TrimLeft(rest[i:], " \t\r\n"), "(")
+}
+
+func (p *parser) parseRecursive() (builder.SelectorSpec, error) {
+	p.pos++ // the 'R'
+	var limit selector.RecursionLimit
+	if p.src[p.pos] == '*' {
+		p.pos++
+		limit = selector.RecursionLimitNone()
+	} else {
+		depth, err := p.parseInt()
+		if err != nil {
+			return nil, err
+		}
+		limit = selector.RecursionLimitDepth(depth)
+	}
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	sequence, err := p.parseUnion()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(')'); err != nil {
+		return nil, err
+	}
+	return p.ssb.ExploreRecursive(limit, sequence), nil
+}
+
+func (p *parser) parseIndexOrRange() (builder.SelectorSpec, error) {
+	p.pos++ // the '['
+	start, err := p.parseInt()
+	if err != nil {
+		return nil, err
+	}
+	switch p.peek() {
+	case ']':
+		p.pos++
+		next, err := p.parseNext()
+		if err != nil {
+			return nil, err
+		}
+		return p.ssb.ExploreIndex(start, next), nil
+	case ':':
+		p.pos++
+		end, err := p.parseInt()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(']'); err != nil {
+			return nil, err
+		}
+		next, err := p.parseNext()
+		if err != nil {
+			return nil, err
+		}
+		return p.ssb.ExploreRange(start, end, next), nil
+	default:
+		return nil, p.errorf("expected ']' or ':' in index")
+	}
+}
+
+func (p *parser) parseInt() (int64, error) {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.src) && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+		p.pos++
+	}
+	if start == p.pos {
+		return 0, p.errorf("expected a number")
+	}
+	i, err := strconv.ParseInt(p.src[start:p.pos], 10, 64)
+	if err != nil {
+		p.pos = start
+		return 0, p.errorf("invalid number: %s", err)
+	}
+	return i, nil
+}
+
+func (p *parser) parseField() (string, error) {
+	start := p.pos
+	if p.src[p.pos] == '"' {
+		// Find the closing quote, skipping escaped characters.
+		for i := p.pos + 1; i < len(p.src); i++ {
+			switch p.src[i] {
+			case '\\':
+				i++
+			case '"':
+				field, err := strconv.Unquote(p.src[start : i+1])
+				if err != nil {
+					return "", p.errorf("invalid quoted field name: %s", err)
+				}
+				p.pos = i + 1
+				return field, nil
+			}
+		}
+		return "", p.errorf("unterminated quoted field name")
+	}
+	for p.pos < len(p.src) && isWordByte(p.src[p.pos]) {
+		p.pos++
+	}
+	return p.src[start:p.pos], nil
+}
+
+func isWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' ||
+		c >= 'A' && c <= 'Z' ||
+		c >= '0' && c <= '9' ||
+		c == '_' || c == '-'
+}
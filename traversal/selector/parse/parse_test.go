@@ -0,0 +1,89 @@
+package parse
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	"github.com/ipld/go-ipld-prime/codec/dagjson"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/traversal/selector"
+	"github.com/ipld/go-ipld-prime/traversal/selector/builder"
+)
+
+func TestParseString(t *testing.T) {
+	ssb := builder.NewSelectorSpecBuilder(basicnode.Prototype__Any{})
+	for _, tc := range []struct {
+		src      string
+		expected builder.SelectorSpec
+	}{
+		{".", ssb.Matcher()},
+		{"*/.", ssb.ExploreAll(ssb.Matcher())},
+		{"foo/bar/.", ssb.ExploreFields(func(efsb builder.ExploreFieldsSpecBuilder) {
+			efsb.Insert("foo", ssb.ExploreFields(func(efsb builder.ExploreFieldsSpecBuilder) {
+				efsb.Insert("bar", ssb.Matcher())
+			}))
+		})},
+		{`"f o/o"/.`, ssb.ExploreFields(func(efsb builder.ExploreFieldsSpecBuilder) {
+			efsb.Insert("f o/o", ssb.Matcher())
+		})},
+		{"[3]/.", ssb.ExploreIndex(3, ssb.Matcher())},
+		{"[ 100 : 200 ] / .", ssb.ExploreRange(100, 200, ssb.Matcher())},
+		{". | */.", ssb.ExploreUnion(ssb.Matcher(), ssb.ExploreAll(ssb.Matcher()))},
+		{"foo/(. | Rfoo/.)", ssb.ExploreFields(func(efsb builder.ExploreFieldsSpecBuilder) {
+			efsb.Insert("foo", ssb.ExploreUnion(ssb.Matcher(), ssb.ExploreFields(func(efsb builder.ExploreFieldsSpecBuilder) {
+				efsb.Insert("Rfoo", ssb.Matcher())
+			})))
+		})},
+		{"R5(. | */@)", ssb.ExploreRecursive(selector.RecursionLimitDepth(5), ssb.ExploreUnion(
+			ssb.Matcher(),
+			ssb.ExploreAll(ssb.ExploreRecursiveEdge()),
+		))},
+		{"R*(entries/*/@)", ssb.ExploreRecursive(selector.RecursionLimitNone(),
+			ssb.ExploreFields(func(efsb builder.ExploreFieldsSpecBuilder) {
+				efsb.Insert("entries", ssb.ExploreAll(ssb.ExploreRecursiveEdge()))
+			}),
+		)},
+	} {
+		t.Run(tc.src, func(t *testing.T) {
+			n, err := ParseStringToNode(tc.src)
+			Require(t, err, ShouldEqual, nil)
+			var expected, actual bytes.Buffer
+			Require(t, dagjson.Encode(tc.expected.Node(), &expected), ShouldEqual, nil)
+			Require(t, dagjson.Encode(n, &actual), ShouldEqual, nil)
+			Wish(t, actual.String(), ShouldEqual, expected.String())
+			_, err = ParseString(tc.src)
+			Wish(t, err, ShouldEqual, nil)
+		})
+	}
+}
+
+func TestParseStringErrors(t *testing.T) {
+	for _, tc := range []struct {
+		src string
+		err string
+	}{
+		{"", `selector syntax error at offset 0: expected selector, got end of input`},
+		{"foo", `selector syntax error at offset 3: expected '/', got end of input`},
+		{"foo/", `selector syntax error at offset 4: expected selector, got end of input`},
+		{"[x]/.", `selector syntax error at offset 1: expected a number`},
+		{"[1;2]/.", `selector syntax error at offset 2: expected ']' or ':' in index`},
+		{"(. | .", `selector syntax error at offset 6: expected ')', got end of input`},
+		{". .", `selector syntax error at offset 2: unexpected '.' after end of selector`},
+		{`"foo/.`, `selector syntax error at offset 0: unterminated quoted field name`},
+		{"?", `selector syntax error at offset 0: expected selector, got '?'`},
+	} {
+		t.Run(tc.src, func(t *testing.T) {
+			_, err := ParseString(tc.src)
+			Require(t, err != nil, ShouldEqual, true)
+			Wish(t, err.Error(), ShouldEqual, tc.err)
+		})
+	}
+	t.Run("invalid selectors should be rejected when compiling", func(t *testing.T) {
+		_, err := ParseString("*/@")
+		Require(t, err != nil, ShouldEqual, true)
+		_, err = ParseStringToNode("*/@")
+		Wish(t, err, ShouldEqual, nil)
+	})
+}
@@ -0,0 +1,91 @@
+// Package presets provides ready-made selectors for common patterns,
+// so that applications don't need to assemble (or copy-paste) the
+// selector data for them by hand.
+//
+// Each preset returns a builder.SelectorSpec, from which both the selector's
+// data representation (e.g. to send to a remote peer) and the compiled
+// selector can be obtained.
+package presets
+
+import (
+	"math"
+
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/traversal/selector"
+	"github.com/ipld/go-ipld-prime/traversal/selector/builder"
+)
+
+var ssb = builder.NewSelectorSpecBuilder(basicnode.Prototype__Any{})
+
+// FirstChildren returns a selector which matches the first n children of a list.
+// If the list has fewer than n children, all of them are matched;
+// if n is zero or negative, none are.
+//
+// The node itself is not matched.
+func FirstChildren(n int64) builder.SelectorSpec {
+	if n <= 0 {
+		return matchNothing()
+	}
+	return ssb.ExploreRange(0, n, ssb.Matcher())
+}
+
+// ListEntriesAfter returns a selector which matches every child of a list
+// following the given index (that is, from index+1 to the end of the list).
+// This is the usual shape of a request for the "next page" of a list,
+// where index is the last entry seen so far; an index of -1 matches
+// every child.
+//
+// Only lists have a stable notion of position which selectors can express,
+// so there is no equivalent for map keys.
+// The node itself is not matched.
+func ListEntriesAfter(index int64) builder.SelectorSpec {
+	if index < -1 {
+		index = -1
+	}
+	if index >= math.MaxInt64-1 {
+		// No list can have an entry after this index.
+		return matchNothing()
+	}
+	return ssb.ExploreRange(index+1, math.MaxInt64, ssb.Matcher())
+}
+
+// DepthLimitedWalk returns a selector which matches a node and every node
+// reachable from it, down to the given depth: a depth of 1 matches the node
+// and its immediate children, and so on.
+// Links are traversed like any other edge, and count as one level of depth.
+// A negative depth is treated as zero, and a depth too large to express
+// as a recursion limit is treated as no limit at all, like FullWalk.
+func DepthLimitedWalk(depth int64) builder.SelectorSpec {
+	if depth < 0 {
+		depth = 0
+	}
+	if depth >= math.MaxInt64-1 {
+		return FullWalk()
+	}
+	// The recursion limit counts applications of the sequence, and the
+	// first application already happens at the node itself.
+	return exploreEverything(selector.RecursionLimitDepth(depth + 1))
+}
+
+// FullWalk returns a selector which matches a node and every node reachable
+// from it, without any depth limit.
+//
+// Beware that, on large graphs or on data from untrusted sources, a full walk
+// may do an unbounded amount of work; see DepthLimitedWalk, or
+// selector.NewBudgetedSelector.
+func FullWalk() builder.SelectorSpec {
+	return exploreEverything(selector.RecursionLimitNone())
+}
+
+// matchNothing returns a selector which matches nothing,
+// not even the node itself.
+func matchNothing() builder.SelectorSpec {
+	return ssb.ExploreFields(func(builder.ExploreFieldsSpecBuilder) {})
+}
+
+func exploreEverything(limit selector.RecursionLimit) builder.SelectorSpec {
+	return ssb.ExploreRecursive(limit, ssb.ExploreUnion(
+		ssb.Matcher(),
+		ssb.ExploreAll(ssb.ExploreRecursiveEdge()),
+	))
+}
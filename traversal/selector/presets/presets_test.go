@@ -0,0 +1,97 @@
+package presets
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/fluent"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/traversal"
+	"github.com/ipld/go-ipld-prime/traversal/selector/builder"
+)
+
+var testNode = fluent.MustBuildMap(basicnode.Prototype__Any{}, 2, func(na fluent.MapAssembler) {
+	na.AssembleEntry("name").AssignString("root")
+	na.AssembleEntry("items").CreateList(4, func(na fluent.ListAssembler) {
+		na.AssembleValue().AssignString("a")
+		na.AssembleValue().AssignString("b")
+		na.AssembleValue().CreateMap(1, func(na fluent.MapAssembler) {
+			na.AssembleEntry("deep").AssignString("c")
+		})
+		na.AssembleValue().AssignString("d")
+	})
+})
+
+func matchedPaths(t *testing.T, n ipld.Node, spec builder.SelectorSpec) []string {
+	s, err := spec.Selector()
+	Require(t, err, ShouldEqual, nil)
+	var paths []string
+	err = traversal.WalkMatching(n, s, func(prog traversal.Progress, n ipld.Node) error {
+		paths = append(paths, prog.Path.String())
+		return nil
+	})
+	Require(t, err, ShouldEqual, nil)
+	return paths
+}
+
+func TestFirstChildren(t *testing.T) {
+	items, _ := testNode.LookupByString("items")
+	t.Run("fewer children than the list has", func(t *testing.T) {
+		Wish(t, matchedPaths(t, items, FirstChildren(2)), ShouldEqual, []string{"0", "1"})
+	})
+	t.Run("more children than the list has", func(t *testing.T) {
+		Wish(t, matchedPaths(t, items, FirstChildren(10)), ShouldEqual, []string{"0", "1", "2", "3"})
+	})
+	t.Run("no children", func(t *testing.T) {
+		Wish(t, matchedPaths(t, items, FirstChildren(0)), ShouldEqual, []string(nil))
+		Wish(t, matchedPaths(t, items, FirstChildren(-1)), ShouldEqual, []string(nil))
+	})
+}
+
+func TestListEntriesAfter(t *testing.T) {
+	items, _ := testNode.LookupByString("items")
+	Wish(t, matchedPaths(t, items, ListEntriesAfter(1)), ShouldEqual, []string{"2", "3"})
+	Wish(t, matchedPaths(t, items, ListEntriesAfter(3)), ShouldEqual, []string(nil))
+	Wish(t, matchedPaths(t, items, ListEntriesAfter(-1)), ShouldEqual, []string{"0", "1", "2", "3"})
+	Wish(t, matchedPaths(t, items, ListEntriesAfter(-5)), ShouldEqual, []string{"0", "1", "2", "3"})
+	for _, index := range []int64{math.MaxInt64 - 2, math.MaxInt64 - 1, math.MaxInt64} {
+		Wish(t, matchedPaths(t, items, ListEntriesAfter(index)), ShouldEqual, []string(nil))
+	}
+}
+
+func TestDepthLimitedWalk(t *testing.T) {
+	t.Run("depth zero matches only the node", func(t *testing.T) {
+		Wish(t, matchedPaths(t, testNode, DepthLimitedWalk(0)), ShouldEqual, []string{""})
+	})
+	t.Run("depth one matches the node and its children", func(t *testing.T) {
+		Wish(t, matchedPaths(t, testNode, DepthLimitedWalk(1)), ShouldEqual, []string{"", "name", "items"})
+	})
+	t.Run("depth three reaches every node", func(t *testing.T) {
+		Wish(t, matchedPaths(t, testNode, DepthLimitedWalk(3)), ShouldEqual, matchedPaths(t, testNode, FullWalk()))
+	})
+	t.Run("negative depths match only the node", func(t *testing.T) {
+		Wish(t, matchedPaths(t, testNode, DepthLimitedWalk(-1)), ShouldEqual, []string{""})
+		Wish(t, matchedPaths(t, testNode, DepthLimitedWalk(math.MinInt64)), ShouldEqual, []string{""})
+	})
+	t.Run("the largest depths reach every node", func(t *testing.T) {
+		for _, depth := range []int64{math.MaxInt64 - 2, math.MaxInt64 - 1, math.MaxInt64} {
+			Wish(t, matchedPaths(t, testNode, DepthLimitedWalk(depth)), ShouldEqual, matchedPaths(t, testNode, FullWalk()))
+		}
+	})
+}
+
+func TestFullWalk(t *testing.T) {
+	Wish(t, matchedPaths(t, testNode, FullWalk()), ShouldEqual, []string{
+		"",
+		"name",
+		"items",
+		"items/0",
+		"items/1",
+		"items/2",
+		"items/2/deep",
+		"items/3",
+	})
+}
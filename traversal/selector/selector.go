@@ -12,6 +12,60 @@ type Selector interface {
 	Interests() []ipld.PathSegment                // returns the segments we're likely interested in **or nil** if we're a high-cardinality or expression based matcher and need all segments proposed to us.
 	Explore(ipld.Node, ipld.PathSegment) Selector // explore one step -- iteration comes from outside (either whole node, or by following suggestions of Interests).  returns nil if no interest.  you have to traverse to the next node yourself (the selector doesn't do it for you because you might be considering multiple selection reasons at the same time).
 	Decide(ipld.Node) bool
+	ToNode(ipld.NodePrototype) ipld.Node // builds the selector's canonical IPLD data representation (which ParseSelector will accept) using the given NodePrototype.  Panics if the NodePrototype can't hold the data.
+}
+
+// MatchLabels returns the labels of any labelled Matchers within the selector
+// which cause it to decide the given node is a match.
+// It returns nil if the selector doesn't match the node,
+// or if none of the matchers involved are labelled.
+func MatchLabels(s Selector, n ipld.Node) []string {
+	if ml, ok := s.(matchLabeler); ok {
+		return ml.MatchLabels(n)
+	}
+	return nil
+}
+
+// matchLabeler is implemented by selectors which may contain a Matcher.
+type matchLabeler interface {
+	MatchLabels(ipld.Node) []string
+}
+
+// MatchedNode returns the node which should be reported as the result of
+// the selector matching the given node.
+// This is usually the node itself; but a Matcher with a Subset limits
+// string and bytes nodes to a range of their content.
+// If several matchers within the selector match the node, the first one
+// determines the result.
+//
+// MatchedNode should only be called for nodes which the selector decides to match.
+func MatchedNode(s Selector, n ipld.Node) (ipld.Node, error) {
+	if mn, ok := s.(matchedNoder); ok {
+		return mn.MatchedNode(n)
+	}
+	return n, nil
+}
+
+// matchedNoder is implemented by selectors which may contain a Matcher.
+type matchedNoder interface {
+	MatchedNode(ipld.Node) (ipld.Node, error)
+}
+
+// IndexRange returns the half-open range of list indexes which the selector
+// explores, if it's one which only explores a range of a list's elements.
+// Such a selector may have nil Interests when the range is too wide to
+// enumerate; a traversal can still visit just the indexes in the range,
+// rather than iterating over the entire list.
+func IndexRange(s Selector) (start, end int64, ok bool) {
+	if ir, ok := s.(indexRanger); ok {
+		return ir.IndexRange()
+	}
+	return 0, 0, false
+}
+
+// indexRanger is implemented by selectors which explore a range of a list.
+type indexRanger interface {
+	IndexRange() (start, end int64, ok bool)
 }
 
 // ParsedParent is created whenever you are parsing a selector node that may have
@@ -23,6 +77,23 @@ type ParsedParent interface {
 // ParseContext tracks the progress when parsing a selector
 type ParseContext struct {
 	parentStack []ParsedParent
+	path        ipld.Path // path within the selector document to the node being parsed
+}
+
+// ParseError is returned when parsing a selector fails.
+// It reports where in the selector document the rejected node was found,
+// which is useful when debugging large or programmatically generated selectors.
+type ParseError struct {
+	Path ipld.Path // path within the selector document to the rejected node
+	Err  error     // describes why the node was rejected
+}
+
+func (e ParseError) Error() string {
+	return fmt.Sprintf("%s (at selector path %q)", e.Err, e.Path)
+}
+
+func (e ParseError) Unwrap() error {
+	return e.Err
 }
 
 // ParseSelector creates a Selector that can be traversed from an IPLD Selector node
@@ -30,38 +101,50 @@ func ParseSelector(n ipld.Node) (Selector, error) {
 	return ParseContext{}.ParseSelector(n)
 }
 
-// ParseSelector creates a Selector from an IPLD Selector Node with the given context
+// ParseSelector creates a Selector from an IPLD Selector Node with the given context.
+//
+// Any error returned is a ParseError, reporting the path
+// (relative to the parse context) of the node which was rejected.
 func (pc ParseContext) ParseSelector(n ipld.Node) (Selector, error) {
 	if n.Kind() != ipld.Kind_Map {
-		return nil, fmt.Errorf("selector spec parse rejected: selector is a keyed union and thus must be a map")
+		return nil, pc.errorf("selector spec parse rejected: selector is a keyed union and thus must be a map, got %s", n.Kind())
 	}
 	if n.Length() != 1 {
-		return nil, fmt.Errorf("selector spec parse rejected: selector is a keyed union and thus must be single-entry map")
+		return nil, pc.errorf("selector spec parse rejected: selector is a keyed union and thus must be single-entry map")
 	}
 	kn, v, _ := n.MapIterator().Next()
 	kstr, _ := kn.AsString()
+	pcBody := pc.Descend(ipld.PathSegmentOfString(kstr))
+	var s Selector
+	var err error
 	// Switch over the single key to determine which selector body comes next.
 	//  (This switch is where the keyed union discriminators concretely happen.)
 	switch kstr {
 	case SelectorKey_ExploreFields:
-		return pc.ParseExploreFields(v)
+		s, err = pcBody.ParseExploreFields(v)
 	case SelectorKey_ExploreAll:
-		return pc.ParseExploreAll(v)
+		s, err = pcBody.ParseExploreAll(v)
 	case SelectorKey_ExploreIndex:
-		return pc.ParseExploreIndex(v)
+		s, err = pcBody.ParseExploreIndex(v)
 	case SelectorKey_ExploreRange:
-		return pc.ParseExploreRange(v)
+		s, err = pcBody.ParseExploreRange(v)
 	case SelectorKey_ExploreUnion:
-		return pc.ParseExploreUnion(v)
+		s, err = pcBody.ParseExploreUnion(v)
 	case SelectorKey_ExploreRecursive:
-		return pc.ParseExploreRecursive(v)
+		s, err = pcBody.ParseExploreRecursive(v)
 	case SelectorKey_ExploreRecursiveEdge:
-		return pc.ParseExploreRecursiveEdge(v)
+		s, err = pcBody.ParseExploreRecursiveEdge(v)
+	case SelectorKey_ExploreConditional:
+		s, err = pcBody.ParseExploreConditional(v)
 	case SelectorKey_Matcher:
-		return pc.ParseMatcher(v)
+		s, err = pcBody.ParseMatcher(v)
 	default:
-		return nil, fmt.Errorf("selector spec parse rejected: %q is not a known member of the selector union", kstr)
+		return nil, pc.errorf("selector spec parse rejected: %q is not a known member of the selector union", kstr)
+	}
+	if err != nil {
+		return nil, pcBody.wrapError(err)
 	}
+	return s, nil
 }
 
 // PushParent puts a parent onto the stack of parents for a parse context
@@ -70,7 +153,31 @@ func (pc ParseContext) PushParent(parent ParsedParent) ParseContext {
 	parents := make([]ParsedParent, 0, l+1)
 	parents = append(parents, parent)
 	parents = append(parents, pc.parentStack...)
-	return ParseContext{parents}
+	return ParseContext{parents, pc.path}
+}
+
+// Descend returns a parse context for a node beneath the current one,
+// reached via the given path segment.
+func (pc ParseContext) Descend(ps ipld.PathSegment) ParseContext {
+	return ParseContext{pc.parentStack, pc.path.AppendSegment(ps)}
+}
+
+// Path returns the path within the selector document to the node being parsed.
+func (pc ParseContext) Path() ipld.Path {
+	return pc.path
+}
+
+func (pc ParseContext) errorf(format string, args ...interface{}) error {
+	return ParseError{pc.path, fmt.Errorf(format, args...)}
+}
+
+// wrapError wraps an error in a ParseError for the current path,
+// unless it is already a ParseError from deeper in the selector document.
+func (pc ParseContext) wrapError(err error) error {
+	if _, ok := err.(ParseError); ok {
+		return err
+	}
+	return ParseError{pc.path, err}
 }
 
 // SegmentIterator iterates either a list or a map, generating PathSegments
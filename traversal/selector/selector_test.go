@@ -0,0 +1,43 @@
+package selector
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/fluent"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+)
+
+func TestParseSelectorErrorPaths(t *testing.T) {
+	t.Run("parsing a non-map node should error at the root", func(t *testing.T) {
+		_, err := ParseSelector(basicnode.NewString("cheese"))
+		Wish(t, err, ShouldEqual, ParseError{ipld.Path{}, fmt.Errorf("selector spec parse rejected: selector is a keyed union and thus must be a map, got string")})
+		Wish(t, err.Error(), ShouldEqual, `selector spec parse rejected: selector is a keyed union and thus must be a map, got string (at selector path "")`)
+	})
+	t.Run("parsing a deeply invalid selector should report the path of the invalid node", func(t *testing.T) {
+		sn := fluent.MustBuildMap(basicnode.Prototype__Map{}, 1, func(na fluent.MapAssembler) {
+			na.AssembleEntry(SelectorKey_ExploreUnion).CreateList(2, func(na fluent.ListAssembler) {
+				na.AssembleValue().CreateMap(1, func(na fluent.MapAssembler) {
+					na.AssembleEntry(SelectorKey_Matcher).CreateMap(0, func(na fluent.MapAssembler) {})
+				})
+				na.AssembleValue().CreateMap(1, func(na fluent.MapAssembler) {
+					na.AssembleEntry(SelectorKey_ExploreFields).CreateMap(1, func(na fluent.MapAssembler) {
+						na.AssembleEntry(SelectorKey_Fields).CreateMap(1, func(na fluent.MapAssembler) {
+							na.AssembleEntry("foo").CreateMap(1, func(na fluent.MapAssembler) {
+								na.AssembleEntry(SelectorKey_ExploreRange).CreateMap(2, func(na fluent.MapAssembler) {
+									na.AssembleEntry(SelectorKey_Start).AssignString("cheese")
+									na.AssembleEntry(SelectorKey_End).AssignInt(3)
+								})
+							})
+						})
+					})
+				})
+			})
+		})
+		_, err := ParseSelector(sn)
+		Wish(t, err.Error(), ShouldEqual, `selector spec parse rejected: start field must be a number in ExploreRange selector (at selector path "|/1/f/f>/foo/r")`)
+	})
+}
@@ -0,0 +1,151 @@
+package selector
+
+import (
+	"fmt"
+	"io"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/fluent"
+)
+
+// Subset restricts a Matcher's result to a byte range of a string or bytes node.
+//
+// The range is half-open: from is included, to is not.
+// If the node is shorter than the range, the range is truncated to fit;
+// nodes of any other kind are matched unmodified.
+type Subset struct {
+	from int64
+	to   int64
+}
+
+// From returns the (inclusive) start of the byte range.
+func (s Subset) From() int64 {
+	return s.from
+}
+
+// To returns the (exclusive) end of the byte range.
+func (s Subset) To() int64 {
+	return s.to
+}
+
+// Apply returns a node holding only the selected byte range of the given node,
+// built using the given node's own prototype.
+// If the range covers the whole node, the node itself is returned.
+// Nodes which are not of string or bytes kind are returned unmodified.
+//
+// For bytes nodes which implement ipld.LargeBytesNode, only the selected range
+// is read, so that the rest of a large value needn't be loaded at all.
+func (s Subset) Apply(n ipld.Node) (ipld.Node, error) {
+	switch n.Kind() {
+	case ipld.Kind_String:
+		str, err := n.AsString()
+		if err != nil {
+			return nil, err
+		}
+		from, to := s.clamp(int64(len(str)))
+		if from == 0 && to == int64(len(str)) {
+			return n, nil
+		}
+		nb := n.Prototype().NewBuilder()
+		if err := nb.AssignString(str[from:to]); err != nil {
+			return nil, err
+		}
+		return nb.Build(), nil
+	case ipld.Kind_Bytes:
+		var bs []byte
+		if lbn, ok := n.(ipld.LargeBytesNode); ok {
+			var err error
+			if bs, err = s.readRange(lbn); err != nil {
+				return nil, err
+			}
+		} else {
+			var err error
+			if bs, err = n.AsBytes(); err != nil {
+				return nil, err
+			}
+			from, to := s.clamp(int64(len(bs)))
+			if from == 0 && to == int64(len(bs)) {
+				return n, nil
+			}
+			bs = bs[from:to]
+		}
+		nb := n.Prototype().NewBuilder()
+		if err := nb.AssignBytes(bs); err != nil {
+			return nil, err
+		}
+		return nb.Build(), nil
+	default:
+		return n, nil
+	}
+}
+
+// readRange reads the selected byte range from a large bytes node,
+// seeking straight to it.
+func (s Subset) readRange(n ipld.LargeBytesNode) ([]byte, error) {
+	rs, err := n.AsLargeBytes()
+	if err != nil {
+		return nil, err
+	}
+	length, err := rs.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+	from, to := s.clamp(length)
+	if _, err := rs.Seek(from, io.SeekStart); err != nil {
+		return nil, err
+	}
+	bs := make([]byte, to-from)
+	if _, err := io.ReadFull(rs, bs); err != nil {
+		return nil, err
+	}
+	return bs, nil
+}
+
+func (s Subset) clamp(length int64) (from, to int64) {
+	from, to = s.from, s.to
+	if to > length {
+		to = length
+	}
+	if from > to {
+		from = to
+	}
+	return from, to
+}
+
+// ToNode returns the IPLD data representation of this subset
+func (s Subset) ToNode(np ipld.NodePrototype) ipld.Node {
+	return fluent.MustBuildMap(np, 2, func(na fluent.MapAssembler) {
+		na.AssembleEntry(SelectorKey_From).AssignInt(s.from)
+		na.AssembleEntry(SelectorKey_To).AssignInt(s.to)
+	})
+}
+
+// ParseSubset assembles a Subset from a subset node
+func (pc ParseContext) ParseSubset(n ipld.Node) (Subset, error) {
+	if n.Kind() != ipld.Kind_Map {
+		return Subset{}, fmt.Errorf("selector spec parse rejected: subset body must be a map")
+	}
+	fromNode, err := n.LookupByString(SelectorKey_From)
+	if err != nil {
+		return Subset{}, fmt.Errorf("selector spec parse rejected: from field must be present in subset")
+	}
+	fromValue, err := fromNode.AsInt()
+	if err != nil {
+		return Subset{}, fmt.Errorf("selector spec parse rejected: from field must be a number in subset")
+	}
+	toNode, err := n.LookupByString(SelectorKey_To)
+	if err != nil {
+		return Subset{}, fmt.Errorf("selector spec parse rejected: to field must be present in subset")
+	}
+	toValue, err := toNode.AsInt()
+	if err != nil {
+		return Subset{}, fmt.Errorf("selector spec parse rejected: to field must be a number in subset")
+	}
+	if fromValue < 0 {
+		return Subset{}, fmt.Errorf("selector spec parse rejected: from field must not be negative in subset")
+	}
+	if fromValue > toValue {
+		return Subset{}, fmt.Errorf("selector spec parse rejected: to field must not be less than from field in subset")
+	}
+	return Subset{fromValue, toValue}, nil
+}
@@ -0,0 +1,121 @@
+package selector
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	. "github.com/warpfork/go-wish"
+
+	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/fluent"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+)
+
+func TestParseSubset(t *testing.T) {
+	t.Run("parsing non map node should error", func(t *testing.T) {
+		_, err := ParseContext{}.ParseSubset(basicnode.NewInt(0))
+		Wish(t, err, ShouldEqual, fmt.Errorf("selector spec parse rejected: subset body must be a map"))
+	})
+	t.Run("parsing map node without from field should error", func(t *testing.T) {
+		sn := fluent.MustBuildMap(basicnode.Prototype__Map{}, 1, func(na fluent.MapAssembler) {
+			na.AssembleEntry(SelectorKey_To).AssignInt(3)
+		})
+		_, err := ParseContext{}.ParseSubset(sn)
+		Wish(t, err, ShouldEqual, fmt.Errorf("selector spec parse rejected: from field must be present in subset"))
+	})
+	t.Run("parsing map node with to field that is not an int should error", func(t *testing.T) {
+		sn := fluent.MustBuildMap(basicnode.Prototype__Map{}, 2, func(na fluent.MapAssembler) {
+			na.AssembleEntry(SelectorKey_From).AssignInt(1)
+			na.AssembleEntry(SelectorKey_To).AssignString("cheese")
+		})
+		_, err := ParseContext{}.ParseSubset(sn)
+		Wish(t, err, ShouldEqual, fmt.Errorf("selector spec parse rejected: to field must be a number in subset"))
+	})
+	t.Run("parsing map node where to is less than from should error", func(t *testing.T) {
+		sn := fluent.MustBuildMap(basicnode.Prototype__Map{}, 2, func(na fluent.MapAssembler) {
+			na.AssembleEntry(SelectorKey_From).AssignInt(3)
+			na.AssembleEntry(SelectorKey_To).AssignInt(2)
+		})
+		_, err := ParseContext{}.ParseSubset(sn)
+		Wish(t, err, ShouldEqual, fmt.Errorf("selector spec parse rejected: to field must not be less than from field in subset"))
+	})
+	t.Run("parsing a matcher with a subset should parse", func(t *testing.T) {
+		sn := fluent.MustBuildMap(basicnode.Prototype__Map{}, 1, func(na fluent.MapAssembler) {
+			na.AssembleEntry(SelectorKey_Subset).CreateMap(2, func(na fluent.MapAssembler) {
+				na.AssembleEntry(SelectorKey_From).AssignInt(1)
+				na.AssembleEntry(SelectorKey_To).AssignInt(3)
+			})
+		})
+		s, err := ParseContext{}.ParseMatcher(sn)
+		Wish(t, err, ShouldEqual, nil)
+		Wish(t, s, ShouldEqual, Matcher{}.WithSubset(1, 3))
+		s2, err := ParseSelector(s.ToNode(basicnode.Prototype__Any{}))
+		Wish(t, err, ShouldEqual, nil)
+		Wish(t, s2, ShouldEqual, s)
+	})
+}
+
+func TestSubsetApply(t *testing.T) {
+	s := Matcher{}.WithSubset(1, 3)
+	t.Run("strings should be sliced", func(t *testing.T) {
+		n, err := MatchedNode(s, basicnode.NewString("abcdef"))
+		Wish(t, err, ShouldEqual, nil)
+		Wish(t, n, ShouldEqual, basicnode.NewString("bc"))
+	})
+	t.Run("bytes should be sliced", func(t *testing.T) {
+		n, err := MatchedNode(s, basicnode.NewBytes([]byte{0, 1, 2, 3}))
+		Wish(t, err, ShouldEqual, nil)
+		Wish(t, n, ShouldEqual, basicnode.NewBytes([]byte{1, 2}))
+	})
+	t.Run("ranges past the end should be truncated", func(t *testing.T) {
+		n, err := MatchedNode(s, basicnode.NewString("ab"))
+		Wish(t, err, ShouldEqual, nil)
+		Wish(t, n, ShouldEqual, basicnode.NewString("b"))
+		n, err = MatchedNode(s, basicnode.NewString(""))
+		Wish(t, err, ShouldEqual, nil)
+		Wish(t, n, ShouldEqual, basicnode.NewString(""))
+	})
+	t.Run("other kinds should be unmodified", func(t *testing.T) {
+		n, err := MatchedNode(s, basicnode.NewInt(12345))
+		Wish(t, err, ShouldEqual, nil)
+		Wish(t, n, ShouldEqual, basicnode.NewInt(12345))
+	})
+	t.Run("large bytes should only have the range read", func(t *testing.T) {
+		lbn := largeBytes{basicnode.NewBytes(nil), []byte{0, 1, 2, 3}}
+		n, err := MatchedNode(s, lbn)
+		Wish(t, err, ShouldEqual, nil)
+		Wish(t, n, ShouldEqual, basicnode.NewBytes([]byte{1, 2}))
+		n, err = MatchedNode(Matcher{}.WithSubset(3, 10), lbn)
+		Wish(t, err, ShouldEqual, nil)
+		Wish(t, n, ShouldEqual, basicnode.NewBytes([]byte{3}))
+	})
+	t.Run("ranges covering the whole value should give the node itself", func(t *testing.T) {
+		orig := basicnode.NewString("ab")
+		n, err := MatchedNode(Matcher{}.WithSubset(0, 5), orig)
+		Wish(t, err, ShouldEqual, nil)
+		Wish(t, n == orig, ShouldEqual, true)
+	})
+	t.Run("the first matching union member should decide the result", func(t *testing.T) {
+		cond := ConditionKind(ipld.Kind_Bytes)
+		u := ExploreUnion{[]Selector{NewMatcher(&cond, ""), s}}
+		n, err := MatchedNode(u, basicnode.NewString("abcdef"))
+		Wish(t, err, ShouldEqual, nil)
+		Wish(t, n, ShouldEqual, basicnode.NewString("bc"))
+	})
+}
+
+// largeBytes is a bytes node whose data can only be read via AsLargeBytes.
+type largeBytes struct {
+	ipld.Node
+	data []byte
+}
+
+func (n largeBytes) AsBytes() ([]byte, error) {
+	return nil, fmt.Errorf("too large to read at once")
+}
+
+func (n largeBytes) AsLargeBytes() (io.ReadSeeker, error) {
+	return bytes.NewReader(n.data), nil
+}
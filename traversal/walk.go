@@ -57,8 +57,8 @@ func WalkTransforming(n ipld.Node, s selector.Selector, fn TransformFn) (ipld.No
 // This is important to note because when walking DAGs with Links,
 // it means you may visit the same node multiple times
 // due to having reached it via a different path.
-// (You can prevent this by using a LinkLoader function which memoizes a set of
-// already-visited Links, and returns a SkipMe when encountering them again.)
+// (You can prevent this by using a LinkSystem whose StorageReadOpener memoizes
+// a set of already-visited Links, and returns a SkipMe when encountering them again.)
 //
 // WalkMatching (and the other traversal functions) can be used again again inside the VisitFn!
 // By using the traversal.Progress handed to the VisitFn,
@@ -86,7 +86,13 @@ func (prog Progress) WalkAdv(n ipld.Node, s selector.Selector, fn AdvVisitFn) er
 
 func (prog Progress) walkAdv(n ipld.Node, s selector.Selector, fn AdvVisitFn) error {
 	if s.Decide(n) {
-		if err := fn(prog, n, VisitReason_SelectionMatch); err != nil {
+		progMatch := prog
+		progMatch.MatchLabels = selector.MatchLabels(s, n)
+		matched, err := selector.MatchedNode(s, n)
+		if err != nil {
+			return fmt.Errorf("error traversing node at %q: %w", prog.Path, err)
+		}
+		if err := fn(progMatch, matched, VisitReason_SelectionMatch); err != nil {
 			return err
 		}
 	} else {
@@ -102,6 +108,9 @@ func (prog Progress) walkAdv(n ipld.Node, s selector.Selector, fn AdvVisitFn) er
 	}
 	attn := s.Interests()
 	if attn == nil {
+		if start, end, ok := selector.IndexRange(s); ok && nk == ipld.Kind_List {
+			return prog.walkAdv_iterateRange(n, start, end, s, fn)
+		}
 		return prog.walkAdv_iterateAll(n, s, fn)
 	}
 	return prog.walkAdv_iterateSelective(n, attn, s, fn)
@@ -109,7 +118,43 @@ func (prog Progress) walkAdv(n ipld.Node, s selector.Selector, fn AdvVisitFn) er
 }
 
 func (prog Progress) walkAdv_iterateAll(n ipld.Node, s selector.Selector, fn AdvVisitFn) error {
-	for itr := selector.NewSegmentIterator(n); !itr.Done(); {
+	return prog.walkAdv_iterate(n, selector.NewSegmentIterator(n), s, fn)
+}
+
+func (prog Progress) walkAdv_iterateSelective(n ipld.Node, attn []ipld.PathSegment, s selector.Selector, fn AdvVisitFn) error {
+	return prog.walkAdv_iterate(n, &segmentLookups{n: n, segs: attn}, s, fn)
+}
+
+// walkAdv_iterateRange is like walkAdv_iterateSelective, for a list and a
+// range of its indexes; the range may be far wider than the list.
+func (prog Progress) walkAdv_iterateRange(n ipld.Node, start, end int64, s selector.Selector, fn AdvVisitFn) error {
+	if l := n.Length(); end > l {
+		end = l
+	}
+	return prog.walkAdv_iterate(n, &indexLookups{n: n, i: start, end: end}, s, fn)
+}
+
+// walkAdv_iterate walks the entries of n which itr yields,
+// exploring each of them with s.
+func (prog Progress) walkAdv_iterate(n ipld.Node, itr selector.SegmentIterator, s selector.Selector, fn AdvVisitFn) error {
+	if prog.Cfg.LinkSystem.StoragePrefetcher != nil {
+		// Gather the entries and their links in a single pass,
+		//  so that the links can be prefetched before walking the entries.
+		entries := &segmentEntries{}
+		var lnks []ipld.Link
+		for !itr.Done() {
+			ps, v, err := itr.Next()
+			if err != nil {
+				return err
+			}
+			entries.segs = append(entries.segs, ps)
+			entries.values = append(entries.values, v)
+			lnks = appendLink(lnks, v)
+		}
+		prog.prefetch(n, lnks)
+		itr = entries
+	}
+	for !itr.Done() {
 		ps, v, err := itr.Next()
 		if err != nil {
 			return err
@@ -140,36 +185,89 @@ func (prog Progress) walkAdv_iterateAll(n ipld.Node, s selector.Selector, fn Adv
 	return nil
 }
 
-func (prog Progress) walkAdv_iterateSelective(n ipld.Node, attn []ipld.PathSegment, s selector.Selector, fn AdvVisitFn) error {
-	for _, ps := range attn {
-		v, err := n.LookupBySegment(ps)
+// segmentEntries is a selector.SegmentIterator over entries which were
+// already read from a node.
+type segmentEntries struct {
+	segs   []ipld.PathSegment
+	values []ipld.Node
+}
+
+func (e *segmentEntries) Done() bool {
+	return len(e.segs) == 0
+}
+
+func (e *segmentEntries) Next() (ipld.PathSegment, ipld.Node, error) {
+	ps, v := e.segs[0], e.values[0]
+	e.segs, e.values = e.segs[1:], e.values[1:]
+	return ps, v, nil
+}
+
+// segmentLookups is a selector.SegmentIterator over the entries of a node
+// at the given segments, skipping those which the node doesn't have.
+type segmentLookups struct {
+	n    ipld.Node
+	segs []ipld.PathSegment
+	next ipld.Node // the entry at segs[0], once looked up.
+}
+
+func (e *segmentLookups) Done() bool {
+	for e.next == nil && len(e.segs) > 0 {
+		v, err := e.n.LookupBySegment(e.segs[0])
 		if err != nil {
+			e.segs = e.segs[1:]
 			continue
 		}
-		sNext := s.Explore(n, ps)
-		if sNext != nil {
-			progNext := prog
-			progNext.Path = prog.Path.AppendSegment(ps)
-			if v.Kind() == ipld.Kind_Link {
-				lnk, _ := v.AsLink()
-				progNext.LastBlock.Path = progNext.Path
-				progNext.LastBlock.Link = lnk
-				v, err = progNext.loadLink(v, n)
-				if err != nil {
-					if _, ok := err.(SkipMe); ok {
-						return nil
-					}
-					return err
-				}
-			}
+		e.next = v
+	}
+	return e.next == nil
+}
 
-			err = progNext.walkAdv(v, sNext, fn)
-			if err != nil {
-				return err
-			}
-		}
+func (e *segmentLookups) Next() (ipld.PathSegment, ipld.Node, error) {
+	e.Done()
+	ps, v := e.segs[0], e.next
+	e.segs, e.next = e.segs[1:], nil
+	return ps, v, nil
+}
+
+// indexLookups is a selector.SegmentIterator over the entries of a list
+// at the indexes from i up to end.
+type indexLookups struct {
+	n      ipld.Node
+	i, end int64
+}
+
+func (e *indexLookups) Done() bool {
+	return e.i >= e.end
+}
+
+func (e *indexLookups) Next() (ipld.PathSegment, ipld.Node, error) {
+	ps := ipld.PathSegmentOfInt(e.i)
+	v, err := e.n.LookupByIndex(e.i)
+	e.i++
+	return ps, v, err
+}
+
+// appendLink appends v to lnks if it's a link.
+func appendLink(lnks []ipld.Link, v ipld.Node) []ipld.Link {
+	if v.Kind() != ipld.Kind_Link {
+		return lnks
 	}
-	return nil
+	lnk, err := v.AsLink()
+	if err != nil {
+		return lnks
+	}
+	return append(lnks, lnk)
+}
+
+// prefetch hints the LinkSystem that the links among the children of n,
+// which the walk is about to visit, are likely to be loaded soon.
+// The selector may still skip some of them.
+func (prog Progress) prefetch(n ipld.Node, lnks []ipld.Link) {
+	prog.Cfg.LinkSystem.Prefetch(ipld.LinkContext{
+		Ctx:        prog.Cfg.Ctx,
+		LinkPath:   prog.Path,
+		ParentNode: n,
+	}, lnks)
 }
 
 func (prog Progress) loadLink(v ipld.Node, parent ipld.Node) (ipld.Node, error) {
@@ -1,6 +1,7 @@
 package traversal_test
 
 import (
+	"errors"
 	"testing"
 
 	. "github.com/warpfork/go-wish"
@@ -197,6 +198,61 @@ func TestWalkMatching(t *testing.T) {
 		Wish(t, err, ShouldEqual, nil)
 		Wish(t, order, ShouldEqual, 3)
 	})
+	t.Run("traversing a slice from the middle of a list should work", func(t *testing.T) {
+		for _, end := range []int64{3, 1 << 40} {
+			ss := ssb.ExploreRange(1, end, ssb.Matcher())
+			s, err := ss.Selector()
+			Require(t, err, ShouldEqual, nil)
+			var order int
+			lsys := cidlink.DefaultLinkSystem()
+			lsys.StorageReadOpener = (&store).OpenRead
+			err = traversal.Progress{
+				Cfg: &traversal.Config{
+					LinkSystem: lsys,
+					LinkTargetNodePrototypeChooser: func(_ ipld.Link, _ ipld.LinkContext) (ipld.NodePrototype, error) {
+						return basicnode.Prototype__Any{}, nil
+					},
+				},
+			}.WalkMatching(middleListNode, s, func(prog traversal.Progress, n ipld.Node) error {
+				switch order {
+				case 0:
+					Wish(t, n, ShouldEqual, basicnode.NewString("alpha"))
+					Wish(t, prog.Path.String(), ShouldEqual, "1")
+				case 1:
+					Wish(t, n, ShouldEqual, basicnode.NewString("beta"))
+					Wish(t, prog.Path.String(), ShouldEqual, "2")
+				case 2:
+					Wish(t, n, ShouldEqual, basicnode.NewString("alpha"))
+					Wish(t, prog.Path.String(), ShouldEqual, "3")
+				}
+				order++
+				return nil
+			})
+			Wish(t, err, ShouldEqual, nil)
+			if end == 3 {
+				Wish(t, order, ShouldEqual, 2)
+			} else {
+				Wish(t, order, ShouldEqual, 3)
+			}
+		}
+	})
+	t.Run("traversing a very wide range should only visit the list's elements in it", func(t *testing.T) {
+		ss := ssb.ExploreRange(2, 1<<40, ssb.Matcher())
+		s, err := ss.Selector()
+		Require(t, err, ShouldEqual, nil)
+		n := fluent.MustBuildList(basicnode.Prototype__List{}, 4, func(na fluent.ListAssembler) {
+			for i := int64(0); i < 4; i++ {
+				na.AssembleValue().AssignInt(i)
+			}
+		})
+		var visited []string
+		err = traversal.WalkMatching(noIteratorList{n}, s, func(prog traversal.Progress, n ipld.Node) error {
+			visited = append(visited, prog.Path.String())
+			return nil
+		})
+		Wish(t, err, ShouldEqual, nil)
+		Wish(t, visited, ShouldEqual, []string{"2", "3"})
+	})
 	t.Run("multiple layers of link traversal should work", func(t *testing.T) {
 		ss := ssb.ExploreFields(func(efsb builder.ExploreFieldsSpecBuilder) {
 			efsb.Insert("linkedList", ssb.ExploreAll(ssb.Matcher()))
@@ -263,3 +319,287 @@ func TestWalkMatching(t *testing.T) {
 		Wish(t, order, ShouldEqual, 7)
 	})
 }
+
+func TestWalkMatchLabels(t *testing.T) {
+	cond := selector.ConditionKind(ipld.Kind_Bool)
+	sn := fluent.MustBuildMap(basicnode.Prototype__Map{}, 1, func(na fluent.MapAssembler) {
+		na.AssembleEntry(selector.SelectorKey_ExploreAll).CreateMap(1, func(na fluent.MapAssembler) {
+			na.AssembleEntry(selector.SelectorKey_Next).CreateMap(1, func(na fluent.MapAssembler) {
+				na.AssembleEntry(selector.SelectorKey_Matcher).CreateMap(2, func(na fluent.MapAssembler) {
+					na.AssembleEntry(selector.SelectorKey_OnlyIf).CreateMap(1, func(na fluent.MapAssembler) {
+						na.AssembleEntry(selector.SelectorKey_ConditionHasKind).AssignString(cond.Kind().String())
+					})
+					na.AssembleEntry(selector.SelectorKey_Label).AssignString("bools")
+				})
+			})
+		})
+	})
+	sel, err := selector.ParseSelector(sn)
+	Require(t, err, ShouldEqual, nil)
+	var matched []string
+	err = traversal.WalkAdv(middleMapNode, sel, func(prog traversal.Progress, n ipld.Node, reason traversal.VisitReason) error {
+		if reason == traversal.VisitReason_SelectionMatch {
+			Wish(t, prog.MatchLabels, ShouldEqual, []string{"bools"})
+			matched = append(matched, prog.Path.String())
+		} else {
+			Wish(t, prog.MatchLabels, ShouldEqual, []string(nil))
+		}
+		return nil
+	})
+	Wish(t, err, ShouldEqual, nil)
+	Wish(t, matched, ShouldEqual, []string{"foo", "bar"})
+}
+
+func TestWalkMatchSubset(t *testing.T) {
+	sn := fluent.MustBuildMap(basicnode.Prototype__Map{}, 1, func(na fluent.MapAssembler) {
+		na.AssembleEntry(selector.SelectorKey_ExploreFields).CreateMap(1, func(na fluent.MapAssembler) {
+			na.AssembleEntry(selector.SelectorKey_Fields).CreateMap(1, func(na fluent.MapAssembler) {
+				na.AssembleEntry("plain").CreateMap(1, func(na fluent.MapAssembler) {
+					na.AssembleEntry(selector.SelectorKey_Matcher).CreateMap(1, func(na fluent.MapAssembler) {
+						na.AssembleEntry(selector.SelectorKey_Subset).CreateMap(2, func(na fluent.MapAssembler) {
+							na.AssembleEntry(selector.SelectorKey_From).AssignInt(2)
+							na.AssembleEntry(selector.SelectorKey_To).AssignInt(4)
+						})
+					})
+				})
+			})
+		})
+	})
+	sel, err := selector.ParseSelector(sn)
+	Require(t, err, ShouldEqual, nil)
+	var order int
+	err = traversal.WalkMatching(rootNode, sel, func(prog traversal.Progress, n ipld.Node) error {
+		Wish(t, n, ShouldEqual, basicnode.NewString("de"))
+		Wish(t, prog.Path.String(), ShouldEqual, "plain")
+		order++
+		return nil
+	})
+	Wish(t, err, ShouldEqual, nil)
+	Wish(t, order, ShouldEqual, 1)
+}
+
+func TestWalkConditional(t *testing.T) {
+	ssb := builder.NewSelectorSpecBuilder(basicnode.Prototype__Any{})
+	entry := func(na fluent.MapAssembler, typ string, fn func(na fluent.ListAssembler)) {
+		na.AssembleEntry("type").AssignString(typ)
+		na.AssembleEntry("entries").CreateList(-1, fn)
+	}
+	tree := fluent.MustBuildMap(basicnode.Prototype__Map{}, 2, func(na fluent.MapAssembler) {
+		entry(na, "dir", func(na fluent.ListAssembler) {
+			na.AssembleValue().CreateMap(2, func(na fluent.MapAssembler) {
+				entry(na, "dir", func(na fluent.ListAssembler) {})
+			})
+			na.AssembleValue().CreateMap(2, func(na fluent.MapAssembler) {
+				entry(na, "file", func(na fluent.ListAssembler) {
+					na.AssembleValue().CreateMap(2, func(na fluent.MapAssembler) {
+						entry(na, "dir", func(na fluent.ListAssembler) {})
+					})
+				})
+			})
+		})
+	})
+	// Recurse only into entries whose "type" field equals "dir", matching each of them.
+	ss := ssb.ExploreRecursive(selector.RecursionLimitNone(), ssb.ExploreConditional(
+		selector.ConditionFieldEquals("type", basicnode.NewString("dir")),
+		ssb.ExploreUnion(
+			ssb.Matcher(),
+			ssb.ExploreFields(func(efsb builder.ExploreFieldsSpecBuilder) {
+				efsb.Insert("entries", ssb.ExploreAll(ssb.ExploreRecursiveEdge()))
+			}),
+		),
+	))
+	s, err := ss.Selector()
+	Require(t, err, ShouldEqual, nil)
+	var matched []string
+	err = traversal.WalkMatching(tree, s, func(prog traversal.Progress, n ipld.Node) error {
+		matched = append(matched, prog.Path.String())
+		return nil
+	})
+	Wish(t, err, ShouldEqual, nil)
+	Wish(t, matched, ShouldEqual, []string{"", "entries/0"})
+}
+
+func TestWalkBudget(t *testing.T) {
+	ssb := builder.NewSelectorSpecBuilder(basicnode.Prototype__Any{})
+	s, err := ssb.ExploreRecursive(selector.RecursionLimitNone(), ssb.ExploreUnion(
+		ssb.Matcher(),
+		ssb.ExploreAll(ssb.ExploreRecursiveEdge()),
+	)).Selector()
+	Require(t, err, ShouldEqual, nil)
+	n := fluent.MustBuildMap(basicnode.Prototype__Map{}, 2, func(na fluent.MapAssembler) {
+		na.AssembleEntry("foo").AssignBool(true)
+		na.AssembleEntry("nested").CreateMap(2, func(na fluent.MapAssembler) {
+			na.AssembleEntry("bar").AssignBool(false)
+			na.AssembleEntry("baz").CreateList(1, func(na fluent.ListAssembler) {
+				na.AssembleValue().AssignString("zoo")
+			})
+		})
+	})
+	t.Run("walking within the budget should work", func(t *testing.T) {
+		var order int
+		err := traversal.WalkMatching(n, selector.NewBudgetedSelector(s, selector.Budget{MaxEvaluations: 100, MaxDepth: 3}), func(prog traversal.Progress, n ipld.Node) error {
+			order++
+			return nil
+		})
+		Wish(t, err, ShouldEqual, nil)
+		Wish(t, order, ShouldEqual, 6)
+	})
+	t.Run("walking beyond the budget should error", func(t *testing.T) {
+		err := traversal.WalkMatching(n, selector.NewBudgetedSelector(s, selector.Budget{MaxDepth: 1}), func(prog traversal.Progress, n ipld.Node) error {
+			return nil
+		})
+		Wish(t, errors.Is(err, selector.ErrBudgetExceeded{Limit: "depth", Value: 1}), ShouldEqual, true)
+		err = traversal.WalkMatching(n, selector.NewBudgetedSelector(s, selector.Budget{MaxEvaluations: 5}), func(prog traversal.Progress, n ipld.Node) error {
+			return nil
+		})
+		Wish(t, errors.Is(err, selector.ErrBudgetExceeded{Limit: "evaluations", Value: 5}), ShouldEqual, true)
+	})
+	t.Run("a budget nested within another selector should still error", func(t *testing.T) {
+		nested := selector.ExploreUnion{Members: []selector.Selector{
+			selector.NewBudgetedSelector(s, selector.Budget{MaxEvaluations: 5}),
+		}}
+		err := traversal.WalkMatching(n, nested, func(prog traversal.Progress, n ipld.Node) error {
+			return nil
+		})
+		Wish(t, errors.Is(err, selector.ErrBudgetExceeded{Limit: "evaluations", Value: 5}), ShouldEqual, true)
+	})
+}
+
+func TestWalkOverlappingUnion(t *testing.T) {
+	ssb := builder.NewSelectorSpecBuilder(basicnode.Prototype__Any{})
+	ss := ssb.ExploreUnion(
+		ssb.ExploreFields(func(efsb builder.ExploreFieldsSpecBuilder) {
+			efsb.Insert("nested", ssb.Matcher())
+		}),
+		ssb.ExploreFields(func(efsb builder.ExploreFieldsSpecBuilder) {
+			efsb.Insert("foo", ssb.Matcher())
+			efsb.Insert("nested", ssb.ExploreFields(func(efsb builder.ExploreFieldsSpecBuilder) {
+				efsb.Insert("nonlink", ssb.Matcher())
+			}))
+		}),
+	)
+	s, err := ss.Selector()
+	Require(t, err, ShouldEqual, nil)
+	var visited []string
+	err = traversal.WalkAdv(middleMapNode, s, func(prog traversal.Progress, n ipld.Node, reason traversal.VisitReason) error {
+		visited = append(visited, string(reason)+":"+prog.Path.String())
+		return nil
+	})
+	Wish(t, err, ShouldEqual, nil)
+	Wish(t, visited, ShouldEqual, []string{"x:", "m:nested", "m:nested/nonlink", "m:foo"})
+}
+
+func TestWalkPrefetch(t *testing.T) {
+	ssb := builder.NewSelectorSpecBuilder(basicnode.Prototype__Any{})
+	s, err := ssb.ExploreRecursive(selector.RecursionLimitNone(), ssb.ExploreUnion(
+		ssb.Matcher(),
+		ssb.ExploreAll(ssb.ExploreRecursiveEdge()),
+	)).Selector()
+	Require(t, err, ShouldEqual, nil)
+	type batch struct {
+		path string
+		lnks []ipld.Link
+	}
+	var batches []batch
+	lsys := cidlink.DefaultLinkSystem()
+	lsys.StorageReadOpener = (&store).OpenRead
+	lsys.NodePrototypeChooser = func(_ ipld.Link, _ ipld.LinkContext) (ipld.NodePrototype, error) {
+		return basicnode.Prototype__Any{}, nil
+	}
+	lsys.StoragePrefetcher = func(lnkCtx ipld.LinkContext, lnks []ipld.Link) {
+		batches = append(batches, batch{lnkCtx.LinkPath.String(), lnks})
+	}
+	err = traversal.Progress{
+		Cfg: &traversal.Config{LinkSystem: lsys},
+	}.WalkMatching(rootNode, s, func(prog traversal.Progress, n ipld.Node) error {
+		return nil
+	})
+	Require(t, err, ShouldEqual, nil)
+	// Each node with links is hinted before any of its links are loaded,
+	// with all of its links in one batch.
+	Wish(t, batches, ShouldEqual, []batch{
+		{"", []ipld.Link{leafAlphaLnk, middleMapNodeLnk, middleListNodeLnk}},
+		{"linkedMap/nested", []ipld.Link{leafAlphaLnk}},
+		{"linkedList", []ipld.Link{leafAlphaLnk, leafAlphaLnk, leafBetaLnk, leafAlphaLnk}},
+	})
+
+	// Gathering the links to prefetch doesn't iterate over a node a second time.
+	root := &countingMap{Node: rootNode}
+	err = traversal.Progress{
+		Cfg: &traversal.Config{LinkSystem: lsys},
+	}.WalkMatching(root, s, func(prog traversal.Progress, n ipld.Node) error {
+		return nil
+	})
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, root.iterations, ShouldEqual, 1)
+
+	// Nor does it look up the entries in a range of a list a second time,
+	// whether the range is walked by its interests or by its indexes.
+	s, err = ssb.ExploreRange(1, 3, ssb.Matcher()).Selector()
+	Require(t, err, ShouldEqual, nil)
+	batches = nil
+	list := &countingList{Node: middleListNode}
+	err = traversal.Progress{
+		Cfg: &traversal.Config{LinkSystem: lsys},
+	}.WalkMatching(list, s, func(prog traversal.Progress, n ipld.Node) error {
+		return nil
+	})
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, batches, ShouldEqual, []batch{
+		{"", []ipld.Link{leafAlphaLnk, leafBetaLnk}},
+	})
+	Wish(t, list.lookups, ShouldEqual, 2)
+
+	s, err = ssb.ExploreRange(1, 1<<40, ssb.Matcher()).Selector()
+	Require(t, err, ShouldEqual, nil)
+	batches = nil
+	list = &countingList{Node: middleListNode}
+	err = traversal.Progress{
+		Cfg: &traversal.Config{LinkSystem: lsys},
+	}.WalkMatching(list, s, func(prog traversal.Progress, n ipld.Node) error {
+		return nil
+	})
+	Require(t, err, ShouldEqual, nil)
+	Wish(t, batches, ShouldEqual, []batch{
+		{"", []ipld.Link{leafAlphaLnk, leafBetaLnk, leafAlphaLnk}},
+	})
+	Wish(t, list.lookups, ShouldEqual, 3)
+}
+
+// countingMap is a map node which counts how many times it's iterated over.
+type countingMap struct {
+	ipld.Node
+	iterations int
+}
+
+func (n *countingMap) MapIterator() ipld.MapIterator {
+	n.iterations++
+	return n.Node.MapIterator()
+}
+
+// countingList is a list node which counts how many times its entries are
+// looked up.
+type countingList struct {
+	ipld.Node
+	lookups int
+}
+
+func (n *countingList) LookupByIndex(idx int64) (ipld.Node, error) {
+	n.lookups++
+	return n.Node.LookupByIndex(idx)
+}
+
+func (n *countingList) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	n.lookups++
+	return n.Node.LookupBySegment(seg)
+}
+
+// noIteratorList is a list node which can't be iterated over,
+// only looked up by index.
+type noIteratorList struct {
+	ipld.Node
+}
+
+func (noIteratorList) ListIterator() ipld.ListIterator {
+	panic("list should not be iterated over")
+}